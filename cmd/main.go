@@ -20,10 +20,12 @@ import (
 //   - 4: Not found (plugin errors: ErrPluginNotFound, ErrPluginNotInstalled, ErrNoPluginsFound)
 //   - 7: Service unavailable (plugin errors: ErrSourceNotAvailable, ErrUnavailable)
 //   - 8: Partial failure (plugin errors: ErrPartialFailure)
+//   - 10-14: Scan gate failed (scan --fail-on/--fail-on-cve); the highest
+//     failing finding severity: 10 info, 11 low, 12 medium, 13 high, 14 critical
 func main() {
 	command := vulntorCli.NewCommand()
 
-	err := command.Execute()
+	err := vulntorCli.Execute(command)
 	if err != nil {
 		// Determine exit code based on error type
 		exitCode := getExitCode(err)
@@ -32,9 +34,15 @@ func main() {
 }
 
 // getExitCode determines the appropriate exit code for an error.
+// Errors carrying their own exit code (such as scan gate failures) use it.
 // It checks if the error is a plugin service error and uses plugin.ExitCode() for mapping.
 // Otherwise, it returns 1 (general error).
 func getExitCode(err error) int {
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) {
+		return coded.ExitCode()
+	}
+
 	// Check if it's a plugin error using errors.Is
 	if isPluginError(err) {
 		return plugin.ExitCode(err)
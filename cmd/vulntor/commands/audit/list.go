@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newListCommand() *cobra.Command {
+	var (
+		actor  string
+		action string
+		since  string
+		limit  int
+		tenant string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List audit events",
+		Long: `List audit events, newest first.
+
+--action matches an exact action (plugin.install) or every action on a
+resource type (plugin). --since accepts a duration (24h, 30m) or an RFC 3339
+timestamp.`,
+		Example: `  # Everything from the last day
+  vulntor audit list --since 24h
+
+  # Plugin changes made by alice
+  vulntor audit list --actor alice --action plugin
+
+  # Scans submitted in another tenant, as JSON
+  vulntor audit list --tenant team-a --action scan --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			filter := storage.AuditFilter{Actor: actor, Action: action, Limit: limit}
+			if since != "" {
+				t, err := parseSince(since, time.Now())
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("list audit events", err, storage.ErrorCode(err))
+				}
+				filter.Since = t
+			}
+
+			cfg, ok := storage.ConfigFromContext(cmd.Context())
+			if !ok {
+				var err error
+				if cfg, err = storage.DefaultConfig(); err != nil {
+					return formatter.PrintTotalFailureSummary("list audit events", err, storage.ErrorCode(err))
+				}
+			}
+
+			backend, err := storage.NewBackend(cmd.Context(), cfg)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list audit events", err, storage.ErrorCode(err))
+			}
+			defer func() {
+				if err := backend.Close(); err != nil {
+					log.Warn().Err(err).Msg("Failed to close storage backend")
+				}
+			}()
+
+			auditBackend, ok := backend.(storage.AuditBackend)
+			if !ok {
+				err := fmt.Errorf("%w: storage backend does not keep an audit log", storage.ErrNotSupported)
+				return formatter.PrintTotalFailureSummary("list audit events", err, storage.ErrorCode(err))
+			}
+
+			events, err := auditBackend.Audit().List(cmd.Context(), tenant, filter)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list audit events", err, storage.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"events": events, "count": len(events)})
+			}
+
+			if len(events) == 0 {
+				return formatter.PrintSummary("No audit events found.")
+			}
+
+			rows := make([][]string, 0, len(events))
+			for _, e := range events {
+				resource := e.Resource
+				if resource == "" {
+					resource = "-"
+				}
+				outcome := e.Outcome
+				if e.Error != "" {
+					outcome += ": " + e.Error
+				}
+				rows = append(rows, []string{
+					e.Timestamp.Local().Format(time.RFC3339), e.Actor, e.Source, e.Action, resource, outcome,
+				})
+			}
+			if err := formatter.PrintTable([]string{"Time", "Actor", "Source", "Action", "Resource", "Outcome"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d audit event(s)", len(events)))
+		},
+	}
+
+	cmd.Flags().StringVar(&actor, "actor", "", "Only events by this actor (user ID, apikey:<id> or OS user)")
+	cmd.Flags().StringVar(&action, "action", "", "Only this action (plugin.install) or resource type (plugin)")
+	cmd.Flags().StringVar(&since, "since", "", "Only events newer than a duration (24h) or RFC 3339 timestamp")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of events to show (0 = all)")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant whose events to list")
+
+	return cmd
+}
+
+// parseSince accepts a duration relative to now ("24h") or an RFC 3339 timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, storage.NewInvalidInputError("since", "duration must be positive")
+		}
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, storage.NewInvalidInputError("since", fmt.Sprintf("%q is neither a duration (24h) nor an RFC 3339 timestamp", value))
+}
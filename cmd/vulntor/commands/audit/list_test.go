@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runAuditCommand(t *testing.T, root string, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
+
+func TestListCommand(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+
+	old := time.Now().Add(-48 * time.Hour).UTC()
+	for _, e := range []*storage.AuditEvent{
+		{Timestamp: old, Actor: "alice", Source: "cli", Action: "plugin.install", Resource: "ssh", Outcome: storage.AuditOutcomeSuccess},
+		{Actor: "bob", Source: "api", Action: "scan.create", Resource: "scan-1", Outcome: storage.AuditOutcomeSuccess},
+		{Actor: "alice", Source: "cli", Action: "plugin.uninstall", Resource: "ssh", Outcome: storage.AuditOutcomeFailure, Error: "not installed"},
+	} {
+		require.NoError(t, backend.Audit().Append(ctx, storage.DefaultOrgID, e))
+	}
+	require.NoError(t, backend.Audit().Append(ctx, "team-a", &storage.AuditEvent{Actor: "carol", Action: "scan.create"}))
+
+	out := runAuditCommand(t, root, "list")
+	require.Contains(t, out, "plugin.uninstall")
+	require.Contains(t, out, "failure: not installed")
+	require.Contains(t, out, "Found 3 audit event(s)")
+
+	out = runAuditCommand(t, root, "list", "--action", "plugin", "--since", "24h", "--output", "json")
+	var listed struct {
+		Events []storage.AuditEvent `json:"events"`
+		Count  int                  `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 1, listed.Count)
+	require.Equal(t, "plugin.uninstall", listed.Events[0].Action)
+
+	out = runAuditCommand(t, root, "list", "--tenant", "team-a", "--output", "json")
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 1, listed.Count)
+	require.Equal(t, "carol", listed.Events[0].Actor)
+
+	out = runAuditCommand(t, root, "list", "--actor", "nobody")
+	require.Contains(t, out, "No audit events found.")
+
+	out = runAuditCommand(t, root, "list", "--since", "yesterday")
+	require.Contains(t, out, "neither a duration")
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseSince("24h", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-24*time.Hour), got)
+
+	got, err = parseSince("2025-06-01T00:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), got)
+
+	_, err = parseSince("-1h", now)
+	require.True(t, storage.IsInvalidInput(err))
+	_, err = parseSince("last week", now)
+	require.True(t, storage.IsInvalidInput(err))
+}
@@ -0,0 +1,36 @@
+// Package audit provides CLI commands for inspecting the audit log.
+package audit
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates the 'vulntor audit' command group.
+//
+// The audit log records mutating operations (scans, plugin installs and
+// removals, API key, user and tenant changes) from both the CLI and the
+// server API.
+//
+// Example usage:
+//
+//	vulntor audit list
+//	vulntor audit list --action plugin --since 24h
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit log",
+		Long: `Inspect the audit log of mutating operations.
+
+Every scan, plugin install/update/uninstall, and API key, user or tenant
+change is recorded with the acting user, the interface used (cli or api),
+the affected resource and whether the operation succeeded. Events are kept
+per tenant in the workspace storage backend.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newListCommand())
+
+	return cmd
+}
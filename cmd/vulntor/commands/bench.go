@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/bench"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+// NewBenchCommand creates the 'vulntor bench' command.
+func NewBenchCommand() *cobra.Command {
+	var (
+		opts         bench.Options
+		outputFile   string
+		baselineFile string
+		tolerance    float64
+	)
+
+	cmd := &cobra.Command{
+		Use:     "bench",
+		Short:   "Measure scan performance against synthetic local targets",
+		GroupID: "core",
+		Long: `Measure the throughput of the scan pipeline against synthetic targets that
+run inside the process, without network access:
+
+  port-scan    TCP port discovery of --ports loopback listeners (ports/s)
+  fingerprint  Service fingerprinting of a fixed set of banners (banners/s)
+  plugin-eval  Evaluation of the embedded plugins against fixed scan data
+               (evaluations/s)
+
+Each benchmark repeats its workload for at least --duration. --output-file
+saves the results as a JSON baseline; --baseline compares the results with
+one and exits with status 1 when a rate dropped by more than --tolerance.
+Compare baselines taken on the same machine only.`,
+		Example: `  # Record a baseline for the current release
+  vulntor bench --output-file bench-v1.4.json
+
+  # Check a new build against it in CI
+  vulntor bench --baseline bench-v1.4.json --tolerance 0.25
+
+  # Only the plugin evaluation benchmark, as JSON
+  vulntor bench --only plugin-eval --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			formatter := format.FromCommand(cmd)
+
+			var baseline *bench.Report
+			if baselineFile != "" {
+				data, err := os.ReadFile(baselineFile)
+				if err != nil {
+					return fmt.Errorf("read baseline: %w", err)
+				}
+				if err := json.Unmarshal(data, &baseline); err != nil {
+					return fmt.Errorf("parse baseline %s: %w", baselineFile, err)
+				}
+			}
+
+			report, err := bench.Run(cmd.Context(), opts)
+			if err != nil {
+				return err
+			}
+			report.Version = version.GetVersion().Version
+
+			if outputFile != "" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(outputFile, append(data, '\n'), 0o644); err != nil {
+					return fmt.Errorf("write baseline: %w", err)
+				}
+			}
+
+			var regressions []bench.Regression
+			if baseline != nil {
+				regressions = bench.Compare(baseline, report, tolerance)
+			}
+
+			if formatter.IsStructured() {
+				result := map[string]any{"report": report}
+				if baseline != nil {
+					result["baseline_version"] = baseline.Version
+					result["regressions"] = regressions
+				}
+				if err := formatter.PrintStructured(result); err != nil {
+					return err
+				}
+			} else if err := printBenchResults(formatter, report, baseline); err != nil {
+				return err
+			}
+
+			if len(regressions) > 0 {
+				regErr := &bench.RegressionError{Regressions: regressions}
+				cmd.SilenceErrors = true
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "✗ %v\n", regErr)
+				return regErr
+			}
+			if outputFile != "" {
+				return formatter.PrintSummary(fmt.Sprintf("Baseline written to %s", outputFile))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Ports, "ports", bench.DefaultPorts, "Number of loopback listeners the port scan benchmark scans")
+	cmd.Flags().DurationVar(&opts.Duration, "duration", bench.DefaultDuration, "Minimum time each benchmark runs for")
+	cmd.Flags().StringSliceVar(&opts.Benchmarks, "only", nil, "Benchmarks to run: port-scan, fingerprint, plugin-eval (comma-separated; default: all)")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Save the results as a JSON baseline")
+	cmd.Flags().StringVar(&baselineFile, "baseline", "", "Compare the results with a baseline saved by --output-file")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", bench.DefaultTolerance, "Drop in rate tolerated against the baseline (0.2 = 20%)")
+
+	return cmd
+}
+
+func printBenchResults(formatter format.Formatter, report *bench.Report, baseline *bench.Report) error {
+	headers := []string{"Benchmark", "Rate", "Operations", "Elapsed"}
+	if baseline != nil {
+		headers = append(headers, "Baseline", "Change")
+	}
+	rows := make([][]string, 0, len(report.Results))
+	for _, r := range report.Results {
+		row := []string{
+			r.Name,
+			fmt.Sprintf("%.0f %s", r.Rate, r.Unit),
+			fmt.Sprintf("%d", r.Operations),
+			r.Elapsed.Round(time.Millisecond).String(),
+		}
+		if baseline != nil {
+			if base := baseline.Result(r.Name); base != nil && base.Rate > 0 {
+				row = append(row, fmt.Sprintf("%.0f %s", base.Rate, base.Unit), fmt.Sprintf("%+.1f%%", 100*(r.Rate/base.Rate-1)))
+			} else {
+				row = append(row, "-", "-")
+			}
+		}
+		rows = append(rows, row)
+	}
+	return formatter.PrintTable(headers, rows)
+}
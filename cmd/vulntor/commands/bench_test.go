@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/bench"
+)
+
+func runBench(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewBenchCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(append([]string{"--only", "fingerprint", "--duration", "10ms"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestBenchCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "baseline.json")
+	out, err := runBench(t, "--output-file", file)
+	require.NoError(t, err, out)
+	require.Contains(t, out, "fingerprint")
+	require.Contains(t, out, "Baseline written to "+file)
+
+	var baseline bench.Report
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &baseline))
+	require.Len(t, baseline.Results, 1)
+
+	// A baseline far faster than any run fails the comparison
+	baseline.Results[0].Rate *= 1e6
+	data, err = json.Marshal(baseline)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0o600))
+
+	out, err = runBench(t, "--baseline", file, "--output", "json")
+	var regErr *bench.RegressionError
+	require.ErrorAs(t, err, &regErr)
+	require.Len(t, regErr.Regressions, 1)
+	require.Contains(t, out, `"regressions"`)
+	require.Contains(t, out, "✗ performance regression: fingerprint")
+
+	_, err = runBench(t, "--baseline", filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "read baseline")
+}
@@ -0,0 +1,91 @@
+// Package config provides CLI commands for checking config files.
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+// NewCommand creates the 'vulntor config' command group.
+//
+// Example usage:
+//
+//	vulntor config validate vulntor.yaml
+//	vulntor config schema > vulntor.schema.json
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "config",
+		GroupID: "core",
+		Short:   "Validate config files and export their schema",
+		Long: `Validate config files and export their JSON Schema.
+
+Validation is strict: unknown keys, values of the wrong type and invalid
+settings are reported with their line and column. The schema lets editors
+autocomplete config files and CI pipelines validate them.`,
+		// Config files are checked here, not loaded: skip the root
+		// command's setup so that broken files can still be validated.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return format.ResolveFlags(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newValidateCommand())
+	cmd.AddCommand(newSchemaCommand())
+
+	return cmd
+}
+
+// newValidator returns a validator that knows the registered scan modules
+// and checks the notification, ticketing and fingerprint sections like a
+// scan would.
+func newValidator() (*config.Validator, error) {
+	modules, err := moduleSchemas()
+	if err != nil {
+		return nil, err
+	}
+	return &config.Validator{
+		Modules: modules,
+		Checks: map[string]config.SectionCheck{
+			"notifications": func(cfg config.Config) error {
+				_, err := notify.New(cfg.Notifications)
+				return err
+			},
+			"ticketing": func(cfg config.Config) error {
+				_, err := ticketing.New(cfg.Ticketing)
+				return err
+			},
+			"fingerprint": func(cfg config.Config) error {
+				_, err := fingerprint.LoadNormalizer(cfg.Fingerprint.Normalization)
+				return err
+			},
+		},
+	}, nil
+}
+
+// moduleSchemas returns the config parameters of the registered scan
+// modules, accepted under modules.<name>.
+func moduleSchemas() (config.ModuleSchemas, error) {
+	metadata, err := engine.GetAllModuleMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make(config.ModuleSchemas, len(metadata))
+	for _, meta := range metadata {
+		params := make(map[string]config.ModuleParameter, len(meta.ConfigSchema))
+		for name, def := range meta.ConfigSchema {
+			params[name] = config.ModuleParameter{Type: def.Type, Description: def.Description, Default: def.Default}
+		}
+		schemas[meta.Name] = params
+	}
+	return schemas, nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func newSchemaCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of config files",
+		Long: `Print a JSON Schema (draft 2020-12) describing config files.
+
+The schema lists every key with its type, description and default, including
+the parameters of each scan module under modules.<name>. Unknown keys are
+rejected, as with 'vulntor config validate'.
+
+Point your editor at the schema for autocompletion, e.g. with the YAML
+language server:
+
+  # yaml-language-server: $schema=./vulntor.schema.json`,
+		Example: `  # Save the schema next to your config file
+  vulntor config schema > vulntor.schema.json
+
+  # Validate config files in CI with any JSON Schema validator
+  check-jsonschema --schemafile vulntor.schema.json vulntor.yaml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			modules, err := moduleSchemas()
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(config.JSONSchema(modules), "", "  ")
+			if err != nil {
+				return fmt.Errorf("encode schema: %w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCommand(t *testing.T) {
+	out, err := runConfigCommand(t, "schema")
+	require.NoError(t, err)
+
+	var schema struct {
+		Schema     string `json:"$schema"`
+		Properties struct {
+			Modules struct {
+				Properties map[string]struct {
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"properties"`
+			} `json:"modules"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &schema))
+	require.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema.Schema)
+
+	banner := schema.Properties.Modules.Properties["banner-grabber"].Properties
+	require.Equal(t, "string", banner["read_timeout"]["type"])
+	require.Equal(t, "boolean", banner["send_probes"]["type"])
+}
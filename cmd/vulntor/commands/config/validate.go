@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// errInvalidConfig is returned after the problems of an invalid file have
+// been printed, so that the command exits non-zero.
+var errInvalidConfig = errors.New("config file is invalid")
+
+func newValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [file]",
+		Short: "Validate a config file",
+		Long: `Validate a config file strictly.
+
+Reports YAML syntax errors, unknown keys, values of the wrong type (for
+example a port given as a string or a malformed duration) and invalid
+settings such as an out-of-range server port or a webhook without an
+http(s) URL. Each problem is printed as file:line:column: key: message.
+Keys under modules.<name> are checked against the parameters of the scan
+modules.
+
+The file defaults to the one given with --config. The command exits with
+status 1 when the file is invalid.`,
+		Example: `  # Validate a config file
+  vulntor config validate vulntor.yaml
+
+  # Validate the file passed with --config, as JSON
+  vulntor --config /etc/vulntor/config.yaml config validate --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			} else if flag := cmd.Flags().Lookup("config"); flag != nil {
+				path = flag.Value.String()
+			}
+			if path == "" {
+				return fmt.Errorf("no config file: pass a file or use --config")
+			}
+
+			validator, err := newValidator()
+			if err != nil {
+				return err
+			}
+
+			err = validator.ValidateFile(path)
+			var problems config.ValidationErrors
+			if err != nil && !errors.As(err, &problems) {
+				return err
+			}
+
+			formatter := format.FromCommand(cmd)
+			if formatter.IsStructured() {
+				if problems == nil {
+					problems = config.ValidationErrors{}
+				}
+				if err := formatter.PrintStructured(map[string]any{"file": path, "valid": len(problems) == 0, "errors": problems}); err != nil {
+					return err
+				}
+			} else if len(problems) == 0 {
+				if err := formatter.PrintSummary(fmt.Sprintf("✓ %s is valid", path)); err != nil {
+					return err
+				}
+			} else {
+				out := cmd.OutOrStdout()
+				for _, p := range problems {
+					sep := ":"
+					if p.Line == 0 {
+						sep = ": "
+					}
+					if _, err := fmt.Fprintf(out, "%s%s%s\n", path, sep, p.Error()); err != nil {
+						return err
+					}
+				}
+				if _, err := fmt.Fprintf(out, "\n✗ Found %d problem(s) in %s\n", len(problems), path); err != nil {
+					return err
+				}
+			}
+
+			if len(problems) > 0 {
+				cmd.SilenceErrors = true
+				return errInvalidConfig
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
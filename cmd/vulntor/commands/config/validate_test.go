@@ -0,0 +1,113 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	_ "github.com/vulntor/vulntor/pkg/modules/scan" // Registers banner-grabber
+)
+
+func runConfigCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	cmd.SilenceUsage = true                // As on the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vulntor.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestValidateCommand_Valid(t *testing.T) {
+	path := writeConfig(t, `log:
+  level: debug
+modules:
+  banner-grabber:
+    read_timeout: 5s
+    send_probes: false
+`)
+
+	out, err := runConfigCommand(t, "validate", path)
+	require.NoError(t, err)
+	require.Contains(t, out, path+" is valid")
+}
+
+func TestValidateCommand_Invalid(t *testing.T) {
+	path := writeConfig(t, `server:
+  port: http
+modules:
+  banner-grabber:
+    read_timout: 5s
+notifications:
+  webhooks:
+    - url: ftp://example.com
+`)
+
+	out, err := runConfigCommand(t, "validate", path)
+	require.ErrorIs(t, err, errInvalidConfig)
+	require.Contains(t, out, path+`:2:9: server.port: expected an integer, got "http"`)
+	require.Contains(t, out, path+`:5:5: modules.banner-grabber.read_timout: unknown key "read_timout"`)
+	require.Contains(t, out, "Found 2 problem(s)")
+
+	// Settings are checked once the file is well-formed
+	path = writeConfig(t, "notifications:\n  webhooks:\n    - url: ftp://example.com\n")
+	out, err = runConfigCommand(t, "validate", path, "--output", "json")
+	require.ErrorIs(t, err, errInvalidConfig)
+
+	var result struct {
+		File   string `json:"file"`
+		Valid  bool   `json:"valid"`
+		Errors []struct {
+			Line    int    `json:"line"`
+			Path    string `json:"path"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	require.Equal(t, 1, result.Errors[0].Line)
+	require.Equal(t, "notifications", result.Errors[0].Path)
+	require.Contains(t, result.Errors[0].Message, "invalid url")
+
+	path = writeConfig(t, "fingerprint:\n  normalization: /nonexistent/aliases.yaml\n")
+	out, err = runConfigCommand(t, "validate", path)
+	require.ErrorIs(t, err, errInvalidConfig)
+	require.Contains(t, out, "read normalization dictionary")
+}
+
+func TestValidateCommand_ConfigFlag(t *testing.T) {
+	path := writeConfig(t, "log:\n  level: info\n")
+
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	cmd.PersistentFlags().String("config", "", "Configuration file path")
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"validate", "--config", path, "--output", "json"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), `"valid": true`)
+
+	_, err := runConfigCommand(t, "validate")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no config file")
+
+	_, err = runConfigCommand(t, "validate", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errInvalidConfig)
+}
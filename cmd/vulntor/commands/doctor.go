@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/doctor"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// errDoctorFailed is returned after the results have been printed when a
+// check failed, so that the command exits non-zero.
+var errDoctorFailed = errors.New("one or more checks failed")
+
+// NewDoctorCommand creates the 'vulntor doctor' command.
+func NewDoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "doctor",
+		Short:   "Diagnose the environment scans run in",
+		GroupID: "core",
+		Long: `Check that this host is ready to scan and print a fix for every problem.
+
+Checks:
+  raw sockets      Raw ICMP sockets for privileged host discovery
+  dns              Resolution of the plugin source host
+  plugin source    Outbound HTTPS to each plugin source and its mirrors,
+                   through the configured proxy
+  storage          The storage backend initializes and lists scans
+  plugin cache     The plugin cache directory is writable
+  clock            Local clock skew against the plugin source
+
+Warnings mean scans run with reduced capabilities. The command exits with
+status 1 when a check fails.`,
+		Example: `  # Run all checks
+  vulntor doctor
+
+  # As JSON, for support tickets
+  vulntor doctor --json`,
+		Args: cobra.NoArgs,
+		RunE: runDoctor,
+	}
+
+	cmd.Flags().Duration("timeout", 0, "Timeout of each network check (default 5s)")
+
+	return cmd
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	formatter := format.FromCommand(cmd)
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	opts := doctor.Options{Timeout: timeout}
+	if cfg, ok := storage.ConfigFromContext(cmd.Context()); ok {
+		opts.Storage = cfg
+		opts.CacheDir = filepath.Join(cfg.WorkspaceRoot, "plugins", "cache")
+	} else if cfg, err := storage.DefaultConfig(); err == nil {
+		// Storage is disabled, but plugin commands still use the cache
+		opts.CacheDir = filepath.Join(cfg.WorkspaceRoot, "plugins", "cache")
+	}
+
+	// Reach the plugin sources the way plugin commands do
+	if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+		proxyConfig := cfgMgr.Get().Proxy
+		proxy, err := proxyConfig.Proxy()
+		if err != nil {
+			return err
+		}
+		opts.Proxy = proxy
+		opts.Sources = plugin.ApplySourceProxies(plugin.DefaultSources(), proxyConfig.Sources)
+	}
+
+	results := doctor.Run(cmd.Context(), opts)
+	failed := doctor.Failed(results)
+
+	if formatter.IsStructured() {
+		if err := formatter.PrintStructured(map[string]any{"ok": !failed, "checks": results}); err != nil {
+			return err
+		}
+	} else if err := printDoctorResults(cmd, results); err != nil {
+		return err
+	}
+
+	if failed {
+		cmd.SilenceErrors = true
+		return errDoctorFailed
+	}
+	return nil
+}
+
+func printDoctorResults(cmd *cobra.Command, results []doctor.Result) error {
+	out := cmd.OutOrStdout()
+	counts := map[doctor.Status]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		if _, err := fmt.Fprintf(out, "%s %-24s %s\n", doctorStatusIcon(r.Status), r.Name, r.Message); err != nil {
+			return err
+		}
+		if r.Fix != "" {
+			if _, err := fmt.Fprintf(out, "  → Fix: %s\n", r.Fix); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(out, "\n%d passed, %d warning(s), %d failed, %d skipped\n",
+		counts[doctor.StatusOK], counts[doctor.StatusWarn], counts[doctor.StatusFail], counts[doctor.StatusSkip])
+	return err
+}
+
+func doctorStatusIcon(status doctor.Status) string {
+	switch status {
+	case doctor.StatusOK:
+		return "✓"
+	case doctor.StatusWarn:
+		return "⚠"
+	case doctor.StatusFail:
+		return "✗"
+	default:
+		return "⊘"
+	}
+}
@@ -0,0 +1,224 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// NewFindingsCommand returns the findings command.
+func NewFindingsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "findings",
+		Short:   "Track findings across scans",
+		GroupID: "scan",
+	}
+	cmd.AddCommand(newFindingsRecheckCommand())
+	cmd.AddCommand(newFindingsSetStateCommand())
+	return cmd
+}
+
+// newFindingsRecheckCommand returns the command that verifies the
+// remediation of a single finding.
+func newFindingsRecheckCommand() *cobra.Command {
+	var (
+		scanID string
+		tenant string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "recheck <finding-id>",
+		Short: "Check whether a finding is still present",
+		Long: `Run the plugin that reported a finding again, against only the target and
+port it was reported on, and record whether the finding is still present or
+fixed. Each recheck is added to the finding's history with its time and scan
+ID, so remediation can be verified without a full rescan.
+
+Finding IDs are listed in the "Finding ID" column of CSV and XLSX reports.
+Findings are looked up in the recent scans; use --scan for findings of older
+scans. The recheck is stored as a new scan that neither reports changes since
+the last scan nor updates tickets.`,
+		Example: `  # Verify that a finding was fixed
+  vulntor findings recheck 14d34128101e76ec
+
+  # A finding reported by an older scan
+  vulntor findings recheck 14d34128101e76ec --scan 3f2a9c1e-...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFindingsRecheckCommand(cmd, args[0], scanID, tenant)
+		},
+	}
+
+	cmd.Flags().StringVar(&scanID, "scan", "", "Scan that reported the finding (default: search the recent scans)")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the finding")
+
+	return cmd
+}
+
+func runFindingsRecheckCommand(cmd *cobra.Command, findingID, scanID, tenant string) error {
+	formatter := format.FromCommand(cmd)
+	const operation = "findings recheck"
+
+	logger := log.With().Str("command", "findings recheck").Str("finding_id", findingID).Logger()
+
+	orchestratorCtx, appMgr, err := orchestratorContext(cmd)
+	if err != nil {
+		logger.Error().Err(err).Msg("AppManager not found in context.")
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+
+	svc, closeStorage, err := newScanService(orchestratorCtx, appMgr, logger)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+	defer closeStorage()
+
+	// Progress messages would interleave with structured output
+	if !formatter.IsStructured() {
+		orchestratorCtx = context.WithValue(orchestratorCtx, output.OutputKey, setupOutputPipeline(cmd))
+	}
+
+	res, recheckErr := svc.Recheck(orchestratorCtx, findingID, scanID, scanexec.Params{
+		OrgID:        tenant,
+		OutputFormat: "text",
+	})
+	var runID string
+	if res != nil {
+		runID = res.Run.RunID
+	}
+	details := map[string]string{"finding_id": findingID}
+	if res != nil {
+		details["status"] = res.Finding.Status
+	}
+	audit.RecordCLI(orchestratorCtx, "finding.recheck", runID, recheckErr, details)
+	if recheckErr != nil {
+		logger.Error().Err(recheckErr).Msg("Finding recheck failed")
+		return formatter.PrintTotalFailureSummary(operation, recheckErr, scanexec.ErrorCode(recheckErr))
+	}
+
+	finding := res.Finding
+	if formatter.IsStructured() {
+		return formatter.PrintStructured(finding)
+	}
+
+	location := finding.Target
+	if finding.Port > 0 {
+		location += ":" + strconv.Itoa(finding.Port)
+	}
+	rows := make([][]string, 0, len(finding.History))
+	for _, check := range finding.History {
+		rows = append(rows, []string{check.CheckedAt.Local().Format(time.DateTime), check.Status, check.ScanID})
+	}
+	if err := formatter.PrintTable([]string{"Checked At", "Status", "Scan ID"}, rows); err != nil {
+		return err
+	}
+	return formatter.PrintSummary(fmt.Sprintf("Finding %s (%s on %s) is %s", finding.ID, finding.Plugin, location, finding.Status))
+}
+
+// newFindingsSetStateCommand returns the command that triages a finding.
+func newFindingsSetStateCommand() *cobra.Command {
+	var (
+		assignee string
+		note     string
+		scanID   string
+		tenant   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set-state <finding-id> <state>",
+		Short: "Change the triage state or assignee of a finding",
+		Long: `Move a finding to a triage state and optionally assign it. Every change is
+recorded with its time, author and note.
+
+States: open, acknowledged, in-progress, fixed, accepted-risk, false-positive.
+Findings are open until triaged. --assignee "" unassigns the finding; without
+--assignee the current assignee is kept.`,
+		Example: `  # Take ownership of a finding
+  vulntor findings set-state 14d34128101e76ec in-progress --assignee alice
+
+  # Accept the risk with a justification
+  vulntor findings set-state 14d34128101e76ec accepted-risk --note "Legacy host, isolated VLAN"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			const operation = "set finding state"
+
+			change := scanexec.FindingStateChange{
+				State: args[1],
+				Actor: audit.LocalActor(),
+				Note:  note,
+			}
+			if cmd.Flags().Changed("assignee") {
+				change.Assignee = &assignee
+			}
+			finding, err := setFindingState(cmd.Context(), tenant, args[0], scanID, change)
+			audit.RecordCLI(cmd.Context(), "finding.triage", args[0], err, map[string]string{
+				"state":    args[1],
+				"assignee": assignee,
+			})
+			if err != nil {
+				code := scanexec.ErrorCode(err)
+				if storage.IsNotFound(err) {
+					code = storage.ErrorCode(err)
+				}
+				return formatter.PrintTotalFailureSummary(operation, err, code)
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(finding)
+			}
+
+			rows := make([][]string, 0, len(finding.Transitions))
+			for _, t := range finding.Transitions {
+				rows = append(rows, []string{t.At.Local().Format(time.DateTime), t.From, t.To, t.Assignee, t.Actor, t.Note})
+			}
+			if err := formatter.PrintTable([]string{"Changed At", "From", "To", "Assignee", "By", "Note"}, rows); err != nil {
+				return err
+			}
+			summary := fmt.Sprintf("Finding %s is %s", finding.ID, finding.State)
+			if finding.Assignee != "" {
+				summary += ", assigned to " + finding.Assignee
+			}
+			return formatter.PrintSummary(summary)
+		},
+	}
+
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Assign the finding to this user (\"\" unassigns)")
+	cmd.Flags().StringVar(&note, "note", "", "Reason for the change")
+	cmd.Flags().StringVar(&scanID, "scan", "", "Scan that reported the finding (default: search the recent scans)")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the finding")
+
+	return cmd
+}
+
+// setFindingState triages a finding in the workspace storage backend.
+func setFindingState(ctx context.Context, tenant, findingID, scanID string, change scanexec.FindingStateChange) (*storage.FindingStatus, error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	return scanexec.SetFindingState(ctx, backend, tenant, findingID, scanID, change)
+}
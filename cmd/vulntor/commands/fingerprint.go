@@ -8,6 +8,8 @@ import (
 
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
 	"github.com/vulntor/vulntor/pkg/fingerprint"
 	"github.com/vulntor/vulntor/pkg/fingerprint/catalogsync"
 	"github.com/vulntor/vulntor/pkg/storage"
@@ -60,7 +62,15 @@ func newFingerprintSyncCommand() *cobra.Command {
 			if opts.FilePath != "" {
 				svc.Source = catalogsync.FileSource{Path: opts.FilePath}
 			} else {
-				svc.Source = catalogsync.HTTPSource{URL: opts.URL}
+				var bandwidthConfig config.BandwidthConfig
+				if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+					bandwidthConfig = cfgMgr.Get().Bandwidth
+				}
+				limiter, err := bind.BindBandwidthLimit(cmd, bandwidthConfig)
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("sync fingerprint catalog", err, fingerprint.ErrorCode(err))
+				}
+				svc.Source = catalogsync.HTTPSource{URL: opts.URL, Limiter: limiter}
 			}
 			svc.Store = catalogsync.FileStore{Path: filepath.Join(destination, "probe.catalog.yaml")}
 
@@ -78,6 +88,7 @@ func newFingerprintSyncCommand() *cobra.Command {
 	cmd.Flags().String("file", "", "Load probe catalog from a local file")
 	cmd.Flags().String("url", "", "Download probe catalog from a remote URL")
 	cmd.Flags().String("cache-dir", "", "Override probe cache destination directory")
+	cmd.Flags().String("limit-rate", "", "Maximum download rate per second, e.g. 512KB (overrides bandwidth.limit)")
 
 	return cmd
 }
@@ -99,15 +110,14 @@ func newFingerprintValidateCommand() *cobra.Command {
 
 			// Get flags
 			strict, _ := cmd.Flags().GetBool("strict")
-			jsonOutput, _ := cmd.Flags().GetBool("json")
 
 			// Validate rules
 			validator := fingerprint.NewValidator(strict)
 			result := validator.Validate(rules)
 
 			// Output results
-			if jsonOutput {
-				return formatter.PrintJSON(map[string]interface{}{
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]interface{}{
 					"valid":      result.IsValid(),
 					"rule_count": result.RuleCount,
 					"errors":     result.Errors,
@@ -156,7 +166,6 @@ func newFingerprintValidateCommand() *cobra.Command {
 	}
 
 	cmd.Flags().Bool("strict", false, "Treat warnings as failures (exit code 2)")
-	cmd.Flags().Bool("json", false, "Output results as JSON")
 
 	return cmd
 }
@@ -0,0 +1,55 @@
+package group
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+)
+
+func newDeleteCommand() *cobra.Command {
+	var tenant string
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a target group",
+		Long: `Delete a target group.
+
+Scans and findings keep the group name and tags they were recorded with.`,
+		Example: `  vulntor group delete branch-office-berlin`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			name := args[0]
+
+			store, closeFn, err := openTargetGroupStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("delete target group", err, errorCode(err))
+			}
+			defer closeFn()
+
+			err = store.Delete(cmd.Context(), tenant, name)
+			audit.RecordCLI(cmd.Context(), "group.delete", name, err, nil)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("delete target group", err, errorCode(err))
+			}
+
+			log.Info().
+				Str("component", "group").
+				Str("group", name).
+				Msg("Target group deleted")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"name": name, "deleted": true})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Target group %s deleted", name))
+		},
+	}
+
+	addTenantFlag(cmd, &tenant)
+
+	return cmd
+}
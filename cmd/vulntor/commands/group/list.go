@@ -0,0 +1,66 @@
+package group
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+)
+
+func newListCommand() *cobra.Command {
+	var tenant string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List target groups",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			store, closeFn, err := openTargetGroupStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list target groups", err, errorCode(err))
+			}
+			defer closeFn()
+
+			groups, err := store.List(cmd.Context(), tenant)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list target groups", err, errorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"groups": groups, "count": len(groups)})
+			}
+
+			if len(groups) == 0 {
+				return formatter.PrintSummary("No target groups found.")
+			}
+
+			rows := make([][]string, 0, len(groups))
+			for _, g := range groups {
+				tags := "-"
+				if len(g.Tags) > 0 {
+					tags = strings.Join(g.Tags, ",")
+				}
+				blackouts := "-"
+				if len(g.Blackouts) > 0 {
+					blackouts = strings.Join(g.Blackouts, "; ")
+				}
+				description := g.Description
+				if description == "" {
+					description = "-"
+				}
+				rows = append(rows, []string{g.Name, strings.Join(g.Targets, ","), tags, blackouts, description})
+			}
+			if err := formatter.PrintTable([]string{"Name", "Targets", "Tags", "Blackouts", "Description"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d target group(s)", len(groups)))
+		},
+	}
+
+	addTenantFlag(cmd, &tenant)
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+// Package group provides CLI commands for managing target groups.
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// errorCodeUnknownTargetGroup matches the code scans use for unknown groups.
+const errorCodeUnknownTargetGroup = "UNKNOWN_TARGET_GROUP"
+
+// NewCommand creates the 'vulntor group' command group.
+//
+// Target groups name a set of hosts, IP addresses and CIDR ranges (e.g.,
+// prod-web or branch-office-berlin) and carry tags. Scans can reference
+// groups instead of listing targets, and findings on a group's targets
+// inherit its name and tags.
+//
+// Example usage:
+//
+//	vulntor group create prod-web --target 10.0.1.0/24 --tag prod
+//	vulntor scan --group prod-web
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage target groups",
+		Long: `Manage named target groups and their tags.
+
+A target group is a named set of hosts, IP addresses and CIDR ranges, such
+as prod-web or branch-office-berlin. Scan a group with
+"vulntor scan --group <name>" or the "groups" field of the scan API.
+Findings on the targets of a group are recorded with the group name and
+its tags, so they can be filtered (?group= and ?tag= on the findings API)
+and appear in the Groups and Group Tags report columns.
+
+Groups are kept per tenant in the workspace storage backend. Changing or
+deleting a group does not change scans that already ran.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCreateCommand())
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newUpdateCommand())
+	cmd.AddCommand(newDeleteCommand())
+
+	return cmd
+}
+
+// openTargetGroupStore opens the workspace storage backend and returns its
+// target group store. The returned function closes the backend.
+func openTargetGroupStore(ctx context.Context) (storage.TargetGroupStore, func(), error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeFn := func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}
+
+	groupBackend, ok := backend.(storage.TargetGroupBackend)
+	if !ok {
+		closeFn()
+		return nil, nil, fmt.Errorf("%w: storage backend does not keep target groups", storage.ErrNotSupported)
+	}
+	return groupBackend.TargetGroups(), closeFn, nil
+}
+
+// errorCode classifies target group errors; missing groups point the user
+// at "vulntor group list".
+func errorCode(err error) string {
+	if storage.IsNotFound(err) {
+		return errorCodeUnknownTargetGroup
+	}
+	return format.ErrorCode(err)
+}
+
+// addTenantFlag registers the --tenant flag shared by the group commands.
+func addTenantFlag(cmd *cobra.Command, tenant *string) {
+	cmd.Flags().StringVar(tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the target groups")
+}
@@ -0,0 +1,82 @@
+package group
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runGroupCommand(t *testing.T, root string, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
+
+func TestGroupCommand_Lifecycle(t *testing.T) {
+	root := t.TempDir()
+
+	out := runGroupCommand(t, root, "create", "prod-web", "--target", "10.0.1.0/24", "--target", "www.example.com", "--tag", "prod,pci")
+	require.Contains(t, out, "Target group prod-web created with 2 target(s)")
+
+	out = runGroupCommand(t, root, "update", "prod-web", "--tag", "prod", "--description", "Public web servers", "--blackout", "mon-fri 08:00-18:00 UTC", "--blackout", "2026-12-24/2026-12-26")
+	require.Contains(t, out, "Target group prod-web updated")
+
+	out = runGroupCommand(t, root, "list", "--output", "json")
+	var listed struct {
+		Groups []storage.TargetGroup `json:"groups"`
+		Count  int                   `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 1, listed.Count)
+	// Flags not given to update keep their values
+	require.Equal(t, []string{"10.0.1.0/24", "www.example.com"}, listed.Groups[0].Targets)
+	require.Equal(t, []string{"prod"}, listed.Groups[0].Tags)
+	require.Equal(t, "Public web servers", listed.Groups[0].Description)
+	require.Equal(t, []string{"mon-fri 08:00-18:00 UTC", "2026-12-24/2026-12-26"}, listed.Groups[0].Blackouts)
+
+	require.Contains(t, runGroupCommand(t, root, "list", "--tenant", "team-a"), "No target groups found.")
+
+	out = runGroupCommand(t, root, "delete", "prod-web")
+	require.Contains(t, out, "Target group prod-web deleted")
+
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	events, err := backend.Audit().List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "group"})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, "group.delete", events[0].Action)
+	require.Equal(t, "prod-web", events[0].Resource)
+}
+
+func TestGroupCommand_Errors(t *testing.T) {
+	root := t.TempDir()
+
+	out := runGroupCommand(t, root, "create", "Prod_Web", "--target", "10.0.1.5")
+	require.Contains(t, out, "✗ Failed to create target group")
+	require.Contains(t, out, "lowercase letters, digits or dashes")
+
+	out = runGroupCommand(t, root, "create", "prod-web")
+	require.Contains(t, out, "at least one target")
+
+	out = runGroupCommand(t, root, "create", "prod-web", "--target", "10.0.1.5", "--blackout", "weekdays")
+	require.Contains(t, out, `invalid weekday "weekdays"`)
+
+	out = runGroupCommand(t, root, "update", "prod-web", "--tag", "prod")
+	require.Contains(t, out, "vulntor group list")
+
+	out = runGroupCommand(t, root, "delete", "prod-web")
+	require.Contains(t, out, "vulntor group list")
+}
@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/importer"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+)
+
+// NewImportCommand wires the commands that import results of other scanners.
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Analyze results of other scanners without re-scanning",
+		Long: `Import hosts, open ports and service banners found by another scanner and
+run the rest of the scan pipeline over them, skipping host discovery and port
+scanning. nmap imports use the banners in the report and send no packets;
+masscan imports grab banners from the open ports. Results are stored like a
+scan, so they can be listed, reported on and compared with other scans.`,
+		GroupID: "scan",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newImportSourceCommand(importSource{name: "nmap", parse: importer.ParseNmap}, &cobra.Command{
+		Use:   "nmap <file.xml>",
+		Short: "Import an nmap XML report (nmap -oX)",
+		Long: `Import an nmap XML report (nmap -oX) and analyze the open TCP ports it found.
+
+Banners recorded by the banner and http-server-header NSE scripts are used as
+is; for other ports a banner is built from the product and version detected
+by nmap -sV. Run nmap with -sV (and ideally --script banner) for the best
+results. Hosts that are down, ports that are not open and UDP ports are
+skipped.`,
+		Example: `  # Analyze an existing nmap scan
+  vulntor import nmap result.xml
+
+  # Fingerprint only, without plugin evaluation
+  vulntor import nmap result.xml --no-vuln
+
+  # SARIF for CI code scanning
+  vulntor import nmap result.xml --output sarif > vulntor.sarif`,
+	}))
+
+	cmd.AddCommand(newImportSourceCommand(importSource{name: "masscan", parse: importer.ParseMasscan, grabBanners: true}, &cobra.Command{
+		Use:   "masscan <file>",
+		Short: "Grab banners from and evaluate open ports found by masscan",
+		Long: `Import masscan output and run banner grabbing, fingerprinting and plugin
+evaluation against the open TCP ports it found, skipping host discovery and
+port scanning. This combines the speed of masscan with Vulntor's detection.
+
+JSON (-oJ), NDJSON (-oD) and list (-oL) output are supported. Banners
+recorded by masscan --banners are ignored; Vulntor grabs its own.`,
+		Example: `  # Sweep with masscan, then analyze the open ports
+  masscan 10.0.0.0/16 -p1-65535 --rate 10000 -oJ masscan.json
+  vulntor import masscan masscan.json
+
+  # List output with a shorter connect timeout
+  vulntor import masscan masscan.txt --timeout 2s --concurrency 200`,
+	}))
+
+	return cmd
+}
+
+// importSource describes a scanner whose results can be imported.
+type importSource struct {
+	name  string
+	parse func(io.Reader) (*importer.Result, error)
+
+	// grabBanners runs banner grabbing against the imported ports instead of
+	// using banners from the import file. It connects to the imported hosts.
+	grabBanners bool
+}
+
+// newImportSourceCommand completes cmd into a command that imports results
+// of source.
+func newImportSourceCommand(source importSource, cmd *cobra.Command) *cobra.Command {
+	cmd.Args = cobra.ExactArgs(1)
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runImportCommand(cmd, source, args[0])
+	}
+
+	cmd.Flags().StringP("output", "o", "text", "Output format: text, json, yaml, sarif")
+	cmd.Flags().Bool("no-vuln", false, "Skip plugin evaluation and only fingerprint services")
+	cmd.Flags().Bool("progress", false, "Print live progress updates during analysis")
+	if source.grabBanners {
+		cmd.Flags().String("timeout", "", "Override timeout for banner grabbing (default: module-specific or from config file)")
+		cmd.Flags().Int("concurrency", 0, "Override concurrency for banner grabbing (default: module-specific or from config file)")
+	}
+
+	return cmd
+}
+
+func runImportCommand(cmd *cobra.Command, source importSource, path string) error {
+	formatter := format.FromCommand(cmd)
+	out := setupOutputPipeline(cmd)
+	operation := "import " + source.name
+
+	logger := log.With().Str("command", "import").Str("source", source.name).Logger()
+
+	result, err := readImport(path, source.parse)
+	if err != nil {
+		logger.Error().Err(err).Str("file", path).Msg("Failed to read import file")
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+	if len(result.Hosts) == 0 {
+		err := fmt.Errorf("no live hosts found in %s", path)
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+
+	orchestratorCtx, appMgr, err := orchestratorContext(cmd)
+	if err != nil {
+		logger.Error().Err(err).Msg("AppManager not found in context.")
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+
+	svc, closeStorage, err := newScanService(orchestratorCtx, appMgr, logger)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+	defer closeStorage()
+
+	if progress, _ := cmd.Flags().GetBool("progress"); progress {
+		svc = svc.WithProgressSink(&progressLogger{logger: logger, out: out})
+	}
+	orchestratorCtx = context.WithValue(orchestratorCtx, output.OutputKey, out)
+
+	outputFormat := format.OutputFlag(cmd)
+	noVuln, _ := cmd.Flags().GetBool("no-vuln")
+	params := scanexec.Params{
+		Targets:      result.Targets(),
+		EnableVuln:   !noVuln,
+		OutputFormat: outputFormat,
+		Seed:         result.Seed(),
+	}
+	if source.grabBanners {
+		params.Seed = result.PortSeed()
+		params.CustomTimeout, _ = cmd.Flags().GetString("timeout")
+		params.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	}
+
+	tcpPorts := result.TCPPorts()
+	if outputFormat == "text" {
+		out.Info(fmt.Sprintf("Analyzing %d hosts with %d open TCP ports from %s", len(result.Hosts), tcpPorts, path))
+		if skipped := countPorts(result) - tcpPorts; skipped > 0 {
+			out.Warning(fmt.Sprintf("Skipping %d non-TCP ports", skipped))
+		}
+	}
+
+	res, runErr := svc.Run(orchestratorCtx, params)
+	var runID string
+	if res != nil {
+		runID = res.RunID
+	}
+	audit.RecordCLI(orchestratorCtx, "scan.import", runID, runErr, map[string]string{
+		"source": source.name,
+		"file":   path,
+		"hosts":  strconv.Itoa(len(result.Hosts)),
+	})
+	if runErr != nil {
+		logger.Error().Err(runErr).Msg("Import analysis failed")
+		out.Error(runErr)
+		return formatter.PrintTotalFailureSummary(operation, runErr, scanexec.ErrorCode(runErr))
+	}
+
+	if err := renderScanOutput(out, formatter, params, res, extractDataContext(res), logger); err != nil {
+		return err
+	}
+	if outputFormat == "text" {
+		out.Info(fmt.Sprintf("Scan ID: %s", runID))
+	}
+	return nil
+}
+
+// readImport parses the import file at path.
+func readImport(path string, parse func(io.Reader) (*importer.Result, error)) (*importer.Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open import file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return parse(f)
+}
+
+func countPorts(result *importer.Result) int {
+	n := 0
+	for _, h := range result.Hosts {
+		n += len(h.Ports)
+	}
+	return n
+}
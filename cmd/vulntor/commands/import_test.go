@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+const importNmapXML = `<?xml version="1.0" encoding="UTF-8"?>
+<nmaprun scanner="nmap">
+<host><status state="up"/><address addr="10.0.0.5" addrtype="ipv4"/>
+<ports>
+<port protocol="tcp" portid="22"><state state="open"/><service name="ssh" product="OpenSSH" version="8.2p1"/></port>
+<port protocol="tcp" portid="80"><state state="open"/><service name="http" product="nginx" version="1.18.0"/></port>
+</ports>
+</host>
+</nmaprun>`
+
+// runImport runs an import command in a workspace under tmp and returns the
+// hosts data file of the stored scan.
+func runImport(t *testing.T, tmp string, args ...string) string {
+	t.Helper()
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_DATA_HOME", tmp)
+
+	cmd := NewCommand()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(append([]string{"import"}, append(args, "--no-vuln", "--output", "json")...))
+
+	// JSON results go to stdout
+	stdout := os.Stdout
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	os.Stdout = devNull
+	err = cmd.Execute()
+	os.Stdout = stdout
+	_ = devNull.Close()
+	require.NoError(t, err, buf.String())
+
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: filepath.Join(tmp, "vulntor")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	scans, err := backend.Scans().List(ctx, storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+	require.Equal(t, "completed", scans[0].Status)
+
+	hosts, err := backend.Scans().ReadData(ctx, storage.DefaultOrgID, scans[0].ID, storage.DataTypeHosts)
+	require.NoError(t, err)
+	defer func() { _ = hosts.Close() }()
+	content, err := io.ReadAll(hosts)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestImportNmapCommand_StoresResults(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "result.xml")
+	require.NoError(t, os.WriteFile(path, []byte(importNmapXML), 0o600))
+
+	hosts := runImport(t, tmp, "nmap", path)
+	require.Contains(t, hosts, `"ip":"10.0.0.5"`)
+	require.Contains(t, hosts, `"port":22`)
+	require.Contains(t, hosts, `"product":"OpenSSH"`)
+}
+
+func TestImportMasscanCommand_GrabsBanners(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+			_ = conn.Close()
+		}
+	}()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "masscan.txt")
+	list := fmt.Sprintf("#masscan\nopen tcp %d 127.0.0.1 1700000000\n# end\n", port)
+	require.NoError(t, os.WriteFile(path, []byte(list), 0o600))
+
+	hosts := runImport(t, tmp, "masscan", path, "--timeout", "2s")
+	require.Contains(t, hosts, `"ip":"127.0.0.1"`)
+	require.Contains(t, hosts, fmt.Sprintf(`"port":%d`, port))
+	require.Contains(t, hosts, `"product":"OpenSSH"`)
+}
+
+func TestImportNmapCommand_InvalidFile(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	t.Setenv("XDG_DATA_HOME", tmp)
+
+	path := filepath.Join(tmp, "result.xml")
+	require.NoError(t, os.WriteFile(path, []byte(`<nmaprun><host>`), 0o600))
+
+	cmd := NewCommand()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"import", "nmap", path})
+
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, buf.String(), "parse nmap XML")
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/inventory"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// providerScan is what a scan covers of a provider inventory.
+type providerScan struct {
+	// Targets are the addresses of the instances
+	Targets []string
+
+	// Ports are the ports the instances expose (Kubernetes workloads), as
+	// a --ports list
+	Ports string
+
+	// Cloud maps the targets to their instances
+	Cloud map[string]*storage.CloudRecord
+}
+
+// providerTargets lists the instances of the provider matching the --filter
+// flags, and returns what to scan of them.
+func providerTargets(cmd *cobra.Command, providerName string) (*providerScan, error) {
+	specs, _ := cmd.Flags().GetStringSlice("filter")
+	public, _ := cmd.Flags().GetBool("public-ips")
+
+	filter, err := inventory.ParseFilter(specs)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := inventory.New(providerName)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := provider.Instances(cmd.Context(), filter)
+	if err != nil {
+		return nil, fmt.Errorf("list %s instances: %w", provider.Name(), err)
+	}
+
+	targets := inventory.Targets(instances, public)
+	if len(targets) == 0 {
+		kind := "private"
+		if public {
+			kind = "public"
+		}
+		return nil, fmt.Errorf("%w: no running %s instances with %s addresses match %s", scanexec.ErrNoTargets, provider.Name(), kind, describeFilter(specs))
+	}
+	ports := make([]string, 0, len(instances))
+	for _, port := range inventory.Ports(instances) {
+		ports = append(ports, strconv.Itoa(port))
+	}
+	return &providerScan{Targets: targets, Ports: strings.Join(ports, ","), Cloud: cloudRecords(instances, public)}, nil
+}
+
+// cloudRecords maps the scanned addresses of instances to the instances, so
+// that hosts and findings are stored with them. An address shared by
+// several instances (e.g. the nodes of Kubernetes NodePorts) keeps the first.
+func cloudRecords(instances []inventory.Instance, public bool) map[string]*storage.CloudRecord {
+	records := make(map[string]*storage.CloudRecord)
+	for _, inst := range instances {
+		rec := &storage.CloudRecord{
+			Provider:       inst.Provider,
+			InstanceID:     inst.ID,
+			Name:           inst.Name,
+			Account:        inst.Account,
+			Region:         inst.Region,
+			Network:        inst.Network,
+			SecurityGroups: inst.SecurityGroups,
+			Tags:           inst.Tags,
+		}
+		for _, addr := range inst.Addresses(public) {
+			if _, ok := records[addr]; !ok && addr != "" {
+				records[addr] = rec
+			}
+		}
+	}
+	return records
+}
+
+// describeFilter returns the filter specs for messages.
+func describeFilter(specs []string) string {
+	if len(specs) == 0 {
+		return "(no filter)"
+	}
+	return strings.Join(specs, ", ")
+}
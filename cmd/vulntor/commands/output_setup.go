@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/output/subscribers"
 )
@@ -16,8 +17,9 @@ import (
 // setupOutputPipeline creates and configures the output pipeline based on CLI flags.
 //
 // Flag-based selection:
-//   - --output=json: JSONFormatter (structured JSON Lines output to stdout)
+//   - --output=json or --json: JSONFormatter (structured JSON Lines output to stdout)
 //   - --output=text: HumanFormatter (colored tables, human-friendly output)
+//   - --output=sarif: HumanFormatter on stderr, keeping stdout for the SARIF log
 //   - -v/-vv/-vvv: DiagnosticSubscriber (verbose/debug/trace output to stderr)
 //
 // Both CE and EE use the same output pipeline - format selection is flag-based,
@@ -27,13 +29,17 @@ func setupOutputPipeline(cmd *cobra.Command) output.Output {
 	stream := output.NewOutputEventStream()
 
 	// Get flags
-	outputFormat, _ := cmd.Flags().GetString("output")
+	outputFormat := format.OutputFlag(cmd)
 	verbosityCount, _ := cmd.Flags().GetCount("verbosity")
 
 	// Format subscriber: --output flag determines Human vs JSON
 	if outputFormat == "json" {
 		// JSON mode: Structured JSON Lines format (one JSON object per line)
 		stream.Subscribe(subscribers.NewJSONFormatter(os.Stdout))
+	} else if outputFormat == "sarif" {
+		// SARIF mode: stdout carries only the SARIF log, so CI pipelines can
+		// redirect it to a file; human messages go to stderr
+		stream.Subscribe(subscribers.NewHumanFormatter(os.Stderr, os.Stderr, true))
 	} else {
 		// Human mode: Colored tables, progress bars, human-friendly output
 		// Color detection: Check if stdout is a TTY (future enhancement)
@@ -0,0 +1,144 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newAuditCommand() *cobra.Command {
+	var (
+		scans    int
+		minScans int
+		tenant   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Find plugins to prune or fix from past scans",
+		Long: `Analyze the plugin executions recorded by past scans and report the plugins
+that need a maintainer's attention:
+
+  erroring        failed in every scan that evaluated them
+  always-matched  matched every time they were evaluated (likely false positives)
+  never-matched   never matched in any scan
+
+Only completed scans that recorded plugin statistics are analyzed, and
+plugins evaluated in fewer than --min-scans scans are not judged.`,
+		Example: `  # Audit the plugins of the last 50 scans
+  vulntor plugin audit
+
+  # Audit the last 200 scans, judging plugins evaluated in 10 of them
+  vulntor plugin audit --scans 200 --min-scans 10
+
+  # JSON output
+  vulntor plugin audit --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if scans < 1 {
+				return fmt.Errorf("--scans must be at least 1, got %d", scans)
+			}
+			if minScans < 1 {
+				return fmt.Errorf("--min-scans must be at least 1, got %d", minScans)
+			}
+			return executeAuditCommand(cmd, tenant, scans, minScans)
+		},
+	}
+
+	cmd.Flags().IntVar(&scans, "scans", 50, "Number of most recent completed scans to analyze")
+	cmd.Flags().IntVar(&minScans, "min-scans", 3, "Minimum number of scans a plugin must be evaluated in to be judged")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant whose scans are analyzed")
+
+	return cmd
+}
+
+// executeAuditCommand orchestrates the audit command execution
+func executeAuditCommand(cmd *cobra.Command, tenant string, scans, minScans int) error {
+	logger := log.With().
+		Str("component", "plugin.cli").
+		Str("op", "audit").
+		Logger()
+
+	formatter := getFormatter(cmd)
+	history, err := loadPluginHistory(cmd.Context(), tenant, scans)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary("plugin audit", err, storage.ErrorCode(err))
+	}
+	curated := plugin.Curate(history, minScans)
+
+	logger.Debug().
+		Int("scans", len(history)).
+		Int("flagged", len(curated)).
+		Msg("plugin executions audited")
+
+	return printAudit(formatter, len(history), curated)
+}
+
+// loadPluginHistory reads the plugin executions of the most recent scans
+// from the workspace storage backend.
+func loadPluginHistory(ctx context.Context, tenant string, scans int) ([][]plugin.ExecStats, error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	return scanexec.LoadPluginHistory(ctx, backend, tenant, scans)
+}
+
+// printAudit formats and prints the plugins flagged by an audit
+func printAudit(f format.Formatter, scans int, curated []plugin.Curation) error {
+	if f.IsStructured() {
+		if curated == nil {
+			curated = []plugin.Curation{}
+		}
+		return f.PrintStructured(map[string]any{
+			"scans":   scans,
+			"plugins": curated,
+		})
+	}
+
+	if scans == 0 {
+		return f.PrintSummary("No scans with plugin statistics to audit.")
+	}
+	if len(curated) == 0 {
+		return f.PrintSummary(fmt.Sprintf("No plugins flagged across %d scans.", scans))
+	}
+
+	counts := make(map[plugin.Verdict]int)
+	rows := make([][]string, 0, len(curated))
+	for _, c := range curated {
+		counts[c.Verdict]++
+		rows = append(rows, []string{
+			c.PluginID,
+			c.Plugin,
+			string(c.Verdict),
+			fmt.Sprintf("%d", c.Scans),
+			fmt.Sprintf("%d", c.Attempts),
+			fmt.Sprintf("%d", c.Matches),
+			fmt.Sprintf("%d", c.Errors),
+		})
+	}
+	if err := f.PrintTable([]string{"Plugin", "Name", "Verdict", "Scans", "Attempts", "Matches", "Errors"}, rows); err != nil {
+		return err
+	}
+	return f.PrintSummary(fmt.Sprintf("%d plugins flagged across %d scans: %d erroring, %d always matched, %d never matched",
+		len(curated), scans, counts[plugin.VerdictErroring], counts[plugin.VerdictAlwaysMatched], counts[plugin.VerdictNeverMatched]))
+}
@@ -0,0 +1,143 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+// defaultBundleFile is the file 'plugin bundle create' writes by default.
+const defaultBundleFile = "vulntor-plugins.tar.gz"
+
+func newBundleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Package plugins for offline installs",
+		Long: `Package installed plugins and their manifest into a single archive.
+
+Bundles carry plugins to air-gapped hosts: create a bundle where plugin
+sources are reachable, copy it over, and install from it with --offline,
+which disables all network fetches.`,
+		Example: `  # Bundle every installed plugin
+  vulntor plugin bundle create
+
+  # On the air-gapped host
+  vulntor plugin install ssh --offline --bundle vulntor-plugins.tar.gz`,
+	}
+
+	cmd.AddCommand(newBundleCreateCommand())
+
+	return cmd
+}
+
+func newBundleCreateCommand() *cobra.Command {
+	var cacheDir string
+
+	cmd := &cobra.Command{
+		Use:   "create [category|plugin-name...]",
+		Short: "Create a plugin bundle from installed plugins",
+		Long: `Write installed plugins and a manifest listing them to a .tar.gz bundle.
+
+Arguments select plugins by category or name; without arguments every
+installed plugin is bundled. Cached files are verified against their
+checksums first, and the bundle keeps the checksums so installs from it
+are verified too.`,
+		Example: `  # Bundle every installed plugin
+  vulntor plugin bundle create
+
+  # Bundle SSH plugins and one HTTP plugin
+  vulntor plugin bundle create ssh http-weak-security-headers --file assessment.tar.gz
+
+  # Install from the bundle without network access
+  vulntor plugin install ssh --offline --bundle assessment.tar.gz`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeBundleCreateCommand(cmd, args, cacheDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Plugin cache directory (default: platform-specific, see storage config)")
+	cmd.Flags().StringP("file", "f", defaultBundleFile, "Bundle file to write")
+
+	return cmd
+}
+
+// executeBundleCreateCommand orchestrates the bundle create command execution
+func executeBundleCreateCommand(cmd *cobra.Command, targets []string, cacheDir string) error {
+	logger := log.With().
+		Str("component", "plugin.cli").
+		Str("op", "bundle").
+		Logger()
+
+	start := time.Now()
+	defer func() {
+		logger.Info().
+			Dur("duration_ms", time.Since(start)).
+			Msg("bundle completed")
+	}()
+
+	file, _ := cmd.Flags().GetString("file")
+	formatter := getFormatter(cmd)
+	svc, err := getPluginService(cmd, cacheDir)
+	if err != nil {
+		return err
+	}
+
+	// Write next to the destination and rename, so a failed run never
+	// leaves a truncated bundle behind
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".vulntor-bundle-*")
+	if err != nil {
+		return fmt.Errorf("create bundle file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	result, err := svc.Bundle(cmd.Context(), tmp, plugin.BundleOptions{Targets: targets})
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("write bundle: %w", closeErr)
+	}
+	if err == nil {
+		if err = os.Chmod(tmp.Name(), 0o644); err == nil {
+			err = os.Rename(tmp.Name(), file)
+		}
+	}
+	bundleDetails := map[string]string{"file": file}
+	if result != nil {
+		bundleDetails["plugins"] = strconv.Itoa(len(result.Plugins))
+	}
+	audit.RecordCLI(cmd.Context(), "plugin.bundle", file, err, bundleDetails)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary("bundle", err, plugin.ErrorCode(err))
+	}
+
+	logger.Info().
+		Str("file", file).
+		Int("plugin_count", len(result.Plugins)).
+		Msg("bundle succeeded")
+
+	return printBundleResult(formatter, file, result)
+}
+
+// printBundleResult formats and prints the bundle result
+func printBundleResult(f format.Formatter, file string, result *plugin.BundleResult) error {
+	if f.IsStructured() {
+		return f.PrintStructured(map[string]any{
+			"file":         file,
+			"plugins":      result.Plugins,
+			"plugin_count": len(result.Plugins),
+			"success":      true,
+		})
+	}
+
+	if err := f.PrintTable([]string{"Name", "Version", "Category"}, buildPluginTable(result.Plugins)); err != nil {
+		return err
+	}
+	return f.PrintSummary(fmt.Sprintf("✓ Bundled %d plugin(s) into %s", len(result.Plugins), file))
+}
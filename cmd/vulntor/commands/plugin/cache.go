@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+func newCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect the plugin cache",
+		Long: `Inspect the plugin download cache.
+
+The cache can be bounded with plugins.max_cache_size in the config file.
+Installing a plugin that pushes the cache over the limit evicts the least
+recently used plugin versions; 'vulntor plugin clean' enforces the limit too.`,
+		Example: `  # Show cache usage
+  vulntor plugin cache stats`,
+	}
+
+	cmd.AddCommand(newCacheStatsCommand())
+
+	return cmd
+}
+
+func newCacheStatsCommand() *cobra.Command {
+	var cacheDir string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show plugin cache usage",
+		Long: `Show the size of the plugin cache against its limit and list the cached
+plugin versions, least recently used (first to be evicted) first.`,
+		Example: `  # Show cache usage
+  vulntor plugin cache stats
+
+  # JSON output
+  vulntor plugin cache stats --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeCacheStatsCommand(cmd, cacheDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Plugin cache directory (default: platform-specific, see storage config)")
+
+	return cmd
+}
+
+// executeCacheStatsCommand orchestrates the cache stats command execution
+func executeCacheStatsCommand(cmd *cobra.Command, cacheDir string) error {
+	logger := log.With().
+		Str("component", "plugin.cli").
+		Str("op", "cache_stats").
+		Logger()
+
+	formatter := getFormatter(cmd)
+	svc, err := getPluginService(cmd, cacheDir)
+	if err != nil {
+		return err
+	}
+
+	stats, err := svc.CacheStats(cmd.Context())
+	if err != nil {
+		return formatter.PrintTotalFailureSummary("cache stats", err, plugin.ErrorCode(err))
+	}
+
+	logger.Debug().
+		Int("entries", stats.Entries).
+		Int64("size", stats.Size).
+		Int64("max_size", stats.MaxSize).
+		Msg("cache stats read")
+
+	return printCacheStats(formatter, stats)
+}
+
+// printCacheStats formats and prints cache usage
+func printCacheStats(f format.Formatter, stats *plugin.CacheStats) error {
+	if f.IsStructured() {
+		return f.PrintStructured(stats)
+	}
+
+	if stats.Entries == 0 {
+		return f.PrintSummary("Plugin cache is empty.")
+	}
+
+	rows := make([][]string, 0, len(stats.Usage))
+	for _, u := range stats.Usage {
+		rows = append(rows, []string{u.ID, u.Version, formatBytes(u.Size), u.LastUsed.Local().Format(time.DateTime)})
+	}
+	if err := f.PrintTable([]string{"Plugin", "Version", "Size", "Last Used"}, rows); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%d plugin(s), %d version(s), %s", stats.Plugins, stats.Entries, formatBytes(stats.Size))
+	if stats.MaxSize > 0 {
+		summary += fmt.Sprintf(" of %s (%.0f%%)", formatBytes(stats.MaxSize), float64(stats.Size)*100/float64(stats.MaxSize))
+	} else {
+		summary += " (no size limit)"
+	}
+	return f.PrintSummary(summary)
+}
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
 	"github.com/vulntor/vulntor/pkg/plugin"
 )
 
@@ -86,6 +88,13 @@ func executeCleanCommand(cmd *cobra.Command, cacheDir string) error {
 
 	// Call service layer
 	result, err := svc.Clean(cmd.Context(), opts)
+	if !opts.DryRun {
+		cleanDetails := map[string]string{"older_than": opts.OlderThan.String()}
+		if result != nil {
+			cleanDetails["removed"] = strconv.Itoa(result.RemovedCount)
+		}
+		audit.RecordCLI(cmd.Context(), "plugin.clean", "", err, cleanDetails)
+	}
 	if err != nil {
 		return formatter.PrintTotalFailureSummary("clean", err, plugin.ErrorCode(err))
 	}
@@ -117,7 +126,7 @@ func printCleanHeader(f format.Formatter, cacheDir string, opts plugin.CleanOpti
 
 // printCleanResult formats and prints the clean result
 func printCleanResult(f format.Formatter, result *plugin.CleanResult, dryRun bool) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printCleanJSON(f, result, dryRun)
 	}
 
@@ -154,5 +163,5 @@ func printCleanJSON(f format.Formatter, result *plugin.CleanResult, dryRun bool)
 		"dry_run":       dryRun,
 		"success":       true,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
@@ -2,7 +2,6 @@ package plugin
 
 import (
 	"fmt"
-	"os"
 	"sort"
 
 	"github.com/spf13/cobra"
@@ -40,10 +39,7 @@ for common security issues across SSH, HTTP, TLS, Database, and Network protocol
   vulntor plugin embedded --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Create formatter
-			outputMode := format.ParseMode(cmd.Flag("output").Value.String())
-			quiet, _ := cmd.Flags().GetBool("quiet")
-			noColor, _ := cmd.Flags().GetBool("no-color")
-			formatter := format.New(os.Stdout, os.Stderr, outputMode, quiet, !noColor)
+			formatter := getFormatter(cmd)
 
 			// Load embedded plugins
 			plugins, err := plugin.LoadEmbeddedPlugins()
@@ -120,7 +116,7 @@ func filterPluginsByCategory(plugins map[plugin.Category][]*plugin.YAMLPlugin, c
 // printEmbeddedResult formats and prints the embedded plugin result
 func printEmbeddedResult(f format.Formatter, allPlugins map[plugin.Category][]*plugin.YAMLPlugin, pluginsToDisplay []*plugin.YAMLPlugin, category string, verbose bool) error {
 	// JSON mode: output complete result as JSON
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printEmbeddedJSON(f, allPlugins, pluginsToDisplay)
 	}
 
@@ -141,7 +137,7 @@ func printEmbeddedJSON(f format.Formatter, allPlugins map[plugin.Category][]*plu
 		"total_count": len(pluginsToDisplay),
 		"categories":  categoryCount,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
 
 // printEmbeddedTable outputs embedded plugins as a table
@@ -10,51 +10,86 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/output/subscribers"
 	"github.com/vulntor/vulntor/pkg/plugin"
 	"github.com/vulntor/vulntor/pkg/storage"
-)
-
-const (
-	outputFormatJSON = "json"
+	"github.com/vulntor/vulntor/pkg/wordlist"
 )
 
 // getFormatter creates a formatter from command flags
 func getFormatter(cmd *cobra.Command) format.Formatter {
-	outputMode := format.ParseMode(cmd.Flag("output").Value.String())
-	quiet, _ := cmd.Flags().GetBool("quiet")
-	noColor, _ := cmd.Flags().GetBool("no-color")
-	return format.New(os.Stdout, os.Stderr, outputMode, quiet, !noColor)
+	return format.FromCommand(cmd)
+}
+
+// isStructured reports whether --output (or --json) selects JSON or YAML.
+func isStructured(cmd *cobra.Command) bool {
+	return format.ModeFromCommand(cmd) != format.ModeTable
 }
 
 // getPluginService creates a plugin service with the given cache directory and output format
 // If cacheDir is empty, uses the default platform-specific cache directory
 // Suppresses service layer info logs in text mode (JSON mode keeps them for observability)
 func getPluginService(cmd *cobra.Command, cacheDir string) (*plugin.Service, error) {
+	storageConfig, err := storage.DefaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get storage config: %w", err)
+	}
 	if cacheDir == "" {
-		storageConfig, err := storage.DefaultConfig()
-		if err != nil {
-			return nil, fmt.Errorf("get storage config: %w", err)
-		}
 		cacheDir = filepath.Join(storageConfig.WorkspaceRoot, "plugins", "cache")
 	}
 
 	// Create logger based on output format
 	// Text mode: suppress info logs (Output pipeline handles user messaging)
-	// JSON mode: keep info logs (structured observability)
-	outputFormat, _ := cmd.Flags().GetString("output")
+	// JSON/YAML mode: keep info logs (structured observability)
 	logger := log.With().Str("component", "plugin.service").Logger()
-	if outputFormat != outputFormatJSON {
+	if !isStructured(cmd) {
 		// Suppress info-level logs in text mode (only show warnings and errors)
 		logger = logger.Level(zerolog.WarnLevel)
 	}
 
-	svc, err := plugin.NewService(
+	opts := []plugin.ServiceOption{
 		plugin.WithCacheDir(cacheDir),
 		plugin.WithLogger(logger),
-	)
+		// Plugins reference the wordlists of the workspace by name
+		plugin.WithWordlistDir(filepath.Join(storageConfig.WorkspaceRoot, wordlist.DirName)),
+	}
+	// Downloads go through the configured proxy
+	var bandwidthConfig config.BandwidthConfig
+	if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+		cfg := cfgMgr.Get()
+		bandwidthConfig = cfg.Bandwidth
+		maxCacheSize, err := cfg.Plugins.CacheSizeLimit()
+		if err != nil {
+			return nil, fmt.Errorf("plugins.max_cache_size: %w", err)
+		}
+		opts = append(opts,
+			plugin.WithProxyConfig(cfg.Proxy),
+			plugin.WithSourceKeys(cfg.Plugins.SourceKeys),
+			plugin.WithSourceTrust(cfg.Plugins.SourceTrust),
+			plugin.WithMaxCacheSize(maxCacheSize),
+			plugin.WithRetryPolicy(cfg.Plugins.Retry),
+		)
+	}
+	// Downloads share one rate limit, if any
+	limiter, err := bind.BindBandwidthLimit(cmd, bandwidthConfig)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, plugin.WithBandwidthLimiter(limiter))
+	// Bundles are sources too; offline mode keeps only them
+	if bundles, err := cmd.Flags().GetStringSlice("bundle"); err == nil && len(bundles) > 0 {
+		opts = append(opts, plugin.WithBundles(bundles...))
+	}
+	if offline, _ := cmd.Flags().GetBool("offline"); offline {
+		opts = append(opts, plugin.WithOfflineMode(true))
+	}
+
+	svc, err := plugin.NewService(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create plugin service: %w", err)
 	}
@@ -122,13 +157,17 @@ func setupPluginOutputPipeline(cmd *cobra.Command) output.Output {
 	stream := output.NewOutputEventStream()
 
 	// Determine output format
-	outputFormat, _ := cmd.Flags().GetString("output")
+	mode := format.ModeFromCommand(cmd)
 	noColor, _ := cmd.Flags().GetBool("no-color")
 
-	if outputFormat == outputFormatJSON {
+	switch mode {
+	case format.ModeJSON:
 		// JSON output mode
 		stream.Subscribe(subscribers.NewJSONFormatter(os.Stdout))
-	} else {
+	case format.ModeYAML:
+		// YAML output mode: events would break the YAML document, only
+		// the final result is printed
+	default:
 		// Human-friendly output mode with optional color
 		colorEnabled := !noColor
 		stream.Subscribe(subscribers.NewHumanFormatter(os.Stdout, os.Stderr, colorEnabled))
@@ -138,7 +177,7 @@ func setupPluginOutputPipeline(cmd *cobra.Command) output.Output {
 	// Only for text mode (JSON mode should not have styled diagnostic output)
 	// Uses global persistent -v flag (same as scan command)
 	// -v (1): Verbose, -vv (2): Debug, -vvv (3): Trace
-	if outputFormat != outputFormatJSON {
+	if mode == format.ModeTable {
 		verbosityCount, _ := cmd.Flags().GetCount("verbosity")
 		if verbosityCount > 0 {
 			level := output.OutputLevel(verbosityCount)
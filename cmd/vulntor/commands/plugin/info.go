@@ -95,6 +95,7 @@ func printPluginInfo(f format.Formatter, info *plugin.PluginInfo) error {
 	rows := [][]string{
 		{"Name", info.Name},
 		{"Version", info.Version},
+		{"Source", formatProvenance(info)},
 		{"Checksum", info.Checksum},
 		{"Download URL", info.DownloadURL},
 		{"Installed", info.InstalledAt.Format("2006-01-02 15:04:05")},
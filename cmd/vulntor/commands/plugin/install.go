@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/plugin"
 )
@@ -24,22 +26,39 @@ func newInstallCommand() *cobra.Command {
 		Long: `Install plugins from remote repositories by category or specific plugin name.
 
 This command downloads plugins from configured sources and stores them in the local cache.
-You can install entire categories (ssh, http, tls, database, network) or specific plugins by name.`,
+You can install entire categories (ssh, http, tls, database, network) or specific plugins by name.
+Categories form a taxonomy: a category includes its subcategories (e.g., database/mysql),
+and a pattern such as database/* selects a category and everything below it.
+
+Plugins from sources configured as untrusted (plugins.source_trust) are refused
+unless --allow-untrusted is given.`,
 		Example: `  # Install all SSH plugins
   vulntor plugin install ssh
 
   # Install all HTTP plugins
   vulntor plugin install http
 
+  # Install all database plugins, including subcategories
+  vulntor plugin install 'database/*'
+
+  # Install only MySQL plugins
+  vulntor plugin install database/mysql
+
   # Install specific plugin by name
   vulntor plugin install ssh-cve-2024-6387
 
   # Install from specific source
   vulntor plugin install ssh --source official
 
+  # Install from an untrusted source after reviewing it
+  vulntor plugin install scratch-check --allow-untrusted
+
   # Force re-install even if already cached
   vulntor plugin install ssh --force
 
+  # Install from a plugin bundle without network access
+  vulntor plugin install ssh --offline --bundle vulntor-plugins.tar.gz
+
   # JSON output
   vulntor plugin install ssh --output json`,
 		Args: cobra.ExactArgs(1),
@@ -51,6 +70,8 @@ You can install entire categories (ssh, http, tls, database, network) or specifi
 	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Plugin cache directory (default: platform-specific, see storage config)")
 	cmd.Flags().String("source", "", "Install from specific source (e.g., 'official')")
 	cmd.Flags().Bool("force", false, "Force re-install even if already cached")
+	cmd.Flags().Bool("allow-untrusted", false, "Allow installing plugins from untrusted sources")
+	cmd.Flags().StringSlice("bundle", nil, "Install from a plugin bundle created with 'plugin bundle create' (repeatable)")
 
 	return cmd
 }
@@ -68,10 +89,9 @@ func executeInstallCommand(cmd *cobra.Command, target, cacheDir string) error {
 
 	// Setup structured logger with operation context
 	// For human-friendly text mode, suppress info logs (Output pipeline handles user messaging)
-	// For JSON mode, keep info logs for structured observability
-	outputFormat, _ := cmd.Flags().GetString("output")
+	// For JSON/YAML modes, keep info logs for structured observability
 	var logger zerolog.Logger
-	if outputFormat == outputFormatJSON {
+	if isStructured(cmd) {
 		logger = log.With().
 			Str("component", "plugin.cli").
 			Str("op", "install").
@@ -112,8 +132,19 @@ func executeInstallCommand(cmd *cobra.Command, target, cacheDir string) error {
 	// Emit info message about operation start
 	out.Info(fmt.Sprintf("Installing plugin(s): %s...", target))
 
+	// Report per-plugin download progress
+	opts.Progress = newProgressReporter(out)
+
 	// Call service layer
 	result, err := svc.Install(ctx, target, opts)
+	installDetails := map[string]string{"force": strconv.FormatBool(opts.Force)}
+	if opts.Source != "" {
+		installDetails["source"] = opts.Source
+	}
+	if result != nil {
+		installDetails["installed"] = strconv.Itoa(result.InstalledCount)
+	}
+	audit.RecordCLI(ctx, "plugin.install", target, err, installDetails)
 	// Handle errors with structured logging
 	if err != nil {
 
@@ -141,7 +172,7 @@ func executeInstallCommand(cmd *cobra.Command, target, cacheDir string) error {
 
 // printInstallResult formats and prints the install result
 func printInstallResult(f format.Formatter, result *plugin.InstallResult) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printInstallJSON(f, result)
 	}
 
@@ -223,5 +254,5 @@ func printInstallJSON(f format.Formatter, result *plugin.InstallResult) error {
 		"partial_failure": result.FailedCount > 0 && result.InstalledCount > 0,
 		"errors":          result.Errors,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
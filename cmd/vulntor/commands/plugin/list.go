@@ -20,8 +20,9 @@ func newListCommand() *cobra.Command {
 		Short: "List all installed plugins",
 		Long: `List all plugins currently installed in the cache.
 
-Displays plugin name, version, and installation status for each plugin
-found in the plugin cache directory.`,
+Displays plugin name, version, and provenance (the source a plugin was
+installed from and its trust level) for each plugin found in the plugin
+cache directory.`,
 		Example: `  # List all installed plugins
   vulntor plugin list
 
@@ -92,7 +93,7 @@ func executeListCommand(cmd *cobra.Command, cacheDir string, verbose bool) error
 
 // printListResult formats and prints the list result
 func printListResult(f format.Formatter, plugins []*plugin.PluginInfo, verbose bool) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printListJSON(f, plugins)
 	}
 
@@ -117,7 +118,7 @@ func printListJSON(f format.Formatter, plugins []*plugin.PluginInfo) error {
 		"plugins": plugins,
 		"count":   len(plugins),
 	}
-	return f.PrintJSON(result)
+	return f.PrintStructured(result)
 }
 
 // printEmptyPluginList prints message when no plugins are installed
@@ -140,25 +141,38 @@ func buildListTable(plugins []*plugin.PluginInfo, verbose bool) ([]string, [][]s
 	var rows [][]string
 
 	if verbose {
-		headers = []string{"Name", "Version", "Checksum", "Download URL"}
+		headers = []string{"Name", "Version", "Source", "Checksum", "Download URL"}
 		for _, p := range plugins {
 			rows = append(rows, []string{
 				p.Name,
 				p.Version,
+				formatProvenance(p),
 				truncateChecksum(p.Checksum),
 				truncateURL(p.DownloadURL),
 			})
 		}
 	} else {
-		headers = []string{"Name", "Version"}
+		headers = []string{"Name", "Version", "Source"}
 		for _, p := range plugins {
-			rows = append(rows, []string{p.ID, p.Version})
+			rows = append(rows, []string{p.ID, p.Version, formatProvenance(p)})
 		}
 	}
 
 	return headers, rows
 }
 
+// formatProvenance formats the source of a plugin and its trust level,
+// e.g. "official (official)"
+func formatProvenance(p *plugin.PluginInfo) string {
+	if p.Source == "" {
+		return "unknown"
+	}
+	if p.Trust == "" {
+		return p.Source
+	}
+	return fmt.Sprintf("%s (%s)", p.Source, p.Trust)
+}
+
 // truncateChecksum truncates checksum for display (shows first 12 chars)
 func truncateChecksum(checksum string) string {
 	if len(checksum) > 12 {
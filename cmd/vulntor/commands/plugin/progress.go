@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+// progressInterval limits how often download progress is redrawn
+const progressInterval = 200 * time.Millisecond
+
+// progressReporter renders plugin.ProgressEvent as output progress updates
+type progressReporter struct {
+	out      output.Output
+	lastDraw time.Time
+}
+
+// newProgressReporter returns a reporter writing progress of install and
+// update batches to out
+func newProgressReporter(out output.Output) plugin.ProgressReporter {
+	return &progressReporter{out: out}
+}
+
+// OnProgress implements plugin.ProgressReporter
+func (r *progressReporter) OnProgress(event plugin.ProgressEvent) {
+	if event.Phase == plugin.ProgressDownloading {
+		if time.Since(r.lastDraw) < progressInterval {
+			return
+		}
+	}
+	r.lastDraw = time.Now()
+	r.out.Progress(event.Completed, event.Total, formatProgress(event))
+}
+
+// formatProgress describes the event in one line, e.g.
+// "(3/40) ssh-weak-kex 12.0 KiB/40.0 KiB | 1.2 MiB/3.4 MiB at 300.0 KiB/s, ETA 8s"
+func formatProgress(event plugin.ProgressEvent) string {
+	msg := fmt.Sprintf("(%d/%d) %s", event.Index, event.Total, event.PluginID)
+
+	switch event.Phase {
+	case plugin.ProgressStarted:
+		return fmt.Sprintf("%s v%s", msg, event.Version)
+	case plugin.ProgressDownloading:
+		msg += " " + formatBytes(event.Bytes)
+		if event.Size > 0 {
+			msg += "/" + formatBytes(event.Size)
+		}
+	case plugin.ProgressSkipped:
+		msg += " already installed"
+	case plugin.ProgressFailed:
+		msg += " failed"
+	default:
+		msg += " " + string(event.Phase)
+	}
+
+	msg += " | " + formatBytes(event.TotalBytes)
+	if event.ExpectedBytes > event.TotalBytes {
+		msg += "/" + formatBytes(event.ExpectedBytes)
+	}
+	if speed := event.Speed(); speed > 0 {
+		msg += fmt.Sprintf(" at %s/s", formatBytes(int64(speed)))
+	}
+	if eta := event.ETA(); eta > 0 {
+		msg += fmt.Sprintf(", ETA %s", eta.Round(time.Second))
+	}
+	return msg
+}
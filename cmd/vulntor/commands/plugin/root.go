@@ -38,18 +38,34 @@ Use these commands to list, inspect, verify, and maintain your plugin cache.`,
   vulntor plugin verify
 
   # Clean unused cache entries
-  vulntor plugin clean`,
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Validate global --output once for all subcommands
-			output, _ := cmd.Flags().GetString("output")
-			return format.ValidateMode(output)
-		},
+  vulntor plugin clean
+
+  # Show cache usage against plugins.max_cache_size
+  vulntor plugin cache stats
+
+  # Find plugins that never match, always match or keep failing
+  vulntor plugin audit
+
+  # Package installed plugins for an air-gapped host, then install there
+  vulntor plugin bundle create --file vulntor-plugins.tar.gz
+  vulntor plugin install ssh --offline --bundle vulntor-plugins.tar.gz`,
 	}
 
-	// Global flags inherited by subcommands
-	cmd.PersistentFlags().String("output", "table", "Output format: json, table")
-	cmd.PersistentFlags().Bool("quiet", false, "Suppress non-essential output")
-	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	cmd.PersistentFlags().Bool("offline", false, "Disable network access; install and update only from --bundle files")
+	cmd.PersistentFlags().String("limit-rate", "", "Maximum download rate per second, e.g. 512KB (overrides bandwidth.limit)")
+
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		// Validate global --output once for all subcommands
+		if err := format.ResolveFlags(c); err != nil {
+			return err
+		}
+		// Cobra runs only the nearest persistent pre-run: load the config
+		// (proxy, logging) through the root command as well
+		if root := c.Root(); root != cmd && root.PersistentPreRunE != nil {
+			return root.PersistentPreRunE(c, args)
+		}
+		return nil
+	}
 
 	// Add subcommands
 	cmd.AddCommand(newListCommand())
@@ -60,6 +76,9 @@ Use these commands to list, inspect, verify, and maintain your plugin cache.`,
 	cmd.AddCommand(newInfoCommand())
 	cmd.AddCommand(newVerifyCommand())
 	cmd.AddCommand(newCleanCommand())
+	cmd.AddCommand(newCacheCommand())
+	cmd.AddCommand(newBundleCommand())
+	cmd.AddCommand(newAuditCommand())
 
 	return cmd
 }
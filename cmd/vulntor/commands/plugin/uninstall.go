@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
 	"github.com/vulntor/vulntor/pkg/plugin"
 )
 
@@ -23,13 +25,17 @@ func newUninstallCommand() *cobra.Command {
 		Long: `Uninstall (remove) plugins from the local cache.
 
 This command removes plugins from the cache directory. You can uninstall specific plugins by name,
-all plugins in a category, or all plugins at once.`,
+all plugins in a category, or all plugins at once. A category includes its subcategories,
+and a subcategory or pattern (e.g., database/*) may be given in place of a plugin name.`,
 		Example: `  # Uninstall specific plugin
   vulntor plugin uninstall ssh-cve-2024-6387
 
   # Uninstall all SSH plugins
   vulntor plugin uninstall --category ssh
 
+  # Uninstall all database plugins, including subcategories
+  vulntor plugin uninstall 'database/*'
+
   # Uninstall all plugins
   vulntor plugin uninstall --all
 
@@ -50,7 +56,7 @@ all plugins in a category, or all plugins at once.`,
 
 	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Plugin cache directory (default: platform-specific, see storage config)")
 	cmd.Flags().Bool("all", false, "Uninstall all plugins")
-	cmd.Flags().String("category", "", "Uninstall all plugins from category (ssh, http, tls, database, network)")
+	cmd.Flags().String("category", "", "Uninstall all plugins from category or pattern (e.g., ssh, database/mysql, web/*)")
 
 	return cmd
 }
@@ -94,6 +100,14 @@ func executeUninstallCommand(cmd *cobra.Command, target, cacheDir string) error
 
 	// Call service layer
 	result, err := svc.Uninstall(ctx, target, opts)
+	uninstallDetails := map[string]string{"all": strconv.FormatBool(opts.All)}
+	if opts.Category != "" {
+		uninstallDetails["category"] = string(opts.Category)
+	}
+	if result != nil {
+		uninstallDetails["removed"] = strconv.Itoa(result.RemovedCount)
+	}
+	audit.RecordCLI(ctx, "plugin.uninstall", target, err, uninstallDetails)
 
 	// Handle partial failure (exit code 8)
 	if handleErr := handlePartialFailure(err, formatter, func() error {
@@ -120,7 +134,7 @@ func executeUninstallCommand(cmd *cobra.Command, target, cacheDir string) error
 
 // printUninstallResult formats and prints the uninstall result
 func printUninstallResult(f format.Formatter, result *plugin.UninstallResult) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printUninstallJSON(f, result)
 	}
 
@@ -179,5 +193,5 @@ func printUninstallJSON(f format.Formatter, result *plugin.UninstallResult) erro
 		"partial_failure": result.FailedCount > 0 && result.RemovedCount > 0,
 		"errors":          result.Errors,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/plugin"
 )
@@ -40,6 +42,9 @@ new or updated plugins to the local cache. By default, it downloads all core plu
   # Update from specific source
   vulntor plugin update --source official
 
+  # Update from a plugin bundle without network access
+  vulntor plugin update --offline --bundle vulntor-plugins.tar.gz
+
   # JSON output
   vulntor plugin update --output json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -49,9 +54,11 @@ new or updated plugins to the local cache. By default, it downloads all core plu
 
 	cmd.Flags().StringVar(&cacheDir, "cache-dir", "", "Plugin cache directory (default: platform-specific, see storage config)")
 	cmd.Flags().String("source", "", "Download from specific source (e.g., 'official')")
-	cmd.Flags().String("category", "", "Download only plugins from category (ssh, http, tls, database, network)")
+	cmd.Flags().String("category", "", "Download only plugins from category or pattern (e.g., ssh, database/mysql, web/*)")
 	cmd.Flags().Bool("dry-run", false, "Show what would be downloaded without downloading")
 	cmd.Flags().Bool("force", false, "Force re-download even if already cached")
+	cmd.Flags().Bool("allow-untrusted", false, "Allow downloading plugins from untrusted sources")
+	cmd.Flags().StringSlice("bundle", nil, "Update from a plugin bundle created with 'plugin bundle create' (repeatable)")
 
 	return cmd
 }
@@ -80,10 +87,9 @@ func executeUpdateCommand(cmd *cobra.Command, cacheDir string) error {
 
 	// Setup structured logger
 	// For human-friendly text mode, suppress info logs (Output pipeline handles user messaging)
-	// For JSON mode, keep info logs for structured observability
-	outputFormat, _ := cmd.Flags().GetString("output")
+	// For JSON/YAML modes, keep info logs for structured observability
 	var logger zerolog.Logger
-	if outputFormat == outputFormatJSON {
+	if isStructured(cmd) {
 		logger = log.With().
 			Str("component", "plugin.cli").
 			Str("op", "update").
@@ -122,8 +128,21 @@ func executeUpdateCommand(cmd *cobra.Command, cacheDir string) error {
 		out.Info(fmt.Sprintf("Updating plugins from remote repository%s...", categoryMsg))
 	}
 
+	// Report per-plugin download progress
+	opts.Progress = newProgressReporter(out)
+
 	// Call service layer
 	result, err := svc.Update(ctx, opts)
+	if !opts.DryRun {
+		updateDetails := map[string]string{"force": strconv.FormatBool(opts.Force)}
+		if opts.Source != "" {
+			updateDetails["source"] = opts.Source
+		}
+		if result != nil {
+			updateDetails["updated"] = strconv.Itoa(result.UpdatedCount)
+		}
+		audit.RecordCLI(ctx, "plugin.update", string(opts.Category), err, updateDetails)
+	}
 
 	// Handle partial failure (exit code 8)
 	if handleErr := handlePartialFailure(err, formatter, func() error {
@@ -151,7 +170,7 @@ func executeUpdateCommand(cmd *cobra.Command, cacheDir string) error {
 
 // printUpdateResult formats and prints the update result
 func printUpdateResult(f format.Formatter, result *plugin.UpdateResult, dryRun bool) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printUpdateJSON(f, result, dryRun)
 	}
 
@@ -246,7 +265,7 @@ func printUpdateJSON(f format.Formatter, result *plugin.UpdateResult, dryRun boo
 		"partial_failure": result.FailedCount > 0 && result.UpdatedCount > 0,
 		"errors":          result.Errors,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
 
 // printUpdateDryRun prints dry run output
@@ -111,7 +111,7 @@ func executeVerifyCommand(cmd *cobra.Command, cacheDir string) error {
 
 // printVerifyResult formats and prints the verify result
 func printVerifyResult(f format.Formatter, result *plugin.VerifyResult) error {
-	if f.IsJSON() {
+	if f.IsStructured() {
 		return printVerifyJSON(f, result)
 	}
 
@@ -147,7 +147,7 @@ func printVerifyJSON(f format.Formatter, result *plugin.VerifyResult) error {
 		"failed_count": result.FailedCount,
 		"success":      result.FailedCount == 0,
 	}
-	return f.PrintJSON(jsonResult)
+	return f.PrintStructured(jsonResult)
 }
 
 // buildVerifyTable builds table rows for verify results
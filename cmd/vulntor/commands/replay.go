@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+)
+
+// newScanReplayCommand returns the command that re-evaluates the stored
+// evidence of a scan.
+func newScanReplayCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay <scan-id>",
+		Short: "Re-evaluate a stored scan without touching the network",
+		Long: `Re-run fingerprinting and plugin evaluation over the evidence stored by an
+earlier scan: the open ports, banners and protocol details its probes
+recorded. No packets are sent, so plugins released or installed since can be
+applied to historical scans, e.g. to check old results for a new CVE.
+
+Nuclei templates and multi-step plugins send their own requests and are
+skipped. The replay is stored as a new scan that references the replayed one;
+it neither reports changes since the last scan nor updates tickets. Scans run
+before evidence was stored cannot be replayed.`,
+		Example: `  # Evaluate a scan again with the current plugins
+  vulntor scan replay 3f2a9c1e-...
+
+  # Only the SSH plugins and one specific check
+  vulntor scan replay 3f2a9c1e-... --plugins ssh,tls-expired-certificate`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScanReplayCommand(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringSlice("plugins", []string{}, "Plugins to evaluate, by ID, name, category or tag (comma-separated; default: all)")
+	cmd.Flags().StringP("output", "o", "text", "Output format: text, json, yaml, sarif")
+	cmd.Flags().Bool("progress", false, "Print live progress updates during the replay")
+
+	return cmd
+}
+
+func runScanReplayCommand(cmd *cobra.Command, scanID string) error {
+	formatter := format.FromCommand(cmd)
+	out := setupOutputPipeline(cmd)
+	const operation = "scan replay"
+
+	logger := log.With().Str("command", "scan replay").Str("replay_of", scanID).Logger()
+
+	orchestratorCtx, appMgr, err := orchestratorContext(cmd)
+	if err != nil {
+		logger.Error().Err(err).Msg("AppManager not found in context.")
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+
+	svc, closeStorage, err := newScanService(orchestratorCtx, appMgr, logger)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary(operation, err, scanexec.ErrorCode(err))
+	}
+	defer closeStorage()
+
+	if progress, _ := cmd.Flags().GetBool("progress"); progress {
+		svc = svc.WithProgressSink(&progressLogger{logger: logger, out: out})
+	}
+	orchestratorCtx = context.WithValue(orchestratorCtx, output.OutputKey, out)
+
+	plugins, _ := cmd.Flags().GetStringSlice("plugins")
+	params := scanexec.Params{
+		ReplayOf:     scanID,
+		Plugins:      plugins,
+		EnableVuln:   true,
+		OutputFormat: format.OutputFlag(cmd),
+	}
+	if params.OutputFormat == "text" {
+		out.Info(fmt.Sprintf("Replaying scan %s from stored evidence", scanID))
+	}
+
+	res, runErr := svc.Run(orchestratorCtx, params)
+	var runID string
+	if res != nil {
+		runID = res.RunID
+	}
+	audit.RecordCLI(orchestratorCtx, "scan.replay", runID, runErr, map[string]string{
+		"replay_of": scanID,
+		"plugins":   strings.Join(plugins, ","),
+	})
+	if runErr != nil {
+		logger.Error().Err(runErr).Msg("Scan replay failed")
+		out.Error(runErr)
+		return formatter.PrintTotalFailureSummary(operation, runErr, scanexec.ErrorCode(runErr))
+	}
+
+	if err := renderScanOutput(out, formatter, params, res, extractDataContext(res), logger); err != nil {
+		return err
+	}
+	if params.OutputFormat == "text" {
+		out.Info(fmt.Sprintf("Scan ID: %s", runID))
+	}
+	return nil
+}
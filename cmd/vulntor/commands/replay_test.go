@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestScanReplayCommand_ReevaluatesStoredScan(t *testing.T) {
+	tmp := t.TempDir()
+	path := filepath.Join(tmp, "result.xml")
+	require.NoError(t, os.WriteFile(path, []byte(importNmapXML), 0o600))
+	runImport(t, tmp, "nmap", path)
+
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: filepath.Join(tmp, "vulntor")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	scans, err := backend.Scans().List(ctx, storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+	imported := scans[0].ID
+
+	cmd := NewCommand()
+	buf := &bytes.Buffer{}
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"scan", "replay", imported, "--plugins", "ssh", "--output", "json"})
+	stdout := os.Stdout
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	os.Stdout = devNull
+	err = cmd.Execute()
+	os.Stdout = stdout
+	_ = devNull.Close()
+	require.NoError(t, err, buf.String())
+
+	scans, err = backend.Scans().List(ctx, storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 2)
+	var replay *storage.ScanMetadata
+	for _, s := range scans {
+		if s.ReplayOf == imported {
+			replay = s
+		}
+	}
+	require.NotNil(t, replay, "replay scan not stored")
+	require.Equal(t, "completed", replay.Status)
+	require.Equal(t, "10.0.0.5", replay.Target)
+
+	hosts, err := backend.Scans().ReadData(ctx, storage.DefaultOrgID, replay.ID, storage.DataTypeHosts)
+	require.NoError(t, err)
+	defer func() { _ = hosts.Close() }()
+	content, err := io.ReadAll(hosts)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"product":"OpenSSH"`)
+}
@@ -0,0 +1,265 @@
+// Package report provides the CLI command that renders reports for stored scans.
+package report
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/compliance"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+// Supported report formats.
+const (
+	formatHTML = "html"
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+)
+
+// NewCommand creates the 'vulntor report' command.
+//
+// Example usage:
+//
+//	vulntor report <scan-id> --output-file report.html
+//	vulntor report <scan-id> --template corporate.html.tmpl > report.html
+//	vulntor report <scan-id> --format xlsx --output-file findings.xlsx
+//	vulntor report <scan-id> --compliance cis --format csv > cis.csv
+//	vulntor report <scan-id> --executive --output-file summary.html
+func NewCommand() *cobra.Command {
+	var opts options
+
+	cmd := &cobra.Command{
+		Use:   "report <scan-id>",
+		Short: "Generate a report for a stored scan",
+		Long: `Generate a report for a scan kept in the workspace storage backend.
+
+Formats:
+  html  A single self-contained file (styles and charts inline, no external
+        assets) with an executive summary, findings by severity and a
+        per-host drill-down of open ports and findings.
+  csv   One row per finding: host, port, service, version, plugin,
+        severity, CVE, evidence and remediation.
+  xlsx  An Excel workbook with Summary, Findings (as in csv) and Hosts sheets.
+
+--template renders the report with a custom Go html/template instead of the
+built-in one, e.g. for corporate branding. See the report documentation for
+the fields and functions available to templates.
+
+--overdue limits the report to findings past their remediation due date
+(see the sla configuration section) that were not triaged as fixed,
+accepted-risk or false-positive or rechecked as fixed.
+
+--compliance rolls the findings up into the controls of a compliance
+framework (cis, pci-dss, nist, or a custom mapping file) instead: one row per
+control with its status, for the whole scan and each of its target groups.
+A control fails when a plugin mapped to it reported a finding, passes when
+its plugins ran without findings, and is not assessed when none ran.
+
+--executive compares the scan with the previous scans (--history, default 5)
+of the same target groups, or the same target for scans run without groups,
+for leadership reporting: findings by severity per scan with the findings
+new and fixed since the scan before, the mean time to remediate by severity,
+and the findings reported by the most scans. The csv format has one row per
+scan.`,
+		Example: `  # Write an HTML report to a file
+  vulntor report 20231006-143022-a1b2c3 --output-file report.html
+
+  # Export findings for spreadsheet triage
+  vulntor report 20231006-143022-a1b2c3 --format csv > findings.csv
+  vulntor report 20231006-143022-a1b2c3 --format xlsx --output-file findings.xlsx
+
+  # List the findings that breached their SLA
+  vulntor report 20231006-143022-a1b2c3 --overdue --format csv > overdue.csv
+
+  # PCI DSS control coverage, per target group
+  vulntor report 20231006-143022-a1b2c3 --compliance pci-dss --output-file pci.html
+
+  # Quarterly summary of the last 12 scans for leadership
+  vulntor report 20231006-143022-a1b2c3 --executive --history 12 --output-file summary.html
+
+  # Render with a corporate template
+  vulntor report 20231006-143022-a1b2c3 --template acme.html.tmpl > report.html
+
+  # Report on a scan in another tenant
+  vulntor report 20231006-143022-a1b2c3 --tenant team-a --output-file report.html`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			err := runReport(cmd.Context(), cmd.OutOrStdout(), args[0], opts)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("generate report", err, storage.ErrorCode(err))
+			}
+			if opts.outputFile != "" {
+				return formatter.PrintSummary(fmt.Sprintf("Report written to %s", opts.outputFile))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.format, "format", formatHTML, "Report format: html, csv, xlsx")
+	cmd.Flags().StringVar(&opts.templatePath, "template", "", "Custom html/template file for the html format (default: built-in template)")
+	cmd.Flags().StringVar(&opts.outputFile, "output-file", "", "Write the report to a file (default: stdout)")
+	cmd.Flags().StringVar(&opts.tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the scan")
+	cmd.Flags().BoolVar(&opts.overdue, "overdue", false, "Only report findings past their remediation due date")
+	cmd.Flags().StringVar(&opts.compliance, "compliance", "", "Report control coverage of a framework: cis, pci-dss, nist or a mapping file")
+	cmd.Flags().BoolVar(&opts.executive, "executive", false, "Summarize trends against the previous scans of the same target group")
+	cmd.Flags().IntVar(&opts.history, "history", 5, "Previous scans compared by --executive")
+
+	return cmd
+}
+
+// options are the flags of the report command.
+type options struct {
+	format       string
+	templatePath string
+	outputFile   string
+	tenant       string
+	overdue      bool
+	compliance   string // framework ID or mapping file, for compliance reports
+	executive    bool
+	history      int // previous scans compared by executive summaries
+}
+
+func runReport(ctx context.Context, stdout io.Writer, scanID string, opts options) error {
+	reportFormat := strings.ToLower(opts.format)
+	var (
+		render         func(io.Writer, *report.Data) error
+		renderControls func(io.Writer, *report.ComplianceReport) error
+		renderSummary  func(io.Writer, *report.ExecutiveSummary) error
+	)
+	switch reportFormat {
+	case formatHTML:
+		renderControls, renderSummary = report.WriteComplianceHTML, report.WriteExecutiveHTML
+		if opts.compliance != "" || opts.executive {
+			break
+		}
+		// Fail on a broken template before touching storage
+		tmpl, err := report.ParseHTMLTemplate(opts.templatePath)
+		if err != nil {
+			return err
+		}
+		render = func(w io.Writer, data *report.Data) error {
+			return report.WriteHTML(w, report.NewHTMLReport(data, version.GetVersion().Version), tmpl)
+		}
+	case formatCSV:
+		render, renderControls, renderSummary = report.WriteCSV, report.WriteComplianceCSV, report.WriteExecutiveCSV
+	case formatXLSX:
+		render, renderControls, renderSummary = report.WriteXLSX, report.WriteComplianceXLSX, report.WriteExecutiveXLSX
+	default:
+		return storage.NewInvalidInputError("format", fmt.Sprintf("unsupported report format %q (must be html, csv or xlsx)", reportFormat))
+	}
+	if opts.templatePath != "" && reportFormat != formatHTML {
+		return storage.NewInvalidInputError("template", "only the html format supports templates")
+	}
+
+	if opts.compliance != "" || opts.executive {
+		kind := "compliance reports"
+		if opts.executive {
+			kind = "executive summaries"
+		}
+		switch {
+		case opts.compliance != "" && opts.executive:
+			return storage.NewInvalidInputError("executive", "cannot be combined with --compliance")
+		case opts.templatePath != "":
+			return storage.NewInvalidInputError("template", kind+" do not support templates")
+		case opts.overdue:
+			return storage.NewInvalidInputError("overdue", kind+" cover every finding of the scan")
+		}
+	}
+	if opts.history < 0 {
+		return storage.NewInvalidInputError("history", fmt.Sprintf("must be >= 0, got %d", opts.history))
+	}
+	var framework *compliance.Framework
+	if opts.compliance != "" {
+		var err error
+		if framework, err = compliance.Load(opts.compliance); err != nil {
+			return storage.NewInvalidInputError("compliance", err.Error())
+		}
+	}
+
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	var data *report.Data
+	if opts.executive {
+		history, err := report.LoadHistory(ctx, backend.Scans(), opts.tenant, scanID, opts.history)
+		if err != nil {
+			return err
+		}
+		summary := report.NewExecutiveSummary(history, version.GetVersion().Version)
+		render = func(w io.Writer, _ *report.Data) error { return renderSummary(w, summary) }
+		data = history[len(history)-1]
+	} else if data, err = report.Load(ctx, backend.Scans(), opts.tenant, scanID); err != nil {
+		return err
+	}
+	if opts.overdue {
+		if err := keepOverdue(ctx, backend, opts.tenant, data); err != nil {
+			return err
+		}
+	}
+	if framework != nil {
+		stats, err := scanexec.LoadPluginStats(ctx, backend, opts.tenant, scanID)
+		if err != nil {
+			return err
+		}
+		controls := report.NewComplianceReport(data, framework, stats, version.GetVersion().Version)
+		render = func(w io.Writer, _ *report.Data) error { return renderControls(w, controls) }
+	}
+
+	if opts.outputFile == "" {
+		return render(stdout, data)
+	}
+
+	f, err := os.Create(opts.outputFile)
+	if err != nil {
+		return fmt.Errorf("create report file: %w", err)
+	}
+	if err := render(f, data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// keepOverdue drops the findings of data that are not overdue, taking
+// their triage from the finding statuses of backend, when it keeps them.
+func keepOverdue(ctx context.Context, backend storage.Backend, tenant string, data *report.Data) error {
+	statuses := make(map[string]*storage.FindingStatus)
+	if statusBackend, ok := backend.(storage.FindingStatusBackend); ok {
+		list, err := statusBackend.FindingStatuses().List(ctx, tenant)
+		if err != nil {
+			return err
+		}
+		for _, status := range list {
+			statuses[status.ID] = status
+		}
+	}
+	data.KeepOverdue(statuses, time.Now())
+	return nil
+}
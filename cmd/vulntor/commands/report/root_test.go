@@ -0,0 +1,230 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runReportCommand(t *testing.T, root string, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func seedScan(t *testing.T, root string) {
+	t.Helper()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	defer func() { _ = backend.Close() }()
+
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{
+		ID: "scan-1", Target: "10.0.0.0/24", Status: "completed", StartedAt: time.Now(),
+	}))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeHosts, strings.NewReader(
+		`{"ip":"10.0.0.5","ports":[{"port":22,"protocol":"tcp","service":"ssh"}]}`+"\n")))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.0.5","port":22,"plugin":"SSH Weak MAC Algorithm","severity":"medium","message":"Weak MAC"}`+"\n")))
+}
+
+func TestReportCommand_HTML(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	out, err := runReportCommand(t, root, "scan-1")
+	require.NoError(t, err)
+	require.Contains(t, out, "<!DOCTYPE html>")
+	require.Contains(t, out, "SSH Weak MAC Algorithm")
+
+	path := filepath.Join(t.TempDir(), "report.html")
+	out, err = runReportCommand(t, root, "scan-1", "--output-file", path)
+	require.NoError(t, err)
+	require.Contains(t, out, "Report written to "+path)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "10.0.0.5")
+}
+
+func TestReportCommand_CSV(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	out, err := runReportCommand(t, root, "scan-1", "--format", "csv")
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "Host", records[0][0])
+	require.Equal(t, []string{"10.0.0.5", "22", "ssh", "SSH Weak MAC Algorithm", "medium"},
+		[]string{records[1][0], records[1][2], records[1][4], records[1][7], records[1][9]})
+}
+
+func TestReportCommand_Overdue(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.0.5","port":22,"plugin":"SSH Weak MAC Algorithm","severity":"medium","due_at":"2020-01-01T00:00:00Z"}`+"\n"+
+			`{"target":"10.0.0.5","port":23,"plugin":"Telnet Enabled","severity":"high","due_at":"2020-01-01T00:00:00Z"}`+"\n"+
+			`{"target":"10.0.0.5","port":80,"plugin":"Banner","severity":"low"}`+"\n")))
+	telnet := &storage.FindingStatus{ID: "a89bdcaa21b8a1bd"}
+	require.NoError(t, backend.FindingStatuses().Transition(ctx, storage.DefaultOrgID, telnet, storage.FindingTransition{To: storage.FindingStateFalsePositive}))
+	require.NoError(t, backend.Close())
+
+	out, err := runReportCommand(t, root, "scan-1", "--format", "csv", "--overdue")
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "SSH Weak MAC Algorithm", records[1][7])
+	require.Equal(t, "2020-01-01", records[1][24]) // Due Date
+}
+
+func TestReportCommand_Compliance(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.0.5","port":22,"plugin":"SSH Weak MAC Algorithm","plugin_id":"ssh-weak-mac","severity":"medium"}`+"\n")))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypePluginStats, strings.NewReader(
+		`{"plugin_id":"ssh-weak-mac","plugin":"SSH Weak MAC Algorithm","attempts":1,"matches":1}`+"\n"+
+			`{"plugin_id":"ssh-cve-2024-6387","plugin":"OpenSSH regreSSHion","attempts":1}`+"\n")))
+	require.NoError(t, backend.Close())
+
+	out, err := runReportCommand(t, root, "scan-1", "--compliance", "nist", "--format", "csv")
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	statuses := make(map[string]string)
+	for _, r := range records[1:] {
+		statuses[r[1]] = r[3]
+	}
+	require.Equal(t, "fail", statuses["SC-8"])
+	require.Equal(t, "pass", statuses["SI-2"])
+	require.Equal(t, "not-assessed", statuses["IA-5"])
+
+	out, err = runReportCommand(t, root, "scan-1", "--compliance", "pci-dss")
+	require.NoError(t, err)
+	require.Contains(t, out, "PCI DSS 4.0 Compliance Report")
+
+	out, err = runReportCommand(t, root, "scan-1", "--compliance", "hipaa")
+	require.NoError(t, err)
+	require.Contains(t, out, `unknown framework "hipaa"`)
+
+	out, err = runReportCommand(t, root, "scan-1", "--compliance", "cis", "--overdue")
+	require.NoError(t, err)
+	require.Contains(t, out, "compliance reports cover every finding of the scan")
+}
+
+func TestReportCommand_Executive(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{
+		ID: "scan-0", Target: "10.0.0.0/24", Status: "completed", StartedAt: time.Now().Add(-7 * 24 * time.Hour),
+	}))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-0", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.0.5","port":22,"plugin":"SSH Weak MAC Algorithm","severity":"medium"}`+"\n"+
+			`{"target":"10.0.0.5","port":23,"plugin":"Telnet Enabled","severity":"high"}`+"\n")))
+	require.NoError(t, backend.Close())
+
+	out, err := runReportCommand(t, root, "scan-1", "--executive", "--format", "csv")
+	require.NoError(t, err)
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "scan-0", records[1][0])
+	require.Equal(t, []string{"scan-1", "1", "0", "1"}, []string{records[2][0], records[2][7], records[2][8], records[2][9]})
+
+	out, err = runReportCommand(t, root, "scan-1", "--executive")
+	require.NoError(t, err)
+	require.Contains(t, out, "Vulntor Executive Summary")
+
+	out, err = runReportCommand(t, root, "scan-1", "--executive", "--compliance", "cis")
+	require.NoError(t, err)
+	require.Contains(t, out, "cannot be combined with --compliance")
+
+	out, err = runReportCommand(t, root, "scan-1", "--executive", "--history", "-1")
+	require.NoError(t, err)
+	require.Contains(t, out, "must be >= 0, got -1")
+}
+
+func TestReportCommand_XLSX(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	path := filepath.Join(t.TempDir(), "findings.xlsx")
+	out, err := runReportCommand(t, root, "scan-1", "--format", "XLSX", "--output-file", path)
+	require.NoError(t, err)
+	require.Contains(t, out, "Report written to "+path)
+
+	zr, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer func() { _ = zr.Close() }()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	require.Contains(t, names, "xl/workbook.xml")
+	require.Contains(t, names, "xl/worksheets/sheet2.xml")
+}
+
+func TestReportCommand_CustomTemplate(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	tmpl := filepath.Join(t.TempDir(), "acme.html.tmpl")
+	require.NoError(t, os.WriteFile(tmpl, []byte(`ACME {{.Scan.ID}}: {{.TotalFindings}} finding(s), risk {{.Risk}}`), 0o644))
+
+	out, err := runReportCommand(t, root, "scan-1", "--template", tmpl)
+	require.NoError(t, err)
+	require.Equal(t, "ACME scan-1: 1 finding(s), risk medium", out)
+}
+
+func TestReportCommand_Errors(t *testing.T) {
+	root := t.TempDir()
+	seedScan(t, root)
+
+	out, err := runReportCommand(t, root, "missing")
+	require.NoError(t, err)
+	require.Contains(t, out, "not found")
+
+	out, err = runReportCommand(t, root, "scan-1", "--format", "csv", "--template", "acme.html.tmpl")
+	require.NoError(t, err)
+	require.Contains(t, out, "only the html format supports templates")
+
+	out, err = runReportCommand(t, root, "scan-1", "--format", "pdf")
+	require.NoError(t, err)
+	require.Contains(t, out, "unsupported report format")
+
+	_, err = runReportCommand(t, root)
+	require.Error(t, err)
+}
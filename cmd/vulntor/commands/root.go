@@ -3,15 +3,24 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	auditCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/audit"
+	configCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/config"
 	dagCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/dag"
+	groupCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/group"
 	pluginCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/plugin"
+	reportCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/report"
+	scopeCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/scope"
 	serverCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/server"
 	storageCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/storage"
+	telemetryCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/telemetry"
+	wordlistCmd "github.com/vulntor/vulntor/cmd/vulntor/commands/wordlist"
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
 	"github.com/vulntor/vulntor/pkg/appctx"
 	"github.com/vulntor/vulntor/pkg/cli"
 	"github.com/vulntor/vulntor/pkg/config"
@@ -22,7 +31,9 @@ import (
 	_ "github.com/vulntor/vulntor/pkg/modules/parse"      // Protocol parser modules
 	_ "github.com/vulntor/vulntor/pkg/modules/reporting"  // Reporting modules
 	_ "github.com/vulntor/vulntor/pkg/modules/scan"       // Scanner modules
+	"github.com/vulntor/vulntor/pkg/secrets"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 const cliExecutable = "vulntor"
@@ -35,6 +46,7 @@ func NewCommand() *cobra.Command {
 		storageDir      string
 		storageDisabled bool
 		appManager      engine.Manager
+		shutdownTracing func(context.Context) error
 		verbosityCount  int
 		verbose         bool
 	)
@@ -43,11 +55,15 @@ func NewCommand() *cobra.Command {
 		Use:   cliExecutable,
 		Short: "Vulntor is a fast and flexible network scanner",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := format.ResolveFlags(cmd); err != nil {
+				return err
+			}
+
 			factory := &engine.DefaultAppManagerFactory{}
 
 			mgr, err := factory.Create(cmd.Flags(), configFile)
 			if err != nil {
-				return fmt.Errorf("initialize AppManager: %w", err)
+				return format.WithErrorCode(fmt.Errorf("initialize AppManager: %w", err), format.ErrorCodeConfigLoadFailed)
 			}
 			appManager = mgr
 
@@ -84,6 +100,20 @@ func NewCommand() *cobra.Command {
 				}
 			}
 
+			// Export spans to an OTLP collector when tracing is enabled in config
+			shutdownTracing, err = tracing.Setup(appManager.Config().Get().Tracing)
+			if err != nil {
+				return err
+			}
+
+			// Read secret references from the configured secret managers
+			secretsConfig := appManager.Config().Get().Secrets
+			resolver, err := secretsConfig.Resolver()
+			if err != nil {
+				return format.WithErrorCode(fmt.Errorf("invalid secrets config: %w", err), format.ErrorCodeConfigLoadFailed)
+			}
+			secrets.SetDefault(resolver)
+
 			cmd.SetContext(ctx)
 			if root := cmd.Root(); root != nil && root != cmd {
 				root.SetContext(ctx)
@@ -91,6 +121,13 @@ func NewCommand() *cobra.Command {
 			return nil
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if shutdownTracing != nil {
+				flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := shutdownTracing(flushCtx); err != nil {
+					log.Warn().Err(err).Msg("Failed to flush trace spans")
+				}
+				cancel()
+			}
 			if appManager != nil {
 				appManager.Shutdown()
 			}
@@ -107,18 +144,50 @@ func NewCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose logging (shows service layer logs)")
 
 	config.BindFlags(cmd.PersistentFlags())
+	format.AddFlags(cmd.PersistentFlags())
 
 	cmd.AddGroup(&cobra.Group{ID: "scan", Title: "Scan Commands"})
 	cmd.AddGroup(&cobra.Group{ID: "core", Title: "Core Commands"})
 
+	cmd.AddCommand(auditCmd.NewCommand())
+	cmd.AddCommand(configCmd.NewCommand())
 	cmd.AddCommand(dagCmd.NewCommand())
+	cmd.AddCommand(groupCmd.NewCommand())
 	cmd.AddCommand(pluginCmd.NewCommand())
+	cmd.AddCommand(reportCmd.NewCommand())
+	cmd.AddCommand(scopeCmd.NewCommand())
 	cmd.AddCommand(serverCmd.NewCommand())
 	cmd.AddCommand(storageCmd.NewStorageCommand())
+	cmd.AddCommand(telemetryCmd.NewCommand())
+	cmd.AddCommand(wordlistCmd.NewCommand())
 	cmd.AddCommand(cli.NewVersionCommand(cliExecutable))
 	cmd.AddCommand(ScanCmd)
+	cmd.AddCommand(NewImportCommand())
+	cmd.AddCommand(NewFindingsCommand())
 	cmd.AddCommand(NewFingerprintCommand())
 	cmd.AddCommand(NewStatsCommand())
+	cmd.AddCommand(NewDoctorCommand())
+	cmd.AddCommand(NewServeRPCCommand())
+	cmd.AddCommand(NewBenchCommand())
 
 	return cmd
 }
+
+// Execute runs root and prints an error returned by any command, or by
+// flag and argument parsing, with its error code, suggestions and docs
+// link in the output format of the failing command. Commands that print
+// their own failure set SilenceErrors.
+func Execute(root *cobra.Command) error {
+	root.SilenceErrors = true
+	cmd, err := root.ExecuteC()
+	if err == nil {
+		return nil
+	}
+	if cmd == nil {
+		cmd = root
+	}
+	if cmd == root || !cmd.SilenceErrors {
+		_ = format.FromCommand(cmd).PrintError(err)
+	}
+	return err
+}
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -27,3 +29,44 @@ func TestRootCommandPreparesWorkspaceAndRunsVersion(t *testing.T) {
 		t.Fatalf("workspace root should exist: %v", err)
 	}
 }
+
+func TestExecutePrintsErrorsWithHints(t *testing.T) {
+	t.Setenv("VULNTOR_WORKSPACE", t.TempDir())
+
+	cmd := NewCommand()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"version", "--no-such-flag"})
+
+	if err := Execute(cmd); err == nil {
+		t.Fatal("expected an error for an unknown flag")
+	}
+	for _, want := range []string{"✗ Error: unknown flag: --no-such-flag", "💡 Suggestions:", "--help", "📖 Docs: https://docs.vulntor.ai/cli/overview"} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Fatalf("stderr missing %q:\n%s", want, stderr.String())
+		}
+	}
+
+	cmd = NewCommand()
+	stdout.Reset()
+	stderr.Reset()
+	cmd.SetOut(stdout)
+	cmd.SetErr(stderr)
+	cmd.SetArgs([]string{"--json", "--output", "yaml", "version"})
+
+	if err := Execute(cmd); err == nil {
+		t.Fatal("expected an error for conflicting output flags")
+	}
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Code    string `json:"code"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		t.Fatalf("expected a JSON error on stdout: %v\n%s", err, stdout.String())
+	}
+	if result.Success || result.Error != "--json conflicts with --output yaml" || result.Code != "INVALID_USAGE" {
+		t.Fatalf("unexpected JSON error: %+v", result)
+	}
+}
@@ -3,9 +3,13 @@ package commands
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -16,12 +20,21 @@ import (
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
 	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
 	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/engagement"
 	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
 	parsepkg "github.com/vulntor/vulntor/pkg/modules/parse" // Alias for parse package functions
+	"github.com/vulntor/vulntor/pkg/notify"
 	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/report"
 	"github.com/vulntor/vulntor/pkg/scanexec"
 	"github.com/vulntor/vulntor/pkg/storage"
 	"github.com/vulntor/vulntor/pkg/stringutil"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+	"github.com/vulntor/vulntor/pkg/version"
 )
 
 // ScanCmd defines the 'scan' command for comprehensive scanning.
@@ -29,7 +42,29 @@ var ScanCmd = &cobra.Command{
 	Use:   "scan [targets...]",
 	Short: "Perform a comprehensive scan on specified targets",
 	Long: `Performs various scanning stages based on selected profile, level, or flags.
-The command automatically plans the execution DAG using available modules.`,
+The command automatically plans the execution DAG using available modules.
+
+With --fail-on or --fail-on-cve the command exits with a non-zero code when
+vulnerability findings fail the gate: 10 (info), 11 (low), 12 (medium),
+13 (high) or 14 (critical), after the highest failing severity. Gates see the
+severities remapped by the policy config for --environment.`,
+	Example: `  # Block a deploy on high or critical findings
+  vulntor scan 10.0.0.0/24 --vuln --fail-on high
+
+  # Fail when specific CVEs are found
+  vulntor scan app.example.com --vuln --fail-on-cve CVE-2024-3094,CVE-2021-44228
+
+  # Apply the severity overrides of an OT network
+  vulntor scan 10.20.0.0/16 --vuln --environment ot --fail-on high
+
+  # Scan a target group; findings inherit its tags
+  vulntor scan --group prod-web --vuln
+
+  # Scan the running EC2 instances tagged env=prod
+  vulntor scan --provider aws --filter tag:env=prod --vuln
+
+  # Scan the Services, NodePorts and Ingresses of a Kubernetes namespace
+  vulntor scan --provider kubernetes --filter namespace:shop`,
 	GroupID: "scan",
 	Args:    cobra.ArbitraryArgs,
 	RunE:    runScanCommand,
@@ -39,7 +74,13 @@ func runScanCommand(cmd *cobra.Command, args []string) error {
 	formatter := format.FromCommand(cmd)
 	out := setupOutputPipeline(cmd)
 
-	if len(args) == 0 {
+	groupNames, _ := cmd.Flags().GetStringSlice("group")
+	providerName, _ := cmd.Flags().GetString("provider")
+	if filters, _ := cmd.Flags().GetStringSlice("filter"); len(filters) > 0 && providerName == "" {
+		err := fmt.Errorf("--filter selects provider instances; add --provider aws, gcp, azure or kubernetes")
+		return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
+	}
+	if len(args) == 0 && len(groupNames) == 0 && providerName == "" {
 		return formatter.PrintTotalFailureSummary("scan", scanexec.ErrNoTargets, scanexec.ErrorCode(scanexec.ErrNoTargets))
 	}
 
@@ -58,45 +99,49 @@ func runScanCommand(cmd *cobra.Command, args []string) error {
 		return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
 	}
 
-	svc := scanexec.NewService()
+	// Instances of the provider are scanned besides the listed targets; the
+	// ports Kubernetes workloads expose are scanned unless --ports is given.
+	// Hosts and findings are stored with their instances.
+	if providerName != "" {
+		inv, err := providerTargets(cmd, providerName)
+		if err != nil {
+			logger.Error().Err(err).Str("provider", providerName).Msg("Failed to list provider instances")
+			return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
+		}
+		out.Info(fmt.Sprintf("Found %d addresses in the %s inventory", len(inv.Targets), providerName))
+		params.Targets = scanexec.GroupTargets(params.Targets, []*storage.TargetGroup{{Targets: inv.Targets}})
+		if inv.Ports != "" && params.Ports == "" {
+			params.Ports = inv.Ports
+		}
+		params.Cloud = inv.Cloud
+	}
 
-	ctxFromCmd := cmd.Context()
-	if ctxFromCmd == nil && cmd.Root() != nil {
-		ctxFromCmd = cmd.Root().Context()
+	// Gate errors fail the command, so that a mistyped gate cannot pass CI
+	gate, err := bind.BindScanGate(cmd)
+	if err != nil {
+		return err
 	}
-	appMgr, ok := ctxFromCmd.Value(engine.AppManagerKey).(*engine.AppManager)
-	if !ok || appMgr == nil {
-		appErr := fmt.Errorf("app manager missing from context")
-		logger.Error().Err(appErr).Msg("AppManager not found in context.")
-		return formatter.PrintTotalFailureSummary("scan", appErr, scanexec.ErrorCode(appErr))
+	if gate.Enabled() && !params.EnableVuln {
+		out.Warning("--fail-on and --fail-on-cve only see findings of vulnerability checks; add --vuln")
 	}
-	orchestratorCtx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
-	orchestratorCtx = appctx.WithConfig(orchestratorCtx, appMgr.Config())
 
-	// Create and attach storage backend for scan result persistence
-	storageConfig, err := storage.DefaultConfig()
+	orchestratorCtx, appMgr, err := orchestratorContext(cmd)
 	if err != nil {
-		logger.Warn().Err(err).Msg("Failed to get storage config, scans will not be persisted")
-	} else {
-		storageBackend, err := storage.NewBackend(orchestratorCtx, storageConfig)
-		if err != nil {
-			logger.Warn().Err(err).Msg("Failed to create storage backend, scans will not be persisted")
-		} else {
-			// Initialize storage
-			if err := storageBackend.Initialize(orchestratorCtx); err != nil {
-				logger.Warn().Err(err).Msg("Failed to initialize storage, scans will not be persisted")
-			} else {
-				svc = svc.WithStorage(storageBackend)
-				logger.Info().Msg("Storage backend initialized for scan persistence")
-
-				// Ensure storage is closed when scan completes
-				defer func() {
-					if err := storageBackend.Close(); err != nil {
-						logger.Warn().Err(err).Msg("Failed to close storage backend")
-					}
-				}()
-			}
-		}
+		logger.Error().Err(err).Msg("AppManager not found in context.")
+		return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
+	}
+
+	svc, closeStorage, err := newScanService(orchestratorCtx, appMgr, logger)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
+	}
+	defer closeStorage()
+
+	// Group targets are scanned after the explicit ones; their findings
+	// inherit the group tags
+	params.TargetGroups, err = svc.ResolveTargetGroups(orchestratorCtx, storage.DefaultOrgID, groupNames)
+	if err != nil {
+		return formatter.PrintTotalFailureSummary("scan", err, scanexec.ErrorCode(err))
 	}
 
 	// Enable progress logging if interactive flag is set
@@ -121,15 +166,217 @@ func runScanCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Ctrl+C stops the scan gracefully, keeping its partial results; a
+	// second one exits at once
+	drainTimeout, _ := cmd.Flags().GetDuration("drain-timeout")
+	shutdown := scanexec.NewShutdown(drainTimeout)
+	stopSignals := shutdown.NotifySignals(os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	svc = svc.WithShutdown(shutdown)
+
 	res, runErr := svc.Run(orchestratorCtx, params)
+	var runID string
+	if res != nil {
+		runID = res.RunID
+	}
+	if errors.Is(runErr, scanexec.ErrInterrupted) {
+		out.Warning(fmt.Sprintf("Scan interrupted; partial results stored as scan %s", runID))
+	}
+	details := map[string]string{"targets": strings.Join(params.Targets, ",")}
+	if len(groupNames) > 0 {
+		details["groups"] = strings.Join(groupNames, ",")
+	}
+	if providerName != "" {
+		details["provider"] = providerName
+	}
+	if res != nil && res.Engagement != nil {
+		details["engagement_client"] = res.Engagement.Client
+		details["engagement_authorization"] = res.Engagement.Authorization
+		details["engagement_tester"] = res.Engagement.Tester
+	}
+	audit.RecordCLI(orchestratorCtx, "scan.run", runID, runErr, details)
 	if runErr != nil {
 		logger.Error().Err(runErr).Msg("Scan execution failed")
 		out.Error(runErr)
-		return formatter.PrintTotalFailureSummary("scan", runErr, scanexec.ErrorCode(runErr))
+		if err := formatter.PrintTotalFailureSummary("scan", runErr, scanexec.ErrorCode(runErr)); err != nil || !gate.Enabled() {
+			return err
+		}
+		// A scan that did not finish cannot pass a gate
+		cmd.SilenceErrors = true
+		return runErr
 	}
 
 	dataCtx := extractDataContext(res)
-	return renderScanOutput(out, formatter, params, res, dataCtx, logger)
+	if err := renderScanOutput(out, formatter, params, res, dataCtx, logger); err != nil {
+		return err
+	}
+	return checkScanGate(cmd, gate, dataCtx)
+}
+
+// checkScanGate returns a *report.GateError, which sets the exit code of
+// the command, when the findings of a scan fail gate. The failure goes to
+// stderr, leaving stdout to the scan results.
+func checkScanGate(cmd *cobra.Command, gate report.Gate, dataCtx map[string]interface{}) error {
+	if !gate.Enabled() {
+		return nil
+	}
+	findings, err := report.FindingsFromContext(dataCtx)
+	if err != nil {
+		return fmt.Errorf("check scan gate: %w", err)
+	}
+	if err := gate.Check(findings); err != nil {
+		cmd.SilenceErrors = true
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "✗ %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// orchestratorContext returns the context scans run in, carrying the
+// AppManager and configuration of the command.
+func orchestratorContext(cmd *cobra.Command) (context.Context, *engine.AppManager, error) {
+	ctxFromCmd := cmd.Context()
+	if ctxFromCmd == nil && cmd.Root() != nil {
+		ctxFromCmd = cmd.Root().Context()
+	}
+	appMgr, ok := ctxFromCmd.Value(engine.AppManagerKey).(*engine.AppManager)
+	if !ok || appMgr == nil {
+		return nil, nil, fmt.Errorf("app manager missing from context")
+	}
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	return appctx.WithConfig(ctx, appMgr.Config()), appMgr, nil
+}
+
+// newScanService builds a scan service with the configured webhook
+// notifications, ticketing, proxy, credentials, fingerprint normalization
+// and, when available, the workspace storage backend. The returned func
+// closes the storage backend.
+func newScanService(ctx context.Context, appMgr *engine.AppManager, logger zerolog.Logger) (*scanexec.Service, func(), error) {
+	svc := scanexec.NewService()
+	closeStorage := func() {}
+
+	// Webhook notifications on completion and findings
+	notifier, err := notify.New(appMgr.Config().Get().Notifications)
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid notifications configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithNotifier(notifier)
+
+	// Tickets for findings in Jira / GitHub Issues
+	tickets, err := ticketing.New(appMgr.Config().Get().Ticketing)
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid ticketing configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithTicketing(tickets)
+
+	// Banner grabs and HTTP probes through the configured proxy
+	proxyConfig := appMgr.Config().Get().Proxy
+	proxy, err := proxyConfig.Proxy()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid proxy configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithProxy(proxy)
+
+	// Logins for credentialed checks
+	credentialsConfig := appMgr.Config().Get().Credentials
+	creds, err := credentialsConfig.Store()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid credentials configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithCredentials(creds)
+
+	// Severity overrides per environment
+	policyConfig := appMgr.Config().Get().Policy
+	severityPolicy, err := policyConfig.Policy()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid policy configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithPolicy(severityPolicy)
+
+	// Secrets masked in evidence before it is stored or exported
+	redactionConfig := appMgr.Config().Get().Redaction
+	redactor, err := redactionConfig.Redactor()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid redaction configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithRedactor(redactor)
+
+	// Remediation deadlines of findings per severity
+	slaConfig := appMgr.Config().Get().SLA
+	deadlines, err := slaConfig.SLA()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid SLA configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithSLA(deadlines)
+
+	// Low confidence for findings on possible honeypots and tarpits
+	honeypotConfig := appMgr.Config().Get().Honeypot
+	decoys, err := honeypotConfig.Detector()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid honeypot configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithHoneypot(decoys)
+
+	// How endpoints behind CDNs and WAFs are scanned
+	cdnConfig := appMgr.Config().Get().CDN
+	cdnPolicy, err := cdnConfig.CDNPolicy()
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid CDN configuration")
+		return nil, closeStorage, err
+	}
+	svc = svc.WithCDNPolicy(cdnPolicy)
+
+	// Canonical vendor and product names of fingerprint matches
+	normalizer, err := fingerprint.LoadNormalizer(appMgr.Config().Get().Fingerprint.Normalization)
+	if err != nil {
+		logger.Error().Err(err).Msg("Invalid fingerprint configuration")
+		return nil, closeStorage, err
+	}
+	fingerprint.RegisterNormalizer(normalizer)
+
+	// Anonymous aggregates of recent scans, when opted in
+	telemetryConfig := appMgr.Config().Get().Telemetry
+	if err := telemetryConfig.Validate(); err != nil {
+		logger.Error().Err(err).Msg("Invalid telemetry configuration")
+		return nil, closeStorage, err
+	}
+
+	// Create and attach storage backend for scan result persistence
+	storageConfig, err := storage.DefaultConfig()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to get storage config, scans will not be persisted")
+		return svc, closeStorage, nil
+	}
+	storageBackend, err := storage.NewBackend(ctx, storageConfig)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to create storage backend, scans will not be persisted")
+		return svc, closeStorage, nil
+	}
+	// Initialize storage
+	if err := storageBackend.Initialize(ctx); err != nil {
+		logger.Warn().Err(err).Msg("Failed to initialize storage, scans will not be persisted")
+		return svc, closeStorage, nil
+	}
+	logger.Info().Msg("Storage backend initialized for scan persistence")
+
+	// Ensure storage is closed when the run completes
+	closeStorage = func() {
+		if err := storageBackend.Close(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}
+
+	// Reports of recent scans are sent after runs, when due
+	reporter := telemetry.NewReporter(storageBackend, filepath.Join(storageConfig.WorkspaceRoot, telemetry.StateFile), telemetryConfig)
+	return svc.WithStorage(storageBackend).WithTelemetry(reporter), closeStorage, nil
 }
 
 func extractDataContext(res *scanexec.Result) map[string]interface{} {
@@ -172,6 +419,20 @@ func renderScanOutput(out output.Output, formatter format.Formatter, params scan
 			return formatter.PrintTotalFailureSummary("scan", yamlErr, scanexec.ErrorCode(yamlErr))
 		}
 		fmt.Println(string(yamlData))
+	case "sarif":
+		findings, findingsErr := report.FindingsFromContext(dataCtx)
+		if findingsErr != nil {
+			logger.Error().Err(findingsErr).Msg("Failed to read findings for SARIF output")
+			return formatter.PrintTotalFailureSummary("scan", findingsErr, scanexec.ErrorCode(findingsErr))
+		}
+		var eng *engagement.Record
+		if res != nil {
+			eng = res.Engagement
+		}
+		if sarifErr := report.WriteSARIF(os.Stdout, findings, version.GetVersion().Version, eng); sarifErr != nil {
+			logger.Error().Err(sarifErr).Msg("Failed to write SARIF output")
+			return formatter.PrintTotalFailureSummary("scan", sarifErr, scanexec.ErrorCode(sarifErr))
+		}
 	default:
 		if len(profiles) > 0 {
 			if res != nil {
@@ -302,6 +563,7 @@ func printAssetProfileTextOutput(out output.Output, profiles []engine.AssetProfi
 						out.Warning("         Vulnerabilities:")
 						for _, vuln := range port.Vulnerabilities {
 							out.Warning(fmt.Sprintf("           - [%s] %s (%s)", vuln.Severity, vuln.ID, vuln.Summary))
+							printFindingDetails(out, vuln)
 						}
 					}
 				}
@@ -314,6 +576,37 @@ func printAssetProfileTextOutput(out output.Output, profiles []engine.AssetProfi
 	out.Info("\n--- End of Scan Results ---")
 }
 
+// printFindingDetails prints the remediation, references and evidence
+// of a finding below it.
+func printFindingDetails(out output.Output, vuln engine.VulnerabilityFinding) {
+	const indent = "             "
+	if vuln.OriginalSeverity != "" {
+		remapped := fmt.Sprintf("%sSeverity: %s by policy, was %s", indent, vuln.Severity, vuln.OriginalSeverity)
+		if vuln.SeverityReason != "" {
+			remapped += " (" + vuln.SeverityReason + ")"
+		}
+		out.Info(remapped)
+	}
+	if vuln.Remediation != "" {
+		out.Info(indent + "Remediation: " + vuln.Remediation)
+	}
+	for _, ref := range vuln.References {
+		out.Info(indent + "Reference: " + ref)
+	}
+	fields := make([]string, 0, len(vuln.Evidence))
+	for field := range vuln.Evidence {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		lines := strings.Split(strings.TrimRight(vuln.Evidence[field], "\r\n"), "\n")
+		out.Info(indent + "Evidence (" + field + "): " + strings.TrimRight(lines[0], "\r"))
+		for _, line := range lines[1:] {
+			out.Info(indent + "  " + strings.TrimRight(line, "\r"))
+		}
+	}
+}
+
 // Helper function to get keys from a map for printing.
 func getMapKeys(m map[string]time.Time) []string {
 	keys := make([]string, 0, len(m))
@@ -403,6 +696,12 @@ func printScanSummary(out output.Output, res *scanexec.Result, profiles []engine
 	// Add vulnerabilities row
 	rows = append(rows, []string{"Vulnerabilities", fmt.Sprintf("%d", totalVulns)})
 
+	// The plugin dominating runtime, see 'vulntor scan stats --by-plugin'
+	if len(res.PluginStats) > 0 {
+		slowest := res.PluginStats[0]
+		rows = append(rows, []string{"Slowest Plugin", fmt.Sprintf("%s (%s, %d attempts)", slowest.PluginID, slowest.WallTime.Round(time.Millisecond), slowest.Attempts)})
+	}
+
 	// Output using Output.Table() - this will be rendered by HumanFormatter or JSONFormatter
 	out.Table(headers, rows)
 }
@@ -462,13 +761,47 @@ func init() {
 	ScanCmd.Flags().Bool("only-discover", false, "Run only discovery modules (scan and vuln phases are skipped)")
 	ScanCmd.Flags().Bool("no-discover", false, "Skip discovery phase and proceed directly to port scanning/vuln")
 	ScanCmd.Flags().Bool("progress", false, "Print live progress updates during the scan")
+	ScanCmd.Flags().Duration("drain-timeout", scanexec.DefaultDrainTimeout, "On Ctrl+C or SIGTERM, time the probes in flight get to finish before the partial results are stored")
 	ScanCmd.Flags().String("fingerprint-cache", "", "Path to fingerprint catalog cache directory")
-	ScanCmd.Flags().StringP("output", "o", "text", "Output format: text, json, yaml")
+	ScanCmd.Flags().StringP("output", "o", "text", "Output format: text, json, yaml, sarif")
 	ScanCmd.Flags().String("timeout", "", "Override timeout for network operations (default: module-specific or from config file)")
 	ScanCmd.Flags().Int("concurrency", 0, "Override concurrency for parallel operations (default: module-specific or from config file)")
+	ScanCmd.Flags().Bool("auto-tune", false, "Tune port discovery and banner grabbing concurrency and timeouts from system resources and observed round trips; --concurrency and --timeout set the starting point")
+	ScanCmd.Flags().Bool("verify-timeouts", false, "Probe the ports whose connection attempts timed out once more at the end of port discovery, at lower concurrency and twice the timeout (refused ports are not probed again)")
+	ScanCmd.Flags().String("pcap", "", "Record probe traffic as one pcap file per host, stored with the scan: 'all', or 'findings' for the ports findings were reported on (--pcap alone records all)")
+	ScanCmd.Flags().Lookup("pcap").NoOptDefVal = string(capture.ModeAll)
+	ScanCmd.Flags().StringSlice("nuclei-templates", []string{}, "Nuclei template files or directories to run against HTTP services (requires --vuln)")
+	ScanCmd.Flags().Bool("default-creds", false, "Test the default credentials declared by plugins against the services found (sends login attempts; implies --vuln)")
+	ScanCmd.Flags().Bool("content-discovery", false, "Request a wordlist of paths (e.g., /.git/, /admin) from the HTTP services found and report those that exist; with --vuln, plugins match them as http.paths")
+	ScanCmd.Flags().StringSlice("group", []string{}, "Target groups to scan in addition to the listed targets; findings inherit the group tags (see 'vulntor group')")
+	ScanCmd.Flags().String("provider", "", "Also scan the running instances of a cloud inventory (aws, gcp, azure; credentials from the cloud's environment variables) or the exposed workloads of a cluster (kubernetes; kubeconfig or in-cluster credentials)")
+	ScanCmd.Flags().StringSlice("filter", []string{}, "Select provider instances: tag:KEY=VALUE, vpc:ID, region:NAME, namespace:NAME (repeatable; tags are combined)")
+	ScanCmd.Flags().Bool("public-ips", false, "Scan the public addresses of provider instances instead of their private ones")
+	ScanCmd.Flags().String("environment", "", "Environment the targets belong to, selecting the severity overrides of the policy config (e.g., 'ot', 'prod')")
+	ScanCmd.Flags().String("fail-on", "", "Exit with a non-zero code when a finding has at least this severity: critical, high, medium, low, info")
+	ScanCmd.Flags().StringSlice("fail-on-cve", []string{}, "Exit with a non-zero code when a finding references one of these CVE IDs")
 
 	// Ping specific flags - planner can use these if ICMP module is selected
 	ScanCmd.Flags().Bool("ping", true, "Enable ICMP host discovery (default: true)")
 	ScanCmd.Flags().Int("ping-count", 1, "Number of ICMP pings per host")
 	ScanCmd.Flags().Bool("allow-loopback", false, "Allow scanning loopback addresses")
+
+	// Egress flags - control which interface and address probes leave from
+	ScanCmd.Flags().String("interface", "", "Network interface probes leave by (e.g., 'eth1'); defaults to its first address of each family as source")
+	ScanCmd.Flags().String("source-ip", "", "Source IP address of probes; must be assigned to this host (and to --interface, if set)")
+	ScanCmd.Flags().StringSlice("decoys", []string{}, "IPv4 addresses privileged ICMP host discovery also sends echo requests from (comma-separated; requires root)")
+
+	// Scope flags - approve targets outside the configured scan scope
+	ScanCmd.Flags().String("scope-ack", "", "Signed acknowledgment approving targets outside the scan scope (see 'vulntor scope ack')")
+
+	// Engagement flags - record the authorization the scan is run under
+	ScanCmd.Flags().String("client", "", "Client the scan is run for (default: engagement.client from config)")
+	ScanCmd.Flags().String("authorization", "", "Reference of the authorization to test, e.g. an engagement letter (default: engagement.authorization from config)")
+	ScanCmd.Flags().String("tester", "", "Tester running the scan (default: engagement.tester from config)")
+	ScanCmd.Flags().String("window", "", "Window testing is authorized in, FROM/UNTIL as RFC 3339 times or dates, e.g. 2026-12-01/2026-12-14; scans outside it are refused")
+
+	ScanCmd.AddCommand(newScanReplayCommand())
+	ScanCmd.AddCommand(newScanStatsCommand())
+	ScanCmd.AddCommand(newScanSurfaceCommand())
+	ScanCmd.AddCommand(newScanTopologyCommand())
 }
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// newScanStatsCommand returns the command that reports the plugin
+// executions of a stored scan.
+func newScanStatsCommand() *cobra.Command {
+	var (
+		byPlugin bool
+		top      int
+		tenant   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats <scan-id>",
+		Short: "Show plugin execution statistics of a stored scan",
+		Long: `Show how often the plugins of a stored scan were evaluated, how often they
+matched or failed, and the wall-clock time they took.
+
+Without --by-plugin, the totals of all plugins are shown. --by-plugin lists
+each plugin, slowest first, with its share of the total plugin time, to find
+the plugins that dominate the runtime of a scan.`,
+		Example: `  # Totals of all plugins
+  vulntor scan stats 3f2a9c1e-...
+
+  # The ten slowest plugins
+  vulntor scan stats 3f2a9c1e-... --by-plugin --top 10`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			const operation = "load scan stats"
+
+			stats, err := loadScanPluginStats(cmd.Context(), tenant, args[0])
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, storage.ErrorCode(err))
+			}
+
+			plugins := len(stats)
+			total := plugin.ExecStats{}
+			for _, s := range stats {
+				total.Attempts += s.Attempts
+				total.Matches += s.Matches
+				total.Errors += s.Errors
+				total.WallTime += s.WallTime
+			}
+			if top > 0 && len(stats) > top {
+				stats = stats[:top]
+			}
+
+			if formatter.IsStructured() {
+				result := map[string]any{
+					"scan_id":   args[0],
+					"plugins":   plugins,
+					"attempts":  total.Attempts,
+					"matches":   total.Matches,
+					"errors":    total.Errors,
+					"wall_time": total.WallTime,
+				}
+				if byPlugin {
+					result["by_plugin"] = stats
+				}
+				return formatter.PrintStructured(result)
+			}
+
+			if len(stats) == 0 {
+				return formatter.PrintSummary(fmt.Sprintf("No plugin executions recorded for scan %s.", args[0]))
+			}
+
+			if !byPlugin {
+				rows := [][]string{
+					{"Plugins", fmt.Sprintf("%d", plugins)},
+					{"Attempts", fmt.Sprintf("%d", total.Attempts)},
+					{"Matches", fmt.Sprintf("%d", total.Matches)},
+					{"Errors", fmt.Sprintf("%d", total.Errors)},
+					{"Wall Time", total.WallTime.Round(time.Millisecond).String()},
+					{"Slowest Plugin", stats[0].PluginID},
+				}
+				return formatter.PrintTable([]string{"Metric", "Value"}, rows)
+			}
+
+			rows := make([][]string, 0, len(stats))
+			for _, s := range stats {
+				share := 0.0
+				if total.WallTime > 0 {
+					share = 100 * float64(s.WallTime) / float64(total.WallTime)
+				}
+				rows = append(rows, []string{
+					s.PluginID,
+					s.Plugin,
+					fmt.Sprintf("%d", s.Attempts),
+					fmt.Sprintf("%d", s.Matches),
+					fmt.Sprintf("%d", s.Errors),
+					s.WallTime.Round(time.Millisecond).String(),
+					fmt.Sprintf("%.1f%%", share),
+				})
+			}
+			if err := formatter.PrintTable([]string{"Plugin", "Name", "Attempts", "Matches", "Errors", "Wall Time", "Share"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Plugins took %s in total", total.WallTime.Round(time.Millisecond)))
+		},
+	}
+
+	cmd.Flags().BoolVar(&byPlugin, "by-plugin", false, "List the statistics of each plugin, slowest first")
+	cmd.Flags().IntVar(&top, "top", 0, "Only list the slowest N plugins (default: all)")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the scan")
+
+	return cmd
+}
+
+// loadScanPluginStats reads the plugin stats of a scan from the workspace
+// storage backend.
+func loadScanPluginStats(ctx context.Context, tenant, scanID string) ([]plugin.ExecStats, error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	return scanexec.LoadPluginStats(ctx, backend, tenant, scanID)
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runScanStats(t *testing.T, root string, args ...string) (string, error) {
+	t.Helper()
+	cmd := newScanStatsCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestScanStatsCommand(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	for _, id := range []string{"scan-1", "scan-2"} {
+		require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{
+			ID: id, Target: "10.0.0.0/24", Status: "completed", StartedAt: time.Now(),
+		}))
+	}
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypePluginStats, strings.NewReader(
+		`{"plugin_id":"fast","plugin":"Fast Plugin","attempts":4,"matches":1,"errors":0,"wall_time":100000000}`+"\n"+
+			`{"plugin_id":"slow","plugin":"Slow Plugin","attempts":2,"matches":0,"errors":1,"wall_time":300000000}`+"\n")))
+	require.NoError(t, backend.Close())
+
+	out, err := runScanStats(t, root, "scan-1", "--by-plugin")
+	require.NoError(t, err, out)
+	lines := strings.Split(out, "\n")
+	slow, fast := -1, -1
+	for i, line := range lines {
+		if strings.Contains(line, "Slow Plugin") {
+			slow = i
+			require.Contains(t, line, "75.0%")
+		}
+		if strings.Contains(line, "Fast Plugin") {
+			fast = i
+		}
+	}
+	require.True(t, slow >= 0 && fast > slow, out)
+	require.Contains(t, out, "Plugins took 400ms in total")
+
+	out, err = runScanStats(t, root, "scan-1", "--by-plugin", "--top", "1", "--output", "json")
+	require.NoError(t, err, out)
+	var result struct {
+		Plugins  int   `json:"plugins"`
+		Attempts int64 `json:"attempts"`
+		ByPlugin []struct {
+			PluginID string `json:"plugin_id"`
+		} `json:"by_plugin"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &result), out)
+	require.Equal(t, 2, result.Plugins)
+	require.EqualValues(t, 6, result.Attempts)
+	require.Len(t, result.ByPlugin, 1)
+	require.Equal(t, "slow", result.ByPlugin[0].PluginID)
+
+	out, err = runScanStats(t, root, "scan-2")
+	require.NoError(t, err, out)
+	require.Contains(t, out, "No plugin executions recorded for scan scan-2")
+
+	out, err = runScanStats(t, root, "missing")
+	require.NoError(t, err)
+	require.Contains(t, out, "Failed to load scan stats")
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// newScanSurfaceCommand returns the command that reports the attack
+// surface scores of the scanned hosts and target groups.
+func newScanSurfaceCommand() *cobra.Command {
+	var (
+		kind   string
+		tenant string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "surface [asset]",
+		Short: "Show the attack surface scores of hosts and target groups",
+		Long: `Show the attack surface score of each host and target group, as of the most
+recent completed scan that observed it, and how much it changed since the
+scan before.
+
+A host scores a weight per open port, a risk weight per service (telnet,
+RDP, SMB and exposed databases weigh most) and a weight per unpatched CVE by
+severity. Hosts with a public address or in a target group tagged
+internet-facing score twice as much. A target group scores the sum of its
+hosts. Replays, rechecks and failed scans are not scored.
+
+With an asset (an IP address or group name), its score history is listed
+instead, oldest first.`,
+		Example: `  # Latest scores of all hosts and groups
+  vulntor scan surface
+
+  # Only target groups
+  vulntor scan surface --kind group
+
+  # Score history of a group
+  vulntor scan surface prod-web`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if kind != "" && kind != storage.SurfaceHost && kind != storage.SurfaceGroup {
+				return fmt.Errorf("--kind must be host or group, got %q", kind)
+			}
+
+			formatter := format.FromCommand(cmd)
+			const operation = "load attack surface scores"
+			surfaces, err := loadSurfaces(cmd.Context(), tenant, kind)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, storage.ErrorCode(err))
+			}
+
+			if len(args) == 1 {
+				var asset *storage.AssetSurface
+				for _, a := range surfaces {
+					if a.Asset == args[0] {
+						asset = a
+						break // Groups come first
+					}
+				}
+				if asset == nil {
+					err := storage.NewNotFoundError("asset surface", args[0])
+					return formatter.PrintTotalFailureSummary(operation, err, storage.ErrorCode(err))
+				}
+				if formatter.IsStructured() {
+					return formatter.PrintStructured(asset)
+				}
+
+				rows := make([][]string, 0, len(asset.History))
+				for _, p := range asset.History {
+					rows = append(rows, surfaceRow(p))
+				}
+				return formatter.PrintTable([]string{"Scan", "Scored", "Score", "Hosts", "Internet-Facing", "Open Ports", "CVEs"}, rows)
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"assets": surfaces, "count": len(surfaces)})
+			}
+			if len(surfaces) == 0 {
+				return formatter.PrintSummary("No attack surface scores recorded yet.")
+			}
+
+			rows := make([][]string, 0, len(surfaces))
+			for _, a := range surfaces {
+				latest := a.Latest()
+				if latest == nil {
+					continue
+				}
+				row := surfaceRow(*latest)
+				rows = append(rows, append([]string{a.Kind, a.Asset, row[2], fmt.Sprintf("%+d", a.Change())}, row[3:]...))
+			}
+			return formatter.PrintTable([]string{"Kind", "Asset", "Score", "Change", "Hosts", "Internet-Facing", "Open Ports", "CVEs"}, rows)
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "", "Only hosts or only target groups (host or group)")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the scans")
+
+	return cmd
+}
+
+// surfaceRow returns the cells of a score: scan, time, score, hosts,
+// internet-facing hosts, open ports and CVEs.
+func surfaceRow(p storage.SurfacePoint) []string {
+	return []string{
+		p.ScanID,
+		p.At.Format(time.RFC3339),
+		fmt.Sprintf("%d", p.Score),
+		fmt.Sprintf("%d", p.Hosts),
+		fmt.Sprintf("%d", p.InternetFacing),
+		fmt.Sprintf("%d", p.OpenPorts),
+		fmt.Sprintf("%d", p.CVEs),
+	}
+}
+
+// loadSurfaces reads the attack surface scores of the assets of kind (all
+// when empty) from the workspace storage backend.
+func loadSurfaces(ctx context.Context, tenant, kind string) ([]*storage.AssetSurface, error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	surfaceBackend, ok := backend.(storage.SurfaceBackend)
+	if !ok {
+		return nil, fmt.Errorf("%w: storage backend does not keep attack surface scores", storage.ErrNotSupported)
+	}
+	surfaces, err := surfaceBackend.Surfaces().List(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*storage.AssetSurface, 0, len(surfaces))
+	for _, a := range surfaces {
+		if kind == "" || a.Kind == kind {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runScanSurface(t *testing.T, root string, args ...string) (string, error) {
+	t.Helper()
+	cmd := newScanSurfaceCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestScanSurfaceCommand(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, score := range []int{68, 46} {
+		point := storage.SurfacePoint{ScanID: []string{"scan-1", "scan-2"}[i], At: at.AddDate(0, 0, i), Score: score, Hosts: 1, InternetFacing: 1, OpenPorts: 2 - i, CVEs: 1}
+		require.NoError(t, backend.Surfaces().Record(ctx, storage.DefaultOrgID, []*storage.AssetSurface{
+			{Asset: "10.0.1.5", Kind: storage.SurfaceHost, History: []storage.SurfacePoint{point}},
+			{Asset: "dmz", Kind: storage.SurfaceGroup, History: []storage.SurfacePoint{point}},
+		}))
+	}
+	require.NoError(t, backend.Close())
+
+	out, err := runScanSurface(t, root)
+	require.NoError(t, err, out)
+	lines := strings.Split(out, "\n")
+	group, host := -1, -1
+	for i, line := range lines {
+		if strings.Contains(line, "dmz") {
+			group = i
+			require.Contains(t, line, "-22")
+		}
+		if strings.Contains(line, "10.0.1.5") {
+			host = i
+		}
+	}
+	require.True(t, group >= 0 && host > group, out)
+
+	out, err = runScanSurface(t, root, "--kind", "host", "--output", "json")
+	require.NoError(t, err, out)
+	var listed struct {
+		Assets []storage.AssetSurface `json:"assets"`
+		Count  int                    `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed), out)
+	require.Equal(t, 1, listed.Count)
+	require.Equal(t, "10.0.1.5", listed.Assets[0].Asset)
+
+	// An asset lists its history
+	out, err = runScanSurface(t, root, "dmz")
+	require.NoError(t, err, out)
+	require.Contains(t, out, "scan-1")
+	require.Contains(t, out, "2025-03-02T10:00:00Z")
+
+	out, err = runScanSurface(t, root, "10.9.9.9")
+	require.NoError(t, err)
+	require.Contains(t, out, "Failed to load attack surface scores")
+
+	_, err = runScanSurface(t, root, "--kind", "site")
+	require.ErrorContains(t, err, "--kind must be host or group")
+}
@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/topology"
+)
+
+// reverseDNSTimeout bounds each reverse DNS lookup of --reverse-dns.
+const reverseDNSTimeout = 2 * time.Second
+
+// newScanTopologyCommand returns the command that exports the service
+// relationship graph of a stored scan.
+func newScanTopologyCommand() *cobra.Command {
+	var (
+		graphFormat string
+		outputFile  string
+		reverseDNS  bool
+		tenant      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "topology <scan-id>",
+		Short: "Export the service relationship graph of a stored scan",
+		Long: `Export a graph of how the hosts of a stored scan relate to each other and to
+names outside the scan.
+
+Hosts are linked to the hosts or names their TLS certificates cover
+(tls-name) and that their HTTP redirects point to (redirect). Names are
+attributed to scanned hosts through the host names recorded by the scan
+and, with --reverse-dns, their reverse DNS (PTR) records.
+
+Formats:
+  json  Nodes (scanned hosts and external names) and edges.
+  dot   A Graphviz digraph, e.g. for 'dot -Tsvg topology.dot -o topology.svg'.`,
+		Example: `  # Render the topology as an SVG with Graphviz
+  vulntor scan topology 3f2a9c1e-... --format dot | dot -Tsvg -o topology.svg
+
+  # Export it as JSON, resolving the reverse DNS of the hosts
+  vulntor scan topology 3f2a9c1e-... --reverse-dns --output-file topology.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			err := runScanTopology(cmd.Context(), cmd.OutOrStdout(), args[0], graphFormat, outputFile, reverseDNS, tenant)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("export topology", err, storage.ErrorCode(err))
+			}
+			if outputFile != "" {
+				return formatter.PrintSummary(fmt.Sprintf("Topology written to %s", outputFile))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&graphFormat, "format", "json", "Graph format: json, dot")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Write the graph to a file (default: stdout)")
+	cmd.Flags().BoolVar(&reverseDNS, "reverse-dns", false, "Look up the reverse DNS names of the hosts")
+	cmd.Flags().StringVar(&tenant, "tenant", storage.DefaultOrgID, "Tenant that owns the scan")
+
+	return cmd
+}
+
+func runScanTopology(ctx context.Context, stdout io.Writer, scanID, graphFormat, outputFile string, reverseDNS bool, tenant string) error {
+	var write func(*topology.Graph, io.Writer) error
+	switch strings.ToLower(graphFormat) {
+	case "json":
+		write = (*topology.Graph).WriteJSON
+	case "dot":
+		write = (*topology.Graph).WriteDOT
+	default:
+		return storage.NewInvalidInputError("format", fmt.Sprintf("unsupported graph format %q (must be json or dot)", graphFormat))
+	}
+
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}()
+
+	data, err := report.Load(ctx, backend.Scans(), tenant, scanID)
+	if err != nil {
+		return err
+	}
+
+	var opts topology.Options
+	if reverseDNS {
+		opts.LookupAddr = func(ctx context.Context, addr string) ([]string, error) {
+			ctx, cancel := context.WithTimeout(ctx, reverseDNSTimeout)
+			defer cancel()
+			return net.DefaultResolver.LookupAddr(ctx, addr)
+		}
+	}
+	graph := topology.Build(ctx, data.Hosts, opts)
+
+	if outputFile == "" {
+		return write(graph, stdout)
+	}
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("create topology file: %w", err)
+	}
+	if err := write(graph, f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
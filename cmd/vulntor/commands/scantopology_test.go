@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/topology"
+)
+
+func runScanTopologyCommand(t *testing.T, root string, args ...string) (string, error) {
+	t.Helper()
+	cmd := newScanTopologyCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	err := cmd.ExecuteContext(ctx)
+	return out.String(), err
+}
+
+func TestScanTopologyCommand(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{
+		ID: "scan-1", Target: "10.0.0.0/24", Status: "completed", StartedAt: time.Now(),
+	}))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeHosts, strings.NewReader(
+		`{"ip":"10.0.0.1","target":"10.0.0.0/24","ports":[{"port":80,"protocol":"tcp","redirect":"https://sso.example.com/"}],"vulnerabilities":0}`+"\n"+
+			`{"ip":"10.0.0.2","target":"10.0.0.0/24","hostnames":["sso.example.com"],"ports":[{"port":443,"protocol":"tcp"}],"vulnerabilities":0}`+"\n")))
+	require.NoError(t, backend.Close())
+
+	out, err := runScanTopologyCommand(t, root, "scan-1")
+	require.NoError(t, err, out)
+	var graph topology.Graph
+	require.NoError(t, json.Unmarshal([]byte(out), &graph), out)
+	require.Len(t, graph.Nodes, 2)
+	require.Equal(t, []topology.Edge{
+		{From: "10.0.0.1", To: "10.0.0.2", Relation: topology.RelationRedirect, Port: 80, Name: "sso.example.com"},
+	}, graph.Edges)
+
+	file := filepath.Join(t.TempDir(), "topology.dot")
+	out, err = runScanTopologyCommand(t, root, "scan-1", "--format", "dot", "--output-file", file)
+	require.NoError(t, err, out)
+	require.Contains(t, out, "Topology written to "+file)
+	dot, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Contains(t, string(dot), `"10.0.0.1" -> "10.0.0.2" [label="redirect :80\nsso.example.com"];`)
+
+	out, err = runScanTopologyCommand(t, root, "scan-1", "--format", "svg")
+	require.NoError(t, err)
+	require.Contains(t, out, "unsupported graph format")
+
+	out, err = runScanTopologyCommand(t, root, "missing")
+	require.NoError(t, err)
+	require.Contains(t, out, "Failed to export topology")
+}
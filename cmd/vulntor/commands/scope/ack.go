@@ -0,0 +1,102 @@
+package scope
+
+import (
+	"fmt"
+	"os/user"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/scope"
+)
+
+func newAckCommand() *cobra.Command {
+	var (
+		targets []string
+		reason  string
+		by      string
+		expires time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "ack",
+		Short: "Sign an acknowledgment approving targets outside the scan scope",
+		Long: `Sign an acknowledgment approving scans of targets outside the scan scope,
+with the key of scope.override_key.
+
+The acknowledgment names the approved targets (IP addresses, CIDRs,
+ranges or hostnames), why they may be scanned and who approved them, and
+expires after --expires. Pass the printed token to scans with
+--scope-ack, or as scope_ack to the server API. A scan target is covered
+when it is listed or lies within the listed addresses. Signing is
+recorded in the audit log.`,
+		Example: `  TOKEN=$(vulntor scope ack --target 203.0.113.0/24 --reason "CHG-1234 acquired range" --by alice --quiet)
+  vulntor scan 203.0.113.0/24 --scope-ack "$TOKEN"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			cfg := scopeConfig(cmd)
+			p, err := scope.New(cfg.Allow, cfg.Deny, cfg.OverrideKey)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("sign acknowledgment", err, format.ErrorCode(err))
+			}
+			key, err := p.Key(cmd.Context())
+			if err != nil {
+				wrapped := format.WithErrorCode(err, errorCodeOverrideDisabled)
+				return formatter.PrintTotalFailureSummary("sign acknowledgment", wrapped, format.ErrorCode(wrapped))
+			}
+
+			if by == "" {
+				if u, err := user.Current(); err == nil {
+					by = u.Username
+				}
+			}
+			ack := scope.Acknowledgment{
+				Targets: targets,
+				Reason:  reason,
+				By:      by,
+				Expires: time.Now().Add(expires),
+			}
+			var token string
+			if expires <= 0 {
+				err = fmt.Errorf("--expires must be positive, got %s", expires)
+			} else {
+				token, err = scope.Sign(key, ack)
+			}
+			audit.RecordCLI(cmd.Context(), "scope.ack", strings.Join(targets, ","), err, map[string]string{
+				"reason": reason,
+				"by":     by,
+			})
+			if err != nil {
+				wrapped := format.WithErrorCode(err, errorCodeInvalidAck)
+				return formatter.PrintTotalFailureSummary("sign acknowledgment", wrapped, format.ErrorCode(wrapped))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{
+					"token":   token,
+					"targets": targets,
+					"reason":  reason,
+					"by":      by,
+					"expires": ack.Expires.UTC().Truncate(time.Second),
+				})
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), token)
+			return formatter.PrintSummary(fmt.Sprintf("✓ Acknowledgment for %d target(s) by %s, expires %s",
+				len(targets), by, ack.Expires.UTC().Format("2006-01-02 15:04 MST")))
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&targets, "target", nil, "Target approved for scanning (repeatable, required)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why scanning the targets is authorized, e.g. a change ticket (required)")
+	cmd.Flags().StringVar(&by, "by", "", "Who approved the scan (default: the current OS user)")
+	cmd.Flags().DurationVar(&expires, "expires", 24*time.Hour, "How long the acknowledgment is valid")
+	_ = cmd.MarkFlagRequired("target")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+package scope
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+)
+
+func newCheckCommand() *cobra.Command {
+	var ack string
+
+	cmd := &cobra.Command{
+		Use:   "check <target>...",
+		Short: "Check targets against the scan scope",
+		Long: `Check whether scans of the targets would be permitted by the scan scope,
+without sending any packet to them. Hostnames are checked by the addresses
+they resolve to.
+
+With --scope-ack, targets outside the scope pass when the acknowledgment
+approves them, as they would in a scan.`,
+		Example: `  vulntor scope check 10.0.0.0/24 intranet.example.com
+  vulntor scope check 203.0.113.0/24 --scope-ack "$TOKEN"`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			p, err := policy(cmd)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("check scope", err, format.ErrorCode(err))
+			}
+
+			acknowledged, err := p.Check(cmd.Context(), args, ack)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("check scope", err, scanexec.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				out := map[string]any{"targets": args, "permitted": true}
+				if acknowledged != nil {
+					out["acknowledgment"] = acknowledged
+				}
+				return formatter.PrintStructured(out)
+			}
+			if acknowledged != nil {
+				return formatter.PrintSummary(fmt.Sprintf("✓ %d target(s) permitted; those outside the scope by the acknowledgment of %s (%s), expiring %s",
+					len(args), acknowledged.By, acknowledged.Reason, acknowledged.Expires.UTC().Format("2006-01-02 15:04 MST")))
+			}
+			if p == nil {
+				return formatter.PrintSummary(fmt.Sprintf("✓ %d target(s) permitted; no scan scope is configured", len(args)))
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ %d target(s) within the scan scope", len(args)))
+		},
+	}
+
+	cmd.Flags().StringVar(&ack, "scope-ack", "", "Signed acknowledgment approving targets outside the scan scope")
+
+	return cmd
+}
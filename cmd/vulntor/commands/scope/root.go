@@ -0,0 +1,69 @@
+// Package scope provides CLI commands for the scan scope: checking targets
+// against it and signing acknowledgments that approve targets outside it.
+package scope
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/scope"
+)
+
+// Error codes of the scope commands.
+const (
+	errorCodeOverrideDisabled = "SCOPE_OVERRIDE_DISABLED"
+	errorCodeInvalidAck       = "SCOPE_INVALID_ACK"
+)
+
+// NewCommand creates the 'vulntor scope' command group.
+//
+// The scan scope lists the address ranges scans may target (scope.allow)
+// and those they never target (scope.deny). Scans with targets outside it
+// are refused unless an acknowledgment signed with scope.override_key
+// approves them.
+//
+// Example usage:
+//
+//	vulntor scope check 10.0.0.0/24 203.0.113.5
+//	vulntor scope ack --target 203.0.113.0/24 --reason "CHG-1234" --by alice
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scope",
+		Short: "Check targets against the scan scope and approve overrides",
+		Long: `Check targets against the scan scope and sign acknowledgments approving
+targets outside it.
+
+The scan scope is configured in the scope section: scope.allow lists the
+CIDRs scans may target (any address without it) and scope.deny those they
+never target. Scans, from the CLI and the server API, are refused before
+sending any packet when a target lies outside the scope.
+
+To scan outside the scope, a holder of scope.override_key signs an
+acknowledgment naming the targets, the reason and the approver, valid
+for a limited time, and the scan is started with --scope-ack <token>.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCheckCommand())
+	cmd.AddCommand(newAckCommand())
+
+	return cmd
+}
+
+// scopeConfig returns the scope section of the loaded config.
+func scopeConfig(cmd *cobra.Command) config.ScopeConfig {
+	if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+		return cfgMgr.Get().Scope
+	}
+	return config.ScopeConfig{}
+}
+
+// policy returns the configured scan scope, or nil when no ranges are
+// allowed or denied.
+func policy(cmd *cobra.Command) (*scope.Policy, error) {
+	cfg := scopeConfig(cmd)
+	return cfg.Policy()
+}
@@ -0,0 +1,97 @@
+package scope
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func runScopeCommand(t *testing.T, ctx context.Context, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
+
+func scopeContext(t *testing.T, configYAML string) context.Context {
+	t.Helper()
+	root := t.TempDir()
+	configFile := filepath.Join(root, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configYAML), 0o644))
+	cfgMgr := config.NewManager()
+	require.NoError(t, cfgMgr.LoadWithSources([]config.ConfigSource{&config.FileSource{Path: configFile}}))
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	return appctx.WithConfig(ctx, cfgMgr)
+}
+
+func TestScopeCommand_AckAndCheck(t *testing.T) {
+	t.Setenv("VULNTOR_TEST_SCOPE_KEY", "s3cret")
+	ctx := scopeContext(t, "scope:\n  allow: [10.0.0.0/8]\n  deny: [10.99.0.0/16]\n  override_key: env:VULNTOR_TEST_SCOPE_KEY\n")
+
+	require.Contains(t, runScopeCommand(t, ctx, "check", "10.1.0.0/16", "10.2.3.4"), "2 target(s) within the scan scope")
+
+	out := runScopeCommand(t, ctx, "check", "10.99.1.1", "--output", "json")
+	require.Contains(t, out, "TARGET_OUT_OF_SCOPE")
+	require.Contains(t, out, "denied by 10.99.0.0/16")
+
+	out = runScopeCommand(t, ctx, "ack", "--target", "203.0.113.0/24", "--reason", "CHG-1234", "--by", "alice", "--output", "json")
+	var signed struct {
+		Token string `json:"token"`
+		By    string `json:"by"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &signed))
+	require.Equal(t, "alice", signed.By)
+
+	out = runScopeCommand(t, ctx, "check", "203.0.113.7", "--scope-ack", signed.Token)
+	require.Contains(t, out, "by the acknowledgment of alice (CHG-1234)")
+
+	// The acknowledgment does not reach beyond its targets
+	out = runScopeCommand(t, ctx, "check", "198.51.100.1", "--scope-ack", signed.Token)
+	require.Contains(t, out, "acknowledgment does not cover these targets")
+
+	// Plain output prints the token alone on the first line
+	out = runScopeCommand(t, ctx, "ack", "--target", "203.0.113.5", "--reason", "pentest", "--by", "bob")
+	require.Contains(t, strings.SplitN(out, "\n", 2)[0], ".")
+	require.Contains(t, out, "Acknowledgment for 1 target(s) by bob")
+
+	out = runScopeCommand(t, ctx, "ack", "--target", "203.0.113.5", "--reason", "pentest", "--expires", "0s", "--output", "json")
+	require.Contains(t, out, "SCOPE_INVALID_ACK")
+
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: storageRoot(t, ctx)})
+	require.NoError(t, err)
+	events, err := backend.Audit().List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "scope"})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+}
+
+func TestScopeCommand_WithoutScope(t *testing.T) {
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+
+	require.Contains(t, runScopeCommand(t, ctx, "check", "203.0.113.5"), "no scan scope is configured")
+
+	out := runScopeCommand(t, ctx, "ack", "--target", "203.0.113.5", "--reason", "pentest", "--output", "json")
+	require.Contains(t, out, "SCOPE_OVERRIDE_DISABLED")
+}
+
+func storageRoot(t *testing.T, ctx context.Context) string {
+	t.Helper()
+	cfg, ok := storage.ConfigFromContext(ctx)
+	require.True(t, ok)
+	return cfg.WorkspaceRoot
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	serversvc "github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// newAPIKeyCommand creates the 'vulntor server apikey' command group.
+//
+// API keys authenticate REST API requests when the server runs with
+// --auth-mode apikey. Each key carries scopes that limit which endpoints
+// it can reach.
+func newAPIKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Manage server API keys",
+		Long: `Manage API keys used to authenticate against the Vulntor server.
+
+Keys are stored hashed in the workspace and only take effect when the server
+is started with --auth-mode apikey. Available scopes:
+  read           Read scans, findings and plugins
+  scan:create    Submit scans
+  finding:manage Triage findings
+  plugin:manage  Install, update and remove plugins
+  admin          All of the above`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	addTenantFlag(cmd)
+
+	cmd.AddCommand(newAPIKeyCreateCommand())
+	cmd.AddCommand(newAPIKeyListCommand())
+	cmd.AddCommand(newAPIKeyRevokeCommand())
+
+	return cmd
+}
+
+func newAPIKeyCreateCommand() *cobra.Command {
+	var (
+		name       string
+		scopes     []string
+		userID     string
+		rateLimit  int
+		dailyScans int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new API key",
+		Example: `  # Read-only key for a dashboard
+  vulntor server apikey create --name dashboard --scopes read
+
+  # Key for a CI pipeline that submits scans
+  vulntor server apikey create --name ci --scopes read,scan:create
+
+  # Key owned by a user; the user's role caps its scopes
+  vulntor server apikey create --name laptop --user alice
+
+  # Automation key with its own API limits
+  vulntor server apikey create --name nightly --scopes read,scan:create --rate-limit 30 --daily-scans 10`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			// User-owned keys inherit the user's role unless scopes are narrowed explicitly
+			if userID != "" && !cmd.Flags().Changed("scopes") {
+				scopes = []string{string(auth.ScopeAdmin)}
+			}
+
+			parsed, err := auth.ParseScopes(scopes)
+			if err != nil {
+				wrapped := serversvc.WrapInvalidAPIKey(err)
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			if rateLimit < 0 || dailyScans < 0 {
+				wrapped := serversvc.WrapInvalidAPIKey(fmt.Errorf("--rate-limit and --daily-scans must not be negative"))
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			token, key, err := auth.NewAPIKey(name, parsed)
+			if err != nil {
+				wrapped := serversvc.WrapInvalidAPIKey(err)
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			key.RateLimit = rateLimit
+			key.DailyScans = dailyScans
+
+			if userID != "" {
+				users, closeUsers, err := openUserStore(cmd.Context())
+				if err != nil {
+					wrapped := serversvc.WrapUserStore(err)
+					return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+				}
+				_, err = users.Get(cmd.Context(), orgID, userID)
+				closeUsers()
+				if err != nil {
+					wrapped := serversvc.WrapUserStore(err)
+					return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+				}
+				key.UserID = userID
+			}
+
+			store, closeFn, err := openAPIKeyStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			err = store.Create(cmd.Context(), orgID, key)
+			audit.RecordCLI(storage.WithOrgID(cmd.Context(), orgID), "apikey.create", key.ID, err, map[string]string{
+				"name":   key.Name,
+				"scopes": strings.Join(key.Scopes, ","),
+			})
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("create api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.apikey").
+				Str("key_id", key.ID).
+				Strs("scopes", key.Scopes).
+				Msg("API key created")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{
+					"id":          key.ID,
+					"name":        key.Name,
+					"scopes":      key.Scopes,
+					"user_id":     key.UserID,
+					"rate_limit":  key.RateLimit,
+					"daily_scans": key.DailyScans,
+					"created_at":  key.CreatedAt,
+					"token":       token,
+				})
+			}
+
+			if err := formatter.PrintTable(
+				[]string{"ID", "Name", "Scopes", "Token"},
+				[][]string{{key.ID, key.Name, strings.Join(key.Scopes, ","), token}},
+			); err != nil {
+				return err
+			}
+			return formatter.PrintSummary("Store this token now; it cannot be shown again.")
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Human-readable key name (required)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{string(auth.ScopeRead)}, "Comma-separated scopes: read, scan:create, finding:manage, plugin:manage, admin")
+	cmd.Flags().StringVar(&userID, "user", "", "Owning user; the user's role caps the key's scopes")
+	cmd.Flags().IntVar(&rateLimit, "rate-limit", 0, "API requests per minute of the key (0 = server.rate_limit setting)")
+	cmd.Flags().IntVar(&dailyScans, "daily-scans", 0, "Scans the key may submit per day (0 = server.rate_limit setting)")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func newAPIKeyListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List API keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("list api keys", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			store, closeFn, err := openAPIKeyStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("list api keys", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			keys, err := store.List(cmd.Context(), orgID)
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("list api keys", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			if formatter.IsStructured() {
+				// Hashes are never exposed
+				out := make([]map[string]any, 0, len(keys))
+				for _, k := range keys {
+					out = append(out, map[string]any{
+						"id":         k.ID,
+						"name":       k.Name,
+						"scopes":     k.Scopes,
+						"user_id":    k.UserID,
+						"created_at": k.CreatedAt,
+						"revoked_at": k.RevokedAt,
+					})
+				}
+				return formatter.PrintStructured(map[string]any{"keys": out, "count": len(out)})
+			}
+
+			if len(keys) == 0 {
+				return formatter.PrintSummary("No API keys found.")
+			}
+
+			rows := make([][]string, 0, len(keys))
+			for _, k := range keys {
+				status := "active"
+				if k.IsRevoked() {
+					status = "revoked"
+				}
+				user := k.UserID
+				if user == "" {
+					user = "-"
+				}
+				rows = append(rows, []string{k.ID, k.Name, user, strings.Join(k.Scopes, ","), k.CreatedAt.Format(time.RFC3339), status})
+			}
+			if err := formatter.PrintTable([]string{"ID", "Name", "User", "Scopes", "Created", "Status"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d API key(s)", len(keys)))
+		},
+	}
+}
+
+func newAPIKeyRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke <key-id>",
+		Short:   "Revoke an API key",
+		Example: `  vulntor server apikey revoke 3f9a1c2b7d4e`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("revoke api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			keyID := args[0]
+
+			store, closeFn, err := openAPIKeyStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("revoke api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			err = store.Revoke(cmd.Context(), orgID, keyID)
+			audit.RecordCLI(storage.WithOrgID(cmd.Context(), orgID), "apikey.revoke", keyID, err, nil)
+			if err != nil {
+				wrapped := serversvc.WrapAPIKeyStore(err)
+				return formatter.PrintTotalFailureSummary("revoke api key", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.apikey").
+				Str("key_id", keyID).
+				Msg("API key revoked")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"id": keyID, "revoked": true})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ API key %s revoked", keyID))
+		},
+	}
+}
+
+// openAPIKeyStore opens the workspace storage backend and returns its API key store.
+func openAPIKeyStore(ctx context.Context) (storage.APIKeyStore, func(), error) {
+	backend, closeFn, err := openAuthBackend(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyBackend, ok := backend.(storage.APIKeyBackend)
+	if !ok {
+		closeFn()
+		return nil, nil, fmt.Errorf("%w: storage backend does not support API keys", storage.ErrNotSupported)
+	}
+	return keyBackend.APIKeys(), closeFn, nil
+}
+
+// openAuthBackend opens the workspace storage backend used by the server.
+func openAuthBackend(ctx context.Context) (storage.Backend, func(), error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		cfg, err = storage.DefaultConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeFn := func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}
+	return backend, closeFn, nil
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestAPIKeyCommand_CreateListRevoke(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newAPIKeyCommand, "create", "--name", "ci", "--scopes", "read,scan:create", "--output", "json")
+	var created struct {
+		ID     string   `json:"id"`
+		Token  string   `json:"token"`
+		Scopes []string `json:"scopes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	require.NotEmpty(t, created.ID)
+	require.Contains(t, created.Token, "vnt_"+created.ID+"_")
+	require.Equal(t, []string{"read", "scan:create"}, created.Scopes)
+
+	out = runCommand(t, root, newAPIKeyCommand, "list")
+	require.Contains(t, out, created.ID)
+	require.Contains(t, out, "active")
+	require.NotContains(t, out, created.Token)
+
+	out = runCommand(t, root, newAPIKeyCommand, "revoke", created.ID)
+	require.Contains(t, out, "revoked")
+
+	out = runCommand(t, root, newAPIKeyCommand, "list")
+	require.Contains(t, out, "revoked")
+}
+
+func TestAPIKeyCommand_InvalidScope(t *testing.T) {
+	out := runCommand(t, t.TempDir(), newAPIKeyCommand, "create", "--name", "ci", "--scopes", "write")
+	require.Contains(t, out, "✗ Failed to create api key")
+	require.Contains(t, out, `unknown scope "write"`)
+}
+
+func TestAPIKeyCommand_RevokeUnknown(t *testing.T) {
+	out := runCommand(t, t.TempDir(), newAPIKeyCommand, "revoke", "missing")
+	require.Contains(t, out, "✗ Failed to revoke api key")
+	require.Contains(t, out, "vulntor server apikey list")
+}
+
+func TestAPIKeyCommand_Limits(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newAPIKeyCommand, "create", "--name", "nightly", "--scopes", "read,scan:create",
+		"--rate-limit", "30", "--daily-scans", "10", "--output", "json")
+	var created struct {
+		ID         string `json:"id"`
+		RateLimit  int    `json:"rate_limit"`
+		DailyScans int    `json:"daily_scans"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	require.Equal(t, 30, created.RateLimit)
+	require.Equal(t, 10, created.DailyScans)
+
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	key, err := backend.APIKeys().Get(context.Background(), storage.DefaultOrgID, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, 30, key.RateLimit)
+	require.Equal(t, 10, key.DailyScans)
+
+	out = runCommand(t, root, newAPIKeyCommand, "create", "--name", "bad", "--daily-scans", "-1")
+	require.Contains(t, out, "must not be negative")
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	serversvc "github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/server/certs"
+)
+
+// newCertCommand creates the 'vulntor server cert' command group.
+//
+// The commands create a development CA and issue server and client
+// certificates for trying HTTPS and mutual TLS without an existing PKI.
+func newCertCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cert",
+		Short: "Create development TLS certificates",
+		Long: `Create a development certificate authority and issue server and client
+certificates signed by it.
+
+Use them to try HTTPS and mutual TLS with 'vulntor server start --tls-cert
+--tls-key --tls-client-ca', in test and lab deployments. Production
+deployments should use certificates of their PKI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCertCACommand())
+	cmd.AddCommand(newCertIssueCommand())
+
+	return cmd
+}
+
+func newCertCACommand() *cobra.Command {
+	var (
+		outDir string
+		name   string
+		days   int
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "ca",
+		Short:   "Create a development CA",
+		Example: `  vulntor server cert ca --out-dir ./certs`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			_, issued, err := certs.NewCA(name, time.Duration(days)*24*time.Hour)
+			if err != nil {
+				wrapped := serversvc.WrapCert(err)
+				return formatter.PrintTotalFailureSummary("create CA", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			certPath, keyPath, err := certs.WriteFiles(outDir, "ca", issued, force)
+			if err != nil {
+				wrapped := serversvc.WrapCert(err)
+				return formatter.PrintTotalFailureSummary("create CA", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			return printIssued(formatter, "CA", certPath, keyPath, issued)
+		},
+	}
+
+	cmd.Flags().StringVar(&outDir, "out-dir", ".", "Directory to write ca.crt and ca.key to")
+	cmd.Flags().StringVar(&name, "name", "Vulntor Development CA", "Common name of the CA")
+	cmd.Flags().IntVar(&days, "days", 365, "Validity in days")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace existing files")
+
+	return cmd
+}
+
+func newCertIssueCommand() *cobra.Command {
+	var (
+		caDir  string
+		outDir string
+		dns    []string
+		ips    []string
+		client bool
+		days   int
+		force  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "issue <name>",
+		Short: "Issue a server or client certificate",
+		Long: `Issue a certificate signed by the development CA, written to <name>.crt
+and <name>.key.
+
+Server certificates are valid for the --dns and --ip names, or for
+localhost without them. Client certificates (--client) identify API
+clients to servers started with --tls-client-ca.`,
+		Example: `  # Server certificate
+  vulntor server cert issue server --ca-dir ./certs --out-dir ./certs --dns vulntor.internal --ip 10.0.0.5
+
+  # Client certificate of a CI runner
+  vulntor server cert issue ci-runner --client --ca-dir ./certs --out-dir ./certs`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			req := certs.Request{
+				CommonName: args[0],
+				DNSNames:   dns,
+				Client:     client,
+				Validity:   time.Duration(days) * 24 * time.Hour,
+			}
+			for _, raw := range ips {
+				ip := net.ParseIP(raw)
+				if ip == nil {
+					wrapped := serversvc.WrapCert(fmt.Errorf("invalid --ip %q", raw))
+					return formatter.PrintTotalFailureSummary("issue certificate", wrapped, serversvc.ErrorCode(wrapped))
+				}
+				req.IPs = append(req.IPs, ip)
+			}
+
+			ca, err := certs.LoadCA(caDir)
+			if err != nil {
+				wrapped := serversvc.WrapCert(err)
+				return formatter.PrintTotalFailureSummary("issue certificate", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			issued, err := ca.Issue(req)
+			if err != nil {
+				wrapped := serversvc.WrapCert(err)
+				return formatter.PrintTotalFailureSummary("issue certificate", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			certPath, keyPath, err := certs.WriteFiles(outDir, args[0], issued, force)
+			if err != nil {
+				wrapped := serversvc.WrapCert(err)
+				return formatter.PrintTotalFailureSummary("issue certificate", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			kind := "Server certificate"
+			if client {
+				kind = "Client certificate"
+			}
+			return printIssued(formatter, kind, certPath, keyPath, issued)
+		},
+	}
+
+	cmd.Flags().StringVar(&caDir, "ca-dir", ".", "Directory holding ca.crt and ca.key")
+	cmd.Flags().StringVar(&outDir, "out-dir", ".", "Directory to write the certificate and key to")
+	cmd.Flags().StringArrayVar(&dns, "dns", nil, "DNS name of the server (repeatable)")
+	cmd.Flags().StringArrayVar(&ips, "ip", nil, "IP address of the server (repeatable)")
+	cmd.Flags().BoolVar(&client, "client", false, "Issue a client certificate")
+	cmd.Flags().IntVar(&days, "days", 90, "Validity in days (capped at the CA's)")
+	cmd.Flags().BoolVar(&force, "force", false, "Replace existing files")
+
+	return cmd
+}
+
+func printIssued(formatter format.Formatter, kind, certPath, keyPath string, issued *certs.Issued) error {
+	if formatter.IsStructured() {
+		return formatter.PrintStructured(map[string]any{
+			"subject":     issued.Cert.Subject.CommonName,
+			"cert":        certPath,
+			"key":         keyPath,
+			"not_after":   issued.Cert.NotAfter.UTC(),
+			"fingerprint": issued.Fingerprint(),
+		})
+	}
+	return formatter.PrintSummary(fmt.Sprintf("✓ %s %s written to %s (key: %s, expires %s)",
+		kind, issued.Cert.Subject.CommonName, certPath, keyPath, issued.Cert.NotAfter.UTC().Format("2006-01-02")))
+}
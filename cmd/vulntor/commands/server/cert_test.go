@@ -0,0 +1,73 @@
+package server
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/certs"
+)
+
+func TestCertCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	out := runCommand(t, "", newCertCommand, "ca", "--out-dir", dir)
+	require.Contains(t, out, "CA Vulntor Development CA written to "+filepath.Join(dir, "ca.crt"))
+
+	out = runCommand(t, "", newCertCommand, "issue", "server", "--ca-dir", dir, "--out-dir", dir, "--dns", "vulntor.internal", "--ip", "10.0.0.5")
+	require.Contains(t, out, "Server certificate server written")
+
+	out = runCommand(t, "", newCertCommand, "issue", "ci-runner", "--client", "--ca-dir", dir, "--out-dir", dir, "--output", "json")
+	var issued struct {
+		Subject     string `json:"subject"`
+		Cert        string `json:"cert"`
+		Fingerprint string `json:"fingerprint"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &issued))
+	require.Equal(t, "ci-runner", issued.Subject)
+	require.Len(t, issued.Fingerprint, 64)
+
+	// Both leaves verify against the CA
+	ca, err := certs.LoadCA(dir)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+	server := readCert(t, filepath.Join(dir, "server.crt"))
+	_, err = server.Verify(x509.VerifyOptions{Roots: roots, DNSName: "vulntor.internal"})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.5", server.IPAddresses[0].String())
+	client := readCert(t, issued.Cert)
+	_, err = client.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+}
+
+func TestCertCommand_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	out := runCommand(t, "", newCertCommand, "issue", "server", "--ca-dir", dir, "--out-dir", dir)
+	require.Contains(t, out, "✗ Failed to issue certificate")
+	require.Contains(t, out, "vulntor server cert ca")
+
+	runCommand(t, "", newCertCommand, "ca", "--out-dir", dir)
+	out = runCommand(t, "", newCertCommand, "ca", "--out-dir", dir)
+	require.Contains(t, out, "already exists")
+
+	out = runCommand(t, "", newCertCommand, "issue", "server", "--ca-dir", dir, "--out-dir", dir, "--ip", "not-an-ip")
+	require.Contains(t, out, `invalid --ip "not-an-ip"`)
+}
+
+func readCert(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/server/httpx"
+)
+
+// newOpenAPICommand creates the 'vulntor server openapi' command.
+func newOpenAPICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Print the OpenAPI document of the server API",
+		Long: `Print the OpenAPI 3 document describing the REST API of the Vulntor server.
+
+The document is generated from the server's routes and the Go types of their
+requests and responses. Running servers also serve it at /api/openapi.json,
+with an interactive reference at /api/docs.
+
+Use it to generate API clients.`,
+		Example: `  # Save the document
+  vulntor server openapi > vulntor-openapi.json
+
+  # Generate a Python client
+  openapi-generator-cli generate -i vulntor-openapi.json -g python -o vulntor-client`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := json.MarshalIndent(httpx.OpenAPIDocument(), "", "  ")
+			if err != nil {
+				return fmt.Errorf("encode OpenAPI document: %w", err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			return err
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPICommand(t *testing.T) {
+	cmd := newOpenAPICommand()
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetArgs(nil)
+	require.NoError(t, cmd.Execute())
+
+	var doc struct {
+		OpenAPI string                    `json:"openapi"`
+		Paths   map[string]map[string]any `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Contains(t, doc.Paths, "/api/v1/scans/{id}/findings")
+}
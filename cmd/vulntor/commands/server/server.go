@@ -19,6 +19,11 @@ func NewCommand() *cobra.Command {
 
 	// Subcommands
 	command.AddCommand(newStartServerCommand())
+	command.AddCommand(newAPIKeyCommand())
+	command.AddCommand(newUserCommand())
+	command.AddCommand(newTenantCommand())
+	command.AddCommand(newOpenAPICommand())
+	command.AddCommand(newCertCommand())
 
 	return command
 }
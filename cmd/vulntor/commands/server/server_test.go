@@ -0,0 +1,31 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// runCommand runs the command newCmd builds with args and returns its
+// output. A non-empty root is used as the storage workspace.
+func runCommand(t *testing.T, root string, newCmd func() *cobra.Command, args ...string) string {
+	t.Helper()
+	cmd := newCmd()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	ctx := context.Background()
+	if root != "" {
+		ctx = storage.WithConfig(ctx, &storage.Config{WorkspaceRoot: root})
+	}
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
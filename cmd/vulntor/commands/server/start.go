@@ -20,6 +20,8 @@ import (
 	"github.com/vulntor/vulntor/pkg/server/api"
 	"github.com/vulntor/vulntor/pkg/server/app"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+	"github.com/vulntor/vulntor/pkg/wordlist"
 )
 
 // stubWorkspace is a temporary workspace implementation.
@@ -38,6 +40,7 @@ func (s *stubWorkspace) GetScan(id string) (*api.ScanDetail, error) {
 //
 // This command initializes the Vulntor server runtime, which includes:
 //   - HTTP API server with REST endpoints (/api/v1/scans, etc.)
+//   - gRPC API mirroring the REST endpoints (with --grpc-port)
 //   - Static UI asset serving (/ui/*)
 //   - Health and readiness endpoints (/healthz, /readyz)
 //   - Background job workers (scan execution, scheduling, notifications)
@@ -56,6 +59,14 @@ func (s *stubWorkspace) GetScan(id string) (*api.ScanDetail, error) {
 //	vulntor server start
 //	vulntor server start --addr 0.0.0.0 --port 8080
 //	vulntor server start --workspace-dir /data/vulntor --jobs-concurrency 10
+//	vulntor server start --auth-mode apikey
+//	vulntor server start --grpc-port 9090
+//
+//	# Single sign-on (settings in the server.auth.oidc config block)
+//	vulntor server start --auth-mode oidc
+//
+//	# HTTPS requiring client certificates (see 'vulntor server cert')
+//	vulntor server start --tls-cert server.crt --tls-key server.key --tls-client-ca ca.crt
 //
 // See NOTES.md#30 for detailed server architecture design.
 func newStartServerCommand() *cobra.Command {
@@ -66,6 +77,7 @@ func newStartServerCommand() *cobra.Command {
 
 The server hosts multiple components in a single runtime:
   - HTTP API (REST endpoints for scan management and workspace queries)
+  - gRPC API mirroring the REST endpoints, on --grpc-port (disabled by default)
   - Web UI (static SPA with client-side routing)
   - Background workers (job queue, scheduler, notifier)
 
@@ -94,16 +106,11 @@ shutdown to drain in-flight requests and complete running jobs.`,
 					AssetsPath: opts.UIAssetsPath,
 				},
 				Auth: config.AuthConfig{
-					Mode: "none", // TODO: Add auth flags when auth system is implemented
+					Mode:  opts.AuthMode,
+					Token: opts.AuthToken,
 				},
 			}
 
-			// Validate configuration
-			if err := cfg.Validate(); err != nil {
-				wrapped := serversvc.WrapInvalidConfig(err)
-				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
-			}
-
 			// Get config manager from context
 			cfgMgr, ok := appctx.Config(cmd.Context())
 			if !ok {
@@ -111,6 +118,37 @@ shutdown to drain in-flight requests and complete running jobs.`,
 				return formatter.PrintTotalFailureSummary("start server", err, serversvc.ErrorCode(err))
 			}
 
+			// OIDC settings come from the server.auth.oidc config block
+			cfg.Auth.OIDC = cfgMgr.Get().Server.Auth.OIDC
+			// Job queue limits come from the server.queue config block
+			cfg.Queue = cfgMgr.Get().Server.Queue
+			// Leader election comes from the server.ha config block
+			cfg.HA = cfgMgr.Get().Server.HA
+			// API limits come from the server.rate_limit config block
+			cfg.RateLimit = cfgMgr.Get().Server.RateLimit
+			// The gRPC API listens on server.grpc_port; the flag takes precedence
+			cfg.GRPCPort = cfgMgr.Get().Server.GRPCPort
+			if opts.GRPCPort != 0 {
+				cfg.GRPCPort = opts.GRPCPort
+			}
+			// TLS comes from the server.tls config block; flags take precedence
+			cfg.TLS = cfgMgr.Get().Server.TLS
+			if opts.TLSCert != "" {
+				cfg.TLS.CertFile = opts.TLSCert
+			}
+			if opts.TLSKey != "" {
+				cfg.TLS.KeyFile = opts.TLSKey
+			}
+			if opts.TLSClientCA != "" {
+				cfg.TLS.ClientCAFile = opts.TLSClientCA
+			}
+
+			// Validate configuration
+			if err := cfg.Validate(); err != nil {
+				wrapped := serversvc.WrapInvalidConfig(err)
+				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
 			// Create storage backend
 			storageConfig, err := storage.DefaultConfig()
 			if err != nil {
@@ -131,7 +169,42 @@ shutdown to drain in-flight requests and complete running jobs.`,
 			// Create plugin service for API endpoints
 			// Use storage config's WorkspaceRoot for plugin cache
 			pluginCacheDir := filepath.Join(storageConfig.WorkspaceRoot, "plugins", "cache")
-			pluginService, err := plugin.NewService(plugin.WithCacheDir(pluginCacheDir))
+			wordlistDir := filepath.Join(storageConfig.WorkspaceRoot, wordlist.DirName)
+			proxyConfig := cfgMgr.Get().Proxy
+			pluginsConfig := cfgMgr.Get().Plugins
+			sourceKeys := pluginsConfig.SourceKeys
+			sourceTrust := pluginsConfig.SourceTrust
+			maxCacheSize, err := pluginsConfig.CacheSizeLimit()
+			if err != nil {
+				wrapped := serversvc.WrapInvalidConfig(fmt.Errorf("plugins.max_cache_size: %w", err))
+				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			// Plugin downloads of all tenants share one rate limit
+			bandwidthConfig := cfgMgr.Get().Bandwidth
+			limiter, err := bandwidthConfig.Limiter()
+			if err != nil {
+				wrapped := serversvc.WrapInvalidConfig(fmt.Errorf("bandwidth.%w", err))
+				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			telemetryConfig := cfgMgr.Get().Telemetry
+			if err := telemetryConfig.Validate(); err != nil {
+				wrapped := serversvc.WrapInvalidConfig(fmt.Errorf("telemetry.%w", err))
+				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			// Scans evaluate the installed plugins; the manifest watcher
+			// reloads them on installs without a restart
+			installedPlugins := plugin.NewInstalledSet()
+			pluginService, err := plugin.NewService(
+				plugin.WithCacheDir(pluginCacheDir),
+				plugin.WithInstalledSet(installedPlugins),
+				plugin.WithProxyConfig(proxyConfig),
+				plugin.WithSourceKeys(sourceKeys),
+				plugin.WithSourceTrust(sourceTrust),
+				plugin.WithMaxCacheSize(maxCacheSize),
+				plugin.WithRetryPolicy(pluginsConfig.Retry),
+				plugin.WithBandwidthLimiter(limiter),
+				plugin.WithWordlistDir(wordlistDir),
+			)
 			if err != nil {
 				wrapped := serversvc.WrapPluginInit(err)
 				return formatter.PrintTotalFailureSummary("start server", wrapped, serversvc.ErrorCode(wrapped))
@@ -158,6 +231,35 @@ shutdown to drain in-flight requests and complete running jobs.`,
 				PluginService: pluginService,
 				Config:        cfgMgr,
 				Logger:        logger,
+
+				InstalledPlugins: installedPlugins,
+
+				// Anonymous aggregates of recent scans, when opted in
+				Telemetry: telemetry.NewReporter(storageBackend, filepath.Join(storageConfig.WorkspaceRoot, telemetry.StateFile), telemetryConfig),
+
+				// Tenants install plugins into their own cache, optionally from their own
+				// sources; their scans evaluate their own installed set
+				TenantPluginService: func(tenant *storage.Tenant) (any, error) {
+					opts := []plugin.ServiceOption{
+						plugin.WithCacheDir(filepath.Join(storageConfig.WorkspaceRoot, "plugins", "tenants", tenant.ID, "cache")),
+						plugin.WithInstalledSet(plugin.NewInstalledSet()),
+						plugin.WithWordlistDir(wordlistDir),
+						plugin.WithProxyConfig(proxyConfig),
+						plugin.WithSourceKeys(sourceKeys),
+						plugin.WithSourceTrust(sourceTrust),
+						plugin.WithMaxCacheSize(maxCacheSize),
+						plugin.WithRetryPolicy(pluginsConfig.Retry),
+						plugin.WithBandwidthLimiter(limiter),
+					}
+					if len(tenant.PluginSources) > 0 {
+						sources := make([]plugin.PluginSource, 0, len(tenant.PluginSources))
+						for _, src := range tenant.PluginSources {
+							sources = append(sources, plugin.PluginSource{Name: src.Name, URL: src.URL, Enabled: true, Priority: src.Priority})
+						}
+						opts = append(opts, plugin.WithPluginSources(sources))
+					}
+					return plugin.NewService(opts...)
+				},
 			}
 
 			// Create server app
@@ -181,10 +283,16 @@ shutdown to drain in-flight requests and complete running jobs.`,
 	// Server-specific flags
 	cmd.Flags().String("addr", "127.0.0.1", "Server listen address")
 	cmd.Flags().Int("port", 8080, "Server listen port")
+	cmd.Flags().Int("grpc-port", 0, "gRPC API listen port (default: server.grpc_port, 0 = disabled)")
 	cmd.Flags().Bool("no-ui", false, "Disable UI static serving")
 	cmd.Flags().Bool("no-api", false, "Disable REST API endpoints")
 	cmd.Flags().Int("jobs-concurrency", 4, "Number of concurrent background workers")
 	cmd.Flags().String("ui-assets-path", "", "UI assets directory (dev mode: serve from disk)")
+	cmd.Flags().String("auth-mode", "none", "Authentication mode: none, token, apikey, oidc")
+	cmd.Flags().String("auth-token", "", "Static bearer token (required for --auth-mode token)")
+	cmd.Flags().String("tls-cert", "", "PEM server certificate; serves HTTPS (with --tls-key)")
+	cmd.Flags().String("tls-key", "", "PEM private key of --tls-cert")
+	cmd.Flags().String("tls-client-ca", "", "PEM CA bundle verifying client certificates (mutual TLS)")
 
 	return cmd
 }
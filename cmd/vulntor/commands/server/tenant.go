@@ -0,0 +1,240 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	serversvc "github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// newTenantCommand creates the 'vulntor server tenant' command group.
+//
+// Tenants isolate scans, API keys, users and plugins of different teams
+// hosted on one server. API clients select a tenant with the X-Tenant-ID
+// header.
+func newTenantCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Manage server tenants",
+		Long: `Manage the tenants hosted by the Vulntor server.
+
+Each tenant has its own scan history, API keys, users and installed plugins.
+API requests select a tenant with the X-Tenant-ID header; requests without
+it use the "default" tenant. Use --tenant on the apikey and user commands to
+manage credentials of a tenant.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newTenantCreateCommand())
+	cmd.AddCommand(newTenantListCommand())
+	cmd.AddCommand(newTenantDeleteCommand())
+
+	return cmd
+}
+
+func newTenantCreateCommand() *cobra.Command {
+	var (
+		name    string
+		sources []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <tenant-id>",
+		Short: "Create a tenant",
+		Example: `  vulntor server tenant create team-a --name "Team A"
+
+  # Tenant installing plugins from its own repository
+  vulntor server tenant create team-b --plugin-source internal=https://plugins.example.com/registry.yaml`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			pluginSources, err := parsePluginSources(sources)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("create tenant", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			store, closeFn, err := openTenantStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("create tenant", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			tenant := &storage.Tenant{ID: args[0], Name: name, PluginSources: pluginSources}
+			err = store.Create(cmd.Context(), tenant)
+			audit.RecordCLI(cmd.Context(), "tenant.create", tenant.ID, err, nil)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("create tenant", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.tenant").
+				Str("tenant", tenant.ID).
+				Msg("Tenant created")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(tenant)
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Tenant %s created", tenant.ID))
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Display name")
+	cmd.Flags().StringArrayVar(&sources, "plugin-source", nil, "Plugin repository as name=url (repeatable, listed in priority order)")
+
+	return cmd
+}
+
+func newTenantListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List tenants",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			store, closeFn, err := openTenantStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("list tenants", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			tenants, err := store.List(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("list tenants", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"tenants": tenants, "count": len(tenants)})
+			}
+
+			rows := make([][]string, 0, len(tenants))
+			for _, t := range tenants {
+				sources := "-"
+				if len(t.PluginSources) > 0 {
+					names := make([]string, 0, len(t.PluginSources))
+					for _, src := range t.PluginSources {
+						names = append(names, src.Name)
+					}
+					sources = strings.Join(names, ",")
+				}
+				created := "-"
+				if !t.CreatedAt.IsZero() {
+					created = t.CreatedAt.Format(time.RFC3339)
+				}
+				rows = append(rows, []string{t.ID, t.Name, sources, created})
+			}
+			if err := formatter.PrintTable([]string{"ID", "Name", "Plugin Sources", "Created"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d tenant(s)", len(tenants)))
+		},
+	}
+}
+
+func newTenantDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <tenant-id>",
+		Short: "Delete a tenant",
+		Long: `Delete a tenant. Its API keys stop authenticating immediately.
+
+Scan data and installed plugins are left in the workspace; remove them
+manually once they are no longer needed.`,
+		Example: `  vulntor server tenant delete team-a`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			tenantID := args[0]
+
+			store, closeFn, err := openTenantStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("delete tenant", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			err = store.Delete(cmd.Context(), tenantID)
+			audit.RecordCLI(cmd.Context(), "tenant.delete", tenantID, err, nil)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("delete tenant", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.tenant").
+				Str("tenant", tenantID).
+				Msg("Tenant deleted")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"id": tenantID, "deleted": true})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Tenant %s deleted", tenantID))
+		},
+	}
+}
+
+// parsePluginSources parses name=url pairs; earlier entries take priority.
+func parsePluginSources(values []string) ([]storage.TenantPluginSource, error) {
+	sources := make([]storage.TenantPluginSource, 0, len(values))
+	for i, v := range values {
+		name, url, ok := strings.Cut(v, "=")
+		if !ok || name == "" || url == "" {
+			return nil, storage.NewInvalidInputError("plugin-source", fmt.Sprintf("%q must be name=url", v))
+		}
+		sources = append(sources, storage.TenantPluginSource{Name: name, URL: url, Priority: i + 1})
+	}
+	return sources, nil
+}
+
+// addTenantFlag registers the --tenant flag on a command group.
+func addTenantFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("tenant", storage.DefaultOrgID, "Tenant to manage")
+}
+
+// tenantFromFlags returns the tenant selected with --tenant, checking that it exists.
+func tenantFromFlags(cmd *cobra.Command) (string, error) {
+	tenantID, _ := cmd.Flags().GetString("tenant")
+	if tenantID == "" || tenantID == storage.DefaultOrgID {
+		return storage.DefaultOrgID, nil
+	}
+
+	store, closeFn, err := openTenantStore(cmd.Context())
+	if err != nil {
+		return "", err
+	}
+	defer closeFn()
+
+	if _, err := store.Get(cmd.Context(), tenantID); err != nil {
+		return "", err
+	}
+	return tenantID, nil
+}
+
+// openTenantStore opens the workspace storage backend and returns its tenant store.
+func openTenantStore(ctx context.Context) (storage.TenantStore, func(), error) {
+	backend, closeFn, err := openAuthBackend(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tenantBackend, ok := backend.(storage.TenantBackend)
+	if !ok {
+		closeFn()
+		return nil, nil, fmt.Errorf("%w: storage backend does not support tenants", storage.ErrNotSupported)
+	}
+	return tenantBackend.Tenants(), closeFn, nil
+}
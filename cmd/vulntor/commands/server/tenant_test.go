@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestTenantCommand_Lifecycle(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newTenantCommand, "create", "team-a", "--name", "Team A",
+		"--plugin-source", "internal=https://plugins.example.com/registry.yaml")
+	require.Contains(t, out, "Tenant team-a created")
+
+	out = runCommand(t, root, newTenantCommand, "list", "--output", "json")
+	var listed struct {
+		Tenants []storage.Tenant `json:"tenants"`
+		Count   int              `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 2, listed.Count)
+	require.Equal(t, "default", listed.Tenants[0].ID)
+	require.Equal(t, "team-a", listed.Tenants[1].ID)
+	require.Equal(t, []storage.TenantPluginSource{{Name: "internal", URL: "https://plugins.example.com/registry.yaml", Priority: 1}}, listed.Tenants[1].PluginSources)
+
+	out = runCommand(t, root, newTenantCommand, "delete", "team-a")
+	require.Contains(t, out, "Tenant team-a deleted")
+
+	// Both changes are in the audit log
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	events, err := backend.Audit().List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "tenant"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "tenant.delete", events[0].Action)
+	require.Equal(t, "team-a", events[0].Resource)
+	require.Equal(t, "cli", events[0].Source)
+}
+
+func TestTenantCommand_Errors(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newTenantCommand, "create", "Team_A")
+	require.Contains(t, out, "✗ Failed to create tenant")
+	require.Contains(t, out, "lowercase letters, digits and dashes")
+
+	out = runCommand(t, root, newTenantCommand, "create", "team-a", "--plugin-source", "no-url")
+	require.Contains(t, out, "must be name=url")
+
+	out = runCommand(t, root, newTenantCommand, "delete", "team-b")
+	require.Contains(t, out, "vulntor server tenant list")
+}
+
+func TestTenantFlag_ScopesCredentials(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newUserCommand, "create", "alice", "--tenant", "team-a")
+	require.Contains(t, out, "vulntor server tenant list", "unknown tenant should be rejected")
+
+	runCommand(t, root, newTenantCommand, "create", "team-a")
+	runCommand(t, root, newUserCommand, "create", "alice", "--tenant", "team-a")
+	runCommand(t, root, newAPIKeyCommand, "create", "--name", "ci", "--tenant", "team-a")
+
+	require.Contains(t, runCommand(t, root, newUserCommand, "list"), "No users found.")
+	require.Contains(t, runCommand(t, root, newAPIKeyCommand, "list"), "No API keys found.")
+	require.Contains(t, runCommand(t, root, newUserCommand, "list", "--tenant", "team-a"), "alice")
+	require.Contains(t, runCommand(t, root, newAPIKeyCommand, "list", "--tenant", "team-a"), "ci")
+}
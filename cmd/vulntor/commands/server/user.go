@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	serversvc "github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// newUserCommand creates the 'vulntor server user' command group.
+//
+// Users hold a role (viewer, operator, admin) that caps the scopes of the
+// API keys they own.
+func newUserCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage server users and roles",
+		Long: `Manage server users and their role assignments.
+
+A user's role caps what the API keys owned by that user may do:
+  viewer    Read scans, findings and plugins
+  operator  Read and submit scans
+  admin     Full access, including plugin management`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	addTenantFlag(cmd)
+
+	cmd.AddCommand(newUserCreateCommand())
+	cmd.AddCommand(newUserListCommand())
+	cmd.AddCommand(newUserSetRoleCommand())
+	cmd.AddCommand(newUserDeleteCommand())
+
+	return cmd
+}
+
+func newUserCreateCommand() *cobra.Command {
+	var (
+		name string
+		role string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <user-id>",
+		Short: "Create a user",
+		Example: `  vulntor server user create alice --role operator
+  vulntor server user create bob --role viewer --name "Bob Smith"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("create user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			parsed, err := auth.ParseRole(role)
+			if err != nil {
+				wrapped := serversvc.WrapInvalidUser(err)
+				return formatter.PrintTotalFailureSummary("create user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			store, closeFn, err := openUserStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("create user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			user := &storage.User{ID: args[0], Name: name, Role: string(parsed)}
+			err = store.Create(cmd.Context(), orgID, user)
+			audit.RecordCLI(storage.WithOrgID(cmd.Context(), orgID), "user.create", user.ID, err, map[string]string{"role": user.Role})
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("create user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.user").
+				Str("user_id", user.ID).
+				Str("role", user.Role).
+				Msg("User created")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(user)
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ User %s created with role %s", user.ID, user.Role))
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Display name")
+	cmd.Flags().StringVar(&role, "role", string(auth.RoleViewer), "Role: viewer, operator, admin")
+
+	return cmd
+}
+
+func newUserListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("list users", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			store, closeFn, err := openUserStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("list users", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			users, err := store.List(cmd.Context(), orgID)
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("list users", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"users": users, "count": len(users)})
+			}
+
+			if len(users) == 0 {
+				return formatter.PrintSummary("No users found.")
+			}
+
+			rows := make([][]string, 0, len(users))
+			for _, u := range users {
+				rows = append(rows, []string{u.ID, u.Name, u.Role, u.CreatedAt.Format(time.RFC3339)})
+			}
+			if err := formatter.PrintTable([]string{"ID", "Name", "Role", "Created"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d user(s)", len(users)))
+		},
+	}
+}
+
+func newUserSetRoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "set-role <user-id> <role>",
+		Short:   "Change a user's role",
+		Example: `  vulntor server user set-role alice admin`,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("set user role", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			userID := args[0]
+
+			parsed, err := auth.ParseRole(args[1])
+			if err != nil {
+				wrapped := serversvc.WrapInvalidUser(err)
+				return formatter.PrintTotalFailureSummary("set user role", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			store, closeFn, err := openUserStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("set user role", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			err = store.SetRole(cmd.Context(), orgID, userID, string(parsed))
+			audit.RecordCLI(storage.WithOrgID(cmd.Context(), orgID), "user.set_role", userID, err, map[string]string{"role": string(parsed)})
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("set user role", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.user").
+				Str("user_id", userID).
+				Str("role", string(parsed)).
+				Msg("User role changed")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"id": userID, "role": parsed})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ User %s is now %s", userID, parsed))
+		},
+	}
+}
+
+func newUserDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <user-id>",
+		Short:   "Delete a user",
+		Long:    "Delete a user. API keys owned by the user stop authenticating immediately.",
+		Example: `  vulntor server user delete alice`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			orgID, err := tenantFromFlags(cmd)
+			if err != nil {
+				wrapped := serversvc.WrapTenantStore(err)
+				return formatter.PrintTotalFailureSummary("delete user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			userID := args[0]
+
+			store, closeFn, err := openUserStore(cmd.Context())
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("delete user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+			defer closeFn()
+
+			err = store.Delete(cmd.Context(), orgID, userID)
+			audit.RecordCLI(storage.WithOrgID(cmd.Context(), orgID), "user.delete", userID, err, nil)
+			if err != nil {
+				wrapped := serversvc.WrapUserStore(err)
+				return formatter.PrintTotalFailureSummary("delete user", wrapped, serversvc.ErrorCode(wrapped))
+			}
+
+			log.Info().
+				Str("component", "server.user").
+				Str("user_id", userID).
+				Msg("User deleted")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"id": userID, "deleted": true})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ User %s deleted", userID))
+		},
+	}
+}
+
+// openUserStore opens the workspace storage backend and returns its user store.
+func openUserStore(ctx context.Context) (storage.UserStore, func(), error) {
+	backend, closeFn, err := openAuthBackend(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userBackend, ok := backend.(storage.UserBackend)
+	if !ok {
+		closeFn()
+		return nil, nil, fmt.Errorf("%w: storage backend does not support users", storage.ErrNotSupported)
+	}
+	return userBackend.Users(), closeFn, nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestUserCommand_Lifecycle(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newUserCommand, "create", "alice", "--role", "operator", "--name", "Alice")
+	require.Contains(t, out, "User alice created with role operator")
+
+	out = runCommand(t, root, newUserCommand, "list", "--output", "json")
+	var listed struct {
+		Users []storage.User `json:"users"`
+		Count int            `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 1, listed.Count)
+	require.Equal(t, "operator", listed.Users[0].Role)
+
+	out = runCommand(t, root, newUserCommand, "set-role", "alice", "admin")
+	require.Contains(t, out, "User alice is now admin")
+
+	out = runCommand(t, root, newUserCommand, "delete", "alice")
+	require.Contains(t, out, "User alice deleted")
+
+	out = runCommand(t, root, newUserCommand, "list")
+	require.Contains(t, out, "No users found.")
+}
+
+func TestUserCommand_Errors(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newUserCommand, "create", "alice", "--role", "root")
+	require.Contains(t, out, "✗ Failed to create user")
+	require.Contains(t, out, `unknown role "root"`)
+
+	runCommand(t, root, newUserCommand, "create", "alice")
+	out = runCommand(t, root, newUserCommand, "create", "alice")
+	require.Contains(t, out, "✗ Failed to create user")
+
+	out = runCommand(t, root, newUserCommand, "set-role", "bob", "viewer")
+	require.Contains(t, out, "vulntor server user list")
+}
+
+func TestAPIKeyCommand_CreateForUser(t *testing.T) {
+	root := t.TempDir()
+
+	out := runCommand(t, root, newAPIKeyCommand, "create", "--name", "laptop", "--user", "alice")
+	require.Contains(t, out, "vulntor server user list", "unknown user should be rejected")
+
+	runCommand(t, root, newUserCommand, "create", "alice", "--role", "viewer")
+	out = runCommand(t, root, newAPIKeyCommand, "create", "--name", "laptop", "--user", "alice", "--output", "json")
+	var created struct {
+		UserID string   `json:"user_id"`
+		Scopes []string `json:"scopes"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	require.Equal(t, "alice", created.UserID)
+	require.Equal(t, []string{"admin"}, created.Scopes, "user keys inherit the role by default")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/jsonrpc"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+// NewServeRPCCommand creates the 'vulntor serve-rpc' command.
+func NewServeRPCCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "serve-rpc",
+		Short:   "Drive scans over JSON-RPC on stdin and stdout",
+		GroupID: "core",
+		Long: `Serve the JSON-RPC 2.0 bridge for programs in other languages, such as
+Python orchestration or Jupyter notebooks: start the command as a
+subprocess, write one request per line to its stdin and read responses and
+scan.event notifications, one per line, from its stdout. Logs go to stderr.
+
+Methods:
+  version       Vulntor and protocol version
+  scan.start    Start a scan; returns its scan_id
+  scan.status   Status of a scan
+  scan.wait     Wait for a scan to end; returns its findings
+  scan.cancel   Cancel a scan, keeping its partial results
+
+Scans are stored in the workspace as with 'vulntor scan'. Closing stdin
+cancels the scans still running and exits once they ended.`,
+		Example: `  # Query the versions from the shell
+  echo '{"jsonrpc":"2.0","id":1,"method":"version"}' | vulntor serve-rpc --stdio`,
+		Args: cobra.NoArgs,
+		RunE: runServeRPC,
+	}
+
+	cmd.Flags().Bool("stdio", false, "Serve on stdin and stdout (required; the only transport)")
+	_ = cmd.MarkFlagRequired("stdio")
+
+	return cmd
+}
+
+func runServeRPC(cmd *cobra.Command, args []string) error {
+	in, out := cmd.InOrStdin(), cmd.OutOrStdout()
+
+	// Stdout carries the protocol: logs and stray prints go to stderr
+	if out == os.Stdout {
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = out.(*os.File) }()
+	}
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: cmd.ErrOrStderr(), TimeFormat: "15:04:05", NoColor: true})
+	logger := log.With().Str("command", "serve-rpc").Logger()
+
+	ctx, appMgr, err := orchestratorContext(cmd)
+	if err != nil {
+		return err
+	}
+	svc, closeStorage, err := newScanService(ctx, appMgr, logger)
+	if err != nil {
+		return fmt.Errorf("scan service: %w", err)
+	}
+	defer closeStorage()
+
+	// Ctrl+C interrupts the running scans gracefully, as in 'vulntor scan'
+	shutdown := scanexec.NewShutdown(scanexec.DefaultDrainTimeout)
+	stopSignals := shutdown.NotifySignals(os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	svc = svc.WithShutdown(shutdown)
+
+	logger.Info().Int("protocol", jsonrpc.Version).Msg("Serving JSON-RPC on stdio")
+	return jsonrpc.NewServer(svc.Run, version.GetVersion().Version).Serve(ctx, in, out)
+}
@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,7 +25,6 @@ func NewStatsCommand() *cobra.Command {
 	cmd.Flags().String("since", "", "Start time filter (RFC3339 format: 2024-01-01T00:00:00Z)")
 	cmd.Flags().String("until", "", "End time filter (RFC3339 format: 2024-01-31T23:59:59Z)")
 	cmd.Flags().Int("top-n", 10, "Number of top products to include")
-	cmd.Flags().Bool("json", false, "Output statistics in JSON format")
 
 	return cmd
 }
@@ -40,7 +38,6 @@ func runStats(cmd *cobra.Command, args []string) error {
 	sinceStr, _ := cmd.Flags().GetString("since")
 	untilStr, _ := cmd.Flags().GetString("until")
 	topN, _ := cmd.Flags().GetInt("top-n")
-	outputJSON, _ := cmd.Flags().GetBool("json")
 
 	// Build filter
 	filter := &fingerprint.StatsFilter{
@@ -72,18 +69,13 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Output results
-	if outputJSON {
-		// JSON output
-		data, err := json.MarshalIndent(stats, "", "  ")
-		if err != nil {
-			return formatter.PrintTotalFailureSummary("marshal JSON", err, "JSON_MARSHAL_ERROR")
-		}
-		fmt.Println(string(data))
-	} else {
-		// Human-readable output
-		printHumanReadableStats(stats, filter)
+	if formatter.IsStructured() {
+		// JSON or YAML output
+		return formatter.PrintStructured(stats)
 	}
 
+	// Human-readable output
+	printHumanReadableStats(stats, filter)
 	return nil
 }
 
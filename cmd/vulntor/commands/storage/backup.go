@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup <archive>",
+		Short: "Back up the storage into an archive",
+		Long: `Back up the storage into a portable .tar.gz archive for disaster recovery
+or moving Vulntor to another machine.
+
+The archive holds the whole workspace: scans and their artifacts, API keys,
+users, tenants, target groups, finding statuses, the plugin registry and
+cache, wordlists and the server job queue. The config file passed with
+--config is included too. A manifest records the checksum of every file.
+
+Each file is copied under its file lock, so no file is caught half-written.
+Scans still pending or running are left out, as their files are still being
+written; use --include-active to back them up as they stand.
+
+Use "-" as the archive to write it to stdout.`,
+		Example: `  vulntor storage backup vulntor-backup.tar.gz
+  vulntor storage backup --config /etc/vulntor/config.yaml /backups/vulntor.tar.gz
+  vulntor storage backup - | ssh backup-host 'cat > vulntor.tar.gz'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			ctx := cmd.Context()
+
+			opts, err := bind.BindStorageBackupOptions(cmd, args)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			if opts.Output == "-" && formatter.IsStructured() {
+				err := errors.New("the archive and structured output cannot both go to stdout")
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			storageConfig, err := storage.DefaultConfig()
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			backend, err := storage.NewBackend(ctx, storageConfig)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+			defer func() {
+				if err := backend.Close(); err != nil {
+					log.Warn().Err(err).Msg("Failed to close storage backend")
+				}
+			}()
+
+			backuper, ok := backend.(storage.BackupBackend)
+			if !ok {
+				err := errors.New("storage backend does not support backups")
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			// The archive must not be part of what it backs up
+			if opts.Output != "-" && insideDir(opts.Output, storageConfig.WorkspaceRoot) {
+				err := fmt.Errorf("archive %s is inside the workspace %s", opts.Output, storageConfig.WorkspaceRoot)
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			manifest, err := writeBackup(opts.Output, func(w io.Writer) (*storage.BackupManifest, error) {
+				return backuper.Backup(ctx, w, storage.BackupOptions{
+					ConfigFile:    opts.ConfigFile,
+					IncludeActive: opts.IncludeActive,
+				})
+			})
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("backup", err, storage.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{
+					"archive":             opts.Output,
+					"created_at":          manifest.CreatedAt,
+					"files":               len(manifest.Files),
+					"bytes":               manifest.Size(),
+					"scan_schema_version": manifest.ScanSchemaVersion,
+					"config_file":         manifest.ConfigFile,
+					"skipped_scans":       manifest.SkippedScans,
+				})
+			}
+
+			// Keep stdout for the archive
+			out := os.Stdout
+			if opts.Output == "-" {
+				out = os.Stderr
+			}
+			_, _ = fmt.Fprintf(out, "Backed up %d file(s), %d bytes, to %s\n", len(manifest.Files), manifest.Size(), opts.Output)
+			if manifest.ConfigFile != "" {
+				_, _ = fmt.Fprintf(out, "Included config file %s\n", opts.ConfigFile)
+			}
+			if len(manifest.SkippedScans) > 0 {
+				_, _ = fmt.Fprintf(out, "\nSkipped %d scan(s) still pending or running:\n", len(manifest.SkippedScans))
+				for _, scan := range manifest.SkippedScans {
+					_, _ = fmt.Fprintf(out, "  - %s\n", scan)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("include-active", false, "Include scans still pending or running")
+
+	return cmd
+}
+
+// writeBackup runs backup into the file at path, or stdout for "-". The file
+// only appears once the archive is complete.
+func writeBackup(path string, backup func(io.Writer) (*storage.BackupManifest, error)) (*storage.BackupManifest, error) {
+	if path == "-" {
+		return backup(os.Stdout)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("create archive: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	manifest, err := backup(tmp)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("write archive: %w", closeErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("write archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// insideDir reports whether path is dir or inside it.
+func insideDir(path, dir string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dir, abs)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -85,6 +85,37 @@ Use --dry-run to preview which scans would be deleted without actually deleting
 				Str("org_id", opts.OrgID).
 				Msg("Starting garbage collection")
 
+			// Run GC
+			result, err := backend.GarbageCollect(ctx, storage.GCOptions{
+				DryRun: opts.DryRun,
+				OrgID:  opts.OrgID,
+			})
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("garbage collection", err, storage.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				errs := make([]string, 0, len(result.Errors))
+				for _, err := range result.Errors {
+					errs = append(errs, err.Error())
+				}
+				deleted := result.DeletedScanIDs
+				if deleted == nil {
+					deleted = []string{}
+				}
+				return formatter.PrintStructured(map[string]any{
+					"dry_run": opts.DryRun,
+					"retention": map[string]int{
+						"max_scans":    storageConfig.Retention.MaxScans,
+						"max_age_days": storageConfig.Retention.MaxAgeDays,
+					},
+					"scans_deleted":    result.ScansDeleted,
+					"deleted_scan_ids": deleted,
+					"bytes_freed":      result.BytesFreed,
+					"errors":           errs,
+				})
+			}
+
 			if opts.DryRun {
 				fmt.Println("DRY RUN MODE - No scans will be deleted")
 				fmt.Println()
@@ -100,15 +131,6 @@ Use --dry-run to preview which scans would be deleted without actually deleting
 			}
 			fmt.Println()
 
-			// Run GC
-			result, err := backend.GarbageCollect(ctx, storage.GCOptions{
-				DryRun: opts.DryRun,
-				OrgID:  opts.OrgID,
-			})
-			if err != nil {
-				return formatter.PrintTotalFailureSummary("garbage collection", err, storage.ErrorCode(err))
-			}
-
 			// Print results
 			if result.ScansDeleted == 0 {
 				fmt.Println("No scans to clean up")
@@ -146,9 +168,6 @@ Use --dry-run to preview which scans would be deleted without actually deleting
 	cmd.Flags().String("org-id", "", "Organization ID to clean up (default: all orgs)")
 	cmd.Flags().Int("max-scans", 0, "Maximum number of scans to retain (0 = no limit)")
 	cmd.Flags().Int("max-age-days", 0, "Maximum age of scans in days (0 = no limit)")
-	cmd.Flags().String("output", "table", "Output format: json, table")
-	cmd.Flags().Bool("quiet", false, "Suppress non-essential output")
-	cmd.Flags().Bool("no-color", false, "Disable colored output")
 
 	return cmd
 }
@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate stored scans to the current schema version",
+		Long: `Migrate stored scans to the schema version of this Vulntor release.
+
+Every stored scan records the schema version of its metadata and data files
+(hosts, services, findings, evidence). When a release changes the format, this
+command upgrades the scans stored by earlier releases, one version at a time,
+so that they remain readable. The server runs the migration on startup.
+
+Scans stored by a newer release are left as is and reported as errors.
+
+Use --dry-run to preview which scans would be migrated without changing them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			ctx := cmd.Context()
+
+			opts, err := bind.BindStorageMigrateOptions(cmd)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("migration", err, storage.ErrorCode(err))
+			}
+
+			storageConfig, err := storage.DefaultConfig()
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("migration", err, storage.ErrorCode(err))
+			}
+
+			backend, err := storage.NewBackend(ctx, storageConfig)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("migration", err, storage.ErrorCode(err))
+			}
+			defer func() {
+				if err := backend.Close(); err != nil {
+					log.Warn().Err(err).Msg("Failed to close storage backend")
+				}
+			}()
+
+			migrator, ok := backend.(storage.MigrationBackend)
+			if !ok {
+				err := errors.New("storage backend does not support migrations")
+				return formatter.PrintTotalFailureSummary("migration", err, storage.ErrorCode(err))
+			}
+
+			log.Info().
+				Int("schema_version", storage.ScanSchemaVersion).
+				Bool("dry_run", opts.DryRun).
+				Str("org_id", opts.OrgID).
+				Msg("Starting migration")
+
+			result, err := migrator.MigrateScans(ctx, storage.MigrateOptions{
+				DryRun: opts.DryRun,
+				OrgID:  opts.OrgID,
+			})
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("migration", err, storage.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				errs := make([]string, 0, len(result.Errors))
+				for _, err := range result.Errors {
+					errs = append(errs, err.Error())
+				}
+				return formatter.PrintStructured(map[string]any{
+					"dry_run":           opts.DryRun,
+					"schema_version":    storage.ScanSchemaVersion,
+					"scans_migrated":    result.ScansMigrated,
+					"migrated_scan_ids": result.MigratedScanIDs,
+					"errors":            errs,
+				})
+			}
+
+			if opts.DryRun {
+				fmt.Println("DRY RUN MODE - No scans will be changed")
+				fmt.Println()
+			}
+
+			fmt.Printf("Schema version: %d\n\n", storage.ScanSchemaVersion)
+
+			if result.ScansMigrated == 0 {
+				fmt.Println("All scans are up to date")
+			} else {
+				if opts.DryRun {
+					fmt.Printf("Would migrate %d scan(s):\n", result.ScansMigrated)
+				} else {
+					fmt.Printf("Migrated %d scan(s):\n", result.ScansMigrated)
+				}
+				for _, scanID := range result.MigratedScanIDs {
+					fmt.Printf("  - %s\n", scanID)
+				}
+			}
+
+			if len(result.Errors) > 0 {
+				fmt.Printf("\nEncountered %d error(s):\n", len(result.Errors))
+				for i, err := range result.Errors {
+					fmt.Printf("  %d. %v\n", i+1, err)
+				}
+			}
+
+			if opts.DryRun && result.ScansMigrated > 0 {
+				fmt.Println()
+				fmt.Println("To migrate these scans, run without --dry-run:")
+				fmt.Println("  vulntor storage migrate")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Preview scans to be migrated without changing them")
+	cmd.Flags().String("org-id", "", "Organization ID to migrate (default: all orgs)")
+
+	return cmd
+}
@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newRestoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore the storage from a backup archive",
+		Long: `Restore the storage from an archive written by 'vulntor storage backup'.
+
+The archive is unpacked next to the workspace and every file is verified
+against the checksums of its manifest before the workspace is replaced, so a
+damaged or truncated archive leaves the workspace untouched. Archives of
+scans stored by a newer Vulntor release are rejected; scans from an older
+release are upgraded by 'vulntor storage migrate' or on server start.
+
+A workspace that is not empty is only replaced with --force; it is then kept
+next to the restored one as <workspace>.pre-restore-<time>. Stop the server
+and other Vulntor processes using the workspace first.
+
+The config file in the archive, if any, is written to --config-out.
+
+Use --dry-run to verify an archive without restoring it, and "-" as the
+archive to read it from stdin.`,
+		Example: `  vulntor storage restore --dry-run vulntor-backup.tar.gz
+  vulntor storage restore vulntor-backup.tar.gz
+  vulntor storage restore --force --config-out /etc/vulntor/config.yaml /backups/vulntor.tar.gz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			ctx := cmd.Context()
+
+			opts, err := bind.BindStorageRestoreOptions(cmd, args)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+			}
+
+			storageConfig, err := storage.DefaultConfig()
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+			}
+
+			backend, err := storage.NewBackend(ctx, storageConfig)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+			}
+			defer func() {
+				if err := backend.Close(); err != nil {
+					log.Warn().Err(err).Msg("Failed to close storage backend")
+				}
+			}()
+
+			restorer, ok := backend.(storage.BackupBackend)
+			if !ok {
+				err := errors.New("storage backend does not support backups")
+				return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+			}
+
+			var in io.Reader = os.Stdin
+			if opts.Input != "-" {
+				f, err := os.Open(opts.Input)
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+				}
+				defer func() { _ = f.Close() }()
+				in = f
+			}
+
+			result, err := restorer.Restore(ctx, in, storage.RestoreOptions{
+				DryRun:     opts.DryRun,
+				Force:      opts.Force,
+				ConfigPath: opts.ConfigOut,
+			})
+			if storage.IsAlreadyExists(err) {
+				err = fmt.Errorf("%w; use --force to replace it", err)
+			}
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("restore", err, storage.ErrorCode(err))
+			}
+			manifest := result.Manifest
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{
+					"dry_run":             opts.DryRun,
+					"workspace":           storageConfig.WorkspaceRoot,
+					"created_at":          manifest.CreatedAt,
+					"files":               len(manifest.Files),
+					"bytes":               manifest.Size(),
+					"scan_schema_version": manifest.ScanSchemaVersion,
+					"previous_workspace":  result.PreviousWorkspace,
+					"config_file":         result.ConfigPath,
+				})
+			}
+
+			if opts.DryRun {
+				fmt.Println("DRY RUN MODE - The workspace will not be changed")
+				fmt.Println()
+				fmt.Printf("Archive is valid: %d file(s), %d bytes, backed up %s\n", len(manifest.Files), manifest.Size(), manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("Restored %d file(s), %d bytes, to %s\n", len(manifest.Files), manifest.Size(), storageConfig.WorkspaceRoot)
+			}
+			if result.PreviousWorkspace != "" {
+				fmt.Printf("Previous workspace kept at %s\n", result.PreviousWorkspace)
+			}
+			switch {
+			case result.ConfigPath != "":
+				fmt.Printf("Config file written to %s\n", result.ConfigPath)
+			case manifest.ConfigFile != "":
+				fmt.Println("The archive includes a config file; use --config-out to restore it")
+			}
+			if manifest.ScanSchemaVersion < storage.ScanSchemaVersion && !opts.DryRun {
+				fmt.Println()
+				fmt.Println("The archive holds scans of an older schema version; to upgrade them, run:")
+				fmt.Println("  vulntor storage migrate")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Verify the archive without restoring it")
+	cmd.Flags().Bool("force", false, "Replace a workspace that is not empty, keeping it next to the restored one")
+	cmd.Flags().String("config-out", "", "Write the config file of the archive to this path")
+
+	return cmd
+}
@@ -9,17 +9,24 @@ import (
 //
 // This command provides subcommands for storage management operations:
 //   - gc: Garbage collection to clean up old scans
+//   - migrate: Migration of stored scans to the current schema version
+//   - backup: Snapshot of the storage into a portable archive
+//   - restore: Restore of the storage from such an archive
 //
 // Example usage:
 //
 //	vulntor storage gc
 //	vulntor storage gc --dry-run
 //	vulntor storage gc --max-scans=100
+//	vulntor storage migrate --dry-run
+//	vulntor storage backup vulntor-backup.tar.gz
+//	vulntor storage restore --dry-run vulntor-backup.tar.gz
 func NewStorageCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "storage",
 		Short: "Manage Vulntor storage",
-		Long: `Manage Vulntor storage operations including garbage collection.
+		Long: `Manage Vulntor storage operations including garbage collection,
+schema migrations, and backup and restore.
 
 The storage command provides utilities for managing scan data persistence,
 retention policies, and cleanup operations.`,
@@ -27,6 +34,9 @@ retention policies, and cleanup operations.`,
 
 	// Add subcommands
 	cmd.AddCommand(newGCCommand())
+	cmd.AddCommand(newMigrateCommand())
+	cmd.AddCommand(newBackupCommand())
+	cmd.AddCommand(newRestoreCommand())
 
 	return cmd
 }
@@ -0,0 +1,52 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+)
+
+func newPreviewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "preview",
+		Short: "Print the next telemetry report without sending it",
+		Long: `Print the report telemetry would send now, exactly as it would be sent:
+the aggregates of the scans since the last report, or of the last
+telemetry.interval when none was sent. Nothing is sent, whether telemetry
+is enabled or not.`,
+		Example: `  vulntor telemetry preview`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			const operation = "telemetry preview"
+
+			reporter, closeBackend, err := newReporter(cmd)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, format.ErrorCode(err))
+			}
+			defer closeBackend()
+
+			report, err := reporter.Preview(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, format.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(report)
+			}
+			// The payload is JSON; print it verbatim
+			data, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			if !reporter.Enabled() {
+				return formatter.PrintSummary("Telemetry is disabled; nothing is sent.")
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Sent to %s after the next scan once due.", reporter.Endpoint()))
+		},
+	}
+}
@@ -0,0 +1,98 @@
+// Package telemetry provides CLI commands for the anonymous opt-in
+// telemetry: showing whether it is enabled, previewing the report it would
+// send and sending a report at once.
+package telemetry
+
+import (
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+)
+
+// Error codes of the telemetry commands.
+const (
+	errorCodeDisabled   = "TELEMETRY_DISABLED"
+	errorCodeSendFailed = "TELEMETRY_SEND_FAILED"
+)
+
+// NewCommand creates the 'vulntor telemetry' command group.
+//
+// Telemetry is opt-in (telemetry.enabled). When enabled, anonymous
+// aggregates of the detection quality of recent scans are reported to
+// telemetry.endpoint after a scan, once per telemetry.interval.
+//
+// Example usage:
+//
+//	vulntor telemetry status
+//	vulntor telemetry preview
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Inspect and send the anonymous opt-in telemetry",
+		Long: `Inspect the anonymous telemetry that helps improve detection quality.
+
+Telemetry is disabled unless telemetry.enabled is set in the config file.
+When enabled, a report is sent after a scan once per telemetry.interval
+(default: 24h) to telemetry.endpoint. Reports hold aggregates of the scans
+since the last report:
+
+  - how often each plugin was evaluated, matched or failed
+  - how many services of each protocol fingerprinting did not identify
+  - how many scans each crashing module failed
+
+Reports never contain targets, addresses, hostnames, banners, findings,
+tenants or error messages. 'vulntor telemetry preview' prints the next
+report without sending it, whether telemetry is enabled or not.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newStatusCommand())
+	cmd.AddCommand(newPreviewCommand())
+	cmd.AddCommand(newSendCommand())
+
+	return cmd
+}
+
+// telemetryConfig returns the telemetry section of the loaded config.
+func telemetryConfig(cmd *cobra.Command) config.TelemetryConfig {
+	if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+		return cfgMgr.Get().Telemetry
+	}
+	return config.TelemetryConfig{}
+}
+
+// newReporter returns the reporter of the workspace storage backend and a
+// function closing the backend.
+func newReporter(cmd *cobra.Command) (*telemetry.Reporter, func(), error) {
+	cfg := telemetryConfig(cmd)
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx := cmd.Context()
+	storageConfig, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if storageConfig, err = storage.DefaultConfig(); err != nil {
+			return nil, nil, err
+		}
+	}
+	backend, err := storage.NewBackend(ctx, storageConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeBackend := func() {
+		if err := backend.Close(); err != nil {
+			log.Warn().Err(err).Msg("Failed to close storage backend")
+		}
+	}
+	return telemetry.NewReporter(backend, filepath.Join(storageConfig.WorkspaceRoot, telemetry.StateFile), cfg), closeBackend, nil
+}
@@ -0,0 +1,102 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+)
+
+func runTelemetryCommand(t *testing.T, ctx context.Context, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
+
+func telemetryContext(t *testing.T, configYAML string) context.Context {
+	t.Helper()
+	root := t.TempDir()
+	configFile := filepath.Join(root, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(configYAML), 0o644))
+	cfgMgr := config.NewManager()
+	require.NoError(t, cfgMgr.LoadWithSources([]config.ConfigSource{&config.FileSource{Path: configFile}}))
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	return appctx.WithConfig(ctx, cfgMgr)
+}
+
+func TestTelemetryCommand_Disabled(t *testing.T) {
+	ctx := telemetryContext(t, "telemetry:\n  enabled: false\n")
+
+	out := runTelemetryCommand(t, ctx, "status")
+	require.Contains(t, out, "never (disabled)")
+	require.Contains(t, out, "Telemetry is disabled")
+
+	out = runTelemetryCommand(t, ctx, "preview")
+	require.Contains(t, out, `"schema_version": 1`)
+	require.Contains(t, out, "nothing is sent")
+
+	out = runTelemetryCommand(t, ctx, "send", "--output", "json")
+	require.Contains(t, out, "TELEMETRY_DISABLED")
+}
+
+func TestTelemetryCommand_Send(t *testing.T) {
+	var received []telemetry.Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var report telemetry.Report
+		if json.Unmarshal(body, &report) == nil {
+			received = append(received, report)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	ctx := telemetryContext(t, "telemetry:\n  enabled: true\n  endpoint: "+srv.URL+"\n")
+
+	out := runTelemetryCommand(t, ctx, "status", "--output", "json")
+	var status struct {
+		Enabled   bool   `json:"enabled"`
+		Endpoint  string `json:"endpoint"`
+		InstallID string `json:"install_id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &status))
+	require.True(t, status.Enabled)
+	require.Equal(t, srv.URL, status.Endpoint)
+	require.NotEmpty(t, status.InstallID)
+
+	out = runTelemetryCommand(t, ctx, "preview")
+	require.Contains(t, out, status.InstallID)
+	require.Empty(t, received, "preview must not send")
+
+	require.Contains(t, runTelemetryCommand(t, ctx, "send"), "Sent the report of 0 scan(s)")
+	require.Len(t, received, 1)
+	require.Equal(t, status.InstallID, received[0].InstallID)
+
+	out = runTelemetryCommand(t, ctx, "status")
+	require.Contains(t, out, "after the first scan from")
+
+	// Failed deliveries are reported with their own code
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	require.Contains(t, runTelemetryCommand(t, ctx, "send", "--output", "json"), "TELEMETRY_SEND_FAILED")
+}
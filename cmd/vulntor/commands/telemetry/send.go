@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+)
+
+func newSendCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "send",
+		Short: "Send a telemetry report now",
+		Long: `Send the report 'vulntor telemetry preview' prints now, without waiting
+for the next scan, and restart the reporting interval. Telemetry must be
+enabled.`,
+		Example: `  vulntor telemetry send`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			const operation = "send telemetry"
+
+			reporter, closeBackend, err := newReporter(cmd)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, format.ErrorCode(err))
+			}
+			defer closeBackend()
+
+			report, err := reporter.Send(cmd.Context())
+			audit.RecordCLI(cmd.Context(), "telemetry.send", reporter.Endpoint(), err, nil)
+			if err != nil {
+				code := errorCodeSendFailed
+				if errors.Is(err, telemetry.ErrDisabled) {
+					code = errorCodeDisabled
+				}
+				wrapped := format.WithErrorCode(err, code)
+				return formatter.PrintTotalFailureSummary(operation, wrapped, format.ErrorCode(wrapped))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(report)
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Sent the report of %d scan(s) to %s", report.Scans, reporter.Endpoint()))
+		},
+	}
+}
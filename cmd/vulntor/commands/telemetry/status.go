@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+)
+
+func newStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is enabled and when it reports",
+		Long: `Show whether telemetry is enabled, where reports are sent, the anonymous
+install ID identifying them, and when the last and next reports are due.`,
+		Example: `  vulntor telemetry status
+  vulntor telemetry status --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			const operation = "telemetry status"
+
+			reporter, closeBackend, err := newReporter(cmd)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, format.ErrorCode(err))
+			}
+			defer closeBackend()
+
+			state, err := reporter.State()
+			if err != nil {
+				return formatter.PrintTotalFailureSummary(operation, err, format.ErrorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				out := map[string]any{
+					"enabled":    reporter.Enabled(),
+					"endpoint":   reporter.Endpoint(),
+					"interval":   reporter.Interval().String(),
+					"install_id": state.InstallID,
+				}
+				if !state.LastReport.IsZero() {
+					out["last_report"] = state.LastReport.UTC()
+				}
+				if reporter.Enabled() {
+					out["next_report"] = reporter.NextReport(state).UTC().Truncate(time.Second)
+				}
+				return formatter.PrintStructured(out)
+			}
+
+			enabled, next := "no", "never (disabled)"
+			if reporter.Enabled() {
+				enabled = "yes"
+				next = "after the next scan"
+				if !state.LastReport.IsZero() {
+					next = "after the first scan from " + reporter.NextReport(state).UTC().Format("2006-01-02 15:04 MST")
+				}
+			}
+			last := "never"
+			if !state.LastReport.IsZero() {
+				last = state.LastReport.UTC().Format("2006-01-02 15:04 MST")
+			}
+			rows := [][]string{
+				{"Enabled", enabled},
+				{"Endpoint", reporter.Endpoint()},
+				{"Interval", reporter.Interval().String()},
+				{"Install ID", state.InstallID},
+				{"Last Report", last},
+				{"Next Report", next},
+			}
+			if err := formatter.PrintTable([]string{"Setting", "Value"}, rows); err != nil {
+				return err
+			}
+			if !reporter.Enabled() {
+				return formatter.PrintSummary("Telemetry is disabled; set telemetry.enabled to opt in. 'vulntor telemetry preview' shows what would be sent.")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package wordlist
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+func newAddCommand() *cobra.Command {
+	var (
+		kind        string
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <name> <file>",
+		Short: "Add a wordlist from a local file",
+		Long: `Add a wordlist from a local file, replacing a wordlist of the same name.
+
+Names may contain letters, digits, dashes and underscores. Wordlists added
+from local files are left alone by "vulntor wordlist update".`,
+		Example: `  vulntor wordlist add tomcat-passwords ./tomcat-passwords.txt --kind passwords
+  vulntor wordlist add admin-paths ./paths.txt --kind paths --description "Admin consoles"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			name, file := args[0], args[1]
+
+			store, err := openStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("add wordlist", err, errorCode(err))
+			}
+
+			data, err := os.ReadFile(file) // #nosec G304 -- the user names the file
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("add wordlist", err, errorCode(err))
+			}
+			w, err := store.Save(wordlist.Wordlist{
+				Name:        name,
+				Kind:        wordlist.Kind(kind),
+				Description: description,
+			}, data)
+			audit.RecordCLI(cmd.Context(), "wordlist.add", name, err, nil)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("add wordlist", err, errorCode(err))
+			}
+
+			log.Info().
+				Str("component", "wordlist").
+				Str("wordlist", name).
+				Int("entries", w.Entries).
+				Msg("Wordlist added")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(w)
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Wordlist %s added with %d entries (%s)", w.Name, w.Entries, w.Checksum))
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "", "What the entries are: usernames, passwords, paths or other (default other)")
+	cmd.Flags().StringVar(&description, "description", "", "Description of the wordlist")
+
+	return cmd
+}
@@ -0,0 +1,94 @@
+package wordlist
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+func newListCommand() *cobra.Command {
+	var available bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List wordlists",
+		Long: `List the wordlists of the workspace, or with --available the wordlists
+the configured sources offer.`,
+		Example: `  vulntor wordlist list
+  vulntor wordlist list --available`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			store, err := openStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list wordlists", err, errorCode(err))
+			}
+
+			if available {
+				updater, err := newUpdater(cmd, store)
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("list available wordlists", err, errorCode(err))
+				}
+				offered, err := updater.Available(cmd.Context())
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("list available wordlists", err, errorCode(err))
+				}
+				return printAvailable(formatter, offered)
+			}
+
+			lists, err := store.List()
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("list wordlists", err, errorCode(err))
+			}
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"wordlists": lists, "count": len(lists)})
+			}
+
+			if len(lists) == 0 {
+				return formatter.PrintSummary("No wordlists found.")
+			}
+
+			rows := make([][]string, 0, len(lists))
+			for _, w := range lists {
+				rows = append(rows, []string{w.Name, string(w.Kind), strconv.Itoa(w.Entries), w.Source, w.UpdatedAt.Format("2006-01-02")})
+			}
+			if err := formatter.PrintTable([]string{"Name", "Kind", "Entries", "Source", "Updated"}, rows); err != nil {
+				return err
+			}
+			return formatter.PrintSummary(fmt.Sprintf("Found %d wordlist(s)", len(lists)))
+		},
+	}
+
+	cmd.Flags().BoolVar(&available, "available", false, "List the wordlists the configured sources offer")
+	cmd.Flags().String("limit-rate", "", "Maximum download rate per second, e.g. 512KB (overrides bandwidth.limit)")
+
+	return cmd
+}
+
+func printAvailable(formatter format.Formatter, offered []wordlist.Available) error {
+	if formatter.IsStructured() {
+		return formatter.PrintStructured(map[string]any{"wordlists": offered, "count": len(offered)})
+	}
+
+	if len(offered) == 0 {
+		return formatter.PrintSummary("No wordlists available.")
+	}
+
+	rows := make([][]string, 0, len(offered))
+	for _, a := range offered {
+		description := a.Description
+		if description == "" {
+			description = "-"
+		}
+		rows = append(rows, []string{a.Name, string(a.Kind), a.Source, description})
+	}
+	if err := formatter.PrintTable([]string{"Name", "Kind", "Source", "Description"}, rows); err != nil {
+		return err
+	}
+	return formatter.PrintSummary(fmt.Sprintf("Found %d available wordlist(s)", len(offered)))
+}
@@ -0,0 +1,51 @@
+package wordlist
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/audit"
+)
+
+func newRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a wordlist",
+		Long: `Remove a wordlist from the workspace.
+
+Plugins referencing it fail to load until a wordlist of that name is added
+or installed again.`,
+		Example: `  vulntor wordlist remove tomcat-passwords`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+			name := args[0]
+
+			store, err := openStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("remove wordlist", err, errorCode(err))
+			}
+
+			err = store.Remove(name)
+			audit.RecordCLI(cmd.Context(), "wordlist.remove", name, err, nil)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("remove wordlist", err, errorCode(err))
+			}
+
+			log.Info().
+				Str("component", "wordlist").
+				Str("wordlist", name).
+				Msg("Wordlist removed")
+
+			if formatter.IsStructured() {
+				return formatter.PrintStructured(map[string]any{"name": name, "removed": true})
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ Wordlist %s removed", name))
+		},
+	}
+
+	return cmd
+}
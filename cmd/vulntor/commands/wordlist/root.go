@@ -0,0 +1,89 @@
+// Package wordlist provides CLI commands for managing the wordlists of the
+// workspace.
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+// Error codes of the wordlist commands; docs pages are keyed by the
+// WORDLIST_ prefix.
+const (
+	errorCodeNotFound           = "WORDLIST_NOT_FOUND"
+	errorCodeChecksumMismatch   = "WORDLIST_CHECKSUM_MISMATCH"
+	errorCodeInvalid            = "WORDLIST_INVALID"
+	errorCodeSourceNotAvailable = "WORDLIST_SOURCE_NOT_AVAILABLE"
+)
+
+// NewCommand creates the 'vulntor wordlist' command group.
+//
+// Wordlists are named lists of user names, passwords or paths kept in the
+// workspace. Plugins reference them as {{wordlist:<name>}}, for example in
+// the usernames and passwords of default credential checks.
+//
+// Example usage:
+//
+//	vulntor wordlist add tomcat-passwords ./passwords.txt --kind passwords
+//	vulntor wordlist update
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wordlist",
+		Short: "Manage wordlists",
+		Long: `Manage the named wordlists plugins and default credential checks use.
+
+A wordlist is a text file with one user name, password or path per line;
+blank lines and lines starting with # are ignored. Wordlists are kept in
+<workspace>/wordlists with the checksum of each, and plugins reference
+them as {{wordlist:<name>}} when they ship no wordlist of that name.
+
+Wordlists are added from local files or installed from the sources
+configured in wordlists.sources, whose checksums are verified on download.
+"vulntor wordlist update" refreshes the installed ones.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newListCommand())
+	cmd.AddCommand(newAddCommand())
+	cmd.AddCommand(newRemoveCommand())
+	cmd.AddCommand(newVerifyCommand())
+	cmd.AddCommand(newUpdateCommand())
+
+	return cmd
+}
+
+// openStore returns the wordlist store of the workspace.
+func openStore(ctx context.Context) (*wordlist.Store, error) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return wordlist.NewStore(filepath.Join(cfg.WorkspaceRoot, wordlist.DirName)), nil
+}
+
+// errorCode classifies wordlist errors.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, wordlist.ErrNotFound):
+		return errorCodeNotFound
+	case errors.Is(err, wordlist.ErrChecksumMismatch):
+		return errorCodeChecksumMismatch
+	case errors.Is(err, wordlist.ErrSourceNotAvailable):
+		return errorCodeSourceNotAvailable
+	case errors.Is(err, wordlist.ErrInvalid):
+		return errorCodeInvalid
+	}
+	return format.ErrorCode(err)
+}
@@ -0,0 +1,111 @@
+package wordlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+func runWordlistCommand(t *testing.T, ctx context.Context, args ...string) string {
+	t.Helper()
+	cmd := NewCommand()
+	format.AddFlags(cmd.PersistentFlags()) // Global flags of the root command
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	return out.String()
+}
+
+func TestWordlistCommand_Lifecycle(t *testing.T) {
+	root := t.TempDir()
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+
+	file := filepath.Join(t.TempDir(), "passwords.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# Tomcat\ntomcat\ns3cret\n"), 0o644))
+
+	out := runWordlistCommand(t, ctx, "add", "tomcat-passwords", file, "--kind", "passwords")
+	require.Contains(t, out, "Wordlist tomcat-passwords added with 2 entries (sha256:")
+	require.FileExists(t, filepath.Join(root, wordlist.DirName, "tomcat-passwords.txt"))
+
+	out = runWordlistCommand(t, ctx, "list", "--output", "json")
+	var listed struct {
+		Wordlists []wordlist.Wordlist `json:"wordlists"`
+		Count     int                 `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &listed))
+	require.Equal(t, 1, listed.Count)
+	require.Equal(t, wordlist.KindPasswords, listed.Wordlists[0].Kind)
+	require.Equal(t, wordlist.LocalSource, listed.Wordlists[0].Source)
+
+	require.Contains(t, runWordlistCommand(t, ctx, "verify"), "1 wordlist(s) verified")
+	require.NoError(t, os.WriteFile(filepath.Join(root, wordlist.DirName, "tomcat-passwords.txt"), []byte("edited\n"), 0o644))
+	out = runWordlistCommand(t, ctx, "verify")
+	require.Contains(t, out, "✗ Failed to verify wordlists")
+	require.Contains(t, runWordlistCommand(t, ctx, "verify", "--output", "json"), "WORDLIST_CHECKSUM_MISMATCH")
+
+	out = runWordlistCommand(t, ctx, "remove", "tomcat-passwords")
+	require.Contains(t, out, "Wordlist tomcat-passwords removed")
+	require.Contains(t, runWordlistCommand(t, ctx, "list"), "No wordlists found.")
+
+	out = runWordlistCommand(t, ctx, "remove", "tomcat-passwords", "--output", "json")
+	require.Contains(t, out, "WORDLIST_NOT_FOUND")
+
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	events, err := backend.Audit().List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "wordlist"})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, "wordlist.remove", events[0].Action)
+}
+
+func TestWordlistCommand_Update(t *testing.T) {
+	content := "tomcat\ns3cret\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/index.yaml" {
+			_, _ = fmt.Fprintf(w, "wordlists:\n  - {name: tomcat-passwords, kind: passwords, url: tomcat-passwords.txt, checksum: %q}\n", wordlist.Checksum([]byte(content)))
+			return
+		}
+		_, _ = fmt.Fprint(w, content)
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	configFile := filepath.Join(root, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf("wordlists:\n  sources:\n    - name: official\n      url: %s/index.yaml\n", server.URL)), 0o644))
+	cfgMgr := config.NewManager()
+	require.NoError(t, cfgMgr.LoadWithSources([]config.ConfigSource{&config.FileSource{Path: configFile}}))
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+	ctx = appctx.WithConfig(ctx, cfgMgr)
+
+	require.Contains(t, runWordlistCommand(t, ctx, "list", "--available"), "tomcat-passwords")
+
+	out := runWordlistCommand(t, ctx, "update", "tomcat-passwords")
+	require.Contains(t, out, "installed")
+	require.Contains(t, out, "1 wordlist(s) up to date")
+
+	out = runWordlistCommand(t, ctx, "update")
+	require.Contains(t, out, "current")
+
+	out = runWordlistCommand(t, ctx, "update", "ssh-usernames", "--output", "json")
+	require.Contains(t, out, "WORDLIST_SOURCE_NOT_AVAILABLE")
+
+	// Without sources nothing can be updated
+	out = runWordlistCommand(t, storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root}), "update")
+	require.Contains(t, out, "wordlists.sources")
+}
@@ -0,0 +1,136 @@
+package wordlist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/bind"
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+func newUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update [name...]",
+		Short: "Install or update wordlists from remote sources",
+		Long: `Install the named wordlists from the sources configured in
+wordlists.sources, or update them when the source publishes a new version.
+Without names, every installed wordlist is updated; wordlists added from
+local files are left alone.
+
+Sources are tried in order and downloads are rejected unless they match
+the checksum the source publishes. Downloads go through the configured
+proxy and bandwidth limit.`,
+		Example: `  vulntor wordlist update tomcat-passwords
+  vulntor wordlist update --limit-rate 512KB`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			store, err := openStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("update wordlists", err, errorCode(err))
+			}
+			updater, err := newUpdater(cmd, store)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("update wordlists", err, errorCode(err))
+			}
+
+			results, err := updater.Update(cmd.Context(), args...)
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("update wordlists", err, errorCode(err))
+			}
+
+			var (
+				failed   []string
+				firstErr error
+			)
+			for _, r := range results {
+				if r.Status == wordlist.StatusFailed {
+					failed = append(failed, r.Name)
+					if firstErr == nil {
+						firstErr = r.Err
+					}
+				}
+				// Wordlists already current changed nothing
+				if r.Status != wordlist.StatusCurrent {
+					audit.RecordCLI(cmd.Context(), "wordlist.update", r.Name, r.Err, map[string]string{"source": r.Source, "status": r.Status})
+				}
+				log.Debug().
+					Str("component", "wordlist").
+					Str("wordlist", r.Name).
+					Str("status", r.Status).
+					Msg("Wordlist update")
+			}
+
+			if formatter.IsStructured() {
+				if err := formatter.PrintStructured(map[string]any{"wordlists": results, "count": len(results)}); err != nil {
+					return err
+				}
+			} else if len(results) > 0 {
+				rows := make([][]string, 0, len(results))
+				for _, r := range results {
+					status := r.Status
+					if r.Error != "" {
+						status += ": " + r.Error
+					}
+					source := r.Source
+					if source == "" {
+						source = "-"
+					}
+					rows = append(rows, []string{r.Name, source, status})
+				}
+				if err := formatter.PrintTable([]string{"Name", "Source", "Status"}, rows); err != nil {
+					return err
+				}
+			}
+
+			if len(failed) > 0 {
+				err := fmt.Errorf("%d of %d wordlist(s) failed: %s", len(failed), len(results), strings.Join(failed, ", "))
+				return formatter.PrintTotalFailureSummary("update wordlists", err, errorCode(firstErr))
+			}
+			if formatter.IsStructured() {
+				return nil
+			}
+			if len(results) == 0 {
+				return formatter.PrintSummary("No installed wordlists to update.")
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ %d wordlist(s) up to date", len(results)))
+		},
+	}
+
+	cmd.Flags().String("limit-rate", "", "Maximum download rate per second, e.g. 512KB (overrides bandwidth.limit)")
+
+	return cmd
+}
+
+// newUpdater returns an updater of store drawing on the configured sources,
+// proxy and bandwidth limit.
+func newUpdater(cmd *cobra.Command, store *wordlist.Store) (*wordlist.Updater, error) {
+	updater := &wordlist.Updater{Store: store}
+
+	var bandwidthConfig config.BandwidthConfig
+	if cfgMgr, ok := appctx.Config(cmd.Context()); ok {
+		cfg := cfgMgr.Get()
+		bandwidthConfig = cfg.Bandwidth
+		for _, s := range cfg.Wordlists.Sources {
+			updater.Sources = append(updater.Sources, wordlist.Source{Name: s.Name, URL: s.URL})
+		}
+		proxy, err := cfg.Proxy.Proxy()
+		if err != nil {
+			return nil, fmt.Errorf("proxy: %w", err)
+		}
+		updater.Proxy = proxy
+	}
+	limiter, err := bind.BindBandwidthLimit(cmd, bandwidthConfig)
+	if err != nil {
+		return nil, err
+	}
+	updater.Limiter = limiter
+	return updater, nil
+}
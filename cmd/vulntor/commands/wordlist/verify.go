@@ -0,0 +1,91 @@
+package wordlist
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+)
+
+// verifyResult is the outcome of verifying one wordlist.
+type verifyResult struct {
+	Name  string `json:"name"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+func newVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify [name...]",
+		Short: "Verify wordlist checksums",
+		Long: `Verify wordlist files against the checksums recorded when they were
+added or installed, reporting files modified or deleted since. Without
+names, every wordlist is verified.
+
+Run "vulntor wordlist update <name>" to restore an installed wordlist.`,
+		Example: `  vulntor wordlist verify
+  vulntor wordlist verify tomcat-passwords`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			formatter := format.FromCommand(cmd)
+
+			store, err := openStore(cmd.Context())
+			if err != nil {
+				return formatter.PrintTotalFailureSummary("verify wordlists", err, errorCode(err))
+			}
+
+			names := args
+			if len(names) == 0 {
+				lists, err := store.List()
+				if err != nil {
+					return formatter.PrintTotalFailureSummary("verify wordlists", err, errorCode(err))
+				}
+				for _, w := range lists {
+					names = append(names, w.Name)
+				}
+			}
+
+			results := make([]verifyResult, 0, len(names))
+			var firstErr error
+			for _, name := range names {
+				result := verifyResult{Name: name, Valid: true}
+				if err := store.Verify(name); err != nil {
+					result.Valid = false
+					result.Error = err.Error()
+					if firstErr == nil {
+						firstErr = err
+					}
+				}
+				results = append(results, result)
+			}
+
+			if formatter.IsStructured() {
+				if err := formatter.PrintStructured(map[string]any{"wordlists": results, "count": len(results)}); err != nil {
+					return err
+				}
+			} else if len(results) > 0 {
+				rows := make([][]string, 0, len(results))
+				for _, r := range results {
+					status := "✓ ok"
+					if !r.Valid {
+						status = "✗ " + r.Error
+					}
+					rows = append(rows, []string{r.Name, status})
+				}
+				if err := formatter.PrintTable([]string{"Name", "Status"}, rows); err != nil {
+					return err
+				}
+			}
+
+			if firstErr != nil {
+				return formatter.PrintTotalFailureSummary("verify wordlists", firstErr, errorCode(firstErr))
+			}
+			if formatter.IsStructured() {
+				return nil
+			}
+			return formatter.PrintSummary(fmt.Sprintf("✓ %d wordlist(s) verified", len(results)))
+		},
+	}
+
+	return cmd
+}
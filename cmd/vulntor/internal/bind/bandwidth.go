@@ -0,0 +1,33 @@
+package bind
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// BindBandwidthLimit returns the download rate limiter of a command: the
+// --limit-rate flag when set, otherwise the bandwidth.limit setting of cfg.
+// It returns nil when neither sets a limit.
+//
+// Flags read:
+//   - --limit-rate: Maximum download rate per second (e.g., "512KB", "2MiB")
+func BindBandwidthLimit(cmd *cobra.Command, cfg config.BandwidthConfig) (*bandwidth.Limiter, error) {
+	limit, _ := cmd.Flags().GetString("limit-rate")
+	if limit == "" {
+		limiter, err := cfg.Limiter()
+		if err != nil {
+			return nil, fmt.Errorf("bandwidth.%w", err)
+		}
+		return limiter, nil
+	}
+
+	rate, err := config.ParseByteSize(limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --limit-rate: %w", err)
+	}
+	return bandwidth.NewLimiter(rate), nil
+}
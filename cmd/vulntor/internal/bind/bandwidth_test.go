@@ -0,0 +1,42 @@
+package bind
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func TestBindBandwidthLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		cfg      config.BandwidthConfig
+		wantRate int64
+		wantErr  string
+	}{
+		{name: "unlimited"},
+		{name: "config", cfg: config.BandwidthConfig{Limit: "1MB"}, wantRate: 1e6},
+		{name: "flag overrides config", flag: "256KiB", cfg: config.BandwidthConfig{Limit: "1MB"}, wantRate: 256 << 10},
+		{name: "invalid flag", flag: "slow", wantErr: `invalid --limit-rate: invalid size "slow"`},
+		{name: "invalid config", cfg: config.BandwidthConfig{Limit: "slow"}, wantErr: `bandwidth.limit: invalid size "slow"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("limit-rate", "", "Rate limit")
+			_ = cmd.Flags().Set("limit-rate", tt.flag)
+
+			limiter, err := BindBandwidthLimit(cmd, tt.cfg)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRate, limiter.Rate())
+		})
+	}
+}
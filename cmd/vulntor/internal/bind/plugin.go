@@ -33,11 +33,13 @@ func formatList(items []string) string {
 // Flags read:
 //   - --source: Optional plugin source name
 //   - --force: Force re-install flag
+//   - --allow-untrusted: Allow plugins from untrusted sources
 //
 // Returns an error if validation fails.
 func BindInstallOptions(cmd *cobra.Command) (plugin.InstallOptions, error) {
 	source, _ := cmd.Flags().GetString("source")
 	force, _ := cmd.Flags().GetBool("force")
+	allowUntrusted, _ := cmd.Flags().GetBool("allow-untrusted")
 
 	// Validate source whitelist (CLI layer - early validation)
 	if source != "" && !plugin.IsValidSource(source) {
@@ -46,8 +48,9 @@ func BindInstallOptions(cmd *cobra.Command) (plugin.InstallOptions, error) {
 	}
 
 	opts := plugin.InstallOptions{
-		Source: source,
-		Force:  force,
+		Source:         source,
+		Force:          force,
+		AllowUntrusted: allowUntrusted,
 	}
 
 	return opts, nil
@@ -63,10 +66,11 @@ func BindInstallOptions(cmd *cobra.Command) (plugin.InstallOptions, error) {
 //   - Service layer: Redundant validation for safety (service.Update)
 //
 // Flags read:
-//   - --category: Optional category filter (ssh, http, tls, database, network, misc)
+//   - --category: Optional category filter (e.g., ssh, database/mysql, web/*)
 //   - --source: Optional plugin source name
 //   - --force: Force re-download flag
 //   - --dry-run: Dry run mode (preview only)
+//   - --allow-untrusted: Allow plugins from untrusted sources
 //
 // Returns an error if validation fails (e.g., invalid category or source).
 func BindUpdateOptions(cmd *cobra.Command) (plugin.UpdateOptions, error) {
@@ -74,6 +78,7 @@ func BindUpdateOptions(cmd *cobra.Command) (plugin.UpdateOptions, error) {
 	source, _ := cmd.Flags().GetString("source")
 	force, _ := cmd.Flags().GetBool("force")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	allowUntrusted, _ := cmd.Flags().GetBool("allow-untrusted")
 
 	// Validate category whitelist (CLI layer - early validation)
 	if category != "" && !plugin.IsValidCategory(category) {
@@ -88,9 +93,10 @@ func BindUpdateOptions(cmd *cobra.Command) (plugin.UpdateOptions, error) {
 	}
 
 	opts := plugin.UpdateOptions{
-		Source: source,
-		Force:  force,
-		DryRun: dryRun,
+		Source:         source,
+		Force:          force,
+		DryRun:         dryRun,
+		AllowUntrusted: allowUntrusted,
 	}
 
 	// Convert category string to Category type
@@ -112,7 +118,7 @@ func BindUpdateOptions(cmd *cobra.Command) (plugin.UpdateOptions, error) {
 //
 // Flags read:
 //   - --all: Uninstall all plugins flag
-//   - --category: Optional category filter (ssh, http, tls, database, network, misc)
+//   - --category: Optional category filter (e.g., ssh, database/mysql, web/*)
 //
 // Returns an error if validation fails or if conflicting flags are provided.
 func BindUninstallOptions(cmd *cobra.Command) (plugin.UninstallOptions, error) {
@@ -150,6 +156,7 @@ func BindUninstallOptions(cmd *cobra.Command) (plugin.UninstallOptions, error) {
 // Flags read:
 //   - --older-than: Duration string for removing old cache entries (e.g., "720h" for 30 days)
 //   - --dry-run: Dry run mode (preview only)
+//   - --allow-untrusted: Allow plugins from untrusted sources
 //
 // Returns an error if the duration string is invalid.
 func BindCleanOptions(cmd *cobra.Command) (plugin.CleanOptions, error) {
@@ -28,6 +28,16 @@ func TestBindInstallOptions(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "allow untrusted",
+			flags: map[string]interface{}{
+				"allow-untrusted": true,
+			},
+			want: plugin.InstallOptions{
+				AllowUntrusted: true,
+			},
+			wantErr: false,
+		},
 		{
 			name: "only source set",
 			flags: map[string]interface{}{
@@ -292,6 +302,7 @@ func setupInstallCommand(flags map[string]interface{}) *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.Flags().String("source", "", "Plugin source")
 	cmd.Flags().Bool("force", false, "Force install")
+	cmd.Flags().Bool("allow-untrusted", false, "Allow untrusted sources")
 
 	// Set flag values
 	if source, ok := flags["source"].(string); ok {
@@ -302,6 +313,11 @@ func setupInstallCommand(flags map[string]interface{}) *cobra.Command {
 			_ = cmd.Flags().Set("force", "true")
 		}
 	}
+	if allow, ok := flags["allow-untrusted"].(bool); ok {
+		if allow {
+			_ = cmd.Flags().Set("allow-untrusted", "true")
+		}
+	}
 
 	return cmd
 }
@@ -3,6 +3,10 @@ package bind
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/vulntor/vulntor/cmd/vulntor/internal/format"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/report"
 	"github.com/vulntor/vulntor/pkg/scanexec"
 )
 
@@ -22,14 +26,26 @@ import (
 //   - --no-discover: Skip discovery phase
 //   - --progress: Print live progress updates
 //   - --fingerprint-cache: Fingerprint catalog cache directory
-//   - --output: Output format (text, json, yaml)
+//   - --output: Output format (text, json, yaml), or --json for json
 //   - --timeout: Network operation timeout
 //   - --concurrency: Parallel operation concurrency
+//   - --auto-tune: Tune concurrency and timeouts during the scan
+//   - --verify-timeouts: Probe timed-out ports again at the end of discovery
+//   - --pcap: Record probe traffic as pcap artifacts (all, findings)
 //   - --ping: Enable ICMP host discovery
 //   - --ping-count: Number of ICMP pings per host
 //   - --allow-loopback: Allow scanning loopback addresses
+//   - --interface: Network interface probes leave by
+//   - --source-ip: Source IP address of probes
+//   - --decoys: IPv4 addresses ICMP host discovery also pings from
+//   - --scope-ack: Signed acknowledgment approving out-of-scope targets
+//   - --client, --authorization, --tester, --window: Engagement record
+//   - --default-creds: Test default credentials (implies --vuln)
+//   - --content-discovery: Request a wordlist of paths from HTTP services
+//   - --environment: Environment selecting the severity overrides
 //
-// Returns an error if validation fails (e.g., conflicting flags).
+// Returns an error if validation fails (e.g., conflicting flags, an
+// unknown capture mode or an invalid engagement window).
 func BindScanOptions(cmd *cobra.Command, targets []string) (scanexec.Params, error) {
 	ports, _ := cmd.Flags().GetString("ports")
 	profile, _ := cmd.Flags().GetString("profile")
@@ -41,41 +57,93 @@ func BindScanOptions(cmd *cobra.Command, targets []string) (scanexec.Params, err
 	skipDiscover, _ := cmd.Flags().GetBool("no-discover")
 	progress, _ := cmd.Flags().GetBool("progress")
 	fingerprintCache, _ := cmd.Flags().GetString("fingerprint-cache")
-	output, _ := cmd.Flags().GetString("output")
+	output := format.OutputFlag(cmd)
 	timeout, _ := cmd.Flags().GetString("timeout")
 	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	autoTune, _ := cmd.Flags().GetBool("auto-tune")
+	verifyTimeouts, _ := cmd.Flags().GetBool("verify-timeouts")
+	pcap, _ := cmd.Flags().GetString("pcap")
 	ping, _ := cmd.Flags().GetBool("ping")
 	pingCount, _ := cmd.Flags().GetInt("ping-count")
 	allowLoopback, _ := cmd.Flags().GetBool("allow-loopback")
+	iface, _ := cmd.Flags().GetString("interface")
+	sourceIP, _ := cmd.Flags().GetString("source-ip")
+	decoys, _ := cmd.Flags().GetStringSlice("decoys")
+	scopeAck, _ := cmd.Flags().GetString("scope-ack")
+	nucleiTemplates, _ := cmd.Flags().GetStringSlice("nuclei-templates")
+	defaultCreds, _ := cmd.Flags().GetBool("default-creds")
+	contentDiscovery, _ := cmd.Flags().GetBool("content-discovery")
+	environment, _ := cmd.Flags().GetString("environment")
+	client, _ := cmd.Flags().GetString("client")
+	authorization, _ := cmd.Flags().GetString("authorization")
+	tester, _ := cmd.Flags().GetString("tester")
+	window, _ := cmd.Flags().GetString("window")
 
 	// Validate conflicting flags
 	if onlyDiscover && skipDiscover {
 		return scanexec.Params{}, scanexec.ErrConflictingDiscoveryFlags
 	}
 
-	// If only-discover is set, disable vuln automatically
-	enableVuln := vuln
+	var captureMode capture.Mode
+	if pcap != "" {
+		mode, err := capture.ParseMode(pcap)
+		if err != nil {
+			return scanexec.Params{}, err
+		}
+		captureMode = mode
+	}
+
+	// Unset engagement fields are completed from the config by the service
+	var record *engagement.Record
+	if client != "" || authorization != "" || tester != "" || window != "" {
+		record = &engagement.Record{Client: client, Authorization: authorization, Tester: tester}
+		if window != "" {
+			var err error
+			if record.WindowStart, record.WindowEnd, err = engagement.ParseWindow(window); err != nil {
+				return scanexec.Params{}, err
+			}
+		}
+	}
+
+	// Accepted default credentials are reported as vulnerabilities. If
+	// only-discover is set, disable both automatically
+	enableVuln := vuln || defaultCreds
 	if onlyDiscover {
 		enableVuln = false
+		defaultCreds = false
+		contentDiscovery = false
 	}
 
 	// Build params
 	params := scanexec.Params{
-		Targets:       targets,
-		Ports:         ports,
-		Profile:       profile,
-		Level:         level,
-		IncludeTags:   includeTags,
-		ExcludeTags:   excludeTags,
-		EnableVuln:    enableVuln,
-		OnlyDiscover:  onlyDiscover,
-		SkipDiscover:  skipDiscover,
-		OutputFormat:  output,
-		CustomTimeout: timeout,
-		Concurrency:   concurrency,
-		EnablePing:    ping,
-		PingCount:     pingCount,
-		AllowLoopback: allowLoopback,
+		Targets:         targets,
+		Ports:           ports,
+		Profile:         profile,
+		Level:           level,
+		IncludeTags:     includeTags,
+		ExcludeTags:     excludeTags,
+		EnableVuln:      enableVuln,
+		OnlyDiscover:    onlyDiscover,
+		SkipDiscover:    skipDiscover,
+		OutputFormat:    output,
+		CustomTimeout:   timeout,
+		Concurrency:     concurrency,
+		EnablePing:      ping,
+		PingCount:       pingCount,
+		AllowLoopback:   allowLoopback,
+		Interface:       iface,
+		SourceIP:        sourceIP,
+		Decoys:          decoys,
+		ScopeAck:        scopeAck,
+		Engagement:      record,
+		NucleiTemplates: nucleiTemplates,
+
+		DefaultCredentials: defaultCreds,
+		ContentDiscovery:   contentDiscovery,
+		Environment:        environment,
+		AutoTune:           autoTune,
+		VerifyTimeouts:     verifyTimeouts,
+		Capture:            captureMode,
 	}
 
 	// Store additional flags in RawInputs for potential use
@@ -86,3 +154,15 @@ func BindScanOptions(cmd *cobra.Command, targets []string) (scanexec.Params, err
 
 	return params, nil
 }
+
+// BindScanGate extracts and validates the CI gate flags of the scan
+// command.
+//
+// Flags read:
+//   - --fail-on: Lowest finding severity that fails the scan
+//   - --fail-on-cve: CVE IDs that fail the scan when found
+func BindScanGate(cmd *cobra.Command) (report.Gate, error) {
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	cves, _ := cmd.Flags().GetStringSlice("fail-on-cve")
+	return report.NewGate(failOn, cves)
+}
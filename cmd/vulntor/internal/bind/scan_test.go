@@ -3,10 +3,13 @@ package bind
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/engagement"
 	"github.com/vulntor/vulntor/pkg/scanexec"
 )
 
@@ -36,26 +39,40 @@ func TestBindScanOptions(t *testing.T) {
 				"output":            "json",
 				"timeout":           "5s",
 				"concurrency":       100,
+				"auto-tune":         true,
+				"verify-timeouts":   true,
+				"pcap":              "findings",
 				"ping":              true,
 				"ping-count":        2,
 				"allow-loopback":    true,
+				"interface":         "eth1",
+				"source-ip":         "10.0.0.2",
+				"decoys":            []string{"10.0.0.50", "10.0.0.51"},
+				"scope-ack":         "eyJ0YXJnZXRzIjpbXX0.sig",
 			},
 			want: scanexec.Params{
-				Targets:       []string{"192.168.1.0/24"},
-				Ports:         "22,80,443",
-				Profile:       "quick",
-				Level:         "comprehensive",
-				IncludeTags:   []string{"discovery", "scan"},
-				ExcludeTags:   []string{"slow"},
-				EnableVuln:    true,
-				OnlyDiscover:  false,
-				SkipDiscover:  false,
-				OutputFormat:  "json",
-				CustomTimeout: "5s",
-				Concurrency:   100,
-				EnablePing:    true,
-				PingCount:     2,
-				AllowLoopback: true,
+				Targets:        []string{"192.168.1.0/24"},
+				Ports:          "22,80,443",
+				Profile:        "quick",
+				Level:          "comprehensive",
+				IncludeTags:    []string{"discovery", "scan"},
+				ExcludeTags:    []string{"slow"},
+				EnableVuln:     true,
+				OnlyDiscover:   false,
+				SkipDiscover:   false,
+				OutputFormat:   "json",
+				CustomTimeout:  "5s",
+				Concurrency:    100,
+				AutoTune:       true,
+				VerifyTimeouts: true,
+				Capture:        capture.ModeFindings,
+				EnablePing:     true,
+				PingCount:      2,
+				AllowLoopback:  true,
+				Interface:      "eth1",
+				SourceIP:       "10.0.0.2",
+				Decoys:         []string{"10.0.0.50", "10.0.0.51"},
+				ScopeAck:       "eyJ0YXJnZXRzIjpbXX0.sig",
 			},
 			wantErr: false,
 		},
@@ -244,6 +261,94 @@ func TestBindScanOptions(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "vuln with nuclei templates",
+			targets: []string{"10.0.0.5"},
+			flags: map[string]interface{}{
+				"vuln":             true,
+				"nuclei-templates": []string{"./nuclei-templates/http/exposures", "custom.yaml"},
+			},
+			want: scanexec.Params{
+				Targets:         []string{"10.0.0.5"},
+				Level:           "default",
+				IncludeTags:     []string{},
+				ExcludeTags:     []string{},
+				EnableVuln:      true,
+				OutputFormat:    "text",
+				CustomTimeout:   "1s",
+				Concurrency:     50,
+				EnablePing:      true,
+				PingCount:       1,
+				NucleiTemplates: []string{"./nuclei-templates/http/exposures", "custom.yaml"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "default credentials imply vuln",
+			targets: []string{"10.0.0.6"},
+			flags: map[string]interface{}{
+				"default-creds": true,
+			},
+			want: scanexec.Params{
+				Targets:            []string{"10.0.0.6"},
+				Level:              "default",
+				IncludeTags:        []string{},
+				ExcludeTags:        []string{},
+				EnableVuln:         true,
+				OutputFormat:       "text",
+				CustomTimeout:      "1s",
+				Concurrency:        50,
+				EnablePing:         true,
+				PingCount:          1,
+				NucleiTemplates:    []string{},
+				DefaultCredentials: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "content discovery",
+			targets: []string{"10.0.0.7"},
+			flags: map[string]interface{}{
+				"content-discovery": true,
+			},
+			want: scanexec.Params{
+				Targets:          []string{"10.0.0.7"},
+				Level:            "default",
+				IncludeTags:      []string{},
+				ExcludeTags:      []string{},
+				OutputFormat:     "text",
+				CustomTimeout:    "1s",
+				Concurrency:      50,
+				EnablePing:       true,
+				PingCount:        1,
+				NucleiTemplates:  []string{},
+				ContentDiscovery: true,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "environment",
+			targets: []string{"10.20.0.0/16"},
+			flags: map[string]interface{}{
+				"vuln":        true,
+				"environment": "ot",
+			},
+			want: scanexec.Params{
+				Targets:         []string{"10.20.0.0/16"},
+				Level:           "default",
+				IncludeTags:     []string{},
+				ExcludeTags:     []string{},
+				EnableVuln:      true,
+				OutputFormat:    "text",
+				CustomTimeout:   "1s",
+				Concurrency:     50,
+				EnablePing:      true,
+				PingCount:       1,
+				NucleiTemplates: []string{},
+				Environment:     "ot",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -273,9 +378,18 @@ func TestBindScanOptions(t *testing.T) {
 			require.Equal(t, tt.want.OutputFormat, got.OutputFormat)
 			require.Equal(t, tt.want.CustomTimeout, got.CustomTimeout)
 			require.Equal(t, tt.want.Concurrency, got.Concurrency)
+			require.Equal(t, tt.want.AutoTune, got.AutoTune)
+			require.Equal(t, tt.want.VerifyTimeouts, got.VerifyTimeouts)
+			require.Equal(t, tt.want.Capture, got.Capture)
 			require.Equal(t, tt.want.EnablePing, got.EnablePing)
 			require.Equal(t, tt.want.PingCount, got.PingCount)
 			require.Equal(t, tt.want.AllowLoopback, got.AllowLoopback)
+			require.Equal(t, tt.want.Interface, got.Interface)
+			require.Equal(t, tt.want.SourceIP, got.SourceIP)
+			require.ElementsMatch(t, tt.want.Decoys, got.Decoys)
+			require.Equal(t, tt.want.ScopeAck, got.ScopeAck)
+			require.ElementsMatch(t, tt.want.NucleiTemplates, got.NucleiTemplates)
+			require.Equal(t, tt.want.Environment, got.Environment)
 
 			// Verify RawInputs is populated
 			require.NotNil(t, got.RawInputs)
@@ -284,6 +398,61 @@ func TestBindScanOptions(t *testing.T) {
 }
 
 // setupScanCommand creates a mock command with scan flags
+func TestBindScanGate(t *testing.T) {
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("fail-on", "", "")
+		cmd.Flags().StringSlice("fail-on-cve", nil, "")
+		return cmd
+	}
+
+	gate, err := BindScanGate(newCmd())
+	require.NoError(t, err)
+	require.False(t, gate.Enabled())
+
+	cmd := newCmd()
+	require.NoError(t, cmd.Flags().Set("fail-on", "High"))
+	require.NoError(t, cmd.Flags().Set("fail-on-cve", "cve-2024-3094,CVE-2021-44228"))
+	gate, err = BindScanGate(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "high", gate.FailOn)
+	require.Equal(t, []string{"CVE-2024-3094", "CVE-2021-44228"}, gate.CVEs)
+
+	cmd = newCmd()
+	require.NoError(t, cmd.Flags().Set("fail-on", "urgent"))
+	_, err = BindScanGate(cmd)
+	require.ErrorContains(t, err, "invalid --fail-on severity")
+}
+
+func TestBindScanOptions_InvalidPcap(t *testing.T) {
+	cmd := setupScanCommand(map[string]interface{}{"pcap": "everything"})
+	_, err := BindScanOptions(cmd, []string{"10.0.0.1"})
+	require.EqualError(t, err, `invalid capture mode "everything" (expected all or findings)`)
+}
+
+func TestBindScanOptions_Engagement(t *testing.T) {
+	cmd := setupScanCommand(map[string]interface{}{
+		"client":        "ACME",
+		"authorization": "SOW-42",
+		"window":        "2026-12-01T08:00:00Z/2026-12-14T18:00:00Z",
+	})
+	got, err := BindScanOptions(cmd, []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, &engagement.Record{
+		Client:        "ACME",
+		Authorization: "SOW-42",
+		WindowStart:   time.Date(2026, 12, 1, 8, 0, 0, 0, time.UTC),
+		WindowEnd:     time.Date(2026, 12, 14, 18, 0, 0, 0, time.UTC),
+	}, got.Engagement)
+
+	got, err = BindScanOptions(setupScanCommand(map[string]interface{}{}), []string{"10.0.0.1"})
+	require.NoError(t, err)
+	require.Nil(t, got.Engagement, "left to the config without engagement flags")
+
+	_, err = BindScanOptions(setupScanCommand(map[string]interface{}{"window": "tomorrow"}), []string{"10.0.0.1"})
+	require.ErrorContains(t, err, "want FROM/UNTIL")
+}
+
 func setupScanCommand(flags map[string]interface{}) *cobra.Command {
 	cmd := &cobra.Command{}
 	cmd.Flags().String("ports", "", "Ports")
@@ -299,9 +468,24 @@ func setupScanCommand(flags map[string]interface{}) *cobra.Command {
 	cmd.Flags().String("output", "text", "Output format")
 	cmd.Flags().String("timeout", "1s", "Timeout")
 	cmd.Flags().Int("concurrency", 50, "Concurrency")
+	cmd.Flags().Bool("auto-tune", false, "Auto-tune")
+	cmd.Flags().Bool("verify-timeouts", false, "Verify timeouts")
+	cmd.Flags().String("pcap", "", "Packet capture")
 	cmd.Flags().Bool("ping", true, "Enable ping")
 	cmd.Flags().Int("ping-count", 1, "Ping count")
 	cmd.Flags().Bool("allow-loopback", false, "Allow loopback")
+	cmd.Flags().String("interface", "", "Interface")
+	cmd.Flags().String("source-ip", "", "Source IP")
+	cmd.Flags().StringSlice("decoys", []string{}, "Decoys")
+	cmd.Flags().String("scope-ack", "", "Scope acknowledgment")
+	cmd.Flags().StringSlice("nuclei-templates", []string{}, "Nuclei templates")
+	cmd.Flags().Bool("default-creds", false, "Default credentials")
+	cmd.Flags().Bool("content-discovery", false, "Content discovery")
+	cmd.Flags().String("environment", "", "Environment")
+	cmd.Flags().String("client", "", "Client")
+	cmd.Flags().String("authorization", "", "Authorization")
+	cmd.Flags().String("tester", "", "Tester")
+	cmd.Flags().String("window", "", "Window")
 
 	// Set flag values
 	if ports, ok := flags["ports"].(string); ok {
@@ -347,6 +531,15 @@ func setupScanCommand(flags map[string]interface{}) *cobra.Command {
 	if concurrency, ok := flags["concurrency"].(int); ok {
 		_ = cmd.Flags().Set("concurrency", fmt.Sprintf("%d", concurrency))
 	}
+	if autoTune, ok := flags["auto-tune"].(bool); ok && autoTune {
+		_ = cmd.Flags().Set("auto-tune", "true")
+	}
+	if verify, ok := flags["verify-timeouts"].(bool); ok && verify {
+		_ = cmd.Flags().Set("verify-timeouts", "true")
+	}
+	if pcap, ok := flags["pcap"].(string); ok {
+		_ = cmd.Flags().Set("pcap", pcap)
+	}
 	if ping, ok := flags["ping"].(bool); ok {
 		if ping {
 			_ = cmd.Flags().Set("ping", "true")
@@ -360,6 +553,39 @@ func setupScanCommand(flags map[string]interface{}) *cobra.Command {
 	if allowLoopback, ok := flags["allow-loopback"].(bool); ok && allowLoopback {
 		_ = cmd.Flags().Set("allow-loopback", "true")
 	}
+	if iface, ok := flags["interface"].(string); ok {
+		_ = cmd.Flags().Set("interface", iface)
+	}
+	if sourceIP, ok := flags["source-ip"].(string); ok {
+		_ = cmd.Flags().Set("source-ip", sourceIP)
+	}
+	if decoys, ok := flags["decoys"].([]string); ok {
+		for _, decoy := range decoys {
+			_ = cmd.Flags().Set("decoys", decoy)
+		}
+	}
+	if scopeAck, ok := flags["scope-ack"].(string); ok {
+		_ = cmd.Flags().Set("scope-ack", scopeAck)
+	}
+	for _, name := range []string{"client", "authorization", "tester", "window"} {
+		if value, ok := flags[name].(string); ok {
+			_ = cmd.Flags().Set(name, value)
+		}
+	}
+	if defaultCreds, ok := flags["default-creds"].(bool); ok && defaultCreds {
+		_ = cmd.Flags().Set("default-creds", "true")
+	}
+	if contentDiscovery, ok := flags["content-discovery"].(bool); ok && contentDiscovery {
+		_ = cmd.Flags().Set("content-discovery", "true")
+	}
+	if templates, ok := flags["nuclei-templates"].([]string); ok {
+		for _, tmpl := range templates {
+			_ = cmd.Flags().Set("nuclei-templates", tmpl)
+		}
+	}
+	if environment, ok := flags["environment"].(string); ok {
+		_ = cmd.Flags().Set("environment", environment)
+	}
 
 	return cmd
 }
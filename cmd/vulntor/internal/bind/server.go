@@ -10,10 +10,16 @@ import (
 type ServerOptions struct {
 	Addr         string
 	Port         int
+	GRPCPort     int
 	NoUI         bool
 	NoAPI        bool
 	Concurrency  int
 	UIAssetsPath string
+	AuthMode     string
+	AuthToken    string
+	TLSCert      string
+	TLSKey       string
+	TLSClientCA  string
 }
 
 // BindServerOptions extracts and validates server command flags.
@@ -24,25 +30,40 @@ type ServerOptions struct {
 // Flags read:
 //   - --addr: Server listen address (e.g., "127.0.0.1", "0.0.0.0")
 //   - --port: Server listen port (1-65535)
+//   - --grpc-port: gRPC API listen port (0 = from config, disabled by default)
 //   - --no-ui: Disable UI static serving
 //   - --no-api: Disable REST API endpoints
 //   - --jobs-concurrency: Number of concurrent background workers
 //   - --ui-assets-path: UI assets directory (dev mode)
+//   - --auth-mode: Authentication mode (none, token, apikey, oidc)
+//   - --auth-token: Static bearer token for token mode
+//   - --tls-cert, --tls-key: Server certificate and key (enables HTTPS)
+//   - --tls-client-ca: CA bundle verifying client certificates (mutual TLS)
 //
 // Returns an error if validation fails (e.g., invalid port range, invalid concurrency).
 func BindServerOptions(cmd *cobra.Command) (ServerOptions, error) {
 	addr, _ := cmd.Flags().GetString("addr")
 	port, _ := cmd.Flags().GetInt("port")
+	grpcPort, _ := cmd.Flags().GetInt("grpc-port")
 	noUI, _ := cmd.Flags().GetBool("no-ui")
 	noAPI, _ := cmd.Flags().GetBool("no-api")
 	concurrency, _ := cmd.Flags().GetInt("jobs-concurrency")
 	uiAssetsPath, _ := cmd.Flags().GetString("ui-assets-path")
+	authMode, _ := cmd.Flags().GetString("auth-mode")
+	authToken, _ := cmd.Flags().GetString("auth-token")
+	tlsCert, _ := cmd.Flags().GetString("tls-cert")
+	tlsKey, _ := cmd.Flags().GetString("tls-key")
+	tlsClientCA, _ := cmd.Flags().GetString("tls-client-ca")
 
 	// Validate port range
 	if port < 1 || port > 65535 {
 		return ServerOptions{}, srv.NewInvalidPortError(port)
 	}
 
+	if grpcPort < 0 || grpcPort > 65535 {
+		return ServerOptions{}, srv.NewInvalidPortError(grpcPort)
+	}
+
 	// Validate concurrency
 	if concurrency < 1 {
 		return ServerOptions{}, srv.NewInvalidConcurrencyError(concurrency)
@@ -56,10 +77,16 @@ func BindServerOptions(cmd *cobra.Command) (ServerOptions, error) {
 	opts := ServerOptions{
 		Addr:         addr,
 		Port:         port,
+		GRPCPort:     grpcPort,
 		NoUI:         noUI,
 		NoAPI:        noAPI,
 		Concurrency:  concurrency,
 		UIAssetsPath: uiAssetsPath,
+		AuthMode:     authMode,
+		AuthToken:    authToken,
+		TLSCert:      tlsCert,
+		TLSKey:       tlsKey,
+		TLSClientCA:  tlsClientCA,
 	}
 
 	return opts, nil
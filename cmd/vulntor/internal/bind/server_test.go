@@ -184,6 +184,35 @@ func TestBindServerOptions(t *testing.T) {
 	}
 }
 
+func TestBindServerOptions_Auth(t *testing.T) {
+	cmd := setupServerCommand(map[string]interface{}{})
+	cmd.Flags().String("auth-mode", "none", "Auth mode")
+	cmd.Flags().String("auth-token", "", "Auth token")
+	_ = cmd.Flags().Set("auth-mode", "apikey")
+	_ = cmd.Flags().Set("auth-token", "secret")
+
+	got, err := BindServerOptions(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "apikey", got.AuthMode)
+	require.Equal(t, "secret", got.AuthToken)
+}
+
+func TestBindServerOptions_TLS(t *testing.T) {
+	cmd := setupServerCommand(map[string]interface{}{})
+	cmd.Flags().String("tls-cert", "", "Certificate")
+	cmd.Flags().String("tls-key", "", "Key")
+	cmd.Flags().String("tls-client-ca", "", "Client CA")
+	_ = cmd.Flags().Set("tls-cert", "server.crt")
+	_ = cmd.Flags().Set("tls-key", "server.key")
+	_ = cmd.Flags().Set("tls-client-ca", "ca.crt")
+
+	got, err := BindServerOptions(cmd)
+	require.NoError(t, err)
+	require.Equal(t, "server.crt", got.TLSCert)
+	require.Equal(t, "server.key", got.TLSKey)
+	require.Equal(t, "ca.crt", got.TLSClientCA)
+}
+
 // setupServerCommand creates a mock command with server flags
 func setupServerCommand(flags map[string]interface{}) *cobra.Command {
 	cmd := &cobra.Command{}
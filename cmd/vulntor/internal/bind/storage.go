@@ -1,6 +1,10 @@
 package bind
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
 
 // StorageGCOptions contains validated options for the storage gc command
 type StorageGCOptions struct {
@@ -24,3 +28,69 @@ func BindStorageGCOptions(cmd *cobra.Command) (StorageGCOptions, error) {
 		MaxAgeDays: maxAgeDays,
 	}, nil
 }
+
+// StorageMigrateOptions contains validated options for the storage migrate command
+type StorageMigrateOptions struct {
+	DryRun bool
+	OrgID  string
+}
+
+// BindStorageMigrateOptions extracts and validates storage migrate flags from the command
+func BindStorageMigrateOptions(cmd *cobra.Command) (StorageMigrateOptions, error) {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	orgID, _ := cmd.Flags().GetString("org-id")
+
+	return StorageMigrateOptions{
+		DryRun: dryRun,
+		OrgID:  orgID,
+	}, nil
+}
+
+// StorageBackupOptions contains validated options for the storage backup command
+type StorageBackupOptions struct {
+	Output        string // archive path; "-" writes to stdout
+	ConfigFile    string // config file to include, from the global --config flag
+	IncludeActive bool
+}
+
+// BindStorageBackupOptions extracts and validates storage backup flags and the
+// archive argument from the command
+func BindStorageBackupOptions(cmd *cobra.Command, args []string) (StorageBackupOptions, error) {
+	if len(args) != 1 || args[0] == "" {
+		return StorageBackupOptions{}, fmt.Errorf("archive path is required")
+	}
+	includeActive, _ := cmd.Flags().GetBool("include-active")
+	configFile, _ := cmd.Flags().GetString("config")
+
+	return StorageBackupOptions{
+		Output:        args[0],
+		ConfigFile:    configFile,
+		IncludeActive: includeActive,
+	}, nil
+}
+
+// StorageRestoreOptions contains validated options for the storage restore command
+type StorageRestoreOptions struct {
+	Input     string // archive path; "-" reads from stdin
+	DryRun    bool
+	Force     bool
+	ConfigOut string
+}
+
+// BindStorageRestoreOptions extracts and validates storage restore flags and
+// the archive argument from the command
+func BindStorageRestoreOptions(cmd *cobra.Command, args []string) (StorageRestoreOptions, error) {
+	if len(args) != 1 || args[0] == "" {
+		return StorageRestoreOptions{}, fmt.Errorf("archive path is required")
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	configOut, _ := cmd.Flags().GetString("config-out")
+
+	return StorageRestoreOptions{
+		Input:     args[0],
+		DryRun:    dryRun,
+		Force:     force,
+		ConfigOut: configOut,
+	}, nil
+}
@@ -112,3 +112,55 @@ func TestBindStorageGCOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestBindStorageMigrateOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().String("org-id", "", "")
+
+	opts, err := BindStorageMigrateOptions(cmd)
+	require.NoError(t, err)
+	require.Equal(t, StorageMigrateOptions{}, opts)
+
+	_ = cmd.Flags().Set("dry-run", "true")
+	_ = cmd.Flags().Set("org-id", "team-a")
+	opts, err = BindStorageMigrateOptions(cmd)
+	require.NoError(t, err)
+	require.Equal(t, StorageMigrateOptions{DryRun: true, OrgID: "team-a"}, opts)
+}
+
+func TestBindStorageBackupOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("include-active", false, "")
+	cmd.Flags().String("config", "", "")
+
+	_, err := BindStorageBackupOptions(cmd, nil)
+	require.ErrorContains(t, err, "archive path is required")
+
+	_ = cmd.Flags().Set("include-active", "true")
+	_ = cmd.Flags().Set("config", "/etc/vulntor/config.yaml")
+	opts, err := BindStorageBackupOptions(cmd, []string{"backup.tar.gz"})
+	require.NoError(t, err)
+	require.Equal(t, StorageBackupOptions{Output: "backup.tar.gz", ConfigFile: "/etc/vulntor/config.yaml", IncludeActive: true}, opts)
+}
+
+func TestBindStorageRestoreOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().Bool("force", false, "")
+	cmd.Flags().String("config-out", "", "")
+
+	_, err := BindStorageRestoreOptions(cmd, []string{""})
+	require.ErrorContains(t, err, "archive path is required")
+
+	opts, err := BindStorageRestoreOptions(cmd, []string{"-"})
+	require.NoError(t, err)
+	require.Equal(t, StorageRestoreOptions{Input: "-"}, opts)
+
+	_ = cmd.Flags().Set("dry-run", "true")
+	_ = cmd.Flags().Set("force", "true")
+	_ = cmd.Flags().Set("config-out", "restored.yaml")
+	opts, err = BindStorageRestoreOptions(cmd, []string{"backup.tar.gz"})
+	require.NoError(t, err)
+	require.Equal(t, StorageRestoreOptions{Input: "backup.tar.gz", DryRun: true, Force: true, ConfigOut: "restored.yaml"}, opts)
+}
@@ -1,21 +1,76 @@
 package format
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// AddFlags registers the output flags shared by all commands. The root
+// command adds them as persistent flags; commands with a command-specific
+// --output (scan, import) shadow it.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.String("output", string(ModeTable), "Output format: table, json, yaml")
+	flags.Bool("json", false, "Output JSON (same as --output json)")
+	flags.Bool("quiet", false, "Suppress non-essential output")
+	flags.Bool("no-color", false, "Disable colored output")
+}
+
+// ModeFromCommand returns the output mode selected by --json or --output.
+// --json takes precedence.
+func ModeFromCommand(cmd *cobra.Command) OutputMode {
+	return ParseMode(OutputFlag(cmd))
+}
+
+// OutputFlag returns the --output value of cmd, or "json" when --json is
+// set. Commands with their own --output formats (scan, import) read it
+// instead of --output so that --json works everywhere.
+func OutputFlag(cmd *cobra.Command) string {
+	if jsonSet(cmd) {
+		return string(ModeJSON)
+	}
+	if flag := cmd.Flags().Lookup("output"); flag != nil {
+		return flag.Value.String()
+	}
+	return string(ModeTable)
+}
+
+// ResolveFlags validates the shared --output flag of cmd and rejects an
+// --output that contradicts --json. Command-specific --output flags are
+// validated by their commands.
+func ResolveFlags(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("output")
+	if flag == nil {
+		return nil
+	}
+	if jsonSet(cmd) && flag.Changed && !strings.EqualFold(flag.Value.String(), string(ModeJSON)) {
+		return WithErrorCode(fmt.Errorf("--json conflicts with --output %s", flag.Value.String()), errorCodeUsage)
+	}
+	if flag != cmd.Root().PersistentFlags().Lookup("output") {
+		return nil
+	}
+	return WithErrorCode(ValidateMode(flag.Value.String()), errorCodeUsage)
+}
+
+func jsonSet(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("json")
+	if flag == nil {
+		return false
+	}
+	val, err := strconv.ParseBool(flag.Value.String())
+	return err == nil && val
+}
+
 // FromCommand builds a Formatter using cobra command output/error writers and common flags.
 func FromCommand(cmd *cobra.Command) Formatter {
 	stdout := cmd.OutOrStdout()
 	stderr := cmd.ErrOrStderr()
 
-	outputMode := ModeTable
-	if flag := cmd.Flags().Lookup("output"); flag != nil {
-		outputMode = ParseMode(flag.Value.String())
-	}
+	outputMode := ModeFromCommand(cmd)
 
 	quiet := false
 	if flag := cmd.Flags().Lookup("quiet"); flag != nil {
@@ -29,3 +29,59 @@ func TestFromCommandRespectsFlags(t *testing.T) {
 	require.NoError(t, formatter.PrintSummary("should be suppressed"))
 	require.Equal(t, "", out.String())
 }
+
+func newFlagsTestCommand(t *testing.T, args ...string) (root, sub *cobra.Command) {
+	t.Helper()
+	root = &cobra.Command{Use: "root"}
+	AddFlags(root.PersistentFlags())
+	sub = &cobra.Command{Use: "sub", RunE: func(*cobra.Command, []string) error { return nil }}
+	root.AddCommand(sub)
+	root.SetArgs(args)
+	require.NoError(t, root.Execute())
+	return root, sub
+}
+
+func TestModeFromCommand(t *testing.T) {
+	_, sub := newFlagsTestCommand(t, "sub")
+	require.Equal(t, ModeTable, ModeFromCommand(sub))
+
+	_, sub = newFlagsTestCommand(t, "sub", "--output", "yaml")
+	require.Equal(t, ModeYAML, ModeFromCommand(sub))
+
+	_, sub = newFlagsTestCommand(t, "--json", "sub")
+	require.Equal(t, ModeJSON, ModeFromCommand(sub))
+	require.True(t, FromCommand(sub).IsJSON())
+}
+
+func TestResolveFlags(t *testing.T) {
+	_, sub := newFlagsTestCommand(t, "sub", "--output", "yaml")
+	require.NoError(t, ResolveFlags(sub))
+
+	_, sub = newFlagsTestCommand(t, "sub", "--output", "xml")
+	require.ErrorContains(t, ResolveFlags(sub), "invalid output mode")
+
+	_, sub = newFlagsTestCommand(t, "sub", "--json", "--output", "json")
+	require.NoError(t, ResolveFlags(sub))
+
+	_, sub = newFlagsTestCommand(t, "sub", "--json", "--output", "yaml")
+	require.ErrorContains(t, ResolveFlags(sub), "--json conflicts with --output yaml")
+}
+
+func TestOutputFlag_CommandSpecificOutput(t *testing.T) {
+	root := &cobra.Command{Use: "root"}
+	AddFlags(root.PersistentFlags())
+	scan := &cobra.Command{Use: "scan", RunE: func(*cobra.Command, []string) error { return nil }}
+	scan.Flags().String("output", "text", "Output format (text, json, yaml, sarif)")
+	root.AddCommand(scan)
+
+	root.SetArgs([]string{"scan", "--output", "sarif"})
+	require.NoError(t, root.Execute())
+	require.NoError(t, ResolveFlags(scan), "command-specific formats are validated by the command")
+	require.Equal(t, "sarif", OutputFlag(scan))
+
+	require.NoError(t, scan.Flags().Set("output", "text"))
+	scan.Flags().Lookup("output").Changed = false
+	require.NoError(t, root.PersistentFlags().Set("json", "true"))
+	require.NoError(t, ResolveFlags(scan))
+	require.Equal(t, "json", OutputFlag(scan))
+}
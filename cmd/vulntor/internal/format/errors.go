@@ -0,0 +1,172 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package format
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// DocsBaseURL is the root of the documentation linked from errors.
+const DocsBaseURL = "https://docs.vulntor.ai"
+
+// ErrorCodeConfigLoadFailed marks errors loading the configuration.
+const ErrorCodeConfigLoadFailed = "CONFIG_LOAD_FAILED"
+
+// Error codes for failures that no service package classifies.
+const (
+	errorCodeUsage    = "INVALID_USAGE"
+	errorCodeInternal = "INTERNAL_ERROR"
+)
+
+// codeResolvers classify errors of the service packages. Each resolver
+// falls back to a catch-all code for errors it does not know; that code is
+// skipped so that the next resolver can classify the error.
+var codeResolvers = []struct {
+	resolve  func(error) string
+	fallback string
+}{
+	{plugin.ErrorCode, "INTERNAL_ERROR"},
+	{scanexec.ErrorCode, "SCAN_FAILURE"},
+	{storage.ErrorCode, "STORAGE_FAILURE"},
+	{server.ErrorCode, "SERVER_RUNTIME_FAILED"},
+	{fingerprint.ErrorCode, "FINGERPRINT_SYNC_FAILED"},
+	{engine.ErrorCode, "DAG_MARSHAL_FAILED"},
+}
+
+// usageErrorPrefixes match the flag and argument errors of cobra and pflag.
+var usageErrorPrefixes = []string{
+	"unknown command",
+	"unknown flag",
+	"unknown shorthand flag",
+	"invalid argument",
+	"flag needs an argument",
+	"bad flag syntax",
+	"accepts ",
+	"requires at least",
+	"requires at most",
+	"required flag(s)",
+	"if any flags in the group",
+}
+
+// docsPages maps error codes, or code prefixes ending in "_", to the page
+// documenting how to fix them. Exact codes win over prefixes.
+var docsPages = map[string]string{
+	"SERVER_":                     "/cli/server",
+	"FINGERPRINT_":                "/cli/fingerprint",
+	"VALIDATION_ERROR":            "/advanced/custom-fingerprints",
+	"DAG_":                        "/concepts/dag-engine",
+	"PLUGIN_":                     "/architecture/plugins",
+	"NO_PLUGINS_FOUND":            "/architecture/plugins",
+	"SERVICE_UNAVAILABLE":         "/architecture/plugins",
+	"REMOTE_UNAVAILABLE":          "/architecture/plugins",
+	"SOURCE_NOT_AVAILABLE":        "/architecture/plugins",
+	"OFFLINE_MODE":                "/deployment/air-gapped",
+	"CHECKSUM_MISMATCH":           "/architecture/plugins",
+	"VERSION_CONFLICT":            "/architecture/plugins",
+	"INVALID_CATEGORY":            "/architecture/plugins",
+	"INVALID_SOURCE":              "/architecture/plugins",
+	"INVALID_PLUGIN_ID":           "/architecture/plugins",
+	"INVALID_TARGET":              "/cli/scan#target-specification",
+	"CONFLICTING_DISCOVERY_FLAGS": "/cli/scan#phase-control",
+	"UNKNOWN_TARGET_GROUP":        "/cli/group",
+	"UNKNOWN_FINDING":             "/cli/findings",
+	"INVALID_FINDING_STATE":       "/cli/findings#set-state",
+	"TARGET_OUT_OF_SCOPE":         "/configuration/scan-scope",
+	"SCOPE_":                      "/configuration/scan-scope",
+	"ENGAGEMENT_REQUIRED":         "/configuration/engagements",
+	"OUTSIDE_ENGAGEMENT_WINDOW":   "/configuration/engagements",
+	"TELEMETRY_":                  "/configuration/telemetry",
+	"WORDLIST_":                   "/cli/wordlist",
+	"SCAN_FAILURE":                "/troubleshooting/common-issues#scanning-issues",
+	"SCAN_GATE_FAILED":            "/cli/scan#ci-gates",
+	"SCAN_INTERRUPTED":            "/cli/scan#interrupting-scans",
+	"BENCH_REGRESSION":            "/cli/bench",
+	"NO_RETENTION_POLICY":         "/troubleshooting/common-issues#storage-issues",
+	"INVALID_RETENTION_POLICY":    "/troubleshooting/common-issues#storage-issues",
+	"WORKSPACE_":                  "/troubleshooting/common-issues#storage-issues",
+	"STORAGE_":                    "/troubleshooting/common-issues#storage-issues",
+	ErrorCodeConfigLoadFailed:     "/cli/configuration#validating-config-files",
+	errorCodeUsage:                "/cli/overview",
+	errorCodeInternal:             "/troubleshooting/debugging",
+}
+
+// codedError wraps an error with an explicit error code.
+type codedError struct {
+	error
+	code string
+}
+
+func (e *codedError) Unwrap() error {
+	return e.error
+}
+
+func (e *codedError) Code() string {
+	return e.code
+}
+
+// WithErrorCode wraps err with a CLI error code, for failures of the CLI
+// itself rather than of a service package.
+func WithErrorCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{error: err, code: code}
+}
+
+// ErrorCode resolves any command or service error into an error code:
+// explicit codes first, then the service packages' error types, then
+// usage errors. Anything else is INTERNAL_ERROR.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		if code := coded.Code(); code != "" {
+			return code
+		}
+	}
+
+	for _, r := range codeResolvers {
+		if code := r.resolve(err); code != "" && code != r.fallback {
+			return code
+		}
+	}
+
+	msg := err.Error()
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(msg, prefix) {
+			return errorCodeUsage
+		}
+	}
+	return errorCodeInternal
+}
+
+// DocsURL returns the documentation page for an error code, or "" when
+// no page covers it.
+func DocsURL(errorCode string) string {
+	if page, ok := docsPages[errorCode]; ok {
+		return DocsBaseURL + page
+	}
+	longest := ""
+	for prefix := range docsPages {
+		if strings.HasSuffix(prefix, "_") && strings.HasPrefix(errorCode, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	if longest == "" {
+		return ""
+	}
+	return DocsBaseURL + docsPages[longest]
+}
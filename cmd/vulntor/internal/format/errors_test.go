@@ -0,0 +1,93 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "plugin", err: fmt.Errorf("install: %w", plugin.ErrPluginNotFound), want: "PLUGIN_NOT_FOUND"},
+		{name: "scan", err: scanexec.ErrNoTargets, want: "INVALID_TARGET"},
+		{name: "storage", err: storage.ErrRetentionPolicyNotConfigured, want: "NO_RETENTION_POLICY"},
+		{name: "server", err: server.ErrInvalidPort, want: "SERVER_INVALID_PORT"},
+		{name: "explicit code", err: WithErrorCode(errors.New("bad config"), ErrorCodeConfigLoadFailed), want: "CONFIG_LOAD_FAILED"},
+		{name: "cobra usage", err: errors.New(`unknown command "scna" for "vulntor"`), want: "INVALID_USAGE"},
+		{name: "pflag usage", err: errors.New(`invalid argument "x" for "--limit" flag`), want: "INVALID_USAGE"},
+		{name: "unclassified", err: errors.New("boom"), want: "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ErrorCode(tt.err))
+		})
+	}
+}
+
+func TestDocsURL(t *testing.T) {
+	require.Equal(t, "https://docs.vulntor.ai/cli/scan#target-specification", DocsURL("INVALID_TARGET"))
+	require.Equal(t, "https://docs.vulntor.ai/cli/server", DocsURL("SERVER_USER_NOT_FOUND"))
+	require.Equal(t, "https://docs.vulntor.ai/troubleshooting/common-issues#storage-issues", DocsURL("WORKSPACE_PERMISSION_DENIED"))
+	require.Equal(t, "https://docs.vulntor.ai/architecture/plugins", DocsURL("PLUGIN_NOT_FOUND"))
+	require.Empty(t, DocsURL("PARTIAL_FAILURE"))
+}
+
+func TestPrintError_SuggestionsAndDocs(t *testing.T) {
+	t.Run("table", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		f := New(&stdout, &stderr, ModeTable, false, false)
+		require.NoError(t, f.PrintError(storage.ErrRetentionPolicyNotConfigured))
+
+		require.Empty(t, stdout.String())
+		require.Contains(t, stderr.String(), "✗ Error: "+storage.ErrRetentionPolicyNotConfigured.Error())
+		require.Contains(t, stderr.String(), "💡 Suggestions:\n  → Set max scans:")
+		require.Contains(t, stderr.String(), "📖 Docs: https://docs.vulntor.ai/troubleshooting/common-issues#storage-issues")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		f := New(&stdout, &stderr, ModeJSON, false, false)
+		require.NoError(t, f.PrintError(server.ErrInvalidPort))
+
+		var result map[string]any
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Equal(t, "SERVER_INVALID_PORT", result["code"])
+		require.Equal(t, "Use a port between 1 and 65535", result["suggestion"])
+		require.Len(t, result["suggestions"], 2)
+		require.Equal(t, "https://docs.vulntor.ai/cli/server", result["docs_url"])
+	})
+}
+
+func TestPrintTotalFailureSummary_Docs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	f := New(&stdout, &stderr, ModeTable, false, false)
+	require.NoError(t, f.PrintTotalFailureSummary("scan", scanexec.ErrNoTargets, "INVALID_TARGET"))
+	require.Contains(t, stdout.String(), "✗ Failed to scan: no scan targets specified")
+	require.Contains(t, stdout.String(), "📖 Docs: https://docs.vulntor.ai/cli/scan#target-specification")
+
+	stdout.Reset()
+	f = New(&stdout, &stderr, ModeYAML, false, false)
+	require.NoError(t, f.PrintTotalFailureSummary("scan", scanexec.ErrNoTargets, "INVALID_TARGET"))
+	require.Contains(t, stdout.String(), "error_code: INVALID_TARGET\n")
+	require.Contains(t, stdout.String(), "docs_url: https://docs.vulntor.ai/cli/scan#target-specification\n")
+	require.Contains(t, stdout.String(), "suggestions:\n  - ")
+}
@@ -12,6 +12,7 @@ import (
 	"text/tabwriter"
 
 	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
 
 	"github.com/vulntor/vulntor/pkg/plugin"
 )
@@ -22,6 +23,8 @@ type OutputMode string
 const (
 	// ModeJSON outputs data as JSON
 	ModeJSON OutputMode = "json"
+	// ModeYAML outputs data as YAML
+	ModeYAML OutputMode = "yaml"
 	// ModeTable outputs data as ASCII table
 	ModeTable OutputMode = "table"
 )
@@ -31,6 +34,10 @@ type Formatter interface {
 	// PrintJSON outputs data as JSON to stdout
 	PrintJSON(data any) error
 
+	// PrintStructured outputs data as JSON or YAML to stdout, following the
+	// output mode
+	PrintStructured(data any) error
+
 	// PrintTable outputs data as ASCII table to stdout
 	PrintTable(headers []string, rows [][]string) error
 
@@ -43,6 +50,9 @@ type Formatter interface {
 	// IsJSON returns true if the formatter is in JSON mode
 	IsJSON() bool
 
+	// IsStructured returns true if the formatter is in JSON or YAML mode
+	IsStructured() bool
+
 	// PrintSuccessSummary prints a standardized success message
 	PrintSuccessSummary(operation, pluginID, version string) error
 
@@ -77,13 +87,38 @@ func New(stdout, stderr io.Writer, mode OutputMode, quiet, color bool) Formatter
 func (f *formatter) PrintJSON(data any) error {
 	enc := json.NewEncoder(f.stdout)
 	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false) // Keep <placeholders> in suggestions readable
 	return enc.Encode(data)
 }
 
+// PrintStructured outputs data as JSON or YAML to stdout. YAML keys follow
+// the JSON field names so both formats carry the same document.
+func (f *formatter) PrintStructured(data any) error {
+	if f.mode != ModeYAML {
+		return f.PrintJSON(data)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(f.stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
 // PrintTable outputs data as ASCII table to stdout
 func (f *formatter) PrintTable(headers []string, rows [][]string) error {
-	if f.mode == ModeJSON {
-		// In JSON mode, convert table to structured data
+	if f.IsStructured() {
+		// In JSON and YAML modes, convert table to structured data
 		var items []map[string]string
 		for _, row := range rows {
 			item := make(map[string]string)
@@ -94,7 +129,7 @@ func (f *formatter) PrintTable(headers []string, rows [][]string) error {
 			}
 			items = append(items, item)
 		}
-		return f.PrintJSON(items)
+		return f.PrintStructured(items)
 	}
 
 	// Table mode using text/tabwriter
@@ -131,8 +166,8 @@ func (f *formatter) PrintSummary(message string) error {
 		return nil
 	}
 
-	if f.mode == ModeJSON {
-		// In JSON mode, summary goes to stderr (not stdout)
+	if f.IsStructured() {
+		// In JSON and YAML modes, summary goes to stderr (not stdout)
 		_, err := fmt.Fprintln(f.stderr, message)
 		return err
 	}
@@ -147,30 +182,41 @@ func (f *formatter) PrintSummary(message string) error {
 	return err
 }
 
-// PrintError outputs an error to stderr (or JSON to stdout in JSON mode)
+// PrintError outputs an error with its error code, suggestions and docs
+// link to stderr (or JSON to stdout in JSON mode)
 func (f *formatter) PrintError(err error) error {
 	if err == nil {
 		return nil
 	}
 
-	if f.mode == ModeJSON {
-		// JSON mode: error object to stdout (machine-readable)
-		return f.PrintJSON(map[string]any{
+	code := ErrorCode(err)
+	suggestions := GetSuggestions(code, "<command>")
+	docsURL := DocsURL(code)
+
+	if f.IsStructured() {
+		// JSON and YAML modes: error object to stdout (machine-readable)
+		suggestion := plugin.GetSuggestion(err)
+		if len(suggestions) > 0 {
+			suggestion = suggestions[0]
+		}
+		return f.PrintStructured(withHints(map[string]any{
 			"success":    false,
 			"error":      err.Error(),
-			"code":       plugin.ErrorCode(err),
-			"suggestion": plugin.GetSuggestion(err),
-		})
+			"code":       code,
+			"suggestion": suggestion,
+		}, suggestions, docsURL))
 	}
 
-	// Table mode: error to stderr (human-readable)
-	var writeErr error
+	// Table mode: error with suggestions and docs link to stderr (human-readable)
+	var sb strings.Builder
 	if f.color {
-		_, writeErr = color.New(color.FgRed).Fprintf(f.stderr, "Error: %v\n", err)
+		sb.WriteString(color.RedString("✗ Error: %s\n", strings.TrimSpace(err.Error())))
 	} else {
-		_, writeErr = fmt.Fprintf(f.stderr, "Error: %v\n", err)
+		sb.WriteString(fmt.Sprintf("✗ Error: %s\n", strings.TrimSpace(err.Error())))
 	}
+	writeHints(&sb, suggestions, docsURL)
 
+	_, writeErr := io.WriteString(f.stderr, sb.String())
 	return writeErr
 }
 
@@ -179,13 +225,18 @@ func (f *formatter) IsJSON() bool {
 	return f.mode == ModeJSON
 }
 
+// IsStructured returns true if the formatter is in JSON or YAML mode
+func (f *formatter) IsStructured() bool {
+	return f.mode == ModeJSON || f.mode == ModeYAML
+}
+
 // ValidateMode checks if the output mode is valid
 func ValidateMode(mode string) error {
 	switch OutputMode(mode) {
-	case ModeJSON, ModeTable:
+	case ModeJSON, ModeYAML, ModeTable:
 		return nil
 	default:
-		return fmt.Errorf("invalid output mode: %s (must be 'json' or 'table')", mode)
+		return fmt.Errorf("invalid output mode: %s (must be 'json', 'yaml' or 'table')", mode)
 	}
 }
 
@@ -194,6 +245,8 @@ func ParseMode(mode string) OutputMode {
 	switch strings.ToLower(mode) {
 	case "json":
 		return ModeJSON
+	case "yaml":
+		return ModeYAML
 	case "table":
 		return ModeTable
 	default:
@@ -327,6 +327,11 @@ func TestValidateMode(t *testing.T) {
 			mode:    "json",
 			wantErr: false,
 		},
+		{
+			name:    "valid yaml",
+			mode:    "yaml",
+			wantErr: false,
+		},
 		{
 			name:    "valid table",
 			mode:    "table",
@@ -373,6 +378,11 @@ func TestParseMode(t *testing.T) {
 			input:    "JSON",
 			expected: ModeJSON,
 		},
+		{
+			name:     "yaml uppercase",
+			input:    "YAML",
+			expected: ModeYAML,
+		},
 		{
 			name:     "table lowercase",
 			input:    "table",
@@ -606,3 +616,37 @@ func TestIsJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintStructured(t *testing.T) {
+	data := struct {
+		Name    string   `json:"name"`
+		Count   int      `json:"count"`
+		Plugins []string `json:"plugins,omitempty"`
+	}{Name: "scan-1", Count: 2, Plugins: []string{"ssh", "http"}}
+
+	t.Run("yaml uses json field names in sorted order", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		f := New(&stdout, &stderr, ModeYAML, false, false)
+		require.True(t, f.IsStructured())
+		require.False(t, f.IsJSON())
+		require.NoError(t, f.PrintStructured(data))
+		require.Equal(t, "count: 2\nname: scan-1\nplugins:\n  - ssh\n  - http\n", stdout.String())
+		require.Empty(t, stderr.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		f := New(&stdout, &stderr, ModeJSON, false, false)
+		require.True(t, f.IsStructured())
+		require.NoError(t, f.PrintStructured(data))
+		require.JSONEq(t, `{"name":"scan-1","count":2,"plugins":["ssh","http"]}`, stdout.String())
+	})
+
+	t.Run("yaml table and summary", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		f := New(&stdout, &stderr, ModeYAML, false, false)
+		require.NoError(t, f.PrintTable([]string{"Name", "Version"}, [][]string{{"ssh", "1.0.0"}}))
+		require.NoError(t, f.PrintSummary("done"))
+		require.Equal(t, "- Name: ssh\n  Version: 1.0.0\n", stdout.String())
+	})
+}
@@ -46,9 +46,9 @@ func (f *formatter) PrintSuccessSummary(operation, pluginID, version string) err
 		return nil
 	}
 
-	if f.mode == ModeJSON {
-		// JSON mode: structured output
-		return f.PrintJSON(map[string]any{
+	if f.IsStructured() {
+		// JSON and YAML modes: structured output
+		return f.PrintStructured(map[string]any{
 			"success":   true,
 			"operation": operation,
 			"plugin_id": pluginID,
@@ -94,9 +94,9 @@ func (f *formatter) PrintPartialFailureSummary(summary Summary) error {
 		return nil
 	}
 
-	if f.mode == ModeJSON {
-		// JSON mode: structured output
-		return f.PrintJSON(map[string]any{
+	if f.IsStructured() {
+		// JSON and YAML modes: structured output
+		return f.PrintStructured(map[string]any{
 			"success":       false,
 			"partial":       true,
 			"operation":     summary.Operation,
@@ -174,14 +174,14 @@ func (f *formatter) PrintTotalFailureSummary(operation string, err error, errorC
 		return nil
 	}
 
-	if f.mode == ModeJSON {
-		// JSON mode: structured output
-		return f.PrintJSON(map[string]any{
+	if f.IsStructured() {
+		// JSON and YAML modes: structured output
+		return f.PrintStructured(withHints(map[string]any{
 			"success":    false,
 			"operation":  operation,
 			"error":      err.Error(),
 			"error_code": errorCode,
-		})
+		}, GetSuggestions(errorCode, operation), DocsURL(errorCode)))
 	}
 
 	// Table mode: formatted error with suggestions
@@ -195,17 +195,36 @@ func (f *formatter) PrintTotalFailureSummary(operation string, err error, errorC
 		sb.WriteString(fmt.Sprintf("%s\n", errorMsg))
 	}
 
-	// Suggestions based on error code
-	suggestions := GetSuggestions(errorCode, operation)
+	// Suggestions and docs based on error code
+	writeHints(&sb, GetSuggestions(errorCode, operation), DocsURL(errorCode))
+
+	_, writeErr := f.stdout.Write([]byte(sb.String()))
+	return writeErr
+}
+
+// writeHints appends the suggestions and documentation link of an error.
+func writeHints(sb *strings.Builder, suggestions []string, docsURL string) {
 	if len(suggestions) > 0 {
 		sb.WriteString("\n💡 Suggestions:\n")
 		for _, s := range suggestions {
 			sb.WriteString(fmt.Sprintf("  → %s\n", s))
 		}
 	}
+	if docsURL != "" {
+		sb.WriteString(fmt.Sprintf("\n📖 Docs: %s\n", docsURL))
+	}
+}
 
-	_, writeErr := f.stdout.Write([]byte(sb.String()))
-	return writeErr
+// withHints adds the suggestions and documentation link of an error to
+// its structured output.
+func withHints(data map[string]any, suggestions []string, docsURL string) map[string]any {
+	if len(suggestions) > 0 {
+		data["suggestions"] = suggestions
+	}
+	if docsURL != "" {
+		data["docs_url"] = docsURL
+	}
+	return data
 }
 
 var suggestionGenerators = map[string]func(string) []string{
@@ -221,6 +240,12 @@ var suggestionGenerators = map[string]func(string) []string{
 			"Check network connection",
 		}
 	},
+	"OFFLINE_MODE": func(string) []string {
+		return []string{
+			"Install from a bundle:   vulntor plugin install <target> --offline --bundle <file>",
+			"Create one online:       vulntor plugin bundle create",
+		}
+	},
 	"CHECKSUM_MISMATCH": func(operation string) []string {
 		return []string{
 			fmt.Sprintf("Force re-download:       vulntor plugin %s --force", operation),
@@ -232,6 +257,12 @@ var suggestionGenerators = map[string]func(string) []string{
 			fmt.Sprintf("Force reinstall:         vulntor plugin %s --force", operation),
 		}
 	},
+	"PLUGIN_INCOMPATIBLE": func(string) []string {
+		return []string{
+			"Check your version:      vulntor version",
+			"Upgrade Vulntor to the release the plugin requires",
+		}
+	},
 	"PARTIAL_FAILURE": func(operation string) []string {
 		return []string{
 			fmt.Sprintf("See full details:        vulntor plugin %s --output json", operation),
@@ -275,12 +306,77 @@ var suggestionGenerators = map[string]func(string) []string{
 			"Run help for options:       vulntor scan --help",
 		}
 	},
+	"UNKNOWN_TARGET_GROUP": func(string) []string {
+		return []string{
+			"List target groups:         vulntor group list",
+			"Create the group:           vulntor group create <name> --target <target>",
+		}
+	},
+	"UNKNOWN_FINDING": func(string) []string {
+		return []string{
+			"Find finding IDs:           vulntor report <scan-id> --format csv",
+			"Look in an older scan:      vulntor findings recheck <finding-id> --scan <scan-id>",
+		}
+	},
+	"INVALID_FINDING_STATE": func(string) []string {
+		return []string{
+			"Use one of the states:      open, acknowledged, in-progress, fixed, accepted-risk, false-positive",
+			"Run help for options:       vulntor findings set-state --help",
+		}
+	},
 	"SCAN_FAILURE": func(string) []string {
 		return []string{
 			"Retry with verbose logs:    vulntor scan <target> --verbose",
 			"Enable progress output:     vulntor scan <target> --progress",
 		}
 	},
+	"SCAN_INTERRUPTED": func(string) []string {
+		return []string{
+			"Review the partial results: vulntor report <scan-id>",
+			"Allow more time to drain:   vulntor scan <target> --drain-timeout 30s",
+		}
+	},
+	"TARGET_OUT_OF_SCOPE": func(string) []string {
+		return []string{
+			"Check targets against the scope: vulntor scope check <target>",
+			"Get an approved override:        vulntor scope ack --target <target> --reason <reason>",
+			"Scan with the override:          vulntor scan <target> --scope-ack <token>",
+		}
+	},
+	"SCOPE_OVERRIDE_DISABLED": func(string) []string {
+		return []string{
+			"Set the signing key:        scope.override_key in the config file (e.g. env:VULNTOR_SCOPE_KEY)",
+		}
+	},
+	"SCOPE_INVALID_ACK": func(string) []string {
+		return []string{
+			"Name the approved targets:  --target 203.0.113.0/24 --reason <reason> --by <name>",
+			"Run help for options:       vulntor scope ack --help",
+		}
+	},
+	"ENGAGEMENT_REQUIRED": func(string) []string {
+		return []string{
+			"Record the engagement:      vulntor scan <target> --client <client> --authorization <ref> --tester <name> --window <from>/<until>",
+			"Set engagement defaults:    engagement.client, engagement.tester, ... in the config file",
+		}
+	},
+	"OUTSIDE_ENGAGEMENT_WINDOW": func(string) []string {
+		return []string{
+			"Scan within the engagement window, or record the extended window: --window <from>/<until>",
+		}
+	},
+	"TELEMETRY_DISABLED": func(string) []string {
+		return []string{
+			"Opt in to telemetry:        telemetry.enabled: true in the config file",
+			"Preview the report:         vulntor telemetry preview",
+		}
+	},
+	"TELEMETRY_SEND_FAILED": func(string) []string {
+		return []string{
+			"Check the endpoint:         telemetry.endpoint in the config file",
+			"Check the proxy settings:   HTTPS_PROXY / NO_PROXY",
+		}
+	},
 	"NO_RETENTION_POLICY": func(string) []string {
 		return []string{
 			"Set max scans:              vulntor storage gc --max-scans=100",
@@ -365,6 +461,45 @@ var suggestionGenerators = map[string]func(string) []string{
 			"Review configuration for invalid values",
 		}
 	},
+	"SERVER_INVALID_API_KEY": func(string) []string {
+		return []string{
+			"Provide a name and at least one scope",
+			"Example:                 vulntor server apikey create --name ci --scopes read,scan:create",
+		}
+	},
+	"SERVER_API_KEY_NOT_FOUND": func(string) []string {
+		return []string{
+			"List existing keys:       vulntor server apikey list",
+		}
+	},
+	"SERVER_INVALID_USER": func(string) []string {
+		return []string{
+			"Use a unique user ID and one of the roles: viewer, operator, admin",
+			"Example:                 vulntor server user create alice --role operator",
+		}
+	},
+	"SERVER_USER_NOT_FOUND": func(string) []string {
+		return []string{
+			"List existing users:      vulntor server user list",
+		}
+	},
+	"SERVER_INVALID_TENANT": func(string) []string {
+		return []string{
+			"Use a unique tenant ID of lowercase letters, digits and dashes",
+			"Example:                 vulntor server tenant create team-a --name \"Team A\"",
+		}
+	},
+	"SERVER_TENANT_NOT_FOUND": func(string) []string {
+		return []string{
+			"List existing tenants:    vulntor server tenant list",
+		}
+	},
+	"SERVER_CERT_FAILED": func(string) []string {
+		return []string{
+			"Create a development CA first: vulntor server cert ca --out-dir ./certs",
+			"Replace existing files with:   --force",
+		}
+	},
 	"SERVER_RUNTIME_FAILED": func(string) []string {
 		return []string{
 			"Check server logs for runtime errors",
@@ -395,6 +530,21 @@ var suggestionGenerators = map[string]func(string) []string{
 			"Check network connectivity and cache directory permissions",
 		}
 	},
+	"INVALID_TIME_FORMAT": func(string) []string {
+		return []string{
+			"Use RFC 3339 timestamps:    --since 2024-01-01T00:00:00Z",
+		}
+	},
+	"ANALYSIS_ERROR": func(string) []string {
+		return []string{
+			"Check that the telemetry file exists and holds JSON Lines events",
+		}
+	},
+	"VALIDATION_ERROR": func(string) []string {
+		return []string{
+			"List problems as JSON:      vulntor fingerprint validate <file> --json",
+		}
+	},
 	"DAG_LOAD_FAILED": func(string) []string {
 		return []string{
 			"Verify the DAG file path exists",
@@ -418,6 +568,22 @@ var suggestionGenerators = map[string]func(string) []string{
 			"Retry without --output to print to stdout",
 		}
 	},
+	"INVALID_USAGE": func(string) []string {
+		return []string{
+			"Show usage and flags:       vulntor <command> --help",
+		}
+	},
+	"CONFIG_LOAD_FAILED": func(string) []string {
+		return []string{
+			"Check the config file:      vulntor config validate <file>",
+			"Run without a config file to use the defaults",
+		}
+	},
+	"INTERNAL_ERROR": func(string) []string {
+		return []string{
+			"Retry with verbose logs:    add --verbosity 2",
+		}
+	},
 	"DAG_INVALID": func(string) []string {
 		return []string{
 			"Run vulntor dag validate on the export output",
@@ -66,9 +66,16 @@ const sidebars: SidebarsConfig = {
         'cli/configuration',
         'cli/integrations',
         'cli/scan',
+        'cli/import',
+        'cli/group',
+        'cli/findings',
+        'cli/wordlist',
         'cli/workspace',
+        'cli/storage',
         'cli/server',
         'cli/fingerprint',
+        'cli/report',
+        'cli/bench',
       ],
     },
     {
@@ -79,6 +86,23 @@ const sidebars: SidebarsConfig = {
         'configuration/scan-profiles',
         'configuration/workspace-config',
         'configuration/logging',
+        'configuration/tracing',
+        'configuration/notifications',
+        'configuration/ticketing',
+        'configuration/proxy',
+        'configuration/scan-scope',
+        'configuration/engagements',
+        'configuration/telemetry',
+        'configuration/secrets',
+        'configuration/credentials',
+        'configuration/default-credentials',
+        'configuration/severity-policy',
+        'configuration/remediation-sla',
+        'configuration/honeypot-detection',
+        'configuration/cdn-detection',
+        'configuration/redaction',
+        'configuration/encryption',
+        'configuration/plugin-signing',
       ],
     },
     {
@@ -100,6 +124,8 @@ const sidebars: SidebarsConfig = {
         'advanced/external-plugins',
         'advanced/hooks-events',
         'advanced/custom-fingerprints',
+        'advanced/nuclei-templates',
+        'advanced/compliance-mapping',
       ],
     },
     {
@@ -174,6 +200,9 @@ const sidebars: SidebarsConfig = {
         'api/rest/jobs',
       ],
     },
+    'api/grpc',
+    'api/go-sdk',
+    'api/json-rpc',
     {
       type: 'category',
       label: 'UI Portal',
@@ -0,0 +1,135 @@
+// Package audit records mutating operations (scans, plugin changes,
+// credential and tenant management) in the storage backend's audit log.
+//
+// Events capture who acted (the authenticated principal for API calls, the
+// OS user for CLI commands), what they did, to which resource, and whether
+// it succeeded. Recording is best-effort: a failure to write the audit log
+// is logged but never fails the operation being audited.
+package audit
+
+import (
+	"context"
+	"os"
+	"os/user"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Event sources.
+const (
+	SourceCLI = "cli"
+	SourceAPI = "api"
+)
+
+// Recorder appends audit events to a store. A nil *Recorder records nothing.
+type Recorder struct {
+	store  storage.AuditStore
+	source string
+	actor  string // used when the context carries no principal
+}
+
+// NewRecorder creates a recorder writing events from source ("cli" or "api").
+func NewRecorder(store storage.AuditStore, source string) *Recorder {
+	return &Recorder{
+		store:  store,
+		source: source,
+		actor:  "anonymous",
+	}
+}
+
+// WithActor sets the actor recorded when the context carries no
+// authenticated principal (e.g. the OS user for CLI commands, or the
+// shared token holder in token auth mode).
+func (r *Recorder) WithActor(actor string) *Recorder {
+	r.actor = actor
+	return r
+}
+
+// Record appends an event for action on resource in the context's tenant.
+// A non-nil opErr marks the event as failed.
+func (r *Recorder) Record(ctx context.Context, action, resource string, opErr error, details map[string]string) {
+	if r == nil || r.store == nil {
+		return
+	}
+
+	event := &storage.AuditEvent{
+		Actor:    Actor(ctx, r.actor),
+		Source:   r.source,
+		Action:   action,
+		Resource: resource,
+		Outcome:  storage.AuditOutcomeSuccess,
+		Details:  details,
+	}
+	if opErr != nil {
+		event.Outcome = storage.AuditOutcomeFailure
+		event.Error = opErr.Error()
+	}
+
+	if err := r.store.Append(ctx, storage.OrgIDFromContext(ctx), event); err != nil {
+		log.Warn().
+			Str("component", "audit").
+			Str("action", action).
+			Err(err).
+			Msg("Failed to record audit event")
+	}
+}
+
+// Actor returns the identity behind ctx: the authenticated user, or
+// "apikey:<key-id>" for API keys not owned by a user. Falls back to
+// fallback for unauthenticated contexts.
+func Actor(ctx context.Context, fallback string) string {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	switch {
+	case !ok:
+		return fallback
+	case principal.UserID != "":
+		return principal.UserID
+	case principal.KeyID != "":
+		return "apikey:" + principal.KeyID
+	default:
+		return fallback
+	}
+}
+
+// LocalActor returns the name of the OS user running the CLI.
+func LocalActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// RecordCLI records a CLI operation in the workspace audit log. The
+// workspace comes from the storage config on ctx (storage.WithConfig) or
+// the default storage config.
+func RecordCLI(ctx context.Context, action, resource string, opErr error, details map[string]string) {
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		var err error
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			log.Debug().Str("component", "audit").Err(err).Msg("Audit log unavailable")
+			return
+		}
+	}
+
+	backend, err := storage.NewBackend(ctx, cfg)
+	if err != nil {
+		log.Debug().Str("component", "audit").Err(err).Msg("Audit log unavailable")
+		return
+	}
+	defer func() { _ = backend.Close() }()
+
+	auditBackend, ok := backend.(storage.AuditBackend)
+	if !ok {
+		return
+	}
+	NewRecorder(auditBackend.Audit(), SourceCLI).
+		WithActor(LocalActor()).
+		Record(ctx, action, resource, opErr, details)
+}
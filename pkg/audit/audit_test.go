@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newTestBackend(t *testing.T) (*storage.LocalBackend, *storage.Config) {
+	t.Helper()
+	cfg := &storage.Config{WorkspaceRoot: t.TempDir()}
+	backend, err := storage.NewLocalBackend(context.Background(), cfg)
+	require.NoError(t, err)
+	return backend, cfg
+}
+
+func TestRecorder_Record(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestBackend(t)
+	rec := NewRecorder(backend.Audit(), SourceAPI).WithActor("token")
+
+	// Principal-less requests use the configured actor
+	rec.Record(ctx, "scan.create", "scan-1", nil, map[string]string{"targets": "10.0.0.1"})
+
+	// User-owned credentials are attributed to the user, bare keys to the key
+	rec.Record(auth.WithPrincipal(ctx, &auth.Principal{KeyID: "k1", UserID: "alice"}), "plugin.install", "ssh", nil, nil)
+	rec.Record(auth.WithPrincipal(ctx, &auth.Principal{KeyID: "k2"}), "plugin.uninstall", "ssh", errors.New("not installed"), nil)
+
+	events, err := backend.Audit().List(ctx, storage.DefaultOrgID, storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	require.Equal(t, "apikey:k2", events[0].Actor)
+	require.Equal(t, storage.AuditOutcomeFailure, events[0].Outcome)
+	require.Equal(t, "not installed", events[0].Error)
+
+	require.Equal(t, "alice", events[1].Actor)
+	require.Equal(t, storage.AuditOutcomeSuccess, events[1].Outcome)
+
+	require.Equal(t, "token", events[2].Actor)
+	require.Equal(t, SourceAPI, events[2].Source)
+	require.Equal(t, "scan-1", events[2].Resource)
+	require.Equal(t, map[string]string{"targets": "10.0.0.1"}, events[2].Details)
+}
+
+func TestRecorder_TenantScoped(t *testing.T) {
+	backend, _ := newTestBackend(t)
+	rec := NewRecorder(backend.Audit(), SourceAPI)
+
+	rec.Record(storage.WithOrgID(context.Background(), "acme"), "scan.create", "scan-1", nil, nil)
+
+	events, err := backend.Audit().List(context.Background(), "acme", storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "anonymous", events[0].Actor)
+
+	events, err = backend.Audit().List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestRecorder_Nil(t *testing.T) {
+	var rec *Recorder
+	rec.Record(context.Background(), "scan.create", "scan-1", nil, nil)
+}
+
+func TestRecordCLI(t *testing.T) {
+	backend, cfg := newTestBackend(t)
+	ctx := storage.WithConfig(context.Background(), cfg)
+
+	RecordCLI(ctx, "apikey.create", "k1", nil, map[string]string{"name": "ci"})
+
+	events, err := backend.Audit().List(ctx, storage.DefaultOrgID, storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, SourceCLI, events[0].Source)
+	require.Equal(t, LocalActor(), events[0].Actor)
+	require.Equal(t, "apikey.create", events[0].Action)
+}
@@ -0,0 +1,113 @@
+// Package bandwidth caps the download rate of bulk transfers, such as
+// plugin updates and fingerprint catalog syncs, so they do not saturate
+// constrained links like an assessment laptop on a VPN.
+//
+// A Limiter is a token bucket shared by every transfer it wraps: the rate
+// applies to their sum, not to each one. Readers wrapped with Reader take
+// tokens for the bytes they return and sleep while the bucket is empty.
+//
+// A nil *Limiter is unlimited, so code paths without a configured limit
+// read at full speed.
+package bandwidth
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket refilled at a fixed number of bytes per
+// second. It holds at most one second worth of tokens, so idle time lets a
+// transfer burst by up to that much.
+type Limiter struct {
+	rate float64 // Bytes per second
+
+	mu     sync.Mutex
+	tokens float64 // Negative while readers are in debt
+	last   time.Time
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewLimiter returns a limiter for bytesPerSecond, or nil (unlimited) when
+// it is not positive.
+func NewLimiter(bytesPerSecond int64) *Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &Limiter{
+		rate:   float64(bytesPerSecond),
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+// Rate returns the limit in bytes per second, or 0 when unlimited.
+func (l *Limiter) Rate() int64 {
+	if l == nil {
+		return 0
+	}
+	return int64(l.rate)
+}
+
+// WaitN takes n tokens and waits until the bucket has paid for them. Reads
+// larger than the bucket are allowed; they wait for the deficit.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if l == nil || n <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+	l.tokens -= float64(n)
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+	return l.sleep(ctx, time.Duration(deficit/l.rate*float64(time.Second)))
+}
+
+// Reader returns r limited by l. The reader stops waiting, and returns the
+// context error, when ctx is done.
+func (l *Limiter) Reader(ctx context.Context, r io.Reader) io.Reader {
+	if l == nil {
+		return r
+	}
+	return &reader{ctx: ctx, r: r, limiter: l}
+}
+
+type reader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *Limiter
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.limiter.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// sleepContext sleeps for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
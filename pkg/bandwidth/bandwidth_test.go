@@ -0,0 +1,88 @@
+package bandwidth
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock drives a limiter without sleeping: sleeps advance the clock.
+type fakeClock struct {
+	now   time.Time
+	slept []time.Duration
+}
+
+func newTestLimiter(bytesPerSecond int64) (*Limiter, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLimiter(bytesPerSecond)
+	l.last = clock.now
+	l.now = func() time.Time { return clock.now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		clock.slept = append(clock.slept, d)
+		clock.now = clock.now.Add(d)
+		return nil
+	}
+	return l, clock
+}
+
+func TestLimiter_WaitN(t *testing.T) {
+	l, clock := newTestLimiter(1000)
+	ctx := context.Background()
+
+	// A full bucket pays for the first second
+	require.NoError(t, l.WaitN(ctx, 1000))
+	require.Empty(t, clock.slept)
+
+	// Then reads wait for their bytes at the rate
+	require.NoError(t, l.WaitN(ctx, 500))
+	require.Equal(t, []time.Duration{500 * time.Millisecond}, clock.slept)
+
+	// Reads larger than the bucket wait for the whole deficit
+	require.NoError(t, l.WaitN(ctx, 3000))
+	require.Equal(t, 3*time.Second, clock.slept[1])
+
+	// Idle time refills at most one second worth of tokens
+	clock.now = clock.now.Add(time.Hour)
+	require.NoError(t, l.WaitN(ctx, 1500))
+	require.Equal(t, 500*time.Millisecond, clock.slept[2])
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	require.Nil(t, NewLimiter(0))
+	require.Nil(t, NewLimiter(-1))
+
+	var l *Limiter
+	require.Zero(t, l.Rate())
+	require.NoError(t, l.WaitN(context.Background(), 1<<30))
+
+	r := strings.NewReader("catalog")
+	require.Same(t, r, l.Reader(context.Background(), r))
+}
+
+func TestLimiter_Reader(t *testing.T) {
+	l, clock := newTestLimiter(4)
+	require.Equal(t, int64(4), l.Rate())
+
+	data, err := io.ReadAll(l.Reader(context.Background(), strings.NewReader("0123456789ab")))
+	require.NoError(t, err)
+	require.Equal(t, "0123456789ab", string(data))
+
+	var total time.Duration
+	for _, d := range clock.slept {
+		total += d
+	}
+	require.Equal(t, 2*time.Second, total, "12 bytes at 4 B/s with a 4 byte bucket")
+}
+
+func TestLimiter_ReaderCanceled(t *testing.T) {
+	l := NewLimiter(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := io.ReadAll(l.Reader(ctx, strings.NewReader("more than one second of data")))
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,290 @@
+// Package bench measures the throughput of the scan pipeline against
+// synthetic, in-process targets, so that the results of two builds on the
+// same machine can be compared to catch performance regressions.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+// Benchmark names.
+const (
+	BenchPortScan    = "port-scan"
+	BenchFingerprint = "fingerprint"
+	BenchPluginEval  = "plugin-eval"
+)
+
+// Defaults of Options.
+const (
+	DefaultPorts    = 200
+	DefaultDuration = 2 * time.Second
+)
+
+// Options configure Run.
+type Options struct {
+	// Ports is the number of listeners the port scan benchmark scans;
+	// DefaultPorts when zero.
+	Ports int
+	// Duration is the minimum time each benchmark runs for, repeating its
+	// workload; DefaultDuration when zero.
+	Duration time.Duration
+	// Benchmarks selects the benchmarks to run; all when empty.
+	Benchmarks []string
+}
+
+// Result is the outcome of a benchmark: the work it did, and the rate it
+// did it at.
+type Result struct {
+	Name       string        `json:"name"`
+	Unit       string        `json:"unit"` // of Rate, e.g. "ports/s"
+	Rate       float64       `json:"rate"`
+	Operations int           `json:"operations"`
+	Elapsed    time.Duration `json:"elapsed_ns"`
+}
+
+// Report is the outcome of a run, and the environment it ran in. Reports
+// saved as JSON serve as baselines for Compare.
+type Report struct {
+	Version   string    `json:"version,omitempty"`
+	GoVersion string    `json:"go_version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	CPUs      int       `json:"cpus"`
+	StartedAt time.Time `json:"started_at"`
+	Ports     int       `json:"ports"`
+	Results   []Result  `json:"results"`
+}
+
+// Result returns the result of the named benchmark, or nil.
+func (r *Report) Result(name string) *Result {
+	for i := range r.Results {
+		if r.Results[i].Name == name {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// Benchmarks lists the available benchmarks in the order they run.
+var Benchmarks = []string{BenchPortScan, BenchFingerprint, BenchPluginEval}
+
+// Run runs the benchmarks of opts one after the other.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Ports <= 0 {
+		opts.Ports = DefaultPorts
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = DefaultDuration
+	}
+	selected := opts.Benchmarks
+	if len(selected) == 0 {
+		selected = Benchmarks
+	}
+	for _, name := range selected {
+		if !slices.Contains(Benchmarks, name) {
+			return nil, fmt.Errorf("unknown benchmark %q (must be %s)", name, strings.Join(Benchmarks, ", "))
+		}
+	}
+
+	report := &Report{
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+		StartedAt: time.Now().UTC(),
+		Ports:     opts.Ports,
+	}
+	for _, name := range Benchmarks {
+		if !slices.Contains(selected, name) {
+			continue
+		}
+		var (
+			result Result
+			err    error
+		)
+		switch name {
+		case BenchPortScan:
+			result, err = benchPortScan(ctx, opts)
+		case BenchFingerprint:
+			result, err = benchFingerprint(ctx, opts)
+		case BenchPluginEval:
+			result, err = benchPluginEval(ctx, opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// repeat runs op until opts.Duration has elapsed, and returns the result
+// of the operations it counted.
+func repeat(ctx context.Context, opts Options, name, unit string, op func() (int, error)) (Result, error) {
+	result := Result{Name: name, Unit: unit}
+	start := time.Now()
+	for result.Elapsed < opts.Duration {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		n, err := op()
+		if err != nil {
+			return Result{}, err
+		}
+		result.Operations += n
+		result.Elapsed = time.Since(start)
+	}
+	result.Rate = float64(result.Operations) / result.Elapsed.Seconds()
+	return result, nil
+}
+
+// benchPortScan scans opts.Ports loopback listeners with the TCP port
+// discovery module.
+func benchPortScan(ctx context.Context, opts Options) (Result, error) {
+	ports := make([]string, 0, opts.Ports)
+	for i := 0; i < opts.Ports; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return Result{}, fmt.Errorf("listen: %w", err)
+		}
+		defer ln.Close()
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				_ = conn.Close()
+			}
+		}()
+		ports = append(ports, strconv.Itoa(ln.Addr().(*net.TCPAddr).Port))
+	}
+
+	module := discovery.TCPPortDiscoveryModuleFactory()
+	if err := module.Init("bench", map[string]interface{}{
+		"ports":   []string{strings.Join(ports, ",")},
+		"timeout": "1s",
+	}); err != nil {
+		return Result{}, err
+	}
+	inputs := map[string]interface{}{"config.targets": []string{"127.0.0.1"}}
+
+	return repeat(ctx, opts, BenchPortScan, "ports/s", func() (int, error) {
+		outputChan := make(chan engine.ModuleOutput, 16)
+		done := make(chan int)
+		go func() {
+			open := 0
+			for out := range outputChan {
+				if result, ok := out.Data.(discovery.TCPPortDiscoveryResult); ok {
+					open += len(result.OpenPorts)
+				}
+			}
+			done <- open
+		}()
+		err := module.Execute(ctx, inputs, outputChan)
+		close(outputChan)
+		open := <-done
+		if err != nil {
+			return 0, err
+		}
+		if open != len(ports) {
+			return 0, fmt.Errorf("found %d of %d open ports", open, len(ports))
+		}
+		return len(ports), nil
+	})
+}
+
+// fingerprintBanners are the banners the fingerprint benchmark resolves:
+// common services, and one that matches no rule.
+var fingerprintBanners = []fingerprint.Input{
+	{Protocol: "http", Port: 80, Banner: "HTTP/1.1 200 OK\r\nServer: Apache/2.4.41 (Ubuntu)\r\nContent-Type: text/html\r\n\r\n"},
+	{Protocol: "http", Port: 8080, Banner: "HTTP/1.1 404 Not Found\r\nServer: nginx/1.18.0\r\n\r\n"},
+	{Protocol: "ssh", Port: 22, Banner: "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.6"},
+	{Protocol: "ftp", Port: 21, Banner: "220 (vsFTPd 3.0.3)"},
+	{Protocol: "smtp", Port: 25, Banner: "220 mail.example.com ESMTP Postfix (Ubuntu)"},
+	{Protocol: "mysql", Port: 3306, Banner: "5.7.42-log"},
+	{Protocol: "redis", Port: 6379, Banner: "-NOAUTH Authentication required."},
+	{Protocol: "tcp", Port: 9999, Banner: "\x00\x01\x02 unknown service"},
+}
+
+// benchFingerprint resolves fingerprintBanners with the built-in rules.
+func benchFingerprint(ctx context.Context, opts Options) (Result, error) {
+	resolver := fingerprint.GetFingerprintResolver()
+	return repeat(ctx, opts, BenchFingerprint, "banners/s", func() (int, error) {
+		for _, in := range fingerprintBanners {
+			// Banners matching no rule are expected to fail
+			_, _ = resolver.Resolve(ctx, in)
+		}
+		return len(fingerprintBanners), nil
+	})
+}
+
+// pluginContexts are the scan data the plugin benchmark evaluates the
+// embedded plugins against.
+var pluginContexts = []map[string]any{
+	{
+		"service.name": "ssh",
+		"ssh.banner":   "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.6",
+		"ssh.version":  "8.9p1",
+		"ssh.encryption_algorithms": []string{
+			"chacha20-poly1305@openssh.com", "aes128-ctr", "aes256-cbc", "3des-cbc",
+		},
+		"ssh.mac_algorithms": []string{"hmac-sha2-256", "hmac-sha1", "hmac-md5"},
+		"ssh.kex_algorithms": []string{"curve25519-sha256", "diffie-hellman-group1-sha1"},
+	},
+	{
+		"service.name":        "http",
+		"http.headers.server": "Apache/2.4.41 (Ubuntu)",
+		"http.headers":        map[string]any{"server": "Apache/2.4.41 (Ubuntu)"},
+		"http.title":          "Apache2 Ubuntu Default Page: It works",
+		"http.body":           "<html><head><title>Apache2 Ubuntu Default Page: It works</title></head></html>",
+	},
+	{
+		"service.name":                   "https",
+		"tls.version":                    "TLS1.0",
+		"tls.supported_versions":         []string{"TLS1.0", "TLS1.2"},
+		"tls.cipher_suites":              []string{"TLS_RSA_WITH_RC4_128_SHA", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"tls.certificate.issuer":         "CN=localhost",
+		"tls.certificate.is_self_signed": true,
+		"tls.certificate.not_after":      "2020-01-01T00:00:00Z",
+	},
+	{
+		"service.name": "mysql",
+		"mysql.banner": "5.7.42-log",
+	},
+}
+
+// benchPluginEval evaluates the embedded plugins against pluginContexts.
+func benchPluginEval(ctx context.Context, opts Options) (Result, error) {
+	plugins, err := plugin.LoadAllEmbeddedPlugins()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(plugins) == 0 {
+		return Result{}, errors.New("no embedded plugins")
+	}
+	evaluator := plugin.NewEvaluator()
+	return repeat(ctx, opts, BenchPluginEval, "evaluations/s", func() (int, error) {
+		for _, data := range pluginContexts {
+			// Matching plugins write their extractions into the context
+			if _, err := evaluator.EvaluateAll(plugins, maps.Clone(data)); err != nil {
+				return 0, err
+			}
+		}
+		return len(plugins) * len(pluginContexts), nil
+	})
+}
@@ -0,0 +1,62 @@
+package bench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	report, err := Run(context.Background(), Options{Ports: 5, Duration: 20 * time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, 5, report.Ports)
+	require.NotEmpty(t, report.GoVersion)
+	require.Len(t, report.Results, 3)
+	for i, name := range Benchmarks {
+		r := report.Results[i]
+		require.Equal(t, name, r.Name)
+		require.Positive(t, r.Operations, name)
+		require.Positive(t, r.Rate, name)
+		require.GreaterOrEqual(t, r.Elapsed, 20*time.Millisecond, name)
+	}
+	require.Zero(t, report.Result(BenchPortScan).Operations%5)
+
+	report, err = Run(context.Background(), Options{Duration: time.Millisecond, Benchmarks: []string{BenchFingerprint}})
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	require.Nil(t, report.Result(BenchPluginEval))
+
+	_, err = Run(context.Background(), Options{Benchmarks: []string{"disk"}})
+	require.ErrorContains(t, err, `unknown benchmark "disk"`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Run(ctx, Options{Benchmarks: []string{BenchPluginEval}})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Report{Results: []Result{
+		{Name: BenchPortScan, Unit: "ports/s", Rate: 1000},
+		{Name: BenchFingerprint, Unit: "banners/s", Rate: 5000},
+		{Name: BenchPluginEval, Unit: "evaluations/s", Rate: 0},
+	}}
+	current := &Report{Results: []Result{
+		{Name: BenchPortScan, Unit: "ports/s", Rate: 850},
+		{Name: BenchFingerprint, Unit: "banners/s", Rate: 3000},
+		{Name: BenchPluginEval, Unit: "evaluations/s", Rate: 100},
+	}}
+
+	regressions := Compare(baseline, current, 0)
+	require.Equal(t, []Regression{
+		{Name: BenchFingerprint, Unit: "banners/s", Baseline: 5000, Current: 3000, Change: -0.4},
+	}, regressions)
+	require.Len(t, Compare(baseline, current, 0.1), 2)
+	require.Empty(t, Compare(baseline, current, 0.5))
+
+	err := &RegressionError{Regressions: regressions}
+	require.Equal(t, "performance regression: fingerprint -40% (5000 -> 3000 banners/s)", err.Error())
+	require.Equal(t, "BENCH_REGRESSION", err.Code())
+}
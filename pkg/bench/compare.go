@@ -0,0 +1,63 @@
+package bench
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultTolerance is the drop in rate Compare accepts when the tolerance
+// given is zero: 20%, as runs on one machine vary by a few percent.
+const DefaultTolerance = 0.2
+
+// Regression is a benchmark whose rate dropped below its baseline by more
+// than the tolerance.
+type Regression struct {
+	Name     string  `json:"name"`
+	Unit     string  `json:"unit"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	Change   float64 `json:"change"` // relative change of the rate, e.g. -0.35
+}
+
+// Compare returns the benchmarks of current that regressed against
+// baseline: those whose rate is lower by more than tolerance (a fraction;
+// DefaultTolerance when zero). Benchmarks missing from either report are
+// not compared.
+func Compare(baseline, current *Report, tolerance float64) []Regression {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+	var regressions []Regression
+	for _, cur := range current.Results {
+		base := baseline.Result(cur.Name)
+		if base == nil || base.Rate <= 0 {
+			continue
+		}
+		change := cur.Rate/base.Rate - 1
+		if change < -tolerance {
+			regressions = append(regressions, Regression{
+				Name: cur.Name, Unit: cur.Unit, Baseline: base.Rate, Current: cur.Rate, Change: change,
+			})
+		}
+	}
+	return regressions
+}
+
+// RegressionError is returned when benchmarks regressed against their
+// baseline.
+type RegressionError struct {
+	Regressions []Regression
+}
+
+func (e *RegressionError) Error() string {
+	parts := make([]string, 0, len(e.Regressions))
+	for _, r := range e.Regressions {
+		parts = append(parts, fmt.Sprintf("%s %.0f%% (%.0f -> %.0f %s)", r.Name, 100*r.Change, r.Baseline, r.Current, r.Unit))
+	}
+	return "performance regression: " + strings.Join(parts, ", ")
+}
+
+// Code returns the CLI error code of regressions.
+func (e *RegressionError) Code() string {
+	return "BENCH_REGRESSION"
+}
@@ -0,0 +1,214 @@
+// Package blackout pauses scans during blackout windows, e.g. the business
+// hours of fragile OT segments or a change freeze, and resumes them when
+// the windows end.
+//
+// Windows are configured per target group as specs (see ParseWindow). A
+// Gate holds the windows of the groups of a scan and is carried in the
+// scan context (see WithContext), the same way fdbudget and egress are:
+// probes wait for the gate before they connect to a host, so hosts outside
+// a blackout are scanned on while the others are paused. Connections
+// dialed through netproxy wait automatically, other dialers opt in with
+// Dial. A nil *Gate never pauses.
+package blackout
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/timeutil"
+)
+
+// Window is a blackout window: a daily time range on some weekdays, or a
+// one-off range of dates and times.
+type Window struct {
+	spec string
+
+	// Recurring windows
+	days       []time.Weekday // empty = every day
+	start, end clock          // end <= start spans midnight
+	loc        *time.Location
+
+	// One-off windows
+	from, until time.Time
+}
+
+// clock is a time of day, in minutes after midnight.
+type clock int
+
+func parseClock(s string) (clock, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM)", s)
+	}
+	return clock(t.Hour()*60 + t.Minute()), nil
+}
+
+// on returns the time c on the day of t, in the location of t.
+func (c clock) on(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), int(c)/60, int(c)%60, 0, 0, t.Location())
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWindow parses a window spec, either recurring or one-off:
+//
+//	[DAYS] HH:MM-HH:MM [TIMEZONE]   e.g. "mon-fri 08:00-18:00 Europe/Berlin"
+//	FROM/UNTIL                      e.g. "2026-12-24/2026-12-26"
+//
+// DAYS lists weekdays (mon, tue, ...) and ranges of them separated by
+// commas, e.g. "mon-fri" or "sat,sun"; without DAYS, the window recurs
+// every day. A range ending before it starts spans midnight, and belongs
+// to the day it starts on. TIMEZONE is an IANA time zone name, the local
+// time zone by default.
+//
+// FROM and UNTIL are RFC 3339 times or dates; dates start at midnight in
+// the local time zone, and an UNTIL date includes the whole day.
+func ParseWindow(spec string) (Window, error) {
+	w := Window{spec: strings.TrimSpace(spec)}
+	if w.spec == "" {
+		return w, fmt.Errorf("empty blackout window")
+	}
+	if from, until, ok := strings.Cut(w.spec, "/"); ok && !strings.Contains(w.spec, " ") {
+		return w.parseOneOff(from, until)
+	}
+
+	fields := strings.Fields(w.spec)
+	i := 0
+	if len(fields) > 0 && !strings.Contains(fields[0], ":") {
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return w, fmt.Errorf("blackout window %q: %w", spec, err)
+		}
+		w.days = days
+		i++
+	}
+	if i >= len(fields) {
+		return w, fmt.Errorf("blackout window %q: missing time range (want HH:MM-HH:MM)", spec)
+	}
+	start, end, ok := strings.Cut(fields[i], "-")
+	if !ok {
+		return w, fmt.Errorf("blackout window %q: invalid time range %q (want HH:MM-HH:MM)", spec, fields[i])
+	}
+	var err error
+	if w.start, err = parseClock(start); err != nil {
+		return w, fmt.Errorf("blackout window %q: %w", spec, err)
+	}
+	if w.end, err = parseClock(end); err != nil {
+		return w, fmt.Errorf("blackout window %q: %w", spec, err)
+	}
+	i++
+
+	w.loc = time.Local
+	switch len(fields) - i {
+	case 0:
+	case 1:
+		if w.loc, err = time.LoadLocation(fields[i]); err != nil {
+			return w, fmt.Errorf("blackout window %q: unknown time zone %q", spec, fields[i])
+		}
+	default:
+		return w, fmt.Errorf("blackout window %q: unexpected %q", spec, strings.Join(fields[i+1:], " "))
+	}
+	return w, nil
+}
+
+func (w Window) parseOneOff(from, until string) (Window, error) {
+	var err error
+	if w.from, _, err = timeutil.ParseTime(from); err != nil {
+		return w, fmt.Errorf("blackout window %q: %w", w.spec, err)
+	}
+	var date bool
+	if w.until, date, err = timeutil.ParseTime(until); err != nil {
+		return w, fmt.Errorf("blackout window %q: %w", w.spec, err)
+	}
+	if date {
+		w.until = w.until.AddDate(0, 0, 1)
+	}
+	if !w.until.After(w.from) {
+		return w, fmt.Errorf("blackout window %q ends before it starts", w.spec)
+	}
+	return w, nil
+}
+
+// parseDays parses a list of weekdays and weekday ranges.
+func parseDays(s string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from, ok := weekdays[first]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", first)
+		}
+		to := from
+		if isRange {
+			if to, ok = weekdays[last]; !ok {
+				return nil, fmt.Errorf("invalid weekday %q", last)
+			}
+		}
+		for d := from; ; d = (d + 1) % 7 {
+			if !slices.Contains(days, d) {
+				days = append(days, d)
+			}
+			if d == to {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// String returns the spec of the window.
+func (w Window) String() string {
+	return w.spec
+}
+
+// End returns the end of the occurrence of the window active at t, or the
+// zero Time if the window is not active at t.
+func (w Window) End(t time.Time) time.Time {
+	if w.loc == nil {
+		if !t.Before(w.from) && t.Before(w.until) {
+			return w.until
+		}
+		return time.Time{}
+	}
+
+	// The occurrence active at t started today or, spanning midnight,
+	// yesterday
+	local := t.In(w.loc)
+	for _, day := range []time.Time{local, local.AddDate(0, 0, -1)} {
+		if len(w.days) > 0 && !slices.Contains(w.days, day.Weekday()) {
+			continue
+		}
+		start := w.start.on(day)
+		end := w.end.on(day)
+		if w.end <= w.start {
+			end = w.end.on(day.AddDate(0, 0, 1))
+		}
+		if !local.Before(start) && local.Before(end) {
+			return end
+		}
+	}
+	return time.Time{}
+}
+
+// Active reports whether the window is active at t.
+func (w Window) Active(t time.Time) bool {
+	return !w.End(t).IsZero()
+}
+
+// ParseWindows parses window specs.
+func ParseWindows(specs []string) ([]Window, error) {
+	windows := make([]Window, 0, len(specs))
+	for _, spec := range specs {
+		w, err := ParseWindow(spec)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
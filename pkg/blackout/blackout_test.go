@@ -0,0 +1,123 @@
+package blackout
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	w, err := ParseWindow("mon-fri 08:00-18:00 Europe/Berlin")
+	require.NoError(t, err)
+	require.Equal(t, "mon-fri 08:00-18:00 Europe/Berlin", w.String())
+	// Thursday 2026-10-15
+	require.True(t, w.Active(time.Date(2026, 10, 15, 8, 0, 0, 0, berlin)))
+	require.Equal(t, time.Date(2026, 10, 15, 18, 0, 0, 0, berlin), w.End(time.Date(2026, 10, 15, 12, 0, 0, 0, berlin)))
+	require.False(t, w.Active(time.Date(2026, 10, 15, 18, 0, 0, 0, berlin)))
+	require.False(t, w.Active(time.Date(2026, 10, 17, 12, 0, 0, 0, berlin)), "saturday")
+	require.True(t, w.Active(time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)), "09:00 in Berlin")
+
+	// Ranges ending before they start span midnight, and belong to the day
+	// they start on
+	w, err = ParseWindow("fri,sun 22:00-06:00 UTC")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC), w.End(time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)), "saturday morning")
+	require.False(t, w.Active(time.Date(2026, 10, 16, 1, 0, 0, 0, time.UTC)), "friday morning")
+	require.True(t, w.Active(time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)), "sunday night")
+
+	w, err = ParseWindow("sat-mon 00:00-00:00")
+	require.NoError(t, err)
+	require.True(t, w.Active(time.Date(2026, 10, 19, 12, 0, 0, 0, time.Local)), "monday")
+	require.False(t, w.Active(time.Date(2026, 10, 20, 12, 0, 0, 0, time.Local)), "tuesday")
+
+	w, err = ParseWindow("12:00-13:00")
+	require.NoError(t, err)
+	require.True(t, w.Active(time.Date(2026, 10, 20, 12, 30, 0, 0, time.Local)))
+
+	// One-off windows; an until date includes the whole day
+	w, err = ParseWindow("2026-12-24/2026-12-26")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 12, 27, 0, 0, 0, 0, time.Local), w.End(time.Date(2026, 12, 26, 23, 0, 0, 0, time.Local)))
+	require.False(t, w.Active(time.Date(2026, 12, 23, 23, 0, 0, 0, time.Local)))
+
+	w, err = ParseWindow("2026-11-01T02:00:00Z/2026-11-01T04:00:00Z")
+	require.NoError(t, err)
+	require.True(t, w.Active(time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC)))
+	require.False(t, w.Active(time.Date(2026, 11, 1, 4, 0, 0, 0, time.UTC)))
+
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"", "empty blackout window"},
+		{"mon-fri", "missing time range"},
+		{"mon-fri 08:00", "invalid time range"},
+		{"mon-fri 8h-18h", `invalid time "8h"`},
+		{"weekdays 08:00-18:00", `invalid weekday "weekdays"`},
+		{"08:00-18:00 Mars/Olympus", `unknown time zone "Mars/Olympus"`},
+		{"08:00-18:00 UTC extra", `unexpected "extra"`},
+		{"2026-12-26/2026-12-24", "ends before it starts"},
+		{"2026-12-24/tomorrow", `invalid time "tomorrow"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseWindow(tt.spec)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGate(t *testing.T) {
+	require.Nil(t, NewGate(nil))
+	require.Nil(t, NewGate([]Schedule{{Name: "empty", Contains: func(string) bool { return true }}}))
+
+	windows, err := ParseWindows([]string{"2026-10-16T08:00:00Z/2026-10-16T18:00:00Z", "2026-10-16T17:00:00Z/2026-10-16T19:00:00Z"})
+	require.NoError(t, err)
+	g := NewGate([]Schedule{{
+		Name:     "ot",
+		Contains: func(host string) bool { return host == "10.0.0.5" },
+		Windows:  windows,
+	}})
+
+	noon := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), g.Until("10.0.0.5:502", noon))
+	require.Equal(t, time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC), g.Until("10.0.0.5", noon.Add(6*time.Hour)))
+	require.True(t, g.Until("10.0.0.6:502", noon).IsZero())
+	require.True(t, g.Until("10.0.0.5", noon.Add(-6*time.Hour)).IsZero())
+
+	// Wait resumes once the windows are over
+	var mu sync.Mutex
+	clock := noon
+	g.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now := clock
+		clock = clock.Add(time.Hour) // each wait takes an hour
+		return now
+	}
+	var dialed string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = addr
+		return nil, nil
+	}
+	g.schedules[0].Windows = []Window{{spec: "test", from: noon, until: noon.Add(20 * time.Millisecond)}}
+	_, err = Dial(dial)(WithContext(context.Background(), g), "tcp", "10.0.0.5:502")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.5:502", dialed)
+
+	// Canceled contexts stop waiting
+	g.now = func() time.Time { return noon }
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, g.Wait(ctx, "10.0.0.5"), context.DeadlineExceeded)
+
+	var nilGate *Gate
+	require.NoError(t, nilGate.Wait(ctx, "10.0.0.5"))
+}
@@ -0,0 +1,141 @@
+package blackout
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Schedule is the blackout windows of a set of hosts, e.g. of a target
+// group.
+type Schedule struct {
+	// Name identifies the schedule in logs, e.g. the group name.
+	Name string
+	// Contains reports whether a host, a host name or IP address, belongs
+	// to the schedule.
+	Contains func(host string) bool
+	Windows  []Window
+}
+
+// Gate pauses probes to the hosts of its schedules while one of their
+// windows is active. It is safe for concurrent use.
+type Gate struct {
+	schedules []Schedule
+	now       func() time.Time
+
+	mu     sync.Mutex
+	paused map[string]time.Time // schedule name -> end of the logged pause
+}
+
+// NewGate returns a gate of schedules. It returns nil when no schedule has
+// windows, which never pauses.
+func NewGate(schedules []Schedule) *Gate {
+	g := &Gate{now: time.Now, paused: make(map[string]time.Time)}
+	for _, s := range schedules {
+		if len(s.Windows) > 0 && s.Contains != nil {
+			g.schedules = append(g.schedules, s)
+		}
+	}
+	if len(g.schedules) == 0 {
+		return nil
+	}
+	return g
+}
+
+// Until returns when probes to host may resume, or the zero Time if they
+// are not paused at t. The host may be given with a port.
+func (g *Gate) Until(host string, t time.Time) time.Time {
+	if g == nil {
+		return time.Time{}
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	var until time.Time
+	for _, s := range g.schedules {
+		if !s.Contains(host) {
+			continue
+		}
+		for _, w := range s.Windows {
+			if end := w.End(t); end.After(until) {
+				until = end
+				g.logPause(s.Name, w, end)
+			}
+		}
+	}
+	return until
+}
+
+// logPause logs the first pause of a schedule until end.
+func (g *Gate) logPause(name string, w Window, end time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused[name].Before(end) {
+		return
+	}
+	g.paused[name] = end
+	log.Info().
+		Str("component", "blackout").
+		Str("group", name).
+		Str("window", w.String()).
+		Time("until", end).
+		Msg("Blackout window active, pausing probes")
+}
+
+// Wait blocks while probes to host are paused, or until ctx is done.
+// Windows following each other are waited for in turn.
+func (g *Gate) Wait(ctx context.Context, host string) error {
+	if g == nil {
+		return nil
+	}
+	for {
+		now := g.now()
+		until := g.Until(host, now)
+		if until.IsZero() {
+			return nil
+		}
+		timer := time.NewTimer(until.Sub(now))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// DialContext waits for the gate, then dials addr with dial.
+func (g *Gate) DialContext(ctx context.Context, dial func(ctx context.Context, network, addr string) (net.Conn, error), network, addr string) (net.Conn, error) {
+	if err := g.Wait(ctx, addr); err != nil {
+		return nil, err
+	}
+	return dial(ctx, network, addr)
+}
+
+// Dial returns a dial function waiting for the gate of its context before
+// dialing with dial.
+func Dial(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return FromContext(ctx).DialContext(ctx, dial, network, addr)
+	}
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying g. A nil g leaves ctx unchanged.
+func WithContext(ctx context.Context, g *Gate) context.Context {
+	if g == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, g)
+}
+
+// FromContext returns the gate carried by ctx, or nil.
+func FromContext(ctx context.Context) *Gate {
+	g, _ := ctx.Value(contextKey{}).(*Gate)
+	return g
+}
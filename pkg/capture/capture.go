@@ -0,0 +1,588 @@
+// Package capture records the traffic of scan probes as pcap files, giving
+// assessors raw evidence of what was sent to a host and what it answered.
+//
+// A Recorder is carried in the scan context (see WithContext) and wraps the
+// connections modules dial, the same way fdbudget does: connections dialed
+// through netproxy are recorded automatically, other dialers opt in with
+// Dial. The Recorder does not sniff the network interface, so it needs no
+// privileges or libpcap. It rebuilds packets from the bytes exchanged on
+// each connection instead: payloads are exact, while IP and TCP headers
+// (handshake, sequence numbers, FIN) are synthesized. Connections tunneled
+// through a proxy are recorded as if they reached the target directly.
+//
+// Packets are written to one file per host, named after its IP address,
+// and saved as scan artifacts by Save. A nil *Recorder records nothing.
+package capture
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+)
+
+// Mode selects the traffic kept for a scan.
+type Mode string
+
+const (
+	// ModeAll keeps the traffic of every host.
+	ModeAll Mode = "all"
+	// ModeFindings keeps only the traffic of the ports findings were
+	// reported on.
+	ModeFindings Mode = "findings"
+)
+
+// Modes lists the supported capture modes.
+var Modes = []Mode{ModeAll, ModeFindings}
+
+// ParseMode returns the capture mode named s.
+func ParseMode(s string) (Mode, error) {
+	for _, mode := range Modes {
+		if string(mode) == s {
+			return mode, nil
+		}
+	}
+	return "", fmt.Errorf("invalid capture mode %q (expected all or findings)", s)
+}
+
+const (
+	// snapLen is the largest packet written to the files.
+	snapLen = 65536
+	// segmentSize is the largest TCP payload of a synthesized segment.
+	segmentSize = 1460
+	// flushSize is the amount of packet data a connection buffers before
+	// writing it to the file of its host.
+	flushSize = 1 << 20
+)
+
+// Recorder writes the packets of recorded connections to per-host pcap
+// files in a directory. It is safe for concurrent use.
+type Recorder struct {
+	dir    string
+	redact func(payload []byte) []byte
+
+	mu      sync.Mutex
+	hosts   map[string]struct{} // IP addresses with a file
+	aliases map[string]string   // dialed host name -> IP address
+}
+
+// NewRecorder returns a recorder writing its files to dir, which must exist
+// and is used by the recorder alone.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{
+		dir:     dir,
+		hosts:   make(map[string]struct{}),
+		aliases: make(map[string]string),
+	}
+}
+
+// WithRedaction makes the recorder pass payloads through redact before they
+// are written, e.g. to mask credentials. redact must keep the length of
+// payloads, so that the synthesized headers stay consistent.
+func (r *Recorder) WithRedaction(redact func(payload []byte) []byte) *Recorder {
+	if r != nil {
+		r.redact = redact
+	}
+	return r
+}
+
+// Hosts returns the IP addresses of the recorded hosts, sorted.
+func (r *Recorder) Hosts() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hosts := make([]string, 0, len(r.hosts))
+	for host := range r.hosts {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// Conn returns conn recording its traffic. addr is the address conn was
+// dialed to; connections on networks other than TCP and UDP, and those of
+// a nil recorder, are returned unchanged.
+func (r *Recorder) Conn(conn net.Conn, network, addr string) net.Conn {
+	if r == nil || conn == nil {
+		return conn
+	}
+	var proto layers.IPProtocol
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		proto = layers.IPProtocolTCP
+	case "udp", "udp4", "udp6":
+		proto = layers.IPProtocolUDP
+	default:
+		return conn
+	}
+
+	host, port := splitAddr(addr)
+	remote, remotePort := addrPort(conn.RemoteAddr())
+	if ip, err := netip.ParseAddr(host); err == nil {
+		// Proxied connections report the proxy as their remote address
+		remote = ip.Unmap()
+		if port != 0 {
+			remotePort = port
+		}
+	}
+	if !remote.IsValid() {
+		return conn
+	}
+	local, localPort := addrPort(conn.LocalAddr())
+	if !local.IsValid() || local.Is4() != remote.Is4() {
+		// Proxied connections may use another address family locally
+		local = netip.IPv4Unspecified()
+		if remote.Is6() {
+			local = netip.IPv6Unspecified()
+		}
+	}
+
+	r.mu.Lock()
+	r.hosts[remote.String()] = struct{}{}
+	if host != "" && host != remote.String() {
+		r.aliases[host] = remote.String()
+	}
+	r.mu.Unlock()
+
+	c := &recordedConn{
+		Conn:       conn,
+		recorder:   r,
+		host:       remote.String(),
+		proto:      proto,
+		local:      local,
+		remote:     remote,
+		localPort:  localPort,
+		remotePort: remotePort,
+	}
+	c.writer = pcapgo.NewWriter(&c.buf)
+	if proto == layers.IPProtocolTCP {
+		c.handshake()
+	}
+	if pc, ok := conn.(net.PacketConn); ok {
+		return &packetConn{recordedConn: c, packetConn: pc}
+	}
+	return c
+}
+
+// Dial returns dial recording each connection with the recorder of its
+// context, e.g. for http.Transport.DialContext.
+func Dial(dial func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return FromContext(ctx).Conn(conn, network, addr), nil
+	}
+}
+
+// ArtifactWriter stores scan artifacts, like storage.ArtifactStore.
+type ArtifactWriter interface {
+	Write(ctx context.Context, orgID, scanID, name string, data io.Reader) error
+}
+
+// Filter selects the ports whose traffic Save keeps, by host IP address or
+// dialed host name. Port 0 keeps all traffic of the host.
+type Filter map[string][]int
+
+// Save stores the file of each recorded host as the scan artifact
+// "<ip>.pcap" (with the colons of IPv6 addresses replaced by dashes) and
+// returns the names of the stored artifacts. With a filter, hosts missing
+// from it are skipped and only packets from or to its ports are kept; a
+// nil filter keeps everything.
+func (r *Recorder) Save(ctx context.Context, store ArtifactWriter, orgID, scanID string, filter Filter) ([]string, error) {
+	if r == nil {
+		return nil, nil
+	}
+	ports := r.resolve(filter)
+
+	var saved []string
+	for _, host := range r.Hosts() {
+		name := ArtifactName(host)
+		if filter != nil && ports[host] == nil {
+			continue
+		}
+		data, err := r.read(name, ports[host])
+		if err != nil {
+			return saved, err
+		}
+		if data == nil {
+			continue
+		}
+		if err := store.Write(ctx, orgID, scanID, name, data); err != nil {
+			return saved, fmt.Errorf("failed to save capture of %s: %w", host, err)
+		}
+		saved = append(saved, name)
+	}
+	return saved, nil
+}
+
+// ArtifactName returns the name of the capture artifact of a host.
+func ArtifactName(host string) string {
+	return strings.NewReplacer(":", "-", "%", "-").Replace(host) + ".pcap"
+}
+
+// resolve maps the hosts of filter to recorded IP addresses. The port set
+// of a host is empty, but not nil, when all its ports are kept.
+func (r *Recorder) resolve(filter Filter) map[string]map[int]bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ports := make(map[string]map[int]bool)
+	for host, hostPorts := range filter {
+		if ip, ok := r.aliases[host]; ok {
+			host = ip
+		} else if ip, err := netip.ParseAddr(host); err == nil {
+			host = ip.Unmap().String()
+		}
+		set, ok := ports[host]
+		if !ok {
+			set = make(map[int]bool)
+			ports[host] = set
+		} else if len(set) == 0 {
+			continue // already keeps all ports
+		}
+		for _, port := range hostPorts {
+			if port == 0 {
+				clear(set)
+				break
+			}
+			set[port] = true
+		}
+		if len(hostPorts) == 0 {
+			clear(set)
+		}
+	}
+	return ports
+}
+
+// read returns the file of a host, keeping only the packets of ports if
+// the set is not empty. It returns nil if no packet is kept.
+func (r *Recorder) read(name string, ports map[int]bool) (io.Reader, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := os.ReadFile(filepath.Join(r.dir, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture: %w", err)
+	}
+	if len(ports) == 0 {
+		return bytes.NewReader(data), nil
+	}
+
+	reader, err := pcapgo.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read capture: %w", err)
+	}
+	var filtered bytes.Buffer
+	writer := pcapgo.NewWriter(&filtered)
+	if err := writer.WriteFileHeader(snapLen, layers.LinkTypeRaw); err != nil {
+		return nil, err
+	}
+	kept := 0
+	for {
+		packetData, info, err := reader.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read capture: %w", err)
+		}
+		if !matchesPorts(packetData, ports) {
+			continue
+		}
+		if err := writer.WritePacket(info, packetData); err != nil {
+			return nil, err
+		}
+		kept++
+	}
+	if kept == 0 {
+		return nil, nil
+	}
+	return &filtered, nil
+}
+
+// matchesPorts reports whether a packet is from or to one of ports.
+func matchesPorts(data []byte, ports map[int]bool) bool {
+	packet := gopacket.NewPacket(data, layers.LinkTypeRaw, gopacket.NoCopy)
+	if tcp, ok := packet.Layer(layers.LayerTypeTCP).(*layers.TCP); ok {
+		return ports[int(tcp.SrcPort)] || ports[int(tcp.DstPort)]
+	}
+	if udp, ok := packet.Layer(layers.LayerTypeUDP).(*layers.UDP); ok {
+		return ports[int(udp.SrcPort)] || ports[int(udp.DstPort)]
+	}
+	return false
+}
+
+// append writes packet records to the file of a host, creating it with a
+// pcap header first.
+func (r *Recorder) append(host string, records []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, err := os.OpenFile(filepath.Join(r.dir, ArtifactName(host)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err == nil && info.Size() == 0 {
+		err = pcapgo.NewWriter(file).WriteFileHeader(snapLen, layers.LinkTypeRaw)
+	}
+	if err == nil {
+		_, err = file.Write(records)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// recordedConn records the traffic of a connection. Packets are buffered and
+// appended to the file of the host when the buffer fills up or the
+// connection is closed.
+type recordedConn struct {
+	net.Conn
+	recorder *Recorder
+	host     string
+	proto    layers.IPProtocol
+
+	local, remote         netip.Addr
+	localPort, remotePort int
+
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	writer    *pcapgo.Writer
+	clientSeq uint32 // next sequence number sent
+	serverSeq uint32 // next sequence number received
+	closed    bool
+}
+
+func (c *recordedConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.record(false, p[:n])
+	}
+	return n, err
+}
+
+func (c *recordedConn) Write(p []byte) (int, error) {
+	n, err := c.Conn.Write(p)
+	if n > 0 {
+		c.record(true, p[:n])
+	}
+	return n, err
+}
+
+func (c *recordedConn) Close() error {
+	err := c.Conn.Close()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return err
+	}
+	c.closed = true
+	if c.proto == layers.IPProtocolTCP {
+		c.segment(true, layers.TCP{FIN: true, ACK: true}, nil)
+	}
+	c.flush()
+	return err
+}
+
+// handshake records the three-way handshake of a TCP connection.
+func (c *recordedConn) handshake() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var isn [8]byte
+	_, _ = rand.Read(isn[:])
+	c.clientSeq = binary.BigEndian.Uint32(isn[:4])
+	c.serverSeq = binary.BigEndian.Uint32(isn[4:])
+
+	c.segment(true, layers.TCP{SYN: true}, nil)
+	c.clientSeq++
+	c.segment(false, layers.TCP{SYN: true, ACK: true}, nil)
+	c.serverSeq++
+	c.segment(true, layers.TCP{ACK: true}, nil)
+}
+
+// record writes the payload sent to (out) or received from the host.
+func (c *recordedConn) record(out bool, payload []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	if c.recorder.redact != nil {
+		payload = c.recorder.redact(payload)
+	}
+	if c.proto == layers.IPProtocolUDP {
+		c.datagram(out, payload)
+	} else {
+		for len(payload) > 0 {
+			n := min(len(payload), segmentSize)
+			c.segment(out, layers.TCP{PSH: true, ACK: true}, payload[:n])
+			payload = payload[n:]
+		}
+	}
+	if c.buf.Len() >= flushSize {
+		c.flush()
+	}
+}
+
+// segment writes a TCP segment sent to (out) or received from the host and
+// advances the sequence number of the sender by its payload.
+func (c *recordedConn) segment(out bool, tcp layers.TCP, payload []byte) {
+	tcp.Window = 65535
+	tcp.SrcPort, tcp.DstPort = layers.TCPPort(c.localPort), layers.TCPPort(c.remotePort)
+	tcp.Seq, tcp.Ack = c.clientSeq, c.serverSeq
+	if !out {
+		tcp.SrcPort, tcp.DstPort = tcp.DstPort, tcp.SrcPort
+		tcp.Seq, tcp.Ack = tcp.Ack, tcp.Seq
+	}
+	if !tcp.ACK {
+		tcp.Ack = 0
+	}
+	c.write(out, &tcp, payload)
+
+	if out {
+		c.clientSeq += uint32(len(payload))
+	} else {
+		c.serverSeq += uint32(len(payload))
+	}
+}
+
+// datagram writes a UDP datagram.
+func (c *recordedConn) datagram(out bool, payload []byte) {
+	udp := &layers.UDP{SrcPort: layers.UDPPort(c.localPort), DstPort: layers.UDPPort(c.remotePort)}
+	if !out {
+		udp.SrcPort, udp.DstPort = udp.DstPort, udp.SrcPort
+	}
+	c.write(out, udp, payload)
+}
+
+// transportLayer is a TCP or UDP header.
+type transportLayer interface {
+	gopacket.SerializableLayer
+	SetNetworkLayerForChecksum(gopacket.NetworkLayer) error
+}
+
+// write serializes a packet into the buffer.
+func (c *recordedConn) write(out bool, transport transportLayer, payload []byte) {
+	src, dst := c.local, c.remote
+	if !out {
+		src, dst = dst, src
+	}
+	var network interface {
+		gopacket.SerializableLayer
+		gopacket.NetworkLayer
+	}
+	if dst.Is4() {
+		network = &layers.IPv4{Version: 4, TTL: 64, Protocol: c.proto, SrcIP: src.AsSlice(), DstIP: dst.AsSlice()}
+	} else {
+		network = &layers.IPv6{Version: 6, HopLimit: 64, NextHeader: c.proto, SrcIP: src.AsSlice(), DstIP: dst.AsSlice()}
+	}
+	_ = transport.SetNetworkLayerForChecksum(network)
+
+	packet := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	if err := gopacket.SerializeLayers(packet, opts, network, transport, gopacket.Payload(payload)); err != nil {
+		return
+	}
+	data := packet.Bytes()
+	info := gopacket.CaptureInfo{Timestamp: time.Now(), CaptureLength: len(data), Length: len(data)}
+	if len(data) > snapLen {
+		data = data[:snapLen]
+		info.CaptureLength = snapLen
+	}
+	_ = c.writer.WritePacket(info, data)
+}
+
+// flush appends the buffered packets to the file of the host. Recording
+// is best effort: packets that cannot be written are dropped.
+func (c *recordedConn) flush() {
+	if c.buf.Len() == 0 {
+		return
+	}
+	_ = c.recorder.append(c.host, c.buf.Bytes())
+	c.buf.Reset()
+}
+
+// packetConn is a recorded packet connection, so that UDP connections stay
+// recognizable as such, e.g. for the DNS resolver.
+type packetConn struct {
+	*recordedConn
+	packetConn net.PacketConn
+}
+
+func (c *packetConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	n, addr, err := c.packetConn.ReadFrom(p)
+	if n > 0 {
+		c.record(false, p[:n])
+	}
+	return n, addr, err
+}
+
+func (c *packetConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	n, err := c.packetConn.WriteTo(p, addr)
+	if n > 0 {
+		c.record(true, p[:n])
+	}
+	return n, err
+}
+
+// splitAddr splits host:port, returning the port as a number (0 if absent
+// or invalid).
+func splitAddr(addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, 0
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 0 || port > 65535 {
+		return host, 0
+	}
+	return host, port
+}
+
+// addrPort returns the IP address and port of a TCP or UDP address.
+func addrPort(addr net.Addr) (netip.Addr, int) {
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ap := a.AddrPort()
+		return ap.Addr().Unmap(), int(ap.Port())
+	case *net.UDPAddr:
+		ap := a.AddrPort()
+		return ap.Addr().Unmap(), int(ap.Port())
+	}
+	return netip.Addr{}, 0
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying r. A nil r leaves ctx unchanged.
+func WithContext(ctx context.Context, r *Recorder) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the recorder carried by ctx, or nil.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(contextKey{}).(*Recorder)
+	return r
+}
@@ -0,0 +1,250 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcapgo"
+	"github.com/stretchr/testify/require"
+)
+
+// echoServer returns the address of a TCP server answering "pong" to each
+// message.
+func echoServer(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 64)
+				for {
+					if _, err := conn.Read(buf); err != nil {
+						return
+					}
+					_, _ = conn.Write([]byte("pong"))
+				}
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// exchange dials addr through dial and sends one message.
+func exchange(t *testing.T, ctx context.Context, dial func(context.Context, string, string) (net.Conn, error), addr, msg string) {
+	t.Helper()
+	conn, err := dial(ctx, "tcp", addr)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(msg))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+// memoryStore keeps artifacts in memory, by name.
+type memoryStore map[string][]byte
+
+func (s memoryStore) Write(_ context.Context, _, _, name string, data io.Reader) error {
+	b, err := io.ReadAll(data)
+	s[name] = b
+	return err
+}
+
+// readPackets decodes the packets of a pcap artifact.
+func readPackets(t *testing.T, store memoryStore, name string) []gopacket.Packet {
+	t.Helper()
+	require.Contains(t, store, name)
+	reader, err := pcapgo.NewReader(bytes.NewReader(store[name]))
+	require.NoError(t, err)
+	require.Equal(t, layers.LinkTypeRaw, reader.LinkType())
+
+	var packets []gopacket.Packet
+	for {
+		data, _, err := reader.ReadPacketData()
+		if errors.Is(err, io.EOF) {
+			return packets
+		}
+		require.NoError(t, err)
+		packets = append(packets, gopacket.NewPacket(data, layers.LinkTypeRaw, gopacket.Default))
+	}
+}
+
+func TestRecorder_RecordsTCPExchange(t *testing.T) {
+	addr := echoServer(t)
+	_, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+	rec := NewRecorder(t.TempDir())
+	ctx := WithContext(context.Background(), rec)
+
+	exchange(t, ctx, Dial((&net.Dialer{}).DialContext), addr, "ping")
+	require.Equal(t, []string{"127.0.0.1"}, rec.Hosts())
+
+	store := memoryStore{}
+	saved, err := rec.Save(ctx, store, "default", "scan-1", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1.pcap"}, saved)
+
+	packets := readPackets(t, store, "127.0.0.1.pcap")
+	require.Len(t, packets, 6) // SYN, SYN-ACK, ACK, ping, pong, FIN
+
+	var flags []string
+	var payloads []string
+	var clientSeq uint32
+	for i, packet := range packets {
+		require.Nil(t, packet.ErrorLayer(), "packet %d", i)
+		ip := packet.Layer(layers.LayerTypeIPv4).(*layers.IPv4)
+		tcp := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+		out := int(tcp.DstPort) == port
+		peer := ip.SrcIP
+		if out {
+			peer = ip.DstIP
+		}
+		require.Equal(t, "127.0.0.1", peer.String())
+
+		switch {
+		case tcp.SYN && !tcp.ACK:
+			flags = append(flags, "SYN")
+			clientSeq = tcp.Seq + 1
+		case tcp.SYN:
+			flags = append(flags, "SYN-ACK")
+			require.Equal(t, clientSeq, tcp.Ack)
+		case tcp.FIN:
+			flags = append(flags, "FIN")
+			require.Equal(t, clientSeq+4, tcp.Seq)
+		default:
+			flags = append(flags, "ACK")
+		}
+		if len(tcp.Payload) > 0 {
+			payloads = append(payloads, string(tcp.Payload))
+		}
+	}
+	require.Equal(t, []string{"SYN", "SYN-ACK", "ACK", "ACK", "ACK", "FIN"}, flags)
+	require.Equal(t, []string{"ping", "pong"}, payloads)
+}
+
+func TestRecorder_RecordsUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+	rec := NewRecorder(t.TempDir())
+
+	conn, err := net.Dial("udp", pc.LocalAddr().String())
+	require.NoError(t, err)
+	conn = rec.Conn(conn, "udp", pc.LocalAddr().String())
+	require.Implements(t, (*net.PacketConn)(nil), conn)
+	_, err = conn.Write([]byte("query"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	store := memoryStore{}
+	_, err = rec.Save(context.Background(), store, "default", "scan-1", nil)
+	require.NoError(t, err)
+	packets := readPackets(t, store, "127.0.0.1.pcap")
+	require.Len(t, packets, 1)
+	udp := packets[0].Layer(layers.LayerTypeUDP).(*layers.UDP)
+	require.Equal(t, "query", string(udp.Payload))
+}
+
+func TestRecorder_Redaction(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+	rec := NewRecorder(t.TempDir()).WithRedaction(func(payload []byte) []byte {
+		return bytes.ReplaceAll(payload, []byte("secret"), []byte("******"))
+	})
+
+	conn, err := net.Dial("udp", pc.LocalAddr().String())
+	require.NoError(t, err)
+	conn = rec.Conn(conn, "udp", pc.LocalAddr().String())
+	_, err = conn.Write([]byte("community=secret"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	// The peer gets the payload as sent; the capture is masked
+	buf := make([]byte, 64)
+	n, _, err := pc.ReadFrom(buf)
+	require.NoError(t, err)
+	require.Equal(t, "community=secret", string(buf[:n]))
+
+	store := memoryStore{}
+	_, err = rec.Save(context.Background(), store, "default", "scan-1", nil)
+	require.NoError(t, err)
+	packets := readPackets(t, store, "127.0.0.1.pcap")
+	require.Len(t, packets, 1)
+	udp := packets[0].Layer(layers.LayerTypeUDP).(*layers.UDP)
+	require.Equal(t, "community=******", string(udp.Payload))
+}
+
+func TestRecorder_SaveFilter(t *testing.T) {
+	first, second := echoServer(t), echoServer(t)
+	_, secondPort, _ := net.SplitHostPort(second)
+	port, _ := strconv.Atoi(secondPort)
+	rec := NewRecorder(t.TempDir())
+	ctx := WithContext(context.Background(), rec)
+	dial := Dial((&net.Dialer{}).DialContext)
+	exchange(t, ctx, dial, first, "first")
+	exchange(t, ctx, dial, second, "second")
+
+	// Only the port of the finding is kept
+	store := memoryStore{}
+	saved, err := rec.Save(ctx, store, "default", "scan-1", Filter{"127.0.0.1": {port}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1.pcap"}, saved)
+	packets := readPackets(t, store, "127.0.0.1.pcap")
+	require.Len(t, packets, 6)
+	for _, packet := range packets {
+		tcp := packet.Layer(layers.LayerTypeTCP).(*layers.TCP)
+		require.True(t, int(tcp.SrcPort) == port || int(tcp.DstPort) == port)
+	}
+
+	// Port 0 keeps the whole host
+	store = memoryStore{}
+	_, err = rec.Save(ctx, store, "default", "scan-1", Filter{"127.0.0.1": {port, 0}})
+	require.NoError(t, err)
+	require.Len(t, readPackets(t, store, "127.0.0.1.pcap"), 12)
+
+	// Hosts without findings are skipped
+	store = memoryStore{}
+	saved, err = rec.Save(ctx, store, "default", "scan-1", Filter{"192.0.2.1": {80}})
+	require.NoError(t, err)
+	require.Empty(t, saved)
+}
+
+func TestNilRecorder(t *testing.T) {
+	addr := echoServer(t)
+	var rec *Recorder
+	require.Nil(t, FromContext(WithContext(context.Background(), rec)))
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Same(t, conn, rec.Conn(conn, "tcp", addr))
+
+	saved, err := rec.Save(context.Background(), nil, "default", "scan-1", nil)
+	require.NoError(t, err)
+	require.Empty(t, saved)
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("findings")
+	require.NoError(t, err)
+	require.Equal(t, ModeFindings, mode)
+	_, err = ParseMode("some")
+	require.EqualError(t, err, `invalid capture mode "some" (expected all or findings)`)
+	require.Equal(t, "fe80--1-eth0.pcap", ArtifactName("fe80::1%eth0"))
+}
@@ -0,0 +1,204 @@
+// Package cdn detects endpoints served through a CDN or web application
+// firewall. Scanning one probes the intermediary rather than the origin:
+// its findings describe the edge, aggressive checks may get the scanner
+// blocked, and its address range belongs to someone else.
+//
+// Intermediaries are recognized by the response headers and cookies they
+// add, by the CNAME records pointing names at them, and by their published
+// address ranges.
+package cdn
+
+import (
+	"bufio"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"strings"
+)
+
+// Kinds of intermediaries.
+const (
+	KindCDN = "cdn"
+	KindWAF = "waf"
+)
+
+// How an intermediary was recognized.
+const (
+	ViaHeader = "header"
+	ViaCNAME  = "cname"
+	ViaIP     = "ip"
+)
+
+// Intermediary is a CDN or WAF in front of an endpoint.
+type Intermediary struct {
+	Name string `json:"name"` // e.g. 'Cloudflare'
+	Kind string `json:"kind"` // KindCDN or KindWAF
+	Via  string `json:"via"`  // ViaHeader, ViaCNAME or ViaIP
+
+	// Evidence is what matched: a header or cookie, a CNAME or an
+	// address range.
+	Evidence string `json:"evidence,omitempty"`
+}
+
+// String returns the name and kind of i, e.g. 'Cloudflare (cdn)'.
+func (i *Intermediary) String() string {
+	if i == nil {
+		return ""
+	}
+	return i.Name + " (" + i.Kind + ")"
+}
+
+// headerRule matches a response header, by presence when value is empty
+// and otherwise by a case-insensitive substring of one of its values.
+type headerRule struct {
+	name  string
+	value string
+}
+
+// signature is how an intermediary shows itself.
+type signature struct {
+	name    string
+	kind    string
+	headers []headerRule
+	cnames  []string // domain suffixes, with a leading dot
+	ranges  []netip.Prefix
+}
+
+// signatures are the known intermediaries. WAFs come first: a WAF behind
+// a CDN decides what is blocked.
+var signatures = []signature{
+	{
+		name: "Imperva Incapsula", kind: KindWAF,
+		headers: []headerRule{{name: "X-Iinfo"}, {name: "X-Cdn", value: "incapsula"}, {name: "Set-Cookie", value: "incap_ses_"}, {name: "Set-Cookie", value: "visid_incap_"}},
+		cnames:  []string{".incapdns.net", ".impervadns.net"},
+	},
+	{
+		name: "Sucuri", kind: KindWAF,
+		headers: []headerRule{{name: "X-Sucuri-Id"}, {name: "X-Sucuri-Cache"}, {name: "Server", value: "sucuri"}},
+		ranges:  prefixes("192.88.134.0/23", "185.93.228.0/22", "66.248.200.0/22", "208.109.0.0/22", "2a02:fe80::/29"),
+	},
+	{
+		name: "AWS WAF", kind: KindWAF,
+		headers: []headerRule{{name: "X-Amzn-Waf-Action"}, {name: "Set-Cookie", value: "aws-waf-token"}},
+	},
+	{
+		name: "F5 BIG-IP ASM", kind: KindWAF,
+		headers: []headerRule{{name: "X-Wa-Info"}, {name: "Set-Cookie", value: "TS01"}},
+	},
+	{
+		name: "Barracuda", kind: KindWAF,
+		headers: []headerRule{{name: "Set-Cookie", value: "barra_counter_session"}},
+	},
+	{
+		name: "ModSecurity", kind: KindWAF,
+		headers: []headerRule{{name: "Server", value: "mod_security"}},
+	},
+	{
+		name: "Cloudflare", kind: KindCDN,
+		headers: []headerRule{{name: "Cf-Ray"}, {name: "Server", value: "cloudflare"}, {name: "Set-Cookie", value: "__cf_bm"}},
+		cnames:  []string{".cdn.cloudflare.net"},
+		ranges: prefixes(
+			"173.245.48.0/20", "103.21.244.0/22", "103.22.200.0/22", "103.31.4.0/22",
+			"141.101.64.0/18", "108.162.192.0/18", "190.93.240.0/20", "188.114.96.0/20",
+			"197.234.240.0/22", "198.41.128.0/17", "162.158.0.0/15", "104.16.0.0/13",
+			"104.24.0.0/14", "172.64.0.0/13", "131.0.72.0/22",
+			"2400:cb00::/32", "2606:4700::/32", "2803:f800::/32", "2405:b500::/32",
+			"2405:8100::/32", "2a06:98c0::/29", "2c0f:f248::/32",
+		),
+	},
+	{
+		name: "Akamai", kind: KindCDN,
+		headers: []headerRule{{name: "X-Akamai-Transformed"}, {name: "X-Akamai-Request-Id"}, {name: "Akamai-Grn"}, {name: "Server", value: "akamaighost"}},
+		cnames:  []string{".akamaiedge.net", ".edgekey.net", ".edgesuite.net", ".akamai.net", ".akamaized.net"},
+	},
+	{
+		name: "Fastly", kind: KindCDN,
+		headers: []headerRule{{name: "X-Fastly-Request-Id"}, {name: "Fastly-Debug-Digest"}},
+		cnames:  []string{".fastly.net", ".fastlylb.net"},
+		ranges:  prefixes("151.101.0.0/16", "199.232.0.0/16", "2a04:4e40::/32", "2a04:4e42::/32"),
+	},
+	{
+		name: "Amazon CloudFront", kind: KindCDN,
+		headers: []headerRule{{name: "X-Amz-Cf-Id"}, {name: "X-Amz-Cf-Pop"}, {name: "Via", value: "cloudfront"}, {name: "Server", value: "cloudfront"}},
+		cnames:  []string{".cloudfront.net"},
+	},
+	{
+		name: "Azure Front Door", kind: KindCDN,
+		headers: []headerRule{{name: "X-Azure-Ref"}, {name: "X-Fd-Healthprobe"}},
+		cnames:  []string{".azurefd.net", ".azureedge.net"},
+	},
+}
+
+func prefixes(cidrs ...string) []netip.Prefix {
+	out := make([]netip.Prefix, 0, len(cidrs))
+	for _, c := range cidrs {
+		out = append(out, netip.MustParsePrefix(c))
+	}
+	return out
+}
+
+// FromHeaders returns the intermediary whose headers or cookies appear in
+// header, or nil.
+func FromHeaders(header http.Header) *Intermediary {
+	for _, s := range signatures {
+		for _, rule := range s.headers {
+			for _, v := range header.Values(rule.name) {
+				if rule.value == "" || strings.Contains(strings.ToLower(v), strings.ToLower(rule.value)) {
+					evidence := http.CanonicalHeaderKey(rule.name)
+					if rule.value != "" {
+						evidence += ": " + rule.value
+					}
+					return &Intermediary{Name: s.name, Kind: s.kind, Via: ViaHeader, Evidence: evidence}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// FromResponse returns the intermediary recognized in the headers of a raw
+// HTTP response, such as a grabbed banner, or nil. The body, if any, is
+// ignored.
+func FromResponse(raw string) *Intermediary {
+	if !strings.HasPrefix(raw, "HTTP/") {
+		return nil
+	}
+	r := textproto.NewReader(bufio.NewReader(strings.NewReader(raw)))
+	if _, err := r.ReadLine(); err != nil {
+		return nil
+	}
+	// A banner may be cut off in the headers; keep what was read
+	header, _ := r.ReadMIMEHeader()
+	return FromHeaders(http.Header(header))
+}
+
+// FromCNAME returns the intermediary a canonical name belongs to, or nil.
+func FromCNAME(name string) *Intermediary {
+	name = "." + strings.TrimSuffix(strings.ToLower(name), ".")
+	for _, s := range signatures {
+		for _, suffix := range s.cnames {
+			if strings.HasSuffix(name, suffix) {
+				return &Intermediary{Name: s.name, Kind: s.kind, Via: ViaCNAME, Evidence: name[1:]}
+			}
+		}
+	}
+	return nil
+}
+
+// FromIP returns the intermediary whose published ranges contain ip, or
+// nil.
+func FromIP(ip string) *Intermediary {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+	addr = addr.Unmap()
+	for _, s := range signatures {
+		for _, p := range s.ranges {
+			if p.Contains(addr) {
+				return &Intermediary{Name: s.name, Kind: s.kind, Via: ViaIP, Evidence: p.String()}
+			}
+		}
+	}
+	return nil
+}
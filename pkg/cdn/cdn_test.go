@@ -0,0 +1,118 @@
+package cdn
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *Intermediary
+	}{
+		{
+			name: "cloudflare",
+			raw:  "HTTP/1.1 403 Forbidden\r\nServer: cloudflare\r\nCF-RAY: 8a1b2c3d4e5f-FRA\r\n\r\n<html>",
+			want: &Intermediary{Name: "Cloudflare", Kind: KindCDN, Via: ViaHeader, Evidence: "Cf-Ray"},
+		},
+		{
+			name: "cloudfront",
+			raw:  "HTTP/1.1 200 OK\r\nVia: 1.1 d111.cloudfront.net (CloudFront)\r\n\r\n",
+			want: &Intermediary{Name: "Amazon CloudFront", Kind: KindCDN, Via: ViaHeader, Evidence: "Via: cloudfront"},
+		},
+		{
+			name: "waf cookie behind a cdn",
+			raw:  "HTTP/1.1 200 OK\r\nSet-Cookie: a=b\r\nSet-Cookie: visid_incap_123=abc; path=/\r\nCF-RAY: 1\r\n\r\n",
+			want: &Intermediary{Name: "Imperva Incapsula", Kind: KindWAF, Via: ViaHeader, Evidence: "Set-Cookie: visid_incap_"},
+		},
+		{
+			name: "cut off in the headers",
+			raw:  "HTTP/1.1 200 OK\r\nX-Sucuri-ID: 15016\r\nContent-Ty",
+			want: &Intermediary{Name: "Sucuri", Kind: KindWAF, Via: ViaHeader, Evidence: "X-Sucuri-Id"},
+		},
+		{name: "origin", raw: "HTTP/1.1 200 OK\r\nServer: nginx\r\n\r\n"},
+		{name: "not http", raw: "SSH-2.0-OpenSSH_9.6\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FromResponse(tt.raw))
+		})
+	}
+}
+
+func TestFromCNAME(t *testing.T) {
+	require.Equal(t, &Intermediary{Name: "Akamai", Kind: KindCDN, Via: ViaCNAME, Evidence: "www.example.com.edgekey.net"}, FromCNAME("www.example.com.edgekey.net."))
+	require.Equal(t, "Fastly", FromCNAME("Shop.Global.Fastly.NET").Name)
+	require.Nil(t, FromCNAME("www.example.com."))
+	require.Nil(t, FromCNAME("notfastly.net"))
+}
+
+func TestFromIP(t *testing.T) {
+	require.Equal(t, &Intermediary{Name: "Cloudflare", Kind: KindCDN, Via: ViaIP, Evidence: "104.16.0.0/13"}, FromIP("104.18.32.7"))
+	require.Equal(t, "Cloudflare", FromIP("2606:4700::6810:84e5").Name)
+	require.Equal(t, "Fastly", FromIP("::ffff:151.101.1.69").Name)
+	require.Nil(t, FromIP("10.0.0.5"))
+	require.Nil(t, FromIP("example.com"))
+}
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy("")
+	require.NoError(t, err)
+	require.Equal(t, PolicyAnnotate, p)
+	p, err = ParsePolicy(" Skip ")
+	require.NoError(t, err)
+	require.Equal(t, PolicySkip, p)
+	_, err = ParsePolicy("block")
+	require.ErrorContains(t, err, `unknown policy "block"`)
+}
+
+func TestGuard(t *testing.T) {
+	g := NewGuard(PolicyReduce)
+	g.lookupCNAME = func(_ context.Context, host string) (string, error) {
+		if host == "shop.example.com" {
+			return "shop.example.com.cdn.cloudflare.net.", nil
+		}
+		return "", errors.New("no such host")
+	}
+	g.lookupHost = func(context.Context, string) ([]string, error) {
+		return []string{"203.0.113.10"}, nil
+	}
+
+	g.ResolveTargets(context.Background(), []string{"shop.example.com", "api.example.com", "10.0.0.0/24", "10.0.0.5"})
+	require.Equal(t, "shop.example.com.cdn.cloudflare.net", g.Lookup("Shop.Example.com").Evidence)
+	require.Equal(t, ViaCNAME, g.Lookup("203.0.113.10:443").Via)
+	require.Nil(t, g.Lookup("api.example.com"))
+
+	g.ObserveResponse("10.0.0.7", "HTTP/1.1 200 OK\r\nX-Akamai-Transformed: 9 - 0 pmb=mRUM,1\r\n\r\n")
+	require.Equal(t, "Akamai", g.Lookup("10.0.0.7").Name)
+	// The first intermediary of a host sticks
+	g.ObserveResponse("10.0.0.7", "HTTP/1.1 200 OK\r\nCF-RAY: 1\r\n\r\n")
+	require.Equal(t, "Akamai", g.Lookup("10.0.0.7").Name)
+	require.Equal(t, ViaIP, g.Lookup("104.16.1.1").Via)
+
+	require.True(t, g.Allow("10.0.0.5", true))
+	require.True(t, g.Allow("10.0.0.7", false))
+	require.False(t, g.Allow("10.0.0.7", true))
+	require.False(t, g.Skip("10.0.0.7"))
+
+	skip := NewGuard(PolicySkip)
+	skip.ObserveResponse("10.0.0.7", "HTTP/1.1 200 OK\r\nCF-RAY: 1\r\n\r\n")
+	require.False(t, skip.Allow("10.0.0.7:443", false))
+	require.True(t, skip.Skip("10.0.0.7"))
+	require.False(t, skip.Skip("10.0.0.5"))
+
+	annotate := NewGuard(PolicyAnnotate)
+	require.True(t, annotate.Allow("104.16.1.1", true))
+	require.False(t, annotate.Skip("104.16.1.1"))
+
+	// Without a guard, nothing is held back
+	var none *Guard
+	require.True(t, none.Allow("104.16.1.1", true))
+	require.Nil(t, none.Lookup("104.16.1.1"))
+	require.Nil(t, FromContext(context.Background()))
+	require.Same(t, g, FromContext(WithContext(context.Background(), g)))
+}
@@ -0,0 +1,194 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Policy is how endpoints behind an intermediary are scanned.
+type Policy string
+
+const (
+	// PolicyAnnotate scans them like any other endpoint and records the
+	// intermediary on their hosts and findings.
+	PolicyAnnotate Policy = "annotate"
+
+	// PolicyReduce also keeps intrusive requests away from them: content
+	// discovery, default credentials, Nuclei templates and multi-step
+	// plugins.
+	PolicyReduce Policy = "reduce"
+
+	// PolicySkip also sends them no requests after banner grabbing and
+	// drops their findings, which would describe the intermediary.
+	PolicySkip Policy = "skip"
+)
+
+// ParsePolicy returns the policy named s; empty is PolicyAnnotate.
+func ParsePolicy(s string) (Policy, error) {
+	switch p := Policy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return PolicyAnnotate, nil
+	case PolicyAnnotate, PolicyReduce, PolicySkip:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown policy %q (annotate, reduce or skip)", s)
+	}
+}
+
+// Guard tracks the intermediaries in front of the hosts of a scan and
+// applies its policy to them. It is safe for concurrent use; a nil Guard
+// detects nothing and allows everything.
+type Guard struct {
+	policy Policy
+
+	lookupCNAME func(ctx context.Context, host string) (string, error)
+	lookupHost  func(ctx context.Context, host string) ([]string, error)
+
+	mu    sync.Mutex
+	hosts map[string]*Intermediary // by host name or IP address
+}
+
+// NewGuard returns a guard applying p.
+func NewGuard(p Policy) *Guard {
+	return &Guard{
+		policy:      p,
+		lookupCNAME: net.DefaultResolver.LookupCNAME,
+		lookupHost:  net.DefaultResolver.LookupHost,
+		hosts:       make(map[string]*Intermediary),
+	}
+}
+
+// Policy returns the policy of g.
+func (g *Guard) Policy() Policy {
+	if g == nil {
+		return ""
+	}
+	return g.policy
+}
+
+// ObserveResponse records the intermediary recognized in a raw HTTP
+// response of host, if any.
+func (g *Guard) ObserveResponse(host, raw string) {
+	if g == nil {
+		return
+	}
+	if i := FromResponse(raw); i != nil {
+		g.observe(host, i)
+	}
+}
+
+// ResolveTargets records the intermediaries the host names among targets
+// are aliased to, under the names and their addresses. IP addresses and
+// CIDR ranges are left to Lookup.
+func (g *Guard) ResolveTargets(ctx context.Context, targets []string) {
+	if g == nil {
+		return
+	}
+	for _, target := range targets {
+		name := hostOf(target)
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		if _, err := netip.ParseAddr(name); err == nil {
+			continue
+		}
+
+		cname, err := g.lookupCNAME(ctx, name)
+		if err != nil {
+			continue
+		}
+		i := FromCNAME(cname)
+		if i == nil {
+			continue
+		}
+		g.observe(name, i)
+		addrs, _ := g.lookupHost(ctx, name)
+		for _, addr := range addrs {
+			g.observe(addr, i)
+		}
+	}
+}
+
+// Lookup returns the intermediary in front of host, a host name or IP
+// address optionally with a port, or nil.
+func (g *Guard) Lookup(host string) *Intermediary {
+	if g == nil {
+		return nil
+	}
+	host = hostOf(host)
+
+	g.mu.Lock()
+	i, ok := g.hosts[host]
+	g.mu.Unlock()
+	if ok {
+		return i
+	}
+	return FromIP(host)
+}
+
+// Allow reports whether host may be sent a request under the policy:
+// intrusive ones are held back by PolicyReduce and PolicySkip, all of
+// them by PolicySkip. Modules call it before probing an endpoint, so that
+// endpoints behind a CDN or WAF, whose findings belong to the intermediary
+// rather than the origin, are only probed as far as the policy permits.
+func (g *Guard) Allow(host string, intrusive bool) bool {
+	if g == nil || g.policy == PolicyAnnotate || g.Lookup(host) == nil {
+		return true
+	}
+	return g.policy == PolicyReduce && !intrusive
+}
+
+// Skip reports whether the findings on host are dropped under the policy.
+func (g *Guard) Skip(host string) bool {
+	return g != nil && g.policy == PolicySkip && g.Lookup(host) != nil
+}
+
+// observe records i in front of host, logging the first intermediary of
+// each host.
+func (g *Guard) observe(host string, i *Intermediary) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	host = hostOf(host)
+	if _, ok := g.hosts[host]; ok {
+		return
+	}
+	g.hosts[host] = i
+	log.Info().
+		Str("component", "cdn").
+		Str("host", host).
+		Str("intermediary", i.Name).
+		Str("kind", i.Kind).
+		Str("via", i.Via).
+		Str("policy", string(g.policy)).
+		Msg("Endpoint is behind a CDN or WAF")
+}
+
+// hostOf returns host without a port, lowercased.
+func hostOf(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSpace(host))
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying g. A nil g leaves ctx unchanged.
+func WithContext(ctx context.Context, g *Guard) context.Context {
+	if g == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, g)
+}
+
+// FromContext returns the guard carried by ctx, or nil.
+func FromContext(ctx context.Context) *Guard {
+	g, _ := ctx.Value(contextKey{}).(*Guard)
+	return g
+}
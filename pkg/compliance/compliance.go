@@ -0,0 +1,142 @@
+// Package compliance maps plugins to the controls of compliance
+// frameworks (CIS Controls, PCI DSS, NIST SP 800-53) and rolls the findings
+// of a scan up into the status of each control.
+//
+// A framework lists its controls and maps plugin IDs to the controls their
+// findings fail. A control fails when a mapped plugin reported a finding,
+// passes when mapped plugins ran without reporting any, and is not
+// assessed when none of its plugins ran.
+//
+// The mappings of the built-in frameworks are shipped as data files in
+// data/; custom frameworks use the same format.
+package compliance
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/*.yaml
+var embeddedFrameworks embed.FS
+
+// Framework is a compliance framework with the plugins that assess its
+// controls.
+type Framework struct {
+	ID       string    `yaml:"id"`      // Framework identifier (e.g., "pci-dss")
+	Name     string    `yaml:"name"`    // Name shown in reports (e.g., "PCI DSS")
+	Version  string    `yaml:"version"` // Framework version (e.g., "4.0")
+	Controls []Control `yaml:"controls"`
+
+	// Plugins maps plugin IDs to the IDs of the controls their findings
+	// fail.
+	Plugins map[string][]string `yaml:"plugins"`
+}
+
+// Control is a control of a framework.
+type Control struct {
+	ID    string `yaml:"id"`
+	Title string `yaml:"title"`
+}
+
+// ParseFramework parses a YAML framework mapping. Every control must have
+// a unique ID, and plugins may only map to listed controls.
+func ParseFramework(data []byte) (*Framework, error) {
+	var fw Framework
+	if err := yaml.Unmarshal(data, &fw); err != nil {
+		return nil, fmt.Errorf("failed to parse framework: %w", err)
+	}
+	if strings.TrimSpace(fw.ID) == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	if len(fw.Controls) == 0 {
+		return nil, fmt.Errorf("framework %s: at least one control is required", fw.ID)
+	}
+	controls := make(map[string]bool, len(fw.Controls))
+	for i, c := range fw.Controls {
+		if strings.TrimSpace(c.ID) == "" {
+			return nil, fmt.Errorf("framework %s: controls[%d]: id is required", fw.ID, i)
+		}
+		if controls[c.ID] {
+			return nil, fmt.Errorf("framework %s: duplicate control %s", fw.ID, c.ID)
+		}
+		controls[c.ID] = true
+	}
+	for plugin, ids := range fw.Plugins {
+		for _, id := range ids {
+			if !controls[id] {
+				return nil, fmt.Errorf("framework %s: plugin %s maps to unknown control %s", fw.ID, plugin, id)
+			}
+		}
+	}
+	return &fw, nil
+}
+
+// Frameworks returns the built-in frameworks, ordered by ID.
+func Frameworks() ([]*Framework, error) {
+	entries, err := embeddedFrameworks.ReadDir("data")
+	if err != nil {
+		return nil, err
+	}
+	frameworks := make([]*Framework, 0, len(entries))
+	for _, e := range entries {
+		data, err := embeddedFrameworks.ReadFile(path.Join("data", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		fw, err := ParseFramework(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		frameworks = append(frameworks, fw)
+	}
+	sort.Slice(frameworks, func(i, j int) bool { return frameworks[i].ID < frameworks[j].ID })
+	return frameworks, nil
+}
+
+// Load returns the built-in framework with ID name, or parses the framework
+// file at name when it ends in .yaml or .yml.
+func Load(name string) (*Framework, error) {
+	if ext := strings.ToLower(path.Ext(name)); ext == ".yaml" || ext == ".yml" {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("read framework: %w", err)
+		}
+		fw, err := ParseFramework(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return fw, nil
+	}
+
+	frameworks, err := Frameworks()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(frameworks))
+	for _, fw := range frameworks {
+		if strings.EqualFold(fw.ID, name) {
+			return fw, nil
+		}
+		ids = append(ids, fw.ID)
+	}
+	return nil, fmt.Errorf("unknown framework %q (must be one of %s, or a .yaml file)", name, strings.Join(ids, ", "))
+}
+
+// ControlPlugins returns the IDs of the plugins mapped to control, sorted.
+func (fw *Framework) ControlPlugins(control string) []string {
+	var plugins []string
+	for plugin, ids := range fw.Plugins {
+		if slices.Contains(ids, control) {
+			plugins = append(plugins, plugin)
+		}
+	}
+	sort.Strings(plugins)
+	return plugins
+}
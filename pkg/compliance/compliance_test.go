@@ -0,0 +1,116 @@
+package compliance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+func TestFrameworks(t *testing.T) {
+	frameworks, err := Frameworks()
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(frameworks))
+	for _, fw := range frameworks {
+		ids = append(ids, fw.ID)
+	}
+	require.Equal(t, []string{"cis", "nist", "pci-dss"}, ids)
+
+	// Every mapping must name an embedded plugin
+	plugins, err := plugin.LoadAllEmbeddedPlugins()
+	require.NoError(t, err)
+	known := make(map[string]bool)
+	for _, p := range plugins {
+		known[p.ID] = true
+	}
+	for _, fw := range frameworks {
+		for id := range fw.Plugins {
+			require.True(t, known[id], "framework %s maps unknown plugin %s", fw.ID, id)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	fw, err := Load("PCI-DSS")
+	require.NoError(t, err)
+	require.Equal(t, "pci-dss", fw.ID)
+
+	_, err = Load("hipaa")
+	require.ErrorContains(t, err, `unknown framework "hipaa" (must be one of cis, nist, pci-dss, or a .yaml file)`)
+
+	path := filepath.Join(t.TempDir(), "internal.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+id: internal
+name: Internal Baseline
+controls:
+  - id: NET-1
+    title: No cleartext protocols
+plugins:
+  open-telnet: [NET-1]
+`), 0o600))
+	fw, err = Load(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"open-telnet"}, fw.ControlPlugins("NET-1"))
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+id: internal
+controls:
+  - id: NET-1
+plugins:
+  open-telnet: [NET-2]
+`), 0o600))
+	_, err = Load(path)
+	require.ErrorContains(t, err, "plugin open-telnet maps to unknown control NET-2")
+}
+
+func TestFramework_Evaluate(t *testing.T) {
+	fw := &Framework{
+		ID: "test",
+		Controls: []Control{
+			{ID: "C1", Title: "No cleartext protocols"},
+			{ID: "C2", Title: "No default credentials"},
+			{ID: "C3", Title: "Patched software"},
+			{ID: "C4", Title: "Unmapped"},
+		},
+		Plugins: map[string][]string{
+			"open-telnet":       {"C1"},
+			"ssh-default-creds": {"C2"},
+			"ssh-cve-2024-6387": {"C3"},
+		},
+	}
+	findings := []Finding{
+		{PluginID: "open-telnet", Target: "10.0.0.5", Port: 23, Groups: []string{"office"}},
+		// Findings without a plugin ID match by name
+		{Plugin: "open-telnet", Target: "10.0.1.9", Port: 23, Groups: []string{"ot"}},
+		{PluginID: "unmapped", Target: "10.0.0.5"},
+	}
+	ran := func(plugin string) bool { return plugin != "ssh-cve-2024-6387" }
+
+	res := fw.Evaluate(findings, ran)
+	statuses := make(map[string]string)
+	for _, c := range res.Controls {
+		statuses[c.ID] = c.Status
+	}
+	require.Equal(t, map[string]string{"C1": StatusFail, "C2": StatusPass, "C3": StatusNotAssessed, "C4": StatusNotAssessed}, statuses)
+	require.Len(t, res.Controls[0].Findings, 2)
+	require.Equal(t, 1, res.Passed)
+	require.Equal(t, 1, res.Failed)
+	require.Equal(t, 2, res.NotAssessed)
+	require.InDelta(t, 50.0, res.Coverage(), 0.001)
+
+	// Controls of scans that ran no plugins are not assessed
+	require.Equal(t, 4, fw.Evaluate(nil, nil).NotAssessed)
+
+	results := fw.EvaluateGroups(findings, ran, []string{"office", "lab"})
+	require.Len(t, results, 3)
+	require.Empty(t, results[0].Group)
+	require.Equal(t, "office", results[1].Group)
+	require.Equal(t, StatusFail, results[1].Controls[0].Status)
+	require.Len(t, results[1].Controls[0].Findings, 1)
+	require.Equal(t, "lab", results[2].Group)
+	require.Equal(t, StatusPass, results[2].Controls[0].Status)
+}
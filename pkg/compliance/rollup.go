@@ -0,0 +1,103 @@
+package compliance
+
+import "slices"
+
+// Statuses of a control.
+const (
+	StatusPass        = "pass"
+	StatusFail        = "fail"
+	StatusNotAssessed = "not-assessed"
+)
+
+// Finding is a finding as rolled up into controls.
+type Finding struct {
+	PluginID string
+	Plugin   string // plugin name, matched when no mapping has PluginID
+	Target   string
+	Port     int
+	Severity string
+	Groups   []string // target groups of the finding's target
+}
+
+// ControlResult is the status of a control.
+type ControlResult struct {
+	Control
+	Status   string
+	Plugins  []string  // IDs of the plugins mapped to the control
+	Findings []Finding // findings that fail the control
+}
+
+// Result is the status of every control of a framework over a scan or one
+// of its target groups.
+type Result struct {
+	Group       string // target group, or empty for the whole scan
+	Controls    []ControlResult
+	Passed      int
+	Failed      int
+	NotAssessed int
+}
+
+// Coverage returns the share of controls that were assessed, 0-100.
+func (r Result) Coverage() float64 {
+	if len(r.Controls) == 0 {
+		return 0
+	}
+	return float64(r.Passed+r.Failed) * 100 / float64(len(r.Controls))
+}
+
+// Evaluate rolls findings up into the controls of fw. ran reports whether
+// the plugin with the given ID ran in the scan; a nil ran reports none.
+func (fw *Framework) Evaluate(findings []Finding, ran func(plugin string) bool) Result {
+	failed := make(map[string][]Finding)
+	for _, f := range findings {
+		for _, id := range fw.controlsOf(f) {
+			failed[id] = append(failed[id], f)
+		}
+	}
+
+	var res Result
+	for _, c := range fw.Controls {
+		cr := ControlResult{Control: c, Plugins: fw.ControlPlugins(c.ID), Findings: failed[c.ID]}
+		switch {
+		case len(cr.Findings) > 0:
+			cr.Status = StatusFail
+			res.Failed++
+		case ran != nil && slices.ContainsFunc(cr.Plugins, ran):
+			cr.Status = StatusPass
+			res.Passed++
+		default:
+			cr.Status = StatusNotAssessed
+			res.NotAssessed++
+		}
+		res.Controls = append(res.Controls, cr)
+	}
+	return res
+}
+
+// EvaluateGroups returns the rollup of the whole scan, followed by one per
+// group over the findings on its targets. Plugins count as run in every
+// group.
+func (fw *Framework) EvaluateGroups(findings []Finding, ran func(plugin string) bool, groups []string) []Result {
+	results := []Result{fw.Evaluate(findings, ran)}
+	for _, group := range groups {
+		var inGroup []Finding
+		for _, f := range findings {
+			if slices.Contains(f.Groups, group) {
+				inGroup = append(inGroup, f)
+			}
+		}
+		res := fw.Evaluate(inGroup, ran)
+		res.Group = group
+		results = append(results, res)
+	}
+	return results
+}
+
+// controlsOf returns the IDs of the controls f fails. Findings of scans
+// that predate plugin IDs are matched by plugin name.
+func (fw *Framework) controlsOf(f Finding) []string {
+	if ids, ok := fw.Plugins[f.PluginID]; ok && f.PluginID != "" {
+		return ids
+	}
+	return fw.Plugins[f.Plugin]
+}
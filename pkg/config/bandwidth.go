@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+)
+
+// Validate validates the BandwidthConfig and returns an error if invalid.
+func (c *BandwidthConfig) Validate() error {
+	_, err := c.Limiter()
+	return err
+}
+
+// Limiter returns a limiter for Limit, or nil when no limit is set.
+func (c *BandwidthConfig) Limiter() (*bandwidth.Limiter, error) {
+	if c.Limit == "" {
+		return nil, nil
+	}
+	rate, err := ParseByteSize(c.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("limit: %w", err)
+	}
+	return bandwidth.NewLimiter(rate), nil
+}
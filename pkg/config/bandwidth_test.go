@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthConfig_Limiter(t *testing.T) {
+	cfg := BandwidthConfig{}
+	limiter, err := cfg.Limiter()
+	require.NoError(t, err)
+	require.Nil(t, limiter, "unlimited by default")
+
+	cfg = BandwidthConfig{Limit: "512KiB"}
+	require.NoError(t, cfg.Validate())
+	limiter, err = cfg.Limiter()
+	require.NoError(t, err)
+	require.Equal(t, int64(512<<10), limiter.Rate())
+
+	cfg = BandwidthConfig{Limit: "fast"}
+	require.ErrorContains(t, cfg.Validate(), `limit: invalid size "fast"`)
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+)
+
+// Validate validates the CDNConfig and returns an error if invalid.
+func (c *CDNConfig) Validate() error {
+	if _, err := cdn.ParsePolicy(c.Policy); err != nil {
+		return fmt.Errorf("policy: %w", err)
+	}
+	return nil
+}
+
+// CDNPolicy returns the configured policy for endpoints behind a CDN or
+// WAF, or empty when detection is disabled.
+func (c *CDNConfig) CDNPolicy() (cdn.Policy, error) {
+	p, err := cdn.ParsePolicy(c.Policy)
+	if err != nil {
+		return "", fmt.Errorf("policy: %w", err)
+	}
+	if c.Disabled {
+		return "", nil
+	}
+	return p, nil
+}
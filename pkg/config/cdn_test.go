@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+)
+
+func TestCDNConfig_CDNPolicy(t *testing.T) {
+	cfg := CDNConfig{}
+	p, err := cfg.CDNPolicy()
+	require.NoError(t, err)
+	require.Equal(t, cdn.PolicyAnnotate, p, "enabled by default")
+
+	cfg = CDNConfig{Policy: "reduce"}
+	p, err = cfg.CDNPolicy()
+	require.NoError(t, err)
+	require.Equal(t, cdn.PolicyReduce, p)
+
+	cfg = CDNConfig{Disabled: true, Policy: "skip"}
+	p, err = cfg.CDNPolicy()
+	require.NoError(t, err)
+	require.Empty(t, p)
+
+	cfg = CDNConfig{Policy: "block"}
+	require.ErrorContains(t, cfg.Validate(), `policy: unknown policy "block"`)
+}
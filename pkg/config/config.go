@@ -51,7 +51,8 @@ func DefaultConfig() Config {
 			Format: "text", // Default log format
 			File:   "",     // Default log file path
 		},
-		Server: DefaultServerConfig(),
+		Server:  DefaultServerConfig(),
+		Tracing: DefaultTracingConfig(),
 	}
 }
 
@@ -179,6 +180,12 @@ func DefaultConfigAsMap() map[string]interface{} {
 		// Auth configuration
 		"server.auth.mode":  def.Server.Auth.Mode,
 		"server.auth.token": def.Server.Auth.Token,
+
+		// Tracing configuration
+		"tracing.enabled":      def.Tracing.Enabled,
+		"tracing.endpoint":     def.Tracing.Endpoint,
+		"tracing.service_name": def.Tracing.ServiceName,
+		"tracing.sample_ratio": def.Tracing.SampleRatio,
 	}
 }
 
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/snmp"
+)
+
+// Validate validates the CredentialsConfig and returns an error if invalid.
+// Secret references are not resolved, so the environment of the host
+// running the scans need not be present.
+func (c *CredentialsConfig) Validate() error {
+	for i, cred := range c.SSH {
+		if err := cred.validate(); err != nil {
+			return fmt.Errorf("ssh[%d]: %w", i, err)
+		}
+	}
+	for i, cred := range c.SNMP {
+		if err := cred.validate(); err != nil {
+			return fmt.Errorf("snmp[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *SSHCredentialConfig) validate() error {
+	if c.Username == "" {
+		return fmt.Errorf("username is required")
+	}
+	if c.Password == "" && c.KeyFile == "" {
+		return fmt.Errorf("password or key_file is required")
+	}
+	return validateTargets(c.Targets)
+}
+
+func (c *SNMPCredentialConfig) validate() error {
+	version, err := snmp.ParseVersion(c.version())
+	if err != nil {
+		return err
+	}
+	if version != snmp.Version3 {
+		if c.Community == "" {
+			return fmt.Errorf("community is required for version %s", version)
+		}
+		return validateTargets(c.Targets)
+	}
+
+	if c.Username == "" {
+		return fmt.Errorf("username is required for version 3")
+	}
+	auth, err := snmp.ParseAuthProtocol(c.AuthProtocol)
+	if err != nil {
+		return err
+	}
+	priv, err := snmp.ParsePrivProtocol(c.PrivProtocol)
+	if err != nil {
+		return err
+	}
+	if auth != snmp.AuthNone && c.AuthPassword == "" {
+		return fmt.Errorf("auth_password is required with auth_protocol")
+	}
+	if priv != snmp.PrivNone {
+		if auth == snmp.AuthNone {
+			return fmt.Errorf("priv_protocol requires auth_protocol")
+		}
+		if c.PrivPassword == "" {
+			return fmt.Errorf("priv_password is required with priv_protocol")
+		}
+	}
+	return validateTargets(c.Targets)
+}
+
+// version returns the configured SNMP version, 2c by default.
+func (c *SNMPCredentialConfig) version() string {
+	if c.Version == "" {
+		return "2c"
+	}
+	return c.Version
+}
+
+func validateTargets(targets []string) error {
+	for _, target := range targets {
+		if strings.Contains(target, "/") {
+			if _, err := netip.ParsePrefix(target); err != nil {
+				return fmt.Errorf("invalid target %q: %w", target, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Store resolves the secrets of the configured logins and returns them as
+// a credential store, or nil when no logins are configured.
+func (c *CredentialsConfig) Store() (*credentials.Store, error) {
+	if len(c.SSH) == 0 && len(c.SNMP) == 0 {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	var logins credentials.Logins
+	for i, cfg := range c.SSH {
+		cred, err := cfg.credential()
+		if err != nil {
+			return nil, fmt.Errorf("ssh[%d]: %w", i, err)
+		}
+		if cred.Name == "" {
+			cred.Name = fmt.Sprintf("ssh-%d", i+1)
+		}
+		logins.SSH = append(logins.SSH, cred)
+	}
+	for i, cfg := range c.SNMP {
+		cred, err := cfg.credential()
+		if err != nil {
+			return nil, fmt.Errorf("snmp[%d]: %w", i, err)
+		}
+		if cred.Name == "" {
+			cred.Name = fmt.Sprintf("snmp-%d", i+1)
+		}
+		logins.SNMP = append(logins.SNMP, cred)
+	}
+	return credentials.NewStore(logins), nil
+}
+
+func (c *SSHCredentialConfig) credential() (credentials.SSH, error) {
+	cred := credentials.SSH{
+		Name:       c.Name,
+		Username:   c.Username,
+		KnownHosts: c.KnownHosts,
+		Targets:    c.Targets,
+	}
+
+	var err error
+	if cred.Password, err = credentials.ResolveSecret(c.Password); err != nil {
+		return cred, fmt.Errorf("password: %w", err)
+	}
+	if cred.Passphrase, err = credentials.ResolveSecret(c.Passphrase); err != nil {
+		return cred, fmt.Errorf("passphrase: %w", err)
+	}
+	if c.KeyFile != "" {
+		if cred.PrivateKey, err = os.ReadFile(c.KeyFile); err != nil {
+			return cred, fmt.Errorf("key_file: %w", err)
+		}
+	}
+	return cred, nil
+}
+
+func (c *SNMPCredentialConfig) credential() (credentials.SNMP, error) {
+	cred := credentials.SNMP{
+		Name:         c.Name,
+		Version:      c.version(),
+		Username:     c.Username,
+		AuthProtocol: c.AuthProtocol,
+		PrivProtocol: c.PrivProtocol,
+		Targets:      c.Targets,
+	}
+
+	var err error
+	if cred.Community, err = credentials.ResolveSecret(c.Community); err != nil {
+		return cred, fmt.Errorf("community: %w", err)
+	}
+	if cred.AuthPassword, err = credentials.ResolveSecret(c.AuthPassword); err != nil {
+		return cred, fmt.Errorf("auth_password: %w", err)
+	}
+	if cred.PrivPassword, err = credentials.ResolveSecret(c.PrivPassword); err != nil {
+		return cred, fmt.Errorf("priv_password: %w", err)
+	}
+	return cred, nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"github.com/vulntor/vulntor/pkg/engagement"
+)
+
+// Validate validates the EngagementConfig and returns an error if invalid.
+func (c *EngagementConfig) Validate() error {
+	_, err := c.Policy()
+	return err
+}
+
+// Policy returns the configured engagement policy.
+func (c *EngagementConfig) Policy() (engagement.Policy, error) {
+	p := engagement.Policy{
+		AssessmentMode: c.AssessmentMode,
+		Defaults: engagement.Record{
+			Client:        c.Client,
+			Authorization: c.Authorization,
+			Tester:        c.Tester,
+		},
+	}
+	if c.Window != "" {
+		var err error
+		if p.Defaults.WindowStart, p.Defaults.WindowEnd, err = engagement.ParseWindow(c.Window); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngagementConfig_Policy(t *testing.T) {
+	cfg := EngagementConfig{}
+	p, err := cfg.Policy()
+	require.NoError(t, err)
+	require.False(t, p.AssessmentMode)
+	require.Nil(t, p.Resolve(nil), "scans record no engagement by default")
+
+	cfg = EngagementConfig{AssessmentMode: true, Client: "ACME", Tester: "alice", Window: "2026-12-01T00:00:00Z/2026-12-15T00:00:00Z"}
+	p, err = cfg.Policy()
+	require.NoError(t, err)
+	require.True(t, p.AssessmentMode)
+	r := p.Resolve(nil)
+	require.Equal(t, "ACME", r.Client)
+	require.Equal(t, time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC), r.WindowEnd)
+	require.Equal(t, []string{"authorization"}, r.Missing())
+
+	cfg = EngagementConfig{Window: "next week"}
+	require.ErrorContains(t, cfg.Validate(), "want FROM/UNTIL")
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/honeypot"
+)
+
+// Validate validates the HoneypotConfig and returns an error if invalid.
+func (c *HoneypotConfig) Validate() error {
+	for _, t := range []struct {
+		field string
+		n     int
+	}{
+		{"max_open_ports", c.MaxOpenPorts},
+		{"identical_banners", c.IdenticalBanners},
+		{"tarpit_ports", c.TarpitPorts},
+	} {
+		if t.n < 0 {
+			return fmt.Errorf("%s: must be >= 0, got %d", t.field, t.n)
+		}
+	}
+	return nil
+}
+
+// Detector returns the configured honeypot detector, or nil when detection
+// is disabled.
+func (c *HoneypotConfig) Detector() (*honeypot.Detector, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Disabled {
+		return nil, nil
+	}
+
+	return honeypot.New(honeypot.Thresholds{
+		MaxOpenPorts:     c.MaxOpenPorts,
+		IdenticalBanners: c.IdenticalBanners,
+		TarpitPorts:      c.TarpitPorts,
+	}), nil
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/honeypot"
+)
+
+func TestHoneypotConfig_Detector(t *testing.T) {
+	cfg := HoneypotConfig{}
+	d, err := cfg.Detector()
+	require.NoError(t, err)
+	require.Equal(t, honeypot.Thresholds{MaxOpenPorts: 100, IdenticalBanners: 5, TarpitPorts: 10}, d.Thresholds(), "enabled by default")
+
+	cfg = HoneypotConfig{MaxOpenPorts: 50, TarpitPorts: 20}
+	d, err = cfg.Detector()
+	require.NoError(t, err)
+	require.Equal(t, honeypot.Thresholds{MaxOpenPorts: 50, IdenticalBanners: 5, TarpitPorts: 20}, d.Thresholds())
+
+	cfg = HoneypotConfig{Disabled: true}
+	d, err = cfg.Detector()
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	cfg = HoneypotConfig{IdenticalBanners: -1}
+	require.ErrorContains(t, cfg.Validate(), "identical_banners: must be >= 0, got -1")
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// byteUnits are the size suffixes ParseByteSize accepts, longest first.
+var byteUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+	{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"B", 1},
+}
+
+// trustLevels are the trust levels SourceTrust accepts.
+var trustLevels = []string{"official", "verified", "community", "untrusted"}
+
+// Validate validates the PluginsConfig and returns an error if invalid.
+func (c *PluginsConfig) Validate() error {
+	names := make([]string, 0, len(c.SourceKeys))
+	for name := range c.SourceKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if _, err := c.CacheSizeLimit(); err != nil {
+		return fmt.Errorf("max_cache_size: %w", err)
+	}
+	if err := c.Retry.Validate(); err != nil {
+		return fmt.Errorf("retry.%w", err)
+	}
+
+	trusted := make([]string, 0, len(c.SourceTrust))
+	for name := range c.SourceTrust {
+		trusted = append(trusted, name)
+	}
+	sort.Strings(trusted)
+	for _, name := range trusted {
+		if !slices.Contains(trustLevels, c.SourceTrust[name]) {
+			return fmt.Errorf("source_trust.%s: invalid trust level %q (valid: %s)", name, c.SourceTrust[name], strings.Join(trustLevels, ", "))
+		}
+	}
+
+	for _, name := range names {
+		keys := c.SourceKeys[name]
+		if len(keys) == 0 {
+			return fmt.Errorf("source_keys.%s: at least one key is required", name)
+		}
+		for i, key := range keys {
+			raw, err := base64.StdEncoding.DecodeString(key)
+			if err != nil {
+				return fmt.Errorf("source_keys.%s[%d]: invalid base64: %w", name, i, err)
+			}
+			if len(raw) != ed25519.PublicKeySize {
+				return fmt.Errorf("source_keys.%s[%d]: Ed25519 public key must be %d bytes, got %d", name, i, ed25519.PublicKeySize, len(raw))
+			}
+		}
+	}
+	return nil
+}
+
+// Validate validates the PluginRetryConfig and returns an error if invalid.
+func (c *PluginRetryConfig) Validate() error {
+	switch {
+	case c.Attempts < 0:
+		return fmt.Errorf("attempts: must be >= 0, got %d", c.Attempts)
+	case c.InitialWait < 0:
+		return fmt.Errorf("initial_wait: must be >= 0, got %v", c.InitialWait)
+	case c.MaxWait < 0:
+		return fmt.Errorf("max_wait: must be >= 0, got %v", c.MaxWait)
+	case c.MaxWait > 0 && c.InitialWait > c.MaxWait:
+		return fmt.Errorf("initial_wait: %v exceeds max_wait %v", c.InitialWait, c.MaxWait)
+	case c.BreakerThreshold < -1:
+		return fmt.Errorf("breaker_threshold: must be >= -1, got %d", c.BreakerThreshold)
+	case c.BreakerCooldown < 0:
+		return fmt.Errorf("breaker_cooldown: must be >= 0, got %v", c.BreakerCooldown)
+	}
+	return nil
+}
+
+// CacheSizeLimit returns MaxCacheSize in bytes, or 0 when unset.
+func (c *PluginsConfig) CacheSizeLimit() (int64, error) {
+	if c.MaxCacheSize == "" {
+		return 0, nil
+	}
+	return ParseByteSize(c.MaxCacheSize)
+}
+
+// ParseByteSize parses a size such as 512MB or 1.5GiB. KB, MB, GB and TB
+// are decimal units, KiB, MiB, GiB and TiB binary ones; a bare number is
+// bytes. Units are case-insensitive.
+func ParseByteSize(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	factor := int64(1)
+	for _, unit := range byteUnits {
+		if len(value) >= len(unit.suffix) && strings.EqualFold(value[len(value)-len(unit.suffix):], unit.suffix) {
+			value = strings.TrimSpace(value[:len(value)-len(unit.suffix)])
+			factor = unit.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q (use e.g. 500MB or 1GiB)", s)
+	}
+	return int64(n * float64(factor)), nil
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPluginsConfig_Validate(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	key := base64.StdEncoding.EncodeToString(pub)
+
+	tests := []struct {
+		name    string
+		cfg     PluginsConfig
+		wantErr string
+	}{
+		{name: "unset", cfg: PluginsConfig{}},
+		{name: "valid", cfg: PluginsConfig{SourceKeys: map[string][]string{"internal": {key}}}},
+		{name: "cache size", cfg: PluginsConfig{MaxCacheSize: "500MB"}},
+		{name: "trust", cfg: PluginsConfig{SourceTrust: map[string]string{"internal": "verified", "scratch": "untrusted"}}},
+		{
+			name:    "invalid trust",
+			cfg:     PluginsConfig{SourceTrust: map[string]string{"internal": "trusted"}},
+			wantErr: `source_trust.internal: invalid trust level "trusted"`,
+		},
+		{name: "retry", cfg: PluginsConfig{Retry: PluginRetryConfig{Attempts: 5, MaxWait: time.Minute, BreakerThreshold: -1}}},
+		{
+			name:    "negative attempts",
+			cfg:     PluginsConfig{Retry: PluginRetryConfig{Attempts: -1}},
+			wantErr: "retry.attempts: must be >= 0",
+		},
+		{
+			name:    "initial wait over max wait",
+			cfg:     PluginsConfig{Retry: PluginRetryConfig{InitialWait: time.Minute, MaxWait: time.Second}},
+			wantErr: "retry.initial_wait: 1m0s exceeds max_wait 1s",
+		},
+		{
+			name:    "invalid breaker threshold",
+			cfg:     PluginsConfig{Retry: PluginRetryConfig{BreakerThreshold: -2}},
+			wantErr: "retry.breaker_threshold: must be >= -1",
+		},
+		{
+			name:    "invalid cache size",
+			cfg:     PluginsConfig{MaxCacheSize: "lots"},
+			wantErr: `max_cache_size: invalid size "lots"`,
+		},
+		{
+			name:    "no keys",
+			cfg:     PluginsConfig{SourceKeys: map[string][]string{"internal": nil}},
+			wantErr: "source_keys.internal: at least one key is required",
+		},
+		{
+			name:    "not base64",
+			cfg:     PluginsConfig{SourceKeys: map[string][]string{"internal": {key, "not base64!"}}},
+			wantErr: "source_keys.internal[1]: invalid base64",
+		},
+		{
+			name:    "wrong size",
+			cfg:     PluginsConfig{SourceKeys: map[string][]string{"internal": {base64.StdEncoding.EncodeToString([]byte("short"))}}},
+			wantErr: "must be 32 bytes, got 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"1024", 1024},
+		{"10B", 10},
+		{"500MB", 500_000_000},
+		{"500 mb", 500_000_000},
+		{"1GiB", 1 << 30},
+		{"1.5KiB", 1536},
+		{"2TB", 2_000_000_000_000},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, got, tt.in)
+	}
+
+	for _, in := range []string{"", "MB", "-1GB", "10XB"} {
+		_, err := ParseByteSize(in)
+		require.Error(t, err, in)
+	}
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+// Validate validates the PolicyConfig and returns an error if invalid.
+func (c *PolicyConfig) Validate() error {
+	for i, cfg := range c.SeverityOverrides {
+		override := cfg.override()
+		if err := override.Validate(); err != nil {
+			return fmt.Errorf("severity_overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Policy returns the configured severity policy, or nil when no overrides
+// are configured.
+func (c *PolicyConfig) Policy() (*policy.Policy, error) {
+	if len(c.SeverityOverrides) == 0 {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	overrides := make([]policy.Override, 0, len(c.SeverityOverrides))
+	for _, cfg := range c.SeverityOverrides {
+		overrides = append(overrides, cfg.override())
+	}
+	return policy.New(c.Environment, overrides), nil
+}
+
+func (c *SeverityOverrideConfig) override() policy.Override {
+	return policy.Override{
+		Plugins:      c.Plugins,
+		Tags:         c.Tags,
+		CVE:          c.CVE,
+		Targets:      c.Targets,
+		Environments: c.Environments,
+		Severity:     c.Severity,
+		Reason:       c.Reason,
+	}
+}
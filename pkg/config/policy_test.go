@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     PolicyConfig
+		wantErr string
+	}{
+		{name: "unset", cfg: PolicyConfig{}},
+		{
+			name: "valid",
+			cfg: PolicyConfig{Environment: "ot", SeverityOverrides: []SeverityOverrideConfig{
+				{Plugins: []string{"telnet-enabled"}, Environments: []string{"ot"}, Severity: "medium"},
+			}},
+		},
+		{
+			name:    "missing selector",
+			cfg:     PolicyConfig{SeverityOverrides: []SeverityOverrideConfig{{Targets: []string{"10.0.0.0/8"}, Severity: "low"}}},
+			wantErr: "severity_overrides[0]: plugins, tags or cve is required",
+		},
+		{
+			name:    "invalid severity",
+			cfg:     PolicyConfig{SeverityOverrides: []SeverityOverrideConfig{{Tags: []string{"telnet"}, Severity: "minor"}}},
+			wantErr: `invalid severity "minor"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPolicyConfig_Policy(t *testing.T) {
+	cfg := PolicyConfig{Environment: "ot"}
+	p, err := cfg.Policy()
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	cfg.SeverityOverrides = []SeverityOverrideConfig{
+		{Plugins: []string{"telnet-enabled"}, Environments: []string{"ot"}, Severity: "medium", Reason: "PLC management"},
+	}
+	p, err = cfg.Policy()
+	require.NoError(t, err)
+	require.Equal(t, "ot", p.Environment())
+	o, ok := p.Override(policy.Finding{PluginID: "telnet-enabled"})
+	require.True(t, ok)
+	require.Equal(t, "medium", o.Severity)
+	require.Equal(t, "PLC management", o.Reason)
+
+	cfg.SeverityOverrides[0].Severity = ""
+	_, err = cfg.Policy()
+	require.ErrorContains(t, err, "severity_overrides[0]: invalid severity")
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/vulntor/vulntor/pkg/netproxy"
+)
+
+// Validate validates the ProxyConfig and returns an error if invalid.
+func (p *ProxyConfig) Validate() error {
+	proxy, err := p.Proxy()
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(p.Sources))
+	for name := range p.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := proxy.Override(p.Sources[name]); err != nil {
+			return fmt.Errorf("sources.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Proxy returns the configured proxy, or nil when no URL is set so that
+// the environment applies.
+func (p *ProxyConfig) Proxy() (*netproxy.Proxy, error) {
+	return netproxy.New(p.URL, p.NoProxy)
+}
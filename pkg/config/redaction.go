@@ -0,0 +1,43 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/redact"
+)
+
+// Validate validates the RedactionConfig and returns an error if invalid.
+func (c *RedactionConfig) Validate() error {
+	for i, cfg := range c.Rules {
+		rule := cfg.rule()
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Redactor returns the configured redactor, or nil when redaction is
+// disabled.
+func (c *RedactionConfig) Redactor() (*redact.Redactor, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	rules := make([]redact.Rule, 0, len(c.Rules))
+	for _, cfg := range c.Rules {
+		rules = append(rules, cfg.rule())
+	}
+	return redact.New(rules)
+}
+
+func (c *RedactionRuleConfig) rule() redact.Rule {
+	return redact.Rule{
+		Fields: c.Fields,
+		Deny:   c.Deny,
+		Allow:  c.Allow,
+	}
+}
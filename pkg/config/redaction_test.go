@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RedactionConfig
+		wantErr string
+	}{
+		{name: "unset", cfg: RedactionConfig{}},
+		{
+			name: "valid",
+			cfg: RedactionConfig{Enabled: true, Rules: []RedactionRuleConfig{
+				{Fields: []string{"body"}, Deny: []string{`serial: (\S+)`}, Allow: []string{`^deleted$`}},
+			}},
+		},
+		{
+			name:    "empty rule",
+			cfg:     RedactionConfig{Rules: []RedactionRuleConfig{{Fields: []string{"banner"}}}},
+			wantErr: "rules[0]: deny or allow is required",
+		},
+		{
+			name:    "invalid pattern",
+			cfg:     RedactionConfig{Rules: []RedactionRuleConfig{{Allow: []string{"[a-"}}}},
+			wantErr: `rules[0]: invalid allow pattern "[a-"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRedactionConfig_Redactor(t *testing.T) {
+	cfg := RedactionConfig{Rules: []RedactionRuleConfig{{Deny: []string{"internal"}}}}
+	r, err := cfg.Redactor()
+	require.NoError(t, err)
+	require.Nil(t, r)
+
+	cfg.Enabled = true
+	r, err = cfg.Redactor()
+	require.NoError(t, err)
+	require.Equal(t, "[REDACTED] host, password=[REDACTED]", r.String("banner", "internal host, password=x"))
+
+	cfg.Rules = []RedactionRuleConfig{{}}
+	_, err = cfg.Redactor()
+	require.ErrorContains(t, err, "rules[0]")
+}
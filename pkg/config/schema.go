@@ -0,0 +1,254 @@
+// pkg/config/schema.go
+package config
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema version emitted by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// durationPattern matches Go duration strings such as 500ms, 10s or 1h30m.
+const durationPattern = `^-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// ModuleParameter describes a parameter accepted under modules.<name>.
+// Type uses the scan module schema types: string, int, bool, float,
+// duration and []string.
+type ModuleParameter struct {
+	Type        string
+	Description string
+	Default     any
+}
+
+// ModuleSchemas maps scan module names to their parameters. It describes
+// the modules section of a config file, which configures the modules
+// directly and is not part of Config.
+type ModuleSchemas map[string]map[string]ModuleParameter
+
+type schemaKind int
+
+const (
+	kindAny schemaKind = iota
+	kindString
+	kindBool
+	kindInt
+	kindFloat
+	kindDuration
+	kindList
+	kindMap
+	kindObject
+)
+
+// schemaNode describes the value expected at a config key.
+type schemaNode struct {
+	kind        schemaKind
+	description string
+	def         any                    // default value, nil when unset
+	fields      map[string]*schemaNode // kindObject
+	elem        *schemaNode            // kindList, kindMap
+	// lenient accepts a scalar for a list; modules split comma-separated
+	// values themselves.
+	lenient bool
+}
+
+// configSchema returns the schema of a config file: the Config struct
+// with its defaults plus the modules section.
+func configSchema(modules ModuleSchemas) *schemaNode {
+	root := structSchema(reflect.ValueOf(DefaultConfig()), "")
+	root.fields["modules"] = modulesSchema(modules)
+	return root
+}
+
+// structSchema builds a schema node from a value, using the koanf and
+// description struct tags. Non-zero values become defaults.
+func structSchema(v reflect.Value, description string) *schemaNode {
+	t := v.Type()
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return &schemaNode{kind: kindDuration, description: description, def: defaultOf(v)}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		node := &schemaNode{kind: kindObject, description: description, fields: make(map[string]*schemaNode)}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			key := f.Tag.Get("koanf")
+			if key == "" || key == "-" || !f.IsExported() {
+				continue
+			}
+			node.fields[key] = structSchema(v.Field(i), f.Tag.Get("description"))
+		}
+		return node
+	case reflect.Slice:
+		return &schemaNode{kind: kindList, description: description, elem: structSchema(reflect.New(t.Elem()).Elem(), "")}
+	case reflect.Map:
+		return &schemaNode{kind: kindMap, description: description, elem: structSchema(reflect.New(t.Elem()).Elem(), "")}
+	case reflect.String:
+		return &schemaNode{kind: kindString, description: description, def: defaultOf(v)}
+	case reflect.Bool:
+		return &schemaNode{kind: kindBool, description: description, def: defaultOf(v)}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schemaNode{kind: kindInt, description: description, def: defaultOf(v)}
+	case reflect.Float32, reflect.Float64:
+		return &schemaNode{kind: kindFloat, description: description, def: defaultOf(v)}
+	}
+	return &schemaNode{kind: kindAny, description: description}
+}
+
+// defaultOf returns v as a schema default, or nil for the zero value.
+func defaultOf(v reflect.Value) any {
+	if v.IsZero() {
+		return nil
+	}
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+	return v.Interface()
+}
+
+// modulesSchema returns the schema of the modules section. Without module
+// schemas any modules section is accepted.
+func modulesSchema(modules ModuleSchemas) *schemaNode {
+	const description = "Scan module parameters, keyed by module name"
+	if modules == nil {
+		return &schemaNode{kind: kindMap, description: description, elem: &schemaNode{kind: kindAny}}
+	}
+
+	node := &schemaNode{kind: kindObject, description: description, fields: make(map[string]*schemaNode, len(modules))}
+	for name, params := range modules {
+		module := &schemaNode{kind: kindObject, fields: make(map[string]*schemaNode, len(params))}
+		for param, def := range params {
+			module.fields[param] = parameterSchema(def)
+		}
+		node.fields[name] = module
+	}
+	return node
+}
+
+func parameterSchema(p ModuleParameter) *schemaNode {
+	node := &schemaNode{description: p.Description, def: p.Default}
+	switch p.Type {
+	case "string":
+		node.kind = kindString
+	case "int":
+		node.kind = kindInt
+	case "bool":
+		node.kind = kindBool
+	case "float":
+		node.kind = kindFloat
+	case "duration":
+		node.kind = kindDuration
+	case "[]string":
+		node.kind = kindList
+		node.elem = &schemaNode{kind: kindString}
+		node.lenient = true
+	default:
+		node.kind = kindAny
+	}
+	return node
+}
+
+// JSONSchema returns a JSON Schema describing config files, for editor
+// autocompletion and CI validation. Unknown keys are rejected; pass the
+// scan module schemas to describe the modules section, or nil to accept
+// any modules.
+func JSONSchema(modules ModuleSchemas) map[string]any {
+	schema := configSchema(modules).jsonSchema()
+	schema["$schema"] = JSONSchemaDialect
+	schema["title"] = "Vulntor configuration"
+	return schema
+}
+
+func (n *schemaNode) jsonSchema() map[string]any {
+	s := make(map[string]any)
+	if n.description != "" {
+		s["description"] = n.description
+	}
+	if n.def != nil {
+		s["default"] = n.def
+	}
+
+	switch n.kind {
+	case kindString:
+		s["type"] = "string"
+	case kindBool:
+		s["type"] = "boolean"
+	case kindInt:
+		s["type"] = "integer"
+	case kindFloat:
+		s["type"] = "number"
+	case kindDuration:
+		s["type"] = "string"
+		s["pattern"] = durationPattern
+	case kindList:
+		items := n.elem.jsonSchema()
+		if n.lenient {
+			// A comma-separated string is accepted in place of a list
+			s["anyOf"] = []any{
+				map[string]any{"type": "array", "items": items},
+				map[string]any{"type": "string"},
+			}
+		} else {
+			s["type"] = "array"
+			s["items"] = items
+		}
+	case kindMap:
+		s["type"] = "object"
+		s["additionalProperties"] = n.elem.jsonSchema()
+	case kindObject:
+		props := make(map[string]any, len(n.fields))
+		for key, field := range n.fields {
+			props[key] = field.jsonSchema()
+		}
+		s["type"] = "object"
+		s["properties"] = props
+		s["additionalProperties"] = false
+	}
+	return s
+}
+
+// keys returns the field names of an object node, sorted.
+func (n *schemaNode) keys() []string {
+	keys := make([]string, 0, len(n.fields))
+	for key := range n.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// typeName describes the kind of value a node expects, for error messages.
+func (n *schemaNode) typeName() string {
+	switch n.kind {
+	case kindString:
+		return "a string"
+	case kindBool:
+		return "a boolean"
+	case kindInt:
+		return "an integer"
+	case kindFloat:
+		return "a number"
+	case kindDuration:
+		return "a duration (e.g. 10s, 1m30s)"
+	case kindList:
+		if n.lenient {
+			return "a list or a comma-separated string"
+		}
+		return "a list"
+	case kindMap, kindObject:
+		return "a mapping"
+	}
+	return "a value"
+}
+
+// joinPath appends key to a dotted config path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return strings.Join([]string{path, key}, ".")
+}
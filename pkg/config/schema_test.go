@@ -0,0 +1,51 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema(testModules)
+
+	require.Equal(t, JSONSchemaDialect, schema["$schema"])
+	require.Equal(t, "object", schema["type"])
+	require.Equal(t, false, schema["additionalProperties"])
+
+	props := schema["properties"].(map[string]any)
+	for _, key := range []string{"log", "server", "tracing", "notifications", "ticketing", "proxy", "credentials", "policy", "redaction", "plugins", "fingerprint", "bandwidth", "wordlists", "sla", "honeypot", "cdn", "secrets", "scope", "engagement", "telemetry", "modules"} {
+		require.Contains(t, props, key)
+	}
+
+	server := props["server"].(map[string]any)
+	require.Equal(t, "Server configuration", server["description"])
+	serverProps := server["properties"].(map[string]any)
+	require.Equal(t, map[string]any{"type": "integer", "description": "Server listen port", "default": 8080}, serverProps["port"])
+	require.Equal(t, "string", serverProps["read_timeout"].(map[string]any)["type"])
+	require.Equal(t, durationPattern, serverProps["read_timeout"].(map[string]any)["pattern"])
+
+	webhooks := props["notifications"].(map[string]any)["properties"].(map[string]any)["webhooks"].(map[string]any)
+	require.Equal(t, "array", webhooks["type"])
+	webhook := webhooks["items"].(map[string]any)
+	require.Equal(t, "object", webhook["type"])
+	require.Contains(t, webhook["properties"], "min_severity")
+	headers := webhook["properties"].(map[string]any)["headers"].(map[string]any)
+	require.Equal(t, map[string]any{"type": "string"}, headers["additionalProperties"])
+
+	module := props["modules"].(map[string]any)["properties"].(map[string]any)["tcp-port-discovery"].(map[string]any)
+	moduleProps := module["properties"].(map[string]any)
+	require.Equal(t, "integer", moduleProps["concurrency"].(map[string]any)["type"])
+	require.Contains(t, moduleProps["ports"], "anyOf")
+
+	_, err := json.Marshal(schema)
+	require.NoError(t, err)
+}
+
+func TestJSONSchema_AnyModules(t *testing.T) {
+	modules := JSONSchema(nil)["properties"].(map[string]any)["modules"].(map[string]any)
+
+	require.Equal(t, "object", modules["type"])
+	require.Equal(t, map[string]any{}, modules["additionalProperties"])
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/vulntor/vulntor/pkg/scope"
+)
+
+// Validate validates the ScopeConfig and returns an error if invalid.
+func (c *ScopeConfig) Validate() error {
+	_, err := c.Policy()
+	return err
+}
+
+// Policy returns the configured scan scope, or nil when no ranges are
+// allowed or denied, permitting every target.
+func (c *ScopeConfig) Policy() (*scope.Policy, error) {
+	if len(c.Allow) == 0 && len(c.Deny) == 0 {
+		return nil, nil
+	}
+	return scope.New(c.Allow, c.Deny, c.OverrideKey)
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeConfig_Policy(t *testing.T) {
+	cfg := ScopeConfig{}
+	p, err := cfg.Policy()
+	require.NoError(t, err)
+	require.Nil(t, p, "every target is permitted by default")
+
+	cfg = ScopeConfig{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.1"}}
+	p, err = cfg.Policy()
+	require.NoError(t, err)
+	_, err = p.Check(context.Background(), []string{"10.1.0.0/16"}, "")
+	require.NoError(t, err)
+	_, err = p.Check(context.Background(), []string{"10.0.0.1"}, "")
+	require.ErrorContains(t, err, "denied by 10.0.0.1/32")
+
+	cfg = ScopeConfig{Allow: []string{"10.0.0.0/8", "corp.example"}}
+	require.ErrorContains(t, cfg.Validate(), `allow: invalid IP address "corp.example"`)
+}
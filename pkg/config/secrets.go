@@ -0,0 +1,80 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+// Validate validates the SecretsConfig and returns an error if invalid.
+func (c *SecretsConfig) Validate() error {
+	if err := validateSecretsURL(c.Vault.Address); err != nil {
+		return fmt.Errorf("vault.address: %w", err)
+	}
+	if c.Vault.Timeout < 0 {
+		return fmt.Errorf("vault.timeout: must not be negative")
+	}
+	if err := validateSecretsURL(c.AWS.Endpoint); err != nil {
+		return fmt.Errorf("aws.endpoint: %w", err)
+	}
+	if c.AWS.Timeout < 0 {
+		return fmt.Errorf("aws.timeout: must not be negative")
+	}
+	return nil
+}
+
+func validateSecretsURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url: %q (must be an http(s) URL)", raw)
+	}
+	return nil
+}
+
+// Resolver returns a secrets resolver whose Vault and AWS Secrets Manager
+// providers use the configured settings over the environment.
+func (c *SecretsConfig) Resolver() (*secrets.Resolver, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := secrets.NewResolver()
+
+	vault := secrets.VaultFromEnv()
+	if c.Vault.Address != "" {
+		vault.Address = c.Vault.Address
+	}
+	if c.Vault.Token != "" {
+		// Token references (env:NAME, file:PATH) use the built-in providers
+		token, err := secrets.NewResolver().Resolve(context.Background(), c.Vault.Token)
+		if err != nil {
+			return nil, fmt.Errorf("vault.token: %w", err)
+		}
+		vault.Token = token
+	}
+	if c.Vault.Namespace != "" {
+		vault.Namespace = c.Vault.Namespace
+	}
+	if c.Vault.Timeout > 0 {
+		vault.Client = &http.Client{Timeout: c.Vault.Timeout}
+	}
+	r.Register("vault", vault)
+
+	aws := secrets.AWSFromEnv()
+	if c.AWS.Region != "" {
+		aws.Region = c.AWS.Region
+	}
+	aws.Endpoint = c.AWS.Endpoint
+	if c.AWS.Timeout > 0 {
+		aws.Client = &http.Client{Timeout: c.AWS.Timeout}
+	}
+	r.Register("aws-sm", aws)
+
+	return r, nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsConfig_Validate(t *testing.T) {
+	require.NoError(t, (&SecretsConfig{}).Validate())
+
+	cfg := SecretsConfig{Vault: VaultSecretsConfig{Address: "vault.example.com"}}
+	require.ErrorContains(t, cfg.Validate(), "vault.address: invalid url")
+
+	cfg = SecretsConfig{AWS: AWSSecretsConfig{Endpoint: "ftp://secrets"}}
+	require.ErrorContains(t, cfg.Validate(), "aws.endpoint: invalid url")
+
+	cfg = SecretsConfig{Vault: VaultSecretsConfig{Timeout: -time.Second}}
+	require.ErrorContains(t, cfg.Validate(), "vault.timeout")
+}
+
+func TestSecretsConfig_Resolver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "from-env-token", r.Header.Get("X-Vault-Token"))
+		require.Equal(t, "/v1/secret/data/vulntor/ssh", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":{"data":{"password":"hunter2"},"metadata":{}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("VULNTOR_TEST_VAULT_TOKEN", "from-env-token")
+	cfg := SecretsConfig{Vault: VaultSecretsConfig{Address: srv.URL, Token: "env:VULNTOR_TEST_VAULT_TOKEN"}}
+	r, err := cfg.Resolver()
+	require.NoError(t, err)
+
+	got, err := r.Resolve(context.Background(), "vault:secret/data/vulntor/ssh#password")
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", got)
+
+	cfg = SecretsConfig{Vault: VaultSecretsConfig{Token: "env:VULNTOR_TEST_UNSET"}}
+	_, err = cfg.Resolver()
+	require.ErrorContains(t, err, "vault.token: environment variable VULNTOR_TEST_UNSET is not set")
+}
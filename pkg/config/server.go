@@ -25,6 +25,15 @@ func DefaultServerConfig() ServerConfig {
 			Mode:  "token",
 			Token: "",
 		},
+		Queue: QueueConfig{
+			Size: 100,
+		},
+		HA: HAConfig{
+			LeaseTTL: 15 * time.Second,
+		},
+		RateLimit: RateLimitConfig{
+			FailedAuthPerMinute: 10,
+		},
 	}
 }
 
@@ -35,6 +44,14 @@ func (c *ServerConfig) Validate() error {
 		return fmt.Errorf("invalid port: %d (must be 1-65535)", c.Port)
 	}
 
+	// Validate the gRPC port; 0 disables the gRPC API
+	if c.GRPCPort < 0 || c.GRPCPort > 65535 {
+		return fmt.Errorf("invalid grpc_port: %d (must be 1-65535, or 0 to disable)", c.GRPCPort)
+	}
+	if c.GRPCPort != 0 && c.GRPCPort == c.Port {
+		return fmt.Errorf("invalid grpc_port: %d (must differ from port)", c.GRPCPort)
+	}
+
 	// Validate concurrency
 	if c.Concurrency < 1 {
 		return fmt.Errorf("invalid concurrency: %d (must be >= 1)", c.Concurrency)
@@ -53,6 +70,26 @@ func (c *ServerConfig) Validate() error {
 		return fmt.Errorf("auth config: %w", err)
 	}
 
+	// Validate queue limits
+	if err := c.Queue.Validate(); err != nil {
+		return fmt.Errorf("queue config: %w", err)
+	}
+
+	// Validate leader election
+	if err := c.HA.Validate(); err != nil {
+		return fmt.Errorf("ha config: %w", err)
+	}
+
+	// Validate listener TLS
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls config: %w", err)
+	}
+
+	// Validate API limits
+	if err := c.RateLimit.Validate(); err != nil {
+		return fmt.Errorf("rate_limit config: %w", err)
+	}
+
 	return nil
 }
 
@@ -61,6 +98,15 @@ func (c *ServerConfig) ListenAddr() string {
 	return fmt.Sprintf("%s:%d", c.Addr, c.Port)
 }
 
+// GRPCListenAddr returns the listen address of the gRPC API (addr:grpc_port),
+// or "" when it is disabled.
+func (c *ServerConfig) GRPCListenAddr() string {
+	if c.GRPCPort == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", c.Addr, c.GRPCPort)
+}
+
 // IsAuthEnabled returns true if authentication is enabled.
 func (c *ServerConfig) IsAuthEnabled() bool {
 	return c.Auth.Mode != "none"
@@ -70,10 +116,10 @@ func (c *ServerConfig) IsAuthEnabled() bool {
 func (a *AuthConfig) Validate() error {
 	// Validate auth mode
 	switch a.Mode {
-	case "none", "token", "oidc":
+	case "none", "token", "apikey", "oidc":
 		// Valid modes
 	default:
-		return fmt.Errorf("invalid auth mode: %s (must be none|token|oidc)", a.Mode)
+		return fmt.Errorf("invalid auth mode: %s (must be none|token|apikey|oidc)", a.Mode)
 	}
 
 	// Token mode requires a non-empty token
@@ -81,10 +127,117 @@ func (a *AuthConfig) Validate() error {
 		return fmt.Errorf("token mode requires a non-empty auth.token")
 	}
 
-	// OIDC mode is not yet implemented
+	// OIDC mode requires an issuer and client
 	if a.Mode == "oidc" {
-		return fmt.Errorf("oidc mode is not yet implemented")
+		if err := a.OIDC.Validate(); err != nil {
+			return fmt.Errorf("oidc: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the QueueConfig and returns an error if invalid.
+// Zero values take their default.
+func (q *QueueConfig) Validate() error {
+	if q.Size < 0 {
+		return fmt.Errorf("invalid size: %d (must be >= 0)", q.Size)
+	}
+	if q.TenantConcurrency < 0 {
+		return fmt.Errorf("invalid tenant_concurrency: %d (must be >= 0)", q.TenantConcurrency)
+	}
+	if q.TenantQuota < 0 {
+		return fmt.Errorf("invalid tenant_quota: %d (must be >= 0)", q.TenantQuota)
+	}
+	return nil
+}
+
+// Validate validates the HAConfig and returns an error if invalid.
+// A zero lease_ttl takes its default.
+func (h *HAConfig) Validate() error {
+	if h.LeaseTTL < 0 {
+		return fmt.Errorf("invalid lease_ttl: %v (must be >= 0)", h.LeaseTTL)
+	}
+	return nil
+}
+
+// Validate validates the RateLimitConfig and returns an error if invalid.
+func (r *RateLimitConfig) Validate() error {
+	if r.RequestsPerMinute < 0 {
+		return fmt.Errorf("invalid requests_per_minute: %d (must be >= 0)", r.RequestsPerMinute)
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("invalid burst: %d (must be >= 0)", r.Burst)
+	}
+	if r.DailyScans < 0 {
+		return fmt.Errorf("invalid daily_scans: %d (must be >= 0)", r.DailyScans)
+	}
+	if r.FailedAuthPerMinute < 0 {
+		return fmt.Errorf("invalid failed_auth_per_minute: %d (must be >= 0)", r.FailedAuthPerMinute)
+	}
+	return nil
+}
+
+// Validate validates the TLSConfig and returns an error if invalid.
+// The files are checked when the server starts.
+func (t *TLSConfig) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("cert_file and key_file must be set together")
+	}
+	if t.ClientCAFile != "" && t.CertFile == "" {
+		return fmt.Errorf("client_ca_file requires cert_file and key_file")
+	}
+	switch t.ClientAuth {
+	case "", "require", "optional":
+	default:
+		return fmt.Errorf("invalid client_auth: %s (must be require|optional)", t.ClientAuth)
+	}
+	if t.ClientAuth != "" && t.ClientCAFile == "" {
+		return fmt.Errorf("client_auth requires client_ca_file")
+	}
+	switch t.MinVersion {
+	case "", "1.2", "1.3":
+	default:
+		return fmt.Errorf("invalid min_version: %s (must be 1.2|1.3)", t.MinVersion)
+	}
+	return nil
+}
+
+// IsEnabled returns true if the listener serves HTTPS.
+func (t *TLSConfig) IsEnabled() bool {
+	return t.CertFile != ""
+}
+
+// IsMutual returns true if the listener verifies client certificates.
+func (t *TLSConfig) IsMutual() bool {
+	return t.ClientCAFile != ""
+}
+
+// Validate validates the OIDCConfig and returns an error if invalid.
+func (o *OIDCConfig) Validate() error {
+	if o.IssuerURL == "" {
+		return fmt.Errorf("issuer_url is required")
+	}
+	if o.ClientID == "" {
+		return fmt.Errorf("client_id is required")
+	}
+
+	for group, role := range o.RoleMapping {
+		if !isValidRole(role) {
+			return fmt.Errorf("invalid role %q for group %q (must be viewer|operator|admin)", role, group)
+		}
+	}
+	if o.DefaultRole != "" && !isValidRole(o.DefaultRole) {
+		return fmt.Errorf("invalid default_role %q (must be viewer|operator|admin)", o.DefaultRole)
 	}
 
 	return nil
 }
+
+func isValidRole(role string) bool {
+	switch role {
+	case "viewer", "operator", "admin":
+		return true
+	}
+	return false
+}
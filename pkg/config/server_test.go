@@ -35,6 +35,24 @@ func TestDefaultServerConfig(t *testing.T) {
 	// Auth config
 	require.Equal(t, "token", cfg.Auth.Mode)
 	require.Empty(t, cfg.Auth.Token)
+
+	// Queue config
+	require.Equal(t, 100, cfg.Queue.Size)
+	require.Zero(t, cfg.Queue.TenantConcurrency)
+	require.Zero(t, cfg.Queue.TenantQuota)
+
+	// HA config
+	require.False(t, cfg.HA.Enabled)
+	require.Equal(t, 15*time.Second, cfg.HA.LeaseTTL)
+
+	// TLS config
+	require.False(t, cfg.TLS.IsEnabled())
+	require.False(t, cfg.TLS.IsMutual())
+
+	// API limits are off by default, except for failed authentications
+	require.Zero(t, cfg.RateLimit.RequestsPerMinute)
+	require.Zero(t, cfg.RateLimit.DailyScans)
+	require.Equal(t, 10, cfg.RateLimit.FailedAuthPerMinute)
 }
 
 func TestServerConfig_Validate(t *testing.T) {
@@ -71,6 +89,26 @@ func TestServerConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid port",
 		},
+		{
+			name: "grpc port same as port",
+			cfg: ServerConfig{
+				Port:     8080,
+				GRPCPort: 8080,
+				Auth:     AuthConfig{Mode: "none"},
+			},
+			wantErr: true,
+			errMsg:  "must differ from port",
+		},
+		{
+			name: "invalid grpc port",
+			cfg: ServerConfig{
+				Port:     8080,
+				GRPCPort: 70000,
+				Auth:     AuthConfig{Mode: "none"},
+			},
+			wantErr: true,
+			errMsg:  "invalid grpc_port",
+		},
 		{
 			name: "invalid concurrency",
 			cfg: ServerConfig{
@@ -113,6 +151,104 @@ func TestServerConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "token mode requires",
 		},
+		{
+			name: "negative tenant quota",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				Queue:       QueueConfig{TenantQuota: -1},
+			},
+			wantErr: true,
+			errMsg:  "queue config: invalid tenant_quota",
+		},
+		{
+			name: "negative lease ttl",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				HA:          HAConfig{Enabled: true, LeaseTTL: -time.Second},
+			},
+			wantErr: true,
+			errMsg:  "ha config: invalid lease_ttl",
+		},
+		{
+			name: "valid mutual tls",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				TLS:         TLSConfig{CertFile: "server.crt", KeyFile: "server.key", ClientCAFile: "ca.crt", ClientAuth: "optional", MinVersion: "1.3"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "tls cert without key",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				TLS:         TLSConfig{CertFile: "server.crt"},
+			},
+			wantErr: true,
+			errMsg:  "tls config: cert_file and key_file must be set together",
+		},
+		{
+			name: "client ca without server certificate",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				TLS:         TLSConfig{ClientCAFile: "ca.crt"},
+			},
+			wantErr: true,
+			errMsg:  "tls config: client_ca_file requires",
+		},
+		{
+			name: "invalid client auth",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				TLS:         TLSConfig{CertFile: "server.crt", KeyFile: "server.key", ClientCAFile: "ca.crt", ClientAuth: "request"},
+			},
+			wantErr: true,
+			errMsg:  "tls config: invalid client_auth",
+		},
+		{
+			name: "invalid tls min version",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				TLS:         TLSConfig{CertFile: "server.crt", KeyFile: "server.key", MinVersion: "1.0"},
+			},
+			wantErr: true,
+			errMsg:  "tls config: invalid min_version",
+		},
+		{
+			name: "negative daily scans",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				RateLimit:   RateLimitConfig{RequestsPerMinute: 60, DailyScans: -1},
+			},
+			wantErr: true,
+			errMsg:  "rate_limit config: invalid daily_scans",
+		},
+		{
+			name: "negative failed auth limit",
+			cfg: ServerConfig{
+				Port:        8080,
+				Concurrency: 1,
+				Auth:        AuthConfig{Mode: "none"},
+				RateLimit:   RateLimitConfig{FailedAuthPerMinute: -1},
+			},
+			wantErr: true,
+			errMsg:  "rate_limit config: invalid failed_auth_per_minute",
+		},
 	}
 
 	for _, tt := range tests {
@@ -197,6 +333,11 @@ func TestAuthConfig_Validate(t *testing.T) {
 			cfg:     AuthConfig{Mode: "token", Token: "test-token"},
 			wantErr: false,
 		},
+		{
+			name:    "valid apikey mode",
+			cfg:     AuthConfig{Mode: "apikey"},
+			wantErr: false,
+		},
 		{
 			name:    "invalid mode",
 			cfg:     AuthConfig{Mode: "invalid"},
@@ -210,10 +351,46 @@ func TestAuthConfig_Validate(t *testing.T) {
 			errMsg:  "token mode requires a non-empty auth.token",
 		},
 		{
-			name:    "oidc mode not implemented",
+			name:    "oidc mode without issuer",
 			cfg:     AuthConfig{Mode: "oidc"},
 			wantErr: true,
-			errMsg:  "oidc mode is not yet implemented",
+			errMsg:  "oidc: issuer_url is required",
+		},
+		{
+			name:    "oidc mode without client",
+			cfg:     AuthConfig{Mode: "oidc", OIDC: OIDCConfig{IssuerURL: "https://idp.example.com"}},
+			wantErr: true,
+			errMsg:  "oidc: client_id is required",
+		},
+		{
+			name: "valid oidc mode",
+			cfg: AuthConfig{Mode: "oidc", OIDC: OIDCConfig{
+				IssuerURL:   "https://idp.example.com",
+				ClientID:    "vulntor",
+				RoleMapping: map[string]string{"sec-team": "admin", "devs": "viewer"},
+				DefaultRole: "viewer",
+			}},
+			wantErr: false,
+		},
+		{
+			name: "oidc mode with invalid mapped role",
+			cfg: AuthConfig{Mode: "oidc", OIDC: OIDCConfig{
+				IssuerURL:   "https://idp.example.com",
+				ClientID:    "vulntor",
+				RoleMapping: map[string]string{"sec-team": "root"},
+			}},
+			wantErr: true,
+			errMsg:  `invalid role "root" for group "sec-team"`,
+		},
+		{
+			name: "oidc mode with invalid default role",
+			cfg: AuthConfig{Mode: "oidc", OIDC: OIDCConfig{
+				IssuerURL:   "https://idp.example.com",
+				ClientID:    "vulntor",
+				DefaultRole: "guest",
+			}},
+			wantErr: true,
+			errMsg:  `invalid default_role "guest"`,
 		},
 	}
 
@@ -229,4 +406,3 @@ func TestAuthConfig_Validate(t *testing.T) {
 		})
 	}
 }
-
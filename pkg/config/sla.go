@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/sla"
+)
+
+// Validate validates the SLAConfig and returns an error if invalid.
+func (c *SLAConfig) Validate() error {
+	for severity, days := range c.days() {
+		if days < 0 {
+			return fmt.Errorf("%s: must be >= 0 days, got %d", severity, days)
+		}
+	}
+	return nil
+}
+
+// SLA returns the configured remediation deadlines, or nil when no
+// severity has one.
+func (c *SLAConfig) SLA() (*sla.SLA, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	deadlines := make(map[string]time.Duration)
+	for severity, days := range c.days() {
+		deadlines[severity] = time.Duration(days) * 24 * time.Hour
+	}
+	return sla.New(deadlines), nil
+}
+
+func (c *SLAConfig) days() map[string]int {
+	return map[string]int{
+		"critical": c.Critical,
+		"high":     c.High,
+		"medium":   c.Medium,
+		"low":      c.Low,
+		"info":     c.Info,
+	}
+}
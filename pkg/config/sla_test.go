@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAConfig_SLA(t *testing.T) {
+	cfg := SLAConfig{}
+	s, err := cfg.SLA()
+	require.NoError(t, err)
+	require.Nil(t, s, "no deadlines by default")
+
+	cfg = SLAConfig{Critical: 7, High: 30}
+	require.NoError(t, cfg.Validate())
+	s, err = cfg.SLA()
+	require.NoError(t, err)
+	deadline, ok := s.Deadline("critical")
+	require.True(t, ok)
+	require.Equal(t, 7*24*time.Hour, deadline)
+	_, ok = s.Deadline("medium")
+	require.False(t, ok)
+
+	cfg = SLAConfig{Low: -1}
+	require.ErrorContains(t, cfg.Validate(), "low: must be >= 0 days")
+}
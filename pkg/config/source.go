@@ -4,6 +4,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
@@ -117,6 +118,9 @@ func (s *EnvSource) Load(k *koanf.Koanf) error {
 
 // FlagSource loads configuration from command-line flags.
 // Priority: 40 (highest, overrides all other sources)
+//
+// Flags named like a config section (e.g. "plugins") are command options,
+// not values of the section, and are skipped.
 type FlagSource struct {
 	Flags *pflag.FlagSet
 	Debug bool // If true, set log.level to "debug"
@@ -127,7 +131,13 @@ func (s *FlagSource) Priority() int { return 40 }
 
 func (s *FlagSource) Load(k *koanf.Koanf) error {
 	if s.Flags != nil {
-		if err := k.Load(posflag.Provider(s.Flags, ".", k), nil); err != nil {
+		provider := posflag.ProviderWithFlag(s.Flags, ".", k, func(f *pflag.Flag) (string, interface{}) {
+			if configSections[f.Name] {
+				return "", nil
+			}
+			return f.Name, posflag.FlagVal(s.Flags, f)
+		})
+		if err := k.Load(provider, nil); err != nil {
 			return fmt.Errorf("error loading command-line flags: %w", err)
 		}
 	}
@@ -140,6 +150,18 @@ func (s *FlagSource) Load(k *koanf.Koanf) error {
 	return nil
 }
 
+// configSections holds the keys of the sections of Config.
+var configSections = func() map[string]bool {
+	sections := make(map[string]bool)
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.Type.Kind() == reflect.Struct {
+			sections[f.Tag.Get("koanf")] = true
+		}
+	}
+	return sections
+}()
+
 // DefaultSources returns the standard configuration sources.
 // Order: defaults -> file -> env -> flags
 func DefaultSources(configPath string, flags *pflag.FlagSet, debug bool) []ConfigSource {
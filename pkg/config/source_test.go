@@ -146,6 +146,18 @@ func TestFlagSource_Load(t *testing.T) {
 	assert.Equal(t, "debug", k.String("log.level"))
 }
 
+func TestFlagSource_Load_SkipsSectionNames(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringSlice("plugins", nil, "")
+	_ = flags.Set("plugins", "ssh")
+
+	k := koanf.New(".")
+	src := &FlagSource{Flags: flags}
+	require.NoError(t, src.Load(k))
+
+	assert.False(t, k.Exists("plugins"))
+}
+
 func TestFlagSource_Load_DebugFlag(t *testing.T) {
 	k := koanf.New(".")
 
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// minTelemetryInterval bounds how often telemetry reports are sent.
+const minTelemetryInterval = time.Hour
+
+// Validate validates the TelemetryConfig and returns an error if invalid.
+func (c *TelemetryConfig) Validate() error {
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid endpoint: %q (must be an http(s) URL)", c.Endpoint)
+		}
+	}
+	if c.Interval != 0 && c.Interval < minTelemetryInterval {
+		return fmt.Errorf("interval: must be at least %s, got %s", minTelemetryInterval, c.Interval)
+	}
+	return nil
+}
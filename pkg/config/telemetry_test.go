@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryConfig_Validate(t *testing.T) {
+	cfg := TelemetryConfig{}
+	require.NoError(t, cfg.Validate(), "defaults are valid")
+	require.False(t, cfg.Enabled, "disabled by default")
+
+	cfg = TelemetryConfig{Enabled: true, Endpoint: "https://telemetry.example.com/v1/reports", Interval: 6 * time.Hour}
+	require.NoError(t, cfg.Validate())
+
+	cfg = TelemetryConfig{Endpoint: "telemetry.example.com"}
+	require.ErrorContains(t, cfg.Validate(), "must be an http(s) URL")
+
+	cfg = TelemetryConfig{Interval: time.Minute}
+	require.ErrorContains(t, cfg.Validate(), "interval: must be at least 1h0m0s, got 1m0s")
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DefaultTracingConfig returns the default tracing configuration.
+// Tracing is disabled unless explicitly enabled.
+func DefaultTracingConfig() TracingConfig {
+	return TracingConfig{
+		Enabled:     false,
+		Endpoint:    "",
+		ServiceName: "vulntor",
+		SampleRatio: 1.0,
+	}
+}
+
+// Validate validates the TracingConfig and returns an error if invalid.
+func (t *TracingConfig) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return fmt.Errorf("invalid sample_ratio: %v (must be 0.0-1.0)", t.SampleRatio)
+	}
+
+	endpoint := t.TracesURL()
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		return fmt.Errorf("invalid endpoint: %q (must be an http(s) URL)", endpoint)
+	}
+
+	return nil
+}
+
+// TracesURL returns the OTLP/HTTP traces URL. The endpoint falls back to
+// OTEL_EXPORTER_OTLP_ENDPOINT and then http://localhost:4318; the
+// /v1/traces path is appended unless already present.
+func (t *TracingConfig) TracesURL() string {
+	endpoint := t.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if endpoint == "" {
+		endpoint = "http://localhost:4318"
+	}
+
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	return endpoint
+}
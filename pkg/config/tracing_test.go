@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTracingConfig(t *testing.T) {
+	cfg := DefaultTracingConfig()
+
+	require.False(t, cfg.Enabled)
+	require.Empty(t, cfg.Endpoint)
+	require.Equal(t, "vulntor", cfg.ServiceName)
+	require.Equal(t, 1.0, cfg.SampleRatio)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestTracingConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TracingConfig
+		wantErr string
+	}{
+		{
+			name: "disabled ignores invalid values",
+			cfg:  TracingConfig{Enabled: false, SampleRatio: 5, Endpoint: "collector:4318"},
+		},
+		{
+			name: "valid",
+			cfg:  TracingConfig{Enabled: true, SampleRatio: 0.25, Endpoint: "https://otel.example.com"},
+		},
+		{
+			name:    "sample ratio above one",
+			cfg:     TracingConfig{Enabled: true, SampleRatio: 1.5},
+			wantErr: "invalid sample_ratio",
+		},
+		{
+			name:    "negative sample ratio",
+			cfg:     TracingConfig{Enabled: true, SampleRatio: -0.1},
+			wantErr: "invalid sample_ratio",
+		},
+		{
+			name:    "endpoint without scheme",
+			cfg:     TracingConfig{Enabled: true, SampleRatio: 1, Endpoint: "collector:4318"},
+			wantErr: "invalid endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTracingConfig_TracesURL(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+
+	cfg := TracingConfig{}
+	require.Equal(t, "http://localhost:4318/v1/traces", cfg.TracesURL())
+
+	cfg.Endpoint = "http://jaeger:4318/"
+	require.Equal(t, "http://jaeger:4318/v1/traces", cfg.TracesURL())
+
+	cfg.Endpoint = "https://otel.example.com/v1/traces"
+	require.Equal(t, "https://otel.example.com/v1/traces", cfg.TracesURL())
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://tempo:4318")
+	cfg.Endpoint = ""
+	require.Equal(t, "http://tempo:4318/v1/traces", cfg.TracesURL())
+}
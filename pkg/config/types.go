@@ -6,8 +6,26 @@ import "time"
 // Config is the root configuration structure for the Vulntor application.
 // It aggregates all other specific configuration structs.
 type Config struct {
-	Log    LogConfig    `description:"Logging configuration" koanf:"log"`   // Logging configuration
-	Server ServerConfig `description:"Server configuration" koanf:"server"` // Server configuration
+	Log           LogConfig           `description:"Logging configuration" koanf:"log"`                     // Logging configuration
+	Server        ServerConfig        `description:"Server configuration" koanf:"server"`                   // Server configuration
+	Tracing       TracingConfig       `description:"Distributed tracing configuration" koanf:"tracing"`     // Tracing configuration
+	Notifications NotificationsConfig `description:"Scan notification configuration" koanf:"notifications"` // Notification configuration
+	Ticketing     TicketingConfig     `description:"Issue tracker integration" koanf:"ticketing"`           // Ticketing configuration
+	Proxy         ProxyConfig         `description:"Outbound proxy configuration" koanf:"proxy"`            // Proxy configuration
+	Credentials   CredentialsConfig   `description:"Logins for authenticated checks" koanf:"credentials"`   // Credentials configuration
+	Policy        PolicyConfig        `description:"Severity policy per environment" koanf:"policy"`        // Severity policy configuration
+	Redaction     RedactionConfig     `description:"Masking of secrets in evidence" koanf:"redaction"`      // Redaction configuration
+	Plugins       PluginsConfig       `description:"Plugin source settings" koanf:"plugins"`                // Plugin configuration
+	Fingerprint   FingerprintConfig   `description:"Fingerprint result settings" koanf:"fingerprint"`       // Fingerprint configuration
+	Bandwidth     BandwidthConfig     `description:"Download rate limit" koanf:"bandwidth"`                 // Bandwidth configuration
+	Wordlists     WordlistsConfig     `description:"Wordlist sources" koanf:"wordlists"`                    // Wordlist configuration
+	SLA           SLAConfig           `description:"Remediation deadlines per severity" koanf:"sla"`        // SLA configuration
+	Honeypot      HoneypotConfig      `description:"Honeypot and tarpit detection" koanf:"honeypot"`        // Honeypot detection configuration
+	CDN           CDNConfig           `description:"CDN and WAF detection" koanf:"cdn"`                     // CDN detection configuration
+	Secrets       SecretsConfig       `description:"Secret managers" koanf:"secrets"`                       // Secrets provider configuration
+	Scope         ScopeConfig         `description:"Address ranges scans may target" koanf:"scope"`         // Scan scope configuration
+	Engagement    EngagementConfig    `description:"Engagement records of scans" koanf:"engagement"`        // Engagement configuration
+	Telemetry     TelemetryConfig     `description:"Anonymous opt-in telemetry" koanf:"telemetry"`          // Telemetry configuration
 }
 
 // LogConfig holds logging related configuration.
@@ -24,6 +42,9 @@ type ServerConfig struct {
 	Addr string `description:"Server listen address" koanf:"addr"`
 	Port int    `description:"Server listen port" koanf:"port"`
 
+	// GRPCPort serves the gRPC API on addr:grpc_port; 0 disables it
+	GRPCPort int `description:"gRPC API listen port (0 = disabled)" koanf:"grpc_port"`
+
 	// Component toggles
 	UIEnabled   bool `description:"Enable UI static serving" koanf:"ui_enabled"`
 	APIEnabled  bool `description:"Enable REST API endpoints" koanf:"api_enabled"`
@@ -40,8 +61,48 @@ type ServerConfig struct {
 	WriteTimeout time.Duration `description:"HTTP write timeout" koanf:"write_timeout"`
 
 	// Sub-configurations
-	UI   UIConfig   `description:"UI configuration" koanf:"ui"`
-	Auth AuthConfig `description:"Authentication configuration" koanf:"auth"`
+	UI    UIConfig    `description:"UI configuration" koanf:"ui"`
+	Auth  AuthConfig  `description:"Authentication configuration" koanf:"auth"`
+	Queue QueueConfig `description:"Scan job queue limits" koanf:"queue"`
+	HA    HAConfig    `description:"High availability across server replicas" koanf:"ha"`
+	TLS   TLSConfig   `description:"HTTPS and mutual TLS of the listener" koanf:"tls"`
+
+	RateLimit RateLimitConfig `description:"API request rate limits and daily scan quotas" koanf:"rate_limit"`
+}
+
+// RateLimitConfig holds the API limits of each client: an API key, an OIDC
+// user, or a client IP without either. API keys may override the limits.
+type RateLimitConfig struct {
+	RequestsPerMinute   int `description:"API requests per minute of each client (0 = no limit)" koanf:"requests_per_minute"`
+	Burst               int `description:"Requests a client may send at once (default: requests_per_minute)" koanf:"burst"`
+	DailyScans          int `description:"Scans each client may submit per day, UTC (0 = no limit)" koanf:"daily_scans"`
+	FailedAuthPerMinute int `description:"Failed authentications per minute of each IP address (0 = no limit)" koanf:"failed_auth_per_minute"`
+}
+
+// TLSConfig holds the TLS settings of the server listener. The files are
+// re-read when they change, so renewed certificates apply without a restart.
+type TLSConfig struct {
+	CertFile     string `description:"PEM certificate (chain) of the server; enables HTTPS" koanf:"cert_file"`
+	KeyFile      string `description:"PEM private key of the server certificate" koanf:"key_file"`
+	ClientCAFile string `description:"PEM CA bundle verifying client certificates; enables mutual TLS" koanf:"client_ca_file"`
+	ClientAuth   string `description:"Client certificates with client_ca_file: require|optional (default: require)" koanf:"client_auth"`
+	MinVersion   string `description:"Minimum TLS version: 1.2|1.3 (default: 1.2)" koanf:"min_version"`
+}
+
+// QueueConfig holds the limits of the scan job queue. Queued jobs are
+// persisted in the workspace and run after a restart.
+type QueueConfig struct {
+	Size              int `description:"Maximum queued jobs; further submissions are rejected (default: 100)" koanf:"size"`
+	TenantConcurrency int `description:"Maximum running jobs per tenant (0 = no limit)" koanf:"tenant_concurrency"`
+	TenantQuota       int `description:"Maximum queued and running jobs per tenant (0 = no limit)" koanf:"tenant_quota"`
+}
+
+// HAConfig holds the leader election settings of server replicas sharing a
+// workspace. Every replica serves the API; only the leader runs scan jobs.
+type HAConfig struct {
+	Enabled  bool          `description:"Elect a single replica to run scan jobs" koanf:"enabled"`
+	NodeID   string        `description:"ID of this replica in the election (default: hostname-pid)" koanf:"node_id"`
+	LeaseTTL time.Duration `description:"How long the leader lease lasts unrenewed; failover takes up to this long (default: 15s)" koanf:"lease_ttl"`
 }
 
 // UIConfig holds UI-specific configuration.
@@ -51,6 +112,291 @@ type UIConfig struct {
 
 // AuthConfig holds authentication configuration.
 type AuthConfig struct {
-	Mode  string `description:"Authentication mode: none|token|oidc" koanf:"mode"`
-	Token string `description:"Static bearer token (required for token mode)" koanf:"token"`
+	Mode  string     `description:"Authentication mode: none|token|apikey|oidc" koanf:"mode"`
+	Token string     `description:"Static bearer token (required for token mode)" koanf:"token"`
+	OIDC  OIDCConfig `description:"OpenID Connect settings (required for oidc mode)" koanf:"oidc"`
+}
+
+// OIDCConfig holds OpenID Connect settings for single sign-on (Okta,
+// Keycloak, Azure AD, ...).
+type OIDCConfig struct {
+	IssuerURL     string            `description:"OIDC issuer URL (discovery document at /.well-known/openid-configuration)" koanf:"issuer_url"`
+	ClientID      string            `description:"OAuth client ID; tokens must list it as audience" koanf:"client_id"`
+	ClientSecret  string            `description:"OAuth client secret (confidential clients)" koanf:"client_secret"`
+	RedirectURL   string            `description:"Callback URL registered with the IdP, e.g. https://vulntor.example.com/auth/callback" koanf:"redirect_url"`
+	Scopes        []string          `description:"OAuth scopes requested at login (default: openid, profile, email)" koanf:"scopes"`
+	UsernameClaim string            `description:"Claim used as user ID (default: preferred_username, falling back to sub)" koanf:"username_claim"`
+	GroupsClaim   string            `description:"Claim listing the user's IdP groups (default: groups)" koanf:"groups_claim"`
+	RoleMapping   map[string]string `description:"IdP group to role mapping (viewer|operator|admin)" koanf:"role_mapping"`
+	DefaultRole   string            `description:"Role for users without a mapped group (empty denies access)" koanf:"default_role"`
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration. Spans cover the
+// scan pipeline (DAG execution, fingerprinting, plugin evaluation, storage)
+// and the server API, and are exported via OTLP/HTTP.
+type TracingConfig struct {
+	Enabled     bool              `description:"Export trace spans via OTLP" koanf:"enabled"`
+	Endpoint    string            `description:"OTLP/HTTP collector endpoint, e.g. http://localhost:4318 (default: OTEL_EXPORTER_OTLP_ENDPOINT)" koanf:"endpoint"`
+	ServiceName string            `description:"service.name resource attribute" koanf:"service_name"`
+	SampleRatio float64           `description:"Fraction of root traces sampled (0.0-1.0)" koanf:"sample_ratio"`
+	Headers     map[string]string `description:"Extra HTTP headers sent to the collector (e.g. authentication)" koanf:"headers"`
+}
+
+// NotificationsConfig holds destinations notified when scans finish or
+// produce findings.
+type NotificationsConfig struct {
+	Webhooks []WebhookConfig `description:"Webhook destinations" koanf:"webhooks"`
+}
+
+// WebhookConfig describes a webhook destination. Payloads are JSON POSTs
+// signed with HMAC-SHA256 when Secret is set.
+type WebhookConfig struct {
+	Name        string            `description:"Destination name used in logs" koanf:"name"`
+	URL         string            `description:"http(s) URL receiving POST requests" koanf:"url"`
+	Secret      string            `description:"Shared secret for the X-Vulntor-Signature header, or secret reference" koanf:"secret"`
+	Events      []string          `description:"Events to deliver: scan.completed, scan.failed, findings.new, changes.detected, findings.overdue (default: all)" koanf:"events"`
+	MinSeverity string            `description:"Lowest finding severity reported by findings.new: info|low|medium|high|critical (default: high)" koanf:"min_severity"`
+	Headers     map[string]string `description:"Extra HTTP headers sent with each request; values may be secret references" koanf:"headers"`
+	Timeout     time.Duration     `description:"Per-request timeout (default: 10s)" koanf:"timeout"`
+}
+
+// TicketingConfig holds issue trackers where tickets are opened for
+// findings and resolved once the findings disappear.
+type TicketingConfig struct {
+	Trackers []TrackerConfig `description:"Issue trackers" koanf:"trackers"`
+}
+
+// TrackerConfig describes a Jira project or GitHub repository receiving
+// finding tickets.
+type TrackerConfig struct {
+	Type        string   `description:"Tracker type: jira|github" koanf:"type"`
+	Name        string   `description:"Tracker name used in logs" koanf:"name"`
+	URL         string   `description:"Jira base URL, or GitHub API URL (default: https://api.github.com)" koanf:"url"`
+	Project     string   `description:"Jira project key" koanf:"project"`
+	IssueType   string   `description:"Jira issue type (default: Bug)" koanf:"issue_type"`
+	Repository  string   `description:"GitHub repository as owner/name" koanf:"repository"`
+	Username    string   `description:"Jira Cloud account email; empty sends the token as a bearer token" koanf:"username"`
+	Token       string   `description:"Jira API token or GitHub token, or secret reference" koanf:"token"`
+	MinSeverity string   `description:"Lowest finding severity ticketed: info|low|medium|high|critical (default: high)" koanf:"min_severity"`
+	Labels      []string `description:"Extra labels added to created tickets" koanf:"labels"`
+	AutoResolve bool     `description:"Resolve tickets whose finding is gone when its host is rescanned" koanf:"auto_resolve"`
+}
+
+// ProxyConfig holds the proxy used for plugin downloads, HTTP probes and
+// banner grabbing. Without a URL, HTTP traffic honors the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables and probes connect
+// directly.
+type ProxyConfig struct {
+	URL     string            `description:"Proxy URL: http://, https://, socks5:// or socks5h://, optionally with user:password" koanf:"url"`
+	NoProxy []string          `description:"Hosts, domains (.example.com) and CIDR ranges reached directly" koanf:"no_proxy"`
+	Sources map[string]string `description:"Per plugin source proxy URL, keyed by source name (direct bypasses the proxy)" koanf:"sources"`
+}
+
+// BandwidthConfig caps the download rate of plugin downloads and
+// fingerprint catalog syncs, shared by all their transfers. Scans are not
+// limited.
+type BandwidthConfig struct {
+	Limit string `description:"Maximum download rate per second, e.g. 512KB or 2MiB (default: unlimited)" koanf:"limit"`
+}
+
+// SLAConfig holds the number of days findings of each severity must be
+// remediated in, counted from when a finding was first seen. Findings past
+// their due date are overdue until triaged as fixed, accepted-risk or
+// false-positive, or rechecked as fixed.
+type SLAConfig struct {
+	Critical int `description:"Days to remediate critical findings (default: no deadline)" koanf:"critical"`
+	High     int `description:"Days to remediate high findings (default: no deadline)" koanf:"high"`
+	Medium   int `description:"Days to remediate medium findings (default: no deadline)" koanf:"medium"`
+	Low      int `description:"Days to remediate low findings (default: no deadline)" koanf:"low"`
+	Info     int `description:"Days to remediate info findings (default: no deadline)" koanf:"info"`
+}
+
+// HoneypotConfig sets when hosts are flagged as possible honeypots or
+// tarpits. Findings on flagged hosts are reported as low confidence.
+type HoneypotConfig struct {
+	Disabled         bool `description:"Report findings on possible honeypots and tarpits at full confidence" koanf:"disabled"`
+	MaxOpenPorts     int  `description:"Open ports above which a host is flagged (default: 100)" koanf:"max_open_ports"`
+	IdenticalBanners int  `description:"Ports sending the same banner at which a host is flagged (default: 5)" koanf:"identical_banners"`
+	TarpitPorts      int  `description:"Open ports sending no data at which a host is flagged as a tarpit (default: 10)" koanf:"tarpit_ports"`
+}
+
+// SecretsConfig holds the secret managers that secret references read
+// from: vault:PATH#FIELD from HashiCorp Vault and aws-sm:NAME#FIELD from
+// AWS Secrets Manager. Unset settings fall back to the environment
+// variables of the vault and aws CLIs.
+type SecretsConfig struct {
+	Vault VaultSecretsConfig `description:"HashiCorp Vault" koanf:"vault"`
+	AWS   AWSSecretsConfig   `description:"AWS Secrets Manager" koanf:"aws"`
+}
+
+// VaultSecretsConfig describes the Vault server vault: references read
+// from.
+type VaultSecretsConfig struct {
+	Address   string        `description:"Vault server URL (default: VAULT_ADDR)" koanf:"address"`
+	Token     string        `description:"Vault token or secret reference (env:NAME, file:PATH) (default: VAULT_TOKEN or ~/.vault-token)" koanf:"token"`
+	Namespace string        `description:"Vault Enterprise namespace (default: VAULT_NAMESPACE)" koanf:"namespace"`
+	Timeout   time.Duration `description:"Per-request timeout (default: 10s)" koanf:"timeout"`
+}
+
+// AWSSecretsConfig describes the Secrets Manager region aws-sm: references
+// read from. Credentials are taken from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN.
+type AWSSecretsConfig struct {
+	Region   string        `description:"AWS region (default: AWS_REGION or AWS_DEFAULT_REGION)" koanf:"region"`
+	Endpoint string        `description:"Secrets Manager endpoint URL, e.g. a VPC endpoint (default: the regional endpoint)" koanf:"endpoint"`
+	Timeout  time.Duration `description:"Per-request timeout (default: 10s)" koanf:"timeout"`
+}
+
+// ScopeConfig holds the address ranges scans are authorized to target.
+// Scans with targets outside them are refused before sending any packet,
+// unless an acknowledgment signed with the override key approves them.
+type ScopeConfig struct {
+	Allow       []string `description:"CIDRs and IP addresses scans may target (default: any address)" koanf:"allow"`
+	Deny        []string `description:"CIDRs and IP addresses scans never target, even within allow" koanf:"deny"`
+	OverrideKey string   `description:"Key signing out-of-scope acknowledgments, or a secret reference (env:NAME, file:PATH, vault:, aws-sm:) (default: no overrides)" koanf:"override_key"`
+}
+
+// EngagementConfig sets the engagement records scans are run under. In
+// assessment mode, scans without a client, authorization reference, tester
+// and window are refused. The other fields fill the records of scans that
+// leave them empty.
+type EngagementConfig struct {
+	AssessmentMode bool   `description:"Refuse scans without a complete engagement record" koanf:"assessment_mode"`
+	Client         string `description:"Client scans are run for" koanf:"client"`
+	Authorization  string `description:"Reference of the authorization to test, e.g. an engagement letter" koanf:"authorization"`
+	Tester         string `description:"Tester running scans" koanf:"tester"`
+	Window         string `description:"Window testing is authorized in, FROM/UNTIL as RFC 3339 times or dates" koanf:"window"`
+}
+
+// TelemetryConfig opts in to anonymous telemetry: aggregates of the
+// detection quality of recent scans (plugin match rates, the rate of
+// unidentified services per protocol, module crashes) are reported to
+// Endpoint. Disabled unless explicitly enabled.
+type TelemetryConfig struct {
+	Enabled  bool          `description:"Report anonymous detection quality aggregates (opt-in)" koanf:"enabled"`
+	Endpoint string        `description:"http(s) URL receiving telemetry reports (default: https://telemetry.vulntor.ai/v1/reports)" koanf:"endpoint"`
+	Interval time.Duration `description:"Time between reports, at least 1h (default: 24h)" koanf:"interval"`
+}
+
+// CDNConfig sets how endpoints behind a CDN or web application firewall
+// are scanned.
+type CDNConfig struct {
+	Disabled bool   `description:"Do not detect CDNs and WAFs" koanf:"disabled"`
+	Policy   string `description:"Endpoints behind a CDN or WAF: annotate, reduce or skip (default: annotate)" koanf:"policy"`
+}
+
+// WordlistsConfig holds the remote sources wordlists are installed and
+// updated from with "vulntor wordlist update".
+type WordlistsConfig struct {
+	Sources []WordlistSourceConfig `description:"Wordlist sources, tried in order" koanf:"sources"`
+}
+
+// WordlistSourceConfig is a remote source of wordlists: the URL of the
+// index listing them.
+type WordlistSourceConfig struct {
+	Name string `description:"Source name recorded with the wordlists it provides" koanf:"name"`
+	URL  string `description:"http(s) URL of the source's wordlist index" koanf:"url"`
+}
+
+// CredentialsConfig holds logins used by authenticated checks, which
+// inspect targets from the inside instead of only probing their services.
+type CredentialsConfig struct {
+	SSH  []SSHCredentialConfig  `description:"SSH logins for credentialed collection" koanf:"ssh"`
+	SNMP []SNMPCredentialConfig `description:"SNMP communities and users for the SNMP walk" koanf:"snmp"`
+}
+
+// PolicyConfig remaps the severity of findings to the environment they
+// are found in, e.g. to report telnet on OT networks as medium. Findings
+// keep the plugin's severity as their original severity.
+type PolicyConfig struct {
+	Environment       string                   `description:"Environment scans run in, selecting the overrides that apply (e.g. ot, prod)" koanf:"environment"`
+	SeverityOverrides []SeverityOverrideConfig `description:"Severity overrides, tried in order; the first one selecting a finding applies" koanf:"severity_overrides"`
+}
+
+// SeverityOverrideConfig remaps the severity of the findings it selects. A
+// finding is selected when it matches every criterion that is set.
+type SeverityOverrideConfig struct {
+	Plugins      []string `description:"Plugin IDs or names" koanf:"plugins"`
+	Tags         []string `description:"Plugin tags" koanf:"tags"`
+	CVE          []string `description:"CVE identifiers" koanf:"cve"`
+	Targets      []string `description:"Hosts, IP addresses and CIDR ranges the override applies to (default: all)" koanf:"targets"`
+	Environments []string `description:"Environments the override applies in (default: all)" koanf:"environments"`
+	Severity     string   `description:"Severity reported instead: critical, high, medium, low or info" koanf:"severity"`
+	Reason       string   `description:"Why the severity differs, shown in reports" koanf:"reason"`
+}
+
+// RedactionConfig masks credentials, cookies, tokens and other sensitive
+// strings in captured banners and responses before they are stored or
+// exported. Rules add patterns to the built-in ones.
+type RedactionConfig struct {
+	Enabled bool                  `description:"Mask secrets in evidence before it is stored or exported" koanf:"enabled"`
+	Rules   []RedactionRuleConfig `description:"Patterns masked or kept in addition to the built-in ones" koanf:"rules"`
+}
+
+// RedactionRuleConfig adds deny and allow patterns to the redaction of
+// some fields.
+type RedactionRuleConfig struct {
+	Fields []string `description:"Evidence fields the rule applies to, named as in the JSON output, e.g. banner or body (default: all)" koanf:"fields"`
+	Deny   []string `description:"Regular expressions of strings to mask; patterns with capture groups mask only the groups" koanf:"deny"`
+	Allow  []string `description:"Regular expressions of strings kept although a deny or built-in pattern matches them" koanf:"allow"`
+}
+
+// PluginsConfig holds plugin source and cache settings. Sources listed in
+// SourceKeys must publish a detached Ed25519 signature next to their
+// manifest, at the manifest URL plus ".sig"; manifests without a valid
+// signature are rejected. Plugins of sources whose SourceTrust is
+// untrusted install only with --allow-untrusted.
+type PluginsConfig struct {
+	SourceKeys   map[string][]string `description:"Base64 Ed25519 public keys per plugin source name; manifests of listed sources must be signed" koanf:"source_keys"`
+	SourceTrust  map[string]string   `description:"Trust level per plugin source name: official, verified, community or untrusted" koanf:"source_trust"`
+	MaxCacheSize string              `description:"Plugin cache size limit, e.g. 500MB or 1GiB; least recently used plugins are evicted (default: unbounded)" koanf:"max_cache_size"`
+	Retry        PluginRetryConfig   `description:"Retries and circuit breaker for plugin downloads" koanf:"retry"`
+}
+
+// PluginRetryConfig controls how plugin downloads recover from transient
+// network errors and 5xx responses. Failed downloads are retried with
+// exponential backoff and jitter; a source whose downloads keep failing is
+// skipped until BreakerCooldown has passed. Zero values use the defaults.
+type PluginRetryConfig struct {
+	Attempts         int           `description:"Attempts per download, including the first; 1 disables retries (default: 3)" koanf:"attempts"`
+	InitialWait      time.Duration `description:"Wait before the first retry, doubled for each further one (default: 1s)" koanf:"initial_wait"`
+	MaxWait          time.Duration `description:"Longest wait between retries (default: 30s)" koanf:"max_wait"`
+	BreakerThreshold int           `description:"Consecutive failed downloads after which a source is skipped; -1 never skips (default: 5)" koanf:"breaker_threshold"`
+	BreakerCooldown  time.Duration `description:"How long a failing source is skipped (default: 30s)" koanf:"breaker_cooldown"`
+}
+
+// FingerprintConfig holds settings applied to fingerprint matches. The
+// normalization dictionary maps vendor and product aliases to the
+// canonical names used in CPEs and reports, extending the built-in one.
+type FingerprintConfig struct {
+	Normalization string `description:"YAML dictionary of vendor and product aliases, merged over the built-in one" koanf:"normalization"`
+}
+
+// SSHCredentialConfig describes an SSH login. Password and Passphrase take
+// secret references: env:NAME reads an environment variable, file:PATH a
+// file, and vault:PATH#FIELD and aws-sm:NAME#FIELD a secret manager (see
+// SecretsConfig), so secrets need not be stored in the config file.
+type SSHCredentialConfig struct {
+	Name       string   `description:"Credential name used in logs and results" koanf:"name"`
+	Username   string   `description:"Login user" koanf:"username"`
+	Password   string   `description:"Password or secret reference (env:, file:, vault:, aws-sm:)" koanf:"password"`
+	KeyFile    string   `description:"Private key file" koanf:"key_file"`
+	Passphrase string   `description:"Private key passphrase or secret reference (env:, file:, vault:, aws-sm:)" koanf:"passphrase"`
+	KnownHosts string   `description:"known_hosts file host keys are verified against (default: accept any host key)" koanf:"known_hosts"`
+	Targets    []string `description:"Hosts, IP addresses and CIDR ranges the login applies to (default: all)" koanf:"targets"`
+}
+
+// SNMPCredentialConfig describes an SNMP login: a community for versions
+// 1 and 2c, or a user for version 3. Community and passwords take secret
+// references like SSH passwords.
+type SNMPCredentialConfig struct {
+	Name         string   `description:"Credential name used in logs and results" koanf:"name"`
+	Version      string   `description:"SNMP version: 1, 2c or 3 (default: 2c)" koanf:"version"`
+	Community    string   `description:"Community string or secret reference (env:, file:, vault:, aws-sm:)" koanf:"community"`
+	Username     string   `description:"SNMPv3 user" koanf:"username"`
+	AuthProtocol string   `description:"SNMPv3 auth protocol: MD5, SHA, SHA256 or SHA512" koanf:"auth_protocol"`
+	AuthPassword string   `description:"SNMPv3 auth password or secret reference (env:, file:, vault:, aws-sm:)" koanf:"auth_password"`
+	PrivProtocol string   `description:"SNMPv3 privacy protocol: DES or AES" koanf:"priv_protocol"`
+	PrivPassword string   `description:"SNMPv3 privacy password or secret reference (env:, file:, vault:, aws-sm:)" koanf:"priv_password"`
+	Targets      []string `description:"Hosts, IP addresses and CIDR ranges the login applies to (default: all)" koanf:"targets"`
 }
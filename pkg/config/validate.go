@@ -0,0 +1,309 @@
+// pkg/config/validate.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is a problem found in a config file.
+type ValidationError struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"` // Config key, e.g. server.port or notifications.webhooks[0].url
+	Message string `json:"message"`
+}
+
+// Error formats the error as line:column: path: message.
+func (e ValidationError) Error() string {
+	var sb strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "%d:%d: ", e.Line, e.Column)
+	}
+	if e.Path != "" {
+		sb.WriteString(e.Path + ": ")
+	}
+	sb.WriteString(e.Message)
+	return sb.String()
+}
+
+// ValidationErrors lists the problems found in a config file, in file order.
+type ValidationErrors []ValidationError
+
+// Error joins the errors, one per line.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SectionCheck validates the decoded configuration of a top-level section.
+type SectionCheck func(cfg Config) error
+
+// Validator strictly validates config files: unknown keys, values of the
+// wrong type and invalid settings are reported with their line and column.
+type Validator struct {
+	// Modules describes the modules section. Nil accepts any modules.
+	Modules ModuleSchemas
+	// Checks validate sections beyond their structure, keyed by top-level
+	// key. They run when the file is well-formed and sets the section.
+	// The server, tracing, proxy, credentials, policy, redaction, plugins,
+	// bandwidth, wordlists, sla, honeypot, cdn, secrets, scope, engagement
+	// and telemetry sections are always checked.
+	Checks map[string]SectionCheck
+}
+
+// builtinChecks validate the sections owned by this package.
+var builtinChecks = map[string]SectionCheck{
+	"server":      func(cfg Config) error { return cfg.Server.Validate() },
+	"tracing":     func(cfg Config) error { return cfg.Tracing.Validate() },
+	"proxy":       func(cfg Config) error { return cfg.Proxy.Validate() },
+	"credentials": func(cfg Config) error { return cfg.Credentials.Validate() },
+	"policy":      func(cfg Config) error { return cfg.Policy.Validate() },
+	"redaction":   func(cfg Config) error { return cfg.Redaction.Validate() },
+	"plugins":     func(cfg Config) error { return cfg.Plugins.Validate() },
+	"bandwidth":   func(cfg Config) error { return cfg.Bandwidth.Validate() },
+	"wordlists":   func(cfg Config) error { return cfg.Wordlists.Validate() },
+	"sla":         func(cfg Config) error { return cfg.SLA.Validate() },
+	"honeypot":    func(cfg Config) error { return cfg.Honeypot.Validate() },
+	"cdn":         func(cfg Config) error { return cfg.CDN.Validate() },
+	"secrets":     func(cfg Config) error { return cfg.Secrets.Validate() },
+	"scope":       func(cfg Config) error { return cfg.Scope.Validate() },
+	"engagement":  func(cfg Config) error { return cfg.Engagement.Validate() },
+	"telemetry":   func(cfg Config) error { return cfg.Telemetry.Validate() },
+}
+
+// yamlLineRe extracts the line number from yaml.v3 syntax errors.
+var yamlLineRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// ValidateFile validates the config file at path. It returns
+// ValidationErrors when the file is invalid.
+func (v *Validator) ValidateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+	return v.Validate(data)
+}
+
+// Validate validates YAML config data. It returns ValidationErrors when the
+// data is invalid.
+func (v *Validator) Validate(data []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return ValidationErrors{syntaxError(err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil // Empty file
+	}
+	root := doc.Content[0]
+
+	var errs ValidationErrors
+	schema := configSchema(v.Modules)
+	checkNode(root, schema, "", &errs)
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			if errs[i].Line != errs[j].Line {
+				return errs[i].Line < errs[j].Line
+			}
+			return errs[i].Column < errs[j].Column
+		})
+		return errs
+	}
+
+	cfg, err := decodeConfig(root)
+	if err != nil {
+		return ValidationErrors{{Line: root.Line, Column: root.Column, Message: err.Error()}}
+	}
+
+	checks := make(map[string]SectionCheck, len(builtinChecks)+len(v.Checks))
+	for key, check := range builtinChecks {
+		checks[key] = check
+	}
+	for key, check := range v.Checks {
+		checks[key] = check
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		check, ok := checks[key.Value]
+		if !ok {
+			continue
+		}
+		if err := check(cfg); err != nil {
+			errs = append(errs, ValidationError{Line: key.Line, Column: key.Column, Path: key.Value, Message: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// syntaxError converts a YAML parse error into a ValidationError.
+func syntaxError(err error) ValidationError {
+	var typeErr *yaml.TypeError
+	msg := err.Error()
+	if errors.As(err, &typeErr) && len(typeErr.Errors) > 0 {
+		msg = typeErr.Errors[0]
+	}
+	if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return ValidationError{Line: line, Column: 1, Message: m[2]}
+	}
+	return ValidationError{Message: strings.TrimPrefix(msg, "yaml: ")}
+}
+
+// checkNode reports where node does not match schema.
+func checkNode(node *yaml.Node, schema *schemaNode, path string, errs *ValidationErrors) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return // Unset, the default applies
+	}
+
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, ValidationError{Line: node.Line, Column: node.Column, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	expected := func() {
+		fail("expected %s, got %s", schema.typeName(), describeNode(node))
+	}
+
+	switch schema.kind {
+	case kindAny:
+		return
+	case kindString:
+		if node.Kind != yaml.ScalarNode {
+			expected()
+		}
+	case kindBool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			expected()
+		}
+	case kindInt:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			expected()
+		}
+	case kindFloat:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			expected()
+		}
+	case kindDuration:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!str" {
+			expected()
+			return
+		}
+		if _, err := time.ParseDuration(node.Value); err != nil {
+			fail("invalid duration %q (e.g. 10s, 1m30s)", node.Value)
+		}
+	case kindList:
+		if node.Kind == yaml.ScalarNode && schema.lenient {
+			return
+		}
+		if node.Kind != yaml.SequenceNode {
+			expected()
+			return
+		}
+		for i, item := range node.Content {
+			checkNode(item, schema.elem, fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case kindMap, kindObject:
+		if node.Kind != yaml.MappingNode {
+			expected()
+			return
+		}
+		checkMapping(node, schema, path, errs)
+	}
+}
+
+// checkMapping checks the keys and values of a mapping node.
+func checkMapping(node *yaml.Node, schema *schemaNode, path string, errs *ValidationErrors) {
+	seen := make(map[string]bool)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keyPath := joinPath(path, key.Value)
+
+		if key.Value == "<<" {
+			// Merge keys bring in the keys of another mapping
+			checkNode(value, schema, path, errs)
+			continue
+		}
+		if seen[key.Value] {
+			*errs = append(*errs, ValidationError{Line: key.Line, Column: key.Column, Path: keyPath, Message: "duplicate key"})
+			continue
+		}
+		seen[key.Value] = true
+
+		if schema.kind == kindMap {
+			checkNode(value, schema.elem, keyPath, errs)
+			continue
+		}
+		field, ok := schema.fields[key.Value]
+		if !ok {
+			*errs = append(*errs, ValidationError{Line: key.Line, Column: key.Column, Path: keyPath, Message: unknownKeyMessage(key.Value, schema)})
+			continue
+		}
+		checkNode(value, field, keyPath, errs)
+	}
+}
+
+// unknownKeyMessage reports an unknown key with the keys allowed instead.
+func unknownKeyMessage(key string, schema *schemaNode) string {
+	keys := schema.keys()
+	if len(keys) == 0 {
+		return fmt.Sprintf("unknown key %q (no keys allowed here)", key)
+	}
+	return fmt.Sprintf("unknown key %q (allowed: %s)", key, strings.Join(keys, ", "))
+}
+
+// describeNode names the kind of value a node holds, for error messages.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.Tag {
+	case "!!bool":
+		return "boolean " + node.Value
+	case "!!int", "!!float":
+		return "number " + node.Value
+	}
+	return strconv.Quote(node.Value)
+}
+
+// decodeConfig decodes a well-formed config document over the defaults,
+// as the config manager would.
+func decodeConfig(root *yaml.Node) (Config, error) {
+	var raw map[string]interface{}
+	if err := root.Decode(&raw); err != nil {
+		return Config{}, err
+	}
+
+	k := koanf.New(".")
+	if err := k.Load(confmap.Provider(DefaultConfigAsMap(), "."), nil); err != nil {
+		return Config{}, err
+	}
+	if err := k.Load(confmap.Provider(raw, ""), nil); err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	if err := k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{Tag: "koanf"}); err != nil {
+		return Config{}, fmt.Errorf("decode config: %w", err)
+	}
+	return cfg, nil
+}
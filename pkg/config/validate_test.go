@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testModules = ModuleSchemas{
+	"tcp-port-discovery": {
+		"ports":       {Type: "[]string"},
+		"timeout":     {Type: "duration"},
+		"concurrency": {Type: "int"},
+	},
+}
+
+func validationErrors(t *testing.T, err error) ValidationErrors {
+	t.Helper()
+	var errs ValidationErrors
+	require.True(t, errors.As(err, &errs), "expected ValidationErrors, got %v", err)
+	return errs
+}
+
+func TestValidator_Validate_Valid(t *testing.T) {
+	data := []byte(`log:
+  level: debug
+server:
+  port: 9090
+  read_timeout: 30s
+  auth:
+    mode: none
+tracing:
+  enabled: true
+  sample_ratio: 0.5
+  headers:
+    Authorization: Bearer x
+notifications:
+  webhooks:
+    - url: https://hooks.example.com/vulntor
+      events: [scan.completed]
+      timeout: 5s
+modules:
+  tcp-port-discovery:
+    ports: "22,80"
+    timeout: 500ms
+    concurrency: 100
+`)
+
+	v := &Validator{Modules: testModules}
+	require.NoError(t, v.Validate(data))
+	require.NoError(t, v.Validate(nil), "an empty file is valid")
+}
+
+func TestValidator_Validate_Structure(t *testing.T) {
+	data := []byte(`log:
+  levle: debug
+server:
+  port: "8080"
+  read_timeout: 30
+  ui_enabled: maybe
+tracing:
+  sample_ratio: [1]
+notifications:
+  webhooks:
+    - url: https://a.example.com
+      timeout: soon
+      headers:
+        X-Team: [a]
+modules:
+  tcp-port-discovery:
+    concurrency: many
+    retries: 3
+  nmap: {}
+`)
+
+	err := (&Validator{Modules: testModules}).Validate(data)
+	errs := validationErrors(t, err)
+
+	require.Equal(t, ValidationErrors{
+		{Line: 2, Column: 3, Path: "log.levle", Message: `unknown key "levle" (allowed: file, format, level)`},
+		{Line: 4, Column: 9, Path: "server.port", Message: `expected an integer, got "8080"`},
+		{Line: 5, Column: 17, Path: "server.read_timeout", Message: "expected a duration (e.g. 10s, 1m30s), got number 30"},
+		{Line: 6, Column: 15, Path: "server.ui_enabled", Message: `expected a boolean, got "maybe"`},
+		{Line: 8, Column: 17, Path: "tracing.sample_ratio", Message: "expected a number, got a list"},
+		{Line: 12, Column: 16, Path: "notifications.webhooks[0].timeout", Message: `invalid duration "soon" (e.g. 10s, 1m30s)`},
+		{Line: 14, Column: 17, Path: "notifications.webhooks[0].headers.X-Team", Message: "expected a string, got a list"},
+		{Line: 17, Column: 18, Path: "modules.tcp-port-discovery.concurrency", Message: `expected an integer, got "many"`},
+		{Line: 18, Column: 5, Path: "modules.tcp-port-discovery.retries", Message: `unknown key "retries" (allowed: concurrency, ports, timeout)`},
+		{Line: 19, Column: 3, Path: "modules.nmap", Message: `unknown key "nmap" (allowed: tcp-port-discovery)`},
+	}, errs)
+	require.Contains(t, err.Error(), `2:3: log.levle: unknown key "levle"`)
+}
+
+func TestValidator_Validate_DuplicateKeysAndAnchors(t *testing.T) {
+	data := []byte(`notifications:
+  webhooks:
+    - &hook
+      url: https://a.example.com
+      timeout: 5s
+    - <<: *hook
+      url: https://b.example.com
+      retries: 3
+`)
+	errs := validationErrors(t, (&Validator{}).Validate(data))
+	require.Len(t, errs, 1)
+	require.Equal(t, "notifications.webhooks[1].retries", errs[0].Path)
+
+	data = []byte(`server:
+  port: 9090
+  port: 9091
+`)
+	errs = validationErrors(t, (&Validator{}).Validate(data))
+	require.Equal(t, ValidationErrors{{Line: 3, Column: 3, Path: "server.port", Message: "duplicate key"}}, errs)
+}
+
+func TestValidator_Validate_AnyModulesWithoutSchemas(t *testing.T) {
+	data := []byte("modules:\n  anything:\n    key: [1, 2]\n")
+	require.NoError(t, (&Validator{}).Validate(data))
+
+	data = []byte("modules: [a]\n")
+	errs := validationErrors(t, (&Validator{}).Validate(data))
+	require.Equal(t, "expected a mapping, got a list", errs[0].Message)
+}
+
+func TestValidator_Validate_SyntaxError(t *testing.T) {
+	data := []byte("log:\n  level: debug\nserver: x: y\n")
+
+	errs := validationErrors(t, (&Validator{}).Validate(data))
+	require.Equal(t, ValidationErrors{{Line: 3, Column: 1, Message: "mapping values are not allowed in this context"}}, errs)
+}
+
+func TestValidator_Validate_SectionChecks(t *testing.T) {
+	data := []byte(`log:
+  level: info
+server:
+  port: 70000
+  auth:
+    mode: none
+notifications:
+  webhooks:
+    - url: ftp://example.com
+proxy:
+  url: proxy.corp:3128
+`)
+
+	v := &Validator{Checks: map[string]SectionCheck{
+		"notifications": func(cfg Config) error {
+			if cfg.Notifications.Webhooks[0].URL == "ftp://example.com" {
+				return errors.New("invalid url")
+			}
+			return nil
+		},
+		// Sections missing from the file are not checked
+		"ticketing": func(Config) error { return errors.New("unexpected") },
+	}}
+
+	errs := validationErrors(t, v.Validate(data))
+	require.Equal(t, ValidationErrors{
+		{Line: 3, Column: 1, Path: "server", Message: "invalid port: 70000 (must be 1-65535)"},
+		{Line: 7, Column: 1, Path: "notifications", Message: "invalid url"},
+		{Line: 10, Column: 1, Path: "proxy", Message: `invalid proxy URL "proxy.corp:3128": scheme must be one of http, https, socks5, socks5h`},
+	}, errs)
+
+	// Defaults fill in settings missing from the file
+	require.NoError(t, (&Validator{}).Validate([]byte("server:\n  auth:\n    mode: none\n")))
+}
+
+func TestValidator_ValidateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vulntor.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("log:\n  level: warn\n"), 0o644))
+	require.NoError(t, (&Validator{}).ValidateFile(path))
+
+	err := (&Validator{}).ValidateFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "read config file")
+}
+
+func TestValidationError_Error(t *testing.T) {
+	require.Equal(t, "3:5: server.port: bad", ValidationError{Line: 3, Column: 5, Path: "server.port", Message: "bad"}.Error())
+	require.Equal(t, "bad", ValidationError{Message: "bad"}.Error())
+}
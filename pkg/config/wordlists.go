@@ -0,0 +1,26 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Validate validates the WordlistsConfig and returns an error if invalid.
+func (c *WordlistsConfig) Validate() error {
+	seen := make(map[string]bool, len(c.Sources))
+	for i, source := range c.Sources {
+		if source.Name == "" {
+			return fmt.Errorf("sources[%d]: name is required", i)
+		}
+		if seen[source.Name] {
+			return fmt.Errorf("sources[%d]: duplicate source name %q", i, source.Name)
+		}
+		seen[source.Name] = true
+
+		u, err := url.Parse(source.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("sources[%d]: url must be an http(s) URL, got %q", i, source.URL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordlistsConfig_Validate(t *testing.T) {
+	require.NoError(t, (&WordlistsConfig{}).Validate())
+
+	cfg := WordlistsConfig{Sources: []WordlistSourceConfig{
+		{Name: "official", URL: "https://wordlists.example.com/index.yaml"},
+		{Name: "internal", URL: "http://10.0.0.5/wordlists/index.yaml"},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Sources = append(cfg.Sources, WordlistSourceConfig{Name: "official", URL: "https://mirror.example.com/index.yaml"})
+	require.EqualError(t, cfg.Validate(), `sources[2]: duplicate source name "official"`)
+
+	cfg.Sources = []WordlistSourceConfig{{URL: "https://wordlists.example.com/index.yaml"}}
+	require.EqualError(t, cfg.Validate(), "sources[0]: name is required")
+
+	cfg.Sources = []WordlistSourceConfig{{Name: "local", URL: "/srv/wordlists/index.yaml"}}
+	require.EqualError(t, cfg.Validate(), `sources[0]: url must be an http(s) URL, got "/srv/wordlists/index.yaml"`)
+}
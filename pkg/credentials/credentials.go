@@ -0,0 +1,174 @@
+// Package credentials holds the logins authenticated checks use to inspect
+// targets from the inside, such as the SSH credentials of the credentialed
+// collection module and the SNMP communities and users of the SNMP walk.
+//
+// Secrets are given as references rather than inline values where
+// possible (see ResolveSecret), so config files can be shared without
+// the passwords they unlock.
+package credentials
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+// SSH is a login for SSH servers.
+type SSH struct {
+	// Name identifies the credential in logs and results; secrets never
+	// appear there.
+	Name     string
+	Username string
+	// Password is used for password and keyboard-interactive auth.
+	Password string
+	// PrivateKey is a PEM encoded private key, encrypted with Passphrase
+	// when set.
+	PrivateKey []byte
+	Passphrase string
+	// KnownHosts is a known_hosts file host keys are verified against.
+	// Empty accepts any host key.
+	KnownHosts string
+	// Targets limits the credential to these hosts, IP addresses and CIDR
+	// ranges. Empty matches every target.
+	Targets []string
+}
+
+// Matches reports whether the credential applies to host.
+func (c SSH) Matches(host string) bool {
+	return matchTargets(c.Targets, host)
+}
+
+// SNMP is a login for SNMP agents: a community string for SNMPv1 and
+// SNMPv2c, or a user for SNMPv3.
+type SNMP struct {
+	// Name identifies the credential in logs and results; secrets never
+	// appear there.
+	Name string
+	// Version is "1", "2c" or "3".
+	Version   string
+	Community string
+	// Username, AuthProtocol (MD5, SHA, SHA256, SHA512) and PrivProtocol
+	// (DES, AES) configure SNMPv3. Empty protocols disable auth or
+	// privacy.
+	Username     string
+	AuthProtocol string
+	AuthPassword string
+	PrivProtocol string
+	PrivPassword string
+	// Targets limits the credential to these hosts, IP addresses and CIDR
+	// ranges. Empty matches every target.
+	Targets []string
+}
+
+// Matches reports whether the credential applies to host.
+func (c SNMP) Matches(host string) bool {
+	return matchTargets(c.Targets, host)
+}
+
+func matchTargets(targets []string, host string) bool {
+	if len(targets) == 0 {
+		return true
+	}
+	addr, addrErr := netip.ParseAddr(host)
+	for _, target := range targets {
+		if strings.EqualFold(target, host) {
+			return true
+		}
+		if addrErr != nil {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(target); err == nil && prefix.Contains(addr.Unmap()) {
+			return true
+		}
+		if ip, err := netip.ParseAddr(target); err == nil && ip.Unmap() == addr.Unmap() {
+			return true
+		}
+	}
+	return false
+}
+
+// Logins lists the credentials of a scan run by protocol. Each list is
+// tried in order.
+type Logins struct {
+	SSH  []SSH
+	SNMP []SNMP
+}
+
+// Store holds the credentials of a scan run. A nil Store holds none.
+type Store struct {
+	logins Logins
+}
+
+// NewStore returns a store holding the given credentials.
+func NewStore(logins Logins) *Store {
+	return &Store{logins: logins}
+}
+
+// SSH returns the SSH credentials that apply to host, in order.
+func (s *Store) SSH(host string) []SSH {
+	if s == nil {
+		return nil
+	}
+	host = hostOnly(host)
+	var matched []SSH
+	for _, c := range s.logins.SSH {
+		if c.Matches(host) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// SNMP returns the SNMP credentials that apply to host, in order.
+func (s *Store) SNMP(host string) []SNMP {
+	if s == nil {
+		return nil
+	}
+	host = hostOnly(host)
+	var matched []SNMP
+	for _, c := range s.logins.SNMP {
+		if c.Matches(host) {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// hostOnly strips the port and IPv6 brackets from host.
+func hostOnly(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return strings.Trim(host, "[]")
+}
+
+// Empty reports whether the store holds no credentials.
+func (s *Store) Empty() bool {
+	return s == nil || (len(s.logins.SSH) == 0 && len(s.logins.SNMP) == 0)
+}
+
+// ResolveSecret returns the secret value ref refers to, such as env:NAME,
+// file:PATH or a secret manager reference (see package secrets). Any other
+// value is returned as is.
+func ResolveSecret(ref string) (string, error) {
+	return secrets.Resolve(context.Background(), ref)
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying s. A nil s leaves ctx unchanged.
+func WithContext(ctx context.Context, s *Store) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, s)
+}
+
+// FromContext returns the store carried by ctx, or nil.
+func FromContext(ctx context.Context) *Store {
+	s, _ := ctx.Value(contextKey{}).(*Store)
+	return s
+}
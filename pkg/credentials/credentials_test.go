@@ -0,0 +1,97 @@
+package credentials
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSH_Matches(t *testing.T) {
+	cred := SSH{Targets: []string{"10.0.0.0/8", "192.0.2.7", "bastion.corp"}}
+
+	require.True(t, cred.Matches("10.1.2.3"))
+	require.True(t, cred.Matches("192.0.2.7"))
+	require.True(t, cred.Matches("::ffff:192.0.2.7"))
+	require.True(t, cred.Matches("BASTION.corp"))
+	require.False(t, cred.Matches("192.0.2.8"))
+	require.False(t, cred.Matches("web.corp"))
+	require.True(t, SSH{}.Matches("198.51.100.1"))
+}
+
+func TestStore_SSH(t *testing.T) {
+	s := NewStore(Logins{SSH: []SSH{
+		{Name: "lab", Targets: []string{"10.0.0.0/8"}},
+		{Name: "default"},
+	}})
+
+	names := func(creds []SSH) []string {
+		var out []string
+		for _, c := range creds {
+			out = append(out, c.Name)
+		}
+		return out
+	}
+	require.Equal(t, []string{"lab", "default"}, names(s.SSH("10.0.0.5:22")))
+	require.Equal(t, []string{"default"}, names(s.SSH("[2001:db8::1]")))
+	require.Equal(t, []string{"default"}, names(s.SSH("[2001:db8::1]:22")))
+
+	var empty *Store
+	require.True(t, empty.Empty())
+	require.Nil(t, empty.SSH("10.0.0.5"))
+	require.False(t, s.Empty())
+	require.True(t, NewStore(Logins{}).Empty())
+}
+
+func TestStore_SNMP(t *testing.T) {
+	s := NewStore(Logins{SNMP: []SNMP{
+		{Name: "core", Version: "3", Targets: []string{"10.10.0.0/16"}},
+		{Name: "public", Version: "2c", Community: "public"},
+	}})
+
+	require.Len(t, s.SNMP("10.10.1.1:161"), 2)
+	require.Equal(t, "public", s.SNMP("192.0.2.1")[0].Name)
+	require.Empty(t, s.SSH("10.10.1.1"))
+	require.False(t, s.Empty())
+}
+
+func TestResolveSecret(t *testing.T) {
+	t.Setenv("VULNTOR_TEST_SSH_PASSWORD", "from-env")
+	file := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "inline", want: "inline"},
+		{ref: "env:VULNTOR_TEST_SSH_PASSWORD", want: "from-env"},
+		{ref: "file:" + file, want: "from-file"},
+		{ref: "env:VULNTOR_TEST_UNSET", wantErr: "VULNTOR_TEST_UNSET is not set"},
+		{ref: "file:" + file + ".missing", wantErr: "read secret file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ResolveSecret(tt.ref)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, FromContext(ctx))
+	require.Equal(t, ctx, WithContext(ctx, nil))
+
+	s := NewStore(Logins{})
+	require.Same(t, s, FromContext(WithContext(ctx, s)))
+}
@@ -0,0 +1,283 @@
+// Package dns implements DNS zone transfers (AXFR, RFC 5936), which the
+// standard library resolver does not support. A name server that hands
+// its zone to anyone discloses every host name in it.
+package dns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// DefaultTimeout bounds a whole transfer when Options.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// DefaultMaxRecords caps the records kept from a transfer when
+// Options.MaxRecords is zero.
+const DefaultMaxRecords = 10000
+
+// ErrRefused is returned when the server does not transfer the zone.
+var ErrRefused = errors.New("dns: zone transfer refused")
+
+// Options configure zone transfers.
+type Options struct {
+	// Timeout bounds the whole transfer; DefaultTimeout when zero.
+	Timeout time.Duration
+	// Dial opens TCP connections; a net.Dialer when nil.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+	// MaxRecords caps the records kept; DefaultMaxRecords when zero. The
+	// transfer stops once it is reached.
+	MaxRecords int
+}
+
+func (o Options) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (o Options) maxRecords() int {
+	if o.MaxRecords > 0 {
+		return o.MaxRecords
+	}
+	return DefaultMaxRecords
+}
+
+// Record is a resource record in presentation form.
+type Record struct {
+	Name  string `json:"name"` // Fully qualified, e.g. 'www.example.com.'
+	Type  string `json:"type"` // e.g. 'A', 'MX'
+	TTL   uint32 `json:"ttl"`
+	Value string `json:"value"` // Record data, e.g. '10 mail.example.com.'
+}
+
+// String returns the record in zone file form.
+func (r Record) String() string {
+	return fmt.Sprintf("%s %d IN %s %s", r.Name, r.TTL, r.Type, r.Value)
+}
+
+// Zone is the result of a transfer.
+type Zone struct {
+	Name    string
+	Records []Record
+	// Truncated is set when the transfer stopped at MaxRecords.
+	Truncated bool
+}
+
+// Transfer requests the zone from the name server at addr (host:port)
+// over TCP. It returns ErrRefused when the server answers with an error
+// code, or closes the connection, instead of sending the zone.
+func Transfer(ctx context.Context, addr, zone string, opts Options) (*Zone, error) {
+	name, err := dnsmessage.NewName(fqdn(zone))
+	if err != nil {
+		return nil, fmt.Errorf("dns: invalid zone %q: %w", zone, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+	var conn net.Conn
+	if opts.Dial != nil {
+		conn, err = opts.Dial(ctx, "tcp", addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	var idBytes [2]byte
+	_, _ = rand.Read(idBytes[:])
+	id := binary.BigEndian.Uint16(idBytes[:])
+	query, err := axfrQuery(id, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(query); err != nil {
+		return nil, ctxErr(ctx, err)
+	}
+
+	result := &Zone{Name: name.String()}
+	soas := 0
+	for {
+		msg, err := readMessage(conn)
+		if err != nil {
+			if errors.Is(err, io.EOF) && soas == 0 {
+				return nil, fmt.Errorf("%w: connection closed", ErrRefused)
+			}
+			return nil, ctxErr(ctx, err)
+		}
+
+		var p dnsmessage.Parser
+		header, err := p.Start(msg)
+		if err != nil {
+			return nil, fmt.Errorf("dns: invalid response: %w", err)
+		}
+		if header.ID != id || !header.Response {
+			return nil, errors.New("dns: response does not match the query")
+		}
+		if header.RCode != dnsmessage.RCodeSuccess {
+			return nil, fmt.Errorf("%w: %s", ErrRefused, rcodeName(header.RCode))
+		}
+		if err := p.SkipAllQuestions(); err != nil {
+			return nil, fmt.Errorf("dns: invalid response: %w", err)
+		}
+		answers, err := p.AllAnswers()
+		if err != nil {
+			return nil, fmt.Errorf("dns: invalid response: %w", err)
+		}
+		if soas == 0 && len(answers) == 0 {
+			return nil, fmt.Errorf("%w: empty answer", ErrRefused)
+		}
+
+		for _, rr := range answers {
+			if rr.Header.Type == dnsmessage.TypeSOA {
+				soas++
+				// The zone starts and ends with its SOA record
+				if soas == 2 {
+					return result, nil
+				}
+			} else if soas == 0 {
+				return nil, fmt.Errorf("%w: transfer does not start with SOA", ErrRefused)
+			}
+			if len(result.Records) >= opts.maxRecords() {
+				result.Truncated = true
+				return result, nil
+			}
+			result.Records = append(result.Records, recordFrom(rr))
+		}
+	}
+}
+
+// axfrQuery builds the length-prefixed AXFR query.
+func axfrQuery(id uint16, name dnsmessage.Name) ([]byte, error) {
+	b := dnsmessage.NewBuilder(make([]byte, 2, 512), dnsmessage.Header{ID: id})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: name, Type: dnsmessage.TypeAXFR, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	msg, err := b.Finish()
+	if err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint16(msg, uint16(len(msg)-2)) // #nosec G115 -- a single question is short
+	return msg, nil
+}
+
+// readMessage reads a length-prefixed message from a TCP stream.
+func readMessage(r io.Reader) ([]byte, error) {
+	var length [2]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint16(length[:]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// recordFrom converts a parsed resource to its presentation form.
+func recordFrom(rr dnsmessage.Resource) Record {
+	rec := Record{
+		Name: rr.Header.Name.String(),
+		Type: TypeName(rr.Header.Type),
+		TTL:  rr.Header.TTL,
+	}
+	switch body := rr.Body.(type) {
+	case *dnsmessage.AResource:
+		rec.Value = netip.AddrFrom4(body.A).String()
+	case *dnsmessage.AAAAResource:
+		rec.Value = netip.AddrFrom16(body.AAAA).String()
+	case *dnsmessage.NSResource:
+		rec.Value = body.NS.String()
+	case *dnsmessage.CNAMEResource:
+		rec.Value = body.CNAME.String()
+	case *dnsmessage.PTRResource:
+		rec.Value = body.PTR.String()
+	case *dnsmessage.MXResource:
+		rec.Value = fmt.Sprintf("%d %s", body.Pref, body.MX.String())
+	case *dnsmessage.SRVResource:
+		rec.Value = fmt.Sprintf("%d %d %d %s", body.Priority, body.Weight, body.Port, body.Target.String())
+	case *dnsmessage.SOAResource:
+		rec.Value = fmt.Sprintf("%s %s %d %d %d %d %d", body.NS.String(), body.MBox.String(),
+			body.Serial, body.Refresh, body.Retry, body.Expire, body.MinTTL)
+	case *dnsmessage.TXTResource:
+		quoted := make([]string, len(body.TXT))
+		for i, s := range body.TXT {
+			quoted[i] = strconv.Quote(s)
+		}
+		rec.Value = strings.Join(quoted, " ")
+	case *dnsmessage.SVCBResource:
+		rec.Value = svcbValue(body)
+	case *dnsmessage.HTTPSResource:
+		rec.Value = svcbValue(&body.SVCBResource)
+	case *dnsmessage.UnknownResource:
+		// RFC 3597 generic form
+		rec.Value = fmt.Sprintf(`\# %d %s`, len(body.Data), hex.EncodeToString(body.Data))
+	}
+	return rec
+}
+
+// svcbValue renders SVCB and HTTPS data with the generic parameter form
+// of RFC 9460, e.g. `1 . key1="\x02h2"`.
+func svcbValue(r *dnsmessage.SVCBResource) string {
+	parts := []string{strconv.Itoa(int(r.Priority)), r.Target.String()}
+	for _, p := range r.Params {
+		parts = append(parts, fmt.Sprintf("key%d=%s", uint16(p.Key), strconv.Quote(string(p.Value))))
+	}
+	return strings.Join(parts, " ")
+}
+
+// TypeName returns the mnemonic of a record type, e.g. "MX", or
+// "TYPE99" for types without one (RFC 3597).
+func TypeName(t dnsmessage.Type) string {
+	s := t.String()
+	if name, ok := strings.CutPrefix(s, "Type"); ok {
+		return name
+	}
+	return "TYPE" + strconv.Itoa(int(t))
+}
+
+func rcodeName(r dnsmessage.RCode) string {
+	s := r.String()
+	if name, ok := strings.CutPrefix(s, "RCode"); ok {
+		return name
+	}
+	return "rcode " + strconv.Itoa(int(r))
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// ctxErr prefers the context's error over the I/O error it caused.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
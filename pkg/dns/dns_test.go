@@ -0,0 +1,171 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// axfrServer answers one AXFR query per connection with respond, which
+// writes the responses to the query with the given ID.
+func axfrServer(t *testing.T, respond func(t *testing.T, conn net.Conn, id uint16, q dnsmessage.Question)) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				msg, err := readMessage(conn)
+				if err != nil {
+					return
+				}
+				var p dnsmessage.Parser
+				header, err := p.Start(msg)
+				if err != nil {
+					return
+				}
+				q, err := p.Question()
+				if err != nil {
+					return
+				}
+				respond(t, conn, header.ID, q)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// writeResponse writes a response carrying the answers added by add.
+func writeResponse(t *testing.T, conn net.Conn, id uint16, rcode dnsmessage.RCode, q dnsmessage.Question, add func(b *dnsmessage.Builder)) {
+	b := dnsmessage.NewBuilder(make([]byte, 2, 1024), dnsmessage.Header{ID: id, Response: true, Authoritative: true, RCode: rcode})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(q))
+	require.NoError(t, b.StartAnswers())
+	if add != nil {
+		add(&b)
+	}
+	msg, err := b.Finish()
+	require.NoError(t, err)
+	binary.BigEndian.PutUint16(msg, uint16(len(msg)-2))
+	_, _ = conn.Write(msg)
+}
+
+func rrHeader(name string, ttl uint32) dnsmessage.ResourceHeader {
+	return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(name), Class: dnsmessage.ClassINET, TTL: ttl}
+}
+
+func soa(b *dnsmessage.Builder) {
+	_ = b.SOAResource(rrHeader("example.com.", 3600), dnsmessage.SOAResource{
+		NS: dnsmessage.MustNewName("ns1.example.com."), MBox: dnsmessage.MustNewName("hostmaster.example.com."),
+		Serial: 2024010101, Refresh: 7200, Retry: 900, Expire: 1209600, MinTTL: 300,
+	})
+}
+
+func TestTransfer(t *testing.T) {
+	addr := axfrServer(t, func(t *testing.T, conn net.Conn, id uint16, q dnsmessage.Question) {
+		require.Equal(t, dnsmessage.TypeAXFR, q.Type)
+		require.Equal(t, "example.com.", q.Name.String())
+		// The zone spans two messages
+		writeResponse(t, conn, id, dnsmessage.RCodeSuccess, q, func(b *dnsmessage.Builder) {
+			soa(b)
+			_ = b.NSResource(rrHeader("example.com.", 3600), dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.com.")})
+			_ = b.MXResource(rrHeader("example.com.", 3600), dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")})
+			_ = b.TXTResource(rrHeader("example.com.", 300), dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}})
+		})
+		writeResponse(t, conn, id, dnsmessage.RCodeSuccess, q, func(b *dnsmessage.Builder) {
+			_ = b.AResource(rrHeader("vpn.example.com.", 60), dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}})
+			_ = b.AAAAResource(rrHeader("vpn.example.com.", 60), dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}})
+			_ = b.SRVResource(rrHeader("_sip._tcp.example.com.", 60), dnsmessage.SRVResource{Priority: 10, Weight: 5, Port: 5060, Target: dnsmessage.MustNewName("sip.example.com.")})
+			_ = b.HTTPSResource(rrHeader("example.com.", 60), dnsmessage.HTTPSResource{SVCBResource: dnsmessage.SVCBResource{
+				Priority: 1, Target: dnsmessage.MustNewName("."), Params: []dnsmessage.SVCParam{{Key: dnsmessage.SVCParamALPN, Value: []byte("\x02h2")}},
+			}})
+			_ = b.UnknownResource(rrHeader("example.com.", 60), dnsmessage.UnknownResource{Type: 99, Data: []byte{0, 1}})
+			soa(b)
+		})
+	})
+
+	zone, err := Transfer(context.Background(), addr, "example.com", Options{Timeout: 2 * time.Second})
+	require.NoError(t, err)
+	require.Equal(t, "example.com.", zone.Name)
+	require.False(t, zone.Truncated)
+	var lines []string
+	for _, rec := range zone.Records {
+		lines = append(lines, rec.String())
+	}
+	require.Equal(t, []string{
+		"example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 2024010101 7200 900 1209600 300",
+		"example.com. 3600 IN NS ns1.example.com.",
+		"example.com. 3600 IN MX 10 mail.example.com.",
+		`example.com. 300 IN TXT "v=spf1 -all"`,
+		"vpn.example.com. 60 IN A 192.0.2.10",
+		"vpn.example.com. 60 IN AAAA 2001:db8::1",
+		"_sip._tcp.example.com. 60 IN SRV 10 5 5060 sip.example.com.",
+		`example.com. 60 IN HTTPS 1 . key1="\x02h2"`,
+		`example.com. 60 IN TYPE99 \# 2 0001`,
+	}, lines)
+
+	// MaxRecords stops the transfer
+	zone, err = Transfer(context.Background(), addr, "example.com.", Options{Timeout: 2 * time.Second, MaxRecords: 3})
+	require.NoError(t, err)
+	require.True(t, zone.Truncated)
+	require.Len(t, zone.Records, 3)
+}
+
+func TestTransfer_Refused(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(t *testing.T, conn net.Conn, id uint16, q dnsmessage.Question)
+		want    string
+	}{
+		{
+			name: "refused",
+			respond: func(t *testing.T, conn net.Conn, id uint16, q dnsmessage.Question) {
+				writeResponse(t, conn, id, dnsmessage.RCodeRefused, q, nil)
+			},
+			want: "dns: zone transfer refused: Refused",
+		},
+		{
+			name: "not authoritative",
+			respond: func(t *testing.T, conn net.Conn, id uint16, q dnsmessage.Question) {
+				writeResponse(t, conn, id, dnsmessage.RCodeSuccess, q, nil)
+			},
+			want: "dns: zone transfer refused: empty answer",
+		},
+		{
+			name:    "closed",
+			respond: func(*testing.T, net.Conn, uint16, dnsmessage.Question) {},
+			want:    "dns: zone transfer refused: connection closed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := axfrServer(t, tt.respond)
+			_, err := Transfer(context.Background(), addr, "example.com", Options{Timeout: 2 * time.Second})
+			require.ErrorIs(t, err, ErrRefused)
+			require.EqualError(t, err, tt.want)
+		})
+	}
+
+	// A server that never answers times out
+	addr := axfrServer(t, func(*testing.T, net.Conn, uint16, dnsmessage.Question) { time.Sleep(time.Second) })
+	_, err := Transfer(context.Background(), addr, "example.com", Options{Timeout: 100 * time.Millisecond})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTypeName(t *testing.T) {
+	require.Equal(t, "MX", TypeName(dnsmessage.TypeMX))
+	require.Equal(t, "HTTPS", TypeName(dnsmessage.TypeHTTPS))
+	require.Equal(t, "TYPE99", TypeName(99))
+}
@@ -0,0 +1,353 @@
+// Package doctor diagnoses the environment scans run in: raw socket
+// capabilities, DNS resolution, connectivity to plugin sources, storage
+// health, cache directory permissions and clock skew. Every failed check
+// carries an actionable fix.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusOK means the check passed.
+	StatusOK Status = "ok"
+	// StatusWarn means scans work, but with reduced capabilities.
+	StatusWarn Status = "warn"
+	// StatusFail means a feature will not work until fixed.
+	StatusFail Status = "fail"
+	// StatusSkip means the check does not apply to this run.
+	StatusSkip Status = "skip"
+)
+
+const (
+	defaultTimeout = 5 * time.Second
+
+	// Clock skew beyond these limits breaks TLS certificate and signature
+	// validity checks.
+	maxClockSkewWarn = time.Minute
+	maxClockSkewFail = 5 * time.Minute
+)
+
+// Result is the outcome of one check.
+type Result struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// Options configures the checks. Zero values select the defaults.
+type Options struct {
+	// Storage is the storage configuration to check; nil skips the check,
+	// as with --no-storage.
+	Storage *storage.Config
+	// CacheDir is the plugin cache directory.
+	CacheDir string
+	// Sources are the plugin sources to reach; defaults to
+	// plugin.DefaultSources.
+	Sources []plugin.PluginSource
+	// Timeout bounds each network check.
+	Timeout time.Duration
+	// Proxy routes requests to the plugin sources, unless a source sets
+	// its own; nil uses the environment.
+	Proxy *netproxy.Proxy
+
+	// HTTPClient, Resolver, ListenRaw and Now are overridable for tests.
+	HTTPClient *http.Client
+	Resolver   *net.Resolver
+	ListenRaw  func() (net.PacketConn, error)
+	Now        func() time.Time
+}
+
+// Run runs every check and returns their results in a fixed order.
+func Run(ctx context.Context, opts Options) []Result {
+	opts = withDefaults(opts)
+
+	results := []Result{checkRawSockets(opts), checkDNS(ctx, opts)}
+	results = append(results, checkSources(ctx, opts)...)
+	results = append(results,
+		checkStorage(ctx, opts),
+		checkCacheDir(opts),
+		checkClock(ctx, opts),
+	)
+	return results
+}
+
+// Failed reports whether any result failed.
+func Failed(results []Result) bool {
+	for _, r := range results {
+		if r.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if opts.Sources == nil {
+		opts.Sources = plugin.DefaultSources()
+	}
+	if opts.HTTPClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = netproxy.HTTPProxy
+		opts.HTTPClient = &http.Client{Timeout: opts.Timeout, Transport: transport}
+	}
+	if opts.Resolver == nil {
+		opts.Resolver = net.DefaultResolver
+	}
+	if opts.ListenRaw == nil {
+		opts.ListenRaw = func() (net.PacketConn, error) {
+			return net.ListenPacket("ip4:icmp", "0.0.0.0")
+		}
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return opts
+}
+
+func checkRawSockets(opts Options) Result {
+	r := Result{Name: "raw sockets"}
+	conn, err := opts.ListenRaw()
+	if err != nil {
+		r.Status = StatusWarn
+		r.Message = fmt.Sprintf("raw ICMP sockets unavailable (%v); ICMP discovery falls back to unprivileged pings", err)
+		switch runtime.GOOS {
+		case "linux":
+			r.Fix = "Run as root or grant the capability: sudo setcap cap_net_raw+ep $(command -v vulntor)"
+		case "windows":
+			r.Fix = "Run vulntor from an administrator shell"
+		default:
+			r.Fix = "Run vulntor as root (sudo)"
+		}
+		return r
+	}
+	_ = conn.Close()
+	r.Status = StatusOK
+	r.Message = "raw ICMP sockets available"
+	return r
+}
+
+func checkDNS(ctx context.Context, opts Options) Result {
+	r := Result{Name: "dns"}
+	src, ok := firstSource(opts.Sources)
+	if !ok {
+		r.Status = StatusSkip
+		r.Message = "no plugin source host to resolve"
+		return r
+	}
+	host := hostOf(src.URL)
+	if proxyURL, _ := sourceProxyURL(opts, src); proxyURL != nil {
+		r.Status = StatusSkip
+		r.Message = fmt.Sprintf("%s is resolved by the proxy %s", host, proxyURL.Redacted())
+		return r
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	addrs, err := opts.Resolver.LookupHost(ctx, host)
+	if err != nil {
+		r.Status = StatusFail
+		r.Message = fmt.Sprintf("cannot resolve %s: %v", host, err)
+		r.Fix = "Check the DNS servers in /etc/resolv.conf (or the network settings) and that this host can reach them"
+		return r
+	}
+	r.Status = StatusOK
+	r.Message = fmt.Sprintf("%s resolves to %s", host, addrs[0])
+	return r
+}
+
+func checkSources(ctx context.Context, opts Options) []Result {
+	var results []Result
+	for _, src := range opts.Sources {
+		if !src.Enabled {
+			continue
+		}
+		r := Result{Name: "plugin source " + src.Name}
+		var errs []error
+		for _, u := range append([]string{src.URL}, src.Mirrors...) {
+			resp, err := fetch(ctx, opts, src, u)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode >= 400 {
+				errs = append(errs, fmt.Errorf("%s: HTTP %d", u, resp.StatusCode))
+				continue
+			}
+			r.Status = StatusOK
+			r.Message = fmt.Sprintf("%s reachable", u)
+			if len(errs) > 0 {
+				r.Status = StatusWarn
+				r.Message = fmt.Sprintf("%s reachable, but %v", u, errs[0])
+			}
+			break
+		}
+		if r.Status == "" {
+			r.Status = StatusFail
+			r.Message = fmt.Sprintf("unreachable: %v", errs[0])
+			r.Fix = "Allow outbound HTTPS to the plugin source, set proxy.url in the config (or HTTPS_PROXY) if a proxy is required, or use the embedded plugins offline"
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+func checkStorage(ctx context.Context, opts Options) Result {
+	r := Result{Name: "storage"}
+	if opts.Storage == nil {
+		r.Status = StatusSkip
+		r.Message = "storage disabled for this run"
+		return r
+	}
+
+	backend, err := storage.NewBackend(ctx, opts.Storage)
+	if err == nil {
+		defer func() { _ = backend.Close() }()
+		if err = backend.Initialize(ctx); err == nil {
+			_, err = backend.Scans().List(ctx, "default", storage.ScanFilter{Limit: 1})
+		}
+	}
+	if err != nil {
+		r.Status = StatusFail
+		r.Message = fmt.Sprintf("storage at %s unusable: %v", opts.Storage.WorkspaceRoot, err)
+		r.Fix = "Make the directory writable by this user or choose another with --storage-dir"
+		return r
+	}
+	r.Status = StatusOK
+	r.Message = fmt.Sprintf("storage at %s healthy", opts.Storage.WorkspaceRoot)
+	return r
+}
+
+func checkCacheDir(opts Options) Result {
+	r := Result{Name: "plugin cache"}
+	if opts.CacheDir == "" {
+		r.Status = StatusSkip
+		r.Message = "no plugin cache directory configured"
+		return r
+	}
+
+	err := os.MkdirAll(opts.CacheDir, 0o755)
+	if err == nil {
+		var probe *os.File
+		if probe, err = os.CreateTemp(opts.CacheDir, ".doctor-*"); err == nil {
+			_ = probe.Close()
+			err = os.Remove(probe.Name())
+		}
+	}
+	if err != nil {
+		r.Status = StatusFail
+		r.Message = fmt.Sprintf("%s not writable: %v", opts.CacheDir, err)
+		r.Fix = fmt.Sprintf("Fix the permissions: chown -R $(id -u) %s, or pass --cache-dir to plugin commands", filepath.Dir(opts.CacheDir))
+		return r
+	}
+	r.Status = StatusOK
+	r.Message = fmt.Sprintf("%s writable", opts.CacheDir)
+	return r
+}
+
+// checkClock compares the local clock to the Date header of the first
+// reachable plugin source.
+func checkClock(ctx context.Context, opts Options) Result {
+	r := Result{Name: "clock"}
+	for _, src := range opts.Sources {
+		if !src.Enabled {
+			continue
+		}
+		for _, u := range append([]string{src.URL}, src.Mirrors...) {
+			resp, err := fetch(ctx, opts, src, u)
+			if err != nil {
+				continue
+			}
+			_ = resp.Body.Close()
+			remote, err := http.ParseTime(resp.Header.Get("Date"))
+			if err != nil {
+				continue
+			}
+
+			skew := opts.Now().Sub(remote)
+			if skew < 0 {
+				skew = -skew
+			}
+			r.Message = fmt.Sprintf("local clock is %s off %s", skew.Round(time.Second), hostOf(u))
+			switch {
+			case skew > maxClockSkewFail:
+				r.Status = StatusFail
+			case skew > maxClockSkewWarn:
+				r.Status = StatusWarn
+			default:
+				r.Status = StatusOK
+				return r
+			}
+			r.Fix = "Synchronize the clock with NTP, e.g. sudo timedatectl set-ntp true; skew breaks TLS certificate and signature checks"
+			return r
+		}
+	}
+	r.Status = StatusSkip
+	r.Message = "no plugin source reachable to compare the clock with"
+	return r
+}
+
+// fetch sends a HEAD request to rawURL through the proxy of src.
+func fetch(ctx context.Context, opts Options, src plugin.PluginSource, rawURL string) (*http.Response, error) {
+	proxy, err := opts.Proxy.Override(src.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(netproxy.WithContext(ctx, proxy), opts.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return opts.HTTPClient.Do(req)
+}
+
+// sourceProxyURL returns the proxy requests to src go through, or nil.
+func sourceProxyURL(opts Options, src plugin.PluginSource) (*url.URL, error) {
+	proxy, err := opts.Proxy.Override(src.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return nil, err
+	}
+	return proxy.ProxyURL(u)
+}
+
+func firstSource(sources []plugin.PluginSource) (plugin.PluginSource, bool) {
+	for _, src := range sources {
+		if src.Enabled && hostOf(src.URL) != "" {
+			return src, true
+		}
+	}
+	return plugin.PluginSource{}, false
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
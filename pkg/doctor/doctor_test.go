@@ -0,0 +1,133 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func testOptions(t *testing.T, sourceURL string, now time.Time) Options {
+	t.Helper()
+	root := t.TempDir()
+	return Options{
+		Storage:  &storage.Config{WorkspaceRoot: filepath.Join(root, "workspace")},
+		CacheDir: filepath.Join(root, "plugins", "cache"),
+		Sources:  []plugin.PluginSource{{Name: "official", URL: sourceURL, Enabled: true}},
+		Timeout:  2 * time.Second,
+		ListenRaw: func() (net.PacketConn, error) {
+			return net.ListenPacket("udp", "127.0.0.1:0")
+		},
+		Now: func() time.Time { return now },
+	}
+}
+
+func resultsByName(results []Result) map[string]Result {
+	byName := make(map[string]Result, len(results))
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	return byName
+}
+
+func TestRun_Healthy(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second) // HTTP dates have second precision
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", now.Add(10*time.Second).Format(http.TimeFormat))
+	}))
+	defer srv.Close()
+
+	results := Run(context.Background(), testOptions(t, srv.URL+"/manifest.yaml", now))
+	require.False(t, Failed(results))
+
+	byName := resultsByName(results)
+	require.Len(t, byName, 6)
+	for _, name := range []string{"raw sockets", "dns", "plugin source official", "storage", "plugin cache", "clock"} {
+		require.Equal(t, StatusOK, byName[name].Status, "%s: %s", name, byName[name].Message)
+	}
+	require.Contains(t, byName["dns"].Message, "127.0.0.1 resolves to")
+}
+
+func TestRun_Failures(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second) // HTTP dates have second precision
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", now.Add(-10*time.Minute).Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	opts := testOptions(t, srv.URL+"/manifest.yaml", now)
+	opts.Sources = append(opts.Sources, plugin.PluginSource{Name: "mirror", URL: "http://127.0.0.1:1/manifest.yaml", Enabled: true})
+	opts.ListenRaw = func() (net.PacketConn, error) { return nil, errors.New("operation not permitted") }
+
+	// A file where the cache directory should be fails even for root
+	notDir := filepath.Join(t.TempDir(), "plugins")
+	require.NoError(t, os.WriteFile(notDir, nil, 0o644))
+	opts.CacheDir = filepath.Join(notDir, "cache")
+
+	results := Run(context.Background(), opts)
+	require.True(t, Failed(results))
+
+	byName := resultsByName(results)
+	require.Equal(t, StatusWarn, byName["raw sockets"].Status)
+	require.NotEmpty(t, byName["raw sockets"].Fix)
+	require.Equal(t, StatusOK, byName["plugin source official"].Status)
+	require.Equal(t, StatusFail, byName["plugin source mirror"].Status)
+	require.Contains(t, byName["plugin source mirror"].Fix, "HTTPS_PROXY")
+	require.Equal(t, StatusFail, byName["plugin cache"].Status)
+	require.Contains(t, byName["plugin cache"].Fix, "--cache-dir")
+	require.Equal(t, StatusFail, byName["clock"].Status)
+	require.Contains(t, byName["clock"].Message, "10m0s off 127.0.0.1")
+	require.Contains(t, byName["clock"].Fix, "NTP")
+}
+
+func TestRun_Proxy(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second) // HTTP dates have second precision
+	var requested []string
+	proxySrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.String())
+		w.Header().Set("Date", now.Format(http.TimeFormat))
+	}))
+	defer proxySrv.Close()
+
+	proxy, err := netproxy.New(proxySrv.URL, nil)
+	require.NoError(t, err)
+	opts := testOptions(t, "http://plugins.corp.test/manifest.yaml", now)
+	opts.Proxy = proxy
+
+	byName := resultsByName(Run(context.Background(), opts))
+	require.Equal(t, StatusSkip, byName["dns"].Status)
+	require.Equal(t, "plugins.corp.test is resolved by the proxy "+proxySrv.URL, byName["dns"].Message)
+	require.Equal(t, StatusOK, byName["plugin source official"].Status)
+	require.Equal(t, StatusOK, byName["clock"].Status)
+	require.Contains(t, requested, "http://plugins.corp.test/manifest.yaml")
+
+	// Sources may bypass the proxy
+	opts.Sources[0].Proxy = netproxy.Direct
+	byName = resultsByName(Run(context.Background(), opts))
+	require.NotEqual(t, StatusSkip, byName["dns"].Status)
+}
+
+func TestRun_SkipsDisabledChecks(t *testing.T) {
+	opts := testOptions(t, "", time.Now())
+	opts.Sources = []plugin.PluginSource{}
+	opts.Storage = nil
+	opts.CacheDir = ""
+
+	byName := resultsByName(Run(context.Background(), opts))
+	require.Equal(t, StatusSkip, byName["dns"].Status)
+	require.Equal(t, StatusSkip, byName["storage"].Status)
+	require.Equal(t, StatusSkip, byName["plugin cache"].Status)
+	require.Equal(t, StatusSkip, byName["clock"].Status)
+}
@@ -0,0 +1,96 @@
+package egress
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// DecoyPinger sends ICMP echo requests with the decoy addresses of a
+// binding as their source, so that the hosts pinged during discovery see
+// the scanner's own requests among others. Replies go to the decoys and
+// are not awaited. It needs a raw IPv4 socket, i.e. root or CAP_NET_RAW,
+// and is safe for concurrent use.
+type DecoyPinger struct {
+	decoys []netip.Addr
+	id     int
+
+	mu   sync.Mutex
+	conn net.PacketConn
+	raw  *ipv4.RawConn
+	seq  int
+}
+
+// DecoyPinger opens a raw socket sending the decoy echo requests of b. It
+// returns nil when b has no decoys.
+func (b *Binding) DecoyPinger() (*DecoyPinger, error) {
+	if len(b.Decoys()) == 0 {
+		return nil, nil
+	}
+	conn, err := net.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return nil, fmt.Errorf("open raw socket for decoys: %w", err)
+	}
+	raw, err := ipv4.NewRawConn(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("open raw socket for decoys: %w", err)
+	}
+	return &DecoyPinger{decoys: b.decoys, id: os.Getpid() & 0xffff, conn: conn, raw: raw}, nil
+}
+
+// Ping sends an echo request to target from each decoy. Only IPv4 targets
+// are pinged.
+func (p *DecoyPinger) Ping(target netip.Addr) error {
+	if p == nil || !target.Unmap().Is4() {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, decoy := range p.decoys {
+		p.seq++
+		header, payload, err := decoyEcho(decoy, target.Unmap(), p.id, p.seq)
+		if err != nil {
+			return err
+		}
+		if err := p.raw.WriteTo(header, payload, nil); err != nil {
+			return fmt.Errorf("send decoy echo request from %s: %w", decoy, err)
+		}
+	}
+	return nil
+}
+
+// Close closes the raw socket.
+func (p *DecoyPinger) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// decoyEcho builds an ICMP echo request from src to dst.
+func decoyEcho(src, dst netip.Addr, id, seq int) (*ipv4.Header, []byte, error) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{ID: id, Seq: seq & 0xffff, Data: make([]byte, 24)},
+	}
+	payload, err := msg.Marshal(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	header := &ipv4.Header{
+		Version:  ipv4.Version,
+		Len:      ipv4.HeaderLen,
+		TotalLen: ipv4.HeaderLen + len(payload),
+		TTL:      64,
+		Protocol: 1, // ICMP
+		Src:      src.AsSlice(),
+		Dst:      dst.AsSlice(),
+	}
+	return header, payload, nil
+}
@@ -0,0 +1,35 @@
+//go:build linux
+
+package egress
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// bindToDevice returns a dialer control function binding sockets to the
+// interface iface with SO_BINDTODEVICE, after running control. Without the
+// privileges to do so, the source address alone selects the interface.
+func bindToDevice(iface string, control func(network, address string, c syscall.RawConn) error) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		if control != nil {
+			if err := control(network, address, c); err != nil {
+				return err
+			}
+		}
+		var sockErr error
+		if err := c.Control(func(fd uintptr) {
+			sockErr = syscall.SetsockoptString(int(fd), syscall.SOL_SOCKET, syscall.SO_BINDTODEVICE, iface)
+		}); err != nil {
+			return err
+		}
+		if errors.Is(sockErr, syscall.EPERM) {
+			return nil
+		}
+		if sockErr != nil {
+			return os.NewSyscallError("setsockopt", sockErr)
+		}
+		return nil
+	}
+}
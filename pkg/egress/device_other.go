@@ -0,0 +1,11 @@
+//go:build !linux
+
+package egress
+
+import "syscall"
+
+// bindToDevice returns control: the platform cannot bind sockets to an
+// interface, so the source address alone selects it.
+func bindToDevice(_ string, control func(network, address string, c syscall.RawConn) error) func(network, address string, c syscall.RawConn) error {
+	return control
+}
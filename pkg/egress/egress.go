@@ -0,0 +1,231 @@
+// Package egress controls the source of scan traffic. Scanner hosts often
+// have several interfaces, e.g. a management and a scanning network, and
+// blue teams attribute scans by the source address they see. A Binding
+// makes probes leave by a given network interface, from a given source IP
+// address, and can send decoy host discovery probes from other addresses.
+//
+// A Binding is carried in the scan context (see WithContext), the same way
+// fdbudget and capture are: connections dialed through netproxy are bound
+// automatically, other dialers opt in with Dial. A nil *Binding leaves
+// connections unbound.
+package egress
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Binding binds connections to an interface and source address.
+type Binding struct {
+	iface  string
+	v4, v6 netip.Addr // source addresses, per address family
+	decoys []netip.Addr
+}
+
+// New returns a binding of connections to the network interface iface and
+// the source address sourceIP, and sending decoy probes from decoys. Each
+// may be empty. The source address must be assigned to the interface, or
+// to this host when no interface is given; with an interface alone,
+// connections use its first address of the target's family. New returns
+// nil when nothing is set.
+func New(iface, sourceIP string, decoys []string) (*Binding, error) {
+	if iface == "" && sourceIP == "" && len(decoys) == 0 {
+		return nil, nil
+	}
+
+	b := &Binding{iface: iface}
+	var local []netip.Addr
+	if iface != "" {
+		ifi, err := net.InterfaceByName(iface)
+		if err != nil {
+			return nil, fmt.Errorf("interface %q: %w", iface, err)
+		}
+		addrs, err := ifi.Addrs()
+		if err != nil {
+			return nil, fmt.Errorf("interface %q: %w", iface, err)
+		}
+		local = prefixAddrs(addrs)
+		for _, addr := range local {
+			switch {
+			case addr.Is4() && !b.v4.IsValid():
+				b.v4 = addr
+			case addr.Is6() && !addr.IsLinkLocalUnicast() && !b.v6.IsValid():
+				b.v6 = addr
+			}
+		}
+		if !b.v4.IsValid() && !b.v6.IsValid() {
+			return nil, fmt.Errorf("interface %q has no usable IP address", iface)
+		}
+	}
+
+	if sourceIP != "" {
+		source, err := netip.ParseAddr(sourceIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source IP %q", sourceIP)
+		}
+		source = source.Unmap()
+		if iface == "" {
+			addrs, err := net.InterfaceAddrs()
+			if err != nil {
+				return nil, fmt.Errorf("list local addresses: %w", err)
+			}
+			local = prefixAddrs(addrs)
+		}
+		if !contains(local, source) {
+			if iface != "" {
+				return nil, fmt.Errorf("source IP %s is not assigned to interface %q", source, iface)
+			}
+			return nil, fmt.Errorf("source IP %s is not assigned to this host", source)
+		}
+		if source.Is4() {
+			b.v4 = source
+		} else {
+			b.v6 = source
+		}
+	}
+
+	for _, decoy := range decoys {
+		addr, err := netip.ParseAddr(strings.TrimSpace(decoy))
+		if err != nil || !addr.Unmap().Is4() {
+			return nil, fmt.Errorf("invalid decoy %q (decoys must be IPv4 addresses)", decoy)
+		}
+		b.decoys = append(b.decoys, addr.Unmap())
+	}
+	return b, nil
+}
+
+// prefixAddrs returns the IP addresses of interface addresses.
+func prefixAddrs(addrs []net.Addr) []netip.Addr {
+	var out []netip.Addr
+	for _, addr := range addrs {
+		if prefix, err := netip.ParsePrefix(addr.String()); err == nil {
+			out = append(out, prefix.Addr().Unmap())
+		}
+	}
+	return out
+}
+
+func contains(addrs []netip.Addr, addr netip.Addr) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Interface returns the name of the interface connections are bound to.
+func (b *Binding) Interface() string {
+	if b == nil {
+		return ""
+	}
+	return b.iface
+}
+
+// Source returns the source address of connections to target, or the zero
+// Addr when they are not bound to one.
+func (b *Binding) Source(target netip.Addr) netip.Addr {
+	if b == nil {
+		return netip.Addr{}
+	}
+	if target.Unmap().Is4() {
+		return b.v4
+	}
+	return b.v6
+}
+
+// Decoys returns the addresses decoy probes are sent from.
+func (b *Binding) Decoys() []netip.Addr {
+	if b == nil {
+		return nil
+	}
+	return b.decoys
+}
+
+// Dialer returns a copy of d binding connections to addr on network to
+// the interface and source address of b. Host names are reached over IPv4
+// when b has an IPv4 source address. d is returned unchanged by a nil
+// binding, and for networks other than IP, TCP and UDP.
+func (b *Binding) Dialer(d *net.Dialer, network, addr string) (*net.Dialer, error) {
+	if d == nil {
+		d = &net.Dialer{}
+	}
+	if b == nil {
+		return d, nil
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	var source netip.Addr
+	if ip, err := netip.ParseAddr(host); err == nil {
+		source = b.Source(ip)
+		if !source.IsValid() && (b.v4.IsValid() || b.v6.IsValid()) {
+			family := "IPv4"
+			if !ip.Unmap().Is4() {
+				family = "IPv6"
+			}
+			return nil, fmt.Errorf("no %s source address to reach %s", family, host)
+		}
+	} else if b.v4.IsValid() {
+		source = b.v4
+	} else {
+		source = b.v6
+	}
+
+	bound := *d
+	if source.IsValid() {
+		ip := net.IP(source.AsSlice())
+		switch network {
+		case "tcp", "tcp4", "tcp6":
+			bound.LocalAddr = &net.TCPAddr{IP: ip}
+		case "udp", "udp4", "udp6":
+			bound.LocalAddr = &net.UDPAddr{IP: ip}
+		case "ip", "ip4", "ip6":
+			bound.LocalAddr = &net.IPAddr{IP: ip}
+		default:
+			return d, nil
+		}
+	}
+	if b.iface != "" {
+		bound.Control = bindToDevice(b.iface, d.Control)
+	}
+	return &bound, nil
+}
+
+// DialContext connects to addr on network with d, bound by b.
+func (b *Binding) DialContext(ctx context.Context, d *net.Dialer, network, addr string) (net.Conn, error) {
+	bound, err := b.Dialer(d, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return bound.DialContext(ctx, network, addr)
+}
+
+// Dial returns a dial function binding each connection of d with the
+// binding of its context, e.g. for http.Transport.DialContext.
+func Dial(d *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return FromContext(ctx).DialContext(ctx, d, network, addr)
+	}
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying b. A nil b leaves ctx unchanged.
+func WithContext(ctx context.Context, b *Binding) context.Context {
+	if b == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, b)
+}
+
+// FromContext returns the binding carried by ctx, or nil.
+func FromContext(ctx context.Context) *Binding {
+	b, _ := ctx.Value(contextKey{}).(*Binding)
+	return b
+}
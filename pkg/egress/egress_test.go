@@ -0,0 +1,136 @@
+package egress
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// loopbackInterface returns the name of the loopback interface.
+func loopbackInterface(t *testing.T) string {
+	t.Helper()
+	ifaces, err := net.Interfaces()
+	require.NoError(t, err)
+	for _, ifi := range ifaces {
+		if ifi.Flags&net.FlagLoopback != 0 {
+			return ifi.Name
+		}
+	}
+	t.Skip("no loopback interface")
+	return ""
+}
+
+func TestNew(t *testing.T) {
+	b, err := New("", "", nil)
+	require.NoError(t, err)
+	require.Nil(t, b)
+
+	lo := loopbackInterface(t)
+	b, err = New(lo, "", nil)
+	require.NoError(t, err)
+	require.Equal(t, lo, b.Interface())
+	require.Equal(t, netip.MustParseAddr("127.0.0.1"), b.Source(netip.MustParseAddr("10.0.0.5")))
+
+	b, err = New("", "127.0.0.1", []string{"192.0.2.10", " 192.0.2.11"})
+	require.NoError(t, err)
+	require.Equal(t, []netip.Addr{netip.MustParseAddr("192.0.2.10"), netip.MustParseAddr("192.0.2.11")}, b.Decoys())
+
+	tests := []struct {
+		name          string
+		iface, source string
+		decoys        []string
+		wantErr       string
+	}{
+		{name: "unknown interface", iface: "vulntor-missing0", wantErr: `interface "vulntor-missing0"`},
+		{name: "invalid source", source: "10.0.0", wantErr: `invalid source IP "10.0.0"`},
+		{name: "foreign source", source: "192.0.2.1", wantErr: "source IP 192.0.2.1 is not assigned to this host"},
+		{name: "source of other interface", iface: lo, source: "192.0.2.1", wantErr: "is not assigned to interface"},
+		{name: "ipv6 decoy", decoys: []string{"2001:db8::1"}, wantErr: "decoys must be IPv4 addresses"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.iface, tt.source, tt.decoys)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestBinding_Dialer(t *testing.T) {
+	b, err := New("", "127.0.0.1", nil)
+	require.NoError(t, err)
+
+	d, err := b.Dialer(nil, "tcp", "10.0.0.5:80")
+	require.NoError(t, err)
+	require.Equal(t, &net.TCPAddr{IP: net.IP(netip.MustParseAddr("127.0.0.1").AsSlice())}, d.LocalAddr)
+	d, err = b.Dialer(nil, "udp", "scanme.example:53")
+	require.NoError(t, err)
+	require.IsType(t, &net.UDPAddr{}, d.LocalAddr)
+
+	_, err = b.Dialer(nil, "tcp", "[2001:db8::1]:80")
+	require.ErrorContains(t, err, "no IPv6 source address to reach 2001:db8::1")
+
+	// The dialer passed in is left unchanged
+	orig := &net.Dialer{}
+	_, err = b.Dialer(orig, "tcp", "10.0.0.5:80")
+	require.NoError(t, err)
+	require.Nil(t, orig.LocalAddr)
+
+	var nilBinding *Binding
+	d, err = nilBinding.Dialer(orig, "tcp", "10.0.0.5:80")
+	require.NoError(t, err)
+	require.Same(t, orig, d)
+}
+
+func TestDial_BindsFromContext(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	b, err := New(loopbackInterface(t), "127.0.0.1", nil)
+	require.NoError(t, err)
+	ctx := WithContext(context.Background(), b)
+	require.Same(t, b, FromContext(ctx))
+
+	conn, err := Dial(&net.Dialer{})(ctx, "tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Equal(t, "127.0.0.1", conn.LocalAddr().(*net.TCPAddr).IP.String())
+}
+
+func TestDecoyEcho(t *testing.T) {
+	src, dst := netip.MustParseAddr("192.0.2.10"), netip.MustParseAddr("10.0.0.5")
+	header, payload, err := decoyEcho(src, dst, 42, 7)
+	require.NoError(t, err)
+	require.Equal(t, "192.0.2.10", header.Src.String())
+	require.Equal(t, "10.0.0.5", header.Dst.String())
+	require.Equal(t, ipv4.HeaderLen+len(payload), header.TotalLen)
+
+	msg, err := icmp.ParseMessage(1, payload)
+	require.NoError(t, err)
+	require.Equal(t, ipv4.ICMPTypeEcho, msg.Type)
+	echo := msg.Body.(*icmp.Echo)
+	require.Equal(t, 42, echo.ID)
+	require.Equal(t, 7, echo.Seq)
+
+	// Without decoys, there is nothing to send
+	b, err := New("", "127.0.0.1", nil)
+	require.NoError(t, err)
+	pinger, err := b.DecoyPinger()
+	require.NoError(t, err)
+	require.Nil(t, pinger)
+	require.NoError(t, pinger.Ping(dst))
+}
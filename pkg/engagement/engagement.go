@@ -0,0 +1,174 @@
+// Package engagement records the authorization a scan is run under: the
+// client it is run for, the reference of the authorization (e.g. the
+// signed engagement letter), the tester running it and the window of time
+// testing is authorized in. The record is stored with the scan and
+// embedded into its reports, so that every result can be traced back to
+// its authorization.
+//
+// In assessment mode (see Policy) scans without a complete record are
+// refused, and scans outside the record's window are always refused.
+package engagement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/timeutil"
+)
+
+var (
+	// ErrRequired is matched by the errors of scans missing engagement
+	// details in assessment mode.
+	ErrRequired = errors.New("engagement details are required in assessment mode")
+
+	// ErrOutsideWindow is matched by the errors of scans outside their
+	// engagement window.
+	ErrOutsideWindow = errors.New("scan is outside the engagement window")
+)
+
+// Record is the engagement a scan is run under.
+type Record struct {
+	// Client is who the scan is run for.
+	Client string `json:"client,omitempty"`
+
+	// Authorization references the authorization to test, e.g. a contract
+	// or engagement letter number.
+	Authorization string `json:"authorization,omitempty"`
+
+	// Tester is who runs the scan.
+	Tester string `json:"tester,omitempty"`
+
+	// WindowStart and WindowEnd bound the time testing is authorized in;
+	// the end is excluded. Zero = unbounded.
+	WindowStart time.Time `json:"window_start,omitzero"`
+	WindowEnd   time.Time `json:"window_end,omitzero"`
+}
+
+// IsZero reports whether r records nothing.
+func (r *Record) IsZero() bool {
+	return r == nil || *r == Record{}
+}
+
+// Window formats the window of r as FROM/UNTIL in RFC 3339, empty when r
+// has none.
+func (r *Record) Window() string {
+	if r == nil || (r.WindowStart.IsZero() && r.WindowEnd.IsZero()) {
+		return ""
+	}
+	format := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return format(r.WindowStart) + "/" + format(r.WindowEnd)
+}
+
+// Missing returns the names of the fields r leaves empty.
+func (r *Record) Missing() []string {
+	if r == nil {
+		r = &Record{}
+	}
+	var missing []string
+	for _, f := range []struct {
+		name, value string
+	}{
+		{"client", r.Client},
+		{"authorization", r.Authorization},
+		{"tester", r.Tester},
+		{"window", r.Window()},
+	} {
+		if strings.TrimSpace(f.value) == "" {
+			missing = append(missing, f.name)
+		}
+	}
+	return missing
+}
+
+// Within returns an error matching ErrOutsideWindow when t lies outside the
+// window of r.
+func (r *Record) Within(t time.Time) error {
+	if r == nil {
+		return nil
+	}
+	if !r.WindowStart.IsZero() && t.Before(r.WindowStart) {
+		return fmt.Errorf("%w: it starts at %s", ErrOutsideWindow, r.WindowStart.UTC().Format(time.RFC3339))
+	}
+	if !r.WindowEnd.IsZero() && !t.Before(r.WindowEnd) {
+		return fmt.Errorf("%w: it ended at %s", ErrOutsideWindow, r.WindowEnd.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// ParseWindow parses a window spec FROM/UNTIL, e.g.
+// "2026-12-01/2026-12-14" or "2026-12-01T08:00:00Z/2026-12-01T18:00:00Z".
+// FROM and UNTIL are RFC 3339 times or dates; dates start at midnight in
+// the local time zone, and an UNTIL date includes the whole day.
+func ParseWindow(spec string) (start, end time.Time, err error) {
+	spec = strings.TrimSpace(spec)
+	from, until, ok := strings.Cut(spec, "/")
+	if !ok {
+		return start, end, fmt.Errorf("engagement window %q: want FROM/UNTIL", spec)
+	}
+	if start, _, err = timeutil.ParseTime(from); err != nil {
+		return start, end, fmt.Errorf("engagement window %q: %w", spec, err)
+	}
+	var date bool
+	if end, date, err = timeutil.ParseTime(until); err != nil {
+		return start, end, fmt.Errorf("engagement window %q: %w", spec, err)
+	}
+	if date {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		return start, end, fmt.Errorf("engagement window %q ends before it starts", spec)
+	}
+	return start.UTC(), end.UTC(), nil
+}
+
+// Policy tells what engagement records scans need.
+type Policy struct {
+	// AssessmentMode refuses scans without a complete record.
+	AssessmentMode bool
+
+	// Defaults fill the fields the records of scans leave empty.
+	Defaults Record
+}
+
+// Resolve returns r with the fields it leaves empty taken from the
+// policy's defaults, nil when the result records nothing.
+func (p Policy) Resolve(r *Record) *Record {
+	var resolved Record
+	if r != nil {
+		resolved = *r
+	}
+	if resolved.Client == "" {
+		resolved.Client = p.Defaults.Client
+	}
+	if resolved.Authorization == "" {
+		resolved.Authorization = p.Defaults.Authorization
+	}
+	if resolved.Tester == "" {
+		resolved.Tester = p.Defaults.Tester
+	}
+	if resolved.WindowStart.IsZero() && resolved.WindowEnd.IsZero() {
+		resolved.WindowStart, resolved.WindowEnd = p.Defaults.WindowStart, p.Defaults.WindowEnd
+	}
+	if resolved.IsZero() {
+		return nil
+	}
+	return &resolved
+}
+
+// Check refuses a scan under r at now: in assessment mode when r is
+// incomplete, and always when now is outside the window of r.
+func (p Policy) Check(r *Record, now time.Time) error {
+	if p.AssessmentMode {
+		if missing := r.Missing(); len(missing) > 0 {
+			return fmt.Errorf("%w: missing %s", ErrRequired, strings.Join(missing, ", "))
+		}
+	}
+	return r.Within(now)
+}
@@ -0,0 +1,69 @@
+package engagement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	start, end, err := ParseWindow("2026-12-01T08:00:00Z/2026-12-01T18:00:00+02:00")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 12, 1, 8, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2026, 12, 1, 16, 0, 0, 0, time.UTC), end)
+
+	// An UNTIL date includes the whole day
+	start, end, err = ParseWindow("2026-12-01/2026-12-14")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local).UTC(), start)
+	require.Equal(t, time.Date(2026, 12, 15, 0, 0, 0, 0, time.Local).UTC(), end)
+
+	_, _, err = ParseWindow("2026-12-01")
+	require.ErrorContains(t, err, "want FROM/UNTIL")
+	_, _, err = ParseWindow("2026-12-01/soon")
+	require.ErrorContains(t, err, `invalid time "soon"`)
+	_, _, err = ParseWindow("2026-12-14/2026-12-01")
+	require.ErrorContains(t, err, "ends before it starts")
+}
+
+func TestPolicy_Check(t *testing.T) {
+	start, end, err := ParseWindow("2026-12-01T00:00:00Z/2026-12-15T00:00:00Z")
+	require.NoError(t, err)
+	complete := &Record{Client: "ACME", Authorization: "SOW-42", Tester: "alice", WindowStart: start, WindowEnd: end}
+	during := time.Date(2026, 12, 5, 12, 0, 0, 0, time.UTC)
+
+	assessment := Policy{AssessmentMode: true}
+	require.NoError(t, assessment.Check(complete, during))
+
+	err = assessment.Check(&Record{Client: "ACME"}, during)
+	require.ErrorIs(t, err, ErrRequired)
+	require.ErrorContains(t, err, "missing authorization, tester, window")
+	require.ErrorIs(t, assessment.Check(nil, during), ErrRequired)
+
+	err = assessment.Check(complete, start.Add(-time.Minute))
+	require.ErrorIs(t, err, ErrOutsideWindow)
+	require.ErrorContains(t, err, "it starts at 2026-12-01T00:00:00Z")
+	err = assessment.Check(complete, end)
+	require.ErrorContains(t, err, "it ended at 2026-12-15T00:00:00Z")
+
+	// Outside assessment mode records are optional, but windows still apply
+	var open Policy
+	require.NoError(t, open.Check(nil, during))
+	require.NoError(t, open.Check(&Record{Client: "ACME"}, during))
+	require.ErrorIs(t, open.Check(complete, end.Add(time.Hour)), ErrOutsideWindow)
+}
+
+func TestPolicy_Resolve(t *testing.T) {
+	start, end, err := ParseWindow("2026-12-01/2026-12-14")
+	require.NoError(t, err)
+	p := Policy{Defaults: Record{Client: "ACME", Tester: "alice", WindowStart: start, WindowEnd: end}}
+
+	r := p.Resolve(&Record{Authorization: "SOW-42", Tester: "bob"})
+	require.Equal(t, &Record{Client: "ACME", Authorization: "SOW-42", Tester: "bob", WindowStart: start, WindowEnd: end}, r)
+	require.Empty(t, r.Missing())
+
+	require.Nil(t, Policy{}.Resolve(nil))
+	require.Nil(t, Policy{}.Resolve(&Record{}))
+	require.Equal(t, "ACME", p.Resolve(nil).Client)
+}
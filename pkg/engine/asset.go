@@ -13,6 +13,7 @@ type ProbeObservation struct {
 	Description string          `json:"description,omitempty" yaml:"description,omitempty"`
 	Protocol    string          `json:"protocol,omitempty" yaml:"protocol,omitempty"`
 	IsTLS       bool            `json:"is_tls,omitempty" yaml:"is_tls,omitempty"`
+	StartTLS    bool            `json:"starttls,omitempty" yaml:"starttls,omitempty"` // TLS was negotiated after a plaintext greeting
 	Duration    time.Duration   `json:"duration_ns,omitempty" yaml:"duration_ns,omitempty"`
 	Response    string          `json:"response,omitempty" yaml:"response,omitempty"`
 	Error       string          `json:"error,omitempty" yaml:"error,omitempty"`
@@ -55,7 +56,12 @@ type VulnerabilityFinding struct {
 	Description  string          `json:"description,omitempty" yaml:"description,omitempty"`
 	References   []string        `json:"references,omitempty" yaml:"references,omitempty"`
 	Remediation  string          `json:"remediation,omitempty" yaml:"remediation,omitempty"`
-	// Evidence string `json:"evidence,omitempty" yaml:"evidence,omitempty"` // Specific evidence
+	// Evidence maps the scan data fields behind the finding to their values
+	Evidence map[string]string `json:"evidence,omitempty" yaml:"evidence,omitempty"`
+	// OriginalSeverity is the plugin's severity when the severity policy
+	// remapped Severity, for SeverityReason
+	OriginalSeverity FindingSeverity `json:"original_severity,omitempty" yaml:"original_severity,omitempty"`
+	SeverityReason   string          `json:"severity_reason,omitempty" yaml:"severity_reason,omitempty"`
 }
 
 // ServiceDetails contains information about the service running on a port.
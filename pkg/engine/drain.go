@@ -0,0 +1,73 @@
+// pkg/engine/drain.go
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDrained indicates that a run was drained, e.g. during a graceful
+// shutdown, before all its modules ran.
+var ErrDrained = errors.New("dag drained")
+
+// DrainedError reports the modules of a drained run: those that completed
+// and those that did not start or did not finish. Err is the error the run
+// failed with otherwise, e.g. the cancellation of modules that outlived the
+// drain deadline.
+type DrainedError struct {
+	Completed []string
+	Pending   []string
+	Err       error
+}
+
+func (e *DrainedError) Error() string {
+	msg := fmt.Sprintf("%s: %d module(s) pending (%s)", ErrDrained, len(e.Pending), strings.Join(e.Pending, ", "))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is reports whether target is ErrDrained.
+func (e *DrainedError) Is(target error) bool {
+	return target == ErrDrained
+}
+
+func (e *DrainedError) Unwrap() error {
+	return e.Err
+}
+
+type drainKeyType struct{}
+
+var drainKey = drainKeyType{}
+
+// WithDrain returns a context whose runs drain once drain is closed: the
+// orchestrator starts no further modules, and modules dispatching many
+// probes stop dispatching (see Draining), while the work in flight
+// finishes. A nil drain leaves ctx unchanged.
+func WithDrain(ctx context.Context, drain <-chan struct{}) context.Context {
+	if drain == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, drainKey, drain)
+}
+
+// drainFromContext returns the drain channel carried by ctx, or nil, which
+// never fires.
+func drainFromContext(ctx context.Context) <-chan struct{} {
+	drain, _ := ctx.Value(drainKey).(<-chan struct{})
+	return drain
+}
+
+// Draining reports whether the run of ctx is draining and should start no
+// new work.
+func Draining(ctx context.Context) bool {
+	select {
+	case <-drainFromContext(ctx):
+		return true
+	default:
+		return false
+	}
+}
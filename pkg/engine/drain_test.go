@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDraining(t *testing.T) {
+	require.False(t, Draining(context.Background()))
+	require.False(t, Draining(WithDrain(context.Background(), nil)))
+
+	drain := make(chan struct{})
+	ctx := WithDrain(context.Background(), drain)
+	require.False(t, Draining(ctx))
+	close(drain)
+	require.True(t, Draining(ctx))
+}
+
+func TestOrchestrator_Run_Drain(t *testing.T) {
+	drain := make(chan struct{})
+	consumerRan := false
+	RegisterModuleFactory("mock-drain-producer", func() Module {
+		return &mockModule{
+			meta: ModuleMetadata{Name: "mock-drain-producer", Produces: []DataContractEntry{{Key: "foo"}}},
+			execFunc: func(ctx context.Context, inputs map[string]interface{}, out chan<- ModuleOutput) error {
+				// Shut down while the module runs; it still finishes
+				close(drain)
+				require.True(t, Draining(ctx))
+				out <- ModuleOutput{DataKey: "foo", Data: "bar"}
+				return nil
+			},
+		}
+	})
+	RegisterModuleFactory("mock-drain-consumer", func() Module {
+		return &mockModule{
+			meta: ModuleMetadata{Name: "mock-drain-consumer", Consumes: []DataContractEntry{{Key: "foo"}}},
+			execFunc: func(ctx context.Context, inputs map[string]interface{}, out chan<- ModuleOutput) error {
+				consumerRan = true
+				return nil
+			},
+		}
+	})
+	defer func() {
+		delete(moduleRegistry, "mock-drain-producer")
+		delete(moduleRegistry, "mock-drain-consumer")
+	}()
+
+	orc, err := NewOrchestrator(&DAGDefinition{
+		Name: "test-drain",
+		Nodes: []DAGNodeConfig{
+			{InstanceID: "mod1", ModuleType: "mock-drain-producer"},
+			{InstanceID: "mod2", ModuleType: "mock-drain-consumer"},
+		},
+	})
+	require.NoError(t, err)
+
+	results, err := orc.Run(WithDrain(context.Background(), drain), nil)
+	require.ErrorIs(t, err, ErrDrained)
+	var drained *DrainedError
+	require.True(t, errors.As(err, &drained))
+	require.Equal(t, []string{"mod1"}, drained.Completed)
+	require.Equal(t, []string{"mod2"}, drained.Pending)
+	require.False(t, consumerRan)
+	require.Equal(t, []interface{}{"bar"}, results["foo"])
+}
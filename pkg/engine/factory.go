@@ -48,15 +48,21 @@ func (f *DefaultAppManagerFactory) Create(flags *pflag.FlagSet, configFile strin
 		return nil, err
 	}
 
-	context, cancel := context.WithCancel(context.Background())
+	return NewAppManager(ConfigManager), nil
+}
 
+// NewAppManager returns an AppManager using the loaded configuration cfg.
+// Unlike the factory, it leaves global logging as configured by the caller,
+// for programs embedding the engine.
+func NewAppManager(cfg *config.Manager) *AppManager {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &AppManager{
-		ctx:           context,
+		ctx:           ctx,
 		cancel:        cancel,
-		ConfigManager: ConfigManager,
+		ConfigManager: cfg,
 		EventManager:  event.NewManager(),
 		HookManager:   hook.NewManager(),
-	}, nil
+	}
 }
 
 // CreateWithConfig creates a new AppManager instance using the provided pflag.FlagSet and configuration file.
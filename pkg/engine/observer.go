@@ -0,0 +1,25 @@
+// pkg/engine/observer.go
+package engine
+
+import "context"
+
+// OutputObserver is notified of every module output as the orchestrator
+// publishes it to the DataContext. Observers run on the module's output
+// goroutine and must not block.
+type OutputObserver func(output ModuleOutput)
+
+type outputObserverKeyType struct{}
+
+var outputObserverKey = outputObserverKeyType{}
+
+// WithOutputObserver returns a context that carries obs. The orchestrator
+// picks it up from the context passed to Run.
+func WithOutputObserver(ctx context.Context, obs OutputObserver) context.Context {
+	return context.WithValue(ctx, outputObserverKey, obs)
+}
+
+// OutputObserverFromContext returns the observer carried by ctx, if any.
+func OutputObserverFromContext(ctx context.Context) (OutputObserver, bool) {
+	obs, ok := ctx.Value(outputObserverKey).(OutputObserver)
+	return obs, ok && obs != nil
+}
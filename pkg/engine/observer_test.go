@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// emittingModule publishes a fixed set of outputs.
+type emittingModule struct {
+	id      string
+	outputs []ModuleOutput
+}
+
+func (m *emittingModule) Metadata() ModuleMetadata {
+	return ModuleMetadata{Name: "test.emitter", Type: ScanModuleType}
+}
+
+func (m *emittingModule) Init(instanceID string, _ map[string]interface{}) error {
+	m.id = instanceID
+	return nil
+}
+
+func (m *emittingModule) Execute(ctx context.Context, _ map[string]interface{}, out chan<- ModuleOutput) error {
+	for _, o := range m.outputs {
+		out <- o
+	}
+	return nil
+}
+
+func TestOutputObserverFromContext(t *testing.T) {
+	_, ok := OutputObserverFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := WithOutputObserver(context.Background(), func(ModuleOutput) {})
+	obs, ok := OutputObserverFromContext(ctx)
+	require.True(t, ok)
+	require.NotNil(t, obs)
+
+	ctx = WithOutputObserver(context.Background(), nil)
+	_, ok = OutputObserverFromContext(ctx)
+	require.False(t, ok, "nil observer should be ignored")
+}
+
+func TestOrchestrator_NotifiesOutputObserver(t *testing.T) {
+	RegisterModuleFactory("observer-emitter", func() Module {
+		return &emittingModule{outputs: []ModuleOutput{
+			{DataKey: "test.first", Data: 1},
+			{DataKey: "test.second", Data: "two"},
+		}}
+	})
+
+	orc, err := NewOrchestrator(&DAGDefinition{
+		Name:  "observer",
+		Nodes: []DAGNodeConfig{{InstanceID: "emitter", ModuleType: "observer-emitter"}},
+	})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var seen []ModuleOutput
+	ctx := WithOutputObserver(context.Background(), func(o ModuleOutput) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, o)
+	})
+
+	_, err = orc.Run(ctx, nil)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, seen, 2)
+	require.Equal(t, "test.first", seen[0].DataKey)
+	require.Equal(t, "emitter", seen[0].FromModuleName, "observer sees normalized outputs")
+	require.Equal(t, "two", seen[1].Data)
+}
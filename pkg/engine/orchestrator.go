@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 // DAGNodeConfig defines the configuration for a single node (module instance) in the DAG.
@@ -278,6 +281,11 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 	logger := log.With().Str("dag", o.dag.Name).Logger()
 	logger.Info().Msg("Starting DAG execution")
 
+	ctx, span := tracing.Start(ctx, "dag.run",
+		tracing.String("dag.name", o.dag.Name),
+		tracing.Int("dag.nodes", len(o.moduleNodes)))
+	defer span.End()
+
 	// Ensure common schema exists (idempotent)
 	RegisterCommonSchema(o.dataCtx)
 
@@ -319,8 +327,20 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 
 	var activeGoroutines sync.WaitGroup
 
+	// Optional observer for live progress (e.g., server event streams)
+	observer, hasObserver := OutputObserverFromContext(ctx)
+
+	// Draining runs start no further modules; those running finish
+	drain := drainFromContext(ctx)
+	drained := false
+
 	// Loop until all nodes are completed or an error occurs that halts the DAG
 	for len(executionCompleted) < len(o.moduleNodes) {
+		if Draining(ctx) {
+			logger.Info().Msg("Draining DAG, starting no further modules")
+			drained = true
+			break
+		}
 		madeProgressInIteration := false
 
 		for _, node := range o.moduleNodes {
@@ -428,12 +448,20 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 				execContext, execCancel := context.WithCancel(ctx) // Create a context for this specific execution
 				defer execCancel()
 
+				meta := currentNode.module.Metadata()
+				execContext, nodeSpan := tracing.Start(execContext, "module.execute",
+					tracing.String("module.instance", currentNode.instanceID),
+					tracing.String("module.name", meta.Name),
+					tracing.String("module.type", string(meta.Type)))
+				defer nodeSpan.End()
+
 				mlogger := o.logger.With().
 					Str("module", currentNode.instanceID).Logger()
 
 				// Optional lifecycle start (before Execute)
 				if lc, ok := currentNode.module.(ModuleLifecycle); ok {
 					if err := lc.LifecycleStart(execContext); err != nil {
+						nodeSpan.RecordError(err)
 						completedMutex.Lock()
 						currentNode.status = StatusFailed
 						currentNode.err = err
@@ -491,6 +519,10 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 						o.dataCtx.AddOrAppendToList(dataCtxKey, output.Data)
 					}
 
+					if hasObserver {
+						observer(output)
+					}
+
 					if output.Error != nil {
 						// fmt.Fprintf(os.Stderr, "[ERROR] Output error from module '%s' for DataKey '%s': %v\n", currentNode.instanceID, output.DataKey, output.Error)
 						log.Error().Msgf("[ERROR] Output error from module '%s' for DataKey '%s': %v", currentNode.instanceID, output.DataKey, output.Error)
@@ -506,6 +538,8 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 
 				currentNode.endTime = time.Now()
 				duration := currentNode.endTime.Sub(currentNode.startTime)
+				nodeSpan.SetAttributes(tracing.Int("module.outputs", len(currentNode.outputs)))
+				nodeSpan.RecordError(moduleErr)
 
 				completedMutex.Lock()
 				if moduleErr != nil {
@@ -531,6 +565,8 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 			select {
 			case <-nodeDoneSignal:
 				// A node finished, loop again to check for newly runnable nodes
+			case <-drain:
+				// Draining, loop again to stop
 			case <-ctx.Done():
 				fmt.Println("[INFO] Orchestrator: Main context canceled during execution.")
 				setOverallError(ctx.Err()) // Set overall error to context error
@@ -550,6 +586,23 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 
 	activeGoroutines.Wait() // Wait for any launched goroutines to finish
 
+	// Report the modules a drain left unfinished, e.g. to resume the run
+	if drained {
+		var completed, pending []string
+		for id, rn := range o.moduleNodes {
+			if rn.status == StatusCompleted {
+				completed = append(completed, id)
+			} else {
+				pending = append(pending, id)
+			}
+		}
+		if len(pending) > 0 {
+			sort.Strings(completed)
+			sort.Strings(pending)
+			overallError = &DrainedError{Completed: completed, Pending: pending, Err: overallError}
+		}
+	}
+
 	// Teardown lifecycle in reverse order (best-effort)
 	for i := len(o.order) - 1; i >= 0; i-- {
 		id := o.order[i]
@@ -574,5 +627,8 @@ func (o *Orchestrator) Run(ctx context.Context, initialInputs map[string]interfa
 	o.logger.Info().
 		Str("status", oStatus).Msg("DAG execution finished")
 
+	span.SetAttributes(tracing.String("dag.status", oStatus))
+	span.RecordError(overallError)
+
 	return o.dataCtx.GetAll(), overallError
 }
@@ -3,6 +3,7 @@ package engine
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/rs/zerolog"
@@ -33,6 +34,46 @@ type ScanIntent struct {
 	Concurrency      int    // Number of concurrent modules to run
 	DiscoveryOnly    bool
 	SkipDiscovery    bool
+
+	// SeededDataKeys are data keys supplied with the initial inputs, e.g.
+	// hosts and banners imported from another scanner. Modules that produce
+	// any of them are not planned, so only the later stages run.
+	SeededDataKeys []string
+
+	// NucleiTemplates are Nuclei template files or directories run against
+	// the HTTP services found when vulnerability checks are enabled.
+	NucleiTemplates []string
+
+	// DefaultCredentials enables testing the default credentials declared
+	// by plugins. The modules that send login attempts are intrusive and
+	// otherwise only planned, disabled, by the full scan profile.
+	DefaultCredentials bool
+
+	// ContentDiscovery enables requesting a wordlist of paths from the
+	// HTTP services found. Like default credential testing, the module
+	// is intrusive and otherwise only planned, disabled, by the full scan
+	// profile.
+	ContentDiscovery bool
+
+	// AutoTune lets TCP port discovery and banner grabbing tune their
+	// concurrency and timeouts from system resources and the round trips
+	// observed during the scan. Concurrency and CustomTimeout are then
+	// the starting point.
+	AutoTune bool
+
+	// VerifyTimeouts lets TCP port discovery probe the ports that timed
+	// out once more at the end, at lower concurrency.
+	VerifyTimeouts bool
+
+	// Offline plans only modules that work on data already collected,
+	// e.g. to replay a stored scan: discovery and scan modules are not
+	// planned, and plugin evaluation skips Nuclei templates and multi-step
+	// plugins, which send requests.
+	Offline bool
+
+	// Plugins restricts plugin evaluation to the plugins with these IDs,
+	// names, categories or tags. Empty = all plugins.
+	Plugins []string
 }
 
 // DAGPlanner is responsible for automatically constructing a DAGDefinition based on scan intent and module metadata.
@@ -70,6 +111,9 @@ func (p *DAGPlanner) initializeDataKeys(intent ScanIntent) map[string]string {
 
 		p.logger.Debug().Interface("initial_keys", availableDataKeys).Msg("Initial available data keys")
 	}
+	for _, key := range intent.SeededDataKeys {
+		availableDataKeys[key] = "initial_input"
+	}
 	return availableDataKeys
 }
 
@@ -259,6 +303,42 @@ func (p *DAGPlanner) filterHostDiscoveryModules(selected []ModuleFactory) []Modu
 	return filtered
 }
 
+// filterSeededProducers removes modules that produce any of the seeded data keys.
+func (p *DAGPlanner) filterSeededProducers(selected []ModuleFactory, seeded []string) []ModuleFactory {
+	filtered := selected[:0]
+	for _, factory := range selected {
+		meta := factory().Metadata()
+		producesSeeded := false
+		for _, produced := range meta.Produces {
+			if slices.Contains(seeded, produced.Key) {
+				producesSeeded = true
+				break
+			}
+		}
+		if producesSeeded {
+			p.logger.Debug().Str("module", meta.Name).Msg("Filtered module whose output is seeded")
+			continue
+		}
+		filtered = append(filtered, factory)
+	}
+	return filtered
+}
+
+// filterProbingModules removes the modules that send probes to the
+// targets (discovery and scan modules) when Offline=true.
+func (p *DAGPlanner) filterProbingModules(selected []ModuleFactory) []ModuleFactory {
+	filtered := selected[:0]
+	for _, factory := range selected {
+		meta := factory().Metadata()
+		if meta.Type == DiscoveryModuleType || meta.Type == ScanModuleType {
+			p.logger.Debug().Str("module", meta.Name).Msg("Filtered probing module for offline run")
+			continue
+		}
+		filtered = append(filtered, factory)
+	}
+	return filtered
+}
+
 // selectModulesByType filters modules by type and tags from the registry.
 func (p *DAGPlanner) selectModulesByType(
 	moduleTypes []ModuleType,
@@ -327,11 +407,15 @@ func (p *DAGPlanner) selectFullScanModules(intent ScanIntent) []ModuleFactory {
 func (p *DAGPlanner) selectDefaultModules(intent ScanIntent) []ModuleFactory {
 	var selected []ModuleFactory
 
-	// Select discovery and scan modules (non-intrusive)
+	// Select discovery and scan modules (non-intrusive, except default
+	// credential testing when requested)
 	for name, factory := range p.moduleRegistry {
 		meta := factory().Metadata()
+		intrusive := containsTag(meta.Tags, "intrusive") &&
+			!(intent.DefaultCredentials && containsTag(meta.Tags, "default-credentials")) &&
+			!(intent.ContentDiscovery && containsTag(meta.Tags, "content-discovery"))
 		if (meta.Type == DiscoveryModuleType || meta.Type == ScanModuleType) &&
-			!containsTag(meta.Tags, "intrusive") &&
+			!intrusive &&
 			p.matchesTags(meta.Tags, intent.IncludeTags, intent.ExcludeTags) {
 			selected = append(selected, factory)
 			p.logger.Debug().Str("module", name).Msg("Selected module for default profile")
@@ -380,6 +464,16 @@ func (p *DAGPlanner) selectModulesForIntent(intent ScanIntent) []ModuleFactory {
 		selected = p.filterHostDiscoveryModules(selected)
 	}
 
+	// Seeded data replaces the modules that would have produced it
+	if len(intent.SeededDataKeys) > 0 {
+		selected = p.filterSeededProducers(selected, intent.SeededDataKeys)
+	}
+
+	// Offline runs send no probes
+	if intent.Offline {
+		selected = p.filterProbingModules(selected)
+	}
+
 	// Ensure reporter module exists
 	return p.ensureReporter(selected, intent)
 }
@@ -538,6 +632,52 @@ func (p *DAGPlanner) applyIntentOverrides(cfg map[string]interface{}, meta Modul
 		cfg["connect_timeout"] = intent.CustomTimeout
 		p.logger.Debug().Str("module", meta.Name).Str("read_timeout", intent.CustomTimeout).Str("connect_timeout", intent.CustomTimeout).Msg("Applied custom banner timeouts from intent")
 	}
+
+	// Banner grabber concurrency override
+	if meta.Name == "banner-grabber" && intent.Concurrency > 0 {
+		cfg["concurrency"] = intent.Concurrency
+		p.logger.Debug().Str("module", meta.Name).Int("concurrency", intent.Concurrency).Msg("Applied custom banner concurrency from intent")
+	}
+
+	// Auto-tuning (TCP port discovery and banner grabber)
+	if (meta.Name == moduleTypeTCPPortDiscovery || meta.Name == "banner-grabber") && intent.AutoTune {
+		cfg["auto_tune"] = true
+		p.logger.Debug().Str("module", meta.Name).Msg("Enabled auto-tuning from intent")
+	}
+
+	// Verification of timed-out ports (TCP port discovery)
+	if meta.Name == moduleTypeTCPPortDiscovery && intent.VerifyTimeouts {
+		cfg["verify_timeouts"] = true
+		p.logger.Debug().Str("module", meta.Name).Msg("Enabled verification of timed-out ports from intent")
+	}
+
+	// Nuclei templates for plugin evaluation
+	if meta.Name == "plugin-evaluation" && len(intent.NucleiTemplates) > 0 {
+		cfg["nuclei_templates"] = intent.NucleiTemplates
+		p.logger.Debug().Str("module", meta.Name).Strs("nuclei_templates", intent.NucleiTemplates).Msg("Applied Nuclei templates from intent")
+	}
+
+	// Offline plugin evaluation and plugin selection
+	if meta.Name == "plugin-evaluation" && intent.Offline {
+		cfg["offline"] = true
+		p.logger.Debug().Str("module", meta.Name).Msg("Disabled plugins sending requests for offline run")
+	}
+	if meta.Name == "plugin-evaluation" && len(intent.Plugins) > 0 {
+		cfg["plugins"] = intent.Plugins
+		p.logger.Debug().Str("module", meta.Name).Strs("plugins", intent.Plugins).Msg("Applied plugin selection from intent")
+	}
+
+	// Default-credential testing is opt-in per scan
+	if containsTag(meta.Tags, "default-credentials") && intent.DefaultCredentials {
+		cfg["enabled"] = true
+		p.logger.Debug().Str("module", meta.Name).Msg("Enabled default-credential testing from intent")
+	}
+
+	// So is content discovery
+	if containsTag(meta.Tags, "content-discovery") && intent.ContentDiscovery {
+		cfg["enabled"] = true
+		p.logger.Debug().Str("module", meta.Name).Msg("Enabled content discovery from intent")
+	}
 }
 
 // generateInstanceID creates a unique instance ID for a module in the DAG.
@@ -121,6 +121,75 @@ func TestPlanner_configureModule_AppliesCustoms(t *testing.T) {
 	if sc["read_timeout"] != "7s" || sc["connect_timeout"] != "7s" {
 		t.Fatalf("expected scan timeouts 7s, got read=%v connect=%v", sc["read_timeout"], sc["connect_timeout"])
 	}
+
+	// banner-grabber gets concurrency from intent
+	sc = planner.configureModule(scanMeta, ScanIntent{Concurrency: 50})
+	if sc["concurrency"] != 50 {
+		t.Fatalf("expected banner concurrency 50, got %v", sc["concurrency"])
+	}
+
+	// discovery and banner-grabber get auto-tuning from intent
+	if cfg := planner.configureModule(meta, ScanIntent{AutoTune: true}); cfg["auto_tune"] != true {
+		t.Fatalf("expected discovery auto_tune, got %v", cfg["auto_tune"])
+	}
+	if sc = planner.configureModule(scanMeta, ScanIntent{AutoTune: true}); sc["auto_tune"] != true {
+		t.Fatalf("expected banner auto_tune, got %v", sc["auto_tune"])
+	}
+	if _, ok := planner.configureModule(scanMeta, ScanIntent{})["auto_tune"]; ok {
+		t.Fatalf("expected no auto_tune without intent")
+	}
+
+	// only discovery verifies timed-out ports
+	if cfg := planner.configureModule(meta, ScanIntent{VerifyTimeouts: true}); cfg["verify_timeouts"] != true {
+		t.Fatalf("expected discovery verify_timeouts, got %v", cfg["verify_timeouts"])
+	}
+	if _, ok := planner.configureModule(scanMeta, ScanIntent{VerifyTimeouts: true})["verify_timeouts"]; ok {
+		t.Fatalf("expected no banner verify_timeouts")
+	}
+
+	// plugin-evaluation gets Nuclei templates from intent
+	evalMeta := ModuleMetadata{Name: "plugin-evaluation"}
+	ec := planner.configureModule(evalMeta, ScanIntent{NucleiTemplates: []string{"templates/"}})
+	if templates, ok := ec["nuclei_templates"].([]string); !ok || len(templates) != 1 || templates[0] != "templates/" {
+		t.Fatalf("expected nuclei templates [templates/], got %v", ec["nuclei_templates"])
+	}
+	if _, ok := planner.configureModule(evalMeta, ScanIntent{})["nuclei_templates"]; ok {
+		t.Fatalf("expected no nuclei templates without intent")
+	}
+
+	// plugin-evaluation gets offline mode and the plugin selection from intent
+	ec = planner.configureModule(evalMeta, ScanIntent{Offline: true, Plugins: []string{"ssh"}})
+	if ec["offline"] != true {
+		t.Fatalf("expected offline plugin evaluation, got %v", ec["offline"])
+	}
+	if plugins, ok := ec["plugins"].([]string); !ok || len(plugins) != 1 || plugins[0] != "ssh" {
+		t.Fatalf("expected plugins [ssh], got %v", ec["plugins"])
+	}
+	ec = planner.configureModule(evalMeta, ScanIntent{})
+	if _, ok := ec["offline"]; ok {
+		t.Fatalf("expected no offline mode without intent")
+	}
+	if _, ok := ec["plugins"]; ok {
+		t.Fatalf("expected no plugin selection without intent")
+	}
+
+	// default-credentials modules are enabled by the intent only
+	credsMeta := ModuleMetadata{Name: "default-creds", Tags: []string{"default-credentials"}, ConfigSchema: map[string]ParameterDefinition{"enabled": {Default: false}}}
+	if cfg := planner.configureModule(credsMeta, ScanIntent{DefaultCredentials: true}); cfg["enabled"] != true {
+		t.Fatalf("expected default-creds enabled, got %v", cfg["enabled"])
+	}
+	if cfg := planner.configureModule(credsMeta, ScanIntent{}); cfg["enabled"] == true {
+		t.Fatalf("expected default-creds disabled without intent")
+	}
+
+	// So is content discovery
+	contentMeta := ModuleMetadata{Name: "http-content-discovery", Tags: []string{"content-discovery"}, ConfigSchema: map[string]ParameterDefinition{"enabled": {Default: false}}}
+	if cfg := planner.configureModule(contentMeta, ScanIntent{ContentDiscovery: true}); cfg["enabled"] != true {
+		t.Fatalf("expected content discovery enabled, got %v", cfg["enabled"])
+	}
+	if cfg := planner.configureModule(contentMeta, ScanIntent{DefaultCredentials: true}); cfg["enabled"] == true {
+		t.Fatalf("expected content discovery disabled without intent")
+	}
 }
 
 func TestPlanner_generateInstanceID_Unique(t *testing.T) {
@@ -269,6 +338,135 @@ func TestPlanner_initializeDataKeys_SkipDiscovery(t *testing.T) {
 	}
 }
 
+// Test PlanDAG with seeded data skips the modules producing it
+func TestPlanner_PlanDAG_SeededDataKeys(t *testing.T) {
+	discoveryMeta := ModuleMetadata{
+		Name: "tcp-port-discovery", Type: DiscoveryModuleType,
+		Produces: []DataContractEntry{{Key: "discovery.open_tcp_ports"}},
+	}
+	scanMeta := ModuleMetadata{
+		Name: "banner-grabber", Type: ScanModuleType,
+		Consumes: []DataContractEntry{{Key: "discovery.open_tcp_ports"}},
+		Produces: []DataContractEntry{{Key: "service.banner.tcp"}},
+	}
+	parseMeta := ModuleMetadata{
+		Name: "ssh-parser", Type: ParseModuleType,
+		Consumes: []DataContractEntry{{Key: "service.banner.tcp"}},
+		Produces: []DataContractEntry{{Key: "service.ssh.details"}},
+	}
+	evalMeta := ModuleMetadata{
+		Name: "plugin-evaluator", Type: EvaluationModuleType,
+		Consumes: []DataContractEntry{{Key: "service.ssh.details"}},
+		Produces: []DataContractEntry{{Key: "evaluation.vulnerabilities"}},
+	}
+	reporterMeta := ModuleMetadata{Name: "reporter", Type: ReportingModuleType}
+
+	registry := map[string]ModuleFactory{
+		discoveryMeta.Name: fakeFactory(discoveryMeta),
+		scanMeta.Name:      fakeFactory(scanMeta),
+		parseMeta.Name:     fakeFactory(parseMeta),
+		evalMeta.Name:      fakeFactory(evalMeta),
+		reporterMeta.Name:  fakeFactory(reporterMeta),
+	}
+	planner, _ := NewDAGPlanner(registry, nil)
+
+	intent := ScanIntent{
+		Targets:          []string{"10.0.0.1"},
+		EnableVulnChecks: true,
+		SeededDataKeys:   []string{"discovery.live_hosts", "discovery.open_tcp_ports", "service.banner.tcp"},
+	}
+	keys := planner.initializeDataKeys(intent)
+	for _, key := range intent.SeededDataKeys {
+		if keys[key] != "initial_input" {
+			t.Fatalf("expected seeded key %s to be initialized, got %v", key, keys)
+		}
+	}
+
+	dag, err := planner.PlanDAG(intent)
+	if err != nil {
+		t.Fatalf("PlanDAG error: %v", err)
+	}
+	planned := map[string]bool{}
+	for _, n := range dag.Nodes {
+		planned[n.ModuleType] = true
+	}
+	if planned[discoveryMeta.Name] || planned[scanMeta.Name] {
+		t.Fatalf("modules producing seeded data should not be planned, got %v", planned)
+	}
+	for _, name := range []string{parseMeta.Name, evalMeta.Name, reporterMeta.Name} {
+		if !planned[name] {
+			t.Fatalf("expected %s to be planned, got %v", name, planned)
+		}
+	}
+}
+
+// Test offline plans leave out the modules sending probes
+func TestPlanner_PlanDAG_Offline(t *testing.T) {
+	discoveryMeta := ModuleMetadata{
+		Name: "tcp-port-discovery", Type: DiscoveryModuleType,
+		Produces: []DataContractEntry{{Key: "discovery.open_tcp_ports"}},
+	}
+	bannerMeta := ModuleMetadata{
+		Name: "banner-grabber", Type: ScanModuleType,
+		Consumes: []DataContractEntry{{Key: "discovery.open_tcp_ports"}},
+		Produces: []DataContractEntry{{Key: "service.banner.tcp"}},
+	}
+	smbMeta := ModuleMetadata{
+		Name: "smb-enum", Type: ScanModuleType,
+		Consumes: []DataContractEntry{{Key: "discovery.open_tcp_ports"}},
+		Produces: []DataContractEntry{{Key: "service.smb.details"}},
+	}
+	parseMeta := ModuleMetadata{
+		Name: "fingerprint-parser", Type: ParseModuleType,
+		Consumes: []DataContractEntry{{Key: "service.banner.tcp"}},
+		Produces: []DataContractEntry{{Key: "service.fingerprint.details"}},
+	}
+	evalMeta := ModuleMetadata{
+		Name: "plugin-evaluation", Type: EvaluationModuleType,
+		Consumes: []DataContractEntry{{Key: "service.fingerprint.details"}},
+		Produces: []DataContractEntry{{Key: "evaluation.vulnerabilities"}},
+	}
+	reporterMeta := ModuleMetadata{Name: "reporter", Type: ReportingModuleType}
+
+	registry := map[string]ModuleFactory{
+		discoveryMeta.Name: fakeFactory(discoveryMeta),
+		bannerMeta.Name:    fakeFactory(bannerMeta),
+		smbMeta.Name:       fakeFactory(smbMeta),
+		parseMeta.Name:     fakeFactory(parseMeta),
+		evalMeta.Name:      fakeFactory(evalMeta),
+		reporterMeta.Name:  fakeFactory(reporterMeta),
+	}
+	planner, _ := NewDAGPlanner(registry, nil)
+
+	// smb-enum output is not seeded, but it would probe the targets
+	dag, err := planner.PlanDAG(ScanIntent{
+		Targets:          []string{"10.0.0.1"},
+		EnableVulnChecks: true,
+		SeededDataKeys:   []string{"discovery.open_tcp_ports", "service.banner.tcp"},
+		Offline:          true,
+	})
+	if err != nil {
+		t.Fatalf("PlanDAG error: %v", err)
+	}
+	planned := map[string]bool{}
+	for _, n := range dag.Nodes {
+		planned[n.ModuleType] = true
+		if n.ModuleType == evalMeta.Name && n.Config["offline"] != true {
+			t.Fatalf("expected offline plugin evaluation, got %v", n.Config)
+		}
+	}
+	for _, name := range []string{discoveryMeta.Name, bannerMeta.Name, smbMeta.Name} {
+		if planned[name] {
+			t.Fatalf("probing module %s should not be planned offline, got %v", name, planned)
+		}
+	}
+	for _, name := range []string{parseMeta.Name, evalMeta.Name, reporterMeta.Name} {
+		if !planned[name] {
+			t.Fatalf("expected %s to be planned, got %v", name, planned)
+		}
+	}
+}
+
 // Test different profiles select correct modules
 func TestPlanner_selectModulesByProfile_Profiles(t *testing.T) {
 	discoveryMeta := ModuleMetadata{Name: "icmp-ping", Type: DiscoveryModuleType}
@@ -591,6 +789,16 @@ func TestDAGPlanner_selectDefaultModules(t *testing.T) {
 		Type: ScanModuleType,
 		Tags: []string{"intrusive"},
 	}
+	defaultCredsMeta := ModuleMetadata{
+		Name: "default-creds",
+		Type: ScanModuleType,
+		Tags: []string{"scan", "intrusive", "default-credentials"},
+	}
+	contentMeta := ModuleMetadata{
+		Name: "http-content-discovery",
+		Type: ScanModuleType,
+		Tags: []string{"scan", "intrusive", "content-discovery"},
+	}
 	evalMeta := ModuleMetadata{
 		Name: "vuln-evaluator",
 		Type: EvaluationModuleType,
@@ -606,6 +814,8 @@ func TestDAGPlanner_selectDefaultModules(t *testing.T) {
 		discoveryMeta.Name:     fakeFactory(discoveryMeta),
 		scanMeta.Name:          fakeFactory(scanMeta),
 		intrusiveScanMeta.Name: fakeFactory(intrusiveScanMeta),
+		defaultCredsMeta.Name:  fakeFactory(defaultCredsMeta),
+		contentMeta.Name:       fakeFactory(contentMeta),
 		evalMeta.Name:          fakeFactory(evalMeta),
 		otherMeta.Name:         fakeFactory(otherMeta),
 	}
@@ -631,6 +841,9 @@ func TestDAGPlanner_selectDefaultModules(t *testing.T) {
 		if found["intrusive-scanner"] {
 			t.Error("did not expect intrusive-scanner in default modules")
 		}
+		if found["default-creds"] {
+			t.Error("did not expect default-creds unless DefaultCredentials is true")
+		}
 		if found["vuln-evaluator"] {
 			t.Error("did not expect vuln-evaluator unless EnableVulnChecks is true")
 		}
@@ -651,6 +864,35 @@ func TestDAGPlanner_selectDefaultModules(t *testing.T) {
 		}
 	})
 
+	t.Run("default profile with DefaultCredentials includes credential testing only", func(t *testing.T) {
+		intent := ScanIntent{DefaultCredentials: true}
+		selected := planner.selectDefaultModules(intent)
+		found := map[string]bool{}
+		for _, factory := range selected {
+			found[factory().Metadata().Name] = true
+		}
+		if !found["default-creds"] {
+			t.Error("expected default-creds in default modules when DefaultCredentials is true")
+		}
+		if found["intrusive-scanner"] || found["http-content-discovery"] {
+			t.Error("did not expect other intrusive modules when DefaultCredentials is true")
+		}
+	})
+
+	t.Run("default profile with ContentDiscovery includes content discovery only", func(t *testing.T) {
+		selected := planner.selectDefaultModules(ScanIntent{ContentDiscovery: true})
+		found := map[string]bool{}
+		for _, factory := range selected {
+			found[factory().Metadata().Name] = true
+		}
+		if !found["http-content-discovery"] {
+			t.Error("expected http-content-discovery in default modules when ContentDiscovery is true")
+		}
+		if found["default-creds"] || found["intrusive-scanner"] {
+			t.Error("did not expect other intrusive modules when ContentDiscovery is true")
+		}
+	})
+
 	t.Run("default profile with includeTags filters modules", func(t *testing.T) {
 		intent := ScanIntent{IncludeTags: []string{"scan"}}
 		selected := planner.selectDefaultModules(intent)
@@ -0,0 +1,367 @@
+// Package envelope encrypts sensitive data at rest with envelope
+// encryption: every sealed value is encrypted with its own random data key
+// (AES-256-GCM), and the data key is encrypted (wrapped) with a key
+// encryption key held outside the workspace, such as an environment
+// variable, a KMS or the OS keyring (see ResolveKey).
+//
+// A sealed value is one line of text:
+//
+//	enc:v1:<key id>:<wrapped data key>:<ciphertext>
+//
+// so sealed values can be stored in line-oriented files next to older
+// plaintext lines. The key id names the key encryption key that wrapped
+// the data key; a Keyring keeps previous keys to open values sealed before
+// a key rotation. A nil *Keyring seals nothing and opens only plaintext.
+package envelope
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+// Prefix starts every sealed value.
+const Prefix = "enc:v1:"
+
+// KeySize is the size in bytes of key encryption keys and data keys.
+const KeySize = 32
+
+var (
+	// ErrNoKey is returned when opening a sealed value without a keyring.
+	ErrNoKey = errors.New("data is encrypted but no encryption key is configured")
+	// ErrUnknownKey is returned when a value was sealed with a key the
+	// keyring does not hold.
+	ErrUnknownKey = errors.New("data is encrypted with an unknown key")
+	// ErrCorrupt is returned for sealed values that are malformed or fail
+	// authentication.
+	ErrCorrupt = errors.New("encrypted data is corrupt")
+)
+
+// Keyring holds the key encryption keys: the primary key seals new values,
+// and all keys open values.
+type Keyring struct {
+	primary *kek
+	keys    map[string]*kek
+}
+
+type kek struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewKeyring returns a keyring sealing with primary and opening with
+// primary and previous. Keys must be KeySize bytes long.
+func NewKeyring(primary []byte, previous ...[]byte) (*Keyring, error) {
+	k := &Keyring{keys: make(map[string]*kek)}
+	for i, raw := range append([][]byte{primary}, previous...) {
+		key, err := newKEK(raw)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("key: %w", err)
+			}
+			return nil, fmt.Errorf("previous key %d: %w", i, err)
+		}
+		if i == 0 {
+			k.primary = key
+		}
+		if _, ok := k.keys[key.id]; !ok {
+			k.keys[key.id] = key
+		}
+	}
+	return k, nil
+}
+
+// LoadKeyring resolves the key references primary and previous (see
+// ResolveKey) and returns their keyring.
+func LoadKeyring(primary string, previous ...string) (*Keyring, error) {
+	key, err := ResolveKey(primary)
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	var old [][]byte
+	for i, ref := range previous {
+		prev, err := ResolveKey(ref)
+		if err != nil {
+			return nil, fmt.Errorf("previous key %d: %w", i+1, err)
+		}
+		old = append(old, prev)
+	}
+	return NewKeyring(key, old...)
+}
+
+func newKEK(raw []byte) (*kek, error) {
+	if len(raw) != KeySize {
+		return nil, fmt.Errorf("must be %d bytes, got %d", KeySize, len(raw))
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+	return &kek{id: hex.EncodeToString(sum[:4]), aead: aead}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyID returns the id of the primary key, or "" for a nil keyring.
+func (k *Keyring) KeyID() string {
+	if k == nil {
+		return ""
+	}
+	return k.primary.id
+}
+
+// Seal encrypts plaintext with a new data key and returns the sealed
+// value. A nil keyring returns plaintext unchanged.
+func (k *Keyring) Seal(plaintext []byte) ([]byte, error) {
+	if k == nil {
+		return plaintext, nil
+	}
+
+	dek := make([]byte, KeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("generate data key: %w", err)
+	}
+	header := Prefix + k.primary.id
+	wrapped, err := seal(k.primary.aead, dek, []byte(header))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(aead, plaintext, []byte(header))
+	if err != nil {
+		return nil, err
+	}
+
+	enc := base64.RawStdEncoding
+	out := make([]byte, 0, len(header)+2+enc.EncodedLen(len(wrapped))+enc.EncodedLen(len(ciphertext)))
+	out = append(out, header...)
+	out = append(out, ':')
+	out = enc.AppendEncode(out, wrapped)
+	out = append(out, ':')
+	out = enc.AppendEncode(out, ciphertext)
+	return out, nil
+}
+
+// Open decrypts a sealed value. Values that are not sealed (see IsSealed)
+// are returned unchanged, so plaintext written before encryption was
+// enabled stays readable.
+func (k *Keyring) Open(data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return data, nil
+	}
+	if k == nil {
+		return nil, ErrNoKey
+	}
+
+	parts := strings.Split(strings.TrimSpace(string(data[len(Prefix):])), ":")
+	if len(parts) != 3 {
+		return nil, ErrCorrupt
+	}
+	key, ok := k.keys[parts[0]]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownKey, parts[0])
+	}
+	header := []byte(Prefix + key.id)
+	enc := base64.RawStdEncoding
+	wrapped, err := enc.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	ciphertext, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+
+	dek, err := open(key.aead, wrapped, header)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	return open(aead, ciphertext, header)
+}
+
+// seal encrypts plaintext with a random nonce and returns nonce|ciphertext.
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+func open(aead cipher.AEAD, data, additional []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrCorrupt
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additional)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	return plaintext, nil
+}
+
+// IsSealed reports whether data is a sealed value.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(Prefix))
+}
+
+// NewReader returns a reader of the plaintext of r, a stream of lines
+// where each line is either a sealed value or plaintext. Sealed lines are
+// replaced by their plaintext, which carries its own line breaks; other
+// lines pass through unchanged.
+func (k *Keyring) NewReader(r io.Reader) io.Reader {
+	return &reader{keys: k, src: bufio.NewReader(r)}
+}
+
+type reader struct {
+	keys *Keyring
+	src  *bufio.Reader
+	buf  []byte
+	err  error
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		line, err := r.src.ReadBytes('\n')
+		if IsSealed(line) {
+			plaintext, openErr := r.keys.Open(line)
+			if openErr != nil {
+				r.err = openErr
+				return 0, openErr
+			}
+			line = plaintext
+		}
+		r.buf, r.err = line, err
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// ResolveKey returns the key encryption key ref refers to, encoded in
+// base64:
+//
+//	env:NAME                 the environment variable NAME
+//	file:PATH                the content of the file at PATH
+//	exec:COMMAND             the output of a shell command, e.g. a KMS or
+//	                         secret manager CLI decrypting the key
+//	keyring:SERVICE/ACCOUNT  the OS keyring entry of SERVICE and ACCOUNT
+//	                         (secret-tool on Linux, security on macOS)
+//	vault:, aws-sm:          a secret manager (see secrets.Resolve)
+//
+// Any other value is the base64 encoded key itself.
+func ResolveKey(ref string) ([]byte, error) {
+	var value string
+	switch {
+	case ref == "":
+		return nil, errors.New("no key given")
+	case strings.HasPrefix(ref, "exec:"):
+		out, err := runShell(strings.TrimPrefix(ref, "exec:"))
+		if err != nil {
+			return nil, err
+		}
+		value = string(out)
+	case strings.HasPrefix(ref, "keyring:"):
+		out, err := lookupKeyring(strings.TrimPrefix(ref, "keyring:"))
+		if err != nil {
+			return nil, err
+		}
+		value = string(out)
+	default:
+		secret, err := secrets.Resolve(context.Background(), ref)
+		if err != nil {
+			return nil, err
+		}
+		value = secret
+	}
+	return decodeKey(value)
+}
+
+// decodeKey decodes a base64 key with or without padding.
+func decodeKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		key, err = base64.RawStdEncoding.DecodeString(value)
+	}
+	if err != nil {
+		return nil, errors.New("key is not valid base64")
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
+
+// runCommand runs a command and returns its standard output. Tests
+// replace it.
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).Output() // #nosec G204 -- key command is chosen by the user
+}
+
+func runShell(command string) ([]byte, error) {
+	if command == "" {
+		return nil, errors.New("exec: no command given")
+	}
+	name, args := "sh", []string{"-c", command}
+	if runtime.GOOS == "windows" {
+		name, args = "cmd", []string{"/C", command}
+	}
+	out, err := runCommand(name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("exec key command: %w", err)
+	}
+	return out, nil
+}
+
+func lookupKeyring(entry string) ([]byte, error) {
+	service, account, ok := strings.Cut(entry, "/")
+	if !ok || service == "" || account == "" {
+		return nil, fmt.Errorf("keyring: %q is not SERVICE/ACCOUNT", entry)
+	}
+	var out []byte
+	var err error
+	switch runtime.GOOS {
+	case "darwin":
+		out, err = runCommand("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "windows":
+		return nil, errors.New("keyring: not supported on windows, use env:, file: or exec:")
+	default:
+		out, err = runCommand("secret-tool", "lookup", "service", service, "account", account)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("keyring lookup of %s/%s: %w", service, account, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestKeyring_SealOpen(t *testing.T) {
+	k, err := NewKeyring(testKey(1))
+	require.NoError(t, err)
+
+	sealed, err := k.Seal([]byte("SSH-2.0-OpenSSH_8.9\n"))
+	require.NoError(t, err)
+	require.True(t, IsSealed(sealed))
+	require.True(t, strings.HasPrefix(string(sealed), Prefix+k.KeyID()+":"))
+	require.NotContains(t, string(sealed), "OpenSSH")
+	require.NotContains(t, string(sealed), "\n")
+
+	again, err := k.Seal([]byte("SSH-2.0-OpenSSH_8.9\n"))
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again, "each value uses a new data key")
+
+	plaintext, err := k.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, "SSH-2.0-OpenSSH_8.9\n", string(plaintext))
+
+	// Plaintext passes through
+	plaintext, err = k.Open([]byte(`{"id":"key-1"}`))
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"key-1"}`, string(plaintext))
+
+	// Tampering fails authentication
+	tampered := bytes.Clone(sealed)
+	tampered[len(tampered)-2] ^= 1
+	_, err = k.Open(tampered)
+	require.ErrorIs(t, err, ErrCorrupt)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old, err := NewKeyring(testKey(1))
+	require.NoError(t, err)
+	sealed, err := old.Seal([]byte("secret"))
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring(testKey(2), testKey(1))
+	require.NoError(t, err)
+	require.NotEqual(t, old.KeyID(), rotated.KeyID())
+	plaintext, err := rotated.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(plaintext))
+
+	other, err := NewKeyring(testKey(3))
+	require.NoError(t, err)
+	_, err = other.Open(sealed)
+	require.ErrorIs(t, err, ErrUnknownKey)
+
+	var none *Keyring
+	_, err = none.Open(sealed)
+	require.ErrorIs(t, err, ErrNoKey)
+	out, err := none.Seal([]byte("plain"))
+	require.NoError(t, err)
+	require.Equal(t, "plain", string(out))
+
+	_, err = NewKeyring([]byte("short"))
+	require.ErrorContains(t, err, "must be 32 bytes")
+}
+
+func TestKeyring_NewReader(t *testing.T) {
+	k, err := NewKeyring(testKey(1))
+	require.NoError(t, err)
+
+	first, err := k.Seal([]byte("line 2\nline 3\n"))
+	require.NoError(t, err)
+	second, err := k.Seal([]byte("line 4"))
+	require.NoError(t, err)
+	stream := "line 1\n" + string(first) + "\n" + string(second) + "\n"
+
+	out, err := io.ReadAll(k.NewReader(strings.NewReader(stream)))
+	require.NoError(t, err)
+	require.Equal(t, "line 1\nline 2\nline 3\nline 4", string(out))
+
+	var none *Keyring
+	_, err = io.ReadAll(none.NewReader(strings.NewReader(stream)))
+	require.ErrorIs(t, err, ErrNoKey)
+}
+
+func TestResolveKey(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(testKey(7))
+	t.Setenv("VULNTOR_TEST_KEY", encoded)
+	file := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(file, []byte(encoded+"\n"), 0o600))
+
+	var ran []string
+	orig := runCommand
+	t.Cleanup(func() { runCommand = orig })
+	runCommand = func(name string, args ...string) ([]byte, error) {
+		ran = append([]string{name}, args...)
+		return []byte(encoded + "\n"), nil
+	}
+
+	for _, ref := range []string{encoded, strings.TrimRight(encoded, "="), "env:VULNTOR_TEST_KEY", "file:" + file, "exec:kms decrypt"} {
+		key, err := ResolveKey(ref)
+		require.NoError(t, err, ref)
+		require.Equal(t, testKey(7), key)
+	}
+	require.Equal(t, "kms decrypt", ran[len(ran)-1])
+
+	if runtime.GOOS != "windows" {
+		key, err := ResolveKey("keyring:vulntor/storage")
+		require.NoError(t, err)
+		require.Equal(t, testKey(7), key)
+		require.Contains(t, ran, "storage")
+	}
+
+	_, err := ResolveKey("keyring:vulntor")
+	require.ErrorContains(t, err, "SERVICE/ACCOUNT")
+	_, err = ResolveKey("not base64!")
+	require.ErrorContains(t, err, "not valid base64")
+	_, err = ResolveKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	require.ErrorContains(t, err, "must be 32 bytes")
+	_, err = ResolveKey("")
+	require.Error(t, err)
+}
@@ -0,0 +1,244 @@
+// Package fdbudget bounds the file descriptors scans hold for network
+// connections, so that aggressive scans wait for descriptors instead of
+// failing with "too many open files".
+//
+// Every module that opens sockets (port discovery, banner grabbing, HTTP,
+// TLS, DNS and SNMP probes) draws from one Budget, carried in the scan
+// context (see WithContext). A descriptor is taken before a connection is
+// dialed and returned when it is closed. Connections dialed through
+// netproxy draw from the budget of their context automatically.
+//
+// A nil *Budget is unlimited, so code paths without a budget in their
+// context dial as before.
+package fdbudget
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/netutil"
+)
+
+const (
+	// reservedFiles are kept out of the default budget for the process
+	// itself (logs, storage, plugin files, server listeners).
+	reservedFiles = 128
+	// unknownLimit is the default budget when the descriptor limit cannot
+	// be read.
+	unknownLimit = 4096
+)
+
+// DialFunc opens a connection, like net.Dialer.DialContext.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Stats reports the use of a budget. Waits and WaitTime measure the
+// backpressure: how often and how long dials waited for a descriptor.
+type Stats struct {
+	Limit    int           `json:"limit"`
+	InUse    int           `json:"in_use"`
+	Peak     int           `json:"peak"`
+	Waiting  int           `json:"waiting"`
+	Acquired int64         `json:"acquired"`
+	Waits    int64         `json:"waits"`
+	WaitTime time.Duration `json:"wait_time"`
+}
+
+// Sub returns the counters accumulated since earlier, e.g. during one
+// scan. Limit, InUse, Peak and Waiting are those of s.
+func (s Stats) Sub(earlier Stats) Stats {
+	s.Acquired -= earlier.Acquired
+	s.Waits -= earlier.Waits
+	s.WaitTime -= earlier.WaitTime
+	return s
+}
+
+// Budget hands out up to a fixed number of file descriptors. It is safe
+// for concurrent use.
+type Budget struct {
+	slots chan struct{}
+
+	mu       sync.Mutex
+	peak     int
+	waiting  int
+	acquired int64
+	waits    int64
+	waitTime time.Duration
+}
+
+// New returns a budget of limit descriptors. A limit below 1 returns nil,
+// which is unlimited.
+func New(limit int) *Budget {
+	if limit < 1 {
+		return nil
+	}
+	return &Budget{slots: make(chan struct{}, limit)}
+}
+
+var (
+	defaultOnce   sync.Once
+	defaultBudget *Budget
+)
+
+// Default returns the budget shared by all scans of the process, sized by
+// DefaultLimit.
+func Default() *Budget {
+	defaultOnce.Do(func() {
+		defaultBudget = New(DefaultLimit())
+	})
+	return defaultBudget
+}
+
+// DefaultLimit returns the descriptors scans may hold: the soft limit of
+// open files of the process, minus a reserve for the process itself.
+func DefaultLimit() int {
+	openFiles := netutil.DetectResources().OpenFiles
+	if openFiles <= 0 {
+		return unknownLimit
+	}
+	return max(openFiles-max(openFiles/10, reservedFiles), 1)
+}
+
+// Acquire blocks until a descriptor is free or ctx is done.
+func (b *Budget) Acquire(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	select {
+	case b.slots <- struct{}{}:
+		b.record(0, false)
+		return nil
+	default:
+	}
+
+	b.mu.Lock()
+	b.waiting++
+	b.mu.Unlock()
+	start := time.Now()
+
+	select {
+	case b.slots <- struct{}{}:
+		b.record(time.Since(start), true)
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.waiting--
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// record counts an acquired descriptor and, if the dial waited, its wait.
+func (b *Budget) record(wait time.Duration, waited bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.acquired++
+	b.peak = max(b.peak, len(b.slots))
+	if waited {
+		b.waiting--
+		b.waits++
+		b.waitTime += wait
+	}
+}
+
+// Release returns a descriptor taken by Acquire.
+func (b *Budget) Release() {
+	if b == nil {
+		return
+	}
+	<-b.slots
+}
+
+// DialContext takes a descriptor, dials addr with dial and returns the
+// descriptor when the connection is closed, or at once if the dial fails.
+func (b *Budget) DialContext(ctx context.Context, dial DialFunc, network, addr string) (net.Conn, error) {
+	if b == nil {
+		return dial(ctx, network, addr)
+	}
+	if err := b.Acquire(ctx); err != nil {
+		return nil, err
+	}
+	conn, err := dial(ctx, network, addr)
+	if err != nil {
+		b.Release()
+		return nil, err
+	}
+	bc := &budgetConn{Conn: conn, budget: b}
+	if pc, ok := conn.(net.PacketConn); ok {
+		// Keep UDP connections recognizable as packet connections, e.g.
+		// for the DNS resolver, which frames messages differently on them
+		return &budgetPacketConn{budgetConn: bc, packetConn: pc}, nil
+	}
+	return bc, nil
+}
+
+// Stats returns the current use of the budget. A nil budget reports zero
+// values.
+func (b *Budget) Stats() Stats {
+	if b == nil {
+		return Stats{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Stats{
+		Limit:    cap(b.slots),
+		InUse:    len(b.slots),
+		Peak:     b.peak,
+		Waiting:  b.waiting,
+		Acquired: b.acquired,
+		Waits:    b.waits,
+		WaitTime: b.waitTime,
+	}
+}
+
+// budgetConn returns its descriptor to the budget on the first Close.
+type budgetConn struct {
+	net.Conn
+	budget *Budget
+	once   sync.Once
+}
+
+func (c *budgetConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.budget.Release)
+	return err
+}
+
+// budgetPacketConn is a budgetConn of a packet connection.
+type budgetPacketConn struct {
+	*budgetConn
+	packetConn net.PacketConn
+}
+
+func (c *budgetPacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	return c.packetConn.ReadFrom(p)
+}
+
+func (c *budgetPacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	return c.packetConn.WriteTo(p, addr)
+}
+
+// Dial returns dial drawing a descriptor for each connection from the
+// budget of its context, e.g. for http.Transport.DialContext.
+func Dial(dial DialFunc) DialFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return FromContext(ctx).DialContext(ctx, dial, network, addr)
+	}
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying b. A nil b leaves ctx unchanged.
+func WithContext(ctx context.Context, b *Budget) context.Context {
+	if b == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, b)
+}
+
+// FromContext returns the budget carried by ctx, or nil.
+func FromContext(ctx context.Context) *Budget {
+	b, _ := ctx.Value(contextKey{}).(*Budget)
+	return b
+}
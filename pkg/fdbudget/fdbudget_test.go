@@ -0,0 +1,149 @@
+package fdbudget
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listen returns the address of a server accepting TCP connections.
+func listen(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestBudget_DialContext(t *testing.T) {
+	addr := listen(t)
+	var d net.Dialer
+	b := New(2)
+
+	c1, err := b.DialContext(context.Background(), d.DialContext, "tcp", addr)
+	require.NoError(t, err)
+	c2, err := b.DialContext(context.Background(), d.DialContext, "tcp", addr)
+	require.NoError(t, err)
+	require.Equal(t, 2, b.Stats().InUse)
+
+	// The budget is exhausted: the next dial waits for a Close
+	dialed := make(chan error, 1)
+	go func() {
+		c3, err := b.DialContext(context.Background(), d.DialContext, "tcp", addr)
+		if err == nil {
+			_ = c3.Close()
+		}
+		dialed <- err
+	}()
+	require.Eventually(t, func() bool { return b.Stats().Waiting == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, c1.Close())
+	require.NoError(t, <-dialed)
+	_ = c1.Close() // closing twice returns the descriptor once
+	require.NoError(t, c2.Close())
+
+	stats := b.Stats()
+	require.Equal(t, 2, stats.Limit)
+	require.Equal(t, 0, stats.InUse)
+	require.Equal(t, 2, stats.Peak)
+	require.Equal(t, 0, stats.Waiting)
+	require.Equal(t, int64(3), stats.Acquired)
+	require.Equal(t, int64(1), stats.Waits)
+	require.Positive(t, stats.WaitTime)
+}
+
+func TestBudget_DialError(t *testing.T) {
+	b := New(1)
+	failing := func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	_, err := b.DialContext(context.Background(), failing, "tcp", "192.0.2.1:80")
+	require.EqualError(t, err, "connection refused")
+	require.Equal(t, 0, b.Stats().InUse)
+}
+
+func TestBudget_AcquireCanceled(t *testing.T) {
+	b := New(1)
+	require.NoError(t, b.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, b.Acquire(ctx), context.DeadlineExceeded)
+	require.Equal(t, 0, b.Stats().Waiting)
+
+	b.Release()
+	require.Equal(t, 0, b.Stats().InUse)
+}
+
+func TestBudget_DialPacketConn(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer pc.Close()
+	b := New(1)
+
+	conn, err := b.DialContext(context.Background(), (&net.Dialer{}).DialContext, "udp", pc.LocalAddr().String())
+	require.NoError(t, err)
+	require.Implements(t, (*net.PacketConn)(nil), conn)
+	require.Equal(t, 1, b.Stats().InUse)
+	require.NoError(t, conn.Close())
+	require.Equal(t, 0, b.Stats().InUse)
+}
+
+func TestNilBudget(t *testing.T) {
+	addr := listen(t)
+	var b *Budget
+	require.Nil(t, New(0))
+
+	require.NoError(t, b.Acquire(context.Background()))
+	b.Release()
+	conn, err := b.DialContext(context.Background(), (&net.Dialer{}).DialContext, "tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	require.Equal(t, Stats{}, b.Stats())
+}
+
+func TestDial_UsesContextBudget(t *testing.T) {
+	addr := listen(t)
+	b := New(4)
+	dial := Dial((&net.Dialer{}).DialContext)
+
+	require.Same(t, b, FromContext(WithContext(context.Background(), b)))
+	require.Nil(t, FromContext(context.Background()))
+
+	conn, err := dial(WithContext(context.Background(), b), "tcp", addr)
+	require.NoError(t, err)
+	require.Equal(t, 1, b.Stats().InUse)
+	require.NoError(t, conn.Close())
+	require.Equal(t, 0, b.Stats().InUse)
+
+	// Without a budget in the context, dials are not counted
+	conn, err = dial(context.Background(), "tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	require.Equal(t, int64(1), b.Stats().Acquired)
+}
+
+func TestStats_Sub(t *testing.T) {
+	earlier := Stats{Limit: 10, Acquired: 5, Waits: 1, WaitTime: time.Second}
+	now := Stats{Limit: 10, InUse: 3, Peak: 8, Acquired: 12, Waits: 4, WaitTime: 3 * time.Second}
+	require.Equal(t, Stats{Limit: 10, InUse: 3, Peak: 8, Acquired: 7, Waits: 3, WaitTime: 2 * time.Second}, now.Sub(earlier))
+}
+
+func TestDefaultLimit(t *testing.T) {
+	require.Positive(t, DefaultLimit())
+	require.Same(t, Default(), Default())
+}
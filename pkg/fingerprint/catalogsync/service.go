@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/vulntor/vulntor/pkg/bandwidth"
 	"github.com/vulntor/vulntor/pkg/fingerprint"
 )
 
@@ -76,10 +77,12 @@ func (f FileSource) Load(_ context.Context) ([]byte, error) {
 	return os.ReadFile(f.Path)
 }
 
-// HTTPSource downloads the catalog from a URL using the provided http.Client (or default).
+// HTTPSource downloads the catalog from a URL using the provided http.Client (or default),
+// at the rate Limiter allows (unlimited when nil).
 type HTTPSource struct {
-	URL    string
-	Client *http.Client
+	URL     string
+	Client  *http.Client
+	Limiter *bandwidth.Limiter
 }
 
 func (h HTTPSource) Load(ctx context.Context) ([]byte, error) {
@@ -106,7 +109,7 @@ func (h HTTPSource) Load(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("unexpected status from catalog source: %s", resp.Status)
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	data, err := io.ReadAll(h.Limiter.Reader(ctx, resp.Body))
 	if err != nil {
 		return nil, fmt.Errorf("read catalog body: %w", err)
 	}
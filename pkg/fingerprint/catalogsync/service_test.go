@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
 )
 
 func TestFileSource_Load(t *testing.T) {
@@ -52,6 +54,9 @@ func TestHTTPSource_Load(t *testing.T) {
 	if b, err := (HTTPSource{URL: ts.URL}).Load(context.Background()); err != nil || len(b) == 0 {
 		t.Fatalf("expected ok, err=%v", err)
 	}
+	if b, err := (HTTPSource{URL: ts.URL, Limiter: bandwidth.NewLimiter(1 << 20)}).Load(context.Background()); err != nil || string(b) != "rules: []\n" {
+		t.Fatalf("expected ok with limiter, got %q, err=%v", b, err)
+	}
 	ts2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 	}))
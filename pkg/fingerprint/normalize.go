@@ -0,0 +1,216 @@
+package fingerprint
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed data/normalization.yaml
+var embeddedNormalizationYAML []byte
+
+// Dictionary maps the aliases of vendors and products to canonical names.
+type Dictionary struct {
+	Vendors  []VendorName  `yaml:"vendors"`
+	Products []ProductName `yaml:"products"`
+}
+
+// VendorName is the canonical name of a vendor.
+type VendorName struct {
+	Name    string   `yaml:"name"`    // Name shown in reports (e.g., "F5")
+	CPE     string   `yaml:"cpe"`     // CPE vendor component (e.g., "f5")
+	Aliases []string `yaml:"aliases"` // Other names of the vendor (e.g., "F5 Networks")
+}
+
+// ProductName is the canonical name of a product.
+type ProductName struct {
+	Name    string   `yaml:"name"`             // Name shown in reports (e.g., "MariaDB")
+	Vendor  string   `yaml:"vendor,omitempty"` // Canonical vendor name, set on results
+	CPE     string   `yaml:"cpe"`              // CPE product component (e.g., "mariadb")
+	Family  string   `yaml:"family,omitempty"` // Products treated alike (e.g., "mysql")
+	Aliases []string `yaml:"aliases"`          // Other names of the product
+}
+
+// ParseDictionary parses a YAML normalization dictionary.
+func ParseDictionary(data []byte) (Dictionary, error) {
+	var dict Dictionary
+	if err := yaml.Unmarshal(data, &dict); err != nil {
+		return Dictionary{}, fmt.Errorf("failed to parse normalization dictionary: %w", err)
+	}
+	for i, v := range dict.Vendors {
+		if strings.TrimSpace(v.Name) == "" {
+			return Dictionary{}, fmt.Errorf("vendors[%d]: name is required", i)
+		}
+	}
+	for i, p := range dict.Products {
+		if strings.TrimSpace(p.Name) == "" {
+			return Dictionary{}, fmt.Errorf("products[%d]: name is required", i)
+		}
+	}
+	return dict, nil
+}
+
+// Normalizer rewrites the vendor, product and CPE of results to canonical
+// names. A nil Normalizer leaves results unchanged.
+type Normalizer struct {
+	vendors  map[string]*VendorName
+	products map[string]*ProductName
+}
+
+// NewNormalizer returns a normalizer for the dictionaries. An entry of a
+// later dictionary replaces the entry of an earlier one with the same name,
+// aliases included.
+func NewNormalizer(dicts ...Dictionary) *Normalizer {
+	var merged Dictionary
+	vendorAt := make(map[string]int)
+	productAt := make(map[string]int)
+	for _, dict := range dicts {
+		for _, v := range dict.Vendors {
+			key := normalizationKey(v.Name)
+			if i, ok := vendorAt[key]; ok {
+				merged.Vendors[i] = v
+				continue
+			}
+			vendorAt[key] = len(merged.Vendors)
+			merged.Vendors = append(merged.Vendors, v)
+		}
+		for _, p := range dict.Products {
+			key := normalizationKey(p.Name)
+			if i, ok := productAt[key]; ok {
+				merged.Products[i] = p
+				continue
+			}
+			productAt[key] = len(merged.Products)
+			merged.Products = append(merged.Products, p)
+		}
+	}
+
+	n := &Normalizer{vendors: make(map[string]*VendorName), products: make(map[string]*ProductName)}
+	for i := range merged.Vendors {
+		v := &merged.Vendors[i]
+		for _, name := range append([]string{v.Name, v.CPE}, v.Aliases...) {
+			if key := normalizationKey(name); key != "" {
+				n.vendors[key] = v
+			}
+		}
+	}
+	for i := range merged.Products {
+		p := &merged.Products[i]
+		for _, name := range append([]string{p.Name, p.CPE}, p.Aliases...) {
+			if key := normalizationKey(name); key != "" {
+				n.products[key] = p
+			}
+		}
+	}
+	return n
+}
+
+// LoadNormalizer returns a normalizer for the built-in dictionary, extended
+// by the dictionary file at path when path is not empty.
+func LoadNormalizer(path string) (*Normalizer, error) {
+	builtin, err := ParseDictionary(embeddedNormalizationYAML)
+	if err != nil {
+		return nil, fmt.Errorf("built-in dictionary: %w", err)
+	}
+	if path == "" {
+		return NewNormalizer(builtin), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read normalization dictionary: %w", err)
+	}
+	custom, err := ParseDictionary(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return NewNormalizer(builtin, custom), nil
+}
+
+// Normalize returns r with the canonical names of its product and vendor,
+// the family of its product, and the CPE components of both.
+func (n *Normalizer) Normalize(r Result) Result {
+	if n == nil {
+		return r
+	}
+	part := cpeParts(r.CPE)
+
+	if p, ok := n.products[normalizationKey(r.Product)]; ok {
+		r.Product = p.Name
+		r.Family = p.Family
+		if p.Vendor != "" {
+			r.Vendor = p.Vendor
+		}
+		if p.CPE != "" && part != nil {
+			part[4] = p.CPE
+		}
+	} else if part != nil {
+		// The CPE names the product when the result names it differently
+		if p, ok := n.products[normalizationKey(part[4])]; ok && p.CPE != "" {
+			part[4] = p.CPE
+		}
+	}
+
+	if v, ok := n.vendors[normalizationKey(r.Vendor)]; ok {
+		r.Vendor = v.Name
+		if v.CPE != "" && part != nil {
+			part[3] = v.CPE
+		}
+	} else if part != nil {
+		if v, ok := n.vendors[normalizationKey(part[3])]; ok && v.CPE != "" {
+			part[3] = v.CPE
+		}
+	}
+
+	if part != nil {
+		r.CPE = strings.Join(part, ":")
+	}
+	return r
+}
+
+// cpeParts splits a CPE 2.3 name into its components, or returns nil when
+// cpe is not one. Names with escaped colons are not split.
+func cpeParts(cpe string) []string {
+	if !strings.HasPrefix(cpe, "cpe:2.3:") || strings.Contains(cpe, `\:`) {
+		return nil
+	}
+	part := strings.Split(cpe, ":")
+	if len(part) < 5 {
+		return nil
+	}
+	return part
+}
+
+// normalizationKey folds a name for comparison: lower case, with runs of
+// punctuation and spaces replaced by a single space.
+func normalizationKey(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// Holds the normalizer applied to fingerprint matches (default: built-in dictionary)
+var activeNormalizer *Normalizer
+
+func init() {
+	n, err := LoadNormalizer("")
+	if err != nil {
+		fmt.Printf("Failed to load embedded normalization dictionary: %v\n", err)
+		return
+	}
+	activeNormalizer = n
+}
+
+// RegisterNormalizer sets the normalizer applied to fingerprint matches.
+func RegisterNormalizer(n *Normalizer) {
+	activeNormalizer = n
+}
+
+// GetNormalizer returns the normalizer applied to fingerprint matches.
+func GetNormalizer() *Normalizer {
+	return activeNormalizer
+}
@@ -0,0 +1,93 @@
+package fingerprint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizer_Builtin(t *testing.T) {
+	n, err := LoadNormalizer("")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		in   Result
+		want Result
+	}{
+		{
+			name: "vendor alias",
+			in:   Result{Product: "nginx", Vendor: "NGINX, Inc.", CPE: "cpe:2.3:a:nginx:nginx:*:*:*:*:*:*:*:*"},
+			want: Result{Product: "nginx", Vendor: "F5", CPE: "cpe:2.3:a:f5:nginx:*:*:*:*:*:*:*:*"},
+		},
+		{
+			name: "product alias sets vendor and CPE",
+			in:   Result{Product: "Microsoft-IIS", Vendor: "Microsoft Corporation", CPE: "cpe:2.3:a:microsoft:iis:10.0:*:*:*:*:*:*:*"},
+			want: Result{Product: "IIS", Vendor: "Microsoft", CPE: "cpe:2.3:a:microsoft:internet_information_services:10.0:*:*:*:*:*:*:*"},
+		},
+		{
+			name: "family",
+			in:   Result{Product: "MariaDB Server", Vendor: "MariaDB Corporation Ab", Version: "10.6.12"},
+			want: Result{Product: "MariaDB", Vendor: "MariaDB", Family: "mysql", Version: "10.6.12"},
+		},
+		{
+			name: "CPE vendor alias",
+			in:   Result{Product: "Kibana", Vendor: "Elasticsearch BV", CPE: "cpe:2.3:a:elasticsearch:kibana:*:*:*:*:*:*:*:*"},
+			want: Result{Product: "Kibana", Vendor: "Elastic", CPE: "cpe:2.3:a:elastic:kibana:*:*:*:*:*:*:*:*"},
+		},
+		{
+			name: "unknown names are kept",
+			in:   Result{Product: "Acme Server", Vendor: "Acme", CPE: "cpe:/a:acme:server"},
+			want: Result{Product: "Acme Server", Vendor: "Acme", CPE: "cpe:/a:acme:server"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, n.Normalize(tt.in))
+		})
+	}
+
+	// Every built-in rule keeps a product and vendor
+	for _, rule := range loadBuiltinRules() {
+		got := n.Normalize(Result{Product: rule.Product, Vendor: rule.Vendor, CPE: rule.CPE})
+		require.NotEmpty(t, got.Product, rule.ID)
+		require.NotEmpty(t, got.Vendor, rule.ID)
+		require.Len(t, cpeParts(got.CPE), 13, rule.ID)
+	}
+
+	var nilNormalizer *Normalizer
+	require.Equal(t, Result{Product: "x"}, nilNormalizer.Normalize(Result{Product: "x"}))
+}
+
+func TestLoadNormalizer_CustomDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aliases.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+vendors:
+  - name: Acme Corp
+    cpe: acme
+    aliases: [acme inc]
+products:
+  - name: MariaDB
+    vendor: MariaDB
+    cpe: mariadb
+    family: mariadb
+`), 0o600))
+
+	n, err := LoadNormalizer(path)
+	require.NoError(t, err)
+	require.Equal(t, Result{Vendor: "Acme Corp", CPE: "cpe:2.3:a:acme:widget"}, n.Normalize(Result{Vendor: "ACME Inc.", CPE: "cpe:2.3:a:acme_inc:widget"}))
+	// Custom entries replace built-in ones with their aliases, and the
+	// other built-in ones still apply
+	require.Equal(t, "mariadb", n.Normalize(Result{Product: "MariaDB"}).Family)
+	require.Equal(t, "MariaDB Server", n.Normalize(Result{Product: "MariaDB Server"}).Product)
+	require.Equal(t, "F5", n.Normalize(Result{Vendor: "F5 Networks"}).Vendor)
+
+	require.NoError(t, os.WriteFile(path, []byte("products:\n  - cpe: x\n"), 0o600))
+	_, err = LoadNormalizer(path)
+	require.ErrorContains(t, err, "products[0]: name is required")
+
+	_, err = LoadNormalizer(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "read normalization dictionary")
+}
@@ -0,0 +1,239 @@
+package fingerprint
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// literalFilter skips the rules whose match regex cannot match a banner,
+// without running the regex: it finds, in a single pass over the banner,
+// the literals extracted from the match regexes with an Aho–Corasick
+// automaton. A rule whose regex has no required literal is always
+// evaluated.
+type literalFilter struct {
+	// always lists the rules without a required literal
+	always []int
+	nodes  []acNode
+	rules  int
+}
+
+// acNode is a state of the Aho–Corasick automaton.
+type acNode struct {
+	next map[byte]int32
+	fail int32
+	// out lists the rules having a literal ending at this state, including
+	// through the failure links
+	out []int
+}
+
+// newLiteralFilter builds the filter of rules, whose regexes are compiled.
+func newLiteralFilter(rules []StaticRule) *literalFilter {
+	f := &literalFilter{nodes: []acNode{{}}, rules: len(rules)}
+	for i, rule := range rules {
+		lits := requiredLiterals(rule.matchRegex.String())
+		if len(lits) == 0 {
+			f.always = append(f.always, i)
+			continue
+		}
+		for _, lit := range lits {
+			f.insert(lit, i)
+		}
+	}
+	f.link()
+	return f
+}
+
+// insert adds the literal of rule to the trie.
+func (f *literalFilter) insert(lit string, rule int) {
+	state := int32(0)
+	for i := 0; i < len(lit); i++ {
+		next, ok := f.nodes[state].next[lit[i]]
+		if !ok {
+			next = int32(len(f.nodes))
+			f.nodes = append(f.nodes, acNode{})
+			if f.nodes[state].next == nil {
+				f.nodes[state].next = make(map[byte]int32)
+			}
+			f.nodes[state].next[lit[i]] = next
+		}
+		state = next
+	}
+	if out := f.nodes[state].out; len(out) == 0 || out[len(out)-1] != rule {
+		f.nodes[state].out = append(out, rule)
+	}
+}
+
+// link computes the failure links of the trie breadth first, and merges
+// the outputs of the states they point to.
+func (f *literalFilter) link() {
+	queue := make([]int32, 0, len(f.nodes))
+	for _, child := range f.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for b, child := range f.nodes[state].next {
+			fail := f.nodes[state].fail
+			for {
+				if next, ok := f.nodes[fail].next[b]; ok {
+					f.nodes[child].fail = next
+					break
+				}
+				if fail == 0 {
+					break
+				}
+				fail = f.nodes[fail].fail
+			}
+			f.nodes[child].out = append(f.nodes[child].out, f.nodes[f.nodes[child].fail].out...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// candidates returns the rules that may match banner: those a literal of
+// which appears in it, and those without literals.
+func (f *literalFilter) candidates(banner string) ruleSet {
+	set := newRuleSet(f.rules)
+	for _, i := range f.always {
+		set.add(i)
+	}
+	state := int32(0)
+	for i := 0; i < len(banner); i++ {
+		for {
+			if next, ok := f.nodes[state].next[banner[i]]; ok {
+				state = next
+				break
+			}
+			if state == 0 {
+				break
+			}
+			state = f.nodes[state].fail
+		}
+		for _, rule := range f.nodes[state].out {
+			set.add(rule)
+		}
+	}
+	return set
+}
+
+// ruleSet is a set of rule positions.
+type ruleSet []uint64
+
+func newRuleSet(n int) ruleSet {
+	return make(ruleSet, (n+63)/64)
+}
+
+func (s ruleSet) add(i int) {
+	s[i/64] |= 1 << (i % 64)
+}
+
+func (s ruleSet) has(i int) bool {
+	return s[i/64]&(1<<(i%64)) != 0
+}
+
+// requiredLiterals returns literals one of which appears in any string the
+// regex pattern matches, or nil when there are none (or the pattern does not
+// parse). Case-insensitive parts of the pattern yield no literal.
+func requiredLiterals(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	lits, ok := literalsOf(re.Simplify())
+	if !ok {
+		return nil
+	}
+	return lits
+}
+
+// literalsOf returns the literals one of which any match of re contains,
+// preferring the longest ones.
+func literalsOf(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		lit, ok := literalString(re)
+		if !ok {
+			return nil, false
+		}
+		return []string{lit}, true
+	case syntax.OpCapture, syntax.OpPlus:
+		return literalsOf(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min < 1 {
+			return nil, false
+		}
+		return literalsOf(re.Sub[0])
+	case syntax.OpAlternate:
+		var all []string
+		for _, sub := range re.Sub {
+			lits, ok := literalsOf(sub)
+			if !ok {
+				return nil, false
+			}
+			all = append(all, lits...)
+		}
+		return all, true
+	case syntax.OpConcat:
+		// Adjacent literals form one longer literal
+		var (
+			best    []string
+			found   bool
+			pending strings.Builder
+		)
+		consider := func(lits []string) {
+			if !found || shortest(lits) > shortest(best) {
+				best, found = lits, true
+			}
+		}
+		flush := func() {
+			if pending.Len() > 0 {
+				consider([]string{pending.String()})
+				pending.Reset()
+			}
+		}
+		for _, sub := range re.Sub {
+			if sub.Op == syntax.OpLiteral {
+				if lit, ok := literalString(sub); ok {
+					pending.WriteString(lit)
+					continue
+				}
+			}
+			flush()
+			if lits, ok := literalsOf(sub); ok {
+				consider(lits)
+			}
+		}
+		flush()
+		return best, found
+	default:
+		return nil, false
+	}
+}
+
+// literalString returns the text of a case-sensitive literal. Banners are
+// matched as valid UTF-8, so a literal with the replacement character is
+// not searched for.
+func literalString(re *syntax.Regexp) (string, bool) {
+	if re.Flags&syntax.FoldCase != 0 || len(re.Rune) == 0 {
+		return "", false
+	}
+	for _, r := range re.Rune {
+		if r == utf8.RuneError {
+			return "", false
+		}
+	}
+	return string(re.Rune), true
+}
+
+// shortest returns the length of the shortest of lits.
+func shortest(lits []string) int {
+	n := -1
+	for _, lit := range lits {
+		if n < 0 || len(lit) < n {
+			n = len(lit)
+		}
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+package fingerprint
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{`server:\s*apache`, []string{"server:"}},
+		{`ssh-\d\.\d+-openssh_`, []string{"-openssh_"}},
+		{`mongodb|"version"`, []string{"mongodb", `"version"`}},
+		{`server:\s*(?:apache-coyote|tomcat)`, []string{"server:"}},
+		{`(?:apache-coyote|tomcat)/\d`, []string{"apache-coyote", "tomcat"}},
+		{`\x00\x00\x00\x0a`, []string{"\x00\x00\x00\x0a"}},
+		{`(redis)+_version`, []string{"_version"}},
+		{`^routeros\b`, []string{"routeros"}},
+		{`version\s+[\d\.]+`, []string{"version"}},
+		{`(?:foo)?bar*`, []string{"ba"}},
+		{`\d+\.\d+`, []string{"."}},
+		// No literal every match contains
+		{`rfb|vnc|`, nil},
+		{`\d+`, nil},
+		{`(?i)apache`, nil},
+		{`[`, nil},
+	}
+	for _, tt := range tests {
+		got := requiredLiterals(tt.pattern)
+		slices.Sort(got)
+		slices.Sort(tt.want)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("requiredLiterals(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestLiteralFilter_Candidates(t *testing.T) {
+	rules := prepareRules([]StaticRule{
+		{ID: "apache", Match: `server:\s*apache`},
+		{ID: "nginx", Match: `nginx`},
+		{ID: "any-version", Match: `v?\d+`},
+		{ID: "ftp", Match: `pure-ftpd|proftpd`},
+		{ID: "ssh", Match: `openssh`},
+	})
+	f := newLiteralFilter(rules)
+
+	tests := []struct {
+		banner string
+		want   []string
+	}{
+		{"server: nginx/1.18.0", []string{"apache", "nginx", "any-version"}},
+		{"220 proftpd 1.3.5 server", []string{"any-version", "ftp"}},
+		{"ssh-2.0-openssh_8.9", []string{"any-version", "ssh"}},
+		{"", []string{"any-version"}},
+	}
+	for _, tt := range tests {
+		set := f.candidates(tt.banner)
+		var got []string
+		for i, rule := range rules {
+			if set.has(i) {
+				got = append(got, rule.ID)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("candidates(%q) = %v, want %v", tt.banner, got, tt.want)
+		}
+	}
+}
+
+// The filter must never skip a rule whose regex matches a banner.
+func TestLiteralFilter_KeepsEveryMatchingRule(t *testing.T) {
+	rules := prepareRules(loadBuiltinRules())
+	ds, err := LoadValidationDataset("testdata/validation_dataset.yaml")
+	if err != nil {
+		t.Fatalf("load dataset: %v", err)
+	}
+	f := newLiteralFilter(rules)
+
+	cases := slices.Concat(ds.TruePositives, ds.TrueNegatives, ds.EdgeCases)
+	if len(cases) == 0 {
+		t.Fatal("empty validation dataset")
+	}
+	for _, tc := range cases {
+		banner := strings.ToLower(tc.Banner)
+		set := f.candidates(banner)
+		for i, rule := range rules {
+			if rule.matchRegex.MatchString(banner) && !set.has(i) {
+				t.Errorf("rule %s matches %q but was filtered out", rule.ID, banner)
+			}
+		}
+	}
+
+	// Overlapping literals are all found
+	overlap := prepareRules([]StaticRule{
+		{ID: "a", Match: regexp.QuoteMeta("abcd")},
+		{ID: "b", Match: "bc"},
+		{ID: "c", Match: "bcx"},
+	})
+	set := newLiteralFilter(overlap).candidates("xabcdbcx")
+	for i := range overlap {
+		if !set.has(i) {
+			t.Errorf("rule %s was filtered out", overlap[i].ID)
+		}
+	}
+}
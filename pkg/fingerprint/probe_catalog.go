@@ -26,11 +26,14 @@ type ProbeGroup struct {
 
 // ProbeSpec describes a single active probe that banner_grab can execute.
 // The payload is a raw string that will be written as-is to the remote endpoint.
+// StartTLS probes upgrade a plaintext connection with the protocol's STARTTLS
+// command before writing the payload.
 type ProbeSpec struct {
 	ID              string            `yaml:"id" json:"id"`
 	Description     string            `yaml:"description,omitempty" json:"description,omitempty"`
 	Protocol        string            `yaml:"protocol" json:"protocol"`
 	UseTLS          bool              `yaml:"use_tls,omitempty" json:"use_tls,omitempty"`
+	StartTLS        bool              `yaml:"starttls,omitempty" json:"starttls,omitempty"`
 	Payload         string            `yaml:"payload" json:"payload"`
 	Headers         map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
 	Timeout         string            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
@@ -176,6 +179,9 @@ func (c *ProbeCatalog) Validate() error {
 			if probe.Payload == "" {
 				return fmt.Errorf("probe %q missing payload", probe.ID)
 			}
+			if probe.StartTLS && probe.UseTLS {
+				return fmt.Errorf("probe %q cannot use both use_tls and starttls", probe.ID)
+			}
 		}
 	}
 	return nil
@@ -25,6 +25,17 @@ func TestGetProbeCatalog(t *testing.T) {
 	if _, ok := seen["http-get"]; !ok {
 		t.Fatalf("expected http-get probe in result: %v", probes)
 	}
+
+	// Plaintext mail ports get a STARTTLS probe; implicit TLS ports do not
+	for port, want := range map[int]bool{25: true, 465: false, 143: true, 993: false} {
+		starttls := false
+		for _, p := range catalog.ProbesFor(port, nil) {
+			starttls = starttls || p.StartTLS
+		}
+		if starttls != want {
+			t.Fatalf("port %d: expected STARTTLS probe %v, got %v", port, want, starttls)
+		}
+	}
 }
 
 func TestProbeCatalogValidate(t *testing.T) {
@@ -142,4 +153,10 @@ func TestProbeCatalog_Validate_Errors(t *testing.T) {
 	if err := c.Validate(); err == nil {
 		t.Fatalf("expected probe missing payload error")
 	}
+
+	// probe both implicit and STARTTLS
+	c = ProbeCatalog{Groups: []ProbeGroup{{ID: "g", Probes: []ProbeSpec{{ID: "p", Protocol: "smtp", Payload: "y", UseTLS: true, StartTLS: true}}}}}
+	if err := c.Validate(); err == nil {
+		t.Fatalf("expected use_tls and starttls error")
+	}
 }
@@ -29,6 +29,7 @@ type Result struct {
 	Product     string  // Product name (e.g., "LiteSpeed Web Server")
 	Version     string  // Version string (e.g., "6.1")
 	Vendor      string  // Vendor name (e.g., "LiteSpeed Technologies")
+	Family      string  // Product family set by normalization (e.g., "mysql" for MariaDB)
 	CPE         string  // Normalized CPE identifier (e.g., "cpe:2.3:a:...")
 	Confidence  float64 // Confidence score (0.0–1.0), especially for AI-based resolution
 	Technique   string  // Technique used, e.g., "static" or "ml"
@@ -59,7 +59,9 @@ func calculateConfidence(base, softPenalty, portBonus float64) float64 {
 //     return x
 // }
 
-// normalizeVersion trims and lowercases a version-like string.
+// normalizeVersion trims and lowercases a version-like string. Versions are
+// reported as extracted (e.g. "003.008"); plugins compare them with
+// pkg/version, which reads them as their canonical form.
 func normalizeVersion(s string) string {
 	return strings.TrimSpace(strings.ToLower(s))
 }
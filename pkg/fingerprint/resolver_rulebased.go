@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 // StaticRule defines a fingerprint rule loaded from fingerprint_db.yaml.
@@ -42,12 +44,38 @@ type StaticRule struct {
 // RuleBasedResolver uses a preloaded list of static rules to resolve banners into metadata.
 type RuleBasedResolver struct {
 	rules     []StaticRule
+	index     ruleIndex
+	filter    *literalFilter
 	telemetry *TelemetryWriter
 }
 
+// ruleIndex locates the rules relevant to a banner without scanning all of
+// them. Both maps hold positions in the rules slice, in rule order.
+type ruleIndex struct {
+	byProtocol map[string][]int
+	// byPort buckets the rules by the ports of their port bonuses, for
+	// banners without a protocol hint
+	byPort map[int][]int
+}
+
+// newRuleIndex indexes rules by protocol and port.
+func newRuleIndex(rules []StaticRule) ruleIndex {
+	idx := ruleIndex{byProtocol: make(map[string][]int), byPort: make(map[int][]int)}
+	for i, rule := range rules {
+		idx.byProtocol[rule.Protocol] = append(idx.byProtocol[rule.Protocol], i)
+		for _, port := range rule.PortBonuses {
+			if bucket := idx.byPort[port]; len(bucket) == 0 || bucket[len(bucket)-1] != i {
+				idx.byPort[port] = append(bucket, i)
+			}
+		}
+	}
+	return idx
+}
+
 // NewRuleBasedResolver initializes a resolver using fingerprint rules loaded from a YAML file.
 func NewRuleBasedResolver(rules []StaticRule) *RuleBasedResolver {
-	return &RuleBasedResolver{rules: prepareRules(rules), telemetry: nil}
+	prepared := prepareRules(rules)
+	return &RuleBasedResolver{rules: prepared, index: newRuleIndex(prepared), filter: newLiteralFilter(prepared), telemetry: nil}
 }
 
 // SetTelemetry configures telemetry writer for the resolver.
@@ -55,13 +83,24 @@ func (r *RuleBasedResolver) SetTelemetry(telemetry *TelemetryWriter) {
 	r.telemetry = telemetry
 }
 
+// candidate is a rule that matched a banner with enough confidence.
+type candidate struct {
+	pos        int
+	rule       *StaticRule
+	version    string
+	confidence float64
+}
+
 // Resolve attempts to identify a fingerprint based on the provided FingerprintInput.
-// It normalizes the input banner, iterates through the resolver's rules, and checks for a matching protocol and banner pattern.
-// If a rule matches, it extracts the version (if available) using the rule's versionRegex, and returns a FingerprintResult
-// populated with the rule's metadata and a high confidence score. If no rule matches, it returns an error.
+// It normalizes the input banner, scores the rules of the input's protocol against it, and
+// returns a FingerprintResult populated with the metadata of the most confident match, with
+// the version extracted by the rule's versionRegex (if available). If no rule matches, it
+// returns an error.
 //
 // Phase 1: If in.Protocol is empty, "tcp", or "udp" (generic transport), this method will try ALL rules
 // as a fallback mechanism. This enables detection on non-standard ports (e.g., MySQL on 3210, HTTP on 2096).
+// The rules with a port bonus for in.Port are scored first; the others only when they can reach
+// the best confidence among them, as they get no port bonus.
 //
 // Parameters:
 //
@@ -72,28 +111,101 @@ func (r *RuleBasedResolver) SetTelemetry(telemetry *TelemetryWriter) {
 //
 //	Result - The result of the fingerprinting process, populated if a rule matches.
 //	error             - An error if no matching rule is found.
-//
-//nolint:gocyclo // Telemetry logging adds complexity, refactor planned for later
-func (r *RuleBasedResolver) Resolve(_ context.Context, in Input) (Result, error) {
-	normalizedBanner := strings.ToLower(in.Banner)
+func (r *RuleBasedResolver) Resolve(ctx context.Context, in Input) (Result, error) {
+	_, span := tracing.Start(ctx, "fingerprint.resolve",
+		tracing.String("fingerprint.protocol", in.Protocol),
+		tracing.Int("net.port", in.Port),
+		tracing.Int("fingerprint.rules", len(r.rules)))
+	defer span.End()
 
-	type candidate struct {
-		rule       StaticRule
-		version    string
-		confidence float64
-	}
-	cands := make([]candidate, 0, 8)
+	normalizedBanner := strings.ToLower(in.Banner)
 
 	// Phase 1: Determine if we should try all rules (fallback mode)
 	// Fallback activates when protocol hint is generic (tcp/udp) or unknown
 	useFallback := in.Protocol == "" || in.Protocol == "tcp" || in.Protocol == "udp"
 
-	for _, rule := range r.rules {
-		// Phase 1: Skip protocol check if fallback mode is active
-		if !useFallback && rule.Protocol != in.Protocol {
-			continue // skip unrelated protocol (fast path)
+	// Only the rules whose literals appear in the banner can match it
+	possible := r.filter.candidates(normalizedBanner)
+
+	var cands []candidate
+	scored := 0
+	if !useFallback {
+		cands = r.score(in, normalizedBanner, possible, r.index.byProtocol[in.Protocol])
+		scored = len(r.index.byProtocol[in.Protocol])
+	} else {
+		bucket := r.index.byPort[in.Port]
+		if in.Port > 0 && len(bucket) > 0 {
+			cands = r.score(in, normalizedBanner, possible, bucket)
+			scored = len(bucket)
+		}
+		bucketBest := 0.0
+		for _, c := range cands {
+			bucketBest = max(bucketBest, c.confidence)
+		}
+		others := make([]int, 0, len(r.rules)-len(bucket))
+		for i := range r.rules {
+			if in.Port > 0 && containsPort(r.rules[i].PortBonuses, in.Port) {
+				continue
+			}
+			if len(cands) > 0 && calculateConfidence(r.rules[i].PatternStrength, 0, 0) < bucketBest {
+				continue
+			}
+			others = append(others, i)
 		}
-		if !rule.matchRegex.MatchString(normalizedBanner) {
+		cands = append(cands, r.score(in, normalizedBanner, possible, others)...)
+		scored += len(others)
+	}
+	span.SetAttributes(tracing.Int("fingerprint.rules_scored", scored))
+
+	if len(cands) == 0 {
+		// Log no match if telemetry is enabled
+		if r.telemetry != nil && r.telemetry.IsEnabled() {
+			_ = r.telemetry.WriteNoMatch("", in.Port, in.Protocol, "static")
+		}
+		span.SetAttributes(tracing.Bool("fingerprint.matched", false))
+		return Result{}, fmt.Errorf("no matching rule found")
+	}
+	// Ties go to the earlier rule
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].confidence != cands[j].confidence {
+			return cands[i].confidence > cands[j].confidence
+		}
+		return cands[i].pos < cands[j].pos
+	})
+	best := cands[0]
+
+	result := Result{
+		Product:     best.rule.Product,
+		Vendor:      best.rule.Vendor,
+		Version:     best.version,
+		CPE:         best.rule.CPE,
+		Confidence:  best.confidence,
+		Technique:   "static",
+		Description: best.rule.Description,
+	}
+
+	// Log successful match if telemetry is enabled
+	if r.telemetry != nil && r.telemetry.IsEnabled() {
+		_ = r.telemetry.WriteSuccess("", in.Port, in.Protocol, result, "static", best.rule.ID)
+	}
+
+	span.SetAttributes(
+		tracing.Bool("fingerprint.matched", true),
+		tracing.String("fingerprint.rule", best.rule.ID),
+		tracing.String("fingerprint.product", result.Product),
+		tracing.Float("fingerprint.confidence", result.Confidence))
+
+	return result, nil
+}
+
+// score matches the rules at positions against the normalized banner, and
+// returns those matching with enough confidence, in rule order. Rules not in
+// possible are known not to match.
+func (r *RuleBasedResolver) score(in Input, normalizedBanner string, possible ruleSet, positions []int) []candidate {
+	cands := make([]candidate, 0, 8)
+	for _, i := range positions {
+		rule := &r.rules[i]
+		if !possible.has(i) || !rule.matchRegex.MatchString(normalizedBanner) {
 			continue
 		}
 		// Hard exclude
@@ -132,35 +244,9 @@ func (r *RuleBasedResolver) Resolve(_ context.Context, in Input) (Result, error)
 			}
 			continue
 		}
-		cands = append(cands, candidate{rule: rule, version: version, confidence: conf})
+		cands = append(cands, candidate{pos: i, rule: rule, version: version, confidence: conf})
 	}
-
-	if len(cands) == 0 {
-		// Log no match if telemetry is enabled
-		if r.telemetry != nil && r.telemetry.IsEnabled() {
-			_ = r.telemetry.WriteNoMatch("", in.Port, in.Protocol, "static")
-		}
-		return Result{}, fmt.Errorf("no matching rule found")
-	}
-	sort.SliceStable(cands, func(i, j int) bool { return cands[i].confidence > cands[j].confidence })
-	best := cands[0]
-
-	result := Result{
-		Product:     best.rule.Product,
-		Vendor:      best.rule.Vendor,
-		Version:     best.version,
-		CPE:         best.rule.CPE,
-		Confidence:  best.confidence,
-		Technique:   "static",
-		Description: best.rule.Description,
-	}
-
-	// Log successful match if telemetry is enabled
-	if r.telemetry != nil && r.telemetry.IsEnabled() {
-		_ = r.telemetry.WriteSuccess("", in.Port, in.Protocol, result, "static", best.rule.ID)
-	}
-
-	return result, nil
+	return cands
 }
 
 func prepareRules(rules []StaticRule) []StaticRule {
@@ -2,6 +2,7 @@ package fingerprint
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 
@@ -55,6 +56,35 @@ func BenchmarkResolverMultipleRules(b *testing.B) {
 	}
 }
 
+// BenchmarkResolverLargeRulePack benchmarks resolver with thousands of rules, of which few are relevant to the banner.
+func BenchmarkResolverLargeRulePack(b *testing.B) {
+	protocols := []string{"http", "ssh", "ftp", "smtp", "mysql", "redis", "postgresql", "imap"}
+	rules := make([]StaticRule, 0, 5000)
+	for i := 0; i < 5000; i++ {
+		rules = append(rules, StaticRule{
+			ID:              fmt.Sprintf("bench.rule.%d", i),
+			Protocol:        protocols[i%len(protocols)],
+			Product:         fmt.Sprintf("Product%d", i),
+			Match:           fmt.Sprintf(`product%d/`, i),
+			PatternStrength: 0.80,
+			PortBonuses:     []int{10000 + i%500},
+		})
+	}
+	resolver := NewRuleBasedResolver(rules)
+
+	inputs := []Input{
+		{Port: 10008, Protocol: "http", Banner: "Server: Product4008/1.0"},
+		{Port: 10008, Protocol: "tcp", Banner: "Server: Product4008/1.0"},
+	}
+	for _, input := range inputs {
+		b.Run(input.Protocol, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = resolver.Resolve(context.Background(), input)
+			}
+		})
+	}
+}
+
 // BenchmarkResolverNoMatch benchmarks resolver when no rules match.
 func BenchmarkResolverNoMatch(b *testing.B) {
 	rules, err := LoadRulesFromFile("data/fingerprint_db.yaml")
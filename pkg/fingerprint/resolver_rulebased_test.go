@@ -165,6 +165,118 @@ func TestResolve_SkipsDifferentProtocolAndNonMatchingAndHardExclude(t *testing.T
 	}
 }
 
+func TestNewRuleIndex(t *testing.T) {
+	rules := []StaticRule{
+		{ID: "a", Protocol: "http", PortBonuses: []int{80, 8080, 80}},
+		{ID: "b", Protocol: "ssh", PortBonuses: []int{22}},
+		{ID: "c", Protocol: "http", PortBonuses: []int{80}},
+	}
+	idx := newRuleIndex(rules)
+
+	if got := idx.byProtocol["http"]; len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Fatalf("unexpected http rules: %v", got)
+	}
+	if got := idx.byPort[80]; len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Fatalf("unexpected port 80 rules (duplicates?): %v", got)
+	}
+	if got := idx.byPort[22]; len(got) != 1 || got[0] != 1 {
+		t.Fatalf("unexpected port 22 rules: %v", got)
+	}
+	if _, ok := idx.byPort[443]; ok {
+		t.Fatalf("unexpected bucket for port 443")
+	}
+}
+
+func TestResolve_FallbackScoresPortBucketFirst(t *testing.T) {
+	rules := []StaticRule{
+		{ // stronger, but no bonus for the port
+			ID:              "generic",
+			Protocol:        "http",
+			Product:         "Generic",
+			Match:           `server:`,
+			PatternStrength: 0.95,
+		},
+		{
+			ID:              "alt",
+			Protocol:        "http",
+			Product:         "AltHTTP",
+			Match:           `server: alt`,
+			PatternStrength: 0.70,
+			PortBonuses:     []int{8081},
+		},
+		{ // weaker than the bucket's best even without a soft penalty
+			ID:              "weak",
+			Protocol:        "http",
+			Product:         "Weak",
+			Match:           `server: alt`,
+			PatternStrength: 0.60,
+		},
+	}
+	rb := NewRuleBasedResolver(rules)
+
+	// The best match lies outside the port bucket: it still wins
+	res, err := rb.Resolve(context.TODO(), Input{Protocol: "tcp", Port: 8081, Banner: "Server: alt/1.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Product != "Generic" {
+		t.Fatalf("expected Generic from outside the port bucket, got %s", res.Product)
+	}
+
+	// No rule of the bucket matches: all the others are scored
+	res, err = rb.Resolve(context.TODO(), Input{Protocol: "tcp", Port: 8081, Banner: "Server: other"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Product != "Generic" {
+		t.Fatalf("expected fallback to Generic, got %s", res.Product)
+	}
+
+	// Without a bucket for the port, the strongest of all rules wins
+	res, err = rb.Resolve(context.TODO(), Input{Protocol: "", Port: 9000, Banner: "Server: alt/1.0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Product != "Generic" {
+		t.Fatalf("expected Generic, got %s", res.Product)
+	}
+
+	// Protocol-specific inputs only score the rules of their protocol
+	if _, err := rb.Resolve(context.TODO(), Input{Protocol: "ssh", Port: 8081, Banner: "Server: alt/1.0"}); err == nil {
+		t.Fatalf("expected no match for ssh")
+	}
+}
+
+func TestResolve_FallbackSkipsRulesBelowPortBucket(t *testing.T) {
+	rules := []StaticRule{
+		{ID: "a", Protocol: "http", Product: "A", Match: `server: a`, PatternStrength: 0.70},
+		{ID: "b", Protocol: "http", Product: "B", Match: `server: a`, PatternStrength: 0.75, PortBonuses: []int{8081}},
+		{ID: "c", Protocol: "http", Product: "C", Match: `server: a`, PatternStrength: 0.80},
+	}
+	rb := NewRuleBasedResolver(rules)
+
+	// B reaches 0.80 with its port bonus; A cannot, C ties and, as the
+	// later rule, loses like it would if all rules were scored together
+	res, err := rb.Resolve(context.TODO(), Input{Protocol: "tcp", Port: 8081, Banner: "Server: a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Product != "B" || res.Confidence != 0.80 {
+		t.Fatalf("expected B at 0.80, got %s at %.2f", res.Product, res.Confidence)
+	}
+
+	// A stronger rule outside the bucket wins over the bucket
+	rules[2].PatternStrength = 0.85
+	rb = NewRuleBasedResolver(rules)
+	res, err = rb.Resolve(context.TODO(), Input{Protocol: "tcp", Port: 8081, Banner: "Server: a"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Product != "C" {
+		t.Fatalf("expected C, got %s", res.Product)
+	}
+}
+
 func TestResolve_RegexNonMatchingBranchIsTaken(t *testing.T) {
 	rules := []StaticRule{
 		{
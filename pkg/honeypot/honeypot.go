@@ -0,0 +1,140 @@
+// Package honeypot flags hosts whose responses are more likely a honeypot
+// or tarpit than real services, so their findings can be reported as low
+// confidence instead of flooding reports with false positives:
+//
+//   - hosts open on implausibly many ports,
+//   - hosts sending the same banner on many ports, and
+//   - hosts accepting connections on many ports without ever sending data,
+//     as LaBrea-style tarpits do.
+//
+// A nil *Detector flags nothing, so code paths without detection configured
+// keep every finding as reported.
+package honeypot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default thresholds, used for zero Thresholds fields.
+const (
+	DefaultMaxOpenPorts     = 100
+	DefaultIdenticalBanners = 5
+	DefaultTarpitPorts      = 10
+)
+
+// Thresholds configure when a host is flagged. Zero fields take their
+// default.
+type Thresholds struct {
+	// MaxOpenPorts is the number of open ports above which a host is
+	// flagged.
+	MaxOpenPorts int
+
+	// IdenticalBanners is the number of ports sending the same banner at
+	// which a host is flagged.
+	IdenticalBanners int
+
+	// TarpitPorts is the number of open ports, none of which sent any
+	// data, at which a host is flagged as a tarpit.
+	TarpitPorts int
+}
+
+// Port is an open port of a host.
+type Port struct {
+	Port int
+
+	// Banner is what the port sent when connected to, empty if nothing.
+	Banner string
+
+	// Responded reports whether the port sent any data, to the banner grab
+	// or any later probe.
+	Responded bool
+}
+
+// Host is an IP address and its open ports.
+type Host struct {
+	IP    string
+	Ports []Port
+}
+
+// Detector flags honeypot and tarpit hosts by Thresholds.
+type Detector struct {
+	thresholds Thresholds
+}
+
+// New returns a Detector flagging hosts by t.
+func New(t Thresholds) *Detector {
+	if t.MaxOpenPorts <= 0 {
+		t.MaxOpenPorts = DefaultMaxOpenPorts
+	}
+	if t.IdenticalBanners <= 0 {
+		t.IdenticalBanners = DefaultIdenticalBanners
+	}
+	if t.TarpitPorts <= 0 {
+		t.TarpitPorts = DefaultTarpitPorts
+	}
+	return &Detector{thresholds: t}
+}
+
+// Thresholds returns the thresholds of d, with defaults applied.
+func (d *Detector) Thresholds() Thresholds {
+	if d == nil {
+		return Thresholds{}
+	}
+	return d.thresholds
+}
+
+// Detect returns why h looks like a honeypot or tarpit, one reason per
+// heuristic it trips, or nil if it looks like a real host.
+func (d *Detector) Detect(h Host) []string {
+	if d == nil || len(h.Ports) == 0 {
+		return nil
+	}
+
+	var reasons []string
+	if len(h.Ports) > d.thresholds.MaxOpenPorts {
+		reasons = append(reasons, fmt.Sprintf("open on %d ports", len(h.Ports)))
+	}
+
+	responded := 0
+	banners := make(map[string]int)
+	for _, p := range h.Ports {
+		if p.Responded || p.Banner != "" {
+			responded++
+		}
+		if banner := strings.TrimSpace(p.Banner); banner != "" {
+			banners[banner]++
+		}
+	}
+
+	repeated := 0
+	for _, n := range banners {
+		repeated = max(repeated, n)
+	}
+	if repeated >= d.thresholds.IdenticalBanners {
+		reasons = append(reasons, fmt.Sprintf("same banner on %d ports", repeated))
+	}
+
+	if responded == 0 && len(h.Ports) >= d.thresholds.TarpitPorts {
+		reasons = append(reasons, fmt.Sprintf("accepts connections on %d ports without sending data (tarpit)", len(h.Ports)))
+	}
+	return reasons
+}
+
+// DetectAll returns the reasons of each host of hosts that looks like a
+// honeypot or tarpit, by IP.
+func (d *Detector) DetectAll(hosts []Host) map[string][]string {
+	flagged := make(map[string][]string)
+	for _, h := range hosts {
+		if reasons := d.Detect(h); len(reasons) > 0 {
+			flagged[h.IP] = reasons
+		}
+	}
+	return flagged
+}
+
+// Reason joins the reasons of a flagged host into the sentence reports show
+// for its findings.
+func Reason(reasons []string) string {
+	return "Possible honeypot or tarpit: " + strings.Join(reasons, "; ")
+}
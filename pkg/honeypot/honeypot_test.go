@@ -0,0 +1,59 @@
+package honeypot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ports returns n open ports sending banner, from port 1.
+func ports(n int, banner string) []Port {
+	out := make([]Port, n)
+	for i := range out {
+		out[i] = Port{Port: i + 1, Banner: banner, Responded: banner != ""}
+	}
+	return out
+}
+
+func TestDetector_Detect(t *testing.T) {
+	var none *Detector
+	require.Nil(t, none.Detect(Host{IP: "10.0.0.5", Ports: ports(500, "")}))
+
+	d := New(Thresholds{})
+	require.Equal(t, Thresholds{MaxOpenPorts: 100, IdenticalBanners: 5, TarpitPorts: 10}, d.Thresholds())
+
+	// A typical server
+	server := Host{IP: "10.0.0.5", Ports: []Port{
+		{Port: 22, Banner: "SSH-2.0-OpenSSH_9.6", Responded: true},
+		{Port: 80, Responded: true},
+		{Port: 443, Responded: true},
+	}}
+	require.Nil(t, d.Detect(server))
+
+	many := d.Detect(Host{IP: "10.0.0.6", Ports: ports(101, "")})
+	require.Equal(t, "open on 101 ports", many[0])
+
+	require.Equal(t, []string{"same banner on 5 ports"}, d.Detect(Host{IP: "10.0.0.7", Ports: ports(5, "220 Service ready")}))
+
+	// Connections accepted, nothing ever sent
+	require.Equal(t, []string{"accepts connections on 10 ports without sending data (tarpit)"}, d.Detect(Host{IP: "10.0.0.8", Ports: ports(10, "")}))
+	require.Nil(t, d.Detect(Host{IP: "10.0.0.9", Ports: ports(9, "")}))
+
+	tarpit := Host{IP: "10.0.0.10", Ports: ports(10, "")}
+	tarpit.Ports[3].Responded = true
+	require.Nil(t, d.Detect(tarpit))
+
+	// Every heuristic at once
+	reasons := New(Thresholds{MaxOpenPorts: 3, IdenticalBanners: 2, TarpitPorts: 2}).Detect(Host{IP: "10.0.0.11", Ports: ports(4, "")})
+	require.Len(t, reasons, 2)
+	require.Equal(t, "Possible honeypot or tarpit: open on 4 ports; accepts connections on 4 ports without sending data (tarpit)", Reason(reasons))
+}
+
+func TestDetector_DetectAll(t *testing.T) {
+	flagged := New(Thresholds{}).DetectAll([]Host{
+		{IP: "10.0.0.5", Ports: ports(2, "")},
+		{IP: "10.0.0.8", Ports: ports(12, "")},
+	})
+	require.Len(t, flagged, 1)
+	require.Contains(t, flagged, "10.0.0.8")
+}
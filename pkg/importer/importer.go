@@ -0,0 +1,166 @@
+// Package importer converts the results of other scanners into the data the
+// scan pipeline produces itself (live hosts, open ports and banners), so that
+// the later stages (banner grabbing, fingerprinting and plugin evaluation)
+// can run over existing scan data.
+package importer
+
+import (
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+)
+
+// Data keys seeded into the scan pipeline by imports.
+const (
+	LiveHostsKey    = "discovery.live_hosts"
+	OpenTCPPortsKey = "discovery.open_tcp_ports"
+	BannersKey      = "service.banner.tcp"
+)
+
+// Result is the hosts found by an imported scan.
+type Result struct {
+	Source string // scanner that produced the data, e.g. "nmap"
+	Hosts  []Host
+}
+
+// Host is an imported host with its open ports.
+type Host struct {
+	IP        string   `json:"ip"`
+	Hostnames []string `json:"hostnames,omitempty"`
+	Ports     []Port   `json:"ports,omitempty"`
+}
+
+// Port is an open port with the service the scanner identified on it.
+type Port struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`          // "tcp" or "udp"
+	Service  string `json:"service,omitempty"` // e.g. "ssh", "http"
+	Product  string `json:"product,omitempty"` // e.g. "OpenSSH"
+	Version  string `json:"version,omitempty"` // e.g. "8.2p1"
+	TLS      bool   `json:"tls,omitempty"`
+	Banner   string `json:"banner,omitempty"` // raw banner, when the scanner recorded one
+}
+
+// Targets returns the IP of every imported host.
+func (r *Result) Targets() []string {
+	targets := make([]string, 0, len(r.Hosts))
+	for _, h := range r.Hosts {
+		targets = append(targets, h.IP)
+	}
+	return targets
+}
+
+// TCPPorts returns the number of open TCP ports, the ports the scan
+// pipeline can process.
+func (r *Result) TCPPorts() int {
+	n := 0
+	for _, h := range r.Hosts {
+		for _, p := range h.Ports {
+			if p.Protocol == "tcp" {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Seed converts the result into the data produced by the discovery and
+// banner grabbing stages of a scan, keyed by data key. Ports without a
+// recorded banner get one built from the identified product and version,
+// so the protocol parsers and fingerprinting can identify them.
+func (r *Result) Seed() map[string]interface{} {
+	seed := r.PortSeed()
+	banners := make([]interface{}, 0)
+	for _, h := range r.Hosts {
+		for _, p := range h.Ports {
+			if p.Protocol != "tcp" {
+				continue
+			}
+			if banner := p.banner(); banner != "" {
+				banners = append(banners, scan.BannerGrabResult{
+					IP:       h.IP,
+					Port:     p.Port,
+					Protocol: "tcp",
+					Banner:   banner,
+					IsTLS:    p.TLS,
+				})
+			}
+		}
+	}
+	seed[BannersKey] = banners
+	return seed
+}
+
+// PortSeed converts the result into the data produced by the discovery
+// stage of a scan only, so that banner grabbing runs against the imported
+// open ports.
+func (r *Result) PortSeed() map[string]interface{} {
+	live := discovery.ICMPPingDiscoveryResult{LiveHosts: r.Targets()}
+	openPorts := make([]interface{}, 0, len(r.Hosts))
+	for _, h := range r.Hosts {
+		var ports []int
+		for _, p := range h.Ports {
+			if p.Protocol == "tcp" {
+				ports = append(ports, p.Port)
+			}
+		}
+		if len(ports) > 0 {
+			openPorts = append(openPorts, discovery.TCPPortDiscoveryResult{Target: h.IP, OpenPorts: ports})
+		}
+	}
+
+	return map[string]interface{}{
+		LiveHostsKey:    []interface{}{live},
+		OpenTCPPortsKey: openPorts,
+	}
+}
+
+// banner returns the recorded banner, or one synthesized in the format the
+// service would announce itself with.
+func (p Port) banner() string {
+	if p.Banner != "" {
+		return p.Banner
+	}
+	if p.Product == "" {
+		return ""
+	}
+
+	switch {
+	case p.Service == "ssh":
+		// e.g. "SSH-2.0-OpenSSH_8.2p1"
+		return "SSH-2.0-" + strings.ReplaceAll(p.Product, " ", "_") + withPrefix("_", p.Version)
+	case strings.HasPrefix(p.Service, "http") || p.Service == "https":
+		return HTTPBanner(serverHeader(p.Product) + withPrefix("/", p.Version))
+	default:
+		return strings.TrimSpace(p.Product + " " + p.Version)
+	}
+}
+
+// HTTPBanner returns a minimal HTTP response announcing server in its
+// Server header.
+func HTTPBanner(server string) string {
+	return "HTTP/1.1 200 OK\r\nServer: " + server + "\r\n\r\n"
+}
+
+// serverHeaders maps nmap product names to the Server header the product
+// sends.
+var serverHeaders = map[string]string{
+	"Apache httpd":        "Apache",
+	"Microsoft IIS httpd": "Microsoft-IIS",
+	"Apache Tomcat":       "Apache-Coyote",
+}
+
+func serverHeader(product string) string {
+	if header, ok := serverHeaders[product]; ok {
+		return header
+	}
+	return strings.ReplaceAll(strings.TrimSuffix(product, " httpd"), " ", "-")
+}
+
+func withPrefix(prefix, s string) string {
+	if s == "" {
+		return ""
+	}
+	return prefix + s
+}
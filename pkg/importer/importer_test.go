@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+)
+
+func TestResultSeed(t *testing.T) {
+	result := &Result{Hosts: []Host{
+		{IP: "10.0.0.5", Ports: []Port{
+			{Port: 22, Protocol: "tcp", Service: "ssh", Product: "OpenSSH", Version: "8.2p1"},
+			{Port: 161, Protocol: "udp", Service: "snmp", Product: "net-snmp"},
+			{Port: 3389, Protocol: "tcp", Service: "ms-wbt-server"},
+		}},
+		{IP: "10.0.0.6"},
+	}}
+
+	seed := result.Seed()
+	require.Equal(t, []interface{}{discovery.ICMPPingDiscoveryResult{LiveHosts: []string{"10.0.0.5", "10.0.0.6"}}}, seed[LiveHostsKey])
+	// Only TCP ports are seeded, and hosts without any are left out
+	require.Equal(t, []interface{}{discovery.TCPPortDiscoveryResult{Target: "10.0.0.5", OpenPorts: []int{22, 3389}}}, seed[OpenTCPPortsKey])
+	// Ports without product or banner get no banner
+	require.Equal(t, []interface{}{scan.BannerGrabResult{IP: "10.0.0.5", Port: 22, Protocol: "tcp", Banner: "SSH-2.0-OpenSSH_8.2p1"}}, seed[BannersKey])
+}
+
+func TestResultPortSeed(t *testing.T) {
+	result := &Result{Hosts: []Host{{IP: "10.0.0.5", Ports: []Port{{Port: 22, Protocol: "tcp", Banner: "SSH-2.0-OpenSSH_8.2p1"}}}}}
+
+	seed := result.PortSeed()
+	require.Contains(t, seed, LiveHostsKey)
+	require.Contains(t, seed, OpenTCPPortsKey)
+	// Banners are left to the banner grabber
+	require.NotContains(t, seed, BannersKey)
+}
+
+func TestPortBanner(t *testing.T) {
+	tests := []struct {
+		name string
+		port Port
+		want string
+	}{
+		{"recorded banner", Port{Service: "ftp", Product: "vsftpd", Banner: "220 (vsFTPd 3.0.3)"}, "220 (vsFTPd 3.0.3)"},
+		{"ssh", Port{Service: "ssh", Product: "Dropbear sshd", Version: "2019.78"}, "SSH-2.0-Dropbear_sshd_2019.78"},
+		{"http", Port{Service: "http", Product: "nginx", Version: "1.18.0"}, HTTPBanner("nginx/1.18.0")},
+		{"http known product", Port{Service: "http", Product: "Apache httpd", Version: "2.4.41"}, HTTPBanner("Apache/2.4.41")},
+		{"http without version", Port{Service: "http-proxy", Product: "Squid http proxy"}, HTTPBanner("Squid-http-proxy")},
+		{"other", Port{Service: "mysql", Product: "MySQL", Version: "5.7.33"}, "MySQL 5.7.33"},
+		{"no product", Port{Service: "ms-wbt-server"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.port.banner())
+		})
+	}
+}
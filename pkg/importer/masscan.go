@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// masscanRecord is one host entry of masscan JSON output (-oJ or -oD).
+type masscanRecord struct {
+	IP    string `json:"ip"`
+	Ports []struct {
+		Port   int    `json:"port"`
+		Proto  string `json:"proto"`
+		Status string `json:"status"`
+	} `json:"ports"`
+}
+
+// ParseMasscan reads masscan output in JSON (-oJ), NDJSON (-oD) or list
+// (-oL) format, detected from the first character. Only open ports are
+// kept; banner records are ignored.
+func ParseMasscan(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read masscan output: %w", err)
+	}
+
+	b := newResultBuilder("masscan")
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		err = parseMasscanJSON(trimmed, b)
+	} else {
+		err = parseMasscanList(trimmed, b)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b.result(), nil
+}
+
+// parseMasscanJSON decodes the host objects of masscan JSON output. Older
+// masscan versions write a trailing comma after the last object and a
+// closing "{finished: 1}" that is not valid JSON, so objects are decoded
+// one at a time instead of as a single array.
+func parseMasscanJSON(data []byte, b *resultBuilder) error {
+	for i := 0; i < len(data); {
+		switch data[i] {
+		case '[', ']', ',', ' ', '\t', '\r', '\n':
+			i++
+			continue
+		}
+		if bytes.HasPrefix(data[i:], []byte("{finished")) {
+			return nil
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data[i:]))
+		var rec masscanRecord
+		if err := dec.Decode(&rec); err != nil {
+			return fmt.Errorf("parse masscan JSON: %w", err)
+		}
+		i += int(dec.InputOffset())
+
+		for _, p := range rec.Ports {
+			if p.Status == "open" {
+				b.addPort(rec.IP, p.Port, p.Proto)
+			}
+		}
+	}
+	return nil
+}
+
+// parseMasscanList reads masscan list output, e.g.
+// "open tcp 443 10.0.0.1 1700000000".
+func parseMasscanList(data []byte, b *resultBuilder) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || fields[0] != "open" {
+			continue
+		}
+		if len(fields) < 4 {
+			return fmt.Errorf("parse masscan list: line %d: expected \"open <proto> <port> <ip>\"", line)
+		}
+		port, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return fmt.Errorf("parse masscan list: line %d: invalid port %q", line, fields[2])
+		}
+		b.addPort(fields[3], port, fields[1])
+	}
+	return scanner.Err()
+}
+
+// resultBuilder merges the per-port records of scanners that report each
+// open port separately into one entry per host.
+type resultBuilder struct {
+	res   *Result
+	hosts map[string]int
+	ports map[string]bool
+}
+
+func newResultBuilder(source string) *resultBuilder {
+	return &resultBuilder{
+		res:   &Result{Source: source},
+		hosts: make(map[string]int),
+		ports: make(map[string]bool),
+	}
+}
+
+func (b *resultBuilder) addPort(ip string, port int, protocol string) {
+	if ip == "" || port <= 0 || port > 65535 {
+		return
+	}
+	protocol = strings.ToLower(protocol)
+	key := ip + "|" + protocol + "|" + strconv.Itoa(port)
+	if b.ports[key] {
+		return
+	}
+	b.ports[key] = true
+
+	idx, ok := b.hosts[ip]
+	if !ok {
+		idx = len(b.res.Hosts)
+		b.hosts[ip] = idx
+		b.res.Hosts = append(b.res.Hosts, Host{IP: ip})
+	}
+	b.res.Hosts[idx].Ports = append(b.res.Hosts[idx].Ports, Port{Port: port, Protocol: protocol})
+}
+
+// result returns the hosts in the order first seen, with their ports sorted.
+func (b *resultBuilder) result() *Result {
+	for i := range b.res.Hosts {
+		ports := b.res.Hosts[i].Ports
+		sort.Slice(ports, func(a, c int) bool {
+			if ports[a].Port != ports[c].Port {
+				return ports[a].Port < ports[c].Port
+			}
+			return ports[a].Protocol < ports[c].Protocol
+		})
+	}
+	return b.res
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMasscan_JSON(t *testing.T) {
+	// Old masscan style: trailing comma and invalid closing object
+	data := `[
+{   "ip": "10.0.0.5",   "timestamp": "1700000000", "ports": [ {"port": 443, "proto": "tcp", "status": "open", "reason": "syn-ack", "ttl": 64} ] },
+{   "ip": "10.0.0.6",   "timestamp": "1700000000", "ports": [ {"port": 22, "proto": "tcp", "status": "open", "reason": "syn-ack", "ttl": 64} ] },
+{   "ip": "10.0.0.5",   "timestamp": "1700000001", "ports": [ {"port": 22, "proto": "tcp", "status": "open", "reason": "syn-ack", "ttl": 64} ] },
+{   "ip": "10.0.0.5",   "timestamp": "1700000002", "ports": [ {"port": 80, "proto": "tcp", "service": {"name": "http", "banner": "Server: nginx"} } ] },
+{   "ip": "10.0.0.5",   "timestamp": "1700000003", "ports": [ {"port": 443, "proto": "tcp", "status": "open", "reason": "syn-ack", "ttl": 64} ] },
+{finished: 1}
+]`
+
+	result, err := ParseMasscan(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, "masscan", result.Source)
+	require.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, result.Targets())
+	// Banner records are ignored, duplicates merged and ports sorted
+	require.Equal(t, []Port{{Port: 22, Protocol: "tcp"}, {Port: 443, Protocol: "tcp"}}, result.Hosts[0].Ports)
+	require.Equal(t, []Port{{Port: 22, Protocol: "tcp"}}, result.Hosts[1].Ports)
+}
+
+func TestParseMasscan_NDJSON(t *testing.T) {
+	data := `{"ip":"10.0.0.5","timestamp":"1700000000","ports":[{"port":8080,"proto":"tcp","status":"open"}]}
+{"ip":"10.0.0.5","timestamp":"1700000000","ports":[{"port":53,"proto":"udp","status":"open"}]}
+`
+	result, err := ParseMasscan(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []Port{{Port: 53, Protocol: "udp"}, {Port: 8080, Protocol: "tcp"}}, result.Hosts[0].Ports)
+	require.Equal(t, 1, result.TCPPorts())
+}
+
+func TestParseMasscan_List(t *testing.T) {
+	data := `#masscan
+open tcp 80 10.0.0.7 1700000000
+open tcp 22 10.0.0.7 1700000000
+banner tcp 80 10.0.0.7 1700000001 http "Server: nginx"
+open tcp 3306 10.0.0.8 1700000000
+# end
+`
+	result, err := ParseMasscan(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.7", "10.0.0.8"}, result.Targets())
+	require.Equal(t, []Port{{Port: 22, Protocol: "tcp"}, {Port: 80, Protocol: "tcp"}}, result.Hosts[0].Ports)
+}
+
+func TestParseMasscan_Invalid(t *testing.T) {
+	_, err := ParseMasscan(strings.NewReader(`[{"ip": "10.0.0.5", "ports": [`))
+	require.Error(t, err)
+
+	_, err = ParseMasscan(strings.NewReader("open tcp http 10.0.0.5 1700000000\n"))
+	require.ErrorContains(t, err, "line 1")
+}
@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// nmapRun is the subset of an nmap XML report (nmap -oX) used by imports.
+type nmapRun struct {
+	XMLName xml.Name   `xml:"nmaprun"`
+	Hosts   []nmapHost `xml:"host"`
+}
+
+type nmapHost struct {
+	Status struct {
+		State string `xml:"state,attr"`
+	} `xml:"status"`
+	Addresses []struct {
+		Addr     string `xml:"addr,attr"`
+		AddrType string `xml:"addrtype,attr"`
+	} `xml:"address"`
+	Hostnames []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"hostnames>hostname"`
+	Ports []nmapPort `xml:"ports>port"`
+}
+
+type nmapPort struct {
+	Protocol string `xml:"protocol,attr"`
+	PortID   int    `xml:"portid,attr"`
+	State    struct {
+		State string `xml:"state,attr"`
+	} `xml:"state"`
+	Service struct {
+		Name    string `xml:"name,attr"`
+		Product string `xml:"product,attr"`
+		Version string `xml:"version,attr"`
+		Tunnel  string `xml:"tunnel,attr"`
+	} `xml:"service"`
+	Scripts []struct {
+		ID     string `xml:"id,attr"`
+		Output string `xml:"output,attr"`
+	} `xml:"script"`
+}
+
+// ParseNmap reads an nmap XML report. Hosts that are down and ports that
+// are not open are left out. Banners come from the banner and
+// http-server-header NSE scripts when they ran.
+func ParseNmap(r io.Reader) (*Result, error) {
+	var run nmapRun
+	if err := xml.NewDecoder(r).Decode(&run); err != nil {
+		return nil, fmt.Errorf("parse nmap XML: %w", err)
+	}
+
+	result := &Result{Source: "nmap"}
+	byIP := make(map[string]int)
+	for _, nh := range run.Hosts {
+		if nh.Status.State != "" && nh.Status.State != "up" {
+			continue
+		}
+		ip := nh.ip()
+		if ip == "" {
+			continue
+		}
+
+		idx, seen := byIP[ip]
+		if !seen {
+			idx = len(result.Hosts)
+			byIP[ip] = idx
+			result.Hosts = append(result.Hosts, Host{IP: ip})
+		}
+		host := &result.Hosts[idx]
+		for _, hn := range nh.Hostnames {
+			if hn.Name != "" {
+				host.Hostnames = append(host.Hostnames, hn.Name)
+			}
+		}
+		for _, np := range nh.Ports {
+			if np.State.State != "open" {
+				continue
+			}
+			host.Ports = append(host.Ports, np.port())
+		}
+	}
+	return result, nil
+}
+
+// ip returns the IPv4 or IPv6 address of the host, ignoring MAC addresses.
+func (h nmapHost) ip() string {
+	for _, a := range h.Addresses {
+		if a.AddrType == "ipv4" || a.AddrType == "ipv6" {
+			return a.Addr
+		}
+	}
+	return ""
+}
+
+func (np nmapPort) port() Port {
+	p := Port{
+		Port:     np.PortID,
+		Protocol: strings.ToLower(np.Protocol),
+		Service:  np.Service.Name,
+		Product:  np.Service.Product,
+		Version:  np.Service.Version,
+		TLS:      np.Service.Tunnel == "ssl" || np.Service.Name == "https",
+	}
+	for _, s := range np.Scripts {
+		output := strings.TrimSpace(s.Output)
+		switch {
+		case output == "":
+		case s.ID == "banner":
+			p.Banner = output
+		case s.ID == "http-server-header" && p.Banner == "":
+			p.Banner = HTTPBanner(output)
+		}
+	}
+	return p
+}
@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNmap(t *testing.T) {
+	f, err := os.Open("testdata/nmap.xml")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	result, err := ParseNmap(f)
+	require.NoError(t, err)
+	require.Equal(t, "nmap", result.Source)
+
+	// The down host is skipped
+	require.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, result.Targets())
+
+	web := result.Hosts[0]
+	require.Equal(t, []string{"web01.example.internal"}, web.Hostnames)
+	// The filtered port is skipped
+	require.Equal(t, []Port{
+		{Port: 22, Protocol: "tcp", Service: "ssh", Product: "OpenSSH", Version: "8.2p1 Ubuntu 4ubuntu0.5"},
+		{Port: 80, Protocol: "tcp", Service: "http", Product: "nginx", Version: "1.18.0", Banner: HTTPBanner("nginx/1.18.0 (Ubuntu)")},
+		{Port: 443, Protocol: "tcp", Service: "http", Product: "Apache httpd", Version: "2.4.41", TLS: true},
+	}, web.Ports)
+
+	ftp := result.Hosts[1]
+	require.Empty(t, ftp.Hostnames)
+	require.Len(t, ftp.Ports, 3)
+	require.Equal(t, "220 (vsFTPd 3.0.3)", ftp.Ports[0].Banner)
+	require.Equal(t, "udp", ftp.Ports[1].Protocol)
+	require.Equal(t, 5, result.TCPPorts())
+}
+
+func TestParseNmap_MergesRepeatedHosts(t *testing.T) {
+	xml := `<nmaprun>
+<host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/><ports><port protocol="tcp" portid="22"><state state="open"/></port></ports></host>
+<host><status state="up"/><address addr="10.0.0.1" addrtype="ipv4"/><ports><port protocol="tcp" portid="80"><state state="open"/></port></ports></host>
+<host><status state="up"/><address addr="fe80::1" addrtype="ipv6"/></host>
+</nmaprun>`
+
+	result, err := ParseNmap(strings.NewReader(xml))
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1", "fe80::1"}, result.Targets())
+	require.Len(t, result.Hosts[0].Ports, 2)
+}
+
+func TestParseNmap_Invalid(t *testing.T) {
+	_, err := ParseNmap(strings.NewReader(`<masscan></masscan>`))
+	require.Error(t, err)
+
+	_, err = ParseNmap(strings.NewReader(`<nmaprun><host>`))
+	require.Error(t, err)
+}
@@ -0,0 +1,264 @@
+package inventory
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ec2APIVersion is the version of the EC2 Query API.
+const ec2APIVersion = "2016-11-15"
+
+// AWS lists the running EC2 instances of the regions of the filter.
+type AWS struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Region is queried when the filter names no regions
+	Region string
+
+	// Endpoint returns the EC2 API endpoint of a region; nil for the
+	// public endpoints
+	Endpoint func(region string) string
+}
+
+// NewAWS returns the EC2 provider with the credentials of the environment:
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and
+// AWS_REGION (or AWS_DEFAULT_REGION).
+func NewAWS() (*AWS, error) {
+	p := &AWS{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		Region:          os.Getenv("AWS_REGION"),
+	}
+	if p.Region == "" {
+		p.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if p.AccessKeyID == "" || p.SecretAccessKey == "" {
+		return nil, fmt.Errorf("aws: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	return p, nil
+}
+
+// Name returns "aws".
+func (p *AWS) Name() string { return ProviderAWS }
+
+// Instances returns the running instances matching f in each region of f.
+func (p *AWS) Instances(ctx context.Context, f Filter) ([]Instance, error) {
+	regions := f.Regions
+	if len(regions) == 0 {
+		if p.Region == "" {
+			return nil, fmt.Errorf("aws: no region; set AWS_REGION or add a region: filter")
+		}
+		regions = []string{p.Region}
+	}
+
+	var instances []Instance
+	for _, region := range regions {
+		found, err := p.regionInstances(ctx, region, f)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, found...)
+	}
+	return instances, nil
+}
+
+// regionInstances pages through DescribeInstances in region.
+func (p *AWS) regionInstances(ctx context.Context, region string, f Filter) ([]Instance, error) {
+	form := url.Values{}
+	form.Set("Action", "DescribeInstances")
+	form.Set("Version", ec2APIVersion)
+	form.Set("MaxResults", "1000")
+
+	// Filters are applied by EC2
+	n := 0
+	addFilter := func(name string, values ...string) {
+		n++
+		form.Set("Filter."+strconv.Itoa(n)+".Name", name)
+		for i, v := range values {
+			form.Set("Filter."+strconv.Itoa(n)+".Value."+strconv.Itoa(i+1), v)
+		}
+	}
+	addFilter("instance-state-name", "running")
+	keys := make([]string, 0, len(f.Tags))
+	for key := range f.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		addFilter("tag:"+key, f.Tags[key])
+	}
+	if len(f.Networks) > 0 {
+		addFilter("vpc-id", f.Networks...)
+	}
+
+	var instances []Instance
+	for {
+		var out ec2DescribeInstancesResponse
+		if err := p.call(ctx, region, form, &out); err != nil {
+			return nil, err
+		}
+		for _, res := range out.Reservations {
+			for _, inst := range res.Instances {
+				instances = append(instances, inst.instance(res.OwnerID, region))
+			}
+		}
+		if out.NextToken == "" {
+			return instances, nil
+		}
+		form.Set("NextToken", out.NextToken)
+	}
+}
+
+// call sends a signed EC2 Query API request and decodes its XML response.
+func (p *AWS) call(ctx context.Context, region string, form url.Values, v interface{}) error {
+	endpoint := "https://ec2." + region + ".amazonaws.com/"
+	if p.Endpoint != nil {
+		endpoint = p.Endpoint(region)
+	}
+	body := form.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	p.sign(req, body, region, "ec2", time.Now().UTC())
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("aws API: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError(ProviderAWS, resp)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("aws API: decode response: %w", err)
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 headers of service to req.
+func (p *AWS) sign(req *http.Request, body, region, service string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	if p.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.SessionToken)
+	}
+
+	headers := map[string]string{
+		"content-type": req.Header.Get("Content-Type"),
+		"host":         req.URL.Host,
+		"x-amz-date":   amzDate,
+	}
+	if p.SessionToken != "" {
+		headers["x-amz-security-token"] = p.SessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex(canonicalRequest)
+
+	key := hmacSHA256([]byte("AWS4"+p.SecretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+p.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// ec2DescribeInstancesResponse is the part of the DescribeInstances
+// response the provider reads.
+type ec2DescribeInstancesResponse struct {
+	Reservations []struct {
+		OwnerID   string        `xml:"ownerId"`
+		Instances []ec2Instance `xml:"instancesSet>item"`
+	} `xml:"reservationSet>item"`
+	NextToken string `xml:"nextToken"`
+}
+
+type ec2Instance struct {
+	InstanceID       string `xml:"instanceId"`
+	PrivateIPAddress string `xml:"privateIpAddress"`
+	IPAddress        string `xml:"ipAddress"`
+	VpcID            string `xml:"vpcId"`
+	Groups           []struct {
+		GroupID string `xml:"groupId"`
+	} `xml:"groupSet>item"`
+	Tags []struct {
+		Key   string `xml:"key"`
+		Value string `xml:"value"`
+	} `xml:"tagSet>item"`
+}
+
+// instance converts an EC2 instance of account and region.
+func (e ec2Instance) instance(account, region string) Instance {
+	inst := Instance{Provider: ProviderAWS, ID: e.InstanceID, Account: account, Region: region, Network: e.VpcID}
+	for _, g := range e.Groups {
+		inst.SecurityGroups = append(inst.SecurityGroups, g.GroupID)
+	}
+	if e.PrivateIPAddress != "" {
+		inst.PrivateIPs = []string{e.PrivateIPAddress}
+	}
+	if e.IPAddress != "" {
+		inst.PublicIPs = []string{e.IPAddress}
+	}
+	if len(e.Tags) > 0 {
+		inst.Tags = make(map[string]string, len(e.Tags))
+		for _, t := range e.Tags {
+			inst.Tags[t.Key] = t.Value
+		}
+		inst.Name = inst.Tags["Name"]
+	}
+	return inst
+}
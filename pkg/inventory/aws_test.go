@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWS_Sign(t *testing.T) {
+	// Example request of the AWS Signature Version 4 documentation
+	p := &AWS{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	p.sign(req, "", "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
+
+const ec2Page1 = `<?xml version="1.0" encoding="UTF-8"?>
+<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <ownerId>123456789012</ownerId>
+      <instancesSet>
+        <item>
+          <instanceId>i-0001</instanceId>
+          <privateIpAddress>10.0.1.10</privateIpAddress>
+          <ipAddress>203.0.113.10</ipAddress>
+          <vpcId>vpc-prod</vpcId>
+          <groupSet>
+            <item><groupId>sg-web</groupId><groupName>web</groupName></item>
+            <item><groupId>sg-ssh</groupId><groupName>ssh</groupName></item>
+          </groupSet>
+          <tagSet>
+            <item><key>Name</key><value>web-1</value></item>
+            <item><key>env</key><value>prod</value></item>
+          </tagSet>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+  <nextToken>page2</nextToken>
+</DescribeInstancesResponse>`
+
+const ec2Page2 = `<DescribeInstancesResponse>
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-0002</instanceId>
+          <privateIpAddress>10.0.1.11</privateIpAddress>
+          <vpcId>vpc-prod</vpcId>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`
+
+func TestAWS_Instances(t *testing.T) {
+	var forms []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		require.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		body, _ := io.ReadAll(r.Body)
+		form, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+		forms = append(forms, form)
+		if form.Get("NextToken") == "" {
+			_, _ = io.WriteString(w, ec2Page1)
+			return
+		}
+		_, _ = io.WriteString(w, ec2Page2)
+	}))
+	defer srv.Close()
+
+	p := &AWS{
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+		Endpoint:        func(region string) string { return srv.URL + "/" + region },
+	}
+	f, err := ParseFilter([]string{"tag:env=prod", "vpc:vpc-prod", "region:eu-west-1"})
+	require.NoError(t, err)
+
+	instances, err := p.Instances(context.Background(), f)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	require.Equal(t, Instance{
+		Provider:       ProviderAWS,
+		ID:             "i-0001",
+		Name:           "web-1",
+		Account:        "123456789012",
+		Region:         "eu-west-1",
+		Network:        "vpc-prod",
+		PrivateIPs:     []string{"10.0.1.10"},
+		PublicIPs:      []string{"203.0.113.10"},
+		Tags:           map[string]string{"Name": "web-1", "env": "prod"},
+		SecurityGroups: []string{"sg-web", "sg-ssh"},
+	}, instances[0])
+	require.Empty(t, instances[1].PublicIPs)
+
+	require.Len(t, forms, 2)
+	require.Equal(t, "DescribeInstances", forms[0].Get("Action"))
+	require.Equal(t, "instance-state-name", forms[0].Get("Filter.1.Name"))
+	require.Equal(t, "running", forms[0].Get("Filter.1.Value.1"))
+	require.Equal(t, "tag:env", forms[0].Get("Filter.2.Name"))
+	require.Equal(t, "prod", forms[0].Get("Filter.2.Value.1"))
+	require.Equal(t, "vpc-id", forms[0].Get("Filter.3.Name"))
+	require.Equal(t, "page2", forms[1].Get("NextToken"))
+}
+
+func TestAWS_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = io.WriteString(w, "<Response><Errors><Error><Code>AuthFailure</Code></Error></Errors></Response>")
+	}))
+	defer srv.Close()
+
+	p := &AWS{AccessKeyID: "AKID", SecretAccessKey: "secret", Region: "us-east-1", Endpoint: func(string) string { return srv.URL }}
+	_, err := p.Instances(context.Background(), Filter{})
+	require.ErrorContains(t, err, "401")
+	require.ErrorContains(t, err, "AuthFailure")
+}
@@ -0,0 +1,246 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	azureManagementURL = "https://management.azure.com"
+	azureLoginURL      = "https://login.microsoftonline.com"
+
+	azureComputeAPIVersion = "2024-07-01"
+	azureNetworkAPIVersion = "2023-09-01"
+)
+
+// Azure lists the virtual machines of a subscription.
+type Azure struct {
+	SubscriptionID string
+
+	// AccessToken authenticates API requests; empty to get one for the
+	// service principal of TenantID, ClientID and ClientSecret
+	AccessToken  string
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+
+	// ManagementURL and LoginURL override the API endpoints
+	ManagementURL string
+	LoginURL      string
+}
+
+// NewAzure returns the virtual machine provider of the subscription in
+// AZURE_SUBSCRIPTION_ID, authenticated with AZURE_ACCESS_TOKEN (e.g. from
+// `az account get-access-token`) or the service principal of
+// AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET.
+func NewAzure() (*Azure, error) {
+	p := &Azure{
+		SubscriptionID: os.Getenv("AZURE_SUBSCRIPTION_ID"),
+		AccessToken:    os.Getenv("AZURE_ACCESS_TOKEN"),
+		TenantID:       os.Getenv("AZURE_TENANT_ID"),
+		ClientID:       os.Getenv("AZURE_CLIENT_ID"),
+		ClientSecret:   os.Getenv("AZURE_CLIENT_SECRET"),
+	}
+	if p.SubscriptionID == "" {
+		return nil, fmt.Errorf("azure: set AZURE_SUBSCRIPTION_ID")
+	}
+	if p.AccessToken == "" && (p.TenantID == "" || p.ClientID == "" || p.ClientSecret == "") {
+		return nil, fmt.Errorf("azure: set AZURE_ACCESS_TOKEN, or AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET")
+	}
+	return p, nil
+}
+
+// Name returns "azure".
+func (p *Azure) Name() string { return ProviderAzure }
+
+// Instances returns the virtual machines matching f, with the addresses of
+// their network interfaces. Azure lists deallocated machines too; they do
+// not answer probes.
+func (p *Azure) Instances(ctx context.Context, f Filter) ([]Instance, error) {
+	client := newHTTPClient()
+	token, err := p.token(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	base := p.ManagementURL
+	if base == "" {
+		base = azureManagementURL
+	}
+	sub := base + "/subscriptions/" + url.PathEscape(p.SubscriptionID) + "/providers/"
+
+	var vms []azureVM
+	if err := azureList(ctx, client, sub+"Microsoft.Compute/virtualMachines?api-version="+azureComputeAPIVersion, token, &vms); err != nil {
+		return nil, err
+	}
+	var nics []azureNIC
+	if err := azureList(ctx, client, sub+"Microsoft.Network/networkInterfaces?api-version="+azureNetworkAPIVersion, token, &nics); err != nil {
+		return nil, err
+	}
+	var publicIPs []azurePublicIP
+	if err := azureList(ctx, client, sub+"Microsoft.Network/publicIPAddresses?api-version="+azureNetworkAPIVersion, token, &publicIPs); err != nil {
+		return nil, err
+	}
+
+	// Resource IDs are case-insensitive
+	nicByID := make(map[string]azureNIC, len(nics))
+	for _, nic := range nics {
+		nicByID[strings.ToLower(nic.ID)] = nic
+	}
+	publicIPByID := make(map[string]string, len(publicIPs))
+	for _, ip := range publicIPs {
+		publicIPByID[strings.ToLower(ip.ID)] = ip.Properties.IPAddress
+	}
+
+	var instances []Instance
+	for _, vm := range vms {
+		if !f.matchTags(vm.Tags, true) || !matchLocation(f.Regions, vm.Location) {
+			continue
+		}
+		inst := Instance{Provider: ProviderAzure, ID: vm.ID, Name: vm.Name, Account: p.SubscriptionID, Region: vm.Location, Tags: vm.Tags}
+		for _, ref := range vm.Properties.NetworkProfile.NetworkInterfaces {
+			nic, ok := nicByID[strings.ToLower(ref.ID)]
+			if !ok {
+				continue
+			}
+			if nsg := nic.Properties.NetworkSecurityGroup.ID; nsg != "" {
+				inst.SecurityGroups = append(inst.SecurityGroups, path.Base(nsg))
+			}
+			for _, ipc := range nic.Properties.IPConfigurations {
+				if inst.Network == "" {
+					inst.Network = azureVirtualNetwork(ipc.Properties.Subnet.ID)
+				}
+				if ip := ipc.Properties.PrivateIPAddress; ip != "" {
+					inst.PrivateIPs = append(inst.PrivateIPs, ip)
+				}
+				if ip := publicIPByID[strings.ToLower(ipc.Properties.PublicIPAddress.ID)]; ip != "" {
+					inst.PublicIPs = append(inst.PublicIPs, ip)
+				}
+			}
+		}
+		if f.matchNetwork(inst.Network) {
+			instances = append(instances, inst)
+		}
+	}
+	return instances, nil
+}
+
+// token returns the access token of API requests.
+func (p *Azure) token(ctx context.Context, client *http.Client) (string, error) {
+	if p.AccessToken != "" {
+		return p.AccessToken, nil
+	}
+	base := p.LoginURL
+	if base == "" {
+		base = azureLoginURL
+	}
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", p.ClientID)
+	form.Set("client_secret", p.ClientSecret)
+	form.Set("scope", "https://management.azure.com/.default")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/"+url.PathEscape(p.TenantID)+"/oauth2/v2.0/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("azure login: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", apiError(ProviderAzure, resp)
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := decodeJSON(resp, &tok); err != nil || tok.AccessToken == "" {
+		return "", fmt.Errorf("azure login: no access token in response")
+	}
+	return tok.AccessToken, nil
+}
+
+// azureList fetches all pages of a list operation into items.
+func azureList[T any](ctx context.Context, client *http.Client, u, token string, items *[]T) error {
+	for u != "" {
+		var page struct {
+			Value    []T    `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		if err := getJSON(ctx, client, ProviderAzure, u, token, &page); err != nil {
+			return err
+		}
+		*items = append(*items, page.Value...)
+		u = page.NextLink
+	}
+	return nil
+}
+
+// matchLocation reports whether location is one of regions.
+func matchLocation(regions []string, location string) bool {
+	if len(regions) == 0 {
+		return true
+	}
+	for _, r := range regions {
+		if strings.EqualFold(r, location) {
+			return true
+		}
+	}
+	return false
+}
+
+// azureVirtualNetwork returns the virtual network name of a subnet ID
+// (.../virtualNetworks/<name>/subnets/<subnet>).
+func azureVirtualNetwork(subnetID string) string {
+	if subnetID == "" {
+		return ""
+	}
+	return path.Base(path.Dir(path.Dir(subnetID)))
+}
+
+type azureVM struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Location   string            `json:"location"`
+	Tags       map[string]string `json:"tags"`
+	Properties struct {
+		NetworkProfile struct {
+			NetworkInterfaces []struct {
+				ID string `json:"id"`
+			} `json:"networkInterfaces"`
+		} `json:"networkProfile"`
+	} `json:"properties"`
+}
+
+type azureNIC struct {
+	ID         string `json:"id"`
+	Properties struct {
+		NetworkSecurityGroup struct {
+			ID string `json:"id"`
+		} `json:"networkSecurityGroup"`
+		IPConfigurations []struct {
+			Properties struct {
+				PrivateIPAddress string `json:"privateIPAddress"`
+				Subnet           struct {
+					ID string `json:"id"`
+				} `json:"subnet"`
+				PublicIPAddress struct {
+					ID string `json:"id"`
+				} `json:"publicIPAddress"`
+			} `json:"properties"`
+		} `json:"ipConfigurations"`
+	} `json:"properties"`
+}
+
+type azurePublicIP struct {
+	ID         string `json:"id"`
+	Properties struct {
+		IPAddress string `json:"ipAddress"`
+	} `json:"properties"`
+}
@@ -0,0 +1,89 @@
+package inventory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	azureSub    = "/subscriptions/sub-1"
+	azureVMList = `{"value": [
+	  {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/web-1", "name": "web-1", "location": "westeurope",
+	   "tags": {"Env": "prod"},
+	   "properties": {"networkProfile": {"networkInterfaces": [{"id": "` + azureSub + `/resourceGroups/RG/providers/Microsoft.Network/networkInterfaces/web-1-nic"}]}}},
+	  {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Compute/virtualMachines/dev-1", "name": "dev-1", "location": "westeurope",
+	   "tags": {"Env": "dev"},
+	   "properties": {"networkProfile": {"networkInterfaces": [{"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/dev-1-nic"}]}}}
+	]}`
+	azureNICList = `{"value": [
+	  {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/networkInterfaces/web-1-nic",
+	   "properties": {"networkSecurityGroup": {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/web-nsg"},
+	   "ipConfigurations": [{"properties": {
+	     "privateIPAddress": "10.1.0.4",
+	     "subnet": {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/prod-vnet/subnets/default"},
+	     "publicIPAddress": {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/web-1-ip"}
+	   }}]}}
+	]}`
+	azurePublicIPList = `{"value": [
+	  {"id": "` + azureSub + `/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/web-1-ip", "properties": {"ipAddress": "20.1.2.3"}}
+	]}`
+)
+
+func TestAzure_Instances(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /login/tenant-1/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "client-1", r.PostForm.Get("client_id"))
+		require.Equal(t, "secret", r.PostForm.Get("client_secret"))
+		_, _ = w.Write([]byte(`{"access_token": "sp-token"}`))
+	})
+	var srv *httptest.Server
+	mux.HandleFunc("GET "+azureSub+"/providers/Microsoft.Compute/virtualMachines", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer sp-token", r.Header.Get("Authorization"))
+		// The machines come in two pages
+		if r.URL.Query().Get("page") == "" {
+			_, _ = w.Write([]byte(`{"value": [], "nextLink": "` + srv.URL + azureSub + `/providers/Microsoft.Compute/virtualMachines?page=2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(azureVMList))
+	})
+	mux.HandleFunc("GET "+azureSub+"/providers/Microsoft.Network/networkInterfaces", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(azureNICList))
+	})
+	mux.HandleFunc("GET "+azureSub+"/providers/Microsoft.Network/publicIPAddresses", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(azurePublicIPList))
+	})
+	srv = httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := &Azure{
+		SubscriptionID: "sub-1",
+		TenantID:       "tenant-1",
+		ClientID:       "client-1",
+		ClientSecret:   "secret",
+		ManagementURL:  srv.URL,
+		LoginURL:       srv.URL + "/login",
+	}
+	f, err := ParseFilter([]string{"tag:env=prod", "vpc:prod-vnet", "region:WestEurope"})
+	require.NoError(t, err)
+
+	instances, err := p.Instances(context.Background(), f)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	require.Equal(t, "web-1", instances[0].Name)
+	require.Equal(t, "prod-vnet", instances[0].Network)
+	require.Equal(t, []string{"10.1.0.4"}, instances[0].PrivateIPs)
+	require.Equal(t, []string{"20.1.2.3"}, instances[0].PublicIPs)
+	require.Equal(t, "sub-1", instances[0].Account)
+	require.Equal(t, []string{"web-nsg"}, instances[0].SecurityGroups)
+}
+
+func TestAzureVirtualNetwork(t *testing.T) {
+	require.Equal(t, "prod-vnet", azureVirtualNetwork("/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/prod-vnet/subnets/default"))
+	require.Empty(t, azureVirtualNetwork(""))
+}
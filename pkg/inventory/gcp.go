@@ -0,0 +1,210 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	gcpComputeURL  = "https://compute.googleapis.com/compute/v1"
+	gcpMetadataURL = "http://metadata.google.internal/computeMetadata/v1"
+)
+
+// GCP lists the running Compute Engine instances of a project.
+type GCP struct {
+	Project string
+
+	// AccessToken authenticates API requests; empty to get one from the
+	// metadata server of the Compute Engine instance Vulntor runs on
+	AccessToken string
+
+	// ComputeURL and MetadataURL override the API endpoints
+	ComputeURL  string
+	MetadataURL string
+}
+
+// NewGCP returns the Compute Engine provider of the project in
+// GOOGLE_CLOUD_PROJECT (or CLOUDSDK_CORE_PROJECT), authenticated with
+// GOOGLE_OAUTH_ACCESS_TOKEN (e.g. from `gcloud auth print-access-token`) or
+// the metadata server.
+func NewGCP() (*GCP, error) {
+	p := &GCP{
+		Project:     os.Getenv("GOOGLE_CLOUD_PROJECT"),
+		AccessToken: os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"),
+	}
+	if p.Project == "" {
+		p.Project = os.Getenv("CLOUDSDK_CORE_PROJECT")
+	}
+	if p.Project == "" {
+		return nil, fmt.Errorf("gcp: set GOOGLE_CLOUD_PROJECT")
+	}
+	return p, nil
+}
+
+// Name returns "gcp".
+func (p *GCP) Name() string { return ProviderGCP }
+
+// Instances returns the running instances of all zones matching f.
+func (p *GCP) Instances(ctx context.Context, f Filter) ([]Instance, error) {
+	client := newHTTPClient()
+	token, err := p.token(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	base := p.ComputeURL
+	if base == "" {
+		base = gcpComputeURL
+	}
+	q := url.Values{}
+	q.Set("filter", gcpFilter(f))
+	q.Set("returnPartialSuccess", "true")
+
+	var instances []Instance
+	for {
+		var page gcpAggregatedInstances
+		u := base + "/projects/" + url.PathEscape(p.Project) + "/aggregated/instances?" + q.Encode()
+		if err := getJSON(ctx, client, ProviderGCP, u, token, &page); err != nil {
+			return nil, err
+		}
+		zones := make([]string, 0, len(page.Items))
+		for zone := range page.Items {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+		for _, zone := range zones {
+			for _, gi := range page.Items[zone].Instances {
+				inst := gi.instance(p.Project)
+				if matchZone(f.Regions, inst.Region) && f.matchNetwork(inst.Network) {
+					instances = append(instances, inst)
+				}
+			}
+		}
+		if page.NextPageToken == "" {
+			return instances, nil
+		}
+		q.Set("pageToken", page.NextPageToken)
+	}
+}
+
+// token returns the access token of API requests.
+func (p *GCP) token(ctx context.Context, client *http.Client) (string, error) {
+	if p.AccessToken != "" {
+		return p.AccessToken, nil
+	}
+	base := p.MetadataURL
+	if base == "" {
+		base = gcpMetadataURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/instance/service-accounts/default/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gcp: no GOOGLE_OAUTH_ACCESS_TOKEN and no metadata server: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", apiError(ProviderGCP, resp)
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := decodeJSON(resp, &tok); err != nil || tok.AccessToken == "" {
+		return "", fmt.Errorf("gcp: metadata server returned no access token")
+	}
+	return tok.AccessToken, nil
+}
+
+// gcpFilter returns the API filter of the running instances with the
+// labels of f. Regions and networks are matched on the results.
+func gcpFilter(f Filter) string {
+	terms := []string{"(status = RUNNING)"}
+	keys := make([]string, 0, len(f.Tags))
+	for key := range f.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		terms = append(terms, "(labels."+key+" = "+strconv.Quote(f.Tags[key])+")")
+	}
+	return strings.Join(terms, " ")
+}
+
+// matchZone reports whether zone is in one of regions (e.g. zone
+// "europe-west1-b" of region "europe-west1"), or equals one of them.
+func matchZone(regions []string, zone string) bool {
+	if len(regions) == 0 {
+		return true
+	}
+	for _, r := range regions {
+		if zone == r || strings.HasPrefix(zone, r+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// gcpAggregatedInstances is the part of an aggregated instance list the
+// provider reads.
+type gcpAggregatedInstances struct {
+	Items map[string]struct {
+		Instances []gcpInstance `json:"instances"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+type gcpInstance struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Zone   string            `json:"zone"`
+	Labels map[string]string `json:"labels"`
+	Tags   struct {
+		Items []string `json:"items"`
+	} `json:"tags"`
+	NetworkInterfaces []struct {
+		Network       string `json:"network"`
+		NetworkIP     string `json:"networkIP"`
+		AccessConfigs []struct {
+			NatIP string `json:"natIP"`
+		} `json:"accessConfigs"`
+	} `json:"networkInterfaces"`
+}
+
+// instance converts a Compute Engine instance of project. Its network is
+// that of its first interface; its network tags, which firewall rules
+// target, stand for security groups.
+func (g gcpInstance) instance(project string) Instance {
+	inst := Instance{
+		Provider:       ProviderGCP,
+		ID:             g.ID,
+		Name:           g.Name,
+		Account:        project,
+		Region:         path.Base(g.Zone),
+		Tags:           g.Labels,
+		SecurityGroups: g.Tags.Items,
+	}
+	for i, nic := range g.NetworkInterfaces {
+		if i == 0 {
+			inst.Network = path.Base(nic.Network)
+		}
+		if nic.NetworkIP != "" {
+			inst.PrivateIPs = append(inst.PrivateIPs, nic.NetworkIP)
+		}
+		for _, ac := range nic.AccessConfigs {
+			if ac.NatIP != "" {
+				inst.PublicIPs = append(inst.PublicIPs, ac.NatIP)
+			}
+		}
+	}
+	return inst
+}
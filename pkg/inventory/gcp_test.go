@@ -0,0 +1,96 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCP_Instances(t *testing.T) {
+	var filters, pageTokens []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metadata/instance/service-accounts/default/token", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "meta-token"})
+	})
+	mux.HandleFunc("GET /compute/projects/acme/aggregated/instances", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer meta-token", r.Header.Get("Authorization"))
+		filters = append(filters, r.URL.Query().Get("filter"))
+		pageTokens = append(pageTokens, r.URL.Query().Get("pageToken"))
+		if r.URL.Query().Get("pageToken") == "" {
+			_, _ = w.Write([]byte(`{
+			  "items": {
+			    "zones/europe-west1-b": {"instances": [{
+			      "id": "101", "name": "web-1",
+			      "zone": "https://www.googleapis.com/compute/v1/projects/acme/zones/europe-west1-b",
+			      "labels": {"env": "prod"},
+			      "tags": {"items": ["http-server", "ssh"]},
+			      "networkInterfaces": [{
+			        "network": "https://www.googleapis.com/compute/v1/projects/acme/global/networks/prod-net",
+			        "networkIP": "10.132.0.2",
+			        "accessConfigs": [{"natIP": "34.1.2.3"}]
+			      }]
+			    }]},
+			    "zones/us-central1-a": {"warning": {"code": "NO_RESULTS_ON_PAGE"}}
+			  },
+			  "nextPageToken": "p2"
+			}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": {"zones/us-central1-a": {"instances": [{
+		  "id": "102", "name": "web-2",
+		  "zone": "https://www.googleapis.com/compute/v1/projects/acme/zones/us-central1-a",
+		  "networkInterfaces": [{"network": "global/networks/prod-net", "networkIP": "10.128.0.2"}]
+		}]}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	p := &GCP{Project: "acme", ComputeURL: srv.URL + "/compute", MetadataURL: srv.URL + "/metadata"}
+	f, err := ParseFilter([]string{"tag:env=prod", "vpc:prod-net", "region:europe-west1"})
+	require.NoError(t, err)
+
+	instances, err := p.Instances(context.Background(), f)
+	require.NoError(t, err)
+	require.Equal(t, []Instance{{
+		Provider:       ProviderGCP,
+		ID:             "101",
+		Name:           "web-1",
+		Account:        "acme",
+		Region:         "europe-west1-b",
+		Network:        "prod-net",
+		PrivateIPs:     []string{"10.132.0.2"},
+		PublicIPs:      []string{"34.1.2.3"},
+		Tags:           map[string]string{"env": "prod"},
+		SecurityGroups: []string{"http-server", "ssh"},
+	}}, instances, "the instance of us-central1-a is not in the filtered region")
+
+	require.Equal(t, []string{`(status = RUNNING) (labels.env = "prod")`, `(status = RUNNING) (labels.env = "prod")`}, filters)
+	require.Equal(t, []string{"", "p2"}, pageTokens)
+}
+
+func TestGCP_AccessTokenAndErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer user-token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"message": "Required 'compute.instances.list' permission"}}`))
+	}))
+	defer srv.Close()
+
+	p := &GCP{Project: "acme", AccessToken: "user-token", ComputeURL: srv.URL}
+	_, err := p.Instances(context.Background(), Filter{})
+	require.ErrorContains(t, err, "403")
+	require.ErrorContains(t, err, "compute.instances.list")
+}
+
+func TestMatchZone(t *testing.T) {
+	require.True(t, matchZone(nil, "europe-west1-b"))
+	require.True(t, matchZone([]string{"europe-west1"}, "europe-west1-b"))
+	require.True(t, matchZone([]string{"europe-west1-b"}, "europe-west1-b"))
+	require.False(t, matchZone([]string{"europe-west"}, "europe-west1-b"))
+	require.False(t, matchZone([]string{"us-central1"}, "europe-west1-b"))
+}
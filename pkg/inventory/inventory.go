@@ -0,0 +1,261 @@
+// Package inventory lists the instances of cloud providers (AWS EC2, GCP
+// Compute Engine and Azure virtual machines) and the exposed workloads of
+// Kubernetes clusters as scan targets, so that scans cover the live
+// inventory instead of static address ranges.
+//
+// Providers call the APIs directly and take their credentials from the
+// environment variables or configuration files of the platform's own
+// tooling; see the provider constructors.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Provider names.
+const (
+	ProviderAWS        = "aws"
+	ProviderGCP        = "gcp"
+	ProviderAzure      = "azure"
+	ProviderKubernetes = "kubernetes"
+)
+
+// Providers lists the supported provider names.
+var Providers = []string{ProviderAWS, ProviderGCP, ProviderAzure, ProviderKubernetes}
+
+// defaultTimeout bounds each API request.
+const defaultTimeout = 30 * time.Second
+
+// Instance is a virtual machine of a cloud provider, or a Service, NodePort
+// or Ingress of a Kubernetes cluster.
+type Instance struct {
+	Provider   string            `json:"provider"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name,omitempty"`
+	Account    string            `json:"account,omitempty"` // AWS account, GCP project or Azure subscription
+	Region     string            `json:"region,omitempty"`  // AWS/Azure region, GCP zone
+	Network    string            `json:"network,omitempty"` // VPC ID, GCP network, Azure virtual network or Kubernetes namespace
+	PrivateIPs []string          `json:"private_ips,omitempty"`
+	PublicIPs  []string          `json:"public_ips,omitempty"` // addresses, or Kubernetes load balancer and Ingress hostnames
+	Ports      []int             `json:"ports,omitempty"`      // TCP ports exposed by Kubernetes workloads
+	Tags       map[string]string `json:"tags,omitempty"`       // tags, or GCP and Kubernetes labels
+
+	// SecurityGroups are the AWS security group IDs, GCP network tags or
+	// Azure network security groups of the instance
+	SecurityGroups []string `json:"security_groups,omitempty"`
+}
+
+// Filter selects instances. Empty fields match all instances.
+type Filter struct {
+	// Tags must all be set on instances with these values (GCP and
+	// Kubernetes labels)
+	Tags map[string]string
+
+	// Networks are the VPC IDs (AWS), network names (GCP) or virtual
+	// network names (Azure) instances must be attached to, any of them
+	Networks []string
+
+	// Regions are the regions instances must run in, any of them. AWS
+	// queries these regions (default: AWS_REGION); GCP matches them as
+	// zone prefixes.
+	Regions []string
+
+	// Namespaces are the Kubernetes namespaces workloads must run in, any
+	// of them
+	Namespaces []string
+}
+
+// ParseFilter parses filter specs such as "tag:env=prod", "vpc:vpc-0abc",
+// "region:eu-west-1" and "namespace:default". Tag filters are combined; vpc,
+// region and namespace filters match any of their values.
+func ParseFilter(specs []string) (Filter, error) {
+	var f Filter
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		kind, value, ok := strings.Cut(spec, ":")
+		if !ok || value == "" {
+			return Filter{}, fmt.Errorf("invalid filter %q: expected tag:KEY=VALUE, vpc:ID, region:NAME or namespace:NAME", spec)
+		}
+		switch kind {
+		case "tag":
+			key, val, ok := strings.Cut(value, "=")
+			if !ok || key == "" {
+				return Filter{}, fmt.Errorf("invalid filter %q: expected tag:KEY=VALUE", spec)
+			}
+			if f.Tags == nil {
+				f.Tags = map[string]string{}
+			}
+			f.Tags[key] = val
+		case "vpc":
+			f.Networks = append(f.Networks, value)
+		case "region":
+			f.Regions = append(f.Regions, value)
+		case "namespace":
+			f.Namespaces = append(f.Namespaces, value)
+		default:
+			return Filter{}, fmt.Errorf("invalid filter %q: unknown filter %q (use tag, vpc, region or namespace)", spec, kind)
+		}
+	}
+	return f, nil
+}
+
+// matchTags reports whether tags has all tags of f, comparing keys
+// case-insensitively if foldKeys is set (Azure tags).
+func (f Filter) matchTags(tags map[string]string, foldKeys bool) bool {
+	for key, want := range f.Tags {
+		got, ok := tags[key]
+		if !ok && foldKeys {
+			for k, v := range tags {
+				if strings.EqualFold(k, key) {
+					got, ok = v, true
+					break
+				}
+			}
+		}
+		if !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// matchNetwork reports whether network is one of the networks of f.
+func (f Filter) matchNetwork(network string) bool {
+	return len(f.Networks) == 0 || slices.ContainsFunc(f.Networks, func(n string) bool {
+		return strings.EqualFold(n, network)
+	})
+}
+
+// matchNamespace reports whether namespace is one of the namespaces of f.
+func (f Filter) matchNamespace(namespace string) bool {
+	return len(f.Namespaces) == 0 || slices.Contains(f.Namespaces, namespace)
+}
+
+// Provider lists the instances of a cloud account.
+type Provider interface {
+	// Name returns the provider name, e.g. "aws".
+	Name() string
+
+	// Instances returns the running instances matching f.
+	Instances(ctx context.Context, f Filter) ([]Instance, error)
+}
+
+// New returns the provider named name, configured from the environment.
+func New(name string) (Provider, error) {
+	var (
+		p   Provider
+		err error
+	)
+	switch strings.ToLower(name) {
+	case ProviderAWS:
+		p, err = NewAWS()
+	case ProviderGCP:
+		p, err = NewGCP()
+	case ProviderAzure:
+		p, err = NewAzure()
+	case ProviderKubernetes:
+		p, err = NewKubernetes()
+	default:
+		return nil, fmt.Errorf("unknown inventory provider %q (use %s)", name, strings.Join(Providers, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Addresses returns the private addresses of the instance, or its public
+// ones if public is set.
+func (inst Instance) Addresses(public bool) []string {
+	if public {
+		return inst.PublicIPs
+	}
+	return inst.PrivateIPs
+}
+
+// Targets returns the addresses of instances to scan: their private
+// addresses, or their public ones if public is set. Instances without such
+// an address are left out. Addresses are sorted and listed once.
+func Targets(instances []Instance, public bool) []string {
+	seen := map[string]bool{}
+	var targets []string
+	for _, inst := range instances {
+		for _, ip := range inst.Addresses(public) {
+			if ip != "" && !seen[ip] {
+				seen[ip] = true
+				targets = append(targets, ip)
+			}
+		}
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// Ports returns the ports exposed by instances, sorted and listed once.
+func Ports(instances []Instance) []int {
+	var ports []int
+	for _, inst := range instances {
+		for _, port := range inst.Ports {
+			if !slices.Contains(ports, port) {
+				ports = append(ports, port)
+			}
+		}
+	}
+	sort.Ints(ports)
+	return ports
+}
+
+// newHTTPClient returns the client of provider API requests. Proxies are
+// taken from the environment.
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: defaultTimeout}
+}
+
+// apiError returns the error of a failed API response, with the start of
+// its body, which holds the provider's error message.
+func apiError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s API %s: %s: %s", provider, resp.Request.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// getJSON fetches url with the bearer token, if any, and decodes the JSON
+// response into v.
+func getJSON(ctx context.Context, client *http.Client, provider, url, token string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s API: %w", provider, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return apiError(provider, resp)
+	}
+	if err := decodeJSON(resp, v); err != nil {
+		return fmt.Errorf("%s API %s: decode response: %w", provider, req.URL.Path, err)
+	}
+	return nil
+}
+
+// decodeJSON decodes the JSON body of resp into v.
+func decodeJSON(resp *http.Response, v interface{}) error {
+	return json.NewDecoder(resp.Body).Decode(v)
+}
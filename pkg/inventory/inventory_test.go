@@ -0,0 +1,71 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter([]string{"tag:env=prod", "tag:team=web", "vpc:vpc-1", "vpc:vpc-2", "region:eu-west-1", "namespace:shop", " "})
+	require.NoError(t, err)
+	require.Equal(t, Filter{
+		Tags:       map[string]string{"env": "prod", "team": "web"},
+		Networks:   []string{"vpc-1", "vpc-2"},
+		Regions:    []string{"eu-west-1"},
+		Namespaces: []string{"shop"},
+	}, f)
+
+	f, err = ParseFilter([]string{"tag:empty="})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"empty": ""}, f.Tags)
+
+	for _, spec := range []string{"env=prod", "tag:env", "tag:=prod", "vpc:", "zone:a"} {
+		_, err := ParseFilter([]string{spec})
+		require.Error(t, err, spec)
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	f := Filter{Tags: map[string]string{"env": "prod"}, Networks: []string{"VPC-1"}}
+	require.True(t, f.matchTags(map[string]string{"env": "prod", "x": "y"}, false))
+	require.False(t, f.matchTags(map[string]string{"Env": "prod"}, false))
+	require.True(t, f.matchTags(map[string]string{"Env": "prod"}, true))
+	require.False(t, f.matchTags(map[string]string{"env": "Prod"}, true))
+	require.True(t, f.matchNetwork("vpc-1"))
+	require.False(t, f.matchNetwork("vpc-2"))
+	require.True(t, Filter{}.matchNetwork(""))
+}
+
+func TestTargets(t *testing.T) {
+	instances := []Instance{
+		{PrivateIPs: []string{"10.0.0.2"}, PublicIPs: []string{"203.0.113.2"}},
+		{PrivateIPs: []string{"10.0.0.1", "10.0.0.2"}},
+	}
+	require.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, Targets(instances, false))
+	require.Equal(t, []string{"203.0.113.2"}, Targets(instances, true))
+	require.Empty(t, Targets(nil, false))
+}
+
+func TestPorts(t *testing.T) {
+	instances := []Instance{{Ports: []int{443, 80}}, {}, {Ports: []int{80, 30080}}}
+	require.Equal(t, []int{80, 443, 30080}, Ports(instances))
+	require.Empty(t, Ports(nil))
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	_, err := New("aws")
+	require.ErrorContains(t, err, "AWS_ACCESS_KEY_ID")
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "eu-west-1")
+	p, err := New("AWS")
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", p.(*AWS).Region)
+
+	_, err = New("oracle")
+	require.ErrorContains(t, err, "unknown inventory provider")
+}
@@ -0,0 +1,501 @@
+package inventory
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// In-cluster service account credentials.
+const (
+	inClusterTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	inClusterCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Kubernetes lists the exposed workloads of a cluster: its Services (cluster
+// IPs and load balancers), NodePorts on the node addresses, and Ingress
+// hosts.
+type Kubernetes struct {
+	// Server is the API server URL
+	Server string
+
+	// Token authenticates requests as a bearer token; alternatively
+	// ClientCert and ClientKey (PEM) authenticate with a client certificate
+	Token      string
+	ClientCert []byte
+	ClientKey  []byte
+
+	// CA (PEM) verifies the API server; Insecure skips verification
+	CA       []byte
+	Insecure bool
+}
+
+// NewKubernetes returns the provider of the current context of the
+// kubeconfig (KUBECONFIG, or ~/.kube/config), or, without one, of the
+// in-cluster service account.
+func NewKubernetes() (*Kubernetes, error) {
+	path := os.Getenv("KUBECONFIG")
+	if i := strings.IndexRune(path, os.PathListSeparator); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, ".kube", "config")
+		}
+	}
+	if path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			return kubernetesFromKubeconfig(data, filepath.Dir(path))
+		}
+	}
+
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" {
+		return nil, fmt.Errorf("kubernetes: no kubeconfig at %q and not running in a cluster", path)
+	}
+	token, err := os.ReadFile(inClusterTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(inClusterCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: read service account CA: %w", err)
+	}
+	return &Kubernetes{Server: "https://" + joinHostPort(host, port), Token: strings.TrimSpace(string(token)), CA: ca}, nil
+}
+
+// joinHostPort joins host and port, bracketing IPv6 hosts.
+func joinHostPort(host, port string) string {
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port == "" {
+		return host
+	}
+	return host + ":" + port
+}
+
+// kubeconfig is the part of a kubeconfig file the provider reads.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string        `yaml:"token"`
+			TokenFile             string        `yaml:"tokenFile"`
+			ClientCertificate     string        `yaml:"client-certificate"`
+			ClientCertificateData string        `yaml:"client-certificate-data"`
+			ClientKey             string        `yaml:"client-key"`
+			ClientKeyData         string        `yaml:"client-key-data"`
+			Exec                  *kubeExecAuth `yaml:"exec"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// kubeExecAuth is a credential plugin, such as the ones of EKS and GKE.
+type kubeExecAuth struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Command    string   `yaml:"command"`
+	Args       []string `yaml:"args"`
+	Env        []struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"env"`
+}
+
+// kubernetesFromKubeconfig returns the provider of the current context of a
+// kubeconfig. Relative file paths are resolved against dir.
+func kubernetesFromKubeconfig(data []byte, dir string) (*Kubernetes, error) {
+	var cfg kubeconfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("kubernetes: parse kubeconfig: %w", err)
+	}
+
+	var clusterName, userName string
+	found := false
+	for _, c := range cfg.Contexts {
+		if c.Name == cfg.CurrentContext {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("kubernetes: kubeconfig has no current context %q", cfg.CurrentContext)
+	}
+
+	k := &Kubernetes{}
+	readFile := func(name string) ([]byte, error) {
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		return os.ReadFile(name)
+	}
+	decode := func(field, data string) ([]byte, error) {
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: kubeconfig %s: %w", field, err)
+		}
+		return b, nil
+	}
+
+	for _, c := range cfg.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		k.Server = c.Cluster.Server
+		k.Insecure = c.Cluster.InsecureSkipTLSVerify
+		var err error
+		switch {
+		case c.Cluster.CertificateAuthorityData != "":
+			k.CA, err = decode("certificate-authority-data", c.Cluster.CertificateAuthorityData)
+		case c.Cluster.CertificateAuthority != "":
+			k.CA, err = readFile(c.Cluster.CertificateAuthority)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if k.Server == "" {
+		return nil, fmt.Errorf("kubernetes: kubeconfig has no server for cluster %q", clusterName)
+	}
+
+	for _, u := range cfg.Users {
+		if u.Name != userName {
+			continue
+		}
+		var err error
+		switch {
+		case u.User.Token != "":
+			k.Token = u.User.Token
+		case u.User.TokenFile != "":
+			var token []byte
+			token, err = readFile(u.User.TokenFile)
+			k.Token = strings.TrimSpace(string(token))
+		case u.User.Exec != nil:
+			k.Token, err = u.User.Exec.token()
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case u.User.ClientCertificateData != "":
+			if k.ClientCert, err = decode("client-certificate-data", u.User.ClientCertificateData); err != nil {
+				return nil, err
+			}
+		case u.User.ClientCertificate != "":
+			if k.ClientCert, err = readFile(u.User.ClientCertificate); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case u.User.ClientKeyData != "":
+			if k.ClientKey, err = decode("client-key-data", u.User.ClientKeyData); err != nil {
+				return nil, err
+			}
+		case u.User.ClientKey != "":
+			if k.ClientKey, err = readFile(u.User.ClientKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return k, nil
+}
+
+// token runs the credential plugin and returns the token it issued.
+func (e *kubeExecAuth) token() (string, error) {
+	cmd := exec.Command(e.Command, e.Args...)
+	cmd.Env = os.Environ()
+	for _, v := range e.Env {
+		cmd.Env = append(cmd.Env, v.Name+"="+v.Value)
+	}
+	info, _ := json.Marshal(map[string]interface{}{
+		"apiVersion": e.APIVersion,
+		"kind":       "ExecCredential",
+		"spec":       map[string]interface{}{"interactive": false},
+	})
+	cmd.Env = append(cmd.Env, "KUBERNETES_EXEC_INFO="+string(info))
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("kubernetes: credential plugin %s: %w", e.Command, err)
+	}
+	var cred struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(out, &cred); err != nil || cred.Status.Token == "" {
+		return "", fmt.Errorf("kubernetes: credential plugin %s returned no token", e.Command)
+	}
+	return cred.Status.Token, nil
+}
+
+// Name returns "kubernetes".
+func (p *Kubernetes) Name() string { return ProviderKubernetes }
+
+// Instances returns the Services, NodePorts and Ingresses matching f, with
+// the ports they expose. Cluster IPs and node internal addresses are
+// private; load balancer, Ingress and node external addresses are public.
+func (p *Kubernetes) Instances(ctx context.Context, f Filter) ([]Instance, error) {
+	client, err := p.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	if selector := labelSelector(f.Tags); selector != "" {
+		q.Set("labelSelector", selector)
+	}
+	get := func(path string, v interface{}) error {
+		u := strings.TrimSuffix(p.Server, "/") + path
+		if len(q) > 0 {
+			u += "?" + q.Encode()
+		}
+		return getJSON(ctx, client, ProviderKubernetes, u, p.Token, v)
+	}
+
+	var services kubeServiceList
+	if err := get("/api/v1/services", &services); err != nil {
+		return nil, err
+	}
+	var ingresses kubeIngressList
+	if err := get("/apis/networking.k8s.io/v1/ingresses", &ingresses); err != nil {
+		return nil, err
+	}
+	var nodes kubeNodeList
+	if services.hasNodePorts(f) {
+		// Nodes are not selected by the labels of services
+		q = url.Values{}
+		if err := get("/api/v1/nodes", &nodes); err != nil {
+			return nil, err
+		}
+	}
+	nodeInternal, nodeExternal := nodes.addresses()
+
+	var instances []Instance
+	for _, svc := range services.Items {
+		if !f.matchNamespace(svc.Metadata.Namespace) {
+			continue
+		}
+		inst := svc.Metadata.instance(ProviderKubernetes, "service")
+		for _, ip := range svc.Spec.ClusterIPs {
+			if ip != "" && ip != "None" {
+				inst.PrivateIPs = append(inst.PrivateIPs, ip)
+			}
+		}
+		for _, lb := range svc.Status.LoadBalancer.Ingress {
+			inst.PublicIPs = appendAddress(inst.PublicIPs, lb.IP, lb.Hostname)
+		}
+		var nodePorts []int
+		for _, port := range svc.Spec.Ports {
+			if strings.EqualFold(port.Protocol, "UDP") || strings.EqualFold(port.Protocol, "SCTP") {
+				continue
+			}
+			inst.Ports = append(inst.Ports, port.Port)
+			if port.NodePort != 0 {
+				nodePorts = append(nodePorts, port.NodePort)
+			}
+		}
+		if len(inst.PrivateIPs) > 0 || len(inst.PublicIPs) > 0 {
+			instances = append(instances, inst)
+		}
+
+		if len(nodePorts) > 0 && (svc.Spec.Type == "NodePort" || svc.Spec.Type == "LoadBalancer") {
+			np := svc.Metadata.instance(ProviderKubernetes, "nodeport")
+			np.PrivateIPs, np.PublicIPs, np.Ports = nodeInternal, nodeExternal, nodePorts
+			instances = append(instances, np)
+		}
+	}
+
+	for _, ing := range ingresses.Items {
+		if !f.matchNamespace(ing.Metadata.Namespace) {
+			continue
+		}
+		inst := ing.Metadata.instance(ProviderKubernetes, "ingress")
+		for _, lb := range ing.Status.LoadBalancer.Ingress {
+			inst.PublicIPs = appendAddress(inst.PublicIPs, lb.IP, lb.Hostname)
+		}
+		for _, rule := range ing.Spec.Rules {
+			// Wildcard hosts name no host to scan
+			if rule.Host != "" && !strings.HasPrefix(rule.Host, "*") {
+				inst.PublicIPs = appendAddress(inst.PublicIPs, rule.Host)
+			}
+		}
+		inst.Ports = []int{80}
+		if len(ing.Spec.TLS) > 0 {
+			inst.Ports = append(inst.Ports, 443)
+		}
+		if len(inst.PublicIPs) > 0 {
+			instances = append(instances, inst)
+		}
+	}
+	return instances, nil
+}
+
+// httpClient returns the client of API requests, trusting the cluster CA
+// and presenting the client certificate.
+func (p *Kubernetes) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: p.Insecure} // #nosec G402 -- opt-in via kubeconfig
+	if len(p.CA) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(p.CA) {
+			return nil, fmt.Errorf("kubernetes: no certificates in the cluster CA")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(p.ClientCert) > 0 {
+		cert, err := tls.X509KeyPair(p.ClientCert, p.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes: client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	client := newHTTPClient()
+	client.Transport = transport
+	return client, nil
+}
+
+// labelSelector returns the equality-based label selector of tags.
+func labelSelector(tags map[string]string) string {
+	terms := make([]string, 0, len(tags))
+	for key, value := range tags {
+		terms = append(terms, key+"="+value)
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, ",")
+}
+
+// appendAddress appends the non-empty addresses to list.
+func appendAddress(list []string, addrs ...string) []string {
+	for _, a := range addrs {
+		if a != "" {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+type kubeMetadata struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	UID       string            `json:"uid"`
+	Labels    map[string]string `json:"labels"`
+}
+
+// instance returns the instance of an object of kind, identified as
+// kind/namespace/name.
+func (m kubeMetadata) instance(provider, kind string) Instance {
+	return Instance{
+		Provider: provider,
+		ID:       kind + "/" + m.Namespace + "/" + m.Name,
+		Name:     m.Name,
+		Network:  m.Namespace,
+		Tags:     m.Labels,
+	}
+}
+
+type kubeLoadBalancerStatus struct {
+	Ingress []struct {
+		IP       string `json:"ip"`
+		Hostname string `json:"hostname"`
+	} `json:"ingress"`
+}
+
+type kubeServiceList struct {
+	Items []struct {
+		Metadata kubeMetadata `json:"metadata"`
+		Spec     struct {
+			Type       string   `json:"type"`
+			ClusterIPs []string `json:"clusterIPs"`
+			Ports      []struct {
+				Protocol string `json:"protocol"`
+				Port     int    `json:"port"`
+				NodePort int    `json:"nodePort"`
+			} `json:"ports"`
+		} `json:"spec"`
+		Status struct {
+			LoadBalancer kubeLoadBalancerStatus `json:"loadBalancer"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// hasNodePorts reports whether a service matching f exposes NodePorts.
+func (l kubeServiceList) hasNodePorts(f Filter) bool {
+	for _, svc := range l.Items {
+		if f.matchNamespace(svc.Metadata.Namespace) && (svc.Spec.Type == "NodePort" || svc.Spec.Type == "LoadBalancer") {
+			return true
+		}
+	}
+	return false
+}
+
+type kubeIngressList struct {
+	Items []struct {
+		Metadata kubeMetadata `json:"metadata"`
+		Spec     struct {
+			TLS   []json.RawMessage `json:"tls"`
+			Rules []struct {
+				Host string `json:"host"`
+			} `json:"rules"`
+		} `json:"spec"`
+		Status struct {
+			LoadBalancer kubeLoadBalancerStatus `json:"loadBalancer"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type kubeNodeList struct {
+	Items []struct {
+		Status struct {
+			Addresses []struct {
+				Type    string `json:"type"`
+				Address string `json:"address"`
+			} `json:"addresses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// addresses returns the internal and external addresses of the nodes.
+func (l kubeNodeList) addresses() (internal, external []string) {
+	for _, node := range l.Items {
+		for _, a := range node.Status.Addresses {
+			switch a.Type {
+			case "InternalIP":
+				internal = append(internal, a.Address)
+			case "ExternalIP":
+				external = append(external, a.Address)
+			}
+		}
+	}
+	return internal, external
+}
@@ -0,0 +1,145 @@
+package inventory
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubernetes_Instances(t *testing.T) {
+	var selectors []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/services", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer kube-token", r.Header.Get("Authorization"))
+		selectors = append(selectors, r.URL.Query().Get("labelSelector"))
+		_, _ = w.Write([]byte(`{"items": [
+		  {"metadata": {"name": "web", "namespace": "shop", "labels": {"app": "web"}},
+		   "spec": {"type": "LoadBalancer", "clusterIPs": ["10.96.0.10"], "ports": [
+		     {"protocol": "TCP", "port": 443, "nodePort": 30443},
+		     {"protocol": "UDP", "port": 53, "nodePort": 30053}]},
+		   "status": {"loadBalancer": {"ingress": [{"ip": "203.0.113.10"}, {"hostname": "web.elb.example.com"}]}}},
+		  {"metadata": {"name": "db", "namespace": "shop"},
+		   "spec": {"type": "ClusterIP", "clusterIPs": ["None"], "ports": [{"port": 5432}]}},
+		  {"metadata": {"name": "admin", "namespace": "ops"},
+		   "spec": {"type": "NodePort", "clusterIPs": ["10.96.0.20"], "ports": [{"port": 8080, "nodePort": 30080}]}}
+		]}`))
+	})
+	mux.HandleFunc("GET /apis/networking.k8s.io/v1/ingresses", func(w http.ResponseWriter, r *http.Request) {
+		selectors = append(selectors, r.URL.Query().Get("labelSelector"))
+		_, _ = w.Write([]byte(`{"items": [
+		  {"metadata": {"name": "shop", "namespace": "shop"},
+		   "spec": {"tls": [{"hosts": ["shop.example.com"]}], "rules": [{"host": "shop.example.com"}, {"host": "*.example.com"}]},
+		   "status": {"loadBalancer": {"ingress": [{"ip": "203.0.113.20"}]}}}
+		]}`))
+	})
+	mux.HandleFunc("GET /api/v1/nodes", func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.URL.Query().Get("labelSelector"))
+		_, _ = w.Write([]byte(`{"items": [{"status": {"addresses": [
+		  {"type": "InternalIP", "address": "192.168.1.10"},
+		  {"type": "ExternalIP", "address": "198.51.100.10"},
+		  {"type": "Hostname", "address": "node-1"}]}}]}`))
+	})
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+
+	p := &Kubernetes{
+		Server: srv.URL,
+		Token:  "kube-token",
+		CA:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}),
+	}
+	f, err := ParseFilter([]string{"tag:app=web", "namespace:shop"})
+	require.NoError(t, err)
+
+	instances, err := p.Instances(context.Background(), f)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app=web", "app=web"}, selectors)
+	require.Equal(t, []Instance{
+		{
+			Provider:   ProviderKubernetes,
+			ID:         "service/shop/web",
+			Name:       "web",
+			Network:    "shop",
+			PrivateIPs: []string{"10.96.0.10"},
+			PublicIPs:  []string{"203.0.113.10", "web.elb.example.com"},
+			Ports:      []int{443},
+			Tags:       map[string]string{"app": "web"},
+		},
+		{
+			Provider:   ProviderKubernetes,
+			ID:         "nodeport/shop/web",
+			Name:       "web",
+			Network:    "shop",
+			PrivateIPs: []string{"192.168.1.10"},
+			PublicIPs:  []string{"198.51.100.10"},
+			Ports:      []int{30443},
+			Tags:       map[string]string{"app": "web"},
+		},
+		{
+			Provider:  ProviderKubernetes,
+			ID:        "ingress/shop/shop",
+			Name:      "shop",
+			Network:   "shop",
+			PublicIPs: []string{"203.0.113.20", "shop.example.com"},
+			Ports:     []int{80, 443},
+		},
+	}, instances)
+	require.Equal(t, []int{80, 443, 30443}, Ports(instances))
+
+	// The cluster CA is verified
+	p.CA = nil
+	_, err = p.Instances(context.Background(), Filter{})
+	require.Error(t, err)
+}
+
+func TestKubernetesFromKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("file-token\n"), 0o600))
+	ca := base64.StdEncoding.EncodeToString([]byte("ca-pem"))
+
+	kubeconfig := `
+apiVersion: v1
+kind: Config
+current-context: prod
+clusters:
+- name: dev
+  cluster: {server: https://dev.example.com}
+- name: prod
+  cluster:
+    server: https://prod.example.com:6443
+    certificate-authority-data: ` + ca + `
+contexts:
+- name: dev
+  context: {cluster: dev, user: dev}
+- name: prod
+  context: {cluster: prod, user: prod, namespace: shop}
+users:
+- name: dev
+  user: {token: dev-token}
+- name: prod
+  user: {tokenFile: token}
+`
+	p, err := kubernetesFromKubeconfig([]byte(kubeconfig), dir)
+	require.NoError(t, err)
+	require.Equal(t, &Kubernetes{
+		Server: "https://prod.example.com:6443",
+		Token:  "file-token",
+		CA:     []byte("ca-pem"),
+	}, p)
+
+	_, err = kubernetesFromKubeconfig([]byte("current-context: missing\n"), dir)
+	require.ErrorContains(t, err, "no current context")
+}
+
+func TestNewKubernetes_NoConfig(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	_, err := NewKubernetes()
+	require.ErrorContains(t, err, "not running in a cluster")
+}
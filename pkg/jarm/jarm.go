@@ -0,0 +1,507 @@
+// Package jarm computes active TLS server fingerprints. JARM sends ten
+// crafted ClientHellos varying the TLS version, cipher order, ALPN values
+// and extensions, and hashes how the server answers each; servers built on
+// the same TLS stack and configuration share a fingerprint whatever their
+// banner says, which groups infrastructure and picks out C2 frameworks.
+//
+// The hellos and the hash follow the reference implementation
+// (github.com/salesforce/jarm), so fingerprints compare with published
+// ones. JA3S, the hash of the server's version, cipher and extensions, is
+// computed from the answer to the first hello.
+package jarm
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // #nosec G501 -- JA3S is defined as an MD5 hash
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmptyHash is the JARM of a server that answered none of the hellos.
+const EmptyHash = "00000000000000000000000000000000000000000000000000000000000000"
+
+// maxResponse bounds the part of the server's answer read, as the
+// reference implementation does.
+const maxResponse = 1484
+
+// order is how a list of ciphers, ALPN values or versions is reordered.
+type order int
+
+const (
+	forward order = iota
+	reverse
+	topHalf
+	bottomHalf
+	middleOut
+)
+
+// support is the supported_versions extension sent by a hello.
+type support int
+
+const (
+	supportNone support = iota
+	support12
+	support13
+)
+
+// probe describes one of the JARM ClientHellos.
+type probe struct {
+	Version        uint16 // TLS version of the hello: 0x0302, 0x0303 or 0x0304
+	NoTLS13Ciphers bool   // Leave out the TLS 1.3 cipher suites
+	CipherOrder    order
+	GREASE         bool
+	RareALPN       bool // Offer only ALPN values servers seldom select
+	Support        support
+	ExtensionOrder order // Order of the ALPN values and supported versions
+}
+
+// probes are the JARM hellos, in the order their answers are hashed.
+var probes = []probe{
+	{Version: 0x0303, CipherOrder: forward, Support: support12, ExtensionOrder: reverse},
+	{Version: 0x0303, CipherOrder: reverse, Support: support12, ExtensionOrder: forward},
+	{Version: 0x0303, CipherOrder: topHalf, Support: supportNone, ExtensionOrder: forward},
+	{Version: 0x0303, CipherOrder: bottomHalf, RareALPN: true, Support: supportNone, ExtensionOrder: forward},
+	{Version: 0x0303, CipherOrder: middleOut, GREASE: true, RareALPN: true, Support: supportNone, ExtensionOrder: reverse},
+	{Version: 0x0302, CipherOrder: forward, Support: supportNone, ExtensionOrder: forward},
+	{Version: 0x0304, CipherOrder: forward, Support: support13, ExtensionOrder: reverse},
+	{Version: 0x0304, CipherOrder: reverse, Support: support13, ExtensionOrder: forward},
+	{Version: 0x0304, NoTLS13Ciphers: true, CipherOrder: forward, Support: support13, ExtensionOrder: forward},
+	{Version: 0x0304, CipherOrder: middleOut, GREASE: true, Support: support13, ExtensionOrder: reverse},
+}
+
+// allCiphers are the cipher suites offered, in the reference order.
+var allCiphers = []uint16{
+	0x0016, 0x0033, 0x0067, 0xc09e, 0xc0a2, 0x009e, 0x0039, 0x006b, 0xc09f, 0xc0a3,
+	0x009f, 0x0045, 0x00be, 0x0088, 0x00c4, 0x009a, 0xc008, 0xc009, 0xc023, 0xc0ac,
+	0xc0ae, 0xc02b, 0xc00a, 0xc024, 0xc0ad, 0xc0af, 0xc02c, 0xc072, 0xc073, 0xcca9,
+	0x1302, 0x1301, 0xcc14, 0xc007, 0xc012, 0xc013, 0xc027, 0xc02f, 0xc014, 0xc028,
+	0xc030, 0xc060, 0xc061, 0xc076, 0xc077, 0xcca8, 0x1305, 0x1304, 0x1303, 0xcc13,
+	0xc011, 0x000a, 0x002f, 0x003c, 0xc09c, 0xc0a0, 0x009c, 0x0035, 0x003d, 0xc09d,
+	0xc0a1, 0x009d, 0x0041, 0x00ba, 0x0084, 0x00c0, 0x0007, 0x0004, 0x0005,
+}
+
+// hashCiphers numbers the cipher suites a server can select, from 1, for
+// the hash.
+var hashCiphers = []uint16{
+	0x0004, 0x0005, 0x0007, 0x000a, 0x0016, 0x002f, 0x0033, 0x0035, 0x0039, 0x003c,
+	0x003d, 0x0041, 0x0045, 0x0067, 0x006b, 0x0084, 0x0088, 0x009a, 0x009c, 0x009d,
+	0x009e, 0x009f, 0x00ba, 0x00be, 0x00c0, 0x00c4, 0xc007, 0xc008, 0xc009, 0xc00a,
+	0xc011, 0xc012, 0xc013, 0xc014, 0xc023, 0xc024, 0xc027, 0xc028, 0xc02b, 0xc02c,
+	0xc02f, 0xc030, 0xc060, 0xc061, 0xc072, 0xc073, 0xc076, 0xc077, 0xc09c, 0xc09d,
+	0xc09e, 0xc09f, 0xc0a0, 0xc0a1, 0xc0a2, 0xc0a3, 0xc0ac, 0xc0ad, 0xc0ae, 0xc0af,
+	0xcc13, 0xcc14, 0xcca8, 0xcca9, 0x1301, 0x1302, 0x1303, 0x1304, 0x1305,
+}
+
+var (
+	alpns     = []string{"http/0.9", "http/1.0", "http/1.1", "spdy/1", "spdy/2", "spdy/3", "h2", "h2c", "hq"}
+	rareALPNs = []string{"http/0.9", "http/1.0", "spdy/1", "spdy/2", "spdy/3", "h2c", "hq"}
+)
+
+// reorder returns items in order o.
+func reorder[T any](items []T, o order) []T {
+	n := len(items)
+	out := make([]T, 0, n)
+	switch o {
+	case forward:
+		out = append(out, items...)
+	case reverse:
+		for i := n - 1; i >= 0; i-- {
+			out = append(out, items[i])
+		}
+	case bottomHalf:
+		out = append(out, items[n/2+n%2:]...)
+	case topHalf:
+		if n%2 == 1 {
+			out = append(out, items[n/2])
+		}
+		out = append(out, reorder(reorder(items, reverse), bottomHalf)...)
+	case middleOut:
+		middle := n / 2
+		if n%2 == 1 {
+			out = append(out, items[middle])
+			for i := 1; i <= middle; i++ {
+				out = append(out, items[middle+i], items[middle-i])
+			}
+		} else {
+			for i := 1; i <= middle; i++ {
+				out = append(out, items[middle-1+i], items[middle-i])
+			}
+		}
+	}
+	return out
+}
+
+// grease returns a random GREASE value (RFC 8701).
+func grease() []byte {
+	var b [1]byte
+	_, _ = rand.Read(b[:])
+	v := b[0]&0xf0 | 0x0a
+	return []byte{v, v}
+}
+
+// random returns n random bytes.
+func random(n int) []byte {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return b
+}
+
+// clientHello returns the TLS record carrying the ClientHello of p for
+// host, the name sent in the SNI extension.
+func clientHello(p probe, host string) []byte {
+	recordVersion, helloVersion := p.Version, p.Version
+	if p.Version == 0x0304 {
+		recordVersion, helloVersion = 0x0301, 0x0303
+	}
+
+	var hello bytes.Buffer
+	hello.Write(u16(helloVersion))
+	hello.Write(random(32))
+	hello.WriteByte(32)
+	hello.Write(random(32)) // Session ID
+
+	var ciphers bytes.Buffer
+	if p.GREASE {
+		ciphers.Write(grease())
+	}
+	suites := allCiphers
+	if p.NoTLS13Ciphers {
+		suites = nil
+		for _, c := range allCiphers {
+			if c>>8 != 0x13 {
+				suites = append(suites, c)
+			}
+		}
+	}
+	for _, c := range reorder(suites, p.CipherOrder) {
+		ciphers.Write(u16(c))
+	}
+	hello.Write(u16(uint16(ciphers.Len())))
+	hello.Write(ciphers.Bytes())
+	hello.Write([]byte{0x01, 0x00}) // One compression method: null
+	hello.Write(extensions(p, host))
+
+	handshake := append([]byte{0x01, 0x00}, u16(uint16(hello.Len()))...)
+	handshake = append(handshake, hello.Bytes()...)
+	record := append([]byte{0x16}, u16(recordVersion)...)
+	record = append(record, u16(uint16(len(handshake)))...)
+	return append(record, handshake...)
+}
+
+// extensions returns the extensions block of the hello of p.
+func extensions(p probe, host string) []byte {
+	var ext bytes.Buffer
+	if p.GREASE {
+		ext.Write(grease())
+		ext.Write([]byte{0x00, 0x00})
+	}
+
+	// server_name
+	ext.Write([]byte{0x00, 0x00})
+	ext.Write(u16(uint16(len(host) + 5)))
+	ext.Write(u16(uint16(len(host) + 3)))
+	ext.WriteByte(0x00)
+	ext.Write(u16(uint16(len(host))))
+	ext.WriteString(host)
+
+	ext.Write([]byte{0x00, 0x17, 0x00, 0x00})                                                             // extended_master_secret
+	ext.Write([]byte{0x00, 0x01, 0x00, 0x01, 0x01})                                                       // max_fragment_length
+	ext.Write([]byte{0xff, 0x01, 0x00, 0x01, 0x00})                                                       // renegotiation_info
+	ext.Write([]byte{0x00, 0x0a, 0x00, 0x0a, 0x00, 0x08, 0x00, 0x1d, 0x00, 0x17, 0x00, 0x18, 0x00, 0x19}) // supported_groups
+	ext.Write([]byte{0x00, 0x0b, 0x00, 0x02, 0x01, 0x00})                                                 // ec_point_formats
+	ext.Write([]byte{0x00, 0x23, 0x00, 0x00})                                                             // session_ticket
+
+	// application_layer_protocol_negotiation
+	protocols := alpns
+	if p.RareALPN {
+		protocols = rareALPNs
+	}
+	var list bytes.Buffer
+	for _, proto := range reorder(protocols, p.ExtensionOrder) {
+		list.WriteByte(byte(len(proto)))
+		list.WriteString(proto)
+	}
+	ext.Write([]byte{0x00, 0x10})
+	ext.Write(u16(uint16(list.Len() + 2)))
+	ext.Write(u16(uint16(list.Len())))
+	ext.Write(list.Bytes())
+
+	// signature_algorithms
+	ext.Write([]byte{0x00, 0x0d, 0x00, 0x14, 0x00, 0x12, 0x04, 0x03, 0x08, 0x04, 0x04, 0x01,
+		0x05, 0x03, 0x08, 0x05, 0x05, 0x01, 0x08, 0x06, 0x06, 0x01, 0x02, 0x01})
+
+	// key_share: an X25519 share
+	var share bytes.Buffer
+	if p.GREASE {
+		share.Write(grease())
+		share.Write([]byte{0x00, 0x01, 0x00})
+	}
+	share.Write([]byte{0x00, 0x1d, 0x00, 0x20})
+	share.Write(random(32))
+	ext.Write([]byte{0x00, 0x33})
+	ext.Write(u16(uint16(share.Len() + 2)))
+	ext.Write(u16(uint16(share.Len())))
+	ext.Write(share.Bytes())
+
+	ext.Write([]byte{0x00, 0x2d, 0x00, 0x02, 0x01, 0x01}) // psk_key_exchange_modes
+
+	if p.Version == 0x0304 || p.Support == support12 {
+		versions := []uint16{0x0301, 0x0302, 0x0303}
+		if p.Support == support13 {
+			versions = append(versions, 0x0304)
+		}
+		var list bytes.Buffer
+		if p.GREASE {
+			list.Write(grease())
+		}
+		for _, v := range reorder(versions, p.ExtensionOrder) {
+			list.Write(u16(v))
+		}
+		ext.Write([]byte{0x00, 0x2b})
+		ext.Write(u16(uint16(list.Len() + 1)))
+		ext.WriteByte(byte(list.Len()))
+		ext.Write(list.Bytes())
+	}
+
+	return append(u16(uint16(ext.Len())), ext.Bytes()...)
+}
+
+func u16(v uint16) []byte {
+	return binary.BigEndian.AppendUint16(nil, v)
+}
+
+// ServerHello is what a server answered a hello with.
+type ServerHello struct {
+	Version    uint16 // legacy_version of the ServerHello
+	Cipher     uint16
+	Extensions []uint16 // Extension types, in the order sent
+	ALPN       string
+}
+
+// errNoServerHello is returned for answers that are not a ServerHello,
+// such as alerts.
+var errNoServerHello = errors.New("no server hello")
+
+// Parse decodes the ServerHello at the start of data, the server's answer
+// to a hello, and returns it with its JARM component: the selected cipher,
+// version, ALPN and extension types, separated by '|'. Answers that are
+// not a ServerHello give "|||".
+func Parse(data []byte) (*ServerHello, string) {
+	if len(data) < 44 || data[0] != 0x16 || data[5] != 0x02 {
+		return nil, "|||"
+	}
+	recordLength := int(binary.BigEndian.Uint16(data[3:5]))
+	sessionIDLength := int(data[43])
+	if len(data) < sessionIDLength+46 {
+		return nil, "|||"
+	}
+	hello := &ServerHello{
+		Version: binary.BigEndian.Uint16(data[9:11]),
+		Cipher:  binary.BigEndian.Uint16(data[sessionIDLength+44 : sessionIDLength+46]),
+	}
+	component := fmt.Sprintf("%04x|%04x|", hello.Cipher, hello.Version)
+	extensions, err := parseExtensions(data, sessionIDLength, recordLength, hello)
+	if err != nil {
+		return hello, component + "|"
+	}
+	return hello, component + extensions
+}
+
+// parseExtensions reads the extensions of the ServerHello into hello and
+// returns the ALPN and extension types part of its JARM component. The
+// checks mirror the reference implementation, which treats messages
+// following a ServerHello without extensions as none.
+func parseExtensions(data []byte, counter, recordLength int, hello *ServerHello) (string, error) {
+	if len(data) <= counter+48 {
+		return "", errNoServerHello
+	}
+	if data[counter+47] == 11 {
+		return "", errNoServerHello
+	}
+	if (len(data) >= counter+53 && bytes.Equal(data[counter+50:counter+53], []byte{0x0e, 0xac, 0x0b})) ||
+		(len(data) >= 85 && bytes.Equal(data[82:85], []byte{0x0f, 0xf0, 0x0b})) {
+		return "", errNoServerHello
+	}
+	if counter+42 >= recordLength {
+		return "", errNoServerHello
+	}
+
+	count := counter + 49
+	maximum := int(binary.BigEndian.Uint16(data[counter+47:counter+49])) + count - 1
+	var types []string
+	for count < maximum {
+		if len(data) < count+4 {
+			return "", errNoServerHello
+		}
+		typ := binary.BigEndian.Uint16(data[count : count+2])
+		length := int(binary.BigEndian.Uint16(data[count+2 : count+4]))
+		end := min(count+4+length, len(data))
+		value := data[count+4 : end]
+		if typ == 0x0010 && hello.ALPN == "" && len(value) > 3 {
+			hello.ALPN = string(value[3:])
+		}
+		hello.Extensions = append(hello.Extensions, typ)
+		types = append(types, fmt.Sprintf("%04x", typ))
+		count += length + 4
+	}
+	return hello.ALPN + "|" + strings.Join(types, "-"), nil
+}
+
+// Hash returns the JARM of the components of the answers to probes, in
+// order: for each, the number of the selected cipher and a letter for the
+// version, followed by the truncated SHA-256 of the ALPNs and extensions.
+func Hash(components []string) string {
+	empty := true
+	for _, c := range components {
+		if c != "|||" {
+			empty = false
+		}
+	}
+	if empty {
+		return EmptyHash
+	}
+
+	var fuzzy, rest strings.Builder
+	for _, c := range components {
+		parts := strings.SplitN(c, "|", 4)
+		for len(parts) < 4 {
+			parts = append(parts, "")
+		}
+		fuzzy.WriteString(cipherByte(parts[0]))
+		fuzzy.WriteString(versionByte(parts[1]))
+		rest.WriteString(parts[2])
+		rest.WriteString(parts[3])
+	}
+	sum := sha256.Sum256([]byte(rest.String()))
+	return fuzzy.String() + hex.EncodeToString(sum[:])[:32]
+}
+
+// cipherByte numbers cipher, a hex cipher suite, in hashCiphers.
+func cipherByte(cipher string) string {
+	if cipher == "" {
+		return "00"
+	}
+	n := len(hashCiphers) + 1
+	if v, err := strconv.ParseUint(cipher, 16, 16); err == nil {
+		for i, c := range hashCiphers {
+			if uint16(v) == c {
+				n = i + 1
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%02x", n)
+}
+
+// versionByte returns a letter for version, a hex TLS version: 'a' for
+// SSL 3.0 (0300) through 'e' for TLS 1.3 (0304).
+func versionByte(version string) string {
+	if len(version) < 4 {
+		return "0"
+	}
+	i := int(version[3] - '0')
+	if i < 0 || i > 5 {
+		return "0"
+	}
+	return string("abcdef"[i])
+}
+
+// JA3S returns the JA3S string of hello, "version,cipher,extensions" in
+// decimal, and its MD5 hash.
+func JA3S(hello *ServerHello) (string, string) {
+	types := make([]string, len(hello.Extensions))
+	for i, t := range hello.Extensions {
+		types[i] = strconv.Itoa(int(t))
+	}
+	s := fmt.Sprintf("%d,%d,%s", hello.Version, hello.Cipher, strings.Join(types, "-"))
+	sum := md5.Sum([]byte(s)) // #nosec G401 -- JA3S is defined as an MD5 hash
+	return s, hex.EncodeToString(sum[:])
+}
+
+// DialFunc opens a connection to addr.
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// Result is the fingerprint of a TLS server.
+type Result struct {
+	JARM       string `json:"jarm"`
+	JA3S       string `json:"ja3s,omitempty"`
+	JA3SString string `json:"ja3s_string,omitempty"`
+}
+
+// Fingerprint sends the JARM hellos to the server at addr, one connection
+// each, and returns its fingerprint. host is the name sent in the SNI
+// extension. Hellos the server does not answer count as empty; the error
+// reports that it answered none.
+func Fingerprint(ctx context.Context, dial DialFunc, addr, host string, timeout time.Duration) (Result, error) {
+	components := make([]string, len(probes))
+	var first *ServerHello
+	var lastErr error
+	for i, p := range probes {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		data, err := exchange(ctx, dial, addr, clientHello(p, host), timeout)
+		if err != nil {
+			lastErr = err
+		}
+		hello, component := Parse(data)
+		components[i] = component
+		if i == 0 {
+			first = hello
+		}
+	}
+
+	result := Result{JARM: Hash(components)}
+	if result.JARM == EmptyHash {
+		if lastErr == nil {
+			lastErr = errNoServerHello
+		}
+		return result, fmt.Errorf("no TLS server hello from %s: %w", addr, lastErr)
+	}
+	if first != nil {
+		result.JA3SString, result.JA3S = JA3S(first)
+	}
+	return result, nil
+}
+
+// exchange sends hello to addr and reads the answer, up to the end of its
+// first record.
+func exchange(ctx context.Context, dial DialFunc, addr string, hello []byte, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	if _, err := conn.Write(hello); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, maxResponse)
+	n, err := io.ReadAtLeast(conn, buf, 5)
+	if err != nil {
+		return nil, err
+	}
+	want := min(5+int(binary.BigEndian.Uint16(buf[3:5])), maxResponse)
+	for n < want {
+		m, err := conn.Read(buf[n:want])
+		n += m
+		if err != nil {
+			break
+		}
+	}
+	return buf[:n], nil
+}
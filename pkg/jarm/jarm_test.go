@@ -0,0 +1,150 @@
+package jarm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorder(t *testing.T) {
+	odd := []int{1, 2, 3, 4, 5}
+	require.Equal(t, []int{1, 2, 3, 4, 5}, reorder(odd, forward))
+	require.Equal(t, []int{5, 4, 3, 2, 1}, reorder(odd, reverse))
+	require.Equal(t, []int{4, 5}, reorder(odd, bottomHalf))
+	require.Equal(t, []int{3, 2, 1}, reorder(odd, topHalf))
+	require.Equal(t, []int{3, 4, 2, 5, 1}, reorder(odd, middleOut))
+
+	even := []int{1, 2, 3, 4}
+	require.Equal(t, []int{3, 4}, reorder(even, bottomHalf))
+	require.Equal(t, []int{2, 1}, reorder(even, topHalf))
+	require.Equal(t, []int{3, 2, 4, 1}, reorder(even, middleOut))
+}
+
+func TestHashCiphers(t *testing.T) {
+	// Every suite offered is numbered once
+	require.ElementsMatch(t, allCiphers, hashCiphers)
+	require.Len(t, slices.Compact(slices.Sorted(slices.Values(hashCiphers))), len(hashCiphers))
+}
+
+func TestClientHello(t *testing.T) {
+	hello := clientHello(probes[6], "example.com")
+	require.Equal(t, []byte{0x16, 0x03, 0x01}, hello[:3], "TLS 1.3 hellos use a TLS 1.0 record")
+	require.Equal(t, len(hello)-5, int(hello[3])<<8|int(hello[4]))
+	require.Equal(t, byte(0x01), hello[5], "client hello")
+	require.Equal(t, []byte{0x03, 0x03}, hello[9:11])
+	require.Contains(t, string(hello), "example.com")
+
+	// GREASE probes offer a GREASE suite first
+	hello = clientHello(probes[4], "example.com")
+	require.Equal(t, hello[78], hello[79])
+	require.Equal(t, byte(0x0a), hello[78]&0x0f)
+}
+
+// serverHello returns a ServerHello record selecting cipher with the
+// given extensions, each a type followed by its data.
+func serverHello(cipher uint16, extensions ...[]byte) []byte {
+	body := []byte{0x03, 0x03}
+	body = append(body, make([]byte, 32)...) // Random
+	body = append(body, 32)
+	body = append(body, make([]byte, 32)...) // Session ID
+	body = append(body, u16(cipher)...)
+	body = append(body, 0x00)
+	var ext []byte
+	for _, e := range extensions {
+		ext = append(ext, e[:2]...)
+		ext = append(ext, u16(uint16(len(e)-2))...)
+		ext = append(ext, e[2:]...)
+	}
+	body = append(body, u16(uint16(len(ext)))...)
+	body = append(body, ext...)
+
+	handshake := append([]byte{0x02, 0x00}, u16(uint16(len(body)))...)
+	handshake = append(handshake, body...)
+	record := append([]byte{0x16, 0x03, 0x03}, u16(uint16(len(handshake)))...)
+	return append(record, handshake...)
+}
+
+func TestParse(t *testing.T) {
+	data := serverHello(0xc02f,
+		[]byte{0xff, 0x01, 0x00},
+		[]byte{0x00, 0x10, 0x00, 0x03, 0x02, 'h', '2'},
+		[]byte{0x00, 0x0b, 0x01, 0x00},
+	)
+	hello, component := Parse(data)
+	require.Equal(t, "c02f|0303|h2|ff01-0010-000b", component)
+	require.Equal(t, &ServerHello{Version: 0x0303, Cipher: 0xc02f, Extensions: []uint16{0xff01, 0x0010, 0x000b}, ALPN: "h2"}, hello)
+
+	ja3s, hash := JA3S(hello)
+	require.Equal(t, "771,49199,65281-16-11", ja3s)
+	require.Len(t, hash, 32)
+
+	// Alerts and other answers are empty components
+	hello, component = Parse([]byte{0x15, 0x03, 0x03, 0x00, 0x02, 0x02, 0x28})
+	require.Nil(t, hello)
+	require.Equal(t, "|||", component)
+	_, component = Parse(nil)
+	require.Equal(t, "|||", component)
+
+	// A truncated extension list keeps the cipher and version
+	_, component = Parse(data[:len(data)-6])
+	require.Equal(t, "c02f|0303||", component)
+}
+
+func TestHash(t *testing.T) {
+	empty := make([]string, len(probes))
+	for i := range empty {
+		empty[i] = "|||"
+	}
+	require.Equal(t, EmptyHash, Hash(empty))
+	require.Len(t, EmptyHash, 62)
+
+	components := slices.Clone(empty)
+	components[0] = "c02f|0303|h2|ff01-0010"
+	components[6] = "1301|0303||002b-0033"
+	sum := sha256.Sum256([]byte("h2ff01-0010002b-0033"))
+	require.Equal(t, "29d"+strings.Repeat("000", 5)+"41d"+strings.Repeat("000", 3)+hex.EncodeToString(sum[:])[:32], Hash(components))
+
+	// Suites the hellos do not offer are numbered past the list
+	components[0] = "abcd|0301||"
+	require.True(t, strings.HasPrefix(Hash(components), "46b"))
+}
+
+func TestFingerprint(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Most hellos fail
+	server.StartTLS()
+	defer server.Close()
+	addr := server.Listener.Addr().String()
+	dial := (&net.Dialer{}).DialContext
+	ctx := context.Background()
+
+	result, err := Fingerprint(ctx, dial, addr, "127.0.0.1", 5*time.Second)
+	require.NoError(t, err)
+	require.Len(t, result.JARM, 62)
+	require.NotEqual(t, EmptyHash, result.JARM)
+	require.True(t, strings.HasPrefix(result.JA3SString, "771,"), result.JA3SString)
+	require.Len(t, result.JA3S, 32)
+
+	// The same server gives the same fingerprint
+	again, err := Fingerprint(ctx, dial, addr, "127.0.0.1", 5*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, result, again)
+
+	// Servers that do not speak TLS have none
+	plain := httptest.NewServer(http.NotFoundHandler())
+	defer plain.Close()
+	result, err = Fingerprint(ctx, dial, plain.Listener.Addr().String(), "127.0.0.1", 5*time.Second)
+	require.Error(t, err)
+	require.Equal(t, EmptyHash, result.JARM)
+}
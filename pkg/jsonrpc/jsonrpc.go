@@ -0,0 +1,246 @@
+// Package jsonrpc implements the JSON-RPC 2.0 bridge of `vulntor serve-rpc`,
+// which lets programs in other languages drive scans over a pipe.
+//
+// Messages are JSON objects, one per line, in both directions. Clients send
+// requests; the server answers each request with an ID and pushes the live
+// events of scans as scan.event notifications:
+//
+//	-> {"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":["192.168.1.10"]}}
+//	<- {"jsonrpc":"2.0","id":1,"result":{"scan_id":"3f2a9c1e-..."}}
+//	<- {"jsonrpc":"2.0","method":"scan.event","params":{"type":"port.open","scan_id":"3f2a9c1e-...",...}}
+//	-> {"jsonrpc":"2.0","id":2,"method":"scan.wait","params":{"scan_id":"3f2a9c1e-..."}}
+//	<- {"jsonrpc":"2.0","id":2,"result":{"scan_id":"3f2a9c1e-...","status":"completed","findings":[...]}}
+//
+// Requests are handled concurrently, so responses may arrive out of order.
+// Batches are not supported.
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server/events"
+)
+
+// Version is the protocol version; it changes with incompatible changes to
+// the methods or their messages.
+const Version = 1
+
+// maxMessageSize bounds the size of a request line.
+const maxMessageSize = 1 << 20
+
+// Error codes of the JSON-RPC 2.0 specification, and of the bridge.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	// CodeScanNotFound is returned for unknown scan IDs.
+	CodeScanNotFound = -32001
+)
+
+// Request is a JSON-RPC request. ID is absent for notifications.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response: either Result or Error is set.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Notification is a message of the server without a response, such as
+// scan.event.
+type Notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// Error is a JSON-RPC error object.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+// invalidParams returns a CodeInvalidParams error.
+func invalidParams(format string, args ...interface{}) *Error {
+	return &Error{Code: CodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+// Runner runs a scan (scanexec.Service.Run in production). Its context
+// carries the app manager, config and output observer of the scan.
+type Runner func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error)
+
+// Server serves the bridge protocol.
+type Server struct {
+	run     Runner
+	version string
+
+	mu    sync.Mutex
+	scans map[string]*scan
+}
+
+// NewServer returns a server running scans with run. version is reported
+// by the version method.
+func NewServer(run Runner, version string) *Server {
+	return &Server{run: run, version: version, scans: map[string]*scan{}}
+}
+
+// conn is the client connection of Serve.
+type conn struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// send writes msg as one line. Write errors are dropped: the client is
+// gone, and Serve ends when its input does.
+func (c *conn) send(msg interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.enc.Encode(msg)
+}
+
+// Publish sends ev as a scan.event notification (events.Publisher).
+func (c *conn) Publish(ev events.Event) {
+	c.send(Notification{JSONRPC: "2.0", Method: "scan.event", Params: ev})
+}
+
+// Serve reads requests from r and writes responses and notifications to w
+// until r ends or ctx is canceled. Scans still running then are canceled,
+// and Serve returns once they ended.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := &conn{enc: json.NewEncoder(w)}
+	var handlers sync.WaitGroup
+	defer func() {
+		cancel()
+		handlers.Wait()
+		s.waitScans()
+	}()
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case line := <-lines:
+			if len(line) == 0 {
+				continue
+			}
+			handlers.Add(1)
+			go func() {
+				defer handlers.Done()
+				if resp := s.handle(ctx, c, line); resp != nil {
+					c.send(resp)
+				}
+			}()
+		}
+	}
+}
+
+// handle processes one request line and returns its response; nil for
+// notifications.
+func (s *Server) handle(ctx context.Context, c *conn, line []byte) *Response {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &Response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: "parse error: " + err.Error()}}
+	}
+	id := req.ID
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return &Response{JSONRPC: "2.0", ID: id, Error: &Error{Code: CodeInvalidRequest, Message: `invalid request: expected "jsonrpc":"2.0" and a method`}}
+	}
+
+	result, err := s.call(ctx, c, req.Method, req.Params)
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &Response{JSONRPC: "2.0", ID: id, Result: result}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: CodeInternalError, Message: err.Error()}
+		}
+		resp.Result, resp.Error = nil, rpcErr
+	}
+	return resp
+}
+
+// call dispatches method.
+func (s *Server) call(ctx context.Context, c *conn, method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "version":
+		return VersionResult{Version: s.version, Protocol: Version}, nil
+	case "scan.start":
+		return s.startScan(ctx, c, params)
+	case "scan.status":
+		return s.scanStatus(params)
+	case "scan.wait":
+		return s.waitScan(ctx, params)
+	case "scan.cancel":
+		return s.cancelScan(params)
+	default:
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// decodeParams decodes the params of a request into v, rejecting unknown
+// fields so that misspelled options are not silently ignored.
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 {
+		return invalidParams("missing params")
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
+
+// VersionResult is the result of the version method.
+type VersionResult struct {
+	Version  string `json:"version"`
+	Protocol int    `json:"protocol"`
+}
@@ -0,0 +1,170 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+)
+
+// client drives a server over pipes.
+type client struct {
+	t   *testing.T
+	in  *io.PipeWriter
+	out *bufio.Scanner
+}
+
+func startServer(t *testing.T, run Runner) *client {
+	t.Helper()
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- NewServer(run, "1.2.3").Serve(context.Background(), inR, outW)
+		_ = outW.Close()
+	}()
+	t.Cleanup(func() {
+		_ = inW.Close()
+		go func() { _, _ = io.Copy(io.Discard, outR) }()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Error("Serve did not return after stdin closed")
+		}
+	})
+	return &client{t: t, in: inW, out: bufio.NewScanner(outR)}
+}
+
+func (c *client) send(line string) {
+	c.t.Helper()
+	_, err := io.WriteString(c.in, line+"\n")
+	require.NoError(c.t, err)
+}
+
+// next returns the next message of the server.
+func (c *client) next() map[string]interface{} {
+	c.t.Helper()
+	require.True(c.t, c.out.Scan(), "server closed its output")
+	var msg map[string]interface{}
+	require.NoError(c.t, json.Unmarshal(c.out.Bytes(), &msg))
+	return msg
+}
+
+func errorCode(msg map[string]interface{}) int {
+	e, _ := msg["error"].(map[string]interface{})
+	code, _ := e["code"].(float64)
+	return int(code)
+}
+
+func TestServe_ScanLifecycle(t *testing.T) {
+	var got scanexec.Params
+	c := startServer(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got = params
+		observe, ok := engine.OutputObserverFromContext(ctx)
+		require.True(t, ok)
+		observe(engine.ModuleOutput{DataKey: "discovery.open_tcp_ports", Data: map[string]interface{}{"port": 22}})
+		return &scanexec.Result{
+			RunID:  params.ScanID,
+			Status: "completed",
+			RawContext: map[string]interface{}{
+				report.FindingsKey: []interface{}{
+					map[string]interface{}{"target": "192.0.2.1", "port": 22, "plugin": "ssh-weak", "severity": "high", "message": "weak"},
+				},
+			},
+		}, nil
+	})
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":["192.0.2.1"],"ports":"22","timeout":"2s"}}`)
+	resp := c.next()
+	require.EqualValues(t, 1, resp["id"])
+	scanID := resp["result"].(map[string]interface{})["scan_id"].(string)
+	require.NotEmpty(t, scanID)
+
+	ev := c.next()
+	require.Equal(t, "scan.event", ev["method"])
+	require.Equal(t, "port.open", ev["params"].(map[string]interface{})["type"])
+	ev = c.next()
+	require.Equal(t, "scan.finished", ev["params"].(map[string]interface{})["type"])
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"scan.wait","params":{"scan_id":"` + scanID + `","include_data":true}}`)
+	resp = c.next()
+	require.EqualValues(t, 2, resp["id"])
+	result := resp["result"].(map[string]interface{})
+	require.Equal(t, "completed", result["status"])
+	require.Len(t, result["findings"], 1)
+	require.Contains(t, result["data"], report.FindingsKey)
+
+	require.Equal(t, scanID, got.ScanID)
+	require.Equal(t, "22", got.Ports)
+	require.Equal(t, "2s", got.CustomTimeout)
+}
+
+func TestServe_CancelScan(t *testing.T) {
+	c := startServer(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		<-ctx.Done()
+		return &scanexec.Result{RunID: params.ScanID, Status: "interrupted"}, ctx.Err()
+	})
+
+	c.send(`{"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":["192.0.2.1"]}}`)
+	scanID := c.next()["result"].(map[string]interface{})["scan_id"].(string)
+
+	c.send(`{"jsonrpc":"2.0","id":2,"method":"scan.status","params":{"scan_id":"` + scanID + `"}}`)
+	require.Equal(t, "running", c.next()["result"].(map[string]interface{})["status"])
+
+	c.send(`{"jsonrpc":"2.0","id":3,"method":"scan.cancel","params":{"scan_id":"` + scanID + `"}}`)
+	// The cancel response and scan.finished may arrive in either order
+	var finished map[string]interface{}
+	for finished == nil {
+		msg := c.next()
+		if msg["method"] == "scan.event" {
+			finished = msg["params"].(map[string]interface{})
+		}
+	}
+	data := finished["data"].(map[string]interface{})
+	require.Equal(t, "interrupted", data["status"])
+	require.Equal(t, context.Canceled.Error(), data["error"])
+}
+
+func TestServe_Errors(t *testing.T) {
+	c := startServer(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		return nil, errors.New("unexpected run")
+	})
+
+	tests := []struct {
+		line string
+		code int
+	}{
+		{`not json`, CodeParseError},
+		{`{"id":1,"method":"version"}`, CodeInvalidRequest},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.nope"}`, CodeMethodNotFound},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.start"}`, CodeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":[]}}`, CodeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":["a"],"port":"22"}}`, CodeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.start","params":{"targets":["a"],"timeout":"soon"}}`, CodeInvalidParams},
+		{`{"jsonrpc":"2.0","id":1,"method":"scan.wait","params":{"scan_id":"missing"}}`, CodeScanNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			c.send(tt.line)
+			require.Equal(t, tt.code, errorCode(c.next()))
+		})
+	}
+
+	// Notifications get no response
+	c.send(`{"jsonrpc":"2.0","method":"version"}`)
+	c.send(`{"jsonrpc":"2.0","id":"v","method":"version"}`)
+	resp := c.next()
+	require.Equal(t, "v", resp["id"])
+	require.Equal(t, map[string]interface{}{"version": "1.2.3", "protocol": float64(Version)}, resp["result"])
+}
@@ -0,0 +1,236 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server/events"
+)
+
+// Scan statuses reported by scan.status and scan.wait.
+const (
+	StatusRunning     = "running"
+	StatusCompleted   = "completed"
+	StatusFailed      = "failed"
+	StatusInterrupted = "interrupted"
+)
+
+// StartParams are the params of scan.start.
+type StartParams struct {
+	Targets       []string `json:"targets"`
+	Ports         string   `json:"ports,omitempty"`
+	Profile       string   `json:"profile,omitempty"`
+	EnableVuln    bool     `json:"enable_vuln,omitempty"`
+	Plugins       []string `json:"plugins,omitempty"`
+	OnlyDiscover  bool     `json:"only_discover,omitempty"`
+	SkipDiscover  bool     `json:"skip_discover,omitempty"`
+	Concurrency   int      `json:"concurrency,omitempty"`
+	Timeout       string   `json:"timeout,omitempty"` // per-probe timeout, e.g. "2s"
+	AllowLoopback bool     `json:"allow_loopback,omitempty"`
+}
+
+// StartResult is the result of scan.start.
+type StartResult struct {
+	ScanID string `json:"scan_id"`
+}
+
+// ScanParams are the params of the methods on one scan.
+type ScanParams struct {
+	ScanID string `json:"scan_id"`
+
+	// IncludeData adds all scan results by data key to the result of
+	// scan.wait, as in the CLI's JSON output.
+	IncludeData bool `json:"include_data,omitempty"`
+}
+
+// ScanResult is the result of scan.status and scan.wait. Findings and Data
+// are only set by scan.wait.
+type ScanResult struct {
+	ScanID      string                 `json:"scan_id"`
+	Status      string                 `json:"status"`
+	StartedAt   time.Time              `json:"started_at"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Findings    []report.Finding       `json:"findings,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+}
+
+// scan is a scan started by scan.start.
+type scan struct {
+	id        string
+	startedAt time.Time
+	cancel    context.CancelFunc
+	done      chan struct{}
+
+	// Set when done is closed
+	res         *scanexec.Result
+	err         error
+	completedAt time.Time
+}
+
+// status returns the status of sc, which has ended if done.
+func (sc *scan) status(done bool) string {
+	switch {
+	case !done:
+		return StatusRunning
+	case sc.res != nil && sc.res.Status != "":
+		return sc.res.Status
+	case sc.err != nil:
+		return StatusFailed
+	default:
+		return StatusCompleted
+	}
+}
+
+// result returns the result of sc; with findings and, if includeData, the
+// scan results once it ended.
+func (sc *scan) result(withFindings, includeData bool) ScanResult {
+	var done bool
+	select {
+	case <-sc.done:
+		done = true
+	default:
+	}
+
+	r := ScanResult{ScanID: sc.id, Status: sc.status(done), StartedAt: sc.startedAt}
+	if !done {
+		return r
+	}
+	r.CompletedAt = &sc.completedAt
+	if sc.err != nil {
+		r.Error = sc.err.Error()
+	}
+	if withFindings && sc.res != nil {
+		// Findings the report format cannot read are left out
+		if findings, err := report.FindingsFromContext(sc.res.RawContext); err == nil {
+			r.Findings = findings
+		}
+		if includeData {
+			r.Data = sc.res.RawContext
+		}
+	}
+	return r
+}
+
+// startScan starts a scan in the background; its events are sent to c.
+func (s *Server) startScan(ctx context.Context, c *conn, raw json.RawMessage) (interface{}, error) {
+	var p StartParams
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Targets) == 0 {
+		return nil, invalidParams("targets: at least one target is required")
+	}
+	params := scanexec.Params{
+		ScanID:        uuid.New().String(),
+		Targets:       p.Targets,
+		Ports:         p.Ports,
+		Profile:       p.Profile,
+		EnableVuln:    p.EnableVuln,
+		Plugins:       p.Plugins,
+		OnlyDiscover:  p.OnlyDiscover,
+		SkipDiscover:  p.SkipDiscover,
+		Concurrency:   p.Concurrency,
+		AllowLoopback: p.AllowLoopback,
+		OutputFormat:  "json",
+	}
+	if p.Timeout != "" {
+		if d, err := time.ParseDuration(p.Timeout); err != nil || d <= 0 {
+			return nil, invalidParams("timeout: must be a positive duration such as 2s")
+		}
+		params.CustomTimeout = p.Timeout
+	}
+
+	// Scans outlive the request that started them, not the connection
+	scanCtx, cancel := context.WithCancel(ctx)
+	scanCtx = engine.WithOutputObserver(scanCtx, events.Observer(c, params.ScanID))
+	sc := &scan{id: params.ScanID, startedAt: time.Now().UTC(), cancel: cancel, done: make(chan struct{})}
+
+	s.mu.Lock()
+	s.scans[sc.id] = sc
+	s.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		sc.res, sc.err = s.run(scanCtx, params)
+		sc.completedAt = time.Now().UTC()
+
+		// scan.finished precedes the response of scan.wait
+		data := map[string]string{"status": sc.status(true)}
+		if sc.err != nil {
+			data["error"] = sc.err.Error()
+		}
+		c.Publish(events.Event{Type: events.TypeScanFinished, ScanID: sc.id, Timestamp: sc.completedAt, Data: data})
+		close(sc.done)
+	}()
+	return StartResult{ScanID: sc.id}, nil
+}
+
+// lookup returns the scan of the params of a scan method.
+func (s *Server) lookup(raw json.RawMessage) (*scan, ScanParams, error) {
+	var p ScanParams
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, p, err
+	}
+	s.mu.Lock()
+	sc, ok := s.scans[p.ScanID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, p, &Error{Code: CodeScanNotFound, Message: "scan not found: " + p.ScanID}
+	}
+	return sc, p, nil
+}
+
+// scanStatus returns the status of a scan without waiting for it.
+func (s *Server) scanStatus(raw json.RawMessage) (interface{}, error) {
+	sc, _, err := s.lookup(raw)
+	if err != nil {
+		return nil, err
+	}
+	return sc.result(false, false), nil
+}
+
+// waitScan waits for a scan to end and returns its findings. Failed and
+// interrupted scans return their partial results with the error.
+func (s *Server) waitScan(ctx context.Context, raw json.RawMessage) (interface{}, error) {
+	sc, p, err := s.lookup(raw)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-sc.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return sc.result(true, p.IncludeData), nil
+}
+
+// cancelScan cancels a scan. The scan ends with its partial results,
+// reported by scan.wait.
+func (s *Server) cancelScan(raw json.RawMessage) (interface{}, error) {
+	sc, _, err := s.lookup(raw)
+	if err != nil {
+		return nil, err
+	}
+	sc.cancel()
+	return sc.result(false, false), nil
+}
+
+// waitScans waits for all scans to end.
+func (s *Server) waitScans() {
+	s.mu.Lock()
+	scans := make([]*scan, 0, len(s.scans))
+	for _, sc := range s.scans {
+		scans = append(scans, sc)
+	}
+	s.mu.Unlock()
+	for _, sc := range scans {
+		<-sc.done
+	}
+}
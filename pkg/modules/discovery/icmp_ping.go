@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/netip"
 	"os"
 	"runtime"
 	"sync"
@@ -18,6 +19,8 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cast"
 
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/egress"
 	"github.com/vulntor/vulntor/pkg/engine" // Assuming your core module interfaces are in pkg/engine
 	"github.com/vulntor/vulntor/pkg/netutil"
 	"github.com/vulntor/vulntor/pkg/output"
@@ -52,6 +55,7 @@ type Pinger interface {
 	SetCount(int)
 	SetInterval(time.Duration)
 	SetTimeout(time.Duration)
+	SetSource(string)
 	GetTimeout() time.Duration
 }
 
@@ -302,6 +306,22 @@ func (m *ICMPPingDiscoveryModule) Execute(ctx context.Context, inputs map[string
 		return nil // Module itself didn't fail, just had no work.
 	}
 
+	// Echo requests leave from the source address of the scan; decoy
+	// requests need raw sockets, so only privileged pings send them.
+	binding := egress.FromContext(ctx)
+	var decoys *egress.DecoyPinger
+	if len(binding.Decoys()) > 0 {
+		if m.config.Privileged {
+			var err error
+			if decoys, err = binding.DecoyPinger(); err != nil {
+				logger.Warn().Err(err).Msg("Cannot send decoy echo requests")
+			}
+			defer decoys.Close()
+		} else {
+			logger.Warn().Msg("Decoys require privileged ping; sending echo requests from the scanner's address only")
+		}
+	}
+
 	var liveHosts []string
 	var mu sync.Mutex // Protects liveHosts
 	var wg sync.WaitGroup
@@ -316,6 +336,11 @@ func (m *ICMPPingDiscoveryModule) Execute(ctx context.Context, inputs map[string
 			return ctx.Err() // Propagate cancellation
 		default:
 		}
+		// Draining scans ping no further hosts but report those found
+		if engine.Draining(ctx) {
+			logger.Info().Msg("Scan draining, pinging no further hosts")
+			break
+		}
 
 		wg.Add(1)
 		sem <- struct{}{} // Acquire a spot in the semaphore
@@ -330,6 +355,11 @@ func (m *ICMPPingDiscoveryModule) Execute(ctx context.Context, inputs map[string
 			default:
 			}
 
+			// Hosts in a blackout window are pinged once it ends
+			if blackout.FromContext(ctx).Wait(ctx, ip) != nil {
+				return
+			}
+
 			pinger, err := m.pingerFactory(ip)
 			if err != nil {
 				logger.Warn().Str("target", ip).Err(err).Msg("Failed to create pinger")
@@ -340,6 +370,14 @@ func (m *ICMPPingDiscoveryModule) Execute(ctx context.Context, inputs map[string
 			pinger.SetCount(m.config.Count)
 			pinger.SetInterval(m.config.Interval)
 			pinger.SetTimeout(m.config.PacketTimeout)
+			if addr, err := netip.ParseAddr(ip); err == nil {
+				if source := binding.Source(addr); source.IsValid() {
+					pinger.SetSource(source.String())
+				}
+				if err := decoys.Ping(addr); err != nil {
+					logger.Debug().Err(err).Str("target", ip).Msg("Decoy echo request failed")
+				}
+			}
 
 			opCtx, opCancel := context.WithTimeout(ctx, pinger.GetTimeout()+(500*time.Millisecond))
 			defer opCancel()
@@ -423,4 +461,5 @@ func (r *realPingerAdapter) SetAddr(a string)            { _ = r.p.SetAddr(a) }
 func (r *realPingerAdapter) SetCount(c int)              { r.p.Count = c }
 func (r *realPingerAdapter) SetInterval(i time.Duration) { r.p.Interval = i }
 func (r *realPingerAdapter) SetTimeout(t time.Duration)  { r.p.Timeout = t }
+func (r *realPingerAdapter) SetSource(s string)          { r.p.Source = s }
 func (r *realPingerAdapter) GetTimeout() time.Duration   { return r.p.Timeout }
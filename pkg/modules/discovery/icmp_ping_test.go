@@ -14,6 +14,7 @@ import (
 	//nolint:staticcheck // Ignore staticcheck warning for this import
 	"github.com/go-ping/ping"
 
+	"github.com/vulntor/vulntor/pkg/egress"
 	"github.com/vulntor/vulntor/pkg/engine"
 	"github.com/vulntor/vulntor/pkg/netutil"
 )
@@ -21,6 +22,7 @@ import (
 type fakePinger struct {
 	timeout time.Duration
 	stats   *ping.Statistics
+	source  *string
 }
 
 func (f *fakePinger) Run() error                   { return nil }
@@ -32,7 +34,12 @@ func (f *fakePinger) SetAddr(a string)             {}
 func (f *fakePinger) SetCount(c int)               {}
 func (f *fakePinger) SetInterval(d time.Duration)  {}
 func (f *fakePinger) SetTimeout(t time.Duration)   { f.timeout = t }
-func (f *fakePinger) GetTimeout() time.Duration    { return f.timeout }
+func (f *fakePinger) SetSource(s string) {
+	if f.source != nil {
+		*f.source = s
+	}
+}
+func (f *fakePinger) GetTimeout() time.Duration { return f.timeout }
 
 func TestICMPPingDiscoveryModule_Init(t *testing.T) {
 	mod := newICMPPingDiscoveryModule() // Use internal constructor
@@ -425,3 +432,26 @@ func TestICMPPingModuleFactory_ReturnsModule(t *testing.T) {
 		t.Errorf("Expected module Type '%s', got '%s'", engine.DiscoveryModuleType, meta.Type)
 	}
 }
+
+func TestICMPPingDiscoveryModule_Execute_SourceFromBinding(t *testing.T) {
+	binding, err := egress.New("", "127.0.0.1", nil)
+	if err != nil {
+		t.Fatalf("egress.New failed: %v", err)
+	}
+	var source string
+	mod := newICMPPingDiscoveryModule()
+	mod.pingerFactory = func(ip string) (Pinger, error) {
+		return &fakePinger{source: &source}, nil
+	}
+	mod.config.Targets = []string{"127.0.0.1"}
+	mod.config.AllowLoopback = true
+
+	out := make(chan engine.ModuleOutput, 1)
+	if err := mod.Execute(egress.WithContext(context.Background(), binding), nil, out); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-out
+	if source != "127.0.0.1" {
+		t.Errorf("expected pings from 127.0.0.1, got %q", source)
+	}
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cast"
 
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/egress"
 	"github.com/vulntor/vulntor/pkg/engine" // Engine interfaces
+	"github.com/vulntor/vulntor/pkg/fdbudget"
 	"github.com/vulntor/vulntor/pkg/netutil"
 	"github.com/vulntor/vulntor/pkg/output"
 )
@@ -31,6 +34,14 @@ type TCPPortDiscoveryConfig struct {
 	Ports       []string      `json:"ports"`   // Port ranges and lists (e.g., "1-1024", "80,443,8080")
 	Timeout     time.Duration `json:"timeout"` // Connection timeout for each port
 	Concurrency int           `json:"concurrency"`
+	// AutoTune adapts concurrency and timeout to system resources and
+	// observed round trips; Concurrency and Timeout are the starting point.
+	AutoTune bool `json:"auto_tune"`
+	// VerifyTimeouts probes the ports whose connection attempts timed out
+	// once more after the scan, at VerifyConcurrency and twice the timeout.
+	// Refused ports are not probed again.
+	VerifyTimeouts    bool `json:"verify_timeouts"`
+	VerifyConcurrency int  `json:"verify_concurrency"`
 }
 
 // TCPPortDiscoveryModule implements the engine.Module interface for TCP port discovery.
@@ -44,6 +55,7 @@ const (
 	defaultTCPPortDiscoveryTimeout = 1 * time.Second
 	defaultTCPConcurrency          = 100
 	defaultTCPPorts                = "1-1024" // Default common ports or a well-known range
+	defaultVerifyConcurrency       = 10
 )
 
 // newTCPPortDiscoveryModule is the internal constructor for the module.
@@ -53,6 +65,8 @@ func newTCPPortDiscoveryModule() *TCPPortDiscoveryModule {
 		Ports:       []string{defaultTCPPorts},
 		Timeout:     defaultTCPPortDiscoveryTimeout,
 		Concurrency: defaultTCPConcurrency,
+
+		VerifyConcurrency: defaultVerifyConcurrency,
 	}
 	return &TCPPortDiscoveryModule{
 		meta: engine.ModuleMetadata{
@@ -122,6 +136,24 @@ func newTCPPortDiscoveryModule() *TCPPortDiscoveryModule {
 					Required:    false,
 					Default:     defaultTCPConcurrency,
 				},
+				"auto_tune": {
+					Description: "Tune concurrency and timeout from system resources and observed round trip times.",
+					Type:        "bool",
+					Required:    false,
+					Default:     false,
+				},
+				"verify_timeouts": {
+					Description: "Probe the ports that timed out once more after the scan, at lower concurrency and twice the timeout.",
+					Type:        "bool",
+					Required:    false,
+					Default:     false,
+				},
+				"verify_concurrency": {
+					Description: "Number of concurrent probes of the verification pass.",
+					Type:        "int",
+					Required:    false,
+					Default:     defaultVerifyConcurrency,
+				},
 			},
 			// ActivationTriggers: Usually none for a primary discovery module, unless it depends on a very specific prior state.
 			// IsDynamic: false,
@@ -165,6 +197,20 @@ func (m *TCPPortDiscoveryModule) Init(instanceID string, moduleConfig map[string
 		}
 	}
 
+	if autoTuneVal, ok := moduleConfig["auto_tune"]; ok {
+		cfg.AutoTune = cast.ToBool(autoTuneVal)
+	}
+	if verifyVal, ok := moduleConfig["verify_timeouts"]; ok {
+		cfg.VerifyTimeouts = cast.ToBool(verifyVal)
+	}
+	if verifyConcurrencyVal, ok := moduleConfig["verify_concurrency"]; ok {
+		cfg.VerifyConcurrency = cast.ToInt(verifyConcurrencyVal)
+		if cfg.VerifyConcurrency < 1 {
+			fmt.Printf("[WARN] Module '%s': Verify concurrency in config is < 1 (%d). Setting to default: %d.\n", m.meta.Name, cfg.VerifyConcurrency, defaultVerifyConcurrency)
+			cfg.VerifyConcurrency = defaultVerifyConcurrency
+		}
+	}
+
 	// Sanitize final values
 	if cfg.Timeout <= 0 {
 		cfg.Timeout = defaultTCPPortDiscoveryTimeout
@@ -243,9 +289,22 @@ func (m *TCPPortDiscoveryModule) Execute(ctx context.Context, inputs map[string]
 	var wg sync.WaitGroup
 	sem := make(chan struct{}, m.config.Concurrency) // Semaphore to limit concurrency
 
+	// With auto-tuning, the tuner limits concurrency instead of sem
+	var tuner *netutil.AutoTuner
+	if m.config.AutoTune {
+		tuner = netutil.NewAutoTuner(netutil.AutoTuneConfig{
+			InitialConcurrency: m.config.Concurrency,
+			Timeout:            m.config.Timeout,
+		})
+		logger.Info().Msgf("Auto-tuning enabled. Initial concurrency: %d", tuner.Limit())
+	}
+
 	// Group results by target
 	openPortsByTarget := make(map[string][]int)
-	var mapMutex sync.Mutex // To protect openPortsByTarget map
+	// Ports whose dials timed out, and hosts that answered on any port
+	timedOutByTarget := make(map[string][]int)
+	answered := make(map[string]bool)
+	var mapMutex sync.Mutex // To protect openPortsByTarget, timedOutByTarget and answered
 
 	batchSize := 10 // Gruplama büyüklüğü
 	for i := 0; i < len(targetsToScan); i += batchSize {
@@ -267,12 +326,24 @@ func (m *TCPPortDiscoveryModule) Execute(ctx context.Context, inputs map[string]
 					goto endLoops // Break out of both loops
 				default:
 				}
+				// Draining scans dispatch no further probes; those in flight finish
+				if engine.Draining(ctx) {
+					logger.Info().Msg("Scan draining, dispatching no further port probes")
+					goto endLoops
+				}
 
 				wg.Add(1)
 				go func(ip string, p int) {
 					defer wg.Done()
-					sem <- struct{}{}        // Acquire semaphore
-					defer func() { <-sem }() // Release semaphore
+					// Probes to hosts in a blackout window wait for its end
+					// without holding a concurrency slot
+					if blackout.FromContext(ctx).Wait(ctx, ip) != nil {
+						return
+					}
+					if tuner == nil {
+						sem <- struct{}{}        // Acquire semaphore
+						defer func() { <-sem }() // Release semaphore
+					}
 
 					// Check context again inside the goroutine
 					select {
@@ -282,17 +353,34 @@ func (m *TCPPortDiscoveryModule) Execute(ctx context.Context, inputs map[string]
 					}
 
 					address := net.JoinHostPort(ip, strconv.Itoa(p))
-					conn, err := net.DialTimeout("tcp", address, m.config.Timeout)
-					if err == nil {
-						_ = conn.Close()
+					var conn net.Conn
+					var err error
+					if tuner != nil {
+						conn, err = dialTuned(ctx, tuner, address)
+					} else {
+						dialer := &net.Dialer{Timeout: m.config.Timeout}
+						conn, err = fdbudget.FromContext(ctx).DialContext(ctx, egress.Dial(dialer), "tcp", address)
+					}
+					if err != nil {
 						mapMutex.Lock()
-						openPortsByTarget[ip] = append(openPortsByTarget[ip], p)
-						mapMutex.Unlock()
-
-						// Real-time output: Emit open port discovery to user
-						if out, ok := ctx.Value(output.OutputKey).(output.Output); ok {
-							out.Diag(output.LevelNormal, fmt.Sprintf("Open port: %s:%d/tcp", ip, p), nil)
+						switch netutil.ClassifyDialError(err) {
+						case netutil.ProbeTimedOut:
+							timedOutByTarget[ip] = append(timedOutByTarget[ip], p)
+						case netutil.ProbeAnswered:
+							answered[ip] = true
 						}
+						mapMutex.Unlock()
+						return
+					}
+					_ = conn.Close()
+					mapMutex.Lock()
+					openPortsByTarget[ip] = append(openPortsByTarget[ip], p)
+					answered[ip] = true
+					mapMutex.Unlock()
+
+					// Real-time output: Emit open port discovery to user
+					if out, ok := ctx.Value(output.OutputKey).(output.Output); ok {
+						out.Diag(output.LevelNormal, fmt.Sprintf("Open port: %s:%d/tcp", ip, p), nil)
 					}
 				}(targetIP, port)
 			}
@@ -306,6 +394,25 @@ func (m *TCPPortDiscoveryModule) Execute(ctx context.Context, inputs map[string]
 
 endLoops:
 	wg.Wait() // Wait for all goroutines to complete or be canceled
+	if tuner != nil {
+		logger.Info().Msgf("Auto-tuned concurrency: %d, timeout per port: %s", tuner.Limit(), tuner.Timeout())
+	}
+	if m.config.VerifyTimeouts && ctx.Err() == nil && !engine.Draining(ctx) {
+		// Hosts that answered on no port are filtered or down rather than
+		// congested; probing them again would only time out again
+		for ip := range timedOutByTarget {
+			if !answered[ip] {
+				delete(timedOutByTarget, ip)
+			}
+		}
+		timeout := m.config.Timeout
+		if tuner != nil {
+			timeout = max(timeout, tuner.Timeout())
+		}
+		for ip, ports := range m.verifyTimedOut(ctx, timedOutByTarget, 2*timeout) {
+			openPortsByTarget[ip] = append(openPortsByTarget[ip], ports...)
+		}
+	}
 	// Send aggregated results per target
 	for target, openPorts := range openPortsByTarget {
 		if len(openPorts) > 0 {
@@ -331,6 +438,107 @@ endLoops:
 	return nil // Indicate successful completion of the module's execution logic
 }
 
+// verifyTimedOut probes the timed-out ports of each host once more, at the
+// verification concurrency and timeout, and returns those that turned out
+// open. Timeouts on aggressive scans are often congestion rather than
+// filtering; probing again slower near the end of the scan recovers ports
+// that would otherwise be reported closed.
+func (m *TCPPortDiscoveryModule) verifyTimedOut(ctx context.Context, timedOut map[string][]int, timeout time.Duration) map[string][]int {
+	logger := log.With().Str("module", m.meta.Name).Str("instance_id", m.meta.ID).Logger()
+	total := 0
+	for _, ports := range timedOut {
+		total += len(ports)
+	}
+	if total == 0 {
+		return nil
+	}
+	logger.Info().Msgf("Verifying %d timed-out ports on %d hosts. Concurrency: %d, Timeout per port: %s",
+		total, len(timedOut), m.config.VerifyConcurrency, timeout)
+
+	open := make(map[string][]int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(m.config.VerifyConcurrency, 1))
+	dialer := &net.Dialer{Timeout: timeout}
+	for ip, ports := range timedOut {
+		for _, p := range ports {
+			if ctx.Err() != nil || engine.Draining(ctx) {
+				break
+			}
+			wg.Add(1)
+			go func(ip string, p int) {
+				defer wg.Done()
+				if blackout.FromContext(ctx).Wait(ctx, ip) != nil {
+					return
+				}
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				if ctx.Err() != nil {
+					return
+				}
+
+				address := net.JoinHostPort(ip, strconv.Itoa(p))
+				conn, err := fdbudget.FromContext(ctx).DialContext(ctx, egress.Dial(dialer), "tcp", address)
+				if err != nil {
+					return
+				}
+				_ = conn.Close()
+				mu.Lock()
+				open[ip] = append(open[ip], p)
+				mu.Unlock()
+
+				if out, ok := ctx.Value(output.OutputKey).(output.Output); ok {
+					out.Diag(output.LevelNormal, fmt.Sprintf("Open port: %s:%d/tcp (verified)", ip, p), nil)
+				}
+			}(ip, p)
+		}
+	}
+	wg.Wait()
+
+	recovered := 0
+	for _, ports := range open {
+		recovered += len(ports)
+	}
+	logger.Info().Msgf("Verification found %d of %d timed-out ports open", recovered, total)
+	return open
+}
+
+// maxResourceRetries is how often a port is dialed again after the local
+// system ran out of file descriptors or ephemeral ports.
+const maxResourceRetries = 3
+
+// dialTuned dials address within the concurrency limit and timeout of
+// tuner. Dials that failed for lack of local resources are retried once
+// the tuner backed off, so that such ports are not reported closed.
+func dialTuned(ctx context.Context, tuner *netutil.AutoTuner, address string) (net.Conn, error) {
+	budget := fdbudget.FromContext(ctx)
+	var err error
+	for attempt := 0; attempt < maxResourceRetries; attempt++ {
+		if err = tuner.Acquire(ctx); err != nil {
+			return nil, err
+		}
+		// Waiting for a file descriptor is not part of the round trip
+		var start time.Time
+		dialer := &net.Dialer{Timeout: tuner.Timeout()}
+		var conn net.Conn
+		conn, err = budget.DialContext(ctx, func(ctx context.Context, network, addr string) (net.Conn, error) {
+			start = time.Now()
+			return egress.FromContext(ctx).DialContext(ctx, dialer, network, addr)
+		}, "tcp", address)
+		if start.IsZero() {
+			// Canceled while waiting for a descriptor
+			tuner.Release(netutil.ProbeFailed, 0)
+			return nil, err
+		}
+		outcome := netutil.ClassifyDialError(err)
+		tuner.Release(outcome, time.Since(start))
+		if outcome != netutil.ProbeResourceError {
+			return conn, err
+		}
+	}
+	return nil, err
+}
+
 // TCPPortDiscoveryModuleFactory creates a new TCPPortDiscoveryModule instance.
 // This factory function is what's registered with the core engine.
 func TCPPortDiscoveryModuleFactory() engine.Module {
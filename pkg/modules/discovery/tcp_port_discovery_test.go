@@ -2,7 +2,9 @@ package discovery
 
 import (
 	"context"
+	"net"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -172,6 +174,19 @@ func TestTCPPortDiscoveryModule_Init(t *testing.T) {
 				Concurrency: defaultTCPConcurrency,
 			},
 		},
+		{
+			name: "enable auto tune",
+			input: map[string]interface{}{
+				"auto_tune": true,
+			},
+			wantConfig: TCPPortDiscoveryConfig{
+				Targets:     nil,
+				Ports:       []string{"1-1024"},
+				Timeout:     defaultTCPPortDiscoveryTimeout,
+				Concurrency: defaultTCPConcurrency,
+				AutoTune:    true,
+			},
+		},
 		{
 			name: "empty ports falls back to default",
 			input: map[string]interface{}{
@@ -207,6 +222,9 @@ func TestTCPPortDiscoveryModule_Init(t *testing.T) {
 			if got.Concurrency != tt.wantConfig.Concurrency {
 				t.Errorf("Concurrency: got %v, want %v", got.Concurrency, tt.wantConfig.Concurrency)
 			}
+			if got.AutoTune != tt.wantConfig.AutoTune {
+				t.Errorf("AutoTune: got %v, want %v", got.AutoTune, tt.wantConfig.AutoTune)
+			}
 		})
 	}
 }
@@ -330,6 +348,44 @@ func TestTCPPortDiscoveryModule_Execute_SuccessLocalhost(t *testing.T) {
 	}
 }
 
+func TestTCPPortDiscoveryModule_Execute_AutoTune(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	openPort := ln.Addr().(*net.TCPAddr).Port
+
+	module := newTCPPortDiscoveryModule()
+	module.meta.ID = "test-instance"
+	module.config.Targets = []string{"127.0.0.1"}
+	module.config.Ports = []string{strconv.Itoa(openPort), "1-200"}
+	module.config.Timeout = 200 * time.Millisecond
+	module.config.AutoTune = true
+	outputs := make(chan engine.ModuleOutput, 10)
+
+	if err := module.Execute(context.Background(), map[string]interface{}{}, outputs); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	close(outputs)
+
+	found := false
+	for out := range outputs {
+		result, ok := out.Data.(TCPPortDiscoveryResult)
+		if !ok {
+			t.Fatalf("expected TCPPortDiscoveryResult, got %T", out.Data)
+		}
+		for _, p := range result.OpenPorts {
+			if p == openPort {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected open port %d to be discovered", openPort)
+	}
+}
+
 func TestTCPPortDiscoveryModule_Execute_ContextCancelled(t *testing.T) {
 	module := newTCPPortDiscoveryModule()
 	module.meta.ID = "test-instance"
@@ -349,3 +405,72 @@ func TestTCPPortDiscoveryModule_Execute_ContextCancelled(t *testing.T) {
 	}
 	// No outputs expected, but should not panic or deadlock
 }
+
+func TestTCPPortDiscoveryModule_VerifyTimedOut(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	openPort := ln.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	_ = closed.Close()
+
+	module := newTCPPortDiscoveryModule()
+	module.meta.ID = "test-instance"
+	module.config.VerifyConcurrency = 2
+
+	// Ports that timed out under load and are open by the end of the scan
+	// are recovered; those still not open stay closed
+	open := module.verifyTimedOut(context.Background(), map[string][]int{"127.0.0.1": {openPort, closedPort}}, 200*time.Millisecond)
+	if !reflect.DeepEqual(open, map[string][]int{"127.0.0.1": {openPort}}) {
+		t.Errorf("expected port %d to be verified open, got %v", openPort, open)
+	}
+
+	if open := module.verifyTimedOut(context.Background(), nil, time.Second); open != nil {
+		t.Errorf("expected nothing to verify, got %v", open)
+	}
+}
+
+func TestTCPPortDiscoveryModule_Execute_VerifyTimeouts(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer func() { _ = ln.Close() }()
+	openPort := ln.Addr().(*net.TCPAddr).Port
+
+	module := newTCPPortDiscoveryModule()
+	if err := module.Init("test-instance", map[string]interface{}{
+		"targets":            []string{"127.0.0.1"},
+		"ports":              []string{strconv.Itoa(openPort)},
+		"timeout":            "200ms",
+		"verify_timeouts":    true,
+		"verify_concurrency": 0,
+	}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if !module.config.VerifyTimeouts || module.config.VerifyConcurrency != defaultVerifyConcurrency {
+		t.Fatalf("expected verification at concurrency %d, got %+v", defaultVerifyConcurrency, module.config)
+	}
+
+	outputs := make(chan engine.ModuleOutput, 10)
+	if err := module.Execute(context.Background(), map[string]interface{}{}, outputs); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	close(outputs)
+
+	var got []int
+	for out := range outputs {
+		got = append(got, out.Data.(TCPPortDiscoveryResult).OpenPorts...)
+	}
+	// Ports found open in the first pass are not reported twice
+	if !reflect.DeepEqual(got, []int{openPort}) {
+		t.Errorf("expected open ports [%d], got %v", openPort, got)
+	}
+}
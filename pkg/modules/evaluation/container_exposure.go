@@ -0,0 +1,119 @@
+// pkg/modules/evaluation/container_exposure.go
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+// containerExposureCheck describes the finding of an exposed kind of
+// container service.
+type containerExposureCheck struct {
+	id          string
+	name        string
+	severity    plugin.Severity
+	remediation string
+	reference   string
+}
+
+var containerExposureChecks = map[string]containerExposureCheck{
+	scan.ContainerExposureRegistry: {
+		id:          "docker-registry-unauthenticated",
+		name:        "Docker Registry Without Authentication",
+		severity:    plugin.HighSeverity,
+		remediation: "Enable token or htpasswd authentication on the registry, serve it over TLS, and restrict network access to it",
+		reference:   "https://distribution.github.io/distribution/about/deploying/#native-basic-auth",
+	},
+	scan.ContainerExposureKubelet: {
+		id:          "kubelet-unauthenticated",
+		name:        "Kubernetes Kubelet Without Authentication",
+		severity:    plugin.CriticalSeverity,
+		remediation: "Start the kubelet with --anonymous-auth=false, --authorization-mode=Webhook and --read-only-port=0, and firewall ports 10250 and 10255",
+		reference:   "https://kubernetes.io/docs/reference/access-authn-authz/kubelet-authn-authz/",
+	},
+}
+
+// containerExposureTags are the tags of container exposure findings, which
+// also select them in a plugin selection.
+var containerExposureTags = []string{"exposure", "container", "kubernetes"}
+
+// reportContainerExposures sends a vulnerability for each registry or
+// kubelet the container-exposure module found answering without
+// authentication, with the repositories or pods it listed as evidence. It
+// returns the number sent.
+func (m *PluginEvaluationModule) reportContainerExposures(ctx context.Context, inputs map[string]interface{}, out output.Output, outputChan chan<- engine.ModuleOutput, logger zerolog.Logger) int {
+	list, _ := inputs["container.exposures"].([]interface{})
+	if len(list) == 0 {
+		return 0
+	}
+
+	severityPolicy := policy.FromContext(ctx)
+	sent := 0
+	for _, item := range list {
+		result, ok := item.(scan.ContainerExposureResult)
+		if !ok {
+			continue
+		}
+		check, ok := containerExposureChecks[result.Kind]
+		if !ok || !m.selectsBuiltin(check.id, check.name, containerExposureTags) {
+			continue
+		}
+		vuln := containerExposureVulnerability(result, check)
+		applyPolicy(severityPolicy, &vuln)
+		if out != nil {
+			out.Diag(output.LevelNormal, fmt.Sprintf("Vulnerability found: %s - %s (Severity: %s)", vuln.Plugin, vuln.Message, vuln.Severity), nil)
+		}
+		outputChan <- engine.ModuleOutput{DataKey: "evaluation.vulnerabilities", Data: vuln}
+		sent++
+
+		logger.Info().
+			Str("plugin", vuln.Plugin).
+			Str("target", result.Target).
+			Int("port", result.Port).
+			Msg("Unauthenticated container service")
+	}
+	return sent
+}
+
+// containerExposureVulnerability converts an exposed registry or kubelet
+// into a vulnerability.
+func containerExposureVulnerability(result scan.ContainerExposureResult, check containerExposureCheck) VulnerabilityResult {
+	count, listed := len(result.Repositories), "repositories"
+	if result.Kind == scan.ContainerExposureKubelet {
+		count, listed = len(result.Pods), "pods and their images"
+	}
+	more := ""
+	if result.Truncated {
+		more = "more than "
+	}
+
+	evidence := map[string]string{"container.url": result.URL}
+	if inventory := result.Inventory(); inventory != "" {
+		evidence["container.inventory"] = inventory
+	}
+	return VulnerabilityResult{
+		Target:         result.Target,
+		Port:           result.Port,
+		Plugin:         check.name,
+		PluginID:       check.id,
+		PluginType:     "exposure",
+		Severity:       string(check.severity),
+		Message:        fmt.Sprintf("%s answers without authentication and lists %s%d %s", result.URL, more, count, listed),
+		Remediation:    check.remediation,
+		Reference:      check.reference,
+		References:     []string{check.reference},
+		CWE:            []string{"CWE-306"},
+		Tags:           append([]string{result.Kind}, containerExposureTags...),
+		Evidence:       evidence,
+		EvidenceFormat: "code",
+		Matched:        true,
+	}
+}
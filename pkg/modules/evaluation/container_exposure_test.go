@@ -0,0 +1,71 @@
+// pkg/modules/evaluation/container_exposure_test.go
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+)
+
+func TestPluginEvaluationModule_Execute_ContainerExposures(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{}))
+
+	inputs := map[string]interface{}{
+		"container.exposures": []interface{}{
+			scan.ContainerExposureResult{
+				Target: "192.0.2.20", Port: 5000, Kind: scan.ContainerExposureRegistry,
+				URL: "http://192.0.2.20:5000/v2/", Repositories: []string{"app/api"}, Truncated: true,
+			},
+			scan.ContainerExposureResult{
+				Target: "192.0.2.21", Port: 10255, Kind: scan.ContainerExposureKubelet,
+				URL:  "http://192.0.2.21:10255/pods",
+				Pods: []scan.ContainerPod{{Namespace: "shop", Name: "web-1", Images: []string{"nginx:1.27"}}},
+			},
+			scan.ContainerExposureResult{Target: "192.0.2.22", Port: 8080, Kind: "unknown"},
+		},
+	}
+
+	run := func() []VulnerabilityResult {
+		outputChan := make(chan engine.ModuleOutput, 32)
+		require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+		close(outputChan)
+		var vulns []VulnerabilityResult
+		for out := range outputChan {
+			if vuln := out.Data.(VulnerabilityResult); vuln.PluginType == "exposure" {
+				vulns = append(vulns, vuln)
+			}
+		}
+		return vulns
+	}
+
+	vulns := run()
+	require.Len(t, vulns, 2)
+
+	require.Equal(t, "docker-registry-unauthenticated", vulns[0].PluginID)
+	require.Equal(t, 5000, vulns[0].Port)
+	require.Equal(t, "high", vulns[0].Severity)
+	require.Equal(t, "http://192.0.2.20:5000/v2/ answers without authentication and lists more than 1 repositories", vulns[0].Message)
+	require.Equal(t, "app/api\n...\n", vulns[0].Evidence["container.inventory"])
+	require.Equal(t, []string{"CWE-306"}, vulns[0].CWE)
+
+	require.Equal(t, "kubelet-unauthenticated", vulns[1].PluginID)
+	require.Equal(t, "critical", vulns[1].Severity)
+	require.Equal(t, "http://192.0.2.21:10255/pods answers without authentication and lists 1 pods and their images", vulns[1].Message)
+	require.Equal(t, "http://192.0.2.21:10255/pods", vulns[1].Evidence["container.url"])
+	require.Equal(t, "shop/web-1: nginx:1.27\n", vulns[1].Evidence["container.inventory"])
+	require.Contains(t, vulns[1].Tags, "kubernetes")
+
+	// Exposures left out of the plugin selection are not reported
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{pluginsConfig: []string{"kubelet-unauthenticated"}}))
+	vulns = run()
+	require.Len(t, vulns, 1)
+	require.Equal(t, "kubelet-unauthenticated", vulns[0].PluginID)
+
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{pluginsConfig: []string{"ftp"}}))
+	require.Empty(t, run())
+}
@@ -0,0 +1,108 @@
+// pkg/modules/evaluation/default_creds.go
+package evaluation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+// reportDefaultCredentials sends a vulnerability for each default
+// credential the default-creds module logged in with, skipping those of
+// plugins left out of the plugin selection. It returns the number sent.
+func (m *PluginEvaluationModule) reportDefaultCredentials(ctx context.Context, inputs map[string]interface{}, out output.Output, outputChan chan<- engine.ModuleOutput, logger zerolog.Logger) int {
+	list, _ := inputs["auth.default_credentials"].([]interface{})
+	if len(list) == 0 {
+		return 0
+	}
+
+	// Plugins are named by ID, or by name when they have none
+	plugins := make(map[string]*plugin.YAMLPlugin)
+	allPlugins, _ := m.getAllPluginsFlat(plugin.InstalledPluginsFromContext(ctx))
+	for _, p := range allPlugins {
+		if p.DefaultCredentials == nil {
+			continue
+		}
+		if p.ID != "" {
+			plugins[p.ID] = p
+		}
+		plugins[p.Name] = p
+	}
+
+	severityPolicy := policy.FromContext(ctx)
+	sent := 0
+	for _, item := range list {
+		var result scan.DefaultCredentialResult
+		switch r := item.(type) {
+		case scan.DefaultCredentialResult:
+			result = r
+		case map[string]interface{}:
+			// Fallback to map (in case of JSON unmarshaling)
+			result = scan.DefaultCredentialResult{
+				Target:   cast.ToString(r["target"]),
+				Port:     cast.ToInt(r["port"]),
+				Protocol: cast.ToString(r["protocol"]),
+				Plugin:   cast.ToString(r["plugin"]),
+				Username: cast.ToString(r["username"]),
+			}
+		default:
+			continue
+		}
+
+		p := plugins[result.Plugin]
+		if p == nil && len(m.selection) > 0 {
+			continue
+		}
+		vuln := defaultCredentialVulnerability(result, p)
+		applyPolicy(severityPolicy, &vuln)
+		if out != nil {
+			out.Diag(output.LevelNormal, fmt.Sprintf("Vulnerability found: %s - %s (Severity: %s)", vuln.Plugin, vuln.Message, vuln.Severity), nil)
+		}
+		outputChan <- engine.ModuleOutput{DataKey: "evaluation.vulnerabilities", Data: vuln}
+		sent++
+
+		logger.Info().
+			Str("plugin", vuln.Plugin).
+			Str("target", result.Target).
+			Int("port", result.Port).
+			Msg("Default credentials accepted")
+	}
+	return sent
+}
+
+// defaultCredentialVulnerability converts an accepted default credential
+// into a critical vulnerability. The password is left out of the message.
+func defaultCredentialVulnerability(result scan.DefaultCredentialResult, p *plugin.YAMLPlugin) VulnerabilityResult {
+	account := "a password-only login"
+	if result.Username != "" {
+		account = fmt.Sprintf("user %q", result.Username)
+	}
+
+	vuln := VulnerabilityResult{
+		Target:      result.Target,
+		Port:        result.Port,
+		Plugin:      result.Plugin,
+		PluginType:  "default-credentials",
+		Severity:    string(plugin.CriticalSeverity),
+		Message:     fmt.Sprintf("Default credentials accepted over %s for %s", result.Protocol, account),
+		Remediation: "Change the default password or disable the account, and restrict access to the service",
+		Tags:        []string{"authentication", "default-credentials", result.Protocol},
+		Matched:     true,
+	}
+	if p != nil {
+		vuln.Plugin = p.Name
+		vuln.PluginID = p.ID
+		vuln.Reference = p.Output.Reference
+		vuln.References = p.Output.AllReferences()
+		vuln.CWE = p.Output.CWE
+	}
+	return vuln
+}
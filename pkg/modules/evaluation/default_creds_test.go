@@ -0,0 +1,82 @@
+// pkg/modules/evaluation/default_creds_test.go
+package evaluation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+func TestPluginEvaluationModule_Execute_DefaultCredentials(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{}))
+	// A credential-only plugin has no match block and is never matched
+	// against the scan data
+	module.plugins[plugin.CategoryNetwork] = append(module.plugins[plugin.CategoryNetwork], &plugin.YAMLPlugin{
+		ID:   "vnc-default-credentials",
+		Name: "VNC Default Credentials",
+		DefaultCredentials: &plugin.CredentialsBlock{
+			Protocol:    "ssh",
+			Ports:       []int{22},
+			Credentials: []plugin.CredentialPair{{Username: "root", Password: "root"}},
+		},
+		Output: plugin.OutputBlock{Vulnerability: true, Severity: plugin.HighSeverity, Message: "unused"},
+	})
+
+	inputs := map[string]interface{}{
+		"auth.default_credentials": []interface{}{
+			scan.DefaultCredentialResult{
+				Target: "192.0.2.10", Port: 21, Protocol: "ftp", Plugin: "Open FTP Service",
+				Username: "admin", Password: "admin", Attempts: 1,
+			},
+			map[string]interface{}{"target": "192.0.2.11", "port": float64(6379), "protocol": "redis", "plugin": "unknown-plugin"},
+		},
+		"ssh.banner": "SSH-2.0-OpenSSH_9.6",
+	}
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var vulns []VulnerabilityResult
+	for out := range outputChan {
+		vuln := out.Data.(VulnerabilityResult)
+		require.NotEqual(t, "VNC Default Credentials", vuln.Plugin)
+		if vuln.PluginType == "default-credentials" {
+			vulns = append(vulns, vuln)
+		}
+	}
+	require.Len(t, vulns, 2)
+
+	require.Equal(t, "192.0.2.10", vulns[0].Target)
+	require.Equal(t, 21, vulns[0].Port)
+	require.Equal(t, "Open FTP Service", vulns[0].Plugin)
+	require.Equal(t, "critical", vulns[0].Severity)
+	require.Equal(t, `Default credentials accepted over ftp for user "admin"`, vulns[0].Message)
+	require.NotContains(t, vulns[0].Message, "Password")
+	require.Equal(t, "https://cwe.mitre.org/data/definitions/319.html", vulns[0].Reference)
+	require.Contains(t, vulns[0].Tags, "ftp")
+
+	require.Equal(t, "unknown-plugin", vulns[1].Plugin)
+	require.Equal(t, 6379, vulns[1].Port)
+	require.Equal(t, "Default credentials accepted over redis for a password-only login", vulns[1].Message)
+
+	// Credentials of plugins left out of the selection are not reported
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{pluginsConfig: []string{"ftp"}}))
+	outputChan = make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+	vulns = nil
+	for out := range outputChan {
+		if vuln := out.Data.(VulnerabilityResult); vuln.PluginType == "default-credentials" {
+			vulns = append(vulns, vuln)
+		}
+	}
+	require.Len(t, vulns, 1)
+	require.Equal(t, "Open FTP Service", vulns[0].Plugin)
+}
@@ -0,0 +1,226 @@
+// pkg/modules/evaluation/nuclei.go
+package evaluation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/parse"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+// Plugin evaluation config keys for Nuclei templates.
+const (
+	nucleiTemplatesConfig   = "nuclei_templates"
+	nucleiTimeoutConfig     = "nuclei_timeout"
+	nucleiConcurrencyConfig = "nuclei_concurrency"
+
+	defaultNucleiConcurrency = 10
+)
+
+// nucleiTarget is an HTTP service Nuclei checks run against.
+type nucleiTarget struct {
+	ip      string
+	port    int
+	baseURL string
+}
+
+// initNuclei loads the Nuclei templates listed in the module config.
+// Templates outside the supported subset are skipped.
+func (m *PluginEvaluationModule) initNuclei(config map[string]interface{}, logger zerolog.Logger) error {
+	paths := cast.ToStringSlice(config[nucleiTemplatesConfig])
+	if len(paths) == 0 {
+		return nil
+	}
+
+	timeout := plugin.DefaultNucleiTimeout
+	if v, ok := config[nucleiTimeoutConfig].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", nucleiTimeoutConfig, v, err)
+		}
+		timeout = d
+	}
+	m.nucleiConcurrency = defaultNucleiConcurrency
+	if v, ok := config[nucleiConcurrencyConfig]; ok && cast.ToInt(v) > 0 {
+		m.nucleiConcurrency = cast.ToInt(v)
+	}
+
+	checks, errs := plugin.LoadNucleiTemplates(paths)
+	unsupported := 0
+	for _, err := range errs {
+		if errors.Is(err, plugin.ErrUnsupportedNucleiTemplate) {
+			unsupported++
+			logger.Debug().Err(err).Msg("Skipping unsupported Nuclei template")
+			continue
+		}
+		logger.Warn().Err(err).Msg("Failed to load Nuclei template")
+	}
+	logger.Info().
+		Int("loaded", len(checks)).
+		Int("unsupported", unsupported).
+		Int("failed", len(errs)-unsupported).
+		Msg("Loaded Nuclei templates")
+
+	m.nucleiChecks = checks
+	m.nucleiRunner = plugin.NewNucleiRunner(timeout)
+	return nil
+}
+
+// runNucleiChecks runs the loaded Nuclei checks against every HTTP service
+// found by the scan and sends a vulnerability for each match. It returns
+// the number of matches.
+func (m *PluginEvaluationModule) runNucleiChecks(ctx context.Context, inputs map[string]interface{}, out output.Output, outputChan chan<- engine.ModuleOutput, logger zerolog.Logger) int {
+	if len(m.nucleiChecks) == 0 {
+		return 0
+	}
+	targets := intrusiveTargets(ctx, nucleiTargets(inputs))
+	if len(targets) == 0 {
+		logger.Debug().Msg("No HTTP services found, skipping Nuclei templates")
+		return 0
+	}
+	logger.Info().
+		Int("templates", len(m.nucleiChecks)).
+		Int("http_services", len(targets)).
+		Msg("Running Nuclei templates")
+
+	type job struct {
+		target nucleiTarget
+		check  *plugin.NucleiCheck
+	}
+	severityPolicy := policy.FromContext(ctx)
+	jobs := make(chan job)
+	var (
+		mu      sync.Mutex
+		matches int
+		wg      sync.WaitGroup
+	)
+	for range m.nucleiConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result, err := m.nucleiRunner.Run(ctx, j.check, j.target.baseURL)
+				if err != nil {
+					logger.Debug().Err(err).Str("template", j.check.Plugin.ID).Str("url", j.target.baseURL).Msg("Nuclei template failed")
+					continue
+				}
+				if !result.Matched {
+					continue
+				}
+
+				vuln := nucleiVulnerability(j.target, result)
+				applyPolicy(severityPolicy, &vuln)
+				if out != nil {
+					out.Diag(output.LevelNormal, fmt.Sprintf("Vulnerability found: %s (Severity: %s)", vuln.Message, vuln.Severity), nil)
+				}
+				mu.Lock()
+				matches++
+				mu.Unlock()
+				outputChan <- engine.ModuleOutput{DataKey: "evaluation.vulnerabilities", Data: vuln}
+			}
+		}()
+	}
+
+feed:
+	for _, target := range targets {
+		for _, check := range m.nucleiChecks {
+			select {
+			case jobs <- job{target: target, check: check}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return matches
+}
+
+// nucleiVulnerability converts a Nuclei match into a vulnerability.
+func nucleiVulnerability(target nucleiTarget, result *plugin.YAMLMatchResult) VulnerabilityResult {
+	message := result.Output.Message
+	if at := result.Output.Metadata["matched_at"]; at != "" {
+		message += " at " + at
+	}
+	if extracted := result.Output.Metadata["extracted"]; extracted != "" {
+		message += " [" + extracted + "]"
+	}
+
+	vuln := VulnerabilityResult{
+		Target:      target.ip,
+		Port:        target.port,
+		Plugin:      result.Plugin.Name,
+		PluginID:    result.Plugin.ID,
+		PluginType:  "nuclei",
+		Severity:    string(result.Output.Severity),
+		Message:     message,
+		Remediation: result.Output.Remediation,
+		Reference:   result.Output.Reference,
+		Tags:        result.Plugin.Metadata.Tags,
+		Matched:     true,
+	}
+	if result.Plugin.Metadata.CVE != "" {
+		vuln.CVE = []string{result.Plugin.Metadata.CVE}
+	}
+	return vuln
+}
+
+// intrusiveTargets returns the targets that may be sent intrusive requests
+// under the CDN policy of ctx.
+func intrusiveTargets(ctx context.Context, targets []nucleiTarget) []nucleiTarget {
+	guard := cdn.FromContext(ctx)
+	return slices.DeleteFunc(targets, func(t nucleiTarget) bool { return !guard.Allow(t.ip, true) })
+}
+
+// nucleiTargets returns the HTTP services in the parsed HTTP details,
+// without duplicates.
+func nucleiTargets(inputs map[string]interface{}) []nucleiTarget {
+	details, _ := inputs["service.http.details"].([]interface{})
+
+	var targets []nucleiTarget
+	seen := make(map[string]bool)
+	for _, d := range details {
+		var ip, scheme string
+		var port int
+		switch info := d.(type) {
+		case parse.HTTPParsedInfo:
+			ip, port, scheme = info.Target, info.Port, info.Scheme
+		case map[string]interface{}:
+			// Fallback to map (in case of JSON unmarshaling)
+			ip = cast.ToString(info["target"])
+			port = cast.ToInt(info["port"])
+			scheme = cast.ToString(info["scheme"])
+		default:
+			continue
+		}
+		if ip == "" || port <= 0 {
+			continue
+		}
+		if scheme != "https" {
+			scheme = "http"
+		}
+
+		baseURL := scheme + "://" + net.JoinHostPort(ip, strconv.Itoa(port))
+		if seen[baseURL] {
+			continue
+		}
+		seen[baseURL] = true
+		targets = append(targets, nucleiTarget{ip: ip, port: port, baseURL: baseURL})
+	}
+	return targets
+}
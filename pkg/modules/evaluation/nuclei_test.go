@@ -0,0 +1,139 @@
+// pkg/modules/evaluation/nuclei_test.go
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/parse"
+)
+
+const exposedEnvTemplate = `id: exposed-env
+info:
+  name: Exposed .env File
+  author: test
+  severity: high
+  classification:
+    cve-id: CVE-2099-0001
+  tags: exposure,config
+http:
+  - method: GET
+    path:
+      - "{{BaseURL}}/.env"
+    matchers-condition: and
+    matchers:
+      - type: regex
+        regex: ["(?m)^DB_PASSWORD="]
+      - type: status
+        status: [200]
+    extractors:
+      - type: regex
+        group: 1
+        regex: ["(?m)^APP_NAME=(\\w+)"]
+`
+
+func writeNucleiTemplates(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "exposed-env.yaml"), []byte(exposedEnvTemplate), 0o644))
+	// Unsupported templates are skipped without failing the module
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "dns.yaml"), []byte("id: dns\ninfo: {name: dns, severity: info}\ndns:\n  - name: x\n"), 0o644))
+	return dir
+}
+
+func TestPluginEvaluationModule_Init_NucleiTemplates(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{
+		nucleiTemplatesConfig:   []interface{}{writeNucleiTemplates(t)},
+		nucleiConcurrencyConfig: 3,
+	}))
+
+	require.Len(t, module.nucleiChecks, 1)
+	require.Equal(t, "exposed-env", module.nucleiChecks[0].Plugin.ID)
+	require.NotNil(t, module.nucleiRunner)
+	require.Equal(t, 3, module.nucleiConcurrency)
+
+	err := NewPluginEvaluationModule().Init("test-instance", map[string]interface{}{
+		nucleiTemplatesConfig: []string{"unused"},
+		nucleiTimeoutConfig:   "soon",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid nuclei_timeout")
+}
+
+func TestPluginEvaluationModule_Execute_NucleiTemplates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.env" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, "APP_NAME=shop\nDB_PASSWORD=hunter2\n")
+	}))
+	defer server.Close()
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{
+		nucleiTemplatesConfig: []string{writeNucleiTemplates(t)},
+	}))
+
+	inputs := map[string]interface{}{
+		"service.http.details": []interface{}{
+			parse.HTTPParsedInfo{Target: host, Port: port, Scheme: "http"},
+			// Duplicate services are checked once
+			map[string]interface{}{"target": host, "port": float64(port), "scheme": "http"},
+		},
+	}
+
+	outputChan := make(chan engine.ModuleOutput, 10)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var vulns []VulnerabilityResult
+	for out := range outputChan {
+		require.Equal(t, "evaluation.vulnerabilities", out.DataKey)
+		vulns = append(vulns, out.Data.(VulnerabilityResult))
+	}
+	require.Len(t, vulns, 1)
+
+	vuln := vulns[0]
+	require.Equal(t, host, vuln.Target)
+	require.Equal(t, port, vuln.Port)
+	require.Equal(t, "exposed-env", vuln.PluginID)
+	require.Equal(t, "nuclei", vuln.PluginType)
+	require.Equal(t, "high", vuln.Severity)
+	require.Equal(t, []string{"CVE-2099-0001"}, vuln.CVE)
+	require.Equal(t, "Exposed .env File at "+server.URL+"/.env [shop]", vuln.Message)
+	require.Contains(t, vuln.Tags, "nuclei")
+}
+
+func TestNucleiTargets(t *testing.T) {
+	targets := nucleiTargets(map[string]interface{}{
+		"service.http.details": []interface{}{
+			parse.HTTPParsedInfo{Target: "10.0.0.1", Port: 443, Scheme: "https"},
+			parse.HTTPParsedInfo{Target: "::1", Port: 8080},
+			parse.HTTPParsedInfo{Target: "10.0.0.2"}, // no port
+			"unexpected",
+		},
+	})
+
+	require.Equal(t, []nucleiTarget{
+		{ip: "10.0.0.1", port: 443, baseURL: "https://10.0.0.1:443"},
+		{ip: "::1", port: 8080, baseURL: "http://[::1]:8080"},
+	}, targets)
+	require.Empty(t, nucleiTargets(map[string]interface{}{}))
+}
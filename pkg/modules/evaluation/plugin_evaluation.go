@@ -4,14 +4,20 @@ package evaluation
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
 
 	"github.com/vulntor/vulntor/pkg/engine"
 	"github.com/vulntor/vulntor/pkg/modules/parse"
 	"github.com/vulntor/vulntor/pkg/modules/scan"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 const (
@@ -22,11 +28,21 @@ const (
 	pluginEvalModuleAuthor      = "Vulntor Team"
 )
 
+// Plugin evaluation config keys for replays and plugin selection.
+const (
+	offlineConfig = "offline"
+	pluginsConfig = "plugins"
+)
+
+// httpCacheTTLConfig is how long the HTTP probes of a run share responses.
+const httpCacheTTLConfig = "http_cache_ttl"
+
 // VulnerabilityResult represents a matched vulnerability from plugin evaluation.
 type VulnerabilityResult struct {
 	Target      string   `json:"target"`
 	Port        int      `json:"port,omitempty"`
 	Plugin      string   `json:"plugin"`
+	PluginID    string   `json:"plugin_id,omitempty"`
 	PluginType  string   `json:"plugin_type"`
 	Severity    string   `json:"severity"`
 	Message     string   `json:"message"`
@@ -34,7 +50,62 @@ type VulnerabilityResult struct {
 	CVE         []string `json:"cve,omitempty"`
 	CWE         []string `json:"cwe,omitempty"`
 	Reference   string   `json:"reference,omitempty"`
-	Matched     bool     `json:"matched"`
+	References  []string `json:"references,omitempty"` // Reference and the plugin's further references
+	Tags        []string `json:"tags,omitempty"`
+	// Extracted holds the values the plugin's extractors found
+	Extracted map[string]any `json:"extracted,omitempty"`
+	// Evidence holds the context fields the plugin reports as evidence,
+	// shown by reports as EvidenceFormat (text, code or json)
+	Evidence       map[string]string `json:"evidence,omitempty"`
+	EvidenceFormat string            `json:"evidence_format,omitempty"`
+	// OriginalSeverity is the plugin's severity when the severity policy
+	// remapped Severity, for SeverityReason
+	OriginalSeverity string `json:"original_severity,omitempty"`
+	SeverityReason   string `json:"severity_reason,omitempty"`
+	Matched          bool   `json:"matched"`
+}
+
+// addOutputDetails copies the references, CVE and CWE identifiers and the
+// evidence of a plugin match to vuln. References of the plugin's metadata
+// follow those of its output.
+func addOutputDetails(vuln *VulnerabilityResult, result *plugin.YAMLMatchResult) {
+	vuln.References = result.Output.AllReferences()
+	for _, ref := range result.Plugin.Metadata.References {
+		if !slices.Contains(vuln.References, ref) {
+			vuln.References = append(vuln.References, ref)
+		}
+	}
+	if vuln.Reference == "" && len(vuln.References) > 0 {
+		vuln.Reference = vuln.References[0]
+	}
+	for _, id := range append([]string{result.Plugin.Metadata.CVE}, result.Output.CVE...) {
+		if id != "" && !slices.Contains(vuln.CVE, id) {
+			vuln.CVE = append(vuln.CVE, id)
+		}
+	}
+	vuln.CWE = append(vuln.CWE, result.Output.CWE...)
+	if len(result.Evidence) > 0 {
+		vuln.Evidence = result.Evidence
+		vuln.EvidenceFormat = result.Output.EvidenceFormat()
+	}
+}
+
+// applyPolicy remaps the severity of vuln by the first override of p
+// selecting it, keeping the plugin's severity in OriginalSeverity.
+func applyPolicy(p *policy.Policy, vuln *VulnerabilityResult) {
+	override, ok := p.Override(policy.Finding{
+		Target:   vuln.Target,
+		PluginID: vuln.PluginID,
+		Plugin:   vuln.Plugin,
+		Tags:     vuln.Tags,
+		CVE:      vuln.CVE,
+	})
+	if !ok || override.Severity == vuln.Severity {
+		return
+	}
+	vuln.OriginalSeverity = vuln.Severity
+	vuln.Severity = override.Severity
+	vuln.SeverityReason = override.Reason
 }
 
 // PluginEvaluationModule evaluates scan results against embedded security plugins.
@@ -42,6 +113,24 @@ type PluginEvaluationModule struct {
 	meta      engine.ModuleMetadata
 	plugins   map[plugin.Category][]*plugin.YAMLPlugin
 	evaluator *plugin.Evaluator
+
+	// Nuclei templates run against the HTTP services found by the scan
+	nucleiChecks      []*plugin.NucleiCheck
+	nucleiRunner      *plugin.NucleiRunner
+	nucleiConcurrency int
+
+	// Multi-step plugins send their requests to the HTTP services found
+	stepRunner *plugin.StepRunner
+	// httpCacheTTL is how long Nuclei templates and multi-step plugins
+	// reuse the response to an identical request; 0 disables the cache
+	httpCacheTTL time.Duration
+
+	// offline skips Nuclei templates and multi-step plugins, which send
+	// requests, e.g. when replaying stored evidence
+	offline bool
+	// selection restricts evaluation to the plugins with these IDs, names,
+	// categories or tags (lowercase); empty selects all plugins
+	selection []string
 }
 
 // NewPluginEvaluationModule creates a new plugin evaluation module instance.
@@ -84,6 +173,223 @@ func NewPluginEvaluationModule() *PluginEvaluationModule {
 					IsOptional:   true,
 					Description:  "TCP banner grab results for target/port extraction",
 				},
+				{
+					Key:          "ssh.auth.details",
+					DataTypeName: "scan.SSHAuthResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Host data collected over credentialed SSH; files become ssh.auth.file.<path>",
+				},
+				{
+					Key:          "ssh.auth.kernel",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Kernel release collected over credentialed SSH",
+				},
+				{
+					Key:          "ssh.auth.os",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Operating system name collected over credentialed SSH",
+				},
+				{
+					Key:          "ssh.auth.packages",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Installed packages as 'name version' lines, collected over credentialed SSH",
+				},
+				{
+					Key:          "snmp.sys_descr",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "System description reported by an SNMP agent",
+				},
+				{
+					Key:          "snmp.sys_object_id",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Vendor object identifier reported by an SNMP agent",
+				},
+				{
+					Key:          "snmp.software",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Installed software names, one per line, from the SNMP host resources MIB",
+				},
+				{
+					Key:          "snmp.community",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Default SNMP community the agent accepted, such as public",
+				},
+				{
+					Key:          "smb.dialects",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "SMB 2/3 dialects the server accepted, one per line",
+				},
+				{
+					Key:          "smb.smb1",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Whether the SMB server accepts SMB1",
+				},
+				{
+					Key:          "smb.signing_required",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Whether the SMB server requires message signing",
+				},
+				{
+					Key:          "smb.anonymous",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Whether the SMB server accepts anonymous or guest sessions",
+				},
+				{
+					Key:          "smb.shares",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "SMB share names, one per line",
+				},
+				{
+					Key:          "smb.anonymous_shares",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "SMB shares readable over an anonymous session, one per line",
+				},
+				{
+					Key:          "smb.os_version",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Windows version and build announced in the SMB NTLM challenge",
+				},
+				{
+					Key:          "dns.zone_transfer",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Whether a name server of the target's zone allowed a zone transfer",
+				},
+				{
+					Key:          "dns.zone_transfer_servers",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Name servers that allowed a zone transfer, one per line",
+				},
+				{
+					Key:          "dns.wildcard",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Whether random names in the target's zone resolve",
+				},
+				{
+					Key:          "dns.ns",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Name servers of the target's zone, one per line",
+				},
+				{
+					Key:          "dns.mx",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Mail exchangers of the target's zone, one 'preference host' per line",
+				},
+				{
+					Key:          "dns.txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "TXT records of the target's zone, one per line",
+				},
+				{
+					Key:          "http.ntlm.computer",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Host name disclosed in an HTTP service's NTLM challenge",
+				},
+				{
+					Key:          "http.ntlm.domain",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Domain name disclosed in an HTTP service's NTLM challenge",
+				},
+				{
+					Key:          "http.ntlm.os_version",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Windows version and build disclosed in an HTTP service's NTLM challenge",
+				},
+				{
+					Key:          "http.paths",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Paths found on an HTTP service by content discovery, one per line",
+				},
+				{
+					Key:          "http.wellknown.paths",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Well-known metadata endpoints an HTTP service serves, one per line",
+				},
+				{
+					Key:          "http.security_txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Content of an HTTP service's security.txt",
+				},
+				{
+					Key:          "http.security_txt.contact",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Security contacts from an HTTP service's security.txt, one per line",
+				},
+				{
+					Key:          "http.robots_txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Content of an HTTP service's robots.txt",
+				},
+				{
+					Key:          "http.robots_txt.disallow",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Paths an HTTP service's robots.txt disallows, one per line",
+				},
+				{
+					Key:          "service.http.details",
+					DataTypeName: "parse.HTTPParsedInfo",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Parsed HTTP services that Nuclei templates run against",
+				},
 				{
 					Key:          "http.server",
 					DataTypeName: "string",
@@ -120,6 +426,20 @@ func NewPluginEvaluationModule() *PluginEvaluationModule {
 					IsOptional:   true,
 					Description:  "TLS SNI server name",
 				},
+				{
+					Key:          "tls.jarm",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "JARM fingerprint of a TLS service",
+				},
+				{
+					Key:          "tls.ja3s",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "JA3S fingerprint of a TLS service",
+				},
 				{
 					Key:          "tls.certificate.issuer",
 					DataTypeName: "string",
@@ -134,6 +454,20 @@ func NewPluginEvaluationModule() *PluginEvaluationModule {
 					IsOptional:   true,
 					Description:  "TLS certificate common name",
 				},
+				{
+					Key:          "auth.default_credentials",
+					DataTypeName: "scan.DefaultCredentialResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Default credentials accepted by a service",
+				},
+				{
+					Key:          "container.exposures",
+					DataTypeName: "scan.ContainerExposureResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "Docker registries and kubelets answering without authentication",
+				},
 				{
 					Key:          "tls.certificate.not_before",
 					DataTypeName: "time.Time",
@@ -171,6 +505,14 @@ func NewPluginEvaluationModule() *PluginEvaluationModule {
 					Description:  "List of vulnerabilities detected by plugins",
 				},
 			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				nucleiTemplatesConfig:   {Description: "Nuclei template files or directories to run against HTTP services.", Type: "[]string", Required: false},
+				nucleiTimeoutConfig:     {Description: "Timeout for each Nuclei template request (e.g., '10s').", Type: "duration", Required: false, Default: plugin.DefaultNucleiTimeout.String()},
+				nucleiConcurrencyConfig: {Description: "Number of Nuclei template requests run in parallel.", Type: "int", Required: false, Default: defaultNucleiConcurrency},
+				offlineConfig:           {Description: "Skip Nuclei templates and multi-step plugins, which send requests to the services found.", Type: "bool", Required: false, Default: false},
+				pluginsConfig:           {Description: "IDs, names, categories or tags of the plugins to evaluate (default: all).", Type: "[]string", Required: false},
+				httpCacheTTLConfig:      {Description: "How long identical HTTP probes of a run share one response (e.g., '5m'; '0s' disables).", Type: "duration", Required: false, Default: plugin.DefaultHTTPCacheTTL.String()},
+			},
 		},
 	}
 }
@@ -197,6 +539,30 @@ func (m *PluginEvaluationModule) Init(instanceID string, config map[string]inter
 
 	// Create evaluator for plugin execution
 	m.evaluator = plugin.NewEvaluator()
+	m.stepRunner = plugin.NewStepRunner(0)
+
+	m.offline = cast.ToBool(config[offlineConfig])
+	m.httpCacheTTL = plugin.DefaultHTTPCacheTTL
+	if v, ok := config[httpCacheTTLConfig].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid %s %q: must be a non-negative duration", httpCacheTTLConfig, v)
+		}
+		m.httpCacheTTL = d
+	}
+	m.selection = nil
+	for _, s := range cast.ToStringSlice(config[pluginsConfig]) {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			m.selection = append(m.selection, s)
+		}
+	}
+
+	// Load Nuclei templates, if configured
+	if !m.offline {
+		if err := m.initNuclei(config, logger); err != nil {
+			return err
+		}
+	}
 
 	// Log summary
 	totalPlugins := 0
@@ -211,6 +577,8 @@ func (m *PluginEvaluationModule) Init(instanceID string, config map[string]inter
 
 	logger.Info().
 		Int("total_plugins", totalPlugins).
+		Bool("offline", m.offline).
+		Strs("selected_plugins", m.selection).
 		Msg("Plugin evaluation module initialized successfully")
 
 	return nil
@@ -224,6 +592,33 @@ func (m *PluginEvaluationModule) Execute(ctx context.Context, inputs map[string]
 	// Extract Output interface for real-time vulnerability reporting
 	out, _ := ctx.Value(output.OutputKey).(output.Output)
 
+	// Nuclei templates and multi-step plugins probing the same URL share
+	// the response, unless the caller brought its own cache
+	if plugin.HTTPCacheFromContext(ctx) == nil {
+		cache := plugin.NewHTTPCache(m.httpCacheTTL)
+		ctx = plugin.WithHTTPCache(ctx, cache)
+		defer func() {
+			if stats := cache.Stats(); stats.Hits > 0 {
+				logger.Debug().Int("hits", stats.Hits).Int("misses", stats.Misses).Msg("HTTP probe responses shared")
+			}
+		}()
+	}
+
+	// Nuclei templates send their own requests to the HTTP services
+	if matches := m.runNucleiChecks(ctx, inputs, out, outputChan, logger); matches > 0 {
+		logger.Info().Int("matched_templates", matches).Msg("Nuclei template evaluation completed")
+	}
+
+	// Logins confirmed by the default-creds module
+	if sent := m.reportDefaultCredentials(ctx, inputs, out, outputChan, logger); sent > 0 {
+		logger.Info().Int("accepted_credentials", sent).Msg("Default-credential findings reported")
+	}
+
+	// Registries and kubelets found open by the container-exposure module
+	if sent := m.reportContainerExposures(ctx, inputs, out, outputChan, logger); sent > 0 {
+		logger.Info().Int("exposed_services", sent).Msg("Container exposure findings reported")
+	}
+
 	// Build evaluation context from inputs
 	evalContext := m.buildEvaluationContext(inputs)
 	if len(evalContext) == 0 {
@@ -235,16 +630,41 @@ func (m *PluginEvaluationModule) Execute(ctx context.Context, inputs map[string]
 		Int("context_keys", len(evalContext)).
 		Msg("Built evaluation context from inputs")
 
-	// Get all plugins as flat list for evaluation
-	allPlugins, err := m.getAllPluginsFlat()
+	// Get all plugins as flat list for evaluation, with the installed
+	// plugins of this run
+	allPlugins, err := m.getAllPluginsFlat(plugin.InstalledPluginsFromContext(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to get plugins: %w", err)
 	}
 
-	// Evaluate plugins one by one, skipping those with unsupported triggers
+	// Severity overrides of the environment scanned
+	severityPolicy := policy.FromContext(ctx)
+	execStats := plugin.ExecRecorderFromContext(ctx)
+
+	// Evaluate plugins one by one, skipping those with unsupported triggers.
+	// Plugins that match on extracted data run after the plugins extracting it.
 	matchCount := 0
-	for _, pluginToEval := range allPlugins {
+	for _, pluginToEval := range plugin.OrderByExtraction(allPlugins) {
+		// Credential-only plugins are reported from confirmed logins
+		if pluginToEval.Match == nil && pluginToEval.DefaultCredentials != nil {
+			continue
+		}
+		// Multi-step plugins run against each HTTP service below
+		if len(pluginToEval.Steps) > 0 {
+			continue
+		}
+
+		_, span := tracing.Start(ctx, "plugin.evaluate",
+			tracing.String("plugin.id", pluginToEval.ID),
+			tracing.String("plugin.name", pluginToEval.Name))
+		start := time.Now()
 		result, err := m.evaluator.Evaluate(pluginToEval, evalContext)
+		execStats.Record(pluginToEval, time.Since(start), err == nil && result.Matched, err)
+		span.RecordError(err)
+		if err == nil {
+			span.SetAttributes(tracing.Bool("plugin.matched", result.Matched))
+		}
+		span.End()
 		if err != nil {
 			// Skip plugins with unsupported triggers (port, service conditions)
 			logger.Debug().
@@ -258,6 +678,15 @@ func (m *PluginEvaluationModule) Execute(ctx context.Context, inputs map[string]
 			continue
 		}
 
+		// Extraction-only plugins feed later plugins and are not findings
+		if !result.Output.Vulnerability && len(result.Plugin.Extract) > 0 {
+			logger.Debug().
+				Str("plugin", result.Plugin.Name).
+				Interface("extracted", result.Extracted).
+				Msg("Plugin extracted data")
+			continue
+		}
+
 		matchCount++
 
 		// Extract target information from context
@@ -269,22 +698,22 @@ func (m *PluginEvaluationModule) Execute(ctx context.Context, inputs map[string]
 			Target:      target,
 			Port:        port,
 			Plugin:      result.Plugin.Name,
+			PluginID:    result.Plugin.ID,
 			PluginType:  string(result.Plugin.Type),
 			Severity:    string(result.Output.Severity),
 			Message:     result.Output.Message,
 			Remediation: result.Output.Remediation,
 			Reference:   result.Output.Reference,
+			Tags:        result.Plugin.Metadata.Tags,
+			Extracted:   result.Extracted,
 			Matched:     true,
 		}
-
-		// Add CVE reference if available (CVE is a single string in metadata)
-		if len(result.Plugin.Metadata.CVE) > 0 {
-			vuln.CVE = []string{result.Plugin.Metadata.CVE}
-		}
+		addOutputDetails(&vuln, result)
+		applyPolicy(severityPolicy, &vuln)
 
 		// Real-time output: Emit vulnerability detection to user
 		if out != nil {
-			severity := string(result.Output.Severity)
+			severity := vuln.Severity
 			message := fmt.Sprintf("Vulnerability found: %s - %s (Severity: %s)", vuln.Plugin, vuln.Message, severity)
 			if len(vuln.CVE) > 0 {
 				message = fmt.Sprintf("Vulnerability found: %s - %s (%s)", vuln.Plugin, vuln.CVE[0], severity)
@@ -300,11 +729,16 @@ func (m *PluginEvaluationModule) Execute(ctx context.Context, inputs map[string]
 
 		logger.Info().
 			Str("plugin", result.Plugin.Name).
-			Str("severity", string(result.Output.Severity)).
+			Str("severity", vuln.Severity).
 			Str("target", target).
 			Msg("Vulnerability detected")
 	}
 
+	// Multi-step plugins see the data extracted by the plugins above
+	if !m.offline {
+		matchCount += m.runStepPlugins(ctx, allPlugins, evalContext, inputs, out, outputChan, logger)
+	}
+
 	logger.Info().
 		Int("total_plugins", len(allPlugins)).
 		Int("matched_plugins", matchCount).
@@ -326,6 +760,35 @@ func (m *PluginEvaluationModule) buildEvaluationContext(inputs map[string]interf
 		"ssh.kex_algorithms",
 		"ssh.mac_algorithms",
 		"ssh.encryption_algorithms",
+		"ssh.auth.kernel",
+		"ssh.auth.os",
+		"ssh.auth.packages",
+		"snmp.sys_descr",
+		"snmp.sys_object_id",
+		"snmp.software",
+		"snmp.community",
+		"smb.dialects",
+		"smb.smb1",
+		"smb.signing_required",
+		"smb.anonymous",
+		"smb.shares",
+		"smb.anonymous_shares",
+		"smb.os_version",
+		"dns.zone_transfer",
+		"dns.zone_transfer_servers",
+		"dns.wildcard",
+		"dns.ns",
+		"dns.mx",
+		"dns.txt",
+		"http.ntlm.computer",
+		"http.ntlm.domain",
+		"http.ntlm.os_version",
+		"http.paths",
+		"http.wellknown.paths",
+		"http.security_txt",
+		"http.security_txt.contact",
+		"http.robots_txt",
+		"http.robots_txt.disallow",
 		"http.server",
 		"http.headers",
 		"service.port",
@@ -337,6 +800,8 @@ func (m *PluginEvaluationModule) buildEvaluationContext(inputs map[string]interf
 		// Phase 1.8: TLS metadata keys
 		"tls.cipher_suite",
 		"tls.server_name",
+		"tls.jarm",
+		"tls.ja3s",
 		"tls.certificate.issuer",
 		"tls.certificate.common_name",
 		"tls.certificate.not_before",
@@ -361,6 +826,18 @@ func (m *PluginEvaluationModule) buildEvaluationContext(inputs map[string]interf
 		}
 	}
 
+	// Expose files collected over credentialed SSH as ssh.auth.file.<path>
+	if details, ok := inputs["ssh.auth.details"].([]interface{}); ok {
+		for _, item := range details {
+			if result, ok := item.(scan.SSHAuthResult); ok && result.Error == "" {
+				for path, content := range result.Files {
+					context["ssh.auth.file."+path] = content
+				}
+				break
+			}
+		}
+	}
+
 	// Extract target and port from service details (SSH, HTTP, etc.)
 	// This provides context for vulnerability reporting
 	if sshDetails, ok := inputs["service.ssh.details"].([]interface{}); ok && len(sshDetails) > 0 {
@@ -405,15 +882,64 @@ func (m *PluginEvaluationModule) buildEvaluationContext(inputs map[string]interf
 	return context
 }
 
-// getAllPluginsFlat returns all plugins as a flat slice.
-func (m *PluginEvaluationModule) getAllPluginsFlat() ([]*plugin.YAMLPlugin, error) {
+// getAllPluginsFlat returns the embedded plugins and installed as a flat
+// slice. An installed plugin replaces the embedded plugin with its ID.
+// With a plugin selection, only the selected plugins are returned.
+func (m *PluginEvaluationModule) getAllPluginsFlat(installed []*plugin.YAMLPlugin) ([]*plugin.YAMLPlugin, error) {
+	replaced := make(map[string]bool, len(installed))
+	for _, p := range installed {
+		replaced[p.ID] = true
+	}
+
 	var allPlugins []*plugin.YAMLPlugin
-	for _, categoryPlugins := range m.plugins {
-		allPlugins = append(allPlugins, categoryPlugins...)
+	for category, categoryPlugins := range m.plugins {
+		for _, p := range categoryPlugins {
+			if !replaced[p.ID] && m.selects(p, category) {
+				allPlugins = append(allPlugins, p)
+			}
+		}
+	}
+	for _, p := range installed {
+		if m.selects(p, "") {
+			allPlugins = append(allPlugins, p)
+		}
 	}
 	return allPlugins, nil
 }
 
+// selects reports whether p, embedded in category (empty for installed
+// plugins), is part of the plugin selection.
+func (m *PluginEvaluationModule) selects(p *plugin.YAMLPlugin, category plugin.Category) bool {
+	if len(m.selection) == 0 {
+		return true
+	}
+	for _, s := range m.selection {
+		if s == strings.ToLower(p.ID) || s == strings.ToLower(p.Name) || s == string(category) {
+			return true
+		}
+		for _, tag := range p.Metadata.Tags {
+			if s == strings.ToLower(tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectsBuiltin reports whether the built-in check with id, name and tags
+// is part of the plugin selection.
+func (m *PluginEvaluationModule) selectsBuiltin(id, name string, tags []string) bool {
+	if len(m.selection) == 0 {
+		return true
+	}
+	for _, s := range m.selection {
+		if s == id || s == strings.ToLower(name) || slices.Contains(tags, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // extractTarget extracts target information from context.
 func (m *PluginEvaluationModule) extractTarget(context map[string]any) string {
 	// Try to get target from context (will be added in future steps)
@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
 	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
 )
 
 func init() {
@@ -72,7 +74,7 @@ func TestPluginEvaluationModule_Init(t *testing.T) {
 	for _, categoryPlugins := range module.plugins {
 		totalPlugins += len(categoryPlugins)
 	}
-	require.Equal(t, 20, totalPlugins, "should load exactly 20 embedded plugins")
+	require.Equal(t, 26, totalPlugins, "should load exactly 26 embedded plugins")
 
 	// Verify plugins by category
 	require.Contains(t, module.plugins, plugin.CategorySSH)
@@ -83,10 +85,10 @@ func TestPluginEvaluationModule_Init(t *testing.T) {
 
 	// Verify counts per category
 	require.Len(t, module.plugins[plugin.CategorySSH], 6, "should have 6 SSH plugins")
-	require.Len(t, module.plugins[plugin.CategoryHTTP], 4, "should have 4 HTTP plugins")
-	require.Len(t, module.plugins[plugin.CategoryTLS], 4, "should have 4 TLS plugins")
+	require.Len(t, module.plugins[plugin.CategoryHTTP], 8, "should have 8 HTTP plugins")
+	require.Len(t, module.plugins[plugin.CategoryTLS], 5, "should have 5 TLS plugins")
 	require.Len(t, module.plugins[plugin.CategoryDatabase], 3, "should have 3 Database plugins")
-	require.Len(t, module.plugins[plugin.CategoryNetwork], 3, "should have 3 Network plugins")
+	require.Len(t, module.plugins[plugin.CategoryNetwork], 4, "should have 4 Network plugins")
 }
 
 func TestPluginEvaluationModule_Execute_WithContext(t *testing.T) {
@@ -133,6 +135,25 @@ func TestPluginEvaluationModule_Execute_WithContext(t *testing.T) {
 	require.Equal(t, 443, vuln.Port, "port should match input")
 }
 
+func TestPluginEvaluationModule_Execute_C2JARM(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", nil))
+
+	inputs := map[string]interface{}{
+		"tls.jarm":     []interface{}{"07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1"},
+		"service.port": 50050,
+	}
+	outputChan := make(chan engine.ModuleOutput, 10)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var plugins []string
+	for o := range outputChan {
+		plugins = append(plugins, o.Data.(VulnerabilityResult).Plugin)
+	}
+	require.Equal(t, []string{"TLS Fingerprint of a C2 Framework"}, plugins)
+}
+
 func TestPluginEvaluationModule_Execute_NoContext(t *testing.T) {
 	module := NewPluginEvaluationModule()
 	require.NoError(t, module.Init("test-instance", nil))
@@ -196,6 +217,43 @@ func TestPluginEvaluationModule_Execute_TLSWeakCipher(t *testing.T) {
 	require.Equal(t, "high", vuln.Severity) // TLS weak cipher is high severity
 }
 
+func TestPluginEvaluationModule_Execute_Extract(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", nil))
+	// The IIS plugin matches on the version the extraction-only plugin
+	// pulls out of the Server header
+	module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{
+		plugin.CategoryHTTP: {
+			{
+				Name:     "Old IIS",
+				Triggers: []plugin.Trigger{{DataKey: "http.iis.version", Condition: "exists", Value: true}},
+				Match:    &plugin.MatchBlock{Logic: "AND", Rules: []plugin.MatchRule{{Field: "http.iis.version", Operator: "version_lt", Value: "10.0"}}},
+				Output:   plugin.OutputBlock{Vulnerability: true, Severity: plugin.MediumSeverity, Message: "Unsupported IIS version"},
+			},
+			{
+				Name:     "IIS Version",
+				Triggers: []plugin.Trigger{{DataKey: "http.server", Condition: "exists", Value: true}},
+				Extract: []plugin.Extractor{
+					{Name: "http.iis.version", Type: plugin.ExtractRegex, Field: "http.server", Regex: `Microsoft-IIS/([\d.]+)`},
+				},
+				Output: plugin.OutputBlock{Message: "IIS version extracted"},
+			},
+		},
+	}
+
+	outputChan := make(chan engine.ModuleOutput, 10)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"http.server": "Microsoft-IIS/8.5"}, outputChan))
+	close(outputChan)
+
+	var vulns []VulnerabilityResult
+	for out := range outputChan {
+		vulns = append(vulns, out.Data.(VulnerabilityResult))
+	}
+	require.Len(t, vulns, 1)
+	require.Equal(t, "Old IIS", vulns[0].Plugin)
+	require.Equal(t, "Unsupported IIS version", vulns[0].Message)
+}
+
 // NOTE: TLS expired/self-signed tests are removed for now pending
 // alignment of test contexts with plugin match requirements.
 
@@ -242,6 +300,93 @@ func TestVulnerabilityResult_Structure(t *testing.T) {
 	require.Contains(t, vuln.CVE, "CVE-2008-5161")
 }
 
+func TestAddOutputDetails(t *testing.T) {
+	result := &plugin.YAMLMatchResult{
+		Plugin: &plugin.YAMLPlugin{Metadata: plugin.PluginMetadata{CVE: "CVE-2024-6387"}},
+		Output: plugin.OutputBlock{
+			References: []string{"https://www.openssh.com/txt/release-9.8"},
+			CVE:        []string{"CVE-2024-6387", "CVE-2024-6409"},
+			CWE:        []string{"CWE-364"},
+			Evidence:   &plugin.EvidenceBlock{Fields: []string{"ssh.banner"}, Format: plugin.EvidenceCode},
+		},
+		Evidence: map[string]string{"ssh.banner": "SSH-2.0-OpenSSH_9.6"},
+	}
+
+	var vuln VulnerabilityResult
+	addOutputDetails(&vuln, result)
+	require.Equal(t, "https://www.openssh.com/txt/release-9.8", vuln.Reference)
+	require.Equal(t, []string{"https://www.openssh.com/txt/release-9.8"}, vuln.References)
+	require.Equal(t, []string{"CVE-2024-6387", "CVE-2024-6409"}, vuln.CVE)
+	require.Equal(t, []string{"CWE-364"}, vuln.CWE)
+	require.Equal(t, map[string]string{"ssh.banner": "SSH-2.0-OpenSSH_9.6"}, vuln.Evidence)
+	require.Equal(t, "code", vuln.EvidenceFormat)
+
+	// Without evidence, no format is reported
+	vuln = VulnerabilityResult{}
+	result.Evidence = nil
+	addOutputDetails(&vuln, result)
+	require.Empty(t, vuln.EvidenceFormat)
+}
+
+func TestApplyPolicy(t *testing.T) {
+	p := policy.New("ot", []policy.Override{
+		{Tags: []string{"telnet"}, Targets: []string{"10.20.0.0/16"}, Environments: []string{"ot"}, Severity: "medium", Reason: "PLC management"},
+		{Plugins: []string{"ssh-weak-kex"}, Severity: "low"},
+	})
+
+	vuln := VulnerabilityResult{Target: "10.20.1.5", Plugin: "Telnet Enabled", Severity: "high", Tags: []string{"telnet", "cleartext"}}
+	applyPolicy(p, &vuln)
+	require.Equal(t, "medium", vuln.Severity)
+	require.Equal(t, "high", vuln.OriginalSeverity)
+	require.Equal(t, "PLC management", vuln.SeverityReason)
+
+	// Outside the override's targets the plugin's severity is kept
+	vuln = VulnerabilityResult{Target: "10.30.1.5", Severity: "high", Tags: []string{"telnet"}}
+	applyPolicy(p, &vuln)
+	require.Equal(t, "high", vuln.Severity)
+	require.Empty(t, vuln.OriginalSeverity)
+
+	// An override to the same severity is not a remap
+	vuln = VulnerabilityResult{PluginID: "ssh-weak-kex", Severity: "low"}
+	applyPolicy(p, &vuln)
+	require.Empty(t, vuln.OriginalSeverity)
+
+	vuln = VulnerabilityResult{PluginID: "ssh-weak-kex", Severity: "medium"}
+	applyPolicy(nil, &vuln)
+	require.Equal(t, "medium", vuln.Severity)
+}
+
+func TestExecute_SeverityPolicy(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{}))
+
+	inputs := map[string]interface{}{
+		"auth.default_credentials": []interface{}{
+			scan.DefaultCredentialResult{Target: "10.20.0.7", Port: 21, Protocol: "ftp", Plugin: "Open FTP Service", Username: "admin"},
+			scan.DefaultCredentialResult{Target: "10.99.0.7", Port: 21, Protocol: "ftp", Plugin: "Open FTP Service", Username: "admin"},
+		},
+	}
+	p := policy.New("lab", []policy.Override{
+		{Tags: []string{"default-credentials"}, Targets: []string{"10.20.0.0/16"}, Environments: []string{"lab"}, Severity: "low", Reason: "isolated lab"},
+	})
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(policy.WithContext(context.Background(), p), inputs, outputChan))
+	close(outputChan)
+
+	vulns := make(map[string]VulnerabilityResult)
+	for out := range outputChan {
+		vuln := out.Data.(VulnerabilityResult)
+		vulns[vuln.Target] = vuln
+	}
+	require.Len(t, vulns, 2)
+	require.Equal(t, "low", vulns["10.20.0.7"].Severity)
+	require.Equal(t, "critical", vulns["10.20.0.7"].OriginalSeverity)
+	require.Equal(t, "isolated lab", vulns["10.20.0.7"].SeverityReason)
+	require.Equal(t, "critical", vulns["10.99.0.7"].Severity)
+	require.Empty(t, vulns["10.99.0.7"].OriginalSeverity)
+}
+
 func TestBuildEvaluationContext_ArrayAndScalar(t *testing.T) {
 	module := NewPluginEvaluationModule()
 
@@ -301,6 +446,115 @@ func TestBuildEvaluationContext_BannerGrab_MapFallback(t *testing.T) {
 	require.Equal(t, 8080, ctx["service.port"])
 }
 
+func TestBuildEvaluationContext_SSHAuth(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	inputs := map[string]interface{}{
+		"ssh.auth.kernel":   []interface{}{"5.15.0-91-generic"},
+		"ssh.auth.packages": []interface{}{"openssh-server 1:8.9p1-3ubuntu0.4\nopenssl 3.0.2-0ubuntu1.12\n"},
+		"ssh.auth.details": []interface{}{
+			scan.SSHAuthResult{Target: "192.0.2.20", Port: 22, Error: "audit: unable to authenticate"},
+			scan.SSHAuthResult{
+				Target: "192.0.2.21",
+				Port:   22,
+				Files:  map[string]string{"/etc/ssh/sshd_config": "PermitRootLogin yes\n"},
+			},
+		},
+	}
+
+	ctx := module.buildEvaluationContext(inputs)
+
+	require.Equal(t, "5.15.0-91-generic", ctx["ssh.auth.kernel"])
+	require.Contains(t, ctx["ssh.auth.packages"], "openssl 3.0.2")
+	require.Equal(t, "PermitRootLogin yes\n", ctx["ssh.auth.file./etc/ssh/sshd_config"])
+}
+
+func TestBuildEvaluationContext_SNMP(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"snmp.sys_descr":     []interface{}{"RouterOS CCR1009-7G-1C-1S+"},
+		"snmp.sys_object_id": []interface{}{"1.3.6.1.4.1.14988.1"},
+		"snmp.community":     []interface{}{"public"},
+	})
+
+	require.Equal(t, "RouterOS CCR1009-7G-1C-1S+", ctx["snmp.sys_descr"])
+	require.Equal(t, "1.3.6.1.4.1.14988.1", ctx["snmp.sys_object_id"])
+	require.Equal(t, "public", ctx["snmp.community"])
+	require.NotContains(t, ctx, "snmp.software")
+}
+
+func TestBuildEvaluationContext_SMB(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"smb.smb1":             []interface{}{true},
+		"smb.signing_required": []interface{}{false},
+		"smb.anonymous_shares": []interface{}{"public\n"},
+		"smb.os_version":       []interface{}{"10.0.17763"},
+	})
+
+	require.Equal(t, true, ctx["smb.smb1"])
+	require.Equal(t, false, ctx["smb.signing_required"])
+	require.Equal(t, "public\n", ctx["smb.anonymous_shares"])
+	require.Equal(t, "10.0.17763", ctx["smb.os_version"])
+	require.NotContains(t, ctx, "smb.shares")
+}
+
+func TestBuildEvaluationContext_DNS(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"dns.zone_transfer":         []interface{}{true},
+		"dns.zone_transfer_servers": []interface{}{"ns2.example.com\n"},
+		"dns.wildcard":              []interface{}{false},
+		"dns.txt":                   []interface{}{"v=spf1 -all\n"},
+	})
+
+	require.Equal(t, true, ctx["dns.zone_transfer"])
+	require.Equal(t, "ns2.example.com\n", ctx["dns.zone_transfer_servers"])
+	require.Equal(t, false, ctx["dns.wildcard"])
+	require.Equal(t, "v=spf1 -all\n", ctx["dns.txt"])
+	require.NotContains(t, ctx, "dns.mx")
+}
+
+func TestBuildEvaluationContext_HTTPNTLM(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"http.ntlm.computer": []interface{}{"exch01.corp.example"},
+		"http.ntlm.domain":   []interface{}{"corp.example"},
+	})
+
+	require.Equal(t, "exch01.corp.example", ctx["http.ntlm.computer"])
+	require.Equal(t, "corp.example", ctx["http.ntlm.domain"])
+	require.NotContains(t, ctx, "http.ntlm.os_version")
+}
+
+func TestBuildEvaluationContext_HTTPPaths(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"http.paths": []interface{}{"/.git/\n/admin/\n"},
+	})
+
+	require.Equal(t, "/.git/\n/admin/\n", ctx["http.paths"])
+}
+
+func TestBuildEvaluationContext_HTTPWellKnown(t *testing.T) {
+	module := NewPluginEvaluationModule()
+
+	ctx := module.buildEvaluationContext(map[string]interface{}{
+		"http.wellknown.paths":      []interface{}{"/robots.txt\n"},
+		"http.security_txt.contact": []interface{}{"mailto:security@example.com\n"},
+		"http.robots_txt.disallow":  []interface{}{"/admin/\n"},
+	})
+
+	require.Equal(t, "/robots.txt\n", ctx["http.wellknown.paths"])
+	require.Equal(t, "mailto:security@example.com\n", ctx["http.security_txt.contact"])
+	require.Equal(t, "/admin/\n", ctx["http.robots_txt.disallow"])
+}
+
 func TestExtractPort_Int(t *testing.T) {
 	module := NewPluginEvaluationModule()
 
@@ -387,3 +641,40 @@ func TestExtractTarget_NilValueReturnsUnknown(t *testing.T) {
 	target := module.extractTarget(ctx)
 	require.Equal(t, "unknown", target)
 }
+
+func TestGetAllPluginsFlat_Installed(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{
+		plugin.CategorySSH: {{ID: "ssh-weak", Name: "embedded"}, {ID: "ssh-old"}},
+	}
+
+	plugins, err := module.getAllPluginsFlat([]*plugin.YAMLPlugin{{ID: "ssh-weak", Name: "installed"}, {ID: "custom"}})
+	require.NoError(t, err)
+
+	names := make(map[string]string)
+	for _, p := range plugins {
+		names[p.ID] = p.Name
+	}
+	require.Len(t, plugins, 3)
+	require.Equal(t, "installed", names["ssh-weak"])
+	require.Contains(t, names, "custom")
+}
+
+func TestGetAllPluginsFlat_Selection(t *testing.T) {
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{pluginsConfig: []string{"TLS", "ssh-old", " Custom Check "}}))
+	module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{
+		plugin.CategorySSH: {{ID: "ssh-weak"}, {ID: "ssh-old"}},
+		plugin.CategoryTLS: {{ID: "tls-expired"}},
+		plugin.CategoryWeb: {{ID: "web-headers", Metadata: plugin.PluginMetadata{Tags: []string{"tls", "http"}}}},
+	}
+
+	plugins, err := module.getAllPluginsFlat([]*plugin.YAMLPlugin{{ID: "custom", Name: "Custom Check"}, {ID: "other"}})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, p := range plugins {
+		ids = append(ids, p.ID)
+	}
+	require.ElementsMatch(t, []string{"ssh-old", "tls-expired", "web-headers", "custom"}, ids)
+}
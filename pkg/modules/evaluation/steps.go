@@ -0,0 +1,123 @@
+// pkg/modules/evaluation/steps.go
+package evaluation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+)
+
+// defaultStepConcurrency is the number of multi-step plugin runs in
+// parallel.
+const defaultStepConcurrency = 10
+
+// runStepPlugins runs the plugins with steps against every HTTP service
+// found by the scan and sends a vulnerability for each match. evalContext
+// is the context the plugins' triggers and match blocks are evaluated
+// against. It returns the number of matches.
+func (m *PluginEvaluationModule) runStepPlugins(ctx context.Context, plugins []*plugin.YAMLPlugin, evalContext map[string]any, inputs map[string]interface{}, out output.Output, outputChan chan<- engine.ModuleOutput, logger zerolog.Logger) int {
+	var stepPlugins []*plugin.YAMLPlugin
+	for _, p := range plugins {
+		if len(p.Steps) > 0 {
+			stepPlugins = append(stepPlugins, p)
+		}
+	}
+	if len(stepPlugins) == 0 {
+		return 0
+	}
+	targets := intrusiveTargets(ctx, nucleiTargets(inputs))
+	if len(targets) == 0 {
+		logger.Debug().Msg("No HTTP services found, skipping multi-step plugins")
+		return 0
+	}
+
+	type job struct {
+		target nucleiTarget
+		plugin *plugin.YAMLPlugin
+	}
+	severityPolicy := policy.FromContext(ctx)
+	execStats := plugin.ExecRecorderFromContext(ctx)
+	jobs := make(chan job)
+	var (
+		mu      sync.Mutex
+		matches int
+		wg      sync.WaitGroup
+	)
+	for range defaultStepConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				start := time.Now()
+				result, err := m.stepRunner.Run(ctx, j.plugin, j.target.baseURL, evalContext)
+				execStats.Record(j.plugin, time.Since(start), err == nil && result.Matched, err)
+				if err != nil {
+					logger.Debug().Err(err).Str("plugin", j.plugin.Name).Str("url", j.target.baseURL).Msg("Multi-step plugin failed")
+					continue
+				}
+				if !result.Matched || !result.Output.Vulnerability {
+					continue
+				}
+
+				vuln := stepVulnerability(j.target, result)
+				applyPolicy(severityPolicy, &vuln)
+				if out != nil {
+					out.Diag(output.LevelNormal, fmt.Sprintf("Vulnerability found: %s - %s (Severity: %s)", vuln.Plugin, vuln.Message, vuln.Severity), nil)
+				}
+				mu.Lock()
+				matches++
+				mu.Unlock()
+				outputChan <- engine.ModuleOutput{DataKey: "evaluation.vulnerabilities", Data: vuln}
+			}
+		}()
+	}
+
+feed:
+	for _, target := range targets {
+		for _, p := range stepPlugins {
+			select {
+			case jobs <- job{target: target, plugin: p}:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return matches
+}
+
+// stepVulnerability converts a multi-step plugin match into a
+// vulnerability.
+func stepVulnerability(target nucleiTarget, result *plugin.YAMLMatchResult) VulnerabilityResult {
+	message := result.Output.Message
+	if at := result.Output.Metadata["matched_at"]; at != "" {
+		message += " at " + at
+	}
+
+	vuln := VulnerabilityResult{
+		Target:      target.ip,
+		Port:        target.port,
+		Plugin:      result.Plugin.Name,
+		PluginID:    result.Plugin.ID,
+		PluginType:  string(result.Plugin.Type),
+		Severity:    string(result.Output.Severity),
+		Message:     message,
+		Remediation: result.Output.Remediation,
+		Reference:   result.Output.Reference,
+		Tags:        result.Plugin.Metadata.Tags,
+		Extracted:   result.Extracted,
+		Matched:     true,
+	}
+	addOutputDetails(&vuln, result)
+	return vuln
+}
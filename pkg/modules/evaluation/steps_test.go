@@ -0,0 +1,143 @@
+// pkg/modules/evaluation/steps_test.go
+package evaluation
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/parse"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+func TestPluginEvaluationModule_Execute_StepPlugins(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/setup":
+			http.Redirect(w, r, "/setup/step1", http.StatusFound)
+		case "/setup/step1":
+			_, _ = w.Write([]byte("Installer: create the administrator account"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	module := NewPluginEvaluationModule()
+	require.NoError(t, module.Init("test-instance", nil))
+	installer := &plugin.YAMLPlugin{
+		ID:       "exposed-installer",
+		Name:     "Exposed Installer",
+		Type:     plugin.EvaluationType,
+		Metadata: plugin.PluginMetadata{Severity: plugin.HighSeverity, Tags: []string{"http"}},
+		Steps: []plugin.Step{
+			{
+				Request: &plugin.StepRequest{Path: "/setup"},
+				Match:   &plugin.MatchBlock{Logic: "AND", Rules: []plugin.MatchRule{{Field: "http.status_code", Operator: "equals", Value: 302}}},
+			},
+			{
+				Request: &plugin.StepRequest{Path: "/setup", Redirects: true},
+				Match:   &plugin.MatchBlock{Logic: "AND", Rules: []plugin.MatchRule{{Field: "http.body", Operator: "contains", Value: "Installer"}}},
+			},
+		},
+		Output: plugin.OutputBlock{Vulnerability: true, Message: "Installer reachable"},
+	}
+	module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{plugin.CategoryHTTP: {installer}}
+
+	inputs := map[string]interface{}{
+		"ssh.version": []interface{}{"OpenSSH_9.6"},
+		"service.http.details": []interface{}{
+			parse.HTTPParsedInfo{Target: host, Port: port, Scheme: "http"},
+		},
+	}
+
+	outputChan := make(chan engine.ModuleOutput, 10)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var vulns []VulnerabilityResult
+	for out := range outputChan {
+		vulns = append(vulns, out.Data.(VulnerabilityResult))
+	}
+	require.Len(t, vulns, 1)
+	require.Equal(t, host, vulns[0].Target)
+	require.Equal(t, port, vulns[0].Port)
+	require.Equal(t, "exposed-installer", vulns[0].PluginID)
+	require.Equal(t, "high", vulns[0].Severity)
+	require.Equal(t, "Installer reachable at "+server.URL+"/setup", vulns[0].Message)
+
+	// Offline evaluation sends no requests
+	require.NoError(t, module.Init("test-instance", map[string]interface{}{offlineConfig: true}))
+	module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{plugin.CategoryHTTP: {installer}}
+	outputChan = make(chan engine.ModuleOutput, 10)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+	require.Empty(t, outputChan)
+}
+
+func TestPluginEvaluationModule_Execute_SharesHTTPResponses(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("phpinfo()"))
+	}))
+	defer server.Close()
+
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	probe := func(id string) *plugin.YAMLPlugin {
+		return &plugin.YAMLPlugin{
+			ID:       id,
+			Name:     id,
+			Type:     plugin.EvaluationType,
+			Metadata: plugin.PluginMetadata{Severity: plugin.MediumSeverity, Tags: []string{"http"}},
+			Steps: []plugin.Step{{
+				Request: &plugin.StepRequest{Path: "/info.php"},
+				Match:   &plugin.MatchBlock{Logic: "AND", Rules: []plugin.MatchRule{{Field: "http.body", Operator: "contains", Value: "phpinfo"}}},
+			}},
+			Output: plugin.OutputBlock{Vulnerability: true, Message: "phpinfo exposed"},
+		}
+	}
+	inputs := map[string]interface{}{
+		"ssh.version": []interface{}{"OpenSSH_9.6"},
+		"service.http.details": []interface{}{
+			parse.HTTPParsedInfo{Target: host, Port: port, Scheme: "http"},
+		},
+	}
+	run := func(config map[string]interface{}) int {
+		t.Helper()
+		module := NewPluginEvaluationModule()
+		require.NoError(t, module.Init("test-instance", config))
+		module.plugins = map[plugin.Category][]*plugin.YAMLPlugin{plugin.CategoryHTTP: {probe("phpinfo-a"), probe("phpinfo-b")}}
+
+		outputChan := make(chan engine.ModuleOutput, 10)
+		require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+		close(outputChan)
+		return len(outputChan)
+	}
+
+	require.Equal(t, 2, run(nil))
+	require.Equal(t, int32(1), requests.Load(), "both plugins evaluate one response")
+
+	requests.Store(0)
+	require.Equal(t, 2, run(map[string]interface{}{httpCacheTTLConfig: "0s"}))
+	require.Equal(t, int32(2), requests.Load())
+
+	err = NewPluginEvaluationModule().Init("test-instance", map[string]interface{}{httpCacheTTLConfig: "-1m"})
+	require.ErrorContains(t, err, "invalid http_cache_ttl")
+}
@@ -17,12 +17,15 @@ import (
 const (
 	fingerprintParserModuleID          = "fingerprint-parser-instance"
 	fingerprintParserModuleName        = "fingerprint-parser"
-	fingerprintParserModuleDescription = "Matches service banners with fingerprint catalog entries."
+	fingerprintParserModuleDescription = "Matches service banners and SNMP system descriptions with fingerprint catalog entries."
 	fingerprintParserModuleVersion     = "0.1.0"
 	fingerprintParserModuleAuthor      = "Vulntor Team"
 )
 
-var getResolver = fingerprint.GetFingerprintResolver
+var (
+	getResolver   = fingerprint.GetFingerprintResolver
+	getNormalizer = fingerprint.GetNormalizer
+)
 
 // FingerprintParsedInfo represents structured fingerprint output.
 type FingerprintParsedInfo struct {
@@ -31,6 +34,7 @@ type FingerprintParsedInfo struct {
 	Protocol    string  `json:"protocol,omitempty"`
 	Product     string  `json:"product,omitempty"`
 	Vendor      string  `json:"vendor,omitempty"`
+	Family      string  `json:"family,omitempty"`
 	Version     string  `json:"version,omitempty"`
 	CPE         string  `json:"cpe,omitempty"`
 	Confidence  float64 `json:"confidence"`
@@ -64,6 +68,13 @@ func newFingerprintParserModule() *FingerprintParserModule {
 					IsOptional:   true,
 					Description:  "List of raw TCP banners captured from the service-banner module.",
 				},
+				{
+					Key:          "service.snmp.details",
+					DataTypeName: "scan.SNMPResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   true,
+					Description:  "SNMP agent data; the system description identifies the device.",
+				},
 			},
 			Produces: []engine.DataContractEntry{
 				{
@@ -163,19 +174,10 @@ func (m *FingerprintParserModule) Init(instanceID string, _ map[string]interface
 func (m *FingerprintParserModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
 	logger := log.With().Str("module", m.meta.Name).Str("instance_id", m.meta.ID).Logger()
 
-	raw, ok := inputs["service.banner.tcp"]
-	if !ok {
-		return nil
-	}
-
-	bannerList, listOk := raw.([]interface{})
-	if !listOk {
-		return nil
-	}
-
 	resolver := getResolver()
 	matches := 0
 
+	bannerList, _ := inputs["service.banner.tcp"].([]interface{})
 	for _, item := range bannerList {
 		select {
 		case <-ctx.Done():
@@ -191,10 +193,66 @@ func (m *FingerprintParserModule) Execute(ctx context.Context, inputs map[string
 		matches += m.processBannerCandidates(ctx, banner, resolver, outputChan)
 	}
 
+	snmpList, _ := inputs["service.snmp.details"].([]interface{})
+	for _, item := range snmpList {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		result, castOk := item.(scan.SNMPResult)
+		if !castOk {
+			continue
+		}
+
+		matches += m.processSNMPResult(ctx, result, resolver, outputChan)
+	}
+
 	logger.Info().Int("matches", matches).Msg("Fingerprint parsing completed")
 	return nil
 }
 
+// processSNMPResult matches the system description of an SNMP agent,
+// which names the device's operating system and version.
+func (m *FingerprintParserModule) processSNMPResult(ctx context.Context, snmpResult scan.SNMPResult, resolver fingerprint.Resolver, outputChan chan<- engine.ModuleOutput) int {
+	descr := strings.TrimSpace(snmpResult.SysDescr)
+	if descr == "" || snmpResult.Error != "" {
+		return 0
+	}
+
+	result, err := resolver.Resolve(ctx, fingerprint.Input{
+		Protocol: "snmp",
+		Banner:   descr,
+		Port:     snmpResult.Port,
+	})
+	if err != nil || result.Product == "" {
+		return 0
+	}
+	result = getNormalizer().Normalize(result)
+
+	outputChan <- engine.ModuleOutput{
+		FromModuleName: m.meta.ID,
+		DataKey:        m.meta.Produces[0].Key,
+		Data: FingerprintParsedInfo{
+			Target:      snmpResult.Target,
+			Port:        snmpResult.Port,
+			Protocol:    "snmp",
+			Product:     result.Product,
+			Vendor:      result.Vendor,
+			Family:      result.Family,
+			Version:     result.Version,
+			CPE:         result.CPE,
+			Confidence:  result.Confidence,
+			Description: result.Description,
+			SourceProbe: "snmp-sysdescr",
+		},
+		Timestamp: time.Now(),
+		Target:    snmpResult.Target,
+	}
+	return 1
+}
+
 func (m *FingerprintParserModule) processBannerCandidates(ctx context.Context, banner scan.BannerGrabResult, resolver fingerprint.Resolver, outputChan chan<- engine.ModuleOutput) int {
 	logger := log.With().Str("module", m.meta.Name).Str("instance_id", m.meta.ID).Logger()
 	seenCandidates := make(map[string]struct{}) // Changed: track (response, probeID) to allow TLS and non-TLS versions
@@ -244,6 +302,7 @@ func (m *FingerprintParserModule) processBannerCandidates(ctx context.Context, b
 		if err != nil || result.Product == "" {
 			continue
 		}
+		result = getNormalizer().Normalize(result)
 
 		// Phase 1.8: Emit TLS metadata BEFORE deduplication
 		// This ensures TLS metadata is emitted even if the fingerprint match is duplicate
@@ -268,6 +327,7 @@ func (m *FingerprintParserModule) processBannerCandidates(ctx context.Context, b
 			Protocol:    protocolHint,
 			Product:     result.Product,
 			Vendor:      result.Vendor,
+			Family:      result.Family,
 			Version:     result.Version,
 			CPE:         result.CPE,
 			Confidence:  result.Confidence,
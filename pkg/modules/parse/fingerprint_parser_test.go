@@ -594,3 +594,91 @@ func TestFingerprintParserModule_NoTLSMetadata(t *testing.T) {
 		t.Error("Expected service.fingerprint.details to be emitted")
 	}
 }
+
+func TestFingerprintParserModule_SNMPSysDescr(t *testing.T) {
+	originalGetResolver := getResolver
+	defer func() { getResolver = originalGetResolver }()
+
+	var inputsSeen []fingerprint.Input
+	getResolver = func() fingerprint.Resolver {
+		return mockResolver{resolveFn: func(_ context.Context, input fingerprint.Input) (fingerprint.Result, error) {
+			inputsSeen = append(inputsSeen, input)
+			if strings.Contains(input.Banner, "Cisco IOS") {
+				return fingerprint.Result{Product: "IOS", Vendor: "Cisco", Version: "15.0(2)SE11", Confidence: 0.9}, nil
+			}
+			return fingerprint.Result{}, errors.New("no match")
+		}}
+	}
+
+	m := newFingerprintParserModule()
+	out := make(chan engine.ModuleOutput, 10)
+	err := m.Execute(context.Background(), map[string]interface{}{
+		"service.snmp.details": []interface{}{
+			scan.SNMPResult{Target: "10.0.0.1", Port: 161, SysDescr: "Cisco IOS Software, C2960 Software, Version 15.0(2)SE11"},
+			scan.SNMPResult{Target: "10.0.0.2", Port: 161, SysDescr: "Unknown appliance"},
+			scan.SNMPResult{Target: "10.0.0.3", Port: 161, Error: "snmp: unknown user name"},
+		},
+	}, out)
+	close(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(inputsSeen) != 2 || inputsSeen[0].Protocol != "snmp" || inputsSeen[0].Port != 161 {
+		t.Fatalf("unexpected resolver inputs: %+v", inputsSeen)
+	}
+
+	var results []FingerprintParsedInfo
+	for o := range out {
+		results = append(results, o.Data.(FingerprintParsedInfo))
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	got := results[0]
+	if got.Target != "10.0.0.1" || got.Product != "IOS" || got.Version != "15.0(2)SE11" || got.SourceProbe != "snmp-sysdescr" || got.Protocol != "snmp" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+}
+
+func TestFingerprintParserModule_NormalizesMatches(t *testing.T) {
+	originalGetResolver := getResolver
+	defer func() { getResolver = originalGetResolver }()
+
+	getResolver = func() fingerprint.Resolver {
+		return mockResolver{resolveFn: func(_ context.Context, _ fingerprint.Input) (fingerprint.Result, error) {
+			return fingerprint.Result{
+				Product:    "MariaDB Server",
+				Vendor:     "MariaDB Corporation Ab",
+				Version:    "10.6.12",
+				CPE:        "cpe:2.3:a:mariadb:mariadb_server:10.6.12:*:*:*:*:*:*:*",
+				Confidence: 0.9,
+			}, nil
+		}}
+	}
+
+	m := newFingerprintParserModule()
+	out := make(chan engine.ModuleOutput, 10)
+	err := m.Execute(context.Background(), map[string]interface{}{
+		"service.banner.tcp": []interface{}{
+			scan.BannerGrabResult{IP: "10.0.0.1", Port: 3306, Protocol: "mysql", Banner: "10.6.12-MariaDB"},
+		},
+	}, out)
+	close(out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var results []FingerprintParsedInfo
+	for o := range out {
+		results = append(results, o.Data.(FingerprintParsedInfo))
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	got := results[0]
+	if got.Product != "MariaDB" || got.Vendor != "MariaDB" || got.Family != "mysql" ||
+		got.CPE != "cpe:2.3:a:mariadb:mariadb:10.6.12:*:*:*:*:*:*:*" {
+		t.Errorf("unexpected normalized result: %+v", got)
+	}
+}
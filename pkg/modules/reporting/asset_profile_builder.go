@@ -180,7 +180,14 @@ func (m *AssetProfileBuilderModule) Execute(ctx context.Context, inputs map[stri
 							Summary:      vulnResult.Message,
 							Severity:     engine.FindingSeverity(vulnResult.Severity),
 							Remediation:  vulnResult.Remediation,
-							References:   []string{vulnResult.Reference},
+							References:   vulnResult.References,
+							Evidence:     vulnResult.Evidence,
+
+							OriginalSeverity: engine.FindingSeverity(vulnResult.OriginalSeverity),
+							SeverityReason:   vulnResult.SeverityReason,
+						}
+						if len(finding.References) == 0 && vulnResult.Reference != "" {
+							finding.References = []string{vulnResult.Reference}
 						}
 						targetPortKey := fmt.Sprintf("%s:%d", vulnResult.Target, vulnResult.Port)
 						allVulnerabilities[targetPortKey] = append(allVulnerabilities[targetPortKey], finding)
@@ -337,6 +344,9 @@ func (m *AssetProfileBuilderModule) Execute(ctx context.Context, inputs map[stri
 							if primaryFP.Vendor != "" {
 								portProfile.Service.ParsedAttributes["vendor"] = primaryFP.Vendor
 							}
+							if primaryFP.Family != "" {
+								portProfile.Service.ParsedAttributes["family"] = primaryFP.Family
+							}
 							if primaryFP.Description != "" {
 								portProfile.Service.ParsedAttributes["fingerprint_primary_description"] = primaryFP.Description
 							}
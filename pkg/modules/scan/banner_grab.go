@@ -20,9 +20,13 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cast"
 
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/cdn"
 	"github.com/vulntor/vulntor/pkg/engine" // Your engine/core package
 	"github.com/vulntor/vulntor/pkg/fingerprint"
 	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/netutil"
 	"github.com/vulntor/vulntor/pkg/output"
 )
 
@@ -35,6 +39,7 @@ type BannerGrabConfig struct {
 	Concurrency           int           `mapstructure:"concurrency"`              // Number of concurrent banner grabbing operations
 	SendProbes            bool          `mapstructure:"send_probes"`              // Whether to send basic probes (e.g., HTTP GET)
 	TLSInsecureSkipVerify bool          `mapstructure:"tls_insecure_skip_verify"` // For TLS connections, skip cert verification (not recommended for production)
+	AutoTune              bool          `mapstructure:"auto_tune"`                // Size concurrency by available file descriptors and CPUs
 	// Future: Define specific probes for common ports
 	// HTTPProbes     []string      `mapstructure:"http_probes"`  // e.g., ["GET / HTTP/1.1\r\nHost: {HOST}\r\n\r\n", "HEAD / HTTP/1.0\r\n\r\n"]
 	// GenericProbes  []string      `mapstructure:"generic_probes"`// e.g., ["\r\n\r\n", "HELP\r\n"]
@@ -58,9 +63,26 @@ type commandProbeSpec struct {
 	Protocol        string
 	Commands        []string
 	UseTLS          bool
+	StartTLS        bool
 	SkipInitialRead bool
 }
 
+// startTLSDialog describes how a protocol upgrades a plaintext connection
+// to TLS.
+type startTLSDialog struct {
+	Advertisement string   // Capability that offers the upgrade
+	Commands      []string // Sent after the greeting, in order
+	Ready         string   // Reply line that accepts the upgrade
+}
+
+// startTLSDialogs holds the upgrade dialogs by probe protocol.
+var startTLSDialogs = map[string]startTLSDialog{
+	"smtp": {Advertisement: "STARTTLS", Commands: []string{"EHLO vulntor.local\r\n", "STARTTLS\r\n"}, Ready: "220"},
+	"imap": {Advertisement: "STARTTLS", Commands: []string{"A0 STARTTLS\r\n"}, Ready: "A0 OK"},
+	"pop3": {Advertisement: "STLS", Commands: []string{"STLS\r\n"}, Ready: "+OK"},
+	"ftp":  {Advertisement: "AUTH TLS", Commands: []string{"AUTH TLS\r\n"}, Ready: "234"},
+}
+
 // BannerGrabModule attempts to grab banners from open TCP ports.
 type BannerGrabModule struct {
 	meta   engine.ModuleMetadata
@@ -110,11 +132,13 @@ func newBannerGrabModule() *BannerGrabModule {
 				},
 			},
 			ConfigSchema: map[string]engine.ParameterDefinition{
-				"read_timeout":    {Description: "Timeout for reading banner data from an open port (e.g., '3s').", Type: "duration", Required: false, Default: defaultConfig.ReadTimeout.String()},
-				"connect_timeout": {Description: "Timeout for establishing connection if re-dialing (e.g., '2s').", Type: "duration", Required: false, Default: defaultConfig.ConnectTimeout.String()},
-				"buffer_size":     {Description: "Size of the buffer (in bytes) for reading banner data.", Type: "int", Required: false, Default: defaultConfig.BufferSize},
-				"concurrency":     {Description: "Number of concurrent banner grabbing operations.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
-				"send_probes":     {Description: "Whether to send protocol-specific probes after passive banner capture.", Type: "bool", Required: false, Default: defaultConfig.SendProbes},
+				"read_timeout":             {Description: "Timeout for reading banner data from an open port (e.g., '3s').", Type: "duration", Required: false, Default: defaultConfig.ReadTimeout.String()},
+				"connect_timeout":          {Description: "Timeout for establishing connection if re-dialing (e.g., '2s').", Type: "duration", Required: false, Default: defaultConfig.ConnectTimeout.String()},
+				"buffer_size":              {Description: "Size of the buffer (in bytes) for reading banner data.", Type: "int", Required: false, Default: defaultConfig.BufferSize},
+				"concurrency":              {Description: "Number of concurrent banner grabbing operations.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+				"send_probes":              {Description: "Whether to send protocol-specific probes after passive banner capture.", Type: "bool", Required: false, Default: defaultConfig.SendProbes},
+				"tls_insecure_skip_verify": {Description: "Skip TLS certificate verification when probing TLS services.", Type: "bool", Required: false, Default: defaultConfig.TLSInsecureSkipVerify},
+				"auto_tune":                {Description: "Raise concurrency up to what available file descriptors and CPUs allow.", Type: "bool", Required: false, Default: defaultConfig.AutoTune},
 			},
 			EstimatedCost: 2,
 		},
@@ -159,6 +183,9 @@ func (m *BannerGrabModule) Init(instanceID string, configMap map[string]interfac
 	if tlsInsecureSkipVerify, ok := configMap["tls_insecure_skip_verify"].(bool); ok {
 		cfg.TLSInsecureSkipVerify = cast.ToBool(tlsInsecureSkipVerify)
 	}
+	if autoTuneVal, ok := configMap["auto_tune"]; ok {
+		cfg.AutoTune = cast.ToBool(autoTuneVal)
+	}
 
 	if cfg.ReadTimeout <= 0 {
 		cfg.ReadTimeout = 10 * time.Second
@@ -224,12 +251,13 @@ func (m *BannerGrabModule) Execute(ctx context.Context, inputs map[string]interf
 		return nil
 	}
 
+	concurrency := m.concurrency(len(scanTasks), netutil.DetectResources())
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, m.config.Concurrency)
+	sem := make(chan struct{}, concurrency)
 	var resultsMu sync.Mutex
 	grabbedBanners := make([]BannerGrabResult, 0, len(scanTasks))
 
-	m.logger.Info().Int("tasks", len(scanTasks)).Int("concurrency", m.config.Concurrency).Msg("Starting banner grabbing")
+	m.logger.Info().Int("tasks", len(scanTasks)).Int("concurrency", concurrency).Bool("auto_tune", m.config.AutoTune).Msg("Starting banner grabbing")
 
 	for _, task := range scanTasks {
 		select {
@@ -238,6 +266,11 @@ func (m *BannerGrabModule) Execute(ctx context.Context, inputs map[string]interf
 			goto endLoop
 		default:
 		}
+		// Draining scans grab no further banners; those in flight finish
+		if engine.Draining(ctx) {
+			m.logger.Info().Msg("Scan draining, grabbing no further banners")
+			goto endLoop
+		}
 
 		wg.Add(1)
 		sem <- struct{}{}
@@ -288,6 +321,16 @@ endLoop:
 	return nil
 }
 
+// concurrency returns how many banners are grabbed at once. With
+// auto-tuning, it grows beyond the configured value up to what res allows,
+// but not beyond the number of tasks.
+func (m *BannerGrabModule) concurrency(tasks int, res netutil.SystemResources) int {
+	if !m.config.AutoTune {
+		return m.config.Concurrency
+	}
+	return max(m.config.Concurrency, min(res.MaxConcurrency(), tasks))
+}
+
 // runActiveProbes executes active probes against the target port.
 // Extracted from runProbes to reduce cyclomatic complexity.
 func (m *BannerGrabModule) runActiveProbes(
@@ -303,6 +346,19 @@ func (m *BannerGrabModule) runActiveProbes(
 ) {
 	candidateProbes := catalog.ProbesFor(port, hintAcc.slice())
 
+	// STARTTLS probes run after the others, which find out whether the
+	// service offers the upgrade
+	var upgradeProbes []fingerprint.ProbeSpec
+	plainProbes := make([]fingerprint.ProbeSpec, 0, len(candidateProbes))
+	for _, spec := range candidateProbes {
+		if spec.StartTLS {
+			upgradeProbes = append(upgradeProbes, spec)
+		} else {
+			plainProbes = append(plainProbes, spec)
+		}
+	}
+	candidateProbes = plainProbes
+
 	// Phase 1.5: Probe Fallback for non-standard ports
 	// If no port-specific probes matched AND passive banner is empty, try fallback probes
 	if len(candidateProbes) == 0 && *bestBanner == "" {
@@ -343,6 +399,56 @@ func (m *BannerGrabModule) runActiveProbes(
 			break
 		}
 	}
+
+	m.runStartTLSProbes(ctx, target, port, upgradeProbes, seen, observations, bestBanner, bestIsTLS, lastError)
+}
+
+// runStartTLSProbes executes the STARTTLS probes whose upgrade an earlier
+// response advertised, so that TLS analysis and the capability re-grab
+// see the upgraded connection.
+func (m *BannerGrabModule) runStartTLSProbes(
+	ctx context.Context,
+	target string,
+	port int,
+	probes []fingerprint.ProbeSpec,
+	seen map[string]struct{},
+	observations *[]engine.ProbeObservation,
+	bestBanner *string,
+	bestIsTLS *bool,
+	lastError *string,
+) {
+	for _, spec := range probes {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, exists := seen[spec.ID]; exists || !startTLSAdvertised(spec.Protocol, *observations) {
+			continue
+		}
+		seen[spec.ID] = struct{}{}
+
+		obs := m.executeProbeSpec(ctx, target, port, spec)
+		m.logger.Debug().
+			Str("probe_id", obs.ProbeID).
+			Int("port", port).
+			Bool("upgraded", obs.TLS != nil).
+			Msg("STARTTLS probe completed")
+		m.collectObservation(observations, obs, bestBanner, bestIsTLS, lastError)
+	}
+}
+
+// startTLSAdvertised reports whether any observation advertises the
+// STARTTLS upgrade of protocol.
+func startTLSAdvertised(protocol string, observations []engine.ProbeObservation) bool {
+	dialog, ok := startTLSDialogs[strings.ToLower(protocol)]
+	if !ok {
+		return false
+	}
+	for _, obs := range observations {
+		if strings.Contains(strings.ToUpper(obs.Response), dialog.Advertisement) {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *BannerGrabModule) runProbes(ctx context.Context, target string, port int) BannerGrabResult {
@@ -384,6 +490,12 @@ func (m *BannerGrabModule) runProbes(ctx context.Context, target string, port in
 		result.Error = lastError
 	}
 
+	// Later modules hold back requests to endpoints behind a CDN or WAF
+	guard := cdn.FromContext(ctx)
+	for _, obs := range observations {
+		guard.ObserveResponse(target, obs.Response)
+	}
+
 	return result
 }
 
@@ -438,6 +550,7 @@ func (m *BannerGrabModule) executeProbeSpec(ctx context.Context, host string, po
 		Protocol:        spec.Protocol,
 		Commands:        commands,
 		UseTLS:          spec.UseTLS,
+		StartTLS:        spec.StartTLS,
 		SkipInitialRead: spec.SkipInitialRead,
 	}
 	return m.runCommandProbe(ctx, host, port, cmdSpec)
@@ -449,30 +562,31 @@ func (m *BannerGrabModule) runCommandProbe(ctx context.Context, host string, por
 		Description: spec.Description,
 		Protocol:    spec.Protocol,
 		IsTLS:       spec.UseTLS,
+		StartTLS:    spec.StartTLS,
 	}
 
 	address := net.JoinHostPort(host, strconv.Itoa(port))
-	dialer := &net.Dialer{Timeout: m.config.ConnectTimeout}
 	start := time.Now()
 
-	var (
-		conn    net.Conn
-		err     error
-		tlsInfo *engine.TLSObservation
-	)
-
-	if spec.UseTLS {
-		var tlsConn *tls.Conn
-		tlsConn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+	var tlsInfo *engine.TLSObservation
+	var greeting string
+	conn, err := m.dial(ctx, address)
+	if err == nil && spec.StartTLS {
+		if greeting, err = m.startTLS(ctx, conn, spec.Protocol); err != nil {
+			_ = conn.Close()
+		}
+	}
+	if err == nil && (spec.UseTLS || spec.StartTLS) {
+		tlsConn := tls.Client(conn, &tls.Config{
 			InsecureSkipVerify: m.config.TLSInsecureSkipVerify,
 			ServerName:         host,
 		})
-		if err == nil {
+		if err = m.handshake(ctx, tlsConn); err == nil {
 			tlsInfo = extractTLSObservation(tlsConn.ConnectionState())
 			conn = tlsConn
+		} else {
+			_ = conn.Close()
 		}
-	} else {
-		conn, err = dialer.DialContext(ctx, "tcp", address)
 	}
 
 	if err != nil {
@@ -487,7 +601,10 @@ func (m *BannerGrabModule) runCommandProbe(ctx context.Context, host string, por
 	}
 
 	responses := make([]string, 0, len(spec.Commands)+1)
-	if !spec.SkipInitialRead {
+	if greeting != "" {
+		responses = append(responses, greeting)
+	}
+	if !spec.SkipInitialRead && !spec.StartTLS {
 		initial, readErr := m.readProbeResponse(ctx, conn)
 		if initial != "" {
 			responses = append(responses, initial)
@@ -529,12 +646,67 @@ func (m *BannerGrabModule) runCommandProbe(ctx context.Context, host string, por
 	return obs
 }
 
+// startTLS reads the greeting on conn and runs the STARTTLS dialog of
+// protocol, leaving conn ready for the TLS handshake. It returns the
+// greeting.
+func (m *BannerGrabModule) startTLS(ctx context.Context, conn net.Conn, protocol string) (string, error) {
+	dialog, ok := startTLSDialogs[strings.ToLower(protocol)]
+	if !ok {
+		return "", fmt.Errorf("no STARTTLS dialog for protocol %q", protocol)
+	}
+
+	greeting, err := m.readProbeResponse(ctx, conn)
+	if err != nil {
+		return greeting, err
+	}
+	var reply string
+	for _, cmd := range dialog.Commands {
+		if _, err := conn.Write([]byte(cmd)); err != nil {
+			return greeting, err
+		}
+		if reply, err = m.readProbeResponse(ctx, conn); err != nil {
+			return greeting, err
+		}
+	}
+	for _, line := range strings.Split(reply, "\n") {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), dialog.Ready) {
+			return greeting, nil
+		}
+	}
+	return greeting, fmt.Errorf("STARTTLS refused: %q", strings.TrimSpace(reply))
+}
+
+// dial connects to address, through the scan proxy when one is set.
+func (m *BannerGrabModule) dial(ctx context.Context, address string) (net.Conn, error) {
+	// Blackout windows are waited for outside the connect timeout
+	if err := blackout.FromContext(ctx).Wait(ctx, address); err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: m.config.ConnectTimeout}
+	if m.config.ConnectTimeout > 0 {
+		// The timeout also bounds proxy handshakes
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.ConnectTimeout)
+		defer cancel()
+	}
+	return netproxy.FromContext(ctx).DialContext(ctx, dialer, "tcp", address)
+}
+
+// handshake runs the TLS handshake within the connect timeout.
+func (m *BannerGrabModule) handshake(ctx context.Context, conn *tls.Conn) error {
+	if m.config.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.ConnectTimeout)
+		defer cancel()
+	}
+	return conn.HandshakeContext(ctx)
+}
+
 func (m *BannerGrabModule) grabGenericBanner(ctx context.Context, host string, port int) (string, time.Duration, error) {
 	address := net.JoinHostPort(host, strconv.Itoa(port))
-	dialer := &net.Dialer{Timeout: m.config.ConnectTimeout}
 	start := time.Now()
 
-	conn, err := dialer.DialContext(ctx, "tcp", address)
+	conn, err := m.dial(ctx, address)
 	if err != nil {
 		return "", time.Since(start), err
 	}
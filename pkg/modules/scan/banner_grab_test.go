@@ -2,11 +2,13 @@
 package scan
 
 import (
+	"bufio"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +18,9 @@ import (
 	"time"
 
 	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/netutil"
 )
 
 func mustListenTCP(t *testing.T, addr string) net.Listener {
@@ -136,6 +141,21 @@ func TestBannerGrabModule_Init(t *testing.T) {
 				TLSInsecureSkipVerify: true, // Phase 1.6: Default to true for service detection
 			},
 		},
+		{
+			name: "enable auto tune",
+			config: map[string]interface{}{
+				"auto_tune": true,
+			},
+			expected: BannerGrabConfig{
+				ReadTimeout:           10 * time.Second,
+				ConnectTimeout:        5 * time.Second,
+				BufferSize:            2048,
+				Concurrency:           50,
+				SendProbes:            true,
+				TLSInsecureSkipVerify: true,
+				AutoTune:              true,
+			},
+		},
 		{
 			name: "invalid sanitize values",
 			config: map[string]interface{}{
@@ -191,10 +211,34 @@ func TestBannerGrabModule_Init(t *testing.T) {
 			if module.config.TLSInsecureSkipVerify != tt.expected.TLSInsecureSkipVerify {
 				t.Errorf("Expected TLSInsecureSkipVerify %v, got %v", tt.expected.TLSInsecureSkipVerify, module.config.TLSInsecureSkipVerify)
 			}
+			if module.config.AutoTune != tt.expected.AutoTune {
+				t.Errorf("Expected AutoTune %v, got %v", tt.expected.AutoTune, module.config.AutoTune)
+			}
 		})
 	}
 }
 
+func TestBannerGrabModule_Concurrency(t *testing.T) {
+	t.Parallel()
+
+	res := netutil.SystemResources{OpenFiles: 1024, CPUs: 4} // allows 480
+	module := newBannerGrabModule()
+	if got := module.concurrency(1000, res); got != 50 {
+		t.Errorf("Expected configured concurrency 50, got %d", got)
+	}
+
+	module.config.AutoTune = true
+	if got := module.concurrency(1000, res); got != 480 {
+		t.Errorf("Expected resource limited concurrency 480, got %d", got)
+	}
+	if got := module.concurrency(200, res); got != 200 {
+		t.Errorf("Expected one worker per task (200), got %d", got)
+	}
+	if got := module.concurrency(10, res); got != 50 {
+		t.Errorf("Expected at least the configured concurrency 50, got %d", got)
+	}
+}
+
 func TestRunProbesCollectsHTTPEvidence(t *testing.T) {
 	t.Parallel()
 
@@ -374,6 +418,67 @@ func TestGrabGenericBanner_Timeout(t *testing.T) {
 	}
 }
 
+func TestGrabGenericBanner_ThroughProxy(t *testing.T) {
+	t.Parallel()
+
+	target := mustListenTCP(t, "127.0.0.1:0")
+	defer func() { _ = target.Close() }()
+	go func() {
+		conn, err := target.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+	}()
+
+	// An HTTP CONNECT proxy that tunnels every request to the target
+	proxyLn := mustListenTCP(t, "127.0.0.1:0")
+	defer func() { _ = proxyLn.Close() }()
+	connectHost := make(chan string, 1)
+	go func() {
+		conn, err := proxyLn.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		connectHost <- req.Host
+		upstream, err := net.Dial("tcp", target.Addr().String())
+		if err != nil {
+			return
+		}
+		defer func() { _ = upstream.Close() }()
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		_, _ = io.Copy(conn, upstream)
+	}()
+
+	proxy, err := netproxy.New("http://"+proxyLn.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("netproxy.New: %v", err)
+	}
+	ctx := netproxy.WithContext(context.Background(), proxy)
+
+	module := newBannerGrabModule()
+	module.config.ReadTimeout = 1 * time.Second
+	module.config.ConnectTimeout = 1 * time.Second
+
+	// Only the proxy can resolve the target name
+	banner, _, err := module.grabGenericBanner(ctx, "ssh.scanme.test", 22)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.TrimSpace(banner) != "SSH-2.0-OpenSSH_9.6" {
+		t.Errorf("Expected SSH banner, got: %q", banner)
+	}
+	if host := <-connectHost; host != "ssh.scanme.test:22" {
+		t.Errorf("Expected CONNECT to ssh.scanme.test:22, got: %s", host)
+	}
+}
+
 func TestBannerGrabModule_GrabGenericBanner_Err(t *testing.T) {
 	t.Parallel()
 
@@ -605,3 +710,132 @@ func TestExtractTLSObservation_Phase17(t *testing.T) {
 		})
 	}
 }
+
+// serveSMTP runs a minimal SMTP server on ln that offers STARTTLS when
+// advertise is set.
+func serveSMTP(t *testing.T, ln net.Listener, advertise bool) {
+	t.Helper()
+	// Borrow the test certificate of an httptest TLS server
+	certServer := httptest.NewTLSServer(http.NotFoundHandler())
+	config := certServer.TLS.Clone()
+	certServer.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer func() { _ = conn.Close() }()
+				_, _ = fmt.Fprint(conn, "220 mail.example.com ESMTP Postfix\r\n")
+				upgraded := false
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(cmd, "EHLO") && !upgraded && advertise:
+						_, _ = fmt.Fprint(conn, "250-mail.example.com\r\n250-STARTTLS\r\n250 SIZE 10240000\r\n")
+					case strings.HasPrefix(cmd, "EHLO"):
+						_, _ = fmt.Fprint(conn, "250-mail.example.com\r\n250 AUTH PLAIN LOGIN\r\n")
+					case cmd == "STARTTLS" && !upgraded && advertise:
+						_, _ = fmt.Fprint(conn, "220 2.0.0 Ready to start TLS\r\n")
+						tlsConn := tls.Server(conn, config)
+						if tlsConn.Handshake() != nil {
+							return
+						}
+						conn, reader, upgraded = tlsConn, bufio.NewReader(tlsConn), true
+					default:
+						_, _ = fmt.Fprint(conn, "502 5.5.2 Error: command not recognized\r\n")
+					}
+				}
+			}(conn)
+		}
+	}()
+}
+
+func TestRunActiveProbes_StartTLS(t *testing.T) {
+	t.Parallel()
+
+	catalog := &fingerprint.ProbeCatalog{Groups: []fingerprint.ProbeGroup{{
+		ID:            "smtp",
+		ProtocolHints: []string{"smtp"},
+		Probes: []fingerprint.ProbeSpec{
+			{ID: "smtp-starttls", Protocol: "smtp", StartTLS: true, Payload: "EHLO vulntor.local\r\n"},
+			{ID: "smtp-ehlo", Protocol: "smtp", Payload: "EHLO vulntor.local\r\n"},
+		},
+	}}}
+
+	for _, advertise := range []bool{true, false} {
+		ln := mustListenTCP(t, "127.0.0.1:0")
+		defer func() { _ = ln.Close() }()
+		serveSMTP(t, ln, advertise)
+		port := ln.Addr().(*net.TCPAddr).Port
+
+		module := newBannerGrabModule()
+		module.config.TLSInsecureSkipVerify = true
+		module.config.ConnectTimeout = time.Second
+		module.config.ReadTimeout = 500 * time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		observations := []engine.ProbeObservation{}
+		bestBanner := "220 mail.example.com ESMTP Postfix"
+		bestIsTLS := false
+		lastError := ""
+		hints := newHintAccumulator()
+		hints.add("smtp")
+		module.runActiveProbes(ctx, "127.0.0.1", port, catalog, &observations, &bestBanner, &bestIsTLS, &lastError, &hints)
+
+		if !advertise {
+			if len(observations) != 1 || observations[0].ProbeID != "smtp-ehlo" {
+				t.Fatalf("expected only the EHLO probe without STARTTLS, got %+v", observations)
+			}
+			continue
+		}
+
+		// The STARTTLS probe runs after the early exit of the plain ones
+		if len(observations) != 2 || observations[1].ProbeID != "smtp-starttls" {
+			t.Fatalf("expected EHLO then STARTTLS probes, got %+v", observations)
+		}
+		obs := observations[1]
+		if obs.Error != "" {
+			t.Fatalf("unexpected STARTTLS probe error: %s", obs.Error)
+		}
+		if !obs.StartTLS || obs.IsTLS || obs.TLS == nil || obs.TLS.Version == "" {
+			t.Fatalf("expected TLS metadata from the upgraded connection, got %+v", obs)
+		}
+		if !strings.Contains(obs.Response, "ESMTP Postfix") || !strings.Contains(obs.Response, "AUTH PLAIN LOGIN") {
+			t.Fatalf("expected greeting and post-upgrade capabilities, got %q", obs.Response)
+		}
+		if bestIsTLS {
+			t.Fatalf("STARTTLS must not mark the service as TLS on connect")
+		}
+	}
+}
+
+func TestRunCommandProbe_StartTLSRefused(t *testing.T) {
+	t.Parallel()
+
+	ln := mustListenTCP(t, "127.0.0.1:0")
+	defer func() { _ = ln.Close() }()
+	serveSMTP(t, ln, false)
+
+	module := newBannerGrabModule()
+	module.config.ConnectTimeout = time.Second
+	module.config.ReadTimeout = 500 * time.Millisecond
+
+	obs := module.runCommandProbe(context.Background(), "127.0.0.1", ln.Addr().(*net.TCPAddr).Port, commandProbeSpec{
+		ProbeID:  "smtp-starttls",
+		Protocol: "smtp",
+		Commands: []string{"EHLO vulntor.local\r\n"},
+		StartTLS: true,
+	})
+	if !strings.Contains(obs.Error, "STARTTLS refused") || obs.TLS != nil {
+		t.Fatalf("expected refused upgrade, got %+v", obs)
+	}
+}
@@ -0,0 +1,467 @@
+// pkg/modules/scan/container_exposure.go
+package scan
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	containerExposureModuleID   = "container-exposure-instance"
+	containerExposureModuleName = "container-exposure"
+)
+
+// Kinds of exposed container services.
+const (
+	ContainerExposureRegistry = "docker-registry"
+	ContainerExposureKubelet  = "kubelet"
+)
+
+// maxContainerResponse bounds the catalog and pod list responses read.
+const maxContainerResponse = 8 << 20
+
+// ContainerExposureConfig holds configuration for the container exposure
+// module.
+type ContainerExposureConfig struct {
+	RegistryPorts []int         `mapstructure:"registry_ports"` // Open TCP ports probed for the Docker Registry API
+	KubeletPorts  []int         `mapstructure:"kubelet_ports"`  // Open TCP ports probed for the kubelet API
+	MaxItems      int           `mapstructure:"max_items"`      // Repositories or pods listed per service
+	Timeout       time.Duration `mapstructure:"timeout"`        // Timeout for each request
+	Concurrency   int           `mapstructure:"concurrency"`    // Number of services probed concurrently
+}
+
+// ContainerPod is a pod listed by a kubelet.
+type ContainerPod struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Images    []string `json:"images,omitempty"`
+}
+
+// ContainerExposureResult is a Docker registry or kubelet answering
+// without authentication, with what it listed.
+// This is the 'Data' in ModuleOutput with DataKey "container.exposures".
+type ContainerExposureResult struct {
+	Target       string         `json:"target"`
+	Port         int            `json:"port"`
+	Kind         string         `json:"kind"` // docker-registry or kubelet
+	URL          string         `json:"url"`  // Endpoint that answered without authentication
+	Repositories []string       `json:"repositories,omitempty"`
+	Pods         []ContainerPod `json:"pods,omitempty"`
+	// Truncated is set when more than max_items repositories or pods exist
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Inventory returns the repositories or pods (with their images) one per
+// line.
+func (r ContainerExposureResult) Inventory() string {
+	var sb strings.Builder
+	for _, repo := range r.Repositories {
+		sb.WriteString(repo + "\n")
+	}
+	for _, pod := range r.Pods {
+		sb.WriteString(pod.Namespace + "/" + pod.Name)
+		if len(pod.Images) > 0 {
+			sb.WriteString(": " + strings.Join(pod.Images, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	if r.Truncated {
+		sb.WriteString("...\n")
+	}
+	return sb.String()
+}
+
+// ContainerExposureModule detects Docker registries and kubelets that
+// answer without authentication, and lists their repositories or pods. It
+// only sends read-only GET requests.
+type ContainerExposureModule struct {
+	meta   engine.ModuleMetadata
+	config ContainerExposureConfig
+	logger zerolog.Logger
+}
+
+// newContainerExposureModule is the internal constructor for the
+// ContainerExposureModule.
+func newContainerExposureModule() *ContainerExposureModule {
+	defaultConfig := ContainerExposureConfig{
+		RegistryPorts: []int{5000},
+		KubeletPorts:  []int{10250, 10255},
+		MaxItems:      100,
+		Timeout:       5 * time.Second,
+		Concurrency:   10,
+	}
+
+	return &ContainerExposureModule{
+		meta: engine.ModuleMetadata{
+			ID:          containerExposureModuleID,
+			Name:        containerExposureModuleName,
+			Version:     "0.1.0",
+			Description: "Detects Docker Registry APIs and Kubernetes kubelets answering without authentication, and lists their repositories or pods and images with read-only requests.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "http", "container", "kubernetes", "exposure"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "discovery.open_tcp_ports",
+					DataTypeName: "discovery.TCPPortDiscoveryResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of open TCP ports; the configured registry and kubelet ports are probed.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "container.exposures",
+					DataTypeName: "scan.ContainerExposureResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Registries and kubelets answering without authentication, with their repositories or pods.",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"registry_ports": {Description: "Open TCP ports probed for the Docker Registry API.", Type: "[]int", Required: false, Default: defaultConfig.RegistryPorts},
+				"kubelet_ports":  {Description: "Open TCP ports probed for the kubelet API (10250 authenticated, 10255 read-only).", Type: "[]int", Required: false, Default: defaultConfig.KubeletPorts},
+				"max_items":      {Description: "Repositories or pods listed per service.", Type: "int", Required: false, Default: defaultConfig.MaxItems},
+				"timeout":        {Description: "Timeout for each request (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency":    {Description: "Number of services probed concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 1,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *ContainerExposureModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *ContainerExposureModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	for key, ports := range map[string]*[]int{"registry_ports": &cfg.RegistryPorts, "kubelet_ports": &cfg.KubeletPorts} {
+		v, ok := configMap[key]
+		if !ok {
+			continue
+		}
+		list, err := cast.ToIntSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %v: %w", key, v, err)
+		}
+		for _, port := range list {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("invalid port %d in %s", port, key)
+			}
+		}
+		*ports = list
+	}
+	if v, ok := configMap["max_items"]; ok {
+		cfg.MaxItems = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	if cfg.MaxItems < 1 {
+		cfg.MaxItems = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// containerService is an open port probed for kind.
+type containerService struct {
+	Target string
+	Port   int
+	Kind   string
+}
+
+// Execute probes the registry and kubelet ports found in
+// 'discovery.open_tcp_ports'. Services requiring authentication, or not
+// speaking the API, produce no output.
+func (m *ContainerExposureModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var services []containerService
+	seen := make(map[string]bool)
+	if list, ok := inputs["discovery.open_tcp_ports"].([]interface{}); ok {
+		for _, item := range list {
+			result, ok := item.(discovery.TCPPortDiscoveryResult)
+			if !ok {
+				continue
+			}
+			for _, port := range result.OpenPorts {
+				addr := net.JoinHostPort(result.Target, strconv.Itoa(port))
+				if seen[addr] {
+					continue
+				}
+				switch {
+				case slices.Contains(m.config.RegistryPorts, port):
+					services = append(services, containerService{Target: result.Target, Port: port, Kind: ContainerExposureRegistry})
+				case slices.Contains(m.config.KubeletPorts, port):
+					services = append(services, containerService{Target: result.Target, Port: port, Kind: ContainerExposureKubelet})
+				default:
+					continue
+				}
+				seen[addr] = true
+			}
+		}
+	}
+	if len(services) == 0 {
+		m.logger.Debug().Msg("No open registry or kubelet ports")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(svc containerService) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var (
+				result ContainerExposureResult
+				ok     bool
+			)
+			if svc.Kind == ContainerExposureRegistry {
+				result, ok = m.probeRegistry(ctx, svc)
+			} else {
+				result, ok = m.probeKubelet(ctx, svc)
+			}
+			if ok {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(svc)
+	}
+	wg.Wait()
+	return nil
+}
+
+// probeRegistry lists the repositories of a Docker registry (Registry API
+// v2) that answers without authentication.
+func (m *ContainerExposureModule) probeRegistry(ctx context.Context, svc containerService) (ContainerExposureResult, bool) {
+	client := m.client()
+	base, resp, err := m.get(ctx, client, svc, "/v2/")
+	if err != nil {
+		m.logger.Debug().Str("target", svc.Target).Int("port", svc.Port).Err(err).Msg("No registry API")
+		return ContainerExposureResult{}, false
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Docker-Distribution-Api-Version") == "" {
+		return ContainerExposureResult{}, false
+	}
+
+	result := ContainerExposureResult{Target: svc.Target, Port: svc.Port, Kind: ContainerExposureRegistry, URL: base + "/v2/"}
+	resp, err = m.request(ctx, client, base+"/v2/_catalog?n="+strconv.Itoa(m.config.MaxItems+1))
+	if err != nil {
+		m.logger.Debug().Str("url", result.URL).Err(err).Msg("Registry catalog unavailable")
+		return result, true
+	}
+	defer resp.Body.Close()
+	var catalog struct {
+		Repositories []string `json:"repositories"`
+	}
+	if resp.StatusCode == http.StatusOK && json.NewDecoder(io.LimitReader(resp.Body, maxContainerResponse)).Decode(&catalog) == nil {
+		result.Repositories = catalog.Repositories
+		// A next page means more repositories than requested
+		if len(result.Repositories) > m.config.MaxItems || resp.Header.Get("Link") != "" {
+			result.Truncated = true
+		}
+		if len(result.Repositories) > m.config.MaxItems {
+			result.Repositories = result.Repositories[:m.config.MaxItems]
+		}
+	}
+	return result, true
+}
+
+// probeKubelet lists the pods of a kubelet that answers without
+// authentication: the read-only port, or the authenticated port with
+// anonymous authentication enabled.
+func (m *ContainerExposureModule) probeKubelet(ctx context.Context, svc containerService) (ContainerExposureResult, bool) {
+	base, resp, err := m.get(ctx, m.client(), svc, "/pods")
+	if err != nil {
+		m.logger.Debug().Str("target", svc.Target).Int("port", svc.Port).Err(err).Msg("No kubelet API")
+		return ContainerExposureResult{}, false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ContainerExposureResult{}, false
+	}
+
+	var pods struct {
+		Kind  string `json:"kind"`
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Spec struct {
+				Containers []struct {
+					Image string `json:"image"`
+				} `json:"containers"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxContainerResponse)).Decode(&pods); err != nil || pods.Kind != "PodList" {
+		return ContainerExposureResult{}, false
+	}
+
+	result := ContainerExposureResult{Target: svc.Target, Port: svc.Port, Kind: ContainerExposureKubelet, URL: base + "/pods"}
+	for _, item := range pods.Items {
+		if len(result.Pods) == m.config.MaxItems {
+			result.Truncated = true
+			break
+		}
+		pod := ContainerPod{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name}
+		for _, c := range item.Spec.Containers {
+			if c.Image != "" && !slices.Contains(pod.Images, c.Image) {
+				pod.Images = append(pod.Images, c.Image)
+			}
+		}
+		result.Pods = append(result.Pods, pod)
+	}
+	sort.Slice(result.Pods, func(i, j int) bool {
+		if result.Pods[i].Namespace != result.Pods[j].Namespace {
+			return result.Pods[i].Namespace < result.Pods[j].Namespace
+		}
+		return result.Pods[i].Name < result.Pods[j].Name
+	})
+	return result, true
+}
+
+// client returns the client of the probes; it neither verifies
+// certificates nor follows redirects.
+func (m *ContainerExposureModule) client() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+			},
+			// #nosec G402 -- registries and kubelets mostly serve self-signed certificates
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// get requests path from svc over HTTPS, then, if the TLS handshake
+// fails, over plain HTTP. It returns the base URL that answered.
+func (m *ContainerExposureModule) get(ctx context.Context, client *http.Client, svc containerService, path string) (string, *http.Response, error) {
+	addr := net.JoinHostPort(svc.Target, strconv.Itoa(svc.Port))
+	var errs []error
+	for _, scheme := range []string{"https", "http"} {
+		base := scheme + "://" + addr
+		resp, err := m.request(ctx, client, base+path)
+		if err == nil {
+			return base, resp, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return "", nil, errors.Join(errs...)
+}
+
+// request sends a GET request to url within the configured timeout. The
+// body of the response is read before returning, so the timeout covers it.
+func (m *ContainerExposureModule) request(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxContainerResponse))
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(strings.NewReader(string(body)))
+	return resp, nil
+}
+
+// emit sends the output for result and reports it to the user.
+func (m *ContainerExposureModule) emit(ctx context.Context, out output.Output, result ContainerExposureResult, outputChan chan<- engine.ModuleOutput) {
+	count, noun := len(result.Repositories), "repositories"
+	if result.Kind == ContainerExposureKubelet {
+		count, noun = len(result.Pods), "pods"
+	}
+	if out != nil {
+		out.Diag(output.LevelNormal, fmt.Sprintf("Unauthenticated %s: %s - %d %s listed", result.Kind, result.URL, count, noun), nil)
+	}
+	m.logger.Info().Str("url", result.URL).Str("kind", result.Kind).Int(noun, count).Msg("Container service answers without authentication")
+
+	o := engine.ModuleOutput{
+		FromModuleName: m.meta.ID,
+		DataKey:        "container.exposures",
+		Data:           result,
+		Timestamp:      time.Now(),
+		Target:         result.Target,
+	}
+	select {
+	case outputChan <- o:
+	case <-ctx.Done():
+	}
+}
+
+// ContainerExposureModuleFactory creates a new ContainerExposureModule
+// instance.
+func ContainerExposureModuleFactory() engine.Module {
+	return newContainerExposureModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(containerExposureModuleName, ContainerExposureModuleFactory)
+}
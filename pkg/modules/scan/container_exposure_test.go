@@ -0,0 +1,126 @@
+// pkg/modules/scan/container_exposure_test.go
+package scan
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+)
+
+func serverPort(t *testing.T, server *httptest.Server) (string, int) {
+	t.Helper()
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func TestContainerExposureModule_Execute(t *testing.T) {
+	var methods []string
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
+		switch r.URL.Path {
+		case "/v2/":
+			_, _ = w.Write([]byte("{}"))
+		case "/v2/_catalog":
+			require.Equal(t, "3", r.URL.Query().Get("n"))
+			_, _ = w.Write([]byte(`{"repositories": ["app/api", "app/web", "base/alpine"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer registry.Close()
+
+	kubelet := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/pods", r.URL.Path)
+		_, _ = w.Write([]byte(`{"kind": "PodList", "items": [
+		  {"metadata": {"name": "web-1", "namespace": "shop"},
+		   "spec": {"containers": [{"image": "nginx:1.27"}, {"image": "envoy:1.30"}, {"image": "nginx:1.27"}]}},
+		  {"metadata": {"name": "coredns", "namespace": "kube-system"},
+		   "spec": {"containers": [{"image": "coredns:1.11"}]}}
+		]}`))
+	}))
+	defer kubelet.Close()
+
+	// Registries requiring authentication produce no output
+	private := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Docker-Distribution-Api-Version", "registry/2.0")
+		w.Header().Set("WWW-Authenticate", `Bearer realm="https://auth.example.com/token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer private.Close()
+
+	host, registryPort := serverPort(t, registry)
+	_, kubeletPort := serverPort(t, kubelet)
+	_, privatePort := serverPort(t, private)
+
+	module := newContainerExposureModule()
+	require.NoError(t, module.Init("container-exposure", map[string]interface{}{
+		"registry_ports": []int{registryPort, privatePort},
+		"kubelet_ports":  []int{kubeletPort},
+		"max_items":      2,
+	}))
+
+	inputs := map[string]interface{}{
+		"discovery.open_tcp_ports": []interface{}{
+			discovery.TCPPortDiscoveryResult{Target: host, OpenPorts: []int{22, registryPort, kubeletPort, privatePort}},
+		},
+	}
+	outputChan := make(chan engine.ModuleOutput, 8)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var results []ContainerExposureResult
+	for o := range outputChan {
+		require.Equal(t, "container.exposures", o.DataKey)
+		results = append(results, o.Data.(ContainerExposureResult))
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Kind < results[j].Kind })
+	require.Len(t, results, 2)
+
+	require.Equal(t, ContainerExposureResult{
+		Target:       host,
+		Port:         registryPort,
+		Kind:         ContainerExposureRegistry,
+		URL:          "http://" + net.JoinHostPort(host, strconv.Itoa(registryPort)) + "/v2/",
+		Repositories: []string{"app/api", "app/web"},
+		Truncated:    true,
+	}, results[0])
+	require.Equal(t, "app/api\napp/web\n...\n", results[0].Inventory())
+	for _, method := range methods {
+		require.Equal(t, http.MethodGet, method)
+	}
+
+	require.Equal(t, ContainerExposureResult{
+		Target: host,
+		Port:   kubeletPort,
+		Kind:   ContainerExposureKubelet,
+		URL:    "https://" + net.JoinHostPort(host, strconv.Itoa(kubeletPort)) + "/pods",
+		Pods: []ContainerPod{
+			{Namespace: "kube-system", Name: "coredns", Images: []string{"coredns:1.11"}},
+			{Namespace: "shop", Name: "web-1", Images: []string{"nginx:1.27", "envoy:1.30"}},
+		},
+	}, results[1])
+	require.Equal(t, "kube-system/coredns: coredns:1.11\nshop/web-1: nginx:1.27, envoy:1.30\n", results[1].Inventory())
+}
+
+func TestContainerExposureModule_Init(t *testing.T) {
+	module := newContainerExposureModule()
+	require.NoError(t, module.Init("container-exposure", map[string]interface{}{}))
+	require.Equal(t, []int{5000}, module.config.RegistryPorts)
+	require.Equal(t, []int{10250, 10255}, module.config.KubeletPorts)
+
+	require.Error(t, module.Init("container-exposure", map[string]interface{}{"kubelet_ports": []int{70000}}))
+	require.Error(t, module.Init("container-exposure", map[string]interface{}{"timeout": "soon"}))
+}
@@ -0,0 +1,383 @@
+// pkg/modules/scan/default_creds.go
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/spray"
+)
+
+const (
+	defaultCredsModuleID   = "default-creds-instance"
+	defaultCredsModuleName = "default-creds"
+)
+
+// DefaultCredsConfig holds configuration for the default-credentials module.
+// Credential testing sends real login attempts, so it stays off unless
+// enabled, and the limits keep it below common lockout thresholds.
+type DefaultCredsConfig struct {
+	Enabled            bool          `mapstructure:"enabled"`               // Send login attempts at all
+	Rate               float64       `mapstructure:"rate"`                  // Attempts per second over all hosts
+	HostDelay          time.Duration `mapstructure:"host_delay"`            // Pause between attempts against one host
+	MaxAttemptsPerHost int           `mapstructure:"max_attempts_per_host"` // Attempts against one host over all services
+	MaxAttemptsPerUser int           `mapstructure:"max_attempts_per_user"` // Attempts per username on one host
+	MaxErrors          int           `mapstructure:"max_errors"`            // Consecutive failed connections before giving up a service
+	Timeout            time.Duration `mapstructure:"timeout"`               // Timeout for each attempt
+	Concurrency        int           `mapstructure:"concurrency"`           // Number of hosts tested concurrently
+}
+
+// DefaultCredentialResult is a default credential a service accepted.
+// This is the 'Data' in ModuleOutput with DataKey "auth.default_credentials".
+type DefaultCredentialResult struct {
+	Target   string `json:"target"`
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Plugin   string `json:"plugin"` // ID, or name, of the plugin declaring the credential
+	Username string `json:"username,omitempty"`
+	Password string `json:"password"`
+	Attempts int    `json:"attempts"` // Attempts made against the service, including this one
+}
+
+// credentialPlugin is a plugin with a valid default_credentials block.
+type credentialPlugin struct {
+	key   string
+	block *plugin.CredentialsBlock
+}
+
+// DefaultCredsModule tests the default credentials declared by plugins
+// against the services they apply to.
+type DefaultCredsModule struct {
+	meta    engine.ModuleMetadata
+	config  DefaultCredsConfig
+	logger  zerolog.Logger
+	plugins []credentialPlugin
+}
+
+// newDefaultCredsModule is the internal constructor for the DefaultCredsModule.
+func newDefaultCredsModule() *DefaultCredsModule {
+	defaultConfig := DefaultCredsConfig{
+		Enabled:            false,
+		Rate:               5,
+		HostDelay:          time.Second,
+		MaxAttemptsPerHost: 30,
+		MaxAttemptsPerUser: 3,
+		MaxErrors:          3,
+		Timeout:            spray.DefaultTimeout,
+		Concurrency:        5,
+	}
+
+	return &DefaultCredsModule{
+		meta: engine.ModuleMetadata{
+			ID:          defaultCredsModuleID,
+			Name:        defaultCredsModuleName,
+			Version:     "0.1.0",
+			Description: "Tests the default credentials declared by plugins over SSH, FTP, Telnet, HTTP Basic, MySQL and Redis, under strict rate and lockout limits. Disabled unless enabled.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "intrusive", "default-credentials", "brute-force"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "discovery.open_tcp_ports",
+					DataTypeName: "discovery.TCPPortDiscoveryResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of open TCP ports; plugins declare the ports their credentials are tried on.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "auth.default_credentials",
+					DataTypeName: "scan.DefaultCredentialResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Default credentials accepted by a service.",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"enabled":               {Description: "Send login attempts with default credentials.", Type: "bool", Required: false, Default: defaultConfig.Enabled},
+				"rate":                  {Description: "Login attempts per second over all hosts.", Type: "float", Required: false, Default: defaultConfig.Rate},
+				"host_delay":            {Description: "Pause between attempts against the same host (e.g., '1s').", Type: "duration", Required: false, Default: defaultConfig.HostDelay.String()},
+				"max_attempts_per_host": {Description: "Login attempts against one host over all its services.", Type: "int", Required: false, Default: defaultConfig.MaxAttemptsPerHost},
+				"max_attempts_per_user": {Description: "Login attempts per username on one host; keep it below the lockout threshold.", Type: "int", Required: false, Default: defaultConfig.MaxAttemptsPerUser},
+				"max_errors":            {Description: "Consecutive failed connections before a service is given up.", Type: "int", Required: false, Default: defaultConfig.MaxErrors},
+				"timeout":               {Description: "Timeout for each login attempt (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency":           {Description: "Number of hosts tested concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 4,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *DefaultCredsModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map and loads
+// the default credentials declared by the embedded plugins.
+func (m *DefaultCredsModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["enabled"]; ok {
+		cfg.Enabled = cast.ToBool(v)
+	}
+	if v, ok := configMap["rate"]; ok {
+		cfg.Rate = cast.ToFloat64(v)
+	}
+	if v, ok := configMap["host_delay"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid host_delay %v: %w", v, err)
+		}
+		cfg.HostDelay = dur
+	}
+	if v, ok := configMap["max_attempts_per_host"]; ok {
+		cfg.MaxAttemptsPerHost = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_attempts_per_user"]; ok {
+		cfg.MaxAttemptsPerUser = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_errors"]; ok {
+		cfg.MaxErrors = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	if cfg.Rate < 0 || cfg.HostDelay < 0 || cfg.MaxAttemptsPerHost < 0 || cfg.MaxAttemptsPerUser < 0 || cfg.MaxErrors < 0 {
+		return fmt.Errorf("rate and attempt limits cannot be negative")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = spray.DefaultTimeout
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	m.config = cfg
+
+	if cfg.Enabled {
+		plugins, err := plugin.LoadAllEmbeddedPlugins()
+		if err != nil {
+			return fmt.Errorf("failed to load embedded plugins: %w", err)
+		}
+		m.plugins = m.credentialPlugins(plugins)
+	}
+
+	m.logger.Debug().Interface("final_config", m.config).Int("plugins", len(m.plugins)).Msg("Module initialized.")
+	return nil
+}
+
+// credentialPlugins returns the plugins whose default_credentials block
+// is valid. Invalid blocks are logged and skipped.
+func (m *DefaultCredsModule) credentialPlugins(plugins []*plugin.YAMLPlugin) []credentialPlugin {
+	var out []credentialPlugin
+	for _, p := range plugins {
+		if p.DefaultCredentials == nil {
+			continue
+		}
+		key := p.ID
+		if key == "" {
+			key = p.Name
+		}
+		if err := p.DefaultCredentials.Validate(); err != nil {
+			m.logger.Warn().Str("plugin", key).Err(err).Msg("Skipping invalid default_credentials")
+			continue
+		}
+		out = append(out, credentialPlugin{key: key, block: p.DefaultCredentials})
+	}
+	return out
+}
+
+// Execute tests the default credentials against the matching ports in
+// 'discovery.open_tcp_ports'. Hosts are tested concurrently; the services
+// of one host are tested one attempt at a time.
+func (m *DefaultCredsModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	if !m.config.Enabled {
+		m.logger.Debug().Msg("Default-credential testing is disabled")
+		return nil
+	}
+
+	jobs, hosts := m.jobs(inputs)
+	// Login attempts are intrusive
+	guard := cdn.FromContext(ctx)
+	hosts = slices.DeleteFunc(hosts, func(host string) bool { return !guard.Allow(host, true) })
+	if len(hosts) == 0 {
+		m.logger.Debug().Msg("No services to test default credentials against")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	if out != nil {
+		out.Diag(output.LevelNormal, fmt.Sprintf("Testing default credentials against %d host(s)", len(hosts)), nil)
+	}
+
+	sprayer := spray.New(spray.Limits{
+		Rate:               m.config.Rate,
+		HostDelay:          m.config.HostDelay,
+		MaxAttemptsPerHost: m.config.MaxAttemptsPerHost,
+		MaxAttemptsPerUser: m.config.MaxAttemptsPerUser,
+		MaxErrors:          m.config.MaxErrors,
+	}, spray.Options{
+		Timeout: m.config.Timeout,
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+		},
+	})
+
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			for _, outcome := range sprayer.Run(ctx, jobs[host]) {
+				m.report(ctx, out, outcome, outputChan)
+			}
+		}(host)
+	}
+	wg.Wait()
+	return nil
+}
+
+// jobs groups the credentials to test by host, in the order the hosts
+// were discovered.
+func (m *DefaultCredsModule) jobs(inputs map[string]interface{}) (map[string][]spray.Job, []string) {
+	jobs := make(map[string][]spray.Job)
+	var hosts []string
+	seen := make(map[string]bool)
+
+	list, _ := inputs["discovery.open_tcp_ports"].([]interface{})
+	for _, item := range list {
+		result, ok := item.(discovery.TCPPortDiscoveryResult)
+		if !ok {
+			continue
+		}
+		for _, port := range result.OpenPorts {
+			for _, p := range m.plugins {
+				if !slices.Contains(p.block.Ports, port) {
+					continue
+				}
+				key := fmt.Sprintf("%s:%d:%s", result.Target, port, p.key)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				pairs := p.block.Pairs()
+				creds := make([]spray.Credential, 0, len(pairs))
+				for _, c := range pairs {
+					creds = append(creds, spray.Credential{Username: c.Username, Password: c.Password})
+				}
+				if _, ok := jobs[result.Target]; !ok {
+					hosts = append(hosts, result.Target)
+				}
+				jobs[result.Target] = append(jobs[result.Target], spray.Job{
+					Service: spray.Service{
+						Host:     result.Target,
+						Port:     port,
+						Protocol: p.block.Protocol,
+						Path:     p.block.Path,
+						TLS:      p.block.TLS,
+					},
+					Credentials: creds,
+					Source:      p.key,
+				})
+			}
+		}
+	}
+	return jobs, hosts
+}
+
+// report logs the outcome of a job and sends accepted credentials.
+func (m *DefaultCredsModule) report(ctx context.Context, out output.Output, outcome spray.Outcome, outputChan chan<- engine.ModuleOutput) {
+	svc := outcome.Service
+	logger := m.logger.With().Str("target", svc.Addr()).Str("protocol", svc.Protocol).Str("plugin", outcome.Source).
+		Int("attempts", outcome.Attempts).Logger()
+
+	switch {
+	case outcome.Success:
+		logger.Warn().Str("username", outcome.Credential.String()).Msg("Default credentials accepted")
+		if out != nil {
+			out.Diag(output.LevelNormal, fmt.Sprintf("Default credentials accepted: %s (%s) - %s", svc.Addr(), svc.Protocol, outcome.Credential), nil)
+		}
+	case outcome.Stopped == spray.StopLockout:
+		logger.Warn().Err(outcome.Err).Msg("Lockout or throttling reported, stopped testing the host")
+		if out != nil && outcome.Err != nil {
+			out.Diag(output.LevelNormal, fmt.Sprintf("Default-credential testing stopped on %s: lockout or throttling reported", svc.Host), nil)
+		}
+	case outcome.Stopped == spray.StopNotApplicable:
+		logger.Debug().Err(outcome.Err).Msg("Service not tested")
+	case outcome.Stopped != "":
+		logger.Info().Str("reason", string(outcome.Stopped)).Err(outcome.Err).Int("skipped", outcome.Skipped).Msg("Default-credential testing stopped early")
+		if out != nil && outcome.Stopped != spray.StopCanceled {
+			out.Diag(output.LevelVerbose, fmt.Sprintf("Default-credential testing stopped on %s (%s): %s", svc.Addr(), svc.Protocol, outcome.Stopped), nil)
+		}
+	default:
+		logger.Debug().Int("skipped", outcome.Skipped).Msg("No default credentials accepted")
+	}
+	if !outcome.Success {
+		return
+	}
+
+	result := DefaultCredentialResult{
+		Target:   svc.Host,
+		Port:     svc.Port,
+		Protocol: svc.Protocol,
+		Plugin:   outcome.Source,
+		Username: outcome.Credential.Username,
+		Password: outcome.Credential.Password,
+		Attempts: outcome.Attempts,
+	}
+	select {
+	case outputChan <- engine.ModuleOutput{
+		FromModuleName: m.meta.ID,
+		DataKey:        "auth.default_credentials",
+		Data:           result,
+		Timestamp:      time.Now(),
+		Target:         svc.Host,
+	}:
+	case <-ctx.Done():
+	}
+}
+
+// DefaultCredsModuleFactory creates a new DefaultCredsModule instance.
+func DefaultCredsModuleFactory() engine.Module {
+	return newDefaultCredsModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(defaultCredsModuleName, DefaultCredsModuleFactory)
+}
@@ -0,0 +1,164 @@
+// pkg/modules/scan/default_creds_test.go
+package scan
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+// startFTPServer accepts the login admin/admin and records the users
+// tried.
+func startFTPServer(t *testing.T) (int, func() []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	var mu sync.Mutex
+	var tried []string
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				r := bufio.NewReader(conn)
+				_, _ = fmt.Fprint(conn, "220 FTP server ready\r\n")
+				var user string
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+					switch cmd {
+					case "USER":
+						user = arg
+						_, _ = fmt.Fprint(conn, "331 Password required\r\n")
+					case "PASS":
+						mu.Lock()
+						tried = append(tried, user)
+						mu.Unlock()
+						if user == "admin" && arg == "admin" {
+							_, _ = fmt.Fprint(conn, "230 Logged in\r\n")
+						} else {
+							_, _ = fmt.Fprint(conn, "530 Login incorrect\r\n")
+						}
+					default:
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), tried...)
+	}
+}
+
+func ftpCredentialPlugin(port int, pairs ...string) *plugin.YAMLPlugin {
+	block := &plugin.CredentialsBlock{Protocol: "ftp", Ports: []int{port}}
+	for i := 0; i < len(pairs); i += 2 {
+		block.Credentials = append(block.Credentials, plugin.CredentialPair{Username: pairs[i], Password: pairs[i+1]})
+	}
+	return &plugin.YAMLPlugin{ID: "ftp-default-credentials", Name: "FTP Default Credentials", DefaultCredentials: block}
+}
+
+func runDefaultCredsModule(t *testing.T, config map[string]interface{}, plugins []*plugin.YAMLPlugin, ports map[string][]int) []engine.ModuleOutput {
+	t.Helper()
+	module := newDefaultCredsModule()
+	require.NoError(t, module.Init("default_creds", config))
+	module.plugins = module.credentialPlugins(plugins)
+
+	var open []interface{}
+	for target, p := range ports {
+		open = append(open, discovery.TCPPortDiscoveryResult{Target: target, OpenPorts: p})
+	}
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"discovery.open_tcp_ports": open}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func TestDefaultCredsModule_Execute(t *testing.T) {
+	port, tried := startFTPServer(t)
+	config := map[string]interface{}{"enabled": true, "rate": 0, "host_delay": "0s", "timeout": "2s"}
+	plugins := []*plugin.YAMLPlugin{
+		ftpCredentialPlugin(port, "anonymous", "anonymous", "admin", "admin", "ftp", "ftp"),
+		// Invalid blocks are skipped
+		{Name: "Broken", DefaultCredentials: &plugin.CredentialsBlock{Protocol: "rdp", Ports: []int{port}}},
+	}
+
+	outputs := runDefaultCredsModule(t, config, plugins, map[string][]int{"127.0.0.1": {port, 1}})
+	require.Len(t, outputs, 1)
+	require.Equal(t, "auth.default_credentials", outputs[0].DataKey)
+	require.Equal(t, "127.0.0.1", outputs[0].Target)
+	require.Equal(t, DefaultCredentialResult{
+		Target:   "127.0.0.1",
+		Port:     port,
+		Protocol: "ftp",
+		Plugin:   "ftp-default-credentials",
+		Username: "admin",
+		Password: "admin",
+		Attempts: 2,
+	}, outputs[0].Data)
+	require.Equal(t, []string{"anonymous", "admin"}, tried())
+}
+
+func TestDefaultCredsModule_Execute_Limits(t *testing.T) {
+	port, tried := startFTPServer(t)
+	config := map[string]interface{}{"enabled": true, "rate": 0, "host_delay": "0s", "max_attempts_per_user": 1, "timeout": "2s"}
+	plugins := []*plugin.YAMLPlugin{ftpCredentialPlugin(port, "admin", "password", "admin", "admin", "root", "root")}
+
+	outputs := runDefaultCredsModule(t, config, plugins, map[string][]int{"127.0.0.1": {port}})
+	require.Empty(t, outputs)
+	require.Equal(t, []string{"admin", "root"}, tried())
+}
+
+func TestDefaultCredsModule_Execute_Disabled(t *testing.T) {
+	port, tried := startFTPServer(t)
+	plugins := []*plugin.YAMLPlugin{ftpCredentialPlugin(port, "admin", "admin")}
+
+	outputs := runDefaultCredsModule(t, map[string]interface{}{}, plugins, map[string][]int{"127.0.0.1": {port}})
+	require.Empty(t, outputs)
+	require.Empty(t, tried())
+}
+
+func TestDefaultCredsModule_Init(t *testing.T) {
+	module := newDefaultCredsModule()
+	require.NoError(t, module.Init("default_creds", map[string]interface{}{}))
+	require.False(t, module.config.Enabled)
+	require.Empty(t, module.plugins)
+	require.Equal(t, 3, module.config.MaxAttemptsPerUser)
+
+	// Enabling it loads the credentials declared by the embedded plugins
+	module = newDefaultCredsModule()
+	require.NoError(t, module.Init("default_creds", map[string]interface{}{"enabled": true, "concurrency": 0}))
+	require.NotEmpty(t, module.plugins)
+	require.Equal(t, 1, module.config.Concurrency)
+
+	require.Error(t, newDefaultCredsModule().Init("default_creds", map[string]interface{}{"max_attempts_per_host": -1}))
+	require.Error(t, newDefaultCredsModule().Init("default_creds", map[string]interface{}{"host_delay": "soon"}))
+}
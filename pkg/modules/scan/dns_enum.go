@@ -0,0 +1,512 @@
+// pkg/modules/scan/dns_enum.go
+package scan
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/dns"
+	"github.com/vulntor/vulntor/pkg/egress"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	dnsEnumModuleID   = "dns-enum-instance"
+	dnsEnumModuleName = "dns-enum"
+
+	// dnsWildcardProbes is the number of random names resolved to detect
+	// a wildcard record; all of them must resolve.
+	dnsWildcardProbes = 2
+)
+
+// DNSEnumConfig holds configuration for the DNS enumeration module.
+type DNSEnumConfig struct {
+	Resolver    string        `mapstructure:"resolver"`    // DNS server for lookups (host:port); the system resolver when empty
+	Port        int           `mapstructure:"port"`        // Port zone transfers are requested on
+	Timeout     time.Duration `mapstructure:"timeout"`     // Timeout for each lookup and zone transfer
+	Concurrency int           `mapstructure:"concurrency"` // Number of host names enumerated concurrently
+	MaxRecords  int           `mapstructure:"max_records"` // Records kept from a zone transfer
+}
+
+// ZoneTransferResult is the answer of one name server to a zone transfer.
+type ZoneTransferResult struct {
+	Server    string `json:"server"`  // Name server, e.g. 'ns1.example.com'
+	Address   string `json:"address"` // Address the transfer was requested from
+	Allowed   bool   `json:"allowed"`
+	Records   int    `json:"records,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DNSResult holds what was learned about a host name target.
+// This is the 'Data' in ModuleOutput with DataKey "dns.details".
+type DNSResult struct {
+	Target      string   `json:"target"`         // Host name from the scan targets
+	Zone        string   `json:"zone,omitempty"` // Closest enclosing zone with name servers
+	Addresses   []string `json:"addresses,omitempty"`
+	NameServers []string `json:"name_servers,omitempty"`
+	MX          []string `json:"mx,omitempty"` // e.g. '10 mail.example.com'
+	TXT         []string `json:"txt,omitempty"`
+	// Wildcard is set when random names in the zone resolve, to
+	// WildcardAddresses.
+	Wildcard          bool                 `json:"wildcard"`
+	WildcardAddresses []string             `json:"wildcard_addresses,omitempty"`
+	ZoneTransfers     []ZoneTransferResult `json:"zone_transfers,omitempty"`
+	// Records holds the zone from the first name server that allowed a
+	// transfer.
+	Records []dns.Record `json:"records,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// TransferAllowed reports whether any name server transferred the zone.
+func (r DNSResult) TransferAllowed() bool {
+	for _, t := range r.ZoneTransfers {
+		if t.Allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// dnsResolver is the part of net.Resolver the module uses.
+type dnsResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// DNSEnumModule enumerates the DNS records of host name targets, detects
+// wildcard records and requests zone transfers from the zone's name
+// servers.
+type DNSEnumModule struct {
+	meta   engine.ModuleMetadata
+	config DNSEnumConfig
+	logger zerolog.Logger
+
+	resolver dnsResolver
+	transfer func(ctx context.Context, addr, zone string, opts dns.Options) (*dns.Zone, error)
+}
+
+// newDNSEnumModule is the internal constructor for the DNSEnumModule.
+func newDNSEnumModule() *DNSEnumModule {
+	defaultConfig := DNSEnumConfig{
+		Port:        53,
+		Timeout:     5 * time.Second,
+		Concurrency: 5,
+		MaxRecords:  1000,
+	}
+
+	return &DNSEnumModule{
+		meta: engine.ModuleMetadata{
+			ID:          dnsEnumModuleID,
+			Name:        dnsEnumModuleName,
+			Version:     "0.1.0",
+			Description: "Resolves the NS, MX and TXT records of host name targets, detects wildcard records and attempts zone transfers (AXFR) from the zone's name servers.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "dns", "recon"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "config.targets",
+					DataTypeName: "[]string",
+					Cardinality:  engine.CardinalitySingle,
+					IsOptional:   false,
+					Description:  "Scan targets; host names are enumerated, IP addresses and ranges are ignored.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "dns.details",
+					DataTypeName: "scan.DNSResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Records, wildcard and zone transfer results for each host name.",
+				},
+				{
+					Key:          "dns.zone_transfer",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Whether a name server allowed a zone transfer, for plugin evaluation.",
+				},
+				{
+					Key:          "dns.zone_transfer_servers",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Name servers that allowed a zone transfer, one per line, for plugin evaluation.",
+				},
+				{
+					Key:          "dns.wildcard",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Whether random names in the zone resolve, for plugin evaluation.",
+				},
+				{
+					Key:          "dns.ns",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Name servers of the zone, one per line, for plugin evaluation.",
+				},
+				{
+					Key:          "dns.mx",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Mail exchangers, one 'preference host' per line, for plugin evaluation.",
+				},
+				{
+					Key:          "dns.txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "TXT records, one per line, for plugin evaluation (e.g., 'v=spf1 -all').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"resolver":    {Description: "DNS server used for lookups (host:port); the system resolver when empty.", Type: "string", Required: false, Default: defaultConfig.Resolver},
+				"port":        {Description: "Port zone transfers are requested on.", Type: "int", Required: false, Default: defaultConfig.Port},
+				"timeout":     {Description: "Timeout for each lookup and zone transfer (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency": {Description: "Number of host names enumerated concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+				"max_records": {Description: "Records kept from a zone transfer.", Type: "int", Required: false, Default: defaultConfig.MaxRecords},
+			},
+			EstimatedCost: 1,
+		},
+		config:   defaultConfig,
+		resolver: net.DefaultResolver,
+		transfer: dns.Transfer,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *DNSEnumModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *DNSEnumModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["resolver"]; ok {
+		cfg.Resolver = strings.TrimSpace(cast.ToString(v))
+	}
+	if v, ok := configMap["port"]; ok {
+		cfg.Port = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_records"]; ok {
+		cfg.MaxRecords = cast.ToInt(v)
+	}
+
+	if cfg.Port <= 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid port %d", cfg.Port)
+	}
+	if cfg.Resolver != "" {
+		if _, _, err := net.SplitHostPort(cfg.Resolver); err != nil {
+			cfg.Resolver = net.JoinHostPort(cfg.Resolver, "53")
+		}
+		resolver := cfg.Resolver
+		m.resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return capture.Dial(blackout.Dial(fdbudget.Dial(egress.Dial(&net.Dialer{}))))(ctx, network, resolver)
+			},
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxRecords < 1 {
+		cfg.MaxRecords = dns.DefaultMaxRecords
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute enumerates the host names in 'config.targets'.
+func (m *DNSEnumModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var targets []string
+	switch v := inputs["config.targets"].(type) {
+	case []string:
+		targets = v
+	case []interface{}:
+		targets = cast.ToStringSlice(v)
+	}
+	hosts := hostNameTargets(targets)
+	if len(hosts) == 0 {
+		m.logger.Debug().Msg("No host name targets")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, host := range hosts {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			m.emit(ctx, out, m.enumerate(ctx, host), outputChan)
+		}(host)
+	}
+	wg.Wait()
+	return nil
+}
+
+// hostNameTargets returns the targets that are host names, lowercased
+// and without duplicates.
+func hostNameTargets(targets []string) []string {
+	var hosts []string
+	for _, t := range targets {
+		t = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(t)), ".")
+		if t == "" || strings.Contains(t, "/") || strings.Contains(t, ":") {
+			continue
+		}
+		if _, err := netip.ParseAddr(t); err == nil {
+			continue
+		}
+		// IP ranges such as 192.0.2.1-20
+		if start, _, ok := strings.Cut(t, "-"); ok {
+			if _, err := netip.ParseAddr(start); err == nil {
+				continue
+			}
+		}
+		if !strings.Contains(t, ".") || slices.Contains(hosts, t) {
+			continue
+		}
+		hosts = append(hosts, t)
+	}
+	return hosts
+}
+
+// enumerate collects the records of host and its zone.
+func (m *DNSEnumModule) enumerate(ctx context.Context, host string) DNSResult {
+	result := DNSResult{Target: host}
+	lookupCtx := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(ctx, m.config.Timeout)
+	}
+
+	lctx, cancel := lookupCtx()
+	addrs, err := m.resolver.LookupHost(lctx, host)
+	cancel()
+	if err == nil {
+		slices.Sort(addrs)
+		result.Addresses = addrs
+	}
+
+	// The zone is the closest enclosing name with name servers
+	var nameServers []*net.NS
+	for name := host; strings.Contains(name, "."); name = name[strings.Index(name, ".")+1:] {
+		lctx, cancel := lookupCtx()
+		nameServers, err = m.resolver.LookupNS(lctx, name)
+		cancel()
+		if err == nil && len(nameServers) > 0 {
+			result.Zone = name
+			break
+		}
+		if ctx.Err() != nil {
+			result.Error = ctx.Err().Error()
+			return result
+		}
+	}
+	if result.Zone == "" {
+		if len(result.Addresses) == 0 {
+			result.Error = fmt.Sprintf("no records found for %s", host)
+		}
+		return result
+	}
+	for _, ns := range nameServers {
+		result.NameServers = append(result.NameServers, strings.TrimSuffix(ns.Host, "."))
+	}
+	slices.Sort(result.NameServers)
+
+	lctx, cancel = lookupCtx()
+	if mxs, err := m.resolver.LookupMX(lctx, result.Zone); err == nil {
+		for _, mx := range mxs {
+			result.MX = append(result.MX, fmt.Sprintf("%d %s", mx.Pref, strings.TrimSuffix(mx.Host, ".")))
+		}
+	}
+	cancel()
+	lctx, cancel = lookupCtx()
+	if txts, err := m.resolver.LookupTXT(lctx, result.Zone); err == nil {
+		result.TXT = txts
+	}
+	cancel()
+
+	m.detectWildcard(ctx, &result)
+	m.tryZoneTransfers(ctx, &result)
+
+	m.logger.Info().Str("target", host).Str("zone", result.Zone).Int("name_servers", len(result.NameServers)).
+		Bool("wildcard", result.Wildcard).Bool("zone_transfer", result.TransferAllowed()).Msg("Enumerated DNS")
+	return result
+}
+
+// detectWildcard resolves random names in the zone; when all of them
+// resolve, the zone has a wildcard record.
+func (m *DNSEnumModule) detectWildcard(ctx context.Context, result *DNSResult) {
+	seen := make(map[string]bool)
+	for range dnsWildcardProbes {
+		label := make([]byte, 8)
+		_, _ = rand.Read(label)
+		name := "vulntor-" + hex.EncodeToString(label) + "." + result.Zone
+
+		lctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+		addrs, err := m.resolver.LookupHost(lctx, name)
+		cancel()
+		if err != nil || len(addrs) == 0 {
+			return
+		}
+		for _, a := range addrs {
+			seen[a] = true
+		}
+	}
+	result.Wildcard = true
+	for a := range seen {
+		result.WildcardAddresses = append(result.WildcardAddresses, a)
+	}
+	slices.Sort(result.WildcardAddresses)
+}
+
+// tryZoneTransfers requests the zone from each address of each name
+// server. The records of the first transfer allowed are kept.
+func (m *DNSEnumModule) tryZoneTransfers(ctx context.Context, result *DNSResult) {
+	opts := dns.Options{
+		Timeout:    m.config.Timeout,
+		MaxRecords: m.config.MaxRecords,
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+		},
+	}
+
+	for _, server := range result.NameServers {
+		lctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+		addrs, err := m.resolver.LookupHost(lctx, server)
+		cancel()
+		if err != nil {
+			result.ZoneTransfers = append(result.ZoneTransfers, ZoneTransferResult{Server: server, Error: err.Error()})
+			continue
+		}
+		slices.Sort(addrs)
+
+		for _, addr := range addrs {
+			if ctx.Err() != nil {
+				return
+			}
+			attempt := ZoneTransferResult{Server: server, Address: addr}
+			zone, err := m.transfer(ctx, net.JoinHostPort(addr, strconv.Itoa(m.config.Port)), result.Zone, opts)
+			if err != nil {
+				// A refused transfer is the expected, hardened answer
+				attempt.Error = err.Error()
+				if !errors.Is(err, dns.ErrRefused) {
+					m.logger.Debug().Str("server", server).Str("address", addr).Err(err).Msg("Zone transfer failed")
+				}
+			} else {
+				attempt.Allowed = true
+				attempt.Records = len(zone.Records)
+				attempt.Truncated = zone.Truncated
+				if result.Records == nil {
+					result.Records = zone.Records
+				}
+			}
+			result.ZoneTransfers = append(result.ZoneTransfers, attempt)
+		}
+	}
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *DNSEnumModule) emit(ctx context.Context, out output.Output, result DNSResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		switch {
+		case result.Error != "":
+			out.Diag(output.LevelVerbose, fmt.Sprintf("DNS enumeration failed: %s - %s", result.Target, result.Error), nil)
+		case result.TransferAllowed():
+			out.Diag(output.LevelNormal, fmt.Sprintf("DNS zone transfer allowed: %s (%d records)", result.Zone, len(result.Records)), nil)
+		default:
+			out.Diag(output.LevelNormal, fmt.Sprintf("DNS: %s (zone %s) - %d name servers, wildcard: %t",
+				result.Target, result.Zone, len(result.NameServers), result.Wildcard), nil)
+		}
+	}
+
+	outputs := []engine.ModuleOutput{{DataKey: "dns.details", Data: result}}
+	if result.Zone != "" {
+		var servers []string
+		for _, t := range result.ZoneTransfers {
+			if t.Allowed && !slices.Contains(servers, t.Server) {
+				servers = append(servers, t.Server)
+			}
+		}
+		outputs = append(outputs,
+			engine.ModuleOutput{DataKey: "dns.zone_transfer", Data: len(servers) > 0},
+			engine.ModuleOutput{DataKey: "dns.wildcard", Data: result.Wildcard},
+			engine.ModuleOutput{DataKey: "dns.ns", Data: strings.Join(result.NameServers, "\n") + "\n"},
+		)
+		if len(servers) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "dns.zone_transfer_servers", Data: strings.Join(servers, "\n") + "\n"})
+		}
+		if len(result.MX) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "dns.mx", Data: strings.Join(result.MX, "\n") + "\n"})
+		}
+		if len(result.TXT) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "dns.txt", Data: strings.Join(result.TXT, "\n") + "\n"})
+		}
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// DNSEnumModuleFactory creates a new DNSEnumModule instance.
+func DNSEnumModuleFactory() engine.Module {
+	return newDNSEnumModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(dnsEnumModuleName, DNSEnumModuleFactory)
+}
@@ -0,0 +1,202 @@
+// pkg/modules/scan/dns_enum_test.go
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/dns"
+	"github.com/vulntor/vulntor/pkg/engine"
+)
+
+// fakeDNS answers lookups from its maps; names under a wildcard zone
+// resolve to wildcard. Zone transfers are allowed from the addresses in
+// transfers.
+type fakeDNS struct {
+	hosts     map[string][]string
+	ns        map[string][]string
+	mx        map[string][]*net.MX
+	txt       map[string][]string
+	wildcard  map[string]string
+	transfers map[string]bool
+
+	mu        sync.Mutex
+	requested []string
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f *fakeDNS) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := f.hosts[host]; ok {
+		return addrs, nil
+	}
+	for zone, addr := range f.wildcard {
+		if strings.HasSuffix(host, "."+zone) {
+			return []string{addr}, nil
+		}
+	}
+	return nil, notFound(host)
+}
+
+func (f *fakeDNS) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	var ns []*net.NS
+	for _, host := range f.ns[name] {
+		ns = append(ns, &net.NS{Host: host + "."})
+	}
+	if len(ns) == 0 {
+		return nil, notFound(name)
+	}
+	return ns, nil
+}
+
+func (f *fakeDNS) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if mx, ok := f.mx[name]; ok {
+		return mx, nil
+	}
+	return nil, notFound(name)
+}
+
+func (f *fakeDNS) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if txt, ok := f.txt[name]; ok {
+		return txt, nil
+	}
+	return nil, notFound(name)
+}
+
+func (f *fakeDNS) transfer(_ context.Context, addr, zone string, _ dns.Options) (*dns.Zone, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requested = append(f.requested, addr)
+	if !f.transfers[addr] {
+		return nil, fmt.Errorf("%w: Refused", dns.ErrRefused)
+	}
+	return &dns.Zone{Name: zone + ".", Records: []dns.Record{
+		{Name: zone + ".", Type: "SOA", TTL: 3600, Value: "ns1." + zone + ". hostmaster." + zone + ". 1 7200 900 1209600 300"},
+		{Name: "vpn." + zone + ".", Type: "A", TTL: 60, Value: "192.0.2.10"},
+	}}, nil
+}
+
+func runDNSEnumModule(t *testing.T, fake *fakeDNS, targets interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newDNSEnumModule()
+	require.NoError(t, module.Init("dns_enum", config))
+	module.resolver = fake
+	module.transfer = fake.transfer
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"config.targets": targets}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func exampleZone() *fakeDNS {
+	return &fakeDNS{
+		hosts: map[string][]string{
+			"www.example.com": {"192.0.2.80"},
+			"ns1.example.com": {"192.0.2.53"},
+			"ns2.example.com": {"192.0.2.54", "2001:db8::54"},
+		},
+		ns:  map[string][]string{"example.com": {"ns2.example.com", "ns1.example.com"}},
+		mx:  map[string][]*net.MX{"example.com": {{Host: "mail.example.com.", Pref: 10}}},
+		txt: map[string][]string{"example.com": {"v=spf1 -all"}},
+	}
+}
+
+func TestDNSEnumModule_Execute_ZoneTransfer(t *testing.T) {
+	fake := exampleZone()
+	fake.transfers = map[string]bool{"192.0.2.54:53": true}
+
+	outputs := runDNSEnumModule(t, fake, []string{"www.example.com"}, nil)
+	byKey := outputsByKey(outputs)
+	for _, o := range outputs {
+		require.Equal(t, "www.example.com", o.Target)
+	}
+
+	result := byKey["dns.details"].(DNSResult)
+	require.Empty(t, result.Error)
+	require.Equal(t, "example.com", result.Zone)
+	require.Equal(t, []string{"192.0.2.80"}, result.Addresses)
+	require.Equal(t, []string{"ns1.example.com", "ns2.example.com"}, result.NameServers)
+	require.Equal(t, []string{"10 mail.example.com"}, result.MX)
+	require.False(t, result.Wildcard)
+	require.True(t, result.TransferAllowed())
+	require.Len(t, result.Records, 2)
+
+	// Every address of every name server is asked
+	require.ElementsMatch(t, []string{"192.0.2.53:53", "192.0.2.54:53", "[2001:db8::54]:53"}, fake.requested)
+	require.Len(t, result.ZoneTransfers, 3)
+	require.False(t, result.ZoneTransfers[0].Allowed)
+	require.Equal(t, "dns: zone transfer refused: Refused", result.ZoneTransfers[0].Error)
+	require.Equal(t, ZoneTransferResult{Server: "ns2.example.com", Address: "192.0.2.54", Allowed: true, Records: 2}, result.ZoneTransfers[1])
+
+	require.Equal(t, true, byKey["dns.zone_transfer"])
+	require.Equal(t, "ns2.example.com\n", byKey["dns.zone_transfer_servers"])
+	require.Equal(t, false, byKey["dns.wildcard"])
+	require.Equal(t, "ns1.example.com\nns2.example.com\n", byKey["dns.ns"])
+	require.Equal(t, "10 mail.example.com\n", byKey["dns.mx"])
+	require.Equal(t, "v=spf1 -all\n", byKey["dns.txt"])
+}
+
+func TestDNSEnumModule_Execute_Wildcard(t *testing.T) {
+	fake := exampleZone()
+	fake.wildcard = map[string]string{"example.com": "192.0.2.99"}
+
+	byKey := outputsByKey(runDNSEnumModule(t, fake, []interface{}{"example.com"}, map[string]interface{}{"port": 5353}))
+
+	result := byKey["dns.details"].(DNSResult)
+	require.True(t, result.Wildcard)
+	require.Equal(t, []string{"192.0.2.99"}, result.WildcardAddresses)
+	require.False(t, result.TransferAllowed())
+	require.Contains(t, fake.requested, "192.0.2.53:5353")
+	require.Equal(t, true, byKey["dns.wildcard"])
+	require.Equal(t, false, byKey["dns.zone_transfer"])
+	require.NotContains(t, byKey, "dns.zone_transfer_servers")
+}
+
+func TestDNSEnumModule_Execute_NotHostNames(t *testing.T) {
+	// Addresses, networks and ranges are skipped
+	fake := exampleZone()
+	require.Empty(t, runDNSEnumModule(t, fake, []string{"192.0.2.1", "192.0.2.0/24", "192.0.2.1-20", "2001:db8::1", "localhost"}, nil))
+	require.Empty(t, fake.requested)
+
+	// Names without a zone are reported as errors only
+	outputs := runDNSEnumModule(t, fake, []string{"unknown.invalid"}, nil)
+	require.Len(t, outputs, 1)
+	require.Equal(t, "no records found for unknown.invalid", outputs[0].Data.(DNSResult).Error)
+}
+
+func TestHostNameTargets(t *testing.T) {
+	require.Equal(t, []string{"www.example.com", "example.org"},
+		hostNameTargets([]string{"WWW.example.com.", "www.example.com", " example.org ", "10.0.0.1", "10.0.0.0/8", ""}))
+}
+
+func TestDNSEnumModule_Init(t *testing.T) {
+	module := newDNSEnumModule()
+	require.NoError(t, module.Init("dns_enum", map[string]interface{}{
+		"resolver":    "192.0.2.53",
+		"timeout":     "2s",
+		"concurrency": 0,
+		"max_records": 0,
+	}))
+	require.Equal(t, "192.0.2.53:53", module.config.Resolver)
+	require.NotEqual(t, net.DefaultResolver, module.resolver)
+	require.Equal(t, "2s", module.config.Timeout.String())
+	require.Equal(t, 1, module.config.Concurrency)
+	require.Equal(t, dns.DefaultMaxRecords, module.config.MaxRecords)
+
+	require.Error(t, newDNSEnumModule().Init("dns_enum", map[string]interface{}{"port": 0}))
+	require.Error(t, newDNSEnumModule().Init("dns_enum", map[string]interface{}{"timeout": "soon"}))
+}
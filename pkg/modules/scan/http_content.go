@@ -0,0 +1,637 @@
+// pkg/modules/scan/http_content.go
+package scan
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+const (
+	httpContentModuleID   = "http-content-discovery-instance"
+	httpContentModuleName = "http-content-discovery"
+)
+
+// httpContentBodyLimit bounds the part of a response body read to compare
+// it with the not-found baseline.
+const httpContentBodyLimit = 64 << 10
+
+// defaultContentPaths are paths that commonly expose source code,
+// configuration, backups or administration interfaces.
+var defaultContentPaths = []string{
+	"/.git/",
+	"/.git/config",
+	"/.svn/",
+	"/.hg/",
+	"/.env",
+	"/.DS_Store",
+	"/.htaccess",
+	"/web.config",
+	"/backup/",
+	"/backup.zip",
+	"/admin/",
+	"/administrator/",
+	"/login",
+	"/console/",
+	"/manager/html",
+	"/phpmyadmin/",
+	"/phpinfo.php",
+	"/wp-admin/",
+	"/wp-login.php",
+	"/server-status",
+	"/server-info",
+	"/actuator",
+	"/actuator/env",
+	"/debug/",
+	"/api/",
+	"/swagger-ui.html",
+	"/graphql",
+	"/jenkins/",
+	"/robots.txt",
+	"/sitemap.xml",
+	"/.well-known/security.txt",
+}
+
+// defaultContentStatusCodes are the statuses that report a path as
+// present: content, redirects and access denied.
+var defaultContentStatusCodes = []int{200, 204, 301, 302, 307, 308, 401, 403}
+
+// HTTPContentConfig holds configuration for the content discovery module.
+// Discovery sends a request per path to every HTTP service, so it stays
+// off unless enabled.
+type HTTPContentConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`       // Send requests at all
+	Paths        []string      `mapstructure:"paths"`         // Paths probed when no wordlist is set
+	Wordlist     string        `mapstructure:"wordlist"`      // Workspace wordlist name or file of paths
+	StatusCodes  []int         `mapstructure:"status_codes"`  // Statuses reporting a path as present
+	MaxPaths     int           `mapstructure:"max_paths"`     // Paths probed per service
+	MaxFindings  int           `mapstructure:"max_findings"`  // More paths found than this means a catch-all server
+	RequestDelay time.Duration `mapstructure:"request_delay"` // Pause between requests to one service
+	MaxErrors    int           `mapstructure:"max_errors"`    // Consecutive failed requests before giving up a service
+	Timeout      time.Duration `mapstructure:"timeout"`       // Timeout for each request
+	Concurrency  int           `mapstructure:"concurrency"`   // Number of services probed concurrently
+}
+
+// HTTPContentPath is a path an HTTP service answered for.
+type HTTPContentPath struct {
+	Path     string `json:"path"`
+	Status   int    `json:"status"`
+	Length   int    `json:"length"`             // Body bytes read, up to 64 KiB
+	Location string `json:"location,omitempty"` // Redirect target
+}
+
+// HTTPContentResult holds the paths discovered on an HTTP service. This is
+// the 'Data' in ModuleOutput with DataKey "http.content.details".
+type HTTPContentResult struct {
+	Target string            `json:"target"`
+	Port   int               `json:"port"`
+	URL    string            `json:"url"`    // Base URL of the service
+	Probed int               `json:"probed"` // Paths requested
+	Paths  []HTTPContentPath `json:"paths"`
+}
+
+// PathList returns the discovered paths one per line, the form plugins
+// match against in http.paths.
+func (r HTTPContentResult) PathList() string {
+	var sb strings.Builder
+	for _, p := range r.Paths {
+		sb.WriteString(p.Path)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// contentBaseline is how a service answers a path that does not exist: the
+// baseline a probe is compared with, for servers answering 200 or
+// redirecting instead of 404.
+type contentBaseline struct {
+	status   int
+	length   int
+	hash     string // Of the body without the requested path
+	location string // Redirect target with the requested path replaced
+}
+
+// HTTPContentModule requests a list of paths from HTTP services and
+// reports those that exist, telling them from the soft 404 pages of each
+// service by first requesting paths that cannot exist.
+type HTTPContentModule struct {
+	meta   engine.ModuleMetadata
+	config HTTPContentConfig
+	logger zerolog.Logger
+}
+
+// newHTTPContentModule is the internal constructor for the HTTPContentModule.
+func newHTTPContentModule() *HTTPContentModule {
+	defaultConfig := HTTPContentConfig{
+		Enabled:      false,
+		Paths:        defaultContentPaths,
+		StatusCodes:  defaultContentStatusCodes,
+		MaxPaths:     1000,
+		MaxFindings:  50,
+		RequestDelay: 100 * time.Millisecond,
+		MaxErrors:    3,
+		Timeout:      5 * time.Second,
+		Concurrency:  5,
+	}
+
+	return &HTTPContentModule{
+		meta: engine.ModuleMetadata{
+			ID:          httpContentModuleID,
+			Name:        httpContentModuleName,
+			Version:     "0.1.0",
+			Description: "Requests a wordlist of paths from HTTP services and reports the ones that exist, such as /.git/ or /admin, telling them from soft 404 pages. Disabled unless enabled.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "http", "intrusive", "content-discovery", "recon"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "service.banner.tcp",
+					DataTypeName: "scan.BannerGrabResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of TCP banners; services answering with an HTTP status line are probed.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "http.content.details",
+					DataTypeName: "scan.HTTPContentResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Paths found on each HTTP service, with their status and length.",
+				},
+				{
+					Key:          "http.paths",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Paths found, one per line, for plugin evaluation (e.g., '/.git/').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"enabled":       {Description: "Send content discovery requests.", Type: "bool", Required: false, Default: defaultConfig.Enabled},
+				"paths":         {Description: "Paths requested when no wordlist is set.", Type: "[]string", Required: false, Default: defaultConfig.Paths},
+				"wordlist":      {Description: "Name of a workspace wordlist (see 'vulntor wordlist'), or a file, of paths to request instead.", Type: "string", Required: false, Default: defaultConfig.Wordlist},
+				"status_codes":  {Description: "Response statuses that report a path as present.", Type: "[]int", Required: false, Default: defaultConfig.StatusCodes},
+				"max_paths":     {Description: "Paths requested per service.", Type: "int", Required: false, Default: defaultConfig.MaxPaths},
+				"max_findings":  {Description: "Paths found on one service beyond which its answers are deemed catch-all and dropped.", Type: "int", Required: false, Default: defaultConfig.MaxFindings},
+				"request_delay": {Description: "Pause between requests to the same service (e.g., '100ms').", Type: "duration", Required: false, Default: defaultConfig.RequestDelay.String()},
+				"max_errors":    {Description: "Consecutive failed requests before a service is given up.", Type: "int", Required: false, Default: defaultConfig.MaxErrors},
+				"timeout":       {Description: "Timeout for each request (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency":   {Description: "Number of services probed concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 4,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *HTTPContentModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *HTTPContentModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["enabled"]; ok {
+		cfg.Enabled = cast.ToBool(v)
+	}
+	if v, ok := configMap["paths"]; ok {
+		paths, err := cast.ToStringSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid paths %v: %w", v, err)
+		}
+		cfg.Paths = paths
+	}
+	if v, ok := configMap["wordlist"]; ok {
+		cfg.Wordlist = cast.ToString(v)
+	}
+	if v, ok := configMap["status_codes"]; ok {
+		codes, err := cast.ToIntSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid status_codes %v: %w", v, err)
+		}
+		cfg.StatusCodes = codes
+	}
+	if v, ok := configMap["max_paths"]; ok {
+		cfg.MaxPaths = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_findings"]; ok {
+		cfg.MaxFindings = cast.ToInt(v)
+	}
+	if v, ok := configMap["request_delay"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid request_delay %v: %w", v, err)
+		}
+		cfg.RequestDelay = dur
+	}
+	if v, ok := configMap["max_errors"]; ok {
+		cfg.MaxErrors = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	for _, p := range cfg.Paths {
+		if !strings.HasPrefix(p, "/") {
+			return fmt.Errorf("invalid path %q: must start with '/'", p)
+		}
+	}
+	for _, code := range cfg.StatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("invalid status code %d", code)
+		}
+	}
+	if cfg.RequestDelay < 0 || cfg.MaxPaths < 0 || cfg.MaxFindings < 0 || cfg.MaxErrors < 0 {
+		return fmt.Errorf("request_delay and limits cannot be negative")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute probes the HTTP services found in 'service.banner.tcp'. Services
+// on which no path is found produce no output.
+func (m *HTTPContentModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	if !m.config.Enabled {
+		m.logger.Debug().Msg("Content discovery disabled")
+		return nil
+	}
+
+	var banners []interface{}
+	switch raw := inputs["service.banner.tcp"].(type) {
+	case []interface{}:
+		banners = raw
+	case []BannerGrabResult:
+		for _, item := range raw {
+			banners = append(banners, item)
+		}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("input 'service.banner.tcp' is not a list, type: %T", raw)
+	}
+
+	// Content discovery is intrusive
+	services := httpServices(ctx, banners, true)
+	if len(services) == 0 {
+		m.logger.Debug().Msg("No HTTP services")
+		return nil
+	}
+
+	paths, err := m.paths(ctx)
+	if err != nil {
+		return err
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(svc httpService) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if result, ok := m.probe(ctx, svc, paths); ok {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(*svc)
+	}
+	wg.Wait()
+	return nil
+}
+
+// paths returns the paths to request: the entries of the configured
+// wordlist, or the configured paths, up to max_paths.
+func (m *HTTPContentModule) paths(ctx context.Context) ([]string, error) {
+	paths := m.config.Paths
+	if m.config.Wordlist != "" {
+		entries, err := readContentWordlist(ctx, m.config.Wordlist)
+		if err != nil {
+			return nil, fmt.Errorf("wordlist %q: %w", m.config.Wordlist, err)
+		}
+		paths = make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry, "/") {
+				entry = "/" + entry
+			}
+			paths = append(paths, entry)
+		}
+	}
+	if m.config.MaxPaths > 0 && len(paths) > m.config.MaxPaths {
+		m.logger.Warn().Int("paths", len(paths)).Int("max_paths", m.config.MaxPaths).Msg("Requesting only the first max_paths paths")
+		paths = paths[:m.config.MaxPaths]
+	}
+	return paths, nil
+}
+
+// readContentWordlist returns the entries of the file name, or else of the
+// workspace wordlist name.
+func readContentWordlist(ctx context.Context, name string) ([]string, error) {
+	data, err := os.ReadFile(name) // #nosec G304 -- the scan configuration names the file
+	if err == nil {
+		return wordlist.Parse(data), nil
+	}
+	if !os.IsNotExist(err) || wordlist.ValidateName(name) != nil {
+		return nil, err
+	}
+
+	cfg, ok := storage.ConfigFromContext(ctx)
+	if !ok {
+		if cfg, err = storage.DefaultConfig(); err != nil {
+			return nil, err
+		}
+	}
+	return wordlist.NewStore(filepath.Join(cfg.WorkspaceRoot, wordlist.DirName)).Entries(name)
+}
+
+// probe requests paths from svc and returns those it answers for unlike
+// it answers for paths that do not exist.
+func (m *HTTPContentModule) probe(ctx context.Context, svc httpService, paths []string) (HTTPContentResult, bool) {
+	scheme := "http"
+	if svc.TLS {
+		scheme = "https"
+	}
+	base := scheme + "://" + net.JoinHostPort(svc.Target, strconv.Itoa(svc.Port))
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+			},
+			// #nosec G402 -- services are probed by address, not by the names their certificates carry
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	defer client.CloseIdleConnections()
+
+	result := HTTPContentResult{Target: svc.Target, Port: svc.Port, URL: base}
+	baselines := make(map[string]*contentBaseline)
+	errorCount := 0
+	failed := func() bool {
+		errorCount++
+		return m.config.MaxErrors > 0 && errorCount >= m.config.MaxErrors
+	}
+	first := true
+
+	for _, p := range paths {
+		if !first && !m.wait(ctx) {
+			break
+		}
+		first = false
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Each kind of path, directory or file with an extension, is
+		// compared with a path of the same kind that cannot exist
+		kind := pathKind(p)
+		baseline, ok := baselines[kind]
+		if !ok {
+			random := randomPath(kind)
+			resp, err := m.get(ctx, client, base, random)
+			if err != nil {
+				m.logger.Debug().Str("url", base+random).Err(err).Msg("Baseline request failed")
+				if failed() {
+					break
+				}
+				continue
+			}
+			baseline = resp.baseline(random)
+			baselines[kind] = baseline
+			result.Probed++
+			if !m.wait(ctx) {
+				break
+			}
+		}
+
+		resp, err := m.get(ctx, client, base, p)
+		if err != nil {
+			m.logger.Debug().Str("url", base+p).Err(err).Msg("Request failed")
+			if failed() {
+				m.logger.Debug().Str("url", base).Msg("Giving up service after consecutive errors")
+				break
+			}
+			continue
+		}
+		errorCount = 0
+		result.Probed++
+
+		if !m.found(resp, p, baseline) {
+			continue
+		}
+		result.Paths = append(result.Paths, HTTPContentPath{Path: p, Status: resp.status, Length: len(resp.body), Location: resp.location})
+		if m.config.MaxFindings > 0 && len(result.Paths) > m.config.MaxFindings {
+			// Answers this alike are the server's, not of the paths
+			m.logger.Warn().Str("url", base).Int("max_findings", m.config.MaxFindings).
+				Msg("Too many paths found; the service answers any path, dropping its results")
+			return HTTPContentResult{}, false
+		}
+	}
+
+	if len(result.Paths) == 0 {
+		return HTTPContentResult{}, false
+	}
+	m.logger.Info().Str("url", base).Int("probed", result.Probed).Int("found", len(result.Paths)).Msg("Discovered HTTP content")
+	return result, true
+}
+
+// wait pauses for request_delay, reporting false when ctx is done first.
+func (m *HTTPContentModule) wait(ctx context.Context) bool {
+	if m.config.RequestDelay <= 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-time.After(m.config.RequestDelay):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// found reports whether resp, the answer for p, tells that p exists.
+func (m *HTTPContentModule) found(resp *contentResponse, p string, baseline *contentBaseline) bool {
+	if !slices.Contains(m.config.StatusCodes, resp.status) {
+		return false
+	}
+	if baseline == nil || resp.status != baseline.status {
+		return true
+	}
+	// Same status as a missing path: a soft 404 unless the answer differs
+	if resp.location != "" || baseline.location != "" {
+		return strings.ReplaceAll(resp.location, p, "") != baseline.location
+	}
+	if bodyHash(resp.body, p) == baseline.hash {
+		return false
+	}
+	// Pages echoing the path or carrying a timestamp vary a little
+	tolerance := max(baseline.length/20, 32)
+	diff := len(resp.body) - baseline.length
+	return diff > tolerance || diff < -tolerance
+}
+
+// contentResponse is what the module keeps of a response.
+type contentResponse struct {
+	status   int
+	body     []byte
+	location string
+}
+
+// baseline returns the baseline of the response to random, a path that
+// cannot exist.
+func (r *contentResponse) baseline(random string) *contentBaseline {
+	return &contentBaseline{
+		status:   r.status,
+		length:   len(r.body),
+		hash:     bodyHash(r.body, random),
+		location: strings.ReplaceAll(r.location, random, ""),
+	}
+}
+
+// get requests p from the service at base.
+func (m *HTTPContentModule) get(ctx context.Context, client *http.Client, base, p string) (*contentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+p, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpContentBodyLimit))
+	if err != nil {
+		return nil, err
+	}
+	// Drain a little more so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, httpContentBodyLimit))
+
+	location := resp.Header.Get("Location")
+	if u, err := neturl.Parse(location); err == nil && location != "" {
+		// Absolute redirects to the service itself compare as relative
+		if u.Host == req.URL.Host {
+			location = u.RequestURI()
+		}
+	}
+	return &contentResponse{status: resp.StatusCode, body: body, location: location}, nil
+}
+
+// pathKind classifies p for the not-found baseline: "/" for directories,
+// the extension for files, "" for paths without one.
+func pathKind(p string) string {
+	if strings.HasSuffix(p, "/") {
+		return "/"
+	}
+	return path.Ext(p)
+}
+
+// randomPath returns a path of kind that cannot exist.
+func randomPath(kind string) string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	name := "/vulntor-" + hex.EncodeToString(buf)
+	if kind == "/" {
+		return name + "/"
+	}
+	return name + kind
+}
+
+// bodyHash hashes body with the path it answered removed, as error pages
+// often repeat it.
+func bodyHash(body []byte, p string) string {
+	body = bytes.ReplaceAll(body, []byte(p), nil)
+	if escaped := neturl.PathEscape(strings.TrimPrefix(p, "/")); escaped != strings.TrimPrefix(p, "/") {
+		body = bytes.ReplaceAll(body, []byte(escaped), nil)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *HTTPContentModule) emit(ctx context.Context, out output.Output, result HTTPContentResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		paths := make([]string, 0, len(result.Paths))
+		for _, p := range result.Paths {
+			paths = append(paths, fmt.Sprintf("%s (%d)", p.Path, p.Status))
+		}
+		out.Diag(output.LevelNormal, fmt.Sprintf("HTTP content: %s - %s", result.URL, strings.Join(paths, ", ")), nil)
+	}
+
+	outputs := []engine.ModuleOutput{
+		{DataKey: "http.content.details", Data: result},
+		{DataKey: "http.paths", Data: result.PathList()},
+	}
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// HTTPContentModuleFactory creates a new HTTPContentModule instance.
+func HTTPContentModuleFactory() engine.Module {
+	return newHTTPContentModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(httpContentModuleName, HTTPContentModuleFactory)
+}
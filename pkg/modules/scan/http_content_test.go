@@ -0,0 +1,210 @@
+// pkg/modules/scan/http_content_test.go
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/wordlist"
+)
+
+func runHTTPContentModule(t *testing.T, ctx context.Context, banners []interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newHTTPContentModule()
+	cfg := map[string]interface{}{"enabled": true, "request_delay": "0s"}
+	for k, v := range config {
+		cfg[k] = v
+	}
+	require.NoError(t, module.Init("http_content", cfg))
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(ctx, map[string]interface{}{"service.banner.tcp": banners}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func TestHTTPContentModule_Execute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.git/":
+			_, _ = fmt.Fprint(w, "Index of /.git/")
+		case "/admin/":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	outputs := runHTTPContentModule(t, context.Background(), []interface{}{banner, banner}, map[string]interface{}{
+		"paths": []interface{}{"/.git/", "/admin/", "/backup.zip", "/login"},
+	})
+	require.Len(t, outputs, 2, "duplicate banners are probed once")
+	byKey := outputsByKey(outputs)
+
+	result := byKey["http.content.details"].(HTTPContentResult)
+	require.Equal(t, server.URL, result.URL)
+	require.Equal(t, []HTTPContentPath{
+		{Path: "/.git/", Status: http.StatusOK, Length: len("Index of /.git/")},
+		{Path: "/admin/", Status: http.StatusForbidden},
+	}, result.Paths)
+	require.Equal(t, 7, result.Probed, "four paths and a baseline for each kind")
+	require.Equal(t, "/.git/\n/admin/\n", byKey["http.paths"])
+}
+
+func TestHTTPContentModule_Execute_SoftNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/admin":
+			_, _ = fmt.Fprint(w, "<html><h1>Admin console</h1><form>"+strings.Repeat("<input>", 40)+"</form></html>")
+		case r.URL.Path == "/login/":
+			http.Redirect(w, r, "/sso/start", http.StatusFound)
+		case strings.HasSuffix(r.URL.Path, "/"):
+			// Unknown directories redirect to the login page
+			http.Redirect(w, r, "/login?next="+r.URL.Path, http.StatusFound)
+		default:
+			// Unknown files get a 200 page quoting the path
+			_, _ = fmt.Fprintf(w, "<html>Sorry, %s was not found.</html>", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	outputs := runHTTPContentModule(t, context.Background(), []interface{}{httpBanner(t, server)}, map[string]interface{}{
+		"paths": []interface{}{"/admin", "/.env", "/a-much-longer-missing-path-name", "/backup/", "/login/"},
+	})
+	result := outputsByKey(outputs)["http.content.details"].(HTTPContentResult)
+	require.Equal(t, []string{"/admin", "/login/"}, pathsOf(result))
+	require.Equal(t, "/sso/start", result.Paths[1].Location)
+}
+
+func TestHTTPContentModule_Execute_CatchAll(t *testing.T) {
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every answer differs, so none looks like the baseline
+		_, _ = fmt.Fprint(w, strings.Repeat("x", 100*int(n.Add(1))))
+	}))
+	defer server.Close()
+
+	outputs := runHTTPContentModule(t, context.Background(), []interface{}{httpBanner(t, server)}, map[string]interface{}{
+		"paths":        []interface{}{"/a", "/b", "/c", "/d"},
+		"max_findings": 2,
+	})
+	require.Empty(t, outputs)
+}
+
+func TestHTTPContentModule_Execute_Wordlist(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if r.URL.Path == "/.git/" {
+			_, _ = fmt.Fprint(w, "ref: refs/heads/main")
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	root := t.TempDir()
+	store := wordlist.NewStore(filepath.Join(root, wordlist.DirName))
+	_, err := store.Save(wordlist.Wordlist{Name: "web-paths", Kind: wordlist.KindPaths}, []byte("# Paths\n.git/\n/server-status\n"))
+	require.NoError(t, err)
+	ctx := storage.WithConfig(context.Background(), &storage.Config{WorkspaceRoot: root})
+
+	outputs := runHTTPContentModule(t, ctx, []interface{}{banner}, map[string]interface{}{"wordlist": "web-paths", "max_paths": 1})
+	require.Equal(t, "/.git/\n", outputsByKey(outputs)["http.paths"])
+	require.Len(t, requested, 2, "a baseline and the first path only")
+
+	// Files work as well
+	outputs = runHTTPContentModule(t, ctx, []interface{}{banner}, map[string]interface{}{"wordlist": store.Path("web-paths")})
+	require.Equal(t, "/.git/\n", outputsByKey(outputs)["http.paths"])
+
+	module := newHTTPContentModule()
+	require.NoError(t, module.Init("http_content", map[string]interface{}{"enabled": true, "wordlist": "missing"}))
+	err = module.Execute(ctx, map[string]interface{}{"service.banner.tcp": []interface{}{banner}}, make(chan engine.ModuleOutput, 1))
+	require.ErrorIs(t, err, wordlist.ErrNotFound)
+}
+
+func TestHTTPContentModule_Execute_Disabled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	module := newHTTPContentModule()
+	require.NoError(t, module.Init("http_content", nil))
+	outputChan := make(chan engine.ModuleOutput, 1)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"service.banner.tcp": []interface{}{httpBanner(t, server)}}, outputChan))
+	require.Empty(t, outputChan)
+	require.Zero(t, requests.Load())
+
+	// Unreachable services are given up after max_errors failures
+	server.Close()
+	require.Empty(t, runHTTPContentModule(t, context.Background(), []interface{}{httpBanner(t, server)}, nil))
+}
+
+func TestHTTPContentModule_Execute_BehindCDN(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	// Reduced scans keep content discovery away from CDNs and WAFs
+	guard := cdn.NewGuard(cdn.PolicyReduce)
+	guard.ObserveResponse(banner.IP, "HTTP/1.1 403 Forbidden\r\nServer: cloudflare\r\nCF-RAY: 1\r\n\r\n")
+	require.Empty(t, runHTTPContentModule(t, cdn.WithContext(context.Background(), guard), []interface{}{banner}, nil))
+	require.Zero(t, requests.Load())
+
+	// Annotated ones probe them like any other service
+	guard = cdn.NewGuard(cdn.PolicyAnnotate)
+	guard.ObserveResponse(banner.IP, "HTTP/1.1 403 Forbidden\r\nCF-RAY: 1\r\n\r\n")
+	runHTTPContentModule(t, cdn.WithContext(context.Background(), guard), []interface{}{banner}, nil)
+	require.NotZero(t, requests.Load())
+}
+
+func TestHTTPContentModule_Init(t *testing.T) {
+	module := newHTTPContentModule()
+	require.NoError(t, module.Init("http_content", map[string]interface{}{
+		"paths":         []interface{}{"/.git/"},
+		"status_codes":  []interface{}{200, "403"},
+		"request_delay": "250ms",
+		"concurrency":   0,
+	}))
+	require.False(t, module.config.Enabled)
+	require.Equal(t, []string{"/.git/"}, module.config.Paths)
+	require.Equal(t, []int{200, 403}, module.config.StatusCodes)
+	require.Equal(t, "250ms", module.config.RequestDelay.String())
+	require.Equal(t, 1, module.config.Concurrency)
+
+	require.Error(t, newHTTPContentModule().Init("http_content", map[string]interface{}{"paths": []interface{}{".git/"}}))
+	require.Error(t, newHTTPContentModule().Init("http_content", map[string]interface{}{"status_codes": []interface{}{999}}))
+	require.Error(t, newHTTPContentModule().Init("http_content", map[string]interface{}{"request_delay": "-1s"}))
+}
+
+func pathsOf(result HTTPContentResult) []string {
+	paths := make([]string, 0, len(result.Paths))
+	for _, p := range result.Paths {
+		paths = append(paths, p.Path)
+	}
+	return paths
+}
@@ -0,0 +1,446 @@
+// pkg/modules/scan/http_ntlm.go
+package scan
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/ntlm"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	httpNTLMModuleID   = "http-ntlm-info-instance"
+	httpNTLMModuleName = "http-ntlm-info"
+)
+
+// defaultNTLMPaths are endpoints that commonly accept NTLM on Windows
+// servers: IIS, Exchange, SharePoint, ADFS and AD Certificate Services.
+var defaultNTLMPaths = []string{
+	"/",
+	"/autodiscover/autodiscover.xml",
+	"/ews/exchange.asmx",
+	"/mapi/",
+	"/rpc/",
+	"/oab/",
+	"/Microsoft-Server-ActiveSync",
+	"/owa/",
+	"/ecp/",
+	"/powershell/",
+	"/_windows/default.aspx",
+	"/adfs/services/trust/2005/windowstransport",
+	"/certsrv/",
+}
+
+// HTTPNTLMConfig holds configuration for the HTTP NTLM module.
+type HTTPNTLMConfig struct {
+	Paths       []string      `mapstructure:"paths"`       // Paths sent an NTLM NEGOTIATE, in order
+	Timeout     time.Duration `mapstructure:"timeout"`     // Timeout for each request
+	Concurrency int           `mapstructure:"concurrency"` // Number of services probed concurrently
+}
+
+// HTTPNTLMResult holds what an HTTP service disclosed in its NTLM
+// challenge. This is the 'Data' in ModuleOutput with DataKey
+// "http.ntlm.details".
+type HTTPNTLMResult struct {
+	Target          string     `json:"target"`
+	Port            int        `json:"port"`
+	URL             string     `json:"url"`         // Endpoint that answered with the challenge
+	AuthScheme      string     `json:"auth_scheme"` // 'NTLM' or 'Negotiate'
+	OSVersion       string     `json:"os_version,omitempty"`
+	NetBIOSComputer string     `json:"netbios_computer,omitempty"`
+	NetBIOSDomain   string     `json:"netbios_domain,omitempty"`
+	DNSComputer     string     `json:"dns_computer,omitempty"`
+	DNSDomain       string     `json:"dns_domain,omitempty"`
+	DNSTree         string     `json:"dns_tree,omitempty"` // Forest name
+	ServerTime      *time.Time `json:"server_time,omitempty"`
+}
+
+// Computer returns the DNS host name, or the NetBIOS name without one.
+func (r HTTPNTLMResult) Computer() string {
+	if r.DNSComputer != "" {
+		return r.DNSComputer
+	}
+	return r.NetBIOSComputer
+}
+
+// Domain returns the DNS domain, or the NetBIOS domain without one.
+func (r HTTPNTLMResult) Domain() string {
+	if r.DNSDomain != "" {
+		return r.DNSDomain
+	}
+	return r.NetBIOSDomain
+}
+
+// httpService is an HTTP service found by banner grabbing.
+type httpService struct {
+	Target string
+	Port   int
+	TLS    bool
+}
+
+// httpServices returns the HTTP services among the banners of
+// 'service.banner.tcp', once per address, that the CDN policy of ctx lets
+// be sent intrusive or, if not, passive requests. A service is TLS if any
+// of its banners was grabbed over TLS.
+func httpServices(ctx context.Context, banners []interface{}, intrusive bool) []*httpService {
+	var services []*httpService
+	seen := make(map[string]*httpService)
+	for _, item := range banners {
+		banner, ok := item.(BannerGrabResult)
+		if !ok || banner.Error != "" || !strings.HasPrefix(banner.Banner, "HTTP/") {
+			continue
+		}
+		if !cdn.FromContext(ctx).Allow(banner.IP, intrusive) {
+			continue
+		}
+		addr := net.JoinHostPort(banner.IP, strconv.Itoa(banner.Port))
+		if svc, ok := seen[addr]; ok {
+			svc.TLS = svc.TLS || banner.IsTLS
+			continue
+		}
+		svc := &httpService{Target: banner.IP, Port: banner.Port, TLS: banner.IsTLS}
+		seen[addr] = svc
+		services = append(services, svc)
+	}
+	return services
+}
+
+// HTTPNTLMModule sends NTLM NEGOTIATE messages to HTTP services and
+// decodes the challenge, which names the server's host, domain and
+// Windows version before any authentication.
+type HTTPNTLMModule struct {
+	meta   engine.ModuleMetadata
+	config HTTPNTLMConfig
+	logger zerolog.Logger
+}
+
+// newHTTPNTLMModule is the internal constructor for the HTTPNTLMModule.
+func newHTTPNTLMModule() *HTTPNTLMModule {
+	defaultConfig := HTTPNTLMConfig{
+		Paths:       defaultNTLMPaths,
+		Timeout:     5 * time.Second,
+		Concurrency: 10,
+	}
+
+	return &HTTPNTLMModule{
+		meta: engine.ModuleMetadata{
+			ID:          httpNTLMModuleID,
+			Name:        httpNTLMModuleName,
+			Version:     "0.1.0",
+			Description: "Sends NTLM negotiate requests to HTTP services and decodes the challenge to report internal host names, domain names and Windows versions.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "http", "ntlm", "recon"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "service.banner.tcp",
+					DataTypeName: "scan.BannerGrabResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of TCP banners; services answering with an HTTP status line are probed.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "http.ntlm.details",
+					DataTypeName: "scan.HTTPNTLMResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Names and version decoded from each HTTP service's NTLM challenge.",
+				},
+				{
+					Key:          "http.ntlm.computer",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Host name from the NTLM challenge for plugin evaluation (e.g., 'exch01.corp.example').",
+				},
+				{
+					Key:          "http.ntlm.domain",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Domain name from the NTLM challenge for plugin evaluation (e.g., 'corp.example').",
+				},
+				{
+					Key:          "http.ntlm.os_version",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Windows version and build from the NTLM challenge for plugin evaluation (e.g., '10.0.17763').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"paths":       {Description: "Paths sent an NTLM negotiate request, in order; probing stops at the first challenge.", Type: "[]string", Required: false, Default: defaultConfig.Paths},
+				"timeout":     {Description: "Timeout for each request (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency": {Description: "Number of services probed concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 2,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *HTTPNTLMModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *HTTPNTLMModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["paths"]; ok {
+		paths, err := cast.ToStringSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid paths %v: %w", v, err)
+		}
+		cfg.Paths = paths
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	for _, path := range cfg.Paths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid path %q: must start with '/'", path)
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute probes the HTTP services found in 'service.banner.tcp'.
+// Services that do not answer with an NTLM challenge produce no output.
+func (m *HTTPNTLMModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var banners []interface{}
+	switch raw := inputs["service.banner.tcp"].(type) {
+	case []interface{}:
+		banners = raw
+	case []BannerGrabResult:
+		for _, item := range raw {
+			banners = append(banners, item)
+		}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("input 'service.banner.tcp' is not a list, type: %T", raw)
+	}
+
+	services := httpServices(ctx, banners, false)
+	if len(services) == 0 {
+		m.logger.Debug().Msg("No HTTP services")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(svc httpService) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if result, ok := m.probe(ctx, svc); ok {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(*svc)
+	}
+	wg.Wait()
+	return nil
+}
+
+// probe requests the configured paths until one answers with an NTLM
+// challenge.
+func (m *HTTPNTLMModule) probe(ctx context.Context, svc httpService) (HTTPNTLMResult, bool) {
+	scheme := "http"
+	if svc.TLS {
+		scheme = "https"
+	}
+	base := scheme + "://" + net.JoinHostPort(svc.Target, strconv.Itoa(svc.Port))
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+			},
+			// #nosec G402 -- internal Windows servers rarely have certificates for their address
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+
+	for _, path := range m.config.Paths {
+		if ctx.Err() != nil {
+			break
+		}
+		url := base + path
+		challenge, authScheme, err := m.negotiate(ctx, client, url, "NTLM")
+		if errors.Is(err, errNegotiateOnly) {
+			// Servers offering only Negotiate accept a raw NTLM token in it
+			challenge, authScheme, err = m.negotiate(ctx, client, url, "Negotiate")
+		}
+		if err != nil {
+			m.logger.Debug().Str("url", url).Err(err).Msg("No NTLM challenge")
+			var urlErr *neturl.Error
+			if errors.As(err, &urlErr) {
+				// The request itself failed; the remaining paths would too
+				break
+			}
+			continue
+		}
+
+		result := HTTPNTLMResult{
+			Target:          svc.Target,
+			Port:            svc.Port,
+			URL:             url,
+			AuthScheme:      authScheme,
+			NetBIOSComputer: challenge.NetBIOSComputer,
+			NetBIOSDomain:   challenge.NetBIOSDomain,
+			DNSComputer:     challenge.DNSComputer,
+			DNSDomain:       challenge.DNSDomain,
+			DNSTree:         challenge.DNSTree,
+		}
+		if challenge.Version != nil {
+			result.OSVersion = challenge.Version.String()
+		}
+		if !challenge.Timestamp.IsZero() {
+			result.ServerTime = &challenge.Timestamp
+		}
+		m.logger.Info().Str("url", url).Str("computer", result.Computer()).Str("domain", result.Domain()).
+			Str("os_version", result.OSVersion).Msg("Decoded HTTP NTLM challenge")
+		return result, true
+	}
+	return HTTPNTLMResult{}, false
+}
+
+// errNegotiateOnly is returned when a server asks for Negotiate but did
+// not answer an NTLM authorization.
+var errNegotiateOnly = errors.New("server offers Negotiate only")
+
+// negotiate sends a NEGOTIATE message to url in an authScheme
+// authorization and decodes the challenge in the response.
+func (m *HTTPNTLMModule) negotiate(ctx context.Context, client *http.Client, url, authScheme string) (*ntlm.Challenge, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Authorization", authScheme+" "+base64.StdEncoding.EncodeToString(ntlm.NegotiateMessage()))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil, "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	offersNegotiate, offersNTLM := false, false
+	for _, value := range resp.Header.Values("WWW-Authenticate") {
+		name, token, _ := strings.Cut(strings.TrimSpace(value), " ")
+		switch {
+		case strings.EqualFold(name, "NTLM"):
+			offersNTLM = true
+		case strings.EqualFold(name, "Negotiate"):
+			offersNegotiate = true
+		default:
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(token))
+		if err != nil || len(raw) == 0 {
+			continue
+		}
+		challenge, err := ntlm.ParseChallenge(raw)
+		if err != nil {
+			return nil, "", err
+		}
+		return challenge, name, nil
+	}
+	if offersNegotiate && !offersNTLM {
+		return nil, "", errNegotiateOnly
+	}
+	return nil, "", errors.New("no NTLM challenge in response")
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *HTTPNTLMModule) emit(ctx context.Context, out output.Output, result HTTPNTLMResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		out.Diag(output.LevelNormal, fmt.Sprintf("HTTP NTLM challenge: %s - computer: %s, domain: %s, version: %s",
+			result.URL, result.Computer(), result.Domain(), result.OSVersion), nil)
+	}
+
+	outputs := []engine.ModuleOutput{{DataKey: "http.ntlm.details", Data: result}}
+	if computer := result.Computer(); computer != "" {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "http.ntlm.computer", Data: computer})
+	}
+	if domain := result.Domain(); domain != "" {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "http.ntlm.domain", Data: domain})
+	}
+	if result.OSVersion != "" {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "http.ntlm.os_version", Data: result.OSVersion})
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// HTTPNTLMModuleFactory creates a new HTTPNTLMModule instance.
+func HTTPNTLMModuleFactory() engine.Module {
+	return newHTTPNTLMModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(httpNTLMModuleName, HTTPNTLMModuleFactory)
+}
@@ -0,0 +1,154 @@
+// pkg/modules/scan/http_ntlm_test.go
+package scan
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+var testNTLMChallenge = &ntlm.Challenge{
+	Version:         &ntlm.Version{Major: 10, Minor: 0, Build: 17763, Revision: 15},
+	NetBIOSComputer: "EXCH01",
+	NetBIOSDomain:   "CORP",
+	DNSComputer:     "exch01.corp.example",
+	DNSDomain:       "corp.example",
+	DNSTree:         "corp.example",
+	Timestamp:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+}
+
+// ntlmHandler answers NEGOTIATE messages sent with scheme on path with
+// the test challenge, and asks for scheme everywhere else.
+func ntlmHandler(t *testing.T, scheme, path string, requested *[]string) http.Handler {
+	var mu sync.Mutex
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requested = append(*requested, r.URL.Path)
+		mu.Unlock()
+
+		name, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if r.URL.Path == path && name == scheme {
+			raw, err := base64.StdEncoding.DecodeString(token)
+			require.NoError(t, err)
+			require.Equal(t, ntlm.Signature, raw[:8])
+			w.Header().Set("WWW-Authenticate", scheme+" "+base64.StdEncoding.EncodeToString(testNTLMChallenge.Marshal()))
+		} else {
+			w.Header().Add("WWW-Authenticate", scheme)
+			w.Header().Add("WWW-Authenticate", `Basic realm="exch01"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+func httpBanner(t *testing.T, server *httptest.Server) BannerGrabResult {
+	t.Helper()
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return BannerGrabResult{IP: host, Port: port, Protocol: "tcp", Banner: "HTTP/1.1 401 Unauthorized\r\n", IsTLS: server.TLS != nil}
+}
+
+func runHTTPNTLMModule(t *testing.T, banners []interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newHTTPNTLMModule()
+	require.NoError(t, module.Init("http_ntlm", config))
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"service.banner.tcp": banners}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func TestHTTPNTLMModule_Execute(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(ntlmHandler(t, "NTLM", "/ews/exchange.asmx", &requested))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	// Duplicate banners are probed once
+	outputs := runHTTPNTLMModule(t, []interface{}{banner, banner}, nil)
+	byKey := outputsByKey(outputs)
+	require.Len(t, outputs, 4)
+
+	result := byKey["http.ntlm.details"].(HTTPNTLMResult)
+	require.Equal(t, server.URL+"/ews/exchange.asmx", result.URL)
+	require.Equal(t, "NTLM", result.AuthScheme)
+	require.Equal(t, "10.0.17763", result.OSVersion)
+	require.Equal(t, "EXCH01", result.NetBIOSComputer)
+	require.Equal(t, "corp.example", result.DNSTree)
+	require.Equal(t, testNTLMChallenge.Timestamp, *result.ServerTime)
+	require.Equal(t, "exch01.corp.example", byKey["http.ntlm.computer"])
+	require.Equal(t, "corp.example", byKey["http.ntlm.domain"])
+	require.Equal(t, "10.0.17763", byKey["http.ntlm.os_version"])
+
+	// Probing stops at the first challenge
+	require.Equal(t, []string{"/", "/autodiscover/autodiscover.xml", "/ews/exchange.asmx"}, requested)
+}
+
+func TestHTTPNTLMModule_Execute_NegotiateOverTLS(t *testing.T) {
+	var requested []string
+	server := httptest.NewTLSServer(ntlmHandler(t, "Negotiate", "/", &requested))
+	defer server.Close()
+
+	byKey := outputsByKey(runHTTPNTLMModule(t, []interface{}{httpBanner(t, server)}, nil))
+
+	result := byKey["http.ntlm.details"].(HTTPNTLMResult)
+	require.True(t, strings.HasPrefix(result.URL, "https://"))
+	require.Equal(t, "Negotiate", result.AuthScheme)
+	require.Equal(t, "exch01.corp.example", result.Computer())
+	require.Equal(t, []string{"/", "/"}, requested)
+}
+
+func TestHTTPNTLMModule_Execute_NoNTLM(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	// Services without NTLM and banners of other protocols produce nothing
+	ssh := BannerGrabResult{IP: banner.IP, Port: banner.Port, Banner: "SSH-2.0-OpenSSH_9.6"}
+	require.Empty(t, runHTTPNTLMModule(t, []interface{}{banner, ssh}, map[string]interface{}{"paths": []interface{}{"/", "/owa/"}}))
+	require.Equal(t, []string{"/", "/owa/"}, requested)
+
+	// Unreachable services are given up after the first request
+	server.Close()
+	requested = nil
+	require.Empty(t, runHTTPNTLMModule(t, []interface{}{banner}, nil))
+	require.Empty(t, requested)
+}
+
+func TestHTTPNTLMModule_Init(t *testing.T) {
+	module := newHTTPNTLMModule()
+	require.NoError(t, module.Init("http_ntlm", map[string]interface{}{
+		"paths":       []interface{}{"/owa/"},
+		"timeout":     "2s",
+		"concurrency": 0,
+	}))
+	require.Equal(t, []string{"/owa/"}, module.config.Paths)
+	require.Equal(t, "2s", module.config.Timeout.String())
+	require.Equal(t, 1, module.config.Concurrency)
+
+	require.Error(t, newHTTPNTLMModule().Init("http_ntlm", map[string]interface{}{"paths": []interface{}{"owa"}}))
+	require.Error(t, newHTTPNTLMModule().Init("http_ntlm", map[string]interface{}{"timeout": "soon"}))
+}
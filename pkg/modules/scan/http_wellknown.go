@@ -0,0 +1,549 @@
+// pkg/modules/scan/http_wellknown.go
+package scan
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	httpWellKnownModuleID   = "http-wellknown-instance"
+	httpWellKnownModuleName = "http-wellknown"
+)
+
+const (
+	securityTxtPath       = "/.well-known/security.txt"
+	legacySecurityTxtPath = "/security.txt"
+	robotsTxtPath         = "/robots.txt"
+	changePasswordPath    = "/.well-known/change-password"
+)
+
+// defaultWellKnownPaths are the metadata endpoints requested from each
+// HTTP service: security contacts (RFC 9116), crawler rules and the
+// well-known URIs of common protocols.
+var defaultWellKnownPaths = []string{
+	securityTxtPath,
+	legacySecurityTxtPath,
+	robotsTxtPath,
+	"/humans.txt",
+	"/sitemap.xml",
+	changePasswordPath,
+	"/.well-known/openid-configuration",
+	"/.well-known/mta-sts.txt",
+}
+
+// HTTPWellKnownConfig holds configuration for the HTTP well-known module.
+type HTTPWellKnownConfig struct {
+	Paths       []string      `mapstructure:"paths"`         // Endpoints requested from each service
+	MaxBodySize int           `mapstructure:"max_body_size"` // Bytes of each endpoint's content kept
+	Timeout     time.Duration `mapstructure:"timeout"`       // Timeout for each request
+	Concurrency int           `mapstructure:"concurrency"`   // Number of services probed concurrently
+}
+
+// HTTPWellKnownEndpoint is a metadata endpoint an HTTP service serves.
+type HTTPWellKnownEndpoint struct {
+	Path        string `json:"path"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Location    string `json:"location,omitempty"` // Redirect target, for change-password
+	Content     string `json:"content,omitempty"`  // Up to max_body_size bytes
+}
+
+// SecurityTxt holds the fields of a security.txt file (RFC 9116).
+type SecurityTxt struct {
+	Contacts           []string  `json:"contacts,omitempty"`
+	Expires            time.Time `json:"expires,omitempty"`
+	Encryption         []string  `json:"encryption,omitempty"`
+	Acknowledgments    []string  `json:"acknowledgments,omitempty"`
+	PreferredLanguages string    `json:"preferred_languages,omitempty"`
+	Canonical          []string  `json:"canonical,omitempty"`
+	Policy             []string  `json:"policy,omitempty"`
+	Hiring             []string  `json:"hiring,omitempty"`
+	Signed             bool      `json:"signed"` // Wrapped in an OpenPGP cleartext signature
+}
+
+// RobotsTxt holds the rules of a robots.txt file, for all user agents.
+type RobotsTxt struct {
+	Disallow []string `json:"disallow,omitempty"`
+	Allow    []string `json:"allow,omitempty"`
+	Sitemaps []string `json:"sitemaps,omitempty"`
+}
+
+// HTTPWellKnownResult holds the metadata endpoints an HTTP service serves.
+// This is the 'Data' in ModuleOutput with DataKey "http.wellknown.details".
+type HTTPWellKnownResult struct {
+	Target      string                  `json:"target"`
+	Port        int                     `json:"port"`
+	URL         string                  `json:"url"` // Base URL of the service
+	Endpoints   []HTTPWellKnownEndpoint `json:"endpoints"`
+	SecurityTxt *SecurityTxt            `json:"security_txt,omitempty"`
+	RobotsTxt   *RobotsTxt              `json:"robots_txt,omitempty"`
+}
+
+// Endpoint returns the served endpoint at path, or nil.
+func (r HTTPWellKnownResult) Endpoint(path string) *HTTPWellKnownEndpoint {
+	for i := range r.Endpoints {
+		if r.Endpoints[i].Path == path {
+			return &r.Endpoints[i]
+		}
+	}
+	return nil
+}
+
+// PathList returns the paths of the served endpoints, one per line, as
+// plugins match on them.
+func (r HTTPWellKnownResult) PathList() string {
+	var b strings.Builder
+	for _, e := range r.Endpoints {
+		b.WriteString(e.Path)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// HTTPWellKnownModule requests security.txt, robots.txt and other metadata
+// endpoints from HTTP services, reporting who to contact about security
+// issues and the paths the site asks crawlers to avoid.
+type HTTPWellKnownModule struct {
+	meta   engine.ModuleMetadata
+	config HTTPWellKnownConfig
+	logger zerolog.Logger
+}
+
+// newHTTPWellKnownModule is the internal constructor for the
+// HTTPWellKnownModule.
+func newHTTPWellKnownModule() *HTTPWellKnownModule {
+	defaultConfig := HTTPWellKnownConfig{
+		Paths:       defaultWellKnownPaths,
+		MaxBodySize: 32 << 10,
+		Timeout:     5 * time.Second,
+		Concurrency: 10,
+	}
+
+	return &HTTPWellKnownModule{
+		meta: engine.ModuleMetadata{
+			ID:          httpWellKnownModuleID,
+			Name:        httpWellKnownModuleName,
+			Version:     "0.1.0",
+			Description: "Fetches security.txt, robots.txt and other well-known metadata endpoints from HTTP services and reports their security contacts, policies and disallowed paths.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "http", "wellknown", "recon"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "service.banner.tcp",
+					DataTypeName: "scan.BannerGrabResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of TCP banners; services answering with an HTTP status line are probed.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "http.wellknown.details",
+					DataTypeName: "scan.HTTPWellKnownResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Metadata endpoints served by each HTTP service, with the parsed security.txt and robots.txt.",
+				},
+				{
+					Key:          "http.wellknown.paths",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Metadata endpoints served, one per line, for plugin evaluation (e.g., '/robots.txt').",
+				},
+				{
+					Key:          "http.security_txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Content of the security.txt file for plugin evaluation.",
+				},
+				{
+					Key:          "http.security_txt.contact",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Contacts from security.txt, one per line, for plugin evaluation (e.g., 'mailto:security@example.com').",
+				},
+				{
+					Key:          "http.robots_txt",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Content of the robots.txt file for plugin evaluation.",
+				},
+				{
+					Key:          "http.robots_txt.disallow",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Paths disallowed by robots.txt, one per line, for plugin evaluation (e.g., '/admin/').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"paths":         {Description: "Metadata endpoints requested from each HTTP service.", Type: "[]string", Required: false, Default: defaultConfig.Paths},
+				"max_body_size": {Description: "Bytes of each endpoint's content kept and parsed.", Type: "int", Required: false, Default: defaultConfig.MaxBodySize},
+				"timeout":       {Description: "Timeout for each request (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency":   {Description: "Number of services probed concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 2,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *HTTPWellKnownModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *HTTPWellKnownModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["paths"]; ok {
+		paths, err := cast.ToStringSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid paths %v: %w", v, err)
+		}
+		cfg.Paths = paths
+	}
+	if v, ok := configMap["max_body_size"]; ok {
+		cfg.MaxBodySize = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	for _, path := range cfg.Paths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("invalid path %q: must start with '/'", path)
+		}
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = 32 << 10
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute probes the HTTP services found in 'service.banner.tcp'.
+// Services that serve none of the endpoints produce no output.
+func (m *HTTPWellKnownModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var banners []interface{}
+	switch raw := inputs["service.banner.tcp"].(type) {
+	case []interface{}:
+		banners = raw
+	case []BannerGrabResult:
+		for _, item := range raw {
+			banners = append(banners, item)
+		}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("input 'service.banner.tcp' is not a list, type: %T", raw)
+	}
+
+	services := httpServices(ctx, banners, false)
+	if len(services) == 0 {
+		m.logger.Debug().Msg("No HTTP services")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(svc httpService) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if result, ok := m.probe(ctx, svc); ok {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(*svc)
+	}
+	wg.Wait()
+	return nil
+}
+
+// probe requests the configured endpoints from svc and parses the
+// security.txt and robots.txt it serves.
+func (m *HTTPWellKnownModule) probe(ctx context.Context, svc httpService) (HTTPWellKnownResult, bool) {
+	scheme := "http"
+	if svc.TLS {
+		scheme = "https"
+	}
+	base := scheme + "://" + net.JoinHostPort(svc.Target, strconv.Itoa(svc.Port))
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+			},
+			// #nosec G402 -- services are probed by address, not by the names their certificates carry
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	defer client.CloseIdleConnections()
+
+	result := HTTPWellKnownResult{Target: svc.Target, Port: svc.Port, URL: base}
+	for _, path := range m.config.Paths {
+		if ctx.Err() != nil {
+			break
+		}
+		if path == legacySecurityTxtPath && result.SecurityTxt != nil {
+			// The legacy location only matters without the well-known one
+			continue
+		}
+
+		endpoint, err := m.get(ctx, client, base, path)
+		if err != nil {
+			// The request itself failed; the remaining paths would too
+			m.logger.Debug().Str("url", base+path).Err(err).Msg("Request failed")
+			break
+		}
+		if !wellKnownServed(endpoint) {
+			continue
+		}
+		result.Endpoints = append(result.Endpoints, *endpoint)
+
+		switch path {
+		case securityTxtPath, legacySecurityTxtPath:
+			result.SecurityTxt = parseSecurityTxt(endpoint.Content)
+		case robotsTxtPath:
+			result.RobotsTxt = parseRobotsTxt(endpoint.Content)
+		}
+	}
+
+	if len(result.Endpoints) == 0 {
+		return HTTPWellKnownResult{}, false
+	}
+	m.logger.Info().Str("url", base).Int("endpoints", len(result.Endpoints)).Msg("Collected well-known endpoints")
+	return result, true
+}
+
+// get requests path from the service at base.
+func (m *HTTPWellKnownModule) get(ctx context.Context, client *http.Client, base, path string) (*HTTPWellKnownEndpoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(m.config.MaxBodySize)))
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPWellKnownEndpoint{
+		Path:        path,
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Location:    resp.Header.Get("Location"),
+		Content:     string(body),
+	}, nil
+}
+
+// wellKnownServed reports whether e is the endpoint it was requested as,
+// not an error or catch-all page. The metadata endpoints are text, JSON
+// or XML, while sites answering any path do so with HTML; change-password
+// is a redirect to the site's password change form.
+func wellKnownServed(e *HTTPWellKnownEndpoint) bool {
+	if e.Path == changePasswordPath {
+		return e.Status >= 300 && e.Status < 400 && e.Location != ""
+	}
+	if e.Status != http.StatusOK || strings.TrimSpace(e.Content) == "" {
+		return false
+	}
+	if strings.HasPrefix(strings.ToLower(e.ContentType), "text/html") {
+		return false
+	}
+	head := strings.ToLower(strings.TrimSpace(e.Content))
+	return !strings.HasPrefix(head, "<!doctype html") && !strings.HasPrefix(head, "<html")
+}
+
+// parseSecurityTxt parses the fields of a security.txt file. Unknown
+// fields and the OpenPGP signature are ignored.
+func parseSecurityTxt(content string) *SecurityTxt {
+	txt := &SecurityTxt{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "-----BEGIN PGP SIGNED MESSAGE-----":
+			txt.Signed = true
+			continue
+		case line == "-----BEGIN PGP SIGNATURE-----":
+			return txt
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "contact":
+			txt.Contacts = append(txt.Contacts, value)
+		case "expires":
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				txt.Expires = t.UTC()
+			}
+		case "encryption":
+			txt.Encryption = append(txt.Encryption, value)
+		case "acknowledgments", "acknowledgements":
+			txt.Acknowledgments = append(txt.Acknowledgments, value)
+		case "preferred-languages":
+			txt.PreferredLanguages = value
+		case "canonical":
+			txt.Canonical = append(txt.Canonical, value)
+		case "policy":
+			txt.Policy = append(txt.Policy, value)
+		case "hiring":
+			txt.Hiring = append(txt.Hiring, value)
+		}
+	}
+	return txt
+}
+
+// parseRobotsTxt parses the rules of a robots.txt file that apply to all
+// user agents ('*'), and its sitemaps.
+func parseRobotsTxt(content string) *RobotsTxt {
+	robots := &RobotsTxt{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	applies, inAgents := false, false
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "user-agent":
+			// Consecutive user-agent lines share the rules that follow
+			if !inAgents {
+				applies = false
+			}
+			inAgents = true
+			applies = applies || value == "*"
+		case "disallow":
+			inAgents = false
+			if applies && value != "" {
+				robots.Disallow = append(robots.Disallow, value)
+			}
+		case "allow":
+			inAgents = false
+			if applies && value != "" {
+				robots.Allow = append(robots.Allow, value)
+			}
+		case "sitemap":
+			if value != "" {
+				robots.Sitemaps = append(robots.Sitemaps, value)
+			}
+		}
+	}
+	// Groups for several user agents often repeat the same rules
+	robots.Disallow = slices.Compact(slices.Sorted(slices.Values(robots.Disallow)))
+	robots.Allow = slices.Compact(slices.Sorted(slices.Values(robots.Allow)))
+	return robots
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *HTTPWellKnownModule) emit(ctx context.Context, out output.Output, result HTTPWellKnownResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		msg := fmt.Sprintf("HTTP well-known endpoints: %s - %s", result.URL, strings.TrimSpace(strings.ReplaceAll(result.PathList(), "\n", " ")))
+		if result.SecurityTxt != nil && len(result.SecurityTxt.Contacts) > 0 {
+			msg += ", security contact: " + strings.Join(result.SecurityTxt.Contacts, ", ")
+		}
+		out.Diag(output.LevelNormal, msg, nil)
+	}
+
+	outputs := []engine.ModuleOutput{
+		{DataKey: "http.wellknown.details", Data: result},
+		{DataKey: "http.wellknown.paths", Data: result.PathList()},
+	}
+	if result.SecurityTxt != nil {
+		security := result.Endpoint(securityTxtPath)
+		if security == nil {
+			security = result.Endpoint(legacySecurityTxtPath)
+		}
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "http.security_txt", Data: security.Content})
+		if len(result.SecurityTxt.Contacts) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "http.security_txt.contact", Data: strings.Join(result.SecurityTxt.Contacts, "\n") + "\n"})
+		}
+	}
+	if result.RobotsTxt != nil {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "http.robots_txt", Data: result.Endpoint(robotsTxtPath).Content})
+		if len(result.RobotsTxt.Disallow) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "http.robots_txt.disallow", Data: strings.Join(result.RobotsTxt.Disallow, "\n") + "\n"})
+		}
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// HTTPWellKnownModuleFactory creates a new HTTPWellKnownModule instance.
+func HTTPWellKnownModuleFactory() engine.Module {
+	return newHTTPWellKnownModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(httpWellKnownModuleName, HTTPWellKnownModuleFactory)
+}
@@ -0,0 +1,166 @@
+// pkg/modules/scan/http_wellknown_test.go
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+)
+
+const testSecurityTxt = `-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+# Our security policy
+Contact: mailto:security@example.com
+Contact: https://example.com/report
+Expires: 2027-01-31T23:00:00.000Z
+Encryption: https://example.com/pgp-key.txt
+Policy: https://example.com/disclosure
+Preferred-Languages: en, de
+-----BEGIN PGP SIGNATURE-----
+Contact: mailto:ignored@example.com
+-----END PGP SIGNATURE-----
+`
+
+const testRobotsTxt = `User-agent: Googlebot
+Disallow: /search
+
+User-agent: Bingbot
+User-agent: *
+Disallow: /admin/ # staff only
+Disallow: /backup/
+Allow: /admin/help
+Disallow:
+
+User-agent: *
+Disallow: /admin/
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func runHTTPWellKnownModule(t *testing.T, banners []interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newHTTPWellKnownModule()
+	require.NoError(t, module.Init("http_wellknown", config))
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"service.banner.tcp": banners}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func TestHTTPWellKnownModule_Execute(t *testing.T) {
+	var legacy atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/security.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = fmt.Fprint(w, testSecurityTxt)
+		case "/security.txt":
+			legacy.Add(1)
+		case "/robots.txt":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = fmt.Fprint(w, testRobotsTxt)
+		case "/.well-known/change-password":
+			http.Redirect(w, r, "/account/password", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	outputs := runHTTPWellKnownModule(t, []interface{}{banner, banner}, nil)
+	require.Len(t, outputs, 6, "duplicate banners are probed once")
+	require.Zero(t, legacy.Load(), "the legacy location is skipped after the well-known one")
+	byKey := outputsByKey(outputs)
+
+	result := byKey["http.wellknown.details"].(HTTPWellKnownResult)
+	require.Equal(t, server.URL, result.URL)
+	require.Equal(t, "/.well-known/security.txt\n/robots.txt\n/.well-known/change-password\n", byKey["http.wellknown.paths"])
+	require.Equal(t, "/account/password", result.Endpoint("/.well-known/change-password").Location)
+
+	require.Equal(t, &SecurityTxt{
+		Contacts:           []string{"mailto:security@example.com", "https://example.com/report"},
+		Expires:            time.Date(2027, 1, 31, 23, 0, 0, 0, time.UTC),
+		Encryption:         []string{"https://example.com/pgp-key.txt"},
+		Policy:             []string{"https://example.com/disclosure"},
+		PreferredLanguages: "en, de",
+		Signed:             true,
+	}, result.SecurityTxt)
+	require.Equal(t, testSecurityTxt, byKey["http.security_txt"])
+	require.Equal(t, "mailto:security@example.com\nhttps://example.com/report\n", byKey["http.security_txt.contact"])
+
+	require.Equal(t, &RobotsTxt{
+		Disallow: []string{"/admin/", "/backup/"},
+		Allow:    []string{"/admin/help"},
+		Sitemaps: []string{"https://example.com/sitemap.xml"},
+	}, result.RobotsTxt)
+	require.Equal(t, testRobotsTxt, byKey["http.robots_txt"])
+	require.Equal(t, "/admin/\n/backup/\n", byKey["http.robots_txt.disallow"])
+}
+
+func TestHTTPWellKnownModule_Execute_CatchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/security.txt":
+			_, _ = fmt.Fprint(w, "Contact: mailto:psirt@example.com\n")
+		case "/humans.txt":
+			// Single-page apps answer any path with their index page
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = fmt.Fprint(w, "<!DOCTYPE html><html><div id=app></div></html>")
+		case "/.well-known/change-password":
+			_, _ = fmt.Fprint(w, "<html>Change your password</html>")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = fmt.Fprint(w, "<div id=app></div>")
+		}
+	}))
+	defer server.Close()
+
+	outputs := runHTTPWellKnownModule(t, []interface{}{httpBanner(t, server)}, nil)
+	byKey := outputsByKey(outputs)
+	require.Equal(t, "/security.txt\n", byKey["http.wellknown.paths"], "the legacy location counts without the well-known one")
+	require.Equal(t, "mailto:psirt@example.com\n", byKey["http.security_txt.contact"])
+	require.NotContains(t, byKey, "http.robots_txt")
+}
+
+func TestHTTPWellKnownModule_Execute_NotServed(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	require.Empty(t, runHTTPWellKnownModule(t, []interface{}{httpBanner(t, server)}, nil))
+
+	// Unreachable services are given up after the first failure
+	server.Close()
+	require.Empty(t, runHTTPWellKnownModule(t, []interface{}{httpBanner(t, server)}, nil))
+}
+
+func TestHTTPWellKnownModule_Init(t *testing.T) {
+	module := newHTTPWellKnownModule()
+	require.NoError(t, module.Init("http_wellknown", map[string]interface{}{
+		"paths":         []interface{}{"/robots.txt"},
+		"max_body_size": 0,
+		"timeout":       "2s",
+		"concurrency":   0,
+	}))
+	require.Equal(t, []string{"/robots.txt"}, module.config.Paths)
+	require.Equal(t, 32<<10, module.config.MaxBodySize)
+	require.Equal(t, 2*time.Second, module.config.Timeout)
+	require.Equal(t, 1, module.config.Concurrency)
+
+	require.Error(t, newHTTPWellKnownModule().Init("http_wellknown", map[string]interface{}{"paths": []interface{}{"robots.txt"}}))
+	require.Error(t, newHTTPWellKnownModule().Init("http_wellknown", map[string]interface{}{"timeout": "soon"}))
+}
@@ -0,0 +1,459 @@
+// pkg/modules/scan/smb_enum.go
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/ntlm"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/smb"
+)
+
+const (
+	smbEnumModuleID   = "smb-enum-instance"
+	smbEnumModuleName = "smb-enum"
+)
+
+// SMBEnumConfig holds configuration for the SMB enumeration module.
+type SMBEnumConfig struct {
+	Ports       []int         `mapstructure:"ports"`       // Open TCP ports treated as SMB
+	Timeout     time.Duration `mapstructure:"timeout"`     // Timeout for connecting and each request
+	Concurrency int           `mapstructure:"concurrency"` // Number of servers enumerated concurrently
+	MaxShares   int           `mapstructure:"max_shares"`  // Disk shares tested for anonymous access
+}
+
+// SMBShare is a share listed by an SMB server.
+type SMBShare struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"` // disk, printer, device, ipc or unknown
+	Remark  string `json:"remark,omitempty"`
+	Special bool   `json:"special,omitempty"` // Administrative share such as C$
+	// Anonymous is set when the share could be connected to over the
+	// anonymous session.
+	Anonymous bool `json:"anonymous,omitempty"`
+}
+
+// SMBResult holds what was learned about an SMB server.
+// This is the 'Data' in ModuleOutput with DataKey "service.smb.details".
+type SMBResult struct {
+	Target          string   `json:"target"`
+	Port            int      `json:"port"`
+	Dialect         string   `json:"dialect,omitempty"`  // Highest dialect negotiated
+	Dialects        []string `json:"dialects,omitempty"` // Every SMB 2/3 dialect accepted
+	SMB1            bool     `json:"smb1"`
+	SigningEnabled  bool     `json:"signing_enabled"`
+	SigningRequired bool     `json:"signing_required"`
+	// AnonymousSession is set when a null session was accepted; Guest
+	// when the server mapped it to the guest account instead.
+	AnonymousSession bool       `json:"anonymous_session"`
+	Guest            bool       `json:"guest,omitempty"`
+	OSVersion        string     `json:"os_version,omitempty"` // e.g. '10.0.17763', from the NTLM challenge
+	NetBIOSComputer  string     `json:"netbios_computer,omitempty"`
+	NetBIOSDomain    string     `json:"netbios_domain,omitempty"`
+	DNSComputer      string     `json:"dns_computer,omitempty"`
+	DNSDomain        string     `json:"dns_domain,omitempty"`
+	ServerTime       *time.Time `json:"server_time,omitempty"`
+	Shares           []SMBShare `json:"shares,omitempty"`
+	Error            string     `json:"error,omitempty"`
+}
+
+// ShareList returns the share names one per line, the form plugins match
+// against in smb.shares.
+func (r SMBResult) ShareList() string {
+	var sb strings.Builder
+	for _, share := range r.Shares {
+		sb.WriteString(share.Name + "\n")
+	}
+	return sb.String()
+}
+
+// AnonymousShareList returns the names of the shares readable over the
+// anonymous session one per line, for smb.anonymous_shares.
+func (r SMBResult) AnonymousShareList() string {
+	var sb strings.Builder
+	for _, share := range r.Shares {
+		if share.Anonymous {
+			sb.WriteString(share.Name + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// smbClient is the part of smb.Client the module uses.
+type smbClient interface {
+	Info() smb.NegotiateInfo
+	LoginAnonymous(ctx context.Context) (*ntlm.Challenge, error)
+	Guest() bool
+	ListShares(ctx context.Context) ([]smb.Share, error)
+	TreeConnect(ctx context.Context, share string) (smb.Tree, error)
+	TreeDisconnect(ctx context.Context, tree smb.Tree) error
+	Close() error
+}
+
+// SMBEnumModule negotiates with SMB servers and reports their dialects,
+// signing requirements, operating system and anonymously readable shares.
+type SMBEnumModule struct {
+	meta   engine.ModuleMetadata
+	config SMBEnumConfig
+	logger zerolog.Logger
+
+	dial          func(ctx context.Context, addr string, opts smb.Options) (smbClient, error)
+	probeDialects func(ctx context.Context, addr string, opts smb.Options) ([]smb.Dialect, error)
+	supportsSMB1  func(ctx context.Context, addr string, opts smb.Options) (bool, error)
+}
+
+// newSMBEnumModule is the internal constructor for the SMBEnumModule.
+func newSMBEnumModule() *SMBEnumModule {
+	defaultConfig := SMBEnumConfig{
+		Ports:       []int{445},
+		Timeout:     smb.DefaultTimeout,
+		Concurrency: 10,
+		MaxShares:   50,
+	}
+
+	return &SMBEnumModule{
+		meta: engine.ModuleMetadata{
+			ID:          smbEnumModuleID,
+			Name:        smbEnumModuleName,
+			Version:     "0.1.0",
+			Description: "Negotiates with SMB servers to report dialects, SMB1 and signing support, OS version and shares readable over an anonymous session.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "smb", "tcp"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "discovery.open_tcp_ports",
+					DataTypeName: "discovery.TCPPortDiscoveryResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of open TCP ports; the configured SMB ports are enumerated.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "service.smb.details",
+					DataTypeName: "scan.SMBResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Data learned from each SMB server, or the error.",
+				},
+				{
+					Key:          "smb.dialects",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Accepted SMB 2/3 dialects, one per line, for plugin evaluation (e.g., '2.0.2').",
+				},
+				{
+					Key:          "smb.smb1",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Whether the server accepts SMB1 for plugin evaluation.",
+				},
+				{
+					Key:          "smb.signing_required",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Whether the server requires message signing for plugin evaluation.",
+				},
+				{
+					Key:          "smb.anonymous",
+					DataTypeName: "bool",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Whether the server accepts anonymous or guest sessions for plugin evaluation.",
+				},
+				{
+					Key:          "smb.shares",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Share names, one per line, for plugin evaluation.",
+				},
+				{
+					Key:          "smb.anonymous_shares",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Shares readable over the anonymous session, one per line, for plugin evaluation.",
+				},
+				{
+					Key:          "smb.os_version",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Windows version and build from the NTLM challenge for plugin evaluation (e.g., '10.0.17763').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"ports":       {Description: "Open TCP ports treated as SMB.", Type: "[]int", Required: false, Default: defaultConfig.Ports},
+				"timeout":     {Description: "Timeout for connecting and each request (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency": {Description: "Number of servers enumerated concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+				"max_shares":  {Description: "Disk shares tested for anonymous access per server.", Type: "int", Required: false, Default: defaultConfig.MaxShares},
+			},
+			EstimatedCost: 2,
+		},
+		config: defaultConfig,
+		dial: func(ctx context.Context, addr string, opts smb.Options) (smbClient, error) {
+			return smb.Dial(ctx, addr, nil, opts)
+		},
+		probeDialects: smb.ProbeDialects,
+		supportsSMB1:  smb.SupportsSMB1,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *SMBEnumModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *SMBEnumModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["ports"]; ok {
+		ports, err := cast.ToIntSliceE(v)
+		if err != nil {
+			return fmt.Errorf("invalid ports %v: %w", v, err)
+		}
+		cfg.Ports = ports
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_shares"]; ok {
+		cfg.MaxShares = cast.ToInt(v)
+	}
+
+	for _, port := range cfg.Ports {
+		if port <= 0 || port > 65535 {
+			return fmt.Errorf("invalid port %d", port)
+		}
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = smb.DefaultTimeout
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxShares < 0 {
+		cfg.MaxShares = 0
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute enumerates the SMB servers on the configured ports found in
+// 'discovery.open_tcp_ports'. Ports that turn out not to speak SMB
+// produce no output.
+func (m *SMBEnumModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var tasks []TargetPortData
+	seen := make(map[string]bool)
+	if list, ok := inputs["discovery.open_tcp_ports"].([]interface{}); ok {
+		for _, item := range list {
+			result, ok := item.(discovery.TCPPortDiscoveryResult)
+			if !ok {
+				continue
+			}
+			for _, port := range result.OpenPorts {
+				addr := net.JoinHostPort(result.Target, strconv.Itoa(port))
+				if seen[addr] || !slices.Contains(m.config.Ports, port) {
+					continue
+				}
+				seen[addr] = true
+				tasks = append(tasks, TargetPortData{Target: result.Target, Port: port})
+			}
+		}
+	}
+	if len(tasks) == 0 {
+		m.logger.Debug().Msg("No open SMB ports")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(task TargetPortData) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, isSMB := m.collect(ctx, task.Target, task.Port)
+			if isSMB {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(task)
+	}
+	wg.Wait()
+	return nil
+}
+
+// collect enumerates the server at target:port. It reports whether the
+// port speaks SMB at all.
+func (m *SMBEnumModule) collect(ctx context.Context, target string, port int) (SMBResult, bool) {
+	result := SMBResult{Target: target, Port: port}
+	addr := net.JoinHostPort(target, strconv.Itoa(port))
+	opts := smb.Options{
+		Timeout: m.config.Timeout,
+		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+		},
+	}
+
+	dialects, err := m.probeDialects(ctx, addr, opts)
+	if err != nil {
+		m.logger.Debug().Str("target", addr).Err(err).Msg("Could not probe SMB dialects")
+		return result, false
+	}
+	for _, d := range dialects {
+		result.Dialects = append(result.Dialects, d.String())
+	}
+	if result.SMB1, err = m.supportsSMB1(ctx, addr, opts); err != nil {
+		m.logger.Debug().Str("target", addr).Err(err).Msg("Could not probe SMB1")
+	}
+	if len(dialects) == 0 {
+		return result, result.SMB1
+	}
+
+	client, err := m.dial(ctx, addr, opts)
+	if err != nil {
+		result.Error = err.Error()
+		return result, true
+	}
+	defer func() { _ = client.Close() }()
+
+	info := client.Info()
+	result.Dialect = info.Dialect.String()
+	result.SigningEnabled = info.SigningEnabled
+	result.SigningRequired = info.SigningRequired
+	if !info.SystemTime.IsZero() {
+		result.ServerTime = &info.SystemTime
+	}
+
+	challenge, err := client.LoginAnonymous(ctx)
+	if challenge != nil {
+		setSMBChallenge(&result, challenge)
+	}
+	if err != nil {
+		// A refused null session is the expected, hardened answer
+		m.logger.Debug().Str("target", addr).Err(err).Msg("Anonymous SMB session refused")
+		if !errors.Is(err, smb.StatusLogonFailure) && !errors.Is(err, smb.StatusAccessDenied) && ctx.Err() == nil {
+			result.Error = err.Error()
+		}
+		return result, true
+	}
+	result.AnonymousSession = true
+	result.Guest = client.Guest()
+
+	shares, err := client.ListShares(ctx)
+	if err != nil {
+		m.logger.Debug().Str("target", addr).Err(err).Msg("Could not list SMB shares")
+	}
+	tested := 0
+	for _, share := range shares {
+		s := SMBShare{Name: share.Name, Type: share.Kind(), Remark: share.Remark, Special: share.Special()}
+		if s.Type == "disk" && tested < m.config.MaxShares {
+			tested++
+			if tree, err := client.TreeConnect(ctx, share.Name); err == nil {
+				s.Anonymous = true
+				_ = client.TreeDisconnect(ctx, tree)
+			}
+		}
+		result.Shares = append(result.Shares, s)
+	}
+
+	m.logger.Info().Str("target", addr).Str("dialect", result.Dialect).Bool("signing_required", result.SigningRequired).
+		Int("shares", len(result.Shares)).Msg("Enumerated SMB server")
+	return result, true
+}
+
+func setSMBChallenge(result *SMBResult, challenge *ntlm.Challenge) {
+	if challenge.Version != nil {
+		result.OSVersion = challenge.Version.String()
+	}
+	result.NetBIOSComputer = challenge.NetBIOSComputer
+	result.NetBIOSDomain = challenge.NetBIOSDomain
+	result.DNSComputer = challenge.DNSComputer
+	result.DNSDomain = challenge.DNSDomain
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *SMBEnumModule) emit(ctx context.Context, out output.Output, result SMBResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		if result.Error != "" {
+			out.Diag(output.LevelVerbose, fmt.Sprintf("SMB enumeration failed: %s:%d - %s", result.Target, result.Port, result.Error), nil)
+		} else {
+			out.Diag(output.LevelNormal, fmt.Sprintf("SMB server: %s:%d (%s) - signing required: %t, anonymous: %t, %d shares",
+				result.Target, result.Port, result.Dialect, result.SigningRequired, result.AnonymousSession, len(result.Shares)), nil)
+		}
+	}
+
+	outputs := []engine.ModuleOutput{
+		{DataKey: "service.smb.details", Data: result},
+		{DataKey: "smb.smb1", Data: result.SMB1},
+	}
+	if len(result.Dialects) > 0 {
+		outputs = append(outputs,
+			engine.ModuleOutput{DataKey: "smb.dialects", Data: strings.Join(result.Dialects, "\n") + "\n"},
+			engine.ModuleOutput{DataKey: "smb.signing_required", Data: result.SigningRequired},
+			engine.ModuleOutput{DataKey: "smb.anonymous", Data: result.AnonymousSession},
+		)
+	}
+	if result.OSVersion != "" {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "smb.os_version", Data: result.OSVersion})
+	}
+	if len(result.Shares) > 0 {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "smb.shares", Data: result.ShareList()})
+		if anonymous := result.AnonymousShareList(); anonymous != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "smb.anonymous_shares", Data: anonymous})
+		}
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SMBEnumModuleFactory creates a new SMBEnumModule instance.
+func SMBEnumModuleFactory() engine.Module {
+	return newSMBEnumModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(smbEnumModuleName, SMBEnumModuleFactory)
+}
@@ -0,0 +1,238 @@
+// pkg/modules/scan/smb_enum_test.go
+package scan
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/ntlm"
+	"github.com/vulntor/vulntor/pkg/smb"
+)
+
+// fakeSMBServer answers like an SMB server; anonymous sessions are
+// refused with loginErr and readable lists the shares they may connect to.
+type fakeSMBServer struct {
+	dialects []smb.Dialect
+	smb1     bool
+	info     smb.NegotiateInfo
+	loginErr error
+	guest    bool
+	shares   []smb.Share
+	readable []string
+
+	mu        sync.Mutex
+	connected []string
+}
+
+type fakeSMBClient struct {
+	server *fakeSMBServer
+}
+
+func (s *fakeSMBServer) install(m *SMBEnumModule) {
+	m.probeDialects = func(context.Context, string, smb.Options) ([]smb.Dialect, error) {
+		return s.dialects, nil
+	}
+	m.supportsSMB1 = func(context.Context, string, smb.Options) (bool, error) {
+		return s.smb1, nil
+	}
+	m.dial = func(context.Context, string, smb.Options) (smbClient, error) {
+		return &fakeSMBClient{server: s}, nil
+	}
+}
+
+func (c *fakeSMBClient) Info() smb.NegotiateInfo { return c.server.info }
+
+func (c *fakeSMBClient) LoginAnonymous(context.Context) (*ntlm.Challenge, error) {
+	challenge := &ntlm.Challenge{
+		Version:         &ntlm.Version{Major: 10, Minor: 0, Build: 17763},
+		NetBIOSComputer: "FS01",
+		NetBIOSDomain:   "CORP",
+		DNSComputer:     "fs01.corp.example",
+		DNSDomain:       "corp.example",
+	}
+	return challenge, c.server.loginErr
+}
+
+func (c *fakeSMBClient) Guest() bool { return c.server.guest }
+
+func (c *fakeSMBClient) ListShares(context.Context) ([]smb.Share, error) {
+	return c.server.shares, nil
+}
+
+func (c *fakeSMBClient) TreeConnect(_ context.Context, share string) (smb.Tree, error) {
+	c.server.mu.Lock()
+	defer c.server.mu.Unlock()
+	c.server.connected = append(c.server.connected, share)
+	if !slices.Contains(c.server.readable, share) {
+		return smb.Tree{}, smb.StatusAccessDenied
+	}
+	return smb.Tree{ID: 1, Type: 1}, nil
+}
+
+func (c *fakeSMBClient) TreeDisconnect(context.Context, smb.Tree) error { return nil }
+
+func (c *fakeSMBClient) Close() error { return nil }
+
+func runSMBEnumModule(t *testing.T, server *fakeSMBServer, inputs map[string]interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newSMBEnumModule()
+	require.NoError(t, module.Init("smb_enum", config))
+	server.install(module)
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), inputs, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func openTCPPorts(target string, ports ...int) map[string]interface{} {
+	return map[string]interface{}{
+		"discovery.open_tcp_ports": []interface{}{discovery.TCPPortDiscoveryResult{Target: target, OpenPorts: ports}},
+	}
+}
+
+func TestSMBEnumModule_Execute_AnonymousShares(t *testing.T) {
+	server := &fakeSMBServer{
+		dialects: []smb.Dialect{smb.Dialect202, smb.Dialect210, smb.Dialect300},
+		smb1:     true,
+		info:     smb.NegotiateInfo{Dialect: smb.Dialect300, SigningEnabled: true},
+		shares: []smb.Share{
+			{Name: "ADMIN$", Type: 0x80000000, Remark: "Remote Admin"},
+			{Name: "IPC$", Type: 0x80000003, Remark: "Remote IPC"},
+			{Name: "public", Type: smb.ShareTypeDisk, Remark: "Public files"},
+			{Name: "finance", Type: smb.ShareTypeDisk},
+		},
+		readable: []string{"public"},
+	}
+
+	outputs := runSMBEnumModule(t, server, openTCPPorts("192.0.2.10", 22, 445), nil)
+	byKey := outputsByKey(outputs)
+
+	result, ok := byKey["service.smb.details"].(SMBResult)
+	require.True(t, ok)
+	require.Empty(t, result.Error)
+	require.Equal(t, 445, result.Port)
+	require.Equal(t, "3.0", result.Dialect)
+	require.Equal(t, []string{"2.0.2", "2.1", "3.0"}, result.Dialects)
+	require.True(t, result.SMB1)
+	require.True(t, result.SigningEnabled)
+	require.False(t, result.SigningRequired)
+	require.True(t, result.AnonymousSession)
+	require.Equal(t, "10.0.17763", result.OSVersion)
+	require.Equal(t, "FS01", result.NetBIOSComputer)
+	require.Equal(t, "corp.example", result.DNSDomain)
+	require.Equal(t, []SMBShare{
+		{Name: "ADMIN$", Type: "disk", Remark: "Remote Admin", Special: true},
+		{Name: "IPC$", Type: "ipc", Remark: "Remote IPC", Special: true},
+		{Name: "public", Type: "disk", Remark: "Public files", Anonymous: true},
+		{Name: "finance", Type: "disk"},
+	}, result.Shares)
+
+	// Only disk shares are connected to
+	require.Equal(t, []string{"ADMIN$", "public", "finance"}, server.connected)
+
+	require.Equal(t, "2.0.2\n2.1\n3.0\n", byKey["smb.dialects"])
+	require.Equal(t, true, byKey["smb.smb1"])
+	require.Equal(t, false, byKey["smb.signing_required"])
+	require.Equal(t, true, byKey["smb.anonymous"])
+	require.Equal(t, "10.0.17763", byKey["smb.os_version"])
+	require.Equal(t, "ADMIN$\nIPC$\npublic\nfinance\n", byKey["smb.shares"])
+	require.Equal(t, "public\n", byKey["smb.anonymous_shares"])
+	for _, o := range outputs {
+		require.Equal(t, "192.0.2.10", o.Target)
+	}
+}
+
+func TestSMBEnumModule_Execute_Hardened(t *testing.T) {
+	server := &fakeSMBServer{
+		dialects: []smb.Dialect{smb.Dialect300, smb.Dialect302, smb.Dialect311},
+		info:     smb.NegotiateInfo{Dialect: smb.Dialect311, SigningEnabled: true, SigningRequired: true},
+		loginErr: smb.StatusAccessDenied,
+	}
+
+	byKey := outputsByKey(runSMBEnumModule(t, server, openTCPPorts("192.0.2.10", 445), nil))
+
+	// A refused null session is not an error, and still names the host
+	result := byKey["service.smb.details"].(SMBResult)
+	require.Empty(t, result.Error)
+	require.False(t, result.AnonymousSession)
+	require.True(t, result.SigningRequired)
+	require.Equal(t, "fs01.corp.example", result.DNSComputer)
+	require.Equal(t, false, byKey["smb.anonymous"])
+	require.Equal(t, false, byKey["smb.smb1"])
+	require.NotContains(t, byKey, "smb.shares")
+	require.NotContains(t, byKey, "smb.anonymous_shares")
+}
+
+func TestSMBEnumModule_Execute_NotSMB(t *testing.T) {
+	// Ports that do not speak SMB produce nothing
+	server := &fakeSMBServer{}
+	require.Empty(t, runSMBEnumModule(t, server, openTCPPorts("192.0.2.10", 445), nil))
+
+	// Unreachable servers produce nothing
+	module := newSMBEnumModule()
+	require.NoError(t, module.Init("smb_enum", nil))
+	server.install(module)
+	module.probeDialects = func(context.Context, string, smb.Options) ([]smb.Dialect, error) {
+		return nil, &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	}
+	outputChan := make(chan engine.ModuleOutput, 8)
+	require.NoError(t, module.Execute(context.Background(), openTCPPorts("192.0.2.10", 445), outputChan))
+	require.Empty(t, outputChan)
+
+	// SMB1-only servers are reported without SMB 2 details
+	server = &fakeSMBServer{smb1: true}
+	outputs := runSMBEnumModule(t, server, openTCPPorts("192.0.2.10", 139), map[string]interface{}{"ports": []interface{}{139, 445}})
+	byKey := outputsByKey(outputs)
+	require.Len(t, outputs, 2)
+	require.Equal(t, true, byKey["smb.smb1"])
+	require.Empty(t, byKey["service.smb.details"].(SMBResult).Dialect)
+}
+
+func TestSMBEnumModule_Execute_MaxShares(t *testing.T) {
+	server := &fakeSMBServer{
+		dialects: []smb.Dialect{smb.Dialect210},
+		info:     smb.NegotiateInfo{Dialect: smb.Dialect210},
+		guest:    true,
+		shares:   []smb.Share{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		readable: []string{"a", "b", "c"},
+	}
+
+	byKey := outputsByKey(runSMBEnumModule(t, server, openTCPPorts("192.0.2.10", 445), map[string]interface{}{"max_shares": 2}))
+
+	result := byKey["service.smb.details"].(SMBResult)
+	require.True(t, result.Guest)
+	require.Len(t, result.Shares, 3)
+	require.Equal(t, []string{"a", "b"}, server.connected)
+	require.Equal(t, "a\nb\n", byKey["smb.anonymous_shares"])
+}
+
+func TestSMBEnumModule_Init(t *testing.T) {
+	module := newSMBEnumModule()
+	require.NoError(t, module.Init("smb_enum", map[string]interface{}{
+		"ports":       []interface{}{445, "139"},
+		"timeout":     "2s",
+		"concurrency": 0,
+		"max_shares":  -1,
+	}))
+	require.Equal(t, []int{445, 139}, module.config.Ports)
+	require.Equal(t, "2s", module.config.Timeout.String())
+	require.Equal(t, 1, module.config.Concurrency)
+	require.Equal(t, 0, module.config.MaxShares)
+
+	require.Error(t, newSMBEnumModule().Init("smb_enum", map[string]interface{}{"ports": []interface{}{70000}}))
+	require.Error(t, newSMBEnumModule().Init("smb_enum", map[string]interface{}{"timeout": "soon"}))
+}
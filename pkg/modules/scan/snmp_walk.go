@@ -0,0 +1,595 @@
+// pkg/modules/scan/snmp_walk.go
+package scan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/egress"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/netutil"
+	"github.com/vulntor/vulntor/pkg/output"
+	"github.com/vulntor/vulntor/pkg/snmp"
+)
+
+const (
+	snmpWalkModuleID   = "snmp-walk-instance"
+	snmpWalkModuleName = "snmp-walk"
+)
+
+// MIB-II system group, ifTable columns and HOST-RESOURCES-MIB installed
+// software names.
+var (
+	snmpSysDescr    = snmp.MustParseOID("1.3.6.1.2.1.1.1.0")
+	snmpSysObjectID = snmp.MustParseOID("1.3.6.1.2.1.1.2.0")
+	snmpSysUpTime   = snmp.MustParseOID("1.3.6.1.2.1.1.3.0")
+	snmpSysContact  = snmp.MustParseOID("1.3.6.1.2.1.1.4.0")
+	snmpSysName     = snmp.MustParseOID("1.3.6.1.2.1.1.5.0")
+	snmpSysLocation = snmp.MustParseOID("1.3.6.1.2.1.1.6.0")
+
+	snmpIfDescr       = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.2")
+	snmpIfType        = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.3")
+	snmpIfSpeed       = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.5")
+	snmpIfPhysAddress = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.6")
+	snmpIfAdminStatus = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.7")
+	snmpIfOperStatus  = snmp.MustParseOID("1.3.6.1.2.1.2.2.1.8")
+
+	snmpSWInstalledName = snmp.MustParseOID("1.3.6.1.2.1.25.6.3.1.2")
+)
+
+// errSNMPRowLimit stops a walk once MaxRows rows were read.
+var errSNMPRowLimit = errors.New("row limit reached")
+
+// SNMPWalkConfig holds configuration for the SNMP walk module.
+type SNMPWalkConfig struct {
+	Port        int           `mapstructure:"port"`        // Agent UDP port
+	Timeout     time.Duration `mapstructure:"timeout"`     // Timeout for each request attempt
+	Retries     int           `mapstructure:"retries"`     // Retransmissions after a timeout
+	Concurrency int           `mapstructure:"concurrency"` // Number of hosts queried concurrently
+	MaxRows     int           `mapstructure:"max_rows"`    // Rows read per table column
+	Communities []string      `mapstructure:"communities"` // SNMPv2c communities tried when no credential applies
+}
+
+// SNMPInterface is a row of the agent's ifTable.
+type SNMPInterface struct {
+	Index       int    `json:"index"`
+	Name        string `json:"name,omitempty"`
+	Type        int    `json:"type,omitempty"`
+	Speed       uint64 `json:"speed,omitempty"` // Bits per second
+	MAC         string `json:"mac,omitempty"`
+	AdminStatus string `json:"admin_status,omitempty"`
+	OperStatus  string `json:"oper_status,omitempty"`
+}
+
+// SNMPResult holds what was read from an SNMP agent.
+// This is the 'Data' in ModuleOutput with DataKey "service.snmp.details".
+type SNMPResult struct {
+	Target     string `json:"target"`
+	Port       int    `json:"port"`
+	Version    string `json:"version,omitempty"`
+	Credential string `json:"credential,omitempty"` // Name of the credential that was accepted
+	// Community is set when one of the module's default communities, such
+	// as "public", was accepted; configured credentials are never recorded.
+	Community   string          `json:"community,omitempty"`
+	SysDescr    string          `json:"sys_descr,omitempty"`
+	SysObjectID string          `json:"sys_object_id,omitempty"`
+	SysUpTime   uint64          `json:"sys_uptime,omitempty"` // Hundredths of a second
+	SysName     string          `json:"sys_name,omitempty"`
+	SysContact  string          `json:"sys_contact,omitempty"`
+	SysLocation string          `json:"sys_location,omitempty"`
+	Interfaces  []SNMPInterface `json:"interfaces,omitempty"`
+	Software    []string        `json:"software,omitempty"`
+	Error       string          `json:"error,omitempty"`
+}
+
+// SoftwareList returns the installed software names one per line, the
+// form plugins match against in snmp.software.
+func (r SNMPResult) SoftwareList() string {
+	var sb strings.Builder
+	for _, name := range r.Software {
+		sb.WriteString(name + "\n")
+	}
+	return sb.String()
+}
+
+// snmpClient is the part of snmp.Client the module uses.
+type snmpClient interface {
+	Get(ctx context.Context, oids ...snmp.OID) ([]snmp.Variable, error)
+	Walk(ctx context.Context, root snmp.OID, fn func(snmp.Variable) error) error
+	Close() error
+}
+
+// snmpLogin is a way to query an agent: a configured credential or one
+// of the module's default communities.
+type snmpLogin struct {
+	credential string
+	community  string
+	config     snmp.Config
+}
+
+// SNMPWalkModule queries SNMP agents for their system description,
+// interfaces and installed software.
+type SNMPWalkModule struct {
+	meta   engine.ModuleMetadata
+	config SNMPWalkConfig
+	logger zerolog.Logger
+
+	dial func(ctx context.Context, addr string, cfg snmp.Config) (snmpClient, error)
+}
+
+// newSNMPWalkModule is the internal constructor for the SNMPWalkModule.
+func newSNMPWalkModule() *SNMPWalkModule {
+	defaultConfig := SNMPWalkConfig{
+		Port:        161,
+		Timeout:     snmp.DefaultTimeout,
+		Retries:     snmp.DefaultRetries,
+		Concurrency: 20,
+		MaxRows:     1000,
+		Communities: []string{"public"},
+	}
+
+	return &SNMPWalkModule{
+		meta: engine.ModuleMetadata{
+			ID:          snmpWalkModuleID,
+			Name:        snmpWalkModuleName,
+			Version:     "0.1.0",
+			Description: "Queries SNMP agents with community strings or SNMPv3 users for the system description, interfaces and installed software.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "snmp", "udp"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "config.targets",
+					DataTypeName: "[]string",
+					Cardinality:  engine.CardinalitySingle,
+					IsOptional:   true,
+					Description:  "Initial targets, queried when no live hosts were discovered.",
+				},
+				{
+					Key:          "discovery.live_hosts",
+					DataTypeName: "discovery.ICMPPingDiscoveryResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of live hosts whose SNMP agents are queried.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "service.snmp.details",
+					DataTypeName: "scan.SNMPResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Data read from each SNMP agent that answered.",
+				},
+				{
+					Key:          "snmp.sys_descr",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "System description for plugin evaluation (e.g., 'Cisco IOS Software, ... Version 15.0(2)SE11').",
+				},
+				{
+					Key:          "snmp.sys_object_id",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Vendor object ID for plugin evaluation (e.g., '1.3.6.1.4.1.9.1.1208').",
+				},
+				{
+					Key:          "snmp.software",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Installed software names, one per line, for plugin evaluation.",
+				},
+				{
+					Key:          "snmp.community",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Default community accepted by the agent for plugin evaluation (e.g., 'public').",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"port":        {Description: "Agent UDP port.", Type: "int", Required: false, Default: defaultConfig.Port},
+				"timeout":     {Description: "Timeout for each request attempt (e.g., '2s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"retries":     {Description: "Retransmissions after a timeout.", Type: "int", Required: false, Default: defaultConfig.Retries},
+				"concurrency": {Description: "Number of hosts queried concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+				"max_rows":    {Description: "Rows read per table column.", Type: "int", Required: false, Default: defaultConfig.MaxRows},
+				"communities": {Description: "SNMPv2c communities tried on hosts no SNMP credential applies to.", Type: "[]string", Required: false, Default: defaultConfig.Communities},
+			},
+			EstimatedCost: 2,
+		},
+		config: defaultConfig,
+		dial: func(ctx context.Context, addr string, cfg snmp.Config) (snmpClient, error) {
+			cfg.Dial = capture.Dial(blackout.Dial(fdbudget.Dial(egress.Dial(&net.Dialer{}))))
+			return snmp.Dial(ctx, addr, cfg)
+		},
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *SNMPWalkModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *SNMPWalkModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["port"]; ok {
+		cfg.Port = cast.ToInt(v)
+	}
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["retries"]; ok {
+		cfg.Retries = cast.ToInt(v)
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+	if v, ok := configMap["max_rows"]; ok {
+		cfg.MaxRows = cast.ToInt(v)
+	}
+	if v, ok := configMap["communities"]; ok {
+		cfg.Communities = cast.ToStringSlice(v)
+	}
+
+	if cfg.Port <= 0 || cfg.Port > 65535 {
+		return fmt.Errorf("invalid port %d", cfg.Port)
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = snmp.DefaultTimeout
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxRows <= 0 {
+		cfg.MaxRows = 1000
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute queries the SNMP agent of each live host, falling back to
+// 'config.targets' when discovery found none. Hosts whose agent does not
+// answer produce no output.
+func (m *SNMPWalkModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	targets := snmpWalkTargets(inputs)
+	if len(targets) == 0 {
+		m.logger.Debug().Msg("No targets to query")
+		return nil
+	}
+	creds := credentials.FromContext(ctx)
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, target := range targets {
+		logins := m.logins(creds.SNMP(target))
+		if len(logins) == 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(target string, logins []snmpLogin) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result, answered := m.collect(ctx, target, logins)
+			if answered {
+				m.emit(ctx, out, result, outputChan)
+			}
+		}(target, logins)
+	}
+	wg.Wait()
+	return nil
+}
+
+// snmpWalkTargets returns the live hosts in inputs, or the expanded
+// 'config.targets' when there are none.
+func snmpWalkTargets(inputs map[string]interface{}) []string {
+	var hosts []string
+	switch raw := inputs["discovery.live_hosts"].(type) {
+	case discovery.ICMPPingDiscoveryResult:
+		hosts = append(hosts, raw.LiveHosts...)
+	case []interface{}:
+		for _, item := range raw {
+			if result, ok := item.(discovery.ICMPPingDiscoveryResult); ok {
+				hosts = append(hosts, result.LiveHosts...)
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		switch raw := inputs["config.targets"].(type) {
+		case []string:
+			hosts = netutil.ParseAndExpandTargets(raw)
+		case []interface{}:
+			hosts = netutil.ParseAndExpandTargets(cast.ToStringSlice(raw))
+		}
+	}
+
+	seen := make(map[string]bool, len(hosts))
+	targets := hosts[:0]
+	for _, host := range hosts {
+		if !seen[host] {
+			seen[host] = true
+			targets = append(targets, host)
+		}
+	}
+	return targets
+}
+
+// logins returns the ways to query an agent: the SNMP credentials that
+// apply to it, or else the default communities.
+func (m *SNMPWalkModule) logins(creds []credentials.SNMP) []snmpLogin {
+	base := snmp.Config{Timeout: m.config.Timeout, Retries: m.config.Retries}
+
+	var logins []snmpLogin
+	for _, cred := range creds {
+		cfg := base
+		cfg.Community = cred.Community
+		cfg.Username = cred.Username
+		cfg.AuthPassword = cred.AuthPassword
+		cfg.PrivPassword = cred.PrivPassword
+		var err error
+		if cfg.Version, err = snmp.ParseVersion(cred.Version); err == nil {
+			if cfg.AuthProtocol, err = snmp.ParseAuthProtocol(cred.AuthProtocol); err == nil {
+				cfg.PrivProtocol, err = snmp.ParsePrivProtocol(cred.PrivProtocol)
+			}
+		}
+		if err != nil {
+			m.logger.Warn().Str("credential", cred.Name).Err(err).Msg("Skipping invalid SNMP credential")
+			continue
+		}
+		logins = append(logins, snmpLogin{credential: cred.Name, config: cfg})
+	}
+	if len(creds) > 0 {
+		return logins
+	}
+
+	for _, community := range m.config.Communities {
+		cfg := base
+		cfg.Version = snmp.Version2c
+		cfg.Community = community
+		logins = append(logins, snmpLogin{community: community, config: cfg})
+	}
+	return logins
+}
+
+// collect queries target with the first login the agent accepts. It
+// reports whether the agent answered at all; a community the agent does
+// not know looks the same as no agent.
+func (m *SNMPWalkModule) collect(ctx context.Context, target string, logins []snmpLogin) (SNMPResult, bool) {
+	result := SNMPResult{Target: target, Port: m.config.Port}
+	addr := net.JoinHostPort(target, strconv.Itoa(m.config.Port))
+
+	var client snmpClient
+	var errs []string
+	for _, login := range logins {
+		c, vars, err := m.login(ctx, addr, login.config)
+		if err != nil {
+			m.logger.Debug().Str("target", addr).Str("credential", login.credential).Err(err).Msg("SNMP query failed")
+			if !errors.Is(err, snmp.ErrNoResponse) && ctx.Err() == nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", login.name(), err))
+			}
+			continue
+		}
+		client = c
+		result.Version = login.config.Version.String()
+		result.Credential = login.credential
+		result.Community = login.community
+		setSNMPSystem(&result, vars)
+		break
+	}
+	if client == nil {
+		result.Error = strings.Join(errs, "; ")
+		return result, len(errs) > 0
+	}
+	defer func() { _ = client.Close() }()
+
+	result.Interfaces = m.interfaces(ctx, client, addr)
+	err := m.walk(ctx, client, snmpSWInstalledName, func(v snmp.Variable) {
+		if name := strings.TrimSpace(v.String()); name != "" {
+			result.Software = append(result.Software, name)
+		}
+	})
+	if err != nil {
+		m.logger.Debug().Str("target", addr).Err(err).Msg("Could not walk installed software")
+	}
+	sort.Strings(result.Software)
+
+	m.logger.Info().Str("target", addr).Str("sys_descr", result.SysDescr).
+		Int("interfaces", len(result.Interfaces)).Int("software", len(result.Software)).Msg("Collected SNMP data")
+	return result, true
+}
+
+func (l snmpLogin) name() string {
+	if l.credential != "" {
+		return l.credential
+	}
+	return "community " + strconv.Quote(l.community)
+}
+
+// login connects to addr and reads the system group, which also tells
+// whether the agent accepts the login.
+func (m *SNMPWalkModule) login(ctx context.Context, addr string, cfg snmp.Config) (snmpClient, []snmp.Variable, error) {
+	client, err := m.dial(ctx, addr, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	vars, err := client.Get(ctx, snmpSysDescr, snmpSysObjectID, snmpSysUpTime, snmpSysContact, snmpSysName, snmpSysLocation)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, err
+	}
+	return client, vars, nil
+}
+
+func setSNMPSystem(result *SNMPResult, vars []snmp.Variable) {
+	for _, v := range vars {
+		if !v.Exists() {
+			continue
+		}
+		switch v.OID.String() {
+		case snmpSysDescr.String():
+			result.SysDescr = strings.TrimSpace(v.String())
+		case snmpSysObjectID.String():
+			result.SysObjectID = v.String()
+		case snmpSysUpTime.String():
+			result.SysUpTime, _ = v.Value.(uint64)
+		case snmpSysContact.String():
+			result.SysContact = v.String()
+		case snmpSysName.String():
+			result.SysName = v.String()
+		case snmpSysLocation.String():
+			result.SysLocation = v.String()
+		}
+	}
+}
+
+// interfaces reads the ifTable columns the result keeps, keyed by ifIndex.
+func (m *SNMPWalkModule) interfaces(ctx context.Context, client snmpClient, addr string) []SNMPInterface {
+	rows := make(map[int]*SNMPInterface)
+	row := func(v snmp.Variable) *SNMPInterface {
+		index := int(v.OID[len(v.OID)-1])
+		if rows[index] == nil {
+			rows[index] = &SNMPInterface{Index: index}
+		}
+		return rows[index]
+	}
+
+	columns := []struct {
+		oid snmp.OID
+		set func(v snmp.Variable)
+	}{
+		{snmpIfDescr, func(v snmp.Variable) { row(v).Name = v.String() }},
+		{snmpIfType, func(v snmp.Variable) { n, _ := v.Value.(int64); row(v).Type = int(n) }},
+		{snmpIfSpeed, func(v snmp.Variable) { row(v).Speed, _ = v.Value.(uint64) }},
+		{snmpIfPhysAddress, func(v snmp.Variable) {
+			if b, ok := v.Value.([]byte); ok && len(b) > 0 {
+				row(v).MAC = net.HardwareAddr(b).String()
+			}
+		}},
+		{snmpIfAdminStatus, func(v snmp.Variable) { row(v).AdminStatus = snmpIfStatus(v) }},
+		{snmpIfOperStatus, func(v snmp.Variable) { row(v).OperStatus = snmpIfStatus(v) }},
+	}
+	for _, column := range columns {
+		if err := m.walk(ctx, client, column.oid, column.set); err != nil {
+			m.logger.Debug().Str("target", addr).Str("oid", column.oid.String()).Err(err).Msg("Could not walk interface table")
+			break
+		}
+	}
+
+	interfaces := make([]SNMPInterface, 0, len(rows))
+	for _, iface := range rows {
+		interfaces = append(interfaces, *iface)
+	}
+	sort.Slice(interfaces, func(i, j int) bool { return interfaces[i].Index < interfaces[j].Index })
+	return interfaces
+}
+
+// walk calls fn for at most MaxRows rows under root. Reaching the limit
+// is not an error.
+func (m *SNMPWalkModule) walk(ctx context.Context, client snmpClient, root snmp.OID, fn func(snmp.Variable)) error {
+	rows := 0
+	err := client.Walk(ctx, root, func(v snmp.Variable) error {
+		if rows >= m.config.MaxRows {
+			return errSNMPRowLimit
+		}
+		rows++
+		fn(v)
+		return nil
+	})
+	if errors.Is(err, errSNMPRowLimit) {
+		return nil
+	}
+	return err
+}
+
+// snmpIfStatus names an ifAdminStatus or ifOperStatus value.
+func snmpIfStatus(v snmp.Variable) string {
+	statuses := []string{"", "up", "down", "testing", "unknown", "dormant", "notPresent", "lowerLayerDown"}
+	if n, ok := v.Value.(int64); ok && n > 0 && int(n) < len(statuses) {
+		return statuses[n]
+	}
+	return v.String()
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *SNMPWalkModule) emit(ctx context.Context, out output.Output, result SNMPResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		if result.Error != "" {
+			out.Diag(output.LevelVerbose, fmt.Sprintf("SNMP query failed: %s:%d - %s", result.Target, result.Port, result.Error), nil)
+		} else {
+			out.Diag(output.LevelNormal, fmt.Sprintf("SNMP agent: %s:%d (v%s) - %s, %d interfaces, %d software entries",
+				result.Target, result.Port, result.Version, result.SysDescr, len(result.Interfaces), len(result.Software)), nil)
+		}
+	}
+
+	outputs := []engine.ModuleOutput{{DataKey: "service.snmp.details", Data: result}}
+	if result.Error == "" {
+		if result.SysDescr != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "snmp.sys_descr", Data: result.SysDescr})
+		}
+		if result.SysObjectID != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "snmp.sys_object_id", Data: result.SysObjectID})
+		}
+		if len(result.Software) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "snmp.software", Data: result.SoftwareList()})
+		}
+		if result.Community != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "snmp.community", Data: result.Community})
+		}
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SNMPWalkModuleFactory creates a new SNMPWalkModule instance.
+func SNMPWalkModuleFactory() engine.Module {
+	return newSNMPWalkModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(snmpWalkModuleName, SNMPWalkModuleFactory)
+}
@@ -0,0 +1,281 @@
+// pkg/modules/scan/snmp_walk_test.go
+package scan
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/snmp"
+)
+
+// fakeSNMPAgent answers like an agent serving mib to logins accepted by
+// allow; other logins get no response.
+type fakeSNMPAgent struct {
+	mib   []snmp.Variable
+	allow func(cfg snmp.Config) error
+
+	mu    sync.Mutex
+	dials []snmp.Config
+}
+
+type fakeSNMPClient struct {
+	agent *fakeSNMPAgent
+	err   error
+}
+
+func (a *fakeSNMPAgent) dial(_ context.Context, _ string, cfg snmp.Config) (snmpClient, error) {
+	a.mu.Lock()
+	a.dials = append(a.dials, cfg)
+	a.mu.Unlock()
+	return &fakeSNMPClient{agent: a, err: a.allow(cfg)}, nil
+}
+
+func (c *fakeSNMPClient) Get(_ context.Context, oids ...snmp.OID) ([]snmp.Variable, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	vars := make([]snmp.Variable, 0, len(oids))
+	for _, oid := range oids {
+		v := snmp.Variable{OID: oid, Type: snmp.TypeNoSuchObject}
+		for _, obj := range c.agent.mib {
+			if obj.OID.Compare(oid) == 0 {
+				v = obj
+			}
+		}
+		vars = append(vars, v)
+	}
+	return vars, nil
+}
+
+func (c *fakeSNMPClient) Walk(_ context.Context, root snmp.OID, fn func(snmp.Variable) error) error {
+	if c.err != nil {
+		return c.err
+	}
+	for _, obj := range c.agent.mib {
+		if obj.OID.HasPrefix(root) && len(obj.OID) > len(root) {
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *fakeSNMPClient) Close() error { return nil }
+
+func octets(oid, value string) snmp.Variable {
+	return snmp.Variable{OID: snmp.MustParseOID(oid), Type: snmp.TypeOctetString, Value: []byte(value)}
+}
+
+func integer(oid string, value int64) snmp.Variable {
+	return snmp.Variable{OID: snmp.MustParseOID(oid), Type: snmp.TypeInteger, Value: value}
+}
+
+func newFakeSNMPAgent(allow func(cfg snmp.Config) error) *fakeSNMPAgent {
+	mib := []snmp.Variable{
+		octets("1.3.6.1.2.1.1.1.0", "Cisco IOS Software, C2960 Software (C2960-LANBASEK9-M), Version 15.0(2)SE11"),
+		{OID: snmp.MustParseOID("1.3.6.1.2.1.1.2.0"), Type: snmp.TypeObjectIdentifier, Value: snmp.MustParseOID("1.3.6.1.4.1.9.1.1208")},
+		{OID: snmp.MustParseOID("1.3.6.1.2.1.1.3.0"), Type: snmp.TypeTimeTicks, Value: uint64(8640000)},
+		octets("1.3.6.1.2.1.1.5.0", "sw-core-01"),
+		octets("1.3.6.1.2.1.1.6.0", "DC1 rack 4"),
+		octets("1.3.6.1.2.1.2.2.1.2.1", "Vlan1"),
+		octets("1.3.6.1.2.1.2.2.1.2.10101", "GigabitEthernet0/1"),
+		integer("1.3.6.1.2.1.2.2.1.3.1", 53),
+		integer("1.3.6.1.2.1.2.2.1.3.10101", 6),
+		{OID: snmp.MustParseOID("1.3.6.1.2.1.2.2.1.5.10101"), Type: snmp.TypeGauge32, Value: uint64(1000000000)},
+		{OID: snmp.MustParseOID("1.3.6.1.2.1.2.2.1.6.10101"), Type: snmp.TypeOctetString, Value: []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}},
+		integer("1.3.6.1.2.1.2.2.1.7.1", 1),
+		integer("1.3.6.1.2.1.2.2.1.7.10101", 1),
+		integer("1.3.6.1.2.1.2.2.1.8.1", 1),
+		integer("1.3.6.1.2.1.2.2.1.8.10101", 2),
+		octets("1.3.6.1.2.1.25.6.3.1.2.1", "openssl-3.0.2"),
+		octets("1.3.6.1.2.1.25.6.3.1.2.2", "net-snmp-5.9.1"),
+	}
+	sort.Slice(mib, func(i, j int) bool { return mib[i].OID.Compare(mib[j].OID) < 0 })
+	return &fakeSNMPAgent{mib: mib, allow: allow}
+}
+
+func runSNMPWalkModule(t *testing.T, agent *fakeSNMPAgent, creds *credentials.Store, inputs map[string]interface{}, config map[string]interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newSNMPWalkModule()
+	require.NoError(t, module.Init("snmp_walk", config))
+	module.dial = agent.dial
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	ctx := credentials.WithContext(context.Background(), creds)
+	require.NoError(t, module.Execute(ctx, inputs, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func liveHosts(hosts ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"discovery.live_hosts": []interface{}{discovery.ICMPPingDiscoveryResult{LiveHosts: hosts}},
+	}
+}
+
+func TestSNMPWalkModule_Execute_DefaultCommunity(t *testing.T) {
+	agent := newFakeSNMPAgent(func(cfg snmp.Config) error {
+		if cfg.Version == snmp.Version2c && cfg.Community == "public" {
+			return nil
+		}
+		return snmp.ErrNoResponse
+	})
+
+	outputs := runSNMPWalkModule(t, agent, nil, liveHosts("192.0.2.1"), map[string]interface{}{"communities": []interface{}{"private", "public"}})
+	byKey := outputsByKey(outputs)
+
+	result, ok := byKey["service.snmp.details"].(SNMPResult)
+	require.True(t, ok)
+	require.Empty(t, result.Error)
+	require.Equal(t, "192.0.2.1", result.Target)
+	require.Equal(t, 161, result.Port)
+	require.Equal(t, "2c", result.Version)
+	require.Equal(t, "public", result.Community)
+	require.Equal(t, "sw-core-01", result.SysName)
+	require.Equal(t, "DC1 rack 4", result.SysLocation)
+	require.Equal(t, uint64(8640000), result.SysUpTime)
+	require.Equal(t, []SNMPInterface{
+		{Index: 1, Name: "Vlan1", Type: 53, AdminStatus: "up", OperStatus: "up"},
+		{Index: 10101, Name: "GigabitEthernet0/1", Type: 6, Speed: 1000000000, MAC: "00:1a:2b:3c:4d:5e", AdminStatus: "up", OperStatus: "down"},
+	}, result.Interfaces)
+	require.Equal(t, []string{"net-snmp-5.9.1", "openssl-3.0.2"}, result.Software)
+
+	require.Equal(t, "Cisco IOS Software, C2960 Software (C2960-LANBASEK9-M), Version 15.0(2)SE11", byKey["snmp.sys_descr"])
+	require.Equal(t, "1.3.6.1.4.1.9.1.1208", byKey["snmp.sys_object_id"])
+	require.Equal(t, "net-snmp-5.9.1\nopenssl-3.0.2\n", byKey["snmp.software"])
+	require.Equal(t, "public", byKey["snmp.community"])
+	for _, o := range outputs {
+		require.Equal(t, "192.0.2.1", o.Target)
+	}
+}
+
+func TestSNMPWalkModule_Execute_Credentials(t *testing.T) {
+	agent := newFakeSNMPAgent(func(cfg snmp.Config) error {
+		if cfg.Version != snmp.Version3 {
+			return snmp.ErrNoResponse
+		}
+		if cfg.AuthPassword != "authpass123" {
+			return &snmp.ReportError{OID: snmp.MustParseOID("1.3.6.1.6.3.15.1.1.5.0")}
+		}
+		return nil
+	})
+	creds := credentials.NewStore(credentials.Logins{SNMP: []credentials.SNMP{
+		{Name: "stale", Version: "3", Username: "monitor", AuthProtocol: "SHA", AuthPassword: "oldpass123"},
+		{Name: "noc", Version: "3", Username: "monitor", AuthProtocol: "SHA256", AuthPassword: "authpass123", PrivProtocol: "AES", PrivPassword: "privpass123"},
+	}})
+
+	byKey := outputsByKey(runSNMPWalkModule(t, agent, creds, liveHosts("192.0.2.1"), nil))
+
+	result := byKey["service.snmp.details"].(SNMPResult)
+	require.Empty(t, result.Error)
+	require.Equal(t, "3", result.Version)
+	require.Equal(t, "noc", result.Credential)
+	require.Empty(t, result.Community)
+	require.NotContains(t, byKey, "snmp.community")
+
+	// Configured credentials replace the default communities
+	require.Len(t, agent.dials, 2)
+	last := agent.dials[1]
+	require.Equal(t, snmp.AuthSHA256, last.AuthProtocol)
+	require.Equal(t, snmp.PrivAES, last.PrivProtocol)
+	require.Equal(t, "monitor", last.Username)
+}
+
+func TestSNMPWalkModule_Execute_Errors(t *testing.T) {
+	agent := newFakeSNMPAgent(func(cfg snmp.Config) error {
+		if cfg.Username == "" {
+			return snmp.ErrNoResponse
+		}
+		return &snmp.ReportError{OID: snmp.MustParseOID("1.3.6.1.6.3.15.1.1.3.0")}
+	})
+
+	// Hosts that do not answer produce nothing
+	require.Empty(t, runSNMPWalkModule(t, agent, nil, liveHosts("192.0.2.1", "192.0.2.2"), nil))
+
+	// Rejected logins are reported
+	creds := credentials.NewStore(credentials.Logins{SNMP: []credentials.SNMP{
+		{Name: "noc", Version: "3", Username: "monitor"},
+	}})
+	outputs := runSNMPWalkModule(t, agent, creds, liveHosts("192.0.2.1"), nil)
+	require.Len(t, outputs, 1)
+	result := outputs[0].Data.(SNMPResult)
+	require.Equal(t, "noc: snmp: unknown user name", result.Error)
+}
+
+func TestSNMPWalkModule_Execute_MaxRows(t *testing.T) {
+	agent := newFakeSNMPAgent(func(snmp.Config) error { return nil })
+
+	byKey := outputsByKey(runSNMPWalkModule(t, agent, nil, liveHosts("192.0.2.1"), map[string]interface{}{"max_rows": 1}))
+
+	// The limit applies to each walked column
+	result := byKey["service.snmp.details"].(SNMPResult)
+	names := 0
+	for _, iface := range result.Interfaces {
+		if iface.Name != "" {
+			names++
+		}
+	}
+	require.Equal(t, 1, names)
+	require.Equal(t, []string{"openssl-3.0.2"}, result.Software)
+}
+
+func TestSNMPWalkTargets(t *testing.T) {
+	require.Equal(t, []string{"192.0.2.1", "192.0.2.2"}, snmpWalkTargets(map[string]interface{}{
+		"discovery.live_hosts": []interface{}{
+			discovery.ICMPPingDiscoveryResult{LiveHosts: []string{"192.0.2.1", "192.0.2.2"}},
+			discovery.ICMPPingDiscoveryResult{LiveHosts: []string{"192.0.2.1"}},
+		},
+		"config.targets": []string{"198.51.100.0/24"},
+	}))
+
+	require.Equal(t, []string{"198.51.100.1", "198.51.100.2"}, snmpWalkTargets(map[string]interface{}{
+		"config.targets": []string{"198.51.100.1", "198.51.100.2"},
+	}))
+	require.Empty(t, snmpWalkTargets(map[string]interface{}{}))
+}
+
+func TestSNMPWalkModule_Init(t *testing.T) {
+	module := newSNMPWalkModule()
+	require.NoError(t, module.Init("snmp_walk", map[string]interface{}{
+		"port":        1161,
+		"timeout":     "500ms",
+		"concurrency": 0,
+		"communities": []interface{}{"public", "private"},
+	}))
+	require.Equal(t, 1161, module.config.Port)
+	require.Equal(t, 500*time.Millisecond, module.config.Timeout)
+	require.Equal(t, 1, module.config.Concurrency)
+	require.Equal(t, []string{"public", "private"}, module.config.Communities)
+
+	require.Error(t, newSNMPWalkModule().Init("x", map[string]interface{}{"timeout": "soon"}))
+	require.Error(t, newSNMPWalkModule().Init("x", map[string]interface{}{"port": 70000}))
+}
+
+func TestSNMPWalkModule_Execute_RealClient(t *testing.T) {
+	// A closed port answers with ICMP port unreachable, which is no agent
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, conn.Close())
+
+	module := newSNMPWalkModule()
+	require.NoError(t, module.Init("snmp_walk", map[string]interface{}{"port": port, "timeout": "200ms"}))
+	outputChan := make(chan engine.ModuleOutput, 4)
+	require.NoError(t, module.Execute(context.Background(), liveHosts("127.0.0.1"), outputChan))
+	require.Empty(t, outputChan)
+}
@@ -0,0 +1,571 @@
+// pkg/modules/scan/ssh_auth.go
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	sshAuthModuleID   = "ssh-auth-collector-instance"
+	sshAuthModuleName = "ssh-auth-collector"
+
+	// Commands run on the target after login. The package listings print
+	// one "name<TAB>version" line per package.
+	sshAuthKernelCommand = "uname -r"
+	sshAuthOSCommand     = "cat /etc/os-release"
+	sshAuthDpkgCommand   = `dpkg-query -W -f='${Status}\t${Package}\t${Version}\n'`
+	sshAuthRPMCommand    = `rpm -qa --qf '%{NAME}\t%{VERSION}-%{RELEASE}\n'`
+)
+
+// SSHAuthConfig holds configuration for the credentialed SSH module.
+type SSHAuthConfig struct {
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"` // Timeout for connecting and logging in
+	CommandTimeout time.Duration `mapstructure:"command_timeout"` // Timeout for each remote command
+	Concurrency    int           `mapstructure:"concurrency"`     // Number of hosts collected from concurrently
+	Files          []string      `mapstructure:"files"`           // Config files read from each host
+	MaxOutput      int           `mapstructure:"max_output"`      // Bytes kept from each command's output
+}
+
+// SSHPackage is a package installed on an SSH host.
+type SSHPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// SSHAuthResult holds what was collected from a host after logging in.
+// This is the 'Data' in ModuleOutput with DataKey "ssh.auth.details".
+type SSHAuthResult struct {
+	Target         string            `json:"target"`
+	Port           int               `json:"port"`
+	Credential     string            `json:"credential,omitempty"` // Name of the credential that logged in
+	Username       string            `json:"username,omitempty"`
+	HostKey        string            `json:"host_key,omitempty"` // SHA256 fingerprint
+	Kernel         string            `json:"kernel,omitempty"`
+	OS             string            `json:"os,omitempty"`
+	PackageManager string            `json:"package_manager,omitempty"`
+	Packages       []SSHPackage      `json:"packages,omitempty"`
+	Files          map[string]string `json:"files,omitempty"` // Path -> content
+	Error          string            `json:"error,omitempty"`
+}
+
+// PackageList returns the packages as "name version" lines, the form
+// plugins match against in ssh.auth.packages.
+func (r SSHAuthResult) PackageList() string {
+	var sb strings.Builder
+	for _, pkg := range r.Packages {
+		sb.WriteString(pkg.Name + " " + pkg.Version + "\n")
+	}
+	return sb.String()
+}
+
+// SSHAuthModule logs in to SSH services with configured credentials and
+// collects the kernel version, installed packages and config files.
+type SSHAuthModule struct {
+	meta   engine.ModuleMetadata
+	config SSHAuthConfig
+	logger zerolog.Logger
+}
+
+// newSSHAuthModule is the internal constructor for the SSHAuthModule.
+func newSSHAuthModule() *SSHAuthModule {
+	defaultConfig := SSHAuthConfig{
+		ConnectTimeout: 10 * time.Second,
+		CommandTimeout: 30 * time.Second,
+		Concurrency:    5,
+		Files:          []string{"/etc/ssh/sshd_config"},
+		MaxOutput:      4 << 20,
+	}
+
+	return &SSHAuthModule{
+		meta: engine.ModuleMetadata{
+			ID:          sshAuthModuleID,
+			Name:        sshAuthModuleName,
+			Version:     "0.1.0",
+			Description: "Logs in to SSH services with configured credentials and collects the kernel version, installed packages and config files.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "ssh", "credentialed"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "service.banner.tcp",
+					DataTypeName: "scan.BannerGrabResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of TCP banners; services answering with an SSH banner are logged in to.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "ssh.auth.details",
+					DataTypeName: "scan.SSHAuthResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Data collected from each SSH service logged in to, or the login error.",
+				},
+				{
+					Key:          "ssh.auth.kernel",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Kernel release for plugin evaluation (e.g., '5.15.0-91-generic').",
+				},
+				{
+					Key:          "ssh.auth.os",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Operating system name for plugin evaluation (e.g., 'Ubuntu 22.04.3 LTS').",
+				},
+				{
+					Key:          "ssh.auth.packages",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "Installed packages as 'name version' lines for plugin evaluation.",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"connect_timeout": {Description: "Timeout for connecting and logging in (e.g., '10s').", Type: "duration", Required: false, Default: defaultConfig.ConnectTimeout.String()},
+				"command_timeout": {Description: "Timeout for each remote command (e.g., '30s').", Type: "duration", Required: false, Default: defaultConfig.CommandTimeout.String()},
+				"concurrency":     {Description: "Number of hosts collected from concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+				"files":           {Description: "Config files read from each host.", Type: "[]string", Required: false, Default: defaultConfig.Files},
+				"max_output":      {Description: "Bytes kept from each command's output.", Type: "int", Required: false, Default: defaultConfig.MaxOutput},
+			},
+			EstimatedCost: 3,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *SSHAuthModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *SSHAuthModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["connect_timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid connect_timeout %v: %w", v, err)
+		}
+		cfg.ConnectTimeout = dur
+	}
+	if v, ok := configMap["command_timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid command_timeout %v: %w", v, err)
+		}
+		cfg.CommandTimeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+	if v, ok := configMap["files"]; ok {
+		cfg.Files = cast.ToStringSlice(v)
+	}
+	if v, ok := configMap["max_output"]; ok {
+		cfg.MaxOutput = cast.ToInt(v)
+	}
+
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = 10 * time.Second
+	}
+	if cfg.CommandTimeout <= 0 {
+		cfg.CommandTimeout = 30 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	if cfg.MaxOutput <= 0 {
+		cfg.MaxOutput = 4 << 20
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// Execute logs in to the SSH services found in 'service.banner.tcp' that a
+// configured credential applies to. Without credentials it does nothing.
+func (m *SSHAuthModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	creds := credentials.FromContext(ctx)
+	if creds.Empty() {
+		m.logger.Debug().Msg("No credentials configured, skipping credentialed SSH collection")
+		return nil
+	}
+
+	var banners []interface{}
+	switch raw := inputs["service.banner.tcp"].(type) {
+	case []interface{}:
+		banners = raw
+	case []BannerGrabResult:
+		for _, item := range raw {
+			banners = append(banners, item)
+		}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("input 'service.banner.tcp' is not a list, type: %T", raw)
+	}
+
+	var tasks []TargetPortData
+	seen := make(map[string]bool)
+	for _, item := range banners {
+		banner, ok := item.(BannerGrabResult)
+		if !ok || banner.Error != "" || !strings.HasPrefix(banner.Banner, "SSH-") {
+			continue
+		}
+		addr := net.JoinHostPort(banner.IP, strconv.Itoa(banner.Port))
+		if seen[addr] || len(creds.SSH(banner.IP)) == 0 {
+			continue
+		}
+		seen[addr] = true
+		tasks = append(tasks, TargetPortData{Target: banner.IP, Port: banner.Port})
+	}
+	if len(tasks) == 0 {
+		m.logger.Debug().Msg("No SSH services with matching credentials")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, task := range tasks {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(task TargetPortData) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := m.collect(ctx, task.Target, task.Port, creds.SSH(task.Target))
+			m.emit(ctx, out, result, outputChan)
+		}(task)
+	}
+	wg.Wait()
+	return nil
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *SSHAuthModule) emit(ctx context.Context, out output.Output, result SSHAuthResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		if result.Error != "" {
+			out.Diag(output.LevelVerbose, fmt.Sprintf("SSH login failed: %s:%d - %s", result.Target, result.Port, result.Error), nil)
+		} else {
+			out.Diag(output.LevelNormal, fmt.Sprintf("SSH login succeeded: %s:%d as %s - %d packages, kernel %s",
+				result.Target, result.Port, result.Username, len(result.Packages), result.Kernel), nil)
+		}
+	}
+
+	outputs := []engine.ModuleOutput{{DataKey: "ssh.auth.details", Data: result}}
+	if result.Error == "" {
+		if result.Kernel != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "ssh.auth.kernel", Data: result.Kernel})
+		}
+		if result.OS != "" {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "ssh.auth.os", Data: result.OS})
+		}
+		if len(result.Packages) > 0 {
+			outputs = append(outputs, engine.ModuleOutput{DataKey: "ssh.auth.packages", Data: result.PackageList()})
+		}
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect logs in to target:port with the first credential that works and
+// gathers the host data.
+func (m *SSHAuthModule) collect(ctx context.Context, target string, port int, creds []credentials.SSH) SSHAuthResult {
+	result := SSHAuthResult{Target: target, Port: port}
+	addr := net.JoinHostPort(target, strconv.Itoa(port))
+
+	var client *ssh.Client
+	var errs []string
+	for _, cred := range creds {
+		c, hostKey, err := m.login(ctx, addr, cred)
+		if hostKey != "" {
+			result.HostKey = hostKey
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", cred.Name, err))
+			m.logger.Debug().Str("target", addr).Str("credential", cred.Name).Err(err).Msg("SSH login failed")
+			continue
+		}
+		client = c
+		result.Credential = cred.Name
+		result.Username = cred.Username
+		break
+	}
+	if client == nil {
+		result.Error = strings.Join(errs, "; ")
+		return result
+	}
+	defer func() { _ = client.Close() }()
+
+	if kernel, err := m.run(ctx, client, sshAuthKernelCommand); err == nil {
+		result.Kernel = strings.TrimSpace(kernel)
+	}
+	if osRelease, err := m.run(ctx, client, sshAuthOSCommand); err == nil {
+		result.OS = parseOSRelease(osRelease)
+	}
+	if listing, err := m.run(ctx, client, sshAuthDpkgCommand); err == nil && strings.TrimSpace(listing) != "" {
+		result.PackageManager = "dpkg"
+		result.Packages = parseDpkgPackages(listing)
+	} else if listing, err := m.run(ctx, client, sshAuthRPMCommand); err == nil && strings.TrimSpace(listing) != "" {
+		result.PackageManager = "rpm"
+		result.Packages = parseRPMPackages(listing)
+	}
+	for _, path := range m.config.Files {
+		content, err := m.run(ctx, client, "cat -- "+shellQuote(path))
+		if err != nil {
+			m.logger.Debug().Str("target", addr).Str("file", path).Err(err).Msg("Could not read file")
+			continue
+		}
+		if result.Files == nil {
+			result.Files = make(map[string]string)
+		}
+		result.Files[path] = content
+	}
+
+	m.logger.Info().Str("target", addr).Str("credential", result.Credential).
+		Int("packages", len(result.Packages)).Str("kernel", result.Kernel).Msg("Collected host data over SSH")
+	return result
+}
+
+// login connects to addr and authenticates with cred. It returns the
+// host key fingerprint whenever the handshake got that far.
+func (m *SSHAuthModule) login(ctx context.Context, addr string, cred credentials.SSH) (*ssh.Client, string, error) {
+	auth, err := sshAuthMethods(cred)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var verify ssh.HostKeyCallback
+	if cred.KnownHosts != "" {
+		if verify, err = knownhosts.New(cred.KnownHosts); err != nil {
+			return nil, "", fmt.Errorf("known_hosts: %w", err)
+		}
+	}
+	var hostKey string
+	config := &ssh.ClientConfig{
+		User: cred.Username,
+		Auth: auth,
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = ssh.FingerprintSHA256(key)
+			if verify != nil {
+				return verify(hostname, remote, key)
+			}
+			return nil
+		},
+		Timeout: m.config.ConnectTimeout,
+	}
+
+	// Blackout windows are waited for outside the connect timeout
+	if err := blackout.FromContext(ctx).Wait(ctx, addr); err != nil {
+		return nil, "", err
+	}
+	dialCtx, cancel := context.WithTimeout(ctx, m.config.ConnectTimeout)
+	defer cancel()
+	conn, err := netproxy.FromContext(ctx).DialContext(dialCtx, &net.Dialer{}, "tcp", addr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Bound the handshake and login; commands have their own timeout
+	_ = conn.SetDeadline(time.Now().Add(m.config.ConnectTimeout))
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, hostKey, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ssh.NewClient(clientConn, chans, reqs), hostKey, nil
+}
+
+// sshAuthMethods returns the auth methods cred supports: public key first,
+// then password and keyboard-interactive.
+func sshAuthMethods(cred credentials.SSH) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if len(cred.PrivateKey) > 0 {
+		var signer ssh.Signer
+		var err error
+		if cred.Passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(cred.PrivateKey, []byte(cred.Passphrase))
+		} else {
+			signer, err = ssh.ParsePrivateKey(cred.PrivateKey)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("private key: %w", err)
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if cred.Password != "" {
+		password := cred.Password
+		methods = append(methods,
+			ssh.Password(password),
+			ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = password
+				}
+				return answers, nil
+			}),
+		)
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no password or private key")
+	}
+	return methods, nil
+}
+
+// run runs cmd in a new session and returns its standard output, cut to
+// MaxOutput bytes. A non-zero exit status is an error.
+func (m *SSHAuthModule) run(ctx context.Context, client *ssh.Client, cmd string) (string, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = session.Close() }()
+
+	stdout := &limitedBuffer{limit: m.config.MaxOutput}
+	session.Stdout = stdout
+
+	done := make(chan error, 1)
+	go func() { done <- session.Run(cmd) }()
+
+	timer := time.NewTimer(m.config.CommandTimeout)
+	defer timer.Stop()
+	select {
+	case err = <-done:
+	case <-timer.C:
+		err = fmt.Errorf("command timed out after %s", m.config.CommandTimeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards
+// the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.Len(); room > 0 {
+		if len(p) > room {
+			b.Buffer.Write(p[:room])
+		} else {
+			b.Buffer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// parseOSRelease returns the operating system name from /etc/os-release.
+func parseOSRelease(content string) string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"'`)
+	}
+	if name := fields["PRETTY_NAME"]; name != "" {
+		return name
+	}
+	return strings.TrimSpace(fields["NAME"] + " " + fields["VERSION_ID"])
+}
+
+// parseDpkgPackages parses the dpkg listing, skipping packages that are
+// not installed (e.g., removed with only their config files left).
+func parseDpkgPackages(listing string) []SSHPackage {
+	var packages []SSHPackage
+	for _, line := range strings.Split(listing, "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) != 3 || !strings.HasSuffix(parts[0], " installed") {
+			continue
+		}
+		packages = append(packages, SSHPackage{Name: parts[1], Version: parts[2]})
+	}
+	sortPackages(packages)
+	return packages
+}
+
+// parseRPMPackages parses the rpm listing.
+func parseRPMPackages(listing string) []SSHPackage {
+	var packages []SSHPackage
+	for _, line := range strings.Split(listing, "\n") {
+		name, version, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok || name == "" {
+			continue
+		}
+		packages = append(packages, SSHPackage{Name: name, Version: version})
+	}
+	sortPackages(packages)
+	return packages
+}
+
+func sortPackages(packages []SSHPackage) {
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name != packages[j].Name {
+			return packages[i].Name < packages[j].Name
+		}
+		return packages[i].Version < packages[j].Version
+	})
+}
+
+// shellQuote quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SSHAuthModuleFactory creates a new SSHAuthModule instance.
+func SSHAuthModuleFactory() engine.Module {
+	return newSSHAuthModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(sshAuthModuleName, SSHAuthModuleFactory)
+}
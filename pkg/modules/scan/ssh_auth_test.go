@@ -0,0 +1,245 @@
+// pkg/modules/scan/ssh_auth_test.go
+package scan
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/engine"
+)
+
+// sshTestCommands are the outputs of the commands the test server knows;
+// other commands exit with status 127.
+var sshTestCommands = map[string]string{
+	sshAuthKernelCommand: "5.15.0-91-generic\n",
+	sshAuthOSCommand:     "NAME=\"Ubuntu\"\nVERSION_ID=\"22.04\"\nPRETTY_NAME=\"Ubuntu 22.04.3 LTS\"\n",
+	sshAuthDpkgCommand: "install ok installed\topenssl\t3.0.2-0ubuntu1.12\n" +
+		"deinstall ok config-files\tapache2\t2.4.52-1ubuntu4\n" +
+		"install ok installed\topenssh-server\t1:8.9p1-3ubuntu0.4\n",
+	"cat -- '/etc/ssh/sshd_config'": "PermitRootLogin yes\n",
+}
+
+// startSSHServer serves sshTestCommands to user "audit" logging in with
+// password "secret" or the public key authorizedKey.
+func startSSHServer(t *testing.T, authorizedKey ssh.PublicKey) (host string, port int) {
+	t.Helper()
+
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	require.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == "audit" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorizedKey != nil && c.User() == "audit" && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	ln := mustListenTCP(t, "127.0.0.1:0")
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSHConn(conn, config)
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func serveSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unsupported")
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer func() { _ = ch.Close() }()
+			for req := range chReqs {
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				var payload struct{ Command string }
+				_ = ssh.Unmarshal(req.Payload, &payload)
+				_ = req.Reply(true, nil)
+
+				status := uint32(0)
+				if out, ok := sshTestCommands[payload.Command]; ok {
+					_, _ = ch.Write([]byte(out))
+				} else {
+					status = 127
+				}
+				exit := make([]byte, 4)
+				binary.BigEndian.PutUint32(exit, status)
+				_, _ = ch.SendRequest("exit-status", false, exit)
+				return
+			}
+		}()
+	}
+}
+
+func runSSHAuthModule(t *testing.T, creds *credentials.Store, banners []interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newSSHAuthModule()
+	require.NoError(t, module.Init("ssh_auth_collector", map[string]interface{}{"connect_timeout": "2s", "command_timeout": "2s"}))
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	ctx := credentials.WithContext(context.Background(), creds)
+	require.NoError(t, module.Execute(ctx, map[string]interface{}{"service.banner.tcp": banners}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func outputsByKey(outputs []engine.ModuleOutput) map[string]interface{} {
+	byKey := make(map[string]interface{}, len(outputs))
+	for _, o := range outputs {
+		byKey[o.DataKey] = o.Data
+	}
+	return byKey
+}
+
+func TestSSHAuthModule_Execute_Password(t *testing.T) {
+	host, port := startSSHServer(t, nil)
+	creds := credentials.NewStore(credentials.Logins{SSH: []credentials.SSH{
+		{Name: "wrong", Username: "audit", Password: "guess"},
+		{Name: "ops", Username: "audit", Password: "secret", Targets: []string{"127.0.0.0/8"}},
+	}})
+	banners := []interface{}{
+		BannerGrabResult{IP: host, Port: port, Banner: "SSH-2.0-OpenSSH_8.9p1 Ubuntu-3ubuntu0.4"},
+		BannerGrabResult{IP: host, Port: 80, Banner: "HTTP/1.1 200 OK"},
+	}
+
+	byKey := outputsByKey(runSSHAuthModule(t, creds, banners))
+
+	result, ok := byKey["ssh.auth.details"].(SSHAuthResult)
+	require.True(t, ok)
+	require.Empty(t, result.Error)
+	require.Equal(t, "ops", result.Credential)
+	require.Equal(t, "audit", result.Username)
+	require.True(t, strings.HasPrefix(result.HostKey, "SHA256:"))
+	require.Equal(t, "dpkg", result.PackageManager)
+	require.Equal(t, []SSHPackage{
+		{Name: "openssh-server", Version: "1:8.9p1-3ubuntu0.4"},
+		{Name: "openssl", Version: "3.0.2-0ubuntu1.12"},
+	}, result.Packages)
+	require.Equal(t, map[string]string{"/etc/ssh/sshd_config": "PermitRootLogin yes\n"}, result.Files)
+
+	require.Equal(t, "5.15.0-91-generic", byKey["ssh.auth.kernel"])
+	require.Equal(t, "Ubuntu 22.04.3 LTS", byKey["ssh.auth.os"])
+	require.Equal(t, "openssh-server 1:8.9p1-3ubuntu0.4\nopenssl 3.0.2-0ubuntu1.12\n", byKey["ssh.auth.packages"])
+}
+
+func TestSSHAuthModule_Execute_PrivateKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte("hunter2"))
+	require.NoError(t, err)
+
+	host, port := startSSHServer(t, sshPub)
+	creds := credentials.NewStore(credentials.Logins{SSH: []credentials.SSH{
+		{Name: "key", Username: "audit", PrivateKey: pem.EncodeToMemory(block), Passphrase: "hunter2"},
+	}})
+
+	byKey := outputsByKey(runSSHAuthModule(t, creds, []interface{}{
+		BannerGrabResult{IP: host, Port: port, Banner: "SSH-2.0-OpenSSH_8.9p1"},
+	}))
+
+	result := byKey["ssh.auth.details"].(SSHAuthResult)
+	require.Empty(t, result.Error)
+	require.Equal(t, "key", result.Credential)
+	require.Equal(t, "5.15.0-91-generic", byKey["ssh.auth.kernel"])
+}
+
+func TestSSHAuthModule_Execute_LoginFailed(t *testing.T) {
+	host, port := startSSHServer(t, nil)
+	creds := credentials.NewStore(credentials.Logins{SSH: []credentials.SSH{{Name: "wrong", Username: "audit", Password: "guess"}}})
+
+	outputs := runSSHAuthModule(t, creds, []interface{}{
+		BannerGrabResult{IP: host, Port: port, Banner: "SSH-2.0-OpenSSH_8.9p1"},
+	})
+
+	require.Len(t, outputs, 1)
+	result := outputs[0].Data.(SSHAuthResult)
+	require.Contains(t, result.Error, "wrong: ")
+	require.Contains(t, result.Error, "unable to authenticate")
+	require.NotEmpty(t, result.HostKey)
+}
+
+func TestSSHAuthModule_Execute_NoMatchingCredentials(t *testing.T) {
+	banners := []interface{}{BannerGrabResult{IP: "192.0.2.10", Port: 22, Banner: "SSH-2.0-OpenSSH_8.9p1"}}
+
+	// Without credentials, and with credentials scoped to other hosts,
+	// nothing is dialed
+	require.Empty(t, runSSHAuthModule(t, nil, banners))
+	creds := credentials.NewStore(credentials.Logins{SSH: []credentials.SSH{{Username: "audit", Password: "secret", Targets: []string{"10.0.0.0/8"}}}})
+	require.Empty(t, runSSHAuthModule(t, creds, banners))
+}
+
+func TestSSHAuthModule_Init(t *testing.T) {
+	module := newSSHAuthModule()
+	require.NoError(t, module.Init("ssh_auth_collector", map[string]interface{}{
+		"connect_timeout": "3s",
+		"concurrency":     0,
+		"files":           []interface{}{"/etc/login.defs"},
+	}))
+	require.Equal(t, 3*time.Second, module.config.ConnectTimeout)
+	require.Equal(t, 1, module.config.Concurrency)
+	require.Equal(t, []string{"/etc/login.defs"}, module.config.Files)
+
+	require.Error(t, newSSHAuthModule().Init("x", map[string]interface{}{"command_timeout": "soon"}))
+}
+
+func TestParseRPMPackages(t *testing.T) {
+	packages := parseRPMPackages("openssl\t3.0.7-25.el9\nbash\t5.1.8-6.el9\n\n")
+	require.Equal(t, []SSHPackage{
+		{Name: "bash", Version: "5.1.8-6.el9"},
+		{Name: "openssl", Version: "3.0.7-25.el9"},
+	}, packages)
+}
+
+func TestShellQuote(t *testing.T) {
+	require.Equal(t, `'/etc/it'\''s'`, shellQuote("/etc/it's"))
+}
@@ -0,0 +1,270 @@
+// pkg/modules/scan/tls_fingerprint.go
+package scan
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cast"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/jarm"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/output"
+)
+
+const (
+	tlsFingerprintModuleID   = "tls-fingerprint-instance"
+	tlsFingerprintModuleName = "tls-fingerprint"
+)
+
+// TLSFingerprintConfig holds configuration for the TLS fingerprint module.
+type TLSFingerprintConfig struct {
+	Timeout     time.Duration `mapstructure:"timeout"`     // Timeout for each of the handshakes
+	Concurrency int           `mapstructure:"concurrency"` // Number of services fingerprinted concurrently
+}
+
+// TLSFingerprintResult holds the fingerprints of a TLS service. This is
+// the 'Data' in ModuleOutput with DataKey "tls.fingerprint.details".
+type TLSFingerprintResult struct {
+	Target     string `json:"target"`
+	Port       int    `json:"port"`
+	JARM       string `json:"jarm"`
+	JA3S       string `json:"ja3s,omitempty"`
+	JA3SString string `json:"ja3s_string,omitempty"` // Version, cipher and extensions hashed into JA3S
+}
+
+// TLSFingerprintModule computes the JARM and JA3S fingerprints of TLS
+// services, which identify the server's TLS stack and configuration
+// behind generic or missing banners.
+type TLSFingerprintModule struct {
+	meta   engine.ModuleMetadata
+	config TLSFingerprintConfig
+	logger zerolog.Logger
+}
+
+// newTLSFingerprintModule is the internal constructor for the
+// TLSFingerprintModule.
+func newTLSFingerprintModule() *TLSFingerprintModule {
+	defaultConfig := TLSFingerprintConfig{
+		Timeout:     5 * time.Second,
+		Concurrency: 10,
+	}
+
+	return &TLSFingerprintModule{
+		meta: engine.ModuleMetadata{
+			ID:          tlsFingerprintModuleID,
+			Name:        tlsFingerprintModuleName,
+			Version:     "0.1.0",
+			Description: "Sends the ten JARM TLS client hellos to TLS services and reports their JARM and JA3S fingerprints, which cluster servers by TLS stack and configuration.",
+			Type:        engine.ScanModuleType,
+			Author:      "Vulntor Team",
+			Tags:        []string{"scan", "tls", "fingerprint", "recon"},
+			Consumes: []engine.DataContractEntry{
+				{
+					Key:          "service.banner.tcp",
+					DataTypeName: "scan.BannerGrabResult",
+					Cardinality:  engine.CardinalityList,
+					IsOptional:   false,
+					Description:  "List of TCP banners; services that completed a TLS handshake are fingerprinted.",
+				},
+			},
+			Produces: []engine.DataContractEntry{
+				{
+					Key:          "tls.fingerprint.details",
+					DataTypeName: "scan.TLSFingerprintResult",
+					Cardinality:  engine.CardinalityList,
+					Description:  "JARM and JA3S fingerprints of each TLS service.",
+				},
+				{
+					Key:          "tls.jarm",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "JARM fingerprint for plugin evaluation (62 hex characters).",
+				},
+				{
+					Key:          "tls.ja3s",
+					DataTypeName: "string",
+					Cardinality:  engine.CardinalityList,
+					Description:  "JA3S fingerprint for plugin evaluation (MD5 hex digest).",
+				},
+			},
+			ConfigSchema: map[string]engine.ParameterDefinition{
+				"timeout":     {Description: "Timeout for each of the ten handshakes (e.g., '5s').", Type: "duration", Required: false, Default: defaultConfig.Timeout.String()},
+				"concurrency": {Description: "Number of services fingerprinted concurrently.", Type: "int", Required: false, Default: defaultConfig.Concurrency},
+			},
+			EstimatedCost: 2,
+		},
+		config: defaultConfig,
+	}
+}
+
+// Metadata returns the module's descriptive metadata.
+func (m *TLSFingerprintModule) Metadata() engine.ModuleMetadata {
+	return m.meta
+}
+
+// Init initializes the module with the given configuration map.
+func (m *TLSFingerprintModule) Init(instanceID string, configMap map[string]interface{}) error {
+	m.meta.ID = instanceID
+	m.logger = log.With().Str("module", m.meta.Name).Str("instance_id", instanceID).Logger()
+
+	cfg := m.config
+	if v, ok := configMap["timeout"]; ok {
+		dur, err := cast.ToDurationE(v)
+		if err != nil {
+			return fmt.Errorf("invalid timeout %v: %w", v, err)
+		}
+		cfg.Timeout = dur
+	}
+	if v, ok := configMap["concurrency"]; ok {
+		cfg.Concurrency = cast.ToInt(v)
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+
+	m.config = cfg
+	m.logger.Debug().Interface("final_config", m.config).Msg("Module initialized.")
+	return nil
+}
+
+// tlsService is a TLS service found by banner grabbing.
+type tlsService struct {
+	Target string
+	Port   int
+}
+
+// Execute fingerprints the TLS services found in 'service.banner.tcp'.
+// Services that answer none of the hellos produce no output.
+func (m *TLSFingerprintModule) Execute(ctx context.Context, inputs map[string]interface{}, outputChan chan<- engine.ModuleOutput) error {
+	var banners []interface{}
+	switch raw := inputs["service.banner.tcp"].(type) {
+	case []interface{}:
+		banners = raw
+	case []BannerGrabResult:
+		for _, item := range raw {
+			banners = append(banners, item)
+		}
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("input 'service.banner.tcp' is not a list, type: %T", raw)
+	}
+
+	var services []tlsService
+	seen := make(map[tlsService]bool)
+	for _, item := range banners {
+		banner, ok := item.(BannerGrabResult)
+		if !ok || !bannerIsTLS(banner) {
+			continue
+		}
+		if !cdn.FromContext(ctx).Allow(banner.IP, false) {
+			continue
+		}
+		svc := tlsService{Target: banner.IP, Port: banner.Port}
+		if !seen[svc] {
+			seen[svc] = true
+			services = append(services, svc)
+		}
+	}
+	if len(services) == 0 {
+		m.logger.Debug().Msg("No TLS services")
+		return nil
+	}
+
+	out, _ := ctx.Value(output.OutputKey).(output.Output)
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return netproxy.FromContext(ctx).DialContext(ctx, &net.Dialer{}, network, addr)
+	}
+	sem := make(chan struct{}, m.config.Concurrency)
+	var wg sync.WaitGroup
+	for _, svc := range services {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(svc tlsService) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			addr := net.JoinHostPort(svc.Target, strconv.Itoa(svc.Port))
+			fp, err := jarm.Fingerprint(ctx, dial, addr, svc.Target, m.config.Timeout)
+			if err != nil {
+				m.logger.Debug().Str("addr", addr).Err(err).Msg("No TLS fingerprint")
+				return
+			}
+			result := TLSFingerprintResult{Target: svc.Target, Port: svc.Port, JARM: fp.JARM, JA3S: fp.JA3S, JA3SString: fp.JA3SString}
+			m.logger.Info().Str("addr", addr).Str("jarm", result.JARM).Str("ja3s", result.JA3S).Msg("Fingerprinted TLS service")
+			m.emit(ctx, out, result, outputChan)
+		}(svc)
+	}
+	wg.Wait()
+	return nil
+}
+
+// bannerIsTLS reports whether banner grabbing completed a TLS handshake
+// with the service. Handshakes after STARTTLS do not count, as the hellos
+// are sent on connect.
+func bannerIsTLS(banner BannerGrabResult) bool {
+	if banner.IsTLS {
+		return true
+	}
+	for _, obs := range banner.Evidence {
+		if obs.TLS != nil && !obs.StartTLS {
+			return true
+		}
+	}
+	return false
+}
+
+// emit sends the outputs for result and reports it to the user.
+func (m *TLSFingerprintModule) emit(ctx context.Context, out output.Output, result TLSFingerprintResult, outputChan chan<- engine.ModuleOutput) {
+	if out != nil {
+		out.Diag(output.LevelNormal, fmt.Sprintf("TLS fingerprint: %s:%d - JARM: %s, JA3S: %s",
+			result.Target, result.Port, result.JARM, result.JA3S), nil)
+	}
+
+	outputs := []engine.ModuleOutput{
+		{DataKey: "tls.fingerprint.details", Data: result},
+		{DataKey: "tls.jarm", Data: result.JARM},
+	}
+	if result.JA3S != "" {
+		outputs = append(outputs, engine.ModuleOutput{DataKey: "tls.ja3s", Data: result.JA3S})
+	}
+
+	for _, o := range outputs {
+		o.FromModuleName = m.meta.ID
+		o.Timestamp = time.Now()
+		o.Target = result.Target
+		select {
+		case outputChan <- o:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// TLSFingerprintModuleFactory creates a new TLSFingerprintModule instance.
+func TLSFingerprintModuleFactory() engine.Module {
+	return newTLSFingerprintModule()
+}
+
+func init() {
+	engine.RegisterModuleFactory(tlsFingerprintModuleName, TLSFingerprintModuleFactory)
+}
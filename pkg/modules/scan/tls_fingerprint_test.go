@@ -0,0 +1,84 @@
+// pkg/modules/scan/tls_fingerprint_test.go
+package scan
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/jarm"
+)
+
+func runTLSFingerprintModule(t *testing.T, banners []interface{}) []engine.ModuleOutput {
+	t.Helper()
+	module := newTLSFingerprintModule()
+	require.NoError(t, module.Init("tls_fingerprint", map[string]interface{}{"timeout": "2s"}))
+
+	outputChan := make(chan engine.ModuleOutput, 32)
+	require.NoError(t, module.Execute(context.Background(), map[string]interface{}{"service.banner.tcp": banners}, outputChan))
+	close(outputChan)
+
+	var outputs []engine.ModuleOutput
+	for o := range outputChan {
+		outputs = append(outputs, o)
+	}
+	return outputs
+}
+
+func TestTLSFingerprintModule_Execute(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	banner := httpBanner(t, server)
+
+	plain := httptest.NewServer(http.NotFoundHandler())
+	defer plain.Close()
+
+	outputs := runTLSFingerprintModule(t, []interface{}{banner, banner, httpBanner(t, plain)})
+	require.Len(t, outputs, 3, "one TLS service, fingerprinted once")
+	byKey := outputsByKey(outputs)
+
+	result := byKey["tls.fingerprint.details"].(TLSFingerprintResult)
+	require.Equal(t, banner.Port, result.Port)
+	require.Len(t, result.JARM, 62)
+	require.NotEqual(t, jarm.EmptyHash, result.JARM)
+	require.Equal(t, result.JARM, byKey["tls.jarm"])
+	require.Equal(t, result.JA3S, byKey["tls.ja3s"])
+	require.Contains(t, result.JA3SString, "771,")
+
+	// Services found over TLS by probes only count as well
+	banner.IsTLS = false
+	banner.Evidence = []engine.ProbeObservation{{ProbeID: "tls-generic", TLS: &engine.TLSObservation{Version: "TLS1.3"}}}
+	require.Len(t, runTLSFingerprintModule(t, []interface{}{banner}), 3)
+
+	// Handshakes after STARTTLS do not
+	banner.Evidence[0].StartTLS = true
+	require.Empty(t, runTLSFingerprintModule(t, []interface{}{banner}))
+}
+
+func TestTLSFingerprintModule_Execute_NoTLS(t *testing.T) {
+	// Plain services are not fingerprinted, even if marked as TLS by mistake
+	plain := httptest.NewServer(http.NotFoundHandler())
+	defer plain.Close()
+	banner := httpBanner(t, plain)
+	require.Empty(t, runTLSFingerprintModule(t, []interface{}{banner}))
+	banner.IsTLS = true
+	require.Empty(t, runTLSFingerprintModule(t, []interface{}{banner}))
+}
+
+func TestTLSFingerprintModule_Init(t *testing.T) {
+	module := newTLSFingerprintModule()
+	require.NoError(t, module.Init("tls_fingerprint", map[string]interface{}{"timeout": 0, "concurrency": -1}))
+	require.Equal(t, 5*time.Second, module.config.Timeout)
+	require.Equal(t, 1, module.config.Concurrency)
+
+	require.Error(t, newTLSFingerprintModule().Init("tls_fingerprint", map[string]interface{}{"timeout": "soon"}))
+}
@@ -0,0 +1,282 @@
+// Package netproxy routes outbound connections through an HTTP or SOCKS5
+// proxy, as required by scans run from restricted corporate networks.
+//
+// A Proxy serves two kinds of traffic:
+//
+//   - HTTP requests (plugin downloads, HTTP probes) via ProxyURL, usable as
+//     http.Transport.Proxy through HTTPProxy
+//   - raw TCP connections (banner grabbing) via DialContext, tunneled with
+//     HTTP CONNECT or SOCKS5
+//
+// Hosts matching the NO_PROXY list are reached directly, as are localhost
+// and loopback addresses. A nil *Proxy falls back to the HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables for HTTP requests and
+// dials TCP connections directly.
+//
+// Scans carry their proxy in the context (see WithContext), so modules pick
+// it up without reading the configuration themselves. TCP connections wait
+// for the blackout.Gate of the context as well, draw a file descriptor from
+// its fdbudget, are bound to the interface and source address of its
+// egress.Binding, and are recorded by its capture.Recorder, if any.
+package netproxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+	xproxy "golang.org/x/net/proxy"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/egress"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
+)
+
+// Direct is the proxy URL that disables proxying, e.g. to override the
+// global proxy for a plugin source on the internal network.
+const Direct = "direct"
+
+// Schemes lists the supported proxy URL schemes.
+var Schemes = []string{"http", "https", "socks5", "socks5h"}
+
+// Proxy routes connections through a proxy server.
+type Proxy struct {
+	url     *url.URL // nil connects directly
+	noProxy []string
+	match   func(*url.URL) (*url.URL, error)
+}
+
+// New returns a proxy for rawURL, an http(s):// or socks5(h):// URL that
+// may carry user:password credentials. Hosts in noProxy use the NO_PROXY
+// syntax: host names match their subdomains, ".example.com" matches only
+// subdomains, and IP addresses or CIDR ranges match addresses. An empty
+// rawURL returns nil, which uses the environment; Direct returns a proxy
+// that never proxies.
+func New(rawURL string, noProxy []string) (*Proxy, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(rawURL, Direct) {
+		return &Proxy{noProxy: noProxy}, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", rawURL, err)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if !slices.Contains(Schemes, u.Scheme) {
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be one of %s", rawURL, strings.Join(Schemes, ", "))
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", rawURL)
+	}
+
+	cfg := httpproxy.Config{
+		HTTPProxy:  u.String(),
+		HTTPSProxy: u.String(),
+		NoProxy:    strings.Join(noProxy, ","),
+	}
+	return &Proxy{url: u, noProxy: noProxy, match: cfg.ProxyFunc()}, nil
+}
+
+// Override returns a proxy for rawURL that keeps the NO_PROXY list of p.
+// An empty rawURL returns p itself.
+func (p *Proxy) Override(rawURL string) (*Proxy, error) {
+	if strings.TrimSpace(rawURL) == "" {
+		return p, nil
+	}
+	var noProxy []string
+	if p != nil {
+		noProxy = p.noProxy
+	}
+	return New(rawURL, noProxy)
+}
+
+// String returns the proxy URL without its password, or Direct.
+func (p *Proxy) String() string {
+	if p == nil || p.url == nil {
+		return Direct
+	}
+	return p.url.Redacted()
+}
+
+// ProxyURL returns the proxy to use for a request to target, or nil to
+// connect directly.
+func (p *Proxy) ProxyURL(target *url.URL) (*url.URL, error) {
+	if p == nil {
+		return http.ProxyFromEnvironment(&http.Request{URL: target})
+	}
+	if p.url == nil {
+		return nil, nil
+	}
+	return p.match(target)
+}
+
+// DialContext connects to addr on network "tcp", through the proxy unless
+// addr is exempt. dialer reaches the proxy (or addr) and sets the connect
+// timeout; nil uses a zero net.Dialer. The dial waits while addr is in a
+// blackout window of the blackout.Gate of ctx. The connection holds a
+// descriptor of the fdbudget of ctx until it is closed, leaves by the interface and
+// source address of the egress.Binding of ctx, and its traffic is
+// recorded by the capture.Recorder of ctx.
+func (p *Proxy) DialContext(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	if err := blackout.FromContext(ctx).Wait(ctx, addr); err != nil {
+		return nil, err
+	}
+	conn, err := fdbudget.FromContext(ctx).DialContext(ctx, func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return p.dial(ctx, dialer, network, addr)
+	}, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return capture.FromContext(ctx).Conn(conn, network, addr), nil
+}
+
+// dial connects to addr as DialContext does, without taking a descriptor.
+func (p *Proxy) dial(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+	binding := egress.FromContext(ctx)
+	if p == nil || p.url == nil {
+		return binding.DialContext(ctx, dialer, network, addr)
+	}
+
+	proxyURL, err := p.match(&url.URL{Scheme: "https", Host: addr})
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return binding.DialContext(ctx, dialer, network, addr)
+	}
+
+	// Connections to the proxy leave by the bound interface
+	dialer, err = binding.Dialer(dialer, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		var auth *xproxy.Auth
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			auth = &xproxy.Auth{User: proxyURL.User.Username(), Password: password}
+		}
+		socks, err := xproxy.SOCKS5("tcp", proxyURL.Host, auth, dialer)
+		if err != nil {
+			return nil, err
+		}
+		return socks.(xproxy.ContextDialer).DialContext(ctx, network, addr)
+	default:
+		return dialConnect(ctx, dialer, proxyURL, addr)
+	}
+}
+
+// dialConnect opens a tunnel to addr with an HTTP CONNECT request.
+func dialConnect(ctx context.Context, dialer *net.Dialer, proxyURL *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		port := "80"
+		if proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), port)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("connect to proxy %s: %w", proxyAddr, err)
+	}
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("connect to proxy %s: %w", proxyAddr, err)
+		}
+		conn = tlsConn
+	}
+
+	// Bound the handshake by the context, as the dialer does for connects
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT %s: %w", addr, err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT %s: %w", addr, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy CONNECT %s: %s", addr, resp.Status)
+	}
+
+	// Servers may greet before the client speaks; keep what was buffered
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn reads data buffered during the CONNECT handshake first.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying p. A nil p leaves ctx unchanged.
+func WithContext(ctx context.Context, p *Proxy) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the proxy carried by ctx, or nil.
+func FromContext(ctx context.Context) *Proxy {
+	p, _ := ctx.Value(contextKey{}).(*Proxy)
+	return p
+}
+
+// HTTPProxy selects the proxy of req from its context, falling back to the
+// environment. It is meant for http.Transport.Proxy.
+func HTTPProxy(req *http.Request) (*url.URL, error) {
+	return FromContext(req.Context()).ProxyURL(req.URL)
+}
@@ -0,0 +1,294 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"net"
+	"runtime"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// minAutoConcurrency is the fewest concurrent probes an AutoTuner
+	// backs off to.
+	minAutoConcurrency = 8
+	// maxAutoConcurrency caps concurrency however many resources the
+	// system has.
+	maxAutoConcurrency = 4096
+	// probesPerCPU bounds concurrency by the CPUs handling the sockets.
+	probesPerCPU = 256
+	// reservedFiles are kept free for the process itself (logs, storage,
+	// plugin files).
+	reservedFiles = 64
+
+	// warmupProbes are measured before timeouts are tuned.
+	warmupProbes = 50
+	// minRTTSamples is the fewest answered probes timeouts are tuned from.
+	minRTTSamples = 10
+	// timeoutRTTFactor is how many times the 95th percentile round trip
+	// a probe waits before it times out.
+	timeoutRTTFactor = 4
+	// minAutoTimeout is the shortest tuned timeout.
+	minAutoTimeout = 100 * time.Millisecond
+)
+
+// ProbeOutcome classifies the result of a network probe for an AutoTuner.
+type ProbeOutcome int
+
+const (
+	// ProbeAnswered means the host answered: the connection was accepted
+	// or refused. Its round trip time is measured.
+	ProbeAnswered ProbeOutcome = iota
+	// ProbeTimedOut means nothing answered before the timeout, as for
+	// filtered ports or congested links.
+	ProbeTimedOut
+	// ProbeResourceError means the local system ran out of file
+	// descriptors, ephemeral ports or buffers.
+	ProbeResourceError
+	// ProbeFailed is any other error, such as an unreachable network.
+	ProbeFailed
+)
+
+// ClassifyDialError returns the outcome of a dial that returned err.
+func ClassifyDialError(err error) ProbeOutcome {
+	if err == nil || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return ProbeAnswered
+	}
+	if errors.Is(err, syscall.EMFILE) || errors.Is(err, syscall.ENFILE) ||
+		errors.Is(err, syscall.ENOBUFS) || errors.Is(err, syscall.EADDRNOTAVAIL) {
+		return ProbeResourceError
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ProbeTimedOut
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ProbeTimedOut
+	}
+	return ProbeFailed
+}
+
+// SystemResources describes the local limits concurrency is sized by.
+type SystemResources struct {
+	OpenFiles int // soft limit of open file descriptors (0 = unknown)
+	CPUs      int
+}
+
+// DetectResources measures the resources of the running process.
+func DetectResources() SystemResources {
+	return SystemResources{OpenFiles: openFileLimit(), CPUs: runtime.NumCPU()}
+}
+
+// MaxConcurrency returns the most concurrent probes the resources allow:
+// half of the free file descriptors, so that other modules and the
+// process keep theirs, and at most probesPerCPU per CPU.
+func (r SystemResources) MaxConcurrency() int {
+	limit := maxAutoConcurrency
+	if r.CPUs > 0 {
+		limit = min(limit, r.CPUs*probesPerCPU)
+	}
+	if r.OpenFiles > 0 {
+		limit = min(limit, (r.OpenFiles-reservedFiles)/2)
+	}
+	return max(limit, minAutoConcurrency)
+}
+
+// AutoTuneConfig configures an AutoTuner.
+type AutoTuneConfig struct {
+	// Resources bounds concurrency; zero values are detected.
+	Resources SystemResources
+	// MaxConcurrency caps concurrency below the resource limit (0 = none).
+	MaxConcurrency int
+	// InitialConcurrency is the limit during warm-up (0 = a quarter of
+	// the maximum).
+	InitialConcurrency int
+	// Timeout is the probe timeout during warm-up. Tuned timeouts stay
+	// between a quarter and three times Timeout.
+	Timeout time.Duration
+}
+
+// AutoTuner limits concurrent probes and picks their timeout from the
+// outcomes of earlier probes, so that scans neither crawl on fixed
+// defaults nor exhaust the file descriptor limit.
+//
+// Concurrency grows while probes keep answering as fast as during
+// warm-up and shrinks when local resources run out, timeouts pile up
+// or round trips slow down. After warm-up, the timeout follows the
+// measured round trip times.
+//
+// An AutoTuner is safe for concurrent use.
+type AutoTuner struct {
+	mu       sync.Mutex
+	changed  chan struct{} // closed when a slot may have been freed
+	limit    int
+	max      int
+	inFlight int
+
+	timeout    time.Duration
+	minTimeout time.Duration
+	maxTimeout time.Duration
+
+	probes       int // probes completed
+	backoffUntil int // probes in flight at the last back-off complete by then
+	baseRTT      time.Duration
+	baseTimeouts float64 // timeout rate during warm-up
+
+	window windowStats
+}
+
+// windowStats accumulates probe outcomes between two adjustments.
+type windowStats struct {
+	probes   int
+	timeouts int
+	rtts     []time.Duration
+}
+
+// NewAutoTuner returns a tuner for cfg.
+func NewAutoTuner(cfg AutoTuneConfig) *AutoTuner {
+	res := cfg.Resources
+	if res.CPUs == 0 && res.OpenFiles == 0 {
+		res = DetectResources()
+	}
+	maxLimit := res.MaxConcurrency()
+	if cfg.MaxConcurrency > 0 {
+		maxLimit = max(min(maxLimit, cfg.MaxConcurrency), 1)
+	}
+	limit := cfg.InitialConcurrency
+	if limit <= 0 {
+		limit = maxLimit / 4
+	}
+	limit = max(min(limit, maxLimit), min(minAutoConcurrency, maxLimit))
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	return &AutoTuner{
+		changed:    make(chan struct{}),
+		limit:      limit,
+		max:        maxLimit,
+		timeout:    timeout,
+		minTimeout: max(timeout/4, minAutoTimeout),
+		maxTimeout: timeout * 3,
+	}
+}
+
+// Acquire blocks until a probe may start or ctx is done.
+func (t *AutoTuner) Acquire(ctx context.Context) error {
+	for {
+		t.mu.Lock()
+		if t.inFlight < t.limit {
+			t.inFlight++
+			t.mu.Unlock()
+			return nil
+		}
+		changed := t.changed
+		t.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release ends a probe started by Acquire, recording its outcome and, for
+// answered probes, its round trip time.
+func (t *AutoTuner) Release(outcome ProbeOutcome, rtt time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+	t.probes++
+	t.window.probes++
+	switch outcome {
+	case ProbeAnswered:
+		t.window.rtts = append(t.window.rtts, rtt)
+	case ProbeTimedOut:
+		t.window.timeouts++
+	}
+
+	switch {
+	case outcome == ProbeResourceError && t.probes > t.backoffUntil:
+		// Back off at once, and only once for the probes already in
+		// flight: more probes would only fail the same way
+		t.max = max(t.limit*3/4, min(minAutoConcurrency, t.max))
+		t.limit = max(t.limit/2, min(minAutoConcurrency, t.max))
+		t.backoffUntil = t.probes + t.inFlight
+		t.window = windowStats{}
+	case t.probes == warmupProbes:
+		t.endWarmup()
+	case t.probes > warmupProbes && t.window.probes >= max(t.limit, warmupProbes):
+		t.adjust()
+	}
+
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// endWarmup records the round trips and timeout rate of the warm-up
+// probes as the baseline and tunes the timeout from them.
+func (t *AutoTuner) endWarmup() {
+	t.baseTimeouts = float64(t.window.timeouts) / float64(t.window.probes)
+	if len(t.window.rtts) >= minRTTSamples {
+		t.baseRTT = percentile(t.window.rtts, 50)
+		t.tuneTimeout(t.window.rtts)
+	}
+	t.window = windowStats{}
+}
+
+// adjust grows or shrinks the limit by the outcomes of the last window.
+func (t *AutoTuner) adjust() {
+	w := t.window
+	t.window = windowStats{}
+
+	timeoutRate := float64(w.timeouts) / float64(w.probes)
+	slower := false
+	if len(w.rtts) >= minRTTSamples {
+		median := percentile(w.rtts, 50)
+		if t.baseRTT == 0 {
+			t.baseRTT = median
+		}
+		slower = median > 3*t.baseRTT
+		t.tuneTimeout(w.rtts)
+	}
+
+	if slower || timeoutRate > t.baseTimeouts+0.2 {
+		t.limit = max(t.limit*3/4, min(minAutoConcurrency, t.max))
+		return
+	}
+	t.limit = min(t.limit+max(t.limit/5, 1), t.max)
+}
+
+// tuneTimeout sets the timeout to a multiple of the 95th percentile of
+// rtts, within the configured bounds.
+func (t *AutoTuner) tuneTimeout(rtts []time.Duration) {
+	timeout := timeoutRTTFactor * percentile(rtts, 95)
+	t.timeout = min(max(timeout, t.minTimeout), t.maxTimeout)
+}
+
+// Timeout returns the timeout for the next probe.
+func (t *AutoTuner) Timeout() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.timeout
+}
+
+// Limit returns the current concurrency limit.
+func (t *AutoTuner) Limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
+
+// percentile returns the p-th percentile of values. values must not be empty.
+func percentile(values []time.Duration, p int) time.Duration {
+	sorted := make([]time.Duration, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)*p/100]
+}
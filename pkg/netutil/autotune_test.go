@@ -0,0 +1,118 @@
+package netutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemResources_MaxConcurrency(t *testing.T) {
+	require.Equal(t, 1024, SystemResources{CPUs: 4}.MaxConcurrency())
+	require.Equal(t, 480, SystemResources{CPUs: 4, OpenFiles: 1024}.MaxConcurrency())
+	require.Equal(t, maxAutoConcurrency, SystemResources{CPUs: 64, OpenFiles: 1 << 20}.MaxConcurrency())
+	require.Equal(t, minAutoConcurrency, SystemResources{CPUs: 1, OpenFiles: 32}.MaxConcurrency())
+	require.Positive(t, DetectResources().CPUs)
+}
+
+func TestClassifyDialError(t *testing.T) {
+	opErr := func(err error) error {
+		return &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", err)}
+	}
+
+	require.Equal(t, ProbeAnswered, ClassifyDialError(nil))
+	require.Equal(t, ProbeAnswered, ClassifyDialError(opErr(syscall.ECONNREFUSED)))
+	require.Equal(t, ProbeResourceError, ClassifyDialError(opErr(syscall.EMFILE)))
+	require.Equal(t, ProbeResourceError, ClassifyDialError(opErr(syscall.EADDRNOTAVAIL)))
+	require.Equal(t, ProbeTimedOut, ClassifyDialError(fmt.Errorf("dial: %w", context.DeadlineExceeded)))
+	require.Equal(t, ProbeFailed, ClassifyDialError(errors.New("no route to host")))
+
+	_, err := net.DialTimeout("tcp", "192.0.2.1:9", time.Nanosecond)
+	require.Equal(t, ProbeTimedOut, ClassifyDialError(err))
+}
+
+// probe runs n probes through tuner with the given outcome and round trip.
+func probe(t *testing.T, tuner *AutoTuner, n int, outcome ProbeOutcome, rtt time.Duration) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, tuner.Acquire(context.Background()))
+		tuner.Release(outcome, rtt)
+	}
+}
+
+func TestAutoTuner_TunesTimeoutAfterWarmup(t *testing.T) {
+	tuner := NewAutoTuner(AutoTuneConfig{Resources: SystemResources{CPUs: 4}, Timeout: time.Second})
+	require.Equal(t, 256, tuner.Limit())
+	require.Equal(t, time.Second, tuner.Timeout())
+
+	// A fast LAN: 4x the 95th percentile round trip, but at least a
+	// quarter of the configured timeout
+	probe(t, tuner, warmupProbes, ProbeAnswered, 5*time.Millisecond)
+	require.Equal(t, 250*time.Millisecond, tuner.Timeout())
+
+	// A slow link: at most three times the configured timeout
+	tuner = NewAutoTuner(AutoTuneConfig{Resources: SystemResources{CPUs: 4}, Timeout: time.Second})
+	probe(t, tuner, warmupProbes, ProbeAnswered, 600*time.Millisecond)
+	require.Equal(t, 2400*time.Millisecond, tuner.Timeout())
+
+	// Without answers, the configured timeout stays
+	tuner = NewAutoTuner(AutoTuneConfig{Resources: SystemResources{CPUs: 4}, Timeout: time.Second})
+	probe(t, tuner, warmupProbes, ProbeTimedOut, 0)
+	require.Equal(t, time.Second, tuner.Timeout())
+}
+
+func TestAutoTuner_AdjustsConcurrency(t *testing.T) {
+	tuner := NewAutoTuner(AutoTuneConfig{Resources: SystemResources{CPUs: 4}, MaxConcurrency: 500, InitialConcurrency: 100, Timeout: time.Second})
+	require.Equal(t, 100, tuner.Limit())
+
+	// Healthy windows grow the limit up to the maximum
+	probe(t, tuner, warmupProbes, ProbeAnswered, 10*time.Millisecond)
+	probe(t, tuner, 100, ProbeAnswered, 10*time.Millisecond)
+	require.Equal(t, 120, tuner.Limit())
+	probe(t, tuner, 5000, ProbeAnswered, 10*time.Millisecond)
+	require.Equal(t, 500, tuner.Limit())
+
+	// Slower round trips shrink it
+	probe(t, tuner, 500, ProbeAnswered, 50*time.Millisecond)
+	require.Equal(t, 375, tuner.Limit())
+
+	// Running out of file descriptors halves it once and lowers the maximum
+	probe(t, tuner, 1, ProbeResourceError, 0)
+	require.Equal(t, 187, tuner.Limit())
+	probe(t, tuner, 1, ProbeResourceError, 0)
+	require.Equal(t, 93, tuner.Limit())
+	probe(t, tuner, 5000, ProbeAnswered, 10*time.Millisecond)
+	require.Equal(t, 140, tuner.Limit())
+
+	// Piling timeouts shrink it down to the minimum
+	probe(t, tuner, 5000, ProbeTimedOut, 0)
+	require.Equal(t, minAutoConcurrency, tuner.Limit())
+}
+
+func TestAutoTuner_AcquireHonorsLimitAndContext(t *testing.T) {
+	tuner := NewAutoTuner(AutoTuneConfig{Resources: SystemResources{CPUs: 1}, MaxConcurrency: 1})
+	require.Equal(t, 1, tuner.Limit())
+	require.NoError(t, tuner.Acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, tuner.Acquire(ctx), context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		_ = tuner.Acquire(context.Background())
+		close(acquired)
+	}()
+	tuner.Release(ProbeAnswered, time.Millisecond)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Acquire did not proceed after Release")
+	}
+}
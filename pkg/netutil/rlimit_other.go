@@ -0,0 +1,9 @@
+//go:build !unix
+
+package netutil
+
+// openFileLimit returns 0: the platform has no per-process limit of open
+// sockets comparable to RLIMIT_NOFILE.
+func openFileLimit() int {
+	return 0
+}
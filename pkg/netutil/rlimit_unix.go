@@ -0,0 +1,22 @@
+//go:build unix
+
+package netutil
+
+import (
+	"math"
+	"syscall"
+)
+
+// openFileLimit returns the soft limit of open file descriptors, or 0 when
+// it cannot be read.
+func openFileLimit() int {
+	var rl syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rl); err != nil {
+		return 0
+	}
+	cur := uint64(rl.Cur) //nolint:unconvert // int64 on some platforms
+	if cur > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(cur)
+}
@@ -0,0 +1,303 @@
+// Package notify delivers scan notifications to configured webhooks.
+//
+// A Dispatcher is built from config.NotificationsConfig and is told about
+// every finished scan. Each webhook receives the events it subscribes to:
+//
+//   - scan.completed / scan.failed: the scan summary
+//   - findings.new: findings at or above the webhook's severity threshold
+//   - changes.detected: hosts, ports, services and certificates that
+//     changed since they were last scanned
+//   - findings.overdue: findings at or above the webhook's severity
+//     threshold that are past their remediation due date, once per
+//     finding
+//
+// Payloads are JSON POST requests. When a webhook has a secret, requests
+// carry an X-Vulntor-Signature header (see Sign) so receivers can verify
+// the sender. Delivery is best-effort: failures are retried and logged but
+// never fail the scan.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/secrets"
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// Event types.
+const (
+	EventScanCompleted = "scan.completed"
+	EventScanFailed    = "scan.failed"
+	EventFindingsNew   = "findings.new"
+	EventChanges       = "changes.detected"
+	EventOverdue       = "findings.overdue"
+)
+
+// Events lists every event type a webhook can subscribe to.
+var Events = []string{EventScanCompleted, EventScanFailed, EventFindingsNew, EventChanges, EventOverdue}
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultMinSeverity = "high"
+	maxAttempts        = 3
+)
+
+// Scan summarizes a finished scan.
+type Scan struct {
+	ID         string         `json:"id"`
+	OrgID      string         `json:"org_id"`
+	Targets    []string       `json:"targets"`
+	Status     string         `json:"status"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Error      string         `json:"error,omitempty"`
+	Findings   map[string]int `json:"findings"` // count per severity
+}
+
+// Finding is a vulnerability reported in a findings.new or
+// findings.overdue payload. DueAt is its remediation due date, when an SLA
+// applies to its severity.
+type Finding struct {
+	ID       string     `json:"id,omitempty"`
+	Target   string     `json:"target"`
+	Port     int        `json:"port,omitempty"`
+	Plugin   string     `json:"plugin"`
+	Severity string     `json:"severity"`
+	Message  string     `json:"message"`
+	CVE      []string   `json:"cve,omitempty"`
+	DueAt    *time.Time `json:"due_at,omitempty"`
+}
+
+// Change is a host or service change reported in a changes.detected
+// payload, e.g. a port opened since the host was last scanned.
+type Change struct {
+	Type     string `json:"type"` // host.new, port.new, service.changed, cert.changed
+	Target   string `json:"target"`
+	Port     int    `json:"port,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	Previous string `json:"previous,omitempty"`
+	Current  string `json:"current,omitempty"`
+}
+
+// Payload is the JSON body POSTed to webhooks.
+type Payload struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Scan      Scan      `json:"scan"`
+	Findings  []Finding `json:"findings,omitempty"`
+	Changes   []Change  `json:"changes,omitempty"`
+}
+
+// webhook is a validated config.WebhookConfig.
+type webhook struct {
+	name        string
+	url         string
+	secret      string
+	events      []string
+	minSeverity int
+	headers     map[string]string
+	timeout     time.Duration
+}
+
+func (w *webhook) subscribed(event string) bool {
+	return slices.Contains(w.events, event)
+}
+
+// Dispatcher sends notifications to webhooks. A nil *Dispatcher sends nothing.
+type Dispatcher struct {
+	webhooks []*webhook
+	client   *http.Client
+	backoff  time.Duration // delay before the first retry; doubles per attempt
+}
+
+// New builds a dispatcher from cfg. Returns nil (and no error) when no
+// webhooks are configured.
+func New(cfg config.NotificationsConfig) (*Dispatcher, error) {
+	if len(cfg.Webhooks) == 0 {
+		return nil, nil
+	}
+
+	d := &Dispatcher{client: &http.Client{}, backoff: time.Second}
+	for i, wc := range cfg.Webhooks {
+		w, err := newWebhook(wc)
+		if err != nil {
+			return nil, fmt.Errorf("notifications.webhooks[%d]: %w", i, err)
+		}
+		d.webhooks = append(d.webhooks, w)
+	}
+	return d, nil
+}
+
+func newWebhook(cfg config.WebhookConfig) (*webhook, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url: %q (must be an http(s) URL)", cfg.URL)
+	}
+
+	secret, err := secrets.Resolve(context.Background(), cfg.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("secret: %w", err)
+	}
+	var headers map[string]string
+	if len(cfg.Headers) > 0 {
+		headers = make(map[string]string, len(cfg.Headers))
+		for name, value := range cfg.Headers {
+			if headers[name], err = secrets.Resolve(context.Background(), value); err != nil {
+				return nil, fmt.Errorf("headers.%s: %w", name, err)
+			}
+		}
+	}
+
+	w := &webhook{
+		name:    cfg.Name,
+		url:     cfg.URL,
+		secret:  secret,
+		events:  Events,
+		headers: headers,
+		timeout: cfg.Timeout,
+	}
+	if w.name == "" {
+		w.name = u.Host
+	}
+	if w.timeout <= 0 {
+		w.timeout = defaultTimeout
+	}
+
+	if len(cfg.Events) > 0 {
+		w.events = nil
+		for _, e := range cfg.Events {
+			e = strings.ToLower(strings.TrimSpace(e))
+			if !slices.Contains(Events, e) {
+				return nil, fmt.Errorf("unknown event: %q (must be one of %s)", e, strings.Join(Events, ", "))
+			}
+			w.events = append(w.events, e)
+		}
+	}
+
+	minSeverity := strings.ToLower(strings.TrimSpace(cfg.MinSeverity))
+	if minSeverity == "" {
+		minSeverity = defaultMinSeverity
+	}
+	rank := severity.Rank(minSeverity)
+	if rank == 0 {
+		return nil, fmt.Errorf("invalid min_severity: %q (must be info, low, medium, high or critical)", cfg.MinSeverity)
+	}
+	w.minSeverity = rank
+
+	return w, nil
+}
+
+// ScanFinished notifies subscribed webhooks that scan finished with the
+// given findings. It blocks until every delivery succeeded or gave up.
+func (d *Dispatcher) ScanFinished(ctx context.Context, scan Scan, findings []Finding) {
+	if d == nil {
+		return
+	}
+
+	scan.Findings = make(map[string]int)
+	for _, f := range findings {
+		scan.Findings[strings.ToLower(f.Severity)]++
+	}
+
+	event := EventScanCompleted
+	if scan.Status == "failed" {
+		event = EventScanFailed
+	}
+	now := time.Now().UTC()
+
+	d.send(ctx, scan.ID, func(w *webhook) []Payload {
+		var payloads []Payload
+		if w.subscribed(event) {
+			payloads = append(payloads, Payload{Event: event, Timestamp: now, Scan: scan})
+		}
+		if w.subscribed(EventFindingsNew) {
+			if above := filterFindings(findings, w.minSeverity); len(above) > 0 {
+				payloads = append(payloads, Payload{Event: EventFindingsNew, Timestamp: now, Scan: scan, Findings: above})
+			}
+		}
+		return payloads
+	})
+}
+
+// ChangesDetected notifies subscribed webhooks of the changes scan found
+// since the hosts were last scanned. Nothing is sent without changes. It
+// blocks until every delivery succeeded or gave up.
+func (d *Dispatcher) ChangesDetected(ctx context.Context, scan Scan, changes []Change) {
+	if d == nil || len(changes) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	d.send(ctx, scan.ID, func(w *webhook) []Payload {
+		if !w.subscribed(EventChanges) {
+			return nil
+		}
+		return []Payload{{Event: EventChanges, Timestamp: now, Scan: scan, Changes: changes}}
+	})
+}
+
+// FindingsOverdue notifies subscribed webhooks of findings that scan found
+// past their due date. Nothing is sent without findings at or above a
+// webhook's threshold. It blocks until every delivery succeeded or gave up.
+func (d *Dispatcher) FindingsOverdue(ctx context.Context, scan Scan, findings []Finding) {
+	if d == nil || len(findings) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	d.send(ctx, scan.ID, func(w *webhook) []Payload {
+		if !w.subscribed(EventOverdue) {
+			return nil
+		}
+		above := filterFindings(findings, w.minSeverity)
+		if len(above) == 0 {
+			return nil
+		}
+		return []Payload{{Event: EventOverdue, Timestamp: now, Scan: scan, Findings: above}}
+	})
+}
+
+// send delivers the payloads of each webhook concurrently and waits for
+// all deliveries.
+func (d *Dispatcher) send(ctx context.Context, scanID string, payloadsFor func(*webhook) []Payload) {
+	var wg sync.WaitGroup
+	for _, w := range d.webhooks {
+		for _, p := range payloadsFor(w) {
+			wg.Add(1)
+			go func(w *webhook, p Payload) {
+				defer wg.Done()
+				if err := d.deliver(ctx, w, p); err != nil {
+					log.Warn().
+						Str("component", "notify").
+						Str("webhook", w.name).
+						Str("event", p.Event).
+						Str("scan_id", scanID).
+						Err(err).
+						Msg("Webhook delivery failed")
+				}
+			}(w, p)
+		}
+	}
+	wg.Wait()
+}
+
+// filterFindings returns the findings ranked at or above minRank.
+func filterFindings(findings []Finding, minRank int) []Finding {
+	var out []Finding
+	for _, f := range findings {
+		if severity.Rank(strings.ToLower(f.Severity)) >= minRank {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// receiver records payloads POSTed to a test server.
+type receiver struct {
+	mu       sync.Mutex
+	payloads []Payload
+	headers  []http.Header
+}
+
+func newReceiver(t *testing.T) (*receiver, *httptest.Server) {
+	t.Helper()
+	rcv := &receiver{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		rcv.mu.Lock()
+		rcv.payloads = append(rcv.payloads, p)
+		rcv.headers = append(rcv.headers, r.Header.Clone())
+		rcv.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+	return rcv, srv
+}
+
+func (r *receiver) events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]string, 0, len(r.payloads))
+	for _, p := range r.payloads {
+		out = append(out, p.Event)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestNew(t *testing.T) {
+	d, err := New(config.NotificationsConfig{})
+	require.NoError(t, err)
+	require.Nil(t, d)
+
+	d, err = New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: "https://hooks.example.com/vulntor"}}})
+	require.NoError(t, err)
+	require.Len(t, d.webhooks, 1)
+	require.Equal(t, "hooks.example.com", d.webhooks[0].name)
+	require.Equal(t, Events, d.webhooks[0].events)
+	require.Equal(t, severity.Rank("high"), d.webhooks[0].minSeverity)
+	require.Equal(t, defaultTimeout, d.webhooks[0].timeout)
+
+	for _, bad := range []config.WebhookConfig{
+		{URL: ""},
+		{URL: "ftp://hooks.example.com"},
+		{URL: "https://hooks.example.com", Events: []string{"scan.started"}},
+		{URL: "https://hooks.example.com", MinSeverity: "severe"},
+	} {
+		_, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{bad}})
+		require.Error(t, err, "%+v", bad)
+	}
+}
+
+func TestDispatcher_ScanFinished(t *testing.T) {
+	all, allSrv := newReceiver(t)
+	failures, failuresSrv := newReceiver(t)
+
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{
+		{Name: "all", URL: allSrv.URL, MinSeverity: "medium"},
+		{Name: "failures", URL: failuresSrv.URL, Events: []string{"scan.failed"}},
+	}})
+	require.NoError(t, err)
+
+	findings := []Finding{
+		{Target: "10.0.0.5", Port: 22, Plugin: "ssh-weak-cipher", Severity: "MEDIUM", Message: "Weak cipher"},
+		{Target: "10.0.0.5", Port: 80, Plugin: "http-server-banner", Severity: "info", Message: "Banner disclosed"},
+	}
+	d.ScanFinished(context.Background(), Scan{ID: "scan-1", Status: "completed"}, findings)
+
+	require.Equal(t, []string{EventFindingsNew, EventScanCompleted}, all.events())
+	require.Empty(t, failures.events())
+
+	for _, p := range all.payloads {
+		require.Equal(t, "scan-1", p.Scan.ID)
+		require.Equal(t, map[string]int{"medium": 1, "info": 1}, p.Scan.Findings)
+		if p.Event == EventFindingsNew {
+			require.Len(t, p.Findings, 1, "info is below the medium threshold")
+			require.Equal(t, "ssh-weak-cipher", p.Findings[0].Plugin)
+		}
+	}
+
+	// A failed scan without findings above threshold only reports the failure
+	d.ScanFinished(context.Background(), Scan{ID: "scan-2", Status: "failed", Error: "timeout"}, nil)
+	require.Equal(t, []string{EventScanFailed}, failures.events())
+	require.Equal(t, "timeout", failures.payloads[0].Scan.Error)
+	require.Contains(t, all.events(), EventScanFailed)
+}
+
+func TestDispatcher_ChangesDetected(t *testing.T) {
+	all, allSrv := newReceiver(t)
+	findings, findingsSrv := newReceiver(t)
+
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{
+		{Name: "all", URL: allSrv.URL},
+		{Name: "findings", URL: findingsSrv.URL, Events: []string{"findings.new"}},
+	}})
+	require.NoError(t, err)
+
+	// Nothing is sent without changes
+	d.ChangesDetected(context.Background(), Scan{ID: "scan-1"}, nil)
+	require.Empty(t, all.events())
+
+	changes := []Change{{Type: "port.new", Target: "10.0.0.5", Port: 3389, Protocol: "tcp", Current: "rdp"}}
+	d.ChangesDetected(context.Background(), Scan{ID: "scan-2", Status: "completed"}, changes)
+	require.Equal(t, []string{EventChanges}, all.events())
+	require.Equal(t, changes, all.payloads[0].Changes)
+	require.Equal(t, "scan-2", all.payloads[0].Scan.ID)
+	require.Empty(t, findings.events())
+}
+
+func TestDispatcher_FindingsOverdue(t *testing.T) {
+	all, allSrv := newReceiver(t)
+	critical, criticalSrv := newReceiver(t)
+	changes, changesSrv := newReceiver(t)
+
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{
+		{Name: "all", URL: allSrv.URL},
+		{Name: "critical", URL: criticalSrv.URL, MinSeverity: "critical"},
+		{Name: "changes", URL: changesSrv.URL, Events: []string{"changes.detected"}},
+	}})
+	require.NoError(t, err)
+
+	due := time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC)
+	findings := []Finding{{ID: "14d34128101e76ec", Target: "10.0.0.5", Port: 22, Plugin: "ssh-weak-cipher", Severity: "high", DueAt: &due}}
+	d.FindingsOverdue(context.Background(), Scan{ID: "scan-1", Status: "completed"}, findings)
+	require.Equal(t, []string{EventOverdue}, all.events())
+	require.Equal(t, findings, all.payloads[0].Findings)
+	require.Empty(t, critical.events(), "below the webhook's threshold")
+	require.Empty(t, changes.events())
+}
+
+func TestDispatcher_Nil(t *testing.T) {
+	var d *Dispatcher
+	d.ScanFinished(context.Background(), Scan{ID: "scan-1"}, nil)
+	d.ChangesDetected(context.Background(), Scan{ID: "scan-1"}, []Change{{Type: "host.new"}})
+	d.FindingsOverdue(context.Background(), Scan{ID: "scan-1"}, []Finding{{Severity: "high"}})
+}
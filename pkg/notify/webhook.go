@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook request headers.
+const (
+	HeaderEvent     = "X-Vulntor-Event"
+	HeaderDelivery  = "X-Vulntor-Delivery"
+	HeaderTimestamp = "X-Vulntor-Timestamp"
+	HeaderSignature = "X-Vulntor-Signature"
+)
+
+// Sign returns the X-Vulntor-Signature value for a request body sent at
+// timestamp (Unix seconds): "sha256=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with secret. Receivers recompute it and should
+// reject stale timestamps to prevent replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver POSTs p to w, retrying network errors, 429 and 5xx responses.
+// Every attempt reuses the delivery ID so receivers can deduplicate.
+func (d *Dispatcher) deliver(ctx context.Context, w *webhook, p Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+
+	// Notify even when the scan's own context was cancelled
+	ctx = context.WithoutCancel(ctx)
+	deliveryID := uuid.New().String()
+	backoff := d.backoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(ctx, w, p.Event, deliveryID, body)
+		if err == nil || !retry || attempt == maxAttempts {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends a single request. It reports whether a failure is worth retrying.
+func (d *Dispatcher) post(ctx context.Context, w *webhook, event, deliveryID string, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vulntor-webhook")
+	req.Header.Set(HeaderEvent, event)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	if w.secret != "" {
+		ts := time.Now().Unix()
+		req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+		req.Header.Set(HeaderSignature, Sign(w.secret, ts, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status: %s", resp.Status)
+}
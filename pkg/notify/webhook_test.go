@@ -0,0 +1,112 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func TestSign(t *testing.T) {
+	// Reference value: printf '1700000000.{}' | openssl dgst -sha256 -hmac s3cret
+	require.Equal(t,
+		"sha256=97926816e98fbb41ccb1673225ff29a2f35369099990e1b1561651e7bd097ebf",
+		Sign("s3cret", 1700000000, []byte("{}")))
+	require.NotEqual(t, Sign("s3cret", 1700000000, []byte("{}")), Sign("other", 1700000000, []byte("{}")))
+	require.NotEqual(t, Sign("s3cret", 1700000000, []byte("{}")), Sign("s3cret", 1700000001, []byte("{}")))
+}
+
+func TestDeliver_SignedRequest(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{
+		URL: srv.URL, Secret: "s3cret", Events: []string{"scan.completed"}, Headers: map[string]string{"X-Team": "secops"},
+	}}})
+	require.NoError(t, err)
+	d.ScanFinished(context.Background(), Scan{ID: "scan-1", Status: "completed"}, nil)
+
+	require.NotNil(t, got)
+	require.Equal(t, http.MethodPost, got.Method)
+	require.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	require.Equal(t, EventScanCompleted, got.Header.Get(HeaderEvent))
+	require.NotEmpty(t, got.Header.Get(HeaderDelivery))
+	require.Equal(t, "secops", got.Header.Get("X-Team"))
+
+	ts, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, Sign("s3cret", ts, body), got.Header.Get(HeaderSignature))
+}
+
+func TestDeliver_SecretReferences(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	t.Setenv("VULNTOR_TEST_WEBHOOK_SECRET", "s3cret")
+	t.Setenv("VULNTOR_TEST_WEBHOOK_AUTH", "Bearer t0ken")
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{
+		URL: srv.URL, Secret: "env:VULNTOR_TEST_WEBHOOK_SECRET", Events: []string{"scan.completed"},
+		Headers: map[string]string{"Authorization": "env:VULNTOR_TEST_WEBHOOK_AUTH", "X-Team": "secops"},
+	}}})
+	require.NoError(t, err)
+	d.ScanFinished(context.Background(), Scan{ID: "scan-1", Status: "completed"}, nil)
+
+	require.NotNil(t, got)
+	require.Equal(t, "Bearer t0ken", got.Header.Get("Authorization"))
+	require.Equal(t, "secops", got.Header.Get("X-Team"))
+	ts, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	require.Equal(t, Sign("s3cret", ts, body), got.Header.Get(HeaderSignature))
+
+	_, err = New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL, Secret: "env:VULNTOR_TEST_UNSET"}}})
+	require.ErrorContains(t, err, "secret: environment variable VULNTOR_TEST_UNSET is not set")
+}
+
+func TestDeliver_Retries(t *testing.T) {
+	var calls atomic.Int32
+	var deliveries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveries = append(deliveries, r.Header.Get(HeaderDelivery))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	d, err := New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL}}})
+	require.NoError(t, err)
+	d.backoff = 0
+
+	w := d.webhooks[0]
+	require.NoError(t, d.deliver(context.Background(), w, Payload{Event: EventScanCompleted}))
+	require.Equal(t, int32(3), calls.Load())
+	require.Equal(t, deliveries[0], deliveries[2], "retries reuse the delivery ID")
+
+	// Client errors are not retried
+	calls.Store(0)
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer bad.Close()
+	w.url = bad.URL
+	require.Error(t, d.deliver(context.Background(), w, Payload{Event: EventScanCompleted}))
+	require.Equal(t, int32(1), calls.Load())
+}
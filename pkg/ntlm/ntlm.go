@@ -0,0 +1,326 @@
+// Package ntlm builds and decodes the NTLM messages (MS-NLMP) exchanged
+// before authentication. The server's CHALLENGE message discloses its
+// host name, domain and operating system version to anyone who asks,
+// which makes it useful for unauthenticated reconnaissance.
+package ntlm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf16"
+)
+
+// Negotiate flags (MS-NLMP 2.2.2.5) used by this package.
+const (
+	FlagUnicode                 = 0x00000001
+	FlagRequestTarget           = 0x00000004
+	FlagNTLM                    = 0x00000200
+	FlagAnonymous               = 0x00000800
+	FlagAlwaysSign              = 0x00008000
+	FlagExtendedSessionSecurity = 0x00080000
+	FlagTargetInfo              = 0x00800000
+	FlagVersion                 = 0x02000000
+	Flag128                     = 0x20000000
+	Flag56                      = 0x80000000
+)
+
+// Signature starts every NTLM message.
+var Signature = []byte("NTLMSSP\x00")
+
+const (
+	typeNegotiate    = 1
+	typeChallenge    = 2
+	typeAuthenticate = 3
+
+	negotiateFlags = FlagUnicode | FlagRequestTarget | FlagNTLM | FlagAlwaysSign |
+		FlagExtendedSessionSecurity | FlagTargetInfo | FlagVersion | Flag128 | Flag56
+)
+
+// AV pair IDs in the challenge's target info (MS-NLMP 2.2.2.1).
+const (
+	avEOL             = 0
+	avNbComputerName  = 1
+	avNbDomainName    = 2
+	avDNSComputerName = 3
+	avDNSDomainName   = 4
+	avDNSTreeName     = 5
+	avTimestamp       = 7
+)
+
+// clientVersion is sent in our messages: Windows 10, NTLM revision 15.
+var clientVersion = Version{Major: 10, Minor: 0, Build: 0, Revision: 15}
+
+// Version is the operating system version a peer reports.
+type Version struct {
+	Major    uint8  `json:"major"`
+	Minor    uint8  `json:"minor"`
+	Build    uint16 `json:"build"`
+	Revision uint8  `json:"revision"` // NTLM revision, 15 for current Windows
+}
+
+// String returns the version as "major.minor.build", e.g. "10.0.17763".
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Build)
+}
+
+func (v Version) marshal() []byte {
+	b := make([]byte, 8)
+	b[0] = v.Major
+	b[1] = v.Minor
+	binary.LittleEndian.PutUint16(b[2:], v.Build)
+	b[7] = v.Revision
+	return b
+}
+
+// Challenge is a decoded CHALLENGE message.
+type Challenge struct {
+	Flags           uint32
+	ServerChallenge [8]byte
+	TargetName      string
+	// Version is nil when the server did not send one.
+	Version *Version
+
+	// Names from the target info; any of them may be empty.
+	NetBIOSComputer string
+	NetBIOSDomain   string
+	DNSComputer     string
+	DNSDomain       string
+	DNSTree         string
+	// Timestamp is the server's clock, or zero when not sent.
+	Timestamp time.Time
+}
+
+// NegotiateMessage returns a NEGOTIATE message asking the server for its
+// target info and version.
+func NegotiateMessage() []byte {
+	b := make([]byte, 32, 40)
+	copy(b, Signature)
+	binary.LittleEndian.PutUint32(b[8:], typeNegotiate)
+	binary.LittleEndian.PutUint32(b[12:], negotiateFlags)
+	// Empty domain and workstation fields
+	return append(b, clientVersion.marshal()...)
+}
+
+// AnonymousAuthenticateMessage returns the AUTHENTICATE message of an
+// anonymous login (MS-NLMP 3.1.5.1.2) answering a challenge with the
+// given flags.
+func AnonymousAuthenticateMessage(challengeFlags uint32) []byte {
+	const payloadOffset = 72
+	flags := challengeFlags&negotiateFlags | FlagAnonymous
+
+	b := make([]byte, payloadOffset, payloadOffset+1)
+	copy(b, Signature)
+	binary.LittleEndian.PutUint32(b[8:], typeAuthenticate)
+	// LM response is a single zero byte, everything else is empty
+	putField(b[12:], 1, payloadOffset)
+	for _, field := range []int{20, 28, 36, 44, 52} {
+		putField(b[field:], 0, payloadOffset+1)
+	}
+	binary.LittleEndian.PutUint32(b[60:], flags)
+	copy(b[64:], clientVersion.marshal())
+	return append(b, 0)
+}
+
+func putField(b []byte, length, offset int) {
+	binary.LittleEndian.PutUint16(b, uint16(length))     // #nosec G115 -- fixed small lengths
+	binary.LittleEndian.PutUint16(b[2:], uint16(length)) // #nosec G115 -- fixed small lengths
+	binary.LittleEndian.PutUint32(b[4:], uint32(offset)) // #nosec G115 -- fixed small offsets
+}
+
+// ParseChallenge decodes a CHALLENGE message. Bytes after the message,
+// such as the rest of an enclosing SPNEGO token, are ignored.
+func ParseChallenge(msg []byte) (*Challenge, error) {
+	if len(msg) < 48 || !bytes.Equal(msg[:8], Signature) {
+		return nil, errors.New("ntlm: not an NTLM message")
+	}
+	if t := binary.LittleEndian.Uint32(msg[8:]); t != typeChallenge {
+		return nil, fmt.Errorf("ntlm: message type %d is not a challenge", t)
+	}
+
+	c := &Challenge{Flags: binary.LittleEndian.Uint32(msg[20:])}
+	copy(c.ServerChallenge[:], msg[24:32])
+
+	targetName, err := field(msg, 12)
+	if err != nil {
+		return nil, fmt.Errorf("ntlm: target name: %w", err)
+	}
+	c.TargetName = decodeString(targetName, c.Flags&FlagUnicode != 0)
+
+	targetInfo, err := field(msg, 40)
+	if err != nil {
+		return nil, fmt.Errorf("ntlm: target info: %w", err)
+	}
+	if err := c.parseTargetInfo(targetInfo); err != nil {
+		return nil, err
+	}
+
+	// The version sits between the fixed fields and the payload
+	if c.Flags&FlagVersion != 0 && len(msg) >= 56 && payloadStart(msg) >= 56 {
+		c.Version = &Version{
+			Major:    msg[48],
+			Minor:    msg[49],
+			Build:    binary.LittleEndian.Uint16(msg[50:]),
+			Revision: msg[55],
+		}
+	}
+	return c, nil
+}
+
+// Marshal encodes c as a CHALLENGE message, as a server would send it.
+func (c *Challenge) Marshal() []byte {
+	var info []byte
+	for _, av := range []struct {
+		id    uint16
+		value string
+	}{
+		{avNbDomainName, c.NetBIOSDomain},
+		{avNbComputerName, c.NetBIOSComputer},
+		{avDNSDomainName, c.DNSDomain},
+		{avDNSComputerName, c.DNSComputer},
+		{avDNSTreeName, c.DNSTree},
+	} {
+		if av.value != "" {
+			info = appendAV(info, av.id, EncodeUTF16(av.value))
+		}
+	}
+	if !c.Timestamp.IsZero() {
+		ts := make([]byte, 8)
+		binary.LittleEndian.PutUint64(ts, toFileTime(c.Timestamp))
+		info = appendAV(info, avTimestamp, ts)
+	}
+	info = appendAV(info, avEOL, nil)
+
+	flags := c.Flags | FlagUnicode | FlagTargetInfo
+	if c.Version != nil {
+		flags |= FlagVersion
+	} else {
+		flags &^= FlagVersion
+	}
+	targetName := EncodeUTF16(c.TargetName)
+
+	b := make([]byte, 56)
+	copy(b, Signature)
+	binary.LittleEndian.PutUint32(b[8:], typeChallenge)
+	putField(b[12:], len(targetName), len(b))
+	binary.LittleEndian.PutUint32(b[20:], flags)
+	copy(b[24:], c.ServerChallenge[:])
+	putField(b[40:], len(info), len(b)+len(targetName))
+	if c.Version != nil {
+		copy(b[48:], c.Version.marshal())
+	}
+	b = append(b, targetName...)
+	return append(b, info...)
+}
+
+func appendAV(b []byte, id uint16, value []byte) []byte {
+	b = binary.LittleEndian.AppendUint16(b, id)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(value))) // #nosec G115 -- names are short
+	return append(b, value...)
+}
+
+// field returns the payload referenced by the length/offset field at pos.
+func field(msg []byte, pos int) ([]byte, error) {
+	length := int(binary.LittleEndian.Uint16(msg[pos:]))
+	offset := int(binary.LittleEndian.Uint32(msg[pos+4:]))
+	if length == 0 {
+		return nil, nil
+	}
+	if offset < 0 || offset+length > len(msg) {
+		return nil, errors.New("out of bounds")
+	}
+	return msg[offset : offset+length], nil
+}
+
+// payloadStart returns where the first non-empty payload of a challenge
+// begins, or the message length when there is none.
+func payloadStart(msg []byte) int {
+	start := len(msg)
+	for _, pos := range []int{12, 40} {
+		if binary.LittleEndian.Uint16(msg[pos:]) == 0 {
+			continue
+		}
+		if offset := int(binary.LittleEndian.Uint32(msg[pos+4:])); offset < start {
+			start = offset
+		}
+	}
+	return start
+}
+
+func (c *Challenge) parseTargetInfo(info []byte) error {
+	for len(info) >= 4 {
+		id := binary.LittleEndian.Uint16(info)
+		length := int(binary.LittleEndian.Uint16(info[2:]))
+		if id == avEOL {
+			return nil
+		}
+		if 4+length > len(info) {
+			return errors.New("ntlm: truncated target info")
+		}
+		value := info[4 : 4+length]
+		switch id {
+		case avNbComputerName:
+			c.NetBIOSComputer = decodeString(value, true)
+		case avNbDomainName:
+			c.NetBIOSDomain = decodeString(value, true)
+		case avDNSComputerName:
+			c.DNSComputer = decodeString(value, true)
+		case avDNSDomainName:
+			c.DNSDomain = decodeString(value, true)
+		case avDNSTreeName:
+			c.DNSTree = decodeString(value, true)
+		case avTimestamp:
+			if length == 8 {
+				c.Timestamp = FileTime(binary.LittleEndian.Uint64(value))
+			}
+		}
+		info = info[4+length:]
+	}
+	return nil
+}
+
+// FileTime converts a Windows FILETIME, in 100ns intervals since 1601,
+// to a time. Zero stays the zero time.
+func FileTime(ft uint64) time.Time {
+	const epochDiff = 116444736000000000 // 1601 to 1970 in 100ns
+	if ft < epochDiff {
+		return time.Time{}
+	}
+	d := ft - epochDiff
+	return time.Unix(int64(d/1e7), int64(d%1e7)*100).UTC() // #nosec G115 -- d/1e7 fits in int64
+}
+
+func toFileTime(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100) + 116444736000000000 // #nosec G115 -- times after 1970
+}
+
+func decodeString(b []byte, unicode bool) string {
+	if !unicode {
+		return string(b)
+	}
+	return DecodeUTF16(b)
+}
+
+// DecodeUTF16 decodes little-endian UTF-16, dropping a trailing NUL.
+func DecodeUTF16(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	for len(u) > 0 && u[len(u)-1] == 0 {
+		u = u[:len(u)-1]
+	}
+	return string(utf16.Decode(u))
+}
+
+// EncodeUTF16 encodes s as little-endian UTF-16 without a terminator.
+func EncodeUTF16(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i, c := range u {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+	return b
+}
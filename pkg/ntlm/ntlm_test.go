@@ -0,0 +1,94 @@
+package ntlm
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChallenge(t *testing.T) {
+	// MS-NLMP 4.2.4.3, the NTLMv2 CHALLENGE message example
+	msg, err := hex.DecodeString(strings.Join([]string{
+		"4e544c4d53535000020000000c000c003800000033828ae20123456789abcdef",
+		"00000000000000002400240044000000060070170000000f5300650072007600",
+		"6500720002000c0044006f006d00610069006e0001000c005300650072007600",
+		"6500720000000000",
+	}, ""))
+	require.NoError(t, err)
+
+	c, err := ParseChallenge(msg)
+	require.NoError(t, err)
+	require.Equal(t, "Server", c.TargetName)
+	require.Equal(t, "Domain", c.NetBIOSDomain)
+	require.Equal(t, "Server", c.NetBIOSComputer)
+	require.Equal(t, [8]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, c.ServerChallenge)
+	require.Equal(t, &Version{Major: 6, Minor: 0, Build: 6000, Revision: 15}, c.Version)
+	require.Equal(t, "6.0.6000", c.Version.String())
+	require.True(t, c.Timestamp.IsZero())
+}
+
+func TestParseChallenge_Errors(t *testing.T) {
+	_, err := ParseChallenge([]byte("HTTP/1.1 401"))
+	require.EqualError(t, err, "ntlm: not an NTLM message")
+
+	_, err = ParseChallenge(append(NegotiateMessage(), make([]byte, 16)...))
+	require.EqualError(t, err, "ntlm: message type 1 is not a challenge")
+
+	msg := (&Challenge{NetBIOSDomain: "CORP"}).Marshal()
+	binary.LittleEndian.PutUint16(msg[40:], 200)
+	_, err = ParseChallenge(msg)
+	require.EqualError(t, err, "ntlm: target info: out of bounds")
+}
+
+func TestChallenge_RoundTrip(t *testing.T) {
+	want := &Challenge{
+		Flags:           FlagUnicode | FlagTargetInfo | FlagVersion | FlagNTLM,
+		ServerChallenge: [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		TargetName:      "CORP",
+		Version:         &Version{Major: 10, Minor: 0, Build: 17763, Revision: 15},
+		NetBIOSComputer: "DC01",
+		NetBIOSDomain:   "CORP",
+		DNSComputer:     "dc01.corp.example.com",
+		DNSDomain:       "corp.example.com",
+		DNSTree:         "example.com",
+		Timestamp:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	got, err := ParseChallenge(want.Marshal())
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	// Without a version, the version field is left out
+	want.Version = nil
+	want.Flags &^= FlagVersion
+	got, err = ParseChallenge(want.Marshal())
+	require.NoError(t, err)
+	require.Nil(t, got.Version)
+}
+
+func TestMessages(t *testing.T) {
+	negotiate := NegotiateMessage()
+	require.Len(t, negotiate, 40)
+	require.Equal(t, Signature, negotiate[:8])
+	require.NotZero(t, binary.LittleEndian.Uint32(negotiate[12:])&FlagVersion)
+
+	// Key exchange is not echoed: an anonymous login has no session key
+	auth := AnonymousAuthenticateMessage(FlagUnicode | FlagNTLM | 0x40000000)
+	require.Len(t, auth, 73)
+	require.Equal(t, uint32(3), binary.LittleEndian.Uint32(auth[8:]))
+	flags := binary.LittleEndian.Uint32(auth[60:])
+	require.Equal(t, uint32(FlagUnicode|FlagNTLM|FlagAnonymous), flags)
+	// LM response: one zero byte at the payload offset
+	require.Equal(t, uint16(1), binary.LittleEndian.Uint16(auth[12:]))
+	require.Equal(t, uint32(72), binary.LittleEndian.Uint32(auth[16:]))
+	require.Equal(t, uint16(0), binary.LittleEndian.Uint16(auth[20:]))
+}
+
+func TestUTF16(t *testing.T) {
+	require.Equal(t, "Ünïcode", DecodeUTF16(append(EncodeUTF16("Ünïcode"), 0, 0)))
+	require.Empty(t, DecodeUTF16(nil))
+}
@@ -67,6 +67,8 @@ type HumanFormatter struct {
 	stdout       io.Writer
 	stderr       io.Writer
 	colorEnabled bool
+	// Length of the progress line being overwritten, 0 if none
+	progressLen int
 }
 
 // NewHumanFormatter creates a new HumanFormatter subscriber.
@@ -238,9 +240,14 @@ func (s *HumanFormatter) printTable(headers []string, rows [][]string) {
 func (s *HumanFormatter) printProgress(current, total int, message string) {
 	if total > 0 {
 		percentage := float64(current) / float64(total) * 100
-		fmt.Fprintf(s.stdout, "\r[%3.0f%%] %s", percentage, message)
+		line := fmt.Sprintf("[%3.0f%%] %s", percentage, message)
+		// Pad to blank out the rest of a longer previous line
+		pad := max(s.progressLen-len(line), 0)
+		fmt.Fprintf(s.stdout, "\r%s%s", line, strings.Repeat(" ", pad))
+		s.progressLen = len(line)
 		if current == total {
 			fmt.Fprintln(s.stdout) // Newline when complete
+			s.progressLen = 0
 		}
 	}
 }
@@ -0,0 +1,272 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Plugin bundles package plugins with their manifest into a single
+// .tar.gz archive for air-gapped installs:
+//
+//	manifest.yaml                        PluginManifest listing every plugin
+//	plugins/<id>/<version>/plugin.yaml   plugin files, verified by checksum
+//
+// Manifest entry URLs are archive paths. A bundle is used as a plugin
+// source whose URL is the bundle file (see BundleSource).
+const (
+	bundleManifestName = "manifest.yaml"
+	bundlePluginsDir   = "plugins"
+
+	// maxBundleSize bounds the unpacked size of a bundle read into memory.
+	maxBundleSize = 256 << 20
+)
+
+// Bundle is a plugin pack: a manifest and the plugin files it lists.
+type Bundle struct {
+	Manifest PluginManifest
+	files    map[string][]byte // archive path -> content
+}
+
+// NewBundle returns an empty bundle.
+func NewBundle() *Bundle {
+	return &Bundle{
+		Manifest: PluginManifest{Version: "1.0"},
+		files:    make(map[string][]byte),
+	}
+}
+
+// Add adds a plugin file to the bundle. The entry categories must be in the
+// taxonomy (see Category). The entry URL is set to the archive path of the
+// file; an empty checksum or size is computed from data.
+func (b *Bundle) Add(entry PluginManifestEntry, data []byte) error {
+	if err := validatePluginID(entry.ID); err != nil {
+		return err
+	}
+	if entry.Version == "" {
+		return fmt.Errorf("%w: plugin %s has no version", ErrInvalidOption, entry.ID)
+	}
+	if err := validateVersion(entry.Version); err != nil {
+		return err
+	}
+	for _, category := range entry.Categories {
+		if err := category.Validate(); err != nil {
+			return fmt.Errorf("%w: plugin %s: %v", ErrInvalidOption, entry.ID, err)
+		}
+	}
+
+	if entry.Checksum == "" {
+		hash := sha256.Sum256(data)
+		entry.Checksum = "sha256:" + hex.EncodeToString(hash[:])
+	} else if err := verifyChecksum(data, entry.Checksum); err != nil {
+		return fmt.Errorf("plugin %s: %w: %v", entry.ID, ErrChecksumMismatch, err)
+	}
+	entry.URL = path.Join(bundlePluginsDir, entry.ID, entry.Version, "plugin.yaml")
+	entry.Size = int64(len(data))
+
+	b.Manifest.Plugins = append(b.Manifest.Plugins, entry)
+	b.files[entry.URL] = data
+	return nil
+}
+
+// File returns the content of the file at the archive path name.
+func (b *Bundle) File(name string) ([]byte, bool) {
+	data, ok := b.files[name]
+	return data, ok
+}
+
+// Write writes the bundle as a .tar.gz archive, manifest first.
+func (b *Bundle) Write(w io.Writer) error {
+	manifest, err := yaml.Marshal(b.Manifest)
+	if err != nil {
+		return fmt.Errorf("encode bundle manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := time.Now().UTC().Truncate(time.Second)
+
+	names := make([]string, 0, len(b.files))
+	for name := range b.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeFile := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := writeFile(bundleManifestName, manifest); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	for _, name := range names {
+		if err := writeFile(name, b.files[name]); err != nil {
+			return fmt.Errorf("write bundle: %w", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadBundle reads a bundle written by Bundle.Write. Every manifest entry
+// must point to a file in the archive.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	b := NewBundle()
+	var manifest []byte
+	var total int64
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(hdr.Name)
+		if name != bundleManifestName && !strings.HasPrefix(name, bundlePluginsDir+"/") {
+			continue
+		}
+		total += hdr.Size
+		if hdr.Size < 0 || total > maxBundleSize {
+			return nil, fmt.Errorf("read bundle: exceeds %d MiB", maxBundleSize>>20)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %s: %w", name, err)
+		}
+		if name == bundleManifestName {
+			manifest = data
+		} else {
+			b.files[name] = data
+		}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("read bundle: missing %s", bundleManifestName)
+	}
+	if err := yaml.Unmarshal(manifest, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("read bundle: decode %s: %w", bundleManifestName, err)
+	}
+	for i, entry := range b.Manifest.Plugins {
+		name := path.Clean(entry.URL)
+		if _, ok := b.files[name]; !ok {
+			return nil, fmt.Errorf("read bundle: plugin %s: missing file %s", entry.ID, entry.URL)
+		}
+		b.Manifest.Plugins[i].URL = name
+	}
+	return b, nil
+}
+
+// OpenBundle reads the bundle file at name.
+func OpenBundle(name string) (*Bundle, error) {
+	f, err := os.Open(name) // #nosec G304 -- bundle path is chosen by the user
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	b, err := ReadBundle(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return b, nil
+}
+
+// BundleSource returns a plugin source named name serving the bundle file
+// at file. The bundle is read when the source manifest is fetched.
+func BundleSource(name, file string) (PluginSource, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return PluginSource{}, fmt.Errorf("bundle %s: %w", file, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return PluginSource{}, fmt.Errorf("bundle %s: %w", file, err)
+	}
+	if info.IsDir() {
+		return PluginSource{}, fmt.Errorf("bundle %s: is a directory", file)
+	}
+	return PluginSource{
+		Name:    name,
+		URL:     (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String(),
+		Enabled: true,
+	}, nil
+}
+
+// IsBundleSource reports whether src serves a bundle file rather than a
+// remote manifest.
+func IsBundleSource(src PluginSource) bool {
+	return isFileURL(src.URL)
+}
+
+func isFileURL(rawURL string) bool {
+	return strings.HasPrefix(strings.ToLower(rawURL), "file:")
+}
+
+// bundleFileURL splits a file URL into the bundle path and the archive path
+// of a file inside it (the fragment), which is empty for the bundle itself.
+func bundleFileURL(rawURL string) (bundlePath, name string, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid bundle URL %q: %w", rawURL, err)
+	}
+	if u.Path == "" {
+		return "", "", fmt.Errorf("invalid bundle URL %q: missing path", rawURL)
+	}
+	return filepath.FromSlash(u.Path), u.Fragment, nil
+}
+
+// bundleMemberURL returns the URL of the archive path name inside the
+// bundle at bundleURL.
+func bundleMemberURL(bundleURL, name string) string {
+	u, err := url.Parse(bundleURL)
+	if err != nil {
+		return bundleURL + "#" + name
+	}
+	u.Fragment = name
+	return u.String()
+}
@@ -0,0 +1,290 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const bundleTestPlugin = `id: ssh-weak-kex
+name: SSH Weak Key Exchange
+version: 1.0.0
+type: evaluation
+author: vulntor-core
+metadata:
+  severity: medium
+  tags: [ssh]
+triggers:
+  - data_key: ssh.kex
+    condition: exists
+    value: true
+match:
+  logic: OR
+  rules:
+    - field: ssh.kex
+      operator: contains
+      value: "diffie-hellman-group1-sha1"
+output:
+  vulnerability: true
+  severity: medium
+  message: "SSH server offers a weak key exchange"
+`
+
+func bundleTestChecksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+func TestBundle_WriteRead(t *testing.T) {
+	b := NewBundle()
+	require.NoError(t, b.Add(PluginManifestEntry{
+		ID:         "ssh-weak-kex",
+		Name:       "SSH Weak Key Exchange",
+		Version:    "1.0.0",
+		Categories: []Category{CategorySSH},
+	}, []byte(bundleTestPlugin)))
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(&buf))
+
+	read, err := ReadBundle(&buf)
+	require.NoError(t, err)
+	require.Len(t, read.Manifest.Plugins, 1)
+
+	entry := read.Manifest.Plugins[0]
+	require.Equal(t, "plugins/ssh-weak-kex/1.0.0/plugin.yaml", entry.URL)
+	require.Equal(t, bundleTestChecksum([]byte(bundleTestPlugin)), entry.Checksum)
+	require.Equal(t, int64(len(bundleTestPlugin)), entry.Size)
+	require.Equal(t, []Category{CategorySSH}, entry.Categories)
+
+	data, ok := read.File(entry.URL)
+	require.True(t, ok)
+	require.Equal(t, bundleTestPlugin, string(data))
+}
+
+func TestBundle_Add_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		entry   PluginManifestEntry
+		wantErr error
+	}{
+		{
+			name:    "checksum mismatch",
+			entry:   PluginManifestEntry{ID: "ssh-weak-kex", Version: "1.0.0", Checksum: "sha256:abc123"},
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "path in version",
+			entry:   PluginManifestEntry{ID: "ssh-weak-kex", Version: "../1.0.0"},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "missing version",
+			entry:   PluginManifestEntry{ID: "ssh-weak-kex"},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "invalid ID",
+			entry:   PluginManifestEntry{ID: "../ssh", Version: "1.0.0"},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "unknown category",
+			entry:   PluginManifestEntry{ID: "ssh-weak-kex", Version: "1.0.0", Categories: []Category{"mail/smtp"}},
+			wantErr: ErrInvalidInput,
+		},
+		{
+			name:    "malformed subcategory",
+			entry:   PluginManifestEntry{ID: "ssh-weak-kex", Version: "1.0.0", Categories: []Category{"database/My SQL"}},
+			wantErr: ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewBundle().Add(tt.entry, []byte(bundleTestPlugin))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadBundle_Invalid(t *testing.T) {
+	writeArchive := func(files map[string]string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for name, content := range files {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		return &buf
+	}
+
+	manifest, err := yaml.Marshal(PluginManifest{
+		Version: "1.0",
+		Plugins: []PluginManifestEntry{{ID: "ssh-weak-kex", Version: "1.0.0", URL: "plugins/ssh-weak-kex/1.0.0/plugin.yaml"}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		archive *bytes.Buffer
+		wantErr string
+	}{
+		{name: "not gzip", archive: bytes.NewBufferString("manifest.yaml"), wantErr: "read bundle"},
+		{name: "missing manifest", archive: writeArchive(map[string]string{"plugins/x.yaml": "x"}), wantErr: "missing manifest.yaml"},
+		{name: "missing plugin file", archive: writeArchive(map[string]string{"manifest.yaml": string(manifest)}), wantErr: "missing file plugins/ssh-weak-kex/1.0.0/plugin.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBundle(tt.archive)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDownloader_Offline(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	d := NewDownloader(cache, WithOffline(true))
+
+	_, err = d.FetchManifest(context.Background(), PluginSource{Name: "official", URL: server.URL + "/manifest.yaml", Enabled: true})
+	require.ErrorIs(t, err, ErrOffline)
+	_, err = d.downloadFile(context.Background(), server.URL+"/plugin.yaml")
+	require.ErrorIs(t, err, ErrOffline)
+	require.Zero(t, requests)
+}
+
+// newBundleTestService returns a service whose cache holds the test plugin,
+// as after `vulntor plugin install ssh`.
+func newBundleTestService(t *testing.T) *Service {
+	t.Helper()
+	svc, err := NewService(WithCacheDir(filepath.Join(t.TempDir(), "cache")))
+	require.NoError(t, err)
+
+	data := []byte(bundleTestPlugin)
+	var p YAMLPlugin
+	require.NoError(t, yaml.Unmarshal(data, &p))
+	cached, err := svc.cache.(*CacheManager).Add(context.Background(), &p, bundleTestChecksum(data), "", data)
+	require.NoError(t, err)
+	require.NoError(t, svc.manifest.Add(&ManifestEntry{
+		ID:          p.ID,
+		Name:        p.Name,
+		Version:     p.Version,
+		Type:        "evaluation",
+		Author:      p.Author,
+		Checksum:    bundleTestChecksum(data),
+		InstalledAt: time.Now(),
+		Path:        filepath.Join(p.ID, p.Version, "plugin.yaml"),
+		Tags:        []string{"ssh"},
+	}))
+	require.FileExists(t, cached.Path)
+	return svc
+}
+
+func TestService_Bundle(t *testing.T) {
+	ctx := context.Background()
+	svc := newBundleTestService(t)
+
+	t.Run("by category", func(t *testing.T) {
+		var buf bytes.Buffer
+		result, err := svc.Bundle(ctx, &buf, BundleOptions{Targets: []string{"ssh"}})
+		require.NoError(t, err)
+		require.Len(t, result.Plugins, 1)
+		require.Equal(t, "ssh-weak-kex", result.Plugins[0].ID)
+
+		b, err := ReadBundle(&buf)
+		require.NoError(t, err)
+		require.Len(t, b.Manifest.Plugins, 1)
+		require.Equal(t, []Category{CategorySSH}, b.Manifest.Plugins[0].Categories)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		_, err := svc.Bundle(ctx, &bytes.Buffer{}, BundleOptions{Targets: []string{"http"}})
+		require.ErrorIs(t, err, ErrPluginNotInstalled)
+	})
+
+	t.Run("tampered cache file", func(t *testing.T) {
+		tampered := newBundleTestService(t)
+		entry, err := tampered.cache.GetEntry(ctx, "ssh-weak-kex", "1.0.0")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(entry.Path, []byte(bundleTestPlugin+"# changed\n"), 0o644))
+
+		_, err = tampered.Bundle(ctx, &bytes.Buffer{}, BundleOptions{})
+		require.ErrorIs(t, err, ErrChecksumMismatch)
+	})
+}
+
+func TestService_Install_OfflineFromBundle(t *testing.T) {
+	ctx := context.Background()
+
+	bundlePath := filepath.Join(t.TempDir(), "vulntor-plugins.tar.gz")
+	f, err := os.Create(bundlePath)
+	require.NoError(t, err)
+	_, err = newBundleTestService(t).Bundle(ctx, f, BundleOptions{})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// The default source is unreachable; offline mode must not try it
+	svc, err := NewService(
+		WithCacheDir(filepath.Join(t.TempDir(), "cache")),
+		WithPluginSources([]PluginSource{{Name: "official", URL: "http://127.0.0.1:1/manifest.yaml", Enabled: true}}),
+		WithOfflineMode(true),
+		WithBundles(bundlePath),
+	)
+	require.NoError(t, err)
+	require.Len(t, svc.sources, 1)
+	require.Equal(t, "bundle", svc.sources[0].Name)
+
+	result, err := svc.Install(ctx, "ssh", InstallOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.InstalledCount)
+
+	entry, err := svc.cache.GetEntry(ctx, "ssh-weak-kex", "1.0.0")
+	require.NoError(t, err)
+	data, err := os.ReadFile(entry.Path)
+	require.NoError(t, err)
+	require.Equal(t, bundleTestPlugin, string(data))
+}
+
+func TestNewService_Offline(t *testing.T) {
+	t.Run("without bundles", func(t *testing.T) {
+		svc, err := NewService(WithCacheDir(filepath.Join(t.TempDir(), "cache")), WithOfflineMode(true))
+		require.NoError(t, err)
+		require.Empty(t, svc.sources)
+
+		_, err = svc.Install(context.Background(), "ssh", InstallOptions{})
+		require.ErrorIs(t, err, ErrOffline)
+	})
+
+	t.Run("missing bundle file", func(t *testing.T) {
+		_, err := NewService(WithCacheDir(filepath.Join(t.TempDir(), "cache")), WithBundles(filepath.Join(t.TempDir(), "missing.tar.gz")))
+		require.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
@@ -9,11 +9,17 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// lastUsedFile is the stamp file recording when a cached plugin version was
+// last used, as its modification time. File access times are not relied
+// on: many file systems are mounted noatime or relatime.
+const lastUsedFile = ".last_used"
+
 // CacheManager manages plugin download cache.
 // Default cache location: XDG cache dir (e.g., ~/.cache/vulntor/plugins/cache).
 type CacheManager struct {
@@ -22,11 +28,37 @@ type CacheManager struct {
 
 	// Registry for tracking cached plugins
 	registry *YAMLRegistry
+
+	// Maximum cache size in bytes; 0 means unbounded
+	maxSize int64
+
+	// Workspace wordlists cached plugins may reference
+	wordlistDir string
+}
+
+// CacheOption configures a CacheManager.
+type CacheOption func(*CacheManager)
+
+// WithCacheSizeLimit bounds the cache to maxBytes. When an added plugin
+// pushes the cache over the limit, the least recently used plugin versions
+// are evicted. Zero or less means unbounded.
+func WithCacheSizeLimit(maxBytes int64) CacheOption {
+	return func(c *CacheManager) {
+		c.maxSize = max(maxBytes, 0)
+	}
+}
+
+// WithCacheWordlistDir makes the wordlists in dir available to the
+// {{wordlist:name}} references of cached plugins.
+func WithCacheWordlistDir(dir string) CacheOption {
+	return func(c *CacheManager) {
+		c.wordlistDir = dir
+	}
 }
 
 // NewCacheManager creates a new cache manager.
 // It scans the cache directory and loads existing plugins into the registry.
-func NewCacheManager(cacheDir string) (*CacheManager, error) {
+func NewCacheManager(cacheDir string, opts ...CacheOption) (*CacheManager, error) {
 	if cacheDir == "" {
 		return nil, fmt.Errorf("cache directory cannot be empty")
 	}
@@ -40,6 +72,9 @@ func NewCacheManager(cacheDir string) (*CacheManager, error) {
 		cacheDir: cacheDir,
 		registry: NewYAMLRegistry(),
 	}
+	for _, opt := range opts {
+		opt(cm)
+	}
 
 	// Load existing plugins from disk into registry
 	// This prevents re-downloading already cached plugins
@@ -114,6 +149,12 @@ func (c *CacheManager) Add(ctx context.Context, plugin *YAMLPlugin, checksum, do
 		}
 	}
 
+	c.Touch(plugin.ID, plugin.Version)
+
+	// Make room for the new plugin. Eviction is best effort: the plugin is
+	// cached either way, and the next Add or Evict retries.
+	_, _ = c.evict(ctx, plugin.ID, plugin.Version)
+
 	now := time.Now()
 	entry := &CacheEntry{
 		ID:          plugin.ID,
@@ -129,9 +170,38 @@ func (c *CacheManager) Add(ctx context.Context, plugin *YAMLPlugin, checksum, do
 	return entry, nil
 }
 
-// Get retrieves a cached plugin by ID.
+// Get retrieves a cached plugin by ID and records its use.
 func (c *CacheManager) Get(id string) (*YAMLPlugin, bool) {
-	return c.registry.Get(id)
+	plugin, found := c.registry.Get(id)
+	if found {
+		c.Touch(plugin.ID, plugin.Version)
+	}
+	return plugin, found
+}
+
+// Touch records that a cached plugin version was used, which keeps it
+// from being evicted before less recently used ones.
+func (c *CacheManager) Touch(id, version string) {
+	path := filepath.Join(c.cacheDir, id, version, lastUsedFile)
+	if _, err := os.Stat(path); err != nil {
+		// First use; fails when the version is not cached
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			return
+		}
+	}
+	// Set the time explicitly: file system timestamps on creation may be
+	// coarser than the clock
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// lastUsed returns when the plugin version in dir was last used, or
+// fallback when no use was recorded.
+func lastUsed(dir string, fallback time.Time) time.Time {
+	if info, err := os.Stat(filepath.Join(dir, lastUsedFile)); err == nil {
+		return info.ModTime()
+	}
+	return fallback
 }
 
 // GetEntry retrieves a cache entry by ID and version.
@@ -170,7 +240,7 @@ func (c *CacheManager) GetEntry(ctx context.Context, id, version string) (*Cache
 		Version:  version,
 		Path:     cachePath,
 		CachedAt: info.ModTime(),
-		LastUsed: info.ModTime(),
+		LastUsed: lastUsed(pluginDir, info.ModTime()),
 	}
 
 	return entry, nil
@@ -362,6 +432,160 @@ func (c *CacheManager) Prune(ctx context.Context, olderThan time.Duration) (int,
 	return removed, nil
 }
 
+// CacheUsage is the disk usage of one cached plugin version.
+type CacheUsage struct {
+	ID       string    `json:"id"`
+	Version  string    `json:"version"`
+	Size     int64     `json:"size"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// CacheStats summarizes cache usage.
+type CacheStats struct {
+	// Entries is the number of cached plugin versions
+	Entries int `json:"entries"`
+
+	// Plugins is the number of distinct cached plugins
+	Plugins int `json:"plugins"`
+
+	// Size is the total size of the cached plugins in bytes
+	Size int64 `json:"size"`
+
+	// MaxSize is the configured size limit in bytes; 0 means unbounded
+	MaxSize int64 `json:"max_size"`
+
+	// Usage lists the cached plugin versions, least recently used
+	// (first to be evicted) first
+	Usage []CacheUsage `json:"usage"`
+}
+
+// MaxSize returns the cache size limit in bytes; 0 means unbounded.
+func (c *CacheManager) MaxSize() int64 {
+	return c.maxSize
+}
+
+// Stats returns the disk usage of the cache.
+func (c *CacheManager) Stats(ctx context.Context) (*CacheStats, error) {
+	usage, err := c.usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &CacheStats{Entries: len(usage), MaxSize: c.maxSize, Usage: usage}
+	plugins := make(map[string]struct{})
+	for _, u := range usage {
+		stats.Size += u.Size
+		plugins[u.ID] = struct{}{}
+	}
+	stats.Plugins = len(plugins)
+	return stats, nil
+}
+
+// Evict removes the least recently used plugin versions until the cache
+// fits its size limit. It returns the number of versions removed.
+func (c *CacheManager) Evict(ctx context.Context) (int, error) {
+	return c.evict(ctx, "", "")
+}
+
+// evict is Evict sparing the given plugin version, which was just added.
+func (c *CacheManager) evict(ctx context.Context, keepID, keepVersion string) (int, error) {
+	if c.maxSize <= 0 {
+		return 0, nil
+	}
+
+	usage, err := c.usage(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, u := range usage {
+		total += u.Size
+	}
+
+	removed := 0
+	for _, u := range usage {
+		if total <= c.maxSize {
+			break
+		}
+		if u.ID == keepID && u.Version == keepVersion {
+			continue
+		}
+		if err := c.Remove(ctx, u.ID, u.Version); err != nil {
+			return removed, fmt.Errorf("evict %s@%s: %w", u.ID, u.Version, err)
+		}
+		total -= u.Size
+		removed++
+	}
+	return removed, nil
+}
+
+// usage returns the disk usage of every cached plugin version, least
+// recently used first.
+func (c *CacheManager) usage(ctx context.Context) ([]CacheUsage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	plugins, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var usage []CacheUsage
+	for _, p := range plugins {
+		if !p.IsDir() {
+			continue
+		}
+		versions, err := os.ReadDir(filepath.Join(c.cacheDir, p.Name()))
+		if err != nil {
+			continue
+		}
+		for _, v := range versions {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if !v.IsDir() {
+				continue
+			}
+			dir := filepath.Join(c.cacheDir, p.Name(), v.Name())
+			info, err := v.Info()
+			if err != nil {
+				continue
+			}
+			size, err := dirSize(dir)
+			if err != nil {
+				continue
+			}
+			usage = append(usage, CacheUsage{
+				ID:       p.Name(),
+				Version:  v.Name(),
+				Size:     size,
+				LastUsed: lastUsed(dir, info.ModTime()),
+			})
+		}
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		return usage[i].LastUsed.Before(usage[j].LastUsed)
+	})
+	return usage, nil
+}
+
+// dirSize returns the total size of the files below dir.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
 // LoadFromDisk loads all cached plugins from disk into the registry.
 func (c *CacheManager) LoadFromDisk(ctx context.Context) (int, []error) {
 	// Check context cancellation
@@ -369,7 +593,7 @@ func (c *CacheManager) LoadFromDisk(ctx context.Context) (int, []error) {
 		return 0, []error{err}
 	}
 
-	loader := NewLoader(c.cacheDir)
+	loader := NewLoader(c.cacheDir, c.wordlistDirs()...)
 	plugins, err := loader.LoadRecursive(c.cacheDir)
 	if err != nil {
 		// Partial success - some plugins loaded, some failed
@@ -383,3 +607,18 @@ func (c *CacheManager) LoadFromDisk(ctx context.Context) (int, []error) {
 	// All plugins loaded successfully
 	return c.registry.RegisterBulk(plugins)
 }
+
+// wordlistDirs returns the workspace wordlist directories cached plugins
+// read, if any.
+func (c *CacheManager) wordlistDirs() []string {
+	if c.wordlistDir == "" {
+		return nil
+	}
+	return []string{c.wordlistDir}
+}
+
+// readWordlist returns the entries of a workspace wordlist, for plugins
+// parsed before they are cached.
+func (c *CacheManager) readWordlist(name string) ([]string, error) {
+	return readWordlist(name, c.wordlistDirs()...)
+}
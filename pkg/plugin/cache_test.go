@@ -605,6 +605,88 @@ func TestCacheManager_Clear_ReadDirError(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCacheManager_Evict(t *testing.T) {
+	cacheDir := t.TempDir()
+	ctx := context.Background()
+
+	newPlugin := func(id string) *YAMLPlugin {
+		return &YAMLPlugin{
+			ID:       id,
+			Name:     id,
+			Version:  "1.0.0",
+			Type:     EvaluationType,
+			Author:   "test",
+			Metadata: PluginMetadata{Severity: HighSeverity},
+			Output:   OutputBlock{Message: "Test"},
+		}
+	}
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = '#'
+	}
+
+	// Room for two plugins
+	cm, err := NewCacheManager(cacheDir, WithCacheSizeLimit(2500))
+	require.NoError(t, err)
+	require.Equal(t, int64(2500), cm.MaxSize())
+
+	for i, id := range []string{"a", "b"} {
+		_, err = cm.Add(ctx, newPlugin(id), "sha256:abc", "https://example.com", data)
+		require.NoError(t, err)
+		used := time.Now().Add(time.Duration(i-10) * time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(cacheDir, id, "1.0.0", lastUsedFile), used, used))
+	}
+
+	// a is older, but was used last
+	_, found := cm.Get("a")
+	require.True(t, found)
+
+	_, err = cm.Add(ctx, newPlugin("c"), "sha256:abc", "https://example.com", data)
+	require.NoError(t, err)
+
+	stats, err := cm.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Entries)
+	require.Equal(t, 2, stats.Plugins)
+	require.Equal(t, int64(2000), stats.Size)
+	require.Equal(t, int64(2500), stats.MaxSize)
+	require.Equal(t, "a", stats.Usage[0].ID)
+	require.Equal(t, "c", stats.Usage[1].ID)
+	require.NoDirExists(t, filepath.Join(cacheDir, "b"))
+	_, found = cm.registry.Get("b")
+	require.False(t, found)
+
+	// Lowering the limit takes effect on Evict
+	cm.maxSize = 1500
+	removed, err := cm.Evict(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, removed)
+	require.NoDirExists(t, filepath.Join(cacheDir, "a"))
+
+	// A plugin larger than the limit is kept
+	cm.maxSize = 500
+	_, err = cm.Add(ctx, newPlugin("d"), "sha256:abc", "https://example.com", data)
+	require.NoError(t, err)
+	require.DirExists(t, filepath.Join(cacheDir, "d", "1.0.0"))
+	require.NoDirExists(t, filepath.Join(cacheDir, "c"))
+}
+
+func TestCacheManager_Evict_Unbounded(t *testing.T) {
+	cm, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(cm.cacheDir, "a", "1.0.0"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cm.cacheDir, "a", "1.0.0", "plugin.yaml"), make([]byte, 1<<20), 0o644))
+
+	removed, err := cm.Evict(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, removed)
+
+	stats, err := cm.Stats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1<<20), stats.Size)
+	require.Zero(t, stats.MaxSize)
+}
+
 func TestCacheManager_Prune_ReadDirError(t *testing.T) {
 	// Create cache manager with non-existent directory
 	cm := &CacheManager{
@@ -4,9 +4,28 @@
 
 package plugin
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
 // Category represents a plugin category for organization and filtering.
+//
+// Categories form a taxonomy: a category is a slash-separated path whose
+// first segment is one of the standard categories, followed by optional
+// subcategories (e.g., "database/mysql", "web/cms/wordpress"). A category
+// includes all of its subcategories.
 type Category string
 
+// CategoryWildcard ends a category pattern (e.g., "database/*"), which
+// selects the category and all of its subcategories.
+const CategoryWildcard = "/*"
+
+// categorySegmentPattern matches a subcategory segment: lowercase
+// alphanumeric with hyphens, starting with a letter or digit.
+var categorySegmentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
 // Standard plugin categories.
 const (
 	CategorySSH      Category = "ssh"
@@ -38,8 +57,33 @@ func (c Category) String() string {
 	return string(c)
 }
 
-// IsValid checks if the category is a valid standard category.
+// IsValid checks if the category is a standard category or one of its
+// subcategories. Patterns are not categories (see IsPattern).
 func (c Category) IsValid() bool {
+	return c.Validate() == nil
+}
+
+// Validate checks the category against the taxonomy and returns an error
+// describing the first problem found.
+func (c Category) Validate() error {
+	if c == "" {
+		return fmt.Errorf("category is empty")
+	}
+	segments := c.Segments()
+	root := Category(segments[0])
+	if !isStandardCategory(root) {
+		return fmt.Errorf("unknown top-level category '%s' (valid: %s)", root, strings.Join(GetValidCategories(), ", "))
+	}
+	for _, segment := range segments[1:] {
+		if !categorySegmentPattern.MatchString(segment) {
+			return fmt.Errorf("invalid subcategory '%s' in '%s' (must be lowercase alphanumeric with hyphens)", segment, c)
+		}
+	}
+	return nil
+}
+
+// isStandardCategory reports whether c is one of AllCategories.
+func isStandardCategory(c Category) bool {
 	for _, cat := range AllCategories() {
 		if c == cat {
 			return true
@@ -48,8 +92,53 @@ func (c Category) IsValid() bool {
 	return false
 }
 
+// Segments returns the path segments of the category, e.g. ["web", "cms",
+// "wordpress"] for "web/cms/wordpress".
+func (c Category) Segments() []string {
+	return strings.Split(string(c), "/")
+}
+
+// Root returns the top-level category, e.g. "web" for "web/cms/wordpress".
+func (c Category) Root() Category {
+	root, _, _ := strings.Cut(string(c), "/")
+	return Category(root)
+}
+
+// Parent returns the category one level up, or "" for a top-level category.
+func (c Category) Parent() Category {
+	i := strings.LastIndex(string(c), "/")
+	if i < 0 {
+		return ""
+	}
+	return c[:i]
+}
+
+// Contains reports whether other is c or one of its subcategories. A
+// pattern contains the same categories as its base category.
+func (c Category) Contains(other Category) bool {
+	base := c.Base()
+	return other == base || strings.HasPrefix(string(other), string(base)+"/")
+}
+
+// IsPattern reports whether c is a valid category followed by the wildcard
+// (e.g., "database/*").
+func (c Category) IsPattern() bool {
+	return strings.HasSuffix(string(c), CategoryWildcard) && c.Base().IsValid()
+}
+
+// Base returns the category with the wildcard of a pattern removed.
+func (c Category) Base() Category {
+	return Category(strings.TrimSuffix(string(c), CategoryWildcard))
+}
+
+// IsSelector reports whether c selects plugins by category: a valid
+// category or a pattern.
+func (c Category) IsSelector() bool {
+	return c.IsValid() || c.IsPattern()
+}
+
 // CategoryFromString converts a string to a Category.
-// Returns CategoryMisc if the string doesn't match any known category.
+// Returns CategoryMisc if the string isn't a valid category.
 func CategoryFromString(s string) Category {
 	cat := Category(s)
 	if cat.IsValid() {
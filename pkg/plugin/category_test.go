@@ -38,8 +38,15 @@ func TestCategory_IsValid(t *testing.T) {
 		{"valid ssh", CategorySSH, true},
 		{"valid http", CategoryHTTP, true},
 		{"valid database", CategoryDatabase, true},
+		{"valid subcategory", Category("database/mysql"), true},
+		{"valid nested subcategory", Category("web/cms/wordpress"), true},
 		{"invalid custom", Category("custom"), false},
 		{"invalid empty", Category(""), false},
+		{"invalid root", Category("mail/smtp"), false},
+		{"invalid empty segment", Category("database//mysql"), false},
+		{"invalid trailing slash", Category("database/"), false},
+		{"invalid uppercase segment", Category("database/MySQL"), false},
+		{"pattern is not a category", Category("database/*"), false},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +56,60 @@ func TestCategory_IsValid(t *testing.T) {
 	}
 }
 
+func TestCategory_Validate(t *testing.T) {
+	require.NoError(t, Category("web/cms/wordpress").Validate())
+	require.ErrorContains(t, Category("mail/smtp").Validate(), "unknown top-level category 'mail'")
+	require.ErrorContains(t, Category("web/CMS").Validate(), "invalid subcategory 'CMS' in 'web/CMS'")
+}
+
+func TestCategory_Hierarchy(t *testing.T) {
+	c := Category("web/cms/wordpress")
+	require.Equal(t, []string{"web", "cms", "wordpress"}, c.Segments())
+	require.Equal(t, CategoryWeb, c.Root())
+	require.Equal(t, Category("web/cms"), c.Parent())
+	require.Equal(t, CategoryWeb, c.Parent().Parent())
+	require.Equal(t, Category(""), CategoryWeb.Parent())
+	require.Equal(t, CategoryWeb, CategoryWeb.Root())
+}
+
+func TestCategory_Contains(t *testing.T) {
+	tests := []struct {
+		selector Category
+		category Category
+		want     bool
+	}{
+		{"database", "database", true},
+		{"database", "database/mysql", true},
+		{"database/*", "database", true},
+		{"database/*", "database/mysql", true},
+		{"database/*", "database/mysql/galera", true},
+		{"database/mysql", "database/mysql", true},
+		{"database/mysql", "database", false},
+		{"database/mysql", "database/mysqlx", false},
+		{"database/*", "databases", false},
+		{"web/*", "http", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.selector)+" "+string(tt.category), func(t *testing.T) {
+			require.Equal(t, tt.want, tt.selector.Contains(tt.category))
+		})
+	}
+}
+
+func TestCategory_IsPattern(t *testing.T) {
+	require.True(t, Category("database/*").IsPattern())
+	require.True(t, Category("web/cms/*").IsPattern())
+	require.False(t, Category("database").IsPattern())
+	require.False(t, Category("mail/*").IsPattern())
+	require.False(t, Category("/*").IsPattern())
+	require.Equal(t, CategoryDatabase, Category("database/*").Base())
+
+	require.True(t, Category("database/*").IsSelector())
+	require.True(t, Category("database/mysql").IsSelector())
+	require.False(t, Category("database*").IsSelector())
+}
+
 func TestCategoryFromString(t *testing.T) {
 	tests := []struct {
 		name  string
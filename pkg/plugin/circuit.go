@@ -0,0 +1,184 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// CircuitBreakerConfig defines when the Downloader stops contacting a
+// failing source.
+//
+// After FailureThreshold consecutive transient failures (see WithRetry) the
+// circuit of the source opens: fetches from it fail at once with
+// ErrCircuitOpen. Once Cooldown has passed, the circuit is half-open: the
+// next fetch is let through as the only probe of the source while the
+// others keep failing; its success closes the circuit and its failure opens
+// it again.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that open the
+	// circuit (0 = never open)
+	// Default: 5
+	FailureThreshold int
+
+	// Cooldown is how long an open circuit rejects fetches
+	// Default: 30 seconds
+	Cooldown time.Duration
+}
+
+// DefaultCircuitBreakerConfig returns sensible defaults for the circuit breaker.
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		FailureThreshold: 5,
+		Cooldown:         30 * time.Second,
+	}
+}
+
+// NoCircuitBreaker returns a config that never opens the circuit.
+func NoCircuitBreaker() CircuitBreakerConfig {
+	return CircuitBreakerConfig{}
+}
+
+// Validate checks if the circuit breaker config is valid.
+func (c CircuitBreakerConfig) Validate() error {
+	if c.FailureThreshold < 0 {
+		return fmt.Errorf("FailureThreshold must be >= 0, got %d", c.FailureThreshold)
+	}
+	if c.Cooldown < 0 {
+		return fmt.Errorf("cooldown must be >= 0, got %v", c.Cooldown)
+	}
+	return nil
+}
+
+// circuitBreaker tracks the consecutive failures of each source. A nil
+// breaker lets everything through.
+type circuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	sources map[string]*circuitState
+}
+
+// circuitState is the state of one source.
+type circuitState struct {
+	failures int
+	openedAt time.Time // Zero while the circuit is closed
+	probing  bool      // A fetch probes the source after the cooldown
+}
+
+// newCircuitBreaker returns a breaker for config, or nil if it never opens.
+func newCircuitBreaker(config CircuitBreakerConfig) *circuitBreaker {
+	if config.FailureThreshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{
+		config:  config,
+		now:     time.Now,
+		sources: make(map[string]*circuitState),
+	}
+}
+
+// allow returns ErrCircuitOpen while the circuit of source is open, and
+// while another fetch probes it. probe is true for the fetch let through
+// to probe the source after the cooldown; its outcome must be recorded.
+func (b *circuitBreaker) allow(source string) (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.sources[source]
+	if !ok || state.openedAt.IsZero() {
+		return false, nil
+	}
+	wait := b.config.Cooldown - b.now().Sub(state.openedAt)
+	if state.probing {
+		return false, fmt.Errorf("%w: source '%s' failed %d times in a row, probing it again",
+			ErrCircuitOpen, source, state.failures)
+	}
+	if wait <= 0 {
+		state.probing = true
+		return true, nil
+	}
+	return false, fmt.Errorf("%w: source '%s' failed %d times in a row, retrying in %s",
+		ErrCircuitOpen, source, state.failures, wait.Round(time.Second))
+}
+
+// record updates the circuit of source with the outcome of a fetch, the
+// probe of the source when probe is set. Transient failures count against
+// the source; any other outcome except cancellation means it responded and
+// closes the circuit. A cancelled probe lets the next fetch probe instead.
+func (b *circuitBreaker) record(source string, probe bool, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.sources[source]
+	if ok && probe {
+		state.probing = false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	if !isRetryableError(err) {
+		delete(b.sources, source)
+		return
+	}
+	if !ok {
+		state = &circuitState{}
+		b.sources[source] = state
+	}
+	state.failures++
+	if state.failures >= b.config.FailureThreshold {
+		state.openedAt = b.now()
+	}
+}
+
+// retryPolicy returns the retry and circuit breaker configs for cfg, with
+// defaults for its zero fields or for a nil cfg.
+func retryPolicy(cfg *config.PluginRetryConfig) (RetryConfig, CircuitBreakerConfig, error) {
+	if cfg == nil {
+		return DefaultRetryConfig(), DefaultCircuitBreakerConfig(), nil
+	}
+	retry := DefaultRetryConfig()
+	if cfg.Attempts > 0 {
+		retry.MaxAttempts = cfg.Attempts
+	}
+	if cfg.InitialWait > 0 {
+		retry.InitialWait = cfg.InitialWait
+	}
+	if cfg.MaxWait > 0 {
+		retry.MaxWait = cfg.MaxWait
+		retry.InitialWait = min(retry.InitialWait, retry.MaxWait)
+	}
+	if err := retry.Validate(); err != nil {
+		return RetryConfig{}, CircuitBreakerConfig{}, fmt.Errorf("%w: retry: %v", ErrInvalidOption, err)
+	}
+
+	breaker := DefaultCircuitBreakerConfig()
+	switch {
+	case cfg.BreakerThreshold < 0:
+		breaker = NoCircuitBreaker()
+	case cfg.BreakerThreshold > 0:
+		breaker.FailureThreshold = cfg.BreakerThreshold
+	}
+	if cfg.BreakerCooldown > 0 {
+		breaker.Cooldown = cfg.BreakerCooldown
+	}
+	if err := breaker.Validate(); err != nil {
+		return RetryConfig{}, CircuitBreakerConfig{}, fmt.Errorf("%w: circuit breaker: %v", ErrInvalidOption, err)
+	}
+	return retry, breaker, nil
+}
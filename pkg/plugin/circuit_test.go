@@ -0,0 +1,238 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 3, Cooldown: time.Minute})
+	b.now = func() time.Time { return now }
+
+	transient := errors.New("unexpected status code: 503")
+
+	b.record("official", false, transient)
+	b.record("official", false, transient)
+	probe, err := b.allow("official")
+	require.NoError(t, err, "below the threshold")
+	require.False(t, probe)
+
+	b.record("official", false, transient)
+	_, err = b.allow("official")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.ErrorIs(t, err, ErrSourceNotAvailable)
+	require.EqualError(t, err, "plugin source not available: too many consecutive failures: source 'official' failed 3 times in a row, retrying in 1m0s")
+	_, err = b.allow("github")
+	require.NoError(t, err, "circuits are per source")
+
+	// After the cooldown one probe is let through; its failure reopens
+	now = now.Add(time.Minute)
+	probe, err = b.allow("official")
+	require.NoError(t, err)
+	require.True(t, probe)
+	_, err = b.allow("official")
+	require.EqualError(t, err, "plugin source not available: too many consecutive failures: source 'official' failed 3 times in a row, probing it again")
+	b.record("official", true, transient)
+	_, err = b.allow("official")
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	// A cancelled probe lets the next fetch probe
+	now = now.Add(time.Minute)
+	probe, _ = b.allow("official")
+	require.True(t, probe)
+	b.record("official", true, context.Canceled)
+	probe, err = b.allow("official")
+	require.NoError(t, err)
+	require.True(t, probe)
+
+	// The probe's success closes the circuit
+	b.record("official", true, nil)
+	probe, err = b.allow("official")
+	require.NoError(t, err)
+	require.False(t, probe)
+	b.record("official", false, transient)
+	_, err = b.allow("official")
+	require.NoError(t, err, "failures count from zero again")
+}
+
+func TestCircuitBreaker_HalfOpenAdmitsOneProbe(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+	b.now = func() time.Time { return now }
+	b.record("official", false, errors.New("connection refused"))
+	now = now.Add(time.Minute)
+
+	var allowed, probes atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probe, err := b.allow("official")
+			if err == nil {
+				allowed.Add(1)
+			}
+			if probe {
+				probes.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), allowed.Load(), "only the probe gets through while half-open")
+	require.Equal(t, int32(1), probes.Load())
+}
+
+func TestCircuitBreaker_IgnoredOutcomes(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute})
+
+	b.record("official", false, context.Canceled)
+	_, err := b.allow("official")
+	require.NoError(t, err, "cancellation says nothing about the source")
+
+	b.record("official", false, errors.New("unexpected status code: 404"))
+	_, err = b.allow("official")
+	require.NoError(t, err, "the source responded")
+
+	require.Nil(t, newCircuitBreaker(NoCircuitBreaker()))
+	var disabled *circuitBreaker
+	disabled.record("official", false, errors.New("connection refused"))
+	_, err = disabled.allow("official")
+	require.NoError(t, err)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	retry, breaker, err := retryPolicy(nil)
+	require.NoError(t, err)
+	require.Equal(t, DefaultRetryConfig(), retry)
+	require.Equal(t, DefaultCircuitBreakerConfig(), breaker)
+
+	retry, breaker, err = retryPolicy(&config.PluginRetryConfig{
+		Attempts:         5,
+		MaxWait:          500 * time.Millisecond,
+		BreakerThreshold: 10,
+		BreakerCooldown:  time.Minute,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 5, retry.MaxAttempts)
+	require.Equal(t, 500*time.Millisecond, retry.InitialWait, "capped at max wait")
+	require.Equal(t, CircuitBreakerConfig{FailureThreshold: 10, Cooldown: time.Minute}, breaker)
+
+	_, breaker, err = retryPolicy(&config.PluginRetryConfig{BreakerThreshold: -1})
+	require.NoError(t, err)
+	require.Equal(t, NoCircuitBreaker(), breaker)
+}
+
+func TestDownloader_CircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	source := PluginSource{Name: "flaky", URL: server.URL + "/manifest.yaml", Enabled: true}
+	downloader := NewDownloader(cache,
+		WithSources([]PluginSource{source}),
+		WithRetryConfig(NoRetry()),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Minute}),
+	)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := downloader.FetchManifest(ctx, source)
+		require.ErrorContains(t, err, "unexpected status code: 503")
+	}
+
+	_, err = downloader.FetchManifest(ctx, source)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	_, err = downloader.Download(ctx, "ssh-weak-kex", "1.0.0")
+	require.ErrorIs(t, err, ErrCircuitOpen, "download reports why the source was skipped")
+	require.Equal(t, "SOURCE_CIRCUIT_OPEN", ErrorCode(err))
+	require.Equal(t, int32(2), requests.Load(), "open circuit sends no requests")
+}
+
+func TestDownloader_CircuitBreakerHalfOpen(t *testing.T) {
+	var requests atomic.Int32
+	healthy := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		<-healthy
+		_, _ = w.Write([]byte("version: 1\nplugins: []\n"))
+	}))
+	defer server.Close()
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	source := PluginSource{Name: "flaky", URL: server.URL + "/manifest.yaml", Enabled: true}
+	downloader := NewDownloader(cache,
+		WithSources([]PluginSource{source}),
+		WithRetryConfig(NoRetry()),
+		WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute}),
+	)
+	now := time.Now()
+	downloader.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err = downloader.FetchManifest(ctx, source)
+	require.ErrorContains(t, err, "unexpected status code: 503")
+	now = now.Add(time.Minute)
+
+	// While the probe is in flight, concurrent fetches fail at once
+	probed := make(chan error, 1)
+	go func() {
+		_, err := downloader.FetchManifest(ctx, source)
+		probed <- err
+	}()
+	require.Eventually(t, func() bool { return requests.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	var wg sync.WaitGroup
+	var rejected atomic.Int32
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := downloader.FetchManifest(ctx, source); errors.Is(err, ErrCircuitOpen) {
+				rejected.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(10), rejected.Load())
+	require.Equal(t, int32(2), requests.Load(), "only the probe reaches the source")
+
+	// The probe's success closes the circuit
+	close(healthy)
+	require.NoError(t, <-probed)
+	_, err = downloader.FetchManifest(ctx, source)
+	require.NoError(t, err)
+}
+
+func TestIsRetryableError_StatusCodes(t *testing.T) {
+	for _, code := range []string{"429", "500", "502", "503", "504"} {
+		require.True(t, isRetryableError(errors.New("unexpected status code: "+code)), code)
+	}
+	for _, code := range []string{"400", "401", "403", "404", "501", "505"} {
+		require.False(t, isRetryableError(errors.New("unexpected status code: "+code)), code)
+	}
+}
@@ -38,13 +38,14 @@ func IsValidSource(source string) bool {
 	return false
 }
 
-// IsValidCategory checks if a category is valid.
+// IsValidCategory checks if a category selector is valid.
 //
-// This is a convenience wrapper around Category.IsValid() for use in
+// This is a convenience wrapper around Category.IsSelector() for use in
 // validation layers that work with string types.
 //
 // Returns:
-//   - true if category is valid (exists in AllCategories())
+//   - true if category is in the taxonomy (e.g., "database/mysql") or is a
+//     pattern (e.g., "database/*")
 //   - false otherwise
 //
 // Example:
@@ -53,10 +54,10 @@ func IsValidSource(source string) bool {
 //	    return fmt.Errorf("invalid category")
 //	}
 func IsValidCategory(category string) bool {
-	return Category(category).IsValid()
+	return Category(category).IsSelector()
 }
 
-// GetValidCategories returns a list of all valid category names as strings.
+// GetValidCategories returns the names of the top-level categories as strings.
 //
 // This is useful for generating error messages with allowed values.
 //
@@ -0,0 +1,105 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import "sort"
+
+// Verdict classifies a plugin by its executions across scans.
+type Verdict string
+
+const (
+	// VerdictErroring plugins failed in every scan that evaluated them.
+	VerdictErroring Verdict = "erroring"
+
+	// VerdictAlwaysMatched plugins matched every time they were evaluated,
+	// which usually means they report false positives.
+	VerdictAlwaysMatched Verdict = "always-matched"
+
+	// VerdictNeverMatched plugins never matched in any scan.
+	VerdictNeverMatched Verdict = "never-matched"
+)
+
+// verdictOrder lists verdicts by how urgently they need attention.
+var verdictOrder = map[Verdict]int{
+	VerdictErroring:      0,
+	VerdictAlwaysMatched: 1,
+	VerdictNeverMatched:  2,
+}
+
+// Curation reports a plugin that needs a maintainer's attention, with its
+// executions summed over the scans that evaluated it.
+type Curation struct {
+	PluginID string  `json:"plugin_id"`
+	Plugin   string  `json:"plugin"`
+	Verdict  Verdict `json:"verdict"`
+	Scans    int     `json:"scans"`
+	Attempts int64   `json:"attempts"`
+	Matches  int64   `json:"matches"`
+	Errors   int64   `json:"errors"`
+}
+
+// Curate analyzes the plugin executions of past scans, one ExecStats slice
+// per scan, and returns the plugins that never matched, always matched or
+// errored in every scan. Plugins evaluated in fewer than minScans scans are
+// not judged. The result lists erroring plugins first, then always matched
+// and never matched ones, each by the number of scans, most first.
+func Curate(history [][]ExecStats, minScans int) []Curation {
+	type tally struct {
+		Curation
+		alwaysMatched bool
+		alwaysErrored bool
+	}
+	tallies := make(map[string]*tally)
+	for _, scan := range history {
+		for _, s := range scan {
+			if s.Attempts == 0 {
+				continue
+			}
+			key := s.PluginID
+			if key == "" {
+				key = s.Plugin
+			}
+			t, ok := tallies[key]
+			if !ok {
+				t = &tally{Curation: Curation{PluginID: s.PluginID, Plugin: s.Plugin}, alwaysMatched: true, alwaysErrored: true}
+				tallies[key] = t
+			}
+			t.Scans++
+			t.Attempts += s.Attempts
+			t.Matches += s.Matches
+			t.Errors += s.Errors
+			t.alwaysMatched = t.alwaysMatched && s.Matches == s.Attempts
+			t.alwaysErrored = t.alwaysErrored && s.Errors > 0
+		}
+	}
+
+	var out []Curation
+	for _, t := range tallies {
+		if t.Scans < minScans {
+			continue
+		}
+		switch {
+		case t.alwaysErrored:
+			t.Verdict = VerdictErroring
+		case t.alwaysMatched:
+			t.Verdict = VerdictAlwaysMatched
+		case t.Matches == 0:
+			t.Verdict = VerdictNeverMatched
+		default:
+			continue
+		}
+		out = append(out, t.Curation)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Verdict != out[j].Verdict {
+			return verdictOrder[out[i].Verdict] < verdictOrder[out[j].Verdict]
+		}
+		if out[i].Scans != out[j].Scans {
+			return out[i].Scans > out[j].Scans
+		}
+		return out[i].PluginID < out[j].PluginID
+	})
+	return out
+}
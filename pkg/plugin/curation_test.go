@@ -0,0 +1,57 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurate(t *testing.T) {
+	scan := func(stats ...ExecStats) []ExecStats { return stats }
+	history := [][]ExecStats{
+		scan(
+			ExecStats{PluginID: "quiet", Plugin: "Quiet", Attempts: 4},
+			ExecStats{PluginID: "noisy", Plugin: "Noisy", Attempts: 2, Matches: 2},
+			ExecStats{PluginID: "broken", Plugin: "Broken", Attempts: 3, Errors: 1, Matches: 2},
+			ExecStats{PluginID: "useful", Plugin: "Useful", Attempts: 5, Matches: 1},
+			ExecStats{PluginID: "rare", Plugin: "Rare", Attempts: 1},
+		),
+		scan(
+			ExecStats{PluginID: "quiet", Plugin: "Quiet", Attempts: 2},
+			ExecStats{PluginID: "noisy", Plugin: "Noisy", Attempts: 1, Matches: 1},
+			ExecStats{PluginID: "broken", Plugin: "Broken", Attempts: 1, Errors: 1},
+			ExecStats{PluginID: "useful", Plugin: "Useful", Attempts: 5},
+			ExecStats{PluginID: "flaky", Plugin: "Flaky", Attempts: 1, Errors: 1},
+		),
+		scan(
+			ExecStats{PluginID: "quiet", Plugin: "Quiet", Attempts: 1},
+			ExecStats{PluginID: "noisy", Plugin: "Noisy", Attempts: 3, Matches: 3},
+			ExecStats{PluginID: "broken", Plugin: "Broken", Attempts: 2, Errors: 2},
+			ExecStats{PluginID: "useful", Plugin: "Useful", Attempts: 5},
+			ExecStats{PluginID: "flaky", Plugin: "Flaky", Attempts: 1},
+			// Plugins that were loaded but never evaluated do not count
+			ExecStats{PluginID: "rare", Plugin: "Rare"},
+		),
+	}
+
+	require.Equal(t, []Curation{
+		{PluginID: "broken", Plugin: "Broken", Verdict: VerdictErroring, Scans: 3, Attempts: 6, Matches: 2, Errors: 4},
+		{PluginID: "noisy", Plugin: "Noisy", Verdict: VerdictAlwaysMatched, Scans: 3, Attempts: 6, Matches: 6},
+		{PluginID: "quiet", Plugin: "Quiet", Verdict: VerdictNeverMatched, Scans: 3, Attempts: 7},
+		{PluginID: "flaky", Plugin: "Flaky", Verdict: VerdictNeverMatched, Scans: 2, Attempts: 2, Errors: 1},
+		{PluginID: "rare", Plugin: "Rare", Verdict: VerdictNeverMatched, Scans: 1, Attempts: 1},
+	}, Curate(history, 1))
+
+	// Plugins evaluated in fewer than minScans scans are not judged
+	curated := Curate(history, 3)
+	require.Len(t, curated, 3)
+	for _, c := range curated {
+		require.Equal(t, 3, c.Scans, c.PluginID)
+	}
+
+	require.Empty(t, Curate(nil, 1))
+}
@@ -5,6 +5,7 @@
 package plugin
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -12,9 +13,13 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+	"github.com/vulntor/vulntor/pkg/netproxy"
 )
 
 // Note: PluginSource and PluginManifestEntry are now defined in service_types.go
@@ -40,6 +45,13 @@ type Downloader struct {
 	httpClient  *http.Client
 	cache       *CacheManager
 	retryConfig RetryConfig
+	breaker     *circuitBreaker
+	limiter     *bandwidth.Limiter
+	proxy       *netproxy.Proxy
+	offline     bool
+
+	mu      sync.Mutex
+	bundles map[string]*Bundle // bundle path -> contents
 }
 
 // DownloaderOption configures the Downloader.
@@ -52,6 +64,24 @@ func WithHTTPClient(client *http.Client) DownloaderOption {
 	}
 }
 
+// WithProxy routes downloads through p unless a source overrides it. A nil
+// p uses the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// Custom clients set with WithHTTPClient honor it when their transport
+// selects proxies with netproxy.HTTPProxy.
+func WithProxy(p *netproxy.Proxy) DownloaderOption {
+	return func(d *Downloader) {
+		d.proxy = p
+	}
+}
+
+// WithOffline disables network access: only bundle sources (file URLs)
+// are read, and fetches from remote sources fail with ErrOffline.
+func WithOffline(offline bool) DownloaderOption {
+	return func(d *Downloader) {
+		d.offline = offline
+	}
+}
+
 // WithSources sets the plugin sources.
 func WithSources(sources []PluginSource) DownloaderOption {
 	return func(d *Downloader) {
@@ -66,12 +96,28 @@ func WithRetryConfig(config RetryConfig) DownloaderOption {
 	}
 }
 
+// WithCircuitBreaker sets when fetches from a failing source stop.
+func WithCircuitBreaker(config CircuitBreakerConfig) DownloaderOption {
+	return func(d *Downloader) {
+		d.breaker = newCircuitBreaker(config)
+	}
+}
+
+// WithLimiter caps the download rate of all fetches to limiter. A nil
+// limiter is unlimited.
+func WithLimiter(limiter *bandwidth.Limiter) DownloaderOption {
+	return func(d *Downloader) {
+		d.limiter = limiter
+	}
+}
+
 // NewDownloader creates a new plugin downloader.
 func NewDownloader(cache *CacheManager, opts ...DownloaderOption) *Downloader {
 	d := &Downloader{
 		cache: cache,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: newProxyTransport(),
 		},
 		sources: []PluginSource{
 			{
@@ -79,12 +125,14 @@ func NewDownloader(cache *CacheManager, opts ...DownloaderOption) *Downloader {
 				URL:      "https://plugins.pentora.ai/manifest.yaml",
 				Enabled:  true,
 				Priority: 1,
+				Trust:    TrustOfficial,
 				Mirrors: []string{
 					"https://raw.githubusercontent.com/pentora-ai/pentora-plugins/main/manifest.yaml",
 				},
 			},
 		},
 		retryConfig: DefaultRetryConfig(),
+		breaker:     newCircuitBreaker(DefaultCircuitBreakerConfig()),
 	}
 
 	for _, opt := range opts {
@@ -94,25 +142,69 @@ func NewDownloader(cache *CacheManager, opts ...DownloaderOption) *Downloader {
 	return d
 }
 
-// FetchManifest retrieves the plugin manifest from a source.
+// newProxyTransport returns a transport that selects the proxy of each
+// request from its context (see sourceContext).
+func newProxyTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = netproxy.HTTPProxy
+	return t
+}
+
+// sourceContext returns ctx carrying the proxy used for source.
+func (d *Downloader) sourceContext(ctx context.Context, source PluginSource) (context.Context, error) {
+	p, err := d.proxy.Override(source.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: %w", source.Name, err)
+	}
+	return netproxy.WithContext(ctx, p), nil
+}
+
+// FetchManifest retrieves the plugin manifest from a source. Manifests of
+// sources with public keys are rejected unless the signature published
+// next to them verifies; a mirror is tried next. Sources that keep failing
+// are skipped with ErrCircuitOpen (see WithCircuitBreaker).
 func (d *Downloader) FetchManifest(ctx context.Context, source PluginSource) (*PluginManifest, error) {
+	ctx, err := d.sourceContext(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	probe, err := d.breaker.allow(source.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	urls := []string{source.URL}
 	urls = append(urls, source.Mirrors...)
 
 	var lastErr error
 	for _, url := range urls {
-		manifest, err := d.fetchManifestFromURL(ctx, url)
+		manifest, err := d.fetchManifestFromURL(ctx, url, source.PublicKeys)
 		if err == nil {
+			d.breaker.record(source.Name, probe, nil)
 			return manifest, nil
 		}
 		lastErr = err
 	}
+	d.breaker.record(source.Name, probe, lastErr)
 
 	return nil, fmt.Errorf("failed to fetch manifest from %s: %w", source.Name, lastErr)
 }
 
-func (d *Downloader) fetchManifestFromURL(ctx context.Context, url string) (*PluginManifest, error) {
-	var manifest *PluginManifest
+// fetchManifestFromURL fetches the manifest at url. When keys are given,
+// the manifest must carry a signature by one of them. Bundle manifests are
+// local and not checked.
+func (d *Downloader) fetchManifestFromURL(ctx context.Context, url string, keys []string) (*PluginManifest, error) {
+	if isFileURL(url) {
+		return d.bundleManifest(url)
+	}
+	if d.offline {
+		return nil, fmt.Errorf("fetch %s: %w", url, ErrOffline)
+	}
+
+	var (
+		manifest *PluginManifest
+		raw      []byte
+	)
 
 	err := WithRetry(ctx, d.retryConfig, func(ctx context.Context) error {
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -130,18 +222,34 @@ func (d *Downloader) fetchManifestFromURL(ctx context.Context, url string) (*Plu
 			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 		}
 
+		data, err := io.ReadAll(d.limiter.Reader(ctx, resp.Body))
+		if err != nil {
+			return fmt.Errorf("failed to read manifest: %w", err)
+		}
+
 		var m PluginManifest
-		if err := yaml.NewDecoder(resp.Body).Decode(&m); err != nil {
+		if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
 			return fmt.Errorf("failed to decode manifest: %w", err)
 		}
 
 		manifest = &m
+		raw = data
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if len(keys) > 0 {
+		signature, err := d.downloadFile(ctx, url+ManifestSignatureSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("%w: fetch signature of %s: %v", ErrSignatureInvalid, url, err)
+		}
+		if err := VerifyManifestSignature(raw, signature, keys); err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+	}
+
 	return manifest, nil
 }
 
@@ -149,12 +257,14 @@ func (d *Downloader) fetchManifestFromURL(ctx context.Context, url string) (*Plu
 func (d *Downloader) Download(ctx context.Context, id, version string) (*CacheEntry, error) {
 	// Check if already cached
 	if entry, err := d.cache.GetEntry(ctx, id, version); err == nil {
+		d.cache.Touch(id, version)
 		return entry, nil
 	}
 
 	// Find plugin in manifests
 	var manifestEntry *PluginManifestEntry
-	var sourceName string
+	var pluginSource PluginSource
+	var fetchErr error
 
 	for _, source := range d.sources {
 		if !source.Enabled {
@@ -163,13 +273,14 @@ func (d *Downloader) Download(ctx context.Context, id, version string) (*CacheEn
 
 		manifest, err := d.FetchManifest(ctx, source)
 		if err != nil {
+			fetchErr = err
 			continue // Try next source
 		}
 
 		for _, plugin := range manifest.Plugins {
 			if plugin.ID == id && (version == "" || plugin.Version == version) {
 				manifestEntry = &plugin
-				sourceName = source.Name
+				pluginSource = source
 				break
 			}
 		}
@@ -180,10 +291,23 @@ func (d *Downloader) Download(ctx context.Context, id, version string) (*CacheEn
 	}
 
 	if manifestEntry == nil {
+		if fetchErr != nil {
+			// The plugin may be in a source that could not be fetched
+			return nil, fmt.Errorf("plugin '%s' version '%s' not found in any source: %w", id, version, fetchErr)
+		}
 		return nil, fmt.Errorf("plugin '%s' version '%s' not found in any source", id, version)
 	}
-	// Download plugin file
-	pluginData, err := d.downloadFile(ctx, manifestEntry.URL)
+	// Download plugin file through the proxy of its source
+	sourceCtx, err := d.sourceContext(ctx, pluginSource)
+	if err != nil {
+		return nil, err
+	}
+	probe, err := d.breaker.allow(pluginSource.Name)
+	if err != nil {
+		return nil, err
+	}
+	pluginData, err := d.downloadFile(sourceCtx, manifestEntry.URL)
+	d.breaker.record(pluginSource.Name, probe, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download plugin: %w", err)
 	}
@@ -193,15 +317,18 @@ func (d *Downloader) Download(ctx context.Context, id, version string) (*CacheEn
 		return nil, fmt.Errorf("checksum verification failed: %w", err)
 	}
 
-	// Parse plugin
-	var yamlPlugin YAMLPlugin
-	if err := yaml.Unmarshal(pluginData, &yamlPlugin); err != nil {
+	// Parse plugin; it may reference workspace wordlists
+	yamlPlugin, err := parseYAMLPlugin(pluginData, nil, d.cache.readWordlist)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse plugin: %w", err)
 	}
+	if err := yamlPlugin.checkCompatible(); err != nil {
+		return nil, err
+	}
 
 	// Add to cache (pass raw data to preserve checksum)
-	sourceURL := fmt.Sprintf("%s (source: %s)", manifestEntry.URL, sourceName)
-	entry, err := d.cache.Add(ctx, &yamlPlugin, manifestEntry.Checksum, sourceURL, pluginData)
+	sourceURL := fmt.Sprintf("%s (source: %s)", manifestEntry.URL, pluginSource.Name)
+	entry, err := d.cache.Add(ctx, yamlPlugin, manifestEntry.Checksum, sourceURL, pluginData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to cache plugin: %w", err)
 	}
@@ -209,7 +336,8 @@ func (d *Downloader) Download(ctx context.Context, id, version string) (*CacheEn
 	return entry, nil
 }
 
-// DownloadByCategory fetches all plugins for a given category.
+// DownloadByCategory fetches all plugins for a given category, including
+// its subcategories.
 func (d *Downloader) DownloadByCategory(ctx context.Context, category Category) ([]*CacheEntry, error) {
 	entries := make([]*CacheEntry, 0)
 
@@ -228,7 +356,7 @@ func (d *Downloader) DownloadByCategory(ctx context.Context, category Category)
 		for _, plugin := range manifest.Plugins {
 			hasCategory := false
 			for _, cat := range plugin.Categories {
-				if cat == category {
+				if category.Contains(cat) {
 					hasCategory = true
 					break
 				}
@@ -304,6 +432,17 @@ func (d *Downloader) Update(ctx context.Context) (int, error) {
 }
 
 func (d *Downloader) downloadFile(ctx context.Context, url string) ([]byte, error) {
+	if isFileURL(url) {
+		data, err := d.bundleFile(url)
+		if err == nil {
+			reportDownloaded(ctx, int64(len(data)))
+		}
+		return data, err
+	}
+	if d.offline {
+		return nil, fmt.Errorf("fetch %s: %w", url, ErrOffline)
+	}
+
 	var data []byte
 
 	err := WithRetry(ctx, d.retryConfig, func(ctx context.Context) error {
@@ -322,12 +461,12 @@ func (d *Downloader) downloadFile(ctx context.Context, url string) ([]byte, erro
 			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 		}
 
-		d, err := io.ReadAll(resp.Body)
+		body, err := io.ReadAll(withDownloadProgress(ctx, d.limiter.Reader(ctx, resp.Body)))
 		if err != nil {
 			return fmt.Errorf("failed to read response: %w", err)
 		}
 
-		data = d
+		data = body
 		return nil
 	})
 	if err != nil {
@@ -337,6 +476,59 @@ func (d *Downloader) downloadFile(ctx context.Context, url string) ([]byte, erro
 	return data, nil
 }
 
+// openBundle reads the bundle at the path of a file URL once per downloader.
+func (d *Downloader) openBundle(rawURL string) (*Bundle, string, error) {
+	bundlePath, name, err := bundleFileURL(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if b, ok := d.bundles[bundlePath]; ok {
+		return b, name, nil
+	}
+	b, err := OpenBundle(bundlePath)
+	if err != nil {
+		return nil, "", err
+	}
+	if d.bundles == nil {
+		d.bundles = make(map[string]*Bundle)
+	}
+	d.bundles[bundlePath] = b
+	return b, name, nil
+}
+
+// bundleManifest returns the manifest of the bundle at rawURL, with entry
+// URLs pointing into the bundle.
+func (d *Downloader) bundleManifest(rawURL string) (*PluginManifest, error) {
+	b, _, err := d.openBundle(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := b.Manifest
+	manifest.Plugins = make([]PluginManifestEntry, len(b.Manifest.Plugins))
+	for i, entry := range b.Manifest.Plugins {
+		entry.URL = bundleMemberURL(rawURL, entry.URL)
+		manifest.Plugins[i] = entry
+	}
+	return &manifest, nil
+}
+
+// bundleFile returns the bundle file a bundle manifest entry URL points to.
+func (d *Downloader) bundleFile(rawURL string) ([]byte, error) {
+	b, name, err := d.openBundle(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := b.File(name)
+	if !ok {
+		return nil, fmt.Errorf("%s: not in bundle", rawURL)
+	}
+	return data, nil
+}
+
 func verifyChecksum(data []byte, expectedChecksum string) error {
 	// Expected format: "sha256:hex"
 	parts := strings.SplitN(expectedChecksum, ":", 2)
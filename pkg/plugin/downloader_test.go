@@ -6,7 +6,9 @@ package plugin
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"net/http"
@@ -16,6 +18,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
+
+	"github.com/vulntor/vulntor/pkg/netproxy"
 )
 
 func TestNewDownloader(t *testing.T) {
@@ -119,7 +123,7 @@ func TestDownloader_FetchManifest_WithMirrors(t *testing.T) {
 	cache, err := NewCacheManager(cacheDir)
 	require.NoError(t, err)
 
-	downloader := NewDownloader(cache)
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()))
 	source := PluginSource{
 		Name:    "test",
 		URL:     failingServer.URL,
@@ -143,7 +147,7 @@ func TestDownloader_FetchManifest_AllFail(t *testing.T) {
 	cache, err := NewCacheManager(cacheDir)
 	require.NoError(t, err)
 
-	downloader := NewDownloader(cache)
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()))
 	source := PluginSource{
 		Name:    "test",
 		URL:     failingServer.URL,
@@ -237,6 +241,149 @@ func TestDownloader_Download(t *testing.T) {
 	require.Equal(t, entry.Name, cachedEntry.Name)
 }
 
+// newForwardProxy serves requests for hosts only reachable through the
+// proxy, recording the requested URLs.
+func newForwardProxy(t *testing.T, files map[string][]byte) (*httptest.Server, *[]string) {
+	t.Helper()
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.String())
+		data, ok := files[r.URL.String()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requested
+}
+
+func TestDownloader_Download_ThroughProxy(t *testing.T) {
+	plugin := &YAMLPlugin{
+		ID:       "test-plugin",
+		Name:     "test-plugin",
+		Version:  "1.0.0",
+		Type:     EvaluationType,
+		Author:   "test-author",
+		Metadata: PluginMetadata{Severity: HighSeverity},
+		Output:   OutputBlock{Message: "Test vulnerability detected"},
+	}
+	pluginData, err := yaml.Marshal(plugin)
+	require.NoError(t, err)
+	hash := sha256.Sum256(pluginData)
+
+	manifestData, err := yaml.Marshal(PluginManifest{
+		Version: "1.0",
+		Plugins: []PluginManifestEntry{{
+			ID:       "test-plugin",
+			Name:     "test-plugin",
+			Version:  "1.0.0",
+			URL:      "http://plugins.corp.test/test-plugin.yaml",
+			Checksum: "sha256:" + hex.EncodeToString(hash[:]),
+		}},
+	})
+	require.NoError(t, err)
+
+	proxySrv, requested := newForwardProxy(t, map[string][]byte{
+		"http://plugins.corp.test/manifest.yaml":    manifestData,
+		"http://plugins.corp.test/test-plugin.yaml": pluginData,
+	})
+	proxy, err := netproxy.New(proxySrv.URL, nil)
+	require.NoError(t, err)
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	downloader := NewDownloader(cache,
+		WithSources([]PluginSource{{Name: "corp", URL: "http://plugins.corp.test/manifest.yaml", Enabled: true}}),
+		WithProxy(proxy),
+		WithRetryConfig(NoRetry()),
+	)
+
+	entry, err := downloader.Download(context.Background(), "test-plugin", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "test-plugin", entry.ID)
+	require.Equal(t, []string{
+		"http://plugins.corp.test/manifest.yaml",
+		"http://plugins.corp.test/test-plugin.yaml",
+	}, *requested)
+}
+
+func TestDownloader_FetchManifest_SourceProxy(t *testing.T) {
+	manifestData, err := yaml.Marshal(PluginManifest{Version: "1.0"})
+	require.NoError(t, err)
+	files := map[string][]byte{"http://plugins.partner.test/manifest.yaml": manifestData}
+
+	globalSrv, globalRequested := newForwardProxy(t, files)
+	sourceSrv, sourceRequested := newForwardProxy(t, files)
+	proxy, err := netproxy.New(globalSrv.URL, nil)
+	require.NoError(t, err)
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	downloader := NewDownloader(cache, WithProxy(proxy), WithRetryConfig(NoRetry()))
+
+	source := PluginSource{Name: "partner", URL: "http://plugins.partner.test/manifest.yaml", Enabled: true, Proxy: sourceSrv.URL}
+	_, err = downloader.FetchManifest(context.Background(), source)
+	require.NoError(t, err)
+	require.Len(t, *sourceRequested, 1)
+	require.Empty(t, *globalRequested)
+
+	source.Proxy = "gopher://proxy.partner.test"
+	_, err = downloader.FetchManifest(context.Background(), source)
+	require.ErrorContains(t, err, "source partner: invalid proxy URL")
+}
+
+func TestDownloader_FetchManifest_Signed(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	manifestData, err := yaml.Marshal(PluginManifest{Version: "1.0"})
+	require.NoError(t, err)
+	signature := SignManifest(manifestData, priv)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.yaml", "/mirror/manifest.yaml":
+			_, _ = w.Write(manifestData)
+		case "/manifest.yaml.sig":
+			_, _ = w.Write(signature)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	cache, err := NewCacheManager(t.TempDir())
+	require.NoError(t, err)
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()))
+
+	source := PluginSource{
+		Name:       "internal",
+		URL:        server.URL + "/manifest.yaml",
+		Enabled:    true,
+		PublicKeys: []string{base64.StdEncoding.EncodeToString(otherPub), base64.StdEncoding.EncodeToString(pub)},
+	}
+	manifest, err := downloader.FetchManifest(context.Background(), source)
+	require.NoError(t, err)
+	require.Equal(t, "1.0", manifest.Version)
+
+	// Signed by an untrusted key
+	source.PublicKeys = []string{base64.StdEncoding.EncodeToString(otherPub)}
+	_, err = downloader.FetchManifest(context.Background(), source)
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+	require.ErrorContains(t, err, "not signed by a trusted key")
+
+	// Mirror without a signature
+	source.PublicKeys = []string{base64.StdEncoding.EncodeToString(pub)}
+	source.URL = server.URL + "/mirror/manifest.yaml"
+	_, err = downloader.FetchManifest(context.Background(), source)
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
 func TestDownloader_Download_AlreadyCached(t *testing.T) {
 	cacheDir := t.TempDir()
 	cache, err := NewCacheManager(cacheDir)
@@ -706,7 +853,7 @@ func TestDownloader_Update_ManifestFetchError(t *testing.T) {
 		Enabled: true,
 	}
 
-	downloader := NewDownloader(cache, WithSources([]PluginSource{source}))
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()), WithSources([]PluginSource{source}))
 	ctx := context.Background()
 
 	updated, err := downloader.Update(ctx)
@@ -864,7 +1011,7 @@ func TestDownloader_fetchManifestFromURL_InvalidURL(t *testing.T) {
 
 	// Invalid URL with control characters
 	invalidURL := "http://example.com/\x00invalid"
-	manifest, err := downloader.fetchManifestFromURL(ctx, invalidURL)
+	manifest, err := downloader.fetchManifestFromURL(ctx, invalidURL, nil)
 	require.Error(t, err)
 	require.Nil(t, manifest)
 	require.Contains(t, err.Error(), "failed to create request")
@@ -880,7 +1027,7 @@ func TestDownloader_fetchManifestFromURL_NetworkError(t *testing.T) {
 
 	// Use invalid host to trigger network error
 	invalidHost := "http://invalid-host-that-does-not-exist-12345.com"
-	manifest, err := downloader.fetchManifestFromURL(ctx, invalidHost)
+	manifest, err := downloader.fetchManifestFromURL(ctx, invalidHost, nil)
 	require.Error(t, err)
 	require.Nil(t, manifest)
 	require.Contains(t, err.Error(), "failed to fetch manifest")
@@ -896,10 +1043,10 @@ func TestDownloader_fetchManifestFromURL_NonOKStatus(t *testing.T) {
 	cache, err := NewCacheManager(cacheDir)
 	require.NoError(t, err)
 
-	downloader := NewDownloader(cache)
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()))
 	ctx := context.Background()
 
-	manifest, err := downloader.fetchManifestFromURL(ctx, server.URL)
+	manifest, err := downloader.fetchManifestFromURL(ctx, server.URL, nil)
 	require.Error(t, err)
 	require.Nil(t, manifest)
 	require.Contains(t, err.Error(), "unexpected status code: 500")
@@ -965,7 +1112,7 @@ func TestDownloader_DownloadByCategory_AllManifestsFail(t *testing.T) {
 		Enabled: true,
 	}
 
-	downloader := NewDownloader(cache, WithSources([]PluginSource{source}))
+	downloader := NewDownloader(cache, WithRetryConfig(NoRetry()), WithSources([]PluginSource{source}))
 	ctx := context.Background()
 
 	entries, err := downloader.DownloadByCategory(ctx, CategorySSH)
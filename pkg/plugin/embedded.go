@@ -12,7 +12,6 @@ import (
 	"strings"
 
 	"github.com/rs/zerolog/log"
-	"gopkg.in/yaml.v3"
 )
 
 //go:embed embedded/**/*.yaml
@@ -44,9 +43,9 @@ func LoadEmbeddedPlugins() (map[Category][]*YAMLPlugin, error) {
 			return nil // Continue with other plugins
 		}
 
-		// Parse YAML plugin directly from bytes
-		var yamlPlugin YAMLPlugin
-		if err := yaml.Unmarshal(data, &yamlPlugin); err != nil {
+		// Parse YAML plugin directly from bytes, resolving its variables
+		yamlPlugin, err := parseYAMLPlugin(data, nil, nil)
+		if err != nil {
 			logger.Warn().Str("path", path).Err(err).Msg("Failed to parse embedded plugin")
 			return nil // Continue with other plugins
 		}
@@ -56,7 +55,7 @@ func LoadEmbeddedPlugins() (map[Category][]*YAMLPlugin, error) {
 		category := determineCategoryFromPath(path)
 
 		// Add to category map
-		plugins[category] = append(plugins[category], &yamlPlugin)
+		plugins[category] = append(plugins[category], yamlPlugin)
 
 		logger.Debug().
 			Str("plugin", yamlPlugin.Name).
@@ -185,3 +185,20 @@ func TestEmbeddedPluginsHaveRequiredFields(t *testing.T) {
 		})
 	}
 }
+
+func TestEmbeddedPluginsDefaultCredentials(t *testing.T) {
+	plugins, err := LoadAllEmbeddedPlugins()
+	require.NoError(t, err)
+
+	protocols := make(map[string]bool)
+	for _, plugin := range plugins {
+		if plugin.DefaultCredentials == nil {
+			continue
+		}
+		require.NoError(t, plugin.DefaultCredentials.Validate(), plugin.Name)
+		protocols[plugin.DefaultCredentials.Protocol] = true
+	}
+	for protocol := range credentialProtocols {
+		require.True(t, protocols[protocol], "no embedded default credentials for %s", protocol)
+	}
+}
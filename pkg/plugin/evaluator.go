@@ -27,6 +27,8 @@ func NewEvaluator() *Evaluator {
 
 // Evaluate evaluates a YAML plugin against a data context.
 // Returns a YAMLMatchResult indicating if the plugin matched and the output.
+// When the plugin matches, its extractors write their values into context.
+// Plugins with steps never match here; a StepRunner runs them.
 func (e *Evaluator) Evaluate(plugin *YAMLPlugin, context map[string]any) (*YAMLMatchResult, error) {
 	start := time.Now()
 
@@ -56,6 +58,15 @@ func (e *Evaluator) Evaluate(plugin *YAMLPlugin, context map[string]any) (*YAMLM
 		return result, nil
 	}
 
+	// Multi-step plugins match on the responses to their requests
+	if len(plugin.Steps) > 0 {
+		log.Debug().
+			Str("plugin", plugin.Name).
+			Msg("Plugin has steps, skipping evaluation without a step runner")
+		result.ExecutionTime = time.Since(start)
+		return result, nil
+	}
+
 	// Evaluate match block if present
 	if plugin.Match != nil {
 		log.Debug().
@@ -84,6 +95,7 @@ func (e *Evaluator) Evaluate(plugin *YAMLPlugin, context map[string]any) (*YAMLM
 	// Set output if matched
 	if result.Matched {
 		result.Output = plugin.Output
+		result.Output.Message = expandRuntime(result.Output.Message, context)
 
 		// Override severity if specified in output
 		if result.Output.Severity == "" {
@@ -95,18 +107,49 @@ func (e *Evaluator) Evaluate(plugin *YAMLPlugin, context map[string]any) (*YAMLM
 			Str("severity", string(result.Output.Severity)).
 			Str("message", result.Output.Message).
 			Msg("Plugin matched - vulnerability detected")
+
+		result.Evidence = result.Output.collectEvidence(context)
+		result.Extracted = e.extract(plugin, context)
 	}
 
 	result.ExecutionTime = time.Since(start)
 	return result, nil
 }
 
+// extract runs the plugin's extractors and writes the values found into
+// context, where plugins evaluated later can match on them. Extractors
+// that find nothing leave the context unchanged.
+func (e *Evaluator) extract(plugin *YAMLPlugin, context map[string]any) map[string]any {
+	var extracted map[string]any
+	for _, ex := range plugin.Extract {
+		value, ok, err := ex.Extract(context)
+		if err != nil {
+			log.Debug().
+				Str("plugin", plugin.Name).
+				Str("extractor", ex.Name).
+				Err(err).
+				Msg("Extractor failed")
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if extracted == nil {
+			extracted = make(map[string]any)
+		}
+		extracted[ex.Name] = value
+		context[ex.Name] = value
+	}
+	return extracted
+}
+
 // EvaluateAll evaluates multiple YAML plugins against a data context.
-// Returns all match results (both matched and not matched).
+// Returns all match results (both matched and not matched), in the order
+// the plugins were evaluated: after the plugins they extract data from.
 func (e *Evaluator) EvaluateAll(plugins []*YAMLPlugin, context map[string]any) ([]*YAMLMatchResult, error) {
 	results := make([]*YAMLMatchResult, 0, len(plugins))
 
-	for i, plugin := range plugins {
+	for i, plugin := range OrderByExtraction(plugins) {
 		result, err := e.Evaluate(plugin, context)
 		if err != nil {
 			return nil, fmt.Errorf("plugin[%d] (%s) evaluation failed: %w", i, plugin.Name, err)
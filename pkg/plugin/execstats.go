@@ -0,0 +1,125 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExecStats reports the execution of one plugin: how often it was matched
+// against a scan context or service, how often it matched or failed, and
+// the wall-clock time it took.
+type ExecStats struct {
+	PluginID string        `json:"plugin_id"`
+	Plugin   string        `json:"plugin"`
+	Attempts int64         `json:"attempts"`
+	Matches  int64         `json:"matches"`
+	Errors   int64         `json:"errors"`
+	WallTime time.Duration `json:"wall_time"`
+}
+
+// ExecRecorder collects the ExecStats of plugins, e.g. during one scan. It
+// is safe for concurrent use; a nil recorder records nothing.
+type ExecRecorder struct {
+	mu    sync.Mutex
+	stats map[string]*ExecStats
+}
+
+// NewExecRecorder returns an empty recorder.
+func NewExecRecorder() *ExecRecorder {
+	return &ExecRecorder{stats: make(map[string]*ExecStats)}
+}
+
+var totalExec = NewExecRecorder()
+
+// TotalExecStats returns the recorder accumulating the plugin executions
+// of all scans of the process, e.g. for metrics.
+func TotalExecStats() *ExecRecorder {
+	return totalExec
+}
+
+// Record counts a match attempt of p that took elapsed.
+func (r *ExecRecorder) Record(p *YAMLPlugin, elapsed time.Duration, matched bool, err error) {
+	if r == nil || p == nil {
+		return
+	}
+	stats := ExecStats{PluginID: p.ID, Plugin: p.Name, Attempts: 1, WallTime: elapsed}
+	if err != nil {
+		stats.Errors = 1
+	} else if matched {
+		stats.Matches = 1
+	}
+	r.Add([]ExecStats{stats})
+}
+
+// Add adds stats, e.g. those of a finished scan, to the recorder.
+func (r *ExecRecorder) Add(stats []ExecStats) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range stats {
+		key := s.PluginID
+		if key == "" {
+			key = s.Plugin
+		}
+		current, ok := r.stats[key]
+		if !ok {
+			current = &ExecStats{PluginID: s.PluginID, Plugin: s.Plugin}
+			r.stats[key] = current
+		}
+		current.Attempts += s.Attempts
+		current.Matches += s.Matches
+		current.Errors += s.Errors
+		current.WallTime += s.WallTime
+	}
+}
+
+// Stats returns the recorded stats, slowest plugins first.
+func (r *ExecRecorder) Stats() []ExecStats {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	out := make([]ExecStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		out = append(out, *s)
+	}
+	r.mu.Unlock()
+	SortExecStats(out)
+	return out
+}
+
+// SortExecStats sorts stats by wall-clock time, slowest first, and then by
+// plugin ID.
+func SortExecStats(stats []ExecStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].WallTime != stats[j].WallTime {
+			return stats[i].WallTime > stats[j].WallTime
+		}
+		return stats[i].PluginID < stats[j].PluginID
+	})
+}
+
+type execRecorderContextKey struct{}
+
+// WithExecRecorder returns a context carrying the recorder of the plugin
+// executions of a scan. A nil r leaves ctx unchanged.
+func WithExecRecorder(ctx context.Context, r *ExecRecorder) context.Context {
+	if r == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, execRecorderContextKey{}, r)
+}
+
+// ExecRecorderFromContext returns the recorder carried by ctx, or nil.
+func ExecRecorderFromContext(ctx context.Context) *ExecRecorder {
+	r, _ := ctx.Value(execRecorderContextKey{}).(*ExecRecorder)
+	return r
+}
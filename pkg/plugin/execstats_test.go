@@ -0,0 +1,43 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecRecorder(t *testing.T) {
+	r := NewExecRecorder()
+	slow := &YAMLPlugin{ID: "slow", Name: "Slow"}
+	fast := &YAMLPlugin{ID: "fast", Name: "Fast"}
+	r.Record(fast, time.Millisecond, true, nil)
+	r.Record(slow, 2*time.Second, false, nil)
+	r.Record(slow, time.Second, false, errors.New("boom"))
+	r.Record(fast, time.Millisecond, false, nil)
+
+	require.Equal(t, []ExecStats{
+		{PluginID: "slow", Plugin: "Slow", Attempts: 2, Errors: 1, WallTime: 3 * time.Second},
+		{PluginID: "fast", Plugin: "Fast", Attempts: 2, Matches: 1, WallTime: 2 * time.Millisecond},
+	}, r.Stats())
+
+	// Totals add up the stats of scans
+	totals := NewExecRecorder()
+	totals.Add(r.Stats())
+	totals.Add(r.Stats())
+	require.Equal(t, int64(4), totals.Stats()[0].Attempts)
+
+	ctx := WithExecRecorder(context.Background(), r)
+	require.Same(t, r, ExecRecorderFromContext(ctx))
+	require.Nil(t, ExecRecorderFromContext(context.Background()))
+
+	var nilRecorder *ExecRecorder
+	nilRecorder.Record(fast, time.Second, true, nil)
+	require.Nil(t, nilRecorder.Stats())
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Extractor types
+const (
+	ExtractRegex    = "regex"
+	ExtractJSONPath = "jsonpath"
+)
+
+// Validate validates the extractor.
+func (e *Extractor) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if e.Field == "" {
+		return fmt.Errorf("field is required")
+	}
+	if e.Name == e.Field {
+		return fmt.Errorf("name must differ from field: %s", e.Name)
+	}
+
+	switch e.Type {
+	case ExtractRegex:
+		if e.Regex == "" {
+			return fmt.Errorf("regex is required for regex extractors")
+		}
+		re, err := regexp.Compile(e.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		if e.Group < 0 || e.Group > re.NumSubexp() {
+			return fmt.Errorf("group %d out of range (regex has %d groups)", e.Group, re.NumSubexp())
+		}
+	case ExtractJSONPath:
+		if _, err := parseJSONPath(e.Path); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid type: %q (must be regex or jsonpath)", e.Type)
+	}
+	return nil
+}
+
+// Extract returns the value the extractor pulls out of context. It
+// reports false when the field is missing or nothing matches.
+func (e *Extractor) Extract(context map[string]any) (any, bool, error) {
+	actual, ok := context[e.Field]
+	if !ok || actual == nil {
+		return nil, false, nil
+	}
+
+	switch e.Type {
+	case ExtractRegex:
+		re, err := regexp.Compile(e.Regex)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid regex: %w", err)
+		}
+		match := re.FindStringSubmatch(toString(actual))
+		if match == nil {
+			return nil, false, nil
+		}
+		group := e.Group
+		if group == 0 && re.NumSubexp() > 0 {
+			group = 1
+		}
+		if group >= len(match) {
+			return nil, false, fmt.Errorf("group %d out of range", group)
+		}
+		return match[group], true, nil
+
+	case ExtractJSONPath:
+		steps, err := parseJSONPath(e.Path)
+		if err != nil {
+			return nil, false, err
+		}
+		doc := actual
+		switch raw := actual.(type) {
+		case string:
+			if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+				return nil, false, fmt.Errorf("field %s is not JSON: %w", e.Field, err)
+			}
+		case []byte:
+			if err := json.Unmarshal(raw, &doc); err != nil {
+				return nil, false, fmt.Errorf("field %s is not JSON: %w", e.Field, err)
+			}
+		}
+		return evalJSONPath(steps, doc)
+	}
+	return nil, false, fmt.Errorf("invalid extractor type: %q", e.Type)
+}
+
+// jsonPathStep is one step of a JSONPath: a member name, an array index
+// or a wildcard over either.
+type jsonPathStep struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// parseJSONPath parses the JSONPath subset extractors support: member
+// access ($.a.b, $['a b']), array indexes ($.a[0], negative from the
+// end) and wildcards ($.a[*], $.a.*).
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid path %q: must start with $", path)
+	}
+
+	var steps []jsonPathStep
+	rest := path[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			if name == "" {
+				return nil, fmt.Errorf("invalid path %q: empty member name", path)
+			}
+			if name == "*" {
+				steps = append(steps, jsonPathStep{wildcard: true})
+			} else {
+				steps = append(steps, jsonPathStep{key: name})
+			}
+			rest = rest[end:]
+
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed [", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				steps = append(steps, jsonPathStep{key: inner[1 : len(inner)-1]})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid path %q: bad index %q", path, inner)
+				}
+				steps = append(steps, jsonPathStep{index: index, isIndex: true})
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", path, rest[0])
+		}
+	}
+	return steps, nil
+}
+
+// evalJSONPath applies steps to doc. Paths with a wildcard return every
+// value found as a list; others return the single value.
+func evalJSONPath(steps []jsonPathStep, doc any) (any, bool, error) {
+	values := []any{doc}
+	wildcard := false
+	for _, step := range steps {
+		var next []any
+		for _, v := range values {
+			switch node := v.(type) {
+			case map[string]any:
+				if step.wildcard {
+					// Members in key order, for a stable result
+					keys := make([]string, 0, len(node))
+					for k := range node {
+						keys = append(keys, k)
+					}
+					slices.Sort(keys)
+					for _, k := range keys {
+						next = append(next, node[k])
+					}
+				} else if child, ok := node[step.key]; ok && !step.isIndex {
+					next = append(next, child)
+				}
+			case []any:
+				switch {
+				case step.wildcard:
+					next = append(next, node...)
+				case step.isIndex:
+					i := step.index
+					if i < 0 {
+						i += len(node)
+					}
+					if i >= 0 && i < len(node) {
+						next = append(next, node[i])
+					}
+				}
+			}
+		}
+		wildcard = wildcard || step.wildcard
+		values = next
+		if len(values) == 0 {
+			return nil, false, nil
+		}
+	}
+
+	if wildcard {
+		return values, true, nil
+	}
+	return values[0], true, nil
+}
+
+// OrderByExtraction orders plugins so that each plugin comes after the
+// plugins whose extractors write the keys its triggers, match rules and
+// extractors read. Plugins are otherwise kept in their given order;
+// dependency cycles are broken at the first plugin reached.
+func OrderByExtraction(plugins []*YAMLPlugin) []*YAMLPlugin {
+	producers := make(map[string][]int)
+	for i, p := range plugins {
+		for _, ex := range p.Extract {
+			producers[ex.Name] = append(producers[ex.Name], i)
+		}
+	}
+	if len(producers) == 0 {
+		return plugins
+	}
+
+	visited := make([]bool, len(plugins))
+	ordered := make([]*YAMLPlugin, 0, len(plugins))
+	var visit func(i int)
+	visit = func(i int) {
+		if visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, key := range plugins[i].readKeys() {
+			for _, dep := range producers[key] {
+				if dep != i {
+					visit(dep)
+				}
+			}
+		}
+		ordered = append(ordered, plugins[i])
+	}
+	for i := range plugins {
+		visit(i)
+	}
+	return ordered
+}
+
+// readKeys returns the context keys the plugin's triggers, match rules
+// and extractors read.
+func (p *YAMLPlugin) readKeys() []string {
+	var keys []string
+	for _, t := range p.Triggers {
+		keys = append(keys, t.DataKey)
+	}
+	var walk func(b *MatchBlock)
+	walk = func(b *MatchBlock) {
+		for _, r := range b.Rules {
+			keys = append(keys, r.Field)
+		}
+		for i := range b.Blocks {
+			walk(&b.Blocks[i])
+		}
+	}
+	if p.Match != nil {
+		walk(p.Match)
+	}
+	for _, ex := range p.Extract {
+		keys = append(keys, ex.Field)
+	}
+	return keys
+}
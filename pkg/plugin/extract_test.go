@@ -0,0 +1,198 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExtractor_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor Extractor
+		wantErr   string
+	}{
+		{
+			name:      "regex",
+			extractor: Extractor{Name: "http.cms.version", Type: ExtractRegex, Field: "http.body", Regex: `WordPress ([\d.]+)`},
+		},
+		{
+			name:      "jsonpath",
+			extractor: Extractor{Name: "api.version", Type: ExtractJSONPath, Field: "http.body", Path: "$.data['build info'].versions[-1]"},
+		},
+		{
+			name:      "missing name",
+			extractor: Extractor{Type: ExtractRegex, Field: "http.body", Regex: "x"},
+			wantErr:   "name is required",
+		},
+		{
+			name:      "overwrites its field",
+			extractor: Extractor{Name: "http.body", Type: ExtractRegex, Field: "http.body", Regex: "x"},
+			wantErr:   "name must differ from field: http.body",
+		},
+		{
+			name:      "bad regex",
+			extractor: Extractor{Name: "v", Type: ExtractRegex, Field: "http.body", Regex: "("},
+			wantErr:   "invalid regex",
+		},
+		{
+			name:      "group out of range",
+			extractor: Extractor{Name: "v", Type: ExtractRegex, Field: "http.body", Regex: "(a)", Group: 2},
+			wantErr:   "group 2 out of range (regex has 1 groups)",
+		},
+		{
+			name:      "bad path",
+			extractor: Extractor{Name: "v", Type: ExtractJSONPath, Field: "http.body", Path: "data.version"},
+			wantErr:   `invalid path "data.version": must start with $`,
+		},
+		{
+			name:      "unknown type",
+			extractor: Extractor{Name: "v", Type: "xpath", Field: "http.body"},
+			wantErr:   `invalid type: "xpath" (must be regex or jsonpath)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.extractor.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExtractor_Extract(t *testing.T) {
+	context := map[string]any{
+		"http.body":    `{"data": {"version": "6.4.2", "plugins": [{"name": "akismet"}, {"name": "jetpack"}], "debug": true}}`,
+		"http.headers": "Server: Apache/2.4.57 (Debian)\nX-Powered-By: PHP/8.2.7\n",
+		"api.doc":      map[string]any{"items": []any{float64(1), float64(2)}},
+	}
+
+	tests := []struct {
+		name      string
+		extractor Extractor
+		want      any
+		wantFound bool
+	}{
+		{"first group by default", Extractor{Type: ExtractRegex, Field: "http.headers", Regex: `PHP/([\d.]+)`}, "8.2.7", true},
+		{"whole match without groups", Extractor{Type: ExtractRegex, Field: "http.headers", Regex: `Apache/[\d.]+`}, "Apache/2.4.57", true},
+		{"chosen group", Extractor{Type: ExtractRegex, Field: "http.headers", Regex: `(Apache)/([\d.]+)`, Group: 2}, "2.4.57", true},
+		{"no match", Extractor{Type: ExtractRegex, Field: "http.headers", Regex: `nginx/([\d.]+)`}, nil, false},
+		{"missing field", Extractor{Type: ExtractRegex, Field: "ssh.banner", Regex: `.*`}, nil, false},
+		{"json member", Extractor{Type: ExtractJSONPath, Field: "http.body", Path: "$.data.version"}, "6.4.2", true},
+		{"json bool", Extractor{Type: ExtractJSONPath, Field: "http.body", Path: "$['data']['debug']"}, true, true},
+		{"json index", Extractor{Type: ExtractJSONPath, Field: "http.body", Path: "$.data.plugins[-1].name"}, "jetpack", true},
+		{"json wildcard", Extractor{Type: ExtractJSONPath, Field: "http.body", Path: "$.data.plugins[*].name"}, []any{"akismet", "jetpack"}, true},
+		{"json missing", Extractor{Type: ExtractJSONPath, Field: "http.body", Path: "$.data.plugins[5]"}, nil, false},
+		{"decoded document", Extractor{Type: ExtractJSONPath, Field: "api.doc", Path: "$.items[0]"}, float64(1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := tt.extractor.Extract(context)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantFound, found)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	// Fields that are not JSON documents are an error
+	_, _, err := (&Extractor{Type: ExtractJSONPath, Field: "http.headers", Path: "$.a"}).Extract(context)
+	require.ErrorContains(t, err, "field http.headers is not JSON")
+}
+
+func TestEvaluator_Evaluate_Extract(t *testing.T) {
+	// The version plugin extracts from the banner, the CVE plugin matches
+	// on the extracted version
+	version := &YAMLPlugin{
+		Name:     "PHP Version",
+		Triggers: []Trigger{{DataKey: "http.headers", Condition: "exists", Value: true}},
+		Match:    &MatchBlock{Logic: "AND", Rules: []MatchRule{{Field: "http.headers", Operator: "contains", Value: "PHP/"}}},
+		Extract:  []Extractor{{Name: "php.version", Type: ExtractRegex, Field: "http.headers", Regex: `PHP/([\d.]+)`}},
+		Output:   OutputBlock{Message: "PHP version extracted"},
+	}
+	cve := &YAMLPlugin{
+		Name:     "PHP CVE",
+		Triggers: []Trigger{{DataKey: "php.version", Condition: "exists", Value: true}},
+		Match:    &MatchBlock{Logic: "AND", Rules: []MatchRule{{Field: "php.version", Operator: "version_lt", Value: "8.2.8"}}},
+		Output:   OutputBlock{Vulnerability: true, Severity: HighSeverity, Message: "Vulnerable PHP"},
+	}
+
+	context := map[string]any{"http.headers": "X-Powered-By: PHP/8.2.7\n"}
+	results, err := NewEvaluator().EvaluateAll([]*YAMLPlugin{cve, version}, context)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "PHP Version", results[0].Plugin.Name)
+	require.Equal(t, map[string]any{"php.version": "8.2.7"}, results[0].Extracted)
+	require.True(t, results[1].Matched)
+	require.Equal(t, "8.2.7", context["php.version"])
+
+	// Extractors of plugins that do not match write nothing
+	context = map[string]any{"http.headers": "Server: nginx\n"}
+	results, err = NewEvaluator().EvaluateAll([]*YAMLPlugin{cve, version}, context)
+	require.NoError(t, err)
+	require.Nil(t, results[0].Extracted)
+	require.False(t, results[1].Matched)
+	require.NotContains(t, context, "php.version")
+}
+
+func TestOrderByExtraction(t *testing.T) {
+	a := &YAMLPlugin{Name: "a", Extract: []Extractor{{Name: "b.in", Field: "c.in"}}}
+	b := &YAMLPlugin{Name: "b", Match: &MatchBlock{Blocks: []MatchBlock{{Rules: []MatchRule{{Field: "b.in"}}}}}}
+	c := &YAMLPlugin{Name: "c", Extract: []Extractor{{Name: "c.in", Field: "raw"}}}
+	d := &YAMLPlugin{Name: "d", Triggers: []Trigger{{DataKey: "raw"}}}
+
+	names := func(plugins []*YAMLPlugin) []string {
+		var out []string
+		for _, p := range plugins {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+	require.Equal(t, []string{"d", "c", "a", "b"}, names(OrderByExtraction([]*YAMLPlugin{d, b, a, c})))
+
+	// Cycles keep every plugin once
+	x := &YAMLPlugin{Name: "x", Triggers: []Trigger{{DataKey: "y.out"}}, Extract: []Extractor{{Name: "x.out", Field: "raw"}}}
+	y := &YAMLPlugin{Name: "y", Triggers: []Trigger{{DataKey: "x.out"}}, Extract: []Extractor{{Name: "y.out", Field: "raw"}}}
+	require.Equal(t, []string{"y", "x"}, names(OrderByExtraction([]*YAMLPlugin{x, y})))
+}
+
+func TestYAMLPlugin_ExtractFromYAML(t *testing.T) {
+	data := []byte(`
+id: wordpress-version
+name: WordPress Version
+version: 1.0.0
+type: evaluation
+author: test
+metadata:
+  severity: info
+  tags: [http]
+match:
+  logic: AND
+  rules:
+    - field: http.body
+      operator: contains
+      value: wp-content
+extract:
+  - name: wordpress.version
+    type: regex
+    field: http.body
+    regex: 'content="WordPress ([\d.]+)"'
+output:
+  vulnerability: false
+  message: WordPress version extracted
+`)
+	var p YAMLPlugin
+	require.NoError(t, yaml.Unmarshal(data, &p))
+	require.NoError(t, p.Validate())
+	require.Equal(t, []Extractor{{Name: "wordpress.version", Type: ExtractRegex, Field: "http.body", Regex: `content="WordPress ([\d.]+)"`}}, p.Extract)
+
+	p.Extract[0].Regex = "("
+	require.ErrorContains(t, p.Validate(), "extract[0]: invalid regex")
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultHTTPCacheTTL is how long a scan reuses the response to an HTTP probe.
+const DefaultHTTPCacheTTL = 5 * time.Minute
+
+// HTTPCache shares the responses to HTTP probes among the plugins of a
+// scan, so that Nuclei templates and multi-step plugins requesting the same
+// URL of a service send the request once and evaluate the same evidence.
+//
+// Responses are keyed by host, port, method, path and a hash of the request
+// headers and redirect policy, and expire after the TTL. Only GET and HEAD
+// requests without a body are cached, and requests carrying cookies are
+// always sent. Concurrent identical requests wait for the first one.
+//
+// A nil *HTTPCache caches nothing.
+type HTTPCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*httpCacheEntry
+	stats   HTTPCacheStats
+}
+
+// HTTPCacheStats counts the requests an HTTPCache served.
+type HTTPCacheStats struct {
+	Hits   int // Requests answered from the cache
+	Misses int // Requests sent to the target
+}
+
+// httpCacheEntry is a response, or a request in flight while done is open.
+type httpCacheEntry struct {
+	done    chan struct{}
+	resp    *targetResponse
+	err     error
+	expires time.Time // Zero while in flight
+}
+
+// targetResponse is the evaluation context built from a response, with the
+// cookies it set.
+type targetResponse struct {
+	fields  map[string]any
+	cookies []*http.Cookie
+}
+
+// NewHTTPCache returns a cache keeping responses for ttl, or nil (no
+// caching) when ttl is not positive.
+func NewHTTPCache(ttl time.Duration) *HTTPCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &HTTPCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*httpCacheEntry),
+	}
+}
+
+// Stats returns the hits and misses so far.
+func (c *HTTPCache) Stats() HTTPCacheStats {
+	if c == nil {
+		return HTTPCacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// do returns the cached response for key, or calls fetch and caches its
+// response. hit reports whether the response came from the cache. Failed
+// fetches are not cached; requests that waited for one fetch on their own.
+func (c *HTTPCache) do(ctx context.Context, key string, fetch func() (*targetResponse, error)) (resp *targetResponse, hit bool, err error) {
+	if c == nil || key == "" {
+		resp, err = fetch()
+		return resp, false, err
+	}
+
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && (e.expires.IsZero() || c.now().Before(e.expires)) {
+		c.mu.Unlock()
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if e.err != nil {
+			resp, err = fetch()
+			return resp, false, err
+		}
+		c.mu.Lock()
+		c.stats.Hits++
+		c.mu.Unlock()
+		return e.resp, true, nil
+	}
+	e := &httpCacheEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.stats.Misses++
+	c.mu.Unlock()
+
+	e.resp, e.err = fetch()
+
+	c.mu.Lock()
+	if e.err != nil {
+		delete(c.entries, key)
+	} else {
+		e.expires = c.now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+	close(e.done)
+	return e.resp, false, e.err
+}
+
+// httpCacheKey returns the cache key of req sent by client following up to
+// redirects redirects, or "" when its response must not be shared.
+func httpCacheKey(client *http.Client, req *http.Request, redirects int) string {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return ""
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		return ""
+	}
+	if client.Jar != nil && len(client.Jar.Cookies(req.URL)) > 0 {
+		return ""
+	}
+
+	port := req.URL.Port()
+	if port == "" {
+		port = "80"
+		if req.URL.Scheme == "https" {
+			port = "443"
+		}
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		for _, v := range req.Header[name] {
+			_, _ = fmt.Fprintf(h, "%s: %s\n", name, v)
+		}
+	}
+	_, _ = fmt.Fprintf(h, "scheme: %s\nredirects: %d\n", req.URL.Scheme, redirects)
+
+	return fmt.Sprintf("%s|%s|%s|%s|%x", req.URL.Hostname(), port, req.Method, req.URL.RequestURI(), h.Sum(nil)[:8])
+}
+
+type httpCacheContextKey struct{}
+
+// WithHTTPCache returns a context carrying the HTTP response cache of a
+// scan. A nil c leaves ctx unchanged.
+func WithHTTPCache(ctx context.Context, c *HTTPCache) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, httpCacheContextKey{}, c)
+}
+
+// HTTPCacheFromContext returns the cache carried by ctx, or nil.
+func HTTPCacheFromContext(ctx context.Context) *HTTPCache {
+	c, _ := ctx.Value(httpCacheContextKey{}).(*HTTPCache)
+	return c
+}
+
+// cloneFields returns a copy of resp.fields callers may modify.
+func (resp *targetResponse) cloneFields() map[string]any {
+	return maps.Clone(resp.fields)
+}
@@ -0,0 +1,209 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		http.SetCookie(w, &http.Cookie{Name: "visit", Value: "v1"})
+		_, _ = fmt.Fprintf(w, "response %d to %s %s", n, r.Method, r.URL.RequestURI())
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestDoTargetRequest_HTTPCache(t *testing.T) {
+	server, requests := newCountingServer(t)
+	cache := NewHTTPCache(time.Minute)
+	ctx := WithHTTPCache(context.Background(), cache)
+	client := &http.Client{}
+
+	send := func(method, path string, header http.Header, redirects int) string {
+		t.Helper()
+		var body io.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader("q=1")
+		}
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+path, body)
+		require.NoError(t, err)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		fields, err := doTargetRequest(client, req, redirects)
+		require.NoError(t, err)
+		return fields[NucleiBodyField].(string)
+	}
+
+	require.Equal(t, "response 1 to GET /", send(http.MethodGet, "/", nil, 0))
+	require.Equal(t, "response 1 to GET /", send(http.MethodGet, "/", nil, 0), "served from the cache")
+	require.Equal(t, int32(1), requests.Load())
+
+	// Anything in the key sends a new request
+	require.Equal(t, "response 2 to GET /?a=1", send(http.MethodGet, "/?a=1", nil, 0))
+	require.Equal(t, "response 3 to GET /", send(http.MethodGet, "/", http.Header{"X-Api-Key": {"k"}}, 0))
+	require.Equal(t, "response 4 to GET /", send(http.MethodGet, "/", nil, DefaultNucleiMaxRedirects))
+	require.Empty(t, send(http.MethodHead, "/", nil, 0))
+	require.Equal(t, int32(5), requests.Load())
+	require.Equal(t, "response 3 to GET /", send(http.MethodGet, "/", http.Header{"X-Api-Key": {"k"}}, 0))
+
+	// Requests with a body are always sent
+	require.Equal(t, "response 6 to POST /", send(http.MethodPost, "/", nil, 0))
+	require.Equal(t, "response 7 to POST /", send(http.MethodPost, "/", nil, 0))
+
+	require.Equal(t, HTTPCacheStats{Hits: 2, Misses: 5}, cache.Stats())
+}
+
+func TestDoTargetRequest_HTTPCacheCopies(t *testing.T) {
+	server, requests := newCountingServer(t)
+	ctx := WithHTTPCache(context.Background(), NewHTTPCache(time.Minute))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/", nil)
+	require.NoError(t, err)
+	fields, err := doTargetRequest(&http.Client{}, req, 0)
+	require.NoError(t, err)
+	fields[NucleiBodyField] = "changed by a plugin"
+
+	fields, err = doTargetRequest(&http.Client{}, req, 0)
+	require.NoError(t, err)
+	require.Equal(t, "response 1 to GET /", fields[NucleiBodyField])
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestDoTargetRequest_HTTPCacheCookies(t *testing.T) {
+	server, requests := newCountingServer(t)
+	ctx := WithHTTPCache(context.Background(), NewHTTPCache(time.Minute))
+	get := func(client *http.Client) {
+		t.Helper()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/", nil)
+		require.NoError(t, err)
+		_, err = doTargetRequest(client, req, 0)
+		require.NoError(t, err)
+	}
+
+	get(&http.Client{})
+
+	// A hit still hands the cookies the response set to the jar
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{Jar: jar}
+	get(client)
+	require.Equal(t, int32(1), requests.Load())
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	require.Len(t, jar.Cookies(u), 1)
+
+	// Requests carrying cookies depend on the session and are sent
+	get(client)
+	require.Equal(t, int32(2), requests.Load())
+}
+
+func TestDoTargetRequest_NoHTTPCache(t *testing.T) {
+	server, requests := newCountingServer(t)
+	for range 2 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/", nil)
+		require.NoError(t, err)
+		_, err = doTargetRequest(&http.Client{}, req, 0)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), requests.Load())
+
+	require.Nil(t, NewHTTPCache(0))
+	require.Equal(t, context.Background(), WithHTTPCache(context.Background(), nil))
+}
+
+func TestHTTPCache_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewHTTPCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	var fetches int
+	fetch := func() (*targetResponse, error) {
+		fetches++
+		return &targetResponse{fields: map[string]any{"n": fetches}}, nil
+	}
+	ctx := context.Background()
+
+	_, hit, err := cache.do(ctx, "k", fetch)
+	require.NoError(t, err)
+	require.False(t, hit)
+
+	now = now.Add(59 * time.Second)
+	resp, hit, err := cache.do(ctx, "k", fetch)
+	require.NoError(t, err)
+	require.True(t, hit)
+	require.Equal(t, 1, resp.fields["n"])
+
+	now = now.Add(time.Second)
+	resp, hit, err = cache.do(ctx, "k", fetch)
+	require.NoError(t, err)
+	require.False(t, hit, "expired")
+	require.Equal(t, 2, resp.fields["n"])
+}
+
+func TestHTTPCache_ConcurrentRequests(t *testing.T) {
+	cache := NewHTTPCache(time.Minute)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	fetch := func() (*targetResponse, error) {
+		fetches.Add(1)
+		<-release
+		return &targetResponse{fields: map[string]any{}}, nil
+	}
+
+	go func() { _, _, _ = cache.do(ctx, "k", fetch) }()
+	require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, hit, err := cache.do(ctx, "k", fetch)
+			require.NoError(t, err)
+			require.True(t, hit)
+		}()
+	}
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), fetches.Load(), "identical requests in flight wait for the first")
+}
+
+func TestHTTPCache_FailuresNotCached(t *testing.T) {
+	cache := NewHTTPCache(time.Minute)
+	ctx := context.Background()
+
+	_, _, err := cache.do(ctx, "k", func() (*targetResponse, error) {
+		return nil, errors.New("connection refused")
+	})
+	require.EqualError(t, err, "connection refused")
+
+	_, hit, err := cache.do(ctx, "k", func() (*targetResponse, error) {
+		return &targetResponse{}, nil
+	})
+	require.NoError(t, err)
+	require.False(t, hit)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// InstalledSet holds the installed plugins scans evaluate besides the
+// embedded ones. Replace swaps in a new set atomically; scans take a
+// Snapshot when they start, so a reload never changes the plugins of a
+// scan in flight.
+type InstalledSet struct {
+	plugins atomic.Pointer[[]*YAMLPlugin]
+}
+
+// NewInstalledSet returns an empty set.
+func NewInstalledSet() *InstalledSet {
+	return &InstalledSet{}
+}
+
+// Snapshot returns the current plugins. The slice must not be modified.
+// A nil set has no plugins.
+func (s *InstalledSet) Snapshot() []*YAMLPlugin {
+	if s == nil {
+		return nil
+	}
+	if p := s.plugins.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Replace makes plugins the current set. Snapshots taken before keep the
+// previous plugins.
+func (s *InstalledSet) Replace(plugins []*YAMLPlugin) {
+	s.plugins.Store(&plugins)
+}
+
+type installedContextKey struct{}
+
+// WithInstalledPlugins returns a context carrying the installed plugins a
+// scan evaluates. Empty plugins leave ctx unchanged.
+func WithInstalledPlugins(ctx context.Context, plugins []*YAMLPlugin) context.Context {
+	if len(plugins) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, installedContextKey{}, plugins)
+}
+
+// InstalledPluginsFromContext returns the installed plugins carried by ctx,
+// or nil.
+func InstalledPluginsFromContext(ctx context.Context) []*YAMLPlugin {
+	plugins, _ := ctx.Value(installedContextKey{}).([]*YAMLPlugin)
+	return plugins
+}
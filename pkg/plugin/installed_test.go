@@ -0,0 +1,73 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstalledSet(t *testing.T) {
+	var nilSet *InstalledSet
+	require.Nil(t, nilSet.Snapshot())
+
+	set := NewInstalledSet()
+	require.Nil(t, set.Snapshot())
+
+	old := []*YAMLPlugin{{ID: "a"}}
+	set.Replace(old)
+	snapshot := set.Snapshot()
+
+	// A snapshot keeps the plugins it was taken with
+	set.Replace([]*YAMLPlugin{{ID: "b"}})
+	require.Equal(t, "a", snapshot[0].ID)
+	require.Equal(t, "b", set.Snapshot()[0].ID)
+}
+
+func TestInstalledPluginsContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, InstalledPluginsFromContext(ctx))
+	require.Equal(t, ctx, WithInstalledPlugins(ctx, nil))
+
+	plugins := []*YAMLPlugin{{ID: "a"}}
+	require.Equal(t, plugins, InstalledPluginsFromContext(WithInstalledPlugins(ctx, plugins)))
+}
+
+func TestService_ReloadInstalled(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	set := NewInstalledSet()
+	svc, err := NewService(WithCacheDir(cacheDir), WithInstalledSet(set))
+	require.NoError(t, err)
+
+	pluginDir := filepath.Join(cacheDir, "test-plugin", "1.0.0")
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "plugin.yaml"), []byte(`id: test-plugin
+name: test-plugin
+version: 1.0.0
+type: evaluation
+author: test
+metadata:
+  severity: high
+output:
+  vulnerability: true
+  message: Test finding
+`), 0o644))
+	require.NoError(t, svc.manifest.Add(&ManifestEntry{ID: "test-plugin", Name: "test-plugin", Version: "1.0.0"}))
+	// Entries whose file is missing are left out
+	require.NoError(t, svc.manifest.Add(&ManifestEntry{ID: "missing", Name: "missing", Version: "1.0.0"}))
+
+	svc.ReloadInstalled(context.Background())
+	plugins := set.Snapshot()
+	require.Len(t, plugins, 1)
+	require.Equal(t, "test-plugin", plugins[0].ID)
+
+	require.NoError(t, svc.manifest.Remove("test-plugin"))
+	svc.ReloadInstalled(context.Background())
+	require.Empty(t, set.Snapshot())
+}
@@ -20,15 +20,26 @@ type Loader struct {
 	// Base directory for plugins
 	baseDir string
 
+	// Workspace wordlist directories searched after the plugin's own
+	wordlistDirs []string
+
 	// Loaded plugins cache
 	plugins map[string]*YAMLPlugin
+
+	// Shared variables by plugin directory
+	variables map[string]map[string]*yaml.Node
 }
 
-// NewLoader creates a new plugin loader.
-func NewLoader(baseDir string) *Loader {
+// NewLoader creates a new plugin loader. {{wordlist:name}} references
+// read name.txt from the plugin's WordlistDir, then from baseDir's, then
+// from wordlistDirs, such as the workspace wordlists managed with
+// "vulntor wordlist".
+func NewLoader(baseDir string, wordlistDirs ...string) *Loader {
 	return &Loader{
-		baseDir: baseDir,
-		plugins: make(map[string]*YAMLPlugin),
+		baseDir:      baseDir,
+		wordlistDirs: wordlistDirs,
+		plugins:      make(map[string]*YAMLPlugin),
+		variables:    make(map[string]map[string]*yaml.Node),
 	}
 }
 
@@ -47,9 +58,29 @@ func (l *Loader) Load(filePath string) (*YAMLPlugin, error) {
 
 	switch ext {
 	case ".yaml", ".yml":
-		if err := yaml.Unmarshal(data, &plugin); err != nil {
+		// Variables and wordlists come from the plugin's directory
+		dir := filepath.Dir(filePath)
+		dirs := []string{filepath.Join(dir, WordlistDir)}
+		if l.baseDir != "" {
+			dirs = append(dirs, filepath.Join(l.baseDir, WordlistDir))
+		}
+		dirs = append(dirs, l.wordlistDirs...)
+		wordlist := func(name string) ([]string, error) {
+			return readWordlist(name, dirs...)
+		}
+		shared, ok := l.variables[dir]
+		if !ok {
+			shared, err = loadSharedVariables(dir, wordlist)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load shared variables: %w", err)
+			}
+			l.variables[dir] = shared
+		}
+		parsed, err := parseYAMLPlugin(data, shared, wordlist)
+		if err != nil {
 			return nil, fmt.Errorf("failed to parse YAML plugin: %w", err)
 		}
+		plugin = *parsed
 
 	case ".json":
 		if err := json.Unmarshal(data, &plugin); err != nil {
@@ -68,6 +99,9 @@ func (l *Loader) Load(filePath string) (*YAMLPlugin, error) {
 	if err := plugin.Validate(); err != nil {
 		return nil, fmt.Errorf("plugin validation failed: %w", err)
 	}
+	if err := plugin.checkCompatible(); err != nil {
+		return nil, err
+	}
 
 	// Cache the plugin
 	l.plugins[filePath] = &plugin
@@ -93,7 +127,7 @@ func (l *Loader) LoadAll(dirPath string) ([]*YAMLPlugin, error) {
 
 		// Check extension
 		ext := strings.ToLower(filepath.Ext(entry.Name()))
-		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" || entry.Name() == SharedVariablesFile {
 			continue
 		}
 
@@ -132,7 +166,7 @@ func (l *Loader) LoadRecursive(rootPath string) ([]*YAMLPlugin, error) {
 
 		// Check extension
 		ext := strings.ToLower(filepath.Ext(info.Name()))
-		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" || info.Name() == SharedVariablesFile {
 			return nil
 		}
 
@@ -164,7 +198,8 @@ func (l *Loader) GetCached(filePath string) (*YAMLPlugin, bool) {
 	return plugin, ok
 }
 
-// ClearCache clears the plugin cache.
+// ClearCache clears the plugin cache and the shared variables read.
 func (l *Loader) ClearCache() {
 	l.plugins = make(map[string]*YAMLPlugin)
+	l.variables = make(map[string]map[string]*yaml.Node)
 }
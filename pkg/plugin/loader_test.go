@@ -520,3 +520,34 @@ output:
 	_, ok = loader.GetCached(yamlPath)
 	require.False(t, ok)
 }
+
+func TestLoader_Load_IncompatibleVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	setVulntorVersion(t, "v0.3.0")
+
+	pluginYAML := `id: new-syntax
+name: New Syntax
+version: 1.0.0
+type: evaluation
+author: vulntor-test
+vulntor_min_version: 0.4.0
+
+metadata:
+  severity: low
+
+output:
+  message: "Found"
+`
+	yamlPath := filepath.Join(tmpDir, "plugin.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(pluginYAML), 0o644))
+
+	_, err := NewLoader(tmpDir).Load(yamlPath)
+	require.ErrorIs(t, err, ErrIncompatibleVersion)
+	require.Contains(t, err.Error(), "new-syntax requires Vulntor >= 0.4.0")
+
+	// Releases meeting the requirement load it
+	setVulntorVersion(t, "v0.4.0")
+	plugin, err := NewLoader(tmpDir).Load(yamlPath)
+	require.NoError(t, err)
+	require.Equal(t, "0.4.0", plugin.MinVulntorVersion)
+}
@@ -6,12 +6,21 @@ package plugin
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"time"
+
+	"github.com/gofrs/flock"
+	"golang.org/x/mod/semver"
 )
 
+// errManifestCorrupt marks a manifest file that exists but cannot be parsed.
+var errManifestCorrupt = errors.New("manifest corrupt")
+
 // Manifest represents the plugin registry manifest (registry.json).
 // This file tracks all installed plugins and their metadata.
 type Manifest struct {
@@ -51,19 +60,50 @@ type ManifestEntry struct {
 
 	// Severity (for evaluation plugins)
 	Severity string `json:"severity,omitempty"`
+
+	// Compatibility: the oldest Vulntor release that runs the plugin
+	MinVulntorVersion string `json:"vulntor_min_version,omitempty"`
+	// Provenance: the source the plugin was installed from and its trust level
+	Source string `json:"source,omitempty"`
+	Trust  string `json:"trust,omitempty"`
 }
 
 // ManifestManager manages the plugin registry manifest file.
+//
+// Several processes (CLI installs, the server) may share a manifest file.
+// Reads and writes take an advisory lock on a ".lock" file next to it,
+// writes replace the file atomically, and Save merges the changes made
+// since Load into the file's current content, so concurrent installs do not
+// lose each other's entries.
 type ManifestManager struct {
 	// Path to manifest file (registry.json)
 	manifestPath string
 
 	// In-memory manifest
 	manifest *Manifest
+
+	// base is a copy of the manifest as loaded or last saved, the common
+	// ancestor Save merges against
+	base *Manifest
+
+	// recoveryDir is the plugin cache a corrupt manifest is rebuilt from
+	recoveryDir string
+}
+
+// ManifestOption configures a ManifestManager.
+type ManifestOption func(*ManifestManager)
+
+// WithRecoveryDir sets the plugin cache directory a corrupt manifest is
+// rebuilt from. The corrupt file is kept with a ".corrupt" suffix. Without
+// a recovery directory, loading a corrupt manifest fails.
+func WithRecoveryDir(cacheDir string) ManifestOption {
+	return func(m *ManifestManager) {
+		m.recoveryDir = cacheDir
+	}
 }
 
 // NewManifestManager creates a new manifest manager.
-func NewManifestManager(manifestPath string) (*ManifestManager, error) {
+func NewManifestManager(manifestPath string, opts ...ManifestOption) (*ManifestManager, error) {
 	if manifestPath == "" {
 		return nil, fmt.Errorf("manifest path cannot be empty")
 	}
@@ -74,65 +114,266 @@ func NewManifestManager(manifestPath string) (*ManifestManager, error) {
 		return nil, fmt.Errorf("failed to create manifest directory: %w", err)
 	}
 
-	return &ManifestManager{
+	m := &ManifestManager{
 		manifestPath: manifestPath,
 		manifest:     nil, // Loaded on demand
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m, nil
 }
 
-// Load loads the manifest from disk.
-// If the file doesn't exist, returns an empty manifest.
-func (m *ManifestManager) Load() error {
-	// Check if manifest file exists
-	if _, err := os.Stat(m.manifestPath); os.IsNotExist(err) {
-		// Create new empty manifest
-		m.manifest = &Manifest{
-			Version:     "1.0",
-			LastUpdated: time.Now(),
-			Plugins:     make(map[string]*ManifestEntry),
-		}
-		return nil
+// newEmptyManifest returns an empty manifest.
+func newEmptyManifest() *Manifest {
+	return &Manifest{
+		Version:     "1.0",
+		LastUpdated: time.Now(),
+		Plugins:     make(map[string]*ManifestEntry),
 	}
+}
 
-	// Read manifest file
-	data, err := os.ReadFile(m.manifestPath)
-	if err != nil {
-		return fmt.Errorf("failed to read manifest: %w", err)
+// clone returns a deep copy of the manifest.
+func (m *Manifest) clone() *Manifest {
+	c := *m
+	c.Plugins = make(map[string]*ManifestEntry, len(m.Plugins))
+	for id, entry := range m.Plugins {
+		e := *entry
+		e.Tags = slices.Clone(entry.Tags)
+		c.Plugins[id] = &e
 	}
+	return &c
+}
 
-	// Parse JSON
-	var manifest Manifest
-	if err := json.Unmarshal(data, &manifest); err != nil {
-		return fmt.Errorf("failed to parse manifest: %w", err)
+// Load loads the manifest from disk.
+// If the file doesn't exist, returns an empty manifest. A corrupt file is
+// rebuilt from the recovery directory when one is set (see WithRecoveryDir).
+func (m *ManifestManager) Load() error {
+	manifest, err := m.readShared()
+	if errors.Is(err, errManifestCorrupt) && m.recoveryDir != "" {
+		manifest, err = m.recover()
+	}
+	if err != nil {
+		return err
 	}
 
-	m.manifest = &manifest
+	m.manifest = manifest
+	m.base = manifest.clone()
 	return nil
 }
 
-// Save writes the manifest to disk.
+// Save writes the manifest to disk. Entries added, updated or removed since
+// Load are applied to the file's current content; entries other processes
+// saved meanwhile are kept.
 func (m *ManifestManager) Save() error {
 	if m.manifest == nil {
 		return fmt.Errorf("manifest not loaded")
 	}
 
+	lock := flock.New(m.lockPath())
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to write manifest: acquire lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	current, err := m.read()
+	switch {
+	case err == nil:
+		m.manifest = mergeManifest(current, m.base, m.manifest)
+	case errors.Is(err, errManifestCorrupt):
+		// Replaced by the in-memory manifest below
+	default:
+		return err
+	}
+
 	// Update timestamp
 	m.manifest.LastUpdated = time.Now()
 
-	// Marshal to JSON (pretty-printed)
-	data, err := json.MarshalIndent(m.manifest, "", "  ")
+	if err := m.write(m.manifest); err != nil {
+		return err
+	}
+	m.base = m.manifest.clone()
+	return nil
+}
+
+// mergeManifest applies the changes from base to local onto current.
+func mergeManifest(current, base, local *Manifest) *Manifest {
+	merged := current.clone()
+	merged.Version = local.Version
+	if local.RegistryURL != base.RegistryURL {
+		merged.RegistryURL = local.RegistryURL
+	}
+	for id := range base.Plugins {
+		if _, ok := local.Plugins[id]; !ok {
+			delete(merged.Plugins, id)
+		}
+	}
+	for id, entry := range local.Plugins {
+		if old, ok := base.Plugins[id]; !ok || !reflect.DeepEqual(old, entry) {
+			merged.Plugins[id] = entry
+		}
+	}
+	return merged
+}
+
+func (m *ManifestManager) lockPath() string {
+	return m.manifestPath + ".lock"
+}
+
+// readShared reads the manifest file under a shared lock.
+func (m *ManifestManager) readShared() (*Manifest, error) {
+	if _, err := os.Stat(m.manifestPath); os.IsNotExist(err) {
+		return newEmptyManifest(), nil
+	}
+
+	lock := flock.New(m.lockPath())
+	if err := lock.RLock(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: acquire lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	return m.read()
+}
+
+// read reads the manifest file; the caller holds the lock. A missing file
+// yields an empty manifest.
+func (m *ManifestManager) read() (*Manifest, error) {
+	data, err := os.ReadFile(m.manifestPath)
+	if os.IsNotExist(err) {
+		return newEmptyManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w: %v", errManifestCorrupt, err)
+	}
+	if manifest.Plugins == nil {
+		manifest.Plugins = make(map[string]*ManifestEntry)
+	}
+	return &manifest, nil
+}
+
+// write replaces the manifest file with manifest; the caller holds the
+// lock. The data goes to a temporary file first, so a crash never leaves a
+// truncated manifest behind.
+func (m *ManifestManager) write(manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal manifest: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(m.manifestPath, data, 0o644); err != nil {
+	tmp, err := os.CreateTemp(filepath.Dir(m.manifestPath), ".registry-*.tmp")
+	if err != nil {
 		return fmt.Errorf("failed to write manifest: %w", err)
 	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
 
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), m.manifestPath); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
 	return nil
 }
 
+// recover rebuilds a corrupt manifest from the recovery directory under the
+// exclusive lock, keeping the corrupt file for inspection.
+func (m *ManifestManager) recover() (*Manifest, error) {
+	lock := flock.New(m.lockPath())
+	if err := lock.Lock(); err != nil {
+		return nil, fmt.Errorf("failed to recover manifest: acquire lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	// Another process may have recovered it meanwhile
+	if manifest, err := m.read(); !errors.Is(err, errManifestCorrupt) {
+		return manifest, err
+	}
+
+	manifest, err := rebuildManifest(m.recoveryDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover manifest: %w", err)
+	}
+	if err := os.Rename(m.manifestPath, m.manifestPath+".corrupt"); err != nil {
+		return nil, fmt.Errorf("failed to recover manifest: %w", err)
+	}
+	if err := m.write(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// rebuildManifest lists the plugins cached in cacheDir
+// (<id>/<version>/plugin.yaml), keeping the highest version of each.
+// Download URLs are not cached and stay empty; checksums are computed from
+// the cached files. Files that fail to load are skipped.
+func rebuildManifest(cacheDir string) (*Manifest, error) {
+	manifest := newEmptyManifest()
+
+	ids, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	loader := NewLoader(cacheDir)
+	verifier := NewVerifier()
+	for _, id := range ids {
+		if !id.IsDir() {
+			continue
+		}
+		versions, err := os.ReadDir(filepath.Join(cacheDir, id.Name()))
+		if err != nil {
+			continue
+		}
+		for _, version := range versions {
+			if !version.IsDir() {
+				continue
+			}
+			rel := filepath.Join(id.Name(), version.Name(), "plugin.yaml")
+			path := filepath.Join(cacheDir, rel)
+			plugin, err := loader.Load(path)
+			if err != nil {
+				continue
+			}
+			if existing, ok := manifest.Plugins[plugin.ID]; ok &&
+				semver.Compare(normalizeVersion(existing.Version), normalizeVersion(plugin.Version)) >= 0 {
+				continue
+			}
+			checksum, err := verifier.ComputeChecksum(path)
+			if err != nil {
+				continue
+			}
+
+			entry := NewManifestEntryFromPlugin(plugin, checksum, "")
+			entry.ID = plugin.ID
+			entry.Path = rel
+			if info, err := version.Info(); err == nil {
+				entry.InstalledAt = info.ModTime()
+			}
+			manifest.Plugins[plugin.ID] = entry
+		}
+	}
+	return manifest, nil
+}
+
 // Reload reloads the manifest from disk, discarding the in-memory cache.
 // This is used by the file watcher to pick up external changes (e.g., CLI updates).
 func (m *ManifestManager) Reload() error {
@@ -306,5 +547,7 @@ func NewManifestEntryFromPlugin(plugin *YAMLPlugin, checksum, downloadURL string
 		Path:        plugin.FilePath,
 		Tags:        plugin.Metadata.Tags,
 		Severity:    string(plugin.Metadata.Severity),
+
+		MinVulntorVersion: plugin.MinVulntorVersion,
 	}
 }
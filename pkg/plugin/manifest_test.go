@@ -6,12 +6,15 @@ package plugin
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewManifestManager(t *testing.T) {
@@ -1125,3 +1128,133 @@ func TestManifestManager_Load_ReadError(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "failed to read manifest")
 }
+
+func TestManifestManager_Save_MergesConcurrentChanges(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "registry.json")
+
+	a, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	require.NoError(t, a.Add(&ManifestEntry{ID: "shared", Name: "shared", Version: "1.0.0"}))
+	require.NoError(t, a.Save())
+
+	b, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	require.NoError(t, b.Load())
+
+	// Both processes change the manifest before either saves
+	require.NoError(t, a.Add(&ManifestEntry{ID: "from-a", Name: "from-a", Version: "1.0.0"}))
+	require.NoError(t, b.Add(&ManifestEntry{ID: "from-b", Name: "from-b", Version: "1.0.0"}))
+	require.NoError(t, b.Update("shared", &ManifestEntry{ID: "shared", Name: "shared", Version: "2.0.0"}))
+	require.NoError(t, a.Save())
+	require.NoError(t, b.Save())
+
+	// b saw a's entry while saving
+	_, err = b.Get("from-a")
+	require.NoError(t, err)
+
+	// Removals apply too
+	require.NoError(t, a.Remove("from-a"))
+	require.NoError(t, a.Save())
+
+	c, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	entries, err := c.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	shared, err := c.Get("shared")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", shared.Version)
+	_, err = c.Get("from-b")
+	require.NoError(t, err)
+}
+
+func TestManifestManager_Save_Concurrent(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "registry.json")
+
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mm, err := NewManifestManager(manifestPath)
+			if err == nil {
+				err = mm.Add(&ManifestEntry{ID: fmt.Sprintf("plugin-%d", i), Name: "p", Version: "1.0.0"})
+			}
+			if err == nil {
+				err = mm.Save()
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	mm, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	count, err := mm.Count()
+	require.NoError(t, err)
+	require.Equal(t, writers, count)
+
+	// No temporary files are left behind
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(manifestPath), ".registry-*"))
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestManifestManager_Load_RecoversCorrupt(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	manifestPath := filepath.Join(dir, "registry.json")
+
+	for _, version := range []string{"1.0.0", "1.2.0", "1.10.0"} {
+		data, err := yaml.Marshal(&YAMLPlugin{
+			ID:       "ssh-weak-kex",
+			Name:     "SSH Weak KEX",
+			Version:  version,
+			Type:     EvaluationType,
+			Author:   "test",
+			Metadata: PluginMetadata{Severity: HighSeverity, Tags: []string{"ssh"}},
+			Output:   OutputBlock{Message: "Test"},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "ssh-weak-kex", version), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "ssh-weak-kex", version, "plugin.yaml"), data, 0o644))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "broken", "1.0.0"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "broken", "1.0.0", "plugin.yaml"), []byte("{"), 0o644))
+
+	// Truncated by a crash
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"version": "1.0", "plugins": {"ssh-weak`), 0o644))
+
+	mm, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	require.ErrorContains(t, mm.Load(), "failed to parse manifest")
+
+	mm, err = NewManifestManager(manifestPath, WithRecoveryDir(cacheDir))
+	require.NoError(t, err)
+	require.NoError(t, mm.Load())
+
+	entries, err := mm.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	require.Equal(t, "ssh-weak-kex", entry.ID)
+	require.Equal(t, "1.10.0", entry.Version)
+	require.Equal(t, filepath.Join("ssh-weak-kex", "1.10.0", "plugin.yaml"), entry.Path)
+	valid, err := NewVerifier().VerifyFile(filepath.Join(cacheDir, entry.Path), entry.Checksum)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// The rebuilt manifest is on disk; the corrupt one is kept
+	require.FileExists(t, manifestPath+".corrupt")
+	reloaded, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	count, err := reloaded.Count()
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
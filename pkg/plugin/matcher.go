@@ -10,8 +10,9 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/version"
 )
 
 // MatcherEngine evaluates matching rules against a data context.
@@ -47,18 +48,25 @@ func (m *MatcherEngine) Evaluate(match *MatchBlock, context map[string]any) (boo
 		return false, fmt.Errorf("match block is nil")
 	}
 
-	if len(match.Rules) == 0 {
+	if len(match.Rules) == 0 && len(match.Blocks) == 0 {
 		return false, fmt.Errorf("no rules to evaluate")
 	}
 
-	// Evaluate all rules
-	results := make([]bool, len(match.Rules))
+	// Evaluate all rules, then nested blocks
+	results := make([]bool, 0, len(match.Rules)+len(match.Blocks))
 	for i, rule := range match.Rules {
 		result, err := m.evaluateRule(rule, context)
 		if err != nil {
 			return false, fmt.Errorf("rule[%d] evaluation failed: %w", i, err)
 		}
-		results[i] = result
+		results = append(results, result)
+	}
+	for i := range match.Blocks {
+		result, err := m.Evaluate(&match.Blocks[i], context)
+		if err != nil {
+			return false, fmt.Errorf("block[%d] evaluation failed: %w", i, err)
+		}
+		results = append(results, result)
 	}
 
 	// Apply logic
@@ -92,18 +100,21 @@ func (m *MatcherEngine) evaluateRule(rule MatchRule, context map[string]any) (bo
 		return false, fmt.Errorf("unknown operator: %s", rule.Operator)
 	}
 
+	// References to scan data such as {{target.host}} take their values
+	expected := expandRuntimeValue(rule.Value, context)
+
 	// Debug log the comparison
 	log.Debug().
 		Str("field", rule.Field).
 		Str("operator", rule.Operator).
 		Interface("actual", actual).
-		Interface("expected", rule.Value).
+		Interface("expected", expected).
 		Str("actual_type", fmt.Sprintf("%T", actual)).
-		Str("expected_type", fmt.Sprintf("%T", rule.Value)).
+		Str("expected_type", fmt.Sprintf("%T", expected)).
 		Msg("Evaluating rule")
 
 	// Execute operator
-	result, err := opFunc(actual, rule.Value)
+	result, err := opFunc(actual, expected)
 
 	log.Debug().
 		Str("field", rule.Field).
@@ -247,79 +258,54 @@ func opBetween(actual, expected any) (bool, error) {
 }
 
 // Version Operators
+//
+// Versions are compared with pkg/version, so that service versions such as
+// "7.4p1", "2020.81" or "8.0.35-27-percona" order as their vendors intend.
 
-func opVersionEqual(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
+// compareVersions parses actual and expected, and compares them.
+func compareVersions(actual, expected any) (int, error) {
+	av, err := version.Parse(toString(actual))
 	if err != nil {
-		return false, fmt.Errorf("invalid actual version: %w", err)
+		return 0, fmt.Errorf("invalid actual version: %w", err)
 	}
 
-	ev, err := semver.NewVersion(toString(expected))
+	ev, err := version.Parse(toString(expected))
 	if err != nil {
-		return false, fmt.Errorf("invalid expected version: %w", err)
+		return 0, fmt.Errorf("invalid expected version: %w", err)
 	}
 
-	return av.Equal(ev), nil
+	return av.Compare(ev), nil
 }
 
-func opVersionLessThan(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
-	if err != nil {
-		return false, fmt.Errorf("invalid actual version: %w", err)
-	}
-
-	ev, err := semver.NewVersion(toString(expected))
-	if err != nil {
-		return false, fmt.Errorf("invalid expected version: %w", err)
-	}
+func opVersionEqual(actual, expected any) (bool, error) {
+	c, err := compareVersions(actual, expected)
+	return err == nil && c == 0, err
+}
 
-	return av.LessThan(ev), nil
+func opVersionLessThan(actual, expected any) (bool, error) {
+	c, err := compareVersions(actual, expected)
+	return err == nil && c < 0, err
 }
 
 func opVersionGreaterThan(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
-	if err != nil {
-		return false, fmt.Errorf("invalid actual version: %w", err)
-	}
-
-	ev, err := semver.NewVersion(toString(expected))
-	if err != nil {
-		return false, fmt.Errorf("invalid expected version: %w", err)
-	}
-
-	return av.GreaterThan(ev), nil
+	c, err := compareVersions(actual, expected)
+	return err == nil && c > 0, err
 }
 
 func opVersionLessThanOrEqual(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
-	if err != nil {
-		return false, fmt.Errorf("invalid actual version: %w", err)
-	}
-
-	ev, err := semver.NewVersion(toString(expected))
-	if err != nil {
-		return false, fmt.Errorf("invalid expected version: %w", err)
-	}
-
-	return av.LessThan(ev) || av.Equal(ev), nil
+	c, err := compareVersions(actual, expected)
+	return err == nil && c <= 0, err
 }
 
 func opVersionGreaterThanOrEqual(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
-	if err != nil {
-		return false, fmt.Errorf("invalid actual version: %w", err)
-	}
-
-	ev, err := semver.NewVersion(toString(expected))
-	if err != nil {
-		return false, fmt.Errorf("invalid expected version: %w", err)
-	}
-
-	return av.GreaterThan(ev) || av.Equal(ev), nil
+	c, err := compareVersions(actual, expected)
+	return err == nil && c >= 0, err
 }
 
+// opVersionBetween matches versions in an inclusive [min, max] range, such
+// as the affected versions of a CVE.
 func opVersionBetween(actual, expected any) (bool, error) {
-	av, err := semver.NewVersion(toString(actual))
+	av, err := version.Parse(toString(actual))
 	if err != nil {
 		return false, fmt.Errorf("invalid actual version: %w", err)
 	}
@@ -330,17 +316,17 @@ func opVersionBetween(actual, expected any) (bool, error) {
 		return false, fmt.Errorf("version_between operator requires [min, max] array")
 	}
 
-	minV, err := semver.NewVersion(toString(bounds[0]))
+	minV, err := version.Parse(toString(bounds[0]))
 	if err != nil {
 		return false, fmt.Errorf("invalid min version: %w", err)
 	}
 
-	maxV, err := semver.NewVersion(toString(bounds[1]))
+	maxV, err := version.Parse(toString(bounds[1]))
 	if err != nil {
 		return false, fmt.Errorf("invalid max version: %w", err)
 	}
 
-	return (av.GreaterThan(minV) || av.Equal(minV)) && (av.LessThan(maxV) || av.Equal(maxV)), nil
+	return av.Compare(minV) >= 0 && av.Compare(maxV) <= 0, nil
 }
 
 // Logical Operators
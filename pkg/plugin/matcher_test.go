@@ -372,6 +372,50 @@ func TestMatcherEngine_VersionOperators(t *testing.T) {
 			want:     false,
 		},
 
+		// Service versions
+		{
+			name:     "version_lt - OpenSSH patch level",
+			operator: "version_lt",
+			actual:   "OpenSSH_9.7p1",
+			expected: "9.8p1",
+			want:     true,
+		},
+		{
+			name:     "version_between - CVE range includes patch levels",
+			operator: "version_between",
+			actual:   "8.5p1",
+			expected: []any{"8.5p1", "9.7p1"},
+			want:     true,
+		},
+		{
+			name:     "version_gt - two-part release",
+			operator: "version_gt",
+			actual:   "2020.81",
+			expected: "2019.78",
+			want:     true,
+		},
+		{
+			name:     "version_eq - build suffix is ignored",
+			operator: "version_eq",
+			actual:   "8.0.35-27-percona",
+			expected: "8.0.35",
+			want:     true,
+		},
+		{
+			name:     "version_eq - raw fingerprint version is read canonically",
+			operator: "version_eq",
+			actual:   "003.008",
+			expected: "3.8",
+			want:     true,
+		},
+		{
+			name:     "version_lt - pre-release before release",
+			operator: "version_lt",
+			actual:   "2.0.0-rc1",
+			expected: "2.0.0",
+			want:     true,
+		},
+
 		// Invalid versions
 		{
 			name:     "version_eq - invalid actual",
@@ -864,7 +908,7 @@ func TestVersionOperators_ErrorCases(t *testing.T) {
 		{
 			name:     "version_gt - invalid actual",
 			operator: "version_gt",
-			actual:   "v999.999.999.999.999",
+			actual:   "v.x.y",
 			expected: "1.0.0",
 		},
 		{
@@ -977,6 +1021,61 @@ func TestMatcherEngine_Evaluate_NoRules(t *testing.T) {
 	require.Contains(t, err.Error(), "no rules to evaluate")
 }
 
+func TestMatcherEngine_Evaluate_NestedBlocks(t *testing.T) {
+	m := NewMatcherEngine()
+
+	// body contains "admin" AND NOT status 404, OR server is nginx
+	match := &MatchBlock{
+		Logic: "OR",
+		Rules: []MatchRule{
+			{Field: "http.server", Operator: "equals", Value: "nginx"},
+		},
+		Blocks: []MatchBlock{
+			{
+				Logic: "AND",
+				Rules: []MatchRule{
+					{Field: "http.body", Operator: "contains", Value: "admin"},
+				},
+				Blocks: []MatchBlock{
+					{Logic: "NOT", Rules: []MatchRule{{Field: "http.status_code", Operator: "equals", Value: 404}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		context map[string]any
+		want    bool
+	}{
+		{"nested group matches", map[string]any{"http.body": "admin panel", "http.status_code": 200}, true},
+		{"negated rule fails group", map[string]any{"http.body": "admin panel", "http.status_code": 404}, false},
+		{"top-level rule matches", map[string]any{"http.server": "nginx", "http.status_code": 404}, true},
+		{"nothing matches", map[string]any{"http.body": "hello"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Evaluate(match, tt.context)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatcherEngine_Evaluate_NestedBlockError(t *testing.T) {
+	m := NewMatcherEngine()
+
+	match := &MatchBlock{
+		Logic:  "AND",
+		Blocks: []MatchBlock{{Logic: "XOR", Rules: []MatchRule{{Field: "a", Operator: "equals", Value: "a"}}}},
+	}
+
+	_, err := m.Evaluate(match, map[string]any{"a": "a"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "block[0] evaluation failed")
+}
+
 func TestMatcherEngine_EvaluateRule_UnknownOperator(t *testing.T) {
 	m := NewMatcherEngine()
 
@@ -0,0 +1,520 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Evaluation context fields set from the HTTP responses of Nuclei checks.
+// Matchers of translated templates are rules on these fields.
+const (
+	NucleiStatusCodeField    = "http.status_code"    // int
+	NucleiBodyField          = "http.body"           // response body
+	NucleiHeadersField       = "http.headers"        // all headers, one "Name: value" per line
+	NucleiResponseField      = "http.response"       // status line, headers and body
+	NucleiContentLengthField = "http.content_length" // body size in bytes
+	NucleiHeaderFieldPrefix  = "http.header."        // + header name, lowercase with '_' for '-'
+)
+
+// ErrUnsupportedNucleiTemplate is returned for Nuclei templates that use
+// features outside the supported subset, e.g. non-HTTP protocols, DSL
+// matchers or payloads.
+var ErrUnsupportedNucleiTemplate = errors.New("unsupported nuclei template")
+
+// NucleiCheck is a Nuclei HTTP template translated for the plugin engine:
+// the requests to send and the plugin that evaluates each response.
+type NucleiCheck struct {
+	Plugin     *YAMLPlugin
+	Requests   []NucleiRequest
+	Extractors []NucleiExtractor
+
+	// FollowRedirects follows up to MaxRedirects redirects per request.
+	FollowRedirects bool
+	MaxRedirects    int
+}
+
+// NucleiRequest is a single HTTP request of a check. Path and Raw may hold
+// {{BaseURL}}-style placeholders, which are resolved against the target.
+type NucleiRequest struct {
+	Method  string
+	Path    string
+	Headers map[string]string
+	Body    string
+
+	// Raw is a raw HTTP request; when set, the fields above are unused.
+	Raw string
+}
+
+// NucleiExtractor pulls values out of a matching response, reported with
+// the finding.
+type NucleiExtractor struct {
+	Name  string
+	Field string           // context field the regexes run on
+	Regex []*regexp.Regexp // values are the Group submatch
+	Group int
+	KVal  []string // header names, lowercase with '_' for '-'
+}
+
+// nucleiTemplate is the subset of the Nuclei template format that can be
+// translated.
+type nucleiTemplate struct {
+	ID   string `yaml:"id"`
+	Info struct {
+		Name           string     `yaml:"name"`
+		Author         stringList `yaml:"author"`
+		Severity       string     `yaml:"severity"`
+		Description    string     `yaml:"description"`
+		Remediation    string     `yaml:"remediation"`
+		Reference      stringList `yaml:"reference"`
+		Tags           stringList `yaml:"tags"`
+		Classification struct {
+			CVEID stringList `yaml:"cve-id"`
+		} `yaml:"classification"`
+	} `yaml:"info"`
+	HTTP     []nucleiRequest `yaml:"http"`
+	Requests []nucleiRequest `yaml:"requests"` // name of the http section before Nuclei v3
+}
+
+type nucleiRequest struct {
+	Method            string            `yaml:"method"`
+	Path              []string          `yaml:"path"`
+	Raw               []string          `yaml:"raw"`
+	Headers           map[string]string `yaml:"headers"`
+	Body              string            `yaml:"body"`
+	Redirects         bool              `yaml:"redirects"`
+	HostRedirects     bool              `yaml:"host-redirects"`
+	MaxRedirects      int               `yaml:"max-redirects"`
+	MatchersCondition string            `yaml:"matchers-condition"`
+	Matchers          []nucleiMatcher   `yaml:"matchers"`
+	Extractors        []nucleiExtractor `yaml:"extractors"`
+	Payloads          map[string]any    `yaml:"payloads"`
+	Unsafe            bool              `yaml:"unsafe"`
+	ReqCondition      bool              `yaml:"req-condition"`
+}
+
+type nucleiMatcher struct {
+	Type            string     `yaml:"type"`
+	Part            string     `yaml:"part"`
+	Condition       string     `yaml:"condition"`
+	Negative        bool       `yaml:"negative"`
+	CaseInsensitive bool       `yaml:"case-insensitive"`
+	Encoding        string     `yaml:"encoding"`
+	Words           stringList `yaml:"words"`
+	Regex           stringList `yaml:"regex"`
+	Status          []int      `yaml:"status"`
+	Size            []int      `yaml:"size"`
+}
+
+type nucleiExtractor struct {
+	Type     string     `yaml:"type"`
+	Name     string     `yaml:"name"`
+	Part     string     `yaml:"part"`
+	Regex    stringList `yaml:"regex"`
+	Group    int        `yaml:"group"`
+	KVal     stringList `yaml:"kval"`
+	Internal bool       `yaml:"internal"`
+}
+
+// stringList accepts a YAML sequence or a comma-separated scalar, as
+// Nuclei does for authors, tags and references.
+type stringList []string
+
+func (s *stringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*s = nil
+		for _, v := range strings.Split(node.Value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				*s = append(*s, v)
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		var values []string
+		if err := node.Decode(&values); err != nil {
+			return err
+		}
+		*s = values
+		return nil
+	default:
+		return fmt.Errorf("expected a string or a list of strings")
+	}
+}
+
+// nucleiProtocols are the template sections for protocols other than HTTP.
+var nucleiProtocols = []string{
+	"dns", "file", "tcp", "network", "headless", "ssl", "websocket",
+	"whois", "code", "javascript", "workflows", "flow",
+}
+
+// nucleiVariables are the placeholders resolved against the target.
+var nucleiVariables = map[string]bool{
+	"BaseURL": true, "RootURL": true, "Hostname": true, "Host": true,
+	"Port": true, "Path": true, "Scheme": true,
+}
+
+var nucleiPlaceholder = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+// ParseNucleiTemplate translates a Nuclei HTTP template into a check.
+// Supported are templates with a single http (or requests) block using
+// path or raw requests; word, regex, status and size matchers on the
+// body, header or whole response, optionally negated and combined with
+// and/or conditions; and regex and kval extractors. A template with
+// extractors but no matchers matches when an extractor finds a value.
+// Templates using other features fail with ErrUnsupportedNucleiTemplate.
+func ParseNucleiTemplate(data []byte) (*NucleiCheck, error) {
+	var sections map[string]any
+	if err := yaml.Unmarshal(data, &sections); err != nil {
+		return nil, fmt.Errorf("failed to parse nuclei template: %w", err)
+	}
+	for _, protocol := range nucleiProtocols {
+		if _, ok := sections[protocol]; ok {
+			return nil, unsupportedNuclei("%s protocol", protocol)
+		}
+	}
+
+	var tmpl nucleiTemplate
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("failed to parse nuclei template: %w", err)
+	}
+	if tmpl.ID == "" {
+		return nil, fmt.Errorf("nuclei template id is required")
+	}
+
+	requests := make([]nucleiRequest, 0, len(tmpl.HTTP)+len(tmpl.Requests))
+	requests = append(requests, tmpl.HTTP...)
+	requests = append(requests, tmpl.Requests...)
+	switch {
+	case len(requests) == 0:
+		return nil, unsupportedNuclei("no http requests")
+	case len(requests) > 1:
+		return nil, unsupportedNuclei("multiple http request blocks")
+	}
+	req := requests[0]
+
+	check, err := translateNucleiRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	match, err := translateNucleiMatchers(req)
+	if err != nil {
+		return nil, err
+	}
+
+	check.Plugin = tmpl.plugin(match)
+	if err := check.Plugin.Validate(); err != nil {
+		return nil, fmt.Errorf("translated plugin validation failed: %w", err)
+	}
+	return check, nil
+}
+
+// LoadNucleiTemplates loads the Nuclei templates at paths, which may be
+// files or directories searched recursively for .yaml and .yml files.
+// Templates that fail to load are reported in the returned errors and do
+// not stop the others from loading.
+func LoadNucleiTemplates(paths []string) ([]*NucleiCheck, []error) {
+	var checks []*NucleiCheck
+	var errs []error
+
+	load := func(path string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to read nuclei template: %w", path, err))
+			return
+		}
+		check, err := ParseNucleiTemplate(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			return
+		}
+		check.Plugin.FilePath = path
+		checks = append(checks, check)
+	}
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				// Skip hidden directories such as .git and .github
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if path == root || ext == ".yaml" || ext == ".yml" {
+				load(path)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to read nuclei templates: %w", root, err))
+		}
+	}
+
+	return checks, errs
+}
+
+func translateNucleiRequest(req nucleiRequest) (*NucleiCheck, error) {
+	switch {
+	case len(req.Payloads) > 0:
+		return nil, unsupportedNuclei("payloads")
+	case req.Unsafe:
+		return nil, unsupportedNuclei("unsafe raw requests")
+	case req.ReqCondition:
+		return nil, unsupportedNuclei("req-condition")
+	case req.HostRedirects:
+		return nil, unsupportedNuclei("host-redirects")
+	}
+
+	check := &NucleiCheck{
+		FollowRedirects: req.Redirects,
+		MaxRedirects:    req.MaxRedirects,
+	}
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+	for _, path := range req.Path {
+		check.Requests = append(check.Requests, NucleiRequest{Method: method, Path: path, Headers: req.Headers, Body: req.Body})
+	}
+	for _, raw := range req.Raw {
+		check.Requests = append(check.Requests, NucleiRequest{Raw: raw})
+	}
+	if len(check.Requests) == 0 {
+		return nil, unsupportedNuclei("no path or raw requests")
+	}
+
+	for _, r := range check.Requests {
+		texts := []string{r.Path, r.Body, r.Raw}
+		for _, v := range r.Headers {
+			texts = append(texts, v)
+		}
+		for _, text := range texts {
+			for _, m := range nucleiPlaceholder.FindAllStringSubmatch(text, -1) {
+				if !nucleiVariables[m[1]] {
+					return nil, unsupportedNuclei("variable {{%s}}", m[1])
+				}
+			}
+		}
+	}
+
+	for _, e := range req.Extractors {
+		if e.Internal {
+			return nil, unsupportedNuclei("internal extractors")
+		}
+		field, err := nucleiPartField(e.Part)
+		if err != nil {
+			return nil, err
+		}
+		extractor := NucleiExtractor{Name: e.Name, Field: field, Group: e.Group}
+		switch e.Type {
+		case "regex":
+			for _, expr := range e.Regex {
+				re, err := regexp.Compile(expr)
+				if err != nil {
+					return nil, fmt.Errorf("invalid extractor regex %q: %w", expr, err)
+				}
+				extractor.Regex = append(extractor.Regex, re)
+			}
+		case "kval":
+			for _, key := range e.KVal {
+				extractor.KVal = append(extractor.KVal, nucleiHeaderKey(key))
+			}
+		default:
+			return nil, unsupportedNuclei("%s extractors", e.Type)
+		}
+		check.Extractors = append(check.Extractors, extractor)
+	}
+
+	return check, nil
+}
+
+// translateNucleiMatchers maps the matchers of req to a match block: one
+// nested block per matcher, combined by matchers-condition. Without
+// matchers, the extractors decide whether the template matches.
+func translateNucleiMatchers(req nucleiRequest) (*MatchBlock, error) {
+	match := &MatchBlock{Logic: nucleiLogic(req.MatchersCondition)}
+
+	for i, m := range req.Matchers {
+		block, err := translateNucleiMatcher(m)
+		if err != nil {
+			return nil, fmt.Errorf("matcher[%d]: %w", i, err)
+		}
+		match.Blocks = append(match.Blocks, block)
+	}
+	if len(match.Blocks) > 0 {
+		return match, nil
+	}
+
+	match.Logic = "OR"
+	for _, e := range req.Extractors {
+		field, _ := nucleiPartField(e.Part)
+		for _, expr := range e.Regex {
+			match.Rules = append(match.Rules, MatchRule{Field: field, Operator: "matches", Value: expr})
+		}
+		for _, key := range e.KVal {
+			match.Rules = append(match.Rules, MatchRule{Field: NucleiHeaderFieldPrefix + nucleiHeaderKey(key), Operator: "exists", Value: true})
+		}
+	}
+	if len(match.Rules) == 0 {
+		return nil, unsupportedNuclei("no matchers or extractors")
+	}
+	return match, nil
+}
+
+func translateNucleiMatcher(m nucleiMatcher) (MatchBlock, error) {
+	if m.Encoding != "" {
+		return MatchBlock{}, unsupportedNuclei("%s encoded matchers", m.Encoding)
+	}
+
+	block := MatchBlock{Logic: nucleiLogic(m.Condition)}
+	switch m.Type {
+	case "word":
+		field, err := nucleiPartField(m.Part)
+		if err != nil {
+			return MatchBlock{}, err
+		}
+		for _, word := range m.Words {
+			if nucleiPlaceholder.MatchString(word) {
+				return MatchBlock{}, unsupportedNuclei("variables in words")
+			}
+			rule := MatchRule{Field: field, Operator: "contains", Value: word}
+			if m.CaseInsensitive {
+				rule.Operator, rule.Value = "matches", "(?i)"+regexp.QuoteMeta(word)
+			}
+			block.Rules = append(block.Rules, rule)
+		}
+	case "regex":
+		field, err := nucleiPartField(m.Part)
+		if err != nil {
+			return MatchBlock{}, err
+		}
+		for _, expr := range m.Regex {
+			if _, err := regexp.Compile(expr); err != nil {
+				return MatchBlock{}, fmt.Errorf("invalid regex %q: %w", expr, err)
+			}
+			if m.CaseInsensitive {
+				expr = "(?i)" + expr
+			}
+			block.Rules = append(block.Rules, MatchRule{Field: field, Operator: "matches", Value: expr})
+		}
+	case "status":
+		// Any of the listed status codes matches, whatever the condition
+		block.Logic = "OR"
+		for _, code := range m.Status {
+			block.Rules = append(block.Rules, MatchRule{Field: NucleiStatusCodeField, Operator: "equals", Value: code})
+		}
+	case "size":
+		for _, size := range m.Size {
+			block.Rules = append(block.Rules, MatchRule{Field: NucleiContentLengthField, Operator: "equals", Value: size})
+		}
+	default:
+		return MatchBlock{}, unsupportedNuclei("%s matchers", m.Type)
+	}
+	if len(block.Rules) == 0 {
+		return MatchBlock{}, fmt.Errorf("%s matcher has no values", m.Type)
+	}
+
+	if m.Negative {
+		return MatchBlock{Logic: "NOT", Blocks: []MatchBlock{block}}, nil
+	}
+	return block, nil
+}
+
+// plugin builds the plugin that reports matches of the template.
+func (t *nucleiTemplate) plugin(match *MatchBlock) *YAMLPlugin {
+	severity := Severity(strings.ToLower(t.Info.Severity))
+	switch severity {
+	case CriticalSeverity, HighSeverity, MediumSeverity, LowSeverity, InfoSeverity:
+	default:
+		severity = InfoSeverity
+	}
+
+	name := t.Info.Name
+	if name == "" {
+		name = t.ID
+	}
+	author := strings.Join(t.Info.Author, ", ")
+	if author == "" {
+		author = "nuclei-templates"
+	}
+
+	p := &YAMLPlugin{
+		ID:      t.ID,
+		Name:    name,
+		Version: "1.0.0",
+		Type:    EvaluationType,
+		Author:  author,
+		Metadata: PluginMetadata{
+			Severity:   severity,
+			Tags:       append(append([]string{}, t.Info.Tags...), "nuclei"),
+			References: t.Info.Reference,
+		},
+		Triggers: []Trigger{{DataKey: NucleiStatusCodeField, Condition: "exists", Value: true}},
+		Match:    match,
+		Output: OutputBlock{
+			Vulnerability: severity != InfoSeverity,
+			Severity:      severity,
+			Message:       name,
+			Remediation:   strings.TrimSpace(t.Info.Remediation),
+		},
+		LoadedAt: time.Now(),
+	}
+	if len(t.Info.Classification.CVEID) > 0 {
+		p.Metadata.CVE = strings.ToUpper(t.Info.Classification.CVEID[0])
+	}
+	if len(t.Info.Reference) > 0 {
+		p.Output.Reference = t.Info.Reference[0]
+	}
+	return p
+}
+
+// nucleiPartField returns the context field a matcher or extractor part
+// refers to. Nuclei defaults to the body.
+func nucleiPartField(part string) (string, error) {
+	switch part {
+	case "", "body":
+		return NucleiBodyField, nil
+	case "header", "all_headers":
+		return NucleiHeadersField, nil
+	case "all", "response", "raw":
+		return NucleiResponseField, nil
+	case "status_code":
+		return NucleiStatusCodeField, nil
+	default:
+		return "", unsupportedNuclei("part %q", part)
+	}
+}
+
+func nucleiLogic(condition string) string {
+	if strings.EqualFold(condition, "and") {
+		return "AND"
+	}
+	return "OR"
+}
+
+// nucleiHeaderKey normalizes a header name the way Nuclei kval keys are
+// written, e.g. "Content-Type" -> "content_type".
+func nucleiHeaderKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+}
+
+func unsupportedNuclei(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedNucleiTemplate, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,338 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/egress"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+)
+
+// Defaults for running Nuclei checks.
+const (
+	DefaultNucleiTimeout      = 10 * time.Second
+	DefaultNucleiMaxRedirects = 10
+	nucleiMaxBodySize         = 4 << 20 // bytes of a response body that are matched
+)
+
+// NucleiRunner sends the requests of Nuclei checks to a target and
+// evaluates each response with the plugin engine.
+type NucleiRunner struct {
+	transport http.RoundTripper
+	timeout   time.Duration
+	evaluator *Evaluator
+}
+
+// NewNucleiRunner creates a runner whose requests time out after timeout
+// (DefaultNucleiTimeout when zero). Certificates are not verified, as
+// targets commonly use self-signed ones.
+func NewNucleiRunner(timeout time.Duration) *NucleiRunner {
+	if timeout <= 0 {
+		timeout = DefaultNucleiTimeout
+	}
+	return &NucleiRunner{
+		transport: newTargetTransport(timeout),
+		timeout:   timeout,
+		evaluator: NewEvaluator(),
+	}
+}
+
+// Pooled connections to scan targets hold file descriptors of the scan's
+// budget, so few are kept idle and not for long.
+const (
+	targetMaxIdleConnsPerHost = 2
+	targetIdleConnTimeout     = 10 * time.Second
+)
+
+// newTargetTransport returns the transport for requests to scan targets.
+// Certificates are not verified, as targets commonly use self-signed ones.
+func newTargetTransport(timeout time.Duration) *http.Transport {
+	return &http.Transport{
+		// The scan proxy, descriptor budget and capture recorder travel in
+		// the request context
+		Proxy:               netproxy.HTTPProxy,
+		DialContext:         capture.Dial(blackout.Dial(fdbudget.Dial(egress.Dial(&net.Dialer{Timeout: timeout})))),
+		MaxIdleConnsPerHost: targetMaxIdleConnsPerHost,
+		IdleConnTimeout:     targetIdleConnTimeout,
+		TLSClientConfig:     &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // scanning targets with self-signed certificates
+	}
+}
+
+// Run sends the requests of check to the target at baseURL (e.g.
+// "https://10.0.0.5:8443") until a response matches. The result holds the
+// URL that matched in Output.Metadata["matched_at"] and the extracted
+// values, comma-separated, in Output.Metadata["extracted"]. An error is
+// returned only when no request got a response.
+func (r *NucleiRunner) Run(ctx context.Context, check *NucleiCheck, baseURL string) (*YAMLMatchResult, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", baseURL)
+	}
+	vars := nucleiVars(base)
+	redirects := check.maxRedirects()
+	client := r.client(redirects)
+
+	result := &YAMLMatchResult{Plugin: check.Plugin, EvaluatedAt: time.Now()}
+	var lastErr error
+	responded := false
+	for _, req := range check.Requests {
+		httpReq, err := req.build(ctx, vars)
+		if err != nil {
+			return nil, err
+		}
+		evalContext, err := doTargetRequest(client, httpReq, redirects)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+		responded = true
+
+		matched, err := r.evaluator.Evaluate(check.Plugin, evalContext)
+		if err != nil {
+			return nil, err
+		}
+		if !matched.Matched {
+			continue
+		}
+
+		result.Matched = true
+		result.Output = matched.Output
+		result.Output.Metadata = map[string]string{"matched_at": httpReq.URL.String()}
+		for k, v := range matched.Output.Metadata {
+			result.Output.Metadata[k] = v
+		}
+		if values := check.extract(evalContext); len(values) > 0 {
+			result.Output.Metadata["extracted"] = strings.Join(values, ",")
+		}
+		break
+	}
+	result.ExecutionTime = time.Since(result.EvaluatedAt)
+
+	if !responded && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// maxRedirects returns how many redirects the requests of c follow.
+func (c *NucleiCheck) maxRedirects() int {
+	if !c.FollowRedirects {
+		return 0
+	}
+	if c.MaxRedirects <= 0 {
+		return DefaultNucleiMaxRedirects
+	}
+	return c.MaxRedirects
+}
+
+// client returns an HTTP client following up to maxRedirects redirects.
+func (r *NucleiRunner) client(maxRedirects int) *http.Client {
+	return &http.Client{
+		Transport: r.transport,
+		Timeout:   r.timeout,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
+// doTargetRequest sends req with client, which follows up to redirects
+// redirects, and returns the evaluation context built from the response.
+// When the request context carries an HTTPCache, identical requests of the
+// scan share the response.
+func doTargetRequest(client *http.Client, req *http.Request, redirects int) (map[string]any, error) {
+	cache := HTTPCacheFromContext(req.Context())
+	key := httpCacheKey(client, req, redirects)
+	resp, hit, err := cache.do(req.Context(), key, func() (*targetResponse, error) {
+		return fetchTarget(client, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if hit && client.Jar != nil && len(resp.cookies) > 0 {
+		// The response was received by another client; keep the cookies it
+		// set for the next requests of this one
+		client.Jar.SetCookies(req.URL, resp.cookies)
+	}
+	return resp.cloneFields(), nil
+}
+
+// fetchTarget sends req and reads the response.
+func fetchTarget(client *http.Client, req *http.Request) (*targetResponse, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, nucleiMaxBodySize))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	return &targetResponse{fields: nucleiContext(resp, string(body)), cookies: resp.Cookies()}, nil
+}
+
+// nucleiContext builds the evaluation context for a response.
+func nucleiContext(resp *http.Response, body string) map[string]any {
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	evalContext := map[string]any{
+		NucleiStatusCodeField:    resp.StatusCode,
+		NucleiBodyField:          body,
+		NucleiContentLengthField: len(body),
+	}
+	var headers strings.Builder
+	for _, name := range names {
+		values := resp.Header.Values(name)
+		for _, v := range values {
+			headers.WriteString(name + ": " + v + "\r\n")
+		}
+		evalContext[NucleiHeaderFieldPrefix+nucleiHeaderKey(name)] = strings.Join(values, ", ")
+	}
+	evalContext[NucleiHeadersField] = headers.String()
+	evalContext[NucleiResponseField] = fmt.Sprintf("%s %s\r\n%s\r\n%s", resp.Proto, resp.Status, headers.String(), body)
+	if server := resp.Header.Get("Server"); server != "" {
+		evalContext["http.server"] = server
+	}
+	return evalContext
+}
+
+// extract returns the values the extractors of c find in evalContext.
+func (c *NucleiCheck) extract(evalContext map[string]any) []string {
+	var values []string
+	seen := make(map[string]bool)
+	add := func(v string) {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+
+	for _, e := range c.Extractors {
+		text := toString(evalContext[e.Field])
+		for _, re := range e.Regex {
+			for _, m := range re.FindAllStringSubmatch(text, -1) {
+				if e.Group < len(m) {
+					add(m[e.Group])
+				}
+			}
+		}
+		for _, key := range e.KVal {
+			add(toString(evalContext[NucleiHeaderFieldPrefix+key]))
+		}
+	}
+	return values
+}
+
+// nucleiVars returns the values of the template placeholders for base.
+func nucleiVars(base *url.URL) map[string]string {
+	port := base.Port()
+	if port == "" {
+		port = "80"
+		if base.Scheme == "https" {
+			port = "443"
+		}
+	}
+	root := base.Scheme + "://" + base.Host
+	return map[string]string{
+		"BaseURL":  root + strings.TrimSuffix(base.Path, "/"),
+		"RootURL":  root,
+		"Hostname": base.Host,
+		"Host":     base.Hostname(),
+		"Port":     port,
+		"Path":     strings.TrimSuffix(base.Path, "/"),
+		"Scheme":   base.Scheme,
+	}
+}
+
+func resolveNucleiVars(s string, vars map[string]string) string {
+	return nucleiPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := nucleiPlaceholder.FindStringSubmatch(placeholder)[1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		return placeholder
+	})
+}
+
+// build creates the HTTP request for the target described by vars.
+func (r NucleiRequest) build(ctx context.Context, vars map[string]string) (*http.Request, error) {
+	if r.Raw != "" {
+		return buildRawNucleiRequest(ctx, resolveNucleiVars(r.Raw, vars), vars["RootURL"])
+	}
+
+	target := resolveNucleiVars(r.Path, vars)
+	var body io.Reader
+	if r.Body != "" {
+		body = strings.NewReader(resolveNucleiVars(r.Body, vars))
+	}
+	req, err := http.NewRequestWithContext(ctx, r.Method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s: %w", target, err)
+	}
+	for name, value := range r.Headers {
+		req.Header.Set(name, resolveNucleiVars(value, vars))
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	return req, nil
+}
+
+// buildRawNucleiRequest parses a raw request and sends it to root.
+func buildRawNucleiRequest(ctx context.Context, raw, root string) (*http.Request, error) {
+	raw = strings.TrimLeft(strings.ReplaceAll(raw, "\r\n", "\n"), "\n ")
+	head, body, _ := strings.Cut(raw, "\n\n")
+	body = strings.TrimSuffix(body, "\n")
+
+	parsed, err := http.ReadRequest(bufio.NewReader(strings.NewReader(head + "\n\n")))
+	if err != nil {
+		return nil, fmt.Errorf("parse raw request: %w", err)
+	}
+
+	var bodyReader io.Reader
+	if body != "" {
+		bodyReader = strings.NewReader(body)
+	}
+	target := root + parsed.RequestURI
+	if parsed.URL.IsAbs() {
+		target = parsed.URL.String()
+	}
+	req, err := http.NewRequestWithContext(ctx, parsed.Method, target, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("build raw request: %w", err)
+	}
+	req.Header = parsed.Header
+	req.Header.Del("Content-Length")
+	if parsed.Host != "" {
+		req.Host = parsed.Host
+	}
+	return req, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func loadNucleiCheck(t *testing.T, path string) *NucleiCheck {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	check, err := ParseNucleiTemplate(data)
+	require.NoError(t, err)
+	return check
+}
+
+func TestNucleiRunner_Run_Matches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.git/config" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = fmt.Fprint(w, "[core]\n\trepositoryformatversion = 0\n")
+	}))
+	defer server.Close()
+
+	runner := NewNucleiRunner(5 * time.Second)
+	result, err := runner.Run(context.Background(), loadNucleiCheck(t, "testdata/nuclei/git-config.yaml"), server.URL)
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	require.Equal(t, MediumSeverity, result.Output.Severity)
+	require.Equal(t, server.URL+"/.git/config", result.Output.Metadata["matched_at"])
+}
+
+func TestNucleiRunner_Run_NoMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// SPA servers answer every path with the index page
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = fmt.Fprint(w, "<html>[core] repositoryformatversion</html>")
+	}))
+	defer server.Close()
+
+	runner := NewNucleiRunner(5 * time.Second)
+	result, err := runner.Run(context.Background(), loadNucleiCheck(t, "testdata/nuclei/git-config.yaml"), server.URL)
+	require.NoError(t, err)
+	require.False(t, result.Matched)
+}
+
+func TestNucleiRunner_Run_RawRequestExtractor(t *testing.T) {
+	var gotAccept, gotHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAccept = r.Header.Get("Accept")
+		gotHost = r.Host
+		if r.URL.Path == "/version" {
+			_, _ = fmt.Fprint(w, `{"name":"app","version": "2.4.1"}`)
+		}
+	}))
+	defer server.Close()
+
+	runner := NewNucleiRunner(5 * time.Second)
+	result, err := runner.Run(context.Background(), loadNucleiCheck(t, "testdata/nuclei/version-extract.yaml"), server.URL)
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	require.Equal(t, "2.4.1", result.Output.Metadata["extracted"])
+	require.Equal(t, "application/json", gotAccept)
+	require.Equal(t, server.Listener.Addr().String(), gotHost)
+}
+
+func TestNucleiRunner_Run_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/login", http.StatusFound)
+	})
+	mux.HandleFunc("/login", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, "Admin Login")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	template := `id: admin-login
+info: {name: Admin Login, author: test, severity: low}
+http:
+  - path: ["{{BaseURL}}/"]
+    redirects: %t
+    matchers:
+      - type: word
+        words: ["Admin Login"]
+`
+	runner := NewNucleiRunner(5 * time.Second)
+	for _, follow := range []bool{false, true} {
+		check, err := ParseNucleiTemplate(fmt.Appendf(nil, template, follow))
+		require.NoError(t, err)
+
+		result, err := runner.Run(context.Background(), check, server.URL)
+		require.NoError(t, err)
+		require.Equal(t, follow, result.Matched, "redirects: %t", follow)
+	}
+}
+
+func TestNucleiRunner_Run_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	closedURL := server.URL
+	server.Close()
+
+	runner := NewNucleiRunner(time.Second)
+	_, err := runner.Run(context.Background(), loadNucleiCheck(t, "testdata/nuclei/git-config.yaml"), closedURL)
+	require.Error(t, err)
+
+	_, err = runner.Run(context.Background(), loadNucleiCheck(t, "testdata/nuclei/git-config.yaml"), "not a url")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid base URL")
+}
+
+func TestNucleiVars(t *testing.T) {
+	req := NucleiRequest{Method: "GET", Path: "{{BaseURL}}/a?h={{Host}}&p={{Port}}&s={{Scheme}}", Headers: map[string]string{"Referer": "{{RootURL}}/"}}
+	base, err := url.Parse("https://example.com/app/")
+	require.NoError(t, err)
+
+	built, err := req.build(context.Background(), nucleiVars(base))
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/app/a?h=example.com&p=443&s=https", built.URL.String())
+	require.Equal(t, "https://example.com/", built.Header.Get("Referer"))
+}
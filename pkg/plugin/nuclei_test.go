@@ -0,0 +1,218 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNucleiTemplate_Matchers(t *testing.T) {
+	data, err := os.ReadFile("testdata/nuclei/git-config.yaml")
+	require.NoError(t, err)
+
+	check, err := ParseNucleiTemplate(data)
+	require.NoError(t, err)
+
+	p := check.Plugin
+	require.Equal(t, "git-config", p.ID)
+	require.Equal(t, "Git Configuration - Detect", p.Name)
+	require.Equal(t, "pdteam, vulntor-security", p.Author)
+	require.Equal(t, MediumSeverity, p.Metadata.Severity)
+	require.Equal(t, []string{"config", "git", "exposure", "nuclei"}, p.Metadata.Tags)
+	require.Equal(t, "https://example.com/exposed-git", p.Output.Reference)
+	require.Equal(t, "Remove the .git directory from the web root.", p.Output.Remediation)
+	require.True(t, p.Output.Vulnerability)
+
+	require.Equal(t, []NucleiRequest{{Method: "GET", Path: "{{BaseURL}}/.git/config"}}, check.Requests)
+	require.Equal(t, &MatchBlock{
+		Logic: "AND",
+		Blocks: []MatchBlock{
+			{Logic: "AND", Rules: []MatchRule{
+				{Field: NucleiBodyField, Operator: "contains", Value: "[core]"},
+				{Field: NucleiBodyField, Operator: "contains", Value: "repositoryformatversion"},
+			}},
+			{Logic: "NOT", Blocks: []MatchBlock{
+				{Logic: "OR", Rules: []MatchRule{{Field: NucleiHeadersField, Operator: "contains", Value: "text/html"}}},
+			}},
+			{Logic: "OR", Rules: []MatchRule{{Field: NucleiStatusCodeField, Operator: "equals", Value: 200}}},
+		},
+	}, p.Match)
+
+	evaluator := NewEvaluator()
+	matched, err := evaluator.Evaluate(p, map[string]any{
+		NucleiStatusCodeField: 200,
+		NucleiBodyField:       "[core]\n\trepositoryformatversion = 0\n",
+		NucleiHeadersField:    "Content-Type: text/plain\r\n",
+	})
+	require.NoError(t, err)
+	require.True(t, matched.Matched)
+
+	matched, err = evaluator.Evaluate(p, map[string]any{
+		NucleiStatusCodeField: 200,
+		NucleiBodyField:       "[core]\n\trepositoryformatversion = 0\n",
+		NucleiHeadersField:    "Content-Type: text/html\r\n",
+	})
+	require.NoError(t, err)
+	require.False(t, matched.Matched, "negative matcher must reject HTML pages")
+}
+
+func TestParseNucleiTemplate_ExtractorsOnly(t *testing.T) {
+	data, err := os.ReadFile("testdata/nuclei/version-extract.yaml")
+	require.NoError(t, err)
+
+	check, err := ParseNucleiTemplate(data)
+	require.NoError(t, err)
+
+	require.Equal(t, InfoSeverity, check.Plugin.Metadata.Severity)
+	require.False(t, check.Plugin.Output.Vulnerability)
+	require.Len(t, check.Requests, 1)
+	require.Contains(t, check.Requests[0].Raw, "GET /version HTTP/1.1")
+	require.Len(t, check.Extractors, 1)
+	require.Equal(t, 1, check.Extractors[0].Group)
+	require.Equal(t, &MatchBlock{
+		Logic: "OR",
+		Rules: []MatchRule{{Field: NucleiBodyField, Operator: "matches", Value: `"version":\s*"([0-9.]+)"`}},
+	}, check.Plugin.Match)
+}
+
+func TestParseNucleiTemplate_MatcherVariants(t *testing.T) {
+	data := []byte(`id: variants
+info:
+  name: Variants
+  author: [a, b]
+  severity: HIGH
+  classification:
+    cve-id: cve-2021-1234
+http:
+  - path: ["{{BaseURL}}/"]
+    matchers:
+      - type: word
+        part: all
+        case-insensitive: true
+        words: ["Admin.Panel"]
+      - type: regex
+        part: header
+        regex: ["Server: nginx/1\\.1[0-8]"]
+      - type: size
+        size: [42]
+    extractors:
+      - type: kval
+        kval: [X-Powered-By]
+`)
+
+	check, err := ParseNucleiTemplate(data)
+	require.NoError(t, err)
+
+	p := check.Plugin
+	require.Equal(t, "a, b", p.Author)
+	require.Equal(t, HighSeverity, p.Metadata.Severity)
+	require.Equal(t, "CVE-2021-1234", p.Metadata.CVE)
+	require.Equal(t, "OR", p.Match.Logic)
+	require.Equal(t, []MatchRule{{Field: NucleiResponseField, Operator: "matches", Value: `(?i)Admin\.Panel`}}, p.Match.Blocks[0].Rules)
+	require.Equal(t, []MatchRule{{Field: NucleiHeadersField, Operator: "matches", Value: `Server: nginx/1\.1[0-8]`}}, p.Match.Blocks[1].Rules)
+	require.Equal(t, []MatchRule{{Field: NucleiContentLengthField, Operator: "equals", Value: 42}}, p.Match.Blocks[2].Rules)
+	require.Equal(t, []string{"x_powered_by"}, check.Extractors[0].KVal)
+	require.Equal(t, "GET", check.Requests[0].Method)
+}
+
+func TestParseNucleiTemplate_Unsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{
+			name: "other protocol",
+			data: "id: x\ninfo: {name: x, severity: info}\ndns:\n  - name: \"{{FQDN}}\"\n",
+			want: "dns protocol",
+		},
+		{
+			name: "dsl matcher",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}\"]\n    matchers:\n      - type: dsl\n        dsl: [\"status_code == 200\"]\n",
+			want: "dsl matchers",
+		},
+		{
+			name: "payloads",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}/{{path}}\"]\n    payloads:\n      path: [a, b]\n    matchers:\n      - type: status\n        status: [200]\n",
+			want: "payloads",
+		},
+		{
+			name: "unknown variable",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}/{{randstr}}\"]\n    matchers:\n      - type: status\n        status: [200]\n",
+			want: "variable {{randstr}}",
+		},
+		{
+			name: "multiple request blocks",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}\"]\n    matchers: [{type: status, status: [200]}]\n  - path: [\"{{BaseURL}}/a\"]\n    matchers: [{type: status, status: [200]}]\n",
+			want: "multiple http request blocks",
+		},
+		{
+			name: "no matchers",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}\"]\n",
+			want: "no matchers or extractors",
+		},
+		{
+			name: "unsupported part",
+			data: "id: x\ninfo: {name: x, severity: info}\nhttp:\n  - path: [\"{{BaseURL}}\"]\n    matchers: [{type: word, part: location, words: [a]}]\n",
+			want: `part "location"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNucleiTemplate([]byte(tt.data))
+			require.ErrorIs(t, err, ErrUnsupportedNucleiTemplate)
+			require.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseNucleiTemplate_Invalid(t *testing.T) {
+	_, err := ParseNucleiTemplate([]byte("info: {name: x, severity: info}\n"))
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrUnsupportedNucleiTemplate)
+	require.Contains(t, err.Error(), "id is required")
+
+	_, err = ParseNucleiTemplate([]byte("id: [unterminated"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to parse nuclei template")
+}
+
+func TestLoadNucleiTemplates(t *testing.T) {
+	checks, errs := LoadNucleiTemplates([]string{"testdata/nuclei"})
+
+	require.Len(t, checks, 2)
+	ids := []string{checks[0].Plugin.ID, checks[1].Plugin.ID}
+	require.ElementsMatch(t, []string{"git-config", "app-version-detect"}, ids)
+	for _, c := range checks {
+		require.NotEmpty(t, c.Plugin.FilePath)
+	}
+
+	require.Len(t, errs, 1)
+	require.ErrorIs(t, errs[0], ErrUnsupportedNucleiTemplate)
+	require.Contains(t, errs[0].Error(), "dns-unsupported.yaml")
+}
+
+func TestLoadNucleiTemplates_FileAndHiddenDirs(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("testdata/nuclei/git-config.yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".github"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".github", "ci.yaml"), []byte("on: push\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "git.yaml"), data, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# templates\n"), 0o644))
+
+	checks, errs := LoadNucleiTemplates([]string{dir})
+	require.Empty(t, errs)
+	require.Len(t, checks, 1)
+
+	checks, errs = LoadNucleiTemplates([]string{filepath.Join(dir, "git.yaml"), filepath.Join(dir, "missing")})
+	require.Len(t, checks, 1)
+	require.Len(t, errs, 1)
+}
@@ -7,6 +7,8 @@ package plugin
 import (
 	"github.com/rs/zerolog"
 
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+	"github.com/vulntor/vulntor/pkg/config"
 	"github.com/vulntor/vulntor/pkg/storage"
 )
 
@@ -32,6 +34,16 @@ type serviceOptions struct {
 	config   *ServiceConfig
 	storage  storage.Backend
 	sources  []PluginSource
+	proxy    *config.ProxyConfig
+	retry    *config.PluginRetryConfig
+	limiter  *bandwidth.Limiter
+	offline  bool
+	bundles  []string
+	keys     map[string][]string
+	trust    map[string]string
+	maxCache int64
+	loaded   *InstalledSet
+	wordlist string
 }
 
 // WithCacheDir sets the plugin cache directory.
@@ -49,6 +61,57 @@ func WithCacheDir(dir string) ServiceOption {
 	}
 }
 
+// WithWordlistDir makes the workspace wordlists in dir, managed with
+// "vulntor wordlist", available to the {{wordlist:name}} references of
+// installed plugins. A plugin's own wordlists take precedence.
+//
+// Default: "" (plugins read only their own wordlists)
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithWordlistDir("/var/lib/vulntor/wordlists"),
+//	)
+func WithWordlistDir(dir string) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.wordlist = dir
+	}
+}
+
+// WithMaxCacheSize bounds the plugin cache to maxBytes. Installing a plugin
+// that pushes the cache over the limit evicts the least recently used
+// plugin versions.
+//
+// Default: 0 (unbounded)
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithMaxCacheSize(512 << 20),
+//	)
+func WithMaxCacheSize(maxBytes int64) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.maxCache = maxBytes
+	}
+}
+
+// WithInstalledSet keeps set filled with the installed plugins for scans
+// to evaluate. StartManifestWatcher loads it and reloads it whenever the
+// manifest changes, e.g. after a CLI install.
+//
+// Default: nil (installed plugins are not loaded)
+//
+// Example:
+//
+//	installed := plugin.NewInstalledSet()
+//	svc, err := plugin.NewService(plugin.WithInstalledSet(installed))
+//	go svc.StartManifestWatcher(ctx)
+func WithInstalledSet(set *InstalledSet) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.loaded = set
+	}
+}
+
 // WithLogger sets a custom logger for the service.
 //
 // Default: zerolog default logger
@@ -126,3 +189,125 @@ func WithPluginSources(sources []PluginSource) ServiceOption {
 		opts.sources = sources
 	}
 }
+
+// WithProxyConfig routes plugin downloads through the configured proxy.
+// Sources listed in cfg.Sources use their own proxy URL, or none for
+// "direct".
+//
+// Default: HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithProxyConfig(config.ProxyConfig{
+//	        URL:     "http://proxy.corp.example.com:3128",
+//	        NoProxy: []string{".corp.example.com"},
+//	        Sources: map[string]string{"internal": "direct"},
+//	    }),
+//	)
+func WithProxyConfig(cfg config.ProxyConfig) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.proxy = &cfg
+	}
+}
+
+// WithRetryPolicy sets how downloads recover from transient failures:
+// how often they are retried, and when a failing source is skipped (see
+// RetryConfig and CircuitBreakerConfig). Zero fields keep the defaults.
+//
+// Default: DefaultRetryConfig() and DefaultCircuitBreakerConfig()
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithRetryPolicy(config.PluginRetryConfig{
+//	        Attempts:         5,
+//	        BreakerThreshold: 10,
+//	    }),
+//	)
+func WithRetryPolicy(cfg config.PluginRetryConfig) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.retry = &cfg
+	}
+}
+
+// WithBandwidthLimiter caps the download rate of manifest and plugin
+// fetches, shared by all of them (see config.BandwidthConfig).
+//
+// Default: nil (unlimited)
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithBandwidthLimiter(bandwidth.NewLimiter(512 << 10)),
+//	)
+func WithBandwidthLimiter(limiter *bandwidth.Limiter) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.limiter = limiter
+	}
+}
+
+// WithSourceKeys sets the public keys whose signature the manifest of each
+// source must carry, keyed by source name (see SignManifest). Sources with
+// keys of their own keep them.
+//
+// Default: manifests need not be signed
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithSourceKeys(map[string][]string{
+//	        "internal": {"q5ZkT0l8hN3vXc2..."},
+//	    }),
+//	)
+func WithSourceKeys(keys map[string][]string) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.keys = keys
+	}
+}
+
+// WithSourceTrust sets the trust level of each source, keyed by source
+// name (see TrustLevel). Plugins of untrusted sources install only with
+// InstallOptions.AllowUntrusted. Sources with a level of their own keep it.
+//
+// Default: the official source is official, sources with keys are
+// verified, and other sources are community
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithSourceTrust(map[string]string{
+//	        "internal": "verified",
+//	        "scratch":  "untrusted",
+//	    }),
+//	)
+func WithSourceTrust(levels map[string]string) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.trust = levels
+	}
+}
+
+// WithBundles adds plugin bundles (see Bundle) as sources, ahead of the
+// other sources. Bundle files are read when their manifest is fetched.
+//
+// Example:
+//
+//	svc, err := plugin.NewService(
+//	    plugin.WithOfflineMode(true),
+//	    plugin.WithBundles("/media/usb/vulntor-plugins.tar.gz"),
+//	)
+func WithBundles(paths ...string) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.bundles = append(opts.bundles, paths...)
+	}
+}
+
+// WithOfflineMode disables all network fetches: only bundle sources are
+// used, and anything else fails with ErrOffline.
+//
+// Default: false
+func WithOfflineMode(offline bool) ServiceOption {
+	return func(opts *serviceOptions) {
+		opts.offline = offline
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
 )
 
 // TestFunctionalOptions_WithCacheDir tests the WithCacheDir option
@@ -214,6 +216,41 @@ func TestFunctionalOptions_WithPluginSources(t *testing.T) {
 	})
 }
 
+// TestFunctionalOptions_WithProxyConfig tests the WithProxyConfig option
+func TestFunctionalOptions_WithProxyConfig(t *testing.T) {
+	t.Run("applies source overrides", func(t *testing.T) {
+		customSources := []PluginSource{
+			{Name: "official", URL: "https://plugins.example.com/manifest.yaml", Enabled: true},
+			{Name: "internal", URL: "https://plugins.corp.example.com/manifest.yaml", Enabled: true},
+			{Name: "partner", URL: "https://partner.example.com/manifest.yaml", Enabled: true, Proxy: "socks5://partner-proxy:1080"},
+		}
+
+		svc, err := NewService(
+			WithCacheDir(t.TempDir()),
+			WithPluginSources(customSources),
+			WithProxyConfig(config.ProxyConfig{
+				URL:     "http://proxy.example.com:3128",
+				Sources: map[string]string{"internal": "direct", "partner": "direct"},
+			}),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, "http://proxy.example.com:3128", svc.downloader.(*Downloader).proxy.String())
+		require.Empty(t, svc.sources[0].Proxy)
+		require.Equal(t, "direct", svc.sources[1].Proxy)
+		require.Equal(t, "socks5://partner-proxy:1080", svc.sources[2].Proxy, "sources keep their own proxy")
+		require.Empty(t, customSources[1].Proxy, "caller sources are not modified")
+	})
+
+	t.Run("rejects invalid proxy", func(t *testing.T) {
+		_, err := NewService(
+			WithCacheDir(t.TempDir()),
+			WithProxyConfig(config.ProxyConfig{URL: "proxy.example.com:3128"}),
+		)
+		require.ErrorContains(t, err, "create proxy: invalid proxy URL")
+	})
+}
+
 // TestFunctionalOptions_MultipleOptions tests combining multiple options
 func TestFunctionalOptions_MultipleOptions(t *testing.T) {
 	t.Run("combines all options", func(t *testing.T) {
@@ -0,0 +1,106 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Evidence formats, hints for how reports show the evidence of a finding.
+const (
+	EvidenceText = "text" // inline text
+	EvidenceCode = "code" // preformatted, e.g. banners and headers
+	EvidenceJSON = "json" // preformatted JSON document
+)
+
+// maxEvidenceLength caps the bytes of each evidence value kept with a
+// finding.
+const maxEvidenceLength = 4096
+
+var (
+	cvePattern = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+	cwePattern = regexp.MustCompile(`^CWE-\d+$`)
+)
+
+// Validate validates the references, identifiers and evidence of the
+// output block.
+func (o *OutputBlock) Validate() error {
+	for _, ref := range o.References {
+		if strings.TrimSpace(ref) == "" {
+			return fmt.Errorf("references cannot contain empty entries")
+		}
+	}
+	for _, id := range o.CVE {
+		if !cvePattern.MatchString(id) {
+			return fmt.Errorf("invalid CVE identifier: %q (must be like CVE-2024-6387)", id)
+		}
+	}
+	for _, id := range o.CWE {
+		if !cwePattern.MatchString(id) {
+			return fmt.Errorf("invalid CWE identifier: %q (must be like CWE-287)", id)
+		}
+	}
+
+	if o.Evidence != nil {
+		if len(o.Evidence.Fields) == 0 {
+			return fmt.Errorf("evidence fields cannot be empty")
+		}
+		switch o.Evidence.Format {
+		case "", EvidenceText, EvidenceCode, EvidenceJSON:
+		default:
+			return fmt.Errorf("invalid evidence format: %q (must be text, code, or json)", o.Evidence.Format)
+		}
+	}
+	return nil
+}
+
+// AllReferences returns Reference followed by References, without
+// duplicates.
+func (o *OutputBlock) AllReferences() []string {
+	var refs []string
+	for _, ref := range append([]string{o.Reference}, o.References...) {
+		if ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// EvidenceFormat returns the format of the output's evidence, text when
+// none is set.
+func (o *OutputBlock) EvidenceFormat() string {
+	if o.Evidence == nil || o.Evidence.Format == "" {
+		return EvidenceText
+	}
+	return o.Evidence.Format
+}
+
+// collectEvidence returns the values of the output's evidence fields in
+// context. Fields missing from context are left out; long values are
+// truncated.
+func (o *OutputBlock) collectEvidence(context map[string]any) map[string]string {
+	if o.Evidence == nil {
+		return nil
+	}
+	var evidence map[string]string
+	for _, field := range o.Evidence.Fields {
+		value, ok := context[field]
+		if !ok || value == nil {
+			continue
+		}
+		text := toString(value)
+		if len(text) > maxEvidenceLength {
+			text = text[:maxEvidenceLength] + "..."
+		}
+		if evidence == nil {
+			evidence = make(map[string]string)
+		}
+		evidence[field] = text
+	}
+	return evidence
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputBlock_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  OutputBlock
+		wantErr string
+	}{
+		{
+			name: "valid",
+			output: OutputBlock{
+				References: []string{"https://nvd.nist.gov/vuln/detail/CVE-2024-6387"},
+				CVE:        []string{"CVE-2024-6387"},
+				CWE:        []string{"CWE-362"},
+				Evidence:   &EvidenceBlock{Fields: []string{"ssh.banner"}, Format: EvidenceCode},
+			},
+		},
+		{"empty reference", OutputBlock{References: []string{" "}}, "references cannot contain empty entries"},
+		{"bad CVE", OutputBlock{CVE: []string{"2024-6387"}}, `invalid CVE identifier: "2024-6387"`},
+		{"bad CWE", OutputBlock{CWE: []string{"cwe-79"}}, `invalid CWE identifier: "cwe-79"`},
+		{"no evidence fields", OutputBlock{Evidence: &EvidenceBlock{}}, "evidence fields cannot be empty"},
+		{"bad evidence format", OutputBlock{Evidence: &EvidenceBlock{Fields: []string{"x"}, Format: "html"}}, `invalid evidence format: "html"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.output.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOutputBlock_AllReferences(t *testing.T) {
+	o := OutputBlock{Reference: "https://a.example", References: []string{"https://b.example", "https://a.example"}}
+	require.Equal(t, []string{"https://a.example", "https://b.example"}, o.AllReferences())
+	require.Nil(t, (&OutputBlock{}).AllReferences())
+	require.Equal(t, EvidenceText, (&OutputBlock{}).EvidenceFormat())
+}
+
+func TestEvaluator_Evaluate_Evidence(t *testing.T) {
+	p := &YAMLPlugin{
+		Name:  "Old OpenSSH",
+		Match: &MatchBlock{Logic: "AND", Rules: []MatchRule{{Field: "ssh.version", Operator: "version_lt", Value: "8.0"}}},
+		Output: OutputBlock{
+			Vulnerability: true,
+			Message:       "Old OpenSSH",
+			Evidence:      &EvidenceBlock{Fields: []string{"ssh.banner", "ssh.version", "ssh.kex"}, Format: EvidenceCode},
+		},
+	}
+	result, err := NewEvaluator().Evaluate(p, map[string]any{
+		"ssh.banner":  "SSH-2.0-OpenSSH_7.4",
+		"ssh.version": "7.4",
+	})
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	// Missing fields are left out
+	require.Equal(t, map[string]string{"ssh.banner": "SSH-2.0-OpenSSH_7.4", "ssh.version": "7.4"}, result.Evidence)
+
+	// Long values are truncated
+	p.Output.Evidence.Fields = []string{"ssh.banner"}
+	result, err = NewEvaluator().Evaluate(p, map[string]any{"ssh.banner": strings.Repeat("x", 5000), "ssh.version": "7.4"})
+	require.NoError(t, err)
+	require.Len(t, result.Evidence["ssh.banner"], maxEvidenceLength+3)
+}
+
+func TestYAMLPlugin_Validate_Output(t *testing.T) {
+	p := &YAMLPlugin{
+		ID: "x", Name: "x", Version: "1.0.0", Type: EvaluationType, Author: "a",
+		Metadata: PluginMetadata{Severity: InfoSeverity},
+		Output:   OutputBlock{Message: "m", CWE: []string{"79"}},
+	}
+	require.EqualError(t, p.Validate(), `output validation failed: invalid CWE identifier: "79" (must be like CWE-287)`)
+}
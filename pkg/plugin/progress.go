@@ -0,0 +1,206 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ProgressReporter receives the progress of Install and Update, one event
+// per plugin state change and per chunk of downloaded data.
+//
+// Events are delivered synchronously from the goroutine running the
+// operation; implementations should return quickly.
+type ProgressReporter interface {
+	OnProgress(ProgressEvent)
+}
+
+// ProgressFunc adapts a function to ProgressReporter.
+type ProgressFunc func(ProgressEvent)
+
+// OnProgress calls f(event).
+func (f ProgressFunc) OnProgress(event ProgressEvent) {
+	f(event)
+}
+
+// ProgressPhase identifies a plugin state change.
+type ProgressPhase string
+
+// Progress phases, in the order a plugin goes through them.
+const (
+	ProgressStarted     ProgressPhase = "started"     // Plugin processing began
+	ProgressDownloading ProgressPhase = "downloading" // Plugin data received
+	ProgressInstalled   ProgressPhase = "installed"   // Plugin installed or updated
+	ProgressSkipped     ProgressPhase = "skipped"     // Plugin already cached
+	ProgressFailed      ProgressPhase = "failed"      // Plugin failed, see Err
+)
+
+// ProgressEvent reports the progress of one plugin and of the whole batch.
+type ProgressEvent struct {
+	Phase    ProgressPhase
+	PluginID string
+	Version  string
+
+	// Position of the plugin in the batch (1-based) and the batch size
+	Index int
+	Total int
+
+	// Bytes of the plugin downloaded so far, and its size from the
+	// manifest (0 if unknown)
+	Bytes int64
+	Size  int64
+
+	// Bytes downloaded in the batch so far, and the bytes the batch is
+	// expected to download (0 if unknown). The expectation drops as
+	// plugins are skipped or fail.
+	TotalBytes    int64
+	ExpectedBytes int64
+
+	// Completed counts the plugins installed, skipped or failed so far
+	Completed int
+
+	// Elapsed is the time since the batch started
+	Elapsed time.Duration
+
+	// Err is set for ProgressFailed
+	Err error
+}
+
+// Speed returns the average download speed of the batch in bytes per
+// second, or 0 before any data arrived.
+func (e ProgressEvent) Speed() float64 {
+	if e.TotalBytes == 0 || e.Elapsed <= 0 {
+		return 0
+	}
+	return float64(e.TotalBytes) / e.Elapsed.Seconds()
+}
+
+// ETA estimates the time left for the batch from its average speed, or
+// returns 0 when unknown.
+func (e ProgressEvent) ETA() time.Duration {
+	speed := e.Speed()
+	remaining := e.ExpectedBytes - e.TotalBytes
+	if speed == 0 || remaining <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / speed * float64(time.Second))
+}
+
+// progressTracker turns the steps of a batch into progress events. A nil
+// tracker reports nothing.
+type progressTracker struct {
+	reporter ProgressReporter
+	start    time.Time
+	event    ProgressEvent
+	// Bytes downloaded by plugins that are done
+	doneBytes int64
+}
+
+// newProgressTracker returns a tracker for plugins, or nil without a
+// reporter.
+func newProgressTracker(reporter ProgressReporter, plugins []PluginManifestEntry) *progressTracker {
+	if reporter == nil {
+		return nil
+	}
+	t := &progressTracker{reporter: reporter, start: time.Now()}
+	t.event.Total = len(plugins)
+	for _, p := range plugins {
+		t.event.ExpectedBytes += p.Size
+	}
+	return t
+}
+
+// started reports that processing of p began and returns ctx carrying a
+// counter for the bytes of its download.
+func (t *progressTracker) started(ctx context.Context, p PluginManifestEntry) context.Context {
+	if t == nil {
+		return ctx
+	}
+	t.event.Index++
+	t.event.PluginID = p.ID
+	t.event.Version = p.Version
+	t.event.Size = p.Size
+	t.event.Bytes = 0
+	t.event.Err = nil
+	t.emit(ProgressStarted)
+	return context.WithValue(ctx, downloadProgressKey{}, t.downloaded)
+}
+
+// downloaded records that n bytes of the current download arrived. A
+// retried download starts over from zero.
+func (t *progressTracker) downloaded(n int64) {
+	t.event.Bytes = n
+	t.event.TotalBytes = t.doneBytes + n
+	t.emit(ProgressDownloading)
+}
+
+// finished reports the outcome of the current plugin: installed for a nil
+// err, skipped for ErrPluginAlreadyInstalled and failed otherwise.
+func (t *progressTracker) finished(err error) {
+	if t == nil {
+		return
+	}
+	phase := ProgressInstalled
+	switch {
+	case err == ErrPluginAlreadyInstalled:
+		phase = ProgressSkipped
+	case err != nil:
+		phase = ProgressFailed
+		t.event.Err = err
+	}
+	// Only installed plugins download their full size
+	if phase != ProgressInstalled && t.event.Size > t.event.Bytes {
+		t.event.ExpectedBytes -= t.event.Size - t.event.Bytes
+	}
+	t.doneBytes += t.event.Bytes
+	t.event.TotalBytes = t.doneBytes
+	t.event.Completed++
+	t.emit(phase)
+}
+
+func (t *progressTracker) emit(phase ProgressPhase) {
+	t.event.Phase = phase
+	t.event.Elapsed = time.Since(t.start)
+	t.reporter.OnProgress(t.event)
+}
+
+// downloadProgressKey carries the func(int64) receiving the bytes of a
+// download received so far.
+type downloadProgressKey struct{}
+
+// progressReader calls report with the number of bytes read so far.
+type progressReader struct {
+	r      io.Reader
+	n      int64
+	report func(int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.n += int64(n)
+		r.report(r.n)
+	}
+	return n, err
+}
+
+// reportDownloaded reports n bytes received to the counter in ctx, if any.
+func reportDownloaded(ctx context.Context, n int64) {
+	if report, ok := ctx.Value(downloadProgressKey{}).(func(int64)); ok {
+		report(n)
+	}
+}
+
+// withDownloadProgress wraps body to report its progress to the counter
+// in ctx, if any.
+func withDownloadProgress(ctx context.Context, body io.Reader) io.Reader {
+	report, ok := ctx.Value(downloadProgressKey{}).(func(int64))
+	if !ok {
+		return body
+	}
+	return &progressReader{r: body, report: report}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressEvent_SpeedAndETA(t *testing.T) {
+	event := ProgressEvent{TotalBytes: 1000, ExpectedBytes: 3000, Elapsed: 2 * time.Second}
+	require.InDelta(t, 500.0, event.Speed(), 0.001)
+	require.Equal(t, 4*time.Second, event.ETA())
+
+	require.Zero(t, ProgressEvent{ExpectedBytes: 3000, Elapsed: time.Second}.Speed(), "no data yet")
+	require.Zero(t, ProgressEvent{ExpectedBytes: 3000, Elapsed: time.Second}.ETA())
+	require.Zero(t, ProgressEvent{TotalBytes: 1000, Elapsed: time.Second}.ETA(), "unknown sizes")
+}
+
+func TestProgressTracker(t *testing.T) {
+	var events []ProgressEvent
+	tracker := newProgressTracker(ProgressFunc(func(e ProgressEvent) {
+		events = append(events, e)
+	}), []PluginManifestEntry{
+		{ID: "a", Version: "1.0.0", Size: 100},
+		{ID: "b", Version: "1.0.0", Size: 200},
+		{ID: "c", Version: "1.0.0", Size: 300},
+	})
+
+	ctx := tracker.started(context.Background(), PluginManifestEntry{ID: "a", Version: "1.0.0", Size: 100})
+	reportDownloaded(ctx, 40)
+	reportDownloaded(ctx, 100)
+	tracker.finished(nil)
+
+	ctx = tracker.started(context.Background(), PluginManifestEntry{ID: "b", Version: "1.0.0", Size: 200})
+	reportDownloaded(ctx, 50)
+	tracker.finished(errors.New("connection reset"))
+
+	tracker.started(context.Background(), PluginManifestEntry{ID: "c", Version: "1.0.0", Size: 300})
+	tracker.finished(ErrPluginAlreadyInstalled)
+
+	phases := make([]ProgressPhase, 0, len(events))
+	for _, e := range events {
+		phases = append(phases, e.Phase)
+	}
+	require.Equal(t, []ProgressPhase{
+		ProgressStarted, ProgressDownloading, ProgressDownloading, ProgressInstalled,
+		ProgressStarted, ProgressDownloading, ProgressFailed,
+		ProgressStarted, ProgressSkipped,
+	}, phases)
+
+	require.Equal(t, 1, events[0].Index)
+	require.Equal(t, 3, events[0].Total)
+	require.Equal(t, int64(600), events[0].ExpectedBytes)
+
+	require.Equal(t, int64(40), events[1].Bytes)
+	require.Equal(t, int64(100), events[3].TotalBytes)
+	require.Equal(t, 1, events[3].Completed)
+
+	failed := events[6]
+	require.Equal(t, "b", failed.PluginID)
+	require.EqualError(t, failed.Err, "connection reset")
+	require.Equal(t, int64(150), failed.TotalBytes)
+	require.Equal(t, int64(450), failed.ExpectedBytes, "undownloaded bytes of a failed plugin are not expected")
+
+	skipped := events[8]
+	require.Equal(t, 3, skipped.Completed)
+	require.NoError(t, skipped.Err)
+	require.Equal(t, int64(150), skipped.ExpectedBytes)
+}
+
+func TestProgressTracker_NilReporter(t *testing.T) {
+	tracker := newProgressTracker(nil, []PluginManifestEntry{{ID: "a"}})
+	require.Nil(t, tracker)
+
+	ctx := context.Background()
+	require.Equal(t, ctx, tracker.started(ctx, PluginManifestEntry{ID: "a"}))
+	tracker.finished(nil)
+}
+
+func TestWithDownloadProgress(t *testing.T) {
+	body := strings.NewReader("plugin data")
+	require.Same(t, body, withDownloadProgress(context.Background(), body), "no counter in context")
+
+	var reported []int64
+	ctx := context.WithValue(context.Background(), downloadProgressKey{}, func(n int64) {
+		reported = append(reported, n)
+	})
+	data, err := io.ReadAll(withDownloadProgress(ctx, body))
+	require.NoError(t, err)
+	require.Equal(t, "plugin data", string(data))
+	require.NotEmpty(t, reported)
+	require.Equal(t, int64(len(data)), reported[len(reported)-1])
+}
+
+func TestService_Install_Progress(t *testing.T) {
+	downloader := newDownloader(
+		func(ctx context.Context, src PluginSource) (*PluginManifest, error) {
+			return &PluginManifest{
+				Plugins: []PluginManifestEntry{
+					{ID: "ssh-weak-kex", Name: "SSH Weak KEX", Version: "1.0.0", Size: 2048, Categories: []Category{CategorySSH}},
+					{ID: "ssh-root-login", Name: "SSH Root Login", Version: "1.0.0", Size: 1024, Categories: []Category{CategorySSH}},
+				},
+			}, nil
+		},
+		func(ctx context.Context, id, version string) (*CacheEntry, error) {
+			reportDownloaded(ctx, 1024)
+			return &CacheEntry{}, nil
+		},
+	)
+	cache := newCache(func(m *mockCacheManager) {
+		m.getEntryFunc = func(ctx context.Context, name, version string) (*CacheEntry, error) {
+			return nil, ErrPluginNotInstalled
+		}
+	})
+	svc := newTestService(cache, &mockManifestManager{}, downloader, []PluginSource{
+		{Name: "official", URL: "https://example.com/manifest.yaml", Enabled: true},
+	})
+
+	var events []ProgressEvent
+	result, err := svc.Install(context.Background(), "ssh", InstallOptions{
+		Progress: ProgressFunc(func(e ProgressEvent) { events = append(events, e) }),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.InstalledCount)
+
+	require.Len(t, events, 6)
+	last := events[len(events)-1]
+	require.Equal(t, ProgressInstalled, last.Phase)
+	require.Equal(t, 2, last.Completed)
+	require.Equal(t, 2, last.Total)
+	require.Equal(t, int64(2048), last.TotalBytes)
+	require.Equal(t, int64(3072), last.ExpectedBytes)
+}
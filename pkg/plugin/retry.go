@@ -41,11 +41,10 @@ package plugin
 //
 // Integration with Service:
 //
-// The Service layer exposes WithRetry() to configure retry behavior for all plugin operations:
+// The Service layer exposes WithRetryPolicy() to configure retries and the
+// circuit breaker (see CircuitBreakerConfig) for all plugin operations:
 //
-//	svc := plugin.NewService(cacheDir).WithRetry(customConfig)
-//
-// This recreates the internal downloader with the new retry configuration.
+//	svc, err := plugin.NewService(plugin.WithRetryPolicy(cfg.Plugins.Retry))
 
 import (
 	"context"
@@ -162,11 +161,12 @@ type RetryFunc func(ctx context.Context) error
 //
 // Retryable errors:
 //   - Network connectivity errors (connection refused, timeout, DNS failure)
-//   - Temporary HTTP errors (502 Bad Gateway, 503 Service Unavailable, 504 Gateway Timeout)
+//   - Temporary HTTP errors (429 Too Many Requests, 500 Internal Server Error,
+//     502 Bad Gateway, 503 Service Unavailable, 504 Gateway Timeout)
 //
 // Non-retryable errors:
 //   - Client errors (400, 401, 403, 404)
-//   - Permanent server errors (500, 501)
+//   - Permanent server errors (501, 505)
 //   - Context cancellation
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -203,14 +203,14 @@ func isRetryableError(err error) bool {
 	}
 
 	// Check for HTTP status code errors
-	// Only retry 502, 503, 504 (temporary server errors)
-	if strings.Contains(errMsg, "unexpected status code: 502") ||
-		strings.Contains(errMsg, "unexpected status code: 503") ||
-		strings.Contains(errMsg, "unexpected status code: 504") {
-		return true
+	// Only retry rate limiting and temporary server errors
+	for _, code := range []string{"429", "500", "502", "503", "504"} {
+		if strings.Contains(errMsg, "unexpected status code: "+code) {
+			return true
+		}
 	}
 
-	// Don't retry other HTTP status codes (400, 401, 403, 404, 500, etc.)
+	// Don't retry other HTTP status codes (400, 401, 403, 404, 501, etc.)
 	if strings.Contains(errMsg, "unexpected status code:") {
 		return false
 	}
@@ -7,7 +7,9 @@ package plugin
 import (
 	"context"
 	"fmt"
+	"io"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"os"
 
+	"github.com/vulntor/vulntor/pkg/netproxy"
 	"github.com/vulntor/vulntor/pkg/output"
 	"github.com/vulntor/vulntor/pkg/paths"
 	"github.com/vulntor/vulntor/pkg/storage"
@@ -35,6 +38,8 @@ type CacheInterface interface {
 	Size(ctx context.Context) (int64, error)
 	Prune(ctx context.Context, olderThan time.Duration) (int, error)
 	Remove(ctx context.Context, id, version string) error
+	Evict(ctx context.Context) (int, error)
+	Stats(ctx context.Context) (*CacheStats, error)
 }
 
 // ManifestInterface defines the manifest operations needed by Service
@@ -80,12 +85,22 @@ type Service struct {
 	// Plugin sources (default or custom)
 	sources []PluginSource
 
+	// offline disables network fetches (bundle sources only)
+	offline bool
+
 	// Configuration (timeouts, limits)
 	config ServiceConfig
 
 	// Optional dependencies (injected via fluent API)
 	storage storage.Backend
 	logger  zerolog.Logger
+
+	// installed is refreshed with the installed plugins on manifest changes,
+	// read from the plugin files below cacheDir
+	installed *InstalledSet
+	cacheDir  string
+	// wordlistDir holds the workspace wordlists plugins may reference
+	wordlistDir string
 }
 
 // NewService creates a new plugin service using functional options pattern.
@@ -125,7 +140,7 @@ func NewService(opts ...ServiceOption) (*Service, error) {
 		logger:   nil, // Will use default logger if nil
 		config:   nil, // Will use DefaultConfig() if nil
 		storage:  nil,
-		sources:  nil, // Will use defaultSources() if nil
+		sources:  nil, // Will use DefaultSources() if nil
 	}
 
 	for _, opt := range opts {
@@ -148,48 +163,138 @@ func NewService(opts ...ServiceOption) (*Service, error) {
 	}
 
 	if config.sources == nil {
-		config.sources = defaultSources()
+		config.sources = DefaultSources()
 	}
 
 	// Create cache manager
-	cache, err := NewCacheManager(config.cacheDir)
+	cache, err := NewCacheManager(config.cacheDir,
+		WithCacheSizeLimit(config.maxCache),
+		WithCacheWordlistDir(config.wordlist),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("create cache manager: %w", err)
 	}
 
 	// Create manifest manager (registry.json in parent directory of cache)
 	manifestPath := filepath.Join(filepath.Dir(config.cacheDir), "registry.json")
-	manifest, err := NewManifestManager(manifestPath)
+	manifest, err := NewManifestManager(manifestPath, WithRecoveryDir(config.cacheDir))
 	if err != nil {
 		return nil, fmt.Errorf("create manifest manager: %w", err)
 	}
 
+	// Proxy for downloads, overridable per source
+	var proxy *netproxy.Proxy
+	if config.proxy != nil {
+		if proxy, err = config.proxy.Proxy(); err != nil {
+			return nil, fmt.Errorf("create proxy: %w", err)
+		}
+		config.sources = ApplySourceProxies(config.sources, config.proxy.Sources)
+	}
+
+	// Manifests of sources with keys must be signed
+	config.sources = ApplySourceKeys(config.sources, config.keys)
+
+	// Bundles come first; offline mode keeps nothing else
+	sources, err := withBundleSources(config.sources, config.bundles, config.offline)
+	if err != nil {
+		return nil, err
+	}
+	config.sources = sources
+
+	// Trust levels apply to bundles too
+	levels := make(map[string]TrustLevel, len(config.trust))
+	for name, value := range config.trust {
+		level, err := ParseTrustLevel(value)
+		if err != nil {
+			return nil, fmt.Errorf("source %s: %w", name, err)
+		}
+		levels[name] = level
+	}
+	config.sources = ApplySourceTrust(config.sources, levels)
+
+	// Retries and circuit breaker for downloads
+	retry, breaker, err := retryPolicy(config.retry)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create service with configured options
 	svc := &Service{
 		cache:    cache,
 		manifest: manifest,
 		sources:  config.sources,
+		offline:  config.offline,
 		config:   *config.config,
 		logger:   *config.logger,
 		storage:  config.storage,
+
+		installed:   config.loaded,
+		cacheDir:    config.cacheDir,
+		wordlistDir: config.wordlist,
 	}
 
 	// Create downloader with configured sources
-	svc.downloader = NewDownloader(cache, WithSources(svc.sources))
+	svc.downloader = NewDownloader(cache,
+		WithSources(svc.sources),
+		WithProxy(proxy),
+		WithOffline(config.offline),
+		WithRetryConfig(retry),
+		WithCircuitBreaker(breaker),
+		WithLimiter(config.limiter),
+	)
 
 	return svc, nil
 }
 
-// defaultSources returns the default plugin sources.
+// ApplySourceProxies returns a copy of sources whose Proxy is set from
+// overrides, keyed by source name. Sources with their own proxy keep it.
+func ApplySourceProxies(sources []PluginSource, overrides map[string]string) []PluginSource {
+	result := make([]PluginSource, len(sources))
+	copy(result, sources)
+	for i := range result {
+		if override, ok := overrides[result[i].Name]; ok && result[i].Proxy == "" {
+			result[i].Proxy = override
+		}
+	}
+	return result
+}
+
+// withBundleSources prepends a source for each bundle file to sources. In
+// offline mode, sources that need network access are dropped.
+func withBundleSources(sources []PluginSource, bundles []string, offline bool) ([]PluginSource, error) {
+	result := make([]PluginSource, 0, len(bundles)+len(sources))
+	for i, file := range bundles {
+		name := "bundle"
+		if i > 0 {
+			name = fmt.Sprintf("bundle-%d", i+1)
+		}
+		src, err := BundleSource(name, file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+		}
+		src.Priority = i + 1
+		result = append(result, src)
+	}
+	for _, src := range sources {
+		if offline && !IsBundleSource(src) {
+			continue
+		}
+		result = append(result, src)
+	}
+	return result, nil
+}
+
+// DefaultSources returns the default plugin sources.
 //
 // By default, we use the official Vulntor plugin repository with a GitHub mirror.
-func defaultSources() []PluginSource {
+func DefaultSources() []PluginSource {
 	return []PluginSource{
 		{
 			Name:     "official",
 			URL:      "https://plugins.pentora.ai/manifest.yaml",
 			Enabled:  true,
 			Priority: 1,
+			Trust:    TrustOfficial,
 			Mirrors: []string{
 				"https://raw.githubusercontent.com/pentora-ai/pentora-plugins/main/manifest.yaml",
 			},
@@ -200,7 +305,8 @@ func defaultSources() []PluginSource {
 // Install installs plugins by category or plugin ID.
 //
 // The target parameter can be:
-//   - A category name (e.g., "ssh", "http", "tls")
+//   - A category name (e.g., "ssh", "http", "tls"), including its subcategories
+//   - A subcategory or category pattern (e.g., "database/mysql", "database/*")
 //   - A specific plugin ID (e.g., "ssh-default-credentials")
 //
 // The method:
@@ -309,11 +415,11 @@ func (s *Service) Install(ctx context.Context, target string, opts InstallOption
 	// Determine if target is category or plugin ID
 	var toInstall []PluginManifestEntry
 
-	if opts.Category != "" && opts.Category.IsValid() {
+	if opts.Category != "" && opts.Category.IsSelector() {
 		// Use category from options if specified
 		toInstall = s.filterByCategory(allPlugins, opts.Category)
-	} else if category := Category(target); category.IsValid() {
-		// Target is a category
+	} else if category := Category(target); category.IsSelector() {
+		// Target is a category or category pattern (e.g., "database/*")
 		toInstall = s.filterByCategory(allPlugins, category)
 	} else {
 		// Target is a plugin ID
@@ -342,6 +448,7 @@ func (s *Service) Install(ctx context.Context, target string, opts InstallOption
 	out, _ := ctx.Value(output.OutputKey).(output.Output)
 
 	// Install each plugin
+	progress := newProgressTracker(opts.Progress, toInstall)
 	for _, p := range toInstall {
 		// Check context cancellation
 		select {
@@ -355,7 +462,9 @@ func (s *Service) Install(ctx context.Context, target string, opts InstallOption
 			out.Diag(output.LevelVerbose, fmt.Sprintf("Installing %s v%s...", p.Name, p.Version), nil)
 		}
 
-		if err := s.installOne(ctx, p, opts); err != nil {
+		err := s.installOne(progress.started(ctx, p), p, opts)
+		progress.finished(err)
+		if err != nil {
 			// Check if plugin was already installed (not an error)
 			if err == ErrPluginAlreadyInstalled {
 				result.SkippedCount++
@@ -444,6 +553,9 @@ func (s *Service) fetchPlugins(ctx context.Context, sourceName string) ([]Plugin
 		}
 		sources = filteredSources
 	}
+	if s.offline && len(sources) == 0 {
+		return nil, fmt.Errorf("%w: no plugin bundle to install from", ErrOffline)
+	}
 
 	// Fetch from each enabled source
 	for _, src := range sources {
@@ -460,19 +572,24 @@ func (s *Service) fetchPlugins(ctx context.Context, sourceName string) ([]Plugin
 			continue
 		}
 
-		allPlugins = append(allPlugins, manifest.Plugins...)
+		trust := src.TrustLevel()
+		for _, p := range manifest.Plugins {
+			p.Source = src.Name
+			p.Trust = trust
+			allPlugins = append(allPlugins, p)
+		}
 	}
 
 	return allPlugins, nil
 }
 
-// filterByCategory filters plugins by category.
+// filterByCategory filters plugins by category, including subcategories.
 func (s *Service) filterByCategory(plugins []PluginManifestEntry, category Category) []PluginManifestEntry {
 	var filtered []PluginManifestEntry
 
 	for _, p := range plugins {
 		for _, cat := range p.Categories {
-			if cat == category {
+			if category.Contains(cat) {
 				filtered = append(filtered, p)
 				break
 			}
@@ -508,6 +625,16 @@ func (s *Service) installOne(ctx context.Context, p PluginManifestEntry, opts In
 		}
 	}
 
+	// Plugins for a newer Vulntor would fail to load
+	if err := checkVulntorVersion(p.ID, p.MinVulntorVersion); err != nil {
+		return err
+	}
+
+	// Untrusted sources need explicit consent
+	if err := checkSourceTrust(p, opts.AllowUntrusted); err != nil {
+		return err
+	}
+
 	// Return early if dry run
 	if opts.DryRun {
 		s.logger.Info().
@@ -541,6 +668,11 @@ func (s *Service) installOne(ctx context.Context, p PluginManifestEntry, opts In
 		Path:        filepath.Join(p.ID, p.Version, "plugin.yaml"),
 		Tags:        categoryTags,
 		Severity:    "medium", // Default severity (overridden when plugin loads)
+
+		MinVulntorVersion: p.MinVulntorVersion,
+
+		Source: p.Source,
+		Trust:  string(p.Trust),
 	}
 
 	// Add to manifest (failure contributes to partial failure semantics)
@@ -578,6 +710,8 @@ func pluginInfoFromManifestEntry(entry *PluginManifestEntry) *PluginInfo {
 		Checksum:    entry.Checksum,
 		DownloadURL: entry.URL,
 		Tags:        tags,
+		Source:      entry.Source,
+		Trust:       entry.Trust,
 		InstalledAt: time.Now(),
 	}
 }
@@ -685,7 +819,7 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 
 	// Filter by category if specified
 	var toUpdate []PluginManifestEntry
-	if opts.Category != "" && opts.Category.IsValid() {
+	if opts.Category != "" && opts.Category.IsSelector() {
 		toUpdate = s.filterByCategory(allPlugins, opts.Category)
 	} else {
 		toUpdate = allPlugins
@@ -712,16 +846,19 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 	out, _ := ctx.Value(output.OutputKey).(output.Output)
 
 	// Update each plugin
+	progress := newProgressTracker(opts.Progress, toUpdate)
 	for _, p := range toUpdate {
 		select {
 		case <-ctx.Done():
 			return result, ctx.Err()
 		default:
 		}
+		pluginCtx := progress.started(ctx, p)
 
 		// Check if already cached (unless force)
 		if !opts.Force {
 			if _, err := s.cache.GetEntry(ctx, p.Name, p.Version); err == nil {
+				progress.finished(ErrPluginAlreadyInstalled)
 				result.SkippedCount++
 				s.logger.Debug().
 					Str("plugin", p.Name).
@@ -736,8 +873,31 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 			}
 		}
 
+		// Plugins for a newer Vulntor, or from an untrusted source without
+		// consent, are not downloaded
+		err := checkVulntorVersion(p.ID, p.MinVulntorVersion)
+		if err == nil {
+			err = checkSourceTrust(p, opts.AllowUntrusted)
+		}
+		if err != nil {
+			progress.finished(err)
+			result.FailedCount++
+			result.Errors = append(result.Errors, PluginError{
+				PluginID:   p.ID,
+				Error:      err.Error(),
+				Code:       ErrorCode(err),
+				Suggestion: GetSuggestion(err),
+			})
+			s.logger.Warn().
+				Str("plugin", p.Name).
+				Err(err).
+				Msg("Skipping plugin")
+			continue
+		}
+
 		// Dry run mode
 		if opts.DryRun {
+			progress.finished(nil)
 			result.UpdatedCount++
 			result.Plugins = append(result.Plugins, pluginInfoFromManifestEntry(&p))
 			s.logger.Info().
@@ -758,8 +918,8 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 		}
 
 		// Download plugin
-		_, err := s.downloader.Download(ctx, p.ID, p.Version)
-		if err != nil {
+		if _, err := s.downloader.Download(pluginCtx, p.ID, p.Version); err != nil {
+			progress.finished(err)
 			result.FailedCount++
 			result.Errors = append(result.Errors, PluginError{
 				PluginID:   p.ID,
@@ -802,6 +962,11 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 			Path:        filepath.Join(p.ID, p.Version, "plugin.yaml"),
 			Tags:        categoryTags,
 			Severity:    "medium",
+
+			MinVulntorVersion: p.MinVulntorVersion,
+
+			Source: p.Source,
+			Trust:  string(p.Trust),
 		}
 
 		if err := s.manifest.Add(manifestEntry); err != nil {
@@ -809,6 +974,7 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 				Str("plugin", p.Name).
 				Err(err).
 				Msg("Failed to add to manifest")
+			progress.finished(err)
 			result.FailedCount++
 			result.Errors = append(result.Errors, PluginError{
 				PluginID:   p.ID,
@@ -821,6 +987,7 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 
 		if err := s.manifest.Save(); err != nil {
 			s.logger.Error().Err(err).Msg("Failed to save manifest")
+			progress.finished(err)
 			result.FailedCount++
 			result.Errors = append(result.Errors, PluginError{
 				PluginID:   p.ID,
@@ -831,6 +998,7 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 			continue
 		}
 
+		progress.finished(nil)
 		result.UpdatedCount++
 		result.Plugins = append(result.Plugins, pluginInfoFromManifestEntry(&p))
 		s.logger.Info().
@@ -872,7 +1040,8 @@ func (s *Service) Update(ctx context.Context, opts UpdateOptions) (*UpdateResult
 //
 // Parameters:
 //   - ctx: Context for cancellation
-//   - target: Plugin ID to uninstall (ignored if opts.All or opts.Category is set)
+//   - target: Plugin ID to uninstall, or a subcategory or category pattern
+//     (e.g., "database/*") to uninstall by category
 //   - opts: Uninstall options (All, Category)
 //
 // Returns:
@@ -949,9 +1118,16 @@ func (s *Service) Uninstall(ctx context.Context, target string, opts UninstallOp
 		Errors: []PluginError{},
 	}
 
+	// A target naming a subcategory or pattern (e.g., "database/*") cannot
+	// be a plugin ID, so it selects plugins by category
+	if cat := Category(target); opts.Category == "" && strings.Contains(target, "/") && cat.IsSelector() {
+		opts.Category = cat
+		target = ""
+	}
+
 	// Validate input - only one mode allowed
 	hasTarget := target != ""
-	hasCategory := opts.Category != "" && opts.Category.IsValid()
+	hasCategory := opts.Category != "" && opts.Category.IsSelector()
 	hasAll := opts.All
 
 	modesCount := 0
@@ -1156,14 +1332,13 @@ func (s *Service) uninstallOne(ctx context.Context, entry *ManifestEntry) error
 	return nil
 }
 
-// filterManifestByCategory filters manifest entries by category
+// filterManifestByCategory filters manifest entries by category, including subcategories
 func (s *Service) filterManifestByCategory(entries []*ManifestEntry, category Category) []*ManifestEntry {
 	var filtered []*ManifestEntry
-	categoryStr := string(category)
 
 	for _, entry := range entries {
 		for _, tag := range entry.Tags {
-			if tag == categoryStr {
+			if category.Contains(Category(tag)) {
 				filtered = append(filtered, entry)
 				break
 			}
@@ -1238,6 +1413,8 @@ func (s *Service) List(ctx context.Context) ([]*PluginInfo, error) {
 			Author:       entry.Author,
 			Severity:     entry.Severity,
 			Tags:         entry.Tags,
+			Source:       entry.Source,
+			Trust:        TrustLevel(entry.Trust),
 			Checksum:     entry.Checksum,
 			DownloadURL:  entry.DownloadURL,
 			InstalledAt:  entry.InstalledAt,
@@ -1367,6 +1544,8 @@ func (s *Service) GetInfo(ctx context.Context, pluginID string) (*PluginInfo, er
 		Author:       entry.Author,
 		Severity:     entry.Severity,
 		Tags:         entry.Tags,
+		Source:       entry.Source,
+		Trust:        TrustLevel(entry.Trust),
 		Checksum:     entry.Checksum,
 		DownloadURL:  entry.DownloadURL,
 		InstalledAt:  entry.InstalledAt,
@@ -1442,7 +1621,9 @@ func calculateDirSize(path string) (int64, error) {
 	return size, nil
 }
 
-// Clean removes old plugin cache entries based on age.
+// Clean removes old plugin cache entries based on age, then evicts the
+// least recently used entries while the cache exceeds its size limit (see
+// WithMaxCacheSize).
 //
 // Example:
 //
@@ -1527,6 +1708,13 @@ func (s *Service) Clean(ctx context.Context, opts CleanOptions) (*CleanResult, e
 		return nil, fmt.Errorf("prune cache: %w", err)
 	}
 
+	// Enforce the size limit, which may have been lowered since the last install
+	evicted, err := s.cache.Evict(ctx)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to evict cache entries over the size limit")
+	}
+	removed += evicted
+
 	// Calculate size after cleaning
 	sizeAfter, err := s.cache.Size(ctx)
 	if err != nil {
@@ -1556,6 +1744,36 @@ func (s *Service) Clean(ctx context.Context, opts CleanOptions) (*CleanResult, e
 	return result, nil
 }
 
+// CacheStats returns the disk usage of the plugin cache.
+//
+// Example:
+//
+//	stats, err := svc.CacheStats(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Printf("%d plugins, %d bytes\n", stats.Plugins, stats.Size)
+func (s *Service) CacheStats(ctx context.Context) (*CacheStats, error) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline && s.config.GetInfoTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.GetInfoTimeout)
+		defer cancel()
+	}
+
+	stats, err := s.cache.Stats(ctx)
+	if err != nil {
+		s.logger.Error().
+			Str("component", "plugin.service").
+			Str("op", "cache_stats").
+			Str("status", logStatusFail).
+			Str("error_code", ErrorCode(err)).
+			Err(err).
+			Msg("Failed to read cache stats")
+		return nil, fmt.Errorf("cache stats: %w", err)
+	}
+	return stats, nil
+}
+
 // Verify checks the integrity of installed plugins by verifying their checksums.
 //
 // Example:
@@ -1732,6 +1950,156 @@ func (s *Service) Verify(ctx context.Context, opts VerifyOptions) (*VerifyResult
 	return verifyResult, nil
 }
 
+// Bundle writes installed plugins and their manifest to w as a plugin
+// bundle, for installs without network access (see WithBundles).
+//
+// Targets select plugins by ID or category; no targets selects every
+// installed plugin. Cached files are verified against their recorded
+// checksums before they are packed.
+//
+// Example:
+//
+//	f, _ := os.Create("vulntor-plugins.tar.gz")
+//	result, err := svc.Bundle(ctx, f, plugin.BundleOptions{Targets: []string{"ssh"}})
+func (s *Service) Bundle(ctx context.Context, w io.Writer, opts BundleOptions) (*BundleResult, error) {
+	start := time.Now()
+
+	for _, target := range opts.Targets {
+		if err := validateTarget(target); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info().
+		Str("component", "plugin.service").
+		Str("op", "bundle").
+		Strs("targets", opts.Targets).
+		Msg("Creating plugin bundle")
+
+	installed, err := s.manifest.List()
+	if err != nil {
+		return nil, fmt.Errorf("list plugins: %w", err)
+	}
+	entries, err := selectBundleEntries(installed, opts.Targets)
+	if err != nil {
+		s.logger.Error().
+			Str("component", "plugin.service").
+			Str("op", "bundle").
+			Str("status", logStatusFail).
+			Str("error_code", ErrorCode(err)).
+			Err(err).
+			Msg("No plugins to bundle")
+		return nil, err
+	}
+
+	bundle := NewBundle()
+	result := &BundleResult{Plugins: make([]*PluginInfo, 0, len(entries))}
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		cached, err := s.cache.GetEntry(ctx, entry.ID, entry.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrPluginNotInstalled, entry.ID, err)
+		}
+		data, err := os.ReadFile(cached.Path)
+		if err != nil {
+			return nil, fmt.Errorf("read plugin %s: %w", entry.ID, err)
+		}
+
+		categories := make([]Category, 0, len(entry.Tags))
+		for _, tag := range entry.Tags {
+			if category := Category(tag); category.IsValid() {
+				categories = append(categories, category)
+			}
+		}
+		if err := bundle.Add(PluginManifestEntry{
+			ID:         entry.ID,
+			Name:       entry.Name,
+			Version:    entry.Version,
+			Author:     entry.Author,
+			Categories: categories,
+			Checksum:   entry.Checksum,
+
+			MinVulntorVersion: entry.MinVulntorVersion,
+		}, data); err != nil {
+			return nil, err
+		}
+
+		result.Plugins = append(result.Plugins, &PluginInfo{
+			ID:       entry.ID,
+			Name:     entry.Name,
+			Version:  entry.Version,
+			Type:     entry.Type,
+			Author:   entry.Author,
+			Severity: entry.Severity,
+			Tags:     entry.Tags,
+			Checksum: entry.Checksum,
+			Path:     cached.Path,
+		})
+	}
+
+	if err := bundle.Write(w); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info().
+		Str("component", "plugin.service").
+		Str("op", "bundle").
+		Str("status", logStatusSuccess).
+		Int("plugins", len(result.Plugins)).
+		Int("duration_ms", int(time.Since(start).Milliseconds())).
+		Msg("Plugin bundle created")
+
+	return result, nil
+}
+
+// selectBundleEntries returns the installed plugins matching targets (IDs
+// or categories), or all of them for no targets.
+func selectBundleEntries(installed []*ManifestEntry, targets []string) ([]*ManifestEntry, error) {
+	if len(installed) == 0 {
+		return nil, fmt.Errorf("%w: no plugins to bundle", ErrPluginNotInstalled)
+	}
+	if len(targets) == 0 {
+		return installed, nil
+	}
+
+	selected := make([]*ManifestEntry, 0, len(installed))
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		target = strings.ToLower(target)
+		matched := false
+		for _, entry := range installed {
+			if entry.ID != target && !slices.Contains(entry.Tags, target) && !hasCategoryTag(entry.Tags, Category(target)) {
+				continue
+			}
+			matched = true
+			if !seen[entry.ID] {
+				seen[entry.ID] = true
+				selected = append(selected, entry)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("%w: no installed plugin matches '%s'", ErrPluginNotInstalled, target)
+		}
+	}
+	return selected, nil
+}
+
+// hasCategoryTag reports whether any tag is a category within category.
+func hasCategoryTag(tags []string, category Category) bool {
+	if !category.IsSelector() {
+		return false
+	}
+	for _, tag := range tags {
+		if category.Contains(Category(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
 // StartManifestWatcher starts a file watcher that monitors the plugin manifest
 // for changes and automatically reloads it when updates are detected.
 //
@@ -1739,6 +2107,9 @@ func (s *Service) Verify(ctx context.Context, opts VerifyOptions) (*VerifyResult
 // API until restart (Issue #27). When a plugin is installed/uninstalled via
 // CLI, the manifest file (registry.json) is updated, and the watcher triggers
 // an automatic reload so the server API immediately reflects the change.
+// The installed set (see WithInstalledSet) is loaded when the watcher
+// starts and reloaded with the manifest, so new scans evaluate the new
+// plugins while running scans keep theirs.
 //
 // The watcher runs in a separate goroutine and can be stopped by canceling
 // the context. It includes debouncing (100ms) to avoid multiple reloads
@@ -1772,6 +2143,65 @@ func (s *Service) StartManifestWatcher(ctx context.Context) error {
 		return err
 	}
 
+	if s.installed != nil {
+		s.ReloadInstalled(ctx)
+		watcher.OnReload(func() { s.ReloadInstalled(ctx) })
+	}
+
 	// Start watcher in current goroutine (caller should run this in goroutine)
 	return watcher.Start(ctx)
 }
+
+// InstalledSet returns the set the service reloads with the installed
+// plugins (see WithInstalledSet), or nil.
+func (s *Service) InstalledSet() *InstalledSet {
+	return s.installed
+}
+
+// ReloadInstalled loads the plugins listed in the manifest from the cache
+// into the installed set (see WithInstalledSet). Plugins that fail to load
+// are logged and left out; a canceled ctx keeps the current set.
+func (s *Service) ReloadInstalled(ctx context.Context) {
+	if s.installed == nil {
+		return
+	}
+
+	entries, err := s.manifest.List()
+	if err != nil {
+		s.logger.Error().
+			Str("component", "plugin.service").
+			Str("op", "reload").
+			Err(err).
+			Msg("Failed to list installed plugins; keeping the current set")
+		return
+	}
+
+	// Files are read directly: plugins installed by another process (the
+	// CLI) are not in this process's cache registry
+	plugins := make([]*YAMLPlugin, 0, len(entries))
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+		path := entry.Path
+		if path == "" {
+			path = filepath.Join(entry.ID, entry.Version, "plugin.yaml")
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(s.cacheDir, path)
+		}
+		p, err := NewLoader(filepath.Dir(path), s.wordlistDir).Load(path)
+		if err != nil {
+			s.logger.Warn().Str("plugin", entry.ID).Err(err).Msg("Failed to load installed plugin")
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+
+	s.installed.Replace(plugins)
+	s.logger.Info().
+		Str("component", "plugin.service").
+		Str("op", "reload").
+		Int("plugins", len(plugins)).
+		Msg("Installed plugins reloaded")
+}
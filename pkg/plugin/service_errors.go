@@ -4,7 +4,10 @@
 
 package plugin
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Service layer errors
 // These are domain-specific errors that can be checked using errors.Is()
@@ -38,10 +41,37 @@ var (
 	// CLI exit code: 7, HTTP status: 503
 	ErrSourceNotAvailable = errors.New("plugin source not available")
 
+	// ErrOffline is returned when offline mode blocks a network fetch.
+	// It wraps ErrSourceNotAvailable.
+	// CLI exit code: 7, HTTP status: 503
+	ErrOffline = fmt.Errorf("%w: network access disabled in offline mode", ErrSourceNotAvailable)
+
+	// ErrCircuitOpen is returned when a source is skipped after repeated
+	// transient failures (see CircuitBreakerConfig). It wraps
+	// ErrSourceNotAvailable.
+	// CLI exit code: 7, HTTP status: 503
+	ErrCircuitOpen = fmt.Errorf("%w: too many consecutive failures", ErrSourceNotAvailable)
+
 	// ErrChecksumMismatch is returned when downloaded plugin checksum doesn't match
 	// CLI exit code: 1, HTTP status: 500
 	ErrChecksumMismatch = errors.New("checksum mismatch")
 
+	// ErrSignatureInvalid is returned when a manifest lacks a valid
+	// signature by a trusted key of its source. It wraps ErrChecksumMismatch.
+	// CLI exit code: 1, HTTP status: 500
+	ErrSignatureInvalid = fmt.Errorf("%w: manifest signature invalid", ErrChecksumMismatch)
+
+	// ErrIncompatibleVersion is returned when a plugin requires a newer
+	// Vulntor than the running one (vulntor_min_version). It wraps
+	// ErrConflict.
+	// CLI exit code: 1, HTTP status: 409
+	ErrIncompatibleVersion = fmt.Errorf("%w: plugin requires a newer Vulntor", ErrConflict)
+
+	// ErrUntrustedSource is returned when installing a plugin from an
+	// untrusted source without explicitly allowing it
+	// CLI exit code: 1, HTTP status: 403
+	ErrUntrustedSource = errors.New("plugin source not trusted")
+
 	// ErrInvalidInput is returned when input validation fails
 	// CLI exit code: 2, HTTP status: 400
 	ErrInvalidInput = errors.New("invalid input")
@@ -132,6 +162,7 @@ func ExitCode(err error) int {
 // Status code mapping (as defined in ADR-0001):
 //   - 200: Success (or partial failure with errors[] in response)
 //   - 400: Bad Request (invalid input)
+//   - 403: Forbidden (untrusted source)
 //   - 404: Not Found (plugin doesn't exist)
 //   - 409: Conflict (version conflict, already installed)
 //   - 500: Internal Server Error (default)
@@ -162,6 +193,10 @@ func HTTPStatus(err error) int {
 		errors.Is(err, ErrNoPluginsFound):
 		return 404
 
+	// Untrusted source → 403 Forbidden
+	case errors.Is(err, ErrUntrustedSource):
+		return 403
+
 	// Conflict → 409 Conflict
 	case errors.Is(err, ErrPluginAlreadyInstalled),
 		errors.Is(err, ErrConflict):
@@ -206,12 +241,22 @@ func GetSuggestion(err error) string {
 		return "valid categories: ssh, http, tls, database, network, misc"
 	case errors.Is(err, ErrInvalidPluginID):
 		return "use lowercase letters, numbers, and hyphens only"
+	case errors.Is(err, ErrOffline):
+		return "install from a plugin bundle: --offline --bundle <file>"
+	case errors.Is(err, ErrCircuitOpen):
+		return "the source is failing; retry later or with different source: --source github"
 	case errors.Is(err, ErrSourceNotAvailable), errors.Is(err, ErrUnavailable):
 		return "retry with different source: --source github"
+	case errors.Is(err, ErrSignatureInvalid):
+		return "the manifest may have been tampered with; check the source's public keys"
 	case errors.Is(err, ErrChecksumMismatch):
 		return "retry with --force to re-download"
 	case errors.Is(err, ErrPluginAlreadyInstalled):
 		return "use --force to reinstall"
+	case errors.Is(err, ErrUntrustedSource):
+		return "review the source, then install with --allow-untrusted"
+	case errors.Is(err, ErrIncompatibleVersion):
+		return "upgrade Vulntor to the version the plugin requires (see: vulntor version)"
 	case errors.Is(err, ErrConflict):
 		return "uninstall existing version and reinstall"
 	case errors.Is(err, ErrPartialFailure):
@@ -249,16 +294,26 @@ func ErrorCode(err error) string {
 		return "INVALID_CATEGORY"
 	case errors.Is(err, ErrInvalidPluginID):
 		return "INVALID_PLUGIN_ID"
+	case errors.Is(err, ErrOffline):
+		return "OFFLINE_MODE"
+	case errors.Is(err, ErrCircuitOpen):
+		return "SOURCE_CIRCUIT_OPEN"
 	case errors.Is(err, ErrSourceNotAvailable):
 		return "SOURCE_NOT_AVAILABLE"
 	case errors.Is(err, ErrUnavailable):
 		return "SERVICE_UNAVAILABLE"
 	case errors.Is(err, ErrPluginAlreadyInstalled):
 		return "PLUGIN_ALREADY_INSTALLED"
+	case errors.Is(err, ErrUntrustedSource):
+		return "SOURCE_UNTRUSTED"
+	case errors.Is(err, ErrIncompatibleVersion):
+		return "PLUGIN_INCOMPATIBLE"
 	case errors.Is(err, ErrConflict):
 		return "VERSION_CONFLICT"
 	case errors.Is(err, ErrPartialFailure):
 		return "PARTIAL_FAILURE"
+	case errors.Is(err, ErrSignatureInvalid):
+		return "SIGNATURE_INVALID"
 	case errors.Is(err, ErrChecksumMismatch):
 		return "CHECKSUM_MISMATCH"
 	default:
@@ -34,6 +34,16 @@ func TestExitCode(t *testing.T) {
 			err:      ErrUnavailable,
 			expected: 7,
 		},
+		{
+			name:     "ErrOffline returns 7",
+			err:      fmt.Errorf("fetch manifest: %w", ErrOffline),
+			expected: 7,
+		},
+		{
+			name:     "ErrCircuitOpen returns 7",
+			err:      fmt.Errorf("plugin not found in any source: %w", ErrCircuitOpen),
+			expected: 7,
+		},
 		{
 			name:     "ErrPartialFailure returns 8",
 			err:      ErrPartialFailure,
@@ -90,6 +100,11 @@ func TestHTTPStatus(t *testing.T) {
 			err:      ErrInvalidOption,
 			expected: 400,
 		},
+		{
+			name:     "ErrUntrustedSource returns 403",
+			err:      ErrUntrustedSource,
+			expected: 403,
+		},
 		{
 			name:     "ErrUnavailable returns 503",
 			err:      ErrUnavailable,
@@ -151,11 +166,26 @@ func TestErrorCode(t *testing.T) {
 			err:      ErrInvalidOption,
 			expected: "INVALID_INPUT",
 		},
+		{
+			name:     "ErrUntrustedSource returns SOURCE_UNTRUSTED",
+			err:      ErrUntrustedSource,
+			expected: "SOURCE_UNTRUSTED",
+		},
 		{
 			name:     "ErrUnavailable returns SERVICE_UNAVAILABLE",
 			err:      ErrUnavailable,
 			expected: "SERVICE_UNAVAILABLE",
 		},
+		{
+			name:     "ErrOffline returns OFFLINE_MODE",
+			err:      ErrOffline,
+			expected: "OFFLINE_MODE",
+		},
+		{
+			name:     "ErrCircuitOpen returns SOURCE_CIRCUIT_OPEN",
+			err:      ErrCircuitOpen,
+			expected: "SOURCE_CIRCUIT_OPEN",
+		},
 		{
 			name:     "ErrConflict returns VERSION_CONFLICT",
 			err:      ErrConflict,
@@ -202,6 +232,16 @@ func TestGetSuggestion(t *testing.T) {
 			err:      ErrPluginNotFound,
 			expected: "list available plugins with: vulntor plugin list",
 		},
+		{
+			name:     "ErrCircuitOpen suggests waiting",
+			err:      ErrCircuitOpen,
+			expected: "the source is failing; retry later or with different source: --source github",
+		},
+		{
+			name:     "ErrUntrustedSource suggests explicit consent",
+			err:      ErrUntrustedSource,
+			expected: "review the source, then install with --allow-untrusted",
+		},
 		{
 			name:     "ErrPluginNotInstalled suggests installing",
 			err:      ErrPluginNotInstalled,
@@ -227,6 +267,11 @@ func TestGetSuggestion(t *testing.T) {
 			err:      ErrSourceNotAvailable,
 			expected: "retry with different source: --source github",
 		},
+		{
+			name:     "ErrOffline suggests installing from a bundle",
+			err:      ErrOffline,
+			expected: "install from a plugin bundle: --offline --bundle <file>",
+		},
 		{
 			name:     "ErrUnavailable suggests retry with different source",
 			err:      ErrUnavailable,
@@ -75,7 +75,7 @@ func TestNewService(t *testing.T) {
 
 func TestDefaultSources(t *testing.T) {
 	t.Run("returns official source", func(t *testing.T) {
-		sources := defaultSources()
+		sources := DefaultSources()
 
 		require.Len(t, sources, 1)
 		require.Equal(t, "official", sources[0].Name)
@@ -85,7 +85,7 @@ func TestDefaultSources(t *testing.T) {
 	})
 
 	t.Run("returns enabled sources", func(t *testing.T) {
-		sources := defaultSources()
+		sources := DefaultSources()
 
 		for _, source := range sources {
 			require.True(t, source.Enabled, "default sources should be enabled")
@@ -225,6 +225,8 @@ type mockCacheManager struct {
 	putFunc      func(ctx context.Context, entry CacheEntry) error
 	listFunc     func(ctx context.Context) ([]CacheEntry, error)
 	deleteFunc   func(ctx context.Context, name, version string) error
+	evictFunc    func(ctx context.Context) (int, error)
+	statsFunc    func(ctx context.Context) (*CacheStats, error)
 }
 
 func (m *mockCacheManager) GetEntry(ctx context.Context, name, version string) (*CacheEntry, error) {
@@ -248,6 +250,20 @@ func (m *mockCacheManager) Prune(ctx context.Context, olderThan time.Duration) (
 	return 0, nil
 }
 
+func (m *mockCacheManager) Evict(ctx context.Context) (int, error) {
+	if m.evictFunc != nil {
+		return m.evictFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *mockCacheManager) Stats(ctx context.Context) (*CacheStats, error) {
+	if m.statsFunc != nil {
+		return m.statsFunc(ctx)
+	}
+	return &CacheStats{}, nil
+}
+
 func (m *mockCacheManager) Remove(ctx context.Context, id, version string) error {
 	if m.removeFunc != nil {
 		return m.removeFunc(ctx, id, version)
@@ -399,6 +415,38 @@ func TestService_Install_ByPluginID(t *testing.T) {
 		require.Nil(t, result)
 		require.ErrorIs(t, err, ErrNoPluginsFound, "should return ErrNoPluginsFound when manifest is empty")
 	})
+
+	t.Run("plugin requiring a newer Vulntor", func(t *testing.T) {
+		ctx := context.Background()
+		setVulntorVersion(t, "v0.3.0")
+
+		dl := newDownloader(func(ctx context.Context, src PluginSource) (*PluginManifest, error) {
+			return &PluginManifest{
+				Plugins: []PluginManifestEntry{
+					{ID: "test-plugin", Name: "Test Plugin", Version: "1.0.0", MinVulntorVersion: "0.4.0"},
+				},
+			}, nil
+		}, func(ctx context.Context, id, version string) (*CacheEntry, error) {
+			t.Fatal("incompatible plugin must not be downloaded")
+			return nil, nil
+		})
+		cache := newCache(func(m *mockCacheManager) {
+			m.getEntryFunc = func(ctx context.Context, name, version string) (*CacheEntry, error) {
+				return nil, ErrPluginNotInstalled
+			}
+		})
+
+		svc := newTestService(cache, &mockManifestManager{}, dl, []PluginSource{
+			{Name: "official", URL: "https://example.com/manifest.yaml", Enabled: true},
+		})
+
+		result, err := svc.Install(ctx, "test-plugin", InstallOptions{})
+		require.ErrorIs(t, err, ErrPartialFailure)
+		require.Equal(t, 1, result.FailedCount)
+		require.Equal(t, "PLUGIN_INCOMPATIBLE", result.Errors[0].Code)
+		require.Contains(t, result.Errors[0].Error, "test-plugin requires Vulntor >= 0.4.0 (current: v0.3.0)")
+		require.Contains(t, result.Errors[0].Suggestion, "upgrade Vulntor")
+	})
 }
 
 func TestService_Install_ByCategory(t *testing.T) {
@@ -462,6 +510,41 @@ func TestService_Install_ByCategory(t *testing.T) {
 		require.Len(t, result.Plugins, 2)
 	})
 
+	t.Run("install by category pattern", func(t *testing.T) {
+		ctx := context.Background()
+
+		mockDownloader := &mockDownloader{
+			fetchManifestFunc: func(ctx context.Context, src PluginSource) (*PluginManifest, error) {
+				return &PluginManifest{
+					Plugins: []PluginManifestEntry{
+						{ID: "mysql-plugin", Name: "MySQL Plugin", Version: "1.0.0", Categories: []Category{"database/mysql"}},
+						{ID: "postgres-plugin", Name: "PostgreSQL Plugin", Version: "1.0.0", Categories: []Category{"database/postgresql"}},
+						{ID: "wordpress-plugin", Name: "WordPress Plugin", Version: "1.0.0", Categories: []Category{"web/cms/wordpress"}},
+					},
+				}, nil
+			},
+			downloadFunc: func(ctx context.Context, id, version string) (*CacheEntry, error) {
+				return &CacheEntry{}, nil
+			},
+		}
+
+		mockCache := newCache(func(m *mockCacheManager) {
+			m.getEntryFunc = func(ctx context.Context, name, version string) (*CacheEntry, error) {
+				return nil, ErrPluginNotInstalled
+			}
+		})
+
+		svc := newTestService(mockCache, &mockManifestManager{}, mockDownloader, []PluginSource{{Name: "official", URL: "https://example.com/manifest.yaml", Enabled: true}})
+
+		result, err := svc.Install(ctx, "database/*", InstallOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 2, result.InstalledCount, "should install both database plugins")
+
+		result, err = svc.Install(ctx, "database/mysql", InstallOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.InstalledCount, "should install only the MySQL plugin")
+	})
+
 	t.Run("no plugins found in category", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -1420,6 +1503,34 @@ func TestService_Uninstall_ByCategory(t *testing.T) {
 		require.Contains(t, removedIDs, "ssh-plugin-2")
 	})
 
+	t.Run("uninstall by category pattern target", func(t *testing.T) {
+		ctx := context.Background()
+
+		removedIDs := []string{}
+
+		manifest := &mockManifestManager{
+			listFunc: func() ([]*ManifestEntry, error) {
+				return []*ManifestEntry{
+					{ID: "mysql-plugin", Name: "MySQL Plugin", Version: "1.0.0", Tags: []string{"database/mysql"}},
+					{ID: "db-plugin", Name: "Database Plugin", Version: "1.0.0", Tags: []string{"database"}},
+					{ID: "wordpress-plugin", Name: "WordPress Plugin", Version: "1.0.0", Tags: []string{"web/cms/wordpress"}},
+				}, nil
+			},
+			removeFunc: func(id string) error {
+				removedIDs = append(removedIDs, id)
+				return nil
+			},
+		}
+
+		svc := newTestService(&mockCacheManager{}, manifest, &mockDownloader{}, []PluginSource{})
+
+		result, err := svc.Uninstall(ctx, "database/*", UninstallOptions{})
+
+		require.NoError(t, err)
+		require.Equal(t, 2, result.RemovedCount)
+		require.ElementsMatch(t, []string{"mysql-plugin", "db-plugin"}, removedIDs)
+	})
+
 	t.Run("no plugins in category", func(t *testing.T) {
 		ctx := context.Background()
 
@@ -2140,6 +2251,23 @@ func TestService_Clean(t *testing.T) {
 		require.Equal(t, int64(512*1024), result.Freed)
 	})
 
+	t.Run("evicts entries over the size limit", func(t *testing.T) {
+		cache := &mockCacheManager{
+			pruneFunc: func(ctx context.Context, olderThan time.Duration) (int, error) {
+				return 1, nil
+			},
+			evictFunc: func(ctx context.Context) (int, error) {
+				return 2, nil
+			},
+		}
+
+		svc := newTestService(cache, &mockManifestManager{}, &mockDownloader{}, []PluginSource{})
+
+		result, err := svc.Clean(context.Background(), CleanOptions{OlderThan: time.Hour})
+		require.NoError(t, err)
+		require.Equal(t, 3, result.RemovedCount)
+	})
+
 	t.Run("dry run does not remove entries", func(t *testing.T) {
 		ctx := context.Background()
 
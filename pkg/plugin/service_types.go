@@ -73,6 +73,12 @@ type InstallOptions struct {
 
 	// Category filter for bulk installs (optional)
 	Category Category
+
+	// AllowUntrusted permits installing plugins from untrusted sources
+	AllowUntrusted bool
+
+	// Progress receives per-plugin download progress (optional)
+	Progress ProgressReporter
 }
 
 // InstallResult holds results of Install operation
@@ -108,6 +114,12 @@ type UpdateOptions struct {
 
 	// DryRun simulates update without actually downloading
 	DryRun bool
+
+	// AllowUntrusted permits downloading plugins from untrusted sources
+	AllowUntrusted bool
+
+	// Progress receives per-plugin download progress (optional)
+	Progress ProgressReporter
 }
 
 // UpdateResult holds results of Update operation
@@ -168,6 +180,10 @@ type PluginInfo struct {
 	Severity string
 	Tags     []string
 
+	// Provenance: the source the plugin was installed from and its trust level
+	Source string
+	Trust  TrustLevel
+
 	// Installation info
 	Checksum     string
 	DownloadURL  string
@@ -204,6 +220,18 @@ type CleanResult struct {
 	Freed int64
 }
 
+// BundleOptions holds parameters for Bundle operation
+type BundleOptions struct {
+	// Targets select installed plugins by ID or category (empty = all)
+	Targets []string
+}
+
+// BundleResult holds results of Bundle operation
+type BundleResult struct {
+	// Plugins contains info about the bundled plugins
+	Plugins []*PluginInfo
+}
+
 // VerifyOptions holds parameters for Verify operation
 type VerifyOptions struct {
 	// PluginID specifies a single plugin to verify (empty = verify all)
@@ -257,6 +285,18 @@ type PluginSource struct {
 
 	// Mirrors are alternative URLs for redundancy
 	Mirrors []string `yaml:"mirrors,omitempty"`
+
+	// Proxy overrides the downloader proxy for this source: a proxy URL,
+	// or "direct" to bypass the proxy. Empty uses the downloader proxy.
+	Proxy string `yaml:"proxy,omitempty"`
+
+	// PublicKeys are base64 encoded Ed25519 keys the source's manifest must
+	// be signed with (see SignManifest). Empty accepts unsigned manifests.
+	PublicKeys []string `yaml:"public_keys,omitempty"`
+
+	// Trust is the trust level of the source's plugins. Empty derives it
+	// from the source (see TrustLevel).
+	Trust TrustLevel `yaml:"trust,omitempty"`
 }
 
 // PluginManifestEntry describes a plugin in the remote manifest
@@ -271,8 +311,15 @@ type PluginManifestEntry struct {
 	// Categorization
 	Categories []Category `yaml:"categories" json:"categories"`
 
+	// Compatibility: the oldest Vulntor release that runs the plugin
+	MinVulntorVersion string `yaml:"vulntor_min_version,omitempty" json:"vulntor_min_version,omitempty"`
+
 	// Download info
 	URL      string `yaml:"url" json:"url"`           // Download URL
 	Checksum string `yaml:"checksum" json:"checksum"` // sha256:hex
 	Size     int64  `yaml:"size" json:"size"`         // File size in bytes
+
+	// Provenance, set from the source the manifest was fetched from
+	Source string     `yaml:"-" json:"source,omitempty"`
+	Trust  TrustLevel `yaml:"-" json:"trust,omitempty"`
 }
@@ -0,0 +1,68 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+)
+
+// ManifestSignatureSuffix is appended to the URL of a manifest to get the
+// URL of its detached signature.
+const ManifestSignatureSuffix = ".sig"
+
+// SignManifest returns the detached signature of manifest data: its
+// Ed25519 signature by key, base64 encoded. Publish it next to the
+// manifest, at the manifest URL plus ManifestSignatureSuffix.
+func SignManifest(data []byte, key ed25519.PrivateKey) []byte {
+	return []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(key, data)))
+}
+
+// ParsePublicKey parses a base64 encoded Ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key: %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// VerifyManifestSignature checks that signature, as produced by
+// SignManifest, signs data with one of keys (base64 encoded Ed25519 public
+// keys). It returns an error wrapping ErrSignatureInvalid otherwise.
+func VerifyManifestSignature(data, signature []byte, keys []string) error {
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("%w: malformed signature", ErrSignatureInvalid)
+	}
+	for _, k := range keys {
+		key, err := ParsePublicKey(k)
+		if err != nil {
+			return err
+		}
+		if ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: not signed by a trusted key", ErrSignatureInvalid)
+}
+
+// ApplySourceKeys returns a copy of sources whose PublicKeys are set from
+// keys, keyed by source name. Sources with keys of their own keep them.
+func ApplySourceKeys(sources []PluginSource, keys map[string][]string) []PluginSource {
+	result := make([]PluginSource, len(sources))
+	copy(result, sources)
+	for i := range result {
+		if k, ok := keys[result[i].Name]; ok && len(result[i].PublicKeys) == 0 {
+			result[i].PublicKeys = k
+		}
+	}
+	return result
+}
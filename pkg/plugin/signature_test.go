@@ -0,0 +1,55 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyManifestSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	key := base64.StdEncoding.EncodeToString(pub)
+	otherKey := base64.StdEncoding.EncodeToString(otherPub)
+
+	data := []byte("version: \"1.0\"\n")
+	signature := append(SignManifest(data, priv), '\n')
+
+	require.NoError(t, VerifyManifestSignature(data, signature, []string{otherKey, key}))
+
+	err = VerifyManifestSignature(data, signature, []string{otherKey})
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+	require.ErrorContains(t, err, "not signed by a trusted key")
+
+	err = VerifyManifestSignature([]byte("version: \"2.0\"\n"), signature, []string{key})
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+
+	err = VerifyManifestSignature(data, []byte("not a signature"), []string{key})
+	require.ErrorIs(t, err, ErrSignatureInvalid)
+	require.ErrorContains(t, err, "malformed signature")
+
+	err = VerifyManifestSignature(data, signature, []string{"c2hvcnQ="})
+	require.ErrorContains(t, err, "invalid public key: 5 bytes, want 32")
+}
+
+func TestApplySourceKeys(t *testing.T) {
+	sources := []PluginSource{
+		{Name: "official"},
+		{Name: "internal"},
+		{Name: "partner", PublicKeys: []string{"own"}},
+	}
+	result := ApplySourceKeys(sources, map[string][]string{"internal": {"a"}, "partner": {"b"}})
+
+	require.Empty(t, result[0].PublicKeys)
+	require.Equal(t, []string{"a"}, result[1].PublicKeys)
+	require.Equal(t, []string{"own"}, result[2].PublicKeys)
+	require.Empty(t, sources[1].PublicKeys, "input must not be modified")
+}
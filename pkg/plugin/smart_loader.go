@@ -183,7 +183,7 @@ func hasCategory(plugin *YAMLPlugin, category Category) bool {
 
 	// Check tags in metadata
 	for _, tag := range plugin.Metadata.Tags {
-		if CategoryFromString(tag) == category {
+		if category.Contains(CategoryFromString(tag)) {
 			return true
 		}
 	}
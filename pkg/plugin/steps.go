@@ -0,0 +1,266 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultStepTimeout is the timeout of each request of a multi-step
+// plugin.
+const DefaultStepTimeout = 10 * time.Second
+
+// Validate validates the step.
+func (s *Step) Validate() error {
+	if s.Request == nil && s.Match == nil {
+		return fmt.Errorf("request or match is required")
+	}
+
+	if s.Request != nil {
+		if !strings.HasPrefix(s.Request.Path, "/") {
+			return fmt.Errorf("request path must start with /: %q", s.Request.Path)
+		}
+		if strings.ContainsAny(s.Request.Method, " \r\n") {
+			return fmt.Errorf("invalid request method: %q", s.Request.Method)
+		}
+	}
+
+	if s.Match != nil {
+		if err := s.Match.Validate(); err != nil {
+			return fmt.Errorf("match block validation failed: %w", err)
+		}
+	}
+
+	for i := range s.Extract {
+		ex := &s.Extract[i]
+		if err := ex.Validate(); err != nil {
+			return fmt.Errorf("extract[%d]: %w", i, err)
+		}
+		// Plain names are plugin variables, resolved when the plugin loads
+		if !strings.Contains(ex.Name, ".") {
+			return fmt.Errorf("extract[%d]: name must be a dotted key such as login.token: %s", i, ex.Name)
+		}
+	}
+
+	return nil
+}
+
+// label names the step in logs and errors.
+func (s *Step) label(i int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return strconv.Itoa(i)
+}
+
+// StepRunner runs the steps of multi-step plugins against HTTP services.
+type StepRunner struct {
+	transport http.RoundTripper
+	timeout   time.Duration
+	evaluator *Evaluator
+}
+
+// NewStepRunner creates a runner whose requests time out after timeout
+// (DefaultStepTimeout when zero).
+func NewStepRunner(timeout time.Duration) *StepRunner {
+	if timeout <= 0 {
+		timeout = DefaultStepTimeout
+	}
+	return &StepRunner{
+		transport: newTargetTransport(timeout),
+		timeout:   timeout,
+		evaluator: NewEvaluator(),
+	}
+}
+
+// Run runs the steps of p against the HTTP service at baseURL (e.g.
+// "https://10.0.0.5:8443"). The plugin's triggers and match block are
+// evaluated first, against context. Each step then sends its request,
+// whose response replaces the http.* fields of the previous one, matches
+// and extracts; cookies set by a response are sent with later requests.
+// The workflow stops at the first step that does not match. When every
+// step matches, the result holds the URL of the last request in
+// Output.Metadata["matched_at"] and the values the steps extracted.
+// context is not modified.
+func (r *StepRunner) Run(ctx context.Context, p *YAMLPlugin, baseURL string, context map[string]any) (*YAMLMatchResult, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", baseURL)
+	}
+
+	state := make(map[string]any, len(context)+2)
+	for k, v := range context {
+		state[k] = v
+	}
+	state["target"] = base.Hostname()
+	if port, err := strconv.Atoi(nucleiVars(base)["Port"]); err == nil {
+		state["service.port"] = port
+	}
+
+	result := &YAMLMatchResult{Plugin: p, EvaluatedAt: time.Now()}
+	defer func() { result.ExecutionTime = time.Since(result.EvaluatedAt) }()
+
+	triggered, err := r.evaluator.trigger.ShouldTrigger(p.Triggers, state)
+	if err != nil {
+		return nil, fmt.Errorf("trigger evaluation failed: %w", err)
+	}
+	if !triggered {
+		return result, nil
+	}
+	if p.Match != nil {
+		matched, err := r.evaluator.matcher.Evaluate(p.Match, state)
+		if err != nil {
+			return nil, fmt.Errorf("match evaluation failed: %w", err)
+		}
+		if !matched {
+			return result, nil
+		}
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	root := base.Scheme + "://" + base.Host
+
+	var (
+		matchedAt string
+		extracted map[string]any
+		response  map[string]any
+	)
+	for i := range p.Steps {
+		step := &p.Steps[i]
+
+		if step.Request != nil {
+			req, err := step.Request.build(ctx, root, state)
+			if err != nil {
+				return nil, fmt.Errorf("step %s: %w", step.label(i), err)
+			}
+			redirects := 0
+			if step.Request.Redirects {
+				redirects = DefaultNucleiMaxRedirects
+			}
+			fields, err := doTargetRequest(r.client(jar, redirects), req, redirects)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return nil, fmt.Errorf("step %s: %w", step.label(i), err)
+			}
+			for k := range response {
+				delete(state, k)
+			}
+			for k, v := range fields {
+				state[k] = v
+			}
+			response = fields
+			matchedAt = req.URL.String()
+		}
+
+		if step.Match != nil {
+			matched, err := r.evaluator.matcher.Evaluate(step.Match, state)
+			if err != nil {
+				return nil, fmt.Errorf("step %s: match evaluation failed: %w", step.label(i), err)
+			}
+			if !matched {
+				log.Debug().
+					Str("plugin", p.Name).
+					Str("step", step.label(i)).
+					Msg("Step did not match, stopping")
+				return result, nil
+			}
+		}
+
+		for _, ex := range step.Extract {
+			value, ok, err := ex.Extract(state)
+			if err != nil {
+				log.Debug().
+					Str("plugin", p.Name).
+					Str("step", step.label(i)).
+					Str("extractor", ex.Name).
+					Err(err).
+					Msg("Extractor failed")
+				continue
+			}
+			if !ok {
+				continue
+			}
+			if extracted == nil {
+				extracted = make(map[string]any)
+			}
+			extracted[ex.Name] = value
+			state[ex.Name] = value
+		}
+	}
+
+	result.Matched = true
+	result.Output = p.Output
+	result.Output.Message = expandRuntime(p.Output.Message, state)
+	if result.Output.Severity == "" {
+		result.Output.Severity = p.Metadata.Severity
+	}
+	result.Output.Metadata = make(map[string]string, len(p.Output.Metadata)+1)
+	for k, v := range p.Output.Metadata {
+		result.Output.Metadata[k] = v
+	}
+	if matchedAt != "" {
+		result.Output.Metadata["matched_at"] = matchedAt
+	}
+	result.Evidence = p.Output.collectEvidence(state)
+	result.Extracted = extracted
+	return result, nil
+}
+
+// client returns an HTTP client sharing jar, following up to maxRedirects
+// redirects.
+func (r *StepRunner) client(jar http.CookieJar, maxRedirects int) *http.Client {
+	return &http.Client{
+		Transport: r.transport,
+		Timeout:   r.timeout,
+		Jar:       jar,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
+// build creates the request to root, with the runtime references in its
+// path, headers and body resolved against state.
+func (r *StepRequest) build(ctx context.Context, root string, state map[string]any) (*http.Request, error) {
+	method := strings.ToUpper(r.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if r.Body != "" {
+		body = strings.NewReader(expandRuntime(r.Body, state))
+	}
+	target := root + expandRuntime(r.Path, state)
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("build request for %s: %w", target, err)
+	}
+	for name, value := range r.Headers {
+		req.Header.Set(name, expandRuntime(value, state))
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	return req, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const loginPlugin = `
+id: admin-default-login
+name: Admin Default Login
+version: 1.0.0
+type: evaluation
+author: test
+variables:
+  username: admin
+metadata:
+  severity: critical
+  tags: [http, default-credentials]
+steps:
+  - name: login-page
+    request:
+      path: /login
+    match:
+      logic: AND
+      rules:
+        - field: http.status_code
+          operator: equals
+          value: 200
+    extract:
+      - name: login.csrf
+        type: regex
+        field: http.body
+        regex: 'name="csrf" value="(\w+)"'
+  - name: submit
+    request:
+      method: POST
+      path: /login
+      headers:
+        Content-Type: application/x-www-form-urlencoded
+      body: "user={{username}}&pass=admin&csrf={{login.csrf}}"
+    match:
+      logic: AND
+      rules:
+        - field: http.status_code
+          operator: equals
+          value: 302
+  - name: dashboard
+    request:
+      path: /admin
+    match:
+      logic: AND
+      rules:
+        - field: http.body
+          operator: contains
+          value: Dashboard
+output:
+  vulnerability: true
+  message: "Default admin login accepted on {{target.host}}"
+`
+
+func newLoginServer(t *testing.T, password string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/login" && r.Method == http.MethodGet:
+			_, _ = fmt.Fprint(w, `<form><input type="hidden" name="csrf" value="t0k3n"></form>`)
+		case r.URL.Path == "/login" && r.Method == http.MethodPost:
+			if r.FormValue("user") != "admin" || r.FormValue("pass") != password || r.FormValue("csrf") != "t0k3n" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1"})
+			http.Redirect(w, r, "/admin", http.StatusFound)
+		case r.URL.Path == "/admin":
+			if c, err := r.Cookie("session"); err != nil || c.Value != "s1" {
+				http.Redirect(w, r, "/login", http.StatusFound)
+				return
+			}
+			_, _ = fmt.Fprint(w, "Dashboard")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestStepRunner_Run(t *testing.T) {
+	p, err := parseYAMLPlugin([]byte(loginPlugin), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, p.Validate())
+	require.Len(t, p.Steps, 3)
+
+	server := newLoginServer(t, "admin")
+	runner := NewStepRunner(0)
+
+	scanContext := map[string]any{"http.server": "nginx"}
+	result, err := runner.Run(context.Background(), p, server.URL, scanContext)
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	require.Equal(t, CriticalSeverity, result.Output.Severity)
+	require.Equal(t, "Default admin login accepted on 127.0.0.1", result.Output.Message)
+	require.Equal(t, server.URL+"/admin", result.Output.Metadata["matched_at"])
+	require.Equal(t, map[string]any{"login.csrf": "t0k3n"}, result.Extracted)
+	require.Equal(t, map[string]any{"http.server": "nginx"}, scanContext)
+
+	// The workflow stops at the step that does not match
+	result, err = runner.Run(context.Background(), p, newLoginServer(t, "s3cret").URL, nil)
+	require.NoError(t, err)
+	require.False(t, result.Matched)
+
+	// Plain evaluation does not send the requests, and so never matches
+	evaluated, err := NewEvaluator().Evaluate(p, scanContext)
+	require.NoError(t, err)
+	require.False(t, evaluated.Matched)
+}
+
+func TestStepRunner_Run_Gates(t *testing.T) {
+	server := newLoginServer(t, "admin")
+	p := &YAMLPlugin{
+		Name:     "gated",
+		Triggers: []Trigger{{DataKey: "http.server", Condition: "exists", Value: true}},
+		Match:    &MatchBlock{Logic: "AND", Rules: []MatchRule{{Field: "http.server", Operator: "contains", Value: "nginx"}}},
+		Steps:    []Step{{Request: &StepRequest{Path: "/login"}}},
+		Output:   OutputBlock{Message: "reached"},
+	}
+	runner := NewStepRunner(0)
+
+	for _, tt := range []struct {
+		name    string
+		context map[string]any
+		want    bool
+	}{
+		{"not triggered", map[string]any{}, false},
+		{"match block fails", map[string]any{"http.server": "Apache"}, false},
+		{"runs the steps", map[string]any{"http.server": "nginx"}, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runner.Run(context.Background(), p, server.URL, tt.context)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, result.Matched)
+		})
+	}
+
+	_, err := runner.Run(context.Background(), p, "not a url", nil)
+	require.ErrorContains(t, err, "invalid base URL")
+}
+
+func TestStep_Validate(t *testing.T) {
+	rule := &MatchBlock{Logic: "AND", Rules: []MatchRule{{Field: "http.status_code", Operator: "equals", Value: 200}}}
+	tests := []struct {
+		name    string
+		step    Step
+		wantErr string
+	}{
+		{"request", Step{Request: &StepRequest{Method: "POST", Path: "/login"}}, ""},
+		{"match only", Step{Match: rule}, ""},
+		{"empty", Step{}, "request or match is required"},
+		{"relative path", Step{Request: &StepRequest{Path: "login"}}, `request path must start with /: "login"`},
+		{"bad method", Step{Request: &StepRequest{Method: "GET /x", Path: "/"}}, `invalid request method: "GET /x"`},
+		{"bad match", Step{Match: &MatchBlock{Logic: "XOR", Rules: rule.Rules}}, "invalid match logic"},
+		{
+			"plain extractor name",
+			Step{Match: rule, Extract: []Extractor{{Name: "token", Type: ExtractRegex, Field: "http.body", Regex: "x"}}},
+			"extract[0]: name must be a dotted key such as login.token: token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.step.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	p := &YAMLPlugin{ID: "x", Name: "x", Version: "1.0.0", Type: EvaluationType, Author: "a",
+		Metadata: PluginMetadata{Severity: InfoSeverity}, Steps: []Step{{}}, Output: OutputBlock{Message: "m"}}
+	require.EqualError(t, p.Validate(), "step[0]: request or match is required")
+}
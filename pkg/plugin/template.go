@@ -0,0 +1,338 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SharedVariablesFile holds variables shared by the plugins of a
+// directory. It is not a plugin itself.
+const SharedVariablesFile = "_variables.yaml"
+
+// WordlistDir is the directory, next to the plugins or under the loader's
+// base directory, that {{wordlist:name}} references read name.txt from.
+// Wordlists not found there are read from the workspace wordlists (see
+// NewLoader).
+const WordlistDir = "wordlists"
+
+// templateRef matches {{name}} references in plugin YAML.
+var templateRef = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.:-]+)\s*\}\}`)
+
+// plainName restricts variable and wordlist names. Dotted names such as
+// {{target.host}} or {{tls.certificate.common_name}} refer to scan data:
+// the loader leaves them in place and they are resolved when the plugin
+// is evaluated.
+var plainName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// wordlistPrefix marks a reference to a wordlist file.
+const wordlistPrefix = "wordlist:"
+
+// templateResolver resolves variable and wordlist references in the
+// YAML of a plugin.
+type templateResolver struct {
+	variables map[string]*yaml.Node
+	// wordlist returns the entries of a wordlist; nil when the plugin
+	// source has no wordlists.
+	wordlist func(name string) ([]string, error)
+}
+
+// parseYAMLPlugin parses a YAML plugin, resolving the references to its
+// own variables, the shared variables and wordlists. A plugin's own
+// variables take precedence over shared ones of the same name.
+func parseYAMLPlugin(data []byte, shared map[string]*yaml.Node, wordlist func(string) ([]string, error)) (*YAMLPlugin, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var plugin YAMLPlugin
+	if len(doc.Content) == 0 {
+		return &plugin, nil
+	}
+	root := doc.Content[0]
+	if root.Kind == yaml.MappingNode {
+		r := &templateResolver{variables: make(map[string]*yaml.Node, len(shared)), wordlist: wordlist}
+		for name, value := range shared {
+			r.variables[name] = value
+		}
+		if err := r.resolveDocument(root); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := root.Decode(&plugin); err != nil {
+		return nil, err
+	}
+	return &plugin, nil
+}
+
+// resolveDocument defines the variables of the document's 'variables'
+// block, then resolves the references in the rest of it.
+func (r *templateResolver) resolveDocument(root *yaml.Node) error {
+	var body []*yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value == "variables" {
+			if err := r.define(value); err != nil {
+				return err
+			}
+			continue
+		}
+		body = append(body, value)
+	}
+	for _, node := range body {
+		if _, err := r.resolve(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// define adds the variables of a 'variables' mapping. A variable may use
+// wordlists and the variables defined before it.
+func (r *templateResolver) define(block *yaml.Node) error {
+	if block.Kind != yaml.MappingNode {
+		return fmt.Errorf("variables must be a mapping")
+	}
+	for i := 0; i+1 < len(block.Content); i += 2 {
+		name, value := block.Content[i].Value, block.Content[i+1]
+		if !plainName.MatchString(name) {
+			return fmt.Errorf("invalid variable name %q", name)
+		}
+		if value.Kind != yaml.ScalarNode && value.Kind != yaml.SequenceNode {
+			return fmt.Errorf("variable %q must be a scalar or a list", name)
+		}
+		if _, err := r.resolve(value); err != nil {
+			return fmt.Errorf("variable %q: %w", name, err)
+		}
+		r.variables[name] = value
+	}
+	return nil
+}
+
+// resolve replaces the references in node and its children. Mapping keys
+// are left as they are. It reports whether a scalar became a list, which
+// a parent list splices in.
+func (r *templateResolver) resolve(node *yaml.Node) (bool, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if _, err := r.resolve(node.Content[i]); err != nil {
+				return false, err
+			}
+		}
+	case yaml.SequenceNode:
+		items := make([]*yaml.Node, 0, len(node.Content))
+		for _, item := range node.Content {
+			splice, err := r.resolve(item)
+			if err != nil {
+				return false, err
+			}
+			if splice {
+				items = append(items, item.Content...)
+				continue
+			}
+			items = append(items, item)
+		}
+		node.Content = items
+	case yaml.ScalarNode:
+		return r.resolveScalar(node)
+	}
+	return false, nil
+}
+
+// resolveScalar resolves the references in a scalar. A scalar that is a
+// single reference takes the referenced value, keeping its type, or
+// becomes a list; references within text are replaced by their values.
+func (r *templateResolver) resolveScalar(node *yaml.Node) (bool, error) {
+	if node.Tag != "!!str" || !strings.Contains(node.Value, "{{") {
+		return false, nil
+	}
+
+	if m := templateRef.FindStringSubmatch(node.Value); m != nil && m[0] == strings.TrimSpace(node.Value) {
+		if isRuntimeRef(m[1]) {
+			return false, nil
+		}
+		value, err := r.lookup(m[1])
+		if err != nil {
+			return false, err
+		}
+		if value.Kind == yaml.SequenceNode {
+			node.Kind = yaml.SequenceNode
+			node.Tag = "!!seq"
+			node.Style = 0
+			node.Value = ""
+			node.Content = value.Content
+			return true, nil
+		}
+		node.Tag = value.Tag
+		node.Value = value.Value
+		return false, nil
+	}
+
+	var err error
+	node.Value = templateRef.ReplaceAllStringFunc(node.Value, func(ref string) string {
+		name := templateRef.FindStringSubmatch(ref)[1]
+		if err != nil || isRuntimeRef(name) {
+			return ref
+		}
+		value, lookupErr := r.lookup(name)
+		if lookupErr != nil {
+			err = lookupErr
+			return ref
+		}
+		if value.Kind != yaml.ScalarNode {
+			err = fmt.Errorf("list %q cannot be used within text", name)
+			return ref
+		}
+		return value.Value
+	})
+	return false, err
+}
+
+// isRuntimeRef reports whether name refers to scan data.
+func isRuntimeRef(name string) bool {
+	return strings.Contains(name, ".") && !strings.HasPrefix(name, wordlistPrefix)
+}
+
+// lookup returns the value of a variable or wordlist reference.
+func (r *templateResolver) lookup(name string) (*yaml.Node, error) {
+	if list, ok := strings.CutPrefix(name, wordlistPrefix); ok {
+		if r.wordlist == nil {
+			return nil, fmt.Errorf("wordlist %q: wordlists are not available for this plugin", list)
+		}
+		if !plainName.MatchString(list) {
+			return nil, fmt.Errorf("invalid wordlist name %q", list)
+		}
+		entries, err := r.wordlist(list)
+		if err != nil {
+			return nil, fmt.Errorf("wordlist %q: %w", list, err)
+		}
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, entry := range entries {
+			seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry})
+		}
+		return seq, nil
+	}
+
+	value, ok := r.variables[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined variable %q", name)
+	}
+	return value, nil
+}
+
+// loadSharedVariables reads the variables of the SharedVariablesFile in
+// dir. It returns nil when there is none.
+func loadSharedVariables(dir string, wordlist func(string) ([]string, error)) (map[string]*yaml.Node, error) {
+	data, err := os.ReadFile(filepath.Join(dir, SharedVariablesFile)) // #nosec G304 -- fixed name in the plugin directory
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", SharedVariablesFile, err)
+	}
+	r := &templateResolver{variables: make(map[string]*yaml.Node), wordlist: wordlist}
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		root := doc.Content[0]
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "variables" {
+				if err := r.define(root.Content[i+1]); err != nil {
+					return nil, fmt.Errorf("%s: %w", SharedVariablesFile, err)
+				}
+			}
+		}
+	}
+	return r.variables, nil
+}
+
+// readWordlist returns the entries of the first name.txt found in the
+// wordlist directories dirs.
+// Blank lines and lines starting with # are skipped.
+func readWordlist(name string, dirs ...string) ([]string, error) {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name+".txt")) // #nosec G304 -- name is restricted to plain file names
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var entries []string
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			entries = append(entries, line)
+		}
+		return entries, scanner.Err()
+	}
+	return nil, fmt.Errorf("not found in %s", WordlistDir)
+}
+
+// expandRuntime replaces the references to scan data in s with the
+// values in context: {{target.host}} is the target address,
+// {{target.port}} the service port and any other dotted name the context
+// key of that name. References to missing data are left in place.
+func expandRuntime(s string, context map[string]any) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return templateRef.ReplaceAllStringFunc(s, func(ref string) string {
+		name := templateRef.FindStringSubmatch(ref)[1]
+		if !isRuntimeRef(name) {
+			return ref
+		}
+		var value any
+		switch name {
+		case "target.host":
+			value = context["target"]
+		case "target.port":
+			value = context["service.port"]
+		default:
+			value = context[name]
+		}
+		if value == nil {
+			return ref
+		}
+		return toString(value)
+	})
+}
+
+// expandRuntimeValue applies expandRuntime to a rule value: a string or
+// the strings of a list.
+func expandRuntimeValue(value any, context map[string]any) any {
+	switch v := value.(type) {
+	case string:
+		return expandRuntime(v, context)
+	case []any:
+		expanded := make([]any, len(v))
+		for i, item := range v {
+			expanded[i] = expandRuntimeValue(item, context)
+		}
+		return expanded
+	}
+	return value
+}
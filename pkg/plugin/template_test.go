@@ -0,0 +1,200 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const templatedPlugin = `
+id: tomcat-{{product}}-exposed
+name: Tomcat {{product}} Exposed
+version: 1.0.0
+type: evaluation
+author: test
+variables:
+  product: manager
+  port: 8080
+  paths: [/manager/html, /manager/status]
+metadata:
+  severity: medium
+  tags: [http, tomcat, "{{product}}"]
+triggers:
+  - data_key: http.server
+    condition: exists
+    value: true
+match:
+  logic: AND
+  rules:
+    - field: service.port
+      operator: equals
+      value: "{{port}}"
+    - field: http.path
+      operator: in
+      value: ["/", "{{paths}}"]
+    - field: http.host
+      operator: equals
+      value: "{{target.host}}"
+output:
+  vulnerability: true
+  message: "Tomcat {{product}} reachable on {{target.host}}:{{target.port}}"
+`
+
+func TestParseYAMLPlugin_Variables(t *testing.T) {
+	p, err := parseYAMLPlugin([]byte(templatedPlugin), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, p.Validate())
+
+	require.Equal(t, "tomcat-manager-exposed", p.ID)
+	require.Equal(t, "Tomcat manager Exposed", p.Name)
+	require.Equal(t, []string{"http", "tomcat", "manager"}, p.Metadata.Tags)
+	// A whole reference keeps the variable's type; lists are spliced in
+	require.Equal(t, 8080, p.Match.Rules[0].Value)
+	require.Equal(t, []any{"/", "/manager/html", "/manager/status"}, p.Match.Rules[1].Value)
+	// References to scan data are left for evaluation
+	require.Equal(t, "{{target.host}}", p.Match.Rules[2].Value)
+	require.Equal(t, "Tomcat manager reachable on {{target.host}}:{{target.port}}", p.Output.Message)
+
+	result, err := NewEvaluator().Evaluate(p, map[string]any{
+		"http.server":  "Apache-Coyote/1.1",
+		"service.port": 8080,
+		"http.path":    "/manager/status",
+		"http.host":    "192.0.2.10",
+		"target":       "192.0.2.10",
+	})
+	require.NoError(t, err)
+	require.True(t, result.Matched)
+	require.Equal(t, "Tomcat manager reachable on 192.0.2.10:8080", result.Output.Message)
+}
+
+func TestParseYAMLPlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"undefined variable", "name: '{{missing}}'", `undefined variable "missing"`},
+		{"list within text", "variables: {ports: [80, 443]}\nname: 'ports {{ports}}'", `list "ports" cannot be used within text`},
+		{"no wordlists", "name: x\ntags: ['{{wordlist:users}}']", `wordlist "users": wordlists are not available for this plugin`},
+		{"bad variable name", "variables: {a.b: 1}\nname: x", `invalid variable name "a.b"`},
+		{"nested variable", "variables: {a: {b: 1}}\nname: x", `variable "a" must be a scalar or a list`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseYAMLPlugin([]byte(tt.yaml), nil, nil)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoader_SharedVariablesAndWordlists(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "http")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, WordlistDir), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(base, WordlistDir), 0o755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, SharedVariablesFile), []byte(`
+variables:
+  author: web-team
+  severity: low
+  admin_paths: "{{wordlist:admin-paths}}"
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, WordlistDir, "admin-paths.txt"), []byte("# admin consoles\n/admin\n\n/console\n"), 0o600))
+	// Wordlists under the base directory serve every plugin directory
+	require.NoError(t, os.WriteFile(filepath.Join(base, WordlistDir, "users.txt"), []byte("admin\nroot\n"), 0o600))
+
+	plugin := func(id, extra string) string {
+		return `id: ` + id + `
+name: ` + id + `
+version: 1.0.0
+type: evaluation
+author: "{{author}}"
+` + extra + `
+metadata:
+  severity: "{{severity}}"
+  tags: [http]
+match:
+  logic: OR
+  rules:
+    - field: http.path
+      operator: in
+      value: "{{admin_paths}}"
+    - field: http.user
+      operator: in
+      value: "{{wordlist:users}}"
+output:
+  vulnerability: true
+  message: Admin console
+`
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(plugin("a", "")), 0o600))
+	// A plugin's own variables win over shared ones
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(plugin("b", "variables:\n  severity: high")), 0o600))
+
+	loader := NewLoader(base)
+	plugins, err := loader.LoadRecursive(base)
+	require.NoError(t, err)
+	require.Len(t, plugins, 2)
+
+	byID := map[string]*YAMLPlugin{}
+	for _, p := range plugins {
+		byID[p.ID] = p
+	}
+	require.Equal(t, "web-team", byID["a"].Author)
+	require.Equal(t, LowSeverity, byID["a"].Metadata.Severity)
+	require.Equal(t, HighSeverity, byID["b"].Metadata.Severity)
+	require.Equal(t, []any{"/admin", "/console"}, byID["a"].Match.Rules[0].Value)
+	require.Equal(t, []any{"admin", "root"}, byID["a"].Match.Rules[1].Value)
+
+	// Missing wordlists fail the plugin
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.yaml"), []byte(`name: '{{wordlist:nope}}'`), 0o600))
+	_, err = loader.Load(filepath.Join(dir, "c.yaml"))
+	require.ErrorContains(t, err, `wordlist "nope": not found in wordlists`)
+}
+
+func TestLoader_WorkspaceWordlists(t *testing.T) {
+	dir := t.TempDir()
+	workspace := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, WordlistDir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, WordlistDir, "users.txt"), []byte("plugin-user\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "users.txt"), []byte("workspace-user\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(workspace, "passwords.txt"), []byte("admin\nchangeme\n"), 0o600))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(`id: a
+name: a
+version: 1.0.0
+type: evaluation
+author: test
+metadata:
+  severity: high
+  tags: ["{{wordlist:users}}", "{{wordlist:passwords}}"]
+output:
+  message: Weak login
+`), 0o600))
+
+	p, err := NewLoader(dir, workspace).Load(filepath.Join(dir, "a.yaml"))
+	require.NoError(t, err)
+	// The plugin's own wordlist wins over the workspace one
+	require.Equal(t, []string{"plugin-user", "admin", "changeme"}, p.Metadata.Tags)
+
+	_, err = NewLoader(dir).Load(filepath.Join(dir, "a.yaml"))
+	require.ErrorContains(t, err, `wordlist "passwords": not found in wordlists`)
+}
+
+func TestExpandRuntime(t *testing.T) {
+	context := map[string]any{
+		"target":                      "192.0.2.10",
+		"service.port":                443,
+		"tls.certificate.common_name": "CN=localhost",
+	}
+	require.Equal(t, "https://192.0.2.10:443/", expandRuntime("https://{{target.host}}:{{ target.port }}/", context))
+	require.Equal(t, "CN=localhost", expandRuntime("{{tls.certificate.common_name}}", context))
+	require.Equal(t, "{{ssh.banner}}", expandRuntime("{{ssh.banner}}", context))
+	require.Equal(t, []any{"192.0.2.10", 1}, expandRuntimeValue([]any{"{{target.host}}", 1}, context))
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TrustLevel records how far the plugins of a source are trusted.
+type TrustLevel string
+
+// Source trust levels, from most to least trusted.
+const (
+	TrustOfficial  TrustLevel = "official"  // Published by the Vulntor project
+	TrustVerified  TrustLevel = "verified"  // Manifest signed by a configured key
+	TrustCommunity TrustLevel = "community" // Third-party source, installable
+	TrustUntrusted TrustLevel = "untrusted" // Installs require AllowUntrusted
+)
+
+// AllTrustLevels returns all trust levels, from most to least trusted.
+func AllTrustLevels() []TrustLevel {
+	return []TrustLevel{TrustOfficial, TrustVerified, TrustCommunity, TrustUntrusted}
+}
+
+// String returns the string representation of the trust level.
+func (t TrustLevel) String() string {
+	return string(t)
+}
+
+// IsValid checks if the trust level is one of AllTrustLevels.
+func (t TrustLevel) IsValid() bool {
+	for _, level := range AllTrustLevels() {
+		if t == level {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTrustLevel converts a string to a TrustLevel.
+func ParseTrustLevel(s string) (TrustLevel, error) {
+	level := TrustLevel(strings.ToLower(strings.TrimSpace(s)))
+	if !level.IsValid() {
+		names := make([]string, 0, len(AllTrustLevels()))
+		for _, l := range AllTrustLevels() {
+			names = append(names, string(l))
+		}
+		return "", fmt.Errorf("%w: invalid trust level '%s' (valid: %s)", ErrInvalidOption, s, strings.Join(names, ", "))
+	}
+	return level, nil
+}
+
+// TrustLevel returns the trust level of the source: its Trust when set,
+// otherwise verified for sources whose manifest must be signed and
+// community for the rest.
+func (s PluginSource) TrustLevel() TrustLevel {
+	switch {
+	case s.Trust != "":
+		return s.Trust
+	case len(s.PublicKeys) > 0:
+		return TrustVerified
+	default:
+		return TrustCommunity
+	}
+}
+
+// ApplySourceTrust returns a copy of sources whose Trust is set from
+// levels, keyed by source name. Sources with a trust level of their own
+// keep it.
+func ApplySourceTrust(sources []PluginSource, levels map[string]TrustLevel) []PluginSource {
+	result := make([]PluginSource, len(sources))
+	copy(result, sources)
+	for i := range result {
+		if level, ok := levels[result[i].Name]; ok && result[i].Trust == "" {
+			result[i].Trust = level
+		}
+	}
+	return result
+}
+
+// checkSourceTrust returns ErrUntrustedSource for plugins of untrusted
+// sources unless allowed.
+func checkSourceTrust(p PluginManifestEntry, allowUntrusted bool) error {
+	if p.Trust != TrustUntrusted || allowUntrusted {
+		return nil
+	}
+	return fmt.Errorf("%w: %s comes from source '%s'", ErrUntrustedSource, p.ID, p.Source)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustLevel(t *testing.T) {
+	for _, level := range AllTrustLevels() {
+		got, err := ParseTrustLevel(string(level))
+		require.NoError(t, err)
+		require.Equal(t, level, got)
+	}
+
+	got, err := ParseTrustLevel(" Verified ")
+	require.NoError(t, err)
+	require.Equal(t, TrustVerified, got)
+
+	_, err = ParseTrustLevel("trusted")
+	require.ErrorIs(t, err, ErrInvalidOption)
+	require.ErrorContains(t, err, "valid: official, verified, community, untrusted")
+}
+
+func TestPluginSource_TrustLevel(t *testing.T) {
+	require.Equal(t, TrustOfficial, DefaultSources()[0].TrustLevel())
+	require.Equal(t, TrustVerified, PluginSource{Name: "internal", PublicKeys: []string{"key"}}.TrustLevel())
+	require.Equal(t, TrustCommunity, PluginSource{Name: "community"}.TrustLevel())
+	require.Equal(t, TrustUntrusted, PluginSource{Name: "scratch", Trust: TrustUntrusted}.TrustLevel())
+}
+
+func TestApplySourceTrust(t *testing.T) {
+	sources := []PluginSource{
+		{Name: "official", Trust: TrustOfficial},
+		{Name: "scratch"},
+		{Name: "internal"},
+	}
+
+	got := ApplySourceTrust(sources, map[string]TrustLevel{
+		"official": TrustUntrusted,
+		"scratch":  TrustUntrusted,
+	})
+
+	require.Equal(t, TrustOfficial, got[0].Trust, "sources with their own level keep it")
+	require.Equal(t, TrustUntrusted, got[1].Trust)
+	require.Empty(t, got[2].Trust)
+	require.Empty(t, sources[1].Trust, "input is not modified")
+}
+
+func TestNewService_SourceTrust(t *testing.T) {
+	svc, err := NewService(
+		WithCacheDir(t.TempDir()),
+		WithPluginSources([]PluginSource{{Name: "scratch", URL: "https://example.com/manifest.yaml", Enabled: true}}),
+		WithSourceTrust(map[string]string{"scratch": "untrusted"}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, TrustUntrusted, svc.sources[0].Trust)
+
+	_, err = NewService(WithCacheDir(t.TempDir()), WithSourceTrust(map[string]string{"official": "trusted"}))
+	require.ErrorIs(t, err, ErrInvalidOption)
+}
+
+func TestService_Install_UntrustedSource(t *testing.T) {
+	ctx := context.Background()
+
+	var added []*ManifestEntry
+	manifest := &mockManifestManager{
+		addFunc: func(entry *ManifestEntry) error {
+			added = append(added, entry)
+			return nil
+		},
+	}
+	downloader := newDownloader(
+		func(ctx context.Context, src PluginSource) (*PluginManifest, error) {
+			return &PluginManifest{
+				Plugins: []PluginManifestEntry{
+					{ID: "scratch-check", Name: "Scratch Check", Version: "1.0.0", Categories: []Category{CategoryMisc}},
+				},
+			}, nil
+		},
+		func(ctx context.Context, id, version string) (*CacheEntry, error) {
+			return &CacheEntry{}, nil
+		},
+	)
+	cache := newCache(func(m *mockCacheManager) {
+		m.getEntryFunc = func(ctx context.Context, name, version string) (*CacheEntry, error) {
+			return nil, ErrPluginNotInstalled
+		}
+	})
+	svc := newTestService(cache, manifest, downloader, []PluginSource{
+		{Name: "scratch", URL: "https://example.com/manifest.yaml", Enabled: true, Trust: TrustUntrusted},
+	})
+
+	t.Run("refused without consent", func(t *testing.T) {
+		result, err := svc.Install(ctx, "scratch-check", InstallOptions{})
+		require.ErrorIs(t, err, ErrPartialFailure)
+		require.Equal(t, 1, result.FailedCount)
+		require.Equal(t, "SOURCE_UNTRUSTED", result.Errors[0].Code)
+		require.Contains(t, result.Errors[0].Error, "scratch-check comes from source 'scratch'")
+		require.Empty(t, added)
+	})
+
+	t.Run("installed with consent and provenance recorded", func(t *testing.T) {
+		result, err := svc.Install(ctx, "scratch-check", InstallOptions{AllowUntrusted: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, result.InstalledCount)
+		require.Equal(t, "scratch", result.Plugins[0].Source)
+		require.Equal(t, TrustUntrusted, result.Plugins[0].Trust)
+
+		require.Len(t, added, 1)
+		require.Equal(t, "scratch", added[0].Source)
+		require.Equal(t, "untrusted", added[0].Trust)
+	})
+
+	t.Run("update refused without consent", func(t *testing.T) {
+		result, err := svc.Update(ctx, UpdateOptions{})
+		require.ErrorIs(t, err, ErrPartialFailure)
+		require.Equal(t, 1, result.FailedCount)
+		require.Equal(t, "SOURCE_UNTRUSTED", result.Errors[0].Code)
+	})
+}
+
+func TestService_List_Provenance(t *testing.T) {
+	manifest := &mockManifestManager{
+		listFunc: func() ([]*ManifestEntry, error) {
+			return []*ManifestEntry{
+				{ID: "ssh-weak-kex", Name: "SSH Weak KEX", Version: "1.0.0", Source: "official", Trust: "official"},
+			}, nil
+		},
+	}
+	svc := newTestService(&mockCacheManager{}, manifest, &mockDownloader{}, nil)
+
+	plugins, err := svc.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	require.Equal(t, "official", plugins[0].Source)
+	require.Equal(t, TrustOfficial, plugins[0].Trust)
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"golang.org/x/mod/semver"
+
+	"github.com/vulntor/vulntor/pkg/version"
 )
 
 // PluginType defines the category of the plugin.
@@ -47,7 +49,8 @@ type YAMLPlugin struct {
 	Type    PluginType `yaml:"type" json:"type"`
 	Author  string     `yaml:"author" json:"author"`
 
-	// Compatibility (optional)
+	// Compatibility (optional): the oldest Vulntor release that runs the
+	// plugin. Older releases refuse to install or load it.
 	MinVulntorVersion string `yaml:"vulntor_min_version,omitempty" json:"vulntor_min_version,omitempty"`
 
 	// Metadata
@@ -59,6 +62,17 @@ type YAMLPlugin struct {
 	// Matching rules
 	Match *MatchBlock `yaml:"match,omitempty" json:"match,omitempty"`
 
+	// Values pulled from the context when the plugin matches, for plugins
+	// evaluated after it
+	Extract []Extractor `yaml:"extract,omitempty" json:"extract,omitempty"`
+
+	// Requests sent to an HTTP service in order, each matched before the
+	// next is sent; the plugin matches when every step does
+	Steps []Step `yaml:"steps,omitempty" json:"steps,omitempty"`
+
+	// Default credentials tested when credential testing is enabled
+	DefaultCredentials *CredentialsBlock `yaml:"default_credentials,omitempty" json:"default_credentials,omitempty"`
+
 	// Output format
 	Output OutputBlock `yaml:"output" json:"output"`
 
@@ -86,9 +100,12 @@ type Trigger struct {
 }
 
 // MatchBlock defines the matching logic for the plugin.
+// Nested blocks are combined with the rules under the same logic, e.g. an
+// OR of two AND groups.
 type MatchBlock struct {
-	Logic string      `yaml:"logic" json:"logic"` // AND, OR, NOT
-	Rules []MatchRule `yaml:"rules" json:"rules"` // List of rules to evaluate
+	Logic  string       `yaml:"logic" json:"logic"`                       // AND, OR, NOT
+	Rules  []MatchRule  `yaml:"rules" json:"rules"`                       // List of rules to evaluate
+	Blocks []MatchBlock `yaml:"blocks,omitempty" json:"blocks,omitempty"` // Nested blocks
 }
 
 // MatchRule is a single matching rule within a MatchBlock.
@@ -98,6 +115,103 @@ type MatchRule struct {
 	Value    any    `yaml:"value" json:"value"`       // Expected value
 }
 
+// Extractor pulls a value out of a context field and writes it back into
+// the context under Name, where later plugins can match on it.
+type Extractor struct {
+	Name  string `yaml:"name" json:"name"`   // Context key written, e.g. "http.cms.version"
+	Type  string `yaml:"type" json:"type"`   // regex or jsonpath
+	Field string `yaml:"field" json:"field"` // Context key read
+	// regex: the first match; the capture group Group, or the first group
+	// when zero and the expression has one
+	Regex string `yaml:"regex,omitempty" json:"regex,omitempty"`
+	Group int    `yaml:"group,omitempty" json:"group,omitempty"`
+	// jsonpath: a path such as $.data.items[0].version into the field's
+	// JSON document
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+}
+
+// Step is one step of a multi-step plugin: a request, then a match on the
+// response and extractors whose values later steps use as {{name}}.
+type Step struct {
+	Name    string       `yaml:"name,omitempty" json:"name,omitempty"`
+	Request *StepRequest `yaml:"request,omitempty" json:"request,omitempty"`
+	Match   *MatchBlock  `yaml:"match,omitempty" json:"match,omitempty"`
+	Extract []Extractor  `yaml:"extract,omitempty" json:"extract,omitempty"`
+}
+
+// StepRequest is the HTTP request of a step. Path, headers and body may
+// hold runtime references such as {{target.host}} or the name of a value
+// extracted by an earlier step.
+type StepRequest struct {
+	Method    string            `yaml:"method,omitempty" json:"method,omitempty"` // Default GET
+	Path      string            `yaml:"path" json:"path"`                         // e.g. "/login"
+	Headers   map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+	Body      string            `yaml:"body,omitempty" json:"body,omitempty"`
+	Redirects bool              `yaml:"redirects,omitempty" json:"redirects,omitempty"` // Follow redirects
+}
+
+// CredentialsBlock declares the default credentials a plugin tests
+// against a service. They are only sent when default-credential testing
+// is enabled for the scan.
+type CredentialsBlock struct {
+	Protocol    string           `yaml:"protocol" json:"protocol"`             // ssh, ftp, telnet, http-basic, mysql, redis
+	Ports       []int            `yaml:"ports" json:"ports"`                   // Open ports the credentials are tried on
+	Path        string           `yaml:"path,omitempty" json:"path,omitempty"` // http-basic: protected path (default "/")
+	TLS         bool             `yaml:"tls,omitempty" json:"tls,omitempty"`   // http-basic: use HTTPS
+	Credentials []CredentialPair `yaml:"credentials" json:"credentials"`
+	// Usernames and Passwords are tried in combination after Credentials,
+	// usually from wordlists: usernames: "{{wordlist:tomcat-usernames}}"
+	Usernames []string `yaml:"usernames,omitempty" json:"usernames,omitempty"`
+	Passwords []string `yaml:"passwords,omitempty" json:"passwords,omitempty"`
+}
+
+// Pairs returns the credentials to try: Credentials, then each password
+// with every user name, so that a password is tried against all users
+// before the next one. Redis blocks without Usernames try the passwords
+// alone. Duplicates are dropped.
+func (c *CredentialsBlock) Pairs() []CredentialPair {
+	pairs := make([]CredentialPair, 0, len(c.Credentials)+len(c.Usernames)*len(c.Passwords))
+	seen := make(map[CredentialPair]bool, cap(pairs))
+	add := func(pair CredentialPair) {
+		if !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+
+	for _, pair := range c.Credentials {
+		add(pair)
+	}
+	usernames := c.Usernames
+	if len(usernames) == 0 && c.Protocol == "redis" {
+		usernames = []string{""}
+	}
+	for _, password := range c.Passwords {
+		for _, username := range usernames {
+			add(CredentialPair{Username: username, Password: password})
+		}
+	}
+	return pairs
+}
+
+// CredentialPair is a username and password tried together. Redis AUTH
+// without ACLs takes a password only.
+type CredentialPair struct {
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"password"`
+}
+
+// credentialProtocols are the protocols default credentials can be
+// tested over.
+var credentialProtocols = map[string]bool{
+	"ssh":        true,
+	"ftp":        true,
+	"telnet":     true,
+	"http-basic": true,
+	"mysql":      true,
+	"redis":      true,
+}
+
 // OutputBlock defines the output format when a match succeeds.
 type OutputBlock struct {
 	Vulnerability bool              `yaml:"vulnerability" json:"vulnerability"`
@@ -105,14 +219,27 @@ type OutputBlock struct {
 	Message       string            `yaml:"message" json:"message"`
 	Remediation   string            `yaml:"remediation,omitempty" json:"remediation,omitempty"`
 	Reference     string            `yaml:"reference,omitempty" json:"reference,omitempty"`
+	References    []string          `yaml:"references,omitempty" json:"references,omitempty"` // Further advisories and write-ups
+	CVE           []string          `yaml:"cve,omitempty" json:"cve,omitempty"`               // e.g. CVE-2024-6387, besides metadata.cve
+	CWE           []string          `yaml:"cwe,omitempty" json:"cwe,omitempty"`               // e.g. CWE-287
+	Evidence      *EvidenceBlock    `yaml:"evidence,omitempty" json:"evidence,omitempty"`
 	Metadata      map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"` // Custom metadata
 }
 
+// EvidenceBlock names the context fields reported as the evidence of a
+// finding and how reports should show them.
+type EvidenceBlock struct {
+	Fields []string `yaml:"fields" json:"fields"`                     // e.g. http.headers, ssh.banner
+	Format string   `yaml:"format,omitempty" json:"format,omitempty"` // text (default), code or json
+}
+
 // YAMLMatchResult is the result of evaluating a YAML plugin against a data context.
 type YAMLMatchResult struct {
 	Matched       bool
 	Plugin        *YAMLPlugin
 	Output        OutputBlock
+	Extracted     map[string]any    // Values written into the context by the plugin's extractors
+	Evidence      map[string]string // Values of the output's evidence fields
 	EvaluatedAt   time.Time
 	ExecutionTime time.Duration
 }
@@ -174,10 +301,34 @@ func (p *YAMLPlugin) Validate() error {
 		}
 	}
 
+	// Validate extractors
+	for i := range p.Extract {
+		if err := p.Extract[i].Validate(); err != nil {
+			return fmt.Errorf("extract[%d]: %w", i, err)
+		}
+	}
+
+	// Validate steps
+	for i := range p.Steps {
+		if err := p.Steps[i].Validate(); err != nil {
+			return fmt.Errorf("step[%d]: %w", i, err)
+		}
+	}
+
+	// Validate default credentials
+	if p.DefaultCredentials != nil {
+		if err := p.DefaultCredentials.Validate(); err != nil {
+			return fmt.Errorf("default_credentials validation failed: %w", err)
+		}
+	}
+
 	// Validate output block
 	if p.Output.Message == "" {
 		return fmt.Errorf("output message is required")
 	}
+	if err := p.Output.Validate(); err != nil {
+		return fmt.Errorf("output validation failed: %w", err)
+	}
 
 	// Validate vulntor_min_version format if present
 	if p.MinVulntorVersion != "" {
@@ -221,6 +372,42 @@ func (p *YAMLPlugin) IsCompatibleWithVulntor(vulntorVersion string) (bool, error
 	return true, nil
 }
 
+// vulntorVersion returns the version of the running Vulntor, which plugins
+// are checked against on install and load.
+var vulntorVersion = func() string {
+	return version.GetVersion().Version
+}
+
+// checkVulntorVersion returns ErrIncompatibleVersion if the running Vulntor
+// is older than minVersion, the vulntor_min_version of plugin id. Development
+// builds, which have no release version, accept every plugin.
+func checkVulntorVersion(id, minVersion string) error {
+	if minVersion == "" {
+		return nil
+	}
+	current := vulntorVersion()
+	if !isValidSemver(current) {
+		return nil
+	}
+	if !isValidSemver(minVersion) {
+		return fmt.Errorf("%w: plugin %s: invalid vulntor_min_version %q", ErrInvalidInput, id, minVersion)
+	}
+	if semver.Compare(normalizeVersion(current), normalizeVersion(minVersion)) < 0 {
+		return fmt.Errorf("%w: %s requires Vulntor >= %s (current: %s)", ErrIncompatibleVersion, id, minVersion, current)
+	}
+	return nil
+}
+
+// checkCompatible checks the plugin's vulntor_min_version against the
+// running Vulntor (see checkVulntorVersion).
+func (p *YAMLPlugin) checkCompatible() error {
+	id := p.ID
+	if id == "" {
+		id = p.Name
+	}
+	return checkVulntorVersion(id, p.MinVulntorVersion)
+}
+
 // normalizeVersion ensures version string has 'v' prefix for semver compatibility.
 // Examples: "0.1.0" -> "v0.1.0", "v0.1.0" -> "v0.1.0", "dev" -> "vdev"
 func normalizeVersion(v string) string {
@@ -239,6 +426,54 @@ func isValidSemver(v string) bool {
 	return semver.IsValid(normalized)
 }
 
+// Validate validates the default credentials block.
+func (c *CredentialsBlock) Validate() error {
+	if !credentialProtocols[c.Protocol] {
+		return fmt.Errorf("invalid protocol: %q (must be ssh, ftp, telnet, http-basic, mysql, or redis)", c.Protocol)
+	}
+
+	if len(c.Ports) == 0 {
+		return fmt.Errorf("ports cannot be empty")
+	}
+	for _, port := range c.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid port: %d", port)
+		}
+	}
+
+	if len(c.Usernames) > 0 && len(c.Passwords) == 0 {
+		return fmt.Errorf("usernames need passwords to be tried with")
+	}
+	if len(c.Pairs()) == 0 {
+		return fmt.Errorf("credentials cannot be empty")
+	}
+	for i, username := range c.Usernames {
+		if username == "" || strings.ContainsAny(username, "\r\n") {
+			return fmt.Errorf("usernames[%d]: must be a non-empty single line", i)
+		}
+	}
+	for i, password := range c.Passwords {
+		if strings.ContainsAny(password, "\r\n") {
+			return fmt.Errorf("passwords[%d]: line breaks are not allowed", i)
+		}
+	}
+	for i, cred := range c.Credentials {
+		if cred.Username == "" && c.Protocol != "redis" {
+			return fmt.Errorf("credentials[%d]: username is required", i)
+		}
+		// Line-based protocols would read a line break as the end of the field
+		if strings.ContainsAny(cred.Username+cred.Password, "\r\n") {
+			return fmt.Errorf("credentials[%d]: line breaks are not allowed", i)
+		}
+	}
+
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("path must start with /: %s", c.Path)
+	}
+
+	return nil
+}
+
 // Validate validates the match block structure.
 func (m *MatchBlock) Validate() error {
 	if m.Logic == "" {
@@ -255,7 +490,7 @@ func (m *MatchBlock) Validate() error {
 		return fmt.Errorf("invalid match logic: %s (must be AND, OR, or NOT)", m.Logic)
 	}
 
-	if len(m.Rules) == 0 {
+	if len(m.Rules) == 0 && len(m.Blocks) == 0 {
 		return fmt.Errorf("match rules cannot be empty")
 	}
 
@@ -268,5 +503,11 @@ func (m *MatchBlock) Validate() error {
 		}
 	}
 
+	for i := range m.Blocks {
+		if err := m.Blocks[i].Validate(); err != nil {
+			return fmt.Errorf("block[%d]: %w", i, err)
+		}
+	}
+
 	return nil
 }
@@ -306,6 +306,28 @@ func TestMatchBlock_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "operator is required",
 		},
+		{
+			name: "nested blocks only",
+			match: &MatchBlock{
+				Logic: "OR",
+				Blocks: []MatchBlock{
+					{Logic: "AND", Rules: []MatchRule{{Field: "test", Operator: "equals", Value: "test"}}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid nested block",
+			match: &MatchBlock{
+				Logic: "OR",
+				Rules: []MatchRule{{Field: "test", Operator: "equals", Value: "test"}},
+				Blocks: []MatchBlock{
+					{Logic: "AND"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "block[0]: match rules cannot be empty",
+		},
 	}
 
 	for _, tt := range tests {
@@ -575,3 +597,119 @@ func TestPlugin_Validate_WithMinVulntorVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestCredentialsBlock_Validate(t *testing.T) {
+	valid := func() *CredentialsBlock {
+		return &CredentialsBlock{
+			Protocol:    "ssh",
+			Ports:       []int{22},
+			Credentials: []CredentialPair{{Username: "root", Password: "root"}},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(c *CredentialsBlock)
+		errMsg string
+	}{
+		{"unknown protocol", func(c *CredentialsBlock) { c.Protocol = "rdp" }, "invalid protocol"},
+		{"no ports", func(c *CredentialsBlock) { c.Ports = nil }, "ports cannot be empty"},
+		{"invalid port", func(c *CredentialsBlock) { c.Ports = []int{0} }, "invalid port: 0"},
+		{"no credentials", func(c *CredentialsBlock) { c.Credentials = nil }, "credentials cannot be empty"},
+		{"missing username", func(c *CredentialsBlock) { c.Credentials[0].Username = "" }, "credentials[0]: username is required"},
+		{"line break", func(c *CredentialsBlock) { c.Credentials[0].Password = "root\r\nQUIT" }, "line breaks are not allowed"},
+		{"relative path", func(c *CredentialsBlock) { c.Protocol = "http-basic"; c.Path = "manager" }, "path must start with /"},
+		{"usernames only", func(c *CredentialsBlock) { c.Usernames = []string{"admin"} }, "usernames need passwords"},
+		{"empty username", func(c *CredentialsBlock) { c.Usernames, c.Passwords = []string{""}, []string{"admin"} }, "usernames[0]: must be a non-empty single line"},
+		{"password line break", func(c *CredentialsBlock) { c.Usernames, c.Passwords = []string{"admin"}, []string{"a\nb"} }, "passwords[0]: line breaks are not allowed"},
+		{"passwords without users", func(c *CredentialsBlock) { c.Credentials, c.Passwords = nil, []string{"admin"} }, "credentials cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.modify(c)
+			require.ErrorContains(t, c.Validate(), tt.errMsg)
+		})
+	}
+
+	// Redis AUTH without ACLs has no username
+	redis := &CredentialsBlock{Protocol: "redis", Ports: []int{6379}, Credentials: []CredentialPair{{Password: "foobared"}}}
+	require.NoError(t, redis.Validate())
+
+	// Plugins report the block's errors
+	p := &YAMLPlugin{
+		ID: "test", Name: "Test", Version: "1.0.0", Type: EvaluationType, Author: "test",
+		Metadata:           PluginMetadata{Severity: HighSeverity},
+		Output:             OutputBlock{Message: "Test"},
+		DefaultCredentials: &CredentialsBlock{Protocol: "ssh"},
+	}
+	require.ErrorContains(t, p.Validate(), "default_credentials validation failed")
+}
+
+func TestCredentialsBlock_Pairs(t *testing.T) {
+	c := &CredentialsBlock{
+		Protocol:    "ssh",
+		Ports:       []int{22},
+		Credentials: []CredentialPair{{Username: "root", Password: "root"}},
+		Usernames:   []string{"root", "admin"},
+		Passwords:   []string{"root", "changeme"},
+	}
+	require.NoError(t, c.Validate())
+	require.Equal(t, []CredentialPair{
+		{Username: "root", Password: "root"},
+		{Username: "admin", Password: "root"},
+		{Username: "root", Password: "changeme"},
+		{Username: "admin", Password: "changeme"},
+	}, c.Pairs(), "each password against all users, without duplicates")
+
+	// Redis without ACLs tries the passwords alone
+	redis := &CredentialsBlock{Protocol: "redis", Ports: []int{6379}, Passwords: []string{"foobared", "redis"}}
+	require.NoError(t, redis.Validate())
+	require.Equal(t, []CredentialPair{{Password: "foobared"}, {Password: "redis"}}, redis.Pairs())
+}
+
+func TestCheckVulntorVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		current    string
+		minVersion string
+		wantErr    error
+	}{
+		{name: "no min version", current: "v0.3.0", minVersion: ""},
+		{name: "newer Vulntor", current: "v0.4.1", minVersion: "0.4.0"},
+		{name: "same version", current: "v0.4.0", minVersion: "v0.4.0"},
+		{name: "build metadata", current: "v0.4.0+abc1234", minVersion: "0.4.0"},
+		{name: "development build", current: "vdev+unknown", minVersion: "9.0.0"},
+		{name: "older Vulntor", current: "v0.3.9", minVersion: "0.4.0", wantErr: ErrIncompatibleVersion},
+		{name: "pre-release", current: "v0.4.0-rc.1", minVersion: "0.4.0", wantErr: ErrIncompatibleVersion},
+		{name: "invalid min version", current: "v0.4.0", minVersion: "latest", wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setVulntorVersion(t, tt.current)
+
+			err := checkVulntorVersion("test-plugin", tt.minVersion)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	setVulntorVersion(t, "v0.3.0")
+	err := checkVulntorVersion("test-plugin", "0.4.0")
+	require.ErrorIs(t, err, ErrConflict)
+	require.EqualError(t, err, "version conflict: plugin requires a newer Vulntor: test-plugin requires Vulntor >= 0.4.0 (current: v0.3.0)")
+	require.Equal(t, "PLUGIN_INCOMPATIBLE", ErrorCode(err))
+}
+
+// setVulntorVersion makes plugins checked against version v for the test.
+func setVulntorVersion(t *testing.T, v string) {
+	t.Helper()
+	prev := vulntorVersion
+	vulntorVersion = func() string { return v }
+	t.Cleanup(func() { vulntorVersion = prev })
+}
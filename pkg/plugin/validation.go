@@ -37,12 +37,12 @@ func validateTarget(target string) error {
 		return fmt.Errorf("%w: target cannot be whitespace-only", ErrInvalidOption)
 	}
 
-	// Target can be a category or plugin ID
-	// Categories are already validated via Category.IsValid()
+	// Target can be a category, category pattern, or plugin ID
+	// Categories are already validated via Category.IsSelector()
 	// Plugin IDs must match pattern
 	cat := Category(target)
-	if cat.IsValid() {
-		// Valid category
+	if cat.IsSelector() {
+		// Valid category or pattern
 		return nil
 	}
 
@@ -58,19 +58,18 @@ func validateTarget(target string) error {
 //
 // Returns:
 //   - error if category is specified but invalid
-//   - nil if category is empty (optional) or valid
+//   - nil if category is empty (optional), valid, or a pattern (e.g., "database/*")
 func validateCategory(category Category) error {
 	// Empty category is valid (means "no filter")
 	if category == "" {
 		return nil
 	}
 
-	if !category.IsValid() {
-		validCategories := make([]string, 0, len(AllCategories()))
-		for _, cat := range AllCategories() {
-			validCategories = append(validCategories, string(cat))
-		}
-		return fmt.Errorf("%w: invalid category '%s' (valid: %s)", ErrInvalidOption, category, strings.Join(validCategories, ", "))
+	if category.IsPattern() {
+		return nil
+	}
+	if err := category.Validate(); err != nil {
+		return fmt.Errorf("%w: invalid category '%s': %v", ErrInvalidOption, category, err)
 	}
 
 	return nil
@@ -70,11 +70,15 @@ func TestValidateCategory(t *testing.T) {
 		{name: "valid category http", category: CategoryHTTP, wantErr: false},
 		{name: "valid category tls", category: CategoryTLS, wantErr: false},
 		{name: "valid category database", category: CategoryDatabase, wantErr: false},
+		{name: "valid subcategory", category: "database/mysql", wantErr: false},
+		{name: "valid pattern", category: "web/*", wantErr: false},
 
 		// Invalid cases
 		{name: "invalid category", category: "invalid", wantErr: true, errType: ErrInvalidOption},
 		{name: "uppercase category", category: "SSH", wantErr: true, errType: ErrInvalidOption},
 		{name: "typo category", category: "htttp", wantErr: true, errType: ErrInvalidOption},
+		{name: "unknown root", category: "mail/smtp", wantErr: true, errType: ErrInvalidOption},
+		{name: "pattern of unknown root", category: "mail/*", wantErr: true, errType: ErrInvalidOption},
 	}
 
 	for _, tt := range tests {
@@ -44,6 +44,9 @@ type ManifestWatcher struct {
 
 	// debounceTimer is the active debounce timer (if any)
 	debounceTimer *time.Timer
+
+	// onReload runs after each successful reload
+	onReload func()
 }
 
 // NewManifestWatcher creates a new manifest file watcher.
@@ -68,6 +71,12 @@ func NewManifestWatcher(manifest *ManifestManager, logger zerolog.Logger) (*Mani
 	}, nil
 }
 
+// OnReload registers fn to run after each successful reload. Call it
+// before Start.
+func (w *ManifestWatcher) OnReload(fn func()) {
+	w.onReload = fn
+}
+
 // Start begins watching the manifest file for changes.
 //
 // This method blocks until the context is canceled. It should be run
@@ -166,6 +175,9 @@ func (w *ManifestWatcher) scheduleReload() {
 		} else {
 			w.logger.Info().
 				Msg("Manifest reloaded successfully")
+			if w.onReload != nil {
+				w.onReload()
+			}
 		}
 	})
 }
@@ -696,3 +696,37 @@ func TestService_StartManifestWatcher_WatcherStartError(t *testing.T) {
 	err = service.StartManifestWatcher(ctx)
 	require.ErrorIs(t, err, context.Canceled, "Should propagate context cancellation error")
 }
+
+// TestManifestWatcher_OnReload verifies that the reload callback runs after
+// the manifest file changes.
+func TestManifestWatcher_OnReload(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"version":"1.0","plugins":{}}`), 0o644))
+
+	manifest, err := NewManifestManager(manifestPath)
+	require.NoError(t, err)
+	watcher, err := NewManifestWatcher(manifest, zerolog.Nop())
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	reloaded := make(chan struct{}, 1)
+	watcher.OnReload(func() {
+		select {
+		case reloaded <- struct{}{}:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = watcher.Start(ctx) }()
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`{"version":"1.0","plugins":{"test-plugin":{"id":"test-plugin","name":"Test","version":"1.0.0"}}}`), 0o644))
+
+	select {
+	case <-reloaded:
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnReload callback was not called")
+	}
+}
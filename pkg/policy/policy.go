@@ -0,0 +1,175 @@
+// Package policy remaps the severity of findings to the environment they
+// were found in. A telnet service is critical on an office network but may
+// be expected on an OT network, where operators want it reported as
+// medium. Overrides select findings by plugin, tag, CVE and target, and
+// apply in the environments they list.
+//
+// Severities are remapped once, when findings are produced, so reports,
+// scoring and CI gates all see the same severity. The plugin's severity is
+// kept with each remapped finding.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// Override remaps the severity of the findings it selects. A finding is
+// selected when it matches every criterion that is set; within a
+// criterion, any listed value matches.
+type Override struct {
+	// Plugins lists plugin IDs or names.
+	Plugins []string
+	// Tags lists plugin tags.
+	Tags []string
+	// CVE lists CVE identifiers.
+	CVE []string
+	// Targets limits the override to these hosts, IP addresses and CIDR
+	// ranges. Empty matches every target.
+	Targets []string
+	// Environments limits the override to these environments. Empty
+	// applies in every environment.
+	Environments []string
+	// Severity is the severity the selected findings are reported with.
+	Severity string
+	// Reason explains the override in reports.
+	Reason string
+}
+
+// Validate validates the override.
+func (o *Override) Validate() error {
+	if len(o.Plugins) == 0 && len(o.Tags) == 0 && len(o.CVE) == 0 {
+		return fmt.Errorf("plugins, tags or cve is required")
+	}
+	if severity.Rank(strings.ToLower(o.Severity)) == 0 {
+		return fmt.Errorf("invalid severity %q (must be one of %s)", o.Severity, strings.Join(severity.Levels, ", "))
+	}
+	for _, target := range o.Targets {
+		if strings.Contains(target, "/") {
+			if _, err := netip.ParsePrefix(target); err != nil {
+				return fmt.Errorf("invalid target %q: %w", target, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Finding describes a finding to the policy.
+type Finding struct {
+	Target   string
+	PluginID string
+	Plugin   string
+	Tags     []string
+	CVE      []string
+}
+
+// matches reports whether o selects f in environment.
+func (o *Override) matches(f Finding, environment string) bool {
+	if len(o.Environments) > 0 && !containsFold(o.Environments, environment) {
+		return false
+	}
+	if len(o.Plugins) > 0 && !containsFold(o.Plugins, f.PluginID) && !containsFold(o.Plugins, f.Plugin) {
+		return false
+	}
+	if len(o.Tags) > 0 && !slices.ContainsFunc(f.Tags, func(tag string) bool { return containsFold(o.Tags, tag) }) {
+		return false
+	}
+	if len(o.CVE) > 0 && !slices.ContainsFunc(f.CVE, func(id string) bool { return containsFold(o.CVE, id) }) {
+		return false
+	}
+	return matchTargets(o.Targets, f.Target)
+}
+
+// Policy holds the severity overrides of a scan run. A nil Policy
+// overrides nothing.
+type Policy struct {
+	environment string
+	overrides   []Override
+}
+
+// New returns a policy applying overrides in environment. Overrides are
+// tried in order; the first one selecting a finding applies.
+func New(environment string, overrides []Override) *Policy {
+	return &Policy{environment: environment, overrides: overrides}
+}
+
+// Environment returns the environment the policy applies in.
+func (p *Policy) Environment() string {
+	if p == nil {
+		return ""
+	}
+	return p.environment
+}
+
+// WithEnvironment returns a copy of the policy applying in environment.
+// An empty environment keeps the policy's own.
+func (p *Policy) WithEnvironment(environment string) *Policy {
+	if p == nil || environment == "" {
+		return p
+	}
+	return &Policy{environment: environment, overrides: p.overrides}
+}
+
+// Override returns the first override selecting f.
+func (p *Policy) Override(f Finding) (Override, bool) {
+	if p == nil {
+		return Override{}, false
+	}
+	for _, o := range p.overrides {
+		if o.matches(f, p.environment) {
+			o.Severity = strings.ToLower(o.Severity)
+			return o, true
+		}
+	}
+	return Override{}, false
+}
+
+func containsFold(values []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+func matchTargets(targets []string, host string) bool {
+	if len(targets) == 0 {
+		return true
+	}
+	addr, addrErr := netip.ParseAddr(host)
+	for _, target := range targets {
+		if strings.EqualFold(target, host) {
+			return true
+		}
+		if addrErr != nil {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(target); err == nil && prefix.Contains(addr.Unmap()) {
+			return true
+		}
+		if ip, err := netip.ParseAddr(target); err == nil && ip.Unmap() == addr.Unmap() {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// WithContext returns a context carrying p. A nil p leaves ctx unchanged.
+func WithContext(ctx context.Context, p *Policy) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the policy carried by ctx, or nil.
+func FromContext(ctx context.Context) *Policy {
+	p, _ := ctx.Value(contextKey{}).(*Policy)
+	return p
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverride_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		override Override
+		wantErr  string
+	}{
+		{"valid", Override{Plugins: []string{"telnet-enabled"}, Targets: []string{"10.20.0.0/16"}, Severity: "Medium"}, ""},
+		{"no selector", Override{Targets: []string{"10.0.0.1"}, Severity: "low"}, "plugins, tags or cve is required"},
+		{"bad severity", Override{Tags: []string{"telnet"}, Severity: "urgent"}, `invalid severity "urgent"`},
+		{"bad target", Override{CVE: []string{"CVE-2024-6387"}, Severity: "low", Targets: []string{"10.0.0.0/33"}}, `invalid target "10.0.0.0/33"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.override.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPolicy_Override(t *testing.T) {
+	p := New("ot", []Override{
+		{Plugins: []string{"telnet-enabled"}, Environments: []string{"OT"}, Severity: "Medium", Reason: "telnet is required by PLCs"},
+		{Tags: []string{"ssh"}, Targets: []string{"10.20.0.0/16"}, Severity: "low"},
+		{CVE: []string{"CVE-2024-6387"}, Environments: []string{"prod"}, Severity: "critical"},
+	})
+
+	telnet := Finding{Target: "10.0.0.5", PluginID: "telnet-enabled", Plugin: "Telnet Enabled"}
+	o, ok := p.Override(telnet)
+	require.True(t, ok)
+	require.Equal(t, "medium", o.Severity)
+	require.Equal(t, "telnet is required by PLCs", o.Reason)
+
+	// Plugins match by name too
+	o, ok = p.Override(Finding{Plugin: "telnet-enabled"})
+	require.True(t, ok)
+	require.Equal(t, "medium", o.Severity)
+
+	// Targets limit the override
+	ssh := Finding{Target: "10.20.3.4", PluginID: "ssh-weak-kex", Tags: []string{"SSH", "crypto"}}
+	o, ok = p.Override(ssh)
+	require.True(t, ok)
+	require.Equal(t, "low", o.Severity)
+	ssh.Target = "10.30.3.4"
+	_, ok = p.Override(ssh)
+	require.False(t, ok)
+
+	// Environments limit the override
+	regreSSHion := Finding{Target: "10.0.0.5", CVE: []string{"CVE-2024-6387"}}
+	_, ok = p.Override(regreSSHion)
+	require.False(t, ok)
+	o, ok = p.WithEnvironment("prod").Override(regreSSHion)
+	require.True(t, ok)
+	require.Equal(t, "critical", o.Severity)
+	_, ok = p.WithEnvironment("prod").Override(telnet)
+	require.False(t, ok)
+
+	require.Equal(t, "ot", p.WithEnvironment("").Environment())
+	require.Equal(t, "prod", p.WithEnvironment("prod").Environment())
+}
+
+func TestPolicy_Nil(t *testing.T) {
+	var p *Policy
+	_, ok := p.Override(Finding{PluginID: "telnet-enabled"})
+	require.False(t, ok)
+	require.Nil(t, p.WithEnvironment("ot"))
+	require.Empty(t, p.Environment())
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, FromContext(ctx))
+	require.Equal(t, ctx, WithContext(ctx, nil))
+
+	p := New("ot", nil)
+	require.Same(t, p, FromContext(WithContext(ctx, p)))
+}
@@ -0,0 +1,316 @@
+// Package redact masks credentials, cookies, tokens and other sensitive
+// strings in the evidence scans capture, before it is stored or exported.
+// Banners and HTTP responses often echo session cookies, API keys or the
+// passwords of default credential checks; reports and exports should show
+// that a secret was exposed, not the secret itself.
+//
+// A Redactor applies built-in patterns for common secrets and the deny
+// patterns of its rules, and keeps the strings its allow patterns match.
+// Rules apply to the fields they list, named as in the JSON output (e.g.
+// banner or headers), and the values nested in them, or to every field. A
+// nil *Redactor masks nothing.
+package redact
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Mask replaces the strings masked in text.
+const Mask = "[REDACTED]"
+
+// builtinPatterns match common secrets. Patterns with capture groups mask
+// only the text of their groups, keeping e.g. header names.
+var builtinPatterns = []*regexp.Regexp{
+	// Credentials and cookies in HTTP headers
+	regexp.MustCompile(`(?i)\b(?:proxy-)?authorization[ \t]*:[ \t]*([^\r\n]+)`),
+	regexp.MustCompile(`(?i)\b(?:set-)?cookie[ \t]*:[ \t]*([^\r\n]+)`),
+	// key=value and "key": "value" pairs, e.g. in query strings and JSON
+	regexp.MustCompile(`(?i)\b(?:passw(?:or)?d|pwd|secret|client[_-]?secret|(?:access|auth|refresh|api)[_-]?(?:token|key)|token|session[_-]?id)["']?[ \t]*[:=][ \t]*["']?([^\s"'&,;<>]+)`),
+	// Bearer tokens and JWTs
+	regexp.MustCompile(`(?i)\bbearer[ \t]+([A-Za-z0-9\-._~+/]+=*)`),
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	// Passwords in URLs
+	regexp.MustCompile(`(?i)\b[a-z][a-z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`),
+	// Private keys and AWS access key IDs
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+}
+
+// Rule adds patterns to the redaction of some fields.
+type Rule struct {
+	// Fields lists the fields the rule applies to, named as in the JSON
+	// output, including the values nested in them; map keys name the
+	// values of maps. Empty applies to every field.
+	Fields []string
+	// Deny lists regular expressions of strings to mask, in addition to
+	// the built-in patterns. If a pattern has capture groups, only the text
+	// of its groups is masked.
+	Deny []string
+	// Allow lists regular expressions of strings kept although a deny or
+	// built-in pattern matches them.
+	Allow []string
+}
+
+// Validate validates the rule.
+func (r *Rule) Validate() error {
+	if len(r.Deny) == 0 && len(r.Allow) == 0 {
+		return fmt.Errorf("deny or allow is required")
+	}
+	_, err := r.compile()
+	return err
+}
+
+// compiledRule is a rule with its patterns compiled.
+type compiledRule struct {
+	fields []string
+	deny   []*regexp.Regexp
+	allow  []*regexp.Regexp
+}
+
+func (r *Rule) compile() (compiledRule, error) {
+	c := compiledRule{fields: r.Fields}
+	for _, pattern := range r.Deny {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return c, fmt.Errorf("invalid deny pattern %q: %w", pattern, err)
+		}
+		c.deny = append(c.deny, re)
+	}
+	for _, pattern := range r.Allow {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return c, fmt.Errorf("invalid allow pattern %q: %w", pattern, err)
+		}
+		c.allow = append(c.allow, re)
+	}
+	return c, nil
+}
+
+// appliesTo reports whether the rule applies to the value at path, the
+// names of the fields it is nested in.
+func (c *compiledRule) appliesTo(path []string) bool {
+	if len(c.fields) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(c.fields, func(f string) bool {
+		return slices.ContainsFunc(path, func(name string) bool { return strings.EqualFold(f, name) })
+	})
+}
+
+// Redactor masks sensitive strings in scan data.
+type Redactor struct {
+	rules []compiledRule
+}
+
+// New returns a redactor applying the built-in patterns and rules.
+func New(rules []Rule) (*Redactor, error) {
+	r := &Redactor{}
+	for i, rule := range rules {
+		c, err := rule.compile()
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		r.rules = append(r.rules, c)
+	}
+	return r, nil
+}
+
+// String returns s with the sensitive strings masked, applying the rules
+// of field.
+func (r *Redactor) String(field, s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	var path []string
+	if field != "" {
+		path = []string{field}
+	}
+	return r.redact(path, s, func(string) string { return Mask })
+}
+
+// Bytes returns b with the sensitive strings overwritten by asterisks, so
+// that its length is kept. It applies the rules without fields, and is
+// meant for raw traffic such as packet payloads.
+func (r *Redactor) Bytes(b []byte) []byte {
+	if r == nil || len(b) == 0 {
+		return b
+	}
+	s := r.redact(nil, string(b), func(text string) string { return strings.Repeat("*", len(text)) })
+	if s == string(b) {
+		return b
+	}
+	return []byte(s)
+}
+
+// Data returns a copy of a scan data context with the strings of its
+// values masked.
+func (r *Redactor) Data(data map[string]interface{}) map[string]interface{} {
+	if r == nil || data == nil {
+		return data
+	}
+	out := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		out[key] = r.Value(value)
+	}
+	return out
+}
+
+// Value returns a copy of v with its strings masked. Struct fields and map
+// entries are redacted by the rules of their name; v itself is not
+// modified.
+func (r *Redactor) Value(v interface{}) interface{} {
+	if r == nil || v == nil {
+		return v
+	}
+	return r.walk(nil, reflect.ValueOf(v)).Interface()
+}
+
+// walk returns a redacted copy of v, the value at path.
+func (r *Redactor) walk(path []string, v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		s := r.redact(path, v.String(), func(string) string { return Mask })
+		if s == v.String() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.SetString(s)
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			out := reflect.New(v.Type()).Elem()
+			out.SetBytes([]byte(r.redact(path, string(v.Bytes()), func(string) string { return Mask })))
+			return out
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(r.walk(path, v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(r.walk(path, v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			keyPath := path
+			if iter.Key().Kind() == reflect.String {
+				keyPath = append(slices.Clip(path), iter.Key().String())
+			}
+			out.SetMapIndex(iter.Key(), r.walk(keyPath, iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fieldPath := path
+			if !f.Anonymous {
+				fieldPath = append(slices.Clip(path), fieldName(f))
+			}
+			out.Field(i).Set(r.walk(fieldPath, v.Field(i)))
+		}
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(r.walk(path, v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(r.walk(path, v.Elem()))
+		return out
+	}
+	return v
+}
+
+// fieldName returns the JSON name of a struct field.
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return f.Name
+	}
+	return name
+}
+
+// redact masks the matches of the deny patterns of the value at path in s,
+// replacing them by mask.
+func (r *Redactor) redact(path []string, s string, mask func(string) string) string {
+	if s == "" {
+		return s
+	}
+	var allow []*regexp.Regexp
+	deny := builtinPatterns
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if rule.appliesTo(path) {
+			deny = append(deny[:len(deny):len(deny)], rule.deny...)
+			allow = append(allow, rule.allow...)
+		}
+	}
+	allowed := func(text string) bool {
+		return slices.ContainsFunc(allow, func(re *regexp.Regexp) bool { return re.MatchString(text) })
+	}
+	for _, re := range deny {
+		s = replace(re, s, allowed, mask)
+	}
+	return s
+}
+
+// replace replaces the matches of re in s, or the text of their groups if
+// re has any, by mask unless allowed.
+func replace(re *regexp.Regexp, s string, allowed func(string) bool, mask func(string) string) string {
+	matches := re.FindAllStringSubmatchIndex(s, -1)
+	if matches == nil {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		spans := m[:2]
+		if len(m) > 2 {
+			spans = m[2:]
+		}
+		for i := 0; i < len(spans); i += 2 {
+			start, end := spans[i], spans[i+1]
+			if start < last || start == end {
+				continue // Unmatched or nested group
+			}
+			text := s[start:end]
+			if allowed(text) {
+				continue
+			}
+			b.WriteString(s[last:start])
+			b.WriteString(mask(text))
+			last = end
+		}
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
@@ -0,0 +1,180 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/compliance"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+//go:embed templates/compliance.html.tmpl
+var complianceHTMLTemplate string
+
+// complianceColumns are the columns of compliance CSV exports and of the
+// Controls sheet of compliance workbooks.
+var complianceColumns = []string{"Scope", "Control", "Title", "Status", "Plugins", "Findings", "Targets"}
+
+// ComplianceReport is a scan rolled up into the controls of a compliance
+// framework.
+type ComplianceReport struct {
+	Scan        storage.ScanMetadata
+	GeneratedAt time.Time
+	Version     string // Vulntor version that generated the report
+
+	Framework *compliance.Framework
+
+	// Results is the rollup of the whole scan, followed by one per target
+	// group of the scan.
+	Results []compliance.Result
+}
+
+// NewComplianceReport rolls the findings of data up into the controls of
+// fw. stats are the plugin executions of the scan: controls are assessed
+// by the plugins that ran without failing on every attempt.
+func NewComplianceReport(data *Data, fw *compliance.Framework, stats []plugin.ExecStats, version string) *ComplianceReport {
+	ran := make(map[string]bool)
+	for _, st := range stats {
+		if st.Attempts > st.Errors {
+			ran[st.PluginID] = true
+			ran[st.Plugin] = true
+		}
+	}
+	findings := make([]compliance.Finding, 0, len(data.Findings))
+	for _, f := range data.Findings {
+		findings = append(findings, compliance.Finding{
+			PluginID: f.PluginID,
+			Plugin:   f.Plugin,
+			Target:   f.Target,
+			Port:     f.Port,
+			Severity: normalizeSeverity(f.Severity),
+			Groups:   f.Groups,
+		})
+	}
+	return &ComplianceReport{
+		Scan:        data.Scan,
+		GeneratedAt: time.Now().UTC(),
+		Version:     version,
+		Framework:   fw,
+		Results:     fw.EvaluateGroups(findings, func(id string) bool { return ran[id] }, data.Scan.Groups),
+	}
+}
+
+// scope names the scope of a result in exports.
+func scope(res compliance.Result) string {
+	if res.Group == "" {
+		return "All targets"
+	}
+	return "Group " + res.Group
+}
+
+// controlTargets returns the endpoints of the findings that fail c,
+// without duplicates.
+func controlTargets(c compliance.ControlResult) []string {
+	targets := make([]string, 0, len(c.Findings))
+	seen := make(map[string]bool)
+	for _, f := range c.Findings {
+		ep := endpoint(Finding{Target: f.Target, Port: f.Port})
+		if !seen[ep] {
+			seen[ep] = true
+			targets = append(targets, ep)
+		}
+	}
+	return targets
+}
+
+// controlValues returns the cells of a control in complianceColumns order,
+// without Findings, which is a number.
+func controlValues(res compliance.Result, c compliance.ControlResult) []string {
+	return []string{scope(res), c.ID, c.Title, c.Status, strings.Join(c.Plugins, ", "), strings.Join(controlTargets(c), ", ")}
+}
+
+// WriteComplianceCSV writes one row per control and scope of report as CSV
+// with a header row, ending with the engagement as in WriteCSV.
+func WriteComplianceCSV(w io.Writer, report *ComplianceReport) error {
+	eng := engagementFields(report.Scan.Engagement)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(withEngagementColumns(complianceColumns, eng)); err != nil {
+		return err
+	}
+	for _, res := range report.Results {
+		for _, c := range res.Controls {
+			v := controlValues(res, c)
+			for i := range v {
+				v[i] = csvSafe(v[i])
+			}
+			row := []string{v[0], v[1], v[2], v[3], v[4], strconv.Itoa(len(c.Findings)), v[5]}
+			if err := cw.Write(withEngagementValues(row, eng)); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteComplianceXLSX writes report as an Excel workbook with two sheets:
+// Summary (control counts and coverage per scope) and Controls (as in CSV),
+// and an Engagement sheet when the scan was run under one.
+func WriteComplianceXLSX(w io.Writer, report *ComplianceReport) error {
+	summary := [][]xlsxCell{
+		{textCell("Scope"), textCell("Passed"), textCell("Failed"), textCell("Not Assessed"), textCell("Coverage")},
+	}
+	header := make([]xlsxCell, len(complianceColumns))
+	for i, c := range complianceColumns {
+		header[i] = textCell(c)
+	}
+	controls := [][]xlsxCell{header}
+	for _, res := range report.Results {
+		summary = append(summary, []xlsxCell{
+			textCell(scope(res)), numberCell(res.Passed), numberCell(res.Failed), numberCell(res.NotAssessed),
+			textCell(fmt.Sprintf("%.0f%%", res.Coverage())),
+		})
+		for _, c := range res.Controls {
+			v := controlValues(res, c)
+			controls = append(controls, []xlsxCell{
+				textCell(v[0]), textCell(v[1]), textCell(v[2]), textCell(v[3]), textCell(v[4]),
+				numberCell(len(c.Findings)), textCell(v[5]),
+			})
+		}
+	}
+	return writeWorkbook(w, append([]xlsxSheet{
+		{name: "Summary", widths: []int{24, 10, 10, 14, 10}, rows: summary},
+		{name: "Controls", widths: []int{24, 10, 60, 14, 40, 10, 40}, rows: controls, filter: true},
+	}, engagementSheet(engagementFields(report.Scan.Engagement))...))
+}
+
+// complianceFuncs are the functions available to the compliance HTML
+// template, in addition to htmlFuncs.
+var complianceFuncs = template.FuncMap{
+	"scope":   scope,
+	"targets": controlTargets,
+	"statusColor": func(status string) string {
+		switch status {
+		case compliance.StatusPass:
+			return "#2e7d32"
+		case compliance.StatusFail:
+			return "#d32f2f"
+		}
+		return "#90a4ae"
+	},
+}
+
+// WriteComplianceHTML renders report as a single self-contained HTML file.
+func WriteComplianceHTML(w io.Writer, report *ComplianceReport) error {
+	tmpl, err := template.New("compliance.html").Funcs(htmlFuncs).Funcs(complianceFuncs).Parse(complianceHTMLTemplate)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
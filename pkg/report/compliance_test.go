@@ -0,0 +1,106 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/compliance"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func sampleComplianceReport() *ComplianceReport {
+	fw := &compliance.Framework{
+		ID:      "test",
+		Name:    "Test Baseline",
+		Version: "1.0",
+		Controls: []compliance.Control{
+			{ID: "C1", Title: "No cleartext protocols"},
+			{ID: "C2", Title: "Patched software"},
+			{ID: "C3", Title: "No default credentials"},
+		},
+		Plugins: map[string][]string{
+			"open-telnet":       {"C1"},
+			"ssh-cve-2024-6387": {"C2"},
+			"ssh-default-creds": {"C3"},
+		},
+	}
+	data := &Data{
+		Scan: storage.ScanMetadata{ID: "scan-1", Target: "10.0.0.0/24", Status: "completed", Groups: []string{"office", "ot"}},
+		Findings: []Finding{
+			{Target: "10.0.0.7", Port: 23, Plugin: "Telnet Enabled", PluginID: "open-telnet", Severity: "high", Groups: []string{"office"}},
+			// Older findings without a plugin ID match by name
+			{Target: "10.0.0.8", Port: 23, Plugin: "open-telnet", Severity: "high", Groups: []string{"office"}},
+		},
+	}
+	stats := []plugin.ExecStats{
+		{PluginID: "open-telnet", Plugin: "Telnet Enabled", Attempts: 2, Matches: 2},
+		{PluginID: "ssh-cve-2024-6387", Plugin: "OpenSSH regreSSHion", Attempts: 3},
+		// Failed on every attempt: assesses nothing
+		{PluginID: "ssh-default-creds", Plugin: "SSH Default Credentials", Attempts: 1, Errors: 1},
+	}
+	return NewComplianceReport(data, fw, stats, "1.2.3")
+}
+
+func TestNewComplianceReport(t *testing.T) {
+	r := sampleComplianceReport()
+	require.Len(t, r.Results, 3)
+
+	all := r.Results[0]
+	require.Empty(t, all.Group)
+	require.Equal(t, compliance.StatusFail, all.Controls[0].Status)
+	require.Len(t, all.Controls[0].Findings, 2)
+	require.Equal(t, compliance.StatusPass, all.Controls[1].Status)
+	require.Equal(t, compliance.StatusNotAssessed, all.Controls[2].Status)
+
+	// The ot group has no findings, so its assessed controls pass
+	ot := r.Results[2]
+	require.Equal(t, "ot", ot.Group)
+	require.Equal(t, compliance.StatusPass, ot.Controls[0].Status)
+}
+
+func TestWriteComplianceCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteComplianceCSV(&buf, sampleComplianceReport()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 10) // header + 3 controls x 3 scopes
+	require.Equal(t, complianceColumns, records[0])
+	require.Equal(t, []string{"All targets", "C1", "No cleartext protocols", "fail", "open-telnet", "2", "10.0.0.7:23, 10.0.0.8:23"}, records[1])
+	require.Equal(t, []string{"Group office", "C2", "Patched software", "pass", "ssh-cve-2024-6387", "0", ""}, records[5])
+}
+
+func TestWriteComplianceXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteComplianceXLSX(&buf, sampleComplianceReport()))
+	parts := readXLSX(t, buf.Bytes())
+
+	workbook := parts["xl/workbook.xml"]
+	require.Contains(t, workbook, `<sheet name="Summary" sheetId="1" r:id="rId1"/>`)
+	require.Contains(t, workbook, `<sheet name="Controls" sheetId="2" r:id="rId2"/>`)
+
+	summary := parts["xl/worksheets/sheet1.xml"]
+	require.Contains(t, summary, `<t xml:space="preserve">Group ot</t>`)
+	require.Contains(t, summary, `<t xml:space="preserve">67%</t>`)
+
+	controls := parts["xl/worksheets/sheet2.xml"]
+	require.Contains(t, controls, `<autoFilter ref="A1:G10"/>`)
+	require.Contains(t, controls, `<c r="F2"><v>2</v></c>`)
+}
+
+func TestWriteComplianceHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteComplianceHTML(&buf, sampleComplianceReport()))
+	html := buf.String()
+
+	require.Contains(t, html, "Test Baseline 1.0 Compliance Report")
+	require.Contains(t, html, "<h2>All targets</h2>")
+	require.Contains(t, html, "<h2>Group office</h2>")
+	require.Contains(t, html, "10.0.0.7:23, 10.0.0.8:23")
+	require.Contains(t, html, ">not-assessed</span>")
+	require.Contains(t, html, "Generated by Vulntor 1.2.3")
+}
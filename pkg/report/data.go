@@ -0,0 +1,44 @@
+package report
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Data is a stored scan with the hosts and findings it recorded.
+type Data struct {
+	Scan     storage.ScanMetadata
+	Hosts    []storage.HostRecord
+	Findings []Finding
+}
+
+// Load reads a scan and its hosts and findings from scans. Scans without
+// hosts or findings files (e.g., still running or from older versions) load
+// with none. Returns a not-found error when the scan does not exist.
+func Load(ctx context.Context, scans storage.ScanStore, orgID, scanID string) (*Data, error) {
+	meta, err := scans.Get(ctx, orgID, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := storage.ReadJSONL[storage.HostRecord](ctx, scans, orgID, scanID, storage.DataTypeHosts)
+	if err != nil {
+		return nil, err
+	}
+	findings, err := storage.ReadJSONL[Finding](ctx, scans, orgID, scanID, storage.DataTypeVulnerabilities)
+	if err != nil {
+		return nil, err
+	}
+	return &Data{Scan: *meta, Hosts: hosts, Findings: findings}, nil
+}
+
+// KeepOverdue drops the findings that are not overdue at now (see
+// Finding.Overdue). statuses holds the tracked statuses of findings by ID.
+func (d *Data) KeepOverdue(statuses map[string]*storage.FindingStatus, now time.Time) {
+	d.Findings = slices.DeleteFunc(d.Findings, func(f Finding) bool {
+		return !f.Overdue(statuses[f.ID()], now)
+	})
+}
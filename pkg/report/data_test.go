@@ -0,0 +1,79 @@
+package report
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newTestBackend(t *testing.T) *storage.LocalBackend {
+	t.Helper()
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+		ID: "scan-1", Target: "10.0.0.0/24", Status: "completed", StartedAt: time.Now(),
+	}))
+	require.NoError(t, backend.Scans().WriteData(ctx, "default", "scan-1", storage.DataTypeHosts, strings.NewReader(
+		`{"ip":"10.0.0.5","ports":[{"port":22,"protocol":"tcp","service":"ssh"}]}`+"\nnot-json\n")))
+	require.NoError(t, backend.Scans().WriteData(ctx, "default", "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.0.5","port":22,"plugin":"SSH Weak MAC Algorithm","severity":"medium","message":"Weak MAC"}`+"\n")))
+
+	data, err := Load(ctx, backend.Scans(), "default", "scan-1")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.0/24", data.Scan.Target)
+	require.Len(t, data.Hosts, 1)
+	require.Equal(t, "ssh", data.Hosts[0].Ports[0].Service)
+	require.Len(t, data.Findings, 1)
+	require.Equal(t, "SSH Weak MAC Algorithm", data.Findings[0].Plugin)
+}
+
+func TestLoad_NoDataFiles(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+		ID: "scan-1", Target: "10.0.0.1", Status: "running", StartedAt: time.Now(),
+	}))
+
+	data, err := Load(ctx, backend.Scans(), "default", "scan-1")
+	require.NoError(t, err)
+	require.Empty(t, data.Hosts)
+	require.Empty(t, data.Findings)
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	backend := newTestBackend(t)
+	_, err := Load(context.Background(), backend.Scans(), "default", "missing")
+	require.True(t, storage.IsNotFound(err))
+}
+
+func TestData_KeepOverdue(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	data := &Data{Findings: []Finding{
+		{Target: "10.0.0.5", Port: 22, Plugin: "OpenSSH regreSSHion", DueAt: &past},
+		{Target: "10.0.0.7", Port: 23, Plugin: "Telnet Enabled", DueAt: &past},
+		{Target: "10.0.0.5", Port: 443, Plugin: "TLS 1.0 Enabled", DueAt: &future},
+		{Target: "10.0.0.9", Plugin: "HTTP Server Header"},
+	}}
+
+	// Triaged as accepted risk
+	telnet := data.Findings[1].ID()
+	data.KeepOverdue(map[string]*storage.FindingStatus{
+		telnet: {ID: telnet, State: storage.FindingStateAcceptedRisk},
+	}, now)
+	require.Len(t, data.Findings, 1)
+	require.Equal(t, "OpenSSH regreSSHion", data.Findings[0].Plugin)
+}
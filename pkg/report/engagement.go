@@ -0,0 +1,59 @@
+package report
+
+import (
+	"github.com/vulntor/vulntor/pkg/engagement"
+)
+
+// engagementField is a field of the engagement a scan was run under, as
+// reports show it.
+type engagementField struct {
+	Name  string
+	Value string
+}
+
+// engagementFields returns the fields of e, nil when the scan was run
+// under no engagement. Every report embeds them, so that its results can
+// be traced back to the authorization of the scan.
+func engagementFields(e *engagement.Record) []engagementField {
+	if e.IsZero() {
+		return nil
+	}
+	return []engagementField{
+		{"Client", e.Client},
+		{"Authorization", e.Authorization},
+		{"Tester", e.Tester},
+		{"Window", e.Window()},
+	}
+}
+
+// withEngagementColumns returns the columns of a CSV export followed by a
+// column per engagement field, repeating the engagement on every row.
+func withEngagementColumns(columns []string, fields []engagementField) []string {
+	out := append([]string(nil), columns...)
+	for _, f := range fields {
+		out = append(out, "Engagement "+f.Name)
+	}
+	return out
+}
+
+// withEngagementValues returns the values of a CSV row followed by the
+// engagement fields.
+func withEngagementValues(values []string, fields []engagementField) []string {
+	for _, f := range fields {
+		values = append(values, csvSafe(f.Value))
+	}
+	return values
+}
+
+// engagementSheet returns the Engagement sheet of workbooks whose scan was
+// run under an engagement.
+func engagementSheet(fields []engagementField) []xlsxSheet {
+	if len(fields) == 0 {
+		return nil
+	}
+	rows := [][]xlsxCell{{textCell("Field"), textCell("Value")}}
+	for _, f := range fields {
+		rows = append(rows, []xlsxCell{textCell(f.Name), textCell(f.Value)})
+	}
+	return []xlsxSheet{{name: "Engagement", widths: []int{20, 50}, rows: rows}}
+}
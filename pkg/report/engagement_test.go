@@ -0,0 +1,97 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+)
+
+var sampleEngagement = &engagement.Record{
+	Client:        "=ACME",
+	Authorization: "SOW-42",
+	Tester:        "alice",
+	WindowStart:   time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+	WindowEnd:     time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC),
+}
+
+func TestReports_Engagement(t *testing.T) {
+	data := sampleData()
+	data.Scan.Engagement = sampleEngagement
+	engagementRow := []string{"'=ACME", "SOW-42", "alice", "2026-12-01T00:00:00Z/2026-12-15T00:00:00Z"}
+
+	// CSV exports repeat the engagement on every row
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, data))
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Engagement Client", "Engagement Authorization", "Engagement Tester", "Engagement Window"}, records[0][len(findingColumns):])
+	for _, record := range records[1:] {
+		require.Equal(t, engagementRow, record[len(findingColumns):])
+	}
+
+	buf.Reset()
+	require.NoError(t, WriteXLSX(&buf, data))
+	summary := readXLSX(t, buf.Bytes())["xl/worksheets/sheet1.xml"]
+	require.Contains(t, summary, `<t xml:space="preserve">Authorization</t>`)
+	require.Contains(t, summary, `<t xml:space="preserve">SOW-42</t>`)
+
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(data, ""), tmpl))
+	require.Contains(t, buf.String(), "Engagement &middot; Client =ACME &middot; Authorization SOW-42 &middot; Tester alice &middot; Window 2026-12-01T00:00:00Z/2026-12-15T00:00:00Z")
+
+	// Compliance reports
+	compliance := sampleComplianceReport()
+	compliance.Scan.Engagement = sampleEngagement
+	buf.Reset()
+	require.NoError(t, WriteComplianceCSV(&buf, compliance))
+	records, err = csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, engagementRow, records[1][len(complianceColumns):])
+
+	buf.Reset()
+	require.NoError(t, WriteComplianceXLSX(&buf, compliance))
+	parts := readXLSX(t, buf.Bytes())
+	require.Contains(t, parts["xl/workbook.xml"], `<sheet name="Engagement" sheetId="3" r:id="rId3"/>`)
+	require.Contains(t, parts["xl/worksheets/sheet3.xml"], `<t xml:space="preserve">alice</t>`)
+
+	buf.Reset()
+	require.NoError(t, WriteComplianceHTML(&buf, compliance))
+	require.Contains(t, buf.String(), "Authorization SOW-42")
+
+	// Executive summaries show the engagement of the current scan
+	history := sampleHistory()
+	history[len(history)-1].Scan.Engagement = sampleEngagement
+	summaryReport := NewExecutiveSummary(history, "")
+	buf.Reset()
+	require.NoError(t, WriteExecutiveCSV(&buf, summaryReport))
+	records, err = csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, engagementRow, records[1][len(trendColumns):])
+
+	buf.Reset()
+	require.NoError(t, WriteExecutiveXLSX(&buf, summaryReport))
+	require.Contains(t, readXLSX(t, buf.Bytes())["xl/workbook.xml"], `<sheet name="Engagement" sheetId="4" r:id="rId4"/>`)
+
+	buf.Reset()
+	require.NoError(t, WriteExecutiveHTML(&buf, summaryReport))
+	require.Contains(t, buf.String(), "Tester alice")
+}
+
+func TestReports_NoEngagement(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteComplianceXLSX(&buf, sampleComplianceReport()))
+	require.NotContains(t, readXLSX(t, buf.Bytes())["xl/workbook.xml"], "Engagement")
+
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(sampleData(), ""), tmpl))
+	require.NotContains(t, buf.String(), "Engagement")
+}
@@ -0,0 +1,415 @@
+package report
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/severity"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+//go:embed templates/executive.html.tmpl
+var executiveHTMLTemplate string
+
+// maxRecurringClasses caps the finding classes listed as recurring.
+const maxRecurringClasses = 10
+
+// trendColumns are the columns of executive summary CSV exports and of the
+// Trend sheet of executive summary workbooks.
+var trendColumns = []string{"Scan ID", "Started", "Critical", "High", "Medium", "Low", "Info", "Total", "New", "Fixed"}
+
+// LoadHistory loads the scan with ID scanID and up to previous earlier
+// completed scans of the same scope: the same target groups, or the same
+// target for scans run without groups. Replays and rechecks are not part
+// of the history. Scans are returned oldest first, ending with scanID.
+func LoadHistory(ctx context.Context, scans storage.ScanStore, orgID, scanID string, previous int) ([]*Data, error) {
+	current, err := Load(ctx, scans, orgID, scanID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := storage.ScanFilter{Status: string(storage.StatusCompleted)}
+	if len(current.Scan.Groups) > 0 {
+		filter.Group = current.Scan.Groups[0]
+	}
+	list, err := scans.List(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+	var earlier []*storage.ScanMetadata
+	for _, meta := range list {
+		if meta.ID == scanID || meta.ReplayOf != "" || meta.RecheckOf != "" ||
+			!meta.StartedAt.Before(current.Scan.StartedAt) || !sameScope(meta, &current.Scan) {
+			continue
+		}
+		earlier = append(earlier, meta)
+	}
+	sort.Slice(earlier, func(i, j int) bool { return earlier[i].StartedAt.After(earlier[j].StartedAt) })
+	earlier = earlier[:min(len(earlier), max(previous, 0))]
+
+	history := make([]*Data, len(earlier), len(earlier)+1)
+	for i, meta := range earlier {
+		data, err := Load(ctx, scans, orgID, meta.ID)
+		if err != nil {
+			return nil, err
+		}
+		history[len(earlier)-1-i] = data
+	}
+	return append(history, current), nil
+}
+
+// sameScope reports whether a and b scanned the same target groups, or the
+// same target when b was run without groups.
+func sameScope(a, b *storage.ScanMetadata) bool {
+	if len(b.Groups) == 0 {
+		return len(a.Groups) == 0 && a.Target == b.Target
+	}
+	x, y := slices.Clone(a.Groups), slices.Clone(b.Groups)
+	slices.Sort(x)
+	slices.Sort(y)
+	return slices.Equal(x, y)
+}
+
+// ExecutiveSummary compares a scan with the previous scans of its scope,
+// for leadership reporting.
+type ExecutiveSummary struct {
+	Scan        storage.ScanMetadata // the current scan
+	GeneratedAt time.Time
+	Version     string // Vulntor version that generated the report
+
+	// Trend has a point per scan, oldest first, ending with the current
+	// scan.
+	Trend []TrendPoint
+
+	// Remediation is the mean time to remediate of all findings fixed
+	// within the history, followed by that of each severity.
+	Remediation []Remediation
+
+	// Recurring are the finding classes reported by the most scans, at
+	// most 10.
+	Recurring []FindingClass
+}
+
+// TrendPoint is the findings of one scan of the history.
+type TrendPoint struct {
+	ScanID    string
+	StartedAt time.Time
+	Counts    map[string]int // findings by severity
+	Total     int
+	New       int // findings the previous scan did not report
+	Fixed     int // findings of the previous scan no longer reported
+}
+
+// Remediation is the mean time to remediate of fixed findings. A finding
+// is fixed when a scan no longer reports it; it took from when it was first
+// seen to the start of that scan.
+type Remediation struct {
+	Severity string // empty for findings of every severity
+	Fixed    int
+	Mean     time.Duration
+}
+
+// FindingClass is the findings of one plugin across the history.
+type FindingClass struct {
+	PluginID string
+	Plugin   string
+	Severity string // most severe of its findings
+	Scans    int    // scans of the history that reported it
+	Findings int    // findings in the current scan
+}
+
+// NewExecutiveSummary summarizes history, oldest scan first, for an
+// executive summary of its last scan.
+func NewExecutiveSummary(history []*Data, version string) *ExecutiveSummary {
+	s := &ExecutiveSummary{GeneratedAt: time.Now().UTC(), Version: version}
+	if len(history) == 0 {
+		return s
+	}
+	s.Scan = history[len(history)-1].Scan
+
+	type fixedFinding struct {
+		severity string
+		took     time.Duration
+	}
+	var fixed []fixedFinding
+	firstSeen := make(map[string]time.Time)
+	classes := make(map[string]*FindingClass)
+	var previous map[string]Finding
+	for i, data := range history {
+		point := TrendPoint{ScanID: data.Scan.ID, StartedAt: data.Scan.StartedAt, Counts: make(map[string]int)}
+		current := make(map[string]Finding, len(data.Findings))
+		inScan := make(map[string]bool)
+		for _, f := range data.Findings {
+			point.Counts[normalizeSeverity(f.Severity)]++
+			point.Total++
+
+			id := f.ID()
+			current[id] = f
+			if _, ok := firstSeen[id]; !ok {
+				seen := data.Scan.StartedAt
+				if f.FirstSeen != nil && f.FirstSeen.Before(seen) {
+					seen = *f.FirstSeen
+				}
+				firstSeen[id] = seen
+			}
+			if i > 0 {
+				if _, ok := previous[id]; !ok {
+					point.New++
+				}
+			}
+
+			key := f.PluginID
+			if key == "" {
+				key = f.Plugin
+			}
+			class, ok := classes[key]
+			if !ok {
+				class = &FindingClass{PluginID: f.PluginID, Plugin: f.Plugin}
+				classes[key] = class
+			}
+			if sev := normalizeSeverity(f.Severity); class.Severity == "" || severity.Rank(sev) > severity.Rank(class.Severity) {
+				class.Severity = sev
+			}
+			if !inScan[key] {
+				inScan[key] = true
+				class.Scans++
+			}
+			if i == len(history)-1 {
+				class.Findings++
+			}
+		}
+		for id, f := range previous {
+			if _, ok := current[id]; ok {
+				continue
+			}
+			point.Fixed++
+			fixed = append(fixed, fixedFinding{severity: normalizeSeverity(f.Severity), took: data.Scan.StartedAt.Sub(firstSeen[id])})
+			// A finding reported again later is counted as a new one
+			delete(firstSeen, id)
+		}
+		s.Trend = append(s.Trend, point)
+		previous = current
+	}
+
+	mean := func(severity string) Remediation {
+		r := Remediation{Severity: severity}
+		var total time.Duration
+		for _, f := range fixed {
+			if severity == "" || f.severity == severity {
+				r.Fixed++
+				total += f.took
+			}
+		}
+		if r.Fixed > 0 {
+			r.Mean = total / time.Duration(r.Fixed)
+		}
+		return r
+	}
+	s.Remediation = append(s.Remediation, mean(""))
+	for _, sev := range severity.Levels {
+		s.Remediation = append(s.Remediation, mean(sev))
+	}
+
+	for _, class := range classes {
+		s.Recurring = append(s.Recurring, *class)
+	}
+	sort.Slice(s.Recurring, func(i, j int) bool {
+		a, b := s.Recurring[i], s.Recurring[j]
+		if a.Scans != b.Scans {
+			return a.Scans > b.Scans
+		}
+		if ra, rb := severity.Rank(a.Severity), severity.Rank(b.Severity); ra != rb {
+			return ra > rb
+		}
+		if a.Findings != b.Findings {
+			return a.Findings > b.Findings
+		}
+		return a.Plugin < b.Plugin
+	})
+	s.Recurring = s.Recurring[:min(len(s.Recurring), maxRecurringClasses)]
+	return s
+}
+
+// Current returns the trend point of the current scan.
+func (s *ExecutiveSummary) Current() TrendPoint {
+	if len(s.Trend) == 0 {
+		return TrendPoint{}
+	}
+	return s.Trend[len(s.Trend)-1]
+}
+
+// TrendBar is a stacked bar of the severity trend chart.
+type TrendBar struct {
+	X        int
+	Label    string // date of the scan, shown below the bar
+	Total    int    // shown above the bar
+	LabelY   float64
+	AxisY    int
+	Segments []TrendSegment
+}
+
+// TrendSegment is the findings of one severity in a TrendBar.
+type TrendSegment struct {
+	Severity string
+	Y        float64
+	Height   float64
+}
+
+// Trend chart geometry, in SVG user units. Labels take 12 units above
+// and 16 below the bars.
+const (
+	chartBarWidth  = 32
+	chartBarGap    = 16
+	chartBarHeight = 100
+	chartHeight    = chartBarHeight + 28
+)
+
+// ChartWidth returns the width of the severity trend chart.
+func (s *ExecutiveSummary) ChartWidth() int {
+	return len(s.Trend) * (chartBarWidth + chartBarGap)
+}
+
+// Chart returns the bars of the severity trend chart: one per scan, most
+// severe findings on top, scaled to the largest scan.
+func (s *ExecutiveSummary) Chart() []TrendBar {
+	highest := 1
+	for _, p := range s.Trend {
+		highest = max(highest, p.Total)
+	}
+	bars := make([]TrendBar, 0, len(s.Trend))
+	for i, p := range s.Trend {
+		bar := TrendBar{
+			X:     i*(chartBarWidth+chartBarGap) + chartBarGap/2,
+			Label: p.StartedAt.UTC().Format("Jan 2"),
+			Total: p.Total,
+			AxisY: chartBarHeight + 12,
+		}
+		scale := float64(chartBarHeight) / float64(highest)
+		stacked := 0
+		for j := len(severity.Levels) - 1; j >= 0; j-- {
+			sev := severity.Levels[j]
+			if p.Counts[sev] == 0 {
+				continue
+			}
+			stacked += p.Counts[sev]
+			bar.Segments = append(bar.Segments, TrendSegment{
+				Severity: sev,
+				Y:        chartBarHeight - float64(stacked)*scale,
+				Height:   float64(p.Counts[sev]) * scale,
+			})
+		}
+		bar.LabelY = chartBarHeight - float64(stacked)*scale - 3
+		bars = append(bars, bar)
+	}
+	return bars
+}
+
+// formatDuration formats a remediation time in days, or hours when
+// shorter than a day.
+func formatDuration(d time.Duration) string {
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%.0f hours", d.Hours())
+	}
+	return fmt.Sprintf("%.1f days", d.Hours()/24)
+}
+
+// trendValues returns the cells of p in trendColumns order.
+func trendValues(p TrendPoint) []string {
+	values := []string{p.ScanID, p.StartedAt.UTC().Format(time.RFC3339)}
+	for _, sev := range severity.Levels {
+		values = append(values, strconv.Itoa(p.Counts[sev]))
+	}
+	return append(values, strconv.Itoa(p.Total), strconv.Itoa(p.New), strconv.Itoa(p.Fixed))
+}
+
+// WriteExecutiveCSV writes the severity trend of summary as CSV, one row
+// per scan, oldest first, ending with the engagement of the current scan
+// as in WriteCSV.
+func WriteExecutiveCSV(w io.Writer, summary *ExecutiveSummary) error {
+	eng := engagementFields(summary.Scan.Engagement)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(withEngagementColumns(trendColumns, eng)); err != nil {
+		return err
+	}
+	for _, p := range summary.Trend {
+		if err := cw.Write(withEngagementValues(trendValues(p), eng)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteExecutiveXLSX writes summary as an Excel workbook with three sheets:
+// Trend (as in CSV), Remediation (mean time to remediate by severity) and
+// Recurring (the most recurring finding classes), and an Engagement sheet
+// when the current scan was run under one.
+func WriteExecutiveXLSX(w io.Writer, summary *ExecutiveSummary) error {
+	header := func(names ...string) []xlsxCell {
+		cells := make([]xlsxCell, len(names))
+		for i, n := range names {
+			cells[i] = textCell(n)
+		}
+		return cells
+	}
+
+	trend := [][]xlsxCell{header(trendColumns...)}
+	for _, p := range summary.Trend {
+		row := []xlsxCell{textCell(p.ScanID), textCell(p.StartedAt.UTC().Format(time.RFC3339))}
+		for _, sev := range severity.Levels {
+			row = append(row, numberCell(p.Counts[sev]))
+		}
+		trend = append(trend, append(row, numberCell(p.Total), numberCell(p.New), numberCell(p.Fixed)))
+	}
+
+	remediation := [][]xlsxCell{header("Severity", "Fixed", "Mean Time to Remediate")}
+	for _, r := range summary.Remediation {
+		severity, mean := r.Severity, ""
+		if severity == "" {
+			severity = "all"
+		}
+		if r.Fixed > 0 {
+			mean = formatDuration(r.Mean)
+		}
+		remediation = append(remediation, []xlsxCell{textCell(severity), numberCell(r.Fixed), textCell(mean)})
+	}
+
+	recurring := [][]xlsxCell{header("Plugin", "Plugin ID", "Severity", "Scans", "Current Findings")}
+	for _, c := range summary.Recurring {
+		recurring = append(recurring, []xlsxCell{
+			textCell(c.Plugin), textCell(c.PluginID), textCell(c.Severity), numberCell(c.Scans), numberCell(c.Findings),
+		})
+	}
+
+	return writeWorkbook(w, append([]xlsxSheet{
+		{name: "Trend", widths: []int{38, 22, 10, 10, 10, 10, 10, 10, 10, 10}, rows: trend, filter: true},
+		{name: "Remediation", widths: []int{12, 10, 24}, rows: remediation},
+		{name: "Recurring", widths: []int{36, 28, 10, 10, 16}, rows: recurring, filter: true},
+	}, engagementSheet(engagementFields(summary.Scan.Engagement))...))
+}
+
+// WriteExecutiveHTML renders summary as a single self-contained HTML file.
+func WriteExecutiveHTML(w io.Writer, summary *ExecutiveSummary) error {
+	funcs := template.FuncMap{
+		"duration":    formatDuration,
+		"chartHeight": func() int { return chartHeight },
+		"severities":  func() []string { return severity.Levels },
+		"barWidth":    func() int { return chartBarWidth },
+	}
+	tmpl, err := template.New("executive.html").Funcs(htmlFuncs).Funcs(funcs).Parse(executiveHTMLTemplate)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, summary); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
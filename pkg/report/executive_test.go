@@ -0,0 +1,171 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+var day0 = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func sampleHistory() []*Data {
+	telnet := Finding{Target: "10.0.0.7", Port: 23, Plugin: "Telnet Enabled", PluginID: "open-telnet", Severity: "high"}
+	regresshion := Finding{Target: "10.0.0.5", Port: 22, Plugin: "OpenSSH regreSSHion", PluginID: "ssh-cve-2024-6387", Severity: "critical"}
+	weakMAC := Finding{Target: "10.0.0.5", Port: 22, Plugin: "SSH Weak MAC Algorithm", PluginID: "ssh-weak-mac", Severity: "medium"}
+	telnet2 := telnet
+	telnet2.Target = "10.0.0.8"
+
+	scan := func(id string, days int, findings ...Finding) *Data {
+		return &Data{
+			Scan:     storage.ScanMetadata{ID: id, Target: "10.0.0.0/24", Status: "completed", StartedAt: day0.AddDate(0, 0, days)},
+			Findings: findings,
+		}
+	}
+	return []*Data{
+		scan("scan-1", 0, telnet, regresshion, weakMAC),
+		scan("scan-2", 7, telnet, weakMAC),           // regreSSHion fixed after 7 days
+		scan("scan-3", 21, telnet, telnet2, weakMAC), // new telnet host
+	}
+}
+
+func TestNewExecutiveSummary(t *testing.T) {
+	s := NewExecutiveSummary(sampleHistory(), "1.2.3")
+	require.Equal(t, "scan-3", s.Scan.ID)
+	require.Len(t, s.Trend, 3)
+
+	require.Equal(t, TrendPoint{ScanID: "scan-1", StartedAt: day0, Counts: map[string]int{"critical": 1, "high": 1, "medium": 1}, Total: 3}, s.Trend[0])
+	require.Equal(t, 0, s.Trend[1].New)
+	require.Equal(t, 1, s.Trend[1].Fixed)
+	require.Equal(t, 1, s.Trend[2].New)
+	require.Equal(t, 0, s.Trend[2].Fixed)
+	require.Equal(t, s.Trend[2], s.Current())
+
+	require.Equal(t, Remediation{Fixed: 1, Mean: 7 * 24 * time.Hour}, s.Remediation[0])
+	require.Equal(t, Remediation{Severity: "critical", Fixed: 1, Mean: 7 * 24 * time.Hour}, s.Remediation[1])
+	require.Equal(t, Remediation{Severity: "high"}, s.Remediation[2])
+
+	// Most scans first, then most severe
+	require.Len(t, s.Recurring, 3)
+	require.Equal(t, FindingClass{PluginID: "open-telnet", Plugin: "Telnet Enabled", Severity: "high", Scans: 3, Findings: 2}, s.Recurring[0])
+	require.Equal(t, "ssh-weak-mac", s.Recurring[1].PluginID)
+	require.Equal(t, FindingClass{PluginID: "ssh-cve-2024-6387", Plugin: "OpenSSH regreSSHion", Severity: "critical", Scans: 1}, s.Recurring[2])
+}
+
+func TestNewExecutiveSummary_FirstSeen(t *testing.T) {
+	history := sampleHistory()
+	// Tracked findings remember sightings before the history
+	seen := day0.AddDate(0, 0, -3)
+	history[0].Findings[1].FirstSeen = &seen
+
+	s := NewExecutiveSummary(history, "")
+	require.Equal(t, 10*24*time.Hour, s.Remediation[0].Mean)
+}
+
+func TestExecutiveSummary_Chart(t *testing.T) {
+	bars := NewExecutiveSummary(sampleHistory(), "").Chart()
+	require.Len(t, bars, 3)
+	require.Equal(t, "Jun 1", bars[0].Label)
+
+	// Least severe at the bottom, scaled to the largest scan
+	segments := bars[0].Segments
+	require.Len(t, segments, 3)
+	for i, want := range []TrendSegment{
+		{Severity: "medium", Y: 200.0 / 3, Height: 100.0 / 3},
+		{Severity: "high", Y: 100.0 / 3, Height: 100.0 / 3},
+		{Severity: "critical", Y: 0, Height: 100.0 / 3},
+	} {
+		require.Equal(t, want.Severity, segments[i].Severity)
+		require.InDelta(t, want.Y, segments[i].Y, 0.001)
+		require.InDelta(t, want.Height, segments[i].Height, 0.001)
+	}
+	require.InDelta(t, 100.0/3-3, bars[1].LabelY, 0.001)
+}
+
+func TestLoadHistory(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+	scans := backend.Scans()
+
+	create := func(meta storage.ScanMetadata, findings string) {
+		meta.Target, meta.Status = "10.0.0.0/24", "completed"
+		require.NoError(t, scans.Create(ctx, storage.DefaultOrgID, &meta))
+		if findings != "" {
+			require.NoError(t, scans.WriteData(ctx, storage.DefaultOrgID, meta.ID, storage.DataTypeVulnerabilities, strings.NewReader(findings)))
+		}
+	}
+	finding := `{"target":"10.0.0.7","port":23,"plugin":"Telnet Enabled","severity":"high"}` + "\n"
+	create(storage.ScanMetadata{ID: "old", Groups: []string{"office"}, StartedAt: day0}, finding)
+	create(storage.ScanMetadata{ID: "prev", Groups: []string{"office"}, StartedAt: day0.AddDate(0, 0, 7)}, finding)
+	create(storage.ScanMetadata{ID: "recheck", Groups: []string{"office"}, RecheckOf: "a89bdcaa21b8a1bd", StartedAt: day0.AddDate(0, 0, 8)}, "")
+	create(storage.ScanMetadata{ID: "other", Groups: []string{"office", "ot"}, StartedAt: day0.AddDate(0, 0, 9)}, "")
+	create(storage.ScanMetadata{ID: "current", Groups: []string{"office"}, StartedAt: day0.AddDate(0, 0, 14)}, "")
+	create(storage.ScanMetadata{ID: "later", Groups: []string{"office"}, StartedAt: day0.AddDate(0, 0, 21)}, "")
+
+	history, err := LoadHistory(ctx, scans, storage.DefaultOrgID, "current", 5)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(history))
+	for _, d := range history {
+		ids = append(ids, d.Scan.ID)
+	}
+	require.Equal(t, []string{"old", "prev", "current"}, ids)
+	require.Len(t, history[1].Findings, 1)
+
+	history, err = LoadHistory(ctx, scans, storage.DefaultOrgID, "current", 1)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	require.Equal(t, "prev", history[0].Scan.ID)
+
+	_, err = LoadHistory(ctx, scans, storage.DefaultOrgID, "missing", 5)
+	require.True(t, storage.IsNotFound(err))
+}
+
+func TestWriteExecutiveCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteExecutiveCSV(&buf, NewExecutiveSummary(sampleHistory(), "")))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, trendColumns, records[0])
+	require.Equal(t, []string{"scan-2", "2025-06-08T00:00:00Z", "0", "1", "1", "0", "0", "2", "0", "1"}, records[2])
+}
+
+func TestWriteExecutiveXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteExecutiveXLSX(&buf, NewExecutiveSummary(sampleHistory(), "")))
+	parts := readXLSX(t, buf.Bytes())
+
+	workbook := parts["xl/workbook.xml"]
+	require.Contains(t, workbook, `<sheet name="Trend" sheetId="1" r:id="rId1"/>`)
+	require.Contains(t, workbook, `<sheet name="Remediation" sheetId="2" r:id="rId2"/>`)
+	require.Contains(t, workbook, `<sheet name="Recurring" sheetId="3" r:id="rId3"/>`)
+
+	require.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="H2"><v>3</v></c>`)
+	require.Contains(t, parts["xl/worksheets/sheet2.xml"], `<t xml:space="preserve">7.0 days</t>`)
+	require.Contains(t, parts["xl/worksheets/sheet3.xml"], `<t xml:space="preserve">open-telnet</t>`)
+}
+
+func TestWriteExecutiveHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteExecutiveHTML(&buf, NewExecutiveSummary(sampleHistory(), "1.2.3")))
+	html := buf.String()
+
+	require.Contains(t, html, "trend over 3 scans")
+	require.Contains(t, html, "1 is new and 0 were fixed since the previous scan")
+	require.Contains(t, html, "<rect x=")
+	require.Contains(t, html, "7.0 days")
+	require.Contains(t, html, "<strong>Telnet Enabled</strong>")
+	require.Contains(t, html, "Generated by Vulntor 1.2.3")
+
+	// A scan without history still renders
+	buf.Reset()
+	require.NoError(t, WriteExecutiveHTML(&buf, NewExecutiveSummary(sampleHistory()[:1], "")))
+	require.Contains(t, buf.String(), "No earlier scan of 10.0.0.0/24 was found to compare with.")
+}
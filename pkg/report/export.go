@@ -0,0 +1,195 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// FindingRow is a finding flattened with the service it was found on, as
+// exported to CSV and xlsx.
+type FindingRow struct {
+	Host        string
+	Hostnames   string
+	Port        int
+	Protocol    string
+	Service     string
+	Product     string
+	Version     string
+	Plugin      string
+	PluginID    string
+	Severity    string
+	CVE         string
+	CWE         string
+	Evidence    string
+	Remediation string
+	Reference   string
+
+	// OriginalSeverity is the plugin's severity of findings the severity
+	// policy remapped, for SeverityReason
+	OriginalSeverity string
+	SeverityReason   string
+
+	Groups    string
+	GroupTags string
+
+	// Cloud instance of the host, for routing findings to its owners
+	CloudAccount   string
+	CloudRegion    string
+	CloudInstance  string
+	SecurityGroups string
+
+	// FindingID identifies the finding across scans (see Finding.ID)
+	FindingID string
+
+	// DueDate is the remediation due date of the finding (YYYY-MM-DD)
+	DueDate string
+
+	// Confidence is "low" for findings on possible honeypots and tarpits,
+	// with ConfidenceReason saying why
+	Confidence       string
+	ConfidenceReason string
+
+	// Intermediary is the CDN or WAF in front of the host, e.g.
+	// "Cloudflare (cdn)"
+	Intermediary string
+}
+
+// findingColumns are the export column headers, in FindingRow field order.
+// New columns go last, so spreadsheets reading earlier exports by column
+// keep working.
+var findingColumns = []string{
+	"Host", "Hostnames", "Port", "Protocol", "Service", "Product", "Version",
+	"Plugin", "Plugin ID", "Severity", "CVE", "CWE", "Evidence", "Remediation", "Reference",
+	"Original Severity", "Severity Reason", "Groups", "Group Tags",
+	"Cloud Account", "Cloud Region", "Cloud Instance", "Security Groups",
+	"Finding ID", "Due Date", "Confidence", "Confidence Reason", "Intermediary",
+}
+
+func (r FindingRow) values() []string {
+	port := ""
+	if r.Port > 0 {
+		port = strconv.Itoa(r.Port)
+	}
+	return []string{
+		r.Host, r.Hostnames, port, r.Protocol, r.Service, r.Product, r.Version,
+		r.Plugin, r.PluginID, r.Severity, r.CVE, r.CWE, r.Evidence, r.Remediation, r.Reference,
+		r.OriginalSeverity, r.SeverityReason, r.Groups, r.GroupTags,
+		r.CloudAccount, r.CloudRegion, r.CloudInstance, r.SecurityGroups,
+		r.FindingID, r.DueDate, r.Confidence, r.ConfidenceReason, r.Intermediary,
+	}
+}
+
+// FindingRows flattens the findings of data, most severe first. Service
+// details come from the host records of the scan when the finding's host
+// and port were recorded.
+func FindingRows(data *Data) []FindingRow {
+	type endpointKey struct {
+		ip   string
+		port int
+	}
+	hostnames := make(map[string]string)
+	services := make(map[endpointKey]storage.ServiceRecord)
+	for _, h := range data.Hosts {
+		if len(h.Hostnames) > 0 {
+			hostnames[h.IP] = strings.Join(h.Hostnames, ", ")
+		}
+		for _, p := range h.Ports {
+			services[endpointKey{h.IP, p.Port}] = p
+		}
+	}
+
+	findings := sortedFindings(data.Findings)
+	rows := make([]FindingRow, 0, len(findings))
+	for _, f := range findings {
+		row := FindingRow{
+			Host:        f.Target,
+			Hostnames:   hostnames[f.Target],
+			Port:        f.Port,
+			Plugin:      f.Plugin,
+			PluginID:    f.PluginID,
+			Severity:    normalizeSeverity(f.Severity),
+			CVE:         strings.Join(f.CVE, ", "),
+			CWE:         strings.Join(f.CWE, ", "),
+			Evidence:    findingEvidence(f),
+			Remediation: f.Remediation,
+			Reference:   strings.Join(f.AllReferences(), ", "),
+
+			SeverityReason: f.SeverityReason,
+			Groups:         strings.Join(f.Groups, ", "),
+			GroupTags:      strings.Join(f.GroupTags, ", "),
+			FindingID:      f.ID(),
+
+			Confidence:       f.Confidence,
+			ConfidenceReason: f.ConfidenceReason,
+			Intermediary:     f.Intermediary.String(),
+		}
+		if f.OriginalSeverity != "" {
+			row.OriginalSeverity = normalizeSeverity(f.OriginalSeverity)
+		}
+		if f.DueAt != nil {
+			row.DueDate = f.DueAt.UTC().Format(time.DateOnly)
+		}
+		if c := f.Cloud; c != nil {
+			row.CloudAccount = c.Account
+			row.CloudRegion = c.Region
+			row.CloudInstance = c.InstanceID
+			row.SecurityGroups = strings.Join(c.SecurityGroups, ", ")
+		}
+		if svc, ok := services[endpointKey{f.Target, f.Port}]; ok && f.Port > 0 {
+			row.Protocol = svc.Protocol
+			row.Service = svc.Service
+			row.Product = svc.Product
+			row.Version = svc.Version
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// findingEvidence returns the message of f followed by its evidence, one
+// "field: value" line per field.
+func findingEvidence(f Finding) string {
+	var b strings.Builder
+	b.WriteString(f.Message)
+	for _, field := range f.EvidenceFields() {
+		b.WriteString("\n" + field + ": " + f.Evidence[field])
+	}
+	return b.String()
+}
+
+// WriteCSV writes the findings of data as CSV with a header row. Scans run
+// under an engagement end every row with its client, authorization,
+// tester and window.
+func WriteCSV(w io.Writer, data *Data) error {
+	eng := engagementFields(data.Scan.Engagement)
+	cw := csv.NewWriter(w)
+	if err := cw.Write(withEngagementColumns(findingColumns, eng)); err != nil {
+		return err
+	}
+	for _, row := range FindingRows(data) {
+		values := row.values()
+		for i, v := range values {
+			values[i] = csvSafe(v)
+		}
+		if err := cw.Write(withEngagementValues(values, eng)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvSafe neutralizes values a spreadsheet would evaluate as a formula.
+// Scan data such as banners and messages comes from scanned hosts and must
+// not be able to run formulas when the export is opened.
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
@@ -0,0 +1,152 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestFindingRows(t *testing.T) {
+	data := sampleData()
+	data.Hosts[1].Ports[0].Product = "OpenSSH"
+	data.Hosts[1].Ports[0].Version = "8.9p1"
+
+	rows := FindingRows(data)
+	require.Len(t, rows, 4)
+
+	// Most severe first, joined with the service on the same host and port
+	require.Equal(t, FindingRow{
+		Host:      "10.0.0.5",
+		Hostnames: "db.local",
+		Port:      22,
+		Protocol:  "tcp",
+		Service:   "ssh",
+		Product:   "OpenSSH",
+		Version:   "8.9p1",
+		Plugin:    "OpenSSH regreSSHion",
+		Severity:  "critical",
+		CVE:       "CVE-2024-6387",
+		Evidence:  "Vulnerable OpenSSH",
+		FindingID: "14d34128101e76ec",
+	}, rows[0])
+
+	// No host record for 10.0.0.7: service columns stay empty
+	require.Equal(t, "Telnet Enabled", rows[1].Plugin)
+	require.Empty(t, rows[1].Service)
+	require.Equal(t, "info", rows[3].Severity)
+}
+
+func TestFindingRows_EvidenceAndReferences(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{{
+		Target:     "10.0.0.5",
+		Plugin:     "Exposed Server Status",
+		Message:    "mod_status is public",
+		Reference:  "https://a.example",
+		References: []string{"https://a.example", "https://b.example"},
+		Evidence:   map[string]string{"http.status_code": "200", "http.body": "Apache Server Status"},
+	}}})
+	require.Equal(t, "mod_status is public\nhttp.body: Apache Server Status\nhttp.status_code: 200", rows[0].Evidence)
+	require.Equal(t, "https://a.example, https://b.example", rows[0].Reference)
+}
+
+func TestFindingRows_OriginalSeverity(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{{
+		Target:           "10.20.0.5",
+		Plugin:           "Telnet Enabled",
+		Severity:         "medium",
+		OriginalSeverity: "HIGH",
+		SeverityReason:   "PLC management",
+	}}})
+	require.Equal(t, "medium", rows[0].Severity)
+	require.Equal(t, "high", rows[0].OriginalSeverity)
+	require.Equal(t, "PLC management", rows[0].SeverityReason)
+}
+
+func TestFindingRows_Groups(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{{
+		Target:    "10.0.1.5",
+		Plugin:    "Telnet Enabled",
+		Groups:    []string{"prod-web", "dmz"},
+		GroupTags: []string{"prod", "pci"},
+	}}})
+	require.Equal(t, "prod-web, dmz", rows[0].Groups)
+	require.Equal(t, "prod, pci", rows[0].GroupTags)
+}
+
+func TestFindingRows_Cloud(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{{
+		Target: "10.0.1.5",
+		Plugin: "Telnet Enabled",
+		Cloud: &storage.CloudRecord{
+			Provider:       "aws",
+			InstanceID:     "i-0001",
+			Account:        "123456789012",
+			Region:         "eu-west-1",
+			SecurityGroups: []string{"sg-web", "sg-ssh"},
+		},
+	}, {Target: "10.0.1.6", Plugin: "Telnet Enabled"}}})
+	require.Equal(t, "123456789012", rows[0].CloudAccount)
+	require.Equal(t, "eu-west-1", rows[0].CloudRegion)
+	require.Equal(t, "i-0001", rows[0].CloudInstance)
+	require.Equal(t, "sg-web, sg-ssh", rows[0].SecurityGroups)
+	require.Empty(t, rows[1].CloudInstance)
+}
+
+func TestFindingRows_DueDate(t *testing.T) {
+	due := time.Date(2025, 3, 8, 10, 0, 0, 0, time.UTC)
+	rows := FindingRows(&Data{Findings: []Finding{{Target: "10.0.1.5", Plugin: "Telnet Enabled", DueAt: &due}}})
+	require.Equal(t, "2025-03-08", rows[0].DueDate)
+}
+
+func TestFindingRows_Confidence(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{
+		{Target: "10.0.1.5", Plugin: "Telnet Enabled", Confidence: ConfidenceLow, ConfidenceReason: "Possible honeypot or tarpit: open on 412 ports"},
+		{Target: "10.0.1.6", Plugin: "Telnet Enabled"},
+	}})
+	require.Equal(t, "low", rows[0].Confidence)
+	require.Equal(t, "Possible honeypot or tarpit: open on 412 ports", rows[0].ConfidenceReason)
+	require.Empty(t, rows[1].Confidence)
+}
+
+func TestFindingRows_Intermediary(t *testing.T) {
+	rows := FindingRows(&Data{Findings: []Finding{
+		{Target: "104.16.1.1", Plugin: "HSTS Missing", Intermediary: &cdn.Intermediary{Name: "Cloudflare", Kind: cdn.KindCDN, Via: cdn.ViaIP}},
+		{Target: "10.0.1.6", Plugin: "HSTS Missing"},
+	}})
+	require.Equal(t, "10.0.1.6", rows[0].Host)
+	require.Empty(t, rows[0].Intermediary)
+	require.Equal(t, "Cloudflare (cdn)", rows[1].Intermediary)
+}
+
+func TestWriteCSV(t *testing.T) {
+	data := sampleData()
+	data.Findings = append(data.Findings, Finding{Target: "10.0.0.5", Port: 80, Plugin: "Banner", Severity: "low", Message: "=HYPERLINK(\"http://evil\")"})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, data))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	require.Equal(t, findingColumns, records[0])
+	require.Equal(t, []string{"10.0.0.5", "db.local", "22", "tcp", "ssh", "", "", "OpenSSH regreSSHion", "", "critical", "CVE-2024-6387", "", "Vulnerable OpenSSH", "", "", "", "", "", "", "", "", "", "", "14d34128101e76ec", "", "", "", ""}, records[1])
+	// Port column is empty when the finding has no port
+	require.Equal(t, "", records[5][2])
+	// Formula-like evidence is neutralized
+	require.Equal(t, `'=HYPERLINK("http://evil")`, records[4][12])
+}
+
+func TestWriteCSV_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, &Data{}))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{findingColumns}, records)
+}
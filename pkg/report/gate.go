@@ -0,0 +1,134 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// GateExitCodes maps the highest severity of the findings that failed a
+// gate to the exit code of the scan, so that CI pipelines can tell a
+// critical finding from a low one. Codes 10-14 do not overlap the general
+// CLI exit codes.
+var GateExitCodes = map[string]int{
+	"info":     10,
+	"low":      11,
+	"medium":   12,
+	"high":     13,
+	"critical": 14,
+}
+
+var cvePattern = regexp.MustCompile(`^CVE-\d{4}-\d{4,}$`)
+
+// Gate fails a scan whose findings reach a severity or reference given
+// CVEs. Low-confidence findings never fail it. The zero Gate lets every
+// scan pass.
+type Gate struct {
+	// FailOn is the lowest severity that fails the gate; empty disables
+	// the severity check.
+	FailOn string
+	// CVEs fail the gate when a finding references one of them.
+	CVEs []string
+}
+
+// NewGate validates and normalizes a gate. failOn must be empty or a
+// severity of severity.Levels, and cves must be CVE IDs such as CVE-2024-3094.
+func NewGate(failOn string, cves []string) (Gate, error) {
+	g := Gate{FailOn: strings.ToLower(strings.TrimSpace(failOn))}
+	if g.FailOn != "" && severity.Rank(g.FailOn) == 0 {
+		return Gate{}, fmt.Errorf("invalid --fail-on severity: %q (must be %s)", failOn, strings.Join(severity.Levels, ", "))
+	}
+	for _, cve := range cves {
+		id := strings.ToUpper(strings.TrimSpace(cve))
+		if id == "" {
+			continue
+		}
+		if !cvePattern.MatchString(id) {
+			return Gate{}, fmt.Errorf("invalid --fail-on-cve ID: %q (expected CVE-YYYY-NNNN)", cve)
+		}
+		if !slices.Contains(g.CVEs, id) {
+			g.CVEs = append(g.CVEs, id)
+		}
+	}
+	return g, nil
+}
+
+// Enabled reports whether the gate can fail a scan.
+func (g Gate) Enabled() bool {
+	return g.FailOn != "" || len(g.CVEs) > 0
+}
+
+// Check returns a *GateError when findings fail the gate, nil otherwise.
+func (g Gate) Check(findings []Finding) error {
+	minRank := severity.Rank(g.FailOn)
+
+	var failed []Finding
+	var cves []string
+	for _, f := range findings {
+		if f.LowConfidence() {
+			continue
+		}
+		matched := g.FailOn != "" && severity.Rank(normalizeSeverity(f.Severity)) >= minRank
+		for _, cve := range f.CVE {
+			id := strings.ToUpper(strings.TrimSpace(cve))
+			if slices.Contains(g.CVEs, id) {
+				matched = true
+				if !slices.Contains(cves, id) {
+					cves = append(cves, id)
+				}
+			}
+		}
+		if matched {
+			failed = append(failed, f)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	highest := "info"
+	for _, f := range failed {
+		if s := normalizeSeverity(f.Severity); severity.Rank(s) > severity.Rank(highest) {
+			highest = s
+		}
+	}
+	slices.Sort(cves)
+	return &GateError{Severity: highest, Findings: len(failed), CVEs: cves, FailOn: g.FailOn}
+}
+
+// GateError reports findings that failed a gate.
+type GateError struct {
+	// Severity is the highest severity of the failing findings.
+	Severity string
+	// Findings is the number of failing findings.
+	Findings int
+	// CVEs lists the gated CVEs that were found.
+	CVEs []string
+	// FailOn is the severity threshold of the gate, if any.
+	FailOn string
+}
+
+func (e *GateError) Error() string {
+	var reasons []string
+	if e.FailOn != "" {
+		reasons = append(reasons, "severity "+e.FailOn+" or higher")
+	}
+	if len(e.CVEs) > 0 {
+		reasons = append(reasons, strings.Join(e.CVEs, ", "))
+	}
+	return fmt.Sprintf("scan gate failed: %d finding(s) matched %s (highest severity: %s)",
+		e.Findings, strings.Join(reasons, " or "), e.Severity)
+}
+
+// Code returns the CLI error code of gate failures.
+func (e *GateError) Code() string {
+	return "SCAN_GATE_FAILED"
+}
+
+// ExitCode returns the exit code for the highest failing severity.
+func (e *GateError) ExitCode() int {
+	return GateExitCodes[e.Severity]
+}
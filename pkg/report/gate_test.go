@@ -0,0 +1,88 @@
+package report
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGate(t *testing.T) {
+	g, err := NewGate(" High ", []string{"cve-2024-3094", "CVE-2024-3094", ""})
+	require.NoError(t, err)
+	require.Equal(t, Gate{FailOn: "high", CVEs: []string{"CVE-2024-3094"}}, g)
+	require.True(t, g.Enabled())
+
+	g, err = NewGate("", nil)
+	require.NoError(t, err)
+	require.False(t, g.Enabled())
+
+	_, err = NewGate("severe", nil)
+	require.ErrorContains(t, err, `invalid --fail-on severity: "severe"`)
+
+	_, err = NewGate("", []string{"CVE-24-1"})
+	require.ErrorContains(t, err, `invalid --fail-on-cve ID: "CVE-24-1"`)
+}
+
+func TestGateCheck(t *testing.T) {
+	findings := []Finding{
+		{Target: "10.0.0.5", Severity: "medium", Plugin: "Weak MAC"},
+		{Target: "10.0.0.5", Severity: "HIGH", Plugin: "Old OpenSSH", CVE: []string{"CVE-2023-38408"}},
+		{Target: "10.0.0.6", Severity: "low", Plugin: "xz backdoor", CVE: []string{"cve-2024-3094"}},
+	}
+
+	tests := []struct {
+		name     string
+		gate     Gate
+		wantErr  bool
+		severity string
+		count    int
+		cves     []string
+		exitCode int
+	}{
+		{name: "zero gate passes", gate: Gate{}},
+		{name: "below threshold passes", gate: Gate{FailOn: "critical"}},
+		{name: "severity threshold", gate: Gate{FailOn: "medium"}, wantErr: true, severity: "high", count: 2, exitCode: 13},
+		{name: "lowest threshold", gate: Gate{FailOn: "info"}, wantErr: true, severity: "high", count: 3, exitCode: 13},
+		{name: "cve match", gate: Gate{CVEs: []string{"CVE-2024-3094"}}, wantErr: true, severity: "low", count: 1, cves: []string{"CVE-2024-3094"}, exitCode: 11},
+		{name: "cve not found", gate: Gate{CVEs: []string{"CVE-2021-44228"}}},
+		{name: "severity or cve", gate: Gate{FailOn: "critical", CVEs: []string{"CVE-2023-38408"}}, wantErr: true, severity: "high", count: 1, cves: []string{"CVE-2023-38408"}, exitCode: 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gate.Check(findings)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var gateErr *GateError
+			require.True(t, errors.As(err, &gateErr))
+			require.Equal(t, tt.severity, gateErr.Severity)
+			require.Equal(t, tt.count, gateErr.Findings)
+			require.Equal(t, tt.cves, gateErr.CVEs)
+			require.Equal(t, tt.exitCode, gateErr.ExitCode())
+		})
+	}
+}
+
+func TestGateError_Error(t *testing.T) {
+	err := &GateError{Severity: "critical", Findings: 2, FailOn: "high", CVEs: []string{"CVE-2024-3094"}}
+	require.Equal(t, "scan gate failed: 2 finding(s) matched severity high or higher or CVE-2024-3094 (highest severity: critical)", err.Error())
+	require.Equal(t, 14, err.ExitCode())
+}
+
+func TestGateCheck_LowConfidence(t *testing.T) {
+	findings := []Finding{
+		{Target: "10.0.0.5", Severity: "critical", Plugin: "Backdoor Port", CVE: []string{"CVE-2024-3094"}, Confidence: ConfidenceLow},
+		{Target: "10.0.0.6", Severity: "medium", Plugin: "Weak MAC"},
+	}
+
+	// Findings on possible honeypots never fail the gate
+	require.NoError(t, Gate{FailOn: "high", CVEs: []string{"CVE-2024-3094"}}.Check(findings))
+
+	var gateErr *GateError
+	require.True(t, errors.As(Gate{FailOn: "low"}.Check(findings), &gateErr))
+	require.Equal(t, "medium", gateErr.Severity)
+	require.Equal(t, 1, gateErr.Findings)
+}
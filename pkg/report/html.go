@@ -0,0 +1,260 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/severity"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+//go:embed templates/report.html.tmpl
+var defaultHTMLTemplate string
+
+// maxTopFindings caps the findings listed in the executive summary.
+const maxTopFindings = 10
+
+// severityColors are the colors used for each severity in HTML reports.
+var severityColors = map[string]string{
+	"critical": "#7b1fa2",
+	"high":     "#d32f2f",
+	"medium":   "#f57c00",
+	"low":      "#fbc02d",
+	"info":     "#1976d2",
+}
+
+// HTMLReport is the data passed to HTML report templates. Custom templates
+// (see ParseHTMLTemplate) may use any of its fields.
+type HTMLReport struct {
+	Scan        storage.ScanMetadata
+	GeneratedAt time.Time
+	Version     string // Vulntor version that generated the report
+
+	// Risk is the highest severity found, or "none".
+	Risk          string
+	TotalFindings int
+	HostCount     int
+	ServiceCount  int
+	AffectedHosts int // hosts with at least one finding
+
+	// LowConfidence counts the findings on hosts that look like honeypots
+	// or tarpits.
+	LowConfidence int
+
+	// Severities counts findings per severity, most severe first.
+	Severities []SeverityCount
+
+	// TopFindings are the most severe findings, at most 10, leaving out
+	// low-confidence ones.
+	TopFindings []Finding
+
+	// Hosts are every host seen by the scan, most exposed first and
+	// possible honeypots last.
+	Hosts []HostReport
+}
+
+// SeverityCount is the number of findings of one severity.
+type SeverityCount struct {
+	Severity string
+	Count    int
+	Percent  float64 // share of all findings, 0-100
+	Offset   float64 // sum of Percent of the more severe entries, for stacked charts
+}
+
+// HostReport is a host with its open ports and findings.
+type HostReport struct {
+	IP          string
+	Hostnames   []string
+	Ports       []storage.ServiceRecord
+	Findings    []Finding // most severe first
+	MaxSeverity string    // empty when the host has no findings
+
+	// Honeypot says why the host looks like a honeypot or tarpit, empty
+	// if it does not.
+	Honeypot string
+
+	// Intermediary is the CDN or WAF the host was found behind, or nil.
+	Intermediary *cdn.Intermediary
+}
+
+// NewHTMLReport summarizes data for an HTML report.
+func NewHTMLReport(data *Data, version string) *HTMLReport {
+	r := &HTMLReport{
+		Scan:          data.Scan,
+		GeneratedAt:   time.Now().UTC(),
+		Version:       version,
+		Risk:          "none",
+		TotalFindings: len(data.Findings),
+	}
+
+	findings := sortedFindings(data.Findings)
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[normalizeSeverity(f.Severity)]++
+	}
+	var offset float64
+	for _, sev := range severity.Levels {
+		sc := SeverityCount{Severity: sev, Count: counts[sev], Offset: offset}
+		if r.TotalFindings > 0 {
+			sc.Percent = float64(sc.Count) * 100 / float64(r.TotalFindings)
+		}
+		offset += sc.Percent
+		r.Severities = append(r.Severities, sc)
+		if sc.Count > 0 && r.Risk == "none" {
+			r.Risk = sev
+		}
+	}
+	for _, f := range findings {
+		if f.LowConfidence() {
+			r.LowConfidence++
+		} else if len(r.TopFindings) < maxTopFindings {
+			r.TopFindings = append(r.TopFindings, f)
+		}
+	}
+
+	r.Hosts = hostReports(data.Hosts, findings)
+	for _, h := range r.Hosts {
+		r.ServiceCount += len(h.Ports)
+		if len(h.Findings) > 0 {
+			r.AffectedHosts++
+		}
+	}
+	r.HostCount = len(r.Hosts)
+
+	return r
+}
+
+// hostReports merges host records with the findings on each host, most
+// exposed hosts first. findings must already be sorted by severity. Hosts
+// that only appear in findings still get an entry.
+func hostReports(hosts []storage.HostRecord, findings []Finding) []HostReport {
+	byIP := make(map[string]*HostReport)
+	var order []string
+	host := func(ip string) *HostReport {
+		h, ok := byIP[ip]
+		if !ok {
+			h = &HostReport{IP: ip}
+			byIP[ip] = h
+			order = append(order, ip)
+		}
+		return h
+	}
+	for _, rec := range hosts {
+		h := host(rec.IP)
+		h.Hostnames = append(h.Hostnames, rec.Hostnames...)
+		h.Ports = append(h.Ports, rec.Ports...)
+		if rec.Intermediary != nil {
+			h.Intermediary = rec.Intermediary
+		}
+	}
+	for _, f := range findings {
+		h := host(f.Target)
+		h.Findings = append(h.Findings, f)
+		if f.LowConfidence() && h.Honeypot == "" {
+			h.Honeypot = f.ConfidenceReason
+		}
+		if h.Intermediary == nil {
+			h.Intermediary = f.Intermediary
+		}
+		if h.MaxSeverity == "" {
+			h.MaxSeverity = normalizeSeverity(f.Severity)
+		}
+	}
+
+	reports := make([]HostReport, 0, len(order))
+	for _, ip := range order {
+		h := byIP[ip]
+		sort.Slice(h.Ports, func(i, j int) bool { return h.Ports[i].Port < h.Ports[j].Port })
+		reports = append(reports, *h)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		a, b := reports[i], reports[j]
+		if (a.Honeypot == "") != (b.Honeypot == "") {
+			return a.Honeypot == ""
+		}
+		if ra, rb := severity.Rank(a.MaxSeverity), severity.Rank(b.MaxSeverity); ra != rb {
+			return ra > rb
+		}
+		if len(a.Findings) != len(b.Findings) {
+			return len(a.Findings) > len(b.Findings)
+		}
+		return a.IP < b.IP
+	})
+	return reports
+}
+
+// sortedFindings returns a copy of findings ordered by severity (most
+// severe first), then target and port.
+func sortedFindings(findings []Finding) []Finding {
+	out := append([]Finding(nil), findings...)
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if ra, rb := severity.Rank(normalizeSeverity(a.Severity)), severity.Rank(normalizeSeverity(b.Severity)); ra != rb {
+			return ra > rb
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Port < b.Port
+	})
+	return out
+}
+
+// htmlFuncs are the functions available to HTML report templates.
+var htmlFuncs = template.FuncMap{
+	"upper":         strings.ToUpper,
+	"join":          strings.Join,
+	"severity":      normalizeSeverity,
+	"severityColor": func(s string) string { return severityColors[normalizeSeverity(s)] },
+	"endpoint":      endpoint,
+	"engagement":    engagementFields,
+	"formatTime": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+	"percent": func(part, total int) float64 {
+		if total == 0 {
+			return 0
+		}
+		return float64(part) * 100 / float64(total)
+	},
+}
+
+// ParseHTMLTemplate parses the HTML report template at path, or returns the
+// built-in template when path is empty. Templates are executed with an
+// *HTMLReport and may use the helper functions upper, join, severity,
+// severityColor, endpoint, engagement (the Name and Value of each field of
+// .Scan.Engagement), formatTime and percent.
+func ParseHTMLTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return template.New("report.html").Funcs(htmlFuncs).Parse(defaultHTMLTemplate)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(htmlFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// WriteHTML renders report with tmpl to w.
+func WriteHTML(w io.Writer, report *HTMLReport, tmpl *template.Template) error {
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func sampleData() *Data {
+	return &Data{
+		Scan: storage.ScanMetadata{ID: "scan-1", Target: "10.0.0.0/24", Status: "completed"},
+		Hosts: []storage.HostRecord{
+			{IP: "10.0.0.9", Ports: []storage.ServiceRecord{{Port: 80, Protocol: "tcp", Service: "http"}}},
+			{IP: "10.0.0.5", Hostnames: []string{"db.local"}, Ports: []storage.ServiceRecord{
+				{Port: 22, Protocol: "tcp", Service: "ssh"},
+				{Port: 5432, Protocol: "tcp", Service: "postgresql"},
+			}},
+		},
+		Findings: []Finding{
+			{Target: "10.0.0.5", Port: 22, Plugin: "SSH Weak MAC Algorithm", Severity: "medium", Message: "Weak MAC"},
+			{Target: "10.0.0.5", Port: 22, Plugin: "OpenSSH regreSSHion", Severity: "critical", Message: "Vulnerable OpenSSH", CVE: []string{"CVE-2024-6387"}},
+			{Target: "10.0.0.7", Port: 23, Plugin: "Telnet Enabled", Severity: "high", Message: "Telnet <open>"},
+			{Target: "10.0.0.7", Plugin: "Odd Severity", Severity: "bogus"},
+		},
+	}
+}
+
+func TestNewHTMLReport(t *testing.T) {
+	r := NewHTMLReport(sampleData(), "1.2.3")
+
+	require.Equal(t, "critical", r.Risk)
+	require.Equal(t, 4, r.TotalFindings)
+	require.Equal(t, 3, r.HostCount)
+	require.Equal(t, 2, r.AffectedHosts)
+	require.Equal(t, 3, r.ServiceCount)
+
+	require.Len(t, r.Severities, 5)
+	require.Equal(t, SeverityCount{Severity: "critical", Count: 1, Percent: 25}, r.Severities[0])
+	require.Equal(t, SeverityCount{Severity: "high", Count: 1, Percent: 25, Offset: 25}, r.Severities[1])
+	require.Equal(t, SeverityCount{Severity: "info", Count: 1, Percent: 25, Offset: 75}, r.Severities[4])
+
+	require.Equal(t, "OpenSSH regreSSHion", r.TopFindings[0].Plugin)
+	require.Equal(t, "Odd Severity", r.TopFindings[3].Plugin)
+
+	// Most exposed hosts first; hosts only known from findings are included
+	require.Equal(t, "10.0.0.5", r.Hosts[0].IP)
+	require.Equal(t, "critical", r.Hosts[0].MaxSeverity)
+	require.Equal(t, 22, r.Hosts[0].Ports[0].Port)
+	require.Equal(t, "10.0.0.7", r.Hosts[1].IP)
+	require.Equal(t, "high", r.Hosts[1].MaxSeverity)
+	require.Equal(t, "10.0.0.9", r.Hosts[2].IP)
+	require.Empty(t, r.Hosts[2].MaxSeverity)
+}
+
+func TestNewHTMLReport_LowConfidence(t *testing.T) {
+	data := sampleData()
+	reason := "Possible honeypot or tarpit: open on 500 ports"
+	data.Findings = append(data.Findings,
+		Finding{Target: "10.0.0.66", Port: 23, Plugin: "Telnet Enabled", Severity: "critical", Confidence: ConfidenceLow, ConfidenceReason: reason},
+	)
+	r := NewHTMLReport(data, "")
+
+	require.Equal(t, 1, r.LowConfidence)
+	require.Len(t, r.TopFindings, 4)
+	for _, f := range r.TopFindings {
+		require.NotEqual(t, "10.0.0.66", f.Target)
+	}
+
+	// Possible honeypots sort last despite their severity
+	last := r.Hosts[len(r.Hosts)-1]
+	require.Equal(t, "10.0.0.66", last.IP)
+	require.Equal(t, reason, last.Honeypot)
+	require.Empty(t, r.Hosts[0].Honeypot)
+
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, r, tmpl))
+	require.Contains(t, buf.String(), "possible honeypot")
+	require.Contains(t, buf.String(), "low confidence")
+}
+
+func TestNewHTMLReport_Intermediary(t *testing.T) {
+	data := sampleData()
+	data.Hosts[0].Intermediary = &cdn.Intermediary{Name: "Cloudflare", Kind: cdn.KindCDN, Via: cdn.ViaHeader, Evidence: "Cf-Ray"}
+	r := NewHTMLReport(data, "")
+	require.Equal(t, "10.0.0.9", r.Hosts[2].IP)
+	require.Equal(t, "Cloudflare", r.Hosts[2].Intermediary.Name)
+	require.Nil(t, r.Hosts[0].Intermediary)
+
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, r, tmpl))
+	require.Contains(t, buf.String(), `title="header: Cf-Ray">behind Cloudflare</span>`)
+}
+
+func TestNewHTMLReport_NoFindings(t *testing.T) {
+	r := NewHTMLReport(&Data{Scan: storage.ScanMetadata{ID: "scan-1"}}, "")
+	require.Equal(t, "none", r.Risk)
+	require.Zero(t, r.Severities[0].Percent)
+	require.Empty(t, r.TopFindings)
+}
+
+func TestWriteHTML_DefaultTemplate(t *testing.T) {
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(sampleData(), "1.2.3"), tmpl))
+
+	html := buf.String()
+	require.Contains(t, html, "<title>Vulntor Scan Report - 10.0.0.0/24</title>")
+	require.Contains(t, html, "Executive Summary")
+	require.Contains(t, html, "CVE-2024-6387")
+	require.Contains(t, html, "db.local")
+	require.Contains(t, html, "5432/tcp")
+	require.Contains(t, html, "Generated by Vulntor 1.2.3")
+	// Scan data is escaped
+	require.Contains(t, html, "Telnet &lt;open&gt;")
+	require.NotContains(t, html, "Telnet <open>")
+	// Self-contained: no external stylesheets or scripts
+	require.NotContains(t, html, "<link")
+	require.NotContains(t, html, "<script")
+}
+
+func TestWriteHTML_FindingDetails(t *testing.T) {
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+
+	data := &Data{Findings: []Finding{{
+		Target:         "10.0.0.5",
+		Port:           80,
+		Plugin:         "Exposed Server Status",
+		Severity:       "medium",
+		CWE:            []string{"CWE-200"},
+		Reference:      "https://httpd.apache.org/docs/2.4/mod/mod_status.html",
+		References:     []string{"https://httpd.apache.org/docs/2.4/mod/mod_status.html", "javascript:alert(1)"},
+		Evidence:       map[string]string{"http.headers": "Server: Apache\r\nX-Status: <ok>"},
+		EvidenceFormat: "code",
+	}}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(data, ""), tmpl))
+
+	html := buf.String()
+	require.Contains(t, html, "CWE-200")
+	require.Contains(t, html, `<a href="https://httpd.apache.org/docs/2.4/mod/mod_status.html">`)
+	require.NotContains(t, html, `href="javascript:`)
+	require.Contains(t, html, "<pre>Server: Apache\r\nX-Status: &lt;ok&gt;</pre>")
+}
+
+func TestWriteHTML_OriginalSeverity(t *testing.T) {
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+
+	data := &Data{Findings: []Finding{{
+		Target:           "10.20.0.5",
+		Port:             23,
+		Plugin:           "Telnet Enabled",
+		Severity:         "medium",
+		OriginalSeverity: "high",
+		SeverityReason:   "PLC management",
+	}}}
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(data, ""), tmpl))
+
+	html := buf.String()
+	require.Contains(t, html, `<div class="remapped" title="PLC management">was high</div>`)
+	// Risk follows the remapped severity
+	require.Equal(t, "medium", NewHTMLReport(data, "").Risk)
+}
+
+func TestWriteHTML_NoFindings(t *testing.T) {
+	tmpl, err := ParseHTMLTemplate("")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(&Data{Scan: storage.ScanMetadata{ID: "scan-1", Target: "10.0.0.1"}}, ""), tmpl))
+	require.Contains(t, buf.String(), "found no vulnerabilities")
+	require.Contains(t, buf.String(), "No hosts were recorded")
+}
+
+func TestParseHTMLTemplate_Custom(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corporate.html")
+	require.NoError(t, os.WriteFile(path, []byte(`<h1>ACME {{.Scan.ID}}</h1>{{range .Severities}}{{upper .Severity}}={{.Count}} {{end}}`), 0o644))
+
+	tmpl, err := ParseHTMLTemplate(path)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHTML(&buf, NewHTMLReport(sampleData(), ""), tmpl))
+	require.Equal(t, "<h1>ACME scan-1</h1>CRITICAL=1 HIGH=1 MEDIUM=1 LOW=0 INFO=1 ", buf.String())
+}
+
+func TestParseHTMLTemplate_Errors(t *testing.T) {
+	_, err := ParseHTMLTemplate(filepath.Join(t.TempDir(), "missing.html"))
+	require.ErrorContains(t, err, "read template")
+
+	path := filepath.Join(t.TempDir(), "broken.html")
+	require.NoError(t, os.WriteFile(path, []byte(`{{.Scan.ID`), 0o644))
+	_, err = ParseHTMLTemplate(path)
+	require.ErrorContains(t, err, "parse template")
+
+	path = filepath.Join(t.TempDir(), "unknown-field.html")
+	require.NoError(t, os.WriteFile(path, []byte(`{{.Nope}}`), 0o644))
+	tmpl, err := ParseHTMLTemplate(path)
+	require.NoError(t, err)
+	require.ErrorContains(t, WriteHTML(&bytes.Buffer{}, NewHTMLReport(sampleData(), ""), tmpl), "render report")
+}
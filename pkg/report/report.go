@@ -0,0 +1,163 @@
+// Package report renders scan findings for people and other tools: SARIF
+// for CI code scanning, self-contained HTML reports, and CSV and xlsx
+// exports for spreadsheet triage.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/severity"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+// FindingsKey is the data context key holding the findings produced by
+// plugin evaluation.
+const FindingsKey = "evaluation.vulnerabilities"
+
+// ConfidenceLow is the confidence of findings on hosts that look like
+// honeypots or tarpits (see package honeypot).
+const ConfidenceLow = "low"
+
+// normalizeSeverity lower-cases s, mapping unknown severities to info.
+func normalizeSeverity(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if severity.Rank(s) == 0 {
+		return "info"
+	}
+	return s
+}
+
+// Finding is a vulnerability reported by a scan.
+type Finding struct {
+	Target      string   `json:"target"`
+	Port        int      `json:"port,omitempty"`
+	Plugin      string   `json:"plugin"`
+	PluginID    string   `json:"plugin_id,omitempty"`
+	PluginType  string   `json:"plugin_type,omitempty"`
+	Severity    string   `json:"severity"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation,omitempty"`
+	CVE         []string `json:"cve,omitempty"`
+	CWE         []string `json:"cwe,omitempty"`
+	Reference   string   `json:"reference,omitempty"`
+	References  []string `json:"references,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	// Evidence maps the scan data fields behind the finding to their
+	// values; EvidenceFormat (text, code or json) says how to show them.
+	Evidence       map[string]string `json:"evidence,omitempty"`
+	EvidenceFormat string            `json:"evidence_format,omitempty"`
+
+	// OriginalSeverity is the plugin's severity when the severity policy
+	// remapped Severity, for SeverityReason.
+	OriginalSeverity string `json:"original_severity,omitempty"`
+	SeverityReason   string `json:"severity_reason,omitempty"`
+
+	// Groups names the target groups of the scan the finding's target
+	// belongs to; GroupTags are their tags.
+	Groups    []string `json:"groups,omitempty"`
+	GroupTags []string `json:"group_tags,omitempty"`
+
+	// Cloud is the cloud instance of the finding's target, for targets of
+	// a cloud inventory.
+	Cloud *storage.CloudRecord `json:"cloud,omitempty"`
+
+	// Intermediary is the CDN or WAF in front of the finding's target.
+	Intermediary *cdn.Intermediary `json:"intermediary,omitempty"`
+
+	// FirstSeen is when a scan first reported the finding and DueAt when
+	// it must be remediated by, for scans run with remediation SLAs.
+	FirstSeen *time.Time `json:"first_seen,omitempty"`
+	DueAt     *time.Time `json:"due_at,omitempty"`
+
+	// Confidence is ConfidenceLow for findings on hosts that look like
+	// honeypots or tarpits, with ConfidenceReason saying why; empty for
+	// findings reported at full confidence.
+	Confidence       string `json:"confidence,omitempty"`
+	ConfidenceReason string `json:"confidence_reason,omitempty"`
+}
+
+// ID identifies the finding across scans: the same plugin matching on the
+// same target and port always yields the same ID. It is the fingerprint
+// tickets are created with, and names the finding in rechecks.
+func (f Finding) ID() string {
+	return ticketing.Fingerprint(ticketing.Finding{Plugin: f.Plugin, Target: f.Target, Port: f.Port})
+}
+
+// Overdue reports whether f is past its due date at now. status is the
+// tracked status of the finding, if any; findings it closed are not
+// overdue.
+func (f Finding) Overdue(status *storage.FindingStatus, now time.Time) bool {
+	if f.DueAt == nil || !now.After(*f.DueAt) {
+		return false
+	}
+	return status == nil || !status.Closed()
+}
+
+// LowConfidence reports whether f was found on a host that looks like a
+// honeypot or tarpit.
+func (f Finding) LowConfidence() bool {
+	return f.Confidence == ConfidenceLow
+}
+
+// AllReferences returns Reference followed by References, without
+// duplicates.
+func (f Finding) AllReferences() []string {
+	var refs []string
+	for _, ref := range append([]string{f.Reference}, f.References...) {
+		if ref != "" && !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// EvidenceFields returns the fields of the evidence, sorted.
+func (f Finding) EvidenceFields() []string {
+	fields := make([]string, 0, len(f.Evidence))
+	for field := range f.Evidence {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// PreformattedEvidence reports whether the evidence should keep its line
+// breaks and spacing, as code and JSON evidence does.
+func (f Finding) PreformattedEvidence() bool {
+	return f.EvidenceFormat == "code" || f.EvidenceFormat == "json"
+}
+
+// FindingsFromContext extracts the findings from a scan's data context.
+// Entries may be evaluation results or their decoded map form. A context
+// without findings yields an empty slice.
+func FindingsFromContext(dataCtx map[string]interface{}) ([]Finding, error) {
+	raw, found := dataCtx[FindingsKey]
+	if !found || raw == nil {
+		return []Finding{}, nil
+	}
+	vulns, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("findings data has unexpected type: %T", raw)
+	}
+
+	findings := make([]Finding, 0, len(vulns))
+	for i, v := range vulns {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("finding %d: %w", i, err)
+		}
+		var f Finding
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("finding %d: %w", i, err)
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/modules/evaluation"
+)
+
+func TestFindingsFromContext(t *testing.T) {
+	dataCtx := map[string]interface{}{
+		FindingsKey: []interface{}{
+			evaluation.VulnerabilityResult{
+				Target:   "10.0.0.5",
+				Port:     22,
+				Plugin:   "SSH Weak MAC Algorithm",
+				PluginID: "ssh-weak-mac",
+				Severity: "medium",
+				Message:  "Weak MAC",
+				CWE:      []string{"CWE-327"},
+				References: []string{
+					"https://www.openssh.com/txt/release-7.4",
+				},
+				Evidence:       map[string]string{"ssh.banner": "SSH-2.0-OpenSSH_7.2"},
+				EvidenceFormat: "code",
+				Matched:        true,
+			},
+			map[string]interface{}{"target": "10.0.0.6", "plugin": "Telnet Enabled", "severity": "high"},
+		},
+	}
+
+	findings, err := FindingsFromContext(dataCtx)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	require.Equal(t, "ssh-weak-mac", findings[0].PluginID)
+	require.Equal(t, 22, findings[0].Port)
+	require.Equal(t, []string{"CWE-327"}, findings[0].CWE)
+	require.Equal(t, []string{"https://www.openssh.com/txt/release-7.4"}, findings[0].References)
+	require.Equal(t, map[string]string{"ssh.banner": "SSH-2.0-OpenSSH_7.2"}, findings[0].Evidence)
+	require.True(t, findings[0].PreformattedEvidence())
+	require.Equal(t, "Telnet Enabled", findings[1].Plugin)
+	require.Equal(t, "high", findings[1].Severity)
+}
+
+func TestFindingsFromContext_Empty(t *testing.T) {
+	findings, err := FindingsFromContext(map[string]interface{}{})
+	require.NoError(t, err)
+	require.NotNil(t, findings)
+	require.Empty(t, findings)
+}
+
+func TestFindingsFromContext_UnexpectedType(t *testing.T) {
+	_, err := FindingsFromContext(map[string]interface{}{FindingsKey: "oops"})
+	require.ErrorContains(t, err, "unexpected type")
+}
@@ -0,0 +1,362 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// SARIF 2.1.0 identifiers.
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+const (
+	toolName           = "Vulntor"
+	toolInformationURI = "https://github.com/vulntor/vulntor"
+
+	// fingerprintKey names the partial fingerprint consumers use to track a
+	// finding across runs. It matches the fingerprint used for tickets.
+	fingerprintKey = "vulntorFingerprint/v1"
+)
+
+// sarifLevels maps severities to SARIF result levels.
+var sarifLevels = map[string]string{
+	"critical": "error",
+	"high":     "error",
+	"medium":   "warning",
+	"low":      "note",
+	"info":     "note",
+}
+
+// securitySeverities maps severities to the CVSS-style score GitHub code
+// scanning uses to rank security alerts.
+var securitySeverities = map[string]string{
+	"critical": "9.5",
+	"high":     "8.0",
+	"medium":   "5.5",
+	"low":      "3.0",
+	"info":     "0.0",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool      `json:"tool"`
+	Results    []sarifResult  `json:"results"`
+	Properties *sarifRunProps `json:"properties,omitempty"`
+}
+
+type sarifRunProps struct {
+	Engagement *engagement.Record `json:"engagement,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name,omitempty"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	FullDescription      *sarifMessage      `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	HelpURI              string             `json:"helpUri,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifRuleProps     `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifRuleProps struct {
+	Tags             []string `json:"tags,omitempty"`
+	SecuritySeverity string   `json:"security-severity,omitempty"`
+	CVE              []string `json:"cve,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          sarifResultProps  `json:"properties"`
+}
+
+type sarifResultProps struct {
+	Severity         string   `json:"severity"`
+	OriginalSeverity string   `json:"originalSeverity,omitempty"`
+	SeverityReason   string   `json:"severityReason,omitempty"`
+	Confidence       string   `json:"confidence,omitempty"`
+	ConfidenceReason string   `json:"confidenceReason,omitempty"`
+	Target           string   `json:"target"`
+	Port             int      `json:"port,omitempty"`
+	CVE              []string `json:"cve,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+}
+
+// WriteSARIF writes findings as an indented SARIF 2.1.0 log with a single
+// run. Each plugin that reported a finding becomes a rule; each finding
+// becomes a result located at its target. toolVersion is recorded as the
+// driver version when set, and eng, the engagement the scan was run under,
+// as the engagement property of the run when not nil.
+func WriteSARIF(w io.Writer, findings []Finding, toolVersion string, eng *engagement.Record) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(buildSARIF(findings, toolVersion, eng))
+}
+
+func buildSARIF(findings []Finding, toolVersion string, eng *engagement.Record) sarifLog {
+	rules := sarifRules(findings)
+	ruleIndex := make(map[string]int, len(rules))
+	for i, r := range rules {
+		ruleIndex[r.ID] = i
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+	for _, f := range findings {
+		id := ruleID(f)
+		severity := normalizeSeverity(f.Severity)
+		level := sarifLevels[severity]
+		if f.LowConfidence() {
+			level = "note" // Likely a honeypot artifact; keep it out of the alerts
+		}
+		results = append(results, sarifResult{
+			RuleID:    id,
+			RuleIndex: ruleIndex[id],
+			Level:     level,
+			Message:   sarifMessage{Text: resultMessage(f)},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: locationURI(f)},
+					Region:           sarifRegion{StartLine: 1},
+				},
+				LogicalLocations: []sarifLogicalLocation{{
+					Name:               f.Target,
+					FullyQualifiedName: endpoint(f),
+				}},
+			}},
+			PartialFingerprints: map[string]string{
+				fingerprintKey: f.ID(),
+			},
+			Properties: sarifResultProps{
+				Severity:         severity,
+				OriginalSeverity: f.OriginalSeverity,
+				SeverityReason:   f.SeverityReason,
+				Confidence:       f.Confidence,
+				ConfidenceReason: f.ConfidenceReason,
+				Target:           f.Target,
+				Port:             f.Port,
+				CVE:              f.CVE,
+			},
+		})
+	}
+
+	return sarifLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           toolName,
+				Version:        toolVersion,
+				InformationURI: toolInformationURI,
+				Rules:          rules,
+			}},
+			Results:    results,
+			Properties: runProps(eng),
+		}},
+	}
+}
+
+func runProps(eng *engagement.Record) *sarifRunProps {
+	if eng.IsZero() {
+		return nil
+	}
+	return &sarifRunProps{Engagement: eng}
+}
+
+// sarifRules derives one rule per plugin, sorted by rule ID. A rule takes
+// the highest severity any of its findings reported.
+func sarifRules(findings []Finding) []sarifRule {
+	byID := make(map[string]*sarifRule)
+	severities := make(map[string]string)
+	for _, f := range findings {
+		id := ruleID(f)
+		sev := normalizeSeverity(f.Severity)
+		rule, ok := byID[id]
+		if !ok {
+			rule = &sarifRule{
+				ID:               id,
+				Name:             ruleName(f.Plugin),
+				ShortDescription: sarifMessage{Text: f.Plugin},
+			}
+			if f.Message != "" {
+				rule.FullDescription = &sarifMessage{Text: f.Message}
+			}
+			if f.Remediation != "" {
+				rule.Help = &sarifMessage{Text: f.Remediation, Markdown: "**Remediation:** " + f.Remediation}
+			}
+			for _, ref := range f.AllReferences() {
+				if isWebURL(ref) {
+					rule.HelpURI = ref
+					break
+				}
+			}
+			rule.Properties.Tags = ruleTags(f)
+			rule.Properties.CVE = f.CVE
+			byID[id] = rule
+			severities[id] = sev
+		}
+		if severity.Rank(sev) > severity.Rank(severities[id]) {
+			severities[id] = sev
+		}
+	}
+
+	rules := make([]sarifRule, 0, len(byID))
+	for id, rule := range byID {
+		rule.DefaultConfiguration.Level = sarifLevels[severities[id]]
+		rule.Properties.SecuritySeverity = securitySeverities[severities[id]]
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	return rules
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ruleID identifies the plugin behind a finding: its ID, or a slug of its
+// name for findings recorded without one.
+func ruleID(f Finding) string {
+	if f.PluginID != "" {
+		return f.PluginID
+	}
+	if slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(f.Plugin), "-"), "-"); slug != "" {
+		return slug
+	}
+	return "unknown"
+}
+
+// ruleName returns the plugin name in the PascalCase form SARIF recommends
+// for rule names, e.g. "SSH Weak MAC Algorithm" becomes "SshWeakMacAlgorithm".
+func ruleName(plugin string) string {
+	var b strings.Builder
+	for _, word := range nonSlug.Split(strings.ToLower(plugin), -1) {
+		if word == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]))
+		b.WriteString(word[1:])
+	}
+	return b.String()
+}
+
+// ruleTags returns the rule tags: "security", the plugin's own tags and
+// CWE tags in the form GitHub code scanning links to MITRE.
+func ruleTags(f Finding) []string {
+	tags := []string{"security"}
+	seen := map[string]bool{"security": true}
+	add := func(tag string) {
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	for _, t := range f.Tags {
+		add(strings.ToLower(t))
+	}
+	for _, cwe := range f.CWE {
+		if n := strings.TrimPrefix(strings.ToUpper(cwe), "CWE-"); n != "" {
+			add("external/cwe/cwe-" + n)
+		}
+	}
+	return tags
+}
+
+// resultMessage describes a finding at its endpoint.
+func resultMessage(f Finding) string {
+	msg := f.Message
+	if msg == "" {
+		msg = f.Plugin
+	}
+	if len(f.CVE) > 0 {
+		msg += " (" + strings.Join(f.CVE, ", ") + ")"
+	}
+	return msg + " on " + endpoint(f)
+}
+
+// endpoint returns "host:port" for findings with a port and the bare host
+// otherwise.
+func endpoint(f Finding) string {
+	if f.Port > 0 {
+		return net.JoinHostPort(f.Target, strconv.Itoa(f.Port))
+	}
+	return f.Target
+}
+
+// locationURI returns the artifact URI for a finding. Findings are located
+// on network endpoints rather than files, so the URI names the endpoint:
+// tcp://host:port, or the bare host when no port is known.
+func locationURI(f Finding) string {
+	if f.Port > 0 {
+		return "tcp://" + endpoint(f)
+	}
+	if strings.Contains(f.Target, ":") {
+		return "[" + f.Target + "]"
+	}
+	return f.Target
+}
+
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func sampleFindings() []Finding {
+	return []Finding{
+		{
+			Target:      "10.0.0.5",
+			Port:        22,
+			Plugin:      "SSH Weak MAC Algorithm",
+			PluginID:    "ssh-weak-mac",
+			Severity:    "medium",
+			Message:     "SSH server supports weak MAC algorithms",
+			Remediation: "Disable hmac-md5 and hmac-sha1",
+			CWE:         []string{"CWE-327"},
+			Reference:   "https://example.com/ssh-mac",
+			Tags:        []string{"SSH", "crypto"},
+		},
+		{
+			Target:   "10.0.0.6",
+			Port:     22,
+			Plugin:   "SSH Weak MAC Algorithm",
+			PluginID: "ssh-weak-mac",
+			Severity: "high",
+			Message:  "SSH server supports weak MAC algorithms",
+		},
+		{
+			Target:   "10.0.0.7",
+			Plugin:   "OpenSSH CVE-2024-6387 (regreSSHion)",
+			Severity: "critical",
+			Message:  "Vulnerable OpenSSH version",
+			CVE:      []string{"CVE-2024-6387"},
+		},
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	eng := &engagement.Record{Client: "ACME", Authorization: "SOW-42", Tester: "alice"}
+	require.NoError(t, WriteSARIF(&buf, sampleFindings(), "1.2.3", eng))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, SARIFVersion, log.Version)
+	require.Equal(t, SARIFSchema, log.Schema)
+	require.Len(t, log.Runs, 1)
+
+	driver := log.Runs[0].Tool.Driver
+	require.Equal(t, "Vulntor", driver.Name)
+	require.Equal(t, "1.2.3", driver.Version)
+	require.Equal(t, eng, log.Runs[0].Properties.Engagement)
+
+	// One rule per plugin, sorted by ID
+	require.Len(t, driver.Rules, 2)
+	regre := driver.Rules[0]
+	require.Equal(t, "openssh-cve-2024-6387-regresshion", regre.ID)
+	require.Equal(t, "error", regre.DefaultConfiguration.Level)
+	require.Equal(t, "9.5", regre.Properties.SecuritySeverity)
+	require.Equal(t, []string{"CVE-2024-6387"}, regre.Properties.CVE)
+
+	mac := driver.Rules[1]
+	require.Equal(t, "ssh-weak-mac", mac.ID)
+	require.Equal(t, "SshWeakMacAlgorithm", mac.Name)
+	require.Equal(t, "SSH Weak MAC Algorithm", mac.ShortDescription.Text)
+	require.Equal(t, "Disable hmac-md5 and hmac-sha1", mac.Help.Text)
+	require.Equal(t, "https://example.com/ssh-mac", mac.HelpURI)
+	require.Equal(t, []string{"security", "ssh", "crypto", "external/cwe/cwe-327"}, mac.Properties.Tags)
+	// Highest severity among the plugin's findings wins
+	require.Equal(t, "error", mac.DefaultConfiguration.Level)
+	require.Equal(t, "8.0", mac.Properties.SecuritySeverity)
+
+	results := log.Runs[0].Results
+	require.Len(t, results, 3)
+
+	first := results[0]
+	require.Equal(t, "ssh-weak-mac", first.RuleID)
+	require.Equal(t, 1, first.RuleIndex)
+	require.Equal(t, "warning", first.Level)
+	require.Equal(t, "SSH server supports weak MAC algorithms on 10.0.0.5:22", first.Message.Text)
+	require.Equal(t, "tcp://10.0.0.5:22", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.Equal(t, 1, first.Locations[0].PhysicalLocation.Region.StartLine)
+	require.Equal(t, "10.0.0.5:22", first.Locations[0].LogicalLocations[0].FullyQualifiedName)
+	require.Equal(t,
+		ticketing.Fingerprint(ticketing.Finding{Plugin: "SSH Weak MAC Algorithm", Target: "10.0.0.5", Port: 22}),
+		first.PartialFingerprints[fingerprintKey])
+
+	last := results[2]
+	require.Equal(t, 0, last.RuleIndex)
+	require.Equal(t, "error", last.Level)
+	require.Equal(t, "Vulnerable OpenSSH version (CVE-2024-6387) on 10.0.0.7", last.Message.Text)
+	require.Equal(t, "10.0.0.7", last.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestWriteSARIF_NoFindings(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteSARIF(&buf, nil, "", nil))
+
+	// Consumers expect empty arrays, not nulls
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &raw))
+	run := raw["runs"].([]any)[0].(map[string]any)
+	require.Equal(t, []any{}, run["results"])
+	require.Equal(t, []any{}, run["tool"].(map[string]any)["driver"].(map[string]any)["rules"])
+	require.NotContains(t, run, "properties")
+}
+
+func TestLocationURI(t *testing.T) {
+	require.Equal(t, "tcp://[2001:db8::1]:443", locationURI(Finding{Target: "2001:db8::1", Port: 443}))
+	require.Equal(t, "[2001:db8::1]", locationURI(Finding{Target: "2001:db8::1"}))
+	require.Equal(t, "example.com", locationURI(Finding{Target: "example.com"}))
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	require.Equal(t, "critical", normalizeSeverity(" CRITICAL "))
+	require.Equal(t, "info", normalizeSeverity("bogus"))
+	require.Equal(t, "info", normalizeSeverity(""))
+}
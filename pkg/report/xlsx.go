@@ -0,0 +1,263 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// maxCellLength is the longest text an xlsx cell may hold.
+const maxCellLength = 32767
+
+// xlsxCell is a worksheet cell: a number when isNumber is set, inline text
+// otherwise.
+type xlsxCell struct {
+	text     string
+	number   int
+	isNumber bool
+}
+
+func textCell(s string) xlsxCell { return xlsxCell{text: s} }
+func numberCell(n int) xlsxCell  { return xlsxCell{number: n, isNumber: true} }
+
+// xlsxSheet is a worksheet whose first row is a header.
+type xlsxSheet struct {
+	name   string
+	widths []int // column widths in characters
+	rows   [][]xlsxCell
+	filter bool // add an autofilter over the whole table
+}
+
+// WriteXLSX writes data as an Excel workbook with three sheets: Summary
+// (scan details, the engagement and counts by severity), Findings (one row per finding, as
+// in CSV exports) and Hosts (one row per open port).
+func WriteXLSX(w io.Writer, data *Data) error {
+	return writeWorkbook(w, []xlsxSheet{summarySheet(data), findingsSheet(data), hostsSheet(data)})
+}
+
+func summarySheet(data *Data) xlsxSheet {
+	scan := data.Scan
+	timeCell := func(t time.Time) xlsxCell {
+		if t.IsZero() {
+			return textCell("")
+		}
+		return textCell(t.UTC().Format(time.RFC3339))
+	}
+
+	rows := [][]xlsxCell{
+		{textCell("Field"), textCell("Value")},
+		{textCell("Scan ID"), textCell(scan.ID)},
+		{textCell("Target"), textCell(scan.Target)},
+		{textCell("Status"), textCell(scan.Status)},
+		{textCell("Started"), timeCell(scan.StartedAt)},
+		{textCell("Completed"), timeCell(scan.CompletedAt)},
+	}
+	for _, f := range engagementFields(scan.Engagement) {
+		rows = append(rows, []xlsxCell{textCell(f.Name), textCell(f.Value)})
+	}
+	rows = append(rows, [][]xlsxCell{
+		{textCell("Hosts"), numberCell(len(data.Hosts))},
+		{textCell("Findings"), numberCell(len(data.Findings))},
+	}...)
+	counts := make(map[string]int)
+	for _, f := range data.Findings {
+		counts[normalizeSeverity(f.Severity)]++
+	}
+	for _, sev := range severity.Levels {
+		rows = append(rows, []xlsxCell{textCell("Findings (" + sev + ")"), numberCell(counts[sev])})
+	}
+	return xlsxSheet{name: "Summary", widths: []int{20, 40}, rows: rows}
+}
+
+func findingsSheet(data *Data) xlsxSheet {
+	header := make([]xlsxCell, len(findingColumns))
+	for i, c := range findingColumns {
+		header[i] = textCell(c)
+	}
+	rows := [][]xlsxCell{header}
+	for _, r := range FindingRows(data) {
+		row := make([]xlsxCell, 0, len(findingColumns))
+		for i, v := range r.values() {
+			if i == 2 && r.Port > 0 {
+				row = append(row, numberCell(r.Port))
+				continue
+			}
+			row = append(row, textCell(v))
+		}
+		rows = append(rows, row)
+	}
+	return xlsxSheet{
+		name:   "Findings",
+		widths: []int{16, 24, 8, 10, 14, 18, 12, 36, 24, 10, 18, 12, 60, 60, 40, 12, 40, 20, 24, 16, 14, 24, 24, 18, 12, 12, 60, 24},
+		rows:   rows,
+		filter: true,
+	}
+}
+
+func hostsSheet(data *Data) xlsxSheet {
+	rows := [][]xlsxCell{{
+		textCell("Host"), textCell("Hostnames"), textCell("Port"), textCell("Protocol"),
+		textCell("Service"), textCell("Product"), textCell("Version"), textCell("Host Findings"),
+	}}
+	for _, h := range hostReports(data.Hosts, sortedFindings(data.Findings)) {
+		hostnames := textCell(strings.Join(h.Hostnames, ", "))
+		findings := numberCell(len(h.Findings))
+		if len(h.Ports) == 0 {
+			rows = append(rows, []xlsxCell{textCell(h.IP), hostnames, textCell(""), textCell(""), textCell(""), textCell(""), textCell(""), findings})
+			continue
+		}
+		for _, p := range h.Ports {
+			rows = append(rows, []xlsxCell{
+				textCell(h.IP), hostnames, numberCell(p.Port), textCell(p.Protocol),
+				textCell(p.Service), textCell(p.Product), textCell(p.Version), findings,
+			})
+		}
+	}
+	return xlsxSheet{name: "Hosts", widths: []int{16, 24, 8, 10, 14, 18, 12, 14}, rows: rows, filter: true}
+}
+
+// writeWorkbook writes sheets as a minimal SpreadsheetML package. Text is
+// stored as inline strings, which spreadsheets never evaluate as formulas.
+func writeWorkbook(w io.Writer, sheets []xlsxSheet) error {
+	zw := zip.NewWriter(w)
+	add := func(name, content string) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(f, content)
+		return err
+	}
+
+	var overrides, rels, entries, definedNames bytes.Buffer
+	for i, s := range sheets {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+		fmt.Fprintf(&entries, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(s.name), n, n)
+		if s.filter {
+			fmt.Fprintf(&definedNames, `<definedName name="_xlnm._FilterDatabase" localSheetId="%d" hidden="1">'%s'!%s</definedName>`,
+				i, xmlEscape(s.name), absoluteRange(s))
+		}
+	}
+	stylesRel := len(sheets) + 1
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+			overrides.String() + `</Types>`},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() +
+			fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, stylesRel) +
+			`</Relationships>`},
+		{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + entries.String() + `</sheets>` +
+			wrapNonEmpty("definedNames", definedNames.String()) +
+			`</workbook>`},
+		// Style 0 is the default; style 1 is the bold header row
+		{"xl/styles.xml", xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+			`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+			`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+			`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+			`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+			`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+			`</styleSheet>`},
+	}
+	for i, s := range sheets {
+		parts = append(parts, struct{ name, content string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheetXML(s)})
+	}
+
+	for _, p := range parts {
+		if err := add(p.name, p.content); err != nil {
+			return fmt.Errorf("write %s: %w", p.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// worksheetXML renders a sheet with a frozen, bold header row.
+func worksheetXML(s xlsxSheet) string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width)
+		}
+		b.WriteString(`</cols>`)
+	}
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		style := ""
+		if r == 0 {
+			style = ` s="1"`
+		}
+		for c, cell := range row {
+			ref := columnName(c) + strconv.Itoa(r+1)
+			if cell.isNumber {
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%d</v></c>`, ref, style, cell.number)
+				continue
+			}
+			text := cell.text
+			if len(text) > maxCellLength {
+				text = text[:maxCellLength]
+			}
+			fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(text))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if s.filter && len(s.rows) > 0 {
+		fmt.Fprintf(&b, `<autoFilter ref="A1:%s%d"/>`, columnName(len(s.rows[0])-1), len(s.rows))
+	}
+	b.WriteString(`</worksheet>`)
+	return b.String()
+}
+
+// absoluteRange returns the sheet's table range, e.g. "$A$1:$O$12".
+func absoluteRange(s xlsxSheet) string {
+	cols := 1
+	if len(s.rows) > 0 {
+		cols = len(s.rows[0])
+	}
+	return fmt.Sprintf("$A$1:$%s$%d", columnName(cols-1), max(len(s.rows), 1))
+}
+
+// columnName converts a zero-based column index to its letters (0 -> A, 26 -> AA).
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func wrapNonEmpty(tag, content string) string {
+	if content == "" {
+		return ""
+	}
+	return "<" + tag + ">" + content + "</" + tag + ">"
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readXLSX returns the parts of an xlsx package, checking each is well-formed XML.
+func readXLSX(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, "part %s is not well-formed", f.Name)
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestWriteXLSX(t *testing.T) {
+	data := sampleData()
+	data.Findings = append(data.Findings, Finding{Target: "10.0.0.9", Port: 80, Plugin: "Banner", Severity: "low", Message: "=cmd|' /C calc'!A0 & <script>\x01"})
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteXLSX(&buf, data))
+	parts := readXLSX(t, buf.Bytes())
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		require.Contains(t, parts, name)
+	}
+
+	workbook := parts["xl/workbook.xml"]
+	require.Contains(t, workbook, `<sheet name="Summary" sheetId="1" r:id="rId1"/>`)
+	require.Contains(t, workbook, `<sheet name="Findings" sheetId="2" r:id="rId2"/>`)
+	require.Contains(t, workbook, `<sheet name="Hosts" sheetId="3" r:id="rId3"/>`)
+	require.Contains(t, workbook, `'Findings'!$A$1:$AB$6`)
+
+	summary := parts["xl/worksheets/sheet1.xml"]
+	require.Contains(t, summary, `<t xml:space="preserve">scan-1</t>`)
+	require.Contains(t, summary, `<c r="B8"><v>5</v></c>`) // findings total
+
+	findings := parts["xl/worksheets/sheet2.xml"]
+	require.Contains(t, findings, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Host</t></is></c>`)
+	require.Contains(t, findings, `<c r="C2"><v>22</v></c>`)
+	require.Contains(t, findings, `<autoFilter ref="A1:AB6"/>`)
+	// Untrusted text is escaped and stored as text, never as a formula
+	require.Contains(t, findings, `=cmd|&#39; /C calc&#39;!A0 &amp; &lt;script&gt;`)
+	require.NotContains(t, findings, "<f>")
+
+	hosts := parts["xl/worksheets/sheet3.xml"]
+	require.Contains(t, hosts, `<t xml:space="preserve">postgresql</t>`)
+	require.Equal(t, 5, strings.Count(hosts, "<row ")) // header + 10.0.0.5 (2 ports) + 10.0.0.7 + 10.0.0.9
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "O", columnName(14))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,83 @@
+package scanexec
+
+import (
+	"context"
+	"os"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// startCapture returns the recorder of a run capturing in mode, and a
+// function removing its files once they are saved. Without a mode, or a
+// storage backend keeping artifacts, the recorder is nil.
+func (s *Service) startCapture(scanID string, mode capture.Mode) (*capture.Recorder, func()) {
+	if mode == "" {
+		return nil, func() {}
+	}
+	if _, ok := s.storage.(storage.ArtifactBackend); !ok {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Msg("Packet capture requested, but the storage backend does not keep artifacts; traffic is not recorded")
+		return nil, func() {}
+	}
+
+	dir, err := os.MkdirTemp("", "vulntor-capture-")
+	if err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to create packet capture directory; traffic is not recorded")
+		return nil, func() {}
+	}
+	recorder := capture.NewRecorder(dir)
+	if s.redactor != nil {
+		recorder = recorder.WithRedaction(s.redactor.Bytes)
+	}
+	return recorder, func() { _ = os.RemoveAll(dir) }
+}
+
+// saveCapture stores the packets recorded during a run as artifacts of the
+// scan: all of them, or in capture.ModeFindings only those of the hosts and
+// ports findings were reported on. Findings without a port keep all
+// traffic of their host.
+func (s *Service) saveCapture(ctx context.Context, scanID, orgID string, recorder *capture.Recorder, mode capture.Mode, dataCtx map[string]interface{}) {
+	if recorder == nil {
+		return
+	}
+
+	var filter capture.Filter
+	if mode == capture.ModeFindings {
+		filter = capture.Filter{}
+		for _, f := range decodeFindings[findingLocation](dataCtx) {
+			filter[f.Target] = append(filter[f.Target], f.Port)
+		}
+	}
+
+	store := s.storage.(storage.ArtifactBackend).Artifacts()
+	saved, err := recorder.Save(ctx, store, orgID, scanID, filter)
+	if err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to save packet captures")
+		return
+	}
+	log.Info().
+		Str("component", "scanexec").
+		Str("scan_id", scanID).
+		Str("mode", string(mode)).
+		Int("hosts", len(saved)).
+		Msg("Saved packet captures")
+}
+
+// findingLocation is the host and port a finding was reported on.
+type findingLocation struct {
+	Target string `json:"target"`
+	Port   int    `json:"port"`
+}
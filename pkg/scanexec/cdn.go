@@ -0,0 +1,92 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/report"
+)
+
+// cdnGuard returns the guard tracking the CDNs and WAFs in front of the
+// targets of a run, seeded with those their names are aliased to, or nil
+// when detection is off.
+func (s *Service) cdnGuard(ctx context.Context, params Params) *cdn.Guard {
+	if s.cdnPolicy == "" {
+		return nil
+	}
+	guard := cdn.NewGuard(s.cdnPolicy)
+	// Replays send nothing, not even DNS queries
+	if params.ReplayOf == "" {
+		guard.ResolveTargets(ctx, params.Targets)
+	}
+	return guard
+}
+
+// intermediaryOf returns the CDN or WAF in front of host, found by its
+// address or the scan target it was found through, or nil.
+func intermediaryOf(guard *cdn.Guard, host, target string) *cdn.Intermediary {
+	if i := guard.Lookup(host); i != nil {
+		return i
+	}
+	if target == "" {
+		return nil
+	}
+	return guard.Lookup(target)
+}
+
+// annotateIntermediaries adds the CDN or WAF in front of their host to the
+// findings in dataCtx, as intermediary, and drops them when the policy
+// skips such hosts.
+func (s *Service) annotateIntermediaries(scanID string, dataCtx map[string]interface{}, guard *cdn.Guard) {
+	if guard == nil || dataCtx == nil {
+		return
+	}
+	vulns, _ := dataCtx[report.FindingsKey].([]interface{})
+	if len(vulns) == 0 {
+		return
+	}
+
+	hostTargets := make(map[string]string)
+	for _, rec := range hostRecords(assetProfiles(dataCtx)) {
+		hostTargets[rec.IP] = rec.Target
+	}
+
+	kept := vulns[:0]
+	dropped := 0
+	for _, v := range vulns {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			kept = append(kept, v)
+			continue
+		}
+		var finding map[string]interface{}
+		if err := json.Unmarshal(raw, &finding); err != nil {
+			kept = append(kept, v)
+			continue
+		}
+		host, _ := finding["target"].(string)
+		i := intermediaryOf(guard, host, hostTargets[host])
+		if i == nil {
+			kept = append(kept, v)
+			continue
+		}
+		if guard.Policy() == cdn.PolicySkip {
+			dropped++
+			continue
+		}
+		finding["intermediary"] = i
+		kept = append(kept, finding)
+	}
+	dataCtx[report.FindingsKey] = kept
+
+	if dropped > 0 {
+		log.Info().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Int("findings", dropped).
+			Msg("Dropped findings on endpoints behind a CDN or WAF")
+	}
+}
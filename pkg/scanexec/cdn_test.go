@@ -0,0 +1,106 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_Intermediaries(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	run := func(t *testing.T, policy cdn.Policy) []report.Finding {
+		backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+
+		// 104.16.1.1 is in a Cloudflare range
+		def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+		orch := &mockOrch{out: map[string]interface{}{
+			"asset.profiles": []interface{}{[]engine.AssetProfile{{
+				Target:      "104.16.1.1",
+				ResolvedIPs: map[string]time.Time{"104.16.1.1": time.Now()},
+				OpenPorts:   map[string][]engine.PortProfile{"104.16.1.1": {{PortNumber: 443, Protocol: "tcp"}}},
+			}, {
+				Target:      "10.0.1.5",
+				ResolvedIPs: map[string]time.Time{"10.0.1.5": time.Now()},
+				OpenPorts:   map[string][]engine.PortProfile{"10.0.1.5": {{PortNumber: 22, Protocol: "tcp"}}},
+			}}},
+			"evaluation.vulnerabilities": []interface{}{
+				map[string]interface{}{"target": "104.16.1.1", "port": 443, "plugin": "TLS 1.0 Enabled", "severity": "medium"},
+				map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "SSH Weak MAC Algorithm", "severity": "medium"},
+			},
+		}}
+		svc := NewService().
+			WithStorage(backend).
+			WithCDNPolicy(policy).
+			WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+			WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+		res, err := svc.Run(ctx, Params{Targets: []string{"104.16.1.1", "10.0.1.5"}})
+		require.NoError(t, err)
+
+		data, err := report.Load(ctx, backend.Scans(), storage.DefaultOrgID, res.RunID)
+		require.NoError(t, err)
+		return data.Findings
+	}
+
+	t.Run("annotate", func(t *testing.T) {
+		findings := run(t, cdn.PolicyAnnotate)
+		require.Len(t, findings, 2)
+		for _, f := range findings {
+			if f.Target == "104.16.1.1" {
+				require.Equal(t, &cdn.Intermediary{Name: "Cloudflare", Kind: cdn.KindCDN, Via: cdn.ViaIP, Evidence: "104.16.0.0/13"}, f.Intermediary)
+			} else {
+				require.Nil(t, f.Intermediary)
+			}
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		findings := run(t, cdn.PolicySkip)
+		require.Len(t, findings, 1)
+		require.Equal(t, "10.0.1.5", findings[0].Target)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		findings := run(t, "")
+		require.Len(t, findings, 2)
+		for _, f := range findings {
+			require.Nil(t, f.Intermediary)
+		}
+	})
+}
+
+func TestRun_PassesCDNGuardToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithCDNPolicy(cdn.PolicyReduce).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	guard := cdn.FromContext(orch.ctx)
+	require.NotNil(t, guard)
+	require.Equal(t, cdn.PolicyReduce, guard.Policy())
+}
@@ -0,0 +1,275 @@
+package scanexec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/policy"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Change types, reported when a scan finds hosts and services that differ
+// from their last observation.
+const (
+	ChangeHostNew        = "host.new"
+	ChangePortNew        = "port.new"
+	ChangeServiceChanged = "service.changed"
+	ChangeCertChanged    = "cert.changed"
+)
+
+// changeBaselineScans is the number of recent completed scans the last
+// observation of each host is taken from.
+const changeBaselineScans = 20
+
+// changeChecks describes each change type as a finding. Plugin IDs can be
+// selected by severity overrides of the policy config.
+var changeChecks = map[string]struct {
+	pluginID string
+	plugin   string
+	severity string
+}{
+	ChangeHostNew:        {"change-host-new", "New host", "low"},
+	ChangePortNew:        {"change-port-new", "New open port", "medium"},
+	ChangeServiceChanged: {"change-service-version", "Service version changed", "low"},
+	ChangeCertChanged:    {"change-certificate", "TLS certificate changed", "low"},
+}
+
+// detectChanges compares the live hosts of dataCtx with their last
+// observation in storage and adds a finding for every change to the
+// evaluation.vulnerabilities of dataCtx, so that changes are stored,
+// reported and gated like other findings.
+func (s *Service) detectChanges(ctx context.Context, scanID string, dataCtx map[string]interface{}, environment string) []notify.Change {
+	if s.storage == nil || dataCtx == nil {
+		return nil
+	}
+
+	current := hostRecords(assetProfiles(dataCtx))
+	if len(current) == 0 {
+		return nil
+	}
+
+	baseline, err := s.baselineHosts(ctx, scanID)
+	if err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to load previous scans, skipping change detection")
+		return nil
+	}
+	if len(baseline) == 0 {
+		return nil
+	}
+
+	changes := diffHosts(baseline, current)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	vulns, _ := dataCtx["evaluation.vulnerabilities"].([]interface{})
+	p := s.policy.WithEnvironment(environment)
+	for _, c := range changes {
+		vulns = append(vulns, changeFinding(c, p))
+	}
+	dataCtx["evaluation.vulnerabilities"] = vulns
+
+	log.Info().
+		Str("component", "scanexec").
+		Str("scan_id", scanID).
+		Int("changes", len(changes)).
+		Msg("Detected host and service changes since previous scans")
+	return changes
+}
+
+// baselineHosts returns the last observation of each host in the recent
+// completed scans of the organization, keyed by IP.
+func (s *Service) baselineHosts(ctx context.Context, scanID string) (map[string]storage.HostRecord, error) {
+	orgID := storage.OrgIDFromContext(ctx)
+	scans, _, _, err := s.storage.Scans().ListPaginated(
+		ctx, orgID, storage.ScanFilter{Status: string(storage.StatusCompleted)}, "", changeBaselineScans,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scans are newest first; keep the first observation of each host.
+	// Rechecks observe a single port of their host
+	baseline := make(map[string]storage.HostRecord)
+	for _, scan := range scans {
+		if scan.ID == scanID || scan.Status != string(storage.StatusCompleted) || scan.RecheckOf != "" {
+			continue
+		}
+		hosts, err := storage.ReadHosts(ctx, s.storage.Scans(), orgID, scan.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			if _, ok := baseline[h.IP]; !ok {
+				baseline[h.IP] = h
+			}
+		}
+	}
+	return baseline, nil
+}
+
+// diffHosts returns the changes of current relative to baseline, the last
+// observation of each host. A host is new when an earlier scan of the same
+// target did not find it. Services and certificates only change when both
+// observations identify them, so that a missed banner is not a change.
+func diffHosts(baseline map[string]storage.HostRecord, current []storage.HostRecord) []notify.Change {
+	scannedTargets := make(map[string]bool)
+	for _, h := range baseline {
+		scannedTargets[h.Target] = true
+	}
+
+	var changes []notify.Change
+	for _, host := range current {
+		prev, seen := baseline[host.IP]
+		if !seen {
+			if scannedTargets[host.Target] {
+				changes = append(changes, notify.Change{Type: ChangeHostNew, Target: host.IP, Current: portList(host.Ports)})
+			}
+			continue
+		}
+
+		prevPorts := make(map[string]storage.ServiceRecord, len(prev.Ports))
+		for _, p := range prev.Ports {
+			prevPorts[portKey(p)] = p
+		}
+		for _, p := range host.Ports {
+			old, ok := prevPorts[portKey(p)]
+			switch {
+			case !ok:
+				changes = append(changes, notify.Change{
+					Type: ChangePortNew, Target: host.IP, Port: p.Port, Protocol: p.Protocol, Current: serviceName(p),
+				})
+			case serviceVersion(old) != "" && serviceVersion(p) != "" && serviceVersion(old) != serviceVersion(p):
+				changes = append(changes, notify.Change{
+					Type: ChangeServiceChanged, Target: host.IP, Port: p.Port, Protocol: p.Protocol,
+					Previous: serviceVersion(old), Current: serviceVersion(p),
+				})
+			}
+			if ok && old.Certificate != nil && p.Certificate != nil && !sameCertificate(old.Certificate, p.Certificate) {
+				changes = append(changes, notify.Change{
+					Type: ChangeCertChanged, Target: host.IP, Port: p.Port, Protocol: p.Protocol,
+					Previous: certificateName(old.Certificate), Current: certificateName(p.Certificate),
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// changeFinding renders a change as a finding, with the severity remapped
+// by p.
+func changeFinding(c notify.Change, p *policy.Policy) map[string]interface{} {
+	check := changeChecks[c.Type]
+	finding := map[string]interface{}{
+		"target":      c.Target,
+		"plugin":      check.plugin,
+		"plugin_id":   check.pluginID,
+		"plugin_type": "change",
+		"severity":    check.severity,
+		"message":     changeMessage(c),
+		"tags":        []string{"change"},
+		"matched":     true,
+	}
+	if c.Port > 0 {
+		finding["port"] = c.Port
+	}
+
+	evidence := map[string]string{"change": c.Type}
+	if c.Previous != "" {
+		evidence["previous"] = c.Previous
+	}
+	if c.Current != "" {
+		evidence["current"] = c.Current
+	}
+	finding["evidence"] = evidence
+	finding["evidence_format"] = "text"
+
+	override, ok := p.Override(policy.Finding{
+		Target:   c.Target,
+		PluginID: check.pluginID,
+		Plugin:   check.plugin,
+		Tags:     []string{"change"},
+	})
+	if ok && override.Severity != check.severity {
+		finding["original_severity"] = check.severity
+		finding["severity"] = override.Severity
+		finding["severity_reason"] = override.Reason
+	}
+	return finding
+}
+
+// changeMessage describes a change for reports.
+func changeMessage(c notify.Change) string {
+	switch c.Type {
+	case ChangeHostNew:
+		if c.Current == "" {
+			return fmt.Sprintf("Host %s is up and was not found by earlier scans of its target", c.Target)
+		}
+		return fmt.Sprintf("Host %s is up and was not found by earlier scans of its target (open: %s)", c.Target, c.Current)
+	case ChangePortNew:
+		return fmt.Sprintf("Port %d/%s opened since the last scan of %s (%s)", c.Port, c.Protocol, c.Target, c.Current)
+	case ChangeServiceChanged:
+		return fmt.Sprintf("Service on port %d/%s changed from %s to %s", c.Port, c.Protocol, c.Previous, c.Current)
+	case ChangeCertChanged:
+		return fmt.Sprintf("TLS certificate on port %d/%s changed from %s to %s", c.Port, c.Protocol, c.Previous, c.Current)
+	default:
+		return c.Type
+	}
+}
+
+func portKey(p storage.ServiceRecord) string {
+	return fmt.Sprintf("%d/%s", p.Port, p.Protocol)
+}
+
+// portList renders ports as "22/tcp, 443/tcp".
+func portList(ports []storage.ServiceRecord) string {
+	keys := make([]string, 0, len(ports))
+	for _, p := range ports {
+		keys = append(keys, portKey(p))
+	}
+	return strings.Join(keys, ", ")
+}
+
+// serviceName renders the service on a port, e.g. "ssh OpenSSH 8.9p1".
+func serviceName(p storage.ServiceRecord) string {
+	name := strings.TrimSpace(strings.Join([]string{p.Service, serviceVersion(p)}, " "))
+	if name == "" {
+		return "unknown service"
+	}
+	return name
+}
+
+// serviceVersion renders the product and version of a service, e.g.
+// "OpenSSH 8.9p1", or "" when neither is known.
+func serviceVersion(p storage.ServiceRecord) string {
+	return strings.TrimSpace(p.Product + " " + p.Version)
+}
+
+func sameCertificate(a, b *storage.CertificateRecord) bool {
+	return a.Subject == b.Subject && a.Issuer == b.Issuer && a.NotAfter.Equal(b.NotAfter)
+}
+
+// certificateName renders a certificate as "CN=www.example.com (issuer
+// R3, expires 2025-01-01)".
+func certificateName(c *storage.CertificateRecord) string {
+	var details []string
+	if c.Issuer != "" {
+		details = append(details, "issuer "+c.Issuer)
+	}
+	if !c.NotAfter.IsZero() {
+		details = append(details, "expires "+c.NotAfter.Format("2006-01-02"))
+	}
+	name := "CN=" + c.Subject
+	if len(details) > 0 {
+		name += " (" + strings.Join(details, ", ") + ")"
+	}
+	return name
+}
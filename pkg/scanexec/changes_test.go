@@ -0,0 +1,128 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/policy"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestDiffHosts(t *testing.T) {
+	certA := &storage.CertificateRecord{Subject: "www.example.com", Issuer: "R3", NotAfter: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	certB := &storage.CertificateRecord{Subject: "www.example.com", Issuer: "R3", NotAfter: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	baseline := map[string]storage.HostRecord{
+		"10.0.0.5": {IP: "10.0.0.5", Target: "10.0.0.0/24", Ports: []storage.ServiceRecord{
+			{Port: 22, Protocol: "tcp", Service: "ssh", Product: "OpenSSH", Version: "8.9p1"},
+			{Port: 80, Protocol: "tcp", Service: "http", Product: "nginx"},
+			{Port: 443, Protocol: "tcp", Service: "https", Certificate: certA},
+		}},
+	}
+	current := []storage.HostRecord{
+		{IP: "10.0.0.5", Target: "10.0.0.0/24", Ports: []storage.ServiceRecord{
+			{Port: 22, Protocol: "tcp", Service: "ssh", Product: "OpenSSH", Version: "9.6p1"},
+			{Port: 80, Protocol: "tcp", Service: "http"}, // banner missed: not a change
+			{Port: 443, Protocol: "tcp", Service: "https", Certificate: certB},
+			{Port: 3389, Protocol: "tcp", Service: "rdp"},
+		}},
+		{IP: "10.0.0.6", Target: "10.0.0.0/24", Ports: []storage.ServiceRecord{{Port: 22, Protocol: "tcp"}}},
+		// Never scanned before: not reported as new
+		{IP: "192.0.2.1", Target: "192.0.2.1"},
+	}
+
+	require.Equal(t, []notify.Change{
+		{Type: ChangeServiceChanged, Target: "10.0.0.5", Port: 22, Protocol: "tcp", Previous: "OpenSSH 8.9p1", Current: "OpenSSH 9.6p1"},
+		{Type: ChangeCertChanged, Target: "10.0.0.5", Port: 443, Protocol: "tcp",
+			Previous: "CN=www.example.com (issuer R3, expires 2025-01-01)", Current: "CN=www.example.com (issuer R3, expires 2026-01-01)"},
+		{Type: ChangePortNew, Target: "10.0.0.5", Port: 3389, Protocol: "tcp", Current: "rdp"},
+		{Type: ChangeHostNew, Target: "10.0.0.6", Current: "22/tcp"},
+	}, diffHosts(baseline, current))
+}
+
+func TestRun_DetectsChanges(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	var mu sync.Mutex
+	var changes []notify.Change
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p notify.Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		mu.Lock()
+		if p.Event == notify.EventChanges {
+			changes = append(changes, p.Changes...)
+		}
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	dispatcher, err := notify.New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL}}})
+	require.NoError(t, err)
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+
+	profiles := func(ports ...engine.PortProfile) map[string]interface{} {
+		return map[string]interface{}{
+			"asset.profiles": []interface{}{[]engine.AssetProfile{{
+				Target:    "10.0.0.5",
+				OpenPorts: map[string][]engine.PortProfile{"10.0.0.5": ports},
+			}}},
+		}
+	}
+	ssh := engine.PortProfile{PortNumber: 22, Protocol: "tcp", Service: engine.ServiceDetails{Name: "ssh", Product: "OpenSSH", Version: "8.9p1"}}
+	rdp := engine.PortProfile{PortNumber: 3389, Protocol: "tcp", Service: engine.ServiceDetails{Name: "rdp"}}
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	run := func(scanID string, out map[string]interface{}) {
+		svc := NewService().
+			WithStorage(backend).
+			WithNotifier(dispatcher).
+			WithPolicy(policy.New("", []policy.Override{{Plugins: []string{"change-port-new"}, Severity: "high", Reason: "RDP must stay closed"}})).
+			WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+			WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: out}, nil })
+		_, err := svc.Run(ctx, Params{Targets: []string{"10.0.0.5"}, ScanID: scanID})
+		require.NoError(t, err)
+	}
+
+	// The first scan has nothing to compare with
+	run("scan-1", profiles(ssh))
+	require.Empty(t, changes)
+
+	run("scan-2", profiles(ssh, rdp))
+	require.Equal(t, []notify.Change{{Type: ChangePortNew, Target: "10.0.0.5", Port: 3389, Protocol: "tcp", Current: "rdp"}}, changes)
+
+	// The change is stored as a finding, with the policy's severity
+	rc, err := backend.Scans().ReadData(ctx, storage.DefaultOrgID, "scan-2", storage.DataTypeVulnerabilities)
+	require.NoError(t, err)
+	defer func() { _ = rc.Close() }()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	var finding map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(data))), &finding))
+	require.Equal(t, "change-port-new", finding["plugin_id"])
+	require.Equal(t, "high", finding["severity"])
+	require.Equal(t, "medium", finding["original_severity"])
+	require.Equal(t, "Port 3389/tcp opened since the last scan of 10.0.0.5 (rdp)", finding["message"])
+
+	// Unchanged hosts report nothing
+	changes = nil
+	run("scan-3", profiles(ssh, rdp))
+	require.Empty(t, changes)
+}
@@ -0,0 +1,13 @@
+package scanexec
+
+import "github.com/vulntor/vulntor/pkg/storage"
+
+// cloudRecord returns the cloud instance of host, listed by its address or
+// by the scan target it was found through (e.g. a load balancer hostname),
+// or nil.
+func cloudRecord(cloud map[string]*storage.CloudRecord, host, target string) *storage.CloudRecord {
+	if rec, ok := cloud[host]; ok {
+		return rec
+	}
+	return cloud[target]
+}
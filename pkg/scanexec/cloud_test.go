@@ -0,0 +1,80 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_Cloud(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "10.0.1.5", "plugin": "ssh-weak-cipher", "severity": "high"},
+			map[string]interface{}{"target": "203.0.113.9", "plugin": "http-server-header", "severity": "info"},
+			map[string]interface{}{"target": "192.0.2.1", "plugin": "telnet", "severity": "critical"},
+		},
+		// The load balancer web.elb.example.com resolved to 203.0.113.9
+		"asset.profiles": []interface{}{[]engine.AssetProfile{
+			{Target: "10.0.1.5", ResolvedIPs: map[string]time.Time{"10.0.1.5": time.Now()}},
+			{Target: "web.elb.example.com", ResolvedIPs: map[string]time.Time{"203.0.113.9": time.Now()}},
+			{Target: "192.0.2.1", ResolvedIPs: map[string]time.Time{"192.0.2.1": time.Now()}},
+		}},
+	}
+
+	web := &storage.CloudRecord{Provider: "aws", InstanceID: "i-0001", Account: "123456789012", Region: "eu-west-1", SecurityGroups: []string{"sg-web"}}
+	lb := &storage.CloudRecord{Provider: "kubernetes", InstanceID: "service/shop/web", Network: "shop"}
+
+	scans := &memScans{}
+	orch := &mockOrch{out: orchOut}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{
+		Targets: []string{"10.0.1.5", "web.elb.example.com", "192.0.2.1"},
+		Cloud:   map[string]*storage.CloudRecord{"10.0.1.5": web, "web.elb.example.com": lb},
+	})
+	require.NoError(t, err)
+
+	findings := make(map[string]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(string(scans.written[storage.DataTypeVulnerabilities])), "\n") {
+		var f map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &f))
+		findings[f["target"].(string)] = f
+	}
+	require.Equal(t, map[string]interface{}{
+		"provider":        "aws",
+		"instance_id":     "i-0001",
+		"account":         "123456789012",
+		"region":          "eu-west-1",
+		"security_groups": []interface{}{"sg-web"},
+	}, findings["10.0.1.5"]["cloud"])
+	require.Equal(t, "service/shop/web", findings["203.0.113.9"]["cloud"].(map[string]interface{})["instance_id"])
+	require.NotContains(t, findings["192.0.2.1"], "cloud")
+
+	hosts := make(map[string]storage.HostRecord)
+	for _, line := range strings.Split(strings.TrimSpace(string(scans.written[storage.DataTypeHosts])), "\n") {
+		var h storage.HostRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &h))
+		hosts[h.IP] = h
+	}
+	require.Equal(t, web, hosts["10.0.1.5"].Cloud)
+	require.Equal(t, lb, hosts["203.0.113.9"].Cloud)
+	require.Nil(t, hosts["192.0.2.1"].Cloud)
+}
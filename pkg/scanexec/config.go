@@ -0,0 +1,89 @@
+package scanexec
+
+import (
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/fingerprint"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+// WithConfig applies the scan settings of cfg: webhook notifications,
+// ticketing, proxy, credentials, severity policy, redaction, remediation
+// SLAs, honeypot detection, the CDN policy, the scan scope, the engagement
+// policy and the normalization dictionary of fingerprint matches.
+func (s *Service) WithConfig(cfg config.Config) (*Service, error) {
+	notifier, err := notify.New(cfg.Notifications)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notifications config: %w", err)
+	}
+
+	tickets, err := ticketing.New(cfg.Ticketing)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticketing config: %w", err)
+	}
+
+	proxy, err := cfg.Proxy.Proxy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy config: %w", err)
+	}
+
+	creds, err := cfg.Credentials.Store()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials config: %w", err)
+	}
+
+	severityPolicy, err := cfg.Policy.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy config: %w", err)
+	}
+
+	redactor, err := cfg.Redaction.Redactor()
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction config: %w", err)
+	}
+
+	deadlines, err := cfg.SLA.SLA()
+	if err != nil {
+		return nil, fmt.Errorf("invalid sla config: %w", err)
+	}
+
+	decoys, err := cfg.Honeypot.Detector()
+	if err != nil {
+		return nil, fmt.Errorf("invalid honeypot config: %w", err)
+	}
+
+	cdnPolicy, err := cfg.CDN.CDNPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cdn config: %w", err)
+	}
+
+	scanScope, err := cfg.Scope.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid scope config: %w", err)
+	}
+
+	engagementPolicy, err := cfg.Engagement.Policy()
+	if err != nil {
+		return nil, fmt.Errorf("invalid engagement config: %w", err)
+	}
+
+	normalizer, err := fingerprint.LoadNormalizer(cfg.Fingerprint.Normalization)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fingerprint config: %w", err)
+	}
+	fingerprint.RegisterNormalizer(normalizer)
+
+	return s.WithNotifier(notifier).
+		WithTicketing(tickets).
+		WithProxy(proxy).
+		WithCredentials(creds).
+		WithPolicy(severityPolicy).
+		WithRedactor(redactor).
+		WithSLA(deadlines).
+		WithHoneypot(decoys).
+		WithCDNPolicy(cdnPolicy).
+		WithScope(scanScope).
+		WithEngagement(engagementPolicy), nil
+}
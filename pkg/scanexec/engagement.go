@@ -0,0 +1,42 @@
+package scanexec
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+)
+
+// checkEngagement refuses runs the service's engagement policy refuses
+// under record at now.
+func (s *Service) checkEngagement(scanID string, record *engagement.Record, now time.Time) error {
+	if err := s.engagement.Check(record, now); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Refused scan by its engagement")
+		return err
+	}
+	return nil
+}
+
+// scanEngagement returns the engagement the scan scanID was run under, nil
+// when it recorded none or cannot be read.
+func (s *Service) scanEngagement(ctx context.Context, orgID, scanID string) *engagement.Record {
+	if s.storage == nil || scanID == "" {
+		return nil
+	}
+	meta, err := s.storage.Scans().Get(ctx, orgID, scanID)
+	if err != nil {
+		log.Debug().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to read the engagement of scan")
+		return nil
+	}
+	return meta.Engagement
+}
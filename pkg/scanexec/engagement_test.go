@@ -0,0 +1,74 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func TestRun_Engagement(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &mockOrch{out: map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "ssh-weak-cipher", "plugin_id": "ssh-weak-cipher", "severity": "high"},
+		},
+	}}
+	planned := false
+	now := time.Now().UTC().Truncate(time.Second)
+	svc := NewService().
+		WithStorage(backend).
+		WithEngagement(engagement.Policy{AssessmentMode: true, Defaults: engagement.Record{Tester: "alice"}}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) {
+			planned = true
+			return &mockPlanner{def: def}, nil
+		}).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	// Assessment mode refuses runs without a complete record
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.5"}, Engagement: &engagement.Record{Client: "ACME"}})
+	require.ErrorIs(t, err, engagement.ErrRequired)
+	require.ErrorContains(t, err, "missing authorization, window")
+	require.Equal(t, errorCodeEngagementRequired, ErrorCode(err))
+	require.False(t, planned, "refused before the scan is planned")
+
+	// Runs outside their window are refused
+	ended := &engagement.Record{Client: "ACME", Authorization: "SOW-42", WindowStart: now.Add(-48 * time.Hour), WindowEnd: now.Add(-24 * time.Hour)}
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.5"}, Engagement: ended})
+	require.ErrorIs(t, err, engagement.ErrOutsideWindow)
+	require.Equal(t, errorCodeOutsideEngagement, ErrorCode(err))
+	require.False(t, planned)
+
+	// The record, completed from the defaults, is stored with the scan
+	record := &engagement.Record{Client: "ACME", Authorization: "SOW-42", WindowStart: now.Add(-time.Hour), WindowEnd: now.Add(time.Hour)}
+	res, err := svc.Run(ctx, Params{Targets: []string{"10.0.1.5"}, Engagement: record})
+	require.NoError(t, err)
+	want := &engagement.Record{Client: "ACME", Authorization: "SOW-42", Tester: "alice", WindowStart: record.WindowStart, WindowEnd: record.WindowEnd}
+	require.Equal(t, want, res.Engagement)
+	scan, err := backend.Scans().Get(ctx, storage.DefaultOrgID, res.RunID)
+	require.NoError(t, err)
+	require.Equal(t, want, scan.Engagement)
+
+	// Rechecks inherit the engagement of the scan that reported the finding
+	id := ticketing.Fingerprint(ticketing.Finding{Plugin: "ssh-weak-cipher", Target: "10.0.1.5", Port: 22})
+	recheck, err := svc.Recheck(ctx, id, "", Params{})
+	require.NoError(t, err)
+	require.Equal(t, want, recheck.Run.Engagement)
+}
@@ -3,6 +3,11 @@ package scanexec
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/scope"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 // Sentinel errors for common CLI failures.
@@ -12,13 +17,24 @@ var (
 
 	// ErrConflictingDiscoveryFlags indicates conflicting discovery flags.
 	ErrConflictingDiscoveryFlags = errors.New("cannot use --only-discover and --no-discover together")
+
+	// ErrInterrupted indicates that a run was shut down before it
+	// completed; its partial results were stored.
+	ErrInterrupted = errors.New("scan interrupted")
 )
 
 // Error codes for scan failures used by CLI suggestion system.
 const (
 	errorCodeInvalidTarget        = "INVALID_TARGET"
 	errorCodeConflictingDiscovery = "CONFLICTING_DISCOVERY_FLAGS"
+	errorCodeUnknownTargetGroup   = "UNKNOWN_TARGET_GROUP"
 	errorCodeScanFailure          = "SCAN_FAILURE"
+	errorCodeScanInterrupted      = "SCAN_INTERRUPTED"
+	errorCodeUnknownFinding       = "UNKNOWN_FINDING"
+	errorCodeInvalidFindingState  = "INVALID_FINDING_STATE"
+	errorCodeTargetOutOfScope     = "TARGET_OUT_OF_SCOPE"
+	errorCodeEngagementRequired   = "ENGAGEMENT_REQUIRED"
+	errorCodeOutsideEngagement    = "OUTSIDE_ENGAGEMENT_WINDOW"
 )
 
 // codedError wraps an error with an explicit error code.
@@ -65,6 +81,16 @@ func ErrorCode(err error) string {
 		return errorCodeInvalidTarget
 	case errors.Is(err, ErrConflictingDiscoveryFlags):
 		return errorCodeConflictingDiscovery
+	case errors.Is(err, ErrInterrupted):
+		return errorCodeScanInterrupted
+	case errors.Is(err, ErrFindingNotFound):
+		return errorCodeUnknownFinding
+	case errors.Is(err, scope.ErrOutOfScope):
+		return errorCodeTargetOutOfScope
+	case errors.Is(err, engagement.ErrRequired):
+		return errorCodeEngagementRequired
+	case errors.Is(err, engagement.ErrOutsideWindow):
+		return errorCodeOutsideEngagement
 	}
 
 	return errorCodeScanFailure
@@ -78,7 +104,13 @@ func ExitCode(err error) int {
 
 	switch ErrorCode(err) {
 	case errorCodeInvalidTarget,
-		errorCodeConflictingDiscovery:
+		errorCodeConflictingDiscovery,
+		errorCodeUnknownTargetGroup,
+		errorCodeUnknownFinding,
+		errorCodeInvalidFindingState,
+		errorCodeTargetOutOfScope,
+		errorCodeEngagementRequired,
+		errorCodeOutsideEngagement:
 		return 2
 	default:
 		return 1
@@ -93,8 +125,16 @@ func HTTPStatus(err error) int {
 
 	switch ErrorCode(err) {
 	case errorCodeInvalidTarget,
-		errorCodeConflictingDiscovery:
+		errorCodeConflictingDiscovery,
+		errorCodeUnknownTargetGroup,
+		errorCodeInvalidFindingState,
+		errorCodeEngagementRequired:
 		return 400
+	case errorCodeUnknownFinding:
+		return 404
+	case errorCodeTargetOutOfScope,
+		errorCodeOutsideEngagement:
+		return 403
 	default:
 		return 500
 	}
@@ -117,6 +157,41 @@ func Suggestions(err error) []string {
 			"Remove either --only-discover or --no-discover",
 			"Run help for options:       vulntor scan --help",
 		}
+	case errorCodeUnknownTargetGroup:
+		return []string{
+			"List target groups:         vulntor group list",
+			"Create the group:           vulntor group create <name> --target <target>",
+		}
+	case errorCodeUnknownFinding:
+		return []string{
+			"Find finding IDs:           vulntor report <scan-id> --format csv",
+			"Look in an older scan:      vulntor findings recheck <finding-id> --scan <scan-id>",
+		}
+	case errorCodeInvalidFindingState:
+		return []string{
+			"Use one of the states:      " + strings.Join(storage.FindingStates, ", "),
+			"Run help for options:       vulntor findings set-state --help",
+		}
+	case errorCodeScanInterrupted:
+		return []string{
+			"Review the partial results: vulntor report <scan-id>",
+			"Allow more time to drain:   vulntor scan <target> --drain-timeout 30s",
+		}
+	case errorCodeTargetOutOfScope:
+		return []string{
+			"Check targets against the scope: vulntor scope check <target>",
+			"Get an approved override:        vulntor scope ack --target <target> --reason <reason>",
+			"Scan with the override:          vulntor scan <target> --scope-ack <token>",
+		}
+	case errorCodeEngagementRequired:
+		return []string{
+			"Record the engagement:      vulntor scan <target> --client <client> --authorization <ref> --tester <name> --window <from>/<until>",
+			"Set engagement defaults:    engagement.client, engagement.tester, ... in the config file",
+		}
+	case errorCodeOutsideEngagement:
+		return []string{
+			"Scan within the engagement window, or record the extended window: --window <from>/<until>",
+		}
 	default:
 		return []string{
 			"Retry with verbose logs:    vulntor scan <target> --verbose",
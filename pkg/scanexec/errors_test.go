@@ -2,7 +2,11 @@ package scanexec
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/scope"
 )
 
 func TestScanexecError_WithErrorCodeAndMethods(t *testing.T) {
@@ -41,6 +45,15 @@ func TestScanexecError_ErrorCodeBranches(t *testing.T) {
 	if ErrorCode(ErrConflictingDiscoveryFlags) != errorCodeConflictingDiscovery {
 		t.Errorf("expected conflicting discovery code")
 	}
+	if ErrorCode(fmt.Errorf("run: %w", &scope.Error{})) != errorCodeTargetOutOfScope {
+		t.Errorf("expected out of scope code")
+	}
+	if ErrorCode(fmt.Errorf("run: %w", engagement.ErrRequired)) != errorCodeEngagementRequired {
+		t.Errorf("expected engagement required code")
+	}
+	if ErrorCode(engagement.ErrOutsideWindow) != errorCodeOutsideEngagement {
+		t.Errorf("expected outside engagement window code")
+	}
 	if ErrorCode(errors.New("random")) != errorCodeScanFailure {
 		t.Errorf("expected scan failure default")
 	}
@@ -71,6 +84,9 @@ func TestScanexecError_HTTPStatus(t *testing.T) {
 		{nil, 200},
 		{WithErrorCode(errors.New("x"), errorCodeInvalidTarget), 400},
 		{WithErrorCode(errors.New("x"), errorCodeConflictingDiscovery), 400},
+		{&scope.Error{}, 403},
+		{engagement.ErrRequired, 400},
+		{engagement.ErrOutsideWindow, 403},
 		{WithErrorCode(errors.New("x"), "OTHER"), 500}, // default
 	}
 	for _, tt := range tests {
@@ -0,0 +1,222 @@
+package scanexec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// ErrNoEvidence indicates that a scan has no stored evidence to replay,
+// e.g. because it was run before evidence was stored.
+var ErrNoEvidence = errors.New("scan has no stored evidence")
+
+// evidenceTypes maps the data type names of the outputs of probing modules
+// to the Go types they are decoded into from the evidence file.
+var evidenceTypes = map[string]reflect.Type{
+	"discovery.ICMPPingDiscoveryResult": reflect.TypeOf(discovery.ICMPPingDiscoveryResult{}),
+	"discovery.TCPPortDiscoveryResult":  reflect.TypeOf(discovery.TCPPortDiscoveryResult{}),
+	"scan.BannerGrabResult":             reflect.TypeOf(scan.BannerGrabResult{}),
+	"scan.DefaultCredentialResult":      reflect.TypeOf(scan.DefaultCredentialResult{}),
+	"scan.DNSResult":                    reflect.TypeOf(scan.DNSResult{}),
+	"scan.HTTPNTLMResult":               reflect.TypeOf(scan.HTTPNTLMResult{}),
+	"scan.HTTPWellKnownResult":          reflect.TypeOf(scan.HTTPWellKnownResult{}),
+	"scan.SMBResult":                    reflect.TypeOf(scan.SMBResult{}),
+	"scan.SNMPResult":                   reflect.TypeOf(scan.SNMPResult{}),
+	"scan.SSHAuthResult":                reflect.TypeOf(scan.SSHAuthResult{}),
+	"scan.TLSFingerprintResult":         reflect.TypeOf(scan.TLSFingerprintResult{}),
+	"string":                            reflect.TypeOf(""),
+	"bool":                              reflect.TypeOf(false),
+	"int":                               reflect.TypeOf(0),
+	"[]string":                          reflect.TypeOf([]string{}),
+}
+
+// evidenceRecord is one line of the evidence file (storage.DataTypeEvidence).
+type evidenceRecord struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// evidenceSet holds the outputs of the modules that probed the targets of a
+// scan (discovery and scan modules), by data key: the open ports, banners
+// and protocol details the later stages work on.
+type evidenceSet map[string][]interface{}
+
+// Seed returns the evidence as Params.Seed.
+func (e evidenceSet) Seed() map[string]interface{} {
+	seed := make(map[string]interface{}, len(e))
+	for key, values := range e {
+		seed[key] = values
+	}
+	return seed
+}
+
+// Targets returns the hosts the evidence covers: the live hosts and the
+// hosts open ports were found on, in order of appearance.
+func (e evidenceSet) Targets() []string {
+	seen := make(map[string]bool)
+	var targets []string
+	add := func(host string) {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			targets = append(targets, host)
+		}
+	}
+	for _, v := range e["discovery.live_hosts"] {
+		if result, ok := v.(discovery.ICMPPingDiscoveryResult); ok {
+			for _, host := range result.LiveHosts {
+				add(host)
+			}
+		}
+	}
+	for _, v := range e["discovery.open_tcp_ports"] {
+		if result, ok := v.(discovery.TCPPortDiscoveryResult); ok {
+			add(result.Target)
+		}
+	}
+	return targets
+}
+
+// evidenceKeys returns the data keys produced by discovery and scan
+// modules that the evidence file can hold, sorted by key.
+func evidenceKeys() []engine.DataContractEntry {
+	seen := make(map[string]bool)
+	var keys []engine.DataContractEntry
+	for _, factory := range engine.GetRegisteredModuleFactories() {
+		meta := factory().Metadata()
+		if meta.Type != engine.DiscoveryModuleType && meta.Type != engine.ScanModuleType {
+			continue
+		}
+		for _, produced := range meta.Produces {
+			if _, ok := evidenceTypes[produced.DataTypeName]; !ok || seen[produced.Key] {
+				continue
+			}
+			seen[produced.Key] = true
+			keys = append(keys, produced)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return keys
+}
+
+// persistEvidence writes the outputs of the probing modules to storage as
+// JSONL, so that the scan can be replayed without probing the targets
+// again. Seeded outputs (imports, replays) are written as well.
+func (s *Service) persistEvidence(ctx context.Context, scanID string, dataCtx map[string]interface{}) {
+	if s.storage == nil || dataCtx == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, entry := range evidenceKeys() {
+		values, _ := dataCtx[entry.Key].([]interface{})
+		for _, v := range values {
+			raw, err := json.Marshal(v)
+			if err == nil {
+				err = enc.Encode(evidenceRecord{Key: entry.Key, Type: entry.DataTypeName, Value: raw})
+			}
+			if err != nil {
+				log.Warn().
+					Str("component", "scanexec").
+					Str("scan_id", scanID).
+					Str("key", entry.Key).
+					Err(err).
+					Msg("Failed to encode evidence, skipping")
+			}
+		}
+	}
+	if buf.Len() == 0 {
+		return
+	}
+
+	if err := s.storage.Scans().WriteData(ctx, storage.OrgIDFromContext(ctx), scanID, storage.DataTypeEvidence, &buf); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to persist evidence in storage")
+	}
+}
+
+// loadEvidence reads the evidence stored for a scan. Records of unknown
+// types are skipped. It returns ErrNoEvidence if the scan has none.
+func loadEvidence(ctx context.Context, backend storage.Backend, orgID, scanID string) (evidenceSet, error) {
+	if _, err := backend.Scans().Get(ctx, orgID, scanID); err != nil {
+		return nil, err
+	}
+	rc, err := backend.Scans().ReadData(ctx, orgID, scanID, storage.DataTypeEvidence)
+	if storage.IsNotFound(err) {
+		return nil, fmt.Errorf("scan %s: %w", scanID, ErrNoEvidence)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read evidence of scan %s: %w", scanID, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	evidence := make(evidenceSet)
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record evidenceRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("decode evidence of scan %s: %w", scanID, err)
+		}
+		typ, ok := evidenceTypes[record.Type]
+		if !ok {
+			log.Debug().
+				Str("component", "scanexec").
+				Str("scan_id", scanID).
+				Str("key", record.Key).
+				Str("type", record.Type).
+				Msg("Skipping evidence of unknown type")
+			continue
+		}
+		value := reflect.New(typ)
+		if err := json.Unmarshal(record.Value, value.Interface()); err != nil {
+			return nil, fmt.Errorf("decode evidence %s of scan %s: %w", record.Key, scanID, err)
+		}
+		evidence[record.Key] = append(evidence[record.Key], value.Elem().Interface())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read evidence of scan %s: %w", scanID, err)
+	}
+	if len(evidence) == 0 {
+		return nil, fmt.Errorf("scan %s: %w", scanID, ErrNoEvidence)
+	}
+	return evidence, nil
+}
+
+// prepareReplay points params at the evidence stored for the scan
+// params.ReplayOf: the evidence seeds the run, whose targets are the hosts
+// the evidence covers, and the run inherits the scan's engagement.
+func (s *Service) prepareReplay(ctx context.Context, params *Params) error {
+	if s.storage == nil {
+		return fmt.Errorf("replay scan %s: no storage backend configured", params.ReplayOf)
+	}
+	evidence, err := loadEvidence(ctx, s.storage, storage.OrgIDFromContext(ctx), params.ReplayOf)
+	if err != nil {
+		return err
+	}
+	params.Seed = evidence.Seed()
+	params.Targets = evidence.Targets()
+	if len(params.Targets) == 0 {
+		return fmt.Errorf("scan %s: %w", params.ReplayOf, ErrNoEvidence)
+	}
+	if params.Engagement == nil {
+		params.Engagement = s.scanEngagement(ctx, storage.OrgIDFromContext(ctx), params.ReplayOf)
+	}
+	return nil
+}
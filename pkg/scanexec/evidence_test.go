@@ -0,0 +1,72 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_ReplaysStoredEvidence(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	banner := scan.BannerGrabResult{IP: "10.0.0.5", Port: 22, Protocol: "tcp", Banner: "SSH-2.0-OpenSSH_7.4"}
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"discovery.live_hosts":     []interface{}{discovery.ICMPPingDiscoveryResult{LiveHosts: []string{"10.0.0.5"}}},
+		"discovery.open_tcp_ports": []interface{}{discovery.TCPPortDiscoveryResult{Target: "10.0.0.5", OpenPorts: []int{22, 445}}},
+		"service.banner.tcp":       []interface{}{banner},
+		"smb.signing_required":     []interface{}{false},
+		"service.ssh.details":      []interface{}{map[string]interface{}{"target": "10.0.0.5"}}, // parser output, not evidence
+	}
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+	res, err := svc.Run(ctx, Params{Targets: []string{"10.0.0.0/24"}})
+	require.NoError(t, err)
+
+	// Replays of the scan seed its evidence and send no probes
+	planner := &recordingPlanner{def: def}
+	orch := &inputsOrch{}
+	svc = NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return planner, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+	replay, err := svc.Run(ctx, Params{ReplayOf: res.RunID, Plugins: []string{"ssh"}, EnableVuln: true})
+	require.NoError(t, err)
+
+	require.True(t, planner.intent.Offline)
+	require.Equal(t, []string{"ssh"}, planner.intent.Plugins)
+	require.Equal(t, []string{"10.0.0.5"}, planner.intent.Targets)
+	require.Equal(t, []string{"discovery.live_hosts", "discovery.open_tcp_ports", "service.banner.tcp", "smb.signing_required"}, planner.intent.SeededDataKeys)
+	require.Equal(t, []interface{}{banner}, orch.inputs["service.banner.tcp"])
+	require.Equal(t, []interface{}{false}, orch.inputs["smb.signing_required"])
+	require.NotContains(t, orch.inputs, "service.ssh.details")
+
+	metadata, err := backend.Scans().Get(ctx, storage.DefaultOrgID, replay.RunID)
+	require.NoError(t, err)
+	require.Equal(t, res.RunID, metadata.ReplayOf)
+	require.Equal(t, "10.0.0.5", metadata.Target)
+
+	// Scans without evidence cannot be replayed
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{ID: "old-scan", Target: "10.0.0.5", Status: "completed"}))
+	_, err = svc.Run(ctx, Params{ReplayOf: "old-scan"})
+	require.ErrorIs(t, err, ErrNoEvidence)
+	_, err = svc.Run(ctx, Params{ReplayOf: "missing"})
+	require.True(t, storage.IsNotFound(err))
+}
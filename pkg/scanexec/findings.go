@@ -0,0 +1,122 @@
+package scanexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+// findingScans is how many of the most recent scans are searched for a
+// finding that was never checked or triaged.
+const findingScans = 100
+
+// ErrFindingNotFound indicates that no scan searched reported the finding.
+var ErrFindingNotFound = errors.New("finding not found")
+
+// recordedFinding is the part of a stored finding that identifies it.
+type recordedFinding struct {
+	Target   string `json:"target"`
+	Port     int    `json:"port,omitempty"`
+	Plugin   string `json:"plugin"`
+	PluginID string `json:"plugin_id,omitempty"`
+}
+
+// id returns the fingerprint identifying the finding across scans.
+func (f recordedFinding) id() string {
+	return ticketing.Fingerprint(ticketing.Finding{Plugin: f.Plugin, Target: f.Target, Port: f.Port})
+}
+
+// FindingStateChange is a triage change of a finding.
+type FindingStateChange struct {
+	State    string
+	Assignee *string // nil keeps the current assignee
+	Actor    string
+	Note     string
+}
+
+// SetFindingState moves the finding with ID findingID to the triage state
+// of change and records the transition. Findings never checked or triaged
+// are looked up as by FindFinding.
+func SetFindingState(ctx context.Context, backend storage.Backend, orgID, findingID, scanID string, change FindingStateChange) (*storage.FindingStatus, error) {
+	statusBackend, ok := backend.(storage.FindingStatusBackend)
+	if !ok {
+		return nil, fmt.Errorf("%w: storage backend does not keep finding statuses", storage.ErrNotSupported)
+	}
+	statuses := statusBackend.FindingStatuses()
+
+	if err := storage.ValidateFindingState(change.State); err != nil {
+		return nil, WithErrorCode(err, errorCodeInvalidFindingState)
+	}
+	finding, err := statuses.Get(ctx, orgID, findingID)
+	if storage.IsNotFound(err) {
+		finding, err = FindFinding(ctx, backend, orgID, findingID, scanID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transition := storage.FindingTransition{
+		To:       change.State,
+		Assignee: finding.Assignee,
+		Actor:    change.Actor,
+		Note:     change.Note,
+		At:       time.Now(),
+	}
+	if change.Assignee != nil {
+		transition.Assignee = *change.Assignee
+	}
+	if err := statuses.Transition(ctx, orgID, finding, transition); err != nil {
+		return nil, fmt.Errorf("set state of finding %s: %w", findingID, err)
+	}
+	return finding, nil
+}
+
+// FindFinding looks up the finding with ID findingID in the scan with ID
+// scanID or, when empty, in the recent completed scans of the
+// organization, newest first. The returned status has the finding's
+// details but no checks or transitions.
+func FindFinding(ctx context.Context, backend storage.Backend, orgID, findingID, scanID string) (*storage.FindingStatus, error) {
+	var scans []*storage.ScanMetadata
+	if scanID != "" {
+		scan, err := backend.Scans().Get(ctx, orgID, scanID)
+		if err != nil {
+			return nil, err
+		}
+		scans = append(scans, scan)
+	} else {
+		var err error
+		scans, _, _, err = backend.Scans().ListPaginated(
+			ctx, orgID, storage.ScanFilter{Status: string(storage.StatusCompleted)}, "", findingScans,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, scan := range scans {
+		findings, err := storage.ReadJSONL[recordedFinding](ctx, backend.Scans(), orgID, scan.ID, storage.DataTypeVulnerabilities)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range findings {
+			if f.id() == findingID {
+				return &storage.FindingStatus{
+					ID:       findingID,
+					Target:   f.Target,
+					Port:     f.Port,
+					Plugin:   f.Plugin,
+					PluginID: f.PluginID,
+					ScanID:   scan.ID,
+				}, nil
+			}
+		}
+	}
+	if scanID != "" {
+		return nil, fmt.Errorf("finding %s in scan %s: %w", findingID, scanID, ErrFindingNotFound)
+	}
+	return nil, fmt.Errorf("finding %s: %w", findingID, ErrFindingNotFound)
+}
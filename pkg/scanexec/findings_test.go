@@ -0,0 +1,48 @@
+package scanexec
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func TestSetFindingState(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	scans := backend.Scans()
+	require.NoError(t, scans.Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{ID: "scan-1", Target: "10.0.1.0/24", Status: string(storage.StatusCompleted), StartedAt: time.Now()}))
+	require.NoError(t, scans.WriteData(ctx, storage.DefaultOrgID, "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.1.5","port":22,"plugin":"ssh-weak-cipher","severity":"high"}`+"\n")))
+	id := ticketing.Fingerprint(ticketing.Finding{Plugin: "ssh-weak-cipher", Target: "10.0.1.5", Port: 22})
+
+	alice := "alice"
+	status, err := SetFindingState(ctx, backend, storage.DefaultOrgID, id, "", FindingStateChange{State: storage.FindingStateInProgress, Assignee: &alice, Actor: "bob"})
+	require.NoError(t, err)
+	require.Equal(t, "10.0.1.5", status.Target)
+	require.Equal(t, "scan-1", status.ScanID)
+	require.Equal(t, "alice", status.Assignee)
+
+	// Without an assignee the current one is kept
+	status, err = SetFindingState(ctx, backend, storage.DefaultOrgID, id, "", FindingStateChange{State: storage.FindingStateFixed})
+	require.NoError(t, err)
+	require.Equal(t, storage.FindingStateFixed, status.State)
+	require.Equal(t, "alice", status.Assignee)
+	require.Len(t, status.Transitions, 2)
+
+	_, err = SetFindingState(ctx, backend, storage.DefaultOrgID, id, "", FindingStateChange{State: "closed"})
+	require.Equal(t, errorCodeInvalidFindingState, ErrorCode(err))
+	require.Equal(t, 2, ExitCode(err))
+
+	_, err = SetFindingState(ctx, backend, storage.DefaultOrgID, "0000000000000000", "scan-1", FindingStateChange{State: storage.FindingStateAcknowledged})
+	require.True(t, errors.Is(err, ErrFindingNotFound))
+}
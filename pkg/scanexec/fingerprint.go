@@ -0,0 +1,21 @@
+package scanexec
+
+import "github.com/vulntor/vulntor/pkg/modules/scan"
+
+// tlsFingerprints returns the fingerprints of the TLS services in
+// tls.fingerprint.details, by IP and port.
+func tlsFingerprints(dataCtx map[string]interface{}) map[string]map[int]scan.TLSFingerprintResult {
+	values, _ := dataCtx["tls.fingerprint.details"].([]interface{})
+	fingerprints := make(map[string]map[int]scan.TLSFingerprintResult)
+	for _, v := range values {
+		result, ok := v.(scan.TLSFingerprintResult)
+		if !ok {
+			continue
+		}
+		if fingerprints[result.Target] == nil {
+			fingerprints[result.Target] = make(map[int]scan.TLSFingerprintResult)
+		}
+		fingerprints[result.Target][result.Port] = result
+	}
+	return fingerprints
+}
@@ -0,0 +1,56 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_TLSFingerprint(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	const cobaltStrike = "07d14d16d21d21d07c42d41d00041d24a458a375eef0c576d23a7bab9a9fb1"
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"asset.profiles": []interface{}{[]engine.AssetProfile{{
+			Target:      "10.0.0.9",
+			ResolvedIPs: map[string]time.Time{"10.0.0.9": time.Now()},
+			OpenPorts: map[string][]engine.PortProfile{"10.0.0.9": {
+				{PortNumber: 80, Protocol: "tcp"},
+				{PortNumber: 443, Protocol: "tcp"},
+			}},
+		}}},
+		"tls.fingerprint.details": []interface{}{
+			scan.TLSFingerprintResult{Target: "10.0.0.9", Port: 443, JARM: cobaltStrike, JA3S: "ae4edc6faf64d08308082ad26be60767"},
+		},
+	}
+
+	scans := &memScans{}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.0.9"}})
+	require.NoError(t, err)
+
+	var host storage.HostRecord
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(scans.written[storage.DataTypeHosts]))), &host))
+	require.Len(t, host.Ports, 2)
+	require.Empty(t, host.Ports[0].JARM)
+	require.Equal(t, cobaltStrike, host.Ports[1].JARM)
+	require.Equal(t, "ae4edc6faf64d08308082ad26be60767", host.Ports[1].JA3S)
+}
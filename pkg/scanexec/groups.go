@@ -0,0 +1,135 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// ResolveTargetGroups loads the target groups named by names from the
+// groups of orgID in backend. Unknown names are invalid input, so that API
+// clients get a 400.
+func ResolveTargetGroups(ctx context.Context, backend storage.Backend, orgID string, names []string) ([]*storage.TargetGroup, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	groupBackend, ok := backend.(storage.TargetGroupBackend)
+	if !ok {
+		return nil, fmt.Errorf("%w: storage backend does not keep target groups", storage.ErrNotSupported)
+	}
+
+	groups := make([]*storage.TargetGroup, 0, len(names))
+	for _, name := range names {
+		group, err := groupBackend.TargetGroups().Get(ctx, orgID, name)
+		if storage.IsNotFound(err) {
+			return nil, WithErrorCode(storage.NewInvalidInputError("groups", fmt.Sprintf("unknown target group %q", name)), errorCodeUnknownTargetGroup)
+		}
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// ResolveTargetGroups loads the named target groups from the storage
+// backend of the service. Without a backend that keeps target groups, any
+// name fails with ErrNotSupported.
+func (s *Service) ResolveTargetGroups(ctx context.Context, orgID string, names []string) ([]*storage.TargetGroup, error) {
+	return ResolveTargetGroups(ctx, s.storage, orgID, names)
+}
+
+// GroupTargets returns targets followed by the targets of groups, each
+// listed once.
+func GroupTargets(targets []string, groups []*storage.TargetGroup) []string {
+	if len(groups) == 0 {
+		return targets
+	}
+
+	out := slices.Clone(targets)
+	for _, g := range groups {
+		for _, target := range g.Targets {
+			if !slices.Contains(out, target) {
+				out = append(out, target)
+			}
+		}
+	}
+	return out
+}
+
+// blackoutGate returns the gate pausing probes to the targets of groups
+// during their blackout windows, or nil if they have none.
+func blackoutGate(groups []*storage.TargetGroup) (*blackout.Gate, error) {
+	var schedules []blackout.Schedule
+	for _, g := range groups {
+		windows, err := blackout.ParseWindows(g.Blackouts)
+		if err != nil {
+			return nil, storage.NewInvalidInputError("groups", fmt.Sprintf("target group %q: %v", g.Name, err))
+		}
+		schedules = append(schedules, blackout.Schedule{Name: g.Name, Contains: g.Contains, Windows: windows})
+	}
+	return blackout.NewGate(schedules), nil
+}
+
+// groupNames returns the names of groups.
+func groupNames(groups []*storage.TargetGroup) []string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+// findingGroups returns the groups a finding on host belongs to: the groups
+// listing host (or a range containing it) and the groups listing the scan
+// target host was found through (hostTargets, by IP).
+func findingGroups(groups []*storage.TargetGroup, host string, hostTargets map[string]string) []*storage.TargetGroup {
+	var matched []*storage.TargetGroup
+	for _, g := range groups {
+		if g.Contains(host) || slices.Contains(g.Targets, hostTargets[host]) {
+			matched = append(matched, g)
+		}
+	}
+	return matched
+}
+
+// tagFinding adds the groups of host and their tags to the JSON form of a
+// finding as "groups" and "group_tags", and the cloud instance of host as
+// "cloud". Findings outside every group and instance are returned
+// unchanged.
+func tagFinding(v interface{}, groups []*storage.TargetGroup, cloud map[string]*storage.CloudRecord, hostTargets map[string]string) interface{} {
+	if len(groups) == 0 && len(cloud) == 0 {
+		return v
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var finding map[string]interface{}
+	if err := json.Unmarshal(raw, &finding); err != nil {
+		return v
+	}
+
+	host, _ := finding["target"].(string)
+	matched := findingGroups(groups, host, hostTargets)
+	instance := cloudRecord(cloud, host, hostTargets[host])
+	if len(matched) == 0 && instance == nil {
+		return v
+	}
+	if len(matched) > 0 {
+		finding["groups"] = groupNames(matched)
+		if tags := storage.GroupTags(matched); len(tags) > 0 {
+			finding["group_tags"] = tags
+		}
+	}
+	if instance != nil {
+		finding["cloud"] = instance
+	}
+	return finding
+}
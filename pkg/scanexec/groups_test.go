@@ -0,0 +1,128 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestResolveTargetGroups(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.TargetGroups().Create(ctx, storage.DefaultOrgID, &storage.TargetGroup{Name: "prod-web", Targets: []string{"10.0.1.0/24"}}))
+
+	groups, err := ResolveTargetGroups(ctx, backend, storage.DefaultOrgID, []string{"prod-web"})
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, []string{"10.0.1.0/24"}, groups[0].Targets)
+
+	groups, err = ResolveTargetGroups(ctx, backend, storage.DefaultOrgID, nil)
+	require.NoError(t, err)
+	require.Nil(t, groups)
+
+	// Groups are per tenant
+	_, err = ResolveTargetGroups(ctx, backend, "team-a", []string{"prod-web"})
+	require.True(t, storage.IsInvalidInput(err))
+	require.Equal(t, errorCodeUnknownTargetGroup, ErrorCode(err))
+	require.Equal(t, 2, ExitCode(err))
+	require.Contains(t, err.Error(), `unknown target group "prod-web"`)
+
+	_, err = ResolveTargetGroups(ctx, &memBackend{scans: &memScans{}}, storage.DefaultOrgID, []string{"prod-web"})
+	require.True(t, errors.Is(err, storage.ErrNotSupported))
+}
+
+func TestRun_TargetGroups(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "10.0.1.5", "plugin": "ssh-weak-cipher", "severity": "high"},
+			map[string]interface{}{"target": "203.0.113.9", "plugin": "http-server-header", "severity": "info"},
+			map[string]interface{}{"target": "192.0.2.1", "plugin": "telnet", "severity": "critical"},
+		},
+		// www.example.com resolved to 203.0.113.9
+		"asset.profiles": []interface{}{[]engine.AssetProfile{{
+			Target:      "www.example.com",
+			ResolvedIPs: map[string]time.Time{"203.0.113.9": time.Now()},
+		}}},
+	}
+
+	groups := []*storage.TargetGroup{
+		{Name: "prod-web", Targets: []string{"10.0.1.0/24", "www.example.com"}, Tags: []string{"prod", "pci"}},
+		{Name: "dmz", Targets: []string{"10.0.1.5"}, Tags: []string{"prod", "dmz"}},
+	}
+
+	scans := &memScans{}
+	orch := &mockOrch{out: orchOut}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"192.0.2.1", "10.0.1.5"}, TargetGroups: groups})
+	require.NoError(t, err)
+
+	// Group targets are scanned once, after the explicit targets
+	require.Len(t, scans.created, 1)
+	require.Equal(t, "192.0.2.1 (and 3 more)", scans.created[0].Target)
+	require.Equal(t, []string{"prod-web", "dmz"}, scans.created[0].Groups)
+
+	findings := make(map[string]map[string]interface{})
+	for _, line := range strings.Split(strings.TrimSpace(string(scans.written[storage.DataTypeVulnerabilities])), "\n") {
+		var f map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &f))
+		findings[f["target"].(string)] = f
+	}
+	require.Equal(t, []interface{}{"prod-web", "dmz"}, findings["10.0.1.5"]["groups"])
+	require.Equal(t, []interface{}{"prod", "pci", "dmz"}, findings["10.0.1.5"]["group_tags"])
+	require.Equal(t, []interface{}{"prod-web"}, findings["203.0.113.9"]["groups"])
+	require.NotContains(t, findings["192.0.2.1"], "groups")
+}
+
+func TestRun_BlackoutGate(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	groups := []*storage.TargetGroup{
+		{Name: "ot", Targets: []string{"10.0.9.0/24"}, Blackouts: []string{"00:00-00:00"}},
+		{Name: "web", Targets: []string{"10.0.1.0/24"}},
+	}
+	_, err = svc.Run(ctx, Params{TargetGroups: groups})
+	require.NoError(t, err)
+	gate := blackout.FromContext(orch.ctx)
+	require.False(t, gate.Until("10.0.9.5:502", time.Now()).IsZero())
+	require.True(t, gate.Until("10.0.1.5:443", time.Now()).IsZero())
+
+	// Groups without blackout windows never pause
+	_, err = svc.Run(ctx, Params{TargetGroups: groups[1:]})
+	require.NoError(t, err)
+	require.Nil(t, blackout.FromContext(orch.ctx))
+
+	_, err = svc.Run(ctx, Params{TargetGroups: []*storage.TargetGroup{{Name: "bad", Targets: []string{"10.0.0.1"}, Blackouts: []string{"noon"}}}})
+	require.True(t, storage.IsInvalidInput(err))
+}
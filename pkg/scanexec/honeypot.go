@@ -0,0 +1,63 @@
+package scanexec
+
+import (
+	"encoding/json"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/honeypot"
+	"github.com/vulntor/vulntor/pkg/report"
+)
+
+// flagHoneypots marks the findings in dataCtx on hosts that look like
+// honeypots or tarpits (see package honeypot) as low confidence, adding
+// confidence and confidence_reason to them.
+func (s *Service) flagHoneypots(scanID string, dataCtx map[string]interface{}) {
+	if s.honeypot == nil || dataCtx == nil {
+		return
+	}
+
+	var hosts []honeypot.Host
+	for _, p := range assetProfiles(dataCtx) {
+		for ip, ports := range p.OpenPorts {
+			host := honeypot.Host{IP: ip}
+			for _, port := range ports {
+				responded := port.Service.RawBanner != ""
+				for _, obs := range port.Service.Evidence {
+					responded = responded || obs.Response != ""
+				}
+				host.Ports = append(host.Ports, honeypot.Port{Port: port.PortNumber, Banner: port.Service.RawBanner, Responded: responded})
+			}
+			hosts = append(hosts, host)
+		}
+	}
+	flagged := s.honeypot.DetectAll(hosts)
+	if len(flagged) == 0 {
+		return
+	}
+
+	logger := log.With().Str("component", "scanexec").Str("scan_id", scanID).Logger()
+	for ip, reasons := range flagged {
+		logger.Warn().Str("host", ip).Strs("reasons", reasons).Msg("Host looks like a honeypot or tarpit, its findings are low confidence")
+	}
+
+	vulns, _ := dataCtx[report.FindingsKey].([]interface{})
+	for i, v := range vulns {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		var finding map[string]interface{}
+		if err := json.Unmarshal(raw, &finding); err != nil {
+			continue
+		}
+		host, _ := finding["target"].(string)
+		reasons, ok := flagged[host]
+		if !ok {
+			continue
+		}
+		finding["confidence"] = report.ConfidenceLow
+		finding["confidence_reason"] = honeypot.Reason(reasons)
+		vulns[i] = finding
+	}
+}
@@ -0,0 +1,69 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/honeypot"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_FlagsHoneypots(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	// 10.0.1.5 accepts connections on 12 ports without sending anything
+	var silent []engine.PortProfile
+	for port := 1000; port < 1012; port++ {
+		silent = append(silent, engine.PortProfile{PortNumber: port, Protocol: "tcp"})
+	}
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &mockOrch{out: map[string]interface{}{
+		"asset.profiles": []interface{}{[]engine.AssetProfile{{
+			Target:      "10.0.1.0/24",
+			ResolvedIPs: map[string]time.Time{"10.0.1.5": time.Now(), "10.0.1.6": time.Now()},
+			OpenPorts: map[string][]engine.PortProfile{
+				"10.0.1.5": silent,
+				"10.0.1.6": {{PortNumber: 22, Protocol: "tcp", Service: engine.ServiceDetails{Name: "ssh", RawBanner: "SSH-2.0-OpenSSH_8.9"}}},
+			},
+		}}},
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "10.0.1.5", "port": 1001, "plugin": "Backdoor Port", "severity": "critical"},
+			map[string]interface{}{"target": "10.0.1.6", "port": 22, "plugin": "SSH Weak MAC Algorithm", "severity": "medium"},
+		},
+	}}
+	svc := NewService().
+		WithStorage(backend).
+		WithHoneypot(honeypot.New(honeypot.Thresholds{})).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	res, err := svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}})
+	require.NoError(t, err)
+
+	data, err := report.Load(ctx, backend.Scans(), storage.DefaultOrgID, res.RunID)
+	require.NoError(t, err)
+	require.Len(t, data.Findings, 2)
+	for _, f := range data.Findings {
+		if f.Target == "10.0.1.5" {
+			require.True(t, f.LowConfidence())
+			require.Equal(t, "Possible honeypot or tarpit: accepts connections on 12 ports without sending data (tarpit)", f.ConfidenceReason)
+		} else {
+			require.False(t, f.LowConfidence())
+			require.Empty(t, f.ConfidenceReason)
+		}
+	}
+}
@@ -1,5 +1,13 @@
 package scanexec
 
+import (
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/tracing"
+)
+
 // Params defines the input required to initiate a scan run.
 type Params struct {
 	Targets       []string
@@ -19,6 +27,104 @@ type Params struct {
 	RawInputs     map[string]interface{}
 	OnlyDiscover  bool
 	SkipDiscover  bool
+
+	// Seed supplies results of earlier stages, keyed by data key (e.g.
+	// hosts, open ports and banners imported from another scanner). Modules
+	// producing these keys are skipped and the rest run over the seed.
+	Seed map[string]interface{}
+
+	// ReplayOf re-evaluates the evidence stored by an earlier scan with this
+	// ID instead of probing targets: the banners and protocol details it
+	// recorded are fingerprinted and evaluated again, e.g. with plugins
+	// released since. Targets and Seed are taken from the evidence, and no
+	// module sending probes runs. Requires a storage backend.
+	ReplayOf string
+
+	// Plugins restricts plugin evaluation to the plugins with these IDs,
+	// names, categories or tags. Empty = all plugins.
+	Plugins []string
+
+	// RecheckOf marks the run as the recheck of the finding with this ID
+	// (see Service.Recheck). Rechecks cover a single port and plugin, so
+	// they neither report changes since the last scan, nor update tickets,
+	// nor serve as the baseline of later scans.
+	RecheckOf string
+
+	// NucleiTemplates are Nuclei template files or directories run against
+	// the HTTP services found when EnableVuln is set.
+	NucleiTemplates []string
+
+	// DefaultCredentials tests the default credentials declared by plugins
+	// against the services found. Login attempts are rate limited and stop
+	// on lockout.
+	DefaultCredentials bool
+
+	// ContentDiscovery requests a wordlist of paths from the HTTP services
+	// found and feeds the paths they answer for to plugin evaluation.
+	ContentDiscovery bool
+
+	// AutoTune tunes discovery and banner grabbing concurrency and
+	// timeouts from system resources and observed round trips, starting
+	// from Concurrency and CustomTimeout.
+	AutoTune bool
+
+	// VerifyTimeouts probes the ports whose connection attempts timed out
+	// once more at the end of port discovery, at lower concurrency, so
+	// that congestion on aggressive scans does not hide open ports.
+	VerifyTimeouts bool
+
+	// Capture records the traffic of probes and stores it as one pcap
+	// artifact per host: capture.ModeAll keeps everything, and
+	// capture.ModeFindings only the ports findings were reported on.
+	// Empty = no capture. Requires a storage backend keeping artifacts.
+	Capture capture.Mode
+
+	// Interface and SourceIP bind probes to a network interface and source
+	// address of the scanner host, e.g. the scanning network of a
+	// multi-homed host, so that scan traffic is attributed to the expected
+	// address. Empty = chosen by the routing table.
+	Interface string
+	SourceIP  string
+
+	// Decoys are IPv4 addresses privileged ICMP host discovery also sends
+	// echo requests from. Connect probes cannot be sent from decoys.
+	Decoys []string
+
+	// ScopeAck is a signed acknowledgment approving targets outside the
+	// service's scan scope (see scope.Sign). Empty = such targets are
+	// refused.
+	ScopeAck string
+
+	// Engagement records the authorization the run is under (see package
+	// engagement); the fields it leaves empty are taken from the service's
+	// engagement policy. Replays and rechecks without one inherit the
+	// engagement of the scan they evaluate again.
+	Engagement *engagement.Record
+
+	// Environment selects the severity overrides of the service's policy
+	// that apply to the run (e.g. "ot"). Empty = the policy's environment.
+	Environment string
+
+	// TargetGroups are scanned besides Targets (see ResolveTargetGroups).
+	// Findings on the targets of a group are stored with its name and tags.
+	TargetGroups []*storage.TargetGroup
+
+	// Cloud maps the targets listed from a cloud inventory (by address) to
+	// their instances. Hosts and findings on them are stored with the
+	// instance, so that findings can be routed to the team owning it.
+	Cloud map[string]*storage.CloudRecord
+
+	// ScanID pre-assigns the run identifier (e.g., for async API jobs whose
+	// ID is returned to the caller before execution starts). Empty = generate.
+	ScanID string
+
+	// OrgID is the tenant that owns the scan. Empty = the tenant selected on
+	// the context (storage.WithOrgID), which defaults to storage.DefaultOrgID.
+	OrgID string
+
+	// Trace links the scan's spans to the trace that requested it (e.g., the
+	// API request that submitted an async job). Zero = start a new trace.
+	Trace tracing.SpanContext
 }
 
 // Result is a placeholder for structured scan outputs.
@@ -29,4 +135,8 @@ type Result struct {
 	Status     string
 	Findings   interface{}
 	RawContext map[string]interface{}
+	// PluginStats reports the executions of each plugin, slowest first.
+	PluginStats []plugin.ExecStats
+	// Engagement is the engagement the run was under; nil without one.
+	Engagement *engagement.Record
 }
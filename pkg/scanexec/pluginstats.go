@@ -0,0 +1,106 @@
+package scanexec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/tracing"
+)
+
+// recordPluginStats reports the plugin executions of a run: it adds them
+// to the process totals served as metrics, notes the slowest plugin on the
+// span and persists them for `scan stats`.
+func (s *Service) recordPluginStats(ctx context.Context, span *tracing.Span, scanID string, stats []plugin.ExecStats) {
+	if len(stats) == 0 {
+		return
+	}
+	plugin.TotalExecStats().Add(stats)
+	span.SetAttributes(
+		tracing.Int("plugins.executed", len(stats)),
+		tracing.String("plugins.slowest", stats[0].PluginID),
+		tracing.Int("plugins.slowest_ms", int(stats[0].WallTime.Milliseconds())))
+
+	if s.storage == nil {
+		return
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, st := range stats {
+		if err := enc.Encode(st); err != nil {
+			log.Warn().
+				Str("component", "scanexec").
+				Str("scan_id", scanID).
+				Str("plugin_id", st.PluginID).
+				Err(err).
+				Msg("Failed to encode plugin stats, skipping")
+		}
+	}
+	if err := s.storage.Scans().WriteData(ctx, storage.OrgIDFromContext(ctx), scanID, storage.DataTypePluginStats, &buf); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to persist plugin stats in storage")
+	}
+}
+
+// LoadPluginStats reads the plugin executions stored for a scan, slowest
+// plugins first. Scans that ran no plugins have none.
+func LoadPluginStats(ctx context.Context, backend storage.Backend, orgID, scanID string) ([]plugin.ExecStats, error) {
+	if _, err := backend.Scans().Get(ctx, orgID, scanID); err != nil {
+		return nil, err
+	}
+	rc, err := backend.Scans().ReadData(ctx, orgID, scanID, storage.DataTypePluginStats)
+	if storage.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read plugin stats of scan %s: %w", scanID, err)
+	}
+	defer func() { _ = rc.Close() }()
+
+	var stats []plugin.ExecStats
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		var st plugin.ExecStats
+		if err := json.Unmarshal(scanner.Bytes(), &st); err != nil {
+			return nil, fmt.Errorf("decode plugin stats of scan %s: %w", scanID, err)
+		}
+		stats = append(stats, st)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read plugin stats of scan %s: %w", scanID, err)
+	}
+	plugin.SortExecStats(stats)
+	return stats, nil
+}
+
+// LoadPluginHistory reads the plugin executions of up to scans of the most
+// recently completed scans of an organization, newest first, e.g. to curate
+// the plugin catalog with plugin.Curate. Scans without stats are skipped.
+func LoadPluginHistory(ctx context.Context, backend storage.Backend, orgID string, scans int) ([][]plugin.ExecStats, error) {
+	completed, _, _, err := backend.Scans().ListPaginated(
+		ctx, orgID, storage.ScanFilter{Status: string(storage.StatusCompleted)}, "", scans,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var history [][]plugin.ExecStats
+	for _, scan := range completed {
+		stats, err := LoadPluginStats(ctx, backend, orgID, scan.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(stats) > 0 {
+			history = append(history, stats)
+		}
+	}
+	return history, nil
+}
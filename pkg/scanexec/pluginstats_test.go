@@ -0,0 +1,91 @@
+package scanexec
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// pluginOrch evaluates plugins the way the evaluation modules do,
+// recording their executions.
+type pluginOrch struct{}
+
+func (pluginOrch) Run(ctx context.Context, _ map[string]interface{}) (map[string]interface{}, error) {
+	stats := plugin.ExecRecorderFromContext(ctx)
+	slow := &plugin.YAMLPlugin{ID: "slow", Name: "Slow Plugin"}
+	fast := &plugin.YAMLPlugin{ID: "fast", Name: "Fast Plugin"}
+	stats.Record(slow, 300*time.Millisecond, true, nil)
+	stats.Record(slow, 200*time.Millisecond, false, errors.New("timeout"))
+	stats.Record(fast, time.Millisecond, false, nil)
+	return map[string]interface{}{}, nil
+}
+
+func TestRun_RecordsPluginStats(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return pluginOrch{}, nil })
+
+	before := totalOf(plugin.TotalExecStats().Stats(), "slow")
+	res, err := svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+
+	want := []plugin.ExecStats{
+		{PluginID: "slow", Plugin: "Slow Plugin", Attempts: 2, Matches: 1, Errors: 1, WallTime: 500 * time.Millisecond},
+		{PluginID: "fast", Plugin: "Fast Plugin", Attempts: 1, WallTime: time.Millisecond},
+	}
+	require.Equal(t, want, res.PluginStats)
+
+	stored, err := LoadPluginStats(ctx, backend, storage.OrgIDFromContext(ctx), res.RunID)
+	require.NoError(t, err)
+	require.Equal(t, want, stored)
+
+	after := totalOf(plugin.TotalExecStats().Stats(), "slow")
+	require.Equal(t, before.Attempts+2, after.Attempts)
+	require.Equal(t, before.WallTime+500*time.Millisecond, after.WallTime)
+
+	// Scans that ran no plugins have no stats
+	svc.WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &ctxOrch{}, nil })
+	res, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Empty(t, res.PluginStats)
+	stored, err = LoadPluginStats(ctx, backend, storage.OrgIDFromContext(ctx), res.RunID)
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	// The history holds the scans that stored stats
+	history, err := LoadPluginHistory(ctx, backend, storage.OrgIDFromContext(ctx), 10)
+	require.NoError(t, err)
+	require.Equal(t, [][]plugin.ExecStats{want}, history)
+
+	_, err = LoadPluginStats(ctx, backend, storage.OrgIDFromContext(ctx), "missing")
+	require.True(t, storage.IsNotFound(err))
+}
+
+func totalOf(stats []plugin.ExecStats, id string) plugin.ExecStats {
+	for _, s := range stats {
+		if s.PluginID == id {
+			return s
+		}
+	}
+	return plugin.ExecStats{}
+}
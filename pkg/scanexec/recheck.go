@@ -0,0 +1,91 @@
+package scanexec
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// RecheckResult is the outcome of rechecking a finding.
+type RecheckResult struct {
+	// Finding is the finding's status, with the recheck as its latest
+	// check.
+	Finding storage.FindingStatus
+
+	// Run is the recheck run.
+	Run *Result
+}
+
+// Recheck runs the plugin that reported the finding with ID findingID
+// again, against the target and port it was reported on, and records
+// whether the finding is still present or fixed in the finding's status
+// history. Findings without a status are looked up as by FindFinding.
+//
+// params supply the remaining settings of the run, e.g. its output format
+// and timeouts; their targets, ports and plugins are replaced. Without an
+// engagement, the recheck inherits the one of the scan that reported the
+// finding. Failed runs
+// record nothing, as they cannot tell whether the finding is gone.
+func (s *Service) Recheck(ctx context.Context, findingID, scanID string, params Params) (*RecheckResult, error) {
+	if s.storage == nil {
+		return nil, fmt.Errorf("recheck finding %s: no storage backend configured", findingID)
+	}
+	statusBackend, ok := s.storage.(storage.FindingStatusBackend)
+	if !ok {
+		return nil, fmt.Errorf("%w: storage backend does not keep finding statuses", storage.ErrNotSupported)
+	}
+	statuses := statusBackend.FindingStatuses()
+
+	orgID := params.OrgID
+	if orgID == "" {
+		orgID = storage.OrgIDFromContext(ctx)
+	}
+	ctx = storage.WithOrgID(ctx, orgID)
+
+	finding, err := statuses.Get(ctx, orgID, findingID)
+	if storage.IsNotFound(err) {
+		finding, err = FindFinding(ctx, s.storage, orgID, findingID, scanID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	params.Targets = []string{finding.Target}
+	params.TargetGroups = nil
+	params.Ports = ""
+	if finding.Port > 0 {
+		params.Ports = strconv.Itoa(finding.Port)
+	}
+	params.Plugins = []string{finding.Plugin}
+	if finding.PluginID != "" {
+		params.Plugins = []string{finding.PluginID}
+	}
+	params.EnableVuln = true
+	params.OnlyDiscover = false
+	params.ReplayOf = ""
+	params.Seed = nil
+	params.RecheckOf = findingID
+	if params.Engagement == nil {
+		params.Engagement = s.scanEngagement(ctx, orgID, finding.ScanID)
+	}
+
+	res, err := s.Run(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	check := storage.FindingCheck{Status: storage.FindingFixed, ScanID: res.RunID, CheckedAt: time.Now()}
+	for _, f := range decodeFindings[recordedFinding](res.RawContext) {
+		if f.id() == findingID {
+			check.Status = storage.FindingStillPresent
+			break
+		}
+	}
+	if err := statuses.Record(ctx, orgID, finding, check); err != nil {
+		return nil, fmt.Errorf("record recheck of finding %s: %w", findingID, err)
+	}
+	return &RecheckResult{Finding: *finding, Run: res}, nil
+}
@@ -0,0 +1,78 @@
+package scanexec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func TestRecheck(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	finding := map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "ssh-weak-cipher", "plugin_id": "ssh-weak-cipher", "severity": "high"}
+	orch := &mockOrch{out: map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			finding,
+			map[string]interface{}{"target": "10.0.1.6", "plugin": "telnet", "severity": "critical"},
+		},
+	}}
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	first, err := svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}})
+	require.NoError(t, err)
+
+	id := ticketing.Fingerprint(ticketing.Finding{Plugin: "ssh-weak-cipher", Target: "10.0.1.5", Port: 22})
+	res, err := svc.Recheck(ctx, id, "", Params{})
+	require.NoError(t, err)
+	require.Equal(t, storage.FindingStillPresent, res.Finding.Status)
+	require.Equal(t, first.RunID, res.Finding.ScanID)
+	require.Len(t, res.Finding.History, 1)
+
+	// Only the finding's target, port and plugin are scanned
+	scan, err := backend.Scans().Get(ctx, storage.DefaultOrgID, res.Run.RunID)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.1.5", scan.Target)
+	require.Equal(t, id, scan.RecheckOf)
+
+	// Once fixed, the next recheck no longer reports it
+	orch.out = map[string]interface{}{"evaluation.vulnerabilities": []interface{}{}}
+	res, err = svc.Recheck(ctx, id, "", Params{})
+	require.NoError(t, err)
+	require.Equal(t, storage.FindingFixed, res.Finding.Status)
+	require.Len(t, res.Finding.History, 2)
+	require.Equal(t, storage.FindingStillPresent, res.Finding.History[0].Status)
+	require.Equal(t, res.Run.RunID, res.Finding.History[1].ScanID)
+
+	status, err := backend.FindingStatuses().Get(ctx, storage.DefaultOrgID, id)
+	require.NoError(t, err)
+	require.Equal(t, storage.FindingFixed, status.Status)
+
+	// Rechecks are not searched for findings
+	_, err = svc.Recheck(ctx, "0000000000000000", "", Params{})
+	require.True(t, errors.Is(err, ErrFindingNotFound))
+	require.Equal(t, errorCodeUnknownFinding, ErrorCode(err))
+	_, err = svc.Recheck(ctx, "0000000000000000", first.RunID, Params{})
+	require.True(t, errors.Is(err, ErrFindingNotFound))
+
+	_, err = NewService().WithStorage(&memBackend{scans: &memScans{}}).Recheck(ctx, id, "", Params{})
+	require.True(t, errors.Is(err, storage.ErrNotSupported))
+}
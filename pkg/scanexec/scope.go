@@ -0,0 +1,33 @@
+package scanexec
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// checkScope refuses runs with targets outside the service's scan scope,
+// unless the run's acknowledgment approves them. Approved overrides are
+// logged with who approved them and why.
+func (s *Service) checkScope(ctx context.Context, scanID string, params Params) error {
+	ack, err := s.scope.Check(ctx, params.Targets, params.ScopeAck)
+	if err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Refused scan of targets outside the scan scope")
+		return err
+	}
+	if ack != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Strs("approved_targets", ack.Targets).
+			Str("approved_by", ack.By).
+			Str("reason", ack.Reason).
+			Time("expires", ack.Expires).
+			Msg("Scanning targets outside the scan scope by signed acknowledgment")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/scope"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_RefusesTargetsOutOfScope(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	t.Setenv("VULNTOR_TEST_SCOPE_KEY", "s3cret")
+	policy, err := scope.New([]string{"10.0.0.0/8"}, nil, "env:VULNTOR_TEST_SCOPE_KEY")
+	require.NoError(t, err)
+
+	planned := false
+	svc := NewService().
+		WithScope(policy).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) {
+			planned = true
+			return &mockPlanner{def: &engine.DAGDefinition{}}, nil
+		})
+
+	// Group targets are checked too
+	group := &storage.TargetGroup{Name: "dmz", Targets: []string{"203.0.113.0/28"}}
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.0.5"}, TargetGroups: []*storage.TargetGroup{group}})
+	require.ErrorIs(t, err, scope.ErrOutOfScope)
+	require.ErrorContains(t, err, "203.0.113.0/28")
+	require.Equal(t, errorCodeTargetOutOfScope, ErrorCode(err))
+	require.False(t, planned, "refused before the scan is planned")
+
+	// A signed acknowledgment lets the run proceed
+	token, err := scope.Sign([]byte("s3cret"), scope.Acknowledgment{
+		Targets: []string{"203.0.113.0/24"},
+		Reason:  "CHG-42",
+		By:      "secops",
+		Expires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	_, _ = svc.Run(ctx, Params{Targets: []string{"10.0.0.5"}, TargetGroups: []*storage.TargetGroup{group}, ScopeAck: token})
+	require.True(t, planned)
+}
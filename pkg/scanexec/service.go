@@ -1,15 +1,38 @@
 package scanexec
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
+	"github.com/vulntor/vulntor/pkg/blackout"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/egress"
+	"github.com/vulntor/vulntor/pkg/engagement"
 	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
+	"github.com/vulntor/vulntor/pkg/honeypot"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+	"github.com/vulntor/vulntor/pkg/redact"
+	"github.com/vulntor/vulntor/pkg/scope"
+	"github.com/vulntor/vulntor/pkg/sla"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 type dagPlanner interface {
@@ -39,6 +62,22 @@ type Service struct {
 	orchestratorFactory func(*engine.DAGDefinition) (orchestrator, error)
 	progressSink        ProgressSink
 	storage             storage.Backend
+	notifier            *notify.Dispatcher
+	tickets             *ticketing.Syncer
+	proxy               *netproxy.Proxy
+	credentials         *credentials.Store
+	policy              *policy.Policy
+	redactor            *redact.Redactor
+	sla                 *sla.SLA
+	honeypot            *honeypot.Detector
+	cdnPolicy           cdn.Policy
+	scope               *scope.Policy
+	engagement          engagement.Policy
+	telemetry           *telemetry.Reporter
+	installed           *plugin.InstalledSet
+	tenantInstalled     func(ctx context.Context, orgID string) *plugin.InstalledSet
+	fdBudget            *fdbudget.Budget
+	shutdown            *Shutdown
 }
 
 // NewService builds a Service with default dependencies.
@@ -55,9 +94,142 @@ func NewService() *Service {
 		orchestratorFactory: func(def *engine.DAGDefinition) (orchestrator, error) {
 			return engine.NewOrchestrator(def)
 		},
+		fdBudget: fdbudget.Default(),
 	}
 }
 
+// WithNotifier attaches a dispatcher notified when a run finishes.
+func (s *Service) WithNotifier(n *notify.Dispatcher) *Service {
+	s.notifier = n
+	return s
+}
+
+// WithTicketing attaches a syncer that files tickets for findings of
+// successful runs.
+func (s *Service) WithTicketing(t *ticketing.Syncer) *Service {
+	s.tickets = t
+	return s
+}
+
+// WithProxy routes the probes of a run through p. Modules read it from the
+// context; nil probes directly.
+func (s *Service) WithProxy(p *netproxy.Proxy) *Service {
+	s.proxy = p
+	return s
+}
+
+// WithFDBudget bounds the file descriptors the connections of runs hold
+// by b instead of the process-wide fdbudget.Default. Modules read it from
+// the context; nil leaves connections unbounded.
+func (s *Service) WithFDBudget(b *fdbudget.Budget) *Service {
+	s.fdBudget = b
+	return s
+}
+
+// WithCredentials makes the logins in c available to authenticated checks.
+// Modules read them from the context.
+func (s *Service) WithCredentials(c *credentials.Store) *Service {
+	s.credentials = c
+	return s
+}
+
+// WithPolicy remaps the severity of findings by the overrides of p.
+// Modules read it from the context; Params.Environment selects the
+// environment it applies in for a run.
+func (s *Service) WithPolicy(p *policy.Policy) *Service {
+	s.policy = p
+	return s
+}
+
+// WithRedactor masks secrets in the evidence of runs by r before it is
+// stored, exported or sent, and in recorded traffic. nil keeps evidence
+// as captured.
+func (s *Service) WithRedactor(r *redact.Redactor) *Service {
+	s.redactor = r
+	return s
+}
+
+// WithSLA tracks the findings of runs, due by the deadline of their
+// severity in deadlines, and reminds the notifier of overdue ones. nil
+// tracks nothing.
+func (s *Service) WithSLA(deadlines *sla.SLA) *Service {
+	s.sla = deadlines
+	return s
+}
+
+// WithHoneypot reports the findings of runs on hosts d flags as honeypots
+// or tarpits as low confidence. nil keeps every finding as reported.
+func (s *Service) WithHoneypot(d *honeypot.Detector) *Service {
+	s.honeypot = d
+	return s
+}
+
+// WithCDNPolicy detects the CDNs and WAFs in front of the endpoints of runs
+// and scans those endpoints by p. Empty detects nothing.
+func (s *Service) WithCDNPolicy(p cdn.Policy) *Service {
+	s.cdnPolicy = p
+	return s
+}
+
+// WithScope refuses runs with targets outside p, unless their
+// Params.ScopeAck approves them. nil permits every target.
+func (s *Service) WithScope(p *scope.Policy) *Service {
+	s.scope = p
+	return s
+}
+
+// WithEngagement records the engagement of runs by p: their
+// Params.Engagement is completed from its defaults, and runs p refuses
+// fail before sending any packet.
+func (s *Service) WithEngagement(p engagement.Policy) *Service {
+	s.engagement = p
+	return s
+}
+
+// WithTelemetry reports the anonymous aggregates of recent runs with r
+// after a run finishes, when a report is due. A nil r reports nothing.
+func (s *Service) WithTelemetry(r *telemetry.Reporter) *Service {
+	s.telemetry = r
+	return s
+}
+
+// WithInstalledPlugins makes the plugins of set evaluated besides the
+// embedded ones by runs of the default tenant. Each run takes a snapshot
+// when it starts, so reloading the set does not change the plugins of runs
+// in flight.
+func (s *Service) WithInstalledPlugins(set *plugin.InstalledSet) *Service {
+	s.installed = set
+	return s
+}
+
+// WithTenantInstalledPlugins makes runs of other tenants evaluate the set
+// lookup returns for their tenant (storage.OrgIDFromContext). Without it,
+// or when lookup returns nil, they evaluate the embedded plugins only.
+func (s *Service) WithTenantInstalledPlugins(lookup func(ctx context.Context, orgID string) *plugin.InstalledSet) *Service {
+	s.tenantInstalled = lookup
+	return s
+}
+
+// installedFor returns the installed plugins of the tenant in ctx. A
+// tenant never evaluates the plugins another tenant installed.
+func (s *Service) installedFor(ctx context.Context) *plugin.InstalledSet {
+	orgID := storage.OrgIDFromContext(ctx)
+	if orgID == storage.DefaultOrgID {
+		return s.installed
+	}
+	if s.tenantInstalled == nil {
+		return nil
+	}
+	return s.tenantInstalled(ctx, orgID)
+}
+
+// WithShutdown lets sh interrupt runs gracefully, keeping their partial
+// results; see Shutdown.
+func (s *Service) WithShutdown(sh *Shutdown) *Service {
+	s.shutdown = sh
+	return s
+}
+
 // WithProgressSink attaches a sink to receive progress notifications.
 func (s *Service) WithProgressSink(sink ProgressSink) *Service {
 	s.progressSink = sink
@@ -83,7 +255,7 @@ func (s *Service) WithOrchestratorFactory(factory func(*engine.DAGDefinition) (o
 }
 
 // Run executes the scan pipeline using provided parameters and context carrying AppManager.
-func (s *Service) Run(ctx context.Context, params Params) (*Result, error) {
+func (s *Service) Run(ctx context.Context, params Params) (_ *Result, err error) {
 	// Validate that context contains AppManager (required for engine operation)
 	switch ctx.Value(engine.AppManagerKey).(type) {
 	case *engine.AppManager, engine.Manager:
@@ -92,33 +264,106 @@ func (s *Service) Run(ctx context.Context, params Params) (*Result, error) {
 		return nil, fmt.Errorf("app manager missing from context")
 	}
 
-	// Generate scan ID and start time
-	scanID := uuid.New().String()
+	// Scan the targets of the referenced groups too
+	params.Targets = GroupTargets(params.Targets, params.TargetGroups)
+
+	// Generate scan ID (unless pre-assigned by caller) and start time
+	scanID := params.ScanID
+	if scanID == "" {
+		scanID = uuid.New().String()
+	}
 	startTime := time.Now()
 
-	// Create initial scan metadata if storage is available
-	if s.storage != nil {
-		targetStr := ""
-		if len(params.Targets) > 0 {
-			targetStr = params.Targets[0]
-			if len(params.Targets) > 1 {
-				targetStr = fmt.Sprintf("%s (and %d more)", params.Targets[0], len(params.Targets)-1)
-			}
+	// Pin the owning tenant on the context for all storage writes below
+	orgID := params.OrgID
+	if orgID == "" {
+		orgID = storage.OrgIDFromContext(ctx)
+	}
+	ctx = storage.WithOrgID(ctx, orgID)
+
+	// Replays run over the evidence of the replayed scan
+	if params.ReplayOf != "" {
+		if err := s.prepareReplay(ctx, &params); err != nil {
+			return nil, err
+		}
+	}
+
+	// Targets outside the scan scope are refused before any packet is
+	// sent; replays send none
+	if params.ReplayOf == "" {
+		if err := s.checkScope(ctx, scanID, params); err != nil {
+			return nil, err
 		}
+	}
 
+	// Runs are refused without the engagement record assessment mode
+	// requires, or outside their engagement window; replays probe nothing,
+	// so their window has no say
+	params.Engagement = s.engagement.Resolve(params.Engagement)
+	if params.ReplayOf == "" {
+		if err := s.checkEngagement(scanID, params.Engagement, startTime); err != nil {
+			return nil, err
+		}
+	}
+
+	binding, err := egress.New(params.Interface, params.SourceIP, params.Decoys)
+	if err != nil {
+		return nil, err
+	}
+	gate, err := blackoutGate(params.TargetGroups)
+	if err != nil {
+		return nil, err
+	}
+	intermediaries := s.cdnGuard(ctx, params)
+
+	if tracing.SpanFromContext(ctx) == nil {
+		ctx = tracing.ContextWithSpanContext(ctx, params.Trace)
+	}
+	ctx, span := tracing.Start(ctx, "scan.run",
+		tracing.String("scan.id", scanID),
+		tracing.String("org.id", orgID),
+		tracing.String("scan.profile", params.Profile),
+		tracing.Int("scan.targets", len(params.Targets)))
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
+	// Notify webhooks however the run ends
+	var (
+		dataCtx map[string]interface{}
+		changes []notify.Change
+		overdue []notify.Finding
+	)
+	defer func() {
+		s.notifyFinished(ctx, scanID, orgID, params.Targets, startTime, dataCtx, changes, overdue, err)
+	}()
+
+	// Create initial scan metadata if storage is available
+	if s.storage != nil {
 		metadata := &storage.ScanMetadata{
 			ID:              scanID,
-			OrgID:           "default",
+			OrgID:           orgID,
 			UserID:          "local",
-			Target:          targetStr,
+			Target:          TargetSummary(params.Targets),
+			Groups:          groupNames(params.TargetGroups),
+			ReplayOf:        params.ReplayOf,
+			RecheckOf:       params.RecheckOf,
+			Engagement:      params.Engagement,
 			Status:          "running",
 			StartedAt:       startTime,
 			HostCount:       0,
 			VulnCount:       storage.VulnCounts{},
-			StorageLocation: fmt.Sprintf("scans/default/%s", scanID),
+			StorageLocation: fmt.Sprintf("scans/%s/%s", orgID, scanID),
 		}
 
-		if err := s.storage.Scans().Create(ctx, "default", metadata); err != nil {
+		err := s.storage.Scans().Create(ctx, orgID, metadata)
+		if storage.IsAlreadyExists(err) && params.ScanID != "" {
+			// Metadata was registered up-front (e.g., pending API job); mark it running
+			running := "running"
+			err = s.storage.Scans().Update(ctx, orgID, scanID, storage.ScanUpdates{Status: &running})
+		}
+		if err != nil {
 			log.Warn().
 				Str("component", "scanexec").
 				Str("scan_id", scanID).
@@ -155,12 +400,32 @@ func (s *Service) Run(ctx context.Context, params Params) (*Result, error) {
 		Concurrency:      params.Concurrency,
 		DiscoveryOnly:    params.OnlyDiscover,
 		SkipDiscovery:    params.SkipDiscover,
+		NucleiTemplates:  params.NucleiTemplates,
+
+		DefaultCredentials: params.DefaultCredentials,
+		ContentDiscovery:   params.ContentDiscovery,
+		AutoTune:           params.AutoTune,
+		VerifyTimeouts:     params.VerifyTimeouts,
+		Offline:            params.ReplayOf != "",
+		Plugins:            params.Plugins,
 	}
+	for key := range params.Seed {
+		intent.SeededDataKeys = append(intent.SeededDataKeys, key)
+	}
+	sort.Strings(intent.SeededDataKeys)
 	if intent.DiscoveryOnly {
 		intent.EnableVulnChecks = false
+		intent.DefaultCredentials = false
+		intent.ContentDiscovery = false
 	}
 
+	_, planSpan := tracing.Start(ctx, "scan.plan")
 	dagDefinition, err := planner.PlanDAG(intent)
+	planSpan.RecordError(err)
+	if dagDefinition != nil {
+		planSpan.SetAttributes(tracing.Int("dag.nodes", len(dagDefinition.Nodes)))
+	}
+	planSpan.End()
 	if err != nil {
 		s.updateScanStatus(ctx, scanID, "failed", err.Error(), startTime)
 		return nil, fmt.Errorf("plan dag: %w", err)
@@ -185,37 +450,226 @@ func (s *Service) Run(ctx context.Context, params Params) (*Result, error) {
 	for k, v := range params.RawInputs {
 		inputs[k] = v
 	}
+	for k, v := range params.Seed {
+		inputs[k] = v
+	}
 
 	s.emit("run", "", dagDefinition.Name, "start", "")
 	// Use ctx (not appMgr.Context()) to preserve context values like output.OutputKey
 	// This enables real-time progress reporting from modules
-	dataCtx, runErr := orchestrator.Run(ctx, inputs)
+	var runErr error
+	runCtx := credentials.WithContext(netproxy.WithContext(ctx, s.proxy), s.credentials)
+	runCtx = fdbudget.WithContext(runCtx, s.fdBudget)
+	runCtx = egress.WithContext(runCtx, binding)
+	runCtx = blackout.WithContext(runCtx, gate)
+	runCtx = cdn.WithContext(runCtx, intermediaries)
+	runCtx = policy.WithContext(runCtx, s.policy.WithEnvironment(params.Environment))
+	runCtx = plugin.WithInstalledPlugins(runCtx, s.installedFor(ctx).Snapshot())
+	execStats := plugin.NewExecRecorder()
+	runCtx = plugin.WithExecRecorder(runCtx, execStats)
+	recorder, removeCapture := s.startCapture(scanID, params.Capture)
+	defer removeCapture()
+	runCtx = capture.WithContext(runCtx, recorder)
+	runCtx, stopRun := s.shutdown.runContext(runCtx)
+	defer stopRun()
+	budgetBefore := s.fdBudget.Stats()
+	dataCtx, runErr = orchestrator.Run(runCtx, inputs)
+	stopRun()
+	if runErr != nil && s.shutdown.Stopped() {
+		runErr = fmt.Errorf("%w: %w", ErrInterrupted, runErr)
+	}
+	s.recordFDBudget(span, scanID, s.fdBudget.Stats().Sub(budgetBefore))
 	status := statusFromError(runErr)
+	span.SetAttributes(tracing.String("scan.status", status))
 	s.emit("run", "", dagDefinition.Name, status, "")
 
+	// Mask secrets in the evidence before anything below stores, exports
+	// or sends it
+	dataCtx = s.redactor.Data(dataCtx)
+
+	// Mark findings on hosts that look like honeypots or tarpits before
+	// anything below stores, exports or sends them
+	s.flagHoneypots(scanID, dataCtx)
+
+	// Likewise record the CDN or WAF in front of the host of findings, or
+	// drop them if the policy skips such hosts
+	s.annotateIntermediaries(scanID, dataCtx, intermediaries)
+
+	// Report hosts and services that changed since they were last scanned,
+	// before this scan is stored as completed. Partial results of failed
+	// runs would miss services rather than show changes, replays observe
+	// nothing new and rechecks only a single port
+	if runErr == nil && params.ReplayOf == "" && params.RecheckOf == "" {
+		changes = s.detectChanges(ctx, scanID, dataCtx, params.Environment)
+	}
+
+	// Track when findings were first seen and when they are due. Replays
+	// and rechecks report findings already seen by the scans they repeat
+	if params.ReplayOf == "" && params.RecheckOf == "" {
+		overdue = s.trackFindings(ctx, scanID, dataCtx)
+	}
+
+	// Score the attack surface of the hosts and groups of the run, for the
+	// same reasons as changes
+	if runErr == nil && params.ReplayOf == "" && params.RecheckOf == "" {
+		s.scoreSurface(ctx, scanID, dataCtx, params.TargetGroups)
+	}
+
 	// Update scan status in storage
 	errorMsg := ""
 	if runErr != nil {
 		errorMsg = runErr.Error()
 	}
 	s.updateScanStatus(ctx, scanID, status, errorMsg, startTime)
+	if status == "interrupted" {
+		s.saveResumeState(ctx, scanID, params.Targets, runErr)
+	}
 
 	// Extract and update scan statistics from dataCtx if available
 	s.updateScanStatistics(ctx, scanID, dataCtx)
 
+	// Persist findings and assets so they can be served after the run (e.g., by the API)
+	s.persistFindings(ctx, scanID, dataCtx, params.TargetGroups, params.Cloud)
+	s.persistHosts(ctx, scanID, dataCtx, params.Cloud, intermediaries)
+	s.persistEvidence(ctx, scanID, dataCtx)
+	s.saveCapture(ctx, scanID, orgID, recorder, params.Capture, dataCtx)
+	pluginStats := execStats.Stats()
+	s.recordPluginStats(ctx, span, scanID, pluginStats)
+
+	// Partial results of failed runs would wrongly resolve tickets, and so
+	// would replays evaluating a selection of plugins over old evidence and
+	// rechecks of a single finding
+	if runErr == nil && params.ReplayOf == "" && params.RecheckOf == "" {
+		s.syncTickets(ctx, scanID, dataCtx)
+	}
+
+	// The run is stored: report the aggregates of recent runs when due
+	s.reportTelemetry(ctx)
+
 	result := &Result{
-		RunID:      scanID,
-		StartTime:  startTime.Format(time.RFC3339),
-		EndTime:    time.Now().Format(time.RFC3339),
-		Status:     status,
-		Findings:   dataCtx,
-		RawContext: dataCtx,
+		RunID:       scanID,
+		StartTime:   startTime.Format(time.RFC3339),
+		EndTime:     time.Now().Format(time.RFC3339),
+		Status:      status,
+		Findings:    dataCtx,
+		RawContext:  dataCtx,
+		PluginStats: pluginStats,
+		Engagement:  params.Engagement,
 	}
 
 	return result, runErr
 }
 
+// notifyFinished sends the run's outcome, findings, changes and overdue
+// findings to the notifier.
+func (s *Service) notifyFinished(ctx context.Context, scanID, orgID string, targets []string, startTime time.Time, dataCtx map[string]interface{}, changes []notify.Change, overdue []notify.Finding, runErr error) {
+	if s.notifier == nil {
+		return
+	}
+
+	scan := notify.Scan{
+		ID:         scanID,
+		OrgID:      orgID,
+		Targets:    targets,
+		Status:     statusFromError(runErr),
+		StartedAt:  startTime.UTC(),
+		FinishedAt: time.Now().UTC(),
+	}
+	if runErr != nil {
+		scan.Error = runErr.Error()
+	}
+	s.notifier.ScanFinished(ctx, scan, decodeFindings[notify.Finding](dataCtx))
+	s.notifier.ChangesDetected(ctx, scan, changes)
+	s.notifier.FindingsOverdue(ctx, scan, overdue)
+}
+
+// syncTickets opens and resolves tracker tickets for the run's findings.
+// The hosts covered by the run are its live hosts and finding targets.
+func (s *Service) syncTickets(ctx context.Context, scanID string, dataCtx map[string]interface{}) {
+	if s.tickets == nil {
+		return
+	}
+
+	findings := decodeFindings[ticketing.Finding](dataCtx)
+	seen := make(map[string]bool)
+	var hosts []string
+	addHost := func(h string) {
+		if h != "" && !seen[h] {
+			seen[h] = true
+			hosts = append(hosts, h)
+		}
+	}
+	for _, rec := range hostRecords(assetProfiles(dataCtx)) {
+		addHost(rec.IP)
+	}
+	for _, f := range findings {
+		addHost(f.Target)
+	}
+
+	s.tickets.Sync(ctx, ticketing.Scan{ID: scanID, Hosts: hosts}, findings)
+}
+
+// decodeFindings converts evaluation.vulnerabilities entries (structs or
+// maps) into T via their JSON form.
+func decodeFindings[T any](dataCtx map[string]interface{}) []T {
+	vulns, ok := dataCtx["evaluation.vulnerabilities"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	findings := make([]T, 0, len(vulns))
+	for _, v := range vulns {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		var f T
+		if err := json.Unmarshal(raw, &f); err != nil {
+			continue
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// recordFDBudget reports the file descriptor backpressure of a run: how
+// often and how long its connections waited for the budget shared by all
+// scans. Limit and peak are those of the budget.
+func (s *Service) recordFDBudget(span *tracing.Span, scanID string, stats fdbudget.Stats) {
+	span.SetAttributes(
+		tracing.Int("fd.limit", stats.Limit),
+		tracing.Int("fd.peak", stats.Peak),
+		tracing.Int("fd.waits", int(stats.Waits)),
+		tracing.Int("fd.wait_ms", int(stats.WaitTime.Milliseconds())))
+	if stats.Waits == 0 {
+		return
+	}
+	log.Info().
+		Str("component", "scanexec").
+		Str("scan_id", scanID).
+		Int("fd_limit", stats.Limit).
+		Int64("fd_waits", stats.Waits).
+		Dur("fd_wait_time", stats.WaitTime).
+		Msg("Connections waited for file descriptors; raise the open file limit (ulimit -n) for faster scans")
+}
+
+// TargetSummary renders a target list for scan metadata,
+// e.g. "10.0.0.1 (and 3 more)".
+func TargetSummary(targets []string) string {
+	switch len(targets) {
+	case 0:
+		return ""
+	case 1:
+		return targets[0]
+	default:
+		return fmt.Sprintf("%s (and %d more)", targets[0], len(targets)-1)
+	}
+}
+
 func statusFromError(err error) string {
+	if errors.Is(err, ErrInterrupted) {
+		return "interrupted"
+	}
 	if err != nil {
 		return "failed"
 	}
@@ -251,14 +705,14 @@ func (s *Service) updateScanStatus(ctx context.Context, scanID, status, errorMsg
 	}
 
 	// Set completion time and duration if scan finished
-	if status == "completed" || status == "failed" {
+	if status == "completed" || status == "failed" || status == "interrupted" {
 		completedAt := time.Now()
 		duration := int(completedAt.Sub(startTime).Seconds())
 		updates.CompletedAt = &completedAt
 		updates.Duration = &duration
 	}
 
-	if err := s.storage.Scans().Update(ctx, "default", scanID, updates); err != nil {
+	if err := s.storage.Scans().Update(ctx, storage.OrgIDFromContext(ctx), scanID, updates); err != nil {
 		log.Warn().
 			Str("component", "scanexec").
 			Str("scan_id", scanID).
@@ -325,7 +779,7 @@ func (s *Service) updateScanStatistics(ctx context.Context, scanID string, dataC
 
 	// Only update if we have statistics to update
 	if updates.HostCount != nil || updates.ServiceCount != nil || updates.VulnCount != nil {
-		if err := s.storage.Scans().Update(ctx, "default", scanID, updates); err != nil {
+		if err := s.storage.Scans().Update(ctx, storage.OrgIDFromContext(ctx), scanID, updates); err != nil {
 			log.Warn().
 				Str("component", "scanexec").
 				Str("scan_id", scanID).
@@ -339,3 +793,187 @@ func (s *Service) updateScanStatistics(ctx context.Context, scanID string, dataC
 		}
 	}
 }
+
+// persistFindings writes evaluation.vulnerabilities entries to storage as
+// JSONL. Findings on the targets of groups are tagged with them, and
+// findings on cloud instances with the instance.
+func (s *Service) persistFindings(ctx context.Context, scanID string, dataCtx map[string]interface{}, groups []*storage.TargetGroup, cloud map[string]*storage.CloudRecord) {
+	if s.storage == nil || dataCtx == nil {
+		return
+	}
+
+	vulns, ok := dataCtx["evaluation.vulnerabilities"].([]interface{})
+	if !ok || len(vulns) == 0 {
+		return
+	}
+
+	// Scan targets by IP, for findings on hosts a group's (or a cloud
+	// instance's) hostname or range resolved to
+	var hostTargets map[string]string
+	if len(groups) > 0 || len(cloud) > 0 {
+		hostTargets = make(map[string]string)
+		for _, rec := range hostRecords(assetProfiles(dataCtx)) {
+			hostTargets[rec.IP] = rec.Target
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, v := range vulns {
+		if err := enc.Encode(tagFinding(v, groups, cloud, hostTargets)); err != nil {
+			log.Warn().
+				Str("component", "scanexec").
+				Str("scan_id", scanID).
+				Err(err).
+				Msg("Failed to encode finding, skipping")
+		}
+	}
+
+	if err := s.storage.Scans().WriteData(ctx, storage.OrgIDFromContext(ctx), scanID, storage.DataTypeVulnerabilities, &buf); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to persist findings in storage")
+	}
+}
+
+// persistHosts writes the live hosts of asset.profiles to storage as JSONL
+// (one storage.HostRecord per IP), backing the API's asset inventory. Hosts
+// of cloud instances carry the instance, hosts behind a CDN or WAF the
+// intermediary, TLS services their fingerprints and HTTP services what they
+// publish at their well-known endpoints.
+func (s *Service) persistHosts(ctx context.Context, scanID string, dataCtx map[string]interface{}, cloud map[string]*storage.CloudRecord, intermediaries *cdn.Guard) {
+	if s.storage == nil || dataCtx == nil {
+		return
+	}
+
+	records := hostRecords(assetProfiles(dataCtx))
+	if len(records) == 0 {
+		return
+	}
+
+	fingerprints := tlsFingerprints(dataCtx)
+	wellKnown := wellKnownRecords(dataCtx)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, rec := range records {
+		rec.Cloud = cloudRecord(cloud, rec.IP, rec.Target)
+		rec.Intermediary = intermediaryOf(intermediaries, rec.IP, rec.Target)
+		for i := range rec.Ports {
+			fp := fingerprints[rec.IP][rec.Ports[i].Port]
+			rec.Ports[i].JARM, rec.Ports[i].JA3S = fp.JARM, fp.JA3S
+			rec.Ports[i].WellKnown = wellKnown[rec.IP][rec.Ports[i].Port]
+		}
+		if err := enc.Encode(rec); err != nil {
+			log.Warn().
+				Str("component", "scanexec").
+				Str("scan_id", scanID).
+				Err(err).
+				Msg("Failed to encode host, skipping")
+		}
+	}
+
+	if err := s.storage.Scans().WriteData(ctx, storage.OrgIDFromContext(ctx), scanID, storage.DataTypeHosts, &buf); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to persist hosts in storage")
+	}
+}
+
+// assetProfiles extracts asset.profiles from the data context. The reporting
+// module publishes a single []engine.AssetProfile wrapped in a list.
+func assetProfiles(dataCtx map[string]interface{}) []engine.AssetProfile {
+	switch v := dataCtx["asset.profiles"].(type) {
+	case []engine.AssetProfile:
+		return v
+	case []interface{}:
+		if len(v) > 0 {
+			if profiles, ok := v[0].([]engine.AssetProfile); ok {
+				return profiles
+			}
+		}
+	}
+	return nil
+}
+
+// hostRecords flattens asset profiles into one record per live IP, ordered by IP.
+func hostRecords(profiles []engine.AssetProfile) []storage.HostRecord {
+	var records []storage.HostRecord
+	for _, p := range profiles {
+		ips := make(map[string]struct{}, len(p.ResolvedIPs)+len(p.OpenPorts))
+		for ip := range p.ResolvedIPs {
+			ips[ip] = struct{}{}
+		}
+		for ip := range p.OpenPorts {
+			ips[ip] = struct{}{}
+		}
+
+		for ip := range ips {
+			rec := storage.HostRecord{IP: ip, Target: p.Target, Hostnames: p.Hostnames}
+			for _, port := range p.OpenPorts[ip] {
+				rec.Ports = append(rec.Ports, storage.ServiceRecord{
+					Port:     port.PortNumber,
+					Protocol: port.Protocol,
+					Service:  port.Service.Name,
+					Product:  port.Service.Product,
+					Version:  port.Service.Version,
+
+					Certificate: certificateRecord(port.Service.Evidence),
+					Redirect:    httpRedirect(port.Service),
+				})
+				rec.Vulnerabilities += len(port.Vulnerabilities)
+			}
+			sort.Slice(rec.Ports, func(i, j int) bool { return rec.Ports[i].Port < rec.Ports[j].Port })
+			records = append(records, rec)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].IP < records[j].IP })
+	return records
+}
+
+// certificateRecord returns the certificate of the first TLS probe in
+// evidence, or nil when no probe completed a TLS handshake.
+func certificateRecord(evidence []engine.ProbeObservation) *storage.CertificateRecord {
+	for _, obs := range evidence {
+		if obs.TLS == nil || (obs.TLS.PeerCommonName == "" && len(obs.TLS.PeerDNSNames) == 0 && obs.TLS.Issuer == "" && obs.TLS.NotAfter.IsZero()) {
+			continue
+		}
+		return &storage.CertificateRecord{
+			Subject:  obs.TLS.PeerCommonName,
+			DNSNames: obs.TLS.PeerDNSNames,
+			Issuer:   obs.TLS.Issuer,
+			NotAfter: obs.TLS.NotAfter.UTC(),
+		}
+	}
+	return nil
+}
+
+// httpRedirect returns the Location of the first HTTP redirect in the
+// banner or probe responses of svc, or "" when the service answered none.
+func httpRedirect(svc engine.ServiceDetails) string {
+	responses := []string{svc.RawBanner}
+	for _, obs := range svc.Evidence {
+		responses = append(responses, obs.Response)
+	}
+	for _, resp := range responses {
+		status, headers, _ := strings.Cut(resp, "\n")
+		if _, code, ok := strings.Cut(strings.TrimSpace(status), " "); !ok || !strings.HasPrefix(status, "HTTP/") || !strings.HasPrefix(code, "3") {
+			continue
+		}
+		for _, line := range strings.Split(headers, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				break // End of the headers
+			}
+			if name, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(name, "Location") {
+				if value = strings.TrimSpace(value); value != "" {
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -2,18 +2,38 @@ package scanexec
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/credentials"
+	"github.com/vulntor/vulntor/pkg/egress"
 	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/fdbudget"
 	_ "github.com/vulntor/vulntor/pkg/modules/discovery"
-	_ "github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/policy"
+	"github.com/vulntor/vulntor/pkg/redact"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 // TestRun_HermeticLocal validates minimal execution path using an ephemeral
@@ -133,8 +153,10 @@ func (c *capturingSink) OnEvent(e ProgressEvent) { c.events = append(c.events, e
 
 // minimal in-memory scans store/backends for storage coverage
 type memScans struct {
-	created []*storage.ScanMetadata
-	updates []struct {
+	created   []*storage.ScanMetadata
+	createErr error
+	written   map[storage.DataType][]byte
+	updates   []struct {
 		org string
 		id  string
 		upd storage.ScanUpdates
@@ -142,6 +164,9 @@ type memScans struct {
 }
 
 func (m *memScans) Create(ctx context.Context, orgID string, meta *storage.ScanMetadata) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
 	m.created = append(m.created, meta)
 	return nil
 }
@@ -169,6 +194,14 @@ func (m *memScans) ReadData(ctx context.Context, orgID, scanID string, dataType
 }
 
 func (m *memScans) WriteData(ctx context.Context, orgID, scanID string, dataType storage.DataType, data io.Reader) error {
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if m.written == nil {
+		m.written = make(map[storage.DataType][]byte)
+	}
+	m.written[dataType] = b
 	return nil
 }
 
@@ -231,3 +264,561 @@ func Test_WithProgressSink_And_WithStorage(t *testing.T) {
 	require.Equal(t, 1, len(scans.created))
 	require.GreaterOrEqual(t, len(scans.updates), 1)
 }
+
+func TestRun_PreassignedScanID_PersistsFindings(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"plugin": "ssh-weak-cipher", "severity": "high"},
+			map[string]interface{}{"plugin": "http-server-header", "severity": "info"},
+		},
+		"asset.profiles": []interface{}{[]engine.AssetProfile{{
+			Target:      "127.0.0.1",
+			ResolvedIPs: map[string]time.Time{"127.0.0.1": time.Now()},
+			OpenPorts: map[string][]engine.PortProfile{"127.0.0.1": {
+				{PortNumber: 80, Protocol: "tcp", Service: engine.ServiceDetails{
+					Name: "http", Product: "nginx",
+					RawBanner: "HTTP/1.1 301 Moved Permanently\r\nServer: nginx\r\nlocation: https://www.example.com/\r\n\r\n<html>Location: /ignored</html>",
+				}},
+				{PortNumber: 22, Protocol: "tcp", Service: engine.ServiceDetails{Name: "ssh"}, Vulnerabilities: []engine.VulnerabilityFinding{{}}},
+				{PortNumber: 443, Protocol: "tcp", Service: engine.ServiceDetails{Name: "https", Evidence: []engine.ProbeObservation{
+					{ProbeID: "tls", TLS: &engine.TLSObservation{PeerCommonName: "www.example.com", PeerDNSNames: []string{"www.example.com", "example.com"}}},
+					{ProbeID: "http-get", Response: "HTTP/1.1 200 OK\r\nLocation: https://ignored.example.com/\r\n\r\n"},
+				}}},
+			}},
+		}}},
+	}
+
+	// Metadata already registered by the caller (e.g., pending API job)
+	scans := &memScans{createErr: storage.NewAlreadyExistsError("scan", "scan-123")}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	res, runErr := svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, ScanID: "scan-123"})
+	require.NoError(t, runErr)
+	require.Equal(t, "scan-123", res.RunID)
+
+	// First update flips the pre-registered scan to running
+	require.NotEmpty(t, scans.updates)
+	require.Equal(t, "scan-123", scans.updates[0].id)
+	require.Equal(t, "running", *scans.updates[0].upd.Status)
+
+	// Findings persisted as JSONL
+	data := string(scans.written[storage.DataTypeVulnerabilities])
+	require.Contains(t, data, `"plugin":"ssh-weak-cipher"`)
+	require.Contains(t, data, `"plugin":"http-server-header"`)
+	require.Equal(t, 2, strings.Count(data, "\n"))
+
+	// Hosts persisted for the asset inventory, ports ordered
+	var host storage.HostRecord
+	require.NoError(t, json.Unmarshal(scans.written[storage.DataTypeHosts], &host))
+	require.Equal(t, "127.0.0.1", host.IP)
+	require.Equal(t, 1, host.Vulnerabilities)
+	require.Equal(t, []storage.ServiceRecord{
+		{Port: 22, Protocol: "tcp", Service: "ssh"},
+		{Port: 80, Protocol: "tcp", Service: "http", Product: "nginx", Redirect: "https://www.example.com/"},
+		{Port: 443, Protocol: "tcp", Service: "https", Certificate: &storage.CertificateRecord{
+			Subject: "www.example.com", DNSNames: []string{"www.example.com", "example.com"},
+		}},
+	}, host.Ports)
+}
+
+// spanOrch records the span active when the DAG runs.
+type spanOrch struct{ span tracing.SpanContext }
+
+func (m *spanOrch) Run(ctx context.Context, inputs map[string]interface{}) (map[string]interface{}, error) {
+	m.span = tracing.SpanFromContext(ctx).SpanContext()
+	return map[string]interface{}{}, nil
+}
+
+func TestRun_ContinuesSubmitterTrace(t *testing.T) {
+	provider := tracing.NewProvider(config.TracingConfig{Endpoint: "http://127.0.0.1:1", SampleRatio: 1})
+	tracing.SetProvider(provider)
+	t.Cleanup(func() {
+		tracing.SetProvider(nil)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+
+	// Span of the API request that submitted the job, already finished
+	_, submit := tracing.Start(context.Background(), "POST /api/v1/scans")
+	submit.End()
+
+	orch := &spanOrch{}
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	svc := NewService().
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, Trace: submit.SpanContext()})
+	require.NoError(t, err)
+
+	// The DAG runs inside the scan span, on the submitter's trace
+	require.True(t, orch.span.IsValid())
+	require.Equal(t, submit.SpanContext().TraceID, orch.span.TraceID)
+	require.NotEqual(t, submit.SpanContext().SpanID, orch.span.SpanID)
+}
+
+// recordingPlanner records the intent it was asked to plan.
+type recordingPlanner struct {
+	def    *engine.DAGDefinition
+	intent engine.ScanIntent
+}
+
+func (m *recordingPlanner) PlanDAG(intent engine.ScanIntent) (*engine.DAGDefinition, error) {
+	m.intent = intent
+	return m.def, nil
+}
+
+// inputsOrch records the initial inputs of the run.
+type inputsOrch struct{ inputs map[string]interface{} }
+
+func (m *inputsOrch) Run(ctx context.Context, inputs map[string]interface{}) (map[string]interface{}, error) {
+	m.inputs = inputs
+	return map[string]interface{}{}, nil
+}
+
+func TestRun_SeedsImportedData(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	planner := &recordingPlanner{def: def}
+	orch := &inputsOrch{}
+	svc := NewService().
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return planner, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	ports := []interface{}{map[string]interface{}{"target": "10.0.0.1", "open_ports": []int{22}}}
+	_, err = svc.Run(ctx, Params{
+		Targets: []string{"10.0.0.1"},
+		Seed: map[string]interface{}{
+			"service.banner.tcp":       []interface{}{},
+			"discovery.open_tcp_ports": ports,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"discovery.open_tcp_ports", "service.banner.tcp"}, planner.intent.SeededDataKeys)
+	require.Equal(t, ports, orch.inputs["discovery.open_tcp_ports"])
+	require.Contains(t, orch.inputs, "service.banner.tcp")
+	require.Equal(t, []string{"10.0.0.1"}, orch.inputs["config.targets"])
+}
+
+func TestRun_NotifiesWebhooks(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	var mu sync.Mutex
+	var payloads []notify.Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p notify.Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		mu.Lock()
+		payloads = append(payloads, p)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	dispatcher, err := notify.New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL}}})
+	require.NoError(t, err)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "127.0.0.1", "port": 22, "plugin": "ssh-weak-cipher", "severity": "high"},
+			map[string]interface{}{"target": "127.0.0.1", "plugin": "http-server-header", "severity": "info"},
+		},
+	}
+	svc := NewService().
+		WithNotifier(dispatcher).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, ScanID: "scan-1"})
+	require.NoError(t, err)
+
+	require.Len(t, payloads, 2)
+	events := []string{payloads[0].Event, payloads[1].Event}
+	require.ElementsMatch(t, []string{notify.EventScanCompleted, notify.EventFindingsNew}, events)
+	for _, p := range payloads {
+		require.Equal(t, "scan-1", p.Scan.ID)
+		require.Equal(t, map[string]int{"high": 1, "info": 1}, p.Scan.Findings)
+		if p.Event == notify.EventFindingsNew {
+			require.Equal(t, []notify.Finding{{Target: "127.0.0.1", Port: 22, Plugin: "ssh-weak-cipher", Severity: "high"}}, p.Findings)
+		}
+	}
+
+	// Runs that fail before execution still notify
+	payloads = nil
+	svc = svc.WithPlannerFactory(func(context.Context) (dagPlanner, error) { return nil, errors.New("planner init fail") })
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.Error(t, err)
+	require.Len(t, payloads, 1)
+	require.Equal(t, notify.EventScanFailed, payloads[0].Event)
+	require.Contains(t, payloads[0].Scan.Error, "planner init fail")
+}
+
+func TestRun_SyncsTickets(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	var titles []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/security/issues", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	mux.HandleFunc("POST /repos/acme/security/issues", func(w http.ResponseWriter, r *http.Request) {
+		var issue map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&issue))
+		titles = append(titles, issue["title"].(string))
+		_, _ = w.Write([]byte(`{"number": 1}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tickets, err := ticketing.New(config.TicketingConfig{Trackers: []config.TrackerConfig{
+		{Type: "github", URL: srv.URL, Repository: "acme/security", Token: "t"},
+	}})
+	require.NoError(t, err)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "127.0.0.1", "port": 22, "plugin": "ssh-weak-cipher", "severity": "high"},
+			map[string]interface{}{"target": "127.0.0.1", "plugin": "http-server-header", "severity": "info"},
+		},
+	}
+	svc := NewService().
+		WithTicketing(tickets).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"[HIGH] ssh-weak-cipher on 127.0.0.1:22"}, titles)
+
+	// Failed runs leave tickets alone
+	titles = nil
+	svc = svc.WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) {
+		return &mockOrch{out: orchOut, err: errors.New("module crashed")}, nil
+	})
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.Error(t, err)
+	require.Empty(t, titles)
+}
+
+// ctxOrch records the context modules run with.
+type ctxOrch struct {
+	ctx context.Context
+}
+
+func (m *ctxOrch) Run(ctx context.Context, inputs map[string]interface{}) (map[string]interface{}, error) {
+	m.ctx = ctx
+	return map[string]interface{}{}, nil
+}
+
+func TestRun_PassesProxyToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	proxy, err := netproxy.New("socks5://proxy.corp:1080", nil)
+	require.NoError(t, err)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithProxy(proxy).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Same(t, proxy, netproxy.FromContext(orch.ctx))
+}
+
+func TestRun_PassesFDBudgetToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	// Runs share the process-wide budget by default
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Same(t, fdbudget.Default(), fdbudget.FromContext(orch.ctx))
+
+	budget := fdbudget.New(16)
+	_, err = svc.WithFDBudget(budget).Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Same(t, budget, fdbudget.FromContext(orch.ctx))
+}
+
+// dialOrch connects to addrs through the proxy of the context, as probing
+// modules do, and reports a finding on the first one.
+type dialOrch struct {
+	addrs []string
+}
+
+func (m *dialOrch) Run(ctx context.Context, _ map[string]interface{}) (map[string]interface{}, error) {
+	for _, addr := range m.addrs {
+		conn, err := netproxy.FromContext(ctx).DialContext(ctx, nil, "tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = conn.Write([]byte("probe"))
+		_ = conn.Close()
+	}
+	host, portStr, _ := net.SplitHostPort(m.addrs[0])
+	port, _ := strconv.Atoi(portStr)
+	return map[string]interface{}{
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": host, "port": port, "plugin": "test", "severity": "high"},
+		},
+	}, nil
+}
+
+func TestRun_SavesPacketCapture(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	var addrs []string
+	for i := 0; i < 2; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = ln.Close() })
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				_, _ = io.Copy(io.Discard, conn)
+				_ = conn.Close()
+			}
+		}()
+		addrs = append(addrs, ln.Addr().String())
+	}
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &dialOrch{addrs: addrs}, nil })
+
+	sizes := make(map[capture.Mode]int64)
+	for _, mode := range capture.Modes {
+		res, err := svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, Capture: mode})
+		require.NoError(t, err)
+		artifacts, err := backend.Artifacts().List(ctx, storage.DefaultOrgID, res.RunID)
+		require.NoError(t, err)
+		require.Len(t, artifacts, 1)
+		require.Equal(t, "127.0.0.1.pcap", artifacts[0].Name)
+		sizes[mode] = artifacts[0].Size
+	}
+	// Only the connection of the finding is kept
+	require.Less(t, sizes[capture.ModeFindings], sizes[capture.ModeAll])
+
+	// Without a mode, nothing is recorded
+	res, err := svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	artifacts, err := backend.Artifacts().List(ctx, storage.DefaultOrgID, res.RunID)
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+}
+
+func TestRun_PassesCredentialsToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	creds := credentials.NewStore(credentials.Logins{SSH: []credentials.SSH{{Name: "ops", Username: "audit", Password: "secret"}}})
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithCredentials(creds).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Same(t, creds, credentials.FromContext(orch.ctx))
+}
+
+func TestRun_PassesPolicyToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	p := policy.New("it", []policy.Override{{Tags: []string{"telnet"}, Environments: []string{"ot"}, Severity: "medium"}})
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithPolicy(p).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Same(t, p, policy.FromContext(orch.ctx))
+
+	// The run's environment replaces the policy's
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, Environment: "ot"})
+	require.NoError(t, err)
+	require.Equal(t, "ot", policy.FromContext(orch.ctx).Environment())
+	_, ok := policy.FromContext(orch.ctx).Override(policy.Finding{Tags: []string{"telnet"}})
+	require.True(t, ok)
+}
+
+func TestRun_BindsEgress(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, SourceIP: "127.0.0.1", Decoys: []string{"192.0.2.10"}})
+	require.NoError(t, err)
+	binding := egress.FromContext(orch.ctx)
+	require.Equal(t, "127.0.0.1", binding.Source(netip.MustParseAddr("10.0.0.5")).String())
+	require.Len(t, binding.Decoys(), 1)
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Nil(t, egress.FromContext(orch.ctx))
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}, SourceIP: "192.0.2.1"})
+	require.ErrorContains(t, err, "source IP 192.0.2.1 is not assigned to this host")
+}
+
+func TestRun_PassesInstalledPluginsToModules(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	set := plugin.NewInstalledSet()
+	plugins := []*plugin.YAMLPlugin{{ID: "custom"}}
+	set.Replace(plugins)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orch := &ctxOrch{}
+	svc := NewService().
+		WithInstalledPlugins(set).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Equal(t, plugins, plugin.InstalledPluginsFromContext(orch.ctx))
+
+	// Other tenants evaluate their own set, never the default tenant's
+	_, err = svc.Run(storage.WithOrgID(ctx, "team-a"), Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Nil(t, plugin.InstalledPluginsFromContext(orch.ctx))
+
+	tenantSet := plugin.NewInstalledSet()
+	tenantPlugins := []*plugin.YAMLPlugin{{ID: "team-a-custom"}}
+	tenantSet.Replace(tenantPlugins)
+	var looked []string
+	svc.WithTenantInstalledPlugins(func(_ context.Context, orgID string) *plugin.InstalledSet {
+		looked = append(looked, orgID)
+		if orgID == "team-a" {
+			return tenantSet
+		}
+		return nil
+	})
+	_, err = svc.Run(storage.WithOrgID(ctx, "team-a"), Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Equal(t, tenantPlugins, plugin.InstalledPluginsFromContext(orch.ctx))
+	_, err = svc.Run(storage.WithOrgID(ctx, "team-b"), Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Nil(t, plugin.InstalledPluginsFromContext(orch.ctx))
+	require.Equal(t, []string{"team-a", "team-b"}, looked)
+}
+
+func TestRun_RedactsEvidence(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	redactor, err := redact.New(nil)
+	require.NoError(t, err)
+
+	banner := scan.BannerGrabResult{IP: "127.0.0.1", Port: 80, Protocol: "tcp", Banner: "HTTP/1.1 200 OK\r\nSet-Cookie: sid=s3cr3t\r\n"}
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"service.banner.tcp": []interface{}{banner},
+		"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"plugin": "http-default-login", "severity": "high", "evidence": "login admin, password=admin"},
+		},
+	}
+	scans := &memScans{}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithRedactor(redactor).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	res, err := svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+
+	redacted := res.RawContext["service.banner.tcp"].([]interface{})[0].(scan.BannerGrabResult)
+	require.Equal(t, "HTTP/1.1 200 OK\r\nSet-Cookie: [REDACTED]\r\n", redacted.Banner)
+	require.Contains(t, string(scans.written[storage.DataTypeVulnerabilities]), `"evidence":"login admin, password=[REDACTED]"`)
+	require.Contains(t, string(scans.written[storage.DataTypeEvidence]), `Set-Cookie: [REDACTED]`)
+	require.NotContains(t, string(scans.written[storage.DataTypeEvidence]), "s3cr3t")
+
+	// The outputs of the modules are left unchanged
+	require.Equal(t, "HTTP/1.1 200 OK\r\nSet-Cookie: sid=s3cr3t\r\n", banner.Banner)
+}
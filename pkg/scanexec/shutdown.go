@@ -0,0 +1,141 @@
+package scanexec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// DefaultDrainTimeout is how long runs shut down by a Shutdown give the
+// modules and probes in flight to finish by default.
+const DefaultDrainTimeout = 10 * time.Second
+
+// Shutdown interrupts the runs of a Service gracefully, e.g. on Ctrl+C.
+// Once stopped, runs start no new modules or probes, give those in flight
+// the drain timeout to finish and cancel the rest. Their partial results
+// are stored as usual, and the scans are marked "interrupted" with the
+// metadata to resume them (see storage.ResumeState). It is safe for
+// concurrent use; a nil Shutdown never stops.
+type Shutdown struct {
+	drain time.Duration
+	once  sync.Once
+	stop  chan struct{}
+	at    time.Time // set before stop is closed
+}
+
+// NewShutdown returns a shutdown giving runs drain to finish their work in
+// flight. A drain of zero or less cancels it immediately.
+func NewShutdown(drain time.Duration) *Shutdown {
+	return &Shutdown{drain: drain, stop: make(chan struct{})}
+}
+
+// Stop begins the shutdown. Further calls do nothing.
+func (sh *Shutdown) Stop() {
+	if sh == nil {
+		return
+	}
+	sh.once.Do(func() {
+		sh.at = time.Now().UTC()
+		close(sh.stop)
+	})
+}
+
+// Stopped reports whether the shutdown began.
+func (sh *Shutdown) Stopped() bool {
+	if sh == nil {
+		return false
+	}
+	select {
+	case <-sh.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotifySignals stops sh on the first of sigs. Later signals get their
+// default handling, so that a second Ctrl+C exits at once. The returned
+// function stops listening.
+func (sh *Shutdown) NotifySignals(sigs ...os.Signal) func() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	done := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-ch:
+			signal.Stop(ch)
+			log.Warn().
+				Str("component", "scanexec").
+				Str("signal", sig.String()).
+				Dur("drain_timeout", sh.drain).
+				Msg("Shutting down: finishing probes in flight and storing partial results (signal again to exit at once)")
+			sh.Stop()
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
+
+// runContext returns the context of a run: it drains once sh stops, and is
+// canceled when the drain timeout has passed since. The returned function
+// releases it and must be called when the run ends.
+func (sh *Shutdown) runContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if sh == nil {
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-sh.stop:
+		case <-ctx.Done():
+			return
+		}
+		timer := time.NewTimer(sh.drain)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			log.Warn().
+				Str("component", "scanexec").
+				Dur("drain_timeout", sh.drain).
+				Msg("Drain timeout passed, canceling the work still in flight")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return engine.WithDrain(ctx, sh.stop), cancel
+}
+
+// saveResumeState records how far an interrupted run got: the modules the
+// drain left pending, as reported by the orchestrator in runErr.
+func (s *Service) saveResumeState(ctx context.Context, scanID string, targets []string, runErr error) {
+	if s.storage == nil {
+		return
+	}
+	state := &storage.ResumeState{InterruptedAt: s.shutdown.at, Targets: targets}
+	var drained *engine.DrainedError
+	if errors.As(runErr, &drained) {
+		state.CompletedModules = drained.Completed
+		state.PendingModules = drained.Pending
+	}
+	if err := s.storage.Scans().Update(ctx, storage.OrgIDFromContext(ctx), scanID, storage.ScanUpdates{Resume: state}); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to store resume state of interrupted scan")
+	}
+}
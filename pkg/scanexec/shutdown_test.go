@@ -0,0 +1,95 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/discovery"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// shutdownOrch shuts down while it runs: it finds port 22 open, then waits
+// for the drain timeout to cancel a module in flight.
+type shutdownOrch struct {
+	shutdown *Shutdown
+	draining bool
+}
+
+func (o *shutdownOrch) Run(ctx context.Context, _ map[string]interface{}) (map[string]interface{}, error) {
+	o.shutdown.Stop()
+	o.draining = engine.Draining(ctx)
+	<-ctx.Done()
+	out := map[string]interface{}{
+		"discovery.open_tcp_ports": []interface{}{discovery.TCPPortDiscoveryResult{Target: "10.0.0.5", OpenPorts: []int{22}}},
+	}
+	return out, &engine.DrainedError{Completed: []string{"tcp-port-discovery"}, Pending: []string{"banner-grab"}, Err: ctx.Err()}
+}
+
+func TestRun_Shutdown(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	shutdown := NewShutdown(10 * time.Millisecond)
+	orch := &shutdownOrch{shutdown: shutdown}
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	svc := NewService().
+		WithStorage(backend).
+		WithShutdown(shutdown).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	res, err := svc.Run(ctx, Params{Targets: []string{"10.0.0.5"}})
+	require.ErrorIs(t, err, ErrInterrupted)
+	require.ErrorIs(t, err, engine.ErrDrained)
+	require.Equal(t, errorCodeScanInterrupted, ErrorCode(err))
+	require.True(t, orch.draining)
+	require.Equal(t, "interrupted", res.Status)
+
+	// The partial results are stored, with the state to resume the scan
+	meta, err := backend.Scans().Get(ctx, storage.DefaultOrgID, res.RunID)
+	require.NoError(t, err)
+	require.Equal(t, "interrupted", meta.Status)
+	require.False(t, meta.CompletedAt.IsZero())
+	require.NotNil(t, meta.Resume)
+	require.Equal(t, []string{"10.0.0.5"}, meta.Resume.Targets)
+	require.Equal(t, []string{"tcp-port-discovery"}, meta.Resume.CompletedModules)
+	require.Equal(t, []string{"banner-grab"}, meta.Resume.PendingModules)
+	require.False(t, meta.Resume.InterruptedAt.IsZero())
+	evidence, err := loadEvidence(ctx, backend, storage.DefaultOrgID, res.RunID)
+	require.NoError(t, err)
+	require.Len(t, evidence["discovery.open_tcp_ports"], 1)
+}
+
+func TestShutdown(t *testing.T) {
+	var nilShutdown *Shutdown
+	nilShutdown.Stop()
+	require.False(t, nilShutdown.Stopped())
+	ctx, cancel := nilShutdown.runContext(context.Background())
+	require.False(t, engine.Draining(ctx))
+	cancel()
+
+	// Stopping drains runs; the work in flight keeps its context until the
+	// drain timeout
+	sh := NewShutdown(time.Hour)
+	ctx, cancel = sh.runContext(context.Background())
+	require.False(t, sh.Stopped())
+	require.False(t, engine.Draining(ctx))
+	sh.Stop()
+	sh.Stop()
+	require.True(t, sh.Stopped())
+	require.True(t, engine.Draining(ctx))
+	require.NoError(t, ctx.Err())
+	cancel()
+}
@@ -0,0 +1,123 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// trackedFinding is the part of a finding that SLA tracking reads.
+type trackedFinding struct {
+	recordedFinding
+	Severity string `json:"severity"`
+}
+
+// trackFindings records the run's findings in the finding status store,
+// so that each keeps when it was first seen and the due date its severity
+// had then, and adds both to the findings in dataCtx as first_seen and
+// due_at. Returns the findings past their due date that webhooks were not
+// reminded of yet, and marks them reminded when there is a notifier.
+func (s *Service) trackFindings(ctx context.Context, scanID string, dataCtx map[string]interface{}) []notify.Finding {
+	if s.sla == nil || s.storage == nil || dataCtx == nil {
+		return nil
+	}
+	statusBackend, ok := s.storage.(storage.FindingStatusBackend)
+	if !ok {
+		return nil
+	}
+	vulns, ok := dataCtx["evaluation.vulnerabilities"].([]interface{})
+	if !ok || len(vulns) == 0 {
+		return nil
+	}
+
+	logger := log.With().Str("component", "scanexec").Str("scan_id", scanID).Logger()
+	orgID := storage.OrgIDFromContext(ctx)
+	now := time.Now().UTC()
+
+	// Findings as maps, to annotate them, their IDs and their statuses
+	findings := make([]map[string]interface{}, len(vulns))
+	findingIDs := make([]string, len(vulns))
+	byID := make(map[string]*storage.FindingStatus)
+	var seen []*storage.FindingStatus
+	for i, v := range vulns {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		var tracked trackedFinding
+		if err := json.Unmarshal(raw, &tracked); err != nil || tracked.Target == "" {
+			continue
+		}
+		if err := json.Unmarshal(raw, &findings[i]); err != nil {
+			continue
+		}
+		id := tracked.id()
+		findingIDs[i] = id
+		if byID[id] != nil {
+			continue
+		}
+		status := &storage.FindingStatus{
+			ID:        id,
+			Target:    tracked.Target,
+			Port:      tracked.Port,
+			Plugin:    tracked.Plugin,
+			PluginID:  tracked.PluginID,
+			ScanID:    scanID,
+			Severity:  tracked.Severity,
+			FirstSeen: &now,
+			DueAt:     s.sla.Due(tracked.Severity, now),
+		}
+		byID[id] = status
+		seen = append(seen, status)
+	}
+
+	statuses := statusBackend.FindingStatuses()
+	if err := statuses.Track(ctx, orgID, seen); err != nil {
+		logger.Warn().Err(err).Msg("Failed to track findings")
+		return nil
+	}
+
+	var (
+		overdue []notify.Finding
+		ids     []string
+	)
+	for i, f := range findings {
+		if f == nil {
+			continue
+		}
+		status := byID[findingIDs[i]]
+		if status.FirstSeen != nil {
+			f["first_seen"] = status.FirstSeen
+		}
+		if status.DueAt != nil {
+			f["due_at"] = status.DueAt
+		}
+		vulns[i] = f
+
+		if status.Overdue(now) && status.RemindedAt == nil {
+			var n notify.Finding
+			raw, _ := json.Marshal(f)
+			_ = json.Unmarshal(raw, &n)
+			n.ID = status.ID
+			overdue = append(overdue, n)
+			ids = append(ids, status.ID)
+			status.RemindedAt = &now // Once per finding, even when reported twice
+		}
+	}
+
+	if s.notifier == nil {
+		return nil
+	}
+	if err := statuses.MarkReminded(ctx, orgID, ids, now); err != nil {
+		logger.Warn().Err(err).Msg("Failed to record overdue finding reminders")
+	}
+	if len(overdue) > 0 {
+		logger.Info().Int("overdue", len(overdue)).Msg("Findings past their remediation due date")
+	}
+	return overdue
+}
@@ -0,0 +1,110 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/notify"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/sla"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func TestRun_TracksFindingSLAs(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	var mu sync.Mutex
+	var overdue []notify.Payload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p notify.Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		mu.Lock()
+		defer mu.Unlock()
+		if p.Event == notify.EventOverdue {
+			overdue = append(overdue, p)
+		}
+	}))
+	defer srv.Close()
+	dispatcher, err := notify.New(config.NotificationsConfig{Webhooks: []config.WebhookConfig{{URL: srv.URL, MinSeverity: "info"}}})
+	require.NoError(t, err)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	newOut := func() map[string]interface{} {
+		return map[string]interface{}{"evaluation.vulnerabilities": []interface{}{
+			map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "ssh-weak-cipher", "severity": "critical"},
+			map[string]interface{}{"target": "10.0.1.6", "plugin": "http-server-header", "severity": "info"},
+		}}
+	}
+	orch := &mockOrch{out: newOut()}
+	svc := NewService().
+		WithStorage(backend).
+		WithNotifier(dispatcher).
+		WithSLA(sla.New(map[string]time.Duration{"critical": time.Millisecond})).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	first, err := svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}})
+	require.NoError(t, err)
+	require.Empty(t, overdue, "not due yet")
+
+	// Findings are stored with when they were first seen and are due
+	data, err := report.Load(ctx, backend.Scans(), storage.DefaultOrgID, first.RunID)
+	require.NoError(t, err)
+	require.Len(t, data.Findings, 2)
+	byPlugin := map[string]report.Finding{}
+	for _, f := range data.Findings {
+		byPlugin[f.Plugin] = f
+	}
+	critical := byPlugin["ssh-weak-cipher"]
+	require.NotNil(t, critical.FirstSeen)
+	require.NotNil(t, critical.DueAt)
+	require.Equal(t, critical.FirstSeen.Add(time.Millisecond), *critical.DueAt)
+	require.NotNil(t, byPlugin["http-server-header"].FirstSeen)
+	require.Nil(t, byPlugin["http-server-header"].DueAt, "no SLA for info findings")
+
+	id := ticketing.Fingerprint(ticketing.Finding{Plugin: "ssh-weak-cipher", Target: "10.0.1.5", Port: 22})
+	status, err := backend.FindingStatuses().Get(ctx, storage.DefaultOrgID, id)
+	require.NoError(t, err)
+	require.Equal(t, first.RunID, status.ScanID)
+	require.Equal(t, "critical", status.Severity)
+
+	// The next scan reporting the finding past its due date reminds
+	// webhooks once
+	time.Sleep(5 * time.Millisecond)
+	orch.out = newOut()
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}})
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+	require.Len(t, overdue[0].Findings, 1)
+	require.Equal(t, id, overdue[0].Findings[0].ID)
+	require.Equal(t, critical.DueAt.UTC(), overdue[0].Findings[0].DueAt.UTC())
+
+	orch.out = newOut()
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}})
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+
+	status, err = backend.FindingStatuses().Get(ctx, storage.DefaultOrgID, id)
+	require.NoError(t, err)
+	require.Equal(t, critical.FirstSeen.UTC(), status.FirstSeen.UTC(), "later scans keep the first sighting")
+	require.NotNil(t, status.RemindedAt)
+}
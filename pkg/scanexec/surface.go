@@ -0,0 +1,119 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/surface"
+)
+
+// surfaceFinding is the part of a finding that attack surface scoring
+// reads.
+type surfaceFinding struct {
+	Target   string   `json:"target"`
+	Severity string   `json:"severity"`
+	CVE      []string `json:"cve,omitempty"`
+}
+
+// scoreSurface scores the attack surface of the live hosts of the run and
+// of its target groups (see package surface) and appends the scores to
+// their history in the storage backend. A group scores the sum of its
+// hosts; hosts are internet-facing when their address is public or one of
+// their groups is tagged surface.InternetFacingTag.
+func (s *Service) scoreSurface(ctx context.Context, scanID string, dataCtx map[string]interface{}, groups []*storage.TargetGroup) {
+	if s.storage == nil || dataCtx == nil {
+		return
+	}
+	surfaceBackend, ok := s.storage.(storage.SurfaceBackend)
+	if !ok {
+		return
+	}
+
+	records := hostRecords(assetProfiles(dataCtx))
+	hostTargets := make(map[string]string, len(records))
+	for _, rec := range records {
+		hostTargets[rec.IP] = rec.Target
+	}
+
+	weights := surface.DefaultWeights()
+
+	// Unpatched CVEs by host, with their weightiest severity
+	cves := make(map[string]map[string]string)
+	vulns, _ := dataCtx["evaluation.vulnerabilities"].([]interface{})
+	for _, v := range vulns {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		var f surfaceFinding
+		if err := json.Unmarshal(raw, &f); err != nil || f.Target == "" || len(f.CVE) == 0 {
+			continue
+		}
+		if cves[f.Target] == nil {
+			cves[f.Target] = make(map[string]string)
+		}
+		for _, cve := range f.CVE {
+			if current, ok := cves[f.Target][cve]; !ok || weights.CVEs[strings.ToLower(f.Severity)] > weights.CVEs[strings.ToLower(current)] {
+				cves[f.Target][cve] = f.Severity
+			}
+		}
+	}
+
+	at := time.Now().UTC()
+	groupPoints := make(map[string]*storage.SurfacePoint, len(groups))
+	for _, g := range groups {
+		groupPoints[g.Name] = &storage.SurfacePoint{ScanID: scanID, At: at}
+	}
+
+	scored := make([]*storage.AssetSurface, 0, len(records)+len(groups))
+	for _, rec := range records {
+		matched := findingGroups(groups, rec.IP, hostTargets)
+		host := surface.Host{
+			IP:             rec.IP,
+			CVEs:           cves[rec.IP],
+			InternetFacing: surface.Public(rec.IP) || slices.Contains(storage.GroupTags(matched), surface.InternetFacingTag),
+		}
+		for _, p := range rec.Ports {
+			host.Ports = append(host.Ports, surface.Port{Port: p.Port, Service: p.Service})
+		}
+
+		point := storage.SurfacePoint{
+			ScanID:    scanID,
+			At:        at,
+			Score:     weights.Score(host),
+			Hosts:     1,
+			OpenPorts: len(host.Ports),
+			CVEs:      len(host.CVEs),
+		}
+		if host.InternetFacing {
+			point.InternetFacing = 1
+		}
+		scored = append(scored, &storage.AssetSurface{Asset: rec.IP, Kind: storage.SurfaceHost, History: []storage.SurfacePoint{point}})
+
+		for _, g := range matched {
+			sum := groupPoints[g.Name]
+			sum.Score += point.Score
+			sum.Hosts++
+			sum.InternetFacing += point.InternetFacing
+			sum.OpenPorts += point.OpenPorts
+			sum.CVEs += point.CVEs
+		}
+	}
+	for _, g := range groups {
+		scored = append(scored, &storage.AssetSurface{Asset: g.Name, Kind: storage.SurfaceGroup, History: []storage.SurfacePoint{*groupPoints[g.Name]}})
+	}
+
+	if err := surfaceBackend.Surfaces().Record(ctx, storage.OrgIDFromContext(ctx), scored); err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Str("scan_id", scanID).
+			Err(err).
+			Msg("Failed to record attack surface scores")
+	}
+}
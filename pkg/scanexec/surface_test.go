@@ -0,0 +1,88 @@
+package scanexec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_ScoresSurface(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	out := func(ports ...engine.PortProfile) map[string]interface{} {
+		return map[string]interface{}{
+			"asset.profiles": []interface{}{[]engine.AssetProfile{{
+				Target:      "10.0.1.0/24",
+				ResolvedIPs: map[string]time.Time{"10.0.1.5": time.Now(), "10.0.1.6": time.Now()},
+				OpenPorts: map[string][]engine.PortProfile{
+					"10.0.1.5": ports,
+					"10.0.1.6": {{PortNumber: 443, Protocol: "tcp", Service: engine.ServiceDetails{Name: "https"}}},
+				},
+			}}},
+			"evaluation.vulnerabilities": []interface{}{
+				map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "OpenSSH regreSSHion", "severity": "critical", "cve": []string{"CVE-2024-6387"}},
+				map[string]interface{}{"target": "10.0.1.5", "port": 22, "plugin": "ssh-banner", "severity": "info"},
+			},
+		}
+	}
+	ssh := engine.PortProfile{PortNumber: 22, Protocol: "tcp", Service: engine.ServiceDetails{Name: "ssh"}}
+	telnet := engine.PortProfile{PortNumber: 23, Protocol: "tcp", Service: engine.ServiceDetails{Name: "telnet"}}
+	orch := &mockOrch{out: out(ssh, telnet)}
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return orch, nil })
+
+	groups := []*storage.TargetGroup{
+		{Name: "dmz", Targets: []string{"10.0.1.5"}, Tags: []string{"internet-facing"}},
+		{Name: "office", Targets: []string{"10.0.1.0/24"}},
+	}
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}, TargetGroups: groups})
+	require.NoError(t, err)
+
+	// Telnet is gone in the second scan
+	orch.out = out(ssh)
+	second, err := svc.Run(ctx, Params{Targets: []string{"10.0.1.0/24"}, TargetGroups: groups})
+	require.NoError(t, err)
+
+	surfaces := backend.Surfaces()
+	host, err := surfaces.Get(ctx, storage.DefaultOrgID, storage.SurfaceHost, "10.0.1.5")
+	require.NoError(t, err)
+	require.Len(t, host.History, 2)
+	// (ssh 1+2, telnet 1+10, critical CVE 20) x2 for the internet-facing dmz
+	require.Equal(t, 68, host.History[0].Score)
+	require.Equal(t, storage.SurfacePoint{ScanID: second.RunID, At: host.Latest().At, Score: 46, Hosts: 1, InternetFacing: 1, OpenPorts: 1, CVEs: 1}, *host.Latest())
+	require.Equal(t, -22, host.Change())
+
+	office, err := surfaces.Get(ctx, storage.DefaultOrgID, storage.SurfaceGroup, "office")
+	require.NoError(t, err)
+	require.Equal(t, 48, office.Latest().Score) // plus https 1+1
+	require.Equal(t, 2, office.Latest().Hosts)
+	require.Equal(t, 1, office.Latest().InternetFacing)
+
+	dmz, err := surfaces.Get(ctx, storage.DefaultOrgID, storage.SurfaceGroup, "dmz")
+	require.NoError(t, err)
+	require.Equal(t, 46, dmz.Latest().Score)
+
+	// Rechecks only observe a single port
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.1.5"}, RecheckOf: "14d34128101e76ec"})
+	require.NoError(t, err)
+	host, err = surfaces.Get(ctx, storage.DefaultOrgID, storage.SurfaceHost, "10.0.1.5")
+	require.NoError(t, err)
+	require.Len(t, host.History, 2)
+}
@@ -0,0 +1,29 @@
+package scanexec
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// reportTelemetry sends the telemetry report when one is due. Failures
+// never fail the run; the report is retried after the next run.
+func (s *Service) reportTelemetry(ctx context.Context) {
+	if !s.telemetry.Enabled() {
+		return
+	}
+	report, err := s.telemetry.SendIfDue(ctx)
+	if err != nil {
+		log.Warn().
+			Str("component", "scanexec").
+			Err(err).
+			Msg("Failed to send telemetry report")
+		return
+	}
+	if report != nil {
+		log.Debug().
+			Str("component", "scanexec").
+			Int("scans", report.Scans).
+			Msg("Sent telemetry report")
+	}
+}
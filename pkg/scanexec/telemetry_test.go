@@ -0,0 +1,67 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
+)
+
+func TestRun_ReportsTelemetry(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	root := t.TempDir()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	var reports atomic.Int32
+	var last telemetry.Report
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if json.NewDecoder(r.Body).Decode(&last) == nil {
+			reports.Add(1)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	statePath := filepath.Join(root, telemetry.StateFile)
+	svc := NewService().
+		WithStorage(backend).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return pluginOrch{}, nil })
+
+	// Disabled telemetry sends nothing
+	svc.WithTelemetry(telemetry.NewReporter(backend, statePath, config.TelemetryConfig{Endpoint: srv.URL}))
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.Zero(t, reports.Load())
+
+	// The first run after opting in reports, including itself; the next
+	// report is due a day later
+	svc.WithTelemetry(telemetry.NewReporter(backend, statePath, config.TelemetryConfig{Enabled: true, Endpoint: srv.URL}))
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, reports.Load())
+	require.Equal(t, 2, last.Scans)
+	require.Len(t, last.Plugins, 2)
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"127.0.0.1"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, reports.Load())
+}
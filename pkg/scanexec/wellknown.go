@@ -0,0 +1,37 @@
+package scanexec
+
+import (
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// wellKnownRecords returns what the HTTP services in http.wellknown.details
+// publish at their well-known endpoints, by IP and port.
+func wellKnownRecords(dataCtx map[string]interface{}) map[string]map[int]*storage.WellKnownRecord {
+	values, _ := dataCtx["http.wellknown.details"].([]interface{})
+	records := make(map[string]map[int]*storage.WellKnownRecord)
+	for _, v := range values {
+		result, ok := v.(scan.HTTPWellKnownResult)
+		if !ok || len(result.Endpoints) == 0 {
+			continue
+		}
+		rec := &storage.WellKnownRecord{}
+		for _, e := range result.Endpoints {
+			rec.Endpoints = append(rec.Endpoints, e.Path)
+		}
+		if txt := result.SecurityTxt; txt != nil {
+			rec.Contacts = txt.Contacts
+			rec.Policy = txt.Policy
+			rec.Expires = txt.Expires
+		}
+		if result.RobotsTxt != nil {
+			rec.Disallow = result.RobotsTxt.Disallow
+		}
+
+		if records[result.Target] == nil {
+			records[result.Target] = make(map[int]*storage.WellKnownRecord)
+		}
+		records[result.Target][result.Port] = rec
+	}
+	return records
+}
@@ -0,0 +1,68 @@
+package scanexec
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/modules/scan"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestRun_WellKnown(t *testing.T) {
+	factory := &engine.DefaultAppManagerFactory{}
+	appMgr, err := factory.CreateWithNoConfig()
+	require.NoError(t, err)
+	ctx := context.WithValue(appMgr.Context(), engine.AppManagerKey, appMgr)
+	ctx = appctx.WithConfig(ctx, appMgr.Config())
+
+	expires := time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)
+	def := &engine.DAGDefinition{Name: "test", Nodes: []engine.DAGNodeConfig{{InstanceID: "n1", ModuleType: "noop"}}}
+	orchOut := map[string]interface{}{
+		"asset.profiles": []interface{}{[]engine.AssetProfile{{
+			Target:      "10.0.0.5",
+			ResolvedIPs: map[string]time.Time{"10.0.0.5": time.Now()},
+			OpenPorts: map[string][]engine.PortProfile{"10.0.0.5": {
+				{PortNumber: 22, Protocol: "tcp"},
+				{PortNumber: 443, Protocol: "tcp"},
+			}},
+		}}},
+		"http.wellknown.details": []interface{}{scan.HTTPWellKnownResult{
+			Target: "10.0.0.5",
+			Port:   443,
+			Endpoints: []scan.HTTPWellKnownEndpoint{
+				{Path: "/.well-known/security.txt", Status: 200},
+				{Path: "/robots.txt", Status: 200},
+			},
+			SecurityTxt: &scan.SecurityTxt{Contacts: []string{"mailto:security@example.com"}, Policy: []string{"https://example.com/disclosure"}, Expires: expires},
+			RobotsTxt:   &scan.RobotsTxt{Disallow: []string{"/admin/"}},
+		}},
+	}
+
+	scans := &memScans{}
+	svc := NewService().
+		WithStorage(&memBackend{scans: scans}).
+		WithPlannerFactory(func(context.Context) (dagPlanner, error) { return &mockPlanner{def: def}, nil }).
+		WithOrchestratorFactory(func(d *engine.DAGDefinition) (orchestrator, error) { return &mockOrch{out: orchOut}, nil })
+
+	_, err = svc.Run(ctx, Params{Targets: []string{"10.0.0.5"}})
+	require.NoError(t, err)
+
+	var host storage.HostRecord
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(string(scans.written[storage.DataTypeHosts]))), &host))
+	require.Len(t, host.Ports, 2)
+	require.Nil(t, host.Ports[0].WellKnown)
+	require.Equal(t, &storage.WellKnownRecord{
+		Endpoints: []string{"/.well-known/security.txt", "/robots.txt"},
+		Contacts:  []string{"mailto:security@example.com"},
+		Policy:    []string{"https://example.com/disclosure"},
+		Expires:   expires,
+		Disallow:  []string{"/admin/"},
+	}, host.Ports[1].WellKnown)
+}
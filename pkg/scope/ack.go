@@ -0,0 +1,132 @@
+package scope
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Acknowledgment approves scanning targets outside the policy. Its token
+// (see Sign) is the acknowledgment, signed with the policy's override key,
+// so that only holders of the key can approve out-of-scope scans and the
+// approval cannot be altered.
+type Acknowledgment struct {
+	// Targets are the out-of-scope targets approved: IP addresses, CIDRs,
+	// ranges or hostnames. A target of a scan is covered when it is listed
+	// or lies within the listed addresses.
+	Targets []string `json:"targets"`
+
+	// Reason tells why scanning the targets is authorized, e.g. a change
+	// ticket or engagement letter.
+	Reason string `json:"reason"`
+
+	// By names who approved the scan.
+	By string `json:"by"`
+
+	// Expires is when the acknowledgment stops applying.
+	Expires time.Time `json:"expires"`
+}
+
+// Sign returns the token of ack signed with key.
+func Sign(key []byte, ack Acknowledgment) (string, error) {
+	if len(ack.Targets) == 0 {
+		return "", errors.New("acknowledgment names no targets")
+	}
+	for _, target := range ack.Targets {
+		if strings.TrimSpace(target) == "" {
+			return "", errors.New("acknowledgment names an empty target")
+		}
+	}
+	if strings.TrimSpace(ack.Reason) == "" {
+		return "", errors.New("acknowledgment reason is required")
+	}
+	if strings.TrimSpace(ack.By) == "" {
+		return "", errors.New("acknowledgment approver is required")
+	}
+	if ack.Expires.IsZero() {
+		return "", errors.New("acknowledgment expiry is required")
+	}
+	ack.Expires = ack.Expires.UTC().Truncate(time.Second)
+
+	payload, err := json.Marshal(ack)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signature(key, encoded), nil
+}
+
+// Verify returns the acknowledgment of token when it is signed with key and
+// has not expired at now.
+func Verify(key []byte, token string, now time.Time) (*Acknowledgment, error) {
+	encoded, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return nil, errors.New("malformed acknowledgment")
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(key, encoded))) {
+		return nil, errors.New("acknowledgment signature is invalid")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("malformed acknowledgment")
+	}
+	var ack Acknowledgment
+	if err := json.Unmarshal(payload, &ack); err != nil {
+		return nil, fmt.Errorf("malformed acknowledgment: %w", err)
+	}
+	if !now.Before(ack.Expires) {
+		return nil, fmt.Errorf("acknowledgment expired at %s", ack.Expires.UTC().Format(time.RFC3339))
+	}
+	return &ack, nil
+}
+
+func signature(key []byte, encoded string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// uncovered returns the violations whose targets ack does not approve.
+func (a *Acknowledgment) uncovered(violations []Violation) []Violation {
+	var approved []span
+	for _, target := range a.Targets {
+		if spans, ok := parseTarget(strings.TrimSpace(target)); ok {
+			approved = append(approved, spans...)
+		}
+	}
+
+	var missing []Violation
+	for _, v := range violations {
+		if a.lists(v.Target) {
+			continue
+		}
+		spans, ok := parseTarget(v.Target)
+		if !ok || !allCovered(spans, approved) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+func (a *Acknowledgment) lists(target string) bool {
+	for _, t := range a.Targets {
+		if strings.EqualFold(strings.TrimSpace(t), target) {
+			return true
+		}
+	}
+	return false
+}
+
+func allCovered(spans, set []span) bool {
+	for _, s := range spans {
+		if !covered(s, set) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,297 @@
+// Package scope keeps scans within the address ranges an organization is
+// authorized to scan. A Policy holds the permitted ranges and the ranges
+// never to scan, and is checked before a scan sends its first packet:
+// scans with targets outside the policy are refused as a whole.
+//
+// An operator who must scan outside the policy, e.g. a newly acquired
+// range not yet added to it, presents an acknowledgment signed with the
+// policy's override key (see Sign). The acknowledgment names the targets,
+// who approved them and why, and expires.
+//
+// A nil *Policy permits every target.
+package scope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+// ErrOutOfScope is matched by the errors of targets outside the policy.
+var ErrOutOfScope = errors.New("targets outside the scan scope")
+
+// Violation is a target outside the policy.
+type Violation struct {
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+}
+
+// Error lists the targets of a scan outside the policy.
+type Error struct {
+	Violations []Violation
+	// Override tells why the acknowledgment presented did not apply;
+	// empty without one.
+	Override string
+}
+
+func (e *Error) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = fmt.Sprintf("%s (%s)", v.Target, v.Reason)
+	}
+	msg := fmt.Sprintf("%s: %s", ErrOutOfScope, strings.Join(parts, ", "))
+	if e.Override != "" {
+		msg += "; override rejected: " + e.Override
+	}
+	return msg
+}
+
+// Is matches ErrOutOfScope.
+func (e *Error) Is(target error) bool {
+	return target == ErrOutOfScope
+}
+
+// Policy permits scanning the allowed ranges except the denied ones.
+type Policy struct {
+	allow  []span // empty = every address
+	deny   []netip.Prefix
+	keyRef string
+
+	lookup func(ctx context.Context, host string) ([]netip.Addr, error)
+	now    func() time.Time
+}
+
+// New returns the policy permitting the allow ranges except the deny
+// ranges, given as CIDRs or IP addresses. Without allow ranges every
+// address not denied is permitted. keyRef is the secret reference (see
+// package secrets) of the key acknowledgments are signed with; without
+// it, targets outside the policy cannot be overridden.
+func New(allow, deny []string, keyRef string) (*Policy, error) {
+	p := &Policy{
+		keyRef: keyRef,
+		lookup: lookupHost,
+		now:    time.Now,
+	}
+	for _, raw := range allow {
+		prefix, err := ParsePrefix(raw)
+		if err != nil {
+			return nil, fmt.Errorf("allow: %w", err)
+		}
+		p.allow = append(p.allow, prefixSpan(prefix))
+	}
+	for _, raw := range deny {
+		prefix, err := ParsePrefix(raw)
+		if err != nil {
+			return nil, fmt.Errorf("deny: %w", err)
+		}
+		p.deny = append(p.deny, prefix)
+	}
+	return p, nil
+}
+
+// ParsePrefix parses a CIDR, or an IP address as a single-address prefix.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q", s)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", s)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Check returns an *Error listing the targets outside the policy, unless
+// ack is an acknowledgment covering all of them. Targets take the forms
+// scans accept: IP addresses, CIDRs, ranges (10.0.0.1-20 or
+// 10.0.0.1-10.0.0.20) and hostnames, which are checked by the addresses
+// they resolve to; hostnames that do not resolve are refused. It returns
+// the acknowledgment when one was needed and applied.
+func (p *Policy) Check(ctx context.Context, targets []string, ack string) (*Acknowledgment, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	var violations []Violation
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		if reason := p.violation(ctx, target); reason != "" {
+			violations = append(violations, Violation{Target: target, Reason: reason})
+		}
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	outOfScope := &Error{Violations: violations}
+	if ack == "" {
+		return nil, outOfScope
+	}
+	acknowledged, err := p.verify(ctx, ack)
+	if err != nil {
+		outOfScope.Override = err.Error()
+		return nil, outOfScope
+	}
+	if missing := acknowledged.uncovered(violations); len(missing) > 0 {
+		outOfScope.Violations = missing
+		outOfScope.Override = "acknowledgment does not cover these targets"
+		return nil, outOfScope
+	}
+	return acknowledged, nil
+}
+
+// Key resolves the key acknowledgments are signed with.
+func (p *Policy) Key(ctx context.Context) ([]byte, error) {
+	if p == nil || p.keyRef == "" {
+		return nil, errors.New("scope overrides are disabled: scope.override_key is not set")
+	}
+	key, err := secrets.Resolve(ctx, p.keyRef)
+	if err != nil {
+		return nil, fmt.Errorf("resolve scope override key: %w", err)
+	}
+	if key == "" {
+		return nil, errors.New("scope override key is empty")
+	}
+	return []byte(key), nil
+}
+
+func (p *Policy) verify(ctx context.Context, token string) (*Acknowledgment, error) {
+	key, err := p.Key(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Verify(key, token, p.now())
+}
+
+// violation returns why target is outside the policy, or empty when it is
+// permitted.
+func (p *Policy) violation(ctx context.Context, target string) string {
+	spans, ok := parseTarget(target)
+	if !ok {
+		addrs, err := p.lookup(ctx, target)
+		if err != nil || len(addrs) == 0 {
+			return "does not resolve"
+		}
+		for _, addr := range addrs {
+			spans = append(spans, span{addr.Unmap(), addr.Unmap()})
+		}
+	}
+
+	for _, s := range spans {
+		for _, prefix := range p.deny {
+			if s.overlaps(prefixSpan(prefix)) {
+				return "denied by " + prefix.String()
+			}
+		}
+		if len(p.allow) > 0 && !covered(s, p.allow) {
+			if s.from == s.to {
+				return s.from.String() + " is not in the allowed ranges"
+			}
+			return "not in the allowed ranges"
+		}
+	}
+	return ""
+}
+
+// span is an inclusive range of addresses of one family.
+type span struct {
+	from, to netip.Addr
+}
+
+func (s span) overlaps(o span) bool {
+	return s.from.BitLen() == o.from.BitLen() &&
+		s.from.Compare(o.to) <= 0 && o.from.Compare(s.to) <= 0
+}
+
+func prefixSpan(p netip.Prefix) span {
+	from := p.Masked().Addr()
+	to := from.AsSlice()
+	for bit := p.Bits(); bit < from.BitLen(); bit++ {
+		to[bit/8] |= 0x80 >> (bit % 8)
+	}
+	last, _ := netip.AddrFromSlice(to)
+	return span{from, last}
+}
+
+// covered reports whether the spans of set together contain all of s.
+func covered(s span, set []span) bool {
+	sorted := slices.Clone(set)
+	slices.SortFunc(sorted, func(a, b span) int { return a.from.Compare(b.from) })
+
+	next := s.from
+	for _, o := range sorted {
+		if o.from.BitLen() != next.BitLen() || o.from.Compare(next) > 0 || o.to.Compare(next) < 0 {
+			continue
+		}
+		if o.to.Compare(s.to) >= 0 {
+			return true
+		}
+		next = o.to.Next()
+	}
+	return false
+}
+
+// parseTarget parses an IP address, CIDR or range target; ok is false for
+// hostnames.
+func parseTarget(target string) (_ []span, ok bool) {
+	if strings.Contains(target, "/") {
+		prefix, err := netip.ParsePrefix(target)
+		if err != nil {
+			return nil, false
+		}
+		if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		return []span{prefixSpan(prefix)}, true
+	}
+	if addr, err := netip.ParseAddr(target); err == nil {
+		return []span{{addr.Unmap(), addr.Unmap()}}, true
+	}
+
+	start, end, found := strings.Cut(target, "-")
+	if !found {
+		return nil, false
+	}
+	from, err := netip.ParseAddr(strings.TrimSpace(start))
+	if err != nil {
+		return nil, false
+	}
+	from = from.Unmap()
+	end = strings.TrimSpace(end)
+
+	// Last-octet range, e.g. 192.168.1.10-20
+	if octet, err := strconv.Atoi(end); err == nil && from.Is4() {
+		if octet < int(from.As4()[3]) || octet > 255 {
+			return nil, false
+		}
+		b := from.As4()
+		b[3] = byte(octet)
+		return []span{{from, netip.AddrFrom4(b)}}, true
+	}
+	to, err := netip.ParseAddr(end)
+	if err != nil || to.Unmap().BitLen() != from.BitLen() || to.Unmap().Less(from) {
+		return nil, false
+	}
+	return []span{{from, to.Unmap()}}, true
+}
+
+func lookupHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
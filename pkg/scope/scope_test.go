@@ -0,0 +1,139 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPolicy(t *testing.T, allow, deny []string, keyRef string) *Policy {
+	t.Helper()
+	p, err := New(allow, deny, keyRef)
+	require.NoError(t, err)
+	p.now = func() time.Time { return time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC) }
+	p.lookup = func(_ context.Context, host string) ([]netip.Addr, error) {
+		switch host {
+		case "intranet.example":
+			return []netip.Addr{netip.MustParseAddr("10.1.2.3")}, nil
+		case "www.example":
+			return []netip.Addr{netip.MustParseAddr("10.1.2.4"), netip.MustParseAddr("198.51.100.9")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	return p
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p := newTestPolicy(t, []string{"10.0.0.0/8", "192.168.1.0/25", "192.168.1.128/25", "2001:db8::/32"}, []string{"10.99.0.0/16", "10.0.0.1"}, "")
+	ctx := context.Background()
+
+	for _, target := range []string{
+		"10.1.2.3", "10.2.0.0/16", "192.168.1.0/24", "192.168.1.10-20",
+		"10.1.0.1-10.1.0.255", "2001:db8::1", "intranet.example", "::ffff:10.1.2.3",
+	} {
+		_, err := p.Check(ctx, []string{target}, "")
+		require.NoError(t, err, target)
+	}
+
+	tests := []struct {
+		target string
+		reason string
+	}{
+		{"203.0.113.5", "203.0.113.5 is not in the allowed ranges"},
+		{"192.168.0.0/16", "not in the allowed ranges"},
+		{"10.98.255.250-10.99.0.5", "denied by 10.99.0.0/16"},
+		{"10.0.0.0/24", "denied by 10.0.0.1/32"},
+		{"www.example", "198.51.100.9 is not in the allowed ranges"},
+		{"unknown.example", "does not resolve"},
+		{"2001:db9::1", "2001:db9::1 is not in the allowed ranges"},
+	}
+	for _, tt := range tests {
+		_, err := p.Check(ctx, []string{"10.1.2.3", tt.target}, "")
+		require.ErrorIs(t, err, ErrOutOfScope, tt.target)
+		var outOfScope *Error
+		require.ErrorAs(t, err, &outOfScope)
+		require.Equal(t, []Violation{{Target: tt.target, Reason: tt.reason}}, outOfScope.Violations)
+	}
+
+	// Without allow ranges, only denied ranges are refused
+	open := newTestPolicy(t, nil, []string{"10.99.0.0/16"}, "")
+	_, err := open.Check(ctx, []string{"203.0.113.5", "example-host.invalid-10"}, "")
+	require.ErrorContains(t, err, "example-host.invalid-10 (does not resolve)")
+	_, err = open.Check(ctx, []string{"10.99.1.1"}, "")
+	require.ErrorContains(t, err, "denied by 10.99.0.0/16")
+
+	var none *Policy
+	_, err = none.Check(ctx, []string{"203.0.113.5"}, "")
+	require.NoError(t, err)
+
+	_, err = New([]string{"10.0.0.0/33"}, nil, "")
+	require.ErrorContains(t, err, `allow: invalid CIDR "10.0.0.0/33"`)
+	_, err = New(nil, []string{"host"}, "")
+	require.ErrorContains(t, err, `deny: invalid IP address "host"`)
+}
+
+func TestPolicy_Override(t *testing.T) {
+	t.Setenv("SCOPE_TEST_KEY", "s3cret")
+	p := newTestPolicy(t, []string{"10.0.0.0/8"}, nil, "env:SCOPE_TEST_KEY")
+	ctx := context.Background()
+	now := p.now()
+
+	token, err := Sign([]byte("s3cret"), Acknowledgment{
+		Targets: []string{"203.0.113.0/24", "unknown.example"},
+		Reason:  "CHG-1234 acquisition range",
+		By:      "alice",
+		Expires: now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	ack, err := p.Check(ctx, []string{"10.1.1.1", "203.0.113.10-20", "unknown.example"}, token)
+	require.NoError(t, err)
+	require.Equal(t, "alice", ack.By)
+	require.Equal(t, "CHG-1234 acquisition range", ack.Reason)
+
+	// Acknowledgments are not needed within the policy
+	ack, err = p.Check(ctx, []string{"10.1.1.1"}, token)
+	require.NoError(t, err)
+	require.Nil(t, ack)
+
+	// Targets the acknowledgment does not name stay refused
+	_, err = p.Check(ctx, []string{"203.0.113.0/23"}, token)
+	require.ErrorContains(t, err, "acknowledgment does not cover these targets")
+
+	// Forged, altered and expired acknowledgments are refused
+	forged, err := Sign([]byte("other"), Acknowledgment{Targets: []string{"0.0.0.0/0"}, Reason: "x", By: "mallory", Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = p.Check(ctx, []string{"203.0.113.10"}, forged)
+	require.ErrorContains(t, err, "override rejected: acknowledgment signature is invalid")
+
+	_, err = p.Check(ctx, []string{"203.0.113.10"}, "x"+token)
+	require.ErrorContains(t, err, "signature is invalid")
+
+	p.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = p.Check(ctx, []string{"203.0.113.10"}, token)
+	require.ErrorContains(t, err, "acknowledgment expired at 2026-05-01T13:00:00Z")
+
+	// Without an override key, nothing can be overridden
+	locked := newTestPolicy(t, []string{"10.0.0.0/8"}, nil, "")
+	_, err = locked.Check(ctx, []string{"203.0.113.10"}, token)
+	require.ErrorContains(t, err, "scope.override_key is not set")
+}
+
+func TestSign_Validation(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	_, err := Sign([]byte("k"), Acknowledgment{Reason: "r", By: "b", Expires: expires})
+	require.ErrorContains(t, err, "names no targets")
+	_, err = Sign([]byte("k"), Acknowledgment{Targets: []string{"10.0.0.1"}, By: "b", Expires: expires})
+	require.ErrorContains(t, err, "reason is required")
+	_, err = Sign([]byte("k"), Acknowledgment{Targets: []string{"10.0.0.1"}, Reason: "r", Expires: expires})
+	require.ErrorContains(t, err, "approver is required")
+	_, err = Sign([]byte("k"), Acknowledgment{Targets: []string{"10.0.0.1"}, Reason: "r", By: "b"})
+	require.ErrorContains(t, err, "expiry is required")
+
+	_, err = Verify([]byte("k"), "not-a-token", time.Now())
+	require.ErrorContains(t, err, "malformed acknowledgment")
+}
@@ -0,0 +1,224 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWS reads secrets from AWS Secrets Manager. References name the secret
+// (name or ARN) and optionally a field of its JSON value:
+//
+//	aws-sm:prod/vulntor/jira            the whole secret string
+//	aws-sm:prod/vulntor/snmp#community  a field of a JSON secret
+//
+// Requests are signed with the static credentials of the environment
+// (AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN).
+type AWS struct {
+	// Region is the region of the secrets, e.g. us-east-1.
+	Region string
+	// Endpoint overrides the Secrets Manager endpoint, e.g. for VPC
+	// endpoints or LocalStack. Empty uses the public regional endpoint.
+	Endpoint string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// Client sends the requests. Nil uses a client with DefaultTimeout.
+	Client *http.Client
+
+	now func() time.Time
+}
+
+// AWSFromEnv returns an AWS Secrets Manager provider configured from the
+// standard AWS environment variables.
+func AWSFromEnv() *AWS {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return &AWS{
+		Region:          region,
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		now:             time.Now,
+	}
+}
+
+// Get returns the secret at ref, NAME#FIELD.
+func (a *AWS) Get(ctx context.Context, ref string) (string, error) {
+	name, field := splitField(ref)
+	if a.Region == "" {
+		return "", errors.New("aws-sm: no region configured (set secrets.aws.region or AWS_REGION)")
+	}
+	if a.AccessKeyID == "" || a.SecretAccessKey == "" {
+		return "", errors.New("aws-sm: no credentials (set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+	}
+	if name == "" {
+		return "", errors.New("aws-sm: no secret name given")
+	}
+
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + a.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("aws-sm: invalid endpoint %q", endpoint)
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+
+	body, err := json.Marshal(map[string]string{"SecretId": name})
+	if err != nil {
+		return "", fmt.Errorf("aws-sm: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("aws-sm: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	a.sign(req, body)
+
+	resp, err := a.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("aws-sm: get %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("aws-sm: get %s: %w", name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("aws-sm: get %s: %s", name, awsError(resp.Status, respBody))
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(respBody, &secret); err != nil {
+		return "", fmt.Errorf("aws-sm: get %s: invalid response: %w", name, err)
+	}
+	if secret.SecretString == nil {
+		return "", fmt.Errorf("aws-sm: %s: binary secrets are not supported", name)
+	}
+	if field == "" {
+		return *secret.SecretString, nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(*secret.SecretString), &values); err != nil {
+		return "", fmt.Errorf("aws-sm: %s: secret is not a JSON object, drop #%s", name, field)
+	}
+	value, err := selectField(values, field)
+	if err != nil {
+		return "", fmt.Errorf("aws-sm: %s: %w", name, err)
+	}
+	return value, nil
+}
+
+func (a *AWS) client() *http.Client {
+	if a.Client != nil {
+		return a.Client
+	}
+	return &http.Client{Timeout: DefaultTimeout}
+}
+
+// sign adds the Signature Version 4 authorization of req.
+func (a *AWS) sign(req *http.Request, body []byte) {
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	amzDate := now().UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if a.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + a.Region + "/secretsmanager/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	signature := hex.EncodeToString(hmacSHA256(signingKey(a.SecretAccessKey, date, a.Region, "secretsmanager"), stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+a.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// signingKey derives the Signature Version 4 key of a day, region and
+// service.
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsError returns the type and message of an AWS error response, or its
+// status.
+func awsError(status string, body []byte) string {
+	var resp struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &resp) == nil && resp.Type != "" {
+		typ := resp.Type
+		if i := strings.LastIndex(typ, "#"); i >= 0 {
+			typ = typ[i+1:]
+		}
+		if resp.Message != "" {
+			return status + ": " + typ + ": " + resp.Message
+		}
+		return status + ": " + typ
+	}
+	return status
+}
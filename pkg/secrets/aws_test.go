@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningKey(t *testing.T) {
+	// Example of the AWS Signature Version 4 documentation
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	require.Equal(t, "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d", hex.EncodeToString(key))
+}
+
+func TestAWS_Get(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		require.Equal(t, "20250102T030405Z", r.Header.Get("X-Amz-Date"))
+		require.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		auth := r.Header.Get("Authorization")
+		require.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20250102/eu-west-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature="), auth)
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req struct{ SecretId string }
+		require.NoError(t, json.Unmarshal(body, &req))
+		switch req.SecretId {
+		case "prod/vulntor/snmp":
+			_, _ = w.Write([]byte(`{"Name":"prod/vulntor/snmp","SecretString":"{\"community\":\"s3cret\",\"user\":\"monitor\"}"}`))
+		case "prod/vulntor/jira":
+			_, _ = w.Write([]byte(`{"Name":"prod/vulntor/jira","SecretString":"jira-token"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.secretsmanager#ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+		}
+	}))
+	defer srv.Close()
+
+	a := &AWS{
+		Region:          "eu-west-1",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+		now:             func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) },
+	}
+	ctx := context.Background()
+
+	got, err := a.Get(ctx, "prod/vulntor/snmp#community")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", got)
+
+	got, err = a.Get(ctx, "prod/vulntor/jira")
+	require.NoError(t, err)
+	require.Equal(t, "jira-token", got)
+
+	_, err = a.Get(ctx, "prod/vulntor/jira#token")
+	require.ErrorContains(t, err, "not a JSON object")
+	_, err = a.Get(ctx, "prod/vulntor/missing")
+	require.ErrorContains(t, err, "ResourceNotFoundException")
+
+	_, err = (&AWS{AccessKeyID: "a", SecretAccessKey: "b"}).Get(ctx, "x")
+	require.ErrorContains(t, err, "AWS_REGION")
+	_, err = (&AWS{Region: "eu-west-1"}).Get(ctx, "x")
+	require.ErrorContains(t, err, "AWS_ACCESS_KEY_ID")
+}
+
+func TestAWSFromEnv(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "us-west-2")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	a := AWSFromEnv()
+	require.Equal(t, "us-west-2", a.Region)
+	require.Equal(t, "AKIDEXAMPLE", a.AccessKeyID)
+	require.Equal(t, "secret", a.SecretAccessKey)
+	require.Empty(t, a.SessionToken)
+}
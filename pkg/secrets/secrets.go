@@ -0,0 +1,154 @@
+// Package secrets resolves secret references, so config files name where
+// a password or token is kept instead of holding it:
+//
+//	env:NAME            the environment variable NAME
+//	file:PATH           the content of the file at PATH
+//	vault:PATH#FIELD    a field of a HashiCorp Vault secret
+//	aws-sm:NAME#FIELD   an AWS Secrets Manager secret, or a field of its
+//	                    JSON value
+//
+// Any other value is an inline secret and is returned as is. Providers are
+// pluggable: a Resolver maps each scheme to the Provider reading it, and
+// the default resolver, used by authenticated checks and notification
+// integrations, is replaced with SetDefault once the secrets config is
+// loaded.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Provider reads secrets from a secret store.
+type Provider interface {
+	// Get returns the secret at path, the part of a reference after the
+	// scheme.
+	Get(ctx context.Context, path string) (string, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ctx context.Context, path string) (string, error)
+
+// Get calls f.
+func (f ProviderFunc) Get(ctx context.Context, path string) (string, error) {
+	return f(ctx, path)
+}
+
+// Resolver resolves references with the providers of their scheme.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver returns a resolver with the built-in providers: env, file,
+// and vault and aws-sm configured from the environment (see
+// VaultFromEnv and AWSFromEnv).
+func NewResolver() *Resolver {
+	r := &Resolver{providers: make(map[string]Provider)}
+	r.Register("env", ProviderFunc(getEnv))
+	r.Register("file", ProviderFunc(getFile))
+	r.Register("vault", VaultFromEnv())
+	r.Register("aws-sm", AWSFromEnv())
+	return r
+}
+
+// Register makes p read the references of scheme, replacing the provider
+// registered before.
+func (r *Resolver) Register(scheme string, p Provider) {
+	r.providers[scheme] = p
+}
+
+// Resolve returns the secret ref refers to. Values without a registered
+// scheme are returned as is.
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	scheme, path, ok := strings.Cut(ref, ":")
+	if !ok {
+		return ref, nil
+	}
+	p, ok := r.providers[scheme]
+	if !ok {
+		return ref, nil
+	}
+	return p.Get(ctx, path)
+}
+
+var defaultResolver atomic.Pointer[Resolver]
+
+func init() {
+	defaultResolver.Store(NewResolver())
+}
+
+// Default returns the resolver used by Resolve.
+func Default() *Resolver {
+	return defaultResolver.Load()
+}
+
+// SetDefault replaces the resolver used by Resolve. A nil r restores the
+// built-in providers.
+func SetDefault(r *Resolver) {
+	if r == nil {
+		r = NewResolver()
+	}
+	defaultResolver.Store(r)
+}
+
+// Resolve returns the secret ref refers to, using the default resolver.
+func Resolve(ctx context.Context, ref string) (string, error) {
+	return Default().Resolve(ctx, ref)
+}
+
+func getEnv(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+func getFile(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- secret file is chosen by the user
+	if err != nil {
+		return "", fmt.Errorf("read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// splitField splits PATH#FIELD references.
+func splitField(ref string) (path, field string) {
+	if i := strings.LastIndex(ref, "#"); i >= 0 {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+// selectField returns field of a secret holding several values. Without a
+// field, a secret holding a single value returns it.
+func selectField(values map[string]any, field string) (string, error) {
+	if field == "" {
+		if len(values) != 1 {
+			return "", errors.New("secret has several fields, select one with #FIELD")
+		}
+		for _, v := range values {
+			return stringValue(v)
+		}
+	}
+	v, ok := values[field]
+	if !ok {
+		return "", fmt.Errorf("secret has no field %s", field)
+	}
+	return stringValue(v)
+}
+
+func stringValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case float64, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("secret field is a %T, not a string", v)
+	}
+}
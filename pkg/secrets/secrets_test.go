@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Setenv("VULNTOR_TEST_SECRET", "from-env")
+	file := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(file, []byte("from-file\n"), 0o600))
+
+	r := NewResolver()
+	r.Register("test", ProviderFunc(func(ctx context.Context, path string) (string, error) {
+		return "test:" + path, nil
+	}))
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "inline", want: "inline"},
+		{ref: "pass:word", want: "pass:word"},
+		{ref: "https://hooks.example.com/x", want: "https://hooks.example.com/x"},
+		{ref: "env:VULNTOR_TEST_SECRET", want: "from-env"},
+		{ref: "file:" + file, want: "from-file"},
+		{ref: "test:a/b#c", want: "test:a/b#c"},
+		{ref: "env:VULNTOR_TEST_UNSET", wantErr: "VULNTOR_TEST_UNSET is not set"},
+		{ref: "file:" + file + ".missing", wantErr: "read secret file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := r.Resolve(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	r := NewResolver()
+	r.Register("vault", ProviderFunc(func(ctx context.Context, path string) (string, error) {
+		return "s3cret", nil
+	}))
+	SetDefault(r)
+	require.Same(t, r, Default())
+	got, err := Resolve(context.Background(), "vault:secret/data/ssh#password")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", got)
+
+	SetDefault(nil)
+	require.NotSame(t, r, Default())
+}
+
+func TestSelectField(t *testing.T) {
+	got, err := selectField(map[string]any{"password": "p", "user": "u"}, "password")
+	require.NoError(t, err)
+	require.Equal(t, "p", got)
+
+	got, err = selectField(map[string]any{"token": "t"}, "")
+	require.NoError(t, err)
+	require.Equal(t, "t", got)
+
+	got, err = selectField(map[string]any{"port": float64(161)}, "port")
+	require.NoError(t, err)
+	require.Equal(t, "161", got)
+
+	_, err = selectField(map[string]any{"password": "p", "user": "u"}, "")
+	require.ErrorContains(t, err, "select one with #FIELD")
+	_, err = selectField(map[string]any{"user": "u"}, "password")
+	require.ErrorContains(t, err, "no field password")
+	_, err = selectField(map[string]any{"nested": map[string]any{}}, "nested")
+	require.ErrorContains(t, err, "not a string")
+}
@@ -0,0 +1,128 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each request to a secret store.
+const DefaultTimeout = 10 * time.Second
+
+// Vault reads secrets from HashiCorp Vault. References name the API path
+// of the secret below /v1/ and optionally a field of its data:
+//
+//	vault:secret/data/vulntor/ssh#password   (KV version 2)
+//	vault:kv/vulntor/ssh#password            (KV version 1)
+//
+// A secret with a single field needs no #FIELD.
+type Vault struct {
+	// Address is the Vault server URL, e.g. https://vault.example.com:8200.
+	Address string
+	// Token authenticates the requests.
+	Token string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+	// Client sends the requests. Nil uses a client with DefaultTimeout.
+	Client *http.Client
+}
+
+// VaultFromEnv returns a Vault provider configured like the vault CLI:
+// VAULT_ADDR, VAULT_NAMESPACE, and VAULT_TOKEN or the token file
+// ~/.vault-token.
+func VaultFromEnv() *Vault {
+	v := &Vault{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Token:     os.Getenv("VAULT_TOKEN"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+	if v.Token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				v.Token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	return v
+}
+
+// Get returns the secret at ref, PATH#FIELD.
+func (v *Vault) Get(ctx context.Context, ref string) (string, error) {
+	path, field := splitField(ref)
+	if v.Address == "" {
+		return "", errors.New("vault: no address configured (set secrets.vault.address or VAULT_ADDR)")
+	}
+	if v.Token == "" {
+		return "", errors.New("vault: no token configured (set secrets.vault.token or VAULT_TOKEN)")
+	}
+	if path == "" {
+		return "", errors.New("vault: no secret path given")
+	}
+
+	url := strings.TrimRight(v.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("vault: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", v.Token)
+	if v.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.Namespace)
+	}
+
+	resp, err := v.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault: read %s: %w", path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("vault: read %s: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault: read %s: %s", path, vaultError(resp.Status, body))
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("vault: read %s: invalid response: %w", path, err)
+	}
+	data := secret.Data
+	// KV version 2 nests the secret in data.data next to data.metadata
+	if inner, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	value, err := selectField(data, field)
+	if err != nil {
+		return "", fmt.Errorf("vault: %s: %w", path, err)
+	}
+	return value, nil
+}
+
+func (v *Vault) client() *http.Client {
+	if v.Client != nil {
+		return v.Client
+	}
+	return &http.Client{Timeout: DefaultTimeout}
+}
+
+// vaultError returns the errors of a Vault error response, or its status.
+func vaultError(status string, body []byte) string {
+	var resp struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) == nil && len(resp.Errors) > 0 {
+		return status + ": " + strings.Join(resp.Errors, "; ")
+	}
+	return status
+}
@@ -0,0 +1,67 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault_Get(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		require.Equal(t, "team-a", r.Header.Get("X-Vault-Namespace"))
+		switch r.URL.Path {
+		case "/v1/secret/data/vulntor/ssh":
+			_, _ = w.Write([]byte(`{"data":{"data":{"username":"scanner","password":"kv2-pass"},"metadata":{"version":3}}}`))
+		case "/v1/kv/vulntor/jira":
+			_, _ = w.Write([]byte(`{"data":{"token":"kv1-token"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	v := &Vault{Address: srv.URL + "/", Token: "root", Namespace: "team-a"}
+	ctx := context.Background()
+
+	got, err := v.Get(ctx, "secret/data/vulntor/ssh#password")
+	require.NoError(t, err)
+	require.Equal(t, "kv2-pass", got)
+
+	got, err = v.Get(ctx, "kv/vulntor/jira")
+	require.NoError(t, err)
+	require.Equal(t, "kv1-token", got)
+
+	_, err = v.Get(ctx, "secret/data/vulntor/ssh")
+	require.ErrorContains(t, err, "select one with #FIELD")
+	_, err = v.Get(ctx, "secret/data/missing#password")
+	require.ErrorContains(t, err, "404")
+
+	denied := &Vault{Address: srv.URL, Token: "wrong", Namespace: "team-a"}
+	_, err = denied.Get(ctx, "kv/vulntor/jira")
+	require.ErrorContains(t, err, "permission denied")
+
+	_, err = (&Vault{Token: "root"}).Get(ctx, "kv/vulntor/jira")
+	require.ErrorContains(t, err, "VAULT_ADDR")
+	_, err = (&Vault{Address: srv.URL}).Get(ctx, "kv/vulntor/jira")
+	require.ErrorContains(t, err, "VAULT_TOKEN")
+}
+
+func TestVaultFromEnv(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "https://vault.example.com:8200")
+	t.Setenv("VAULT_TOKEN", "s.token")
+	t.Setenv("VAULT_NAMESPACE", "")
+
+	v := VaultFromEnv()
+	require.Equal(t, "https://vault.example.com:8200", v.Address)
+	require.Equal(t, "s.token", v.Token)
+	require.Empty(t, v.Namespace)
+}
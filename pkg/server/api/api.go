@@ -3,6 +3,7 @@ package api
 import (
 	"sync/atomic"
 
+	"github.com/vulntor/vulntor/pkg/server/events"
 	"github.com/vulntor/vulntor/pkg/storage"
 )
 
@@ -21,6 +22,17 @@ type Deps struct {
 	// Type asserted in router to v1.PluginService
 	PluginService any
 
+	// ScanService launches scans as background jobs
+	// Actual type must implement v1.ScanService; nil disables POST /api/v1/scans
+	ScanService any
+
+	// Events streams live scan events (nil disables GET /api/v1/scans/{id}/events)
+	Events *events.Broker
+
+	// OIDC runs the single sign-on login flow
+	// Actual type: *auth.OIDCProvider (must implement httpx.OIDCLogin); nil disables /auth/*
+	OIDC any
+
 	// Config holds API-level configuration (timeouts, limits, etc.)
 	Config Config
 
@@ -37,15 +49,18 @@ type WorkspaceInterface interface {
 
 // ScanMetadata represents scan list item
 type ScanMetadata struct {
-	ID        string `json:"id"`
-	StartTime string `json:"start_time"`
-	Status    string `json:"status"`
-	Targets   int    `json:"targets"`
+	ID        string   `json:"id"`
+	StartTime string   `json:"start_time"`
+	Status    string   `json:"status"`
+	Targets   int      `json:"targets"`
+	Groups    []string `json:"groups,omitempty"`
 }
 
 // ScanDetail represents full scan details
 type ScanDetail struct {
 	ID        string                 `json:"id"`
+	Target    string                 `json:"target,omitempty"`
+	Groups    []string               `json:"groups,omitempty"`
 	StartTime string                 `json:"start_time"`
 	EndTime   string                 `json:"end_time,omitempty"`
 	Status    string                 `json:"status"`
@@ -44,41 +44,7 @@ type ErrorResponse struct {
 //
 // It also logs the error with structured logging for observability.
 func WriteError(w http.ResponseWriter, r *http.Request, err error) {
-	// Determine status code, error type, and error code based on error type
-	var statusCode int
-	var errorType string
-	var errorCode string
-	var message string
-
-	// First, try to map plugin errors using plugin.HTTPStatus
-	// This handles: ErrPluginNotFound, ErrInvalidInput, ErrUnavailable, ErrConflict, ErrPartialFailure, etc.
-	if isPluginError(err) {
-		statusCode = plugin.HTTPStatus(err)
-		errorCode = plugin.ErrorCode(err)
-		message = err.Error()
-		errorType = httpStatusText(statusCode)
-	} else {
-		// Check for storage errors
-		var notFoundErr *storage.NotFoundError
-		var invalidInputErr *storage.InvalidInputError
-		if errors.As(err, &notFoundErr) {
-			statusCode = http.StatusNotFound
-			errorType = "Not Found"
-			errorCode = "RESOURCE_NOT_FOUND"
-			message = notFoundErr.Error()
-		} else if errors.As(err, &invalidInputErr) {
-			statusCode = http.StatusBadRequest
-			errorType = "Bad Request"
-			errorCode = "INVALID_INPUT"
-			message = invalidInputErr.Error()
-		} else {
-			// Generic error - return 500
-			statusCode = http.StatusInternalServerError
-			errorType = "Internal Server Error"
-			errorCode = "INTERNAL_ERROR"
-			message = err.Error()
-		}
-	}
+	statusCode, errorType, errorCode, message := ErrorStatus(err)
 
 	// Log the error with context
 	logEvent := log.Error().
@@ -117,6 +83,31 @@ func WriteError(w http.ResponseWriter, r *http.Request, err error) {
 	}
 }
 
+// ErrorStatus classifies err like WriteError: it returns the HTTP status
+// code, error type, machine-readable error code and message of the response.
+// Used by APIs that answer outside of HTTP handlers, such as gRPC.
+func ErrorStatus(err error) (statusCode int, errorType, errorCode, message string) {
+	// First, try to map plugin errors using plugin.HTTPStatus
+	// This handles: ErrPluginNotFound, ErrInvalidInput, ErrUnavailable, ErrConflict, ErrPartialFailure, etc.
+	if isPluginError(err) {
+		statusCode = plugin.HTTPStatus(err)
+		return statusCode, httpStatusText(statusCode), plugin.ErrorCode(err), err.Error()
+	}
+
+	// Check for storage errors
+	var notFoundErr *storage.NotFoundError
+	var invalidInputErr *storage.InvalidInputError
+	if errors.As(err, &notFoundErr) {
+		return http.StatusNotFound, "Not Found", "RESOURCE_NOT_FOUND", notFoundErr.Error()
+	}
+	if errors.As(err, &invalidInputErr) {
+		return http.StatusBadRequest, "Bad Request", "INVALID_INPUT", invalidInputErr.Error()
+	}
+
+	// Generic error - return 500
+	return http.StatusInternalServerError, "Internal Server Error", "INTERNAL_ERROR", err.Error()
+}
+
 // isPluginError checks if the error is a plugin service error
 func isPluginError(err error) bool {
 	return errors.Is(err, plugin.ErrPluginNotFound) ||
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// ArtifactsResponse represents the response for GET /api/v1/scans/{id}/artifacts
+type ArtifactsResponse struct {
+	Artifacts []storage.Artifact `json:"artifacts"`
+}
+
+// ListArtifactsHandler handles GET /api/v1/scans/{id}/artifacts
+//
+// Returns the files attached to a scan, such as the packet captures of
+// scans run with "pcap".
+//
+// Response format:
+//
+//	{
+//	  "artifacts": [{"name": "10.0.0.5.pcap", "size": 4812, "modified_at": "2024-01-01T00:05:00Z"}]
+//	}
+//
+// Returns 404 if scan not found.
+func ListArtifactsHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		store, ok := scanArtifacts(w, r, deps, id)
+		if !ok {
+			return
+		}
+
+		artifacts, err := store.List(r.Context(), storage.OrgIDFromContext(r.Context()), id)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		if artifacts == nil {
+			artifacts = []storage.Artifact{}
+		}
+		api.WriteJSON(w, http.StatusOK, ArtifactsResponse{Artifacts: artifacts})
+	}
+}
+
+// GetArtifactHandler handles GET /api/v1/scans/{id}/artifacts/{name}
+//
+// Downloads a file attached to a scan. Packet captures are served as
+// application/vnd.tcpdump.pcap.
+//
+// Returns 404 if the scan or artifact is not found.
+func GetArtifactHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		name := r.PathValue("name")
+		store, ok := scanArtifacts(w, r, deps, id)
+		if !ok {
+			return
+		}
+
+		rc, err := store.Open(r.Context(), storage.OrgIDFromContext(r.Context()), id, name)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		defer func() { _ = rc.Close() }()
+
+		contentType := "application/octet-stream"
+		if path.Ext(name) == ".pcap" {
+			contentType = "application/vnd.tcpdump.pcap"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(name))
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, rc); err != nil {
+			log.Warn().
+				Str("component", "api").
+				Str("scan_id", id).
+				Str("artifact", name).
+				Err(err).
+				Msg("Failed to send artifact")
+		}
+	}
+}
+
+// scanArtifacts returns the artifact store of deps after checking that the
+// scan exists, or writes the error response.
+func scanArtifacts(w http.ResponseWriter, r *http.Request, deps *api.Deps, id string) (storage.ArtifactStore, bool) {
+	if id == "" {
+		api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "SCAN_ID_REQUIRED", "scan id is required")
+		return nil, false
+	}
+	if deps.Storage == nil {
+		api.WriteError(w, r, errors.New("no storage backend configured"))
+		return nil, false
+	}
+	backend, ok := deps.Storage.(storage.ArtifactBackend)
+	if !ok {
+		api.WriteError(w, r, errors.New("storage backend does not keep artifacts"))
+		return nil, false
+	}
+
+	// Ensure the scan exists (404 otherwise)
+	if _, err := deps.Storage.Scans().Get(r.Context(), storage.OrgIDFromContext(r.Context()), id); err != nil {
+		api.WriteError(w, r, err)
+		return nil, false
+	}
+	return backend.Artifacts(), true
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestArtifactHandlers(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{ID: "scan-1", Target: "10.0.0.5", Status: "completed"}))
+	require.NoError(t, backend.Artifacts().Write(ctx, "default", "scan-1", "10.0.0.5.pcap", strings.NewReader("pcap-data")))
+
+	mux := http.NewServeMux()
+	deps := &api.Deps{Storage: backend}
+	mux.HandleFunc("GET /api/v1/scans/{id}/artifacts", ListArtifactsHandler(deps))
+	mux.HandleFunc("GET /api/v1/scans/{id}/artifacts/{name}", GetArtifactHandler(deps))
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/api/v1/scans/scan-1/artifacts")
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp ArtifactsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Artifacts, 1)
+	require.Equal(t, "10.0.0.5.pcap", resp.Artifacts[0].Name)
+	require.Equal(t, int64(len("pcap-data")), resp.Artifacts[0].Size)
+
+	w = get("/api/v1/scans/scan-1/artifacts/10.0.0.5.pcap")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/vnd.tcpdump.pcap", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="10.0.0.5.pcap"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "pcap-data", w.Body.String())
+
+	require.Equal(t, http.StatusNotFound, get("/api/v1/scans/scan-1/artifacts/10.0.0.6.pcap").Code)
+	require.Equal(t, http.StatusNotFound, get("/api/v1/scans/missing/artifacts").Code)
+	require.Equal(t, http.StatusBadRequest, get("/api/v1/scans/scan-1/artifacts/.metadata.pcap").Code)
+}
@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Asset is a host in the asset inventory, as last seen by a completed scan.
+type Asset struct {
+	storage.HostRecord
+
+	// ScanID is the most recent scan that observed the host
+	ScanID string `json:"scan_id"`
+
+	// LastSeen is when that scan completed
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// AssetsResponse represents the response for GET /api/v1/assets
+type AssetsResponse struct {
+	Assets []Asset `json:"assets"`
+	Total  int     `json:"total"`
+
+	// Scans is the number of completed scans the inventory was built from
+	Scans int `json:"scans"`
+
+	// NextCursor is the cursor of the next page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SurfaceResponse represents the response for GET /api/v1/assets/surface
+type SurfaceResponse struct {
+	Assets []*storage.AssetSurface `json:"assets"`
+	Count  int                     `json:"count"`
+}
+
+// ListAssetsHandler handles GET /api/v1/assets
+//
+// Returns the asset inventory: every live host found by the most recent
+// completed scans, with the open ports of its latest observation.
+//
+// Query parameters:
+//   - scans: Number of recent completed scans to aggregate (1-100, default 20)
+//   - limit, cursor, filter, fields: see ParseListOptions
+//
+// Response format:
+//
+//	{
+//	  "assets": [{
+//	    "ip": "10.0.0.5",
+//	    "target": "10.0.0.0/24",
+//	    "ports": [{"port": 22, "protocol": "tcp", "service": "ssh", "product": "OpenSSH", "version": "8.9p1"}],
+//	    "vulnerabilities": 2,
+//	    "scan_id": "6f1c...",
+//	    "last_seen": "2024-01-01T00:05:00Z"
+//	  }],
+//	  "total": 1,
+//	  "scans": 3,
+//	  "next_cursor": "b2Zmc2V0OjUw"
+//	}
+func ListAssetsHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, qerr := ParseListAssetsQuery(r)
+		if qerr != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", qerr.Error())
+			return
+		}
+
+		if deps.Storage == nil {
+			api.WriteError(w, r, errors.New("no storage backend configured"))
+			return
+		}
+
+		ctx := r.Context()
+		scans, _, _, err := deps.Storage.Scans().ListPaginated(
+			ctx, storage.OrgIDFromContext(ctx), storage.ScanFilter{Status: string(storage.StatusCompleted)}, "", query.Scans,
+		)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+
+		// Scans are newest first; keep the first observation of each host
+		seen := map[string]bool{}
+		assets := []Asset{}
+		for _, scan := range scans {
+			hosts, err := ReadHosts(ctx, deps.Storage, scan.ID)
+			if err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+			for _, h := range hosts {
+				if seen[h.IP] {
+					continue
+				}
+				seen[h.IP] = true
+				assets = append(assets, Asset{HostRecord: h, ScanID: scan.ID, LastSeen: scan.CompletedAt})
+			}
+		}
+		sort.Slice(assets, func(i, j int) bool { return assets[i].IP < assets[j].IP })
+
+		page, err := paginate(assets, query.Offset, query.ListOptions)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		if query.Fields != nil {
+			sparse, err := sparseItems(page.Items, query.Fields)
+			if err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+			api.WriteJSON(w, http.StatusOK, map[string]interface{}{
+				"assets":      sparse,
+				"total":       page.Total,
+				"scans":       len(scans),
+				"next_cursor": page.NextCursor,
+			})
+			return
+		}
+
+		api.WriteJSON(w, http.StatusOK, AssetsResponse{Assets: page.Items, Total: page.Total, Scans: len(scans), NextCursor: page.NextCursor})
+	}
+}
+
+// ListSurfaceHandler handles GET /api/v1/assets/surface
+//
+// Returns the attack surface score history of every host and target group
+// scored by completed scans, groups first, each ordered by name.
+//
+// Query parameters:
+//   - kind: Only hosts or only groups (host or group)
+//
+// Response format:
+//
+//	{
+//	  "assets": [{
+//	    "asset": "dmz",
+//	    "kind": "group",
+//	    "history": [{"scan_id": "6f1c...", "at": "2024-01-01T00:05:00Z", "score": 46, "hosts": 1, "internet_facing": 1, "open_ports": 1, "cves": 1}]
+//	  }],
+//	  "count": 1
+//	}
+func ListSurfaceHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, qerr := ParseListSurfaceQuery(r)
+		if qerr != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", qerr.Error())
+			return
+		}
+
+		surfaceBackend, ok := deps.Storage.(storage.SurfaceBackend)
+		if !ok {
+			api.WriteError(w, r, errors.New("storage backend does not keep attack surface scores"))
+			return
+		}
+
+		ctx := r.Context()
+		surfaces, err := surfaceBackend.Surfaces().List(ctx, storage.OrgIDFromContext(ctx))
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+
+		assets := make([]*storage.AssetSurface, 0, len(surfaces))
+		for _, a := range surfaces {
+			if query.Kind == "" || a.Kind == query.Kind {
+				assets = append(assets, a)
+			}
+		}
+		api.WriteJSON(w, http.StatusOK, SurfaceResponse{Assets: assets, Count: len(assets)})
+	}
+}
+
+// ReadHosts loads the hosts recorded for a scan of the tenant in ctx (see
+// storage.ReadHosts).
+func ReadHosts(ctx context.Context, backend storage.Backend, scanID string) ([]storage.HostRecord, error) {
+	return storage.ReadHosts(ctx, backend.Scans(), storage.OrgIDFromContext(ctx), scanID)
+}
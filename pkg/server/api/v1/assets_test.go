@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestListAssetsHandler(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	addScan := func(id, status string, started time.Time, hosts string) {
+		require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+			ID: id, Target: "10.0.0.0/24", Status: status, StartedAt: started, CompletedAt: started.Add(time.Minute),
+		}))
+		if hosts != "" {
+			require.NoError(t, backend.Scans().WriteData(ctx, "default", id, storage.DataTypeHosts, strings.NewReader(hosts)))
+		}
+	}
+	now := time.Now()
+	addScan("old", "completed", now.Add(-2*time.Hour),
+		`{"ip":"10.0.0.5","ports":[{"port":22,"protocol":"tcp"}]}`+"\n"+`{"ip":"10.0.0.9"}`+"\n")
+	addScan("new", "completed", now.Add(-time.Hour),
+		`{"ip":"10.0.0.5","ports":[{"port":443,"protocol":"tcp","service":"https"}],"vulnerabilities":1}`+"\nnot-json\n")
+	addScan("running", "running", now, `{"ip":"10.0.0.7"}`+"\n")
+
+	handler := ListAssetsHandler(&api.Deps{Storage: backend})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/assets", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp AssetsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 2, resp.Scans, "only completed scans count")
+	require.Equal(t, 2, resp.Total)
+
+	// Latest observation wins
+	require.Equal(t, "10.0.0.5", resp.Assets[0].IP)
+	require.Equal(t, "new", resp.Assets[0].ScanID)
+	require.Equal(t, []storage.ServiceRecord{{Port: 443, Protocol: "tcp", Service: "https"}}, resp.Assets[0].Ports)
+	require.Equal(t, 1, resp.Assets[0].Vulnerabilities)
+	require.Equal(t, "10.0.0.9", resp.Assets[1].IP)
+	require.Equal(t, "old", resp.Assets[1].ScanID)
+
+	// Restricting to the latest scan drops hosts only seen earlier
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/assets?scans=1", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Total)
+
+	// Pages follow the cursor
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/assets?limit=1", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp = AssetsResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Assets, 1)
+	require.NotEmpty(t, resp.NextCursor)
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/assets?limit=1&cursor="+resp.NextCursor, nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	resp = AssetsResponse{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "10.0.0.9", resp.Assets[0].IP)
+	require.Empty(t, resp.NextCursor)
+
+	// Filters match nested fields; fields trims the assets
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/assets?filter=ports.port%3D%3D443&fields=ip,ports.service", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"assets":[{"ip":"10.0.0.5","ports":[{"port":443,"protocol":"tcp","service":"https"}]}],"total":1,"scans":2,"next_cursor":""}`, w.Body.String())
+}
+
+func TestListAssetsHandler_InvalidQuery(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/assets?scans=0", nil)
+	w := httptest.NewRecorder()
+	ListAssetsHandler(&api.Deps{}).ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "between 1 and 100")
+}
+
+func TestListSurfaceHandler(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	point := storage.SurfacePoint{ScanID: "s1", At: time.Now(), Score: 46, Hosts: 1, InternetFacing: 1, OpenPorts: 1, CVEs: 1}
+	require.NoError(t, backend.Surfaces().Record(ctx, storage.DefaultOrgID, []*storage.AssetSurface{
+		{Asset: "10.0.1.5", Kind: storage.SurfaceHost, History: []storage.SurfacePoint{point}},
+		{Asset: "dmz", Kind: storage.SurfaceGroup, History: []storage.SurfacePoint{point}},
+	}))
+
+	handler := ListSurfaceHandler(&api.Deps{Storage: backend})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/assets/surface", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var resp SurfaceResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 2, resp.Count)
+	require.Equal(t, "dmz", resp.Assets[0].Asset)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/assets/surface?kind=host", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, resp.Count)
+	require.Equal(t, 46, resp.Assets[0].Latest().Score)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/assets/surface?kind=site", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "must be host or group")
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// AuditResponse represents the response for GET /api/v1/audit
+type AuditResponse struct {
+	Events []*storage.AuditEvent `json:"events"`
+	Count  int                   `json:"count"`
+}
+
+// ListAuditHandler handles GET /api/v1/audit
+//
+// Returns audit log events for the caller's tenant, newest first.
+//
+// Query parameters:
+//   - actor: Only events by this actor (user ID, "apikey:<id>" or OS user)
+//   - action: Exact action ("plugin.install") or resource type ("plugin")
+//   - since: Only events at or after this RFC 3339 timestamp
+//   - until: Only events at or before this RFC 3339 timestamp
+//   - limit: Maximum number of events (1-500, default 50)
+//
+// Response format:
+//
+//	{
+//	  "events": [{
+//	    "id": "b2f0...",
+//	    "timestamp": "2024-01-01T00:05:00Z",
+//	    "actor": "alice",
+//	    "source": "api",
+//	    "action": "scan.create",
+//	    "resource": "6f1c...",
+//	    "outcome": "success",
+//	    "details": {"targets": "10.0.0.0/24"}
+//	  }],
+//	  "count": 1
+//	}
+func ListAuditHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, qerr := ParseListAuditQuery(r)
+		if qerr != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", qerr.Error())
+			return
+		}
+
+		auditBackend, ok := deps.Storage.(storage.AuditBackend)
+		if !ok {
+			api.WriteError(w, r, errors.New("storage backend does not keep an audit log"))
+			return
+		}
+
+		ctx := r.Context()
+		events, err := auditBackend.Audit().List(ctx, storage.OrgIDFromContext(ctx), query.Filter)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+
+		api.WriteJSON(w, http.StatusOK, AuditResponse{Events: events, Count: len(events)})
+	}
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestListAuditHandler(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	for _, e := range []*storage.AuditEvent{
+		{Actor: "alice", Source: "cli", Action: "plugin.install", Resource: "ssh", Outcome: storage.AuditOutcomeSuccess},
+		{Actor: "bob", Source: "api", Action: "scan.create", Resource: "scan-1", Outcome: storage.AuditOutcomeSuccess},
+		{Actor: "alice", Source: "api", Action: "plugin.uninstall", Resource: "ssh", Outcome: storage.AuditOutcomeFailure},
+	} {
+		require.NoError(t, backend.Audit().Append(ctx, storage.DefaultOrgID, e))
+	}
+	require.NoError(t, backend.Audit().Append(ctx, "acme", &storage.AuditEvent{Actor: "carol", Action: "scan.create"}))
+
+	handler := ListAuditHandler(&api.Deps{Storage: backend})
+	list := func(ctx context.Context, query string) (int, AuditResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/audit"+query, nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		var resp AuditResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w.Code, resp
+	}
+
+	code, resp := list(ctx, "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 3, resp.Count)
+	require.Equal(t, "plugin.uninstall", resp.Events[0].Action)
+
+	_, resp = list(ctx, "?actor=alice&action=plugin&limit=1")
+	require.Equal(t, 1, resp.Count)
+	require.Equal(t, "plugin.uninstall", resp.Events[0].Action)
+
+	// Tenants only see their own events
+	_, resp = list(storage.WithOrgID(ctx, "acme"), "")
+	require.Equal(t, 1, resp.Count)
+	require.Equal(t, "carol", resp.Events[0].Actor)
+
+	code, _ = list(ctx, "?since=yesterday")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestListAuditHandler_NoStorage(t *testing.T) {
+	handler := ListAuditHandler(&api.Deps{})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,130 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// sseHeartbeatInterval keeps idle connections alive through proxies.
+var sseHeartbeatInterval = 15 * time.Second
+
+// StreamScanEventsHandler handles GET /api/v1/scans/{id}/events
+//
+// Streams live scan events using Server-Sent Events (text/event-stream).
+// Events already published for the scan are replayed first, so clients that
+// connect mid-scan receive the full picture. The stream ends after the
+// scan.finished event.
+//
+// Event types:
+//   - host.discovered: live hosts found by discovery
+//   - port.open: open ports found on a target
+//   - finding.created: a vulnerability matched by a plugin
+//   - scan.finished: terminal status ({"status": "completed"|"failed", "error": "..."})
+//
+// Wire format:
+//
+//	id: 3
+//	event: port.open
+//	data: {"type":"port.open","scan_id":"...","timestamp":"...","data":{"target":"10.0.0.5","open_ports":[22,80]}}
+//
+// Returns 404 if scan not found.
+func StreamScanEventsHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if id == "" {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "SCAN_ID_REQUIRED", "scan id is required")
+			return
+		}
+
+		// Resolve scan (404 for unknown scans)
+		var metadata *storage.ScanMetadata
+		if deps.Storage != nil {
+			m, err := deps.Storage.Scans().Get(r.Context(), storage.OrgIDFromContext(r.Context()), id)
+			if err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+			metadata = m
+		}
+
+		ch, cancel := deps.Events.Subscribe(id)
+		defer cancel()
+
+		// Streams outlive the server's WriteTimeout; lift the deadline for this response
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		_ = rc.Flush()
+
+		seq := 0
+		send := func(ev events.Event) bool {
+			seq++
+			if err := writeSSE(w, seq, ev); err != nil {
+				return false
+			}
+			return rc.Flush() == nil
+		}
+
+		// Scan finished before this process saw it (e.g., server restart): no
+		// history to replay, so synthesize the terminal event from storage.
+		if metadata != nil && storage.ScanStatus(metadata.Status).IsTerminal() && len(ch) == 0 {
+			data := map[string]string{"status": metadata.Status}
+			if metadata.ErrorMessage != "" {
+				data["error"] = metadata.ErrorMessage
+			}
+			send(events.Event{Type: events.TypeScanFinished, ScanID: id, Timestamp: metadata.CompletedAt.UTC(), Data: data})
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case ev, ok := <-ch:
+				if !ok {
+					return
+				}
+				if !send(ev) {
+					log.Debug().
+						Str("component", "api.events").
+						Str("scan_id", id).
+						Msg("client disconnected")
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				if rc.Flush() != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// writeSSE writes one event in text/event-stream framing.
+func writeSSE(w http.ResponseWriter, seq int, ev events.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", seq, ev.Type, payload)
+	return err
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// readSSE parses text/event-stream frames into events.
+func readSSE(t *testing.T, body string) []events.Event {
+	t.Helper()
+	var out []events.Event
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		var ev events.Event
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev))
+		out = append(out, ev)
+	}
+	return out
+}
+
+func newEventsBackend(t *testing.T, status, errMsg string) storage.Backend {
+	t.Helper()
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.Initialize(ctx))
+	t.Cleanup(func() { _ = backend.Close() })
+
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+		ID:           "scan-1",
+		Target:       "10.0.0.1",
+		Status:       status,
+		ErrorMessage: errMsg,
+		StartedAt:    time.Now(),
+		CompletedAt:  time.Now(),
+	}))
+	return backend
+}
+
+func TestStreamScanEventsHandler_StreamsUntilFinished(t *testing.T) {
+	broker := events.NewBroker()
+	deps := &api.Deps{Storage: newEventsBackend(t, "running", ""), Events: broker}
+
+	// Event published before the client connects is replayed
+	broker.Publish(events.Event{Type: events.TypeHostDiscovered, ScanID: "scan-1", Data: []string{"10.0.0.1"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/events", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		StreamScanEventsHandler(deps).ServeHTTP(w, req)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	broker.Publish(events.Event{Type: events.TypePortOpen, ScanID: "scan-1"})
+	broker.Publish(events.Event{Type: events.TypeScanFinished, ScanID: "scan-1", Data: map[string]string{"status": "completed"}})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("stream did not end after scan.finished")
+	}
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	require.Contains(t, body, "event: host.discovered\n")
+	require.Contains(t, body, "id: 1\n")
+
+	evs := readSSE(t, body)
+	require.Len(t, evs, 3)
+	require.Equal(t, events.TypeHostDiscovered, evs[0].Type)
+	require.Equal(t, events.TypePortOpen, evs[1].Type)
+	require.Equal(t, events.TypeScanFinished, evs[2].Type)
+}
+
+func TestStreamScanEventsHandler_FinishedScanWithoutHistory(t *testing.T) {
+	deps := &api.Deps{Storage: newEventsBackend(t, "failed", "boom"), Events: events.NewBroker()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/events", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	StreamScanEventsHandler(deps).ServeHTTP(w, req)
+
+	evs := readSSE(t, w.Body.String())
+	require.Len(t, evs, 1)
+	require.Equal(t, events.TypeScanFinished, evs[0].Type)
+	data := evs[0].Data.(map[string]interface{})
+	require.Equal(t, "failed", data["status"])
+	require.Equal(t, "boom", data["error"])
+}
+
+func TestStreamScanEventsHandler_ClientDisconnect(t *testing.T) {
+	deps := &api.Deps{Storage: newEventsBackend(t, "running", ""), Events: events.NewBroker()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/events", nil).WithContext(ctx)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		StreamScanEventsHandler(deps).ServeHTTP(w, req)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handler did not return on client disconnect")
+	}
+}
+
+func TestStreamScanEventsHandler_Heartbeat(t *testing.T) {
+	orig := sseHeartbeatInterval
+	sseHeartbeatInterval = 5 * time.Millisecond
+	t.Cleanup(func() { sseHeartbeatInterval = orig })
+
+	broker := events.NewBroker()
+	deps := &api.Deps{Storage: newEventsBackend(t, "running", ""), Events: broker}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/events", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+
+	done := make(chan struct{})
+	go func() {
+		StreamScanEventsHandler(deps).ServeHTTP(w, req)
+		close(done)
+	}()
+	time.Sleep(30 * time.Millisecond)
+	broker.Publish(events.Event{Type: events.TypeScanFinished, ScanID: "scan-1"})
+	<-done
+
+	require.Contains(t, w.Body.String(), ": keep-alive\n\n")
+}
+
+func TestStreamScanEventsHandler_NotFound(t *testing.T) {
+	deps := &api.Deps{Storage: newEventsBackend(t, "running", ""), Events: events.NewBroker()}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/missing/events", nil)
+	req.SetPathValue("id", "missing")
+	w := httptest.NewRecorder()
+	StreamScanEventsHandler(deps).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,220 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// FindingStatusesResponse represents the response for GET /api/v1/findings
+type FindingStatusesResponse struct {
+	Findings []*storage.FindingStatus `json:"findings"`
+	Count    int                      `json:"count"`
+}
+
+// SetFindingStateRequest represents the request body for
+// PUT /api/v1/findings/{id}/state
+type SetFindingStateRequest struct {
+	// State is the new triage state
+	State string `json:"state"`
+
+	// Assignee is the new owner of the finding; omitted keeps the current
+	// one and "" unassigns it
+	Assignee *string `json:"assignee,omitempty"`
+
+	// Note explains the change
+	Note string `json:"note,omitempty"`
+
+	// ScanID is the scan that reported a finding never triaged or
+	// rechecked; omitted searches the recent scans
+	ScanID string `json:"scan_id,omitempty"`
+}
+
+// ListFindingStatusesHandler handles GET /api/v1/findings
+//
+// Returns the findings that were triaged, rechecked or tracked by scans run
+// with remediation SLAs, ordered by ID.
+//
+// Query parameters:
+//   - state: Only findings in this triage state
+//   - assignee: Only findings assigned to this user
+//   - overdue: Only findings past their remediation due date and not
+//     closed (true or false)
+//
+// Response format:
+//
+//	{
+//	  "findings": [{
+//	    "id": "14d34128101e76ec",
+//	    "target": "10.0.0.5",
+//	    "port": 22,
+//	    "plugin": "ssh-weak-cipher",
+//	    "state": "in-progress",
+//	    "assignee": "alice",
+//	    "transitions": [{"from": "open", "to": "in-progress", "assignee": "alice", "actor": "bob", "at": "2024-01-01T00:05:00Z"}]
+//	  }],
+//	  "count": 1
+//	}
+func ListFindingStatusesHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query, qerr := ParseListFindingStatusesQuery(r)
+		if qerr != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", qerr.Error())
+			return
+		}
+
+		statusBackend, ok := deps.Storage.(storage.FindingStatusBackend)
+		if !ok {
+			api.WriteError(w, r, errors.New("storage backend does not keep finding statuses"))
+			return
+		}
+
+		ctx := r.Context()
+		statuses, err := statusBackend.FindingStatuses().List(ctx, storage.OrgIDFromContext(ctx))
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+
+		now := time.Now()
+		findings := make([]*storage.FindingStatus, 0, len(statuses))
+		for _, s := range statuses {
+			if (query.State == "" || s.State == query.State) && (query.Assignee == "" || s.Assignee == query.Assignee) && (!query.Overdue || s.Overdue(now)) {
+				findings = append(findings, s)
+			}
+		}
+		api.WriteJSON(w, http.StatusOK, FindingStatusesResponse{Findings: findings, Count: len(findings)})
+	}
+}
+
+// GetFindingStatusHandler handles GET /api/v1/findings/{id}
+//
+// Returns the triage state, recheck history and transitions of a finding.
+// Findings never triaged or rechecked are looked up in the scan given by
+// the scan query parameter, or in the recent scans, and are open.
+//
+// Returns 404 if no scan searched reported the finding.
+func GetFindingStatusHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statusBackend, ok := deps.Storage.(storage.FindingStatusBackend)
+		if !ok {
+			api.WriteError(w, r, errors.New("storage backend does not keep finding statuses"))
+			return
+		}
+
+		ctx := r.Context()
+		orgID := storage.OrgIDFromContext(ctx)
+		id := r.PathValue("id")
+		status, err := statusBackend.FindingStatuses().Get(ctx, orgID, id)
+		if storage.IsNotFound(err) {
+			status, err = scanexec.FindFinding(ctx, deps.Storage, orgID, id, strings.TrimSpace(r.URL.Query().Get("scan")))
+			if err == nil {
+				status.State = storage.FindingStateOpen
+			}
+		}
+		if err != nil {
+			writeFindingError(w, r, err)
+			return
+		}
+
+		api.WriteJSON(w, http.StatusOK, status)
+	}
+}
+
+// SetFindingStateHandler handles PUT /api/v1/findings/{id}/state
+//
+// Moves a finding to a triage state, optionally changing its assignee, and
+// records the transition with the caller as actor.
+//
+// Request body:
+//
+//	{
+//	  "state": "in-progress",
+//	  "assignee": "alice",        // Optional; "" unassigns
+//	  "note": "Patch scheduled"   // Optional
+//	}
+//
+// Returns the finding's status, 400 for unknown states and 404 if no scan
+// searched reported the finding.
+func SetFindingStateHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Defense-in-depth: Limit request body size (2MB)
+		r.Body = http.MaxBytesReader(w, r.Body, plugin.MaxRequestBodySize)
+
+		var req SetFindingStateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_REQUEST_BODY", "invalid request body: "+err.Error())
+			return
+		}
+		if err := ParseSetFindingState(req); err != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_INPUT", err.Error())
+			return
+		}
+
+		ctx := r.Context()
+		status, err := scanexec.SetFindingState(ctx, deps.Storage, storage.OrgIDFromContext(ctx), r.PathValue("id"), req.ScanID, scanexec.FindingStateChange{
+			State:    req.State,
+			Assignee: req.Assignee,
+			Actor:    audit.Actor(ctx, ""),
+			Note:     req.Note,
+		})
+		if err != nil {
+			writeFindingError(w, r, err)
+			return
+		}
+
+		api.WriteJSON(w, http.StatusOK, status)
+	}
+}
+
+// keepOverdue keeps the findings past their due date (see
+// report.Finding.Overdue), taking their triage from the finding statuses of
+// backend, when it keeps them.
+func keepOverdue(ctx context.Context, backend storage.Backend, findings []map[string]interface{}) ([]map[string]interface{}, error) {
+	statuses := make(map[string]*storage.FindingStatus)
+	if statusBackend, ok := backend.(storage.FindingStatusBackend); ok {
+		list, err := statusBackend.FindingStatuses().List(ctx, storage.OrgIDFromContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range list {
+			statuses[status.ID] = status
+		}
+	}
+
+	now := time.Now()
+	out := make([]map[string]interface{}, 0, len(findings))
+	for _, raw := range findings {
+		data, err := json.Marshal(raw)
+		if err != nil {
+			continue
+		}
+		var f report.Finding
+		if err := json.Unmarshal(data, &f); err != nil {
+			continue
+		}
+		if f.Overdue(statuses[f.ID()], now) {
+			out = append(out, raw)
+		}
+	}
+	return out, nil
+}
+
+// writeFindingError writes err, answering unknown findings with 404.
+func writeFindingError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, scanexec.ErrFindingNotFound) {
+		api.WriteJSONError(w, http.StatusNotFound, "Not Found", "UNKNOWN_FINDING", err.Error())
+		return
+	}
+	api.WriteError(w, r, err)
+}
@@ -0,0 +1,95 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
+)
+
+func TestFindingHandlers(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{ID: "s1", Target: "10.0.1.0/24", Status: "completed", StartedAt: time.Now()}))
+	require.NoError(t, backend.Scans().WriteData(ctx, storage.DefaultOrgID, "s1", storage.DataTypeVulnerabilities, strings.NewReader(
+		`{"target":"10.0.1.5","port":22,"plugin":"ssh-weak-cipher","severity":"high"}`+"\n")))
+	id := ticketing.Fingerprint(ticketing.Finding{Plugin: "ssh-weak-cipher", Target: "10.0.1.5", Port: 22})
+
+	mux := http.NewServeMux()
+	deps := &api.Deps{Storage: backend}
+	mux.HandleFunc("GET /api/v1/findings", ListFindingStatusesHandler(deps))
+	mux.HandleFunc("GET /api/v1/findings/{id}", GetFindingStatusHandler(deps))
+	mux.HandleFunc("PUT /api/v1/findings/{id}/state", SetFindingStateHandler(deps))
+	serve := func(method, path, body string) (int, []byte) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{UserID: "bob"}))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w.Code, w.Body.Bytes()
+	}
+
+	// Untriaged findings are open
+	code, body := serve(http.MethodGet, "/api/v1/findings/"+id, "")
+	require.Equal(t, http.StatusOK, code, string(body))
+	var status storage.FindingStatus
+	require.NoError(t, json.Unmarshal(body, &status))
+	require.Equal(t, storage.FindingStateOpen, status.State)
+	require.Equal(t, "s1", status.ScanID)
+
+	code, body = serve(http.MethodPut, "/api/v1/findings/"+id+"/state", `{"state": "in-progress", "assignee": "alice", "note": "Patch scheduled"}`)
+	require.Equal(t, http.StatusOK, code, string(body))
+	require.NoError(t, json.Unmarshal(body, &status))
+	require.Equal(t, storage.FindingStateInProgress, status.State)
+	require.Equal(t, "alice", status.Assignee)
+	require.Equal(t, "bob", status.Transitions[0].Actor)
+
+	code, body = serve(http.MethodGet, "/api/v1/findings?state=in-progress&assignee=alice", "")
+	require.Equal(t, http.StatusOK, code)
+	var list FindingStatusesResponse
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Equal(t, 1, list.Count)
+	require.Equal(t, id, list.Findings[0].ID)
+
+	_, body = serve(http.MethodGet, "/api/v1/findings?state=fixed", "")
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Zero(t, list.Count)
+
+	// Findings past their due date until closed
+	past := time.Now().Add(-time.Hour)
+	tracked := &storage.FindingStatus{ID: "0a1b2c3d4e5f6a7b", Target: "10.0.1.6", Plugin: "telnet", DueAt: &past}
+	require.NoError(t, backend.FindingStatuses().Track(ctx, storage.DefaultOrgID, []*storage.FindingStatus{tracked}))
+	_, body = serve(http.MethodGet, "/api/v1/findings?overdue=true", "")
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Equal(t, 1, list.Count)
+	require.Equal(t, tracked.ID, list.Findings[0].ID)
+	code, _ = serve(http.MethodPut, "/api/v1/findings/"+tracked.ID+"/state", `{"state": "accepted-risk"}`)
+	require.Equal(t, http.StatusOK, code)
+	_, body = serve(http.MethodGet, "/api/v1/findings?overdue=true", "")
+	require.NoError(t, json.Unmarshal(body, &list))
+	require.Zero(t, list.Count)
+
+	code, _ = serve(http.MethodGet, "/api/v1/findings?overdue=1x", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = serve(http.MethodGet, "/api/v1/findings?state=closed", "")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = serve(http.MethodPut, "/api/v1/findings/"+id+"/state", `{"state": "closed"}`)
+	require.Equal(t, http.StatusBadRequest, code)
+	code, body = serve(http.MethodPut, "/api/v1/findings/0000000000000000/state", `{"state": "fixed"}`)
+	require.Equal(t, http.StatusNotFound, code)
+	require.Contains(t, string(body), "UNKNOWN_FINDING")
+	code, _ = serve(http.MethodGet, "/api/v1/findings/0000000000000000", "")
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,424 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// List conventions
+// The collection endpoints (scans, findings, assets) share these query
+// parameters, parsed by ParseListOptions:
+//
+//   - limit: Number of results per page (1-100, default 50)
+//   - cursor: Opaque cursor from the next_cursor of the previous page
+//   - filter: Conditions the results must all meet, separated by ";"
+//     (e.g. severity==high,critical;port>=1024)
+//   - fields: Comma-separated fields to return (e.g. id,status)
+//
+// A cursor is only valid for the query that returned it.
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 100
+
+	maxFilterConditions = 20
+	maxSelectedFields   = 50
+)
+
+// ListOptions holds the pagination, filtering and field selection options
+// of a collection endpoint.
+type ListOptions struct {
+	Limit  int
+	Cursor string   // Opaque cursor for pagination (empty for first page)
+	Filter Filter   // nil matches all results
+	Fields []string // nil returns all fields
+}
+
+// ParseListOptions parses and validates the list conventions in q.
+// Returns validated options with sane defaults (Limit=50) when omitted.
+func ParseListOptions(q url.Values) (ListOptions, error) {
+	res := ListOptions{Limit: defaultListLimit}
+
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return res, &ValidationError{Field: "limit", Reason: "must be an integer"}
+		}
+		if n < 1 || n > maxListLimit {
+			return res, &ValidationError{Field: "limit", Reason: "must be between 1 and " + strconv.Itoa(maxListLimit)}
+		}
+		res.Limit = n
+	}
+
+	res.Cursor = strings.TrimSpace(q.Get("cursor"))
+
+	if v := strings.TrimSpace(q.Get("filter")); v != "" {
+		f, err := ParseFilter(v)
+		if err != nil {
+			return res, &ValidationError{Field: "filter", Reason: err.Error()}
+		}
+		res.Filter = f
+	}
+
+	if v := strings.TrimSpace(q.Get("fields")); v != "" {
+		fields, err := parseFields(v)
+		if err != nil {
+			return res, &ValidationError{Field: "fields", Reason: err.Error()}
+		}
+		res.Fields = fields
+	}
+
+	return res, nil
+}
+
+// filterOps lists the filter operators, two-character operators first so
+// that ">=" is not read as ">".
+var filterOps = []string{"==", "!=", ">=", "<=", ">", "<", "~"}
+
+var fieldPathRe = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+// Condition is one condition of a filter expression: Field Op Values.
+type Condition struct {
+	// Field is the JSON field, with "." separating nested fields. Fields
+	// of arrays match if any element matches.
+	Field string
+
+	// Op is one of ==, !=, >, >=, <, <= and ~ (contains).
+	Op string
+
+	// Values holds the alternatives of == and != (separated by ","), or
+	// the single operand of the other operators.
+	Values []string
+}
+
+// Filter is a parsed filter expression: conditions that must all be met.
+type Filter []Condition
+
+// ParseFilter parses a filter expression such as
+// "severity==high,critical;port>=1024".
+//
+// == and != compare case-insensitively and accept alternatives separated by
+// ","; ~ matches values containing the operand, case-insensitively. The
+// ordering operators compare numbers numerically and other values as
+// strings, which orders RFC 3339 timestamps.
+func ParseFilter(expr string) (Filter, error) {
+	parts := strings.Split(expr, ";")
+	if len(parts) > maxFilterConditions {
+		return nil, fmt.Errorf("too many conditions (max %d)", maxFilterConditions)
+	}
+
+	f := make(Filter, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		i := strings.IndexAny(part, "=!<>~")
+		if i <= 0 {
+			return nil, fmt.Errorf("%q: expected <field><operator><value>", part)
+		}
+		field := strings.TrimSpace(part[:i])
+		if !fieldPathRe.MatchString(field) {
+			return nil, fmt.Errorf("%q: invalid field %q", part, field)
+		}
+		var op string
+		for _, candidate := range filterOps {
+			if strings.HasPrefix(part[i:], candidate) {
+				op = candidate
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("%q: unknown operator (use ==, !=, >, >=, <, <= or ~)", part)
+		}
+		value := strings.TrimSpace(part[i+len(op):])
+		if value == "" {
+			return nil, fmt.Errorf("%q: missing value", part)
+		}
+
+		values := []string{value}
+		if op == "==" || op == "!=" {
+			values = strings.Split(value, ",")
+			for j := range values {
+				values[j] = strings.TrimSpace(values[j])
+			}
+		}
+		f = append(f, Condition{Field: field, Op: op, Values: values})
+	}
+	if len(f) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	return f, nil
+}
+
+// Match reports whether item, a decoded JSON object, meets every condition
+// of f. A nil filter matches every item.
+func (f Filter) Match(item map[string]interface{}) bool {
+	for _, c := range f {
+		if !c.match(lookupField(item, strings.Split(c.Field, "."))) {
+			return false
+		}
+	}
+	return true
+}
+
+// match reports whether the values of the condition's field meet it.
+// Missing fields only meet !=.
+func (c Condition) match(values []interface{}) bool {
+	if c.Op == "!=" {
+		for _, v := range values {
+			if equalsAny(v, c.Values) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, v := range values {
+		switch c.Op {
+		case "==":
+			if equalsAny(v, c.Values) {
+				return true
+			}
+		case "~":
+			if strings.Contains(strings.ToLower(scalarString(v)), strings.ToLower(c.Values[0])) {
+				return true
+			}
+		default:
+			cmp := compareValues(v, c.Values[0])
+			if (c.Op == ">" && cmp > 0) || (c.Op == ">=" && cmp >= 0) ||
+				(c.Op == "<" && cmp < 0) || (c.Op == "<=" && cmp <= 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupField returns the scalar values at path in v. Arrays on the way
+// contribute each of their elements.
+func lookupField(v interface{}, path []string) []interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		var out []interface{}
+		for _, e := range t {
+			out = append(out, lookupField(e, path)...)
+		}
+		return out
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return nil
+		}
+		next, ok := t[path[0]]
+		if !ok {
+			return nil
+		}
+		return lookupField(next, path[1:])
+	case nil:
+		return nil
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []interface{}{t}
+	}
+}
+
+// equalsAny reports whether v equals one of values: numerically for
+// numbers, case-insensitively otherwise.
+func equalsAny(v interface{}, values []string) bool {
+	for _, want := range values {
+		if n, ok := v.(float64); ok {
+			if w, err := strconv.ParseFloat(want, 64); err == nil && n == w {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(scalarString(v), want) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues compares v with operand: numerically when both are
+// numbers, as strings otherwise.
+func compareValues(v interface{}, operand string) int {
+	if n, ok := v.(float64); ok {
+		if w, err := strconv.ParseFloat(operand, 64); err == nil {
+			switch {
+			case n < w:
+				return -1
+			case n > w:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return strings.Compare(scalarString(v), operand)
+}
+
+// scalarString formats a decoded JSON scalar.
+func scalarString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// parseFields parses a comma-separated list of field paths.
+func parseFields(v string) ([]string, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) > maxSelectedFields {
+		return nil, fmt.Errorf("too many fields (max %d)", maxSelectedFields)
+	}
+	fields := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !fieldPathRe.MatchString(p) {
+			return nil, fmt.Errorf("invalid field %q", p)
+		}
+		fields = append(fields, p)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given")
+	}
+	return fields, nil
+}
+
+// selectFields returns the given fields of item, keeping nested fields
+// nested. Fields below an array keep the whole array.
+func selectFields(item map[string]interface{}, fields []string) map[string]interface{} {
+	out := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		src, dst := item, out
+		path := strings.Split(field, ".")
+		for i, key := range path {
+			v, ok := src[key]
+			if !ok {
+				break
+			}
+			nested, isObject := v.(map[string]interface{})
+			if i == len(path)-1 || !isObject {
+				dst[key] = v
+				break
+			}
+			sub, ok := dst[key].(map[string]interface{})
+			if !ok {
+				sub = map[string]interface{}{}
+				dst[key] = sub
+			}
+			src, dst = nested, sub
+		}
+	}
+	return out
+}
+
+// toObject converts an item to its JSON object form.
+func toObject(item interface{}) (map[string]interface{}, error) {
+	if m, ok := item.(map[string]interface{}); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// pageCursorPrefix marks the cursors of in-memory pages.
+const pageCursorPrefix = "offset:"
+
+// encodePageCursor returns the cursor of the page starting at offset.
+func encodePageCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(pageCursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodePageCursor returns the offset of a cursor from encodePageCursor;
+// 0 for the empty cursor of the first page.
+func decodePageCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	invalid := &ValidationError{Field: "cursor", Reason: "invalid or not valid for this query"}
+	data, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, invalid
+	}
+	rest, ok := strings.CutPrefix(string(data), pageCursorPrefix)
+	if !ok {
+		return 0, invalid
+	}
+	offset, err := strconv.Atoi(rest)
+	if err != nil || offset < 0 {
+		return 0, invalid
+	}
+	return offset, nil
+}
+
+// listPage is one page of a filtered collection.
+type listPage[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      int // number of items matching the filter
+}
+
+// paginate keeps the items matching the filter of opts and returns the
+// page of them starting at offset.
+func paginate[T any](items []T, offset int, opts ListOptions) (*listPage[T], error) {
+	matched := items
+	if opts.Filter != nil {
+		matched = make([]T, 0, len(items))
+		for _, item := range items {
+			obj, err := toObject(item)
+			if err != nil {
+				return nil, err
+			}
+			if opts.Filter.Match(obj) {
+				matched = append(matched, item)
+			}
+		}
+	}
+
+	page := &listPage[T]{Items: []T{}, Total: len(matched)}
+	if offset < len(matched) {
+		end := offset + opts.Limit
+		if end < len(matched) {
+			page.NextCursor = encodePageCursor(end)
+		} else {
+			end = len(matched)
+		}
+		page.Items = matched[offset:end]
+	}
+	return page, nil
+}
+
+// sparseItems returns the given fields of each item.
+func sparseItems[T any](items []T, fields []string) ([]map[string]interface{}, error) {
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		obj, err := toObject(item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, selectFields(obj, fields))
+	}
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListOptions(t *testing.T) {
+	opts, err := ParseListOptions(url.Values{})
+	require.NoError(t, err)
+	require.Equal(t, ListOptions{Limit: 50}, opts)
+
+	opts, err = ParseListOptions(url.Values{
+		"limit":  {"10"},
+		"cursor": {"abc"},
+		"filter": {"severity==high,critical;port>=1024"},
+		"fields": {"id, status,"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 10, opts.Limit)
+	require.Equal(t, "abc", opts.Cursor)
+	require.Equal(t, Filter{
+		{Field: "severity", Op: "==", Values: []string{"high", "critical"}},
+		{Field: "port", Op: ">=", Values: []string{"1024"}},
+	}, opts.Filter)
+	require.Equal(t, []string{"id", "status"}, opts.Fields)
+
+	for _, q := range []url.Values{
+		{"limit": {"0"}},
+		{"limit": {"101"}},
+		{"filter": {"severity"}},
+		{"filter": {"severity=high"}},
+		{"filter": {"sev rity==high"}},
+		{"filter": {"severity=="}},
+		{"filter": {";"}},
+		{"fields": {"id,../x"}},
+		{"fields": {","}},
+	} {
+		_, err := ParseListOptions(q)
+		require.Error(t, err, "query=%v", q)
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	item := map[string]interface{}{
+		"severity": "High",
+		"port":     float64(8443),
+		"target":   "10.0.0.5",
+		"tags":     []interface{}{"ssh", "tls"},
+		"service":  map[string]interface{}{"name": "https"},
+		"hosts":    []interface{}{map[string]interface{}{"ip": "10.0.0.5"}},
+	}
+
+	for expr, want := range map[string]bool{
+		"severity==high,critical":     true,
+		"severity==low":               false,
+		"severity!=low,info":          true,
+		"severity!=high":              false,
+		"port>=1024":                  true,
+		"port<1024":                   false,
+		"port==8443":                  true,
+		"target~0.0.5":                true,
+		"tags==tls":                   true,
+		"tags!=tls":                   false,
+		"service.name==https":         true,
+		"hosts.ip==10.0.0.5":          true,
+		"missing==x":                  false,
+		"missing!=x":                  true,
+		"severity==high;port>9000":    false,
+		"severity==high;port<=8443":   true,
+		"started_at>2024-01-01T00:00": false,
+	} {
+		f, err := ParseFilter(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, f.Match(item), expr)
+	}
+
+	var none Filter
+	require.True(t, none.Match(item))
+}
+
+func TestSelectFields(t *testing.T) {
+	item := map[string]interface{}{
+		"id":      "s1",
+		"status":  "completed",
+		"service": map[string]interface{}{"name": "https", "port": float64(443)},
+		"ports":   []interface{}{map[string]interface{}{"port": float64(22)}},
+	}
+
+	require.Equal(t, map[string]interface{}{
+		"id":      "s1",
+		"service": map[string]interface{}{"name": "https"},
+		"ports":   []interface{}{map[string]interface{}{"port": float64(22)}},
+	}, selectFields(item, []string{"id", "service.name", "ports.port", "missing"}))
+}
+
+func TestPaginate(t *testing.T) {
+	type row struct {
+		N int `json:"n"`
+	}
+	rows := []row{{1}, {2}, {3}, {4}, {5}}
+	filter, err := ParseFilter("n>1")
+	require.NoError(t, err)
+	opts := ListOptions{Limit: 2, Filter: filter}
+
+	page, err := paginate(rows, 0, opts)
+	require.NoError(t, err)
+	require.Equal(t, []row{{2}, {3}}, page.Items)
+	require.Equal(t, 4, page.Total)
+	require.NotEmpty(t, page.NextCursor)
+
+	offset, err := decodePageCursor(page.NextCursor)
+	require.NoError(t, err)
+	page, err = paginate(rows, offset, opts)
+	require.NoError(t, err)
+	require.Equal(t, []row{{4}, {5}}, page.Items)
+	require.Empty(t, page.NextCursor)
+
+	page, err = paginate(rows, 10, opts)
+	require.NoError(t, err)
+	require.Empty(t, page.Items)
+
+	for _, cursor := range []string{"!!", "eyJpZCI6InMxIn0=", encodePageCursor(-1)} {
+		_, err := decodePageCursor(cursor)
+		require.Error(t, err, cursor)
+	}
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// MeResponse describes the caller's identity and effective permissions.
+// The UI uses it to hide actions the caller is not allowed to perform.
+type MeResponse struct {
+	// Authenticated is true when the request was authenticated with an API key.
+	// In auth modes "none" and "token" it is false and access is unrestricted.
+	Authenticated bool `json:"authenticated"`
+
+	// Tenant the request was served for (X-Tenant-ID header, "default" without it)
+	Tenant string `json:"tenant"`
+
+	KeyID  string `json:"key_id,omitempty"`
+	UserID string `json:"user_id,omitempty"`
+	Role   string `json:"role,omitempty"`
+
+	// Scopes granted to the caller (read, scan:create, finding:manage, plugin:manage, admin)
+	Scopes []string `json:"scopes"`
+}
+
+// MeHandler handles GET /api/v1/me
+//
+// Returns the identity attached by the auth middleware.
+//
+// Response format:
+//
+//	{
+//	  "authenticated": true,
+//	  "tenant": "default",
+//	  "key_id": "3f9a1c2b7d4e",
+//	  "user_id": "alice",
+//	  "role": "operator",
+//	  "scopes": ["read", "scan:create"]
+//	}
+func MeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tenant := storage.OrgIDFromContext(r.Context())
+		p, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			api.WriteJSON(w, http.StatusOK, MeResponse{Tenant: tenant, Scopes: []string{string(auth.ScopeAdmin)}})
+			return
+		}
+
+		scopes := p.Scopes
+		if scopes == nil {
+			scopes = []string{}
+		}
+		api.WriteJSON(w, http.StatusOK, MeResponse{
+			Authenticated: true,
+			Tenant:        tenant,
+			KeyID:         p.KeyID,
+			UserID:        p.UserID,
+			Role:          string(p.Role),
+			Scopes:        scopes,
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func TestMeHandler_Unauthenticated(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	w := httptest.NewRecorder()
+
+	MeHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp MeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.False(t, resp.Authenticated)
+	require.Equal(t, "default", resp.Tenant)
+	require.Equal(t, []string{"admin"}, resp.Scopes)
+}
+
+func TestMeHandler_Principal(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	req = req.WithContext(auth.WithPrincipal(storage.WithOrgID(req.Context(), "team-a"), &auth.Principal{
+		KeyID:  "k1",
+		UserID: "alice",
+		Role:   auth.RoleOperator,
+		Scopes: []string{"read", "scan:create"},
+	}))
+	w := httptest.NewRecorder()
+
+	MeHandler().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp MeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.True(t, resp.Authenticated)
+	require.Equal(t, "team-a", resp.Tenant)
+	require.Equal(t, "k1", resp.KeyID)
+	require.Equal(t, "alice", resp.UserID)
+	require.Equal(t, "operator", resp.Role)
+	require.Equal(t, []string{"read", "scan:create"}, resp.Scopes)
+}
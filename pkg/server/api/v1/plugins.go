@@ -74,6 +74,9 @@ type InstallPluginRequest struct {
 
 	// Source to download from (optional, defaults to all sources)
 	Source string `json:"source,omitempty"`
+
+	// AllowUntrusted permits installing plugins from untrusted sources
+	AllowUntrusted bool `json:"allow_untrusted,omitempty"`
 }
 
 // InstallPluginResponse represents the response for plugin installation
@@ -144,6 +147,15 @@ type PluginListResponse struct {
 	Count int `json:"count"`
 }
 
+// UninstallPluginResponse represents the response for plugin uninstallation
+type UninstallPluginResponse struct {
+	// Message confirms the uninstallation
+	Message string `json:"message"`
+
+	// RemovedCount is the number of plugins removed
+	RemovedCount int `json:"removed_count"`
+}
+
 // PluginErrorDTO represents a plugin error in API responses (ADR-0003)
 type PluginErrorDTO struct {
 	// PluginID is the unique identifier of the plugin that failed
@@ -181,6 +193,12 @@ type PluginInfoDTO struct {
 
 	// Tags are the plugin tags
 	Tags []string `json:"tags,omitempty"`
+
+	// Source is the plugin source the plugin was installed from
+	Source string `json:"source,omitempty"`
+
+	// Trust is the trust level of the source (official, verified, community, untrusted)
+	Trust string `json:"trust,omitempty"`
 }
 
 // InstallPluginHandler handles POST /api/v1/plugins/install
@@ -270,8 +288,9 @@ func InstallPluginHandler(pluginService PluginService, config api.Config) http.H
 
 		// Build install options
 		opts := plugin.InstallOptions{
-			Force:  req.Force,
-			Source: req.Source,
+			Force:          req.Force,
+			Source:         req.Source,
+			AllowUntrusted: req.AllowUntrusted,
 		}
 
 		// Call service with timeout context
@@ -317,6 +336,8 @@ func InstallPluginHandler(pluginService PluginService, config api.Config) http.H
 				Author:   p.Author,
 				Severity: p.Severity,
 				Tags:     p.Tags,
+				Source:   p.Source,
+				Trust:    string(p.Trust),
 			})
 		}
 
@@ -412,6 +433,8 @@ func ListPluginsHandler(pluginService PluginService) http.HandlerFunc {
 				Author:   p.Author,
 				Severity: p.Severity,
 				Tags:     p.Tags,
+				Source:   p.Source,
+				Trust:    string(p.Trust),
 			})
 		}
 
@@ -509,6 +532,8 @@ func GetPluginHandler(pluginService PluginService) http.HandlerFunc {
 			Author:   info.Author,
 			Severity: info.Severity,
 			Tags:     info.Tags,
+			Source:   info.Source,
+			Trust:    string(info.Trust),
 		}
 
 		// Log success
@@ -623,9 +648,9 @@ func UninstallPluginHandler(pluginService PluginService, config api.Config) http
 			Int("remaining_count", result.RemainingCount).
 			Msg("uninstall succeeded")
 
-		api.WriteJSON(w, statusCode, map[string]interface{}{
-			"message":       "plugin uninstalled successfully",
-			"removed_count": result.RemovedCount,
+		api.WriteJSON(w, statusCode, UninstallPluginResponse{
+			Message:      "plugin uninstalled successfully",
+			RemovedCount: result.RemovedCount,
 		})
 	}
 }
@@ -781,6 +806,8 @@ func UpdatePluginsHandler(pluginService PluginService, config api.Config) http.H
 				Author:   p.Author,
 				Severity: p.Severity,
 				Tags:     p.Tags,
+				Source:   p.Source,
+				Trust:    string(p.Trust),
 			})
 		}
 
@@ -2,10 +2,18 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/scope"
 	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
 	"github.com/vulntor/vulntor/pkg/storage"
 )
 
@@ -27,6 +35,206 @@ import (
 //    ✓ Add `Tags []string \`json:"tags,omitempty"\`` (backward compatible)
 //    ✗ Remove or rename existing fields (breaks older clients)
 
+// ScanService launches scans as background jobs.
+// Implemented by the server app on top of scanexec and the jobs manager.
+type ScanService interface {
+	Submit(ctx context.Context, req CreateScanRequest) (*CreateScanResponse, error)
+}
+
+// CreateScanRequest represents the request body for POST /api/v1/scans
+type CreateScanRequest struct {
+	// Targets are the hosts, IPs, or CIDR ranges to scan (required unless
+	// Groups is set)
+	Targets []string `json:"targets,omitempty"`
+
+	// Groups names target groups whose targets are scanned too; findings
+	// on them inherit the groups' tags
+	Groups []string `json:"groups,omitempty"`
+
+	// Profile is the scan profile name (optional)
+	Profile string `json:"profile,omitempty"`
+
+	// Ports overrides the port list (e.g., "22,80,443" or "1-1024")
+	Ports string `json:"ports,omitempty"`
+
+	// EnableVuln enables plugin-based vulnerability evaluation
+	EnableVuln bool `json:"enable_vuln,omitempty"`
+
+	// OnlyDiscover runs host discovery only
+	OnlyDiscover bool `json:"only_discover,omitempty"`
+
+	// SkipDiscover skips host discovery and treats all targets as live
+	SkipDiscover bool `json:"skip_discover,omitempty"`
+
+	// Concurrency limits concurrent probes (0 = engine default)
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// Timeout is the per-probe timeout (e.g., "500ms", "2s")
+	Timeout string `json:"timeout,omitempty"`
+
+	// AutoTune tunes concurrency and timeouts during the scan, starting
+	// from Concurrency and Timeout
+	AutoTune bool `json:"auto_tune,omitempty"`
+
+	// VerifyTimeouts probes the ports that timed out once more at the end
+	// of port discovery, at lower concurrency
+	VerifyTimeouts bool `json:"verify_timeouts,omitempty"`
+
+	// Pcap records the probe traffic as one pcap artifact per host: "all",
+	// or "findings" for the ports findings were reported on (empty = no
+	// capture). Artifacts are served by GET /api/v1/scans/{id}/artifacts
+	Pcap string `json:"pcap,omitempty"`
+
+	// Priority orders the scan in the job queue: "low", "normal" (default)
+	// or "high". Scans of higher priority start first.
+	Priority string `json:"priority,omitempty"`
+
+	// ScopeAck is a signed acknowledgment approving targets outside the
+	// server's scan scope (see "vulntor scope ack")
+	ScopeAck string `json:"scope_ack,omitempty"`
+
+	// Engagement records the authorization the scan is run under: client,
+	// authorization reference, tester and testing window. Fields left
+	// empty are taken from the server's engagement config.
+	Engagement *engagement.Record `json:"engagement,omitempty"`
+}
+
+// CreateScanResponse represents the response for POST /api/v1/scans
+type CreateScanResponse struct {
+	// ID is the scan identifier, usable with GET /api/v1/scans/{id}
+	ID string `json:"id"`
+
+	// JobID is the background job identifier (equal to ID for the in-memory manager)
+	JobID string `json:"job_id"`
+
+	// Status is the initial scan status (always "pending")
+	Status string `json:"status"`
+}
+
+// ScansResponse represents the response for GET /api/v1/scans
+type ScansResponse struct {
+	// Scans is the current page of scans
+	Scans []api.ScanMetadata `json:"scans"`
+
+	// NextCursor is the cursor of the next page; empty on the last page
+	NextCursor string `json:"next_cursor"`
+
+	// Total is the number of scans matching the query
+	Total int `json:"total"`
+}
+
+// FindingsResponse represents the response for GET /api/v1/scans/{id}/findings
+type FindingsResponse struct {
+	// Findings is the current page of findings
+	Findings []map[string]interface{} `json:"findings"`
+
+	// Total is the total number of findings for the scan
+	Total int `json:"total"`
+
+	// Limit is the page size used
+	Limit int `json:"limit"`
+
+	// Offset is the index of the first finding in this page
+	Offset int `json:"offset"`
+
+	// NextCursor is the cursor of the next page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// CreateScanHandler handles POST /api/v1/scans
+//
+// Registers a new scan and enqueues it for background execution. The scan ID
+// is returned immediately; clients poll GET /api/v1/scans/{id} for status.
+//
+// Request body:
+//
+//	{
+//	  "targets": ["192.168.1.0/24"],
+//	  "groups": ["prod-web"],    // Optional target groups
+//	  "ports": "22,80,443",      // Optional
+//	  "enable_vuln": true,       // Optional
+//	  "priority": "high",        // Optional: low, normal or high
+//	  "scope_ack": "eyJ0..."     // Optional: approves out-of-scope targets
+//	}
+//
+// Response format (202 Accepted):
+//
+//	{
+//	  "id": "6f1c...",
+//	  "job_id": "6f1c...",
+//	  "status": "pending"
+//	}
+//
+// Returns 400 for invalid requests and unknown groups, 403 if targets lie
+// outside the scan scope without an acknowledgment approving them, 429 if
+// the tenant has as many scans queued or running as its quota allows, 503
+// if the job queue is full.
+func CreateScanHandler(scanService ScanService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := log.With().
+			Str("component", "api.scans").
+			Str("op", "create").
+			Logger()
+
+		// Defense-in-depth: Limit request body size (2MB)
+		r.Body = http.MaxBytesReader(w, r.Body, plugin.MaxRequestBodySize)
+
+		var req CreateScanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_REQUEST_BODY", "invalid request body: "+err.Error())
+			return
+		}
+
+		if err := ParseCreateScan(req); err != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_INPUT", err.Error())
+			return
+		}
+
+		resp, err := scanService.Submit(r.Context(), req)
+		if err != nil {
+			if errors.Is(err, jobs.ErrQueueFull) {
+				logger.Warn().Err(err).Str("error_code", "QUEUE_FULL").Msg("scan rejected")
+				api.WriteJSONError(w, http.StatusServiceUnavailable, "Service Unavailable", "QUEUE_FULL",
+					"scan queue is full, retry later")
+				return
+			}
+			if errors.Is(err, scope.ErrOutOfScope) {
+				logger.Warn().Err(err).Str("error_code", "TARGET_OUT_OF_SCOPE").Msg("scan rejected")
+				api.WriteJSONError(w, http.StatusForbidden, "Forbidden", "TARGET_OUT_OF_SCOPE", err.Error())
+				return
+			}
+			if errors.Is(err, engagement.ErrRequired) {
+				logger.Warn().Err(err).Str("error_code", "ENGAGEMENT_REQUIRED").Msg("scan rejected")
+				api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "ENGAGEMENT_REQUIRED", err.Error())
+				return
+			}
+			if errors.Is(err, engagement.ErrOutsideWindow) {
+				logger.Warn().Err(err).Str("error_code", "OUTSIDE_ENGAGEMENT_WINDOW").Msg("scan rejected")
+				api.WriteJSONError(w, http.StatusForbidden, "Forbidden", "OUTSIDE_ENGAGEMENT_WINDOW", err.Error())
+				return
+			}
+			if errors.Is(err, jobs.ErrTenantQuota) {
+				logger.Warn().Err(err).Str("error_code", "QUOTA_EXCEEDED").Msg("scan rejected")
+				api.WriteJSONError(w, http.StatusTooManyRequests, "Too Many Requests", "QUOTA_EXCEEDED",
+					"too many scans queued or running for this tenant, retry later")
+				return
+			}
+			logger.Error().Err(err).Msg("scan submission failed")
+			api.WriteError(w, r, err)
+			return
+		}
+
+		logger.Info().
+			Str("scan_id", resp.ID).
+			Int("targets", len(req.Targets)).
+			Strs("groups", req.Groups).
+			Msg("scan submitted")
+
+		w.Header().Set("Location", "/api/v1/scans/"+resp.ID)
+		api.WriteJSON(w, http.StatusAccepted, resp)
+	}
+}
+
 // ListScansHandler handles GET /api/v1/scans
 //
 // Returns paginated scan metadata with cursor-based pagination for scalability.
@@ -34,8 +242,10 @@ import (
 //
 // Query parameters:
 //   - status: Filter by status (pending, running, completed, failed)
+//   - group: Only scans run against this target group
 //   - limit: Number of results per page (1-100, default 50)
 //   - cursor: Pagination cursor (empty for first page)
+//   - filter, fields: see ParseListOptions
 //
 // Response format:
 //
@@ -57,28 +267,43 @@ func ListScansHandler(deps *api.Deps) http.HandlerFunc {
 		}
 
 		// Build storage filter (push down status when possible)
-		storageFilter := storage.ScanFilter{}
+		storageFilter := storage.ScanFilter{Group: query.Group}
 		if query.Status != "" {
 			storageFilter.Status = query.Status
 		}
 
+		// Filters apply to every scan of the tenant, so filtered scans are
+		// paged in memory
+		if deps.Storage != nil && query.Filter != nil {
+			offset, err := decodePageCursor(query.Cursor)
+			if err != nil {
+				api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", err.Error())
+				return
+			}
+			scans, err := listAllScansFromStorage(r.Context(), deps.Storage, storageFilter)
+			if err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+			page, err := paginate(scans, offset, query.ListOptions)
+			if err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+			writeScansPage(w, r, page.Items, page.NextCursor, page.Total, query.Fields)
+			return
+		}
+
 		// Use cursor-based pagination from storage layer
 		if deps.Storage != nil {
-			scans, nextCursor, total, err := listScansFromStoragePaginated(
+			scans, nextCursor, total, err := ListScans(
 				r.Context(), deps.Storage, storageFilter, query.Cursor, query.Limit,
 			)
 			if err != nil {
 				api.WriteError(w, r, err)
 				return
 			}
-
-			// Return paginated response with cursor and total
-			response := map[string]interface{}{
-				"scans":       scans,
-				"next_cursor": nextCursor,
-				"total":       total,
-			}
-			api.WriteJSON(w, http.StatusOK, response)
+			writeScansPage(w, r, scans, nextCursor, total, query.Fields)
 			return
 		}
 
@@ -136,7 +361,7 @@ func GetScanHandler(deps *api.Deps) http.HandlerFunc {
 
 		// Try new storage backend first, fall back to workspace
 		if deps.Storage != nil {
-			scan, err = getScanFromStorage(r.Context(), deps.Storage, id)
+			scan, err = GetScan(r.Context(), deps.Storage, id)
 		} else if deps.Workspace != nil {
 			scan, err = deps.Workspace.GetScan(id)
 		} else {
@@ -154,10 +379,148 @@ func GetScanHandler(deps *api.Deps) http.HandlerFunc {
 	}
 }
 
-// listScansFromStoragePaginated uses cursor-based pagination from storage layer
-func listScansFromStoragePaginated(ctx context.Context, backend storage.Backend, filter storage.ScanFilter, cursor string, limit int) ([]api.ScanMetadata, string, int, error) {
-	// Get paginated scans from storage (orgID="default" for OSS)
-	storageScans, nextCursor, total, err := backend.Scans().ListPaginated(ctx, "default", filter, cursor, limit)
+// ListFindingsHandler handles GET /api/v1/scans/{id}/findings
+//
+// Returns the findings (vulnerabilities) produced by a scan with
+// offset-based pagination. Scans that are still running or produced no
+// findings return an empty page.
+//
+// Query parameters:
+//   - limit: Number of results per page (1-100, default 50)
+//   - offset: Index of the first finding (default 0)
+//   - group: Only findings on targets of this target group
+//   - tag: Only findings with this plugin or target group tag
+//   - overdue: Only findings past their remediation due date and not
+//     closed since (true or false)
+//   - cursor, filter, fields: see ParseListOptions; offset and cursor are
+//     exclusive
+//
+// Response format:
+//
+//	{
+//	  "findings": [{"target": "10.0.0.5", "port": 22, "plugin": "ssh-weak-cipher", "severity": "high"}],
+//	  "total": 12,
+//	  "limit": 50,
+//	  "offset": 0
+//	}
+//
+// Returns 404 if scan not found.
+func ListFindingsHandler(deps *api.Deps) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		if id == "" {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "SCAN_ID_REQUIRED", "scan id is required")
+			return
+		}
+
+		query, qerr := ParseListFindingsQuery(r)
+		if qerr != nil {
+			api.WriteJSONError(w, http.StatusBadRequest, "Bad Request", "INVALID_QUERY", qerr.Error())
+			return
+		}
+
+		if deps.Storage == nil {
+			api.WriteError(w, r, errors.New("no storage backend configured"))
+			return
+		}
+
+		// Ensure the scan exists (404 otherwise)
+		if _, err := deps.Storage.Scans().Get(r.Context(), storage.OrgIDFromContext(r.Context()), id); err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+
+		findings, err := ReadFindings(r.Context(), deps.Storage, id)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		findings = filterFindings(findings, query.Group, query.Tag)
+		if query.Overdue {
+			if findings, err = keepOverdue(r.Context(), deps.Storage, findings); err != nil {
+				api.WriteError(w, r, err)
+				return
+			}
+		}
+
+		page, err := paginate(findings, query.Offset, query.ListOptions)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		resp := FindingsResponse{
+			Findings:   page.Items,
+			Total:      page.Total,
+			Limit:      query.Limit,
+			Offset:     query.Offset,
+			NextCursor: page.NextCursor,
+		}
+		if query.Fields != nil {
+			for i, f := range resp.Findings {
+				resp.Findings[i] = selectFields(f, query.Fields)
+			}
+		}
+
+		api.WriteJSON(w, http.StatusOK, resp)
+	}
+}
+
+// ReadFindings loads all findings for a scan of the tenant in ctx from the
+// vulnerabilities JSONL file. A missing file is treated as "no findings yet".
+func ReadFindings(ctx context.Context, backend storage.Backend, scanID string) ([]map[string]interface{}, error) {
+	return storage.ReadJSONL[map[string]interface{}](ctx, backend.Scans(), storage.OrgIDFromContext(ctx), scanID, storage.DataTypeVulnerabilities)
+}
+
+// filterFindings keeps the findings in group (from their "groups") and
+// carrying tag (in "tags" or "group_tags", or as KEY=VALUE in the tags of
+// their cloud instance). Empty criteria match all.
+func filterFindings(findings []map[string]interface{}, group, tag string) []map[string]interface{} {
+	if group == "" && tag == "" {
+		return findings
+	}
+
+	var out []map[string]interface{}
+	for _, f := range findings {
+		if group != "" && !containsValue(f["groups"], group) {
+			continue
+		}
+		if tag != "" && !containsValue(f["tags"], tag) && !containsValue(f["group_tags"], tag) && !hasCloudTag(f, tag) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// hasCloudTag reports whether the cloud instance of finding f has the tag
+// KEY=VALUE.
+func hasCloudTag(f map[string]interface{}, tag string) bool {
+	key, value, ok := strings.Cut(tag, "=")
+	if !ok {
+		return false
+	}
+	cloud, _ := f["cloud"].(map[string]interface{})
+	tags, _ := cloud["tags"].(map[string]interface{})
+	got, found := tags[key]
+	return found && got == value
+}
+
+// containsValue reports whether list, a decoded JSON array, contains s.
+func containsValue(list interface{}, s string) bool {
+	values, _ := list.([]interface{})
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ListScans returns a page of the scans of the tenant in ctx, using
+// cursor-based pagination from the storage layer.
+func ListScans(ctx context.Context, backend storage.Backend, filter storage.ScanFilter, cursor string, limit int) ([]api.ScanMetadata, string, int, error) {
+	// Get paginated scans for the request's tenant
+	storageScans, nextCursor, total, err := backend.Scans().ListPaginated(ctx, storage.OrgIDFromContext(ctx), filter, cursor, limit)
 	if err != nil {
 		return nil, "", 0, err
 	}
@@ -170,16 +533,57 @@ func listScansFromStoragePaginated(ctx context.Context, backend storage.Backend,
 			StartTime: s.StartedAt.Format("2006-01-02T15:04:05Z"),
 			Status:    s.Status,
 			Targets:   1, // TODO: Calculate from target string (e.g., CIDR range)
+			Groups:    s.Groups,
 		})
 	}
 
 	return apiScans, nextCursor, total, nil
 }
 
-// getScanFromStorage retrieves scan details from storage and converts to API format
-func getScanFromStorage(ctx context.Context, backend storage.Backend, scanID string) (*api.ScanDetail, error) {
+// listAllScansFromStorage returns every scan of the request's tenant
+// matching filter, page by page.
+func listAllScansFromStorage(ctx context.Context, backend storage.Backend, filter storage.ScanFilter) ([]api.ScanMetadata, error) {
+	var all []api.ScanMetadata
+	cursor := ""
+	for {
+		scans, next, _, err := ListScans(ctx, backend, filter, cursor, maxListLimit)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, scans...)
+		if next == "" || next == cursor {
+			return all, nil
+		}
+		cursor = next
+	}
+}
+
+// writeScansPage writes a page of scans with its cursor and total, with
+// only the given fields of each scan if fields is not nil.
+func writeScansPage(w http.ResponseWriter, r *http.Request, scans []api.ScanMetadata, nextCursor string, total int, fields []string) {
+	if fields != nil {
+		sparse, err := sparseItems(scans, fields)
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		api.WriteJSON(w, http.StatusOK, map[string]interface{}{
+			"scans":       sparse,
+			"next_cursor": nextCursor,
+			"total":       total,
+		})
+		return
+	}
+
+	// Return paginated response with cursor and total
+	api.WriteJSON(w, http.StatusOK, ScansResponse{Scans: scans, NextCursor: nextCursor, Total: total})
+}
+
+// GetScan retrieves the details of a scan of the tenant in ctx from storage
+// and converts them to API format.
+func GetScan(ctx context.Context, backend storage.Backend, scanID string) (*api.ScanDetail, error) {
 	// Get scan metadata
-	metadata, err := backend.Scans().Get(ctx, "default", scanID)
+	metadata, err := backend.Scans().Get(ctx, storage.OrgIDFromContext(ctx), scanID)
 	if err != nil {
 		return nil, err
 	}
@@ -206,6 +610,8 @@ func getScanFromStorage(ctx context.Context, backend storage.Backend, scanID str
 	// Convert to API format
 	detail := &api.ScanDetail{
 		ID:        metadata.ID,
+		Target:    metadata.Target,
+		Groups:    metadata.Groups,
 		StartTime: metadata.StartedAt.Format("2006-01-02T15:04:05Z"),
 		Status:    metadata.Status,
 		Results:   results,
@@ -3,17 +3,24 @@ package v1
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/scope"
 	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/ticketing"
 )
 
 // Mock workspace for testing
@@ -464,6 +471,74 @@ func TestListScansHandler_StatusFilter(t *testing.T) {
 	require.Equal(t, 2, response.Total)
 }
 
+func TestListScansHandler_GroupFilter(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{ID: "s1", Target: "10.0.1.0/24", Status: "completed", StartedAt: time.Now(), Groups: []string{"prod-web"}}))
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{ID: "s2", Target: "10.8.0.0/16", Status: "completed", StartedAt: time.Now()}))
+	handler := ListScansHandler(&api.Deps{Storage: backend})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans?group=prod-web", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Scans []api.ScanMetadata `json:"scans"`
+		Total int                `json:"total"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	require.Equal(t, 1, response.Total)
+	require.Equal(t, "s1", response.Scans[0].ID)
+	require.Equal(t, []string{"prod-web"}, response.Scans[0].Groups)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/scans?group=../x", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListScansHandler_FilterAndFields(t *testing.T) {
+	now := time.Now()
+	mockStorage := &mockStorageBackend{
+		scans: []*storage.ScanMetadata{
+			{ID: "s1", Status: "completed", StartedAt: now, Groups: []string{"prod-web"}},
+			{ID: "s2", Status: "completed", StartedAt: now},
+			{ID: "s3", Status: "failed", StartedAt: now, Groups: []string{"prod-web"}},
+			{ID: "s4", Status: "completed", StartedAt: now, Groups: []string{"office", "prod-web"}},
+		},
+	}
+	handler := ListScansHandler(&api.Deps{Storage: mockStorage})
+
+	list := func(query string) (int, map[string]interface{}) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans?"+query, nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		var response map[string]interface{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		return w.Code, response
+	}
+
+	filter := "filter=" + url.QueryEscape("status==completed;groups==prod-web")
+	code, response := list(filter + "&fields=id&limit=1")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, float64(2), response["total"])
+	require.Equal(t, []interface{}{map[string]interface{}{"id": "s1"}}, response["scans"])
+
+	code, response = list(filter + "&fields=id&limit=1&cursor=" + response["next_cursor"].(string))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, []interface{}{map[string]interface{}{"id": "s4"}}, response["scans"])
+	require.Equal(t, "", response["next_cursor"])
+
+	code, _ = list(filter + "&cursor=bogus")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = list("fields=bad..field")
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
 func TestListScansHandler_InvalidStatus(t *testing.T) {
 	deps := &api.Deps{}
 	handler := ListScansHandler(deps)
@@ -704,3 +779,352 @@ func TestGetScanHandler_NoBackend(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 	require.Contains(t, w.Body.String(), "no storage backend configured")
 }
+
+type mockScanService struct {
+	got  CreateScanRequest
+	resp *CreateScanResponse
+	err  error
+}
+
+func (m *mockScanService) Submit(ctx context.Context, req CreateScanRequest) (*CreateScanResponse, error) {
+	m.got = req
+	return m.resp, m.err
+}
+
+func TestCreateScanHandler_Accepted(t *testing.T) {
+	svc := &mockScanService{resp: &CreateScanResponse{ID: "scan-1", JobID: "scan-1", Status: "pending"}}
+	handler := CreateScanHandler(svc)
+
+	body := `{"targets":["10.0.0.1","10.0.0.2"],"ports":"22,80","enable_vuln":true}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, "/api/v1/scans/scan-1", w.Header().Get("Location"))
+
+	var resp CreateScanResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, "scan-1", resp.ID)
+	require.Equal(t, "pending", resp.Status)
+
+	require.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, svc.got.Targets)
+	require.Equal(t, "22,80", svc.got.Ports)
+	require.True(t, svc.got.EnableVuln)
+}
+
+func TestCreateScanHandler_InvalidBody(t *testing.T) {
+	handler := CreateScanHandler(&mockScanService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader("{"))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "INVALID_REQUEST_BODY")
+}
+
+func TestCreateScanHandler_ValidationError(t *testing.T) {
+	handler := CreateScanHandler(&mockScanService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":[]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "INVALID_INPUT")
+	require.Contains(t, w.Body.String(), "targets")
+}
+
+func TestCreateScanHandler_GroupsWithoutTargets(t *testing.T) {
+	svc := &mockScanService{resp: &CreateScanResponse{ID: "scan-1", JobID: "scan-1", Status: "pending"}}
+	handler := CreateScanHandler(svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"groups":["prod-web"]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, []string{"prod-web"}, svc.got.Groups)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"groups":["Prod Web"]}`))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "groups")
+}
+
+func TestCreateScanHandler_QueueFull(t *testing.T) {
+	handler := CreateScanHandler(&mockScanService{err: jobs.ErrQueueFull})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Contains(t, w.Body.String(), "QUEUE_FULL")
+}
+
+func TestCreateScanHandler_TenantQuota(t *testing.T) {
+	handler := CreateScanHandler(&mockScanService{err: jobs.ErrTenantQuota})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Contains(t, w.Body.String(), "QUOTA_EXCEEDED")
+}
+
+func TestCreateScanHandler_OutOfScope(t *testing.T) {
+	outOfScope := &scope.Error{Violations: []scope.Violation{{Target: "203.0.113.5", Reason: "203.0.113.5 is not in the allowed ranges"}}}
+	handler := CreateScanHandler(&mockScanService{err: outOfScope})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["203.0.113.5"]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "TARGET_OUT_OF_SCOPE")
+	require.Contains(t, w.Body.String(), "203.0.113.5 is not in the allowed ranges")
+}
+
+func TestCreateScanHandler_Engagement(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{fmt.Errorf("%w: missing authorization", engagement.ErrRequired), http.StatusBadRequest, "ENGAGEMENT_REQUIRED"},
+		{fmt.Errorf("%w: it ended at 2026-12-15T00:00:00Z", engagement.ErrOutsideWindow), http.StatusForbidden, "OUTSIDE_ENGAGEMENT_WINDOW"},
+	}
+	for _, tt := range tests {
+		handler := CreateScanHandler(&mockScanService{err: tt.err})
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"],"engagement":{"client":"ACME"}}`))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, tt.status, w.Code)
+		require.Contains(t, w.Body.String(), tt.code)
+	}
+}
+
+func TestCreateScanHandler_ServiceError(t *testing.T) {
+	handler := CreateScanHandler(&mockScanService{err: errors.New("disk full")})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// newFindingsBackend returns a local storage backend with one scan and the given findings JSONL.
+func newFindingsBackend(t *testing.T, findings string) storage.Backend {
+	t.Helper()
+	ctx := context.Background()
+
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.Initialize(ctx))
+	t.Cleanup(func() { _ = backend.Close() })
+
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+		ID:        "scan-1",
+		Target:    "10.0.0.1",
+		Status:    "completed",
+		StartedAt: time.Now(),
+	}))
+	if findings != "" {
+		require.NoError(t, backend.Scans().WriteData(ctx, "default", "scan-1", storage.DataTypeVulnerabilities, strings.NewReader(findings)))
+	}
+	return backend
+}
+
+func TestListFindingsHandler_Paginated(t *testing.T) {
+	findings := `{"plugin":"p1","severity":"high"}
+{"plugin":"p2","severity":"low"}
+not-json
+{"plugin":"p3","severity":"info"}
+`
+	deps := &api.Deps{Storage: newFindingsBackend(t, findings)}
+	handler := ListFindingsHandler(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?limit=2&offset=1", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp FindingsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 3, resp.Total, "malformed lines are skipped")
+	require.Equal(t, 2, resp.Limit)
+	require.Equal(t, 1, resp.Offset)
+	require.Len(t, resp.Findings, 2)
+	require.Equal(t, "p2", resp.Findings[0]["plugin"])
+	require.Equal(t, "p3", resp.Findings[1]["plugin"])
+}
+
+func TestListFindingsHandler_GroupAndTagFilters(t *testing.T) {
+	findings := `{"plugin":"p1","tags":["ssh"],"groups":["prod-web"],"group_tags":["prod","pci"]}
+{"plugin":"p2","tags":["http"],"groups":["branch-berlin"],"group_tags":["office"]}
+{"plugin":"p3","tags":["ssh"]}
+{"plugin":"p4","cloud":{"provider":"aws","instance_id":"i-0001","tags":{"team":"payments"}}}
+`
+	deps := &api.Deps{Storage: newFindingsBackend(t, findings)}
+	handler := ListFindingsHandler(deps)
+
+	plugins := func(query string) []string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?"+query, nil)
+		req.SetPathValue("id", "scan-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp FindingsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Findings, resp.Total)
+		var names []string
+		for _, f := range resp.Findings {
+			names = append(names, f["plugin"].(string))
+		}
+		return names
+	}
+
+	require.Equal(t, []string{"p1"}, plugins("group=prod-web"))
+	require.Equal(t, []string{"p1"}, plugins("tag=pci"))
+	require.Equal(t, []string{"p1", "p3"}, plugins("tag=ssh"))
+	require.Equal(t, []string{"p2"}, plugins("group=branch-berlin&tag=office"))
+	require.Empty(t, plugins("group=prod-web&tag=office"))
+	require.Equal(t, []string{"p4"}, plugins("tag=team%3Dpayments"))
+	require.Empty(t, plugins("tag=team%3Dsre"))
+}
+
+func TestListFindingsHandler_Overdue(t *testing.T) {
+	findings := `{"target":"10.0.0.1","plugin":"p1","severity":"high","due_at":"2020-01-01T00:00:00Z"}
+{"target":"10.0.0.1","plugin":"p2","severity":"high","due_at":"2020-01-01T00:00:00Z"}
+{"target":"10.0.0.1","plugin":"p3","severity":"high","due_at":"2999-01-01T00:00:00Z"}
+{"target":"10.0.0.1","plugin":"p4","severity":"info"}
+`
+	backend := newFindingsBackend(t, findings)
+	fixed := &storage.FindingStatus{ID: ticketing.Fingerprint(ticketing.Finding{Plugin: "p2", Target: "10.0.0.1"})}
+	require.NoError(t, backend.(storage.FindingStatusBackend).FindingStatuses().Transition(context.Background(), "default", fixed, storage.FindingTransition{To: storage.FindingStateFixed}))
+	handler := ListFindingsHandler(&api.Deps{Storage: backend})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?overdue=true", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp FindingsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, "p1", resp.Findings[0]["plugin"])
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?overdue=maybe", nil)
+	req.SetPathValue("id", "scan-1")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListFindingsHandler_FilterFieldsAndCursor(t *testing.T) {
+	findings := `{"plugin":"p1","severity":"high","port":22}
+{"plugin":"p2","severity":"low","port":80}
+{"plugin":"p3","severity":"critical","port":443}
+{"plugin":"p4","severity":"high","port":8443}
+`
+	deps := &api.Deps{Storage: newFindingsBackend(t, findings)}
+	handler := ListFindingsHandler(deps)
+
+	list := func(query string) FindingsResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?"+query, nil)
+		req.SetPathValue("id", "scan-1")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp FindingsResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp
+	}
+
+	resp := list("filter=" + url.QueryEscape("severity==high,critical;port>=100") + "&fields=plugin&limit=1")
+	require.Equal(t, 2, resp.Total)
+	require.Equal(t, []map[string]interface{}{{"plugin": "p3"}}, resp.Findings)
+	require.NotEmpty(t, resp.NextCursor)
+
+	resp = list("filter=" + url.QueryEscape("severity==high,critical;port>=100") + "&fields=plugin&limit=1&cursor=" + resp.NextCursor)
+	require.Equal(t, []map[string]interface{}{{"plugin": "p4"}}, resp.Findings)
+	require.Equal(t, 1, resp.Offset)
+	require.Empty(t, resp.NextCursor)
+}
+
+func TestListFindingsHandler_OffsetBeyondTotal(t *testing.T) {
+	deps := &api.Deps{Storage: newFindingsBackend(t, `{"plugin":"p1"}`+"\n")}
+	handler := ListFindingsHandler(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?offset=10", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp FindingsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, 1, resp.Total)
+	require.NotNil(t, resp.Findings)
+	require.Empty(t, resp.Findings)
+}
+
+func TestListFindingsHandler_NoFindingsYet(t *testing.T) {
+	deps := &api.Deps{Storage: newFindingsBackend(t, "")}
+	handler := ListFindingsHandler(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"findings":[],"total":0,"limit":50,"offset":0}`, w.Body.String())
+}
+
+func TestListFindingsHandler_ScanNotFound(t *testing.T) {
+	deps := &api.Deps{Storage: newFindingsBackend(t, "")}
+	handler := ListFindingsHandler(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/missing/findings", nil)
+	req.SetPathValue("id", "missing")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListFindingsHandler_InvalidQuery(t *testing.T) {
+	deps := &api.Deps{Storage: newFindingsBackend(t, "")}
+	handler := ListFindingsHandler(deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings?limit=0", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "INVALID_QUERY")
+}
+
+func TestListFindingsHandler_NoStorage(t *testing.T) {
+	handler := ListFindingsHandler(&api.Deps{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/findings", nil)
+	req.SetPathValue("id", "scan-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -2,13 +2,18 @@ package v1
 
 import (
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 
+	"github.com/vulntor/vulntor/pkg/capture"
 	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 var validate = validator.New()
@@ -16,8 +21,8 @@ var validate = validator.New()
 // ListScansQuery represents supported query params for GET /api/v1/scans
 type ListScansQuery struct {
 	Status string
-	Limit  int
-	Cursor string // Opaque cursor for pagination (empty for first page)
+	Group  string // target group name
+	ListOptions
 }
 
 // ParseListScansQuery parses and validates query params.
@@ -34,30 +39,215 @@ func ParseListScansQuery(r *http.Request) (*ListScansQuery, error) {
 		res.Status = v
 	}
 
-	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+	if v := strings.TrimSpace(q.Get("group")); v != "" {
+		if err := storage.ValidateTargetGroupName(v); err != nil {
+			return nil, &ValidationError{Field: "group", Reason: "must be a target group name"}
+		}
+		res.Group = v
+	}
+
+	// Limit, cursor, filter and fields follow the list conventions
+	opts, err := ParseListOptions(q)
+	if err != nil {
+		return nil, err
+	}
+	res.ListOptions = opts
+
+	return &res, nil
+}
+
+// ListFindingsQuery represents supported query params for GET /api/v1/scans/{id}/findings
+type ListFindingsQuery struct {
+	Offset  int    // index of the first finding, from offset or cursor
+	Group   string // only findings on targets of this target group
+	Tag     string // only findings with this plugin, group or cloud instance tag
+	Overdue bool   // only findings past their remediation due date
+	ListOptions
+}
+
+// ParseListFindingsQuery parses and validates findings pagination params.
+// Returns validated query with sane defaults (Limit=50, Offset=0) when omitted.
+func ParseListFindingsQuery(r *http.Request) (*ListFindingsQuery, error) {
+	q := r.URL.Query()
+	var res ListFindingsQuery
+
+	opts, err := ParseListOptions(q)
+	if err != nil {
+		return nil, err
+	}
+	res.ListOptions = opts
+
+	if v := strings.TrimSpace(q.Get("offset")); v != "" {
+		if res.Cursor != "" {
+			return nil, &ValidationError{Field: "offset", Reason: "cannot be combined with cursor"}
+		}
 		n, err := strconv.Atoi(v)
 		if err != nil {
-			return nil, &ValidationError{Field: "limit", Reason: "must be an integer"}
+			return nil, &ValidationError{Field: "offset", Reason: "must be an integer"}
+		}
+		if n < 0 {
+			return nil, &ValidationError{Field: "offset", Reason: "must be >= 0"}
+		}
+		res.Offset = n
+	}
+	if res.Cursor != "" {
+		if res.Offset, err = decodePageCursor(res.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	res.Group = strings.TrimSpace(q.Get("group"))
+	res.Tag = strings.TrimSpace(q.Get("tag"))
+	if res.Overdue, err = parseOverdue(q); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ListAssetsQuery holds validated query params for GET /api/v1/assets.
+type ListAssetsQuery struct {
+	// Scans is the number of most recent completed scans to aggregate
+	Scans int
+
+	// Offset is the index of the first asset, from the cursor
+	Offset int
+
+	ListOptions
+}
+
+// ParseListAssetsQuery parses and validates asset inventory params.
+// Returns validated query with sane defaults (Scans=20, Limit=50) when omitted.
+func ParseListAssetsQuery(r *http.Request) (*ListAssetsQuery, error) {
+	q := r.URL.Query()
+	res := ListAssetsQuery{Scans: 20}
+
+	if v := strings.TrimSpace(q.Get("scans")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &ValidationError{Field: "scans", Reason: "must be an integer"}
 		}
 		if err := validate.Var(n, "min=1,max=100"); err != nil {
-			return nil, &ValidationError{Field: "limit", Reason: "must be between 1 and 100"}
+			return nil, &ValidationError{Field: "scans", Reason: "must be between 1 and 100"}
 		}
-		res.Limit = n
+		res.Scans = n
 	}
 
-	// Cursor parameter (opaque string, no validation needed beyond trimming)
-	if v := strings.TrimSpace(q.Get("cursor")); v != "" {
-		res.Cursor = v
+	opts, err := ParseListOptions(q)
+	if err != nil {
+		return nil, err
+	}
+	res.ListOptions = opts
+	if res.Offset, err = decodePageCursor(res.Cursor); err != nil {
+		return nil, err
 	}
 
-	// Defaults
-	if res.Limit == 0 {
-		res.Limit = 50
+	return &res, nil
+}
+
+// ListSurfaceQuery holds validated query params for
+// GET /api/v1/assets/surface.
+type ListSurfaceQuery struct {
+	Kind string // storage.SurfaceHost, storage.SurfaceGroup or empty for both
+}
+
+// ParseListSurfaceQuery parses and validates attack surface filters.
+func ParseListSurfaceQuery(r *http.Request) (*ListSurfaceQuery, error) {
+	res := ListSurfaceQuery{Kind: strings.TrimSpace(r.URL.Query().Get("kind"))}
+	if res.Kind != "" && res.Kind != storage.SurfaceHost && res.Kind != storage.SurfaceGroup {
+		return nil, &ValidationError{Field: "kind", Reason: "must be host or group"}
+	}
+	return &res, nil
+}
+
+// ListAuditQuery holds validated query params for GET /api/v1/audit.
+type ListAuditQuery struct {
+	Filter storage.AuditFilter
+}
+
+// ParseListAuditQuery parses and validates audit log params.
+// Returns validated query with sane defaults (Limit=50) when omitted.
+func ParseListAuditQuery(r *http.Request) (*ListAuditQuery, error) {
+	q := r.URL.Query()
+	res := ListAuditQuery{Filter: storage.AuditFilter{
+		Actor:  strings.TrimSpace(q.Get("actor")),
+		Action: strings.TrimSpace(q.Get("action")),
+		Limit:  50,
+	}}
+
+	if v := strings.TrimSpace(q.Get("since")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, &ValidationError{Field: "since", Reason: "must be an RFC 3339 timestamp"}
+		}
+		res.Filter.Since = t
+	}
+
+	if v := strings.TrimSpace(q.Get("until")); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, &ValidationError{Field: "until", Reason: "must be an RFC 3339 timestamp"}
+		}
+		res.Filter.Until = t
+	}
+
+	if v := strings.TrimSpace(q.Get("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, &ValidationError{Field: "limit", Reason: "must be an integer"}
+		}
+		if err := validate.Var(n, "min=1,max=500"); err != nil {
+			return nil, &ValidationError{Field: "limit", Reason: "must be between 1 and 500"}
+		}
+		res.Filter.Limit = n
 	}
 
 	return &res, nil
 }
 
+// maxScanTargets bounds the number of targets accepted in a single API request.
+const maxScanTargets = 1024
+
+// ParseCreateScan validates scan creation request fields.
+func ParseCreateScan(req CreateScanRequest) error {
+	if len(req.Targets) == 0 && len(req.Groups) == 0 {
+		return &ValidationError{Field: "targets", Reason: "required unless groups are given"}
+	}
+	if len(req.Targets) > maxScanTargets {
+		return &ValidationError{Field: "targets", Reason: "too many targets (max " + strconv.Itoa(maxScanTargets) + ")"}
+	}
+	for _, t := range req.Targets {
+		if strings.TrimSpace(t) == "" {
+			return &ValidationError{Field: "targets", Reason: "must not contain empty values"}
+		}
+	}
+	for _, g := range req.Groups {
+		if err := storage.ValidateTargetGroupName(g); err != nil {
+			return &ValidationError{Field: "groups", Reason: "must contain target group names"}
+		}
+	}
+	if req.OnlyDiscover && req.SkipDiscover {
+		return &ValidationError{Field: "only_discover", Reason: "cannot be combined with skip_discover"}
+	}
+	if req.Concurrency < 0 {
+		return &ValidationError{Field: "concurrency", Reason: "must be >= 0"}
+	}
+	if req.Timeout != "" {
+		if d, err := time.ParseDuration(req.Timeout); err != nil || d <= 0 {
+			return &ValidationError{Field: "timeout", Reason: "must be a positive duration (e.g., 500ms, 2s)"}
+		}
+	}
+	if req.Pcap != "" {
+		if _, err := capture.ParseMode(req.Pcap); err != nil {
+			return &ValidationError{Field: "pcap", Reason: "must be all or findings"}
+		}
+	}
+	if _, err := jobs.ParsePriority(req.Priority); err != nil {
+		return &ValidationError{Field: "priority", Reason: "must be low, normal or high"}
+	}
+	return nil
+}
+
 // ValidationError is a lightweight error used for 400 responses.
 type ValidationError struct {
 	Field  string
@@ -150,3 +340,50 @@ func ParseListPlugins(r *http.Request) error {
 	}
 	return ValidateCategory(cat)
 }
+
+// ListFindingStatusesQuery holds validated query params for
+// GET /api/v1/findings.
+type ListFindingStatusesQuery struct {
+	State    string
+	Assignee string
+	Overdue  bool
+}
+
+// ParseListFindingStatusesQuery parses and validates finding status
+// filters.
+func ParseListFindingStatusesQuery(r *http.Request) (*ListFindingStatusesQuery, error) {
+	q := r.URL.Query()
+	res := ListFindingStatusesQuery{
+		State:    strings.TrimSpace(q.Get("state")),
+		Assignee: strings.TrimSpace(q.Get("assignee")),
+	}
+	if res.State != "" && storage.ValidateFindingState(res.State) != nil {
+		return nil, &ValidationError{Field: "state", Reason: "must be one of " + strings.Join(storage.FindingStates, ", ")}
+	}
+	var err error
+	if res.Overdue, err = parseOverdue(q); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// parseOverdue parses the overdue finding filter, false when omitted.
+func parseOverdue(q url.Values) (bool, error) {
+	v := strings.TrimSpace(q.Get("overdue"))
+	if v == "" {
+		return false, nil
+	}
+	overdue, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, &ValidationError{Field: "overdue", Reason: "must be true or false"}
+	}
+	return overdue, nil
+}
+
+// ParseSetFindingState validates finding triage request fields.
+func ParseSetFindingState(req SetFindingStateRequest) error {
+	if storage.ValidateFindingState(req.State) != nil {
+		return &ValidationError{Field: "state", Reason: "must be one of " + strings.Join(storage.FindingStates, ", ")}
+	}
+	return nil
+}
@@ -141,3 +141,88 @@ func TestValidation_ValidateCategory(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestValidation_ParseListFindingsQuery(t *testing.T) {
+	got, err := ParseListFindingsQuery(newRequestWithQuery(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 50, got.Limit)
+	assert.Equal(t, 0, got.Offset)
+
+	got, err = ParseListFindingsQuery(newRequestWithQuery(map[string]string{"limit": "5", "offset": "10"}))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, got.Limit)
+	assert.Equal(t, 10, got.Offset)
+
+	for _, params := range []map[string]string{
+		{"limit": "0"},
+		{"limit": "101"},
+		{"limit": "x"},
+		{"offset": "-1"},
+		{"offset": "y"},
+		{"offset": "1", "cursor": encodePageCursor(2)},
+		{"cursor": "not-a-cursor"},
+		{"filter": "severity"},
+	} {
+		_, err := ParseListFindingsQuery(newRequestWithQuery(params))
+		assert.Error(t, err, "params=%v", params)
+	}
+
+	// Cursors resume at the offset of the next page
+	got, err = ParseListFindingsQuery(newRequestWithQuery(map[string]string{"cursor": encodePageCursor(20), "fields": "plugin"}))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, got.Offset)
+	assert.Equal(t, []string{"plugin"}, got.Fields)
+}
+
+func TestValidation_ParseListAuditQuery(t *testing.T) {
+	got, err := ParseListAuditQuery(newRequestWithQuery(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 50, got.Filter.Limit)
+	assert.True(t, got.Filter.Since.IsZero())
+
+	got, err = ParseListAuditQuery(newRequestWithQuery(map[string]string{
+		"actor": "alice", "action": "plugin", "since": "2025-06-01T00:00:00Z", "limit": "500",
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", got.Filter.Actor)
+	assert.Equal(t, "plugin", got.Filter.Action)
+	assert.Equal(t, 2025, got.Filter.Since.Year())
+	assert.Equal(t, 500, got.Filter.Limit)
+
+	for _, params := range []map[string]string{
+		{"limit": "0"},
+		{"limit": "501"},
+		{"since": "24h"},
+		{"until": "2025-06-01"},
+	} {
+		_, err := ParseListAuditQuery(newRequestWithQuery(params))
+		assert.Error(t, err, "params=%v", params)
+	}
+}
+
+func TestValidation_ParseCreateScan(t *testing.T) {
+	assert.NoError(t, ParseCreateScan(CreateScanRequest{Targets: []string{"10.0.0.1"}}))
+	assert.NoError(t, ParseCreateScan(CreateScanRequest{Targets: []string{"10.0.0.0/24"}, Timeout: "500ms"}))
+	assert.NoError(t, ParseCreateScan(CreateScanRequest{Targets: []string{"10.0.0.1"}, Pcap: "findings"}))
+	assert.NoError(t, ParseCreateScan(CreateScanRequest{Targets: []string{"10.0.0.1"}, Priority: "high"}))
+
+	cases := map[string]CreateScanRequest{
+		"targets":       {},
+		"empty target":  {Targets: []string{" "}},
+		"only_discover": {Targets: []string{"a"}, OnlyDiscover: true, SkipDiscover: true},
+		"concurrency":   {Targets: []string{"a"}, Concurrency: -1},
+		"timeout":       {Targets: []string{"a"}, Timeout: "soon"},
+		"pcap":          {Targets: []string{"a"}, Pcap: "everything"},
+		"priority":      {Targets: []string{"a"}, Priority: "urgent"},
+	}
+	for name, req := range cases {
+		err := ParseCreateScan(req)
+		assert.Error(t, err, name)
+	}
+
+	tooMany := make([]string, maxScanTargets+1)
+	for i := range tooMany {
+		tooMany[i] = "10.0.0.1"
+	}
+	assert.Error(t, ParseCreateScan(CreateScanRequest{Targets: tooMany}))
+}
@@ -3,26 +3,50 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"sync/atomic"
 	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/vulntor/vulntor/pkg/audit"
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/scope"
 	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/server/certs"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi"
 	"github.com/vulntor/vulntor/pkg/server/httpx"
 	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/server/leader"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 // App orchestrates the server runtime components:
 // - HTTP server (API + UI)
+// - gRPC server (API), when a gRPC port is configured
 // - Background job manager
+// - Leader election, when replicas share a workspace
 // - Lifecycle management
 type App struct {
 	HTTP   *http.Server
+	GRPC   *grpc.Server // nil when the gRPC API is disabled
 	Jobs   jobs.Manager
 	Ready  *atomic.Bool
 	Config config.ServerConfig
 	Deps   *Deps
+
+	// Leader elects the replica running the jobs; nil without HA
+	Leader *leader.Elector
+
+	stopLeader context.CancelFunc
+	leaderDone chan struct{}
 }
 
 // New creates and configures a new server application.
@@ -35,6 +59,83 @@ func New(ctx context.Context, cfg config.ServerConfig, deps *Deps) (*App, error)
 		if err := deps.Storage.Initialize(ctx); err != nil {
 			return nil, fmt.Errorf("failed to initialize storage: %w", err)
 		}
+
+		// Bring scans stored by older versions to the current schema
+		if migrator, ok := deps.Storage.(storage.MigrationBackend); ok {
+			result, err := migrator.MigrateScans(ctx, storage.MigrateOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to migrate stored scans: %w", err)
+			}
+			if result.ScansMigrated > 0 {
+				deps.Logger.Info().
+					Int("scans", result.ScansMigrated).
+					Int("schema_version", storage.ScanSchemaVersion).
+					Msg("Migrated stored scans")
+			}
+			for _, err := range result.Errors {
+				deps.Logger.Warn().Err(err).Msg("Failed to migrate stored scan")
+			}
+		}
+	}
+
+	// Create job manager; queued jobs survive restarts when the backend
+	// keeps them
+	var jobsMgr jobs.Manager
+	var elector *leader.Elector
+	if cfg.JobsEnabled {
+		opts := []jobs.Option{
+			jobs.WithQueueSize(cfg.Queue.Size),
+			jobs.WithTenantLimits(cfg.Queue.TenantConcurrency, cfg.Queue.TenantQuota),
+		}
+		jobBackend, hasQueue := deps.Storage.(storage.JobBackend)
+		if hasQueue {
+			opts = append(opts, jobs.WithStore(&jobStore{store: jobBackend.Jobs()}))
+		}
+
+		// With HA, replicas share the queue and only the leader runs jobs
+		if cfg.HA.Enabled {
+			leaseBackend, hasLeases := deps.Storage.(storage.LeaseBackend)
+			if !hasQueue || !hasLeases {
+				return nil, fmt.Errorf("ha requires a storage backend with job queue and lease support")
+			}
+			id := cfg.HA.NodeID
+			if id == "" {
+				id = leader.DefaultID()
+			}
+			elector = leader.New(leaseBackend.Leases(), leader.LeaseName, id, cfg.HA.LeaseTTL)
+			ttl := cfg.HA.LeaseTTL
+			if ttl <= 0 {
+				ttl = leader.DefaultTTL
+			}
+			opts = append(opts, jobs.WithStoreSync(ttl/3))
+			deps.Logger.Info().Str("node_id", id).Msg("Leader election enabled")
+		}
+		jobsMgr = jobs.NewMemoryManager(cfg.Concurrency, opts...)
+	}
+
+	// Tenants get their own plugin service when the backend hosts tenants
+	pluginService := deps.PluginService
+	var tenantPlugins *tenantPluginService
+	var authOpts []httpx.AuthOption
+	if tenantBackend, ok := deps.Storage.(storage.TenantBackend); ok {
+		authOpts = append(authOpts, httpx.WithTenants(tenantBackend.Tenants()))
+		if base, ok := deps.PluginService.(v1.PluginService); ok && deps.TenantPluginService != nil {
+			tenantPlugins = newTenantPluginService(ctx, base, tenantBackend.Tenants(), deps.TenantPluginService)
+			tenantPlugins.logger = deps.Logger
+			pluginService = tenantPlugins
+		}
+	}
+
+	// Mutating API calls are recorded in the audit log when the backend keeps one
+	var recorder *audit.Recorder
+	if auditBackend, ok := deps.Storage.(storage.AuditBackend); ok {
+		recorder = audit.NewRecorder(auditBackend.Audit(), audit.SourceAPI)
+		if cfg.Auth.Mode == "token" {
+			recorder = recorder.WithActor("token")
+		}
+		if base, ok := pluginService.(v1.PluginService); ok {
+			pluginService = &auditedPluginService{PluginService: base, audit: recorder}
+		}
 	}
 
 	// Prepare API dependencies
@@ -42,11 +143,72 @@ func New(ctx context.Context, cfg config.ServerConfig, deps *Deps) (*App, error)
 	apiDeps := &api.Deps{
 		Storage:       deps.Storage,
 		Workspace:     deps.Workspace,
-		PluginService: deps.PluginService,
+		PluginService: pluginService,
 		Config:        api.DefaultConfig(), // Use default API config (30s handler timeout)
 		Ready:         ready,
 	}
 
+	// Scan submission requires background workers
+	if jobsMgr != nil {
+		broker := events.NewBroker()
+		runner := scanexec.NewService().WithStorage(deps.Storage).WithInstalledPlugins(deps.InstalledPlugins).WithTelemetry(deps.Telemetry)
+		if tenantPlugins != nil {
+			// Each tenant's scans evaluate the plugins that tenant installed
+			runner = runner.WithTenantInstalledPlugins(tenantPlugins.installedPlugins)
+		}
+		var scanScope *scope.Policy
+		var engagementPolicy engagement.Policy
+		if deps.Config != nil {
+			var err error
+			runner, err = runner.WithConfig(deps.Config.Get())
+			if err != nil {
+				return nil, err
+			}
+			scopeConfig := deps.Config.Get().Scope
+			if scanScope, err = scopeConfig.Policy(); err != nil {
+				return nil, fmt.Errorf("invalid scope config: %w", err)
+			}
+			engagementConfig := deps.Config.Get().Engagement
+			if engagementPolicy, err = engagementConfig.Policy(); err != nil {
+				return nil, fmt.Errorf("invalid engagement config: %w", err)
+			}
+		}
+		scanService := newScanJobService(deps.Storage, jobsMgr, runner.Run, broker)
+		scanService.audit = recorder
+		scanService.scope = scanScope
+		scanService.engagement = engagementPolicy
+		apiDeps.ScanService = scanService
+		apiDeps.Events = broker
+	}
+
+	// API key mode verifies bearer tokens against keys in the storage backend
+	if cfg.Auth.Mode == "apikey" {
+		keyBackend, ok := deps.Storage.(storage.APIKeyBackend)
+		if !ok {
+			return nil, fmt.Errorf("auth mode apikey requires a storage backend with API key support")
+		}
+		verifier := auth.NewVerifier(keyBackend.APIKeys())
+		if userBackend, ok := deps.Storage.(storage.UserBackend); ok {
+			verifier = verifier.WithUsers(userBackend.Users())
+		}
+		authOpts = append(authOpts, httpx.WithKeyVerifier(verifier))
+		deps.Logger.Info().Msg("API key authentication enabled")
+	}
+
+	// OIDC mode verifies ID tokens from the configured identity provider
+	if cfg.Auth.Mode == "oidc" {
+		provider := auth.NewOIDCProvider(cfg.Auth.OIDC)
+		if userBackend, ok := deps.Storage.(storage.UserBackend); ok {
+			provider = provider.WithUsers(userBackend.Users())
+		}
+		authOpts = append(authOpts, httpx.WithOIDCVerifier(provider))
+		// Browser login needs a registered callback; without it only bearer tokens are accepted
+		if cfg.Auth.OIDC.RedirectURL != "" {
+			apiDeps.OIDC = provider
+		}
+		deps.Logger.Info().Str("issuer", cfg.Auth.OIDC.IssuerURL).Msg("OIDC authentication enabled")
+	}
+
 	// Create router with all endpoints mounted
 	router := httpx.NewRouter(cfg, apiDeps)
 
@@ -61,26 +223,59 @@ func New(ctx context.Context, cfg config.ServerConfig, deps *Deps) (*App, error)
 		deps.Logger.Warn().Msg("UI serving disabled")
 	}
 
+	// API limits and failed authentications count across REST and gRPC;
+	// daily scan quotas are shared by replicas when the backend counts them
+	limiter := httpx.NewRateLimiter(cfg.RateLimit)
+	if quotaBackend, ok := deps.Storage.(storage.QuotaBackend); ok {
+		limiter.WithQuotaStore(quotaBackend.Quotas())
+	}
+	authOpts = append(authOpts,
+		httpx.WithRateLimiter(limiter),
+		httpx.WithFailedAuthLimiter(httpx.NewFailedAuthLimiter(cfg.RateLimit.FailedAuthPerMinute)),
+	)
+
 	// Create HTTP server with middleware
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Addr, cfg.Port),
-		Handler:      httpx.Chain(cfg, router),
+		Handler:      httpx.Chain(cfg, router, authOpts...),
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 	}
 
-	// Create job manager
-	var jobsMgr jobs.Manager
-	if cfg.JobsEnabled {
-		jobsMgr = jobs.NewMemoryManager(cfg.Concurrency)
+	// HTTPS, verifying client certificates with a client CA bundle
+	if cfg.TLS.IsEnabled() {
+		reloader, err := certs.NewReloader(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		httpServer.TLSConfig = reloader.TLSConfig(cfg.TLS)
+		if cfg.TLS.IsMutual() {
+			deps.Logger.Info().Str("client_ca", cfg.TLS.ClientCAFile).Msg("Mutual TLS enabled")
+		} else {
+			deps.Logger.Info().Msg("TLS enabled")
+		}
+	}
+
+	// gRPC API on its own port, authenticated, scoped and tenant isolated
+	// like the REST API; TLS settings are shared with the HTTP listener
+	var grpcServer *grpc.Server
+	if cfg.APIEnabled && cfg.GRPCPort != 0 {
+		var grpcOpts []grpc.ServerOption
+		if httpServer.TLSConfig != nil {
+			grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(httpServer.TLSConfig.Clone())))
+		}
+		grpcServer = grpcapi.NewServer(apiDeps, httpx.NewAuthenticator(cfg, authOpts...), grpcOpts...)
+		deps.Logger.Info().Str("addr", cfg.GRPCListenAddr()).Msg("gRPC API enabled")
 	}
 
 	return &App{
 		HTTP:   httpServer,
+		GRPC:   grpcServer,
 		Jobs:   jobsMgr,
 		Ready:  ready,
 		Config: cfg,
 		Deps:   deps,
+		Leader: elector,
 	}, nil
 }
 
@@ -91,19 +286,49 @@ func (a *App) Run(ctx context.Context) error {
 		Bool("api", a.Config.APIEnabled).
 		Bool("ui", a.Config.UIEnabled).
 		Bool("jobs", a.Config.JobsEnabled).
+		Bool("ha", a.Leader != nil).
+		Bool("tls", a.HTTP.TLSConfig != nil).
+		Str("grpc_addr", a.Config.GRPCListenAddr()).
 		Msg("Starting Vulntor server")
 
+	// Bind the gRPC port first, so a taken port fails the start
+	var grpcListener net.Listener
+	if a.GRPC != nil {
+		var err error
+		if grpcListener, err = net.Listen("tcp", a.Config.GRPCListenAddr()); err != nil {
+			return fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}
+
 	// Start HTTP server in goroutine
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
 	go func() {
-		if err := a.HTTP.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if a.HTTP.TLSConfig != nil {
+			// Certificates come from the TLS config, reloaded on change
+			err = a.HTTP.ListenAndServeTLS("", "")
+		} else {
+			err = a.HTTP.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			serverErr <- fmt.Errorf("HTTP server failed: %w", err)
 		}
 	}()
 
-	// Start background jobs
+	// Start gRPC server in goroutine
+	if grpcListener != nil {
+		go func() {
+			if err := a.GRPC.Serve(grpcListener); err != nil && err != grpc.ErrServerStopped {
+				serverErr <- fmt.Errorf("gRPC server failed: %w", err)
+			}
+		}()
+	}
+
+	// Start background jobs, on the leader only with HA
 	if a.Config.JobsEnabled && a.Jobs != nil {
-		if err := a.Jobs.Start(ctx); err != nil {
+		if a.Leader != nil {
+			a.campaign(ctx)
+		} else if err := a.Jobs.Start(ctx); err != nil {
 			return fmt.Errorf("start jobs: %w", err)
 		}
 	}
@@ -125,6 +350,29 @@ func (a *App) Run(ctx context.Context) error {
 	return a.shutdown()
 }
 
+// campaign runs the leader election in the background: the jobs run while
+// this replica leads and stop when it loses the lease.
+func (a *App) campaign(ctx context.Context) {
+	ctx, a.stopLeader = context.WithCancel(ctx)
+	a.leaderDone = make(chan struct{})
+	go func() {
+		defer close(a.leaderDone)
+		a.Leader.Run(ctx, func(ctx context.Context) {
+			if err := a.Jobs.Start(ctx); err != nil {
+				a.Deps.Logger.Error().Err(err).Msg("Failed to start jobs as leader")
+				return
+			}
+			<-ctx.Done()
+
+			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := a.Jobs.Stop(stopCtx); err != nil {
+				a.Deps.Logger.Error().Err(err).Msg("Jobs shutdown failed")
+			}
+		})
+	}()
+}
+
 // shutdown performs graceful shutdown of all components.
 func (a *App) shutdown() error {
 	a.Deps.Logger.Info().Msg("Initiating graceful shutdown")
@@ -143,8 +391,34 @@ func (a *App) shutdown() error {
 	}
 	a.Deps.Logger.Info().Msg("HTTP server stopped")
 
-	// Stop background jobs
-	if a.Config.JobsEnabled && a.Jobs != nil {
+	// Shutdown gRPC server; open streams are cut when the deadline passes
+	if a.GRPC != nil {
+		a.Deps.Logger.Info().Msg("Shutting down gRPC server...")
+		stopped := make(chan struct{})
+		go func() {
+			a.GRPC.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			a.GRPC.Stop()
+		}
+		a.Deps.Logger.Info().Msg("gRPC server stopped")
+	}
+
+	// Stop background jobs; the leader stops them before giving up the lease
+	if a.Leader != nil && a.stopLeader != nil {
+		a.Deps.Logger.Info().Msg("Leaving leader election...")
+		a.stopLeader()
+		select {
+		case <-a.leaderDone:
+		case <-shutdownCtx.Done():
+			a.Deps.Logger.Error().Msg("Leader election shutdown timed out")
+			return shutdownCtx.Err()
+		}
+		a.Deps.Logger.Info().Msg("Left leader election")
+	} else if a.Config.JobsEnabled && a.Jobs != nil {
 		a.Deps.Logger.Info().Msg("Stopping background jobs...")
 		if err := a.Jobs.Stop(shutdownCtx); err != nil {
 			a.Deps.Logger.Error().Err(err).Msg("Jobs shutdown failed")
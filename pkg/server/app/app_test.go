@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
 	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -11,6 +14,8 @@ import (
 
 	"github.com/vulntor/vulntor/pkg/config"
 	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/certs"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 // Mock workspace
@@ -183,3 +188,156 @@ func TestApp_LifecycleWithoutJobs(t *testing.T) {
 		t.Fatal("Shutdown timeout")
 	}
 }
+
+func TestNew_APIKeyAuth(t *testing.T) {
+	cfg := config.ServerConfig{
+		Addr:         "127.0.0.1",
+		Port:         9997,
+		APIEnabled:   true,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		Auth:         config.AuthConfig{Mode: "apikey"},
+	}
+
+	// Backend without API key support cannot serve apikey mode
+	_, err := New(context.Background(), cfg, &Deps{Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.ErrorContains(t, err, "requires a storage backend with API key support")
+
+	backend := newTestBackend(t)
+	app, err := New(context.Background(), cfg, &Deps{Storage: backend, Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	w := httptest.NewRecorder()
+	app.HTTP.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestNew_OIDCAuth(t *testing.T) {
+	cfg := config.ServerConfig{
+		Addr:         "127.0.0.1",
+		Port:         9996,
+		APIEnabled:   true,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		Auth: config.AuthConfig{Mode: "oidc", OIDC: config.OIDCConfig{
+			IssuerURL:   "https://idp.example.com",
+			ClientID:    "vulntor",
+			RedirectURL: "https://vulntor.example.com/auth/callback",
+		}},
+	}
+
+	// IdP discovery is lazy, so the server starts without reaching the IdP
+	app, err := New(context.Background(), cfg, &Deps{Storage: newTestBackend(t), Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	w := httptest.NewRecorder()
+	app.HTTP.Handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestNew_TLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, issued, err := certs.NewCA("Test CA", 0)
+	require.NoError(t, err)
+	_, _, err = certs.WriteFiles(dir, "ca", issued, false)
+	require.NoError(t, err)
+	leaf, err := ca.Issue(certs.Request{CommonName: "server"})
+	require.NoError(t, err)
+	certFile, keyFile, err := certs.WriteFiles(dir, "server", leaf, false)
+	require.NoError(t, err)
+
+	cfg := config.ServerConfig{
+		Addr:         "127.0.0.1",
+		Port:         9995,
+		APIEnabled:   true,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		TLS:          config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: filepath.Join(dir, certs.CACertFile)},
+	}
+	app, err := New(context.Background(), cfg, &Deps{Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+	require.NotNil(t, app.HTTP.TLSConfig)
+	require.Equal(t, tls.RequireAndVerifyClientCert, app.HTTP.TLSConfig.ClientAuth)
+
+	// Unreadable certificates fail the start
+	cfg.TLS.CertFile = filepath.Join(dir, "missing.crt")
+	_, err = New(context.Background(), cfg, &Deps{Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.ErrorContains(t, err, "tls:")
+}
+
+func TestNew_MigratesStoredScans(t *testing.T) {
+	cfg := config.ServerConfig{
+		Addr:         "127.0.0.1",
+		Port:         9995,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+
+	// A scan stored before schema versioning
+	backend := newTestBackend(t)
+	ctx := context.Background()
+	require.NoError(t, backend.Scans().Create(ctx, storage.DefaultOrgID, &storage.ScanMetadata{ID: "legacy", Target: "10.0.0.1", Status: "completed"}))
+	legacy := 0
+	require.NoError(t, backend.Scans().Update(ctx, storage.DefaultOrgID, "legacy", storage.ScanUpdates{SchemaVersion: &legacy}))
+
+	_, err := New(ctx, cfg, &Deps{Storage: backend, Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+
+	scan, err := backend.Scans().Get(ctx, storage.DefaultOrgID, "legacy")
+	require.NoError(t, err)
+	require.Equal(t, storage.ScanSchemaVersion, scan.SchemaVersion)
+}
+
+func TestApp_LeaderElection(t *testing.T) {
+	cfg := config.ServerConfig{
+		Addr:         "127.0.0.1",
+		Port:         9994,
+		APIEnabled:   true,
+		JobsEnabled:  true,
+		Concurrency:  1,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		HA:           config.HAConfig{Enabled: true, NodeID: "node-a", LeaseTTL: 300 * time.Millisecond},
+	}
+
+	// Replicas elect a leader through the storage backend
+	_, err := New(context.Background(), cfg, &Deps{Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.ErrorContains(t, err, "ha requires a storage backend")
+
+	backend := newTestBackend(t)
+	run := func(app *App) (stop func()) {
+		ctx, cancel := context.WithCancel(context.Background())
+		appErr := make(chan error, 1)
+		go func() { appErr <- app.Run(ctx) }()
+		return func() {
+			cancel()
+			select {
+			case err := <-appErr:
+				require.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("Shutdown timeout")
+			}
+		}
+	}
+
+	a, err := New(context.Background(), cfg, &Deps{Storage: backend, Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+	require.Equal(t, "node-a", a.Leader.ID())
+	stopA := run(a)
+	require.Eventually(t, a.Leader.IsLeader, 2*time.Second, 10*time.Millisecond)
+
+	cfg.Port = 9993
+	cfg.HA.NodeID = "node-b"
+	b, err := New(context.Background(), cfg, &Deps{Storage: backend, Workspace: &mockWorkspace{}, Logger: zerolog.Nop()})
+	require.NoError(t, err)
+	stopB := run(b)
+	defer stopB()
+	require.Eventually(t, b.Ready.Load, 2*time.Second, 10*time.Millisecond)
+	require.False(t, b.Leader.IsLeader(), "a follower serves the API without running jobs")
+
+	// The follower takes over when the leader goes away
+	stopA()
+	require.Eventually(t, b.Leader.IsLeader, 2*time.Second, 10*time.Millisecond)
+}
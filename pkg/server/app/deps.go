@@ -4,8 +4,10 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/plugin"
 	"github.com/vulntor/vulntor/pkg/server/api"
 	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/telemetry"
 )
 
 // Deps holds dependencies for the server application.
@@ -24,6 +26,25 @@ type Deps struct {
 	// Type asserted in router to v1.PluginService
 	PluginService any
 
+	// TenantPluginService builds an isolated plugin service for a non-default
+	// tenant (own cache and, optionally, own plugin sources). The result must
+	// implement v1.PluginService. When it is a *plugin.Service with an
+	// installed set, the set is loaded, kept in sync with the tenant's
+	// manifest and evaluated by the tenant's scans. Nil serves every tenant
+	// from PluginService.
+	TenantPluginService func(tenant *storage.Tenant) (any, error)
+
+	// InstalledPlugins are evaluated by scans of the default tenant besides
+	// the embedded plugins.
+	// PluginService reloads them when plugins are installed or removed;
+	// each scan keeps the set it started with. Nil evaluates embedded
+	// plugins only.
+	InstalledPlugins *plugin.InstalledSet
+
+	// Telemetry reports anonymous aggregates of recent scans after a scan
+	// finishes, when opted in and due. Nil reports nothing.
+	Telemetry *telemetry.Reporter
+
 	// Config manager for runtime configuration
 	Config *config.Manager
 
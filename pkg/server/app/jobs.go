@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// jobStore persists the job queue in a storage backend. Payloads are
+// stored as JSON and handed back to handlers as json.RawMessage.
+type jobStore struct {
+	store storage.JobStore
+}
+
+// Save persists a queued job.
+func (s *jobStore) Save(ctx context.Context, job jobs.Job) error {
+	payload, err := json.Marshal(job.Payload)
+	if err != nil {
+		return fmt.Errorf("encode job payload: %w", err)
+	}
+	return s.store.Save(ctx, &storage.QueuedJob{
+		ID:          job.ID,
+		Type:        job.Type,
+		OrgID:       job.Tenant,
+		Priority:    job.Priority,
+		SubmittedAt: job.SubmittedAt,
+		Payload:     payload,
+	})
+}
+
+// Delete removes a job.
+func (s *jobStore) Delete(ctx context.Context, jobID string) error {
+	return s.store.Delete(ctx, jobID)
+}
+
+// Load returns the persisted jobs in submission order.
+func (s *jobStore) Load(ctx context.Context) ([]jobs.Job, error) {
+	queued, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]jobs.Job, 0, len(queued))
+	for _, q := range queued {
+		out = append(out, jobs.Job{
+			ID:          q.ID,
+			Type:        q.Type,
+			Payload:     q.Payload,
+			Tenant:      q.OrgID,
+			Priority:    q.Priority,
+			SubmittedAt: q.SubmittedAt,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// tenantPluginService implements v1.PluginService by routing each call to
+// the plugin service of the request's tenant (storage.OrgIDFromContext).
+//
+// The default tenant uses the server's plugin service; other tenants get
+// their own service, built on first use and rebuilt when their plugin
+// sources change. A tenant service with an installed set has it loaded and
+// watches its manifest until it is rebuilt or ctx ends.
+type tenantPluginService struct {
+	ctx     context.Context
+	base    v1.PluginService
+	tenants storage.TenantStore
+	build   func(tenant *storage.Tenant) (any, error)
+	logger  zerolog.Logger
+
+	mu       sync.Mutex
+	services map[string]*tenantPluginEntry
+}
+
+type tenantPluginEntry struct {
+	svc       v1.PluginService
+	sources   []storage.TenantPluginSource
+	installed *plugin.InstalledSet
+	stop      context.CancelFunc
+}
+
+// installedService is a plugin service keeping an installed set in sync
+// with its manifest (*plugin.Service).
+type installedService interface {
+	InstalledSet() *plugin.InstalledSet
+	ReloadInstalled(ctx context.Context)
+	StartManifestWatcher(ctx context.Context) error
+}
+
+func newTenantPluginService(ctx context.Context, base v1.PluginService, tenants storage.TenantStore, build func(*storage.Tenant) (any, error)) *tenantPluginService {
+	return &tenantPluginService{
+		ctx:      ctx,
+		base:     base,
+		tenants:  tenants,
+		build:    build,
+		services: make(map[string]*tenantPluginEntry),
+	}
+}
+
+// forContext returns the plugin service of the request's tenant.
+func (s *tenantPluginService) forContext(ctx context.Context) (v1.PluginService, error) {
+	orgID := storage.OrgIDFromContext(ctx)
+	if orgID == storage.DefaultOrgID {
+		return s.base, nil
+	}
+
+	tenant, err := s.tenants.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.services[orgID]; ok && slices.Equal(e.sources, tenant.PluginSources) {
+		return e.svc, nil
+	}
+
+	built, err := s.build(tenant)
+	if err != nil {
+		return nil, fmt.Errorf("init plugin service for tenant %s: %w", orgID, err)
+	}
+	svc, ok := built.(v1.PluginService)
+	if !ok {
+		return nil, fmt.Errorf("tenant plugin service %T does not implement v1.PluginService", built)
+	}
+	entry := &tenantPluginEntry{svc: svc, sources: slices.Clone(tenant.PluginSources)}
+	if loader, ok := built.(installedService); ok && loader.InstalledSet() != nil {
+		// Loaded before the first scan; the watcher keeps it current
+		watchCtx, stop := context.WithCancel(s.ctx)
+		loader.ReloadInstalled(watchCtx)
+		go func() {
+			if err := loader.StartManifestWatcher(watchCtx); err != nil && watchCtx.Err() == nil {
+				s.logger.Warn().Str("tenant", orgID).Err(err).
+					Msg("Manifest watcher failed (tenant plugins won't reload on changes)")
+			}
+		}()
+		entry.installed, entry.stop = loader.InstalledSet(), stop
+	}
+	if old, ok := s.services[orgID]; ok && old.stop != nil {
+		old.stop()
+	}
+	s.services[orgID] = entry
+	return svc, nil
+}
+
+// installedPlugins returns the installed set of tenant orgID's plugin
+// service (see scanexec.Service.WithTenantInstalledPlugins), building the
+// service when the tenant has not used it yet. Nil means the tenant's scans
+// evaluate the embedded plugins only.
+func (s *tenantPluginService) installedPlugins(ctx context.Context, orgID string) *plugin.InstalledSet {
+	if _, err := s.forContext(storage.WithOrgID(ctx, orgID)); err != nil {
+		s.logger.Warn().Str("tenant", orgID).Err(err).Msg("Tenant plugins unavailable; scan evaluates embedded plugins only")
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.services[orgID]; ok {
+		return e.installed
+	}
+	return nil
+}
+
+func (s *tenantPluginService) Install(ctx context.Context, target string, opts plugin.InstallOptions) (*plugin.InstallResult, error) {
+	svc, err := s.forContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Install(ctx, target, opts)
+}
+
+func (s *tenantPluginService) Update(ctx context.Context, opts plugin.UpdateOptions) (*plugin.UpdateResult, error) {
+	svc, err := s.forContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Update(ctx, opts)
+}
+
+func (s *tenantPluginService) Uninstall(ctx context.Context, target string, opts plugin.UninstallOptions) (*plugin.UninstallResult, error) {
+	svc, err := s.forContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svc.Uninstall(ctx, target, opts)
+}
+
+func (s *tenantPluginService) List(ctx context.Context) ([]*plugin.PluginInfo, error) {
+	svc, err := s.forContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svc.List(ctx)
+}
+
+func (s *tenantPluginService) GetInfo(ctx context.Context, id string) (*plugin.PluginInfo, error) {
+	svc, err := s.forContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return svc.GetInfo(ctx, id)
+}
+
+// auditedPluginService implements v1.PluginService by recording plugin
+// installs, updates and uninstalls in the audit log. Reads pass through.
+type auditedPluginService struct {
+	v1.PluginService
+	audit *audit.Recorder
+}
+
+func (s *auditedPluginService) Install(ctx context.Context, target string, opts plugin.InstallOptions) (*plugin.InstallResult, error) {
+	result, err := s.PluginService.Install(ctx, target, opts)
+	details := map[string]string{"force": strconv.FormatBool(opts.Force)}
+	if opts.Source != "" {
+		details["source"] = opts.Source
+	}
+	if result != nil {
+		details["installed"] = strconv.Itoa(result.InstalledCount)
+	}
+	s.audit.Record(ctx, "plugin.install", target, err, details)
+	return result, err
+}
+
+func (s *auditedPluginService) Update(ctx context.Context, opts plugin.UpdateOptions) (*plugin.UpdateResult, error) {
+	result, err := s.PluginService.Update(ctx, opts)
+	details := map[string]string{"force": strconv.FormatBool(opts.Force)}
+	if opts.Source != "" {
+		details["source"] = opts.Source
+	}
+	if result != nil {
+		details["updated"] = strconv.Itoa(result.UpdatedCount)
+	}
+	s.audit.Record(ctx, "plugin.update", string(opts.Category), err, details)
+	return result, err
+}
+
+func (s *auditedPluginService) Uninstall(ctx context.Context, target string, opts plugin.UninstallOptions) (*plugin.UninstallResult, error) {
+	result, err := s.PluginService.Uninstall(ctx, target, opts)
+	details := map[string]string{"all": strconv.FormatBool(opts.All)}
+	if opts.Category != "" {
+		details["category"] = string(opts.Category)
+	}
+	if result != nil {
+		details["removed"] = strconv.Itoa(result.RemovedCount)
+	}
+	s.audit.Record(ctx, "plugin.uninstall", target, err, details)
+	return result, err
+}
@@ -0,0 +1,165 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// namedPluginService reports its name as the only installed plugin.
+type namedPluginService struct{ name string }
+
+func (s *namedPluginService) Install(ctx context.Context, target string, opts plugin.InstallOptions) (*plugin.InstallResult, error) {
+	return &plugin.InstallResult{}, nil
+}
+
+func (s *namedPluginService) Update(ctx context.Context, opts plugin.UpdateOptions) (*plugin.UpdateResult, error) {
+	return &plugin.UpdateResult{}, nil
+}
+
+func (s *namedPluginService) Uninstall(ctx context.Context, target string, opts plugin.UninstallOptions) (*plugin.UninstallResult, error) {
+	return &plugin.UninstallResult{}, nil
+}
+
+func (s *namedPluginService) List(ctx context.Context) ([]*plugin.PluginInfo, error) {
+	return []*plugin.PluginInfo{{ID: s.name}}, nil
+}
+
+func (s *namedPluginService) GetInfo(ctx context.Context, id string) (*plugin.PluginInfo, error) {
+	return &plugin.PluginInfo{ID: s.name}, nil
+}
+
+func TestTenantPluginService(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t).(storage.TenantBackend)
+	tenants := backend.Tenants()
+	require.NoError(t, tenants.Create(ctx, &storage.Tenant{ID: "team-a"}))
+
+	builds := 0
+	svc := newTenantPluginService(context.Background(), &namedPluginService{name: "base"}, tenants, func(tenant *storage.Tenant) (any, error) {
+		builds++
+		return &namedPluginService{name: tenant.ID}, nil
+	})
+
+	list := func(orgID string) string {
+		t.Helper()
+		infos, err := svc.List(storage.WithOrgID(ctx, orgID))
+		require.NoError(t, err)
+		return infos[0].ID
+	}
+
+	require.Equal(t, "base", list(storage.DefaultOrgID))
+	require.Equal(t, "team-a", list("team-a"))
+	require.Equal(t, "team-a", list("team-a"))
+	require.Equal(t, 1, builds, "tenant service is cached")
+
+	// Changing the tenant's plugin sources rebuilds its service
+	require.NoError(t, tenants.Delete(ctx, "team-a"))
+	require.NoError(t, tenants.Create(ctx, &storage.Tenant{
+		ID:            "team-a",
+		PluginSources: []storage.TenantPluginSource{{Name: "internal", URL: "https://plugins.example.com/registry.yaml"}},
+	}))
+	require.Equal(t, "team-a", list("team-a"))
+	require.Equal(t, 2, builds)
+
+	_, err := svc.List(storage.WithOrgID(ctx, "unknown"))
+	require.True(t, storage.IsNotFound(err))
+}
+
+// installPlugin writes an evaluation plugin into the cache below cacheDir
+// and records it in the manifest next to it, as an install does.
+func installPlugin(t *testing.T, cacheDir, id string) {
+	t.Helper()
+	dir := filepath.Join(cacheDir, id, "1.0.0")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.yaml"), []byte("id: "+id+`
+name: `+id+`
+version: 1.0.0
+type: evaluation
+author: test
+metadata:
+  severity: high
+output:
+  vulnerability: true
+  message: Test finding
+`), 0o644))
+	manifest, err := plugin.NewManifestManager(filepath.Join(filepath.Dir(cacheDir), "registry.json"))
+	require.NoError(t, err)
+	require.NoError(t, manifest.Load())
+	require.NoError(t, manifest.Add(&plugin.ManifestEntry{ID: id, Name: id, Version: "1.0.0"}))
+	require.NoError(t, manifest.Save())
+}
+
+func TestTenantPluginService_InstalledPluginsIsolated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	backend := newTestBackend(t).(storage.TenantBackend)
+	tenants := backend.Tenants()
+	require.NoError(t, tenants.Create(ctx, &storage.Tenant{ID: "team-a"}))
+	require.NoError(t, tenants.Create(ctx, &storage.Tenant{ID: "team-b"}))
+
+	root := t.TempDir()
+	cacheDir := func(orgID string) string { return filepath.Join(root, "tenants", orgID, "cache") }
+	installPlugin(t, cacheDir("team-a"), "team-a-check")
+	installPlugin(t, cacheDir("team-b"), "team-b-check")
+
+	svc := newTenantPluginService(ctx, &namedPluginService{name: "base"}, tenants, func(tenant *storage.Tenant) (any, error) {
+		return plugin.NewService(plugin.WithCacheDir(cacheDir(tenant.ID)), plugin.WithInstalledSet(plugin.NewInstalledSet()))
+	})
+
+	// The plugins a scan of orgID evaluates (scanexec snapshots the set)
+	ids := func(orgID string) []string {
+		var ids []string
+		for _, p := range svc.installedPlugins(ctx, orgID).Snapshot() {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	// Loaded on the first scan, without a plugin API call before
+	require.Equal(t, []string{"team-a-check"}, ids("team-a"))
+	require.Equal(t, []string{"team-b-check"}, ids("team-b"))
+	require.Nil(t, svc.installedPlugins(ctx, "unknown"))
+
+	// A plugin team-b installs later reaches team-b's scans only. The
+	// install is repeated until the watcher, started in the background,
+	// sees it
+	require.Eventually(t, func() bool {
+		installPlugin(t, cacheDir("team-b"), "team-b-extra")
+		return len(ids("team-b")) == 2
+	}, 10*time.Second, 250*time.Millisecond)
+	require.Equal(t, []string{"team-a-check"}, ids("team-a"))
+}
+
+func TestAuditedPluginService(t *testing.T) {
+	ctx := context.Background()
+	backend := newTestBackend(t)
+	store := backend.(storage.AuditBackend).Audit()
+	svc := &auditedPluginService{
+		PluginService: &namedPluginService{name: "base"},
+		audit:         audit.NewRecorder(store, audit.SourceAPI),
+	}
+
+	_, err := svc.Install(ctx, "ssh-weak-cipher", plugin.InstallOptions{Force: true})
+	require.NoError(t, err)
+	_, err = svc.Uninstall(ctx, "ssh-weak-cipher", plugin.UninstallOptions{})
+	require.NoError(t, err)
+	_, err = svc.List(ctx)
+	require.NoError(t, err)
+
+	events, err := store.List(ctx, storage.DefaultOrgID, storage.AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 2, "reads are not audited")
+	require.Equal(t, "plugin.uninstall", events[0].Action)
+	require.Equal(t, "plugin.install", events[1].Action)
+	require.Equal(t, "ssh-weak-cipher", events[1].Resource)
+	require.Equal(t, "true", events[1].Details["force"])
+}
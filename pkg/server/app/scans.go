@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/capture"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/scope"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/tracing"
+)
+
+// scanJobType is the job type used for API-submitted scans.
+const scanJobType = "scan"
+
+// scanRunner executes a scan synchronously (scanexec.Service.Run in production).
+type scanRunner func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error)
+
+// scanJobService implements v1.ScanService by registering scan metadata
+// up-front and executing the scan on the background job manager.
+type scanJobService struct {
+	storage    storage.Backend
+	jobs       jobs.Manager
+	run        scanRunner
+	events     events.Publisher
+	audit      *audit.Recorder   // nil disables audit records
+	scope      *scope.Policy     // nil permits every target
+	engagement engagement.Policy // defaults and requirements of engagement records
+}
+
+// newScanJobService wires a scan service onto the given job manager.
+// The scan handler is registered on managers that support it.
+// pub may be nil to disable live events.
+func newScanJobService(backend storage.Backend, mgr jobs.Manager, run scanRunner, pub events.Publisher) *scanJobService {
+	svc := &scanJobService{
+		storage: backend,
+		jobs:    mgr,
+		run:     run,
+		events:  pub,
+	}
+	if h, ok := mgr.(interface {
+		Handle(jobType string, h jobs.Handler)
+	}); ok {
+		h.Handle(scanJobType, svc.handle)
+	}
+	return svc
+}
+
+// Submit registers a pending scan and enqueues it for execution.
+func (s *scanJobService) Submit(ctx context.Context, req v1.CreateScanRequest) (_ *v1.CreateScanResponse, err error) {
+	scanID := uuid.New().String()
+	orgID := storage.OrgIDFromContext(ctx)
+	var override *scope.Acknowledgment
+	record := s.engagement.Resolve(req.Engagement)
+
+	defer func() {
+		details := map[string]string{
+			"targets": strings.Join(req.Targets, ","),
+			"profile": req.Profile,
+		}
+		if len(req.Groups) > 0 {
+			details["groups"] = strings.Join(req.Groups, ",")
+		}
+		if override != nil {
+			details["scope_ack_by"] = override.By
+			details["scope_ack_reason"] = override.Reason
+		}
+		if record != nil {
+			details["engagement_client"] = record.Client
+			details["engagement_authorization"] = record.Authorization
+			details["engagement_tester"] = record.Tester
+		}
+		s.audit.Record(ctx, "scan.create", scanID, err, details)
+	}()
+
+	priority, err := jobs.ParsePriority(req.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	// Groups are resolved now, so that unknown groups fail the request and
+	// later group changes do not alter the queued scan
+	groups, err := scanexec.ResolveTargetGroups(ctx, s.storage, orgID, req.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	// Targets outside the scan scope are refused now rather than when the
+	// scan starts; the run checks them again
+	override, err = s.scope.Check(ctx, scanexec.GroupTargets(req.Targets, groups), req.ScopeAck)
+	if err != nil {
+		return nil, err
+	}
+
+	// Likewise the engagement: scans without the record assessment mode
+	// requires, or outside their window, are refused
+	if err = s.engagement.Check(record, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if s.storage != nil {
+		metadata := &storage.ScanMetadata{
+			ID:              scanID,
+			OrgID:           orgID,
+			UserID:          "local",
+			Target:          scanexec.TargetSummary(scanexec.GroupTargets(req.Targets, groups)),
+			Groups:          req.Groups,
+			Engagement:      record,
+			Status:          string(storage.StatusPending),
+			StartedAt:       time.Now(),
+			StorageLocation: fmt.Sprintf("scans/%s/%s", orgID, scanID),
+		}
+		if err := s.storage.Scans().Create(ctx, orgID, metadata); err != nil {
+			return nil, fmt.Errorf("create scan metadata: %w", err)
+		}
+	}
+
+	params := scanexec.Params{
+		ScanID:         scanID,
+		OrgID:          orgID,
+		Targets:        req.Targets,
+		TargetGroups:   groups,
+		Profile:        req.Profile,
+		Ports:          req.Ports,
+		EnableVuln:     req.EnableVuln,
+		OnlyDiscover:   req.OnlyDiscover,
+		SkipDiscover:   req.SkipDiscover,
+		Concurrency:    req.Concurrency,
+		CustomTimeout:  req.Timeout,
+		AutoTune:       req.AutoTune,
+		VerifyTimeouts: req.VerifyTimeouts,
+		Capture:        capture.Mode(req.Pcap),
+		ScopeAck:       req.ScopeAck,
+		Engagement:     record,
+		OutputFormat:   "json",
+		Trace:          tracing.SpanFromContext(ctx).SpanContext(),
+	}
+
+	job := jobs.Job{ID: scanID, Type: scanJobType, Payload: params, Tenant: orgID, Priority: priority}
+	if err := s.jobs.Submit(ctx, job); err != nil {
+		s.markFailed(ctx, orgID, scanID, err)
+		s.publishFinished(scanID, err)
+		return nil, err
+	}
+
+	return &v1.CreateScanResponse{
+		ID:     scanID,
+		JobID:  scanID,
+		Status: string(storage.StatusPending),
+	}, nil
+}
+
+// handle runs a queued scan job. Jobs recovered from the job store carry
+// their parameters as JSON.
+func (s *scanJobService) handle(ctx context.Context, job jobs.Job) error {
+	params, err := scanPayload(job.Payload)
+	if err != nil {
+		orgID := job.Tenant
+		if orgID == "" {
+			orgID = storage.OrgIDFromContext(ctx)
+		}
+		s.markFailed(ctx, orgID, job.ID, err)
+		s.publishFinished(job.ID, err)
+		return err
+	}
+	if s.events != nil {
+		ctx = engine.WithOutputObserver(ctx, events.Observer(s.events, job.ID))
+	}
+
+	_, err = s.run(ctx, params)
+	s.publishFinished(job.ID, err)
+	return err
+}
+
+// scanPayload returns the scan parameters of a job payload.
+func scanPayload(payload any) (scanexec.Params, error) {
+	switch p := payload.(type) {
+	case scanexec.Params:
+		return p, nil
+	case json.RawMessage:
+		var params scanexec.Params
+		if err := json.Unmarshal(p, &params); err != nil {
+			return scanexec.Params{}, fmt.Errorf("decode scan job payload: %w", err)
+		}
+		return params, nil
+	default:
+		return scanexec.Params{}, fmt.Errorf("unexpected scan job payload %T", payload)
+	}
+}
+
+// publishFinished emits the terminal scan event.
+func (s *scanJobService) publishFinished(scanID string, runErr error) {
+	if s.events == nil {
+		return
+	}
+	data := map[string]string{"status": string(storage.StatusCompleted)}
+	if runErr != nil {
+		data["status"] = string(storage.StatusFailed)
+		data["error"] = runErr.Error()
+	}
+	s.events.Publish(events.Event{Type: events.TypeScanFinished, ScanID: scanID, Data: data})
+}
+
+// markFailed records a failure for scans that never reached the runner.
+func (s *scanJobService) markFailed(ctx context.Context, orgID, scanID string, cause error) {
+	if s.storage == nil {
+		return
+	}
+	status := string(storage.StatusFailed)
+	msg := cause.Error()
+	now := time.Now()
+	_ = s.storage.Scans().Update(ctx, orgID, scanID, storage.ScanUpdates{
+		Status:       &status,
+		ErrorMessage: &msg,
+		CompletedAt:  &now,
+	})
+}
@@ -0,0 +1,362 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/audit"
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/scope"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newTestBackend(t *testing.T) storage.Backend {
+	t.Helper()
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.Initialize(context.Background()))
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend
+}
+
+func TestScanJobService_SubmitRunsScan(t *testing.T) {
+	backend := newTestBackend(t)
+	mgr := jobs.NewMemoryManager(1)
+
+	got := make(chan scanexec.Params, 1)
+	svc := newScanJobService(backend, mgr, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got <- params
+		return &scanexec.Result{RunID: params.ScanID}, nil
+	}, nil)
+
+	resp, err := svc.Submit(context.Background(), v1.CreateScanRequest{
+		Targets: []string{"10.0.0.1", "10.0.0.2"},
+		Ports:   "22",
+		Timeout: "1s",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.ID)
+	require.Equal(t, resp.ID, resp.JobID)
+	require.Equal(t, "pending", resp.Status)
+
+	// Metadata is visible before the job runs
+	meta, err := backend.Scans().Get(context.Background(), "default", resp.ID)
+	require.NoError(t, err)
+	require.Equal(t, "pending", meta.Status)
+	require.Equal(t, "10.0.0.1 (and 1 more)", meta.Target)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = mgr.Stop(ctx)
+	})
+
+	select {
+	case params := <-got:
+		require.Equal(t, resp.ID, params.ScanID)
+		require.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, params.Targets)
+		require.Equal(t, "22", params.Ports)
+		require.Equal(t, "1s", params.CustomTimeout)
+	case <-time.After(time.Second):
+		t.Fatal("scan runner was not invoked")
+	}
+
+	require.Eventually(t, func() bool {
+		st, err := mgr.Status(context.Background(), resp.ID)
+		return err == nil && st.State == jobs.StateCompleted
+	}, time.Second, 5*time.Millisecond)
+}
+
+type failingManager struct{ err error }
+
+func (f *failingManager) Start(ctx context.Context) error { return nil }
+func (f *failingManager) Stop(ctx context.Context) error  { return nil }
+func (f *failingManager) Submit(ctx context.Context, job jobs.Job) error {
+	return f.err
+}
+
+func (f *failingManager) Status(ctx context.Context, jobID string) (*jobs.Status, error) {
+	return nil, jobs.ErrJobNotFound
+}
+
+func TestScanJobService_SubmitQueueFullMarksFailed(t *testing.T) {
+	backend := newTestBackend(t)
+	svc := newScanJobService(backend, &failingManager{err: jobs.ErrQueueFull}, nil, nil)
+
+	_, err := svc.Submit(context.Background(), v1.CreateScanRequest{Targets: []string{"10.0.0.1"}})
+	require.ErrorIs(t, err, jobs.ErrQueueFull)
+
+	scans, err := backend.Scans().List(context.Background(), "default", storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+	require.Equal(t, "failed", scans[0].Status)
+	require.Equal(t, jobs.ErrQueueFull.Error(), scans[0].ErrorMessage)
+}
+
+func TestScanJobService_SubmitAudited(t *testing.T) {
+	backend := newTestBackend(t)
+	store := backend.(storage.AuditBackend).Audit()
+	svc := newScanJobService(backend, &failingManager{err: jobs.ErrQueueFull}, nil, nil)
+	svc.audit = audit.NewRecorder(store, audit.SourceAPI)
+
+	_, err := svc.Submit(context.Background(), v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Profile: "quick"})
+	require.ErrorIs(t, err, jobs.ErrQueueFull)
+
+	events, err := store.List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "scan"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "scan.create", events[0].Action)
+	require.Equal(t, storage.AuditOutcomeFailure, events[0].Outcome)
+	require.Equal(t, map[string]string{"targets": "10.0.0.1", "profile": "quick"}, events[0].Details)
+}
+
+func TestScanJobService_SubmitOutOfScope(t *testing.T) {
+	backend := newTestBackend(t)
+	store := backend.(storage.AuditBackend).Audit()
+	mgr := jobs.NewMemoryManager(1)
+	got := make(chan scanexec.Params, 1)
+	svc := newScanJobService(backend, mgr, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got <- params
+		return &scanexec.Result{RunID: params.ScanID}, nil
+	}, nil)
+	svc.audit = audit.NewRecorder(store, audit.SourceAPI)
+
+	t.Setenv("VULNTOR_TEST_SCOPE_KEY", "s3cret")
+	var err error
+	svc.scope, err = scope.New([]string{"10.0.0.0/8"}, nil, "env:VULNTOR_TEST_SCOPE_KEY")
+	require.NoError(t, err)
+
+	// Refused before the scan is registered
+	_, err = svc.Submit(context.Background(), v1.CreateScanRequest{Targets: []string{"10.0.0.1", "203.0.113.5"}})
+	require.ErrorIs(t, err, scope.ErrOutOfScope)
+	scans, err := backend.Scans().List(context.Background(), storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Empty(t, scans)
+
+	token, err := scope.Sign([]byte("s3cret"), scope.Acknowledgment{
+		Targets: []string{"203.0.113.5"}, Reason: "CHG-7", By: "secops", Expires: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	resp, err := svc.Submit(context.Background(), v1.CreateScanRequest{Targets: []string{"10.0.0.1", "203.0.113.5"}, ScopeAck: token})
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = mgr.Stop(ctx)
+	})
+	select {
+	case params := <-got:
+		require.Equal(t, resp.ID, params.ScanID)
+		require.Equal(t, token, params.ScopeAck, "the run checks the acknowledgment again")
+	case <-time.After(time.Second):
+		t.Fatal("scan runner was not invoked")
+	}
+
+	events, err := store.List(context.Background(), storage.DefaultOrgID, storage.AuditFilter{Action: "scan"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "secops", events[0].Details["scope_ack_by"])
+	require.Equal(t, storage.AuditOutcomeFailure, events[1].Outcome)
+}
+
+func TestScanJobService_SubmitEngagement(t *testing.T) {
+	backend := newTestBackend(t)
+	svc := newScanJobService(backend, &failingManager{err: jobs.ErrQueueFull}, nil, nil)
+	svc.engagement = engagement.Policy{AssessmentMode: true, Defaults: engagement.Record{Client: "ACME"}}
+	ctx := context.Background()
+
+	// Refused before the scan is registered
+	_, err := svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Engagement: &engagement.Record{Tester: "alice"}})
+	require.ErrorIs(t, err, engagement.ErrRequired)
+	require.ErrorContains(t, err, "missing authorization, window")
+	scans, err := backend.Scans().List(ctx, storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Empty(t, scans)
+
+	// The record, completed from the config, is registered with the scan
+	now := time.Now().UTC().Truncate(time.Second)
+	record := &engagement.Record{Authorization: "SOW-42", Tester: "alice", WindowStart: now.Add(-time.Hour), WindowEnd: now.Add(time.Hour)}
+	_, err = svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Engagement: record})
+	require.ErrorIs(t, err, jobs.ErrQueueFull)
+	scans, err = backend.Scans().List(ctx, storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+	require.Equal(t, "ACME", scans[0].Engagement.Client)
+	require.Equal(t, "SOW-42", scans[0].Engagement.Authorization)
+
+	record.WindowEnd = now.Add(-time.Minute)
+	_, err = svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Engagement: record})
+	require.ErrorIs(t, err, engagement.ErrOutsideWindow)
+}
+
+func TestScanJobService_SubmitScopedToTenant(t *testing.T) {
+	backend := newTestBackend(t)
+	svc := newScanJobService(backend, &failingManager{err: jobs.ErrQueueFull}, nil, nil)
+
+	ctx := storage.WithOrgID(context.Background(), "team-a")
+	_, err := svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.1"}})
+	require.ErrorIs(t, err, jobs.ErrQueueFull)
+
+	scans, err := backend.Scans().List(context.Background(), "team-a", storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+
+	scans, err = backend.Scans().List(context.Background(), storage.DefaultOrgID, storage.ScanFilter{})
+	require.NoError(t, err)
+	require.Empty(t, scans, "other tenants do not see the scan")
+}
+
+func TestScanJobService_HandleRejectsBadPayload(t *testing.T) {
+	svc := newScanJobService(nil, jobs.NewMemoryManager(1), func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		return nil, errors.New("should not run")
+	}, nil)
+
+	err := svc.handle(context.Background(), jobs.Job{ID: "x", Type: scanJobType, Payload: "not-params"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected scan job payload")
+}
+
+func TestScanJobService_HandlePublishesEvents(t *testing.T) {
+	broker := events.NewBroker()
+	svc := newScanJobService(nil, jobs.NewMemoryManager(1), func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		observer, ok := engine.OutputObserverFromContext(ctx)
+		require.True(t, ok)
+		observer(engine.ModuleOutput{DataKey: "discovery.live_hosts", Data: []string{"10.0.0.1"}})
+		return nil, errors.New("boom")
+	}, broker)
+
+	err := svc.handle(context.Background(), jobs.Job{ID: "scan-1", Type: scanJobType, Payload: scanexec.Params{}})
+	require.EqualError(t, err, "boom")
+
+	ch, cancel := broker.Subscribe("scan-1")
+	defer cancel()
+
+	var got []events.Event
+	for ev := range ch {
+		got = append(got, ev)
+	}
+	require.Len(t, got, 2)
+	require.Equal(t, events.TypeHostDiscovered, got[0].Type)
+	require.Equal(t, events.TypeScanFinished, got[1].Type)
+	require.Equal(t, map[string]string{"status": "failed", "error": "boom"}, got[1].Data)
+}
+
+func TestScanJobService_SubmitTargetGroups(t *testing.T) {
+	backend := newTestBackend(t)
+	groups := backend.(storage.TargetGroupBackend).TargetGroups()
+	require.NoError(t, groups.Create(context.Background(), storage.DefaultOrgID, &storage.TargetGroup{
+		Name: "prod-web", Targets: []string{"10.0.1.0/24", "10.0.0.1"}, Tags: []string{"prod"},
+	}))
+
+	got := make(chan scanexec.Params, 1)
+	mgr := jobs.NewMemoryManager(1)
+	svc := newScanJobService(backend, mgr, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got <- params
+		return &scanexec.Result{RunID: params.ScanID}, nil
+	}, nil)
+
+	resp, err := svc.Submit(context.Background(), v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Groups: []string{"prod-web"}})
+	require.NoError(t, err)
+
+	meta, err := backend.Scans().Get(context.Background(), storage.DefaultOrgID, resp.ID)
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1 (and 1 more)", meta.Target)
+	require.Equal(t, []string{"prod-web"}, meta.Groups)
+
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = mgr.Stop(ctx)
+	})
+	select {
+	case params := <-got:
+		require.Len(t, params.TargetGroups, 1)
+		require.Equal(t, []string{"prod"}, params.TargetGroups[0].Tags)
+	case <-time.After(time.Second):
+		t.Fatal("scan runner was not invoked")
+	}
+
+	// Unknown groups fail the request as invalid input
+	_, err = svc.Submit(context.Background(), v1.CreateScanRequest{Groups: []string{"staging"}})
+	require.True(t, storage.IsInvalidInput(err))
+}
+
+func TestScanJobService_QueuedScansSurviveRestart(t *testing.T) {
+	backend := newTestBackend(t)
+	store := &jobStore{store: backend.(storage.JobBackend).Jobs()}
+	noRun := func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		return nil, errors.New("should not run")
+	}
+
+	// Scans are queued but the server stops before they run
+	svc := newScanJobService(backend, jobs.NewMemoryManager(1, jobs.WithStore(store)), noRun, nil)
+	ctx := storage.WithOrgID(context.Background(), "team-a")
+	low, err := svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.1"}, Priority: "low"})
+	require.NoError(t, err)
+	high, err := svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.2"}, Ports: "22", AutoTune: true, Priority: "high"})
+	require.NoError(t, err)
+
+	_, err = svc.Submit(ctx, v1.CreateScanRequest{Targets: []string{"10.0.0.3"}, Priority: "urgent"})
+	require.ErrorContains(t, err, "unknown priority")
+
+	// After a restart, they run by priority
+	got := make(chan scanexec.Params, 2)
+	mgr := jobs.NewMemoryManager(1, jobs.WithStore(store))
+	newScanJobService(backend, mgr, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got <- params
+		return &scanexec.Result{RunID: params.ScanID}, nil
+	}, nil)
+	require.NoError(t, mgr.Start(context.Background()))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = mgr.Stop(ctx)
+	})
+
+	for _, want := range []string{high.ID, low.ID} {
+		select {
+		case params := <-got:
+			require.Equal(t, want, params.ScanID)
+			require.Equal(t, "team-a", params.OrgID)
+			if want == high.ID {
+				require.Equal(t, []string{"10.0.0.2"}, params.Targets)
+				require.Equal(t, "22", params.Ports)
+				require.True(t, params.AutoTune)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("recovered scan did not run")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		queued, err := store.Load(context.Background())
+		return err == nil && len(queued) == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestScanJobService_HandleBadRecoveredPayload(t *testing.T) {
+	backend := newTestBackend(t)
+	svc := newScanJobService(backend, jobs.NewMemoryManager(1), nil, nil)
+	require.NoError(t, backend.Scans().Create(context.Background(), "team-a", &storage.ScanMetadata{ID: "scan-1", Target: "10.0.0.1", Status: "pending"}))
+
+	err := svc.handle(context.Background(), jobs.Job{ID: "scan-1", Type: scanJobType, Tenant: "team-a", Payload: json.RawMessage(`[]`)})
+	require.ErrorContains(t, err, "decode scan job payload")
+
+	meta, err := backend.Scans().Get(context.Background(), "team-a", "scan-1")
+	require.NoError(t, err)
+	require.Equal(t, "failed", meta.Status)
+}
@@ -0,0 +1,150 @@
+// pkg/server/auth/apikey.go
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// KeyPrefix identifies Vulntor API keys. Tokens have the form
+// "vnt_<id>_<secret>", where id is public and secret is only stored hashed.
+const KeyPrefix = "vnt_"
+
+const (
+	keyIDBytes     = 6
+	keySecretBytes = 32
+)
+
+var (
+	// ErrInvalidKey is returned when a token is malformed, unknown or does not match.
+	ErrInvalidKey = errors.New("invalid api key")
+	// ErrKeyRevoked is returned when a token belongs to a revoked key.
+	ErrKeyRevoked = errors.New("api key revoked")
+)
+
+// NewAPIKey generates a new key with the given name and scopes.
+// It returns the plaintext token (to be shown once) and the record to persist.
+func NewAPIKey(name string, scopes []Scope) (string, *storage.APIKey, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", nil, fmt.Errorf("api key name cannot be empty")
+	}
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("api key requires at least one scope")
+	}
+
+	id, err := randomHex(keyIDBytes)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomHex(keySecretBytes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := &storage.APIKey{
+		ID:        id,
+		Name:      name,
+		Hash:      hashSecret(secret),
+		Scopes:    scopeNames(scopes),
+		CreatedAt: time.Now(),
+	}
+	return KeyPrefix + id + "_" + secret, key, nil
+}
+
+// Verifier authenticates bearer tokens against stored API keys.
+type Verifier struct {
+	store storage.APIKeyStore
+	users storage.UserStore
+}
+
+// NewVerifier creates a Verifier backed by store.
+func NewVerifier(store storage.APIKeyStore) *Verifier {
+	return &Verifier{store: store}
+}
+
+// WithUsers enables role resolution for keys owned by a user.
+// Without it, user-owned keys are rejected.
+func (v *Verifier) WithUsers(users storage.UserStore) *Verifier {
+	v.users = users
+	return v
+}
+
+// Verify resolves token to the principal it authenticates.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	id, secret, ok := parseToken(token)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	key, err := v.store.Get(ctx, storage.OrgIDFromContext(ctx), id)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return nil, ErrInvalidKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(key.Hash)) != 1 {
+		return nil, ErrInvalidKey
+	}
+	if key.IsRevoked() {
+		return nil, ErrKeyRevoked
+	}
+
+	principal := &Principal{KeyID: key.ID, Name: key.Name, Scopes: key.Scopes, RateLimit: key.RateLimit, DailyScans: key.DailyScans}
+	if key.UserID == "" {
+		return principal, nil
+	}
+
+	// User-owned key: the user's current role caps the key's scopes
+	if v.users == nil {
+		return nil, ErrInvalidKey
+	}
+	user, err := v.users.Get(ctx, storage.OrgIDFromContext(ctx), key.UserID)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return nil, ErrInvalidKey
+		}
+		return nil, err
+	}
+
+	principal.UserID = user.ID
+	principal.Role = Role(user.Role)
+	principal.Scopes = EffectiveScopes(key.Scopes, principal.Role)
+	return principal, nil
+}
+
+// parseToken splits "vnt_<id>_<secret>" into its parts.
+func parseToken(token string) (id, secret string, ok bool) {
+	rest, found := strings.CutPrefix(token, KeyPrefix)
+	if !found {
+		return "", "", false
+	}
+	id, secret, found = strings.Cut(rest, "_")
+	if !found || id == "" || secret == "" {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
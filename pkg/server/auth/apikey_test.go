@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newTestVerifier(t *testing.T) (*Verifier, storage.APIKeyStore) {
+	t.Helper()
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.APIKeys()
+	return NewVerifier(store), store
+}
+
+func TestNewAPIKey(t *testing.T) {
+	token, key, err := NewAPIKey("ci", []Scope{ScopeRead, ScopeScanCreate})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(token, KeyPrefix+key.ID+"_"))
+	require.Equal(t, "ci", key.Name)
+	require.Equal(t, []string{"read", "scan:create"}, key.Scopes)
+	require.NotContains(t, key.Hash, strings.TrimPrefix(token, KeyPrefix+key.ID+"_"), "secret must not be stored")
+
+	_, _, err = NewAPIKey(" ", []Scope{ScopeRead})
+	require.Error(t, err)
+	_, _, err = NewAPIKey("ci", nil)
+	require.Error(t, err)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	v, store := newTestVerifier(t)
+
+	token, key, err := NewAPIKey("ci", []Scope{ScopeRead})
+	require.NoError(t, err)
+	key.RateLimit = 30
+	key.DailyScans = 5
+	require.NoError(t, store.Create(ctx, storage.DefaultOrgID, key))
+
+	p, err := v.Verify(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, key.ID, p.KeyID)
+	require.Equal(t, "ci", p.Name)
+	require.Equal(t, []string{"read"}, p.Scopes)
+	require.Equal(t, 30, p.RateLimit)
+	require.Equal(t, 5, p.DailyScans)
+
+	// Wrong secret, unknown ID and malformed tokens are rejected
+	_, err = v.Verify(ctx, KeyPrefix+key.ID+"_deadbeef")
+	require.ErrorIs(t, err, ErrInvalidKey)
+	_, err = v.Verify(ctx, KeyPrefix+"unknown_deadbeef")
+	require.ErrorIs(t, err, ErrInvalidKey)
+	for _, bad := range []string{"", "token", KeyPrefix, KeyPrefix + key.ID, KeyPrefix + "_secret"} {
+		_, err = v.Verify(ctx, bad)
+		require.ErrorIs(t, err, ErrInvalidKey, bad)
+	}
+
+	require.NoError(t, store.Revoke(ctx, storage.DefaultOrgID, key.ID))
+	_, err = v.Verify(ctx, token)
+	require.ErrorIs(t, err, ErrKeyRevoked)
+}
+
+func TestVerifier_VerifyUserOwnedKey(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	keys, users := backend.APIKeys(), backend.Users()
+
+	require.NoError(t, users.Create(ctx, storage.DefaultOrgID, &storage.User{ID: "alice", Role: string(RoleViewer)}))
+	token, key, err := NewAPIKey("laptop", []Scope{ScopeAdmin})
+	require.NoError(t, err)
+	key.UserID = "alice"
+	require.NoError(t, keys.Create(ctx, storage.DefaultOrgID, key))
+
+	// Without a user store, user-owned keys cannot be resolved
+	_, err = NewVerifier(keys).Verify(ctx, token)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	v := NewVerifier(keys).WithUsers(users)
+	p, err := v.Verify(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, "alice", p.UserID)
+	require.Equal(t, RoleViewer, p.Role)
+	require.Equal(t, []string{"read"}, p.Scopes, "viewer role caps admin key")
+
+	// Role changes apply on the next request
+	require.NoError(t, users.SetRole(ctx, storage.DefaultOrgID, "alice", string(RoleOperator)))
+	p, err = v.Verify(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, []string{"read", "scan:create", "finding:manage"}, p.Scopes)
+
+	require.NoError(t, users.Delete(ctx, storage.DefaultOrgID, "alice"))
+	_, err = v.Verify(ctx, token)
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestPrincipalContext(t *testing.T) {
+	_, ok := PrincipalFromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := WithPrincipal(context.Background(), &Principal{KeyID: "k1"})
+	p, ok := PrincipalFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "k1", p.KeyID)
+}
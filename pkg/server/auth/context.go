@@ -0,0 +1,30 @@
+// pkg/server/auth/context.go
+package auth
+
+import "context"
+
+// Principal is the identity attached to an authenticated request.
+type Principal struct {
+	KeyID  string
+	Name   string
+	UserID string // empty for keys not owned by a user
+	Role   Role   // owning user's role, empty for unowned keys
+	Scopes []string
+
+	// Limits of the API key; zero uses the server defaults
+	RateLimit  int
+	DailyScans int
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal attached by the auth middleware.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
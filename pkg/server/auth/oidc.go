@@ -0,0 +1,525 @@
+// pkg/server/auth/oidc.go
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+const (
+	// clockSkew tolerates small clock differences between the IdP and the server.
+	clockSkew = time.Minute
+	// jwksRefreshInterval limits how often unknown key IDs trigger a JWKS refresh.
+	jwksRefreshInterval = time.Minute
+)
+
+var (
+	// ErrInvalidToken is returned when an OIDC token is malformed, expired,
+	// issued for another client or not signed by the IdP.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrNoRole is returned when an authenticated identity maps to no role.
+	ErrNoRole = errors.New("no role assigned")
+)
+
+// OIDCProvider authenticates users through an OpenID Connect identity
+// provider (Okta, Keycloak, Azure AD, ...).
+//
+// It verifies ID tokens against the IdP's published signing keys and maps
+// the user's IdP groups to a Role. Endpoints are discovered lazily from
+// {issuer}/.well-known/openid-configuration, so the server starts even if
+// the IdP is briefly unreachable.
+type OIDCProvider struct {
+	cfg    config.OIDCConfig
+	client *http.Client
+	users  storage.UserStore
+	now    func() time.Time
+
+	mu          sync.Mutex
+	discovery   *oidcDiscovery
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+}
+
+// oidcDiscovery is the subset of the discovery document Vulntor uses.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// NewOIDCProvider creates a provider for cfg, filling in claim and scope defaults.
+func NewOIDCProvider(cfg config.OIDCConfig) *OIDCProvider {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = "preferred_username"
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	cfg.IssuerURL = strings.TrimSuffix(cfg.IssuerURL, "/")
+
+	return &OIDCProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// WithUsers lets role assignments stored in the backend override the IdP
+// group mapping for users that exist there.
+func (p *OIDCProvider) WithUsers(users storage.UserStore) *OIDCProvider {
+	p.users = users
+	return p
+}
+
+// Verify checks an ID token and returns the authenticated principal.
+//
+// Returns ErrInvalidToken for tokens that fail validation and ErrNoRole when
+// the user is authenticated but none of their groups map to a role.
+func (p *OIDCProvider) Verify(ctx context.Context, token string) (*Principal, error) {
+	claims, err := p.verifyJWT(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := stringClaim(claims, p.cfg.UsernameClaim)
+	if userID == "" {
+		userID = stringClaim(claims, "sub")
+	}
+	if userID == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	role, err := p.resolveRole(ctx, userID, stringsClaim(claims, p.cfg.GroupsClaim))
+	if err != nil {
+		return nil, err
+	}
+
+	name := stringClaim(claims, "name")
+	if name == "" {
+		name = stringClaim(claims, "email")
+	}
+
+	return &Principal{
+		Name:   name,
+		UserID: userID,
+		Role:   role,
+		Scopes: scopeNames(RoleScopes(role)),
+	}, nil
+}
+
+// AuthCodeURL returns the IdP login URL for the authorization code flow with
+// PKCE (S256). state and verifier must be random and kept by the caller.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, verifier string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the raw ID token.
+// The token is not verified; callers pass it to Verify.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("token response has no id_token")
+	}
+	return body.IDToken, nil
+}
+
+// resolveRole picks the user's role: a stored assignment wins, then the most
+// privileged mapped group, then the configured default.
+//
+// Group mapping only grants access to the default tenant; other tenants
+// require a user record in that tenant.
+func (p *OIDCProvider) resolveRole(ctx context.Context, userID string, groups []string) (Role, error) {
+	orgID := storage.OrgIDFromContext(ctx)
+	if p.users != nil {
+		user, err := p.users.Get(ctx, orgID, userID)
+		switch {
+		case err == nil:
+			return ParseRole(user.Role)
+		case !storage.IsNotFound(err):
+			return "", err
+		}
+	}
+	if orgID != storage.DefaultOrgID {
+		return "", ErrNoRole
+	}
+
+	best := -1
+	for _, g := range groups {
+		mapped, ok := p.cfg.RoleMapping[g]
+		if !ok {
+			continue
+		}
+		if i := slices.Index(AllRoles, Role(mapped)); i > best {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return AllRoles[best], nil
+	}
+
+	if p.cfg.DefaultRole != "" {
+		return ParseRole(p.cfg.DefaultRole)
+	}
+	return "", ErrNoRole
+}
+
+// verifyJWT validates the signature and standard claims of a compact JWS.
+func (p *OIDCProvider) verifyJWT(ctx context.Context, token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidToken)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad signature encoding", ErrInvalidToken)
+	}
+
+	key, err := p.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: bad claims", ErrInvalidToken)
+	}
+
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(stringClaim(claims, "iss"), "/") != strings.TrimSuffix(d.Issuer, "/") {
+		return nil, fmt.Errorf("%w: issuer mismatch", ErrInvalidToken)
+	}
+	if !slices.Contains(stringsClaim(claims, "aud"), p.cfg.ClientID) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidToken)
+	}
+
+	now := p.now()
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return nil, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return nil, fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the IdP key with the given ID, refreshing the key set
+// when the ID is unknown (key rotation).
+func (p *OIDCProvider) signingKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if !p.keysFetched.IsZero() && p.now().Sub(p.keysFetched) < jwksRefreshInterval {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+
+	keys, err := p.fetchJWKS(ctx, d.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+	p.keysFetched = p.now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+}
+
+// lookupKey finds a key by ID. Tokens without a kid match a single-key set.
+// Callers must hold p.mu.
+func (p *OIDCProvider) lookupKey(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// discover fetches and caches the discovery document.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcDiscovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d oidcDiscovery
+	if err := p.getJSON(ctx, p.cfg.IssuerURL+"/.well-known/openid-configuration", &d); err != nil {
+		return nil, fmt.Errorf("oidc discovery failed: %w", err)
+	}
+	if d.Issuer == "" || d.JWKSURI == "" || d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" {
+		return nil, fmt.Errorf("oidc discovery failed: incomplete discovery document")
+	}
+	// The document must be the issuer's own, or tokens of another issuer
+	// would be accepted (OpenID Connect Discovery 1.0, section 4.3)
+	if strings.TrimSuffix(d.Issuer, "/") != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("oidc discovery failed: issuer %q does not match issuer_url %q", d.Issuer, p.cfg.IssuerURL)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// fetchJWKS downloads the IdP's signing keys. Unsupported key types are skipped.
+func (p *OIDCProvider) fetchJWKS(ctx context.Context, uri string) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, uri, &set); err != nil {
+		return nil, fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(k.N)
+			e, errE := base64.RawURLEncoding.DecodeString(k.E)
+			if errN != nil || errE != nil {
+				continue
+			}
+			keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			curve := ecCurve(k.Crv)
+			x, errX := base64.RawURLEncoding.DecodeString(k.X)
+			y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+			if curve == nil || errX != nil || errY != nil {
+				continue
+			}
+			size := (curve.Params().BitSize + 7) / 8
+			point := make([]byte, 1+2*size)
+			point[0] = 4 // uncompressed
+			new(big.Int).SetBytes(x).FillBytes(point[1 : 1+size])
+			new(big.Int).SetBytes(y).FillBytes(point[1+size:])
+			key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+			if err != nil {
+				continue
+			}
+			keys[k.Kid] = key
+		}
+	}
+	return keys, nil
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, uri string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, uri)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are
+// accepted; "none" and HMAC are rejected.
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	var h hash.Hash
+	var ch crypto.Hash
+	switch alg[min(2, len(alg)):] {
+	case "256":
+		h, ch = sha256.New(), crypto.SHA256
+	case "384":
+		h, ch = sha512.New384(), crypto.SHA384
+	case "512":
+		h, ch = sha512.New(), crypto.SHA512
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "RS"):
+		if pub, ok := key.(*rsa.PublicKey); ok && rsa.VerifyPKCS1v15(pub, ch, digest, sig) == nil {
+			return nil
+		}
+	case strings.HasPrefix(alg, "PS"):
+		if pub, ok := key.(*rsa.PublicKey); ok && rsa.VerifyPSS(pub, ch, digest, sig, nil) == nil {
+			return nil
+		}
+	case strings.HasPrefix(alg, "ES"):
+		if pub, ok := key.(*ecdsa.PublicKey); ok && len(sig)%2 == 0 {
+			r := new(big.Int).SetBytes(sig[:len(sig)/2])
+			s := new(big.Int).SetBytes(sig[len(sig)/2:])
+			if ecdsa.Verify(pub, digest, r, s) {
+				return nil
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, alg)
+	}
+	return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+}
+
+func ecCurve(crv string) elliptic.Curve {
+	switch crv {
+	case "P-256":
+		return elliptic.P256()
+	case "P-384":
+		return elliptic.P384()
+	case "P-521":
+		return elliptic.P521()
+	}
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+// stringsClaim reads a claim that may be a single string or a string array
+// (e.g. "aud", "groups").
+func stringsClaim(claims map[string]any, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
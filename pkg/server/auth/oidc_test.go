@@ -0,0 +1,261 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// fakeIdP is a minimal OIDC provider serving discovery, JWKS and a token endpoint.
+type fakeIdP struct {
+	*httptest.Server
+	rsaKey  *rsa.PrivateKey
+	ecKey   *ecdsa.PrivateKey
+	idToken string // returned by the token endpoint
+	issuer  string // issuer in the discovery document, the server URL if empty
+	form    url.Values
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	idp := &fakeIdP{rsaKey: rsaKey, ecKey: ecKey}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		issuer := idp.URL
+		if idp.issuer != "" {
+			issuer = idp.issuer
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		ecPub, err := ecKey.PublicKey.Bytes()
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kid": "rsa1", "kty": "RSA", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kid": "ec1", "kty": "EC", "crv": "P-256", "x": b64(ecPub[1:33]), "y": b64(ecPub[33:])},
+		}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		idp.form = r.PostForm
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idp.idToken})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func (idp *fakeIdP) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch alg {
+	case "RS256":
+		sig, err = rsa.SignPKCS1v15(rand.Reader, idp.rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, idp.ecKey, digest[:])
+		require.NoError(t, err)
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	default:
+		sig = []byte("sig")
+	}
+	return signed + "." + b64(sig)
+}
+
+func (idp *fakeIdP) claims(overrides map[string]any) map[string]any {
+	c := map[string]any{
+		"iss":                idp.URL,
+		"aud":                "vulntor",
+		"sub":                "00u1abc",
+		"preferred_username": "alice",
+		"name":               "Alice",
+		"groups":             []string{"devs"},
+		"exp":                time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range overrides {
+		c[k] = v
+	}
+	return c
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func newTestOIDCProvider(idp *fakeIdP) *OIDCProvider {
+	return NewOIDCProvider(config.OIDCConfig{
+		IssuerURL:   idp.URL,
+		ClientID:    "vulntor",
+		RedirectURL: "https://vulntor.example.com/auth/callback",
+		RoleMapping: map[string]string{"devs": "viewer", "sec-ops": "operator", "sec-admins": "admin"},
+	})
+}
+
+func TestOIDCProvider_Verify(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	p := newTestOIDCProvider(idp)
+
+	principal, err := p.Verify(ctx, idp.sign(t, "RS256", "rsa1", idp.claims(nil)))
+	require.NoError(t, err)
+	require.Equal(t, "alice", principal.UserID)
+	require.Equal(t, "Alice", principal.Name)
+	require.Equal(t, RoleViewer, principal.Role)
+	require.Equal(t, []string{"read"}, principal.Scopes)
+
+	// EC keys and audience arrays; the most privileged mapped group wins
+	principal, err = p.Verify(ctx, idp.sign(t, "ES256", "ec1", idp.claims(map[string]any{
+		"aud":    []string{"other", "vulntor"},
+		"groups": []string{"devs", "sec-admins", "sec-ops"},
+	})))
+	require.NoError(t, err)
+	require.Equal(t, RoleAdmin, principal.Role)
+
+	// Falls back to sub without a username claim
+	claims := idp.claims(nil)
+	delete(claims, "preferred_username")
+	principal, err = p.Verify(ctx, idp.sign(t, "RS256", "rsa1", claims))
+	require.NoError(t, err)
+	require.Equal(t, "00u1abc", principal.UserID)
+}
+
+func TestOIDCProvider_VerifyRejects(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	p := newTestOIDCProvider(idp)
+
+	valid := idp.sign(t, "RS256", "rsa1", idp.claims(nil))
+	tests := map[string]string{
+		"malformed":          "a.b",
+		"wrong audience":     idp.sign(t, "RS256", "rsa1", idp.claims(map[string]any{"aud": "other"})),
+		"wrong issuer":       idp.sign(t, "RS256", "rsa1", idp.claims(map[string]any{"iss": "https://evil.example.com"})),
+		"expired":            idp.sign(t, "RS256", "rsa1", idp.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"not yet valid":      idp.sign(t, "RS256", "rsa1", idp.claims(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})),
+		"alg none":           idp.sign(t, "none", "rsa1", idp.claims(nil)),
+		"hmac":               idp.sign(t, "HS256", "rsa1", idp.claims(nil)),
+		"key type mixup":     idp.sign(t, "RS256", "ec1", idp.claims(nil)),
+		"unknown key":        idp.sign(t, "RS256", "rotated", idp.claims(nil)),
+		"tampered signature": valid[:len(valid)-10] + "AAAAAAAAAA",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.Verify(ctx, token)
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestOIDCProvider_RoleResolution(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	p := newTestOIDCProvider(idp)
+
+	// No mapped group and no default role
+	token := idp.sign(t, "RS256", "rsa1", idp.claims(map[string]any{"groups": []string{"marketing"}}))
+	_, err := p.Verify(ctx, token)
+	require.ErrorIs(t, err, ErrNoRole)
+
+	p.cfg.DefaultRole = "viewer"
+	principal, err := p.Verify(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, RoleViewer, principal.Role)
+
+	// A stored role assignment overrides the group mapping
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	require.NoError(t, backend.Users().Create(ctx, storage.DefaultOrgID, &storage.User{ID: "alice", Role: "operator"}))
+	p.WithUsers(backend.Users())
+
+	principal, err = p.Verify(ctx, token)
+	require.NoError(t, err)
+	require.Equal(t, RoleOperator, principal.Role)
+	require.Equal(t, []string{"read", "scan:create", "finding:manage"}, principal.Scopes)
+}
+
+func TestOIDCProvider_LoginFlow(t *testing.T) {
+	ctx := context.Background()
+	idp := newFakeIdP(t)
+	p := newTestOIDCProvider(idp)
+
+	loginURL, err := p.AuthCodeURL(ctx, "state123", "verifier123")
+	require.NoError(t, err)
+	u, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	require.Equal(t, idp.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	q := u.Query()
+	require.Equal(t, "code", q.Get("response_type"))
+	require.Equal(t, "vulntor", q.Get("client_id"))
+	require.Equal(t, "state123", q.Get("state"))
+	require.Equal(t, "openid profile email", q.Get("scope"))
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+	challenge := sha256.Sum256([]byte("verifier123"))
+	require.Equal(t, b64(challenge[:]), q.Get("code_challenge"))
+
+	idp.idToken = idp.sign(t, "RS256", "rsa1", idp.claims(nil))
+	token, err := p.Exchange(ctx, "code123", "verifier123")
+	require.NoError(t, err)
+	require.Equal(t, idp.idToken, token)
+	require.Equal(t, "code123", idp.form.Get("code"))
+	require.Equal(t, "verifier123", idp.form.Get("code_verifier"))
+	require.Equal(t, "https://vulntor.example.com/auth/callback", idp.form.Get("redirect_uri"))
+}
+
+func TestOIDCProvider_DiscoveryFailure(t *testing.T) {
+	p := NewOIDCProvider(config.OIDCConfig{IssuerURL: "http://127.0.0.1:1", ClientID: "vulntor"})
+	_, err := p.AuthCodeURL(context.Background(), "s", "v")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "oidc discovery failed")
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.issuer = "https://evil.example.com"
+	p := newTestOIDCProvider(idp)
+
+	// Neither logins nor tokens are accepted from a document of another issuer
+	_, err := p.AuthCodeURL(context.Background(), "s", "v")
+	require.ErrorContains(t, err, "does not match issuer_url")
+	claims := idp.claims(map[string]any{"iss": "https://evil.example.com"})
+	_, err = p.Verify(context.Background(), idp.sign(t, "RS256", "rsa1", claims))
+	require.Error(t, err)
+
+	// A trailing slash is not a mismatch
+	idp.issuer = idp.URL + "/"
+	p = newTestOIDCProvider(idp)
+	_, err = p.AuthCodeURL(context.Background(), "s", "v")
+	require.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+// pkg/server/auth/roles.go
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Role is a user's access level on the server.
+type Role string
+
+// Supported roles, from least to most privileged.
+const (
+	// RoleViewer can read scans, findings and plugins.
+	RoleViewer Role = "viewer"
+	// RoleOperator can additionally submit scans and triage findings.
+	RoleOperator Role = "operator"
+	// RoleAdmin has full access, including plugin management.
+	RoleAdmin Role = "admin"
+)
+
+// AllRoles lists the supported roles in display order.
+var AllRoles = []Role{RoleViewer, RoleOperator, RoleAdmin}
+
+// roleScopes maps each role to the scopes it permits.
+var roleScopes = map[Role][]Scope{
+	RoleViewer:   {ScopeRead},
+	RoleOperator: {ScopeRead, ScopeScanCreate, ScopeFindingManage},
+	RoleAdmin:    {ScopeAdmin},
+}
+
+// ParseRole validates a role name.
+func ParseRole(s string) (Role, error) {
+	r := Role(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(AllRoles, r) {
+		names := make([]string, len(AllRoles))
+		for i, role := range AllRoles {
+			names[i] = string(role)
+		}
+		return "", fmt.Errorf("unknown role %q (valid: %s)", s, strings.Join(names, ", "))
+	}
+	return r, nil
+}
+
+// RoleScopes returns the scopes permitted by role. Unknown roles permit nothing.
+func RoleScopes(role Role) []Scope {
+	return slices.Clone(roleScopes[role])
+}
+
+// EffectiveScopes narrows key scopes to what role permits. A key with the
+// admin scope inherits every scope of the role.
+func EffectiveScopes(keyScopes []string, role Role) []string {
+	permitted := scopeNames(RoleScopes(role))
+	if HasScope(keyScopes, ScopeAdmin) {
+		return permitted
+	}
+
+	var out []string
+	for _, s := range keyScopes {
+		if HasScope(permitted, Scope(s)) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func scopeNames(scopes []Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	r, err := ParseRole(" Operator ")
+	require.NoError(t, err)
+	require.Equal(t, RoleOperator, r)
+
+	_, err = ParseRole("root")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "viewer, operator, admin")
+}
+
+func TestRoleScopes(t *testing.T) {
+	require.Equal(t, []Scope{ScopeRead}, RoleScopes(RoleViewer))
+	require.Equal(t, []Scope{ScopeRead, ScopeScanCreate, ScopeFindingManage}, RoleScopes(RoleOperator))
+	require.Equal(t, []Scope{ScopeAdmin}, RoleScopes(RoleAdmin))
+	require.Empty(t, RoleScopes("unknown"))
+}
+
+func TestEffectiveScopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    []string
+		role   Role
+		expect []string
+	}{
+		{"admin key inherits viewer", []string{"admin"}, RoleViewer, []string{"read"}},
+		{"admin key inherits operator", []string{"admin"}, RoleOperator, []string{"read", "scan:create", "finding:manage"}},
+		{"admin key with admin role", []string{"admin"}, RoleAdmin, []string{"admin"}},
+		{"viewer caps scan:create", []string{"read", "scan:create"}, RoleViewer, []string{"read"}},
+		{"operator caps plugin:manage", []string{"read", "plugin:manage"}, RoleOperator, []string{"read"}},
+		{"admin role keeps narrow key", []string{"read"}, RoleAdmin, []string{"read"}},
+		{"unknown role permits nothing", []string{"admin"}, "unknown", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EffectiveScopes(tt.key, tt.role)
+			if len(tt.expect) == 0 {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, tt.expect, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// pkg/server/auth/scopes.go
+// Package auth implements API key authentication and scope checks for the
+// server API. Keys are persisted through storage.APIKeyStore.
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+// Supported scopes.
+const (
+	// ScopeRead allows read-only access to scans, findings and plugins.
+	ScopeRead Scope = "read"
+	// ScopeScanCreate allows submitting scans.
+	ScopeScanCreate Scope = "scan:create"
+	// ScopeFindingManage allows changing the triage state and assignee of
+	// findings.
+	ScopeFindingManage Scope = "finding:manage"
+	// ScopePluginManage allows installing, updating and removing plugins.
+	ScopePluginManage Scope = "plugin:manage"
+	// ScopeAdmin grants every scope.
+	ScopeAdmin Scope = "admin"
+)
+
+// AllScopes lists the supported scopes in display order.
+var AllScopes = []Scope{ScopeRead, ScopeScanCreate, ScopeFindingManage, ScopePluginManage, ScopeAdmin}
+
+// ParseScopes validates and de-duplicates a list of scope names.
+// Entries may themselves be comma-separated.
+func ParseScopes(values []string) ([]Scope, error) {
+	var scopes []Scope
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			s := Scope(strings.TrimSpace(part))
+			if s == "" {
+				continue
+			}
+			if !slices.Contains(AllScopes, s) {
+				return nil, fmt.Errorf("unknown scope %q (valid: %s)", s, scopeList())
+			}
+			if !slices.Contains(scopes, s) {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required (valid: %s)", scopeList())
+	}
+	return scopes, nil
+}
+
+// HasScope reports whether granted satisfies required. The admin scope
+// satisfies every requirement.
+func HasScope(granted []string, required Scope) bool {
+	for _, g := range granted {
+		if Scope(g) == required || Scope(g) == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeList() string {
+	names := make([]string, len(AllScopes))
+	for i, s := range AllScopes {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
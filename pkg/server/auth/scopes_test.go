@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	scopes, err := ParseScopes([]string{"read,scan:create", " read ", "plugin:manage"})
+	require.NoError(t, err)
+	require.Equal(t, []Scope{ScopeRead, ScopeScanCreate, ScopePluginManage}, scopes)
+
+	_, err = ParseScopes([]string{"read", "write"})
+	require.ErrorContains(t, err, `unknown scope "write"`)
+
+	_, err = ParseScopes([]string{"", " , "})
+	require.ErrorContains(t, err, "at least one scope is required")
+}
+
+func TestHasScope(t *testing.T) {
+	require.True(t, HasScope([]string{"read"}, ScopeRead))
+	require.False(t, HasScope([]string{"read"}, ScopeScanCreate))
+	require.True(t, HasScope([]string{"read", "scan:create"}, ScopeScanCreate))
+	require.True(t, HasScope([]string{"admin"}, ScopePluginManage))
+	require.False(t, HasScope(nil, ScopeRead))
+}
@@ -0,0 +1,232 @@
+package certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File names of the development CA in its directory.
+const (
+	CACertFile = "ca.crt"
+	CAKeyFile  = "ca.key"
+)
+
+// Default validity of issued certificates.
+const (
+	DefaultCAValidity   = 365 * 24 * time.Hour
+	DefaultLeafValidity = 90 * 24 * time.Hour
+)
+
+// ErrExists is returned when writing would overwrite existing files.
+var ErrExists = errors.New("file already exists")
+
+// CA is a certificate authority issuing development certificates. It is
+// meant for test and lab deployments; production deployments use
+// certificates of their PKI.
+type CA struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// Request describes a leaf certificate to issue.
+type Request struct {
+	// CommonName is the subject of the certificate
+	CommonName string
+	// DNSNames and IPs are the subject alternative names
+	DNSNames []string
+	IPs      []net.IP
+	// Client issues a client certificate instead of a server certificate
+	Client bool
+	// Validity defaults to DefaultLeafValidity
+	Validity time.Duration
+}
+
+// Issued is an issued certificate and its private key, PEM encoded.
+type Issued struct {
+	Cert    *x509.Certificate
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the certificate.
+func (i *Issued) Fingerprint() string {
+	sum := sha256.Sum256(i.Cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewCA creates a self-signed CA named name, valid for validity
+// (DefaultCAValidity if zero).
+func NewCA(name string, validity time.Duration) (*CA, *Issued, error) {
+	if validity <= 0 {
+		validity = DefaultCAValidity
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate key: %w", err)
+	}
+	tmpl, err := template(name, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	tmpl.IsCA = true
+	tmpl.BasicConstraintsValid = true
+	tmpl.MaxPathLenZero = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	issued, err := sign(tmpl, tmpl, key, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &CA{Cert: issued.Cert, Key: key}, issued, nil
+}
+
+// LoadCA loads a CA from the PEM certificate and key in dir, as written by
+// WriteFiles.
+func LoadCA(dir string) (*CA, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, CACertFile))
+	if err != nil {
+		return nil, fmt.Errorf("read CA certificate: %w", err)
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, CAKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("read CA key: %w", err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s: no PEM certificate", CACertFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA certificate: %w", err)
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", CACertFile)
+	}
+
+	block, _ = pem.Decode(keyPEM)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("%s: no PEM private key", CAKeyFile)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse CA key: %w", err)
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported key type", CAKeyFile)
+	}
+	return &CA{Cert: cert, Key: key}, nil
+}
+
+// Issue issues a leaf certificate signed by the CA. Server certificates
+// without names are issued for localhost.
+func (ca *CA) Issue(req Request) (*Issued, error) {
+	if req.CommonName == "" {
+		return nil, fmt.Errorf("common name is required")
+	}
+	validity := req.Validity
+	if validity <= 0 {
+		validity = DefaultLeafValidity
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	tmpl, err := template(req.CommonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	tmpl.DNSNames = req.DNSNames
+	tmpl.IPAddresses = req.IPs
+	if req.Client {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		if len(tmpl.DNSNames) == 0 && len(tmpl.IPAddresses) == 0 {
+			tmpl.DNSNames = []string{"localhost"}
+			tmpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+		}
+	}
+	// Leaves do not outlive their CA
+	if tmpl.NotAfter.After(ca.Cert.NotAfter) {
+		tmpl.NotAfter = ca.Cert.NotAfter
+	}
+
+	return sign(tmpl, ca.Cert, key, ca.Key)
+}
+
+// WriteFiles writes the certificate and key of issued to dir as name.crt
+// and name.key; the key is readable by the owner only. Existing files are
+// only replaced with overwrite.
+func WriteFiles(dir, name string, issued *Issued, overwrite bool) (certPath, keyPath string, err error) {
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	if !overwrite {
+		for _, path := range []string{certPath, keyPath} {
+			if _, err := os.Stat(path); err == nil {
+				return "", "", fmt.Errorf("%s: %w (use --force to replace it)", path, ErrExists)
+			}
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(keyPath, issued.KeyPEM, 0o600); err != nil {
+		return "", "", fmt.Errorf("write key: %w", err)
+	}
+	if err := os.WriteFile(certPath, issued.CertPEM, 0o644); err != nil {
+		return "", "", fmt.Errorf("write certificate: %w", err)
+	}
+	return certPath, keyPath, nil
+}
+
+func template(name string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generate serial number: %w", err)
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"Vulntor"}},
+		// Tolerate clock skew between the hosts
+		NotBefore: now.Add(-5 * time.Minute),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+func sign(tmpl, parent *x509.Certificate, key *ecdsa.PrivateKey, signer crypto.Signer) (*Issued, error) {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), signer)
+	if err != nil {
+		return nil, fmt.Errorf("create certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode key: %w", err)
+	}
+	return &Issued{
+		Cert:    cert,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,161 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+type pki struct {
+	dir    string
+	ca     *CA
+	caPool *x509.CertPool
+}
+
+func newPKI(t *testing.T) *pki {
+	t.Helper()
+	dir := t.TempDir()
+	ca, issued, err := NewCA("Test CA", 0)
+	require.NoError(t, err)
+	_, _, err = WriteFiles(dir, "ca", issued, false)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Cert)
+	return &pki{dir: dir, ca: ca, caPool: pool}
+}
+
+func (p *pki) issue(t *testing.T, name string, client bool) (certPath, keyPath string) {
+	t.Helper()
+	issued, err := p.ca.Issue(Request{CommonName: name, Client: client})
+	require.NoError(t, err)
+	certPath, keyPath, err = WriteFiles(p.dir, name, issued, true)
+	require.NoError(t, err)
+	return certPath, keyPath
+}
+
+func TestCA_IssueAndLoad(t *testing.T) {
+	p := newPKI(t)
+
+	loaded, err := LoadCA(p.dir)
+	require.NoError(t, err)
+	require.Equal(t, p.ca.Cert.Raw, loaded.Cert.Raw)
+
+	server, err := loaded.Issue(Request{CommonName: "vulntor.internal", DNSNames: []string{"vulntor.internal"}})
+	require.NoError(t, err)
+	_, err = server.Cert.Verify(x509.VerifyOptions{Roots: p.caPool, DNSName: "vulntor.internal"})
+	require.NoError(t, err)
+	require.Len(t, server.Fingerprint(), 64)
+
+	local, err := loaded.Issue(Request{CommonName: "dev"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"localhost"}, local.Cert.DNSNames)
+	require.Len(t, local.Cert.IPAddresses, 2)
+
+	client, err := loaded.Issue(Request{CommonName: "ci-runner", Client: true, Validity: 10 * 365 * 24 * time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, client.Cert.ExtKeyUsage)
+	require.Empty(t, client.Cert.DNSNames)
+	require.False(t, client.Cert.NotAfter.After(p.ca.Cert.NotAfter), "leaf must not outlive the CA")
+
+	_, err = loaded.Issue(Request{})
+	require.ErrorContains(t, err, "common name is required")
+}
+
+func TestWriteFiles(t *testing.T) {
+	p := newPKI(t)
+	_, _, err := WriteFiles(p.dir, "ca", &Issued{}, false)
+	require.ErrorIs(t, err, ErrExists)
+
+	info, err := os.Stat(filepath.Join(p.dir, CAKeyFile))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	_, err = LoadCA(t.TempDir())
+	require.ErrorContains(t, err, "read CA certificate")
+}
+
+func TestReloader_MutualTLS(t *testing.T) {
+	p := newPKI(t)
+	certFile, keyFile := p.issue(t, "server", false)
+	clientCert, clientKey := p.issue(t, "client", true)
+
+	r, err := NewReloader(certFile, keyFile, filepath.Join(p.dir, CACertFile))
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(req.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = r.TLSConfig(config.TLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: filepath.Join(p.dir, CACertFile)})
+	srv.StartTLS()
+	defer srv.Close()
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	url := "https://" + net.JoinHostPort("localhost", port) + "/"
+	pair, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+
+	withCert := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: p.caPool, Certificates: []tls.Certificate{pair}}}}
+	resp, err := withCert.Get(url)
+	require.NoError(t, err)
+	body := make([]byte, 16)
+	n, _ := resp.Body.Read(body)
+	_ = resp.Body.Close()
+	require.Equal(t, "client", string(body[:n]))
+
+	withoutCert := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: p.caPool}}}
+	_, err = withoutCert.Get(url)
+	require.Error(t, err, "clients without a certificate must be rejected")
+
+	// A client certificate of another CA is rejected too
+	other := newPKI(t)
+	otherCert, otherKey := other.issue(t, "intruder", true)
+	otherPair, err := tls.LoadX509KeyPair(otherCert, otherKey)
+	require.NoError(t, err)
+	foreign := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: p.caPool, Certificates: []tls.Certificate{otherPair}}}}
+	_, err = foreign.Get(url)
+	require.Error(t, err)
+}
+
+func TestReloader_Rotation(t *testing.T) {
+	p := newPKI(t)
+	certFile, keyFile := p.issue(t, "server", false)
+
+	r, err := NewReloader(certFile, keyFile, "")
+	require.NoError(t, err)
+	r.interval = 0
+	first := r.Certificate().Leaf
+	require.Nil(t, r.ClientCAs())
+
+	// Renewed files are picked up on the next check
+	p.issue(t, "server", false)
+	bumpMtime(t, certFile, keyFile)
+	second := r.Certificate().Leaf
+	require.NotEqual(t, first.SerialNumber, second.SerialNumber)
+
+	// A half-written rotation keeps the previous pair
+	require.NoError(t, os.WriteFile(keyFile, []byte("partial"), 0o600))
+	bumpMtime(t, keyFile)
+	require.Equal(t, second.SerialNumber, r.Certificate().Leaf.SerialNumber)
+
+	_, err = NewReloader(certFile, keyFile, "")
+	require.ErrorContains(t, err, "load certificate")
+}
+
+func bumpMtime(t *testing.T, paths ...string) {
+	t.Helper()
+	later := time.Now().Add(time.Minute)
+	for _, path := range paths {
+		require.NoError(t, os.Chtimes(path, later, later))
+	}
+}
@@ -0,0 +1,176 @@
+// pkg/server/certs/reloader.go
+// Package certs provides the TLS certificates of the server listener and
+// issues development certificates. The listener re-reads its certificate,
+// key and client CA bundle when the files change, so certificates renewed
+// on disk (e.g. by cert-manager or an ACME client) apply without a restart.
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// CheckInterval is how often the files are checked for changes, at most.
+// Checks happen on TLS handshakes, so an idle server does not poll.
+const CheckInterval = time.Second
+
+// Reloader serves a certificate and client CA bundle loaded from files and
+// reloads them when the files change. A change that fails to load, e.g. a
+// certificate replaced before its key, keeps the previous files in use and
+// is retried on the next check.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	interval time.Duration
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	stamp   string
+	checked time.Time
+}
+
+// NewReloader loads the certificate and key, and the client CA bundle when
+// caFile is set.
+func NewReloader(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, caFile: caFile, interval: CheckInterval}
+	stamp, err := r.fileStamp()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(stamp); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Certificate returns the certificate in use.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.reload()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cert
+}
+
+// ClientCAs returns the client CA bundle in use; nil without a bundle.
+func (r *Reloader) ClientCAs() *x509.CertPool {
+	r.reload()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pool
+}
+
+// TLSConfig returns the server TLS configuration of cfg. Every handshake
+// uses the certificate and client CA bundle loaded last.
+func (r *Reloader) TLSConfig(cfg config.TLSConfig) *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.NoClientCert,
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+	if cfg.MinVersion == "1.3" {
+		base.MinVersion = tls.VersionTLS13
+	}
+	if cfg.IsMutual() {
+		base.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.ClientAuth == "optional" {
+			base.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
+	out := base.Clone()
+	out.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.reload()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		c := base.Clone()
+		c.Certificates = []tls.Certificate{*r.cert}
+		c.ClientCAs = r.pool
+		return c, nil
+	}
+	return out
+}
+
+// reload loads the files again when they changed since the last check.
+func (r *Reloader) reload() {
+	r.mu.Lock()
+	if time.Since(r.checked) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	r.checked = time.Now()
+	current := r.stamp
+	r.mu.Unlock()
+
+	stamp, err := r.fileStamp()
+	if err == nil && stamp == current {
+		return
+	}
+	if err == nil {
+		err = r.load(stamp)
+	}
+	if err != nil {
+		log.Warn().
+			Str("component", "certs").
+			Err(err).
+			Msg("Failed to reload TLS certificates, keeping the previous ones")
+		return
+	}
+	log.Info().
+		Str("component", "certs").
+		Str("cert_file", r.certFile).
+		Msg("Reloaded TLS certificates")
+}
+
+func (r *Reloader) load(stamp string) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.caFile != "" {
+		data, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return fmt.Errorf("read client CA bundle: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("client CA bundle %s: no PEM certificates", r.caFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.pool = pool
+	r.stamp = stamp
+	return nil
+}
+
+// fileStamp identifies the current version of the files by their size and
+// modification time.
+func (r *Reloader) fileStamp() (string, error) {
+	var sb strings.Builder
+	for _, path := range []string{r.certFile, r.keyFile, r.caFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "%d:%d;", info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String(), nil
+}
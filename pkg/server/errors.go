@@ -3,6 +3,8 @@ package server
 import (
 	"errors"
 	"fmt"
+
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 const (
@@ -15,6 +17,13 @@ const (
 	errorCodePluginInitFailed   = "SERVER_PLUGIN_INIT_FAILED"
 	errorCodeAppInitFailed      = "SERVER_INIT_FAILED"
 	errorCodeRuntimeFailed      = "SERVER_RUNTIME_FAILED"
+	errorCodeInvalidAPIKey      = "SERVER_INVALID_API_KEY"
+	errorCodeAPIKeyNotFound     = "SERVER_API_KEY_NOT_FOUND"
+	errorCodeInvalidUser        = "SERVER_INVALID_USER"
+	errorCodeUserNotFound       = "SERVER_USER_NOT_FOUND"
+	errorCodeInvalidTenant      = "SERVER_INVALID_TENANT"
+	errorCodeTenantNotFound     = "SERVER_TENANT_NOT_FOUND"
+	errorCodeCertFailed         = "SERVER_CERT_FAILED"
 )
 
 var (
@@ -109,6 +118,71 @@ func WrapRuntime(err error) error {
 	return WithErrorCode(err, errorCodeRuntimeFailed)
 }
 
+// WrapInvalidAPIKey annotates invalid API key parameters (name, scopes).
+func WrapInvalidAPIKey(err error) error {
+	if err == nil {
+		return nil
+	}
+	return WithErrorCode(err, errorCodeInvalidAPIKey)
+}
+
+// WrapAPIKeyStore annotates API key storage failures, distinguishing unknown keys.
+func WrapAPIKeyStore(err error) error {
+	if err == nil {
+		return nil
+	}
+	if storage.IsNotFound(err) {
+		return WithErrorCode(err, errorCodeAPIKeyNotFound)
+	}
+	return WithErrorCode(err, errorCodeStorageInitFailed)
+}
+
+// WrapInvalidUser annotates invalid user parameters (ID, role).
+func WrapInvalidUser(err error) error {
+	if err == nil {
+		return nil
+	}
+	return WithErrorCode(err, errorCodeInvalidUser)
+}
+
+// WrapUserStore annotates user storage failures, distinguishing unknown and
+// duplicate users.
+func WrapUserStore(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case storage.IsNotFound(err):
+		return WithErrorCode(err, errorCodeUserNotFound)
+	case storage.IsAlreadyExists(err), storage.IsInvalidInput(err):
+		return WithErrorCode(err, errorCodeInvalidUser)
+	}
+	return WithErrorCode(err, errorCodeStorageInitFailed)
+}
+
+// WrapTenantStore annotates tenant storage failures, distinguishing unknown
+// and invalid or duplicate tenants.
+func WrapTenantStore(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch {
+	case storage.IsNotFound(err):
+		return WithErrorCode(err, errorCodeTenantNotFound)
+	case storage.IsAlreadyExists(err), storage.IsInvalidInput(err):
+		return WithErrorCode(err, errorCodeInvalidTenant)
+	}
+	return WithErrorCode(err, errorCodeStorageInitFailed)
+}
+
+// WrapCert annotates failures creating or writing development certificates.
+func WrapCert(err error) error {
+	if err == nil {
+		return nil
+	}
+	return WithErrorCode(err, errorCodeCertFailed)
+}
+
 // ErrorCode resolves a server error to its error code.
 func ErrorCode(err error) string {
 	if err == nil {
@@ -149,6 +223,10 @@ func ExitCode(err error) int {
 		return 2
 	case errors.Is(err, ErrConfigUnavailable):
 		return 1
+	case ErrorCode(err) == errorCodeInvalidAPIKey,
+		ErrorCode(err) == errorCodeInvalidUser,
+		ErrorCode(err) == errorCodeInvalidTenant:
+		return 2
 	case ErrorCode(err) == errorCodeStorageInitFailed,
 		ErrorCode(err) == errorCodePluginInitFailed,
 		ErrorCode(err) == errorCodeAppInitFailed:
@@ -228,6 +306,33 @@ func Suggestions(err error) []string {
 			"Check server logs for runtime errors",
 			"Ensure no other process is using the selected port",
 		}
+	case errorCodeInvalidAPIKey:
+		return []string{
+			"Provide a name and at least one scope",
+			"Example:                 vulntor server apikey create --name ci --scopes read,scan:create",
+		}
+	case errorCodeAPIKeyNotFound:
+		return []string{
+			"List existing keys:       vulntor server apikey list",
+		}
+	case errorCodeInvalidUser:
+		return []string{
+			"Use a unique user ID and one of the roles: viewer, operator, admin",
+			"Example:                 vulntor server user create alice --role operator",
+		}
+	case errorCodeUserNotFound:
+		return []string{
+			"List existing users:      vulntor server user list",
+		}
+	case errorCodeInvalidTenant:
+		return []string{
+			"Use a unique tenant ID of lowercase letters, digits and dashes",
+			"Example:                 vulntor server tenant create team-a --name \"Team A\"",
+		}
+	case errorCodeTenantNotFound:
+		return []string{
+			"List existing tenants:    vulntor server tenant list",
+		}
 	default:
 		return nil
 	}
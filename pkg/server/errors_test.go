@@ -3,6 +3,8 @@ package server
 import (
 	"errors"
 	"testing"
+
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 func TestServerError_WithErrorCodeAndUnwrap(t *testing.T) {
@@ -104,6 +106,64 @@ func TestServerError_WrapRuntime(t *testing.T) {
 	}
 }
 
+func TestServerError_WrapAPIKeyStore(t *testing.T) {
+	if WrapAPIKeyStore(nil) != nil {
+		t.Errorf("expected nil")
+	}
+	if ErrorCode(WrapAPIKeyStore(storage.NewNotFoundError("api key", "abc"))) != errorCodeAPIKeyNotFound {
+		t.Errorf("expected api key not found code")
+	}
+	if ErrorCode(WrapAPIKeyStore(errors.New("disk"))) != errorCodeStorageInitFailed {
+		t.Errorf("expected storage code")
+	}
+	if ErrorCode(WrapInvalidAPIKey(errors.New("bad scope"))) != errorCodeInvalidAPIKey {
+		t.Errorf("expected invalid api key code")
+	}
+}
+
+func TestServerError_WrapUserStore(t *testing.T) {
+	if WrapUserStore(nil) != nil {
+		t.Errorf("expected nil")
+	}
+	if ErrorCode(WrapUserStore(storage.NewNotFoundError("user", "alice"))) != errorCodeUserNotFound {
+		t.Errorf("expected user not found code")
+	}
+	if ErrorCode(WrapUserStore(storage.NewAlreadyExistsError("user", "alice"))) != errorCodeInvalidUser {
+		t.Errorf("expected invalid user code")
+	}
+	if ErrorCode(WrapUserStore(errors.New("disk"))) != errorCodeStorageInitFailed {
+		t.Errorf("expected storage code")
+	}
+	if ExitCode(WrapInvalidUser(errors.New("bad role"))) != 2 {
+		t.Errorf("expected exit code 2")
+	}
+}
+
+func TestServerError_WrapTenantStore(t *testing.T) {
+	if WrapTenantStore(nil) != nil {
+		t.Errorf("expected nil")
+	}
+	if ErrorCode(WrapTenantStore(storage.NewNotFoundError("tenant", "team-a"))) != errorCodeTenantNotFound {
+		t.Errorf("expected tenant not found code")
+	}
+	invalid := WrapTenantStore(storage.NewInvalidInputError("ID", "bad"))
+	if ErrorCode(invalid) != errorCodeInvalidTenant || ExitCode(invalid) != 2 {
+		t.Errorf("expected invalid tenant code and exit code 2")
+	}
+	if ErrorCode(WrapTenantStore(errors.New("disk"))) != errorCodeStorageInitFailed {
+		t.Errorf("expected storage code")
+	}
+}
+
+func TestServerError_WrapCert(t *testing.T) {
+	if WrapCert(nil) != nil {
+		t.Errorf("expected nil")
+	}
+	if ErrorCode(WrapCert(errors.New("read CA"))) != errorCodeCertFailed {
+		t.Errorf("expected cert code")
+	}
+}
+
 func TestServerError_ErrorCodeBranches(t *testing.T) {
 	if ErrorCode(nil) != "" {
 		t.Errorf("expected empty for nil")
@@ -144,6 +204,7 @@ func TestServerError_ExitCode(t *testing.T) {
 		{WithErrorCode(errors.New("x"), errorCodeStorageInitFailed), 7},
 		{WithErrorCode(errors.New("x"), errorCodePluginInitFailed), 7},
 		{WithErrorCode(errors.New("x"), errorCodeAppInitFailed), 7},
+		{WithErrorCode(errors.New("x"), errorCodeInvalidAPIKey), 2},
 		{WithErrorCode(errors.New("x"), "UNKNOWN_CODE"), 1}, // default branch
 	}
 	for _, tt := range tests {
@@ -186,6 +247,10 @@ func TestServerError_Suggestions(t *testing.T) {
 		{errorCodePluginInitFailed, true},
 		{errorCodeAppInitFailed, true},
 		{errorCodeRuntimeFailed, true},
+		{errorCodeInvalidAPIKey, true},
+		{errorCodeAPIKeyNotFound, true},
+		{errorCodeInvalidUser, true},
+		{errorCodeUserNotFound, true},
 		{"UNKNOWN_CODE", false},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,168 @@
+// pkg/server/events/broker.go
+// Package events fans out live scan events to server-side stream subscribers
+// (SSE clients). It is in-memory and per-process; events are not persisted.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types published during a scan.
+const (
+	TypeHostDiscovered = "host.discovered"
+	TypePortOpen       = "port.open"
+	TypeFindingCreated = "finding.created"
+	TypeScanFinished   = "scan.finished"
+)
+
+// Defaults for Broker buffering.
+const (
+	// DefaultHistorySize bounds the per-scan replay buffer for late subscribers.
+	DefaultHistorySize = 1000
+
+	// DefaultMaxFinished bounds how many finished scans keep their history.
+	DefaultMaxFinished = 100
+
+	// subscriberBuffer is the channel buffer per subscriber. Slow subscribers
+	// drop events rather than stalling the scan.
+	subscriberBuffer = 256
+)
+
+// Event is a single live scan event.
+type Event struct {
+	// Type is one of the Type* constants
+	Type string `json:"type"`
+
+	// ScanID identifies the scan that produced the event
+	ScanID string `json:"scan_id"`
+
+	// Timestamp is when the event was published
+	Timestamp time.Time `json:"timestamp"`
+
+	// Data is the event payload (module output, finding, or final status)
+	Data any `json:"data,omitempty"`
+}
+
+// Publisher publishes scan events.
+type Publisher interface {
+	Publish(ev Event)
+}
+
+type scanStream struct {
+	history  []Event
+	subs     map[chan Event]struct{}
+	finished bool
+}
+
+// Broker is an in-memory Publisher that fans events out to subscribers
+// and keeps a bounded replay history per scan.
+type Broker struct {
+	mu          sync.Mutex
+	scans       map[string]*scanStream
+	finished    []string // finished scan IDs, oldest first
+	historySize int
+	maxFinished int
+}
+
+// NewBroker creates a broker with default buffering limits.
+func NewBroker() *Broker {
+	return &Broker{
+		scans:       make(map[string]*scanStream),
+		historySize: DefaultHistorySize,
+		maxFinished: DefaultMaxFinished,
+	}
+}
+
+// Publish records ev and delivers it to all subscribers of ev.ScanID.
+// A TypeScanFinished event closes all subscriber channels for the scan.
+func (b *Broker) Publish(ev Event) {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now().UTC()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := b.stream(ev.ScanID)
+	if st.finished {
+		return
+	}
+
+	if len(st.history) < b.historySize {
+		st.history = append(st.history, ev)
+	}
+
+	for ch := range st.subs {
+		select {
+		case ch <- ev:
+		default:
+			// Drop for slow subscriber; never block the scan
+		}
+	}
+
+	if ev.Type == TypeScanFinished {
+		st.finished = true
+		for ch := range st.subs {
+			close(ch)
+		}
+		st.subs = nil
+		b.finished = append(b.finished, ev.ScanID)
+		b.evict()
+	}
+}
+
+// Subscribe returns a channel receiving the scan's events, starting with the
+// buffered history. The channel is closed after TypeScanFinished. Call the
+// returned cancel func to unsubscribe early.
+func (b *Broker) Subscribe(scanID string) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := b.stream(scanID)
+	ch := make(chan Event, subscriberBuffer+len(st.history))
+	for _, ev := range st.history {
+		ch <- ev
+	}
+
+	if st.finished {
+		close(ch)
+		return ch, func() {}
+	}
+
+	st.subs[ch] = struct{}{}
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := st.subs[ch]; ok {
+				delete(st.subs, ch)
+				close(ch)
+			}
+			// Forget streams that never saw an event (e.g., unknown scan IDs)
+			if len(st.subs) == 0 && len(st.history) == 0 && !st.finished {
+				delete(b.scans, scanID)
+			}
+		})
+	}
+	return ch, cancel
+}
+
+// stream returns (creating if needed) the stream for scanID. Caller holds mu.
+func (b *Broker) stream(scanID string) *scanStream {
+	st, ok := b.scans[scanID]
+	if !ok {
+		st = &scanStream{subs: make(map[chan Event]struct{})}
+		b.scans[scanID] = st
+	}
+	return st
+}
+
+// evict drops history of the oldest finished scans beyond maxFinished. Caller holds mu.
+func (b *Broker) evict() {
+	for len(b.finished) > b.maxFinished {
+		delete(b.scans, b.finished[0])
+		b.finished = b.finished[1:]
+	}
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func drain(t *testing.T, ch <-chan Event) []Event {
+	t.Helper()
+	var out []Event
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return out
+			}
+			out = append(out, ev)
+		case <-timeout:
+			t.Fatal("channel was not closed")
+		}
+	}
+}
+
+func TestBroker_PublishSubscribe(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("scan-1")
+	defer cancel()
+
+	b.Publish(Event{Type: TypeHostDiscovered, ScanID: "scan-1", Data: "10.0.0.1"})
+	b.Publish(Event{Type: TypeHostDiscovered, ScanID: "scan-2", Data: "10.0.0.2"})
+	b.Publish(Event{Type: TypeScanFinished, ScanID: "scan-1", Data: map[string]string{"status": "completed"}})
+
+	events := drain(t, ch)
+	require.Len(t, events, 2, "only scan-1 events are delivered")
+	require.Equal(t, TypeHostDiscovered, events[0].Type)
+	require.False(t, events[0].Timestamp.IsZero())
+	require.Equal(t, TypeScanFinished, events[1].Type)
+}
+
+func TestBroker_ReplaysHistoryForLateSubscribers(t *testing.T) {
+	b := NewBroker()
+	b.Publish(Event{Type: TypePortOpen, ScanID: "scan-1"})
+	b.Publish(Event{Type: TypeFindingCreated, ScanID: "scan-1"})
+
+	ch, cancel := b.Subscribe("scan-1")
+	defer cancel()
+	b.Publish(Event{Type: TypeScanFinished, ScanID: "scan-1"})
+
+	events := drain(t, ch)
+	require.Len(t, events, 3)
+	require.Equal(t, TypePortOpen, events[0].Type)
+	require.Equal(t, TypeFindingCreated, events[1].Type)
+	require.Equal(t, TypeScanFinished, events[2].Type)
+}
+
+func TestBroker_SubscribeAfterFinish(t *testing.T) {
+	b := NewBroker()
+	b.Publish(Event{Type: TypeScanFinished, ScanID: "scan-1"})
+
+	ch, _ := b.Subscribe("scan-1")
+	events := drain(t, ch)
+	require.Len(t, events, 1)
+
+	// Events after finish are ignored
+	b.Publish(Event{Type: TypePortOpen, ScanID: "scan-1"})
+	ch, _ = b.Subscribe("scan-1")
+	require.Len(t, drain(t, ch), 1)
+}
+
+func TestBroker_CancelUnsubscribes(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe("scan-1")
+	cancel()
+	cancel() // idempotent
+
+	_, ok := <-ch
+	require.False(t, ok, "channel closed on cancel")
+
+	b.mu.Lock()
+	_, tracked := b.scans["scan-1"]
+	b.mu.Unlock()
+	require.False(t, tracked, "empty stream is forgotten")
+
+	// Publishing after cancel must not panic
+	b.Publish(Event{Type: TypePortOpen, ScanID: "scan-1"})
+}
+
+func TestBroker_HistoryAndEvictionBounds(t *testing.T) {
+	b := NewBroker()
+	b.historySize = 2
+	b.maxFinished = 1
+
+	for i := 0; i < 5; i++ {
+		b.Publish(Event{Type: TypePortOpen, ScanID: "scan-1"})
+	}
+	ch, cancel := b.Subscribe("scan-1")
+	require.Len(t, ch, 2, "history is capped")
+	cancel()
+
+	b.Publish(Event{Type: TypeScanFinished, ScanID: "scan-1"})
+	b.Publish(Event{Type: TypeScanFinished, ScanID: "scan-2"})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	require.NotContains(t, b.scans, "scan-1", "oldest finished scan evicted")
+	require.Contains(t, b.scans, "scan-2")
+}
+
+func TestBroker_SlowSubscriberDoesNotBlock(t *testing.T) {
+	b := NewBroker()
+	_, cancel := b.Subscribe("scan-1")
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < subscriberBuffer*2; i++ {
+			b.Publish(Event{Type: TypePortOpen, ScanID: "scan-1"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on slow subscriber")
+	}
+}
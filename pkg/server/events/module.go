@@ -0,0 +1,48 @@
+// pkg/server/events/module.go
+package events
+
+import (
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+)
+
+// Module output keys mapped to live scan events.
+var dataKeyEventTypes = map[string]string{
+	"discovery.live_hosts":       TypeHostDiscovered,
+	"discovery.open_tcp_ports":   TypePortOpen,
+	"evaluation.vulnerabilities": TypeFindingCreated,
+}
+
+// FromModuleOutput converts an orchestrator module output into a scan event.
+// Returns false for outputs that are not surfaced to stream clients.
+func FromModuleOutput(scanID string, out engine.ModuleOutput) (Event, bool) {
+	if out.Error != nil {
+		return Event{}, false
+	}
+	typ, ok := dataKeyEventTypes[out.DataKey]
+	if !ok {
+		return Event{}, false
+	}
+
+	ts := out.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	return Event{
+		Type:      typ,
+		ScanID:    scanID,
+		Timestamp: ts.UTC(),
+		Data:      out.Data,
+	}, true
+}
+
+// Observer returns an engine.OutputObserver that publishes mapped outputs for scanID.
+func Observer(p Publisher, scanID string) engine.OutputObserver {
+	return func(out engine.ModuleOutput) {
+		if ev, ok := FromModuleOutput(scanID, out); ok {
+			p.Publish(ev)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+)
+
+type recordingPublisher struct{ events []Event }
+
+func (r *recordingPublisher) Publish(ev Event) { r.events = append(r.events, ev) }
+
+func TestFromModuleOutput(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"discovery.live_hosts", TypeHostDiscovered, true},
+		{"discovery.open_tcp_ports", TypePortOpen, true},
+		{"evaluation.vulnerabilities", TypeFindingCreated, true},
+		{"service.banner.tcp", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			ev, ok := FromModuleOutput("scan-1", engine.ModuleOutput{DataKey: tt.key, Data: "x"})
+			require.Equal(t, tt.ok, ok)
+			if ok {
+				require.Equal(t, tt.want, ev.Type)
+				require.Equal(t, "scan-1", ev.ScanID)
+				require.Equal(t, "x", ev.Data)
+				require.False(t, ev.Timestamp.IsZero())
+			}
+		})
+	}
+}
+
+func TestFromModuleOutput_SkipsErrors(t *testing.T) {
+	_, ok := FromModuleOutput("scan-1", engine.ModuleOutput{DataKey: "discovery.live_hosts", Error: errors.New("x")})
+	require.False(t, ok)
+}
+
+func TestObserver(t *testing.T) {
+	pub := &recordingPublisher{}
+	obs := Observer(pub, "scan-1")
+
+	obs(engine.ModuleOutput{DataKey: "discovery.open_tcp_ports", Data: 22})
+	obs(engine.ModuleOutput{DataKey: "unrelated"})
+
+	require.Len(t, pub.events, 1)
+	require.Equal(t, TypePortOpen, pub.events[0].Type)
+}
@@ -0,0 +1,161 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+	"github.com/vulntor/vulntor/pkg/server/httpx"
+)
+
+// TenantMetadata selects the tenant a call operates on, like the
+// X-Tenant-ID header of REST requests. Calls without it use the default
+// tenant.
+const TenantMetadata = "x-tenant-id"
+
+// methodScopes holds the scope API keys need for each method, matching the
+// RequireScope wrappers of the REST routes. Methods missing here are
+// denied.
+var methodScopes = map[string]auth.Scope{
+	vulntorv1.ScanService_CreateScan_FullMethodName:        auth.ScopeScanCreate,
+	vulntorv1.ScanService_ListScans_FullMethodName:         auth.ScopeRead,
+	vulntorv1.ScanService_GetScan_FullMethodName:           auth.ScopeRead,
+	vulntorv1.ScanService_StreamScanEvents_FullMethodName:  auth.ScopeRead,
+	vulntorv1.FindingService_ListFindings_FullMethodName:   auth.ScopeRead,
+	vulntorv1.AssetService_ListAssets_FullMethodName:       auth.ScopeRead,
+	vulntorv1.PluginService_InstallPlugin_FullMethodName:   auth.ScopePluginManage,
+	vulntorv1.PluginService_UpdatePlugins_FullMethodName:   auth.ScopePluginManage,
+	vulntorv1.PluginService_ListPlugins_FullMethodName:     auth.ScopeRead,
+	vulntorv1.PluginService_GetPlugin_FullMethodName:       auth.ScopeRead,
+	vulntorv1.PluginService_UninstallPlugin_FullMethodName: auth.ScopePluginManage,
+}
+
+func unaryAuth(authn *httpx.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, authn, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(authn *httpx.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), authn, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authedStream carries the tenant and principal of an authorized stream.
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize authenticates a call to method, checks the scope it needs and
+// counts it against the caller's request rate. The returned context carries
+// the tenant and principal.
+func authorize(ctx context.Context, authn *httpx.Authenticator, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	addr := peerAddr(ctx)
+	ctx, err := authn.Authenticate(ctx, addr, firstValue(md, TenantMetadata), bearerToken(md))
+	if err != nil {
+		log.Warn().
+			Str("component", "grpc.auth").
+			Str("method", method).
+			Err(err).
+			Msg("Credential rejected")
+		return nil, authError(err)
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method is not available")
+	}
+	if p, ok := auth.PrincipalFromContext(ctx); ok && !auth.HasScope(p.Scopes, scope) {
+		log.Warn().
+			Str("component", "grpc.auth").
+			Str("method", method).
+			Str("key_id", p.KeyID).
+			Str("scope", string(scope)).
+			Msg("API key lacks required scope")
+		return nil, status.Error(codes.PermissionDenied, "API key lacks required scope: "+string(scope))
+	}
+
+	if rate, wait := authn.RateLimiter().Allow(ctx, addr); wait > 0 {
+		log.Warn().
+			Str("component", "grpc.auth").
+			Str("method", method).
+			Msg("Rate limit exceeded")
+		return nil, throttledStatus("RATE_LIMITED", fmt.Sprintf("rate limit of %d requests per minute exceeded", rate), wait)
+	}
+	return ctx, nil
+}
+
+// peerAddr returns the address of the caller of ctx.
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// authError maps authentication errors to statuses with the messages of
+// the REST API.
+func authError(err error) error {
+	var throttled *httpx.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		return throttledStatus("RATE_LIMITED", "too many failed authentications", throttled.RetryAfter)
+	case errors.Is(err, httpx.ErrUnknownTenant):
+		return status.Error(codes.NotFound, "Unknown tenant")
+	case errors.Is(err, httpx.ErrMissingCredentials):
+		return status.Error(codes.Unauthenticated, "Missing authorization header")
+	case errors.Is(err, httpx.ErrAuthConfig):
+		return status.Error(codes.Unauthenticated, "Authentication configuration error")
+	case errors.Is(err, auth.ErrKeyRevoked):
+		return status.Error(codes.Unauthenticated, "API key revoked")
+	case errors.Is(err, auth.ErrInvalidKey):
+		return status.Error(codes.Unauthenticated, "Invalid API key")
+	case errors.Is(err, auth.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, "Invalid token")
+	case errors.Is(err, auth.ErrNoRole):
+		return status.Error(codes.PermissionDenied, "No role assigned to user")
+	default:
+		return status.Error(codes.Unauthenticated, "Authentication failed")
+	}
+}
+
+// bearerToken extracts the token of the authorization metadata.
+func bearerToken(md metadata.MD) string {
+	scheme, token, ok := strings.Cut(firstValue(md, "authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
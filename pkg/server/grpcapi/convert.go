@@ -0,0 +1,54 @@
+package grpcapi
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timestampOf converts the RFC 3339 times of the REST DTOs; empty or
+// invalid times are absent.
+func timestampOf(s string) *timestamppb.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// timestampOfTime converts t; the zero time is absent.
+func timestampOfTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// structOf converts a JSON object of the REST DTOs to a Struct. Values go
+// through their JSON encoding, so they read as in REST responses.
+func structOf(v map[string]interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// valueOf converts any JSON value to a Value through its JSON encoding.
+func valueOf(v any) (*structpb.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	value := &structpb.Value{}
+	if err := value.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
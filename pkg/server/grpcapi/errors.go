@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+)
+
+// errorDomain is the domain of the ErrorInfo details of statuses.
+const errorDomain = "vulntor.ai"
+
+// newStatus returns a status error carrying the machine-readable code of
+// the REST error responses (e.g. PLUGIN_NOT_FOUND) as ErrorInfo reason.
+func newStatus(c codes.Code, errorCode, message string) error {
+	st := status.New(c, message)
+	if withInfo, err := st.WithDetails(&errdetails.ErrorInfo{Reason: errorCode, Domain: errorDomain}); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+// throttledStatus returns a ResourceExhausted status with errorCode that
+// tells the client to retry after wait, like the Retry-After header of the
+// REST API's 429 responses.
+func throttledStatus(errorCode, message string, wait time.Duration) error {
+	seconds := max(int(math.Ceil(wait.Seconds())), 1)
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("%s, retry in %ds", message, seconds))
+	if withInfo, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: errorCode, Domain: errorDomain},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Duration(seconds) * time.Second)},
+	); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+// errorStatus classifies err like api.WriteError and returns its status.
+func errorStatus(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return newStatus(codes.DeadlineExceeded, "TIMEOUT", "operation timed out")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	httpStatus, _, errorCode, message := api.ErrorStatus(err)
+	return newStatus(codeOf(httpStatus), errorCode, message)
+}
+
+// codeOf maps HTTP status codes of REST errors to gRPC codes.
+func codeOf(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
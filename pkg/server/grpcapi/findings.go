@@ -0,0 +1,102 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Page sizes of the list methods, as in the REST API.
+const (
+	defaultLimit = 50
+	maxLimit     = 100
+)
+
+// findingServer implements FindingService like GET
+// /api/v1/scans/{id}/findings.
+type findingServer struct {
+	vulntorv1.UnimplementedFindingServiceServer
+	deps *api.Deps
+}
+
+// ListFindings pages through the findings of a scan. Scans that are still
+// running or produced no findings return an empty page.
+func (s *findingServer) ListFindings(ctx context.Context, in *vulntorv1.ListFindingsRequest) (*vulntorv1.ListFindingsResponse, error) {
+	if in.GetScanId() == "" {
+		return nil, newStatus(codes.InvalidArgument, "SCAN_ID_REQUIRED", "scan id is required")
+	}
+	limit, offset := int(in.GetLimit()), int(in.GetOffset())
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_QUERY", "limit: must be between 1 and 100")
+	}
+	if offset < 0 {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_QUERY", "offset: must be >= 0")
+	}
+	if err := requireScan(ctx, s.deps, in.GetScanId()); err != nil {
+		return nil, err
+	}
+
+	findings, err := v1.ReadFindings(ctx, s.deps.Storage, in.GetScanId())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	resp := &vulntorv1.ListFindingsResponse{Total: int32(len(findings)), Limit: int32(limit), Offset: int32(offset)}
+	for i := offset; i < len(findings) && i < offset+limit; i++ {
+		f, err := structOf(findings[i])
+		if err != nil {
+			return nil, errorStatus(err)
+		}
+		resp.Findings = append(resp.Findings, f)
+	}
+	return resp, nil
+}
+
+// assetServer implements AssetService.
+type assetServer struct {
+	vulntorv1.UnimplementedAssetServiceServer
+	deps *api.Deps
+}
+
+// ListAssets returns the live hosts of a scan with their open ports.
+func (s *assetServer) ListAssets(ctx context.Context, in *vulntorv1.ListAssetsRequest) (*vulntorv1.ListAssetsResponse, error) {
+	if in.GetScanId() == "" {
+		return nil, newStatus(codes.InvalidArgument, "SCAN_ID_REQUIRED", "scan id is required")
+	}
+	if err := requireScan(ctx, s.deps, in.GetScanId()); err != nil {
+		return nil, err
+	}
+
+	hosts, err := v1.ReadHosts(ctx, s.deps.Storage, in.GetScanId())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	resp := &vulntorv1.ListAssetsResponse{}
+	for _, h := range hosts {
+		asset := &vulntorv1.Asset{Host: h.IP}
+		for _, p := range h.Ports {
+			asset.OpenPorts = append(asset.OpenPorts, int32(p.Port))
+		}
+		resp.Assets = append(resp.Assets, asset)
+	}
+	return resp, nil
+}
+
+// requireScan returns NotFound unless the tenant of ctx has scan id.
+func requireScan(ctx context.Context, deps *api.Deps, id string) error {
+	if deps.Storage == nil {
+		return errorStatus(errors.New("no storage backend configured"))
+	}
+	if _, err := deps.Storage.Scans().Get(ctx, storage.OrgIDFromContext(ctx), id); err != nil {
+		return errorStatus(err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+)
+
+// pluginServer implements PluginService like the /api/v1/plugins routes.
+type pluginServer struct {
+	vulntorv1.UnimplementedPluginServiceServer
+	plugins v1.PluginService
+	config  api.Config
+}
+
+// withTimeout applies the handler timeout of the API unless the caller set
+// a deadline.
+func (s *pluginServer) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline && s.config.HandlerTimeout > 0 {
+		return context.WithTimeout(ctx, s.config.HandlerTimeout)
+	}
+	return ctx, func() {}
+}
+
+// InstallPlugin installs plugins by ID or category like POST
+// /api/v1/plugins/install.
+func (s *pluginServer) InstallPlugin(ctx context.Context, in *vulntorv1.InstallPluginRequest) (*vulntorv1.InstallPluginResponse, error) {
+	req := v1.InstallPluginRequest{
+		Target:         in.GetTarget(),
+		Force:          in.GetForce(),
+		Source:         in.GetSource(),
+		AllowUntrusted: in.GetAllowUntrusted(),
+	}
+	if err := v1.ParseInstallPlugin(req); err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_INPUT", err.Error())
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	result, err := s.plugins.Install(ctx, req.Target, plugin.InstallOptions{
+		Force:          req.Force,
+		Source:         req.Source,
+		AllowUntrusted: req.AllowUntrusted,
+	})
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	log.Info().
+		Str("component", "grpc.plugins").
+		Str("target", req.Target).
+		Int("installed_count", result.InstalledCount).
+		Int("failed_count", result.FailedCount).
+		Msg("install succeeded")
+	return &vulntorv1.InstallPluginResponse{
+		InstalledCount: int32(result.InstalledCount),
+		SkippedCount:   int32(result.SkippedCount),
+		FailedCount:    int32(result.FailedCount),
+		Plugins:        pluginInfos(result.Plugins),
+		Errors:         pluginErrors(result.Errors),
+	}, nil
+}
+
+// UpdatePlugins downloads plugins from remote sources like POST
+// /api/v1/plugins/update.
+func (s *pluginServer) UpdatePlugins(ctx context.Context, in *vulntorv1.UpdatePluginsRequest) (*vulntorv1.UpdatePluginsResponse, error) {
+	req := v1.UpdatePluginsRequest{
+		Category: in.GetCategory(),
+		Source:   in.GetSource(),
+		Force:    in.GetForce(),
+		DryRun:   in.GetDryRun(),
+	}
+	if err := v1.ParseUpdatePlugins(req); err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_INPUT", err.Error())
+	}
+
+	opts := plugin.UpdateOptions{Source: req.Source, Force: req.Force, DryRun: req.DryRun}
+	if req.Category != "" {
+		opts.Category = plugin.Category(req.Category)
+	}
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	result, err := s.plugins.Update(ctx, opts)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &vulntorv1.UpdatePluginsResponse{
+		UpdatedCount: int32(result.UpdatedCount),
+		SkippedCount: int32(result.SkippedCount),
+		FailedCount:  int32(result.FailedCount),
+		Plugins:      pluginInfos(result.Plugins),
+		Errors:       pluginErrors(result.Errors),
+	}, nil
+}
+
+// ListPlugins lists installed plugins like GET /api/v1/plugins.
+func (s *pluginServer) ListPlugins(ctx context.Context, _ *vulntorv1.ListPluginsRequest) (*vulntorv1.ListPluginsResponse, error) {
+	plugins, err := s.plugins.List(ctx)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &vulntorv1.ListPluginsResponse{Plugins: pluginInfos(plugins), Count: int32(len(plugins))}, nil
+}
+
+// GetPlugin returns plugin details like GET /api/v1/plugins/{id}.
+func (s *pluginServer) GetPlugin(ctx context.Context, in *vulntorv1.GetPluginRequest) (*vulntorv1.PluginInfo, error) {
+	if err := v1.ValidatePluginID(in.GetId()); err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_PLUGIN_ID", err.Error())
+	}
+	info, err := s.plugins.GetInfo(ctx, in.GetId())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return pluginInfo(info), nil
+}
+
+// UninstallPlugin removes a plugin like DELETE /api/v1/plugins/{id}.
+func (s *pluginServer) UninstallPlugin(ctx context.Context, in *vulntorv1.UninstallPluginRequest) (*vulntorv1.UninstallPluginResponse, error) {
+	if err := v1.ValidatePluginID(in.GetId()); err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_PLUGIN_ID", err.Error())
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	result, err := s.plugins.Uninstall(ctx, in.GetId(), plugin.UninstallOptions{All: false})
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &vulntorv1.UninstallPluginResponse{
+		RemovedCount:   int32(result.RemovedCount),
+		FailedCount:    int32(result.FailedCount),
+		RemainingCount: int32(result.RemainingCount),
+		Errors:         pluginErrors(result.Errors),
+	}, nil
+}
+
+func pluginInfo(p *plugin.PluginInfo) *vulntorv1.PluginInfo {
+	return &vulntorv1.PluginInfo{
+		Id:          p.ID,
+		Name:        p.Name,
+		Version:     p.Version,
+		Type:        p.Type,
+		Author:      p.Author,
+		Severity:    p.Severity,
+		Tags:        p.Tags,
+		Checksum:    p.Checksum,
+		DownloadUrl: p.DownloadURL,
+		InstalledAt: timestampOfTime(p.InstalledAt),
+		Source:      p.Source,
+		Trust:       string(p.Trust),
+	}
+}
+
+func pluginInfos(plugins []*plugin.PluginInfo) []*vulntorv1.PluginInfo {
+	out := make([]*vulntorv1.PluginInfo, 0, len(plugins))
+	for _, p := range plugins {
+		out = append(out, pluginInfo(p))
+	}
+	return out
+}
+
+func pluginErrors(errs []plugin.PluginError) []*vulntorv1.PluginError {
+	out := make([]*vulntorv1.PluginError, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, &vulntorv1.PluginError{PluginId: e.PluginID, Error: e.Error, Code: e.Code, Suggestion: e.Suggestion})
+	}
+	return out
+}
@@ -0,0 +1,242 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vulntor/vulntor/pkg/engagement"
+	"github.com/vulntor/vulntor/pkg/scope"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+	"github.com/vulntor/vulntor/pkg/server/httpx"
+	"github.com/vulntor/vulntor/pkg/server/jobs"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// scanServer implements ScanService like the /api/v1/scans routes.
+type scanServer struct {
+	vulntorv1.UnimplementedScanServiceServer
+	deps   *api.Deps
+	limits *httpx.RateLimiter
+}
+
+// CreateScan queues a scan like POST /api/v1/scans.
+func (s *scanServer) CreateScan(ctx context.Context, in *vulntorv1.CreateScanRequest) (*vulntorv1.CreateScanResponse, error) {
+	scanSvc, ok := s.deps.ScanService.(v1.ScanService)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "scan submission requires background jobs")
+	}
+
+	req := v1.CreateScanRequest{
+		Targets:        in.GetTargets(),
+		Groups:         in.GetGroups(),
+		Profile:        in.GetProfile(),
+		Ports:          in.GetPorts(),
+		EnableVuln:     in.GetEnableVuln(),
+		OnlyDiscover:   in.GetOnlyDiscover(),
+		SkipDiscover:   in.GetSkipDiscover(),
+		Concurrency:    int(in.GetConcurrency()),
+		Timeout:        in.GetTimeout(),
+		AutoTune:       in.GetAutoTune(),
+		VerifyTimeouts: in.GetVerifyTimeouts(),
+		Pcap:           in.GetPcap(),
+		Priority:       in.GetPriority(),
+		ScopeAck:       in.GetScopeAck(),
+	}
+	if e := in.GetEngagement(); e != nil {
+		req.Engagement = &engagement.Record{
+			Client:        e.GetClient(),
+			Authorization: e.GetAuthorization(),
+			Tester:        e.GetTester(),
+		}
+		if e.WindowStart != nil {
+			req.Engagement.WindowStart = e.GetWindowStart().AsTime()
+		}
+		if e.WindowEnd != nil {
+			req.Engagement.WindowEnd = e.GetWindowEnd().AsTime()
+		}
+	}
+	if err := v1.ParseCreateScan(req); err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_INPUT", err.Error())
+	}
+
+	// Only accepted scans count against the daily quota
+	quota, release, wait := s.limits.ReserveScan(ctx, peerAddr(ctx))
+	if wait > 0 {
+		log.Warn().
+			Str("component", "grpc.scans").
+			Int("daily_scans", quota).
+			Msg("Daily scan quota exceeded")
+		return nil, throttledStatus("DAILY_QUOTA_EXCEEDED", fmt.Sprintf("daily quota of %d scans exceeded", quota), wait)
+	}
+	resp, err := scanSvc.Submit(ctx, req)
+	if err != nil {
+		release()
+		return nil, submitError(err)
+	}
+	log.Info().
+		Str("component", "grpc.scans").
+		Str("scan_id", resp.ID).
+		Int("targets", len(req.Targets)).
+		Strs("groups", req.Groups).
+		Msg("scan submitted")
+	return &vulntorv1.CreateScanResponse{Id: resp.ID, JobId: resp.JobID, Status: resp.Status}, nil
+}
+
+// submitError maps scan submission errors like CreateScanHandler.
+func submitError(err error) error {
+	switch {
+	case errors.Is(err, jobs.ErrQueueFull):
+		return newStatus(codes.Unavailable, "QUEUE_FULL", "scan queue is full, retry later")
+	case errors.Is(err, scope.ErrOutOfScope):
+		return newStatus(codes.PermissionDenied, "TARGET_OUT_OF_SCOPE", err.Error())
+	case errors.Is(err, engagement.ErrRequired):
+		return newStatus(codes.InvalidArgument, "ENGAGEMENT_REQUIRED", err.Error())
+	case errors.Is(err, engagement.ErrOutsideWindow):
+		return newStatus(codes.PermissionDenied, "OUTSIDE_ENGAGEMENT_WINDOW", err.Error())
+	case errors.Is(err, jobs.ErrTenantQuota):
+		return newStatus(codes.ResourceExhausted, "QUOTA_EXCEEDED", "too many scans queued or running for this tenant, retry later")
+	}
+	return errorStatus(err)
+}
+
+// ListScans pages through scans like GET /api/v1/scans.
+func (s *scanServer) ListScans(ctx context.Context, in *vulntorv1.ListScansRequest) (*vulntorv1.ListScansResponse, error) {
+	if s.deps.Storage == nil {
+		return nil, errorStatus(errors.New("no storage backend configured"))
+	}
+
+	// Validated like the query of the REST route
+	q := url.Values{}
+	for key, value := range map[string]string{"status": in.GetStatus(), "group": in.GetGroup(), "cursor": in.GetCursor()} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	if in.GetLimit() != 0 {
+		q.Set("limit", strconv.Itoa(int(in.GetLimit())))
+	}
+	query, err := v1.ParseListScansQuery(&http.Request{URL: &url.URL{RawQuery: q.Encode()}})
+	if err != nil {
+		return nil, newStatus(codes.InvalidArgument, "INVALID_QUERY", err.Error())
+	}
+
+	filter := storage.ScanFilter{Status: query.Status, Group: query.Group}
+	scans, next, total, err := v1.ListScans(ctx, s.deps.Storage, filter, query.Cursor, query.Limit)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	resp := &vulntorv1.ListScansResponse{NextCursor: next, Total: int32(total)}
+	for _, scan := range scans {
+		resp.Scans = append(resp.Scans, &vulntorv1.ScanSummary{
+			Id:        scan.ID,
+			StartTime: timestampOf(scan.StartTime),
+			Status:    scan.Status,
+			Targets:   int32(scan.Targets),
+			Groups:    scan.Groups,
+		})
+	}
+	return resp, nil
+}
+
+// GetScan returns scan details like GET /api/v1/scans/{id}.
+func (s *scanServer) GetScan(ctx context.Context, in *vulntorv1.GetScanRequest) (*vulntorv1.ScanDetail, error) {
+	if in.GetId() == "" {
+		return nil, newStatus(codes.InvalidArgument, "SCAN_ID_REQUIRED", "scan id is required")
+	}
+	if s.deps.Storage == nil {
+		return nil, errorStatus(errors.New("no storage backend configured"))
+	}
+
+	scan, err := v1.GetScan(ctx, s.deps.Storage, in.GetId())
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	results, err := structOf(scan.Results)
+	if err != nil {
+		return nil, errorStatus(err)
+	}
+	return &vulntorv1.ScanDetail{
+		Id:        scan.ID,
+		Target:    scan.Target,
+		StartTime: timestampOf(scan.StartTime),
+		EndTime:   timestampOf(scan.EndTime),
+		Status:    scan.Status,
+		Results:   results,
+		Groups:    scan.Groups,
+	}, nil
+}
+
+// StreamScanEvents streams live scan events like GET
+// /api/v1/scans/{id}/events: events already published are replayed first,
+// and the stream ends after the scan.finished event.
+func (s *scanServer) StreamScanEvents(in *vulntorv1.StreamScanEventsRequest, stream vulntorv1.ScanService_StreamScanEventsServer) error {
+	if s.deps.Events == nil {
+		return status.Error(codes.Unimplemented, "scan events require background jobs")
+	}
+	id := in.GetScanId()
+	if id == "" {
+		return newStatus(codes.InvalidArgument, "SCAN_ID_REQUIRED", "scan id is required")
+	}
+
+	// Resolve scan (NotFound for unknown scans)
+	ctx := stream.Context()
+	var metadata *storage.ScanMetadata
+	if s.deps.Storage != nil {
+		m, err := s.deps.Storage.Scans().Get(ctx, storage.OrgIDFromContext(ctx), id)
+		if err != nil {
+			return errorStatus(err)
+		}
+		metadata = m
+	}
+
+	ch, cancel := s.deps.Events.Subscribe(id)
+	defer cancel()
+
+	// Scan finished before this process saw it: synthesize the terminal
+	// event from storage
+	if metadata != nil && storage.ScanStatus(metadata.Status).IsTerminal() && len(ch) == 0 {
+		data := map[string]string{"status": metadata.Status}
+		if metadata.ErrorMessage != "" {
+			data["error"] = metadata.ErrorMessage
+		}
+		return sendEvent(stream, events.Event{Type: events.TypeScanFinished, ScanID: id, Timestamp: metadata.CompletedAt.UTC(), Data: data})
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := sendEvent(stream, ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sendEvent(stream vulntorv1.ScanService_StreamScanEventsServer, ev events.Event) error {
+	data, err := valueOf(ev.Data)
+	if err != nil {
+		return errorStatus(err)
+	}
+	return stream.Send(&vulntorv1.ScanEvent{
+		Type:      ev.Type,
+		ScanId:    ev.ScanID,
+		Timestamp: timestampOfTime(ev.Timestamp),
+		Data:      data,
+	})
+}
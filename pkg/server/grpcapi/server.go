@@ -0,0 +1,78 @@
+// Package grpcapi serves the gRPC API defined in api/proto/vulntor/v1 on
+// top of the services of the REST API, for clients generated from the
+// contract.
+//
+// Calls are checked like REST requests: the tenant is selected with the
+// x-tenant-id metadata, credentials are verified by the Auth rules of
+// httpx against that tenant (authorization: Bearer <token>), and API keys
+// only reach the methods their scopes allow. Failed authentications, the
+// request rate and the daily scan quota count against the same limits as
+// REST requests when the servers share the limiters (see
+// httpx.WithFailedAuthLimiter and httpx.WithRateLimiter).
+package grpcapi
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+	"github.com/vulntor/vulntor/pkg/server/httpx"
+)
+
+// NewServer returns a gRPC server serving the API services of deps. Calls
+// are authenticated by authn. The plugin service is only registered when
+// deps has one, like the REST plugin routes.
+func NewServer(deps *api.Deps, authn *httpx.Authenticator, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryRecovery, unaryAuth(authn)),
+		grpc.ChainStreamInterceptor(streamRecovery, streamAuth(authn)),
+	)
+	s := grpc.NewServer(opts...)
+
+	vulntorv1.RegisterScanServiceServer(s, &scanServer{deps: deps, limits: authn.RateLimiter()})
+	vulntorv1.RegisterFindingServiceServer(s, &findingServer{deps: deps})
+	vulntorv1.RegisterAssetServiceServer(s, &assetServer{deps: deps})
+	if pluginSvc, ok := deps.PluginService.(v1.PluginService); ok {
+		vulntorv1.RegisterPluginServiceServer(s, &pluginServer{plugins: pluginSvc, config: deps.Config})
+	}
+	return s
+}
+
+// unaryRecovery turns panics of unary handlers into Internal errors, like
+// the Recovery middleware of the REST API.
+func unaryRecovery(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logPanic(info.FullMethod, p)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// streamRecovery turns panics of stream handlers into Internal errors.
+func streamRecovery(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logPanic(info.FullMethod, p)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+func logPanic(method string, p any) {
+	log.Error().
+		Str("component", "grpc").
+		Str("method", method).
+		Interface("panic", p).
+		Bytes("stack", debug.Stack()).
+		Msg("Panic recovered")
+}
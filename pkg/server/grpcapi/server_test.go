@@ -0,0 +1,244 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/scope"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1"
+	"github.com/vulntor/vulntor/pkg/server/httpx"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// stubKeys accepts two keys of tenant team-a: a read-only key and an admin key.
+type stubKeys struct{}
+
+func (stubKeys) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	if storage.OrgIDFromContext(ctx) != "team-a" {
+		return nil, auth.ErrInvalidKey
+	}
+	switch token {
+	case "vnt_reader":
+		return &auth.Principal{KeyID: "reader", Scopes: []string{string(auth.ScopeRead)}}, nil
+	case "vnt_admin":
+		return &auth.Principal{KeyID: "admin", Scopes: []string{string(auth.ScopeAdmin)}}, nil
+	}
+	return nil, auth.ErrInvalidKey
+}
+
+type stubTenants map[string]bool
+
+func (s stubTenants) Get(ctx context.Context, tenantID string) (*storage.Tenant, error) {
+	if !s[tenantID] {
+		return nil, storage.NewNotFoundError("tenant", tenantID)
+	}
+	return &storage.Tenant{ID: tenantID}, nil
+}
+
+// stubScans records submissions and refuses targets outside 10.0.0.0/8.
+type stubScans struct{ submitted []v1.CreateScanRequest }
+
+func (s *stubScans) Submit(ctx context.Context, req v1.CreateScanRequest) (*v1.CreateScanResponse, error) {
+	for _, target := range req.Targets {
+		if !strings.HasPrefix(target, "10.") {
+			return nil, scope.ErrOutOfScope
+		}
+	}
+	s.submitted = append(s.submitted, req)
+	return &v1.CreateScanResponse{ID: "scan-new", JobID: "scan-new", Status: "pending"}, nil
+}
+
+// serve starts the API on a TCP port and returns a connection to it.
+func serve(t *testing.T, deps *api.Deps, authn *httpx.Authenticator) *grpc.ClientConn {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := NewServer(deps, authn)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func callContext(tenant, token string) context.Context {
+	md := metadata.Pairs()
+	if tenant != "" {
+		md.Set(TenantMetadata, tenant)
+	}
+	if token != "" {
+		md.Set("authorization", "Bearer "+token)
+	}
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+func TestServer_Scans(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+
+	started := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, backend.Scans().Create(ctx, "team-a", &storage.ScanMetadata{
+		ID: "scan-a", Target: "10.0.0.0/24", Status: "completed", StartedAt: started, CompletedAt: started.Add(time.Minute), HostCount: 1,
+	}))
+	require.NoError(t, backend.Scans().WriteData(ctx, "team-a", "scan-a", storage.DataTypeHosts,
+		strings.NewReader(`{"ip":"10.0.0.5","ports":[{"port":22,"protocol":"tcp"},{"port":443,"protocol":"tcp"}]}`+"\n")))
+	require.NoError(t, backend.Scans().WriteData(ctx, "team-a", "scan-a", storage.DataTypeVulnerabilities,
+		strings.NewReader(`{"target":"10.0.0.5","port":22,"plugin":"ssh-weak-cipher","severity":"high"}`+"\n")))
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{ID: "scan-default", Target: "192.168.1.0/24", Status: "completed", StartedAt: started}))
+
+	scans := &stubScans{}
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}
+	authn := httpx.NewAuthenticator(cfg, httpx.WithKeyVerifier(stubKeys{}), httpx.WithTenants(stubTenants{"team-a": true}))
+	conn := serve(t, &api.Deps{Storage: backend, ScanService: scans}, authn)
+	client := vulntorv1.NewScanServiceClient(conn)
+
+	// The tenant's scans, and only them
+	list, err := client.ListScans(callContext("team-a", "vnt_reader"), &vulntorv1.ListScansRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetScans(), 1)
+	require.Equal(t, "scan-a", list.GetScans()[0].GetId())
+	require.Equal(t, started, list.GetScans()[0].GetStartTime().AsTime())
+	require.EqualValues(t, 1, list.GetTotal())
+
+	detail, err := client.GetScan(callContext("team-a", "vnt_reader"), &vulntorv1.GetScanRequest{Id: "scan-a"})
+	require.NoError(t, err)
+	require.Equal(t, "completed", detail.GetStatus())
+	require.EqualValues(t, 1, detail.GetResults().AsMap()["hosts_found"])
+
+	findings, err := vulntorv1.NewFindingServiceClient(conn).ListFindings(callContext("team-a", "vnt_reader"), &vulntorv1.ListFindingsRequest{ScanId: "scan-a"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, findings.GetTotal())
+	require.Equal(t, "ssh-weak-cipher", findings.GetFindings()[0].AsMap()["plugin"])
+
+	assets, err := vulntorv1.NewAssetServiceClient(conn).ListAssets(callContext("team-a", "vnt_reader"), &vulntorv1.ListAssetsRequest{ScanId: "scan-a"})
+	require.NoError(t, err)
+	require.Len(t, assets.GetAssets(), 1)
+	require.Equal(t, []int32{22, 443}, assets.GetAssets()[0].GetOpenPorts())
+
+	// Scans of other tenants are not found, with the REST error code
+	_, err = client.GetScan(callContext("team-a", "vnt_reader"), &vulntorv1.GetScanRequest{Id: "scan-default"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "RESOURCE_NOT_FOUND", reason(t, err))
+
+	// Credentials are verified against the selected tenant
+	_, err = client.ListScans(callContext("", ""), &vulntorv1.ListScansRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ListScans(callContext("", "vnt_reader"), &vulntorv1.ListScansRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ListScans(callContext("team-b", "vnt_reader"), &vulntorv1.ListScansRequest{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Scan submission needs the scan:create scope
+	_, err = client.CreateScan(callContext("team-a", "vnt_reader"), &vulntorv1.CreateScanRequest{Targets: []string{"10.0.0.5"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Empty(t, scans.submitted)
+
+	created, err := client.CreateScan(callContext("team-a", "vnt_admin"), &vulntorv1.CreateScanRequest{
+		Targets:    []string{"10.0.0.5"},
+		Engagement: &vulntorv1.Engagement{Client: "ACME Corp", Tester: "alice"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "scan-new", created.GetId())
+	require.Len(t, scans.submitted, 1)
+	require.Equal(t, "alice", scans.submitted[0].Engagement.Tester)
+
+	_, err = client.CreateScan(callContext("team-a", "vnt_admin"), &vulntorv1.CreateScanRequest{Targets: []string{"203.0.113.5"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, "TARGET_OUT_OF_SCOPE", reason(t, err))
+
+	_, err = client.CreateScan(callContext("team-a", "vnt_admin"), &vulntorv1.CreateScanRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_StreamScanEvents(t *testing.T) {
+	ctx := context.Background()
+	backend, err := storage.NewLocalBackend(ctx, &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	require.NoError(t, backend.Scans().Create(ctx, "default", &storage.ScanMetadata{
+		ID: "scan-1", Target: "10.0.0.1", Status: "failed", ErrorMessage: "boom", StartedAt: time.Now(), CompletedAt: time.Now(),
+	}))
+
+	authn := httpx.NewAuthenticator(config.ServerConfig{Auth: config.AuthConfig{Mode: "token", Token: "s3cret"}})
+	conn := serve(t, &api.Deps{Storage: backend, Events: events.NewBroker()}, authn)
+
+	// Finished scans replay their terminal event from storage
+	stream, err := vulntorv1.NewScanServiceClient(conn).StreamScanEvents(callContext("", "s3cret"), &vulntorv1.StreamScanEventsRequest{ScanId: "scan-1"})
+	require.NoError(t, err)
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, "scan.finished", ev.GetType())
+	require.Equal(t, "boom", ev.GetData().GetStructValue().AsMap()["error"])
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
+
+func TestServer_Limits(t *testing.T) {
+	scans := &stubScans{}
+	authn := httpx.NewAuthenticator(config.ServerConfig{
+		Auth:      config.AuthConfig{Mode: "apikey"},
+		RateLimit: config.RateLimitConfig{FailedAuthPerMinute: 3, DailyScans: 1},
+	}, httpx.WithKeyVerifier(stubKeys{}), httpx.WithTenants(stubTenants{"team-a": true}))
+	client := vulntorv1.NewScanServiceClient(serve(t, &api.Deps{ScanService: scans}, authn))
+
+	// The daily scan quota counts accepted scans only
+	create := func() error {
+		_, err := client.CreateScan(callContext("team-a", "vnt_admin"), &vulntorv1.CreateScanRequest{Targets: []string{"10.0.0.1"}})
+		return err
+	}
+	_, err := client.CreateScan(callContext("team-a", "vnt_admin"), &vulntorv1.CreateScanRequest{Targets: []string{"192.168.1.1"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, create())
+	err = create()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "DAILY_QUOTA_EXCEEDED", reason(t, err))
+	require.Len(t, scans.submitted, 1)
+
+	// Repeated Unauthenticated calls eventually return ResourceExhausted,
+	// then valid keys are refused too
+	for range 3 {
+		_, err = client.ListScans(callContext("team-a", "vnt_guess"), &vulntorv1.ListScansRequest{})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	}
+	_, err = client.ListScans(callContext("team-a", "vnt_reader"), &vulntorv1.ListScansRequest{})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	require.Equal(t, "RATE_LIMITED", reason(t, err))
+	var retry *errdetails.RetryInfo
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	require.NotNil(t, retry)
+	require.Equal(t, 20*time.Second, retry.GetRetryDelay().AsDuration())
+}
+
+func reason(t *testing.T, err error) string {
+	t.Helper()
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,2081 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+// Vulntor gRPC API (v1).
+//
+// Mirrors the REST surface under /api/v1. Field names follow the JSON DTOs
+// in pkg/server/api/v1 and obey the same DTO Evolution Policy: additive-only
+// changes, never renumber or reuse field tags.
+//
+// Generate Go stubs with `make proto` (requires protoc, protoc-gen-go and
+// protoc-gen-go-grpc on PATH).
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: vulntor/v1/vulntor.proto
+
+package vulntorv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateScanRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Targets      []string               `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
+	Profile      string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Ports        string                 `protobuf:"bytes,3,opt,name=ports,proto3" json:"ports,omitempty"`
+	EnableVuln   bool                   `protobuf:"varint,4,opt,name=enable_vuln,json=enableVuln,proto3" json:"enable_vuln,omitempty"`
+	OnlyDiscover bool                   `protobuf:"varint,5,opt,name=only_discover,json=onlyDiscover,proto3" json:"only_discover,omitempty"`
+	SkipDiscover bool                   `protobuf:"varint,6,opt,name=skip_discover,json=skipDiscover,proto3" json:"skip_discover,omitempty"`
+	Concurrency  int32                  `protobuf:"varint,7,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// Go duration string (e.g. "1s").
+	Timeout string `protobuf:"bytes,8,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// Target groups whose targets are scanned too.
+	Groups         []string `protobuf:"bytes,9,rep,name=groups,proto3" json:"groups,omitempty"`
+	AutoTune       bool     `protobuf:"varint,10,opt,name=auto_tune,json=autoTune,proto3" json:"auto_tune,omitempty"`
+	VerifyTimeouts bool     `protobuf:"varint,11,opt,name=verify_timeouts,json=verifyTimeouts,proto3" json:"verify_timeouts,omitempty"`
+	// "all" or "findings"; empty = no capture.
+	Pcap string `protobuf:"bytes,12,opt,name=pcap,proto3" json:"pcap,omitempty"`
+	// low, normal (default) or high.
+	Priority string `protobuf:"bytes,13,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Signed acknowledgment approving targets outside the scan scope.
+	ScopeAck      string      `protobuf:"bytes,14,opt,name=scope_ack,json=scopeAck,proto3" json:"scope_ack,omitempty"`
+	Engagement    *Engagement `protobuf:"bytes,15,opt,name=engagement,proto3" json:"engagement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScanRequest) Reset() {
+	*x = CreateScanRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScanRequest) ProtoMessage() {}
+
+func (x *CreateScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScanRequest.ProtoReflect.Descriptor instead.
+func (*CreateScanRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateScanRequest) GetTargets() []string {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *CreateScanRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetPorts() string {
+	if x != nil {
+		return x.Ports
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetEnableVuln() bool {
+	if x != nil {
+		return x.EnableVuln
+	}
+	return false
+}
+
+func (x *CreateScanRequest) GetOnlyDiscover() bool {
+	if x != nil {
+		return x.OnlyDiscover
+	}
+	return false
+}
+
+func (x *CreateScanRequest) GetSkipDiscover() bool {
+	if x != nil {
+		return x.SkipDiscover
+	}
+	return false
+}
+
+func (x *CreateScanRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *CreateScanRequest) GetTimeout() string {
+	if x != nil {
+		return x.Timeout
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *CreateScanRequest) GetAutoTune() bool {
+	if x != nil {
+		return x.AutoTune
+	}
+	return false
+}
+
+func (x *CreateScanRequest) GetVerifyTimeouts() bool {
+	if x != nil {
+		return x.VerifyTimeouts
+	}
+	return false
+}
+
+func (x *CreateScanRequest) GetPcap() string {
+	if x != nil {
+		return x.Pcap
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetScopeAck() string {
+	if x != nil {
+		return x.ScopeAck
+	}
+	return ""
+}
+
+func (x *CreateScanRequest) GetEngagement() *Engagement {
+	if x != nil {
+		return x.Engagement
+	}
+	return nil
+}
+
+// Engagement mirrors pkg/engagement.Record. Empty fields are taken from the
+// server's engagement config.
+type Engagement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Client        string                 `protobuf:"bytes,1,opt,name=client,proto3" json:"client,omitempty"`
+	Authorization string                 `protobuf:"bytes,2,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	Tester        string                 `protobuf:"bytes,3,opt,name=tester,proto3" json:"tester,omitempty"`
+	WindowStart   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=window_start,json=windowStart,proto3" json:"window_start,omitempty"`
+	WindowEnd     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=window_end,json=windowEnd,proto3" json:"window_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Engagement) Reset() {
+	*x = Engagement{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Engagement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Engagement) ProtoMessage() {}
+
+func (x *Engagement) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Engagement.ProtoReflect.Descriptor instead.
+func (*Engagement) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Engagement) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *Engagement) GetAuthorization() string {
+	if x != nil {
+		return x.Authorization
+	}
+	return ""
+}
+
+func (x *Engagement) GetTester() string {
+	if x != nil {
+		return x.Tester
+	}
+	return ""
+}
+
+func (x *Engagement) GetWindowStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowStart
+	}
+	return nil
+}
+
+func (x *Engagement) GetWindowEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return nil
+}
+
+type CreateScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScanResponse) Reset() {
+	*x = CreateScanResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScanResponse) ProtoMessage() {}
+
+func (x *CreateScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScanResponse.ProtoReflect.Descriptor instead.
+func (*CreateScanResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateScanResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateScanResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *CreateScanResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListScansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Filter by status (pending, running, completed, failed). Empty = all.
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Page size (1-100, default 50).
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Opaque cursor returned by a previous call.
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Only scans run against this target group.
+	Group         string `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScansRequest) Reset() {
+	*x = ListScansRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScansRequest) ProtoMessage() {}
+
+func (x *ListScansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScansRequest.ProtoReflect.Descriptor instead.
+func (*ListScansRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListScansRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListScansRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListScansRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+type ListScansResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scans         []*ScanSummary         `protobuf:"bytes,1,rep,name=scans,proto3" json:"scans,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScansResponse) Reset() {
+	*x = ListScansResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScansResponse) ProtoMessage() {}
+
+func (x *ListScansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScansResponse.ProtoReflect.Descriptor instead.
+func (*ListScansResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListScansResponse) GetScans() []*ScanSummary {
+	if x != nil {
+		return x.Scans
+	}
+	return nil
+}
+
+func (x *ListScansResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListScansResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ScanSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Targets       int32                  `protobuf:"varint,4,opt,name=targets,proto3" json:"targets,omitempty"`
+	Groups        []string               `protobuf:"bytes,5,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanSummary) Reset() {
+	*x = ScanSummary{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanSummary) ProtoMessage() {}
+
+func (x *ScanSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanSummary.ProtoReflect.Descriptor instead.
+func (*ScanSummary) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScanSummary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScanSummary) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ScanSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScanSummary) GetTargets() int32 {
+	if x != nil {
+		return x.Targets
+	}
+	return 0
+}
+
+func (x *ScanSummary) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type GetScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScanRequest) Reset() {
+	*x = GetScanRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScanRequest) ProtoMessage() {}
+
+func (x *GetScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScanRequest.ProtoReflect.Descriptor instead.
+func (*GetScanRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetScanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ScanDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Results       *structpb.Struct       `protobuf:"bytes,6,opt,name=results,proto3" json:"results,omitempty"`
+	Groups        []string               `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanDetail) Reset() {
+	*x = ScanDetail{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanDetail) ProtoMessage() {}
+
+func (x *ScanDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanDetail.ProtoReflect.Descriptor instead.
+func (*ScanDetail) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ScanDetail) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScanDetail) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ScanDetail) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ScanDetail) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ScanDetail) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScanDetail) GetResults() *structpb.Struct {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ScanDetail) GetGroups() []string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type StreamScanEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        string                 `protobuf:"bytes,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamScanEventsRequest) Reset() {
+	*x = StreamScanEventsRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamScanEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamScanEventsRequest) ProtoMessage() {}
+
+func (x *StreamScanEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamScanEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamScanEventsRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StreamScanEventsRequest) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+// ScanEvent mirrors pkg/server/events.Event.
+type ScanEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// host.discovered, port.open, finding.created or scan.finished.
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ScanId        string                 `protobuf:"bytes,2,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanEvent) Reset() {
+	*x = ScanEvent{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanEvent) ProtoMessage() {}
+
+func (x *ScanEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanEvent.ProtoReflect.Descriptor instead.
+func (*ScanEvent) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScanEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *ScanEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ScanEvent) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListFindingsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ScanId string                 `protobuf:"bytes,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	// Page size (1-100, default 50).
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFindingsRequest) Reset() {
+	*x = ListFindingsRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFindingsRequest) ProtoMessage() {}
+
+func (x *ListFindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListFindingsRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListFindingsRequest) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+func (x *ListFindingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFindingsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListFindingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Findings      []*structpb.Struct     `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFindingsResponse) Reset() {
+	*x = ListFindingsResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFindingsResponse) ProtoMessage() {}
+
+func (x *ListFindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListFindingsResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListFindingsResponse) GetFindings() []*structpb.Struct {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *ListFindingsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListFindingsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFindingsResponse) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScanId        string                 `protobuf:"bytes,1,opt,name=scan_id,json=scanId,proto3" json:"scan_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsRequest) Reset() {
+	*x = ListAssetsRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsRequest) ProtoMessage() {}
+
+func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListAssetsRequest) GetScanId() string {
+	if x != nil {
+		return x.ScanId
+	}
+	return ""
+}
+
+type ListAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsResponse) Reset() {
+	*x = ListAssetsResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsResponse) ProtoMessage() {}
+
+func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type Asset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	OpenPorts     []int32                `protobuf:"varint,2,rep,packed,name=open_ports,json=openPorts,proto3" json:"open_ports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Asset) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Asset) GetOpenPorts() []int32 {
+	if x != nil {
+		return x.OpenPorts
+	}
+	return nil
+}
+
+type PluginInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version     string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Type        string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Author      string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Severity    string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Checksum    string                 `protobuf:"bytes,8,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	DownloadUrl string                 `protobuf:"bytes,9,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	InstalledAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=installed_at,json=installedAt,proto3" json:"installed_at,omitempty"`
+	Source      string                 `protobuf:"bytes,11,opt,name=source,proto3" json:"source,omitempty"`
+	// official, verified, community or untrusted.
+	Trust         string `protobuf:"bytes,12,opt,name=trust,proto3" json:"trust,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginInfo) Reset() {
+	*x = PluginInfo{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginInfo) ProtoMessage() {}
+
+func (x *PluginInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginInfo.ProtoReflect.Descriptor instead.
+func (*PluginInfo) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PluginInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetInstalledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InstalledAt
+	}
+	return nil
+}
+
+func (x *PluginInfo) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PluginInfo) GetTrust() string {
+	if x != nil {
+		return x.Trust
+	}
+	return ""
+}
+
+// PluginError mirrors plugin.PluginError for partial failures.
+type PluginError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PluginId      string                 `protobuf:"bytes,1,opt,name=plugin_id,json=pluginId,proto3" json:"plugin_id,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Suggestion    string                 `protobuf:"bytes,4,opt,name=suggestion,proto3" json:"suggestion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PluginError) Reset() {
+	*x = PluginError{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PluginError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginError) ProtoMessage() {}
+
+func (x *PluginError) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginError.ProtoReflect.Descriptor instead.
+func (*PluginError) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PluginError) GetPluginId() string {
+	if x != nil {
+		return x.PluginId
+	}
+	return ""
+}
+
+func (x *PluginError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PluginError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PluginError) GetSuggestion() string {
+	if x != nil {
+		return x.Suggestion
+	}
+	return ""
+}
+
+type InstallPluginRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Plugin ID or category name.
+	Target         string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Source         string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Force          bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	AllowUntrusted bool   `protobuf:"varint,4,opt,name=allow_untrusted,json=allowUntrusted,proto3" json:"allow_untrusted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InstallPluginRequest) Reset() {
+	*x = InstallPluginRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallPluginRequest) ProtoMessage() {}
+
+func (x *InstallPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallPluginRequest.ProtoReflect.Descriptor instead.
+func (*InstallPluginRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InstallPluginRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *InstallPluginRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *InstallPluginRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *InstallPluginRequest) GetAllowUntrusted() bool {
+	if x != nil {
+		return x.AllowUntrusted
+	}
+	return false
+}
+
+type InstallPluginResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	InstalledCount int32                  `protobuf:"varint,1,opt,name=installed_count,json=installedCount,proto3" json:"installed_count,omitempty"`
+	SkippedCount   int32                  `protobuf:"varint,2,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	FailedCount    int32                  `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	Plugins        []*PluginInfo          `protobuf:"bytes,4,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	Errors         []*PluginError         `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InstallPluginResponse) Reset() {
+	*x = InstallPluginResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallPluginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallPluginResponse) ProtoMessage() {}
+
+func (x *InstallPluginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallPluginResponse.ProtoReflect.Descriptor instead.
+func (*InstallPluginResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *InstallPluginResponse) GetInstalledCount() int32 {
+	if x != nil {
+		return x.InstalledCount
+	}
+	return 0
+}
+
+func (x *InstallPluginResponse) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *InstallPluginResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *InstallPluginResponse) GetPlugins() []*PluginInfo {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *InstallPluginResponse) GetErrors() []*PluginError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type UpdatePluginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePluginsRequest) Reset() {
+	*x = UpdatePluginsRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePluginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePluginsRequest) ProtoMessage() {}
+
+func (x *UpdatePluginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePluginsRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePluginsRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdatePluginsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UpdatePluginsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdatePluginsRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *UpdatePluginsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type UpdatePluginsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	SkippedCount  int32                  `protobuf:"varint,2,opt,name=skipped_count,json=skippedCount,proto3" json:"skipped_count,omitempty"`
+	FailedCount   int32                  `protobuf:"varint,3,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	Plugins       []*PluginInfo          `protobuf:"bytes,4,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	Errors        []*PluginError         `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePluginsResponse) Reset() {
+	*x = UpdatePluginsResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePluginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePluginsResponse) ProtoMessage() {}
+
+func (x *UpdatePluginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePluginsResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePluginsResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdatePluginsResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *UpdatePluginsResponse) GetSkippedCount() int32 {
+	if x != nil {
+		return x.SkippedCount
+	}
+	return 0
+}
+
+func (x *UpdatePluginsResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *UpdatePluginsResponse) GetPlugins() []*PluginInfo {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *UpdatePluginsResponse) GetErrors() []*PluginError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ListPluginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsRequest) Reset() {
+	*x = ListPluginsRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsRequest) ProtoMessage() {}
+
+func (x *ListPluginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsRequest.ProtoReflect.Descriptor instead.
+func (*ListPluginsRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{21}
+}
+
+type ListPluginsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plugins       []*PluginInfo          `protobuf:"bytes,1,rep,name=plugins,proto3" json:"plugins,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPluginsResponse) Reset() {
+	*x = ListPluginsResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPluginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPluginsResponse) ProtoMessage() {}
+
+func (x *ListPluginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPluginsResponse.ProtoReflect.Descriptor instead.
+func (*ListPluginsResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPluginsResponse) GetPlugins() []*PluginInfo {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+func (x *ListPluginsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetPluginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPluginRequest) Reset() {
+	*x = GetPluginRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPluginRequest) ProtoMessage() {}
+
+func (x *GetPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPluginRequest.ProtoReflect.Descriptor instead.
+func (*GetPluginRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetPluginRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UninstallPluginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UninstallPluginRequest) Reset() {
+	*x = UninstallPluginRequest{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UninstallPluginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UninstallPluginRequest) ProtoMessage() {}
+
+func (x *UninstallPluginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UninstallPluginRequest.ProtoReflect.Descriptor instead.
+func (*UninstallPluginRequest) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UninstallPluginRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UninstallPluginResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	RemovedCount   int32                  `protobuf:"varint,1,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	FailedCount    int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	RemainingCount int32                  `protobuf:"varint,3,opt,name=remaining_count,json=remainingCount,proto3" json:"remaining_count,omitempty"`
+	Errors         []*PluginError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UninstallPluginResponse) Reset() {
+	*x = UninstallPluginResponse{}
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UninstallPluginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UninstallPluginResponse) ProtoMessage() {}
+
+func (x *UninstallPluginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_vulntor_v1_vulntor_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UninstallPluginResponse.ProtoReflect.Descriptor instead.
+func (*UninstallPluginResponse) Descriptor() ([]byte, []int) {
+	return file_vulntor_v1_vulntor_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UninstallPluginResponse) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+func (x *UninstallPluginResponse) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *UninstallPluginResponse) GetRemainingCount() int32 {
+	if x != nil {
+		return x.RemainingCount
+	}
+	return 0
+}
+
+func (x *UninstallPluginResponse) GetErrors() []*PluginError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_vulntor_v1_vulntor_proto protoreflect.FileDescriptor
+
+const file_vulntor_v1_vulntor_proto_rawDesc = "" +
+	"\n" +
+	"\x18vulntor/v1/vulntor.proto\x12\n" +
+	"vulntor.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xe7\x03\n" +
+	"\x11CreateScanRequest\x12\x18\n" +
+	"\atargets\x18\x01 \x03(\tR\atargets\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x14\n" +
+	"\x05ports\x18\x03 \x01(\tR\x05ports\x12\x1f\n" +
+	"\venable_vuln\x18\x04 \x01(\bR\n" +
+	"enableVuln\x12#\n" +
+	"\ronly_discover\x18\x05 \x01(\bR\fonlyDiscover\x12#\n" +
+	"\rskip_discover\x18\x06 \x01(\bR\fskipDiscover\x12 \n" +
+	"\vconcurrency\x18\a \x01(\x05R\vconcurrency\x12\x18\n" +
+	"\atimeout\x18\b \x01(\tR\atimeout\x12\x16\n" +
+	"\x06groups\x18\t \x03(\tR\x06groups\x12\x1b\n" +
+	"\tauto_tune\x18\n" +
+	" \x01(\bR\bautoTune\x12'\n" +
+	"\x0fverify_timeouts\x18\v \x01(\bR\x0everifyTimeouts\x12\x12\n" +
+	"\x04pcap\x18\f \x01(\tR\x04pcap\x12\x1a\n" +
+	"\bpriority\x18\r \x01(\tR\bpriority\x12\x1b\n" +
+	"\tscope_ack\x18\x0e \x01(\tR\bscopeAck\x126\n" +
+	"\n" +
+	"engagement\x18\x0f \x01(\v2\x16.vulntor.v1.EngagementR\n" +
+	"engagement\"\xdc\x01\n" +
+	"\n" +
+	"Engagement\x12\x16\n" +
+	"\x06client\x18\x01 \x01(\tR\x06client\x12$\n" +
+	"\rauthorization\x18\x02 \x01(\tR\rauthorization\x12\x16\n" +
+	"\x06tester\x18\x03 \x01(\tR\x06tester\x12=\n" +
+	"\fwindow_start\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vwindowStart\x129\n" +
+	"\n" +
+	"window_end\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\twindowEnd\"S\n" +
+	"\x12CreateScanResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"n\n" +
+	"\x10ListScansRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\"y\n" +
+	"\x11ListScansResponse\x12-\n" +
+	"\x05scans\x18\x01 \x03(\v2\x17.vulntor.v1.ScanSummaryR\x05scans\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\"\xa2\x01\n" +
+	"\vScanSummary\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\atargets\x18\x04 \x01(\x05R\atargets\x12\x16\n" +
+	"\x06groups\x18\x05 \x03(\tR\x06groups\" \n" +
+	"\x0eGetScanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x89\x02\n" +
+	"\n" +
+	"ScanDetail\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x121\n" +
+	"\aresults\x18\x06 \x01(\v2\x17.google.protobuf.StructR\aresults\x12\x16\n" +
+	"\x06groups\x18\a \x03(\tR\x06groups\"2\n" +
+	"\x17StreamScanEventsRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\tR\x06scanId\"\x9e\x01\n" +
+	"\tScanEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\ascan_id\x18\x02 \x01(\tR\x06scanId\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12*\n" +
+	"\x04data\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x04data\"\\\n" +
+	"\x13ListFindingsRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\tR\x06scanId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x8f\x01\n" +
+	"\x14ListFindingsResponse\x123\n" +
+	"\bfindings\x18\x01 \x03(\v2\x17.google.protobuf.StructR\bfindings\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\",\n" +
+	"\x11ListAssetsRequest\x12\x17\n" +
+	"\ascan_id\x18\x01 \x01(\tR\x06scanId\"?\n" +
+	"\x12ListAssetsResponse\x12)\n" +
+	"\x06assets\x18\x01 \x03(\v2\x11.vulntor.v1.AssetR\x06assets\":\n" +
+	"\x05Asset\x12\x12\n" +
+	"\x04host\x18\x01 \x01(\tR\x04host\x12\x1d\n" +
+	"\n" +
+	"open_ports\x18\x02 \x03(\x05R\topenPorts\"\xd2\x02\n" +
+	"\n" +
+	"PluginInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x1a\n" +
+	"\bseverity\x18\x06 \x01(\tR\bseverity\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1a\n" +
+	"\bchecksum\x18\b \x01(\tR\bchecksum\x12!\n" +
+	"\fdownload_url\x18\t \x01(\tR\vdownloadUrl\x12=\n" +
+	"\finstalled_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vinstalledAt\x12\x16\n" +
+	"\x06source\x18\v \x01(\tR\x06source\x12\x14\n" +
+	"\x05trust\x18\f \x01(\tR\x05trust\"t\n" +
+	"\vPluginError\x12\x1b\n" +
+	"\tplugin_id\x18\x01 \x01(\tR\bpluginId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1e\n" +
+	"\n" +
+	"suggestion\x18\x04 \x01(\tR\n" +
+	"suggestion\"\x85\x01\n" +
+	"\x14InstallPluginRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12'\n" +
+	"\x0fallow_untrusted\x18\x04 \x01(\bR\x0eallowUntrusted\"\xeb\x01\n" +
+	"\x15InstallPluginResponse\x12'\n" +
+	"\x0finstalled_count\x18\x01 \x01(\x05R\x0einstalledCount\x12#\n" +
+	"\rskipped_count\x18\x02 \x01(\x05R\fskippedCount\x12!\n" +
+	"\ffailed_count\x18\x03 \x01(\x05R\vfailedCount\x120\n" +
+	"\aplugins\x18\x04 \x03(\v2\x16.vulntor.v1.PluginInfoR\aplugins\x12/\n" +
+	"\x06errors\x18\x05 \x03(\v2\x17.vulntor.v1.PluginErrorR\x06errors\"y\n" +
+	"\x14UpdatePluginsRequest\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\xe7\x01\n" +
+	"\x15UpdatePluginsResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12#\n" +
+	"\rskipped_count\x18\x02 \x01(\x05R\fskippedCount\x12!\n" +
+	"\ffailed_count\x18\x03 \x01(\x05R\vfailedCount\x120\n" +
+	"\aplugins\x18\x04 \x03(\v2\x16.vulntor.v1.PluginInfoR\aplugins\x12/\n" +
+	"\x06errors\x18\x05 \x03(\v2\x17.vulntor.v1.PluginErrorR\x06errors\"\x14\n" +
+	"\x12ListPluginsRequest\"]\n" +
+	"\x13ListPluginsResponse\x120\n" +
+	"\aplugins\x18\x01 \x03(\v2\x16.vulntor.v1.PluginInfoR\aplugins\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\"\n" +
+	"\x10GetPluginRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
+	"\x16UninstallPluginRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbb\x01\n" +
+	"\x17UninstallPluginResponse\x12#\n" +
+	"\rremoved_count\x18\x01 \x01(\x05R\fremovedCount\x12!\n" +
+	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12'\n" +
+	"\x0fremaining_count\x18\x03 \x01(\x05R\x0eremainingCount\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.vulntor.v1.PluginErrorR\x06errors2\xb5\x02\n" +
+	"\vScanService\x12K\n" +
+	"\n" +
+	"CreateScan\x12\x1d.vulntor.v1.CreateScanRequest\x1a\x1e.vulntor.v1.CreateScanResponse\x12H\n" +
+	"\tListScans\x12\x1c.vulntor.v1.ListScansRequest\x1a\x1d.vulntor.v1.ListScansResponse\x12=\n" +
+	"\aGetScan\x12\x1a.vulntor.v1.GetScanRequest\x1a\x16.vulntor.v1.ScanDetail\x12P\n" +
+	"\x10StreamScanEvents\x12#.vulntor.v1.StreamScanEventsRequest\x1a\x15.vulntor.v1.ScanEvent0\x012c\n" +
+	"\x0eFindingService\x12Q\n" +
+	"\fListFindings\x12\x1f.vulntor.v1.ListFindingsRequest\x1a .vulntor.v1.ListFindingsResponse2[\n" +
+	"\fAssetService\x12K\n" +
+	"\n" +
+	"ListAssets\x12\x1d.vulntor.v1.ListAssetsRequest\x1a\x1e.vulntor.v1.ListAssetsResponse2\xaa\x03\n" +
+	"\rPluginService\x12T\n" +
+	"\rInstallPlugin\x12 .vulntor.v1.InstallPluginRequest\x1a!.vulntor.v1.InstallPluginResponse\x12T\n" +
+	"\rUpdatePlugins\x12 .vulntor.v1.UpdatePluginsRequest\x1a!.vulntor.v1.UpdatePluginsResponse\x12N\n" +
+	"\vListPlugins\x12\x1e.vulntor.v1.ListPluginsRequest\x1a\x1f.vulntor.v1.ListPluginsResponse\x12A\n" +
+	"\tGetPlugin\x12\x1c.vulntor.v1.GetPluginRequest\x1a\x16.vulntor.v1.PluginInfo\x12Z\n" +
+	"\x0fUninstallPlugin\x12\".vulntor.v1.UninstallPluginRequest\x1a#.vulntor.v1.UninstallPluginResponseBCZAgithub.com/vulntor/vulntor/pkg/server/grpcapi/vulntorv1;vulntorv1b\x06proto3"
+
+var (
+	file_vulntor_v1_vulntor_proto_rawDescOnce sync.Once
+	file_vulntor_v1_vulntor_proto_rawDescData []byte
+)
+
+func file_vulntor_v1_vulntor_proto_rawDescGZIP() []byte {
+	file_vulntor_v1_vulntor_proto_rawDescOnce.Do(func() {
+		file_vulntor_v1_vulntor_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_vulntor_v1_vulntor_proto_rawDesc), len(file_vulntor_v1_vulntor_proto_rawDesc)))
+	})
+	return file_vulntor_v1_vulntor_proto_rawDescData
+}
+
+var file_vulntor_v1_vulntor_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_vulntor_v1_vulntor_proto_goTypes = []any{
+	(*CreateScanRequest)(nil),       // 0: vulntor.v1.CreateScanRequest
+	(*Engagement)(nil),              // 1: vulntor.v1.Engagement
+	(*CreateScanResponse)(nil),      // 2: vulntor.v1.CreateScanResponse
+	(*ListScansRequest)(nil),        // 3: vulntor.v1.ListScansRequest
+	(*ListScansResponse)(nil),       // 4: vulntor.v1.ListScansResponse
+	(*ScanSummary)(nil),             // 5: vulntor.v1.ScanSummary
+	(*GetScanRequest)(nil),          // 6: vulntor.v1.GetScanRequest
+	(*ScanDetail)(nil),              // 7: vulntor.v1.ScanDetail
+	(*StreamScanEventsRequest)(nil), // 8: vulntor.v1.StreamScanEventsRequest
+	(*ScanEvent)(nil),               // 9: vulntor.v1.ScanEvent
+	(*ListFindingsRequest)(nil),     // 10: vulntor.v1.ListFindingsRequest
+	(*ListFindingsResponse)(nil),    // 11: vulntor.v1.ListFindingsResponse
+	(*ListAssetsRequest)(nil),       // 12: vulntor.v1.ListAssetsRequest
+	(*ListAssetsResponse)(nil),      // 13: vulntor.v1.ListAssetsResponse
+	(*Asset)(nil),                   // 14: vulntor.v1.Asset
+	(*PluginInfo)(nil),              // 15: vulntor.v1.PluginInfo
+	(*PluginError)(nil),             // 16: vulntor.v1.PluginError
+	(*InstallPluginRequest)(nil),    // 17: vulntor.v1.InstallPluginRequest
+	(*InstallPluginResponse)(nil),   // 18: vulntor.v1.InstallPluginResponse
+	(*UpdatePluginsRequest)(nil),    // 19: vulntor.v1.UpdatePluginsRequest
+	(*UpdatePluginsResponse)(nil),   // 20: vulntor.v1.UpdatePluginsResponse
+	(*ListPluginsRequest)(nil),      // 21: vulntor.v1.ListPluginsRequest
+	(*ListPluginsResponse)(nil),     // 22: vulntor.v1.ListPluginsResponse
+	(*GetPluginRequest)(nil),        // 23: vulntor.v1.GetPluginRequest
+	(*UninstallPluginRequest)(nil),  // 24: vulntor.v1.UninstallPluginRequest
+	(*UninstallPluginResponse)(nil), // 25: vulntor.v1.UninstallPluginResponse
+	(*timestamppb.Timestamp)(nil),   // 26: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),         // 27: google.protobuf.Struct
+	(*structpb.Value)(nil),          // 28: google.protobuf.Value
+}
+var file_vulntor_v1_vulntor_proto_depIdxs = []int32{
+	1,  // 0: vulntor.v1.CreateScanRequest.engagement:type_name -> vulntor.v1.Engagement
+	26, // 1: vulntor.v1.Engagement.window_start:type_name -> google.protobuf.Timestamp
+	26, // 2: vulntor.v1.Engagement.window_end:type_name -> google.protobuf.Timestamp
+	5,  // 3: vulntor.v1.ListScansResponse.scans:type_name -> vulntor.v1.ScanSummary
+	26, // 4: vulntor.v1.ScanSummary.start_time:type_name -> google.protobuf.Timestamp
+	26, // 5: vulntor.v1.ScanDetail.start_time:type_name -> google.protobuf.Timestamp
+	26, // 6: vulntor.v1.ScanDetail.end_time:type_name -> google.protobuf.Timestamp
+	27, // 7: vulntor.v1.ScanDetail.results:type_name -> google.protobuf.Struct
+	26, // 8: vulntor.v1.ScanEvent.timestamp:type_name -> google.protobuf.Timestamp
+	28, // 9: vulntor.v1.ScanEvent.data:type_name -> google.protobuf.Value
+	27, // 10: vulntor.v1.ListFindingsResponse.findings:type_name -> google.protobuf.Struct
+	14, // 11: vulntor.v1.ListAssetsResponse.assets:type_name -> vulntor.v1.Asset
+	26, // 12: vulntor.v1.PluginInfo.installed_at:type_name -> google.protobuf.Timestamp
+	15, // 13: vulntor.v1.InstallPluginResponse.plugins:type_name -> vulntor.v1.PluginInfo
+	16, // 14: vulntor.v1.InstallPluginResponse.errors:type_name -> vulntor.v1.PluginError
+	15, // 15: vulntor.v1.UpdatePluginsResponse.plugins:type_name -> vulntor.v1.PluginInfo
+	16, // 16: vulntor.v1.UpdatePluginsResponse.errors:type_name -> vulntor.v1.PluginError
+	15, // 17: vulntor.v1.ListPluginsResponse.plugins:type_name -> vulntor.v1.PluginInfo
+	16, // 18: vulntor.v1.UninstallPluginResponse.errors:type_name -> vulntor.v1.PluginError
+	0,  // 19: vulntor.v1.ScanService.CreateScan:input_type -> vulntor.v1.CreateScanRequest
+	3,  // 20: vulntor.v1.ScanService.ListScans:input_type -> vulntor.v1.ListScansRequest
+	6,  // 21: vulntor.v1.ScanService.GetScan:input_type -> vulntor.v1.GetScanRequest
+	8,  // 22: vulntor.v1.ScanService.StreamScanEvents:input_type -> vulntor.v1.StreamScanEventsRequest
+	10, // 23: vulntor.v1.FindingService.ListFindings:input_type -> vulntor.v1.ListFindingsRequest
+	12, // 24: vulntor.v1.AssetService.ListAssets:input_type -> vulntor.v1.ListAssetsRequest
+	17, // 25: vulntor.v1.PluginService.InstallPlugin:input_type -> vulntor.v1.InstallPluginRequest
+	19, // 26: vulntor.v1.PluginService.UpdatePlugins:input_type -> vulntor.v1.UpdatePluginsRequest
+	21, // 27: vulntor.v1.PluginService.ListPlugins:input_type -> vulntor.v1.ListPluginsRequest
+	23, // 28: vulntor.v1.PluginService.GetPlugin:input_type -> vulntor.v1.GetPluginRequest
+	24, // 29: vulntor.v1.PluginService.UninstallPlugin:input_type -> vulntor.v1.UninstallPluginRequest
+	2,  // 30: vulntor.v1.ScanService.CreateScan:output_type -> vulntor.v1.CreateScanResponse
+	4,  // 31: vulntor.v1.ScanService.ListScans:output_type -> vulntor.v1.ListScansResponse
+	7,  // 32: vulntor.v1.ScanService.GetScan:output_type -> vulntor.v1.ScanDetail
+	9,  // 33: vulntor.v1.ScanService.StreamScanEvents:output_type -> vulntor.v1.ScanEvent
+	11, // 34: vulntor.v1.FindingService.ListFindings:output_type -> vulntor.v1.ListFindingsResponse
+	13, // 35: vulntor.v1.AssetService.ListAssets:output_type -> vulntor.v1.ListAssetsResponse
+	18, // 36: vulntor.v1.PluginService.InstallPlugin:output_type -> vulntor.v1.InstallPluginResponse
+	20, // 37: vulntor.v1.PluginService.UpdatePlugins:output_type -> vulntor.v1.UpdatePluginsResponse
+	22, // 38: vulntor.v1.PluginService.ListPlugins:output_type -> vulntor.v1.ListPluginsResponse
+	15, // 39: vulntor.v1.PluginService.GetPlugin:output_type -> vulntor.v1.PluginInfo
+	25, // 40: vulntor.v1.PluginService.UninstallPlugin:output_type -> vulntor.v1.UninstallPluginResponse
+	30, // [30:41] is the sub-list for method output_type
+	19, // [19:30] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_vulntor_v1_vulntor_proto_init() }
+func file_vulntor_v1_vulntor_proto_init() {
+	if File_vulntor_v1_vulntor_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_vulntor_v1_vulntor_proto_rawDesc), len(file_vulntor_v1_vulntor_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_vulntor_v1_vulntor_proto_goTypes,
+		DependencyIndexes: file_vulntor_v1_vulntor_proto_depIdxs,
+		MessageInfos:      file_vulntor_v1_vulntor_proto_msgTypes,
+	}.Build()
+	File_vulntor_v1_vulntor_proto = out.File
+	file_vulntor_v1_vulntor_proto_goTypes = nil
+	file_vulntor_v1_vulntor_proto_depIdxs = nil
+}
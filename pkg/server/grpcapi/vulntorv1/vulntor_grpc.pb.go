@@ -0,0 +1,756 @@
+// Copyright 2025 Vulntor Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+
+// Vulntor gRPC API (v1).
+//
+// Mirrors the REST surface under /api/v1. Field names follow the JSON DTOs
+// in pkg/server/api/v1 and obey the same DTO Evolution Policy: additive-only
+// changes, never renumber or reuse field tags.
+//
+// Generate Go stubs with `make proto` (requires protoc, protoc-gen-go and
+// protoc-gen-go-grpc on PATH).
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: vulntor/v1/vulntor.proto
+
+package vulntorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScanService_CreateScan_FullMethodName       = "/vulntor.v1.ScanService/CreateScan"
+	ScanService_ListScans_FullMethodName        = "/vulntor.v1.ScanService/ListScans"
+	ScanService_GetScan_FullMethodName          = "/vulntor.v1.ScanService/GetScan"
+	ScanService_StreamScanEvents_FullMethodName = "/vulntor.v1.ScanService/StreamScanEvents"
+)
+
+// ScanServiceClient is the client API for ScanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScanService manages scan submission and results.
+// REST equivalent: /api/v1/scans
+type ScanServiceClient interface {
+	// CreateScan queues a scan. REST: POST /api/v1/scans
+	CreateScan(ctx context.Context, in *CreateScanRequest, opts ...grpc.CallOption) (*CreateScanResponse, error)
+	// ListScans lists scans with cursor pagination. REST: GET /api/v1/scans
+	ListScans(ctx context.Context, in *ListScansRequest, opts ...grpc.CallOption) (*ListScansResponse, error)
+	// GetScan returns scan details. REST: GET /api/v1/scans/{id}
+	GetScan(ctx context.Context, in *GetScanRequest, opts ...grpc.CallOption) (*ScanDetail, error)
+	// StreamScanEvents streams live scan progress until the scan finishes.
+	// REST: GET /api/v1/scans/{id}/events (SSE)
+	StreamScanEvents(ctx context.Context, in *StreamScanEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanEvent], error)
+}
+
+type scanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScanServiceClient(cc grpc.ClientConnInterface) ScanServiceClient {
+	return &scanServiceClient{cc}
+}
+
+func (c *scanServiceClient) CreateScan(ctx context.Context, in *CreateScanRequest, opts ...grpc.CallOption) (*CreateScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateScanResponse)
+	err := c.cc.Invoke(ctx, ScanService_CreateScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scanServiceClient) ListScans(ctx context.Context, in *ListScansRequest, opts ...grpc.CallOption) (*ListScansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScansResponse)
+	err := c.cc.Invoke(ctx, ScanService_ListScans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scanServiceClient) GetScan(ctx context.Context, in *GetScanRequest, opts ...grpc.CallOption) (*ScanDetail, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanDetail)
+	err := c.cc.Invoke(ctx, ScanService_GetScan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scanServiceClient) StreamScanEvents(ctx context.Context, in *StreamScanEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScanEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ScanService_ServiceDesc.Streams[0], ScanService_StreamScanEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamScanEventsRequest, ScanEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScanService_StreamScanEventsClient = grpc.ServerStreamingClient[ScanEvent]
+
+// ScanServiceServer is the server API for ScanService service.
+// All implementations must embed UnimplementedScanServiceServer
+// for forward compatibility.
+//
+// ScanService manages scan submission and results.
+// REST equivalent: /api/v1/scans
+type ScanServiceServer interface {
+	// CreateScan queues a scan. REST: POST /api/v1/scans
+	CreateScan(context.Context, *CreateScanRequest) (*CreateScanResponse, error)
+	// ListScans lists scans with cursor pagination. REST: GET /api/v1/scans
+	ListScans(context.Context, *ListScansRequest) (*ListScansResponse, error)
+	// GetScan returns scan details. REST: GET /api/v1/scans/{id}
+	GetScan(context.Context, *GetScanRequest) (*ScanDetail, error)
+	// StreamScanEvents streams live scan progress until the scan finishes.
+	// REST: GET /api/v1/scans/{id}/events (SSE)
+	StreamScanEvents(*StreamScanEventsRequest, grpc.ServerStreamingServer[ScanEvent]) error
+	mustEmbedUnimplementedScanServiceServer()
+}
+
+// UnimplementedScanServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScanServiceServer struct{}
+
+func (UnimplementedScanServiceServer) CreateScan(context.Context, *CreateScanRequest) (*CreateScanResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateScan not implemented")
+}
+func (UnimplementedScanServiceServer) ListScans(context.Context, *ListScansRequest) (*ListScansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScans not implemented")
+}
+func (UnimplementedScanServiceServer) GetScan(context.Context, *GetScanRequest) (*ScanDetail, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetScan not implemented")
+}
+func (UnimplementedScanServiceServer) StreamScanEvents(*StreamScanEventsRequest, grpc.ServerStreamingServer[ScanEvent]) error {
+	return status.Error(codes.Unimplemented, "method StreamScanEvents not implemented")
+}
+func (UnimplementedScanServiceServer) mustEmbedUnimplementedScanServiceServer() {}
+func (UnimplementedScanServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeScanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScanServiceServer will
+// result in compilation errors.
+type UnsafeScanServiceServer interface {
+	mustEmbedUnimplementedScanServiceServer()
+}
+
+func RegisterScanServiceServer(s grpc.ServiceRegistrar, srv ScanServiceServer) {
+	// If the following call panics, it indicates UnimplementedScanServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScanService_ServiceDesc, srv)
+}
+
+func _ScanService_CreateScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScanServiceServer).CreateScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScanService_CreateScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScanServiceServer).CreateScan(ctx, req.(*CreateScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScanService_ListScans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScanServiceServer).ListScans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScanService_ListScans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScanServiceServer).ListScans(ctx, req.(*ListScansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScanService_GetScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScanServiceServer).GetScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScanService_GetScan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScanServiceServer).GetScan(ctx, req.(*GetScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScanService_StreamScanEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamScanEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScanServiceServer).StreamScanEvents(m, &grpc.GenericServerStream[StreamScanEventsRequest, ScanEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ScanService_StreamScanEventsServer = grpc.ServerStreamingServer[ScanEvent]
+
+// ScanService_ServiceDesc is the grpc.ServiceDesc for ScanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vulntor.v1.ScanService",
+	HandlerType: (*ScanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateScan",
+			Handler:    _ScanService_CreateScan_Handler,
+		},
+		{
+			MethodName: "ListScans",
+			Handler:    _ScanService_ListScans_Handler,
+		},
+		{
+			MethodName: "GetScan",
+			Handler:    _ScanService_GetScan_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamScanEvents",
+			Handler:       _ScanService_StreamScanEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "vulntor/v1/vulntor.proto",
+}
+
+const (
+	FindingService_ListFindings_FullMethodName = "/vulntor.v1.FindingService/ListFindings"
+)
+
+// FindingServiceClient is the client API for FindingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FindingService exposes vulnerabilities reported by evaluation plugins.
+// REST equivalent: /api/v1/scans/{id}/findings
+type FindingServiceClient interface {
+	// ListFindings pages through a scan's findings.
+	ListFindings(ctx context.Context, in *ListFindingsRequest, opts ...grpc.CallOption) (*ListFindingsResponse, error)
+}
+
+type findingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFindingServiceClient(cc grpc.ClientConnInterface) FindingServiceClient {
+	return &findingServiceClient{cc}
+}
+
+func (c *findingServiceClient) ListFindings(ctx context.Context, in *ListFindingsRequest, opts ...grpc.CallOption) (*ListFindingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFindingsResponse)
+	err := c.cc.Invoke(ctx, FindingService_ListFindings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FindingServiceServer is the server API for FindingService service.
+// All implementations must embed UnimplementedFindingServiceServer
+// for forward compatibility.
+//
+// FindingService exposes vulnerabilities reported by evaluation plugins.
+// REST equivalent: /api/v1/scans/{id}/findings
+type FindingServiceServer interface {
+	// ListFindings pages through a scan's findings.
+	ListFindings(context.Context, *ListFindingsRequest) (*ListFindingsResponse, error)
+	mustEmbedUnimplementedFindingServiceServer()
+}
+
+// UnimplementedFindingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFindingServiceServer struct{}
+
+func (UnimplementedFindingServiceServer) ListFindings(context.Context, *ListFindingsRequest) (*ListFindingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFindings not implemented")
+}
+func (UnimplementedFindingServiceServer) mustEmbedUnimplementedFindingServiceServer() {}
+func (UnimplementedFindingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeFindingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FindingServiceServer will
+// result in compilation errors.
+type UnsafeFindingServiceServer interface {
+	mustEmbedUnimplementedFindingServiceServer()
+}
+
+func RegisterFindingServiceServer(s grpc.ServiceRegistrar, srv FindingServiceServer) {
+	// If the following call panics, it indicates UnimplementedFindingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FindingService_ServiceDesc, srv)
+}
+
+func _FindingService_ListFindings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFindingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingServiceServer).ListFindings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingService_ListFindings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingServiceServer).ListFindings(ctx, req.(*ListFindingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FindingService_ServiceDesc is the grpc.ServiceDesc for FindingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FindingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vulntor.v1.FindingService",
+	HandlerType: (*FindingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFindings",
+			Handler:    _FindingService_ListFindings_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vulntor/v1/vulntor.proto",
+}
+
+const (
+	AssetService_ListAssets_FullMethodName = "/vulntor.v1.AssetService/ListAssets"
+)
+
+// AssetServiceClient is the client API for AssetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AssetService exposes hosts and services discovered by a scan.
+type AssetServiceClient interface {
+	// ListAssets returns live hosts with their open ports.
+	ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+}
+
+type assetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAssetServiceClient(cc grpc.ClientConnInterface) AssetServiceClient {
+	return &assetServiceClient{cc}
+}
+
+func (c *assetServiceClient) ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetService_ListAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetServiceServer is the server API for AssetService service.
+// All implementations must embed UnimplementedAssetServiceServer
+// for forward compatibility.
+//
+// AssetService exposes hosts and services discovered by a scan.
+type AssetServiceServer interface {
+	// ListAssets returns live hosts with their open ports.
+	ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error)
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+// UnimplementedAssetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAssetServiceServer struct{}
+
+func (UnimplementedAssetServiceServer) ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAssets not implemented")
+}
+func (UnimplementedAssetServiceServer) mustEmbedUnimplementedAssetServiceServer() {}
+func (UnimplementedAssetServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAssetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AssetServiceServer will
+// result in compilation errors.
+type UnsafeAssetServiceServer interface {
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+func RegisterAssetServiceServer(s grpc.ServiceRegistrar, srv AssetServiceServer) {
+	// If the following call panics, it indicates UnimplementedAssetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AssetService_ServiceDesc, srv)
+}
+
+func _AssetService_ListAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ListAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ListAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ListAssets(ctx, req.(*ListAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssetService_ServiceDesc is the grpc.ServiceDesc for AssetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vulntor.v1.AssetService",
+	HandlerType: (*AssetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAssets",
+			Handler:    _AssetService_ListAssets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vulntor/v1/vulntor.proto",
+}
+
+const (
+	PluginService_InstallPlugin_FullMethodName   = "/vulntor.v1.PluginService/InstallPlugin"
+	PluginService_UpdatePlugins_FullMethodName   = "/vulntor.v1.PluginService/UpdatePlugins"
+	PluginService_ListPlugins_FullMethodName     = "/vulntor.v1.PluginService/ListPlugins"
+	PluginService_GetPlugin_FullMethodName       = "/vulntor.v1.PluginService/GetPlugin"
+	PluginService_UninstallPlugin_FullMethodName = "/vulntor.v1.PluginService/UninstallPlugin"
+)
+
+// PluginServiceClient is the client API for PluginService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PluginService manages installed plugins.
+// REST equivalent: /api/v1/plugins
+type PluginServiceClient interface {
+	// InstallPlugin installs a plugin by ID or category. REST: POST /api/v1/plugins/install
+	InstallPlugin(ctx context.Context, in *InstallPluginRequest, opts ...grpc.CallOption) (*InstallPluginResponse, error)
+	// UpdatePlugins downloads plugins from remote sources. REST: POST /api/v1/plugins/update
+	UpdatePlugins(ctx context.Context, in *UpdatePluginsRequest, opts ...grpc.CallOption) (*UpdatePluginsResponse, error)
+	// ListPlugins lists installed plugins. REST: GET /api/v1/plugins
+	ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsResponse, error)
+	// GetPlugin returns plugin details. REST: GET /api/v1/plugins/{id}
+	GetPlugin(ctx context.Context, in *GetPluginRequest, opts ...grpc.CallOption) (*PluginInfo, error)
+	// UninstallPlugin removes a plugin. REST: DELETE /api/v1/plugins/{id}
+	UninstallPlugin(ctx context.Context, in *UninstallPluginRequest, opts ...grpc.CallOption) (*UninstallPluginResponse, error)
+}
+
+type pluginServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPluginServiceClient(cc grpc.ClientConnInterface) PluginServiceClient {
+	return &pluginServiceClient{cc}
+}
+
+func (c *pluginServiceClient) InstallPlugin(ctx context.Context, in *InstallPluginRequest, opts ...grpc.CallOption) (*InstallPluginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InstallPluginResponse)
+	err := c.cc.Invoke(ctx, PluginService_InstallPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginServiceClient) UpdatePlugins(ctx context.Context, in *UpdatePluginsRequest, opts ...grpc.CallOption) (*UpdatePluginsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePluginsResponse)
+	err := c.cc.Invoke(ctx, PluginService_UpdatePlugins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginServiceClient) ListPlugins(ctx context.Context, in *ListPluginsRequest, opts ...grpc.CallOption) (*ListPluginsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPluginsResponse)
+	err := c.cc.Invoke(ctx, PluginService_ListPlugins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginServiceClient) GetPlugin(ctx context.Context, in *GetPluginRequest, opts ...grpc.CallOption) (*PluginInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PluginInfo)
+	err := c.cc.Invoke(ctx, PluginService_GetPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginServiceClient) UninstallPlugin(ctx context.Context, in *UninstallPluginRequest, opts ...grpc.CallOption) (*UninstallPluginResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UninstallPluginResponse)
+	err := c.cc.Invoke(ctx, PluginService_UninstallPlugin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginServiceServer is the server API for PluginService service.
+// All implementations must embed UnimplementedPluginServiceServer
+// for forward compatibility.
+//
+// PluginService manages installed plugins.
+// REST equivalent: /api/v1/plugins
+type PluginServiceServer interface {
+	// InstallPlugin installs a plugin by ID or category. REST: POST /api/v1/plugins/install
+	InstallPlugin(context.Context, *InstallPluginRequest) (*InstallPluginResponse, error)
+	// UpdatePlugins downloads plugins from remote sources. REST: POST /api/v1/plugins/update
+	UpdatePlugins(context.Context, *UpdatePluginsRequest) (*UpdatePluginsResponse, error)
+	// ListPlugins lists installed plugins. REST: GET /api/v1/plugins
+	ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsResponse, error)
+	// GetPlugin returns plugin details. REST: GET /api/v1/plugins/{id}
+	GetPlugin(context.Context, *GetPluginRequest) (*PluginInfo, error)
+	// UninstallPlugin removes a plugin. REST: DELETE /api/v1/plugins/{id}
+	UninstallPlugin(context.Context, *UninstallPluginRequest) (*UninstallPluginResponse, error)
+	mustEmbedUnimplementedPluginServiceServer()
+}
+
+// UnimplementedPluginServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPluginServiceServer struct{}
+
+func (UnimplementedPluginServiceServer) InstallPlugin(context.Context, *InstallPluginRequest) (*InstallPluginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InstallPlugin not implemented")
+}
+func (UnimplementedPluginServiceServer) UpdatePlugins(context.Context, *UpdatePluginsRequest) (*UpdatePluginsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePlugins not implemented")
+}
+func (UnimplementedPluginServiceServer) ListPlugins(context.Context, *ListPluginsRequest) (*ListPluginsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPlugins not implemented")
+}
+func (UnimplementedPluginServiceServer) GetPlugin(context.Context, *GetPluginRequest) (*PluginInfo, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPlugin not implemented")
+}
+func (UnimplementedPluginServiceServer) UninstallPlugin(context.Context, *UninstallPluginRequest) (*UninstallPluginResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UninstallPlugin not implemented")
+}
+func (UnimplementedPluginServiceServer) mustEmbedUnimplementedPluginServiceServer() {}
+func (UnimplementedPluginServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePluginServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PluginServiceServer will
+// result in compilation errors.
+type UnsafePluginServiceServer interface {
+	mustEmbedUnimplementedPluginServiceServer()
+}
+
+func RegisterPluginServiceServer(s grpc.ServiceRegistrar, srv PluginServiceServer) {
+	// If the following call panics, it indicates UnimplementedPluginServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PluginService_ServiceDesc, srv)
+}
+
+func _PluginService_InstallPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).InstallPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_InstallPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).InstallPlugin(ctx, req.(*InstallPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginService_UpdatePlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePluginsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).UpdatePlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_UpdatePlugins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).UpdatePlugins(ctx, req.(*UpdatePluginsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginService_ListPlugins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPluginsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).ListPlugins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_ListPlugins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).ListPlugins(ctx, req.(*ListPluginsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginService_GetPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).GetPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_GetPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).GetPlugin(ctx, req.(*GetPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginService_UninstallPlugin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UninstallPluginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginServiceServer).UninstallPlugin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PluginService_UninstallPlugin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginServiceServer).UninstallPlugin(ctx, req.(*UninstallPluginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PluginService_ServiceDesc is the grpc.ServiceDesc for PluginService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PluginService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vulntor.v1.PluginService",
+	HandlerType: (*PluginServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InstallPlugin",
+			Handler:    _PluginService_InstallPlugin_Handler,
+		},
+		{
+			MethodName: "UpdatePlugins",
+			Handler:    _PluginService_UpdatePlugins_Handler,
+		},
+		{
+			MethodName: "ListPlugins",
+			Handler:    _PluginService_ListPlugins_Handler,
+		},
+		{
+			MethodName: "GetPlugin",
+			Handler:    _PluginService_GetPlugin_Handler,
+		},
+		{
+			MethodName: "UninstallPlugin",
+			Handler:    _PluginService_UninstallPlugin_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vulntor/v1/vulntor.proto",
+}
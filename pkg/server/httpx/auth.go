@@ -1,32 +1,154 @@
 package httpx
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
+// KeyVerifier authenticates API key tokens.
+// Implemented by *auth.Verifier.
+type KeyVerifier interface {
+	Verify(ctx context.Context, token string) (*auth.Principal, error)
+}
+
+// TenantHeader selects the tenant a request operates on. Requests without
+// it use the default tenant.
+const TenantHeader = "X-Tenant-ID"
+
+// TenantLookup resolves registered tenants.
+// Implemented by storage.TenantStore.
+type TenantLookup interface {
+	Get(ctx context.Context, tenantID string) (*storage.Tenant, error)
+}
+
+// AuthOption configures the Auth middleware.
+type AuthOption func(*authOptions)
+
+type authOptions struct {
+	keys     KeyVerifier
+	oidc     KeyVerifier
+	tenants  TenantLookup
+	failures *FailedAuthLimiter
+	limiter  *RateLimiter
+}
+
+// WithTenants enables tenant selection through the X-Tenant-ID header.
+// Without it, only the default tenant is reachable.
+func WithTenants(t TenantLookup) AuthOption {
+	return func(o *authOptions) {
+		o.tenants = t
+	}
+}
+
+// WithKeyVerifier sets the verifier used in "apikey" mode.
+func WithKeyVerifier(v KeyVerifier) AuthOption {
+	return func(o *authOptions) {
+		o.keys = v
+	}
+}
+
+// WithOIDCVerifier sets the ID token verifier used in "oidc" mode.
+func WithOIDCVerifier(v KeyVerifier) AuthOption {
+	return func(o *authOptions) {
+		o.oidc = v
+	}
+}
+
+// WithFailedAuthLimiter sets the limiter counting failed authentications,
+// to share it between the REST and gRPC APIs. Without it, each Auth and
+// Authenticator counts them on its own, with the limit of the server
+// configuration.
+func WithFailedAuthLimiter(l *FailedAuthLimiter) AuthOption {
+	return func(o *authOptions) {
+		o.failures = l
+	}
+}
+
+// WithRateLimiter sets the limiter enforcing the API limits after
+// authentication, to share it between the REST and gRPC APIs. Without it,
+// Chain and each Authenticator use their own, with the limits of the server
+// configuration.
+func WithRateLimiter(l *RateLimiter) AuthOption {
+	return func(o *authOptions) {
+		o.limiter = l
+	}
+}
+
+// newAuthOptions applies opts, defaulting the limiters to those of cfg.
+func newAuthOptions(cfg config.ServerConfig, opts []AuthOption) authOptions {
+	var o authOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.failures == nil {
+		o.failures = NewFailedAuthLimiter(cfg.RateLimit.FailedAuthPerMinute)
+	}
+	if o.limiter == nil {
+		o.limiter = NewRateLimiter(cfg.RateLimit)
+	}
+	return o
+}
+
+// verifier returns the configured verifier for mode, or nil.
+func (o *authOptions) verifier(mode string) KeyVerifier {
+	switch mode {
+	case "apikey":
+		return o.keys
+	case "oidc":
+		return o.oidc
+	}
+	return nil
+}
+
 // Auth returns a middleware that enforces token-based authentication.
 //
 // Behavior:
-//   - Skips authentication for health endpoints (/healthz, /readyz)
+//   - Skips authentication for health endpoints (/healthz, /readyz) and the
+//     API reference (/api/openapi.json, /api/docs)
+//   - Selects the tenant from the X-Tenant-ID header (storage.WithOrgID);
+//     unknown tenants receive 404 Not Found. Credentials are verified
+//     against the selected tenant, so keys and users never cross tenants
 //   - In "none" mode (cfg.Auth.Mode="none"), skips authentication
 //   - In "token" mode, validates Authorization: Bearer <token> header
+//   - In "apikey" mode, verifies the bearer token against stored API keys and
+//     attaches the key's auth.Principal to the request context
+//   - In "oidc" mode, verifies an IdP ID token from the bearer header or the
+//     session cookie; when browser login is configured, page loads without a
+//     session are redirected to /auth/login
 //   - Returns 401 Unauthorized with JSON error if auth fails
+//   - Counts the 401 responses of each IP address; addresses over
+//     cfg.RateLimit.FailedAuthPerMinute receive 429 Too Many Requests with
+//     Retry-After before their credentials are checked
 //
 // Example header: Authorization: Bearer secret-token-12345
-func Auth(cfg config.ServerConfig) func(http.Handler) http.Handler {
+func Auth(cfg config.ServerConfig, opts ...AuthOption) func(http.Handler) http.Handler {
+	o := newAuthOptions(cfg, opts)
+
 	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip auth for health endpoints (always accessible)
-			if isHealthEndpoint(r.URL.Path) {
+		return o.failures.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Skip auth for health endpoints and the API reference (always accessible)
+			if isHealthEndpoint(r.URL.Path) || isAPIReference(r.URL.Path) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
+			// Select the tenant before verifying credentials against it
+			ctx, err := o.selectTenant(r.Context(), r.Header.Get(TenantHeader))
+			if err != nil {
+				writeNotFound(w, "Unknown tenant")
+				return
+			}
+			r = r.WithContext(ctx)
+
 			// Skip auth if mode is "none"
 			if cfg.Auth.Mode == "none" {
 				next.ServeHTTP(w, r)
@@ -59,13 +181,207 @@ func Auth(cfg config.ServerConfig) func(http.Handler) http.Handler {
 				return
 			}
 
+			// API key and OIDC modes: verify the credential and attach the principal
+			if v := o.verifier(cfg.Auth.Mode); v != nil {
+				oidc := cfg.Auth.Mode == "oidc"
+				loginEnabled := oidc && cfg.Auth.OIDC.RedirectURL != ""
+				if loginEnabled && isLoginEndpoint(r.URL.Path) {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				token := extractBearerToken(r)
+				if token == "" && oidc {
+					token = sessionToken(r)
+				}
+				if token == "" {
+					if loginEnabled && isBrowserNavigation(r) {
+						http.Redirect(w, r, loginPath, http.StatusFound)
+						return
+					}
+					log.Warn().
+						Str("component", "auth").
+						Str("path", r.URL.Path).
+						Msg("Missing authorization header")
+					writeUnauthorized(w, "Missing authorization header")
+					return
+				}
+
+				principal, err := v.Verify(r.Context(), token)
+				if err != nil {
+					if loginEnabled && isBrowserNavigation(r) && errors.Is(err, auth.ErrInvalidToken) {
+						// Expired session: log in again
+						http.Redirect(w, r, loginPath, http.StatusFound)
+						return
+					}
+					writeVerifyError(w, r, err)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+				return
+			}
+
 			// Unknown auth mode (should not happen due to validation)
 			log.Error().
 				Str("component", "auth").
 				Str("mode", cfg.Auth.Mode).
 				Msg("Unknown auth mode")
 			writeUnauthorized(w, "Authentication configuration error")
-		})
+		}))
+	}
+}
+
+// selectTenant returns ctx operating on tenantID. The default tenant, also
+// selected by an empty ID, is always known; others must be registered.
+func (o *authOptions) selectTenant(ctx context.Context, tenantID string) (context.Context, error) {
+	if tenantID == "" || tenantID == storage.DefaultOrgID {
+		return ctx, nil
+	}
+	if o.tenants == nil {
+		return nil, ErrUnknownTenant
+	}
+	if _, err := o.tenants.Get(ctx, tenantID); err != nil {
+		log.Warn().
+			Str("component", "auth").
+			Str("tenant", tenantID).
+			Err(err).
+			Msg("Tenant rejected")
+		return nil, ErrUnknownTenant
+	}
+	return storage.WithOrgID(ctx, tenantID), nil
+}
+
+// Errors of Authenticator.Authenticate besides those of the verifiers.
+var (
+	ErrUnknownTenant      = errors.New("unknown tenant")
+	ErrMissingCredentials = errors.New("missing authorization header")
+	ErrAuthConfig         = errors.New("authentication configuration error")
+)
+
+// ThrottledError is returned by Authenticator.Authenticate for addresses
+// over the failed authentication limit.
+type ThrottledError struct {
+	// RetryAfter is how long until the address may authenticate again.
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return "too many failed authentications"
+}
+
+// Authenticator applies the tenant and credential checks of Auth to calls
+// that do not arrive as HTTP requests, such as those of the gRPC API.
+type Authenticator struct {
+	cfg config.ServerConfig
+	o   authOptions
+}
+
+// NewAuthenticator returns an Authenticator checking credentials like
+// Auth(cfg, opts...).
+func NewAuthenticator(cfg config.ServerConfig, opts ...AuthOption) *Authenticator {
+	return &Authenticator{cfg: cfg, o: newAuthOptions(cfg, opts)}
+}
+
+// RateLimiter returns the limiter enforcing the API limits of
+// authenticated calls.
+func (a *Authenticator) RateLimiter() *RateLimiter {
+	return a.o.limiter
+}
+
+// Authenticate selects the tenant tenantID (empty for the default tenant)
+// and verifies the bearer token of the client at addr against it. The
+// returned context carries the tenant and, in "apikey" and "oidc" modes,
+// the caller's auth.Principal.
+//
+// Errors are *ThrottledError for addresses over the failed authentication
+// limit, ErrUnknownTenant, ErrMissingCredentials, auth.ErrInvalidToken for
+// a wrong static token, or those of the mode's verifier. Credential errors
+// count against the limit like the 401 responses of Auth.
+func (a *Authenticator) Authenticate(ctx context.Context, addr, tenantID, token string) (context.Context, error) {
+	host := remoteHost(addr)
+	if wait := a.o.failures.wait(host); wait > 0 {
+		return nil, &ThrottledError{RetryAfter: wait}
+	}
+
+	ctx, err := a.o.selectTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = a.verify(ctx, token)
+	if err != nil && !errors.Is(err, ErrAuthConfig) && !errors.Is(err, auth.ErrNoRole) {
+		a.o.failures.fail(host)
+	}
+	return ctx, err
+}
+
+// verify verifies token in the configured mode.
+func (a *Authenticator) verify(ctx context.Context, token string) (context.Context, error) {
+
+	switch a.cfg.Auth.Mode {
+	case "none":
+		return ctx, nil
+	case "token":
+		if token == "" {
+			return nil, ErrMissingCredentials
+		}
+		if token != a.cfg.Auth.Token {
+			return nil, auth.ErrInvalidToken
+		}
+		return ctx, nil
+	}
+
+	v := a.o.verifier(a.cfg.Auth.Mode)
+	if v == nil {
+		return nil, ErrAuthConfig
+	}
+	if token == "" {
+		return nil, ErrMissingCredentials
+	}
+	principal, err := v.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// writeVerifyError maps credential verification errors to 401/403 responses.
+func writeVerifyError(w http.ResponseWriter, r *http.Request, err error) {
+	log.Warn().
+		Str("component", "auth").
+		Str("path", r.URL.Path).
+		Err(err).
+		Msg("Credential rejected")
+	switch {
+	case errors.Is(err, auth.ErrKeyRevoked):
+		writeUnauthorized(w, "API key revoked")
+	case errors.Is(err, auth.ErrInvalidKey):
+		writeUnauthorized(w, "Invalid API key")
+	case errors.Is(err, auth.ErrInvalidToken):
+		writeUnauthorized(w, "Invalid token")
+	case errors.Is(err, auth.ErrNoRole):
+		writeForbidden(w, "No role assigned to user")
+	default:
+		writeUnauthorized(w, "Authentication failed")
+	}
+}
+
+// RequireScope wraps a handler so it only runs when the request's API key
+// grants scope. Requests without a principal (auth modes "none" and "token")
+// are not scope-restricted and pass through unchanged.
+func RequireScope(scope auth.Scope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok && !auth.HasScope(p.Scopes, scope) {
+			log.Warn().
+				Str("component", "auth").
+				Str("path", r.URL.Path).
+				Str("key_id", p.KeyID).
+				Str("scope", string(scope)).
+				Msg("API key lacks required scope")
+			writeForbidden(w, "API key lacks required scope: "+string(scope))
+			return
+		}
+		next(w, r)
 	}
 }
 
@@ -74,6 +390,11 @@ func isHealthEndpoint(path string) bool {
 	return path == "/healthz" || path == "/readyz"
 }
 
+// isAPIReference checks if path serves the OpenAPI document or its UI
+func isAPIReference(path string) bool {
+	return path == openAPIPath || path == apiDocsPath
+}
+
 // extractBearerToken extracts the token from Authorization: Bearer <token> header
 func extractBearerToken(r *http.Request) string {
 	auth := r.Header.Get("Authorization")
@@ -97,3 +418,17 @@ func writeUnauthorized(w http.ResponseWriter, message string) {
 	// Simple JSON response (not using api.WriteError to avoid circular dependency)
 	_, _ = w.Write([]byte(`{"error":"Unauthorized","message":"` + message + `"}`))
 }
+
+// writeNotFound writes a 404 Not Found response with JSON body
+func writeNotFound(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"error":"Not Found","message":"` + message + `"}`))
+}
+
+// writeForbidden writes a 403 Forbidden response with JSON body
+func writeForbidden(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	_, _ = w.Write([]byte(`{"error":"Forbidden","message":"` + message + `"}`))
+}
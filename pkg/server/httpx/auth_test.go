@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +9,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 func TestAuth_ValidToken(t *testing.T) {
@@ -290,3 +293,332 @@ func TestAuth_UnknownMode(t *testing.T) {
 	require.Equal(t, http.StatusUnauthorized, w.Code)
 	require.Contains(t, w.Body.String(), "Authentication configuration error")
 }
+
+type stubKeyVerifier struct {
+	principal *auth.Principal
+	err       error
+}
+
+func (s *stubKeyVerifier) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	if token != "vnt_k1_secret" {
+		return nil, auth.ErrInvalidKey
+	}
+	return s.principal, s.err
+}
+
+func TestAuth_APIKeyMode(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}
+	verifier := &stubKeyVerifier{principal: &auth.Principal{KeyID: "k1", Scopes: []string{"read"}}}
+
+	var got *auth.Principal
+	handler := Auth(cfg, WithKeyVerifier(verifier))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name    string
+		header  string
+		status  int
+		message string
+	}{
+		{name: "valid key", header: "Bearer vnt_k1_secret", status: http.StatusOK},
+		{name: "missing header", header: "", status: http.StatusUnauthorized, message: "Missing authorization header"},
+		{name: "invalid key", header: "Bearer vnt_k1_wrong", status: http.StatusUnauthorized, message: "Invalid API key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.message != "" {
+				require.Contains(t, w.Body.String(), tt.message)
+				return
+			}
+			require.NotNil(t, got)
+			require.Equal(t, "k1", got.KeyID)
+		})
+	}
+}
+
+func TestAuth_APIKeyMode_Revoked(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}
+	verifier := &stubKeyVerifier{err: auth.ErrKeyRevoked}
+
+	handler := Auth(cfg, WithKeyVerifier(verifier))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("Authorization", "Bearer vnt_k1_secret")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), "API key revoked")
+}
+
+func TestAuth_APIKeyMode_WithoutVerifierFailsClosed(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}
+
+	handler := Auth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("Authorization", "Bearer vnt_k1_secret")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+	require.Contains(t, w.Body.String(), "Authentication configuration error")
+}
+
+func TestRequireScope(t *testing.T) {
+	handler := RequireScope(auth.ScopeScanCreate, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		status    int
+	}{
+		{name: "no principal passes through", principal: nil, status: http.StatusAccepted},
+		{name: "scope granted", principal: &auth.Principal{Scopes: []string{"scan:create"}}, status: http.StatusAccepted},
+		{name: "admin granted", principal: &auth.Principal{Scopes: []string{"admin"}}, status: http.StatusAccepted},
+		{name: "scope missing", principal: &auth.Principal{Scopes: []string{"read"}}, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", nil)
+			if tt.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tt.principal))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusForbidden {
+				require.Contains(t, w.Body.String(), "scan:create")
+			}
+		})
+	}
+}
+
+func TestAuth_OIDCMode(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "oidc", OIDC: config.OIDCConfig{RedirectURL: "https://vulntor.example.com/auth/callback"}}}
+	verifier := &stubOIDCLogin{principal: &auth.Principal{UserID: "alice", Role: auth.RoleViewer, Scopes: []string{"read"}}}
+
+	var got *auth.Principal
+	handler := Auth(cfg, WithOIDCVerifier(verifier))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = auth.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		path     string
+		header   string
+		cookie   string
+		accept   string
+		status   int
+		location string
+		message  string
+	}{
+		{name: "bearer id token", path: "/api/v1/scans", header: "Bearer id-token", status: http.StatusOK},
+		{name: "session cookie", path: "/api/v1/scans", cookie: "id-token", status: http.StatusOK},
+		{name: "api without credentials", path: "/api/v1/scans", accept: "text/html", status: http.StatusUnauthorized, message: "Missing authorization header"},
+		{name: "page load redirects to login", path: "/scans", accept: "text/html", status: http.StatusFound, location: "/auth/login"},
+		{name: "expired session redirects to login", path: "/", cookie: "expired", accept: "text/html", status: http.StatusFound, location: "/auth/login"},
+		{name: "invalid bearer", path: "/api/v1/scans", header: "Bearer expired", status: http.StatusUnauthorized, message: "Invalid token"},
+		{name: "login endpoint is public", path: "/auth/callback", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: tt.cookie})
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.location != "" {
+				require.Equal(t, tt.location, w.Header().Get("Location"))
+			}
+			if tt.message != "" {
+				require.Contains(t, w.Body.String(), tt.message)
+			}
+		})
+	}
+
+	// Authenticated requests carry the IdP user
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("Authorization", "Bearer id-token")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotNil(t, got)
+	require.Equal(t, "alice", got.UserID)
+}
+
+func TestAuth_OIDCMode_NoRole(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "oidc"}}
+	verifier := &stubOIDCLogin{err: auth.ErrNoRole}
+
+	handler := Auth(cfg, WithOIDCVerifier(verifier))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("Authorization", "Bearer id-token")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), "No role assigned to user")
+}
+
+func TestAuth_OIDCMode_WithoutLoginNoRedirect(t *testing.T) {
+	// Without a redirect URL only bearer tokens are accepted
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "oidc"}}
+	handler := Auth(cfg, WithOIDCVerifier(&stubOIDCLogin{}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+type stubTenants map[string]bool
+
+func (s stubTenants) Get(ctx context.Context, tenantID string) (*storage.Tenant, error) {
+	if !s[tenantID] {
+		return nil, storage.NewNotFoundError("tenant", tenantID)
+	}
+	return &storage.Tenant{ID: tenantID}, nil
+}
+
+func TestAuth_TenantSelection(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "none"}}
+
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = storage.OrgIDFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		header  string
+		tenants TenantLookup
+		status  int
+		want    string
+	}{
+		{name: "no header", status: http.StatusOK, want: "default"},
+		{name: "default tenant", header: "default", status: http.StatusOK, want: "default"},
+		{name: "registered tenant", header: "team-a", tenants: stubTenants{"team-a": true}, status: http.StatusOK, want: "team-a"},
+		{name: "unknown tenant", header: "team-b", tenants: stubTenants{"team-a": true}, status: http.StatusNotFound},
+		{name: "tenancy disabled", header: "team-a", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			var opts []AuthOption
+			if tt.tenants != nil {
+				opts = append(opts, WithTenants(tt.tenants))
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+			if tt.header != "" {
+				req.Header.Set(TenantHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			Auth(cfg, opts...)(next).ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusNotFound {
+				require.Contains(t, w.Body.String(), "Unknown tenant")
+				return
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// tenantKeyVerifier accepts its token only within one tenant.
+type tenantKeyVerifier struct{ orgID string }
+
+func (v *tenantKeyVerifier) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	if storage.OrgIDFromContext(ctx) != v.orgID {
+		return nil, auth.ErrInvalidKey
+	}
+	return &auth.Principal{KeyID: "k1", Scopes: []string{"admin"}}, nil
+}
+
+func TestAuth_APIKeyMode_TenantIsolation(t *testing.T) {
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}
+	handler := Auth(cfg, WithKeyVerifier(&tenantKeyVerifier{orgID: "team-a"}), WithTenants(stubTenants{"team-a": true, "team-b": true}))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }),
+	)
+
+	for tenant, status := range map[string]int{"team-a": http.StatusOK, "team-b": http.StatusUnauthorized, "": http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+		req.Header.Set("Authorization", "Bearer vnt_k1_secret")
+		if tenant != "" {
+			req.Header.Set(TenantHeader, tenant)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		require.Equal(t, status, w.Code, "tenant %q", tenant)
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	ctx := context.Background()
+
+	tokenAuth := NewAuthenticator(config.ServerConfig{Auth: config.AuthConfig{Mode: "token", Token: "s3cret"}})
+	_, err := tokenAuth.Authenticate(ctx, "192.0.2.7:51234", "", "")
+	require.ErrorIs(t, err, ErrMissingCredentials)
+	_, err = tokenAuth.Authenticate(ctx, "192.0.2.7:51234", "", "wrong")
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+	_, err = tokenAuth.Authenticate(ctx, "192.0.2.7:51234", "", "s3cret")
+	require.NoError(t, err)
+
+	keyAuth := NewAuthenticator(config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}},
+		WithKeyVerifier(&tenantKeyVerifier{orgID: "team-a"}), WithTenants(stubTenants{"team-a": true, "team-b": true}))
+	got, err := keyAuth.Authenticate(ctx, "192.0.2.7:51234", "team-a", "vnt_k1_secret")
+	require.NoError(t, err)
+	require.Equal(t, "team-a", storage.OrgIDFromContext(got))
+	p, ok := auth.PrincipalFromContext(got)
+	require.True(t, ok)
+	require.Equal(t, "k1", p.KeyID)
+
+	// Keys never cross tenants
+	_, err = keyAuth.Authenticate(ctx, "192.0.2.7:51234", "team-b", "vnt_k1_secret")
+	require.ErrorIs(t, err, auth.ErrInvalidKey)
+	_, err = keyAuth.Authenticate(ctx, "192.0.2.7:51234", "team-c", "vnt_k1_secret")
+	require.ErrorIs(t, err, ErrUnknownTenant)
+
+	// API key mode without a verifier fails closed
+	_, err = NewAuthenticator(config.ServerConfig{Auth: config.AuthConfig{Mode: "apikey"}}).Authenticate(ctx, "192.0.2.7:51234", "", "vnt_k1_secret")
+	require.ErrorIs(t, err, ErrAuthConfig)
+}
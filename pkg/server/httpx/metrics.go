@@ -0,0 +1,45 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+)
+
+// MetricsHandler serves the plugin executions of all scans run by the
+// server in the Prometheus text exposition format: match attempts,
+// matches, errors and wall-clock time per plugin, labeled by plugin ID and
+// name.
+func MetricsHandler(stats *plugin.ExecRecorder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		all := stats.Stats()
+
+		var b strings.Builder
+		counter := func(name, help string, value func(plugin.ExecStats) string) {
+			fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+			for _, s := range all {
+				fmt.Fprintf(&b, "%s{plugin_id=\"%s\",plugin=\"%s\"} %s\n", name, escapeLabel(s.PluginID), escapeLabel(s.Plugin), value(s))
+			}
+		}
+		counter("vulntor_plugin_match_attempts_total", "Number of times a plugin was evaluated",
+			func(s plugin.ExecStats) string { return fmt.Sprint(s.Attempts) })
+		counter("vulntor_plugin_matches_total", "Number of evaluations in which a plugin matched",
+			func(s plugin.ExecStats) string { return fmt.Sprint(s.Matches) })
+		counter("vulntor_plugin_errors_total", "Number of evaluations of a plugin that failed",
+			func(s plugin.ExecStats) string { return fmt.Sprint(s.Errors) })
+		counter("vulntor_plugin_duration_seconds_total", "Wall-clock time spent evaluating a plugin",
+			func(s plugin.ExecStats) string { return fmt.Sprint(s.WallTime.Seconds()) })
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write([]byte(b.String()))
+	}
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,46 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/server/api"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	stats := plugin.NewExecRecorder()
+	stats.Add([]plugin.ExecStats{
+		{PluginID: "ssh-weak-mac", Plugin: `SSH "Weak" MAC`, Attempts: 3, Matches: 1, Errors: 1, WallTime: 1500 * time.Millisecond},
+	})
+
+	w := httptest.NewRecorder()
+	MetricsHandler(stats)(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	body := w.Body.String()
+	require.Contains(t, body, "# TYPE vulntor_plugin_match_attempts_total counter\n")
+	require.Contains(t, body, `vulntor_plugin_match_attempts_total{plugin_id="ssh-weak-mac",plugin="SSH \"Weak\" MAC"} 3`+"\n")
+	require.Contains(t, body, `vulntor_plugin_matches_total{plugin_id="ssh-weak-mac",plugin="SSH \"Weak\" MAC"} 1`+"\n")
+	require.Contains(t, body, `vulntor_plugin_errors_total{plugin_id="ssh-weak-mac",plugin="SSH \"Weak\" MAC"} 1`+"\n")
+	require.Contains(t, body, `vulntor_plugin_duration_seconds_total{plugin_id="ssh-weak-mac",plugin="SSH \"Weak\" MAC"} 1.5`+"\n")
+}
+
+func TestNewRouter_MetricsMounted(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.APIEnabled = false
+	router := NewRouter(cfg, &api.Deps{Ready: &atomic.Bool{}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "# HELP vulntor_plugin_duration_seconds_total")
+}
@@ -1,23 +1,58 @@
 package httpx
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/rs/zerolog/log"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
-// Chain applies middleware in order: Logger → Auth → Recovery → CORS → handler
+// Chain applies middleware in order: Tracing → Logger → Auth → RateLimit → Recovery → CORS → handler
 //
 // This ensures:
-// 1. All requests are logged (even if they panic or fail auth)
+// 1. All requests are traced and logged (even if they panic or fail auth)
 // 2. Authentication is enforced before business logic
-// 3. Panics are recovered and logged
-// 4. CORS headers are set for all responses
-func Chain(cfg config.ServerConfig, handler http.Handler) http.Handler {
-	return Logger(Auth(cfg)(Recovery(CORS(handler))))
+// 3. API limits apply per authenticated client
+// 4. Panics are recovered and logged
+// 5. CORS headers are set for all responses
+//
+// Auth options (e.g. WithKeyVerifier) are passed through to Auth; the
+// limiter of WithRateLimiter, if any, replaces RateLimit(cfg.RateLimit).
+func Chain(cfg config.ServerConfig, handler http.Handler, opts ...AuthOption) http.Handler {
+	o := newAuthOptions(cfg, opts)
+	return Tracing(Logger(Auth(cfg, opts...)(o.limiter.middleware(Recovery(CORS(handler))))))
+}
+
+// Tracing starts a server span for each request, continuing the caller's
+// trace when a W3C traceparent header is present. Spans started by handlers
+// (e.g. submitted scans) become children of the request span.
+//
+// A no-op when tracing is disabled.
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !tracing.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := tracing.Extract(r.Context(), r.Header)
+		ctx, span := tracing.StartKind(ctx, tracing.KindServer, r.Method+" "+r.URL.Path,
+			tracing.String("http.request.method", r.Method),
+			tracing.String("url.path", r.URL.Path))
+		defer span.End()
+
+		ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		span.SetAttributes(tracing.Int("http.response.status_code", ww.statusCode))
+		if ww.statusCode >= http.StatusInternalServerError {
+			span.RecordError(errors.New(http.StatusText(ww.statusCode)))
+		}
+	})
 }
 
 // Logger logs each HTTP request with method, path, status, and duration.
@@ -97,3 +132,16 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Flush forwards to the underlying writer so streaming responses (SSE) work
+// through the middleware chain.
+func (rw *responseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -1,6 +1,8 @@
 package httpx
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 func TestChain(t *testing.T) {
@@ -36,6 +39,57 @@ func TestChain(t *testing.T) {
 	require.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
 }
 
+func TestTracingMiddleware(t *testing.T) {
+	var exported []map[string]any
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []map[string]any `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		exported = append(exported, body.ResourceSpans[0].ScopeSpans[0].Spans...)
+	}))
+	defer collector.Close()
+
+	shutdown, err := tracing.Setup(config.TracingConfig{Enabled: true, Endpoint: collector.URL, SampleRatio: 1})
+	require.NoError(t, err)
+
+	var handlerSpan tracing.SpanContext
+	handler := Tracing(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = tracing.SpanFromContext(r.Context()).SpanContext()
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// The handler runs inside a span continuing the caller's trace
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerSpan.TraceIDString())
+
+	require.NoError(t, shutdown(context.Background()))
+	require.Len(t, exported, 1)
+	span := exported[0]
+	require.Equal(t, "GET /api/v1/scans", span["name"])
+	require.Equal(t, "00f067aa0ba902b7", span["parentSpanId"])
+	require.EqualValues(t, tracing.KindServer, span["kind"])
+	require.EqualValues(t, 2, span["status"].(map[string]any)["code"])
+}
+
+func TestTracingMiddleware_Disabled(t *testing.T) {
+	called := false
+	handler := Tracing(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		require.Nil(t, tracing.SpanFromContext(r.Context()))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	require.True(t, called)
+}
+
 func TestLoggerMiddleware(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,165 @@
+package httpx
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// sessionCookieName holds the ID token of a browser session in "oidc" mode.
+	sessionCookieName = "vulntor_session"
+	// oidcStateCookieName carries the login state and PKCE verifier across the IdP redirect.
+	oidcStateCookieName = "vulntor_oidc_state"
+	// oidcStateMaxAge bounds how long a login may take at the IdP (seconds).
+	oidcStateMaxAge = 600
+
+	loginPath = "/auth/login"
+)
+
+// OIDCLogin runs the OpenID Connect authorization code flow.
+// Implemented by *auth.OIDCProvider.
+type OIDCLogin interface {
+	KeyVerifier
+	AuthCodeURL(ctx context.Context, state, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier string) (string, error)
+}
+
+// OIDCLoginHandler handles GET /auth/login
+//
+// Redirects the browser to the IdP. State and PKCE verifier are kept in a
+// short-lived cookie and checked by OIDCCallbackHandler.
+func OIDCLoginHandler(p OIDCLogin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, errS := randomToken()
+		verifier, errV := randomToken()
+		if errS != nil || errV != nil {
+			http.Error(w, "failed to start login", http.StatusInternalServerError)
+			return
+		}
+
+		target, err := p.AuthCodeURL(r.Context(), state, verifier)
+		if err != nil {
+			log.Error().
+				Str("component", "auth").
+				Err(err).
+				Msg("OIDC login unavailable")
+			http.Error(w, "identity provider unavailable", http.StatusBadGateway)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcStateCookieName,
+			Value:    state + "." + verifier,
+			Path:     "/auth/",
+			MaxAge:   oidcStateMaxAge,
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, target, http.StatusFound)
+	}
+}
+
+// OIDCCallbackHandler handles GET /auth/callback
+//
+// Exchanges the authorization code, verifies the ID token (including role
+// mapping) and stores it in the session cookie before returning to the UI.
+func OIDCCallbackHandler(p OIDCLogin) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if errParam := r.URL.Query().Get("error"); errParam != "" {
+			writeUnauthorized(w, "Login failed at identity provider")
+			return
+		}
+
+		c, err := r.Cookie(oidcStateCookieName)
+		state, verifier, ok := strings.Cut(valueOrEmpty(c, err), ".")
+		if !ok || subtle.ConstantTimeCompare([]byte(state), []byte(r.URL.Query().Get("state"))) != 1 {
+			writeUnauthorized(w, "Invalid login state")
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: oidcStateCookieName, Path: "/auth/", MaxAge: -1})
+
+		idToken, err := p.Exchange(r.Context(), r.URL.Query().Get("code"), verifier)
+		if err != nil {
+			log.Warn().
+				Str("component", "auth").
+				Err(err).
+				Msg("OIDC code exchange failed")
+			writeUnauthorized(w, "Login failed")
+			return
+		}
+
+		principal, err := p.Verify(r.Context(), idToken)
+		if err != nil {
+			writeVerifyError(w, r, err)
+			return
+		}
+
+		log.Info().
+			Str("component", "auth").
+			Str("user_id", principal.UserID).
+			Str("role", string(principal.Role)).
+			Msg("OIDC login")
+
+		// Expiry is enforced from the token itself on every request
+		http.SetCookie(w, &http.Cookie{
+			Name:     sessionCookieName,
+			Value:    idToken,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
+
+// OIDCLogoutHandler handles GET /auth/logout
+//
+// Clears the session cookie. The IdP session is left untouched.
+func OIDCLogoutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Path: "/", MaxAge: -1})
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
+
+// sessionToken returns the ID token from the session cookie, if any.
+func sessionToken(r *http.Request) string {
+	c, err := r.Cookie(sessionCookieName)
+	return valueOrEmpty(c, err)
+}
+
+// isLoginEndpoint checks if path belongs to the OIDC login flow
+func isLoginEndpoint(path string) bool {
+	return path == loginPath || path == "/auth/callback" || path == "/auth/logout"
+}
+
+// isBrowserNavigation reports whether r is a page load that should be sent
+// to the login page rather than answered with 401.
+func isBrowserNavigation(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		!strings.HasPrefix(r.URL.Path, "/api/") &&
+		strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+func valueOrEmpty(c *http.Cookie, err error) string {
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
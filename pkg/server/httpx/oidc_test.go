@@ -0,0 +1,140 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/server/auth"
+)
+
+// stubOIDCLogin accepts "id-token" and issues it for code "good-code".
+type stubOIDCLogin struct {
+	principal    *auth.Principal
+	err          error
+	gotVerifier  string
+	discoveryErr error
+}
+
+func (s *stubOIDCLogin) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	if token != "id-token" {
+		return nil, auth.ErrInvalidToken
+	}
+	return s.principal, s.err
+}
+
+func (s *stubOIDCLogin) AuthCodeURL(ctx context.Context, state, verifier string) (string, error) {
+	if s.discoveryErr != nil {
+		return "", s.discoveryErr
+	}
+	return "https://idp.example.com/authorize?state=" + url.QueryEscape(state), nil
+}
+
+func (s *stubOIDCLogin) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	s.gotVerifier = verifier
+	if code != "good-code" {
+		return "", errors.New("invalid_grant")
+	}
+	return "id-token", nil
+}
+
+// startLogin runs /auth/login and returns the state and the state cookie.
+func startLogin(t *testing.T, p OIDCLogin) (string, *http.Cookie) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	OIDCLoginHandler(p).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+
+	loc, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, oidcStateCookieName, cookies[0].Name)
+	require.True(t, cookies[0].HttpOnly)
+	return loc.Query().Get("state"), cookies[0]
+}
+
+func TestOIDCLoginHandler_DiscoveryFailure(t *testing.T) {
+	p := &stubOIDCLogin{discoveryErr: errors.New("connection refused")}
+	w := httptest.NewRecorder()
+	OIDCLoginHandler(p).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+	require.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestOIDCCallbackHandler_Success(t *testing.T) {
+	p := &stubOIDCLogin{principal: &auth.Principal{UserID: "alice", Role: auth.RoleOperator}}
+	state, stateCookie := startLogin(t, p)
+
+	req := httptest.NewRequest(http.MethodGet, "/auth/callback?code=good-code&state="+url.QueryEscape(state), nil)
+	req.AddCookie(stateCookie)
+	w := httptest.NewRecorder()
+	OIDCCallbackHandler(p).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "/", w.Header().Get("Location"))
+	_, verifier, _ := strings.Cut(stateCookie.Value, ".")
+	require.Equal(t, verifier, p.gotVerifier, "PKCE verifier must round-trip")
+
+	var session *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == sessionCookieName {
+			session = c
+		}
+	}
+	require.NotNil(t, session)
+	require.Equal(t, "id-token", session.Value)
+	require.True(t, session.HttpOnly)
+}
+
+func TestOIDCCallbackHandler_Rejects(t *testing.T) {
+	p := &stubOIDCLogin{principal: &auth.Principal{UserID: "alice"}}
+	state, stateCookie := startLogin(t, p)
+
+	tests := []struct {
+		name   string
+		query  string
+		cookie *http.Cookie
+		status int
+	}{
+		{name: "idp error", query: "error=access_denied", cookie: stateCookie, status: http.StatusUnauthorized},
+		{name: "missing state cookie", query: "code=good-code&state=" + url.QueryEscape(state), status: http.StatusUnauthorized},
+		{name: "state mismatch", query: "code=good-code&state=forged", cookie: stateCookie, status: http.StatusUnauthorized},
+		{name: "bad code", query: "code=bad&state=" + url.QueryEscape(state), cookie: stateCookie, status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/auth/callback?"+tt.query, nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+			w := httptest.NewRecorder()
+			OIDCCallbackHandler(p).ServeHTTP(w, req)
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+
+	// Users without a mapped role are refused a session
+	p.err = auth.ErrNoRole
+	req := httptest.NewRequest(http.MethodGet, "/auth/callback?code=good-code&state="+url.QueryEscape(state), nil)
+	req.AddCookie(stateCookie)
+	w := httptest.NewRecorder()
+	OIDCCallbackHandler(p).ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestOIDCLogoutHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	OIDCLogoutHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/logout", nil))
+
+	require.Equal(t, http.StatusFound, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	require.Equal(t, sessionCookieName, cookies[0].Name)
+	require.Less(t, cookies[0].MaxAge, 0)
+}
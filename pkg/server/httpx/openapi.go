@@ -0,0 +1,264 @@
+package httpx
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/server/openapi"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+// OpenAPI document and API reference endpoints, served without
+// authentication: they describe the API, not a tenant's data.
+const (
+	openAPIPath = "/api/openapi.json"
+	apiDocsPath = "/api/docs"
+)
+
+//go:embed swagger.html
+var swaggerPage []byte
+
+// listParams are the query parameters of the list conventions (see
+// v1.ParseListOptions).
+var listParams = []openapi.Param{
+	{Name: "limit", In: "query", Type: "integer", Description: "Results per page (1-100, default 50)"},
+	{Name: "cursor", In: "query", Description: "next_cursor of the previous page"},
+	{Name: "filter", In: "query", Description: "Conditions separated by \";\" (e.g. severity==high,critical;port>=1024)"},
+	{Name: "fields", In: "query", Description: "Comma-separated fields to return (e.g. id,status)"},
+}
+
+// withListParams returns params followed by the list convention parameters.
+func withListParams(params ...openapi.Param) []openapi.Param {
+	return append(params, listParams...)
+}
+
+// apiRoutes documents the API routes mounted by NewRouter. Routes mounted
+// only with optional dependencies (scan submission, events, plugins) are
+// documented regardless.
+var apiRoutes = []openapi.Route{
+	{
+		Pattern:  "GET /api/v1/me",
+		Tag:      "identity",
+		Summary:  "Get the caller's identity and scopes",
+		Response: v1.MeResponse{},
+	},
+	{
+		Pattern: "GET /api/v1/scans",
+		Tag:     "scans",
+		Summary: "List scans",
+		Scope:   string(auth.ScopeRead),
+		Params: withListParams(
+			openapi.Param{Name: "status", In: "query", Description: "Only scans with this status (pending, running, completed, failed)"},
+			openapi.Param{Name: "group", In: "query", Description: "Only scans run against this target group"},
+		),
+		Response: v1.ScansResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	},
+	{
+		Pattern:     "POST /api/v1/scans",
+		Tag:         "scans",
+		Summary:     "Submit a scan",
+		Description: "Queues a scan; available when the server runs background jobs.",
+		Scope:       string(auth.ScopeScanCreate),
+		Request:     v1.CreateScanRequest{},
+		Response:    v1.CreateScanResponse{},
+		Status:      http.StatusAccepted,
+		Errors:      []int{http.StatusBadRequest, http.StatusServiceUnavailable},
+	},
+	{
+		Pattern:  "GET /api/v1/scans/{id}",
+		Tag:      "scans",
+		Summary:  "Get scan details",
+		Scope:    string(auth.ScopeRead),
+		Response: api.ScanDetail{},
+		Errors:   []int{http.StatusNotFound},
+	},
+	{
+		Pattern: "GET /api/v1/scans/{id}/findings",
+		Tag:     "scans",
+		Summary: "List the findings of a scan",
+		Scope:   string(auth.ScopeRead),
+		Params: withListParams(
+			openapi.Param{Name: "offset", In: "query", Type: "integer", Description: "Index of the first finding (default 0); not combined with cursor"},
+			openapi.Param{Name: "group", In: "query", Description: "Only findings on targets of this target group"},
+			openapi.Param{Name: "tag", In: "query", Description: "Only findings with this plugin or target group tag"},
+			openapi.Param{Name: "overdue", In: "query", Type: "boolean", Description: "Only findings past their remediation due date and not closed"},
+		),
+		Response: v1.FindingsResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		Pattern:  "GET /api/v1/scans/{id}/artifacts",
+		Tag:      "scans",
+		Summary:  "List the artifacts of a scan",
+		Scope:    string(auth.ScopeRead),
+		Response: v1.ArtifactsResponse{},
+		Errors:   []int{http.StatusNotFound},
+	},
+	{
+		Pattern:     "GET /api/v1/scans/{id}/artifacts/{name}",
+		Tag:         "scans",
+		Summary:     "Download an artifact of a scan",
+		Scope:       string(auth.ScopeRead),
+		ContentType: "application/octet-stream",
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Pattern:     "GET /api/v1/scans/{id}/events",
+		Tag:         "scans",
+		Summary:     "Stream the live events of a scan",
+		Description: "Server-Sent Events: host.discovered, port.open, finding.created and scan.finished. Available when the server runs background jobs.",
+		Scope:       string(auth.ScopeRead),
+		ContentType: "text/event-stream",
+		Errors:      []int{http.StatusNotFound},
+	},
+	{
+		Pattern: "GET /api/v1/findings",
+		Tag:     "findings",
+		Summary: "List triaged, rechecked and tracked findings",
+		Scope:   string(auth.ScopeRead),
+		Params: []openapi.Param{
+			{Name: "state", In: "query", Description: "Only findings in this triage state (open, acknowledged, in-progress, fixed, accepted-risk, false-positive)"},
+			{Name: "assignee", In: "query", Description: "Only findings assigned to this user"},
+			{Name: "overdue", In: "query", Type: "boolean", Description: "Only findings past their remediation due date and not closed"},
+		},
+		Response: v1.FindingStatusesResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	},
+	{
+		Pattern:     "GET /api/v1/findings/{id}",
+		Tag:         "findings",
+		Summary:     "Get the triage state and history of a finding",
+		Description: "Findings never triaged or rechecked are looked up in the recent scans and are open.",
+		Scope:       string(auth.ScopeRead),
+		Params: []openapi.Param{
+			{Name: "id", In: "path", Description: "Finding ID, as in the Finding ID column of reports"},
+			{Name: "scan", In: "query", Description: "Scan that reported the finding (default: search the recent scans)"},
+		},
+		Response: storage.FindingStatus{},
+		Errors:   []int{http.StatusNotFound},
+	},
+	{
+		Pattern:  "PUT /api/v1/findings/{id}/state",
+		Tag:      "findings",
+		Summary:  "Change the triage state or assignee of a finding",
+		Scope:    string(auth.ScopeFindingManage),
+		Request:  v1.SetFindingStateRequest{},
+		Response: storage.FindingStatus{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound},
+	},
+	{
+		Pattern: "GET /api/v1/assets",
+		Tag:     "assets",
+		Summary: "List the asset inventory",
+		Scope:   string(auth.ScopeRead),
+		Params: withListParams(
+			openapi.Param{Name: "scans", In: "query", Type: "integer", Description: "Number of recent completed scans to aggregate (1-100, default 20)"},
+		),
+		Response: v1.AssetsResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	},
+	{
+		Pattern:     "GET /api/v1/assets/surface",
+		Tag:         "assets",
+		Summary:     "List the attack surface scores of hosts and target groups",
+		Description: "Each asset lists its scores oldest first, one per completed scan that observed it.",
+		Scope:       string(auth.ScopeRead),
+		Params: []openapi.Param{
+			{Name: "kind", In: "query", Description: "Only hosts or only target groups (host or group)"},
+		},
+		Response: v1.SurfaceResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	},
+	{
+		Pattern: "GET /api/v1/audit",
+		Tag:     "audit",
+		Summary: "List audit log events",
+		Scope:   string(auth.ScopeAdmin),
+		Params: []openapi.Param{
+			{Name: "actor", In: "query", Description: "Only events by this actor"},
+			{Name: "action", In: "query", Description: "Exact action (plugin.install) or resource type (plugin)"},
+			{Name: "since", In: "query", Description: "Only events at or after this RFC 3339 timestamp"},
+			{Name: "until", In: "query", Description: "Only events at or before this RFC 3339 timestamp"},
+			{Name: "limit", In: "query", Type: "integer", Description: "Maximum number of events (1-500, default 50)"},
+		},
+		Response: v1.AuditResponse{},
+		Errors:   []int{http.StatusBadRequest},
+	},
+	{
+		Pattern:  "GET /api/v1/plugins",
+		Tag:      "plugins",
+		Summary:  "List installed plugins",
+		Scope:    string(auth.ScopeRead),
+		Response: v1.PluginListResponse{},
+	},
+	{
+		Pattern:  "GET /api/v1/plugins/{id}",
+		Tag:      "plugins",
+		Summary:  "Get an installed plugin",
+		Scope:    string(auth.ScopeRead),
+		Response: v1.PluginInfoDTO{},
+		Errors:   []int{http.StatusNotFound},
+	},
+	{
+		Pattern:  "POST /api/v1/plugins/install",
+		Tag:      "plugins",
+		Summary:  "Install plugins by ID or category",
+		Scope:    string(auth.ScopePluginManage),
+		Request:  v1.InstallPluginRequest{},
+		Response: v1.InstallPluginResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusGatewayTimeout},
+	},
+	{
+		Pattern:  "POST /api/v1/plugins/update",
+		Tag:      "plugins",
+		Summary:  "Update plugins from their sources",
+		Scope:    string(auth.ScopePluginManage),
+		Request:  v1.UpdatePluginsRequest{},
+		Response: v1.UpdatePluginsResponse{},
+		Errors:   []int{http.StatusBadRequest, http.StatusGatewayTimeout},
+	},
+	{
+		Pattern:  "DELETE /api/v1/plugins/{id}",
+		Tag:      "plugins",
+		Summary:  "Uninstall a plugin",
+		Scope:    string(auth.ScopePluginManage),
+		Response: v1.UninstallPluginResponse{},
+		Errors:   []int{http.StatusNotFound, http.StatusGatewayTimeout},
+	},
+}
+
+// OpenAPIDocument returns the OpenAPI document of the server API.
+func OpenAPIDocument() *openapi.Document {
+	info := openapi.Info{
+		Title:       "Vulntor API",
+		Description: "REST API of the Vulntor server. Select a tenant with the X-Tenant-ID header.",
+		Version:     version.GetVersion().Version,
+	}
+	return openapi.Generate(info, apiRoutes, api.ErrorResponse{})
+}
+
+// OpenAPIHandler serves the OpenAPI document of the server API.
+func OpenAPIHandler() http.HandlerFunc {
+	doc, err := json.Marshal(OpenAPIDocument())
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			api.WriteError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(doc)
+	}
+}
+
+// APIDocsHandler serves the API reference: Swagger UI showing the OpenAPI
+// document.
+func APIDocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(swaggerPage)
+}
@@ -0,0 +1,67 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/server/openapi"
+)
+
+// TestAPIRoutes_Mounted checks that every documented route is mounted with
+// the documented pattern.
+func TestAPIRoutes_Mounted(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+	deps := &api.Deps{
+		Ready:         &atomic.Bool{},
+		ScanService:   &mockScanService{},
+		PluginService: &mockPluginService{},
+		Events:        events.NewBroker(),
+		Config:        api.DefaultConfig(),
+	}
+	router := NewRouter(cfg, deps)
+
+	for _, route := range apiRoutes {
+		method, path, _ := strings.Cut(route.Pattern, " ")
+		path = strings.NewReplacer("{id}", "x", "{name}", "y").Replace(path)
+		_, pattern := router.Handler(httptest.NewRequest(method, path, nil))
+		require.Equal(t, route.Pattern, pattern)
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+	cfg.Auth = config.AuthConfig{Mode: "token", Token: "secret-token-123"}
+	handler := Chain(cfg, NewRouter(cfg, &api.Deps{Ready: &atomic.Bool{}, Config: api.DefaultConfig()}))
+
+	// The document and its UI are served without credentials
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var doc openapi.Document
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&doc))
+	require.Equal(t, openapi.Version, doc.OpenAPI)
+	require.Equal(t, "#/components/schemas/ScansResponse", doc.Paths["/api/v1/scans"]["get"].Responses["200"].Content["application/json"].Schema.Ref)
+	require.Contains(t, doc.Paths["/api/v1/scans"], "post")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "/api/openapi.json")
+
+	// The API itself still requires them
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil))
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
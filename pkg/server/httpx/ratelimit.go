@@ -0,0 +1,422 @@
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/server/api"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Rate limit response headers.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	ScanQuotaLimitHeader     = "X-Scan-Quota-Limit"
+	ScanQuotaRemainingHeader = "X-Scan-Quota-Remaining"
+)
+
+// sweepInterval is how often idle clients are forgotten.
+const sweepInterval = 10 * time.Minute
+
+// RateLimit returns a middleware enforcing the API limits of cfg for each
+// client: its API key, its OIDC user, or its IP address without either.
+// API keys with own limits (auth.Principal.RateLimit, DailyScans) use those.
+//
+// Behavior:
+//   - Limits requests below /api/ except the API reference; health checks,
+//     metrics and the UI are not limited
+//   - Requests over the rate receive 429 Too Many Requests with Retry-After
+//     in seconds; all limited responses carry X-RateLimit-Limit and
+//     X-RateLimit-Remaining
+//   - Scan submissions (POST /api/v1/scans) over the daily quota receive 429
+//     with Retry-After until midnight UTC; only accepted scans count
+//   - Request counts are kept in memory: they are per replica and reset on
+//     restart. Daily scan counts are too, unless kept in a storage
+//     backend (see RateLimiter.WithQuotaStore)
+//
+// Must run after Auth, which attaches the principal.
+func RateLimit(cfg config.RateLimitConfig) func(http.Handler) http.Handler {
+	return NewRateLimiter(cfg).middleware
+}
+
+// RateLimiter keeps the counts of the API limits of each client. The REST
+// API (see RateLimit and WithRateLimiter) and the gRPC API share one, so a
+// client's limits cover both. A nil RateLimiter limits nothing.
+type RateLimiter struct {
+	cfg    config.RateLimitConfig
+	now    func() time.Time
+	quotas storage.QuotaStore // optional
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	scans     map[string]*dailyCount
+	lastSweep time.Time
+}
+
+// WithQuotaStore keeps the daily scan counts in store instead of in
+// memory, so the replicas sharing it enforce one quota that survives
+// restarts. It returns l.
+func (l *RateLimiter) WithQuotaStore(store storage.QuotaStore) *RateLimiter {
+	l.quotas = store
+	return l
+}
+
+// bucket is a token bucket refilled at rate tokens per second.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type dailyCount struct {
+	day   string
+	count int
+}
+
+// NewRateLimiter returns a RateLimiter enforcing the limits of cfg.
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:     cfg,
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+		scans:   make(map[string]*dailyCount),
+	}
+}
+
+func (l *RateLimiter) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") || isAPIReference(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client := clientKey(r.Context(), remoteHost(r.RemoteAddr))
+		rate, burst, daily := l.limits(r.Context())
+
+		if rate > 0 {
+			remaining, wait := l.take(client, rate, burst)
+			w.Header().Set(RateLimitLimitHeader, strconv.Itoa(rate))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(remaining))
+			if wait > 0 {
+				log.Warn().
+					Str("component", "ratelimit").
+					Str("client", client).
+					Str("path", r.URL.Path).
+					Msg("Rate limit exceeded")
+				writeTooManyRequests(w, wait, "RATE_LIMITED",
+					fmt.Sprintf("rate limit of %d requests per minute exceeded", rate))
+				return
+			}
+		}
+
+		if daily > 0 && r.Method == http.MethodPost && r.URL.Path == "/api/v1/scans" {
+			remaining, ok, release := l.reserveScan(r.Context(), client, daily)
+			w.Header().Set(ScanQuotaLimitHeader, strconv.Itoa(daily))
+			w.Header().Set(ScanQuotaRemainingHeader, strconv.Itoa(remaining))
+			if !ok {
+				log.Warn().
+					Str("component", "ratelimit").
+					Str("client", client).
+					Int("daily_scans", daily).
+					Msg("Daily scan quota exceeded")
+				writeTooManyRequests(w, l.untilTomorrow(), "DAILY_QUOTA_EXCEEDED",
+					fmt.Sprintf("daily quota of %d scans exceeded", daily))
+				return
+			}
+
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(ww, r)
+			// Rejected submissions do not count
+			if ww.statusCode >= http.StatusMultipleChoices {
+				release()
+			}
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// limits returns the request rate, burst and daily scans of the client of
+// ctx: those of its API key, or the configured ones.
+func (l *RateLimiter) limits(ctx context.Context) (rate, burst, daily int) {
+	rate, burst, daily = l.cfg.RequestsPerMinute, l.cfg.Burst, l.cfg.DailyScans
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if p.RateLimit > 0 {
+			rate, burst = p.RateLimit, p.RateLimit
+		}
+		if p.DailyScans > 0 {
+			daily = p.DailyScans
+		}
+	}
+	if burst <= 0 {
+		burst = rate
+	}
+	return rate, burst, daily
+}
+
+// Allow counts a call of the client of ctx, or of addr without credential,
+// against its request rate. It returns the rate per minute and, when the
+// client is over it, how long until the next call is allowed.
+func (l *RateLimiter) Allow(ctx context.Context, addr string) (int, time.Duration) {
+	if l == nil {
+		return 0, 0
+	}
+	rate, burst, _ := l.limits(ctx)
+	if rate <= 0 {
+		return 0, 0
+	}
+	_, wait := l.take(clientKey(ctx, addr), rate, burst)
+	return rate, wait
+}
+
+// ReserveScan counts a scan submission of the client of ctx, or of addr
+// without credential, against its daily quota. It returns the quota and,
+// when none is left today, how long until midnight UTC; release gives back
+// the reservation of a submission that was rejected.
+func (l *RateLimiter) ReserveScan(ctx context.Context, addr string) (quota int, release func(), wait time.Duration) {
+	release = func() {}
+	if l == nil {
+		return 0, release, 0
+	}
+	_, _, quota = l.limits(ctx)
+	if quota <= 0 {
+		return 0, release, 0
+	}
+	_, ok, release := l.reserveScan(ctx, clientKey(ctx, addr), quota)
+	if !ok {
+		return quota, release, l.untilTomorrow()
+	}
+	return quota, release, 0
+}
+
+// take takes a token from the client's bucket holding up to burst tokens.
+// It returns the tokens left, or how long until the next token when the
+// bucket is empty.
+func (l *RateLimiter) take(client string, perMinute, burst int) (int, time.Duration) {
+	rate := float64(perMinute) / 60
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &bucket{tokens: float64(burst), last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(float64(burst), b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		return 0, time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return int(b.tokens), 0
+}
+
+// peek returns how long until the client's bucket holding up to burst
+// tokens has one, without taking it; 0 when it has one now.
+func (l *RateLimiter) peek(client string, perMinute, burst int) time.Duration {
+	rate := float64(perMinute) / 60
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[client]
+	if !ok {
+		return 0
+	}
+	tokens := math.Min(float64(burst), b.tokens+l.now().Sub(b.last).Seconds()*rate)
+	if tokens < 1 {
+		return time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return 0
+}
+
+// reserveScan counts a scan submission against the client's daily quota.
+// It returns the submissions left today, false when none were left, and a
+// func giving the submission back. Counts stay in memory when the quota
+// store fails.
+func (l *RateLimiter) reserveScan(ctx context.Context, client string, quota int) (int, bool, func()) {
+	day := l.now().UTC().Format(time.DateOnly)
+	if l.quotas != nil {
+		remaining, ok, err := l.quotas.Reserve(ctx, client, day, quota)
+		if err == nil {
+			return remaining, ok, func() {
+				if err := l.quotas.Release(context.WithoutCancel(ctx), client, day); err != nil {
+					log.Warn().
+						Err(err).
+						Str("component", "ratelimit").
+						Str("client", client).
+						Msg("Failed to release scan quota")
+				}
+			}
+		}
+		log.Warn().
+			Err(err).
+			Str("component", "ratelimit").
+			Str("client", client).
+			Msg("Failed to count scan quota in storage")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.scans[client]
+	if !ok || c.day != day {
+		c = &dailyCount{day: day}
+		l.scans[client] = c
+	}
+	if c.count >= quota {
+		return 0, false, func() {}
+	}
+	c.count++
+	return quota - c.count, true, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if c.count > 0 {
+			c.count--
+		}
+	}
+}
+
+func (l *RateLimiter) untilTomorrow() time.Duration {
+	now := l.now().UTC()
+	return now.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now)
+}
+
+// sweep forgets clients idle since the last sweep and counts of earlier
+// days. Callers hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if now.Sub(b.last) >= sweepInterval {
+			delete(l.buckets, client)
+		}
+	}
+	day := now.UTC().Format(time.DateOnly)
+	for client, c := range l.scans {
+		if c.day != day {
+			delete(l.scans, client)
+		}
+	}
+}
+
+// clientKey identifies the client of ctx within its tenant: its API key,
+// its OIDC user, or its address host without either.
+func clientKey(ctx context.Context, host string) string {
+	tenant := storage.OrgIDFromContext(ctx)
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		if p.KeyID != "" {
+			return tenant + "/key:" + p.KeyID
+		}
+		if p.UserID != "" {
+			return tenant + "/user:" + p.UserID
+		}
+	}
+	return tenant + "/ip:" + host
+}
+
+// remoteHost returns the host of a client address such as
+// http.Request.RemoteAddr.
+func remoteHost(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// FailedAuthLimiter limits the failed authentications of each IP address,
+// so credentials cannot be guessed at the request rate: an address with
+// perMinute failures within the last minute is refused until its count
+// drains, even with valid credentials. The REST API (see Auth and
+// WithFailedAuthLimiter) and the gRPC API share one. A nil
+// FailedAuthLimiter limits nothing.
+type FailedAuthLimiter struct {
+	perMinute int
+	counts    *RateLimiter
+}
+
+// NewFailedAuthLimiter returns a FailedAuthLimiter allowing perMinute
+// failed authentications per IP address; nil when perMinute is 0 or less.
+func NewFailedAuthLimiter(perMinute int) *FailedAuthLimiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	return &FailedAuthLimiter{perMinute: perMinute, counts: NewRateLimiter(config.RateLimitConfig{})}
+}
+
+// wait returns how long host is refused; 0 when it may authenticate.
+func (f *FailedAuthLimiter) wait(host string) time.Duration {
+	if f == nil {
+		return 0
+	}
+	return f.counts.peek("ip:"+host, f.perMinute, f.perMinute)
+}
+
+// fail counts a failed authentication of host.
+func (f *FailedAuthLimiter) fail(host string) {
+	if f == nil {
+		return
+	}
+	if remaining, _ := f.counts.take("ip:"+host, f.perMinute, f.perMinute); remaining == 0 {
+		log.Warn().
+			Str("component", "ratelimit").
+			Str("client", "ip:"+host).
+			Int("failed_auth_per_minute", f.perMinute).
+			Msg("Failed authentication limit reached")
+	}
+}
+
+// middleware refuses requests of refused addresses with 429 Too Many
+// Requests and counts the 401 responses of the others. Health checks and
+// the API reference are never refused.
+func (f *FailedAuthLimiter) middleware(next http.Handler) http.Handler {
+	if f == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isHealthEndpoint(r.URL.Path) || isAPIReference(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		host := remoteHost(r.RemoteAddr)
+		if wait := f.wait(host); wait > 0 {
+			writeTooManyRequests(w, wait, "RATE_LIMITED", "too many failed authentications")
+			return
+		}
+
+		ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(ww, r)
+		if ww.statusCode == http.StatusUnauthorized {
+			f.fail(host)
+		}
+	})
+}
+
+// writeTooManyRequests writes a 429 response telling the client when to retry.
+func writeTooManyRequests(w http.ResponseWriter, wait time.Duration, code, message string) {
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	api.WriteJSONError(w, http.StatusTooManyRequests, "Too Many Requests", code,
+		fmt.Sprintf("%s, retry in %ds", message, seconds))
+}
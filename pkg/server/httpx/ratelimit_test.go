@@ -0,0 +1,233 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newTestLimiter(cfg config.RateLimitConfig, clock *time.Time) (*RateLimiter, http.Handler) {
+	l := NewRateLimiter(cfg)
+	l.now = func() time.Time { return *clock }
+	handler := l.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test-Status") == "reject" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return l, handler
+}
+
+func serveAs(h http.Handler, method, path string, p *auth.Principal) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if p != nil {
+		req = req.WithContext(auth.WithPrincipal(req.Context(), p))
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit_Requests(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, h := newTestLimiter(config.RateLimitConfig{RequestsPerMinute: 60, Burst: 2}, &clock)
+	ci := &auth.Principal{KeyID: "ci"}
+
+	w := serveAs(h, http.MethodGet, "/api/v1/scans", ci)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, "60", w.Header().Get(RateLimitLimitHeader))
+	require.Equal(t, "1", w.Header().Get(RateLimitRemainingHeader))
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", ci).Code)
+
+	w = serveAs(h, http.MethodGet, "/api/v1/scans", ci)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+	var body struct{ Code, Message string }
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "RATE_LIMITED", body.Code)
+	require.Contains(t, body.Message, "60 requests per minute")
+
+	// Other clients have their own bucket
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", &auth.Principal{KeyID: "dashboard"}).Code)
+
+	// Health checks, metrics and the UI are not limited
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/healthz", ci).Code)
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/metrics", ci).Code)
+
+	// Tokens refill over time
+	clock = clock.Add(time.Second)
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", ci).Code)
+}
+
+func TestRateLimit_KeyOverride(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, h := newTestLimiter(config.RateLimitConfig{RequestsPerMinute: 1}, &clock)
+
+	bulk := &auth.Principal{KeyID: "bulk", RateLimit: 3}
+	for range 3 {
+		require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", bulk).Code)
+	}
+	w := serveAs(h, http.MethodGet, "/api/v1/scans", bulk)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "20", w.Header().Get("Retry-After"))
+
+	// Clients without a key are limited by IP
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", nil).Code)
+	require.Equal(t, http.StatusTooManyRequests, serveAs(h, http.MethodGet, "/api/v1/scans", nil).Code)
+}
+
+func TestRateLimit_DailyScans(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	l, h := newTestLimiter(config.RateLimitConfig{DailyScans: 2}, &clock)
+	ci := &auth.Principal{KeyID: "ci"}
+
+	// Rejected submissions do not count
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", nil)
+	req.Header.Set("X-Test-Status", "reject")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req.WithContext(auth.WithPrincipal(req.Context(), ci)))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = serveAs(h, http.MethodPost, "/api/v1/scans", ci)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, "2", w.Header().Get(ScanQuotaLimitHeader))
+	require.Equal(t, "1", w.Header().Get(ScanQuotaRemainingHeader))
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodPost, "/api/v1/scans", ci).Code)
+
+	w = serveAs(h, http.MethodPost, "/api/v1/scans", ci)
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "7200", w.Header().Get("Retry-After"), "retry at midnight UTC")
+	require.Contains(t, w.Body.String(), "DAILY_QUOTA_EXCEEDED")
+
+	// Reads are not counted; a key with its own quota gets it
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodGet, "/api/v1/scans", ci).Code)
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodPost, "/api/v1/scans", &auth.Principal{KeyID: "ci", DailyScans: 3}).Code)
+
+	// The quota resets the next day, and old counts are swept
+	clock = clock.Add(3 * time.Hour)
+	require.Equal(t, http.StatusAccepted, serveAs(h, http.MethodPost, "/api/v1/scans", ci).Code)
+	l.mu.Lock()
+	l.sweep(clock.Add(sweepInterval))
+	require.Len(t, l.scans, 1)
+	l.mu.Unlock()
+}
+
+func TestRateLimit_DailyScansSharedStore(t *testing.T) {
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ci := &auth.Principal{KeyID: "ci"}
+
+	// Replicas sharing the store share the quota
+	l1, h1 := newTestLimiter(config.RateLimitConfig{DailyScans: 2}, &clock)
+	l1.WithQuotaStore(backend.Quotas())
+	l2, h2 := newTestLimiter(config.RateLimitConfig{DailyScans: 2}, &clock)
+	l2.WithQuotaStore(backend.Quotas())
+	require.Equal(t, http.StatusAccepted, serveAs(h1, http.MethodPost, "/api/v1/scans", ci).Code)
+	w := serveAs(h2, http.MethodPost, "/api/v1/scans", ci)
+	require.Equal(t, http.StatusAccepted, w.Code)
+	require.Equal(t, "0", w.Header().Get(ScanQuotaRemainingHeader))
+	require.Equal(t, http.StatusTooManyRequests, serveAs(h1, http.MethodPost, "/api/v1/scans", ci).Code)
+
+	// A restarted replica keeps the count
+	l3, _ := newTestLimiter(config.RateLimitConfig{DailyScans: 2}, &clock)
+	l3.WithQuotaStore(backend.Quotas())
+	ctx := auth.WithPrincipal(context.Background(), ci)
+	_, _, wait := l3.ReserveScan(ctx, "192.0.2.7:51234")
+	require.Equal(t, 12*time.Hour, wait)
+
+	// Rejected submissions are given back to the store
+	l2.now = func() time.Time { return clock.Add(24 * time.Hour) }
+	_, release, wait := l2.ReserveScan(ctx, "192.0.2.7:51234")
+	require.Zero(t, wait)
+	release()
+	_, ok, err := backend.Quotas().Reserve(context.Background(), "default/key:ci", "2026-03-02", 1)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestClientKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans", nil)
+	req.RemoteAddr = "192.0.2.7:51234"
+	require.Equal(t, "default/ip:192.0.2.7", clientKey(req.Context(), remoteHost(req.RemoteAddr)))
+
+	ctx := storage.WithOrgID(req.Context(), "team-a")
+	require.Equal(t, "team-a/key:k1", clientKey(auth.WithPrincipal(ctx, &auth.Principal{KeyID: "k1"}), "192.0.2.7"))
+	require.Equal(t, "team-a/user:alice", clientKey(auth.WithPrincipal(ctx, &auth.Principal{UserID: "alice"}), "192.0.2.7"))
+}
+
+func TestAuth_FailedAuthLimit(t *testing.T) {
+	clock := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	failures := NewFailedAuthLimiter(3)
+	failures.counts.now = func() time.Time { return clock }
+	cfg := config.ServerConfig{Auth: config.AuthConfig{Mode: "token", Token: "s3cret"}}
+	h := Chain(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), WithFailedAuthLimiter(failures))
+
+	call := func(path, addr, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = addr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w
+	}
+
+	// Repeated 401s of one address eventually return 429
+	for range 3 {
+		require.Equal(t, http.StatusUnauthorized, call("/api/v1/scans", "192.0.2.7:51234", "guess").Code)
+	}
+	w := call("/api/v1/scans", "192.0.2.7:51234", "guess")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "20", w.Header().Get("Retry-After"))
+	var body struct{ Code, Message string }
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "RATE_LIMITED", body.Code)
+
+	// Refused before the credential is checked, on every port of the address
+	require.Equal(t, http.StatusTooManyRequests, call("/api/v1/scans", "192.0.2.7:40000", "s3cret").Code)
+	require.Equal(t, http.StatusOK, call("/healthz", "192.0.2.7:40000", "").Code)
+
+	// Other addresses are not refused
+	require.Equal(t, http.StatusOK, call("/api/v1/scans", "192.0.2.8:51234", "s3cret").Code)
+
+	// The count drains at the limit's rate
+	clock = clock.Add(20 * time.Second)
+	require.Equal(t, http.StatusOK, call("/api/v1/scans", "192.0.2.7:51234", "s3cret").Code)
+	require.Equal(t, http.StatusUnauthorized, call("/api/v1/scans", "192.0.2.7:51234", "guess").Code)
+	require.Equal(t, http.StatusTooManyRequests, call("/api/v1/scans", "192.0.2.7:51234", "s3cret").Code)
+}
+
+func TestAuthenticator_FailedAuthLimit(t *testing.T) {
+	ctx := context.Background()
+	authn := NewAuthenticator(config.ServerConfig{
+		Auth:      config.AuthConfig{Mode: "token", Token: "s3cret"},
+		RateLimit: config.RateLimitConfig{FailedAuthPerMinute: 2},
+	})
+
+	_, err := authn.Authenticate(ctx, "192.0.2.7:51234", "", "")
+	require.ErrorIs(t, err, ErrMissingCredentials)
+	_, err = authn.Authenticate(ctx, "192.0.2.7:51234", "", "guess")
+	require.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = authn.Authenticate(ctx, "192.0.2.7:51234", "", "s3cret")
+	var throttled *ThrottledError
+	require.ErrorAs(t, err, &throttled)
+	require.Positive(t, throttled.RetryAfter)
+
+	_, err = authn.Authenticate(ctx, "192.0.2.8:51234", "", "s3cret")
+	require.NoError(t, err)
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/plugin"
 	"github.com/vulntor/vulntor/pkg/server/api"
 	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/auth"
 	"github.com/vulntor/vulntor/pkg/ui"
 )
 
@@ -18,7 +20,10 @@ import (
 // The router uses Go 1.22+ enhanced pattern matching for cleaner routes.
 // Routes are mounted conditionally based on cfg.APIEnabled and cfg.UIEnabled.
 //
-// Health endpoints are always enabled for liveness/readiness checks.
+// Health endpoints are always enabled for liveness/readiness checks, and
+// /metrics for Prometheus.
+// API routes are wrapped with RequireScope so API keys only reach the
+// endpoints their scopes allow.
 func NewRouter(cfg config.ServerConfig, deps *api.Deps) *http.ServeMux {
 	mux := http.NewServeMux()
 
@@ -26,11 +31,53 @@ func NewRouter(cfg config.ServerConfig, deps *api.Deps) *http.ServeMux {
 	mux.HandleFunc("GET /healthz", HealthzHandler)
 	mux.HandleFunc("GET /readyz", v1.ReadyzHandler(deps.Ready))
 
+	// Prometheus metrics of the plugins evaluated by the server's scans
+	mux.HandleFunc("GET /metrics", RequireScope(auth.ScopeRead, MetricsHandler(plugin.TotalExecStats())))
+
+	// OIDC login flow (only in oidc auth mode)
+	if p, ok := deps.OIDC.(OIDCLogin); ok {
+		mux.HandleFunc("GET /auth/login", OIDCLoginHandler(p))
+		mux.HandleFunc("GET /auth/callback", OIDCCallbackHandler(p))
+		mux.HandleFunc("GET /auth/logout", OIDCLogoutHandler())
+	}
+
 	// API endpoints (conditional)
 	if cfg.APIEnabled {
+		// OpenAPI document and its Swagger UI reference
+		mux.HandleFunc("GET "+openAPIPath, OpenAPIHandler())
+		mux.HandleFunc("GET "+apiDocsPath, APIDocsHandler)
+
+		// Caller identity (any authenticated caller)
+		mux.HandleFunc("GET /api/v1/me", v1.MeHandler())
+
 		// Scan endpoints
-		mux.HandleFunc("GET /api/v1/scans", v1.ListScansHandler(deps))
-		mux.HandleFunc("GET /api/v1/scans/{id}", v1.GetScanHandler(deps))
+		mux.HandleFunc("GET /api/v1/scans", RequireScope(auth.ScopeRead, v1.ListScansHandler(deps)))
+		mux.HandleFunc("GET /api/v1/scans/{id}", RequireScope(auth.ScopeRead, v1.GetScanHandler(deps)))
+		mux.HandleFunc("GET /api/v1/scans/{id}/findings", RequireScope(auth.ScopeRead, v1.ListFindingsHandler(deps)))
+		mux.HandleFunc("GET /api/v1/scans/{id}/artifacts", RequireScope(auth.ScopeRead, v1.ListArtifactsHandler(deps)))
+		mux.HandleFunc("GET /api/v1/scans/{id}/artifacts/{name}", RequireScope(auth.ScopeRead, v1.GetArtifactHandler(deps)))
+
+		// Finding triage across scans
+		mux.HandleFunc("GET /api/v1/findings", RequireScope(auth.ScopeRead, v1.ListFindingStatusesHandler(deps)))
+		mux.HandleFunc("GET /api/v1/findings/{id}", RequireScope(auth.ScopeRead, v1.GetFindingStatusHandler(deps)))
+		mux.HandleFunc("PUT /api/v1/findings/{id}/state", RequireScope(auth.ScopeFindingManage, v1.SetFindingStateHandler(deps)))
+
+		// Asset inventory from recent scans
+		mux.HandleFunc("GET /api/v1/assets", RequireScope(auth.ScopeRead, v1.ListAssetsHandler(deps)))
+		mux.HandleFunc("GET /api/v1/assets/surface", RequireScope(auth.ScopeRead, v1.ListSurfaceHandler(deps)))
+
+		// Audit log of mutating operations
+		mux.HandleFunc("GET /api/v1/audit", RequireScope(auth.ScopeAdmin, v1.ListAuditHandler(deps)))
+
+		// Live scan event stream (only if an event broker is available)
+		if deps.Events != nil {
+			mux.HandleFunc("GET /api/v1/scans/{id}/events", RequireScope(auth.ScopeRead, v1.StreamScanEventsHandler(deps)))
+		}
+
+		// Scan creation (only if a ScanService is available, i.e. jobs enabled)
+		if scanSvc, ok := deps.ScanService.(v1.ScanService); ok {
+			mux.HandleFunc("POST /api/v1/scans", RequireScope(auth.ScopeScanCreate, v1.CreateScanHandler(scanSvc)))
+		}
 
 		// Plugin endpoints (only if PluginService is available)
 		if deps.PluginService != nil {
@@ -39,11 +86,11 @@ func NewRouter(cfg config.ServerConfig, deps *api.Deps) *http.ServeMux {
 				log.Info().
 					Str("component", "httpx.router").
 					Msg("mounting plugin API routes")
-				mux.HandleFunc("POST /api/v1/plugins/install", v1.InstallPluginHandler(pluginSvc, deps.Config))
-				mux.HandleFunc("POST /api/v1/plugins/update", v1.UpdatePluginsHandler(pluginSvc, deps.Config))
-				mux.HandleFunc("GET /api/v1/plugins", v1.ListPluginsHandler(pluginSvc))
-				mux.HandleFunc("GET /api/v1/plugins/{id}", v1.GetPluginHandler(pluginSvc))
-				mux.HandleFunc("DELETE /api/v1/plugins/{id}", v1.UninstallPluginHandler(pluginSvc, deps.Config))
+				mux.HandleFunc("POST /api/v1/plugins/install", RequireScope(auth.ScopePluginManage, v1.InstallPluginHandler(pluginSvc, deps.Config)))
+				mux.HandleFunc("POST /api/v1/plugins/update", RequireScope(auth.ScopePluginManage, v1.UpdatePluginsHandler(pluginSvc, deps.Config)))
+				mux.HandleFunc("GET /api/v1/plugins", RequireScope(auth.ScopeRead, v1.ListPluginsHandler(pluginSvc)))
+				mux.HandleFunc("GET /api/v1/plugins/{id}", RequireScope(auth.ScopeRead, v1.GetPluginHandler(pluginSvc)))
+				mux.HandleFunc("DELETE /api/v1/plugins/{id}", RequireScope(auth.ScopePluginManage, v1.UninstallPluginHandler(pluginSvc, deps.Config)))
 			} else {
 				log.Warn().
 					Str("component", "httpx.router").
@@ -5,6 +5,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -15,6 +16,9 @@ import (
 	"github.com/vulntor/vulntor/pkg/config"
 	"github.com/vulntor/vulntor/pkg/plugin"
 	"github.com/vulntor/vulntor/pkg/server/api"
+	v1 "github.com/vulntor/vulntor/pkg/server/api/v1"
+	"github.com/vulntor/vulntor/pkg/server/auth"
+	"github.com/vulntor/vulntor/pkg/storage"
 )
 
 func TestNewRouter(t *testing.T) {
@@ -248,3 +252,138 @@ func TestPluginRoutes_NotMounted_WhenAPIDisabled(t *testing.T) {
 
 	require.Equal(t, http.StatusNotFound, w.Code, "Expected 404 when APIEnabled=false")
 }
+
+type mockScanService struct{}
+
+func (m *mockScanService) Submit(ctx context.Context, req v1.CreateScanRequest) (*v1.CreateScanResponse, error) {
+	return &v1.CreateScanResponse{ID: "scan-1", JobID: "scan-1", Status: "pending"}, nil
+}
+
+func TestScanRoutes_CreateMounted_WhenServiceExists(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	deps := &api.Deps{
+		Ready:       &atomic.Bool{},
+		ScanService: &mockScanService{},
+		Config:      api.DefaultConfig(),
+	}
+	router := NewRouter(cfg, deps)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestScanRoutes_CreateNotMounted_WithoutService(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	deps := &api.Deps{
+		Ready:  &atomic.Bool{},
+		Config: api.DefaultConfig(),
+	}
+	router := NewRouter(cfg, deps)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// GET /api/v1/scans is still mounted, so POST is rejected by the mux
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestScanRoutes_EventsNotMounted_WithoutBroker(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	deps := &api.Deps{
+		Ready:  &atomic.Bool{},
+		Config: api.DefaultConfig(),
+	}
+	router := NewRouter(cfg, deps)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/scans/scan-1/events", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestScanRoutes_APIKeyScopesEnforced(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	deps := &api.Deps{
+		Ready:       &atomic.Bool{},
+		ScanService: &mockScanService{},
+		Config:      api.DefaultConfig(),
+	}
+	router := NewRouter(cfg, deps)
+
+	readOnly := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: "k1", Scopes: []string{"read"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`)).WithContext(readOnly)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	creator := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: "k2", Scopes: []string{"scan:create"}})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/scans", strings.NewReader(`{"targets":["10.0.0.1"]}`)).WithContext(creator)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestRouter_AuditRequiresAdmin(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	router := NewRouter(cfg, &api.Deps{Ready: &atomic.Bool{}, Storage: backend, Config: api.DefaultConfig()})
+
+	reader := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: "k1", Scopes: []string{"read"}})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil).WithContext(reader))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	admin := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: "k2", Scopes: []string{"admin"}})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil).WithContext(admin))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRouter_MeRoute(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	deps := &api.Deps{Ready: &atomic.Bool{}, Config: api.DefaultConfig()}
+	router := NewRouter(cfg, deps)
+
+	// Reachable without any scope so every caller can discover its permissions
+	viewer := auth.WithPrincipal(context.Background(), &auth.Principal{KeyID: "k1", UserID: "alice", Role: auth.RoleViewer})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil).WithContext(viewer)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"role":"viewer"`)
+}
+
+func TestRouter_OIDCRoutes(t *testing.T) {
+	cfg := config.DefaultServerConfig()
+	cfg.UIEnabled = false
+
+	// Not mounted without a provider
+	router := NewRouter(cfg, &api.Deps{Ready: &atomic.Bool{}, Config: api.DefaultConfig()})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	router = NewRouter(cfg, &api.Deps{Ready: &atomic.Bool{}, Config: api.DefaultConfig(), OIDC: &stubOIDCLogin{}})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/auth/login", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+	require.True(t, strings.HasPrefix(w.Header().Get("Location"), "https://idp.example.com/authorize"))
+}
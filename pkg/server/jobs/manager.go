@@ -3,15 +3,87 @@ package jobs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 )
 
+// Job states reported by Status.State.
+const (
+	StatePending   = "pending"
+	StateRunning   = "running"
+	StateCompleted = "completed"
+	StateFailed    = "failed"
+)
+
+// ErrJobNotFound is returned by Status when the job ID is unknown.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrQueueFull is returned by Submit when the job queue cannot accept more work.
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrTenantQuota is returned by Submit when the tenant of a job already has
+// as many jobs queued or running as its quota allows.
+var ErrTenantQuota = errors.New("tenant job quota exceeded")
+
+// Job priorities. Jobs of higher priority run first; jobs of the same
+// priority run in submission order.
+const (
+	PriorityLow    = -1
+	PriorityNormal = 0
+	PriorityHigh   = 1
+)
+
+// ParsePriority returns the priority named s (low, normal or high); empty
+// is PriorityNormal.
+func ParsePriority(s string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return PriorityLow, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "high":
+		return PriorityHigh, nil
+	default:
+		return 0, fmt.Errorf("unknown priority %q (low, normal or high)", s)
+	}
+}
+
 // Job represents a background job to be executed.
 type Job struct {
 	ID      string
 	Type    string
 	Payload interface{}
+
+	// Tenant owns the job; per-tenant limits count the jobs of each tenant
+	// apart. Empty for jobs outside any tenant.
+	Tenant string
+
+	// Priority orders queued jobs, e.g. PriorityHigh.
+	Priority int
+
+	// SubmittedAt is when the job was queued, set by Submit.
+	SubmittedAt time.Time
+}
+
+// Store persists the queued jobs of a manager, so that they survive a
+// restart. Jobs are removed once they start running.
+type Store interface {
+	// Save persists a queued job.
+	Save(ctx context.Context, job Job) error
+
+	// Delete removes a job. Deleting an unknown job is not an error.
+	Delete(ctx context.Context, jobID string) error
+
+	// Load returns the persisted jobs in submission order.
+	Load(ctx context.Context) ([]Job, error)
 }
 
+// Handler processes a job of a given type.
+// The context is canceled when the manager stops.
+type Handler func(ctx context.Context, job Job) error
+
 // Status represents the current status of a job.
 type Status struct {
 	ID        string
@@ -33,9 +105,12 @@ type Manager interface {
 	// It should respect the context deadline for shutdown timeout.
 	Stop(ctx context.Context) error
 
-	// Submit enqueues a job for processing (optional for MVP).
-	// Submit(ctx context.Context, job Job) error
+	// Submit enqueues a job for processing.
+	// Returns ErrQueueFull if the job cannot be accepted, and
+	// ErrTenantQuota if its tenant is at its quota.
+	Submit(ctx context.Context, job Job) error
 
-	// Status returns the current status of a job (optional for MVP).
-	// Status(ctx context.Context, jobID string) (*Status, error)
+	// Status returns the current status of a job.
+	// Returns ErrJobNotFound if the job is unknown.
+	Status(ctx context.Context, jobID string) (*Status, error)
 }
@@ -4,39 +4,274 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+// DefaultQueueSize is the number of jobs a manager queues by default.
+const DefaultQueueSize = 100
+
 // MemoryManager is an in-memory implementation of Manager for OSS.
-// It processes jobs using a worker pool with configurable concurrency.
+// It processes jobs using a worker pool with configurable concurrency,
+// highest priority first. With a Store, queued jobs survive a restart.
+//
+// A manager with a Store that is not started, such as that of a replica
+// following the leader, runs nothing: it queues submitted jobs in the
+// store only, checking the queue size and tenant quota against the jobs
+// queued there, and reports their status from it.
 type MemoryManager struct {
 	concurrency int
-	jobQueue    chan Job
+	queueSize   int
 	wg          sync.WaitGroup
 	cancelFunc  context.CancelFunc
 	mu          sync.RWMutex
 	started     bool
+
+	// Per-tenant limits; 0 = no limit
+	tenantConcurrency int
+	tenantQuota       int
+
+	store        Store         // nil keeps the queue in memory only
+	syncInterval time.Duration // 0 = read the store on Start only
+
+	queue   []Job          // pending jobs in dispatch order
+	running map[string]int // running jobs by tenant
+	wake    chan struct{}  // closed when a job is queued or finishes
+
+	handlers map[string]Handler
+	statuses map[string]*Status
+}
+
+// Option configures a MemoryManager.
+type Option func(*MemoryManager)
+
+// WithQueueSize limits the number of queued jobs; Submit returns
+// ErrQueueFull beyond it. n <= 0 keeps DefaultQueueSize.
+func WithQueueSize(n int) Option {
+	return func(m *MemoryManager) {
+		if n > 0 {
+			m.queueSize = n
+		}
+	}
+}
+
+// WithTenantLimits limits the jobs of each tenant: concurrency is how many
+// may run at once, quota how many may be queued or running at once.
+// Zero means no limit.
+func WithTenantLimits(concurrency, quota int) Option {
+	return func(m *MemoryManager) {
+		m.tenantConcurrency = max(concurrency, 0)
+		m.tenantQuota = max(quota, 0)
+	}
+}
+
+// WithStore persists queued jobs in s; Start queues the jobs it holds.
+func WithStore(s Store) Option {
+	return func(m *MemoryManager) {
+		m.store = s
+	}
+}
+
+// WithStoreSync re-reads the store every interval while started, for a
+// store shared by several managers: jobs submitted through the others are
+// queued, and queued jobs another manager already started are dropped.
+// It has no effect without WithStore.
+func WithStoreSync(interval time.Duration) Option {
+	return func(m *MemoryManager) {
+		m.syncInterval = max(interval, 0)
+	}
 }
 
 // NewMemoryManager creates a new in-memory job manager.
 // concurrency controls the number of worker goroutines.
 // If concurrency <= 0, defaults to 4.
-func NewMemoryManager(concurrency int) *MemoryManager {
+func NewMemoryManager(concurrency int, opts ...Option) *MemoryManager {
 	if concurrency <= 0 {
 		concurrency = 4 // Default worker count
 	}
 
-	return &MemoryManager{
+	m := &MemoryManager{
 		concurrency: concurrency,
-		jobQueue:    make(chan Job, 100), // Buffered channel for jobs
+		queueSize:   DefaultQueueSize,
 		started:     false,
+		running:     make(map[string]int),
+		wake:        make(chan struct{}),
+		handlers:    make(map[string]Handler),
+		statuses:    make(map[string]*Status),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Handle registers the handler for a job type.
+// Jobs without a registered handler complete immediately as a no-op.
+func (m *MemoryManager) Handle(jobType string, h Handler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[jobType] = h
+}
+
+// Submit enqueues a job for processing.
+// Jobs may be submitted before Start; they are picked up once workers run,
+// by this manager or, with a shared Store, by the one that is started.
+func (m *MemoryManager) Submit(ctx context.Context, job Job) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if job.ID == "" {
+		return fmt.Errorf("job ID is required")
+	}
+	if job.SubmittedAt.IsZero() {
+		job.SubmittedAt = time.Now()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.store != nil && !m.started {
+		return m.submitToStore(ctx, job)
+	}
+	if _, exists := m.statuses[job.ID]; exists {
+		return fmt.Errorf("job %s already submitted", job.ID)
+	}
+	if len(m.queue) >= m.queueSize {
+		return ErrQueueFull
+	}
+	if m.tenantQuota > 0 && m.tenantJobs(job.Tenant) >= m.tenantQuota {
+		return ErrTenantQuota
+	}
+	if m.store != nil {
+		if err := m.store.Save(ctx, job); err != nil {
+			return fmt.Errorf("persist job %s: %w", job.ID, err)
+		}
+	}
+
+	m.statuses[job.ID] = &Status{ID: job.ID, State: StatePending}
+	m.enqueue(job)
+	return nil
+}
+
+// submitToStore queues job in the store for the manager that runs its
+// jobs, checking the queue size and tenant quota against the jobs queued
+// there; jobs that manager is running do not count. The caller must hold
+// m.mu.
+func (m *MemoryManager) submitToStore(ctx context.Context, job Job) error {
+	persisted, err := m.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load queued jobs: %w", err)
+	}
+	tenantJobs := 0
+	for _, queued := range persisted {
+		if queued.ID == job.ID {
+			return fmt.Errorf("job %s already submitted", job.ID)
+		}
+		if queued.Tenant == job.Tenant {
+			tenantJobs++
+		}
+	}
+	if len(persisted) >= m.queueSize {
+		return ErrQueueFull
+	}
+	if m.tenantQuota > 0 && tenantJobs >= m.tenantQuota {
+		return ErrTenantQuota
+	}
+	if err := m.store.Save(ctx, job); err != nil {
+		return fmt.Errorf("persist job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+// tenantJobs returns the number of queued and running jobs of tenant.
+// The caller must hold m.mu.
+func (m *MemoryManager) tenantJobs(tenant string) int {
+	n := m.running[tenant]
+	for _, job := range m.queue {
+		if job.Tenant == tenant {
+			n++
+		}
+	}
+	return n
+}
+
+// enqueue inserts job after the queued jobs of the same or higher priority
+// and wakes the workers. The caller must hold m.mu.
+func (m *MemoryManager) enqueue(job Job) {
+	i := sort.Search(len(m.queue), func(i int) bool {
+		return m.queue[i].Priority < job.Priority
+	})
+	m.queue = append(m.queue, Job{})
+	copy(m.queue[i+1:], m.queue[i:])
+	m.queue[i] = job
+	m.notify()
+}
+
+// notify wakes the waiting workers. The caller must hold m.mu.
+func (m *MemoryManager) notify() {
+	close(m.wake)
+	m.wake = make(chan struct{})
+}
+
+// next removes and returns the first queued job whose tenant is below its
+// concurrency limit, or the channel closed on the next change of the queue
+// if there is none.
+func (m *MemoryManager) next() (Job, bool, <-chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, job := range m.queue {
+		if m.tenantConcurrency > 0 && m.running[job.Tenant] >= m.tenantConcurrency {
+			continue
+		}
+		m.queue = append(m.queue[:i], m.queue[i+1:]...)
+		m.running[job.Tenant]++
+		return job, true, nil
+	}
+	return Job{}, false, m.wake
+}
+
+// Status returns a snapshot of the job's current status.
+func (m *MemoryManager) Status(ctx context.Context, jobID string) (*Status, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	st, ok := m.statuses[jobID]
+	if !ok && m.store != nil && !m.started {
+		return m.storedStatus(ctx, jobID)
+	}
+	if !ok {
+		return nil, ErrJobNotFound
 	}
+	snapshot := *st
+	return &snapshot, nil
+}
+
+// storedStatus returns the status of a job queued in the store, for a
+// manager that is not started. The caller must hold m.mu.
+func (m *MemoryManager) storedStatus(ctx context.Context, jobID string) (*Status, error) {
+	persisted, err := m.store.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load queued jobs: %w", err)
+	}
+	for _, job := range persisted {
+		if job.ID == jobID {
+			return &Status{ID: job.ID, State: StatePending}, nil
+		}
+	}
+	return nil, ErrJobNotFound
 }
 
 // Start begins processing jobs in the background.
-// It spawns worker goroutines that process jobs from the queue.
+// It syncs the queue with the store, then spawns worker goroutines that
+// process jobs from the queue.
 func (m *MemoryManager) Start(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -45,10 +280,19 @@ func (m *MemoryManager) Start(ctx context.Context) error {
 		return fmt.Errorf("job manager already started")
 	}
 
+	if err := m.syncStore(ctx); err != nil {
+		return err
+	}
+
 	// Create cancellable context for workers
 	workerCtx, cancel := context.WithCancel(ctx)
 	m.cancelFunc = cancel
 
+	if m.store != nil && m.syncInterval > 0 {
+		m.wg.Add(1)
+		go m.syncLoop(workerCtx)
+	}
+
 	// Start worker pool
 	for i := 0; i < m.concurrency; i++ {
 		m.wg.Add(1)
@@ -59,13 +303,90 @@ func (m *MemoryManager) Start(ctx context.Context) error {
 	log.Info().
 		Str("component", "jobs").
 		Int("workers", m.concurrency).
+		Int("queued", len(m.queue)).
 		Msg("Job manager started")
 
 	return nil
 }
 
+// syncStore queues the jobs persisted in the store that are not known yet,
+// regardless of the queue size and tenant quotas they were accepted under,
+// and drops the queued jobs no longer persisted, which another manager
+// sharing the store has started. The caller must hold m.mu.
+func (m *MemoryManager) syncStore(ctx context.Context) error {
+	if m.store == nil {
+		return nil
+	}
+	persisted, err := m.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load queued jobs: %w", err)
+	}
+
+	ids := make(map[string]bool, len(persisted))
+	for _, job := range persisted {
+		ids[job.ID] = true
+	}
+	queued := m.queue[:0]
+	dropped := 0
+	for _, job := range m.queue {
+		if ids[job.ID] {
+			queued = append(queued, job)
+			continue
+		}
+		delete(m.statuses, job.ID)
+		dropped++
+	}
+	m.queue = queued
+
+	recovered := 0
+	for _, job := range persisted {
+		if _, exists := m.statuses[job.ID]; exists {
+			continue
+		}
+		m.statuses[job.ID] = &Status{ID: job.ID, State: StatePending}
+		m.enqueue(job)
+		recovered++
+	}
+	if recovered > 0 || dropped > 0 {
+		log.Info().
+			Str("component", "jobs").
+			Int("jobs", recovered).
+			Int("dropped", dropped).
+			Msg("Synced job queue with store")
+	}
+	return nil
+}
+
+// syncLoop syncs the queue with the store every m.syncInterval until the
+// context is canceled.
+func (m *MemoryManager) syncLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		m.mu.Lock()
+		err := m.syncStore(ctx)
+		m.mu.Unlock()
+		if err != nil && ctx.Err() == nil {
+			log.Warn().
+				Str("component", "jobs").
+				Err(err).
+				Msg("Failed to sync job queue with store")
+		}
+	}
+}
+
 // Stop gracefully stops all workers and waits for in-flight jobs to complete.
-// It respects the context deadline for shutdown timeout.
+// It respects the context deadline for shutdown timeout. Jobs still queued
+// stay in the store, for the manager started next, and are no longer
+// tracked here.
 func (m *MemoryManager) Stop(ctx context.Context) error {
 	m.mu.Lock()
 	if !m.started {
@@ -78,6 +399,12 @@ func (m *MemoryManager) Stop(ctx context.Context) error {
 		m.cancelFunc()
 	}
 	m.started = false
+	if m.store != nil {
+		for _, job := range m.queue {
+			delete(m.statuses, job.ID)
+		}
+		m.queue = nil
+	}
 	m.mu.Unlock()
 
 	// Wait for workers to finish with timeout
@@ -111,22 +438,96 @@ func (m *MemoryManager) worker(ctx context.Context, id int) {
 		Msg("Worker started")
 
 	for {
-		select {
-		case <-ctx.Done():
+		if ctx.Err() != nil {
 			log.Debug().
 				Str("component", "jobs").
 				Int("worker_id", id).
 				Msg("Worker stopping")
 			return
-		case job := <-m.jobQueue:
-			// Process job (placeholder for MVP)
-			log.Debug().
+		}
+
+		job, ok, wake := m.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+			case <-wake:
+			}
+			continue
+		}
+
+		log.Debug().
+			Str("component", "jobs").
+			Int("worker_id", id).
+			Str("job_id", job.ID).
+			Str("job_type", job.Type).
+			Int("priority", job.Priority).
+			Msg("Processing job")
+		m.process(ctx, job)
+	}
+}
+
+// process runs the handler for a job and records its lifecycle.
+func (m *MemoryManager) process(ctx context.Context, job Job) {
+	// A running job is no longer queued; it is not run again after a restart
+	if m.store != nil {
+		if err := m.store.Delete(context.WithoutCancel(ctx), job.ID); err != nil {
+			log.Warn().
 				Str("component", "jobs").
-				Int("worker_id", id).
 				Str("job_id", job.ID).
-				Str("job_type", job.Type).
-				Msg("Processing job")
-			// Actual job processing logic would go here
+				Err(err).
+				Msg("Failed to remove started job from store")
 		}
 	}
+
+	m.mu.Lock()
+	h := m.handlers[job.Type]
+	st, ok := m.statuses[job.ID]
+	if !ok {
+		st = &Status{ID: job.ID}
+		m.statuses[job.ID] = st
+	}
+	st.State = StateRunning
+	st.StartedAt = time.Now().Unix()
+	m.mu.Unlock()
+
+	var err error
+	if h != nil {
+		err = runHandler(ctx, h, job)
+	}
+
+	m.mu.Lock()
+	st.EndedAt = time.Now().Unix()
+	if err != nil {
+		st.State = StateFailed
+		st.Error = err
+	} else {
+		st.State = StateCompleted
+		st.Progress = 100
+	}
+	if m.running[job.Tenant]--; m.running[job.Tenant] <= 0 {
+		delete(m.running, job.Tenant)
+	}
+	// The tenant may be below its limit again
+	m.notify()
+	m.mu.Unlock()
+
+	if err != nil {
+		log.Warn().
+			Str("component", "jobs").
+			Str("job_id", job.ID).
+			Str("job_type", job.Type).
+			Err(err).
+			Msg("Job failed")
+	}
+}
+
+// runHandler invokes h and converts a panic into an error so a single
+// misbehaving job cannot take down a worker.
+func runHandler(ctx context.Context, h Handler, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job %s panicked: %v", job.ID, r)
+		}
+	}()
+	return h(ctx, job)
 }
@@ -3,6 +3,11 @@ package jobs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -172,7 +177,7 @@ func TestMemoryManager_JobProcessing(t *testing.T) {
 		}
 
 		for _, job := range testJobs {
-			m.jobQueue <- job
+			require.NoError(t, m.Submit(ctx, job))
 		}
 
 		// Give workers time to process jobs
@@ -186,3 +191,391 @@ func TestMemoryManager_JobProcessing(t *testing.T) {
 		require.NoError(t, err, "Should stop after processing jobs")
 	})
 }
+
+func TestMemoryManager_SubmitAndStatus(t *testing.T) {
+	t.Run("handler runs and status completes", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		done := make(chan struct{})
+		m.Handle("scan", func(ctx context.Context, job Job) error {
+			require.Equal(t, "payload", job.Payload)
+			close(done)
+			return nil
+		})
+
+		require.NoError(t, m.Start(context.Background()))
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			_ = m.Stop(stopCtx)
+		}()
+
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-1", Type: "scan", Payload: "payload"}))
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("handler was not invoked")
+		}
+
+		require.Eventually(t, func() bool {
+			st, err := m.Status(context.Background(), "job-1")
+			return err == nil && st.State == StateCompleted
+		}, time.Second, 5*time.Millisecond)
+
+		st, err := m.Status(context.Background(), "job-1")
+		require.NoError(t, err)
+		require.Equal(t, 100, st.Progress)
+		require.NotZero(t, st.StartedAt)
+		require.NotZero(t, st.EndedAt)
+	})
+
+	t.Run("handler error marks job failed", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		m.Handle("scan", func(ctx context.Context, job Job) error {
+			return errors.New("boom")
+		})
+		require.NoError(t, m.Start(context.Background()))
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			_ = m.Stop(stopCtx)
+		}()
+
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-err", Type: "scan"}))
+		require.Eventually(t, func() bool {
+			st, err := m.Status(context.Background(), "job-err")
+			return err == nil && st.State == StateFailed
+		}, time.Second, 5*time.Millisecond)
+
+		st, _ := m.Status(context.Background(), "job-err")
+		require.EqualError(t, st.Error, "boom")
+	})
+
+	t.Run("handler panic marks job failed", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		m.Handle("scan", func(ctx context.Context, job Job) error {
+			panic("unexpected")
+		})
+		require.NoError(t, m.Start(context.Background()))
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+			_ = m.Stop(stopCtx)
+		}()
+
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-panic", Type: "scan"}))
+		require.Eventually(t, func() bool {
+			st, err := m.Status(context.Background(), "job-panic")
+			return err == nil && st.State == StateFailed
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("submit before start stays pending", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-1", Type: "scan"}))
+
+		st, err := m.Status(context.Background(), "job-1")
+		require.NoError(t, err)
+		require.Equal(t, StatePending, st.State)
+	})
+
+	t.Run("duplicate ID rejected", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-1"}))
+		err := m.Submit(context.Background(), Job{ID: "job-1"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already submitted")
+	})
+
+	t.Run("empty ID rejected", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		require.Error(t, m.Submit(context.Background(), Job{}))
+	})
+
+	t.Run("full queue returns ErrQueueFull", func(t *testing.T) {
+		m := NewMemoryManager(1, WithQueueSize(1))
+		require.NoError(t, m.Submit(context.Background(), Job{ID: "job-1"}))
+		err := m.Submit(context.Background(), Job{ID: "job-2"})
+		require.ErrorIs(t, err, ErrQueueFull)
+
+		_, err = m.Status(context.Background(), "job-2")
+		require.ErrorIs(t, err, ErrJobNotFound, "rejected job should not be tracked")
+	})
+
+	t.Run("unknown job returns ErrJobNotFound", func(t *testing.T) {
+		m := NewMemoryManager(1)
+		_, err := m.Status(context.Background(), "missing")
+		require.ErrorIs(t, err, ErrJobNotFound)
+	})
+}
+
+// memStore is a Store keeping jobs in memory.
+type memStore struct {
+	mu   sync.Mutex
+	jobs []Job
+}
+
+func (s *memStore) Save(ctx context.Context, job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+func (s *memStore) Delete(ctx context.Context, jobID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = slices.DeleteFunc(s.jobs, func(job Job) bool { return job.ID == jobID })
+	return nil
+}
+
+func (s *memStore) Load(ctx context.Context) ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.jobs), nil
+}
+
+func (s *memStore) ids() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, job := range s.jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+func TestMemoryManager_Priority(t *testing.T) {
+	m := NewMemoryManager(1)
+	var (
+		mu  sync.Mutex
+		ran []string
+	)
+	m.Handle("scan", func(ctx context.Context, job Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, job.ID)
+		return nil
+	})
+
+	ctx := context.Background()
+	require.NoError(t, m.Submit(ctx, Job{ID: "low", Type: "scan", Priority: PriorityLow}))
+	require.NoError(t, m.Submit(ctx, Job{ID: "normal-1", Type: "scan"}))
+	require.NoError(t, m.Submit(ctx, Job{ID: "high", Type: "scan", Priority: PriorityHigh}))
+	require.NoError(t, m.Submit(ctx, Job{ID: "normal-2", Type: "scan"}))
+
+	require.NoError(t, m.Start(ctx))
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = m.Stop(stopCtx)
+	}()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ran) == 4
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, []string{"high", "normal-1", "normal-2", "low"}, ran)
+}
+
+func TestMemoryManager_TenantLimits(t *testing.T) {
+	t.Run("concurrency", func(t *testing.T) {
+		m := NewMemoryManager(2, WithTenantLimits(1, 0))
+		release := make(chan struct{})
+		started := make(chan string, 3)
+		m.Handle("scan", func(ctx context.Context, job Job) error {
+			started <- job.ID
+			<-release
+			return nil
+		})
+
+		ctx := context.Background()
+		require.NoError(t, m.Submit(ctx, Job{ID: "a-1", Type: "scan", Tenant: "team-a"}))
+		require.NoError(t, m.Submit(ctx, Job{ID: "a-2", Type: "scan", Tenant: "team-a"}))
+		require.NoError(t, m.Submit(ctx, Job{ID: "b-1", Type: "scan", Tenant: "team-b"}))
+		require.NoError(t, m.Start(ctx))
+		defer func() {
+			stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_ = m.Stop(stopCtx)
+		}()
+
+		// The second worker skips a-2 while a-1 runs
+		got := []string{<-started, <-started}
+		require.ElementsMatch(t, []string{"a-1", "b-1"}, got)
+		st, err := m.Status(ctx, "a-2")
+		require.NoError(t, err)
+		require.Equal(t, StatePending, st.State)
+
+		close(release)
+		select {
+		case id := <-started:
+			require.Equal(t, "a-2", id)
+		case <-time.After(time.Second):
+			t.Fatal("queued job of the tenant did not run")
+		}
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		m := NewMemoryManager(1, WithTenantLimits(0, 2))
+		ctx := context.Background()
+		require.NoError(t, m.Submit(ctx, Job{ID: "a-1", Tenant: "team-a"}))
+		require.NoError(t, m.Submit(ctx, Job{ID: "a-2", Tenant: "team-a"}))
+		require.ErrorIs(t, m.Submit(ctx, Job{ID: "a-3", Tenant: "team-a"}), ErrTenantQuota)
+		require.NoError(t, m.Submit(ctx, Job{ID: "b-1", Tenant: "team-b"}))
+
+		_, err := m.Status(ctx, "a-3")
+		require.ErrorIs(t, err, ErrJobNotFound, "rejected job should not be tracked")
+	})
+}
+
+func TestMemoryManager_Store(t *testing.T) {
+	store := &memStore{}
+	ctx := context.Background()
+
+	// Jobs queued when the server stops stay in the store
+	m := NewMemoryManager(1, WithStore(store))
+	require.NoError(t, m.Submit(ctx, Job{ID: "job-1", Type: "scan", Payload: "one"}))
+	require.NoError(t, m.Submit(ctx, Job{ID: "job-2", Type: "scan", Payload: "two", Priority: PriorityHigh}))
+	require.Equal(t, []string{"job-1", "job-2"}, store.ids())
+
+	// and run after a restart, by priority
+	restarted := NewMemoryManager(1, WithStore(store))
+	ran := make(chan Job, 2)
+	restarted.Handle("scan", func(ctx context.Context, job Job) error {
+		ran <- job
+		return nil
+	})
+	require.NoError(t, restarted.Start(ctx))
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = restarted.Stop(stopCtx)
+	}()
+
+	for _, want := range []string{"two", "one"} {
+		select {
+		case job := <-ran:
+			require.Equal(t, want, job.Payload)
+		case <-time.After(time.Second):
+			t.Fatal("recovered job did not run")
+		}
+	}
+	require.Eventually(t, func() bool {
+		st, err := restarted.Status(ctx, "job-1")
+		return err == nil && st.State == StateCompleted
+	}, time.Second, 5*time.Millisecond)
+	require.Empty(t, store.ids(), "started jobs are removed from the store")
+}
+
+func TestMemoryManager_StoreSync(t *testing.T) {
+	store := &memStore{}
+	ctx := context.Background()
+
+	// A manager that is not started queues jobs in the shared store
+	follower := NewMemoryManager(1, WithStore(store), WithStoreSync(10*time.Millisecond))
+	var followerRan atomic.Int32
+	follower.Handle("scan", func(ctx context.Context, job Job) error {
+		followerRan.Add(1)
+		return nil
+	})
+	require.NoError(t, follower.Submit(ctx, Job{ID: "job-1", Type: "scan"}))
+
+	// and the running one picks them up
+	leader := NewMemoryManager(1, WithStore(store), WithStoreSync(10*time.Millisecond))
+	ran := make(chan string, 2)
+	leader.Handle("scan", func(ctx context.Context, job Job) error {
+		ran <- job.ID
+		return nil
+	})
+	require.NoError(t, leader.Start(ctx))
+	require.NoError(t, follower.Submit(ctx, Job{ID: "job-2", Type: "scan"}))
+	for _, want := range []string{"job-1", "job-2"} {
+		select {
+		case id := <-ran:
+			require.Equal(t, want, id)
+		case <-time.After(time.Second):
+			t.Fatal("job submitted through another manager did not run")
+		}
+	}
+	stopCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, leader.Stop(stopCtx))
+
+	// Taking over, the other manager drops the jobs already started
+	require.NoError(t, follower.Start(ctx))
+	defer func() { _ = follower.Stop(stopCtx) }()
+	_, err := follower.Status(ctx, "job-1")
+	require.ErrorIs(t, err, ErrJobNotFound)
+	time.Sleep(30 * time.Millisecond)
+	require.Zero(t, followerRan.Load())
+}
+
+func TestMemoryManager_FollowerSubmit(t *testing.T) {
+	store := &memStore{}
+	ctx := context.Background()
+
+	leader := NewMemoryManager(1, WithStore(store), WithStoreSync(5*time.Millisecond))
+	ran := make(chan string, 10)
+	leader.Handle("scan", func(ctx context.Context, job Job) error {
+		ran <- job.ID
+		return nil
+	})
+	require.NoError(t, leader.Start(ctx))
+	defer func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = leader.Stop(stopCtx)
+	}()
+
+	// The follower is never started; jobs the leader ran free their slots
+	follower := NewMemoryManager(1, WithStore(store), WithQueueSize(2), WithTenantLimits(0, 1))
+	for i := range 5 {
+		id := fmt.Sprintf("job-%d", i)
+		require.NoError(t, follower.Submit(ctx, Job{ID: id, Type: "scan", Tenant: "team-a"}))
+		select {
+		case got := <-ran:
+			require.Equal(t, id, got)
+		case <-time.After(time.Second):
+			t.Fatal("job submitted on the follower did not run")
+		}
+		require.Eventually(t, func() bool {
+			_, err := follower.Status(ctx, id)
+			return errors.Is(err, ErrJobNotFound)
+		}, time.Second, 5*time.Millisecond, "the follower does not track jobs the leader ran")
+	}
+}
+
+func TestMemoryManager_FollowerLimits(t *testing.T) {
+	store := &memStore{}
+	ctx := context.Background()
+	follower := NewMemoryManager(1, WithStore(store), WithQueueSize(2), WithTenantLimits(0, 1))
+
+	// Limits are checked against the jobs queued in the store
+	require.NoError(t, follower.Submit(ctx, Job{ID: "a-1", Tenant: "team-a"}))
+	require.ErrorIs(t, follower.Submit(ctx, Job{ID: "a-2", Tenant: "team-a"}), ErrTenantQuota)
+	require.ErrorContains(t, follower.Submit(ctx, Job{ID: "a-1", Tenant: "team-b"}), "already submitted")
+	require.NoError(t, store.Save(ctx, Job{ID: "c-1", Tenant: "team-c"}))
+	require.ErrorIs(t, follower.Submit(ctx, Job{ID: "b-1", Tenant: "team-b"}), ErrQueueFull)
+
+	st, err := follower.Status(ctx, "c-1")
+	require.NoError(t, err)
+	require.Equal(t, StatePending, st.State)
+
+	// Started jobs leave the store and free the queue
+	require.NoError(t, store.Delete(ctx, "a-1"))
+	require.NoError(t, follower.Submit(ctx, Job{ID: "b-1", Tenant: "team-b"}))
+	_, err = follower.Status(ctx, "a-1")
+	require.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestParsePriority(t *testing.T) {
+	for name, want := range map[string]int{"": PriorityNormal, "normal": PriorityNormal, "High": PriorityHigh, "low": PriorityLow} {
+		got, err := ParsePriority(name)
+		require.NoError(t, err)
+		require.Equal(t, want, got, name)
+	}
+	_, err := ParsePriority("urgent")
+	require.ErrorContains(t, err, `unknown priority "urgent"`)
+}
@@ -0,0 +1,167 @@
+// pkg/server/leader/elector.go
+// Package leader elects the active replica among server replicas sharing a
+// storage backend. Replicas campaign for a lease in the backend; the holder
+// renews it while it runs and the others take it over once it lapses, e.g.
+// because the holder died.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// DefaultTTL is how long a lease lasts without being renewed by default.
+const DefaultTTL = 15 * time.Second
+
+// LeaseName is the lease the server replicas campaign for.
+const LeaseName = "server-leader"
+
+// Elector campaigns for a lease on behalf of one replica.
+type Elector struct {
+	leases storage.LeaseStore
+	name   string
+	id     string
+	ttl    time.Duration
+
+	leading atomic.Bool
+}
+
+// New returns an elector campaigning for the lease name as id. Leases last
+// ttl and are renewed every third of it; ttl <= 0 uses DefaultTTL.
+func New(leases storage.LeaseStore, name, id string, ttl time.Duration) *Elector {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Elector{leases: leases, name: name, id: id, ttl: ttl}
+}
+
+// DefaultID returns an ID telling the replicas apart: the host name and
+// process ID.
+func DefaultID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "vulntor"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// ID returns the ID the elector campaigns as.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// IsLeader reports whether the elector holds the lease.
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Run campaigns for the lease until ctx is canceled. While the elector
+// holds it, lead runs with a context canceled when the lease is lost; Run
+// waits for lead to return before campaigning again, and gives the lease up
+// if lead returns on its own. The lease is released when Run returns.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	interval := e.ttl / 3
+
+	var (
+		cancel  context.CancelFunc
+		done    chan struct{}
+		expires time.Time
+	)
+	stepDown := func(reason string) {
+		if cancel == nil {
+			return
+		}
+		e.leading.Store(false)
+		cancel()
+		<-done
+		cancel, done = nil, nil
+		log.Info().
+			Str("component", "leader").
+			Str("id", e.id).
+			Str("reason", reason).
+			Msg("Stepped down as leader")
+	}
+
+	for {
+		l, err := e.leases.Acquire(ctx, e.name, e.id, e.ttl)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				break
+			}
+			log.Warn().
+				Str("component", "leader").
+				Str("id", e.id).
+				Err(err).
+				Msg("Failed to renew leader lease")
+			// Another replica may take the lease once it lapses
+			if cancel != nil && !time.Now().Add(interval).Before(expires) {
+				stepDown("lease could not be renewed")
+			}
+		case l.Holder == e.id:
+			expires = l.ExpiresAt
+			if cancel == nil {
+				cancel, done = e.lead(ctx, lead)
+			}
+		default:
+			stepDown("lease taken over by " + l.Holder)
+			log.Debug().
+				Str("component", "leader").
+				Str("id", e.id).
+				Str("leader", l.Holder).
+				Msg("Following leader")
+		}
+
+		select {
+		case <-ctx.Done():
+			stepDown("shutting down")
+			e.release()
+			return
+		case <-done:
+			// lead gave up; let another replica take over
+			stepDown("leader stopped")
+			e.release()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// lead marks the elector as leader and runs lead in the background with a
+// context derived from ctx. It returns the function canceling the context
+// and a channel closed when lead returns.
+func (e *Elector) lead(ctx context.Context, lead func(ctx context.Context)) (context.CancelFunc, chan struct{}) {
+	e.leading.Store(true)
+	log.Info().
+		Str("component", "leader").
+		Str("id", e.id).
+		Msg("Elected leader")
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leadCtx)
+	}()
+	return cancel, done
+}
+
+// release gives up the lease, so another replica takes over without waiting
+// for it to lapse.
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl)
+	defer cancel()
+	if err := e.leases.Release(ctx, e.name, e.id); err != nil {
+		log.Warn().
+			Str("component", "leader").
+			Str("id", e.id).
+			Err(err).
+			Msg("Failed to release leader lease")
+	}
+}
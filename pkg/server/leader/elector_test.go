@@ -0,0 +1,130 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func newLeases(t *testing.T) storage.LeaseStore {
+	t.Helper()
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	return backend.Leases()
+}
+
+// campaign runs e until the returned stop function is called; leading
+// receives true when e starts leading and false when it stops.
+func campaign(e *Elector) (leading chan bool, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	leading = make(chan bool, 4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx, func(ctx context.Context) {
+			leading <- true
+			<-ctx.Done()
+			leading <- false
+		})
+	}()
+	return leading, func() {
+		cancel()
+		<-done
+	}
+}
+
+func receive(t *testing.T, ch chan bool) bool {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(2 * time.Second):
+		t.Fatal("no leadership change")
+		return false
+	}
+}
+
+func TestElector_Failover(t *testing.T) {
+	leases := newLeases(t)
+	a := New(leases, LeaseName, "node-a", 150*time.Millisecond)
+	b := New(leases, LeaseName, "node-b", 150*time.Millisecond)
+
+	aLeading, stopA := campaign(a)
+	require.True(t, receive(t, aLeading))
+	require.True(t, a.IsLeader())
+
+	// A single leader while the lease is renewed
+	bLeading, stopB := campaign(b)
+	defer stopB()
+	time.Sleep(300 * time.Millisecond)
+	require.Empty(t, bLeading)
+	require.False(t, b.IsLeader())
+
+	// The leader stops leading before giving the lease up on shutdown
+	stopA()
+	require.False(t, receive(t, aLeading))
+	require.False(t, a.IsLeader())
+	require.True(t, receive(t, bLeading))
+	require.True(t, b.IsLeader())
+	l, err := leases.Get(context.Background(), LeaseName)
+	require.NoError(t, err)
+	require.Equal(t, "node-b", l.Holder)
+}
+
+func TestElector_LeaseLost(t *testing.T) {
+	leases := newLeases(t)
+	a := New(leases, LeaseName, "node-a", 150*time.Millisecond)
+	aLeading, stopA := campaign(a)
+	defer stopA()
+	require.True(t, receive(t, aLeading))
+
+	// Another replica took the lease, e.g. after this one stalled
+	ctx := context.Background()
+	require.NoError(t, leases.Release(ctx, LeaseName, "node-a"))
+	_, err := leases.Acquire(ctx, LeaseName, "node-b", time.Minute)
+	require.NoError(t, err)
+
+	require.False(t, receive(t, aLeading))
+	require.False(t, a.IsLeader())
+}
+
+func TestElector_LeadReturns(t *testing.T) {
+	leases := newLeases(t)
+	a := New(leases, LeaseName, "node-a", 150*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan struct{}, 4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.Run(ctx, func(context.Context) {
+			select {
+			case runs <- struct{}{}:
+			default:
+			}
+		})
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// A leader that stops leading gives the lease up and campaigns again
+	for range 2 {
+		select {
+		case <-runs:
+		case <-time.After(2 * time.Second):
+			t.Fatal("not elected again")
+		}
+	}
+}
+
+func TestDefaultID(t *testing.T) {
+	require.NotEmpty(t, DefaultID())
+	require.Equal(t, "node-a", New(nil, LeaseName, "node-a", 0).ID())
+	require.Equal(t, DefaultTTL, New(nil, LeaseName, "node-a", 0).ttl)
+}
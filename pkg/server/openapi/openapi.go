@@ -0,0 +1,286 @@
+// Package openapi generates the OpenAPI 3 document of the server API from
+// route descriptions and the Go types of their request and response bodies.
+//
+// Schemas are derived from the types by reflection, following encoding/json:
+// JSON field names come from the json tags, fields without omitempty are
+// required, and embedded structs are flattened. Named struct types become
+// shared schemas under components/schemas.
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.0.3"
+
+// Route describes an API endpoint.
+type Route struct {
+	// Pattern is the route pattern as mounted on the router, e.g.
+	// "GET /api/v1/scans/{id}".
+	Pattern string
+
+	// Tag groups the endpoint in the document, e.g. "scans".
+	Tag string
+
+	Summary     string
+	Description string
+
+	// Scope is the API key scope the endpoint requires; empty if any
+	// authenticated caller may use it.
+	Scope string
+
+	// Params are the path and query parameters. Path parameters of the
+	// pattern without a description are added automatically.
+	Params []Param
+
+	// Request is a value of the request body type; nil if the endpoint
+	// takes no body.
+	Request interface{}
+
+	// Response is a value of the success response body type; nil if the
+	// body is not JSON (see ContentType) or empty.
+	Response interface{}
+
+	// Status is the success status code (default 200).
+	Status int
+
+	// ContentType is the media type of non-JSON success responses, such as
+	// "text/event-stream".
+	ContentType string
+
+	// Errors lists the error status codes the endpoint returns besides the
+	// authentication errors every endpoint returns.
+	Errors []int
+}
+
+// Param is a path or query parameter.
+type Param struct {
+	Name        string
+	In          string // "path" or "query"
+	Description string
+
+	// Type is the JSON schema type: "string" (default), "integer" or
+	// "boolean".
+	Type     string
+	Required bool
+}
+
+// Info is the metadata of a document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Document is an OpenAPI 3 document, limited to the parts the server uses.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Tags       []Tag                           `json:"tags,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+	Security   []map[string][]string           `json:"security,omitempty"`
+}
+
+// Tag names a group of operations.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Operation is an operation on a path.
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is an operation parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of an operation's requests.
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the shared schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is an authentication method.
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Generate returns the document of routes. Every route must have a
+// distinct pattern. Errors are described by the errorBody type, the body of
+// every error response.
+func Generate(info Info, routes []Route, errorBody interface{}) *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearer": {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "Static token, API key or OIDC ID token, depending on the server's auth mode",
+				},
+			},
+		},
+		Security: []map[string][]string{{"bearer": {}}},
+	}
+	errorSchema := g.schemaOf(errorBody)
+
+	tags := map[string]bool{}
+	for _, r := range routes {
+		method, path, _ := strings.Cut(r.Pattern, " ")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][strings.ToLower(method)] = g.operation(r, method, path, errorSchema)
+		if r.Tag != "" && !tags[r.Tag] {
+			tags[r.Tag] = true
+			doc.Tags = append(doc.Tags, Tag{Name: r.Tag})
+		}
+	}
+	doc.Components.Schemas = g.schemas
+	return doc
+}
+
+// operation returns the operation of route r.
+func (g *generator) operation(r Route, method, path string, errorSchema *Schema) Operation {
+	op := Operation{
+		OperationID: operationID(method, path),
+		Summary:     r.Summary,
+		Description: r.Description,
+		Responses:   map[string]Response{},
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if r.Scope != "" {
+		desc := "Requires the `" + r.Scope + "` scope with API keys."
+		if op.Description != "" {
+			desc = op.Description + "\n\n" + desc
+		}
+		op.Description = desc
+	}
+
+	// Path parameters come first, in pattern order
+	documented := map[string]Param{}
+	for _, p := range r.Params {
+		documented[p.In+":"+p.Name] = p
+	}
+	for _, name := range pathParams(path) {
+		p, ok := documented["path:"+name]
+		if !ok {
+			p = Param{Name: name, In: "path"}
+		}
+		op.Parameters = append(op.Parameters, parameter(p))
+	}
+	for _, p := range r.Params {
+		if p.In != "path" {
+			op.Parameters = append(op.Parameters, parameter(p))
+		}
+	}
+
+	if r.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: g.schemaOf(r.Request)}},
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	switch {
+	case r.Response != nil:
+		success.Content = map[string]MediaType{"application/json": {Schema: g.schemaOf(r.Response)}}
+	case r.ContentType != "":
+		success.Content = map[string]MediaType{r.ContentType: {Schema: &Schema{Type: "string"}}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	errorContent := map[string]MediaType{"application/json": {Schema: errorSchema}}
+	for _, code := range append([]int{http.StatusUnauthorized, http.StatusForbidden}, r.Errors...) {
+		op.Responses[strconv.Itoa(code)] = Response{Description: http.StatusText(code), Content: errorContent}
+	}
+	return op
+}
+
+// parameter returns the document form of p.
+func parameter(p Param) Parameter {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	return Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required || p.In == "path",
+		Schema:      &Schema{Type: typ},
+	}
+}
+
+// pathParams returns the names of the wildcards of path.
+func pathParams(path string) []string {
+	var names []string
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			names = append(names, strings.TrimSuffix(strings.Trim(seg, "{}"), "..."))
+		}
+	}
+	return names
+}
+
+// operationID derives an operation ID from the method and path, e.g.
+// "getScansIdFindings" for GET /api/v1/scans/{id}/findings.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.Split(path, "/") {
+		seg = strings.Trim(seg, "{}.")
+		if seg == "" || seg == "api" || seg == "v1" {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(seg, func(r rune) bool { return r == '-' || r == '_' }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
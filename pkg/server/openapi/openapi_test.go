@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	IP string `json:"ip"`
+}
+
+type testItem struct {
+	testBase
+
+	Name     string            `json:"name"`
+	Tags     []string          `json:"tags,omitempty"`
+	Seen     time.Time         `json:"seen"`
+	Parent   *testItem         `json:"parent"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Data     interface{}       `json:"data,omitempty"`
+	Internal string            `json:"-"`
+	hidden   string
+}
+
+type testList struct {
+	Items []testItem `json:"items"`
+	Total int64      `json:"total"`
+}
+
+type testError struct {
+	Error string `json:"error"`
+}
+
+func TestGenerate(t *testing.T) {
+	doc := Generate(Info{Title: "Test", Version: "1.0.0"}, []Route{
+		{
+			Pattern: "GET /api/v1/items/{id}/children",
+			Tag:     "items",
+			Summary: "List children",
+			Scope:   "read",
+			Params: []Param{
+				{Name: "limit", In: "query", Type: "integer"},
+			},
+			Response: testList{},
+			Errors:   []int{http.StatusNotFound},
+		},
+		{
+			Pattern:  "POST /api/v1/items",
+			Tag:      "items",
+			Request:  testItem{},
+			Response: testItem{},
+			Status:   http.StatusCreated,
+		},
+		{
+			Pattern:     "GET /api/v1/items/{id}/events",
+			ContentType: "text/event-stream",
+		},
+	}, testError{})
+
+	require.Equal(t, "3.0.3", doc.OpenAPI)
+	require.Equal(t, []Tag{{Name: "items"}}, doc.Tags)
+
+	list := doc.Paths["/api/v1/items/{id}/children"]["get"]
+	require.Equal(t, "getItemsIdChildren", list.OperationID)
+	require.Equal(t, "Requires the `read` scope with API keys.", list.Description)
+	require.Equal(t, []Parameter{
+		{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string"}},
+		{Name: "limit", In: "query", Schema: &Schema{Type: "integer"}},
+	}, list.Parameters)
+	require.Equal(t, "#/components/schemas/testList", list.Responses["200"].Content["application/json"].Schema.Ref)
+	require.Equal(t, "#/components/schemas/testError", list.Responses["404"].Content["application/json"].Schema.Ref)
+	require.Contains(t, list.Responses, "401")
+	require.Contains(t, list.Responses, "403")
+
+	create := doc.Paths["/api/v1/items"]["post"]
+	require.NotNil(t, create.RequestBody)
+	require.Equal(t, "#/components/schemas/testItem", create.RequestBody.Content["application/json"].Schema.Ref)
+	require.Contains(t, create.Responses, "201")
+
+	events := doc.Paths["/api/v1/items/{id}/events"]["get"]
+	require.Equal(t, &Schema{Type: "string"}, events.Responses["200"].Content["text/event-stream"].Schema)
+
+	// Embedded structs are flattened; omitempty and pointer fields are optional
+	item := doc.Components.Schemas["testItem"]
+	require.Equal(t, []string{"ip", "name", "seen"}, item.Required)
+	require.ElementsMatch(t, []string{"ip", "name", "tags", "seen", "parent", "labels", "data"}, keys(item.Properties))
+	require.Equal(t, &Schema{Type: "string", Format: "date-time"}, item.Properties["seen"])
+	require.Equal(t, "#/components/schemas/testItem", item.Properties["parent"].Ref)
+	require.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, item.Properties["labels"])
+	require.Equal(t, &Schema{}, item.Properties["data"])
+	require.Equal(t, &Schema{Type: "integer", Format: "int64"}, doc.Components.Schemas["testList"].Properties["total"])
+
+	_, err := json.Marshal(doc)
+	require.NoError(t, err)
+}
+
+func keys(m map[string]*Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+// Package severity orders the severities of findings, as plugins report
+// them, for the packages that filter, sort and gate findings by severity.
+package severity
+
+import "slices"
+
+// Levels lists finding severities from most to least severe.
+var Levels = []string{"critical", "high", "medium", "low", "info"}
+
+// Rank orders the severities of Levels from 1 (info) to 5 (critical).
+// Unknown severities rank 0, lowest; callers lower-case severities first.
+func Rank(s string) int {
+	if i := slices.Index(Levels, s); i >= 0 {
+		return len(Levels) - i
+	}
+	return 0
+}
@@ -0,0 +1,15 @@
+package severity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRank(t *testing.T) {
+	require.Equal(t, 5, Rank("critical"))
+	require.Equal(t, 1, Rank("info"))
+	require.Greater(t, Rank("high"), Rank("medium"))
+	require.Zero(t, Rank("informational"))
+	require.Zero(t, Rank("Critical"), "callers lower-case severities")
+}
@@ -0,0 +1,56 @@
+// Package sla computes the remediation due dates of findings. Each
+// severity may have a deadline, counted from when a finding was first
+// seen; findings of severities without one are never due.
+//
+// Due dates are computed once, when a finding is first seen, so changing
+// the deadlines does not move the due dates of known findings.
+//
+// A nil *SLA sets no due dates, so code paths without configured
+// deadlines track nothing.
+package sla
+
+import (
+	"strings"
+	"time"
+)
+
+// SLA holds the remediation deadline of each severity.
+type SLA struct {
+	deadlines map[string]time.Duration // by lowercase severity
+}
+
+// New returns an SLA with the given deadlines, keyed by severity. Deadlines
+// that are not positive are dropped. Returns nil when none remains.
+func New(deadlines map[string]time.Duration) *SLA {
+	s := &SLA{deadlines: make(map[string]time.Duration)}
+	for severity, d := range deadlines {
+		if d > 0 {
+			s.deadlines[strings.ToLower(severity)] = d
+		}
+	}
+	if len(s.deadlines) == 0 {
+		return nil
+	}
+	return s
+}
+
+// Deadline returns the deadline of severity, or false when findings of
+// severity are never due.
+func (s *SLA) Deadline(severity string) (time.Duration, bool) {
+	if s == nil {
+		return 0, false
+	}
+	d, ok := s.deadlines[strings.ToLower(severity)]
+	return d, ok
+}
+
+// Due returns when a finding of severity first seen at firstSeen must be
+// remediated, or nil when it is never due.
+func (s *SLA) Due(severity string, firstSeen time.Time) *time.Time {
+	d, ok := s.Deadline(severity)
+	if !ok {
+		return nil
+	}
+	due := firstSeen.Add(d)
+	return &due
+}
@@ -0,0 +1,27 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLA(t *testing.T) {
+	require.Nil(t, New(nil))
+	require.Nil(t, New(map[string]time.Duration{"critical": 0}))
+
+	var none *SLA
+	require.Nil(t, none.Due("critical", time.Now()))
+
+	s := New(map[string]time.Duration{"Critical": 7 * 24 * time.Hour, "high": 30 * 24 * time.Hour, "low": 0})
+	require.NotNil(t, s)
+
+	seen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), *s.Due("critical", seen))
+	require.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), *s.Due("HIGH", seen))
+
+	// Severities without a deadline are never due
+	require.Nil(t, s.Due("low", seen))
+	require.Nil(t, s.Due("medium", seen))
+}
@@ -0,0 +1,394 @@
+package smb
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+// Options configure connections to a server.
+type Options struct {
+	// Timeout bounds each request; DefaultTimeout when zero.
+	Timeout time.Duration
+	// Dial opens TCP connections; a net.Dialer when nil.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (o Options) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (o Options) dial(ctx context.Context, addr string) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, o.timeout())
+	defer cancel()
+	if o.Dial != nil {
+		return o.Dial(ctx, "tcp", addr)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// Tree is a connected share.
+type Tree struct {
+	ID            uint32
+	Type          uint8 // 1 disk, 2 named pipe, 3 printer
+	MaximalAccess uint32
+}
+
+// Client is a connection to an SMB 2 or 3 server, with at most one
+// session. It is not safe for concurrent use.
+type Client struct {
+	conn net.Conn
+	host string
+	opts Options
+	info NegotiateInfo
+
+	messageID    uint64
+	sessionID    uint64
+	sessionFlags uint16
+	callID       uint32
+}
+
+// Dial connects to addr and negotiates the highest of dialects the
+// server supports; all of Dialects when none are given.
+func Dial(ctx context.Context, addr string, dialects []Dialect, opts Options) (*Client, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(dialects) == 0 {
+		dialects = Dialects
+	}
+
+	conn, err := opts.dial(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, host: host, opts: opts}
+
+	var clientGUID [16]byte
+	_, _ = rand.Read(clientGUID[:])
+	_, msg, err := c.call(ctx, cmdNegotiate, 0, negotiateRequest(dialects, clientGUID))
+	if err == nil {
+		c.info, err = parseNegotiateResponse(msg)
+	}
+	if err == nil && !slices.Contains(dialects, c.info.Dialect) {
+		err = fmt.Errorf("smb: server chose dialect %s, which was not offered", c.info.Dialect)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Info returns what the server announced during negotiation.
+func (c *Client) Info() NegotiateInfo {
+	return c.info
+}
+
+// Guest reports whether the server mapped the session to its guest
+// account.
+func (c *Client) Guest() bool {
+	return c.sessionFlags&sessionFlagGuest != 0
+}
+
+// LoginAnonymous sets up an anonymous (null) session with NTLM. The
+// server's challenge is returned even when the login is refused; it
+// names the server and its operating system version.
+func (c *Client) LoginAnonymous(ctx context.Context) (*ntlm.Challenge, error) {
+	token := negTokenInit(ntlm.NegotiateMessage())
+	h, msg, err := c.call(ctx, cmdSessionSetup, 0, sessionSetupRequest(token), StatusMoreProcessingRequired)
+	if err != nil {
+		return nil, err
+	}
+	_, blob, err := parseSessionSetupResponse(msg)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := ntlm.ParseChallenge(ntlmMessage(blob))
+	if err != nil {
+		return nil, err
+	}
+
+	c.sessionID = h.sessionID
+	token = negTokenResp(ntlm.AnonymousAuthenticateMessage(challenge.Flags))
+	_, msg, err = c.call(ctx, cmdSessionSetup, 0, sessionSetupRequest(token))
+	if err != nil {
+		c.sessionID = 0
+		return challenge, err
+	}
+	c.sessionFlags, _, err = parseSessionSetupResponse(msg)
+	return challenge, err
+}
+
+// TreeConnect connects to share, e.g. "IPC$".
+func (c *Client) TreeConnect(ctx context.Context, share string) (Tree, error) {
+	h, msg, err := c.call(ctx, cmdTreeConnect, 0, treeConnectRequest(`\\`+c.host+`\`+share))
+	if err != nil {
+		return Tree{}, err
+	}
+	b, err := body(msg, 16)
+	if err != nil {
+		return Tree{}, err
+	}
+	return Tree{ID: h.treeID, Type: b[2], MaximalAccess: binary.LittleEndian.Uint32(b[12:])}, nil
+}
+
+// TreeDisconnect disconnects from a share.
+func (c *Client) TreeDisconnect(ctx context.Context, tree Tree) error {
+	_, _, err := c.call(ctx, cmdTreeDisconnect, tree.ID, emptyRequest())
+	return err
+}
+
+// ListShares lists the server's shares with NetrShareEnum over the
+// srvsvc named pipe. It needs a session.
+func (c *Client) ListShares(ctx context.Context) ([]Share, error) {
+	ipc, err := c.TreeConnect(ctx, "IPC$")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.TreeDisconnect(ctx, ipc) }()
+
+	_, msg, err := c.call(ctx, cmdCreate, ipc.ID, createRequest("srvsvc"))
+	if err != nil {
+		return nil, err
+	}
+	fileID, err := parseCreateResponse(msg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _, _, _ = c.call(ctx, cmdClose, ipc.ID, closeRequest(fileID)) }()
+
+	p := &pipe{c: c, treeID: ipc.ID, fileID: fileID}
+	c.callID++
+	if err := p.write(ctx, bindRequest(c.callID)); err != nil {
+		return nil, err
+	}
+	ack, err := p.readFragment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBindAck(ack); err != nil {
+		return nil, err
+	}
+
+	c.callID++
+	stub, err := p.call(ctx, requestPDU(c.callID, opNetrShareEnum, netrShareEnumRequest(`\\`+c.host)))
+	if err != nil {
+		return nil, err
+	}
+	return parseNetrShareEnumResponse(stub)
+}
+
+// call sends a request and waits for its response. A status other than
+// success or one of accept is returned as the error, with the response.
+func (c *Client) call(ctx context.Context, command uint16, treeID uint32, req []byte, accept ...Status) (header, []byte, error) {
+	h := header{command: command, credits: 64, messageID: c.messageID, treeID: treeID, sessionID: c.sessionID}
+	if c.info.Dialect > Dialect202 {
+		h.creditCharge = 1
+	}
+	c.messageID++
+
+	// The context cuts the deadline short when it ends first
+	_ = c.conn.SetDeadline(time.Now().Add(c.opts.timeout()))
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if err := writeMessage(c.conn, h.marshal(req)); err != nil {
+		return header{}, nil, ctxErr(ctx, err)
+	}
+	for {
+		msg, err := readMessage(c.conn)
+		if err != nil {
+			return header{}, nil, ctxErr(ctx, err)
+		}
+		resp, err := parseHeader(msg)
+		if err != nil {
+			return header{}, nil, err
+		}
+		// Skip oplock breaks and interim responses
+		if resp.messageID != h.messageID || resp.flags&flagResponse == 0 {
+			continue
+		}
+		if resp.flags&flagAsync != 0 && resp.status == StatusPending {
+			continue
+		}
+		if resp.status != StatusSuccess && !slices.Contains(accept, resp.status) {
+			return resp, msg, resp.status
+		}
+		return resp, msg, nil
+	}
+}
+
+// readSize is the length of each pipe read.
+func (c *Client) readSize() uint32 {
+	if c.info.MaxReadSize == 0 || c.info.MaxReadSize > 65536 {
+		return 65536
+	}
+	return c.info.MaxReadSize
+}
+
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// pipe carries DCE/RPC fragments over an open named pipe.
+type pipe struct {
+	c       *Client
+	treeID  uint32
+	fileID  [16]byte
+	pending []byte
+}
+
+func (p *pipe) write(ctx context.Context, data []byte) error {
+	_, _, err := p.c.call(ctx, cmdWrite, p.treeID, writeRequest(p.fileID, data))
+	return err
+}
+
+// readFragment returns the next complete RPC fragment from the pipe.
+func (p *pipe) readFragment(ctx context.Context) ([]byte, error) {
+	for len(p.pending) < rpcHeaderSize || len(p.pending) < fragLength(p.pending) {
+		// A message larger than the read comes with STATUS_BUFFER_OVERFLOW
+		_, msg, err := p.c.call(ctx, cmdRead, p.treeID, readRequest(p.fileID, p.c.readSize()), StatusBufferOverflow)
+		if err != nil {
+			return nil, err
+		}
+		data, err := parseReadResponse(msg)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) == 0 {
+			return nil, errors.New("smb: empty read from pipe")
+		}
+		p.pending = append(p.pending, data...)
+	}
+
+	n := fragLength(p.pending)
+	if n < rpcHeaderSize {
+		return nil, errors.New("smb: invalid RPC fragment length")
+	}
+	frag := p.pending[:n:n]
+	p.pending = p.pending[n:]
+	return frag, nil
+}
+
+// call sends a request PDU and returns the response stub, reassembled
+// from its fragments.
+func (p *pipe) call(ctx context.Context, pdu []byte) ([]byte, error) {
+	if err := p.write(ctx, pdu); err != nil {
+		return nil, err
+	}
+	var stub []byte
+	for {
+		frag, err := p.readFragment(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := responseStub(frag)
+		if err != nil {
+			return nil, err
+		}
+		if stub = append(stub, data...); len(stub) > maxMessageSize {
+			return nil, errors.New("smb: RPC response too large")
+		}
+		if frag[3]&pfcLastFrag != 0 {
+			return stub, nil
+		}
+	}
+}
+
+// ProbeDialects returns the dialects the server at addr accepts,
+// negotiating each one on its own connection.
+func ProbeDialects(ctx context.Context, addr string, opts Options) ([]Dialect, error) {
+	var supported []Dialect
+	for _, d := range Dialects {
+		c, err := Dial(ctx, addr, []Dialect{d}, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			var opErr *net.OpError
+			if errors.As(err, &opErr) && opErr.Op == "dial" {
+				return nil, err
+			}
+			// Refused with an error status or a dropped connection
+			continue
+		}
+		_ = c.Close()
+		supported = append(supported, d)
+	}
+	return supported, nil
+}
+
+// SupportsSMB1 reports whether the server at addr accepts SMB1, by
+// offering only its last dialect, "NT LM 0.12".
+func SupportsSMB1(ctx context.Context, addr string, opts Options) (bool, error) {
+	conn, err := opts.dial(ctx, addr)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	_ = conn.SetDeadline(time.Now().Add(opts.timeout()))
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	defer stop()
+
+	if err := writeMessage(conn, smb1NegotiateRequest()); err != nil {
+		return false, ctxErr(ctx, err)
+	}
+	msg, err := readMessage(conn)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		// Servers without SMB1 drop the connection
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// 32-byte header, then the word count and the chosen dialect index
+	const smb1Negotiate = 0x72
+	if len(msg) < 35 || !bytes.Equal(msg[:4], smb1Magic) || msg[4] != smb1Negotiate {
+		return false, nil
+	}
+	if binary.LittleEndian.Uint32(msg[5:]) != 0 {
+		return false, nil
+	}
+	return msg[32] > 0 && binary.LittleEndian.Uint16(msg[33:]) == 0, nil
+}
+
+func smb1NegotiateRequest() []byte {
+	b := make([]byte, 32, 48)
+	copy(b, smb1Magic)
+	b[4] = 0x72                                   // SMB_COM_NEGOTIATE
+	b[9] = 0x18                                   // Case-insensitive, canonicalized paths
+	binary.LittleEndian.PutUint16(b[10:], 0xc801) // Unicode, NT status, extended security, long names
+	b = append(b, 0)                              // No parameter words
+	dialect := append([]byte{0x02}, "NT LM 0.12\x00"...)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(dialect))) // #nosec G115 -- fixed length
+	return append(b, dialect...)
+}
@@ -0,0 +1,470 @@
+package smb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+// testServer is a minimal SMB2 server: it negotiates, answers NTLM
+// logins, connects trees and serves NetrShareEnum on the srvsvc pipe.
+type testServer struct {
+	t         *testing.T
+	dialects  []Dialect
+	smb1      bool
+	signing   bool // Require signing
+	anonymous bool // Accept null sessions
+	guest     bool // Map null sessions to guest
+	shares    []Share
+	open      map[string]bool // Shares that accept the anonymous session
+	challenge *ntlm.Challenge
+
+	fragSize  int // RPC response fragment size
+	readChunk int // Bytes returned per pipe read
+
+	negotiations atomic.Int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	return &testServer{
+		t:         t,
+		dialects:  Dialects,
+		anonymous: true,
+		shares: []Share{
+			{Name: "ADMIN$", Type: ShareTypeDisk | shareTypeSpecial, Remark: "Remote Admin"},
+			{Name: "C$", Type: ShareTypeDisk | shareTypeSpecial, Remark: "Default share"},
+			{Name: "IPC$", Type: ShareTypeIPC | shareTypeSpecial, Remark: "Remote IPC"},
+			{Name: "public", Type: ShareTypeDisk},
+			{Name: "HP LaserJet", Type: ShareTypePrint, Remark: "Floor 2"},
+		},
+		open: map[string]bool{"public": true},
+		challenge: &ntlm.Challenge{
+			Flags:           ntlm.FlagUnicode | ntlm.FlagNTLM | ntlm.FlagExtendedSessionSecurity,
+			TargetName:      "CORP",
+			Version:         &ntlm.Version{Major: 10, Minor: 0, Build: 17763, Revision: 15},
+			NetBIOSComputer: "FS01",
+			NetBIOSDomain:   "CORP",
+			DNSComputer:     "fs01.corp.example.com",
+			DNSDomain:       "corp.example.com",
+		},
+		fragSize:  64,
+		readChunk: 100,
+	}
+}
+
+func (s *testServer) start() string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(s.t, err)
+	s.t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func (s *testServer) serve(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	trees := make(map[uint32]string)
+	var pipeOut [][]byte // Pending pipe messages
+	for {
+		msg, err := readMessage(conn)
+		if err != nil {
+			return
+		}
+		if bytes.HasPrefix(msg, smb1Magic) {
+			if !s.smb1 {
+				return
+			}
+			resp := make([]byte, 35)
+			copy(resp, smb1Magic)
+			resp[4] = 0x72
+			resp[32] = 17 // Word count; dialect index 0 follows
+			_ = writeMessage(conn, resp)
+			continue
+		}
+
+		h, err := parseHeader(msg)
+		require.NoError(s.t, err)
+		b := msg[headerSize:]
+		resp := header{command: h.command, credits: 1, flags: flagResponse, messageID: h.messageID, treeID: h.treeID, sessionID: h.sessionID}
+		errorBody := make([]byte, 9)
+		binary.LittleEndian.PutUint16(errorBody, 9)
+		out := errorBody
+
+		switch h.command {
+		case cmdNegotiate:
+			s.negotiations.Add(1)
+			resp.status, out = s.negotiate(b)
+			if resp.status != StatusSuccess {
+				out = errorBody
+			}
+		case cmdSessionSetup:
+			token := ntlmMessage(b[24:])
+			switch binary.LittleEndian.Uint32(token[8:]) {
+			case 1:
+				resp.status = StatusMoreProcessingRequired
+				resp.sessionID = 0x1122334455
+				out = sessionSetupResponse(0, negTokenResp(s.challenge.Marshal()))
+			case 3:
+				ntLength := binary.LittleEndian.Uint16(token[20:])
+				userLength := binary.LittleEndian.Uint16(token[36:])
+				flags := binary.LittleEndian.Uint32(token[60:])
+				if !s.anonymous || ntLength != 0 || userLength != 0 || flags&ntlm.FlagAnonymous == 0 {
+					resp.status = StatusLogonFailure
+					break
+				}
+				var sessionFlags uint16 = sessionFlagNull
+				if s.guest {
+					sessionFlags = sessionFlagGuest
+				}
+				out = sessionSetupResponse(sessionFlags, nil)
+			}
+		case cmdTreeConnect:
+			path := ntlm.DecodeUTF16(b[8 : 8+binary.LittleEndian.Uint16(b[6:])])
+			share := path[strings.LastIndex(path, `\`)+1:]
+			switch {
+			case h.sessionID == 0:
+				resp.status = StatusUserSessionDeleted
+			case share == "IPC$" || s.open[share]:
+				resp.treeID = uint32(len(trees) + 1) // #nosec G115 -- test
+				trees[resp.treeID] = share
+				out = make([]byte, 16)
+				binary.LittleEndian.PutUint16(out, 16)
+				out[2] = 1
+				if share == "IPC$" {
+					out[2] = 2
+				}
+				binary.LittleEndian.PutUint32(out[12:], 0x001200a9)
+			case slices.ContainsFunc(s.shares, func(sh Share) bool { return sh.Name == share }):
+				resp.status = StatusAccessDenied
+			default:
+				resp.status = StatusBadNetworkName
+			}
+		case cmdTreeDisconnect, cmdClose:
+			out = emptyRequest()
+		case cmdCreate:
+			name := ntlm.DecodeUTF16(b[56 : 56+binary.LittleEndian.Uint16(b[46:])])
+			if trees[h.treeID] != "IPC$" || name != "srvsvc" {
+				resp.status = StatusObjectNameNotFound
+				break
+			}
+			out = make([]byte, 88)
+			binary.LittleEndian.PutUint16(out, 89)
+			copy(out[64:], "srvsvc-file-id!!")
+		case cmdWrite:
+			data := msg[binary.LittleEndian.Uint16(b[2:]):]
+			pipeOut = append(pipeOut, s.rpc(data)...)
+			out = make([]byte, 16)
+			binary.LittleEndian.PutUint16(out, 17)
+			binary.LittleEndian.PutUint32(out[4:], uint32(len(data))) // #nosec G115 -- test
+		case cmdRead:
+			if len(pipeOut) == 0 {
+				resp.status = StatusInvalidParameter
+				break
+			}
+			data := pipeOut[0]
+			if len(data) > s.readChunk {
+				resp.status = StatusBufferOverflow
+				pipeOut[0] = data[s.readChunk:]
+				data = data[:s.readChunk]
+			} else {
+				pipeOut = pipeOut[1:]
+			}
+			out = make([]byte, 16, 16+len(data))
+			binary.LittleEndian.PutUint16(out, 17)
+			out[2] = headerSize + 16
+			binary.LittleEndian.PutUint32(out[4:], uint32(len(data))) // #nosec G115 -- test
+			out = append(out, data...)
+		}
+
+		if err := writeMessage(conn, resp.marshal(out)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *testServer) negotiate(b []byte) (Status, []byte) {
+	count := int(binary.LittleEndian.Uint16(b[2:]))
+	var chosen Dialect
+	for i := range count {
+		d := Dialect(binary.LittleEndian.Uint16(b[36+2*i:]))
+		if slices.Contains(s.dialects, d) && d > chosen {
+			chosen = d
+		}
+	}
+	if chosen == 0 {
+		return StatusNotSupported, nil
+	}
+	// SMB 3.1.1 requires the preauth integrity context
+	if chosen == Dialect311 {
+		offset := int(binary.LittleEndian.Uint32(b[28:])) - headerSize
+		if binary.LittleEndian.Uint16(b[32:]) != 2 || binary.LittleEndian.Uint16(b[offset:]) != contextPreauthIntegrity {
+			return StatusInvalidParameter, nil
+		}
+	}
+
+	out := make([]byte, 64)
+	binary.LittleEndian.PutUint16(out, 65)
+	mode := uint16(securityModeSigningEnabled)
+	if s.signing {
+		mode |= securityModeSigningRequired
+	}
+	binary.LittleEndian.PutUint16(out[2:], mode)
+	binary.LittleEndian.PutUint16(out[4:], uint16(chosen))
+	copy(out[8:], "server-guid-0001")
+	binary.LittleEndian.PutUint32(out[28:], 65536)
+	binary.LittleEndian.PutUint32(out[32:], 65536)
+	binary.LittleEndian.PutUint32(out[36:], 65536)
+	binary.LittleEndian.PutUint64(out[40:], 133589952000000000) // 2024-05-01
+	return StatusSuccess, out
+}
+
+func sessionSetupResponse(flags uint16, blob []byte) []byte {
+	out := make([]byte, 8, 8+len(blob))
+	binary.LittleEndian.PutUint16(out, 9)
+	binary.LittleEndian.PutUint16(out[2:], flags)
+	binary.LittleEndian.PutUint16(out[4:], headerSize+8)
+	binary.LittleEndian.PutUint16(out[6:], uint16(len(blob))) // #nosec G115 -- test
+	return append(out, blob...)
+}
+
+// rpc answers a bind or NetrShareEnum request with the PDUs the pipe
+// returns.
+func (s *testServer) rpc(pdu []byte) [][]byte {
+	callID := binary.LittleEndian.Uint32(pdu[12:])
+	switch pdu[2] {
+	case rpcBind:
+		require.Equal(s.t, srvsvcSyntax, pdu[rpcHeaderSize+16:rpcHeaderSize+36])
+		b := binary.LittleEndian.AppendUint16(nil, rpcMaxFrag)
+		b = binary.LittleEndian.AppendUint16(b, rpcMaxFrag)
+		b = binary.LittleEndian.AppendUint32(b, 0x1234)
+		b = binary.LittleEndian.AppendUint16(b, 13)
+		b = append(b, `\PIPE\srvsvc`+"\x00"...)
+		for (rpcHeaderSize+len(b))%4 != 0 {
+			b = append(b, 0)
+		}
+		b = append(b, 1, 0, 0, 0, 0, 0, 0, 0)
+		b = append(b, ndrSyntax...)
+		return [][]byte{rpcPDU(rpcBindAck, callID, b)}
+	case rpcRequest:
+		require.Equal(s.t, uint16(opNetrShareEnum), binary.LittleEndian.Uint16(pdu[rpcHeaderSize+6:]))
+		var w ndrWriter
+		w.u32(1)
+		w.u32(1)
+		w.u32(0x00020000)
+		w.u32(uint32(len(s.shares))) // #nosec G115 -- test
+		w.u32(0x00020004)
+		w.u32(uint32(len(s.shares))) // #nosec G115 -- test
+		for i, sh := range s.shares {
+			w.u32(0x00020008 + uint32(8*i)) // #nosec G115 -- test
+			w.u32(sh.Type)
+			if sh.Remark == "" {
+				w.u32(0)
+			} else {
+				w.u32(0x0002000c + uint32(8*i)) // #nosec G115 -- test
+			}
+		}
+		for _, sh := range s.shares {
+			w.str(sh.Name)
+			if sh.Remark != "" {
+				w.str(sh.Remark)
+			}
+		}
+		w.u32(uint32(len(s.shares))) // #nosec G115 -- test
+		w.u32(0)                     // No resume handle
+		w.u32(0)                     // WERROR_OK
+
+		var frags [][]byte
+		stub := w.b
+		for first := true; first || len(stub) > 0; first = false {
+			chunk := stub[:min(s.fragSize, len(stub))]
+			stub = stub[len(chunk):]
+			body := binary.LittleEndian.AppendUint32(nil, uint32(len(w.b))) // #nosec G115 -- test
+			body = append(body, 0, 0, 0, 0)
+			frag := rpcPDU(rpcResponse, callID, append(body, chunk...))
+			frag[3] = 0
+			if first {
+				frag[3] |= pfcFirstFrag
+			}
+			if len(stub) == 0 {
+				frag[3] |= pfcLastFrag
+			}
+			frags = append(frags, frag)
+		}
+		// The pipe returns fragments back to back
+		return [][]byte{bytes.Join(frags, nil)}
+	}
+	s.t.Errorf("unexpected RPC PDU type %d", pdu[2])
+	return nil
+}
+
+func TestClient_AnonymousShares(t *testing.T) {
+	addr := newTestServer(t).start()
+	ctx := context.Background()
+
+	c, err := Dial(ctx, addr, nil, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+
+	info := c.Info()
+	require.Equal(t, Dialect311, info.Dialect)
+	require.True(t, info.SigningEnabled)
+	require.False(t, info.SigningRequired)
+	require.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), info.SystemTime)
+
+	challenge, err := c.LoginAnonymous(ctx)
+	require.NoError(t, err)
+	require.False(t, c.Guest())
+	require.Equal(t, "FS01", challenge.NetBIOSComputer)
+	require.Equal(t, "corp.example.com", challenge.DNSDomain)
+	require.Equal(t, "10.0.17763", challenge.Version.String())
+
+	shares, err := c.ListShares(ctx)
+	require.NoError(t, err)
+	require.Len(t, shares, 5)
+	require.Equal(t, Share{Name: "ADMIN$", Type: ShareTypeDisk | shareTypeSpecial, Remark: "Remote Admin"}, shares[0])
+	require.Equal(t, Share{Name: "public", Type: ShareTypeDisk}, shares[3])
+	require.Equal(t, "printer", shares[4].Kind())
+	require.True(t, shares[1].Special())
+	require.False(t, shares[3].Special())
+
+	tree, err := c.TreeConnect(ctx, "public")
+	require.NoError(t, err)
+	require.Equal(t, uint8(1), tree.Type)
+	require.NoError(t, c.TreeDisconnect(ctx, tree))
+
+	_, err = c.TreeConnect(ctx, "C$")
+	require.ErrorIs(t, err, StatusAccessDenied)
+	_, err = c.TreeConnect(ctx, "missing")
+	require.EqualError(t, err, "smb: STATUS_BAD_NETWORK_NAME")
+}
+
+func TestClient_LoginRefused(t *testing.T) {
+	server := newTestServer(t)
+	server.anonymous = false
+	addr := server.start()
+
+	c, err := Dial(context.Background(), addr, nil, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+
+	// The challenge still identifies the server
+	challenge, err := c.LoginAnonymous(context.Background())
+	require.ErrorIs(t, err, StatusLogonFailure)
+	require.Equal(t, "FS01", challenge.NetBIOSComputer)
+
+	_, err = c.ListShares(context.Background())
+	require.ErrorIs(t, err, StatusUserSessionDeleted)
+}
+
+func TestClient_Guest(t *testing.T) {
+	server := newTestServer(t)
+	server.guest = true
+	server.dialects = []Dialect{Dialect202}
+	addr := server.start()
+
+	c, err := Dial(context.Background(), addr, nil, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+	require.Equal(t, Dialect202, c.Info().Dialect)
+
+	_, err = c.LoginAnonymous(context.Background())
+	require.NoError(t, err)
+	require.True(t, c.Guest())
+}
+
+func TestProbeDialects(t *testing.T) {
+	server := newTestServer(t)
+	server.dialects = []Dialect{Dialect210, Dialect300}
+	server.signing = true
+	addr := server.start()
+
+	dialects, err := ProbeDialects(context.Background(), addr, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	require.Equal(t, []Dialect{Dialect210, Dialect300}, dialects)
+	require.Equal(t, int32(len(Dialects)), server.negotiations.Load())
+
+	c, err := Dial(context.Background(), addr, nil, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+	require.Equal(t, Dialect300, c.Info().Dialect)
+	require.True(t, c.Info().SigningRequired)
+}
+
+func TestSupportsSMB1(t *testing.T) {
+	server := newTestServer(t)
+	addr := server.start()
+
+	ok, err := SupportsSMB1(context.Background(), addr, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	server = newTestServer(t)
+	server.smb1 = true
+	addr = server.start()
+	ok, err = SupportsSMB1(context.Background(), addr, Options{Timeout: time.Second})
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestDial_Errors(t *testing.T) {
+	// A service that is not SMB
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			_, _ = conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			_ = conn.Close()
+		}
+	}()
+	_, err = Dial(context.Background(), ln.Addr().String(), nil, Options{Timeout: time.Second})
+	require.ErrorIs(t, err, ErrNotSMB)
+
+	// A closed port fails the probe instead of reporting no dialects
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := closed.Addr().String()
+	require.NoError(t, closed.Close())
+	_, err = ProbeDialects(context.Background(), addr, Options{Timeout: time.Second})
+	var opErr *net.OpError
+	require.True(t, errors.As(err, &opErr))
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	go func() {
+		// Accept and never answer
+		conn, err := ln.Accept()
+		if err == nil {
+			defer func() { _ = conn.Close() }()
+			time.Sleep(2 * time.Second)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = Dial(ctx, ln.Addr().String(), nil, Options{Timeout: time.Minute})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,342 @@
+package smb
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+// SMB2 commands (MS-SMB2 2.2.1).
+const (
+	cmdNegotiate      uint16 = 0x0000
+	cmdSessionSetup   uint16 = 0x0001
+	cmdTreeConnect    uint16 = 0x0003
+	cmdTreeDisconnect uint16 = 0x0004
+	cmdCreate         uint16 = 0x0005
+	cmdClose          uint16 = 0x0006
+	cmdRead           uint16 = 0x0008
+	cmdWrite          uint16 = 0x0009
+)
+
+const (
+	headerSize = 64
+
+	// maxMessageSize bounds messages read from the server; the direct
+	// TCP framing allows 16 MiB
+	maxMessageSize = 1<<24 - 1
+
+	flagResponse = 0x00000001
+	flagAsync    = 0x00000002
+
+	securityModeSigningEnabled  = 0x0001
+	securityModeSigningRequired = 0x0002
+
+	sessionFlagGuest = 0x0001
+	sessionFlagNull  = 0x0002
+
+	// Negotiate context types (MS-SMB2 2.2.3.1)
+	contextPreauthIntegrity = 0x0001
+	contextEncryption       = 0x0002
+	hashSHA512              = 0x0001
+	cipherAES128CCM         = 0x0001
+	cipherAES128GCM         = 0x0002
+)
+
+var (
+	smb1Magic = []byte{0xff, 'S', 'M', 'B'}
+	smb2Magic = []byte{0xfe, 'S', 'M', 'B'}
+)
+
+// header is an SMB2 packet header (MS-SMB2 2.2.1.2).
+type header struct {
+	creditCharge uint16
+	status       Status
+	command      uint16
+	credits      uint16
+	flags        uint32
+	messageID    uint64
+	asyncID      uint64
+	treeID       uint32
+	sessionID    uint64
+}
+
+func (h *header) marshal(body []byte) []byte {
+	b := make([]byte, headerSize, headerSize+len(body))
+	copy(b, smb2Magic)
+	binary.LittleEndian.PutUint16(b[4:], headerSize)
+	binary.LittleEndian.PutUint16(b[6:], h.creditCharge)
+	binary.LittleEndian.PutUint32(b[8:], uint32(h.status))
+	binary.LittleEndian.PutUint16(b[12:], h.command)
+	binary.LittleEndian.PutUint16(b[14:], h.credits)
+	binary.LittleEndian.PutUint32(b[16:], h.flags)
+	binary.LittleEndian.PutUint64(b[24:], h.messageID)
+	if h.flags&flagAsync != 0 {
+		binary.LittleEndian.PutUint64(b[32:], h.asyncID)
+	} else {
+		binary.LittleEndian.PutUint32(b[36:], h.treeID)
+	}
+	binary.LittleEndian.PutUint64(b[40:], h.sessionID)
+	return append(b, body...)
+}
+
+func parseHeader(msg []byte) (header, error) {
+	if len(msg) < headerSize || !bytes.Equal(msg[:4], smb2Magic) {
+		return header{}, ErrNotSMB
+	}
+	h := header{
+		creditCharge: binary.LittleEndian.Uint16(msg[6:]),
+		status:       Status(binary.LittleEndian.Uint32(msg[8:])),
+		command:      binary.LittleEndian.Uint16(msg[12:]),
+		credits:      binary.LittleEndian.Uint16(msg[14:]),
+		flags:        binary.LittleEndian.Uint32(msg[16:]),
+		messageID:    binary.LittleEndian.Uint64(msg[24:]),
+		sessionID:    binary.LittleEndian.Uint64(msg[40:]),
+	}
+	if h.flags&flagAsync != 0 {
+		h.asyncID = binary.LittleEndian.Uint64(msg[32:])
+	} else {
+		h.treeID = binary.LittleEndian.Uint32(msg[36:])
+	}
+	return h, nil
+}
+
+// writeMessage sends msg with the 4-byte length prefix of direct TCP
+// transport (MS-SMB2 2.1).
+func writeMessage(w io.Writer, msg []byte) error {
+	if len(msg) > maxMessageSize {
+		return errors.New("smb: message too large")
+	}
+	b := make([]byte, 4, 4+len(msg))
+	b[1] = byte(len(msg) >> 16)
+	b[2] = byte(len(msg) >> 8)
+	b[3] = byte(len(msg))
+	_, err := w.Write(append(b, msg...))
+	return err
+}
+
+func readMessage(r io.Reader) ([]byte, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	if prefix[0] != 0 {
+		return nil, ErrNotSMB
+	}
+	msg := make([]byte, int(prefix[1])<<16|int(prefix[2])<<8|int(prefix[3]))
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// buffer returns the variable-length field at offset, counted from the
+// start of the header.
+func buffer(msg []byte, offset, length int) ([]byte, error) {
+	if length == 0 {
+		return nil, nil
+	}
+	if offset < headerSize || offset+length > len(msg) {
+		return nil, errors.New("smb: buffer out of bounds")
+	}
+	return msg[offset : offset+length], nil
+}
+
+// body returns the fixed part of a response, checking its size.
+func body(msg []byte, size int) ([]byte, error) {
+	if len(msg) < headerSize+size {
+		return nil, fmt.Errorf("smb: response truncated (%d bytes)", len(msg))
+	}
+	return msg[headerSize:], nil
+}
+
+// negotiateRequest offers dialects. With SMB 3.1.1 it carries the
+// negotiate contexts that dialect requires.
+func negotiateRequest(dialects []Dialect, clientGUID [16]byte) []byte {
+	b := make([]byte, 36, 128)
+	binary.LittleEndian.PutUint16(b[0:], 36)
+	binary.LittleEndian.PutUint16(b[2:], uint16(len(dialects))) // #nosec G115 -- at most five dialects
+	binary.LittleEndian.PutUint16(b[4:], securityModeSigningEnabled)
+	copy(b[12:], clientGUID[:])
+	offer311 := false
+	for _, d := range dialects {
+		b = binary.LittleEndian.AppendUint16(b, uint16(d))
+		offer311 = offer311 || d == Dialect311
+	}
+	if !offer311 {
+		return b
+	}
+
+	salt := make([]byte, 32)
+	_, _ = rand.Read(salt)
+	preauth := binary.LittleEndian.AppendUint16(nil, 1)
+	preauth = binary.LittleEndian.AppendUint16(preauth, uint16(len(salt)))
+	preauth = binary.LittleEndian.AppendUint16(preauth, hashSHA512)
+	preauth = append(preauth, salt...)
+	encryption := binary.LittleEndian.AppendUint16(nil, 2)
+	encryption = binary.LittleEndian.AppendUint16(encryption, cipherAES128GCM)
+	encryption = binary.LittleEndian.AppendUint16(encryption, cipherAES128CCM)
+
+	b = pad8(b)
+	binary.LittleEndian.PutUint32(b[28:], uint32(headerSize+len(b))) // #nosec G115 -- small offset
+	binary.LittleEndian.PutUint16(b[32:], 2)
+	b = appendContext(b, contextPreauthIntegrity, preauth)
+	b = pad8(b)
+	return appendContext(b, contextEncryption, encryption)
+}
+
+// pad8 pads b so that the next field is 8-byte aligned in the message.
+func pad8(b []byte) []byte {
+	for (headerSize+len(b))%8 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func appendContext(b []byte, contextType uint16, data []byte) []byte {
+	b = binary.LittleEndian.AppendUint16(b, contextType)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(data))) // #nosec G115 -- small contexts
+	b = append(b, 0, 0, 0, 0)
+	return append(b, data...)
+}
+
+// NegotiateInfo is what the server announced in its negotiate response.
+type NegotiateInfo struct {
+	Dialect         Dialect
+	SigningEnabled  bool
+	SigningRequired bool
+	ServerGUID      [16]byte
+	Capabilities    uint32
+	MaxTransactSize uint32
+	MaxReadSize     uint32
+	MaxWriteSize    uint32
+	SystemTime      time.Time // Zero when the server does not send it
+}
+
+func parseNegotiateResponse(msg []byte) (NegotiateInfo, error) {
+	b, err := body(msg, 64)
+	if err != nil {
+		return NegotiateInfo{}, err
+	}
+	mode := binary.LittleEndian.Uint16(b[2:])
+	info := NegotiateInfo{
+		Dialect:         Dialect(binary.LittleEndian.Uint16(b[4:])),
+		SigningEnabled:  mode&securityModeSigningEnabled != 0,
+		SigningRequired: mode&securityModeSigningRequired != 0,
+		Capabilities:    binary.LittleEndian.Uint32(b[24:]),
+		MaxTransactSize: binary.LittleEndian.Uint32(b[28:]),
+		MaxReadSize:     binary.LittleEndian.Uint32(b[32:]),
+		MaxWriteSize:    binary.LittleEndian.Uint32(b[36:]),
+	}
+	copy(info.ServerGUID[:], b[8:24])
+	if ft := binary.LittleEndian.Uint64(b[40:]); ft != 0 {
+		info.SystemTime = ntlm.FileTime(ft)
+	}
+	return info, nil
+}
+
+func sessionSetupRequest(token []byte) []byte {
+	b := make([]byte, 24, 24+len(token))
+	binary.LittleEndian.PutUint16(b[0:], 25)
+	b[3] = securityModeSigningEnabled
+	binary.LittleEndian.PutUint16(b[12:], headerSize+24)
+	binary.LittleEndian.PutUint16(b[14:], uint16(len(token))) // #nosec G115 -- NTLM tokens are small
+	return append(b, token...)
+}
+
+// parseSessionSetupResponse returns the session flags and security blob.
+func parseSessionSetupResponse(msg []byte) (uint16, []byte, error) {
+	b, err := body(msg, 8)
+	if err != nil {
+		return 0, nil, err
+	}
+	blob, err := buffer(msg, int(binary.LittleEndian.Uint16(b[4:])), int(binary.LittleEndian.Uint16(b[6:])))
+	return binary.LittleEndian.Uint16(b[2:]), blob, err
+}
+
+func treeConnectRequest(path string) []byte {
+	p := ntlm.EncodeUTF16(path)
+	b := make([]byte, 8, 8+len(p))
+	binary.LittleEndian.PutUint16(b[0:], 9)
+	binary.LittleEndian.PutUint16(b[4:], headerSize+8)
+	binary.LittleEndian.PutUint16(b[6:], uint16(len(p))) // #nosec G115 -- share paths are short
+	return append(b, p...)
+}
+
+// emptyRequest is the body of TREE_DISCONNECT.
+func emptyRequest() []byte {
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint16(b, 4)
+	return b
+}
+
+// Access and options for opening a named pipe.
+const (
+	pipeDesiredAccess = 0x0012019f // Read, write, append, attributes, EAs, read control, synchronize
+	shareAccessAll    = 0x00000007
+	fileOpen          = 0x00000001
+	fileNonDirectory  = 0x00000040
+	impersonation     = 0x00000002
+)
+
+func createRequest(name string) []byte {
+	n := ntlm.EncodeUTF16(name)
+	b := make([]byte, 56, 56+len(n))
+	binary.LittleEndian.PutUint16(b[0:], 57)
+	binary.LittleEndian.PutUint32(b[4:], impersonation)
+	binary.LittleEndian.PutUint32(b[24:], pipeDesiredAccess)
+	binary.LittleEndian.PutUint32(b[32:], shareAccessAll)
+	binary.LittleEndian.PutUint32(b[36:], fileOpen)
+	binary.LittleEndian.PutUint32(b[40:], fileNonDirectory)
+	binary.LittleEndian.PutUint16(b[44:], headerSize+56)
+	binary.LittleEndian.PutUint16(b[46:], uint16(len(n))) // #nosec G115 -- pipe names are short
+	return append(b, n...)
+}
+
+func parseCreateResponse(msg []byte) ([16]byte, error) {
+	var fileID [16]byte
+	b, err := body(msg, 88)
+	if err != nil {
+		return fileID, err
+	}
+	copy(fileID[:], b[64:80])
+	return fileID, nil
+}
+
+func closeRequest(fileID [16]byte) []byte {
+	b := make([]byte, 24)
+	binary.LittleEndian.PutUint16(b[0:], 24)
+	copy(b[8:], fileID[:])
+	return b
+}
+
+func writeRequest(fileID [16]byte, data []byte) []byte {
+	b := make([]byte, 48, 48+len(data))
+	binary.LittleEndian.PutUint16(b[0:], 49)
+	binary.LittleEndian.PutUint16(b[2:], headerSize+48)
+	binary.LittleEndian.PutUint32(b[4:], uint32(len(data))) // #nosec G115 -- RPC fragments are small
+	copy(b[16:], fileID[:])
+	return append(b, data...)
+}
+
+func readRequest(fileID [16]byte, length uint32) []byte {
+	b := make([]byte, 49)
+	binary.LittleEndian.PutUint16(b[0:], 49)
+	b[2] = headerSize + 16 // Where the response data should start
+	binary.LittleEndian.PutUint32(b[4:], length)
+	copy(b[16:], fileID[:])
+	return b
+}
+
+func parseReadResponse(msg []byte) ([]byte, error) {
+	b, err := body(msg, 16)
+	if err != nil {
+		return nil, err
+	}
+	return buffer(msg, int(b[2]), int(binary.LittleEndian.Uint32(b[4:])))
+}
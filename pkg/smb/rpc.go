@@ -0,0 +1,261 @@
+package smb
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+// DCE/RPC connection-oriented PDU types and flags (C706 chapter 12).
+const (
+	rpcRequest  = 0
+	rpcResponse = 2
+	rpcFault    = 3
+	rpcBind     = 11
+	rpcBindAck  = 12
+	rpcBindNak  = 13
+
+	pfcFirstFrag = 0x01
+	pfcLastFrag  = 0x02
+
+	rpcHeaderSize = 16
+	rpcMaxFrag    = 4280
+
+	opNetrShareEnum = 15
+)
+
+var (
+	// srvsvc interface 4b324fc8-1670-01d3-1278-5a47bf6ee188 v3.0
+	srvsvcSyntax = syntax("4b324fc8-1670-01d3-1278-5a47bf6ee188", 3)
+	// NDR transfer syntax 8a885d04-1ceb-11c9-9fe8-08002b104860 v2.0
+	ndrSyntax = syntax("8a885d04-1ceb-11c9-9fe8-08002b104860", 2)
+)
+
+// syntax encodes an interface UUID and version as a presentation
+// syntax: the first three UUID fields are little-endian.
+func syntax(uuid string, version uint32) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(uuid, "-", ""))
+	if err != nil || len(b) != 16 {
+		panic("smb: invalid UUID " + uuid)
+	}
+	slices.Reverse(b[0:4])
+	slices.Reverse(b[4:6])
+	slices.Reverse(b[6:8])
+	return binary.LittleEndian.AppendUint32(b, version)
+}
+
+// rpcPDU frames body as a single-fragment PDU with little-endian data
+// representation.
+func rpcPDU(ptype byte, callID uint32, body []byte) []byte {
+	b := make([]byte, rpcHeaderSize, rpcHeaderSize+len(body))
+	b[0] = 5 // Version 5.0
+	b[2] = ptype
+	b[3] = pfcFirstFrag | pfcLastFrag
+	b[4] = 0x10                                                           // Little-endian, ASCII, IEEE floats
+	binary.LittleEndian.PutUint16(b[8:], uint16(rpcHeaderSize+len(body))) // #nosec G115 -- requests fit one fragment
+	binary.LittleEndian.PutUint32(b[12:], callID)
+	return append(b, body...)
+}
+
+// bindRequest binds presentation context 0 to the srvsvc interface.
+func bindRequest(callID uint32) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, rpcMaxFrag) // Max transmit fragment
+	b = binary.LittleEndian.AppendUint16(b, rpcMaxFrag)    // Max receive fragment
+	b = binary.LittleEndian.AppendUint32(b, 0)             // New association group
+	b = append(b, 1, 0, 0, 0)                              // One context element
+	b = append(b, 0, 0, 1, 0)                              // Context 0, one transfer syntax
+	b = append(b, srvsvcSyntax...)
+	b = append(b, ndrSyntax...)
+	return rpcPDU(rpcBind, callID, b)
+}
+
+// requestPDU calls opnum on presentation context 0.
+func requestPDU(callID uint32, opnum uint16, stub []byte) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, uint32(len(stub))) // #nosec G115 -- small stubs
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	b = binary.LittleEndian.AppendUint16(b, opnum)
+	return rpcPDU(rpcRequest, callID, append(b, stub...))
+}
+
+// fragLength returns the length of the fragment starting b.
+func fragLength(b []byte) int {
+	return int(binary.LittleEndian.Uint16(b[8:]))
+}
+
+// checkBindAck verifies that the server accepted the presentation
+// context.
+func checkBindAck(pdu []byte) error {
+	switch pdu[2] {
+	case rpcBindAck:
+	case rpcBindNak:
+		return errors.New("smb: srvsvc bind rejected")
+	default:
+		return fmt.Errorf("smb: unexpected RPC PDU type %d in bind", pdu[2])
+	}
+
+	// Skip the secondary address, then align to 4
+	off := rpcHeaderSize + 8
+	if len(pdu) < off+2 {
+		return errors.New("smb: bind ack truncated")
+	}
+	off += 2 + int(binary.LittleEndian.Uint16(pdu[off:]))
+	off = (off + 3) &^ 3
+	if len(pdu) < off+6 || pdu[off] == 0 {
+		return errors.New("smb: bind ack truncated")
+	}
+	if result := binary.LittleEndian.Uint16(pdu[off+4:]); result != 0 {
+		return fmt.Errorf("smb: srvsvc bind rejected (result %d)", result)
+	}
+	return nil
+}
+
+// responseStub returns the stub data of a response fragment.
+func responseStub(pdu []byte) ([]byte, error) {
+	if len(pdu) < rpcHeaderSize+8 {
+		return nil, errors.New("smb: RPC response truncated")
+	}
+	switch pdu[2] {
+	case rpcResponse:
+		authLength := int(binary.LittleEndian.Uint16(pdu[10:]))
+		end := len(pdu)
+		if authLength > 0 {
+			end -= authLength + 8
+		}
+		if end < rpcHeaderSize+8 {
+			return nil, errors.New("smb: RPC response truncated")
+		}
+		return pdu[rpcHeaderSize+8 : end], nil
+	case rpcFault:
+		return nil, fmt.Errorf("smb: RPC fault 0x%08x", binary.LittleEndian.Uint32(pdu[rpcHeaderSize+8:]))
+	default:
+		return nil, fmt.Errorf("smb: unexpected RPC PDU type %d", pdu[2])
+	}
+}
+
+// netrShareEnumRequest lists shares at level 1: name, type and remark
+// (MS-SRVS 3.1.4.8).
+func netrShareEnumRequest(server string) []byte {
+	var w ndrWriter
+	w.u32(0x00020000) // ServerName referent
+	w.str(server)
+	w.u32(1)          // Level
+	w.u32(1)          // Union arm
+	w.u32(0x00020004) // SHARE_INFO_1_CONTAINER referent
+	w.u32(0)          // EntriesRead
+	w.u32(0)          // Buffer (null)
+	w.u32(0xffffffff) // PreferedMaximumLength: everything
+	w.u32(0x00020008) // ResumeHandle referent
+	w.u32(0)
+	return w.b
+}
+
+func parseNetrShareEnumResponse(stub []byte) ([]Share, error) {
+	r := ndrReader{b: stub}
+	if level := r.u32(); level != 1 && r.err == nil {
+		return nil, fmt.Errorf("smb: unexpected share info level %d", level)
+	}
+	r.u32() // Union arm
+
+	var shares []Share
+	if r.u32() != 0 {
+		r.u32() // EntriesRead
+		if r.u32() != 0 {
+			count := int(r.u32())
+			if count > len(stub)/12 {
+				return nil, errors.New("smb: share count out of bounds")
+			}
+			type entry struct{ name, typ, remark uint32 }
+			entries := make([]entry, count)
+			for i := range entries {
+				entries[i] = entry{r.u32(), r.u32(), r.u32()}
+			}
+			for _, e := range entries {
+				share := Share{Type: e.typ}
+				if e.name != 0 {
+					share.Name = r.str()
+				}
+				if e.remark != 0 {
+					share.Remark = r.str()
+				}
+				shares = append(shares, share)
+			}
+		}
+	}
+	r.u32() // TotalEntries
+	if r.u32() != 0 {
+		r.u32() // ResumeHandle
+	}
+	werr := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if werr != 0 {
+		return nil, fmt.Errorf("smb: NetrShareEnum failed with error 0x%08x", werr)
+	}
+	return shares, nil
+}
+
+// ndrWriter marshals NDR data with 4-byte alignment.
+type ndrWriter struct{ b []byte }
+
+func (w *ndrWriter) u32(v uint32) { w.b = binary.LittleEndian.AppendUint32(w.b, v) }
+
+// str writes a conformant varying string with its NUL terminator.
+func (w *ndrWriter) str(s string) {
+	u := append(ntlm.EncodeUTF16(s), 0, 0)
+	n := uint32(len(u) / 2) // #nosec G115 -- short strings
+	w.u32(n)
+	w.u32(0)
+	w.u32(n)
+	w.b = append(w.b, u...)
+	for len(w.b)%4 != 0 {
+		w.b = append(w.b, 0)
+	}
+}
+
+// ndrReader unmarshals NDR data. The first error sticks; later reads
+// return zero values.
+type ndrReader struct {
+	b   []byte
+	off int
+	err error
+}
+
+func (r *ndrReader) align() {
+	r.off = (r.off + 3) &^ 3
+}
+
+func (r *ndrReader) u32() uint32 {
+	r.align()
+	if r.err != nil || r.off+4 > len(r.b) {
+		r.fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint32(r.b[r.off:])
+	r.off += 4
+	return v
+}
+
+func (r *ndrReader) str() string {
+	r.u32() // Maximum count
+	r.u32() // Offset
+	n := int(r.u32())
+	if r.err != nil || n < 0 || r.off+2*n > len(r.b) {
+		r.fail()
+		return ""
+	}
+	s := ntlm.DecodeUTF16(r.b[r.off : r.off+2*n])
+	r.off += 2 * n
+	return s
+}
+
+func (r *ndrReader) fail() {
+	if r.err == nil {
+		r.err = errors.New("smb: RPC response truncated")
+	}
+}
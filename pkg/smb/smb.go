@@ -0,0 +1,140 @@
+// Package smb implements the parts of the SMB protocol needed to audit a
+// file server without credentials: dialect negotiation, signing
+// requirements, anonymous sessions and share enumeration through the
+// srvsvc RPC interface.
+//
+// Only SMB 2 and 3 are spoken. SMB1 is merely detected, since offering
+// it at all is the finding.
+package smb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each request when Options.Timeout is zero.
+const DefaultTimeout = 5 * time.Second
+
+// Dialect is an SMB 2 or 3 dialect revision.
+type Dialect uint16
+
+const (
+	Dialect202 Dialect = 0x0202
+	Dialect210 Dialect = 0x0210
+	Dialect300 Dialect = 0x0300
+	Dialect302 Dialect = 0x0302
+	Dialect311 Dialect = 0x0311
+)
+
+// Dialects lists the dialects this package negotiates, oldest first.
+var Dialects = []Dialect{Dialect202, Dialect210, Dialect300, Dialect302, Dialect311}
+
+// String returns the dialect's version, e.g. "3.1.1".
+func (d Dialect) String() string {
+	switch d {
+	case Dialect202:
+		return "2.0.2"
+	case Dialect210:
+		return "2.1"
+	case Dialect300:
+		return "3.0"
+	case Dialect302:
+		return "3.0.2"
+	case Dialect311:
+		return "3.1.1"
+	default:
+		return fmt.Sprintf("0x%04x", uint16(d))
+	}
+}
+
+// Status is an NT status code returned by the server.
+type Status uint32
+
+// Status codes this package handles.
+const (
+	StatusSuccess                Status = 0x00000000
+	StatusPending                Status = 0x00000103
+	StatusBufferOverflow         Status = 0x80000005
+	StatusInvalidParameter       Status = 0xc000000d
+	StatusMoreProcessingRequired Status = 0xc0000016
+	StatusAccessDenied           Status = 0xc0000022
+	StatusObjectNameNotFound     Status = 0xc0000034
+	StatusLogonFailure           Status = 0xc000006d
+	StatusAccountDisabled        Status = 0xc0000072
+	StatusNotSupported           Status = 0xc00000bb
+	StatusPipeNotAvailable       Status = 0xc00000ac
+	StatusPipeDisconnected       Status = 0xc00000b0
+	StatusBadNetworkName         Status = 0xc00000cc
+	StatusUserSessionDeleted     Status = 0xc0000203
+)
+
+var statusNames = map[Status]string{
+	StatusSuccess:                "STATUS_SUCCESS",
+	StatusPending:                "STATUS_PENDING",
+	StatusBufferOverflow:         "STATUS_BUFFER_OVERFLOW",
+	StatusInvalidParameter:       "STATUS_INVALID_PARAMETER",
+	StatusMoreProcessingRequired: "STATUS_MORE_PROCESSING_REQUIRED",
+	StatusAccessDenied:           "STATUS_ACCESS_DENIED",
+	StatusObjectNameNotFound:     "STATUS_OBJECT_NAME_NOT_FOUND",
+	StatusLogonFailure:           "STATUS_LOGON_FAILURE",
+	StatusAccountDisabled:        "STATUS_ACCOUNT_DISABLED",
+	StatusNotSupported:           "STATUS_NOT_SUPPORTED",
+	StatusPipeNotAvailable:       "STATUS_PIPE_NOT_AVAILABLE",
+	StatusPipeDisconnected:       "STATUS_PIPE_DISCONNECTED",
+	StatusBadNetworkName:         "STATUS_BAD_NETWORK_NAME",
+	StatusUserSessionDeleted:     "STATUS_USER_SESSION_DELETED",
+}
+
+// Error returns the status name, e.g. "smb: STATUS_ACCESS_DENIED".
+func (s Status) Error() string {
+	if name, ok := statusNames[s]; ok {
+		return "smb: " + name
+	}
+	return fmt.Sprintf("smb: status 0x%08x", uint32(s))
+}
+
+// ErrNotSMB is returned when the peer does not speak SMB 2 or 3.
+var ErrNotSMB = errors.New("smb: not an SMB2 server")
+
+// Share types (MS-SRVS 2.2.2.4), without the special and temporary bits.
+const (
+	ShareTypeDisk   = 0x00000000
+	ShareTypePrint  = 0x00000001
+	ShareTypeDevice = 0x00000002
+	ShareTypeIPC    = 0x00000003
+
+	shareTypeSpecial   = 0x80000000
+	shareTypeTemporary = 0x40000000
+)
+
+// Share is a share listed by the server.
+type Share struct {
+	Name   string
+	Type   uint32
+	Remark string
+}
+
+// Kind returns the share's type name: "disk", "printer", "device" or
+// "ipc".
+func (s Share) Kind() string {
+	switch s.Type &^ (shareTypeSpecial | shareTypeTemporary) {
+	case ShareTypeDisk:
+		return "disk"
+	case ShareTypePrint:
+		return "printer"
+	case ShareTypeDevice:
+		return "device"
+	case ShareTypeIPC:
+		return "ipc"
+	default:
+		return "unknown"
+	}
+}
+
+// Special reports whether the share is an administrative share, such as
+// C$ or ADMIN$.
+func (s Share) Special() bool {
+	return s.Type&shareTypeSpecial != 0 || strings.HasSuffix(s.Name, "$")
+}
@@ -0,0 +1,65 @@
+package smb
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialect_String(t *testing.T) {
+	require.Equal(t, "2.0.2", Dialect202.String())
+	require.Equal(t, "3.1.1", Dialect311.String())
+	require.Equal(t, "0x02ff", Dialect(0x02ff).String())
+}
+
+func TestStatus_Error(t *testing.T) {
+	require.EqualError(t, StatusAccessDenied, "smb: STATUS_ACCESS_DENIED")
+	require.EqualError(t, Status(0xc0000001), "smb: status 0xc0000001")
+}
+
+func TestSyntax(t *testing.T) {
+	// srvsvc v3.0 as it appears in a bind request
+	require.Equal(t, "c84f324b7016d30112785a47bf6ee18803000000", hex.EncodeToString(srvsvcSyntax))
+}
+
+func TestNegTokenInit(t *testing.T) {
+	token := negTokenInit([]byte("NTLMSSP\x00\x01\x00\x00\x00"))
+	require.Equal(t, "602c06062b0601050502a0223020a00e300c060a2b06010401823702020a", hex.EncodeToString(token[:30]))
+	require.Equal(t, []byte("NTLMSSP\x00\x01\x00\x00\x00"), ntlmMessage(token))
+	require.Nil(t, ntlmMessage([]byte{0xa1, 0x00}))
+
+	// Long tokens use the long form of the DER length
+	long := derTLV(0x04, make([]byte, 300))
+	require.Equal(t, []byte{0x04, 0x82, 0x01, 0x2c}, long[:4])
+}
+
+func TestNegotiateRequest(t *testing.T) {
+	var guid [16]byte
+	req := negotiateRequest([]Dialect{Dialect202, Dialect210}, guid)
+	require.Len(t, req, 40)
+	require.Equal(t, "24000200010000000000000000000000", hex.EncodeToString(req[:16]))
+	require.Equal(t, "02021002", hex.EncodeToString(req[36:]))
+
+	// With 3.1.1 the contexts start 8-byte aligned after the dialects
+	req = negotiateRequest(Dialects, guid)
+	require.Equal(t, uint8(headerSize+48), req[28])
+	require.Equal(t, uint8(2), req[32])
+	require.Equal(t, byte(contextPreauthIntegrity), req[48])
+}
+
+func TestParseNetrShareEnumResponse_Truncated(t *testing.T) {
+	var w ndrWriter
+	w.u32(1)
+	w.u32(1)
+	w.u32(0x00020000)
+	w.u32(1)
+	w.u32(0x00020004)
+	w.u32(1)
+	w.u32(0x00020008)
+	_, err := parseNetrShareEnumResponse(w.b)
+	require.EqualError(t, err, "smb: RPC response truncated")
+
+	_, err = parseNetrShareEnumResponse(append(w.b[:8], 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0))
+	require.EqualError(t, err, "smb: NetrShareEnum failed with error 0x00000005")
+}
@@ -0,0 +1,61 @@
+package smb
+
+import (
+	"bytes"
+
+	"github.com/vulntor/vulntor/pkg/ntlm"
+)
+
+// Object identifiers of SPNEGO (1.3.6.1.5.5.2) and NTLMSSP
+// (1.3.6.1.4.1.311.2.2.10), DER encoded.
+var (
+	oidSPNEGO  = []byte{0x2b, 0x06, 0x01, 0x05, 0x05, 0x02}
+	oidNTLMSSP = []byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0x82, 0x37, 0x02, 0x02, 0x0a}
+)
+
+// negTokenInit wraps the first NTLM message in a SPNEGO NegTokenInit
+// (RFC 4178) offering NTLMSSP only.
+func negTokenInit(mechToken []byte) []byte {
+	return derTLV(0x60,
+		derTLV(0x06, oidSPNEGO),
+		derTLV(0xa0, derTLV(0x30,
+			derTLV(0xa0, derTLV(0x30, derTLV(0x06, oidNTLMSSP))),
+			derTLV(0xa2, derTLV(0x04, mechToken)),
+		)),
+	)
+}
+
+// negTokenResp wraps a later NTLM message in a SPNEGO NegTokenResp.
+func negTokenResp(responseToken []byte) []byte {
+	return derTLV(0xa1, derTLV(0x30, derTLV(0xa2, derTLV(0x04, responseToken))))
+}
+
+// ntlmMessage returns the NTLM message inside a SPNEGO token, or nil.
+// Servers wrap it in a NegTokenResp, but some send it bare.
+func ntlmMessage(token []byte) []byte {
+	if i := bytes.Index(token, ntlm.Signature); i >= 0 {
+		return token[i:]
+	}
+	return nil
+}
+
+// derTLV encodes a DER element with the given tag and content.
+func derTLV(tag byte, content ...[]byte) []byte {
+	n := 0
+	for _, c := range content {
+		n += len(c)
+	}
+	b := []byte{tag}
+	switch {
+	case n < 0x80:
+		b = append(b, byte(n))
+	case n < 0x100:
+		b = append(b, 0x81, byte(n))
+	default:
+		b = append(b, 0x82, byte(n>>8), byte(n))
+	}
+	for _, c := range content {
+		b = append(b, c...)
+	}
+	return b
+}
@@ -0,0 +1,304 @@
+package snmp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// ASN.1 BER tags used by SNMP messages, besides the value types.
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagSequence    = 0x30
+
+	tagGetRequest     = 0xa0
+	tagGetNextRequest = 0xa1
+	tagResponse       = 0xa2
+	tagGetBulkRequest = 0xa5
+	tagReport         = 0xa8
+)
+
+var errTruncated = errors.New("snmp: truncated message")
+
+func appendLength(b []byte, n int) []byte {
+	if n < 0x80 {
+		return append(b, byte(n))
+	}
+	var tmp [8]byte
+	i := len(tmp)
+	for n > 0 {
+		i--
+		tmp[i] = byte(n)
+		n >>= 8
+	}
+	b = append(b, 0x80|byte(len(tmp)-i))
+	return append(b, tmp[i:]...)
+}
+
+func appendTLV(b []byte, tag byte, content []byte) []byte {
+	b = append(b, tag)
+	b = appendLength(b, len(content))
+	return append(b, content...)
+}
+
+// encodeInt encodes v as a minimal two's complement integer.
+func encodeInt(v int64) []byte {
+	n := 1
+	for x := v; x > 127 || x < -128; x >>= 8 {
+		n++
+	}
+	out := make([]byte, n)
+	for i := n - 1; i >= 0; i-- {
+		out[i] = byte(v)
+		v >>= 8
+	}
+	return out
+}
+
+// encodeUint encodes v as a minimal non-negative integer.
+func encodeUint(v uint64) []byte {
+	var tmp [9]byte
+	i := len(tmp)
+	for {
+		i--
+		tmp[i] = byte(v)
+		v >>= 8
+		if v == 0 {
+			break
+		}
+	}
+	if tmp[i]&0x80 != 0 {
+		i--
+		tmp[i] = 0
+	}
+	return append([]byte(nil), tmp[i:]...)
+}
+
+func appendBase128(b []byte, v uint64) []byte {
+	var tmp [10]byte
+	i := len(tmp) - 1
+	tmp[i] = byte(v & 0x7f)
+	for v >>= 7; v > 0; v >>= 7 {
+		i--
+		tmp[i] = byte(v&0x7f) | 0x80
+	}
+	return append(b, tmp[i:]...)
+}
+
+func encodeOID(oid OID) ([]byte, error) {
+	if len(oid) < 2 || oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, fmt.Errorf("snmp: invalid OID %s", oid)
+	}
+	out := appendBase128(nil, uint64(oid[0])*40+uint64(oid[1]))
+	for _, arc := range oid[2:] {
+		out = appendBase128(out, uint64(arc))
+	}
+	return out, nil
+}
+
+// readTLV splits the first TLV off data.
+func readTLV(data []byte) (tag byte, content, rest []byte, err error) {
+	if len(data) < 2 {
+		return 0, nil, nil, errTruncated
+	}
+	tag = data[0]
+	length := int(data[1])
+	off := 2
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n == 0 || n > 4 || len(data) < off+n {
+			return 0, nil, nil, fmt.Errorf("snmp: invalid length encoding")
+		}
+		length = 0
+		for _, c := range data[off : off+n] {
+			length = length<<8 | int(c)
+		}
+		off += n
+	}
+	if length < 0 || len(data)-off < length {
+		return 0, nil, nil, errTruncated
+	}
+	return tag, data[off : off+length], data[off+length:], nil
+}
+
+// readExpected reads a TLV and checks its tag.
+func readExpected(data []byte, want byte) (content, rest []byte, err error) {
+	tag, content, rest, err := readTLV(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tag != want {
+		return nil, nil, fmt.Errorf("snmp: unexpected tag 0x%02x, want 0x%02x", tag, want)
+	}
+	return content, rest, nil
+}
+
+func readInt(data []byte) (int64, []byte, error) {
+	content, rest, err := readExpected(data, tagInteger)
+	if err != nil {
+		return 0, nil, err
+	}
+	v, err := decodeInt(content)
+	return v, rest, err
+}
+
+func readOctets(data []byte) ([]byte, []byte, error) {
+	return readExpected(data, tagOctetString)
+}
+
+func decodeInt(content []byte) (int64, error) {
+	if len(content) == 0 || len(content) > 8 {
+		return 0, fmt.Errorf("snmp: invalid integer length %d", len(content))
+	}
+	v := int64(int8(content[0]))
+	for _, c := range content[1:] {
+		v = v<<8 | int64(c)
+	}
+	return v, nil
+}
+
+func decodeUint(content []byte) (uint64, error) {
+	if len(content) == 0 || len(content) > 9 || (len(content) == 9 && content[0] != 0) {
+		return 0, fmt.Errorf("snmp: invalid unsigned integer length %d", len(content))
+	}
+	var v uint64
+	for _, c := range content {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+func decodeOID(content []byte) (OID, error) {
+	var arcs []uint64
+	var v uint64
+	for i, c := range content {
+		if v > 1<<56 {
+			return nil, fmt.Errorf("snmp: OID arc overflows")
+		}
+		v = v<<7 | uint64(c&0x7f)
+		if c&0x80 == 0 {
+			arcs = append(arcs, v)
+			v = 0
+		} else if i == len(content)-1 {
+			return nil, errTruncated
+		}
+	}
+	if len(arcs) == 0 {
+		return nil, fmt.Errorf("snmp: empty OID")
+	}
+
+	oid := make(OID, 0, len(arcs)+1)
+	switch first := arcs[0]; {
+	case first < 40:
+		oid = append(oid, 0, uint32(first))
+	case first < 80:
+		oid = append(oid, 1, uint32(first-40))
+	default:
+		oid = append(oid, 2, uint32(first-80))
+	}
+	for _, arc := range arcs[1:] {
+		if arc > 1<<32-1 {
+			return nil, fmt.Errorf("snmp: OID arc %d out of range", arc)
+		}
+		oid = append(oid, uint32(arc))
+	}
+	return oid, nil
+}
+
+// marshal encodes the variable binding.
+func (v Variable) marshal() ([]byte, error) {
+	oid, err := encodeOID(v.OID)
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	switch v.Type {
+	case TypeNull, TypeNoSuchObject, TypeNoSuchInstance, TypeEndOfMIBView:
+	case TypeInteger:
+		n, ok := v.Value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("snmp: %s value must be int64, got %T", v.Type, v.Value)
+		}
+		content = encodeInt(n)
+	case TypeCounter32, TypeGauge32, TypeTimeTicks, TypeCounter64:
+		n, ok := v.Value.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("snmp: %s value must be uint64, got %T", v.Type, v.Value)
+		}
+		content = encodeUint(n)
+	case TypeOctetString, TypeOpaque:
+		b, ok := v.Value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("snmp: %s value must be []byte, got %T", v.Type, v.Value)
+		}
+		content = b
+	case TypeObjectIdentifier:
+		o, ok := v.Value.(OID)
+		if !ok {
+			return nil, fmt.Errorf("snmp: %s value must be OID, got %T", v.Type, v.Value)
+		}
+		if content, err = encodeOID(o); err != nil {
+			return nil, err
+		}
+	case TypeIPAddress:
+		ip, ok := v.Value.(net.IP)
+		if !ok || ip.To4() == nil {
+			return nil, fmt.Errorf("snmp: %s value must be an IPv4 net.IP, got %T", v.Type, v.Value)
+		}
+		content = ip.To4()
+	default:
+		return nil, fmt.Errorf("snmp: cannot encode %s", v.Type)
+	}
+
+	body := appendTLV(nil, byte(TypeObjectIdentifier), oid)
+	body = appendTLV(body, byte(v.Type), content)
+	return appendTLV(nil, tagSequence, body), nil
+}
+
+func unmarshalVariable(data []byte) (Variable, error) {
+	oidContent, rest, err := readExpected(data, byte(TypeObjectIdentifier))
+	if err != nil {
+		return Variable{}, err
+	}
+	oid, err := decodeOID(oidContent)
+	if err != nil {
+		return Variable{}, err
+	}
+	tag, content, _, err := readTLV(rest)
+	if err != nil {
+		return Variable{}, err
+	}
+
+	v := Variable{OID: oid, Type: Type(tag)}
+	switch v.Type {
+	case TypeNull, TypeNoSuchObject, TypeNoSuchInstance, TypeEndOfMIBView:
+	case TypeInteger:
+		n, err := decodeInt(content)
+		if err != nil {
+			return v, err
+		}
+		v.Value = n
+	case TypeCounter32, TypeGauge32, TypeTimeTicks, TypeCounter64:
+		n, err := decodeUint(content)
+		if err != nil {
+			return v, err
+		}
+		v.Value = n
+	case TypeObjectIdentifier:
+		o, err := decodeOID(content)
+		if err != nil {
+			return v, err
+		}
+		v.Value = o
+	case TypeIPAddress:
+		if len(content) != 4 {
+			return v, fmt.Errorf("snmp: invalid IpAddress length %d", len(content))
+		}
+		v.Value = net.IP(append([]byte(nil), content...))
+	default:
+		v.Value = append([]byte(nil), content...)
+	}
+	return v, nil
+}
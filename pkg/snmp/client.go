@@ -0,0 +1,494 @@
+package snmp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Default request settings.
+const (
+	DefaultTimeout        = 2 * time.Second
+	DefaultRetries        = 1
+	DefaultMaxRepetitions = 10
+)
+
+// ErrNoResponse is returned when the agent does not answer a request
+// within the timeout, after all retries, or the port is unreachable.
+var ErrNoResponse = errors.New("snmp: no response")
+
+// Config configures a client. Community is used by SNMPv1 and SNMPv2c;
+// the user and its auth and privacy settings by SNMPv3.
+type Config struct {
+	Version   Version
+	Community string
+
+	Username     string
+	AuthProtocol AuthProtocol
+	AuthPassword string
+	PrivProtocol PrivProtocol
+	PrivPassword string
+
+	// Timeout bounds each attempt of a request (default 2s)
+	Timeout time.Duration
+	// Retries is the number of retransmissions after a timeout
+	Retries int
+	// MaxRepetitions is the number of rows requested per GetBulk in
+	// walks (default 10)
+	MaxRepetitions int
+	// Dial opens the UDP connection; a net.Dialer when nil
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (c *Config) validate() error {
+	switch c.Version {
+	case Version1, Version2c:
+		if c.Community == "" {
+			return fmt.Errorf("snmp: community is required")
+		}
+	case Version3:
+		if c.Username == "" {
+			return fmt.Errorf("snmp: username is required")
+		}
+		if c.AuthProtocol != AuthNone && len(c.AuthPassword) < 8 {
+			return fmt.Errorf("snmp: auth password must be at least 8 characters")
+		}
+		if c.PrivProtocol != PrivNone {
+			if c.AuthProtocol == AuthNone {
+				return fmt.Errorf("snmp: privacy requires authentication")
+			}
+			if len(c.PrivPassword) < 8 {
+				return fmt.Errorf("snmp: privacy password must be at least 8 characters")
+			}
+		}
+	default:
+		return fmt.Errorf("snmp: unsupported version %s", c.Version)
+	}
+	return nil
+}
+
+// StatusError is a non-zero error status in a response PDU.
+type StatusError struct {
+	Status int
+	Index  int
+}
+
+var statusNames = []string{
+	"noError", "tooBig", "noSuchName", "badValue", "readOnly", "genErr",
+	"noAccess", "wrongType", "wrongLength", "wrongEncoding", "wrongValue",
+	"noCreation", "inconsistentValue", "resourceUnavailable", "commitFailed",
+	"undoFailed", "authorizationError", "notWritable", "inconsistentName",
+}
+
+func (e *StatusError) Error() string {
+	name := fmt.Sprintf("error status %d", e.Status)
+	if e.Status >= 0 && e.Status < len(statusNames) {
+		name = statusNames[e.Status]
+	}
+	return fmt.Sprintf("snmp: %s (index %d)", name, e.Index)
+}
+
+const statusNoSuchName = 2
+
+// ReportError is an SNMPv3 report returned instead of a response, usually
+// because the agent rejected the security parameters.
+type ReportError struct {
+	OID OID
+}
+
+var usmStatsPrefix = OID{1, 3, 6, 1, 6, 3, 15, 1, 1}
+
+var usmStatsReasons = map[uint32]string{
+	1: "unsupported security level",
+	2: "not in time window",
+	3: "unknown user name",
+	4: "unknown engine ID",
+	5: "wrong digest",
+	6: "decryption error",
+}
+
+func (e *ReportError) Error() string {
+	if len(e.OID) == len(usmStatsPrefix)+2 && e.OID.HasPrefix(usmStatsPrefix) {
+		if reason, ok := usmStatsReasons[e.OID[len(usmStatsPrefix)]]; ok {
+			return "snmp: " + reason
+		}
+	}
+	return fmt.Sprintf("snmp: report %s", e.OID)
+}
+
+func (e *ReportError) notInTimeWindow() bool {
+	return e.OID.Compare(append(append(OID{}, usmStatsPrefix...), 2, 0)) == 0
+}
+
+// Client is a connection to one SNMP agent. Requests are sent one at a
+// time; a Client is safe for concurrent use.
+type Client struct {
+	conn net.Conn
+	cfg  Config
+
+	mu        sync.Mutex
+	requestID int32
+	buf       []byte
+
+	// SNMPv3 state of the authoritative engine
+	engineID []byte
+	boots    int32
+	time     int32
+	synced   time.Time
+	authKey  []byte
+	privKey  []byte
+	salt     uint64
+}
+
+// Dial connects to the agent at addr ("host:port"). For SNMPv3 it
+// discovers the agent's engine ID and derives the localized keys.
+func Dial(ctx context.Context, addr string, cfg Config) (*Client, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.Retries < 0 {
+		cfg.Retries = 0
+	}
+	if cfg.MaxRepetitions <= 0 {
+		cfg.MaxRepetitions = DefaultMaxRepetitions
+	}
+
+	dial := cfg.Dial
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	conn, err := dial(ctx, "udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var seed [12]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	c := &Client{
+		conn:      conn,
+		cfg:       cfg,
+		requestID: int32(binary.BigEndian.Uint32(seed[:4]) & 0x7fffffff),
+		salt:      binary.BigEndian.Uint64(seed[4:]),
+		buf:       make([]byte, maxMessageSize),
+	}
+
+	if cfg.Version == Version3 {
+		if err := c.discover(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// EngineID returns the SNMPv3 engine ID of the agent, or nil for SNMPv1
+// and SNMPv2c.
+func (c *Client) EngineID() []byte {
+	return c.engineID
+}
+
+// Get retrieves the given OIDs. Missing objects are returned with an
+// exception type such as TypeNoSuchObject, or, for SNMPv1, as a
+// noSuchName StatusError.
+func (c *Client) Get(ctx context.Context, oids ...OID) ([]Variable, error) {
+	p := &pdu{tag: tagGetRequest}
+	for _, oid := range oids {
+		p.vars = append(p.vars, Variable{OID: oid, Type: TypeNull})
+	}
+	return c.request(ctx, p)
+}
+
+// Walk calls fn for each object in the subtree rooted at root, in order.
+// It uses GetBulk requests, or GetNext for SNMPv1. An error returned by
+// fn stops the walk and is returned.
+func (c *Client) Walk(ctx context.Context, root OID, fn func(Variable) error) error {
+	next := root
+	for {
+		p := &pdu{tag: tagGetBulkRequest, errorIndex: c.cfg.MaxRepetitions}
+		if c.cfg.Version == Version1 {
+			p = &pdu{tag: tagGetNextRequest}
+		}
+		p.vars = []Variable{{OID: next, Type: TypeNull}}
+
+		vars, err := c.request(ctx, p)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.Status == statusNoSuchName && c.cfg.Version == Version1 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(vars) == 0 {
+			return nil
+		}
+
+		for _, v := range vars {
+			if v.Type == TypeEndOfMIBView || !v.OID.HasPrefix(root) {
+				return nil
+			}
+			if v.OID.Compare(next) <= 0 {
+				return fmt.Errorf("snmp: agent returned %s after %s", v.OID, next)
+			}
+			if err := fn(v); err != nil {
+				return err
+			}
+			next = v.OID
+		}
+	}
+}
+
+// request sends p and returns the variables of the response.
+func (c *Client) request(ctx context.Context, p *pdu) ([]Variable, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requestID = (c.requestID + 1) & 0x7fffffff
+	p.requestID = c.requestID
+
+	resp, err := c.exchange(ctx, p)
+	var report *ReportError
+	if errors.As(err, &report) && report.notInTimeWindow() {
+		// The report carried the agent's current boots and time
+		resp, err = c.exchange(ctx, p)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.errorStatus != 0 {
+		return nil, &StatusError{Status: resp.errorStatus, Index: resp.errorIndex}
+	}
+	return resp.vars, nil
+}
+
+// discover learns the agent's engine ID, boots and time from the report to
+// an unauthenticated request (RFC 3414, 4).
+func (c *Client) discover(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requestID = (c.requestID + 1) & 0x7fffffff
+	_, err := c.exchange(ctx, &pdu{tag: tagGetRequest, requestID: c.requestID})
+	var report *ReportError
+	if err != nil && !errors.As(err, &report) {
+		return err
+	}
+	if len(c.engineID) == 0 {
+		return fmt.Errorf("snmp: engine ID discovery failed")
+	}
+
+	if h := c.cfg.AuthProtocol.hash(); h != nil {
+		c.authKey = passwordToKey(h, c.cfg.AuthPassword, c.engineID)
+		if c.cfg.PrivProtocol != PrivNone {
+			c.privKey = passwordToKey(h, c.cfg.PrivPassword, c.engineID)
+		}
+	}
+	return nil
+}
+
+// exchange sends p, retransmitting on timeout, and waits for the message
+// answering it.
+func (c *Client) exchange(ctx context.Context, p *pdu) (*pdu, error) {
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetReadDeadline(time.Now()) })
+	defer stop()
+
+	for attempt := 0; attempt <= c.cfg.Retries; attempt++ {
+		msg, msgID, err := c.encode(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.conn.Write(msg); err != nil {
+			return nil, err
+		}
+
+		deadline := time.Now().Add(c.cfg.Timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := c.conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+
+		for {
+			n, err := c.conn.Read(c.buf)
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				break
+			}
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				// ICMP port unreachable: no agent listens
+				return nil, fmt.Errorf("%w: port unreachable", ErrNoResponse)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := c.decode(c.buf[:n], msgID, p.requestID)
+			if err != nil {
+				return nil, err
+			}
+			if resp != nil {
+				return resp, nil
+			}
+		}
+	}
+	return nil, ErrNoResponse
+}
+
+func (c *Client) encode(p *pdu) (msg []byte, msgID int32, err error) {
+	pduBytes, err := p.marshal()
+	if err != nil {
+		return nil, 0, err
+	}
+	if c.cfg.Version != Version3 {
+		return marshalCommunity(c.cfg.Version, c.cfg.Community, pduBytes), 0, nil
+	}
+
+	c.requestID = (c.requestID + 1) & 0x7fffffff
+	m := &v3Message{
+		msgID:    c.requestID,
+		flags:    flagReportable,
+		engineID: c.engineID,
+		boots:    c.boots,
+		time:     c.engineTime(),
+		data:     marshalScopedPDU(c.engineID, pduBytes),
+	}
+	if c.engineID == nil {
+		// Discovery request: no user and no security
+		return m.marshal(), m.msgID, nil
+	}
+
+	m.user = c.cfg.Username
+	if c.authKey != nil {
+		m.flags |= flagAuth
+		m.authParams = make([]byte, c.cfg.AuthProtocol.macLength())
+	}
+	if c.privKey != nil {
+		m.flags |= flagPriv
+		c.salt++
+		switch c.cfg.PrivProtocol {
+		case PrivDES:
+			m.data, m.privParams, err = encryptDES(c.privKey, m.boots, uint32(c.salt), m.data)
+		case PrivAES:
+			m.data, m.privParams, err = encryptAES(c.privKey, m.boots, m.time, c.salt, m.data)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if c.authKey != nil {
+		m.authParams = messageMAC(c.cfg.AuthProtocol, c.authKey, m.marshal())
+	}
+	return m.marshal(), m.msgID, nil
+}
+
+// decode parses a received message. It returns nil without error for
+// messages that do not answer the outstanding request.
+func (c *Client) decode(msg []byte, msgID, requestID int32) (*pdu, error) {
+	version, err := readVersion(msg)
+	if err != nil || version != c.cfg.Version {
+		return nil, nil
+	}
+	if version != Version3 {
+		_, _, pduBytes, err := unmarshalCommunity(msg)
+		if err != nil {
+			return nil, nil
+		}
+		p, err := unmarshalPDU(pduBytes)
+		if err != nil || p.tag != tagResponse || p.requestID != requestID {
+			return nil, nil
+		}
+		return p, nil
+	}
+
+	m, err := unmarshalV3(msg)
+	if err != nil || m.msgID != msgID {
+		return nil, nil
+	}
+
+	if m.flags&flagAuth != 0 && c.authKey != nil {
+		if len(m.authParams) != c.cfg.AuthProtocol.macLength() {
+			return nil, fmt.Errorf("snmp: invalid message digest length")
+		}
+		zeroed := append([]byte(nil), msg...)
+		clear(zeroed[m.authOffset : m.authOffset+len(m.authParams)])
+		if !hmac.Equal(m.authParams, messageMAC(c.cfg.AuthProtocol, c.authKey, zeroed)) {
+			return nil, fmt.Errorf("snmp: response failed authentication")
+		}
+	}
+
+	data := m.data
+	if m.flags&flagPriv != 0 {
+		if c.privKey == nil {
+			return nil, fmt.Errorf("snmp: unexpected encrypted response")
+		}
+		switch c.cfg.PrivProtocol {
+		case PrivDES:
+			data, err = decryptDES(c.privKey, m.privParams, m.data)
+		case PrivAES:
+			data, err = decryptAES(c.privKey, m.boots, m.time, m.privParams, m.data)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	pduBytes, err := unmarshalScopedPDU(data)
+	if err != nil {
+		return nil, err
+	}
+	p, err := unmarshalPDU(pduBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tag == tagReport {
+		if len(p.vars) == 0 {
+			return nil, fmt.Errorf("snmp: empty report")
+		}
+		// Reports to discovery requests and stale requests carry the
+		// agent's engine state
+		report := &ReportError{OID: p.vars[0].OID}
+		if c.engineID == nil || report.notInTimeWindow() {
+			if c.engineID == nil {
+				c.engineID = m.engineID
+			}
+			c.boots, c.time, c.synced = m.boots, m.time, time.Now()
+		}
+		return nil, report
+	}
+	if p.tag != tagResponse || p.requestID != requestID {
+		return nil, nil
+	}
+	if m.flags&flagAuth != 0 {
+		c.boots, c.time, c.synced = m.boots, m.time, time.Now()
+	}
+	return p, nil
+}
+
+// engineTime estimates the agent's current engine time.
+func (c *Client) engineTime() int32 {
+	if c.synced.IsZero() {
+		return c.time
+	}
+	return c.time + int32(time.Since(c.synced)/time.Second)
+}
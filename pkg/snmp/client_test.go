@@ -0,0 +1,395 @@
+package snmp
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"net"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testAgent is a minimal SNMP agent serving a fixed MIB. It accepts the
+// community "public" and the SNMPv3 user "audit" with the configured
+// auth and privacy settings.
+type testAgent struct {
+	t    *testing.T
+	conn net.PacketConn
+	mib  []Variable
+
+	engineID []byte
+	auth     AuthProtocol
+	priv     PrivProtocol
+	authKey  []byte
+	privKey  []byte
+	boots    atomic.Int32
+	time     int32
+
+	// silent drops requests, to exercise timeouts
+	silent atomic.Bool
+}
+
+var testMIB = []Variable{
+	{OID: MustParseOID("1.3.6.1.2.1.1.1.0"), Type: TypeOctetString, Value: []byte("Cisco IOS Software, C2960 Software, Version 15.0(2)SE11")},
+	{OID: MustParseOID("1.3.6.1.2.1.1.2.0"), Type: TypeObjectIdentifier, Value: MustParseOID("1.3.6.1.4.1.9.1.1208")},
+	{OID: MustParseOID("1.3.6.1.2.1.1.5.0"), Type: TypeOctetString, Value: []byte("sw-core-01")},
+	{OID: MustParseOID("1.3.6.1.2.1.2.2.1.2.1"), Type: TypeOctetString, Value: []byte("Vlan1")},
+	{OID: MustParseOID("1.3.6.1.2.1.2.2.1.2.2"), Type: TypeOctetString, Value: []byte("GigabitEthernet0/1")},
+	{OID: MustParseOID("1.3.6.1.2.1.2.2.1.2.3"), Type: TypeOctetString, Value: []byte("GigabitEthernet0/2")},
+	{OID: MustParseOID("1.3.6.1.2.1.2.2.1.3.1"), Type: TypeInteger, Value: int64(53)},
+}
+
+func startTestAgent(t *testing.T, auth AuthProtocol, authPassword string, priv PrivProtocol, privPassword string) (*testAgent, string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	a := &testAgent{t: t, conn: conn, mib: testMIB, engineID: []byte{0x80, 0x00, 0x1f, 0x88, 0x04, 't', 'e', 's', 't'}, auth: auth, priv: priv, time: 1200}
+	a.boots.Store(4)
+	if h := auth.hash(); h != nil {
+		a.authKey = passwordToKey(h, authPassword, a.engineID)
+		if priv != PrivNone {
+			a.privKey = passwordToKey(h, privPassword, a.engineID)
+		}
+	}
+
+	go func() {
+		buf := make([]byte, maxMessageSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if a.silent.Load() {
+				continue
+			}
+			if resp := a.handle(append([]byte(nil), buf[:n]...)); resp != nil {
+				_, _ = conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+	return a, conn.LocalAddr().String()
+}
+
+func (a *testAgent) handle(msg []byte) []byte {
+	version, err := readVersion(msg)
+	if err != nil {
+		return nil
+	}
+	if version != Version3 {
+		_, community, pduBytes, err := unmarshalCommunity(msg)
+		if err != nil || community != "public" {
+			return nil
+		}
+		p, err := unmarshalPDU(pduBytes)
+		if err != nil {
+			return nil
+		}
+		b, _ := a.respond(version, p).marshal()
+		return marshalCommunity(version, community, b)
+	}
+	return a.handleV3(msg)
+}
+
+func (a *testAgent) handleV3(msg []byte) []byte {
+	m, err := unmarshalV3(msg)
+	if err != nil {
+		return nil
+	}
+	report := func(stat uint32, flags byte) []byte {
+		p := &pdu{tag: tagReport, vars: []Variable{{OID: append(append(OID{}, usmStatsPrefix...), stat, 0), Type: TypeCounter32, Value: uint64(1)}}}
+		return a.encodeV3(m.msgID, flags, p)
+	}
+
+	if len(m.engineID) == 0 {
+		return report(4, 0)
+	}
+	if m.user != "audit" {
+		return report(3, 0)
+	}
+	if m.flags&flagAuth == 0 && a.authKey != nil {
+		return report(1, 0)
+	}
+	if a.authKey != nil {
+		zeroed := append([]byte(nil), msg...)
+		clear(zeroed[m.authOffset : m.authOffset+len(m.authParams)])
+		if !hmac.Equal(m.authParams, messageMAC(a.auth, a.authKey, zeroed)) {
+			return report(5, 0)
+		}
+		if m.boots != a.boots.Load() || m.time < a.time-150 || m.time > a.time+150 {
+			return report(2, flagAuth)
+		}
+	}
+
+	data := m.data
+	if m.flags&flagPriv != 0 {
+		switch a.priv {
+		case PrivDES:
+			data, err = decryptDES(a.privKey, m.privParams, m.data)
+		case PrivAES:
+			data, err = decryptAES(a.privKey, m.boots, m.time, m.privParams, m.data)
+		default:
+			return report(1, flagAuth)
+		}
+		if err != nil {
+			return report(6, flagAuth)
+		}
+	}
+	pduBytes, err := unmarshalScopedPDU(data)
+	if err != nil {
+		return report(6, flagAuth)
+	}
+	p, err := unmarshalPDU(pduBytes)
+	if err != nil {
+		return nil
+	}
+	return a.encodeV3(m.msgID, m.flags&(flagAuth|flagPriv), a.respond(Version3, p))
+}
+
+func (a *testAgent) encodeV3(msgID int32, flags byte, p *pdu) []byte {
+	pduBytes, err := p.marshal()
+	require.NoError(a.t, err)
+	m := &v3Message{
+		msgID:    msgID,
+		flags:    flags,
+		engineID: a.engineID,
+		boots:    a.boots.Load(),
+		time:     a.time,
+		user:     "audit",
+		data:     marshalScopedPDU(a.engineID, pduBytes),
+	}
+	if flags&flagPriv != 0 {
+		switch a.priv {
+		case PrivDES:
+			m.data, m.privParams, err = encryptDES(a.privKey, m.boots, 99, m.data)
+		case PrivAES:
+			m.data, m.privParams, err = encryptAES(a.privKey, m.boots, m.time, 99, m.data)
+		}
+		require.NoError(a.t, err)
+	}
+	if flags&flagAuth != 0 {
+		m.authParams = make([]byte, a.auth.macLength())
+		m.authParams = messageMAC(a.auth, a.authKey, m.marshal())
+	}
+	return m.marshal()
+}
+
+func (a *testAgent) respond(version Version, req *pdu) *pdu {
+	resp := &pdu{tag: tagResponse, requestID: req.requestID}
+	for i, v := range req.vars {
+		switch req.tag {
+		case tagGetRequest:
+			found := Variable{OID: v.OID, Type: TypeNoSuchObject}
+			for _, obj := range a.mib {
+				if obj.OID.Compare(v.OID) == 0 {
+					found = obj
+				}
+			}
+			if version == Version1 && !found.Exists() {
+				return &pdu{tag: tagResponse, requestID: req.requestID, errorStatus: statusNoSuchName, errorIndex: i + 1, vars: req.vars}
+			}
+			resp.vars = append(resp.vars, found)
+		case tagGetNextRequest, tagGetBulkRequest:
+			rows := 1
+			if req.tag == tagGetBulkRequest {
+				rows = req.errorIndex
+			}
+			next := v.OID
+			for range rows {
+				idx := sort.Search(len(a.mib), func(j int) bool { return a.mib[j].OID.Compare(next) > 0 })
+				if idx == len(a.mib) {
+					if version == Version1 {
+						return &pdu{tag: tagResponse, requestID: req.requestID, errorStatus: statusNoSuchName, errorIndex: i + 1, vars: req.vars}
+					}
+					resp.vars = append(resp.vars, Variable{OID: next, Type: TypeEndOfMIBView})
+					break
+				}
+				resp.vars = append(resp.vars, a.mib[idx])
+				next = a.mib[idx].OID
+			}
+		}
+	}
+	return resp
+}
+
+func walkAll(t *testing.T, c *Client, root string) []string {
+	t.Helper()
+	var got []string
+	require.NoError(t, c.Walk(context.Background(), MustParseOID(root), func(v Variable) error {
+		got = append(got, v.OID.String()+"="+v.String())
+		return nil
+	}))
+	return got
+}
+
+func TestClient_Community(t *testing.T) {
+	_, addr := startTestAgent(t, AuthNone, "", PrivNone, "")
+
+	for _, version := range []Version{Version1, Version2c} {
+		t.Run(version.String(), func(t *testing.T) {
+			c, err := Dial(context.Background(), addr, Config{Version: version, Community: "public", MaxRepetitions: 2})
+			require.NoError(t, err)
+			defer func() { _ = c.Close() }()
+
+			vars, err := c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.1.0"), MustParseOID("1.3.6.1.2.1.1.5.0"))
+			require.NoError(t, err)
+			require.Len(t, vars, 2)
+			require.Equal(t, "Cisco IOS Software, C2960 Software, Version 15.0(2)SE11", vars[0].String())
+			require.Equal(t, "sw-core-01", vars[1].String())
+
+			require.Equal(t, []string{
+				"1.3.6.1.2.1.2.2.1.2.1=Vlan1",
+				"1.3.6.1.2.1.2.2.1.2.2=GigabitEthernet0/1",
+				"1.3.6.1.2.1.2.2.1.2.3=GigabitEthernet0/2",
+			}, walkAll(t, c, "1.3.6.1.2.1.2.2.1.2"))
+
+			// Walking past the end of the MIB stops cleanly
+			require.Equal(t, []string{"1.3.6.1.2.1.2.2.1.3.1=53"}, walkAll(t, c, "1.3.6.1.2.1.2.2.1.3"))
+			require.Empty(t, walkAll(t, c, "1.3.6.1.2.1.25.6.3.1.2"))
+		})
+	}
+}
+
+func TestClient_GetMissing(t *testing.T) {
+	_, addr := startTestAgent(t, AuthNone, "", PrivNone, "")
+	missing := MustParseOID("1.3.6.1.2.1.1.9.0")
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version2c, Community: "public"})
+	require.NoError(t, err)
+	vars, err := c.Get(context.Background(), missing)
+	require.NoError(t, err)
+	require.False(t, vars[0].Exists())
+
+	c, err = Dial(context.Background(), addr, Config{Version: Version1, Community: "public"})
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), missing)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	require.Equal(t, "snmp: noSuchName (index 1)", statusErr.Error())
+}
+
+func TestClient_CustomDial(t *testing.T) {
+	_, addr := startTestAgent(t, AuthNone, "", PrivNone, "")
+	var dialed []string
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, network+" "+addr)
+		return (&net.Dialer{}).DialContext(ctx, network, addr)
+	}
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version2c, Community: "public", Dial: dial})
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+	_, err = c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.5.0"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"udp " + addr}, dialed)
+}
+
+func TestClient_WrongCommunityTimesOut(t *testing.T) {
+	_, addr := startTestAgent(t, AuthNone, "", PrivNone, "")
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version2c, Community: "private", Timeout: 50 * time.Millisecond, Retries: 1})
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.1.0"))
+	require.ErrorIs(t, err, ErrNoResponse)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	agent, addr := startTestAgent(t, AuthNone, "", PrivNone, "")
+	agent.silent.Store(true)
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version2c, Community: "public", Timeout: time.Minute})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = c.Get(ctx, MustParseOID("1.3.6.1.2.1.1.1.0"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_V3(t *testing.T) {
+	cases := []struct {
+		name string
+		auth AuthProtocol
+		priv PrivProtocol
+	}{
+		{"noAuthNoPriv", AuthNone, PrivNone},
+		{"authNoPriv MD5", AuthMD5, PrivNone},
+		{"authNoPriv SHA512", AuthSHA512, PrivNone},
+		{"authPriv SHA DES", AuthSHA, PrivDES},
+		{"authPriv SHA256 AES", AuthSHA256, PrivAES},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			agent, addr := startTestAgent(t, tc.auth, "authpass123", tc.priv, "privpass123")
+
+			c, err := Dial(context.Background(), addr, Config{
+				Version:      Version3,
+				Username:     "audit",
+				AuthProtocol: tc.auth,
+				AuthPassword: "authpass123",
+				PrivProtocol: tc.priv,
+				PrivPassword: "privpass123",
+			})
+			require.NoError(t, err)
+			defer func() { _ = c.Close() }()
+			require.Equal(t, agent.engineID, c.EngineID())
+
+			vars, err := c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.5.0"))
+			require.NoError(t, err)
+			require.Equal(t, "sw-core-01", vars[0].String())
+			require.Len(t, walkAll(t, c, "1.3.6.1.2.1.2.2.1.2"), 3)
+		})
+	}
+}
+
+func TestClient_V3_TimeWindowResync(t *testing.T) {
+	agent, addr := startTestAgent(t, AuthSHA, "authpass123", PrivAES, "privpass123")
+	c, err := Dial(context.Background(), addr, Config{Version: Version3, Username: "audit", AuthProtocol: AuthSHA, AuthPassword: "authpass123", PrivProtocol: PrivAES, PrivPassword: "privpass123"})
+	require.NoError(t, err)
+
+	// The agent rebooted: the next request is rejected as stale, and
+	// retried with the engine state from the report
+	agent.boots.Add(1)
+	vars, err := c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.5.0"))
+	require.NoError(t, err)
+	require.Equal(t, "sw-core-01", vars[0].String())
+}
+
+func TestClient_V3_Errors(t *testing.T) {
+	_, addr := startTestAgent(t, AuthSHA, "authpass123", PrivNone, "")
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version3, Username: "audit", AuthProtocol: AuthSHA, AuthPassword: "wrongpass1"})
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.5.0"))
+	var report *ReportError
+	require.True(t, errors.As(err, &report))
+	require.EqualError(t, err, "snmp: wrong digest")
+
+	c, err = Dial(context.Background(), addr, Config{Version: Version3, Username: "nobody", AuthProtocol: AuthSHA, AuthPassword: "authpass123"})
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.5.0"))
+	require.EqualError(t, err, "snmp: unknown user name")
+
+	_, err = Dial(context.Background(), addr, Config{Version: Version3, Username: "audit", AuthProtocol: AuthSHA, AuthPassword: "short"})
+	require.EqualError(t, err, "snmp: auth password must be at least 8 characters")
+	_, err = Dial(context.Background(), addr, Config{Version: Version2c})
+	require.EqualError(t, err, "snmp: community is required")
+}
+
+func TestClient_PortUnreachable(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	c, err := Dial(context.Background(), addr, Config{Version: Version2c, Community: "public", Timeout: time.Second})
+	require.NoError(t, err)
+	_, err = c.Get(context.Background(), MustParseOID("1.3.6.1.2.1.1.1.0"))
+	require.ErrorIs(t, err, ErrNoResponse)
+}
@@ -0,0 +1,273 @@
+package snmp
+
+import (
+	"fmt"
+)
+
+// pdu is a protocol data unit. For GetBulk requests errorStatus and
+// errorIndex carry non-repeaters and max-repetitions.
+type pdu struct {
+	tag         byte
+	requestID   int32
+	errorStatus int
+	errorIndex  int
+	vars        []Variable
+}
+
+func (p *pdu) marshal() ([]byte, error) {
+	var list []byte
+	for _, v := range p.vars {
+		b, err := v.marshal()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, b...)
+	}
+
+	body := appendTLV(nil, tagInteger, encodeInt(int64(p.requestID)))
+	body = appendTLV(body, tagInteger, encodeInt(int64(p.errorStatus)))
+	body = appendTLV(body, tagInteger, encodeInt(int64(p.errorIndex)))
+	body = appendTLV(body, tagSequence, list)
+	return appendTLV(nil, p.tag, body), nil
+}
+
+func unmarshalPDU(data []byte) (*pdu, error) {
+	tag, body, _, err := readTLV(data)
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case tagGetRequest, tagGetNextRequest, tagResponse, tagGetBulkRequest, tagReport:
+	default:
+		return nil, fmt.Errorf("snmp: unsupported PDU type 0x%02x", tag)
+	}
+
+	p := &pdu{tag: tag}
+	var n int64
+	if n, body, err = readInt(body); err != nil {
+		return nil, err
+	}
+	p.requestID = int32(n)
+	if n, body, err = readInt(body); err != nil {
+		return nil, err
+	}
+	p.errorStatus = int(n)
+	if n, body, err = readInt(body); err != nil {
+		return nil, err
+	}
+	p.errorIndex = int(n)
+
+	list, _, err := readExpected(body, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	for len(list) > 0 {
+		var vb []byte
+		if vb, list, err = readExpected(list, tagSequence); err != nil {
+			return nil, err
+		}
+		v, err := unmarshalVariable(vb)
+		if err != nil {
+			return nil, err
+		}
+		p.vars = append(p.vars, v)
+	}
+	return p, nil
+}
+
+// marshalCommunity wraps an encoded PDU in an SNMPv1 or SNMPv2c message.
+func marshalCommunity(version Version, community string, pduBytes []byte) []byte {
+	body := appendTLV(nil, tagInteger, encodeInt(int64(version)))
+	body = appendTLV(body, tagOctetString, []byte(community))
+	body = append(body, pduBytes...)
+	return appendTLV(nil, tagSequence, body)
+}
+
+// readVersion returns the version of an SNMP message.
+func readVersion(data []byte) (Version, error) {
+	body, _, err := readExpected(data, tagSequence)
+	if err != nil {
+		return 0, err
+	}
+	n, _, err := readInt(body)
+	return Version(n), err
+}
+
+func unmarshalCommunity(data []byte) (version Version, community string, pduBytes []byte, err error) {
+	body, _, err := readExpected(data, tagSequence)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	n, body, err := readInt(body)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	c, body, err := readOctets(body)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	return Version(n), string(c), body, nil
+}
+
+// SNMPv3 message flags.
+const (
+	flagAuth       = 0x01
+	flagPriv       = 0x02
+	flagReportable = 0x04
+)
+
+const (
+	securityModelUSM = 3
+	maxMessageSize   = 65507
+)
+
+// v3Message is an SNMPv3 message with user-based security parameters.
+// data holds the encoded scoped PDU, or its ciphertext when the priv flag
+// is set.
+type v3Message struct {
+	msgID      int32
+	flags      byte
+	engineID   []byte
+	boots      int32
+	time       int32
+	user       string
+	authParams []byte
+	privParams []byte
+	data       []byte
+
+	// authOffset is the offset of authParams in the message it was
+	// unmarshaled from, used to verify the message digest.
+	authOffset int
+}
+
+func (m *v3Message) marshal() []byte {
+	header := appendTLV(nil, tagInteger, encodeInt(int64(m.msgID)))
+	header = appendTLV(header, tagInteger, encodeInt(maxMessageSize))
+	header = appendTLV(header, tagOctetString, []byte{m.flags})
+	header = appendTLV(header, tagInteger, encodeInt(securityModelUSM))
+
+	usm := appendTLV(nil, tagOctetString, m.engineID)
+	usm = appendTLV(usm, tagInteger, encodeInt(int64(m.boots)))
+	usm = appendTLV(usm, tagInteger, encodeInt(int64(m.time)))
+	usm = appendTLV(usm, tagOctetString, []byte(m.user))
+	usm = appendTLV(usm, tagOctetString, m.authParams)
+	usm = appendTLV(usm, tagOctetString, m.privParams)
+
+	body := appendTLV(nil, tagInteger, encodeInt(int64(Version3)))
+	body = appendTLV(body, tagSequence, header)
+	body = appendTLV(body, tagOctetString, appendTLV(nil, tagSequence, usm))
+	if m.flags&flagPriv != 0 {
+		body = appendTLV(body, tagOctetString, m.data)
+	} else {
+		body = append(body, m.data...)
+	}
+	return appendTLV(nil, tagSequence, body)
+}
+
+func unmarshalV3(data []byte) (*v3Message, error) {
+	body, _, err := readExpected(data, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	version, body, err := readInt(body)
+	if err != nil {
+		return nil, err
+	}
+	if Version(version) != Version3 {
+		return nil, fmt.Errorf("snmp: unexpected version %d", version)
+	}
+
+	m := &v3Message{}
+	header, body, err := readExpected(body, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	n, header, err := readInt(header)
+	if err != nil {
+		return nil, err
+	}
+	m.msgID = int32(n)
+	if _, header, err = readInt(header); err != nil {
+		return nil, err
+	}
+	flags, header, err := readOctets(header)
+	if err != nil {
+		return nil, err
+	}
+	if len(flags) != 1 {
+		return nil, fmt.Errorf("snmp: invalid message flags")
+	}
+	m.flags = flags[0]
+	if n, _, err = readInt(header); err != nil {
+		return nil, err
+	}
+	if n != securityModelUSM {
+		return nil, fmt.Errorf("snmp: unsupported security model %d", n)
+	}
+
+	secParams, body, err := readOctets(body)
+	if err != nil {
+		return nil, err
+	}
+	usm, _, err := readExpected(secParams, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	if m.engineID, usm, err = readOctets(usm); err != nil {
+		return nil, err
+	}
+	if n, usm, err = readInt(usm); err != nil {
+		return nil, err
+	}
+	m.boots = int32(n)
+	if n, usm, err = readInt(usm); err != nil {
+		return nil, err
+	}
+	m.time = int32(n)
+	user, usm, err := readOctets(usm)
+	if err != nil {
+		return nil, err
+	}
+	m.user = string(user)
+	if m.authParams, usm, err = readOctets(usm); err != nil {
+		return nil, err
+	}
+	// authParams is a subslice of data, so the difference in capacity is
+	// its offset
+	m.authOffset = cap(data) - cap(m.authParams)
+	if m.privParams, _, err = readOctets(usm); err != nil {
+		return nil, err
+	}
+
+	if m.flags&flagPriv != 0 {
+		if m.data, _, err = readOctets(body); err != nil {
+			return nil, err
+		}
+	} else {
+		m.data = body
+	}
+	return m, nil
+}
+
+// marshalScopedPDU encodes a PDU with its context. The context engine ID
+// is the authoritative engine's ID and the context name is empty.
+func marshalScopedPDU(engineID, pduBytes []byte) []byte {
+	body := appendTLV(nil, tagOctetString, engineID)
+	body = appendTLV(body, tagOctetString, nil)
+	body = append(body, pduBytes...)
+	return appendTLV(nil, tagSequence, body)
+}
+
+func unmarshalScopedPDU(data []byte) ([]byte, error) {
+	body, _, err := readExpected(data, tagSequence)
+	if err != nil {
+		return nil, err
+	}
+	if _, body, err = readOctets(body); err != nil {
+		return nil, err
+	}
+	if _, body, err = readOctets(body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,243 @@
+// Package snmp implements the SNMP manager side needed to collect data from
+// network devices: Get and Walk requests over UDP with SNMPv1 and SNMPv2c
+// community strings, or SNMPv3 user-based security (RFC 3414) with optional
+// authentication and privacy.
+package snmp
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Version is an SNMP protocol version as carried in the message header.
+type Version int
+
+// Supported SNMP versions.
+const (
+	Version1  Version = 0
+	Version2c Version = 1
+	Version3  Version = 3
+)
+
+func (v Version) String() string {
+	switch v {
+	case Version1:
+		return "1"
+	case Version2c:
+		return "2c"
+	case Version3:
+		return "3"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(v))
+	}
+}
+
+// ParseVersion parses "1", "2c" or "3", with an optional "v" prefix.
+func ParseVersion(s string) (Version, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(s)), "v") {
+	case "1":
+		return Version1, nil
+	case "2c", "2":
+		return Version2c, nil
+	case "3":
+		return Version3, nil
+	default:
+		return 0, fmt.Errorf("unsupported SNMP version %q", s)
+	}
+}
+
+// OID is an object identifier.
+type OID []uint32
+
+// ParseOID parses a dotted OID such as "1.3.6.1.2.1.1.1.0". A leading dot
+// is allowed.
+func ParseOID(s string) (OID, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "."), ".")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	oid := make(OID, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid[i] = uint32(n)
+	}
+	return oid, nil
+}
+
+// MustParseOID is like ParseOID but panics on invalid input. It is meant
+// for OID constants.
+func MustParseOID(s string) OID {
+	oid, err := ParseOID(s)
+	if err != nil {
+		panic(err)
+	}
+	return oid
+}
+
+func (o OID) String() string {
+	var b strings.Builder
+	for i, arc := range o {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(strconv.FormatUint(uint64(arc), 10))
+	}
+	return b.String()
+}
+
+// HasPrefix reports whether o lies within the subtree rooted at prefix.
+func (o OID) HasPrefix(prefix OID) bool {
+	if len(o) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if o[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare orders OIDs lexicographically, as agents order them for walks.
+func (o OID) Compare(other OID) int {
+	for i := 0; i < len(o) && i < len(other); i++ {
+		switch {
+		case o[i] < other[i]:
+			return -1
+		case o[i] > other[i]:
+			return 1
+		}
+	}
+	switch {
+	case len(o) < len(other):
+		return -1
+	case len(o) > len(other):
+		return 1
+	}
+	return 0
+}
+
+// Type is the type of a variable binding value.
+type Type byte
+
+// Value types and exceptions of variable bindings.
+const (
+	TypeInteger          Type = 0x02
+	TypeOctetString      Type = 0x04
+	TypeNull             Type = 0x05
+	TypeObjectIdentifier Type = 0x06
+	TypeIPAddress        Type = 0x40
+	TypeCounter32        Type = 0x41
+	TypeGauge32          Type = 0x42
+	TypeTimeTicks        Type = 0x43
+	TypeOpaque           Type = 0x44
+	TypeCounter64        Type = 0x46
+	TypeNoSuchObject     Type = 0x80
+	TypeNoSuchInstance   Type = 0x81
+	TypeEndOfMIBView     Type = 0x82
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeInteger:
+		return "INTEGER"
+	case TypeOctetString:
+		return "OCTET STRING"
+	case TypeNull:
+		return "NULL"
+	case TypeObjectIdentifier:
+		return "OBJECT IDENTIFIER"
+	case TypeIPAddress:
+		return "IpAddress"
+	case TypeCounter32:
+		return "Counter32"
+	case TypeGauge32:
+		return "Gauge32"
+	case TypeTimeTicks:
+		return "TimeTicks"
+	case TypeOpaque:
+		return "Opaque"
+	case TypeCounter64:
+		return "Counter64"
+	case TypeNoSuchObject:
+		return "noSuchObject"
+	case TypeNoSuchInstance:
+		return "noSuchInstance"
+	case TypeEndOfMIBView:
+		return "endOfMibView"
+	default:
+		return fmt.Sprintf("type(0x%02x)", byte(t))
+	}
+}
+
+// Variable is a variable binding. Value is an int64 for INTEGER, a uint64
+// for counters, gauges and time ticks, a []byte for OCTET STRING and
+// Opaque, an OID for OBJECT IDENTIFIER, a net.IP for IpAddress and nil
+// otherwise.
+type Variable struct {
+	OID   OID
+	Type  Type
+	Value any
+}
+
+// Exists reports whether the agent returned a value rather than an
+// exception such as noSuchObject.
+func (v Variable) Exists() bool {
+	switch v.Type {
+	case TypeNull, TypeNoSuchObject, TypeNoSuchInstance, TypeEndOfMIBView:
+		return false
+	}
+	return true
+}
+
+// String formats the value for display. Octet strings are returned as
+// text, or as colon-separated hex when they are not printable.
+func (v Variable) String() string {
+	switch val := v.Value.(type) {
+	case []byte:
+		if isPrintable(val) {
+			return strings.TrimRight(string(val), "\x00")
+		}
+		return hexString(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case uint64:
+		return strconv.FormatUint(val, 10)
+	case OID:
+		return val.String()
+	case net.IP:
+		return val.String()
+	default:
+		return ""
+	}
+}
+
+func isPrintable(b []byte) bool {
+	trimmed := strings.TrimRight(string(b), "\x00")
+	if !utf8.ValidString(trimmed) {
+		return false
+	}
+	for _, r := range trimmed {
+		if r == '\n' || r == '\r' || r == '\t' {
+			continue
+		}
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+func hexString(b []byte) string {
+	parts := make([]string, len(b))
+	for i, c := range b {
+		parts[i] = fmt.Sprintf("%02x", c)
+	}
+	return strings.Join(parts, ":")
+}
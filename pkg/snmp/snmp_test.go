@@ -0,0 +1,137 @@
+package snmp
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOID(t *testing.T) {
+	oid, err := ParseOID(".1.3.6.1.2.1.1.1.0")
+	require.NoError(t, err)
+	require.Equal(t, OID{1, 3, 6, 1, 2, 1, 1, 1, 0}, oid)
+	require.Equal(t, "1.3.6.1.2.1.1.1.0", oid.String())
+
+	for _, s := range []string{"", "1", "1.3.x", "1.3.-1", "1.3.4294967296"} {
+		_, err := ParseOID(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestOID_HasPrefixAndCompare(t *testing.T) {
+	ifDescr := MustParseOID("1.3.6.1.2.1.2.2.1.2")
+	require.True(t, MustParseOID("1.3.6.1.2.1.2.2.1.2.1").HasPrefix(ifDescr))
+	require.False(t, MustParseOID("1.3.6.1.2.1.2.2.1.3.1").HasPrefix(ifDescr))
+	require.False(t, MustParseOID("1.3.6.1").HasPrefix(ifDescr))
+
+	require.Equal(t, -1, MustParseOID("1.3.6.1.2").Compare(MustParseOID("1.3.6.1.10")))
+	require.Equal(t, -1, MustParseOID("1.3.6").Compare(MustParseOID("1.3.6.1")))
+	require.Equal(t, 1, MustParseOID("1.3.7").Compare(MustParseOID("1.3.6.1")))
+	require.Equal(t, 0, ifDescr.Compare(MustParseOID("1.3.6.1.2.1.2.2.1.2")))
+}
+
+func TestParseVersion(t *testing.T) {
+	for s, want := range map[string]Version{"1": Version1, "v1": Version1, "2c": Version2c, "V2c": Version2c, "3": Version3} {
+		v, err := ParseVersion(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, v, s)
+	}
+	_, err := ParseVersion("4")
+	require.Error(t, err)
+}
+
+func TestParseProtocols(t *testing.T) {
+	auth, err := ParseAuthProtocol("sha1")
+	require.NoError(t, err)
+	require.Equal(t, AuthSHA, auth)
+	auth, err = ParseAuthProtocol("SHA-256")
+	require.NoError(t, err)
+	require.Equal(t, AuthSHA256, auth)
+	_, err = ParseAuthProtocol("sha3")
+	require.Error(t, err)
+
+	priv, err := ParsePrivProtocol("aes128")
+	require.NoError(t, err)
+	require.Equal(t, PrivAES, priv)
+	priv, err = ParsePrivProtocol("")
+	require.NoError(t, err)
+	require.Equal(t, PrivNone, priv)
+	_, err = ParsePrivProtocol("aes256")
+	require.Error(t, err)
+}
+
+func TestPDU_Marshal(t *testing.T) {
+	// SNMPv2c GetRequest for sysDescr.0 with community "public"
+	p := &pdu{tag: tagGetRequest, requestID: 0x1234, vars: []Variable{{OID: MustParseOID("1.3.6.1.2.1.1.1.0"), Type: TypeNull}}}
+	b, err := p.marshal()
+	require.NoError(t, err)
+	msg := marshalCommunity(Version2c, "public", b)
+
+	want, _ := hex.DecodeString("302702010104067075626c6963a01a020212340201000201003" +
+		"00e300c06082b060102010101000500")
+	require.Equal(t, want, msg)
+}
+
+func TestPDU_RoundTrip(t *testing.T) {
+	p := &pdu{
+		tag:       tagResponse,
+		requestID: 2147483647,
+		vars: []Variable{
+			{OID: MustParseOID("1.3.6.1.2.1.1.1.0"), Type: TypeOctetString, Value: []byte("Cisco IOS Software")},
+			{OID: MustParseOID("1.3.6.1.2.1.1.2.0"), Type: TypeObjectIdentifier, Value: MustParseOID("1.3.6.1.4.1.9.1.1208")},
+			{OID: MustParseOID("1.3.6.1.2.1.1.3.0"), Type: TypeTimeTicks, Value: uint64(4294967295)},
+			{OID: MustParseOID("1.3.6.1.2.1.2.2.1.3.1"), Type: TypeInteger, Value: int64(-129)},
+			{OID: MustParseOID("1.3.6.1.2.1.31.1.1.1.6.1"), Type: TypeCounter64, Value: uint64(1) << 63},
+			{OID: MustParseOID("1.3.6.1.2.1.4.20.1.1.10.0.0.1"), Type: TypeIPAddress, Value: net.IPv4(10, 0, 0, 1).To4()},
+			{OID: MustParseOID("1.3.6.1.2.1.1.9.0"), Type: TypeNoSuchObject},
+		},
+	}
+	b, err := p.marshal()
+	require.NoError(t, err)
+
+	got, err := unmarshalPDU(b)
+	require.NoError(t, err)
+	require.Equal(t, p, got)
+}
+
+func TestVariable_String(t *testing.T) {
+	require.Equal(t, "RouterOS CCR1009", Variable{Type: TypeOctetString, Value: []byte("RouterOS CCR1009\x00")}.String())
+	require.Equal(t, "00:1a:2b:3c:4d:5e", Variable{Type: TypeOctetString, Value: []byte{0, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}}.String())
+	require.Equal(t, "1.3.6.1.4.1.9", Variable{Type: TypeObjectIdentifier, Value: MustParseOID("1.3.6.1.4.1.9")}.String())
+	require.Equal(t, "-5", Variable{Type: TypeInteger, Value: int64(-5)}.String())
+	require.Empty(t, Variable{Type: TypeNoSuchInstance}.String())
+}
+
+func TestPasswordToKey(t *testing.T) {
+	// RFC 3414, A.3
+	engineID, _ := hex.DecodeString("000000000000000000000002")
+
+	key := passwordToKey(md5.New, "maplesyrup", engineID)
+	require.Equal(t, "526f5eed9fcce26f8964c2930787d82b", hex.EncodeToString(key))
+
+	key = passwordToKey(sha1.New, "maplesyrup", engineID)
+	require.Equal(t, "6695febc9288e36282235fc7151f128497b38f3f", hex.EncodeToString(key))
+}
+
+func TestPrivacy_RoundTrip(t *testing.T) {
+	key := passwordToKey(sha1.New, "privpassword", []byte("engine"))
+	plaintext := []byte("scoped pdu bytes")
+
+	ciphertext, salt, err := encryptDES(key, 3, 7, plaintext)
+	require.NoError(t, err)
+	require.Len(t, ciphertext, 16)
+	decrypted, err := decryptDES(key, salt, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	ciphertext, salt, err = encryptAES(key, 3, 1000, 42, plaintext)
+	require.NoError(t, err)
+	require.NotEqual(t, plaintext, ciphertext)
+	decrypted, err = decryptAES(key, 3, 1000, salt, ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+}
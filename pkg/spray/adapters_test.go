@@ -0,0 +1,357 @@
+package spray
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // #nosec G505 -- test server for the MySQL protocol
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+var testOpts = Options{Timeout: 2 * time.Second}
+
+// serve runs handle for each connection to a local listener and returns
+// the service to test.
+func serve(t *testing.T, protocol string, handle func(conn net.Conn)) Service {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				handle(conn)
+			}()
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return Service{Host: "127.0.0.1", Port: addr.Port, Protocol: protocol}
+}
+
+func login(svc Service, username, password string) error {
+	return adapters[svc.Protocol].Login(context.Background(), svc, Credential{Username: username, Password: password}, testOpts)
+}
+
+func TestFTPAdapter(t *testing.T) {
+	svc := serve(t, ProtocolFTP, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		_, _ = fmt.Fprint(conn, "220-Welcome\r\n220 FTP server ready\r\n")
+		var user string
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+			switch cmd {
+			case "USER":
+				user = arg
+				if user == "locked" {
+					_, _ = fmt.Fprint(conn, "421 Too many login failures\r\n")
+					return
+				}
+				_, _ = fmt.Fprint(conn, "331 Password required\r\n")
+			case "PASS":
+				if user == "admin" && arg == "admin" {
+					_, _ = fmt.Fprint(conn, "230 Logged in\r\n")
+				} else {
+					_, _ = fmt.Fprint(conn, "530 Login incorrect\r\n")
+				}
+			case "QUIT":
+				_, _ = fmt.Fprint(conn, "221 Bye\r\n")
+				return
+			}
+		}
+	})
+
+	require.NoError(t, login(svc, "admin", "admin"))
+	require.ErrorIs(t, login(svc, "admin", "wrong"), ErrAuthFailed)
+	require.ErrorIs(t, login(svc, "locked", "x"), ErrLockedOut)
+
+	// A service that is not FTP
+	other := serve(t, ProtocolFTP, func(conn net.Conn) { _, _ = fmt.Fprint(conn, "SSH-2.0-OpenSSH_9.6\r\n") })
+	require.ErrorIs(t, login(other, "admin", "admin"), ErrNotApplicable)
+}
+
+func TestRedisAdapter(t *testing.T) {
+	newServer := func(password string) Service {
+		return serve(t, ProtocolRedis, func(conn net.Conn) {
+			r := bufio.NewReader(conn)
+			var args []string
+			header, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+			for range n {
+				_, _ = r.ReadString('\n')
+				arg, _ := r.ReadString('\n')
+				args = append(args, strings.TrimSpace(arg))
+			}
+			switch {
+			case args[0] == "PING" && password == "":
+				_, _ = fmt.Fprint(conn, "+PONG\r\n")
+			case args[0] == "PING":
+				_, _ = fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+			case password == "":
+				_, _ = fmt.Fprint(conn, "-ERR AUTH <password> called without any password configured for the default user.\r\n")
+			case args[len(args)-1] == password:
+				_, _ = fmt.Fprint(conn, "+OK\r\n")
+			default:
+				_, _ = fmt.Fprint(conn, "-WRONGPASS invalid username-password pair or user is disabled.\r\n")
+			}
+		})
+	}
+
+	svc := newServer("foobared")
+	adapter := redisAdapter{}
+	require.NoError(t, adapter.Check(context.Background(), svc, testOpts))
+	require.NoError(t, login(svc, "", "foobared"))
+	require.NoError(t, login(svc, "default", "foobared"))
+	require.ErrorIs(t, login(svc, "", "redis"), ErrAuthFailed)
+
+	// Servers without a password are a finding of their own
+	open := newServer("")
+	require.ErrorIs(t, adapter.Check(context.Background(), open, testOpts), ErrNotApplicable)
+	require.ErrorIs(t, login(open, "", "redis"), ErrNotApplicable)
+
+	require.Equal(t, "*2\r\n$4\r\nAUTH\r\n$3\r\nabc\r\n", string(redisEncode([]string{"AUTH", "abc"})))
+}
+
+func TestHTTPBasicAdapter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/manager/html" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		user, pass, ok := r.BasicAuth()
+		switch {
+		case ok && user == "tomcat" && pass == "tomcat":
+			http.Redirect(w, r, "/manager/html/list", http.StatusFound)
+		case ok && user == "throttled":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Header().Set("WWW-Authenticate", `Basic realm="Tomcat Manager Application"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	addr := server.Listener.Addr().(*net.TCPAddr)
+	svc := Service{Host: "127.0.0.1", Port: addr.Port, Protocol: ProtocolHTTPBasic, Path: "/manager/html"}
+
+	adapter := httpBasicAdapter{}
+	require.NoError(t, adapter.Check(context.Background(), svc, testOpts))
+	require.NoError(t, login(svc, "tomcat", "tomcat"))
+	require.ErrorIs(t, login(svc, "admin", "admin"), ErrAuthFailed)
+	require.ErrorIs(t, login(svc, "throttled", "x"), ErrLockedOut)
+
+	// Paths that are not protected are not tested
+	svc.Path = "/"
+	require.ErrorIs(t, adapter.Check(context.Background(), svc, testOpts), ErrNotApplicable)
+}
+
+func TestTelnetAdapter(t *testing.T) {
+	svc := serve(t, ProtocolTelnet, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		// WILL ECHO, DO TERMINAL-TYPE
+		_, _ = conn.Write([]byte{telnetIAC, telnetWill, telnetOptEcho, telnetIAC, telnetDo, 24})
+		_, _ = fmt.Fprint(conn, "\r\nRouter\r\nlogin: ")
+		for attempt := 0; attempt < 2; attempt++ {
+			user := telnetLine(r)
+			_, _ = fmt.Fprint(conn, "Password: ")
+			pass := telnetLine(r)
+			switch {
+			case user == "admin" && pass == "admin":
+				_, _ = fmt.Fprint(conn, "\r\nLast login: Mon Oct 12 10:00:00\r\nadmin@router:~$ ")
+				return
+			case user == "locked":
+				_, _ = fmt.Fprint(conn, "\r\nAccount locked\r\n")
+				return
+			}
+			_, _ = fmt.Fprint(conn, "\r\nLogin incorrect\r\nlogin: ")
+		}
+	})
+
+	require.NoError(t, login(svc, "admin", "admin"))
+	require.ErrorIs(t, login(svc, "admin", "wrong"), ErrAuthFailed)
+	require.ErrorIs(t, login(svc, "locked", "x"), ErrLockedOut)
+
+	// A silent service is not a Telnet login
+	silent := serve(t, ProtocolTelnet, func(conn net.Conn) { time.Sleep(3 * time.Second) })
+	err := telnetAdapter{}.Login(context.Background(), silent, Credential{Username: "a", Password: "b"}, Options{Timeout: 100 * time.Millisecond})
+	require.ErrorIs(t, err, ErrNotApplicable)
+}
+
+// telnetLine reads a line, skipping negotiation replies.
+func telnetLine(r *bufio.Reader) string {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil || b == '\n' {
+			return strings.TrimSpace(string(line))
+		}
+		if b == telnetIAC {
+			_, _ = r.ReadByte()
+			_, _ = r.ReadByte()
+			continue
+		}
+		line = append(line, b)
+	}
+}
+
+func TestSSHAdapter(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == "pi" && string(password) == "raspberry" {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("denied")
+		},
+	}
+	config.AddHostKey(signer)
+
+	svc := serve(t, ProtocolSSH, func(conn net.Conn) {
+		sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+		if err != nil {
+			return
+		}
+		defer func() { _ = sconn.Close() }()
+		go ssh.DiscardRequests(reqs)
+		for ch := range chans {
+			_ = ch.Reject(ssh.Prohibited, "")
+		}
+	})
+
+	require.NoError(t, login(svc, "pi", "raspberry"))
+	require.ErrorIs(t, login(svc, "pi", "wrong"), ErrAuthFailed)
+}
+
+// mysqlServer accepts root with password "root", using plugin for the
+// first exchange. Full caching_sha2_password authentication is forced
+// when fullAuth is set.
+func mysqlServer(t *testing.T, plugin string, fullAuth bool) Service {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	scramble := []byte("abcdefghijklmnopqrst")
+
+	return serve(t, ProtocolMySQL, func(conn net.Conn) {
+		c := &mysqlConn{conn: conn}
+		greeting := []byte{10}
+		greeting = append(greeting, "8.0.36\x00"...)
+		greeting = append(greeting, 1, 0, 0, 0)
+		greeting = append(greeting, scramble[:8]...)
+		greeting = append(greeting, 0)
+		caps := uint32(mysqlClientProtocol41 | mysqlClientSecureConnection | mysqlClientPluginAuth)
+		greeting = binary.LittleEndian.AppendUint16(greeting, uint16(caps))
+		greeting = append(greeting, mysqlCharsetUTF8, 2, 0)
+		greeting = binary.LittleEndian.AppendUint16(greeting, uint16(caps>>16))
+		greeting = append(greeting, 21)
+		greeting = append(greeting, make([]byte, 10)...)
+		greeting = append(greeting, scramble[8:]...)
+		greeting = append(greeting, 0)
+		greeting = append(greeting, plugin+"\x00"...)
+		_ = c.write(greeting)
+
+		resp, err := c.read()
+		if err != nil {
+			return
+		}
+		user, rest, _ := bytes.Cut(resp[32:], []byte{0})
+		auth := rest[1 : 1+int(rest[0])]
+
+		ok := false
+		switch {
+		case plugin == mysqlNativePassword:
+			ok = bytes.Equal(auth, mysqlNativeScramble("root", scramble))
+		case fullAuth:
+			_ = c.write([]byte{mysqlMoreData, mysqlFullAuth})
+			if req, err := c.read(); err != nil || !bytes.Equal(req, []byte{mysqlRequestKey}) {
+				return
+			}
+			_ = c.write(append([]byte{mysqlMoreData}, pubPEM...))
+			encrypted, err := c.read()
+			if err != nil {
+				return
+			}
+			plain, err := rsa.DecryptOAEP(sha1.New(), nil, key, encrypted, nil) // #nosec G401 -- MySQL uses OAEP with SHA-1
+			if err != nil {
+				return
+			}
+			for i := range plain {
+				plain[i] ^= scramble[i%len(scramble)]
+			}
+			ok = string(plain) == "root\x00"
+		default:
+			ok = bytes.Equal(auth, mysqlSHA2Scramble("root", scramble))
+			if ok {
+				_ = c.write([]byte{mysqlMoreData, mysqlFastAuthOK})
+			}
+		}
+
+		if ok && string(user) == "root" {
+			_ = c.write([]byte{mysqlOK, 0, 0, 2, 0, 0, 0})
+			return
+		}
+		errPkt := binary.LittleEndian.AppendUint16([]byte{mysqlErr}, mysqlErrAccessDenied)
+		_ = c.write(append(errPkt, "#28000Access denied for user"...))
+	})
+}
+
+func TestMySQLAdapter(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		plugin   string
+		fullAuth bool
+	}{
+		{"native password", mysqlNativePassword, false},
+		{"caching sha2 fast auth", mysqlCachingSHA2, false},
+		{"caching sha2 full auth", mysqlCachingSHA2, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mysqlServer(t, tt.plugin, tt.fullAuth)
+			require.NoError(t, login(svc, "root", "root"))
+			require.ErrorIs(t, login(svc, "root", "mysql"), ErrAuthFailed)
+		})
+	}
+
+	// Blocked hosts stop the spray
+	blocked := serve(t, ProtocolMySQL, func(conn net.Conn) {
+		c := &mysqlConn{conn: conn}
+		errPkt := binary.LittleEndian.AppendUint16([]byte{mysqlErr}, mysqlErrHostBlocked)
+		_ = c.write(append(errPkt, "Host is blocked because of many connection errors"...))
+	})
+	require.ErrorIs(t, login(blocked, "root", "root"), ErrLockedOut)
+
+	// Other protocols are not MySQL
+	other := serve(t, ProtocolMySQL, func(conn net.Conn) { _, _ = fmt.Fprint(conn, "HTTP/1.1 400 Bad Request\r\n\r\n") })
+	require.ErrorIs(t, login(other, "root", "root"), ErrNotApplicable)
+}
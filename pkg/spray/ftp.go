@@ -0,0 +1,93 @@
+package spray
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"strconv"
+)
+
+// FTP reply codes (RFC 959).
+const (
+	ftpReady        = 220
+	ftpLoggedIn     = 230
+	ftpNeedPassword = 331
+	ftpNeedAccount  = 332
+	ftpNotAvailable = 421
+	ftpNotLoggedIn  = 530
+)
+
+// ftpAdapter logs in with USER and PASS.
+type ftpAdapter struct{}
+
+func (ftpAdapter) Login(ctx context.Context, svc Service, cred Credential, opts Options) error {
+	conn, done, err := dial(ctx, svc, opts)
+	if err != nil {
+		return err
+	}
+	defer done()
+	tp := textproto.NewConn(conn)
+
+	// A banner that does not start with a reply code is not FTP, and
+	// ReadResponse would wait on lines like "SSH-2.0-..." as continuations
+	head, err := tp.R.Peek(3)
+	if err != nil {
+		return ftpError(ctx, 0, "", err)
+	}
+	if _, err := strconv.Atoi(string(head)); err != nil {
+		return ErrNotApplicable
+	}
+	code, msg, err := tp.ReadResponse(ftpReady)
+	if err != nil {
+		return ftpError(ctx, code, msg, err)
+	}
+
+	id, err := tp.Cmd("USER %s", cred.Username)
+	if err != nil {
+		return ctxErr(ctx, err)
+	}
+	tp.StartResponse(id)
+	code, msg, err = tp.ReadResponse(0)
+	tp.EndResponse(id)
+	switch {
+	case err != nil:
+		return ctxErr(ctx, err)
+	case code == ftpLoggedIn:
+		return nil
+	case code != ftpNeedPassword:
+		return ftpError(ctx, code, msg, nil)
+	}
+
+	id, err = tp.Cmd("PASS %s", cred.Password)
+	if err != nil {
+		return ctxErr(ctx, err)
+	}
+	tp.StartResponse(id)
+	code, msg, err = tp.ReadResponse(0)
+	tp.EndResponse(id)
+	if err != nil {
+		return ctxErr(ctx, err)
+	}
+	if code == ftpLoggedIn {
+		_, _ = tp.Cmd("QUIT")
+		return nil
+	}
+	return ftpError(ctx, code, msg, nil)
+}
+
+// ftpError maps a failed reply to the package errors.
+func ftpError(ctx context.Context, code int, msg string, err error) error {
+	var protoErr textproto.ProtocolError
+	switch {
+	case code == ftpNotAvailable:
+		return ErrLockedOut
+	case code == ftpNotLoggedIn || code == ftpNeedAccount:
+		return ErrAuthFailed
+	case errors.As(err, &protoErr):
+		// Not an FTP reply at all
+		return ErrNotApplicable
+	case err != nil && code == 0:
+		return ctxErr(ctx, err)
+	}
+	return unexpected("FTP", msg)
+}
@@ -0,0 +1,94 @@
+package spray
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// httpBasicAdapter requests the protected path with HTTP Basic
+// authentication. Redirects are not followed: a redirect after login is
+// a success.
+type httpBasicAdapter struct{}
+
+// Check skips services that do not ask for Basic authentication on the
+// path.
+func (httpBasicAdapter) Check(ctx context.Context, svc Service, opts Options) error {
+	resp, err := httpBasicRequest(ctx, svc, opts, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("%w: status %d without credentials", ErrNotApplicable, resp.StatusCode)
+	}
+	for _, challenge := range resp.Header.Values("WWW-Authenticate") {
+		if strings.HasPrefix(strings.ToLower(challenge), "basic") {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no Basic challenge", ErrNotApplicable)
+}
+
+func (httpBasicAdapter) Login(ctx context.Context, svc Service, cred Credential, opts Options) error {
+	resp, err := httpBasicRequest(ctx, svc, opts, &cred)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return ErrAuthFailed
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return ErrLockedOut
+	case resp.StatusCode < 400:
+		return nil
+	}
+	return fmt.Errorf("spray: unexpected HTTP status %d", resp.StatusCode)
+}
+
+// httpBasicRequest sends a GET for the service's path, with cred when
+// given. The body is discarded.
+func httpBasicRequest(ctx context.Context, svc Service, opts Options, cred *Credential) (*http.Response, error) {
+	scheme := "http"
+	if svc.TLS {
+		scheme = "https"
+	}
+	path := svc.Path
+	if path == "" {
+		path = "/"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+svc.Addr()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cred != nil {
+		req.SetBasicAuth(cred.Username, cred.Password)
+	}
+
+	dialContext := opts.Dial
+	if dialContext == nil {
+		dialContext = (&net.Dialer{}).DialContext
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialContext,
+			// #nosec G402 -- devices with default credentials rarely have valid certificates
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	_ = resp.Body.Close()
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package spray
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// redisAdapter authenticates with AUTH, with a username when one is given
+// (Redis 6 ACLs).
+type redisAdapter struct{}
+
+// Check skips servers that need no password; that is a finding of its own.
+func (redisAdapter) Check(ctx context.Context, svc Service, opts Options) error {
+	reply, err := redisCommand(ctx, svc, opts, "PING")
+	if err != nil {
+		return err
+	}
+	switch {
+	case strings.HasPrefix(reply, "-NOAUTH"):
+		return nil
+	case reply == "+PONG":
+		return fmt.Errorf("%w: no password required", ErrNotApplicable)
+	}
+	return redisError(reply)
+}
+
+func (redisAdapter) Login(ctx context.Context, svc Service, cred Credential, opts Options) error {
+	args := []string{"AUTH", cred.Password}
+	if cred.Username != "" {
+		args = []string{"AUTH", cred.Username, cred.Password}
+	}
+	reply, err := redisCommand(ctx, svc, opts, args...)
+	if err != nil {
+		return err
+	}
+	if reply == "+OK" {
+		return nil
+	}
+	return redisError(reply)
+}
+
+// redisCommand sends one command on a new connection and returns the
+// first line of the reply.
+func redisCommand(ctx context.Context, svc Service, opts Options, args ...string) (string, error) {
+	conn, done, err := dial(ctx, svc, opts)
+	if err != nil {
+		return "", err
+	}
+	defer done()
+
+	if _, err := conn.Write(redisEncode(args)); err != nil {
+		return "", ctxErr(ctx, err)
+	}
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", ctxErr(ctx, err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// redisEncode encodes a command as a RESP array of bulk strings.
+func redisEncode(args []string) []byte {
+	b := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		b = fmt.Appendf(b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return b
+}
+
+// redisError maps an error reply to the package errors.
+func redisError(reply string) error {
+	lower := strings.ToLower(reply)
+	switch {
+	case strings.HasPrefix(reply, "-WRONGPASS"), strings.Contains(lower, "invalid password"),
+		strings.Contains(lower, "invalid username-password"):
+		return ErrAuthFailed
+	case strings.Contains(lower, "no password is set"), strings.Contains(lower, "without any password configured"):
+		return fmt.Errorf("%w: no password required", ErrNotApplicable)
+	case strings.Contains(lower, "max number of clients"):
+		return ErrLockedOut
+	case !strings.HasPrefix(reply, "-") && !strings.HasPrefix(reply, "+"):
+		return ErrNotApplicable
+	}
+	return unexpected("Redis", reply)
+}
@@ -0,0 +1,163 @@
+// Package spray tests default credentials against network services. An
+// Adapter per protocol makes single login attempts; a Sprayer runs the
+// attempts against a host under rate limits and lockout-safety controls,
+// so that testing a published default password never locks out the real
+// account that uses the same name.
+package spray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DefaultTimeout bounds each login attempt when Options.Timeout is zero.
+const DefaultTimeout = 5 * time.Second
+
+// Protocols with an adapter.
+const (
+	ProtocolSSH       = "ssh"
+	ProtocolFTP       = "ftp"
+	ProtocolTelnet    = "telnet"
+	ProtocolHTTPBasic = "http-basic"
+	ProtocolMySQL     = "mysql"
+	ProtocolRedis     = "redis"
+)
+
+var (
+	// ErrAuthFailed is returned when the service rejects the credential.
+	ErrAuthFailed = errors.New("spray: authentication failed")
+	// ErrLockedOut is returned when the service reports that the account
+	// or client is locked out or throttled. No further attempts are made
+	// against the host.
+	ErrLockedOut = errors.New("spray: locked out or throttled")
+	// ErrNotApplicable is returned when the service cannot be tested: it
+	// speaks another protocol, or requires no authentication at all.
+	ErrNotApplicable = errors.New("spray: service not applicable")
+)
+
+// Credential is a username and password tried together. Redis AUTH
+// without ACLs uses the password only.
+type Credential struct {
+	Username string
+	Password string
+}
+
+// String names the credential without its password.
+func (c Credential) String() string {
+	if c.Username == "" {
+		return "(password only)"
+	}
+	return c.Username
+}
+
+// Service is a network service credentials are tested against.
+type Service struct {
+	Host     string
+	Port     int
+	Protocol string
+	Path     string // http-basic: protected path, "/" when empty
+	TLS      bool   // http-basic: use HTTPS
+}
+
+// Addr returns the service's host:port.
+func (s Service) Addr() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// Options configure how adapters connect.
+type Options struct {
+	// Timeout bounds each attempt; DefaultTimeout when zero.
+	Timeout time.Duration
+	// Dial opens TCP connections; a net.Dialer when nil.
+	Dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+func (o Options) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultTimeout
+}
+
+// Adapter attempts logins over one protocol.
+type Adapter interface {
+	// Login makes one attempt with cred. It returns nil when the
+	// credential is accepted, ErrAuthFailed when it is rejected,
+	// ErrLockedOut or ErrNotApplicable, or another error when the
+	// attempt could not be completed.
+	Login(ctx context.Context, svc Service, cred Credential, opts Options) error
+}
+
+// Checker is implemented by adapters that can tell, without sending any
+// credential, whether a service needs testing. Check returns
+// ErrNotApplicable when it does not.
+type Checker interface {
+	Check(ctx context.Context, svc Service, opts Options) error
+}
+
+// adapters maps each protocol to its adapter.
+var adapters = map[string]Adapter{
+	ProtocolSSH:       sshAdapter{},
+	ProtocolFTP:       ftpAdapter{},
+	ProtocolTelnet:    telnetAdapter{},
+	ProtocolHTTPBasic: httpBasicAdapter{},
+	ProtocolMySQL:     mysqlAdapter{},
+	ProtocolRedis:     redisAdapter{},
+}
+
+// Protocols returns the protocols with an adapter, sorted.
+func Protocols() []string {
+	protocols := make([]string, 0, len(adapters))
+	for p := range adapters {
+		protocols = append(protocols, p)
+	}
+	slices.Sort(protocols)
+	return protocols
+}
+
+// dial connects to the service and bounds the whole attempt by the
+// timeout. Canceling ctx interrupts blocked reads and writes.
+func dial(ctx context.Context, svc Service, opts Options) (net.Conn, func(), error) {
+	dctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	if opts.Dial != nil {
+		conn, err = opts.Dial(dctx, "tcp", svc.Addr())
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(dctx, "tcp", svc.Addr())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(opts.timeout()))
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Unix(1, 0)) })
+	return conn, func() {
+		stop()
+		_ = conn.Close()
+	}, nil
+}
+
+// ctxErr prefers the context's error over the I/O error it caused.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// unexpected reports a reply the adapter does not understand.
+func unexpected(protocol, reply string) error {
+	if len(reply) > 80 {
+		reply = reply[:80]
+	}
+	return fmt.Errorf("spray: unexpected %s reply %q", protocol, reply)
+}
@@ -0,0 +1,226 @@
+package spray
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Limits keep credential testing slow and below lockout thresholds.
+type Limits struct {
+	// Rate caps attempts per second across all hosts; unlimited when zero.
+	Rate float64
+	// HostDelay is the pause between two attempts against the same host.
+	HostDelay time.Duration
+	// MaxAttemptsPerHost caps attempts against one host over all its
+	// services; unlimited when zero.
+	MaxAttemptsPerHost int
+	// MaxAttemptsPerUser caps attempts per username on one host, counted
+	// over all its services since they often share an account database;
+	// unlimited when zero. Keep it below the lockout threshold.
+	MaxAttemptsPerUser int
+	// MaxErrors gives up a service after this many consecutive attempts
+	// that could not be completed; unlimited when zero.
+	MaxErrors int
+}
+
+// Job is a list of credentials to test against a service.
+type Job struct {
+	Service     Service
+	Credentials []Credential
+	// Source names where the credentials came from, e.g. a plugin.
+	Source string
+}
+
+// StopReason tells why a job ended before every credential was tried.
+type StopReason string
+
+// Reasons a job stops early. A job that found a working credential stops
+// without a reason.
+const (
+	StopLockout       StopReason = "lockout"
+	StopHostLimit     StopReason = "host-limit"
+	StopErrors        StopReason = "errors"
+	StopNotApplicable StopReason = "not-applicable"
+	StopCanceled      StopReason = "canceled"
+)
+
+// Outcome is the result of a job.
+type Outcome struct {
+	Service Service
+	Source  string
+	// Credential is the accepted credential when Success is set.
+	Credential Credential
+	Success    bool
+	Attempts   int
+	// Skipped counts credentials not tried because their username
+	// reached MaxAttemptsPerUser.
+	Skipped int
+	Stopped StopReason
+	// Err is the last error that was not a rejected credential.
+	Err error
+}
+
+// Sprayer runs jobs under Limits. It is safe for concurrent use; the
+// rate limit is shared by all hosts.
+type Sprayer struct {
+	limits   Limits
+	opts     Options
+	adapters map[string]Adapter
+	limiter  *limiter
+}
+
+// New returns a Sprayer that connects with opts.
+func New(limits Limits, opts Options) *Sprayer {
+	s := &Sprayer{limits: limits, opts: opts, adapters: adapters}
+	if limits.Rate > 0 {
+		s.limiter = &limiter{interval: time.Duration(float64(time.Second) / limits.Rate)}
+	}
+	return s
+}
+
+// hostState tracks the attempts against one host.
+type hostState struct {
+	attempts  int
+	perUser   map[string]int
+	lockedOut bool
+	last      time.Time
+}
+
+// Run tests the jobs, which must all target the same host, one attempt at
+// a time. A job stops at the first accepted credential. When the host
+// reports a lockout, the remaining jobs are not attempted.
+func (s *Sprayer) Run(ctx context.Context, jobs []Job) []Outcome {
+	host := &hostState{perUser: make(map[string]int)}
+	outcomes := make([]Outcome, 0, len(jobs))
+	for _, job := range jobs {
+		outcomes = append(outcomes, s.run(ctx, host, job))
+	}
+	return outcomes
+}
+
+func (s *Sprayer) run(ctx context.Context, host *hostState, job Job) Outcome {
+	outcome := Outcome{Service: job.Service, Source: job.Source}
+	adapter, ok := s.adapters[job.Service.Protocol]
+	if !ok {
+		outcome.Stopped = StopNotApplicable
+		outcome.Err = fmt.Errorf("spray: unsupported protocol %q", job.Service.Protocol)
+		return outcome
+	}
+	if host.lockedOut {
+		outcome.Stopped = StopLockout
+		return outcome
+	}
+
+	if checker, ok := adapter.(Checker); ok {
+		if err := checker.Check(ctx, job.Service, s.opts); err != nil {
+			outcome.Err = err
+			outcome.Stopped = StopNotApplicable
+			if ctx.Err() != nil {
+				outcome.Stopped = StopCanceled
+			}
+			return outcome
+		}
+	}
+
+	errorsInRow := 0
+	for _, cred := range job.Credentials {
+		if s.limits.MaxAttemptsPerHost > 0 && host.attempts >= s.limits.MaxAttemptsPerHost {
+			outcome.Stopped = StopHostLimit
+			return outcome
+		}
+		if s.limits.MaxAttemptsPerUser > 0 && host.perUser[cred.Username] >= s.limits.MaxAttemptsPerUser {
+			outcome.Skipped++
+			continue
+		}
+		if err := s.wait(ctx, host); err != nil {
+			outcome.Stopped = StopCanceled
+			return outcome
+		}
+
+		err := adapter.Login(ctx, job.Service, cred, s.opts)
+		host.attempts++
+		host.perUser[cred.Username]++
+		host.last = time.Now()
+		outcome.Attempts++
+
+		switch {
+		case err == nil:
+			outcome.Success = true
+			outcome.Credential = cred
+			return outcome
+		case errors.Is(err, ErrAuthFailed):
+			errorsInRow = 0
+		case errors.Is(err, ErrLockedOut):
+			host.lockedOut = true
+			outcome.Stopped = StopLockout
+			outcome.Err = err
+			return outcome
+		case errors.Is(err, ErrNotApplicable):
+			outcome.Stopped = StopNotApplicable
+			outcome.Err = err
+			return outcome
+		case ctx.Err() != nil:
+			outcome.Stopped = StopCanceled
+			return outcome
+		default:
+			outcome.Err = err
+			errorsInRow++
+			if s.limits.MaxErrors > 0 && errorsInRow >= s.limits.MaxErrors {
+				outcome.Stopped = StopErrors
+				return outcome
+			}
+		}
+	}
+	return outcome
+}
+
+// wait holds the next attempt until the host delay has passed since the
+// previous attempt on the host and the rate limit allows it.
+func (s *Sprayer) wait(ctx context.Context, host *hostState) error {
+	if !host.last.IsZero() && s.limits.HostDelay > 0 {
+		if err := sleep(ctx, time.Until(host.last.Add(s.limits.HostDelay))); err != nil {
+			return err
+		}
+	}
+	return s.limiter.wait(ctx)
+}
+
+// limiter spaces attempts at least interval apart.
+type limiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func (l *limiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+	return sleep(ctx, time.Until(at))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,177 @@
+package spray
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAdapter accepts the password "secret" unless a reply is scripted
+// for the username.
+type fakeAdapter struct {
+	replies  map[string]error
+	checkErr error
+
+	mu       sync.Mutex
+	attempts []Credential
+	times    []time.Time
+}
+
+func (a *fakeAdapter) Check(context.Context, Service, Options) error { return a.checkErr }
+
+func (a *fakeAdapter) Login(_ context.Context, _ Service, cred Credential, _ Options) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.attempts = append(a.attempts, cred)
+	a.times = append(a.times, time.Now())
+	if err, ok := a.replies[cred.Username]; ok {
+		return err
+	}
+	if cred.Password == "secret" {
+		return nil
+	}
+	return ErrAuthFailed
+}
+
+func newTestSprayer(limits Limits, adapter Adapter) *Sprayer {
+	s := New(limits, Options{})
+	s.adapters = map[string]Adapter{"fake": adapter}
+	return s
+}
+
+func creds(pairs ...string) []Credential {
+	var out []Credential
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, Credential{Username: pairs[i], Password: pairs[i+1]})
+	}
+	return out
+}
+
+func fakeJob(port int, c []Credential) Job {
+	return Job{Service: Service{Host: "192.0.2.1", Port: port, Protocol: "fake"}, Credentials: c, Source: "test-plugin"}
+}
+
+func TestSprayer_Run_Success(t *testing.T) {
+	adapter := &fakeAdapter{}
+	s := newTestSprayer(Limits{}, adapter)
+
+	outcomes := s.Run(context.Background(), []Job{fakeJob(22, creds("root", "root", "admin", "secret", "root", "secret"))})
+	require.Len(t, outcomes, 1)
+	require.True(t, outcomes[0].Success)
+	require.Equal(t, Credential{Username: "admin", Password: "secret"}, outcomes[0].Credential)
+	require.Equal(t, 2, outcomes[0].Attempts)
+	require.Equal(t, "test-plugin", outcomes[0].Source)
+	require.Empty(t, outcomes[0].Stopped)
+
+	// The job stops at the first accepted credential
+	require.Len(t, adapter.attempts, 2)
+}
+
+func TestSprayer_Run_MaxAttemptsPerUser(t *testing.T) {
+	adapter := &fakeAdapter{}
+	s := newTestSprayer(Limits{MaxAttemptsPerUser: 2}, adapter)
+
+	outcomes := s.Run(context.Background(), []Job{
+		fakeJob(21, creds("root", "a", "root", "b")),
+		// The count carries over to other services on the host
+		fakeJob(22, creds("root", "secret", "admin", "secret")),
+	})
+	require.Equal(t, 2, outcomes[0].Attempts)
+	require.Equal(t, 1, outcomes[1].Skipped)
+	require.True(t, outcomes[1].Success)
+	require.Equal(t, "admin", outcomes[1].Credential.Username)
+}
+
+func TestSprayer_Run_Lockout(t *testing.T) {
+	adapter := &fakeAdapter{replies: map[string]error{"admin": ErrLockedOut}}
+	s := newTestSprayer(Limits{}, adapter)
+
+	outcomes := s.Run(context.Background(), []Job{
+		fakeJob(21, creds("admin", "a", "root", "secret")),
+		fakeJob(22, creds("root", "secret")),
+	})
+	require.Equal(t, StopLockout, outcomes[0].Stopped)
+	require.ErrorIs(t, outcomes[0].Err, ErrLockedOut)
+	require.Equal(t, StopLockout, outcomes[1].Stopped)
+	require.Zero(t, outcomes[1].Attempts)
+	require.Len(t, adapter.attempts, 1)
+}
+
+func TestSprayer_Run_Limits(t *testing.T) {
+	// Host limit
+	adapter := &fakeAdapter{}
+	s := newTestSprayer(Limits{MaxAttemptsPerHost: 3}, adapter)
+	outcomes := s.Run(context.Background(), []Job{
+		fakeJob(21, creds("a", "1", "b", "2")),
+		fakeJob(22, creds("c", "3", "d", "4")),
+	})
+	require.Empty(t, outcomes[0].Stopped)
+	require.Equal(t, StopHostLimit, outcomes[1].Stopped)
+	require.Equal(t, 1, outcomes[1].Attempts)
+
+	// Consecutive errors
+	adapter = &fakeAdapter{replies: map[string]error{"a": errors.New("connection reset"), "b": errors.New("connection reset")}}
+	s = newTestSprayer(Limits{MaxErrors: 2}, adapter)
+	outcomes = s.Run(context.Background(), []Job{fakeJob(21, creds("a", "1", "b", "2", "c", "secret"))})
+	require.Equal(t, StopErrors, outcomes[0].Stopped)
+	require.EqualError(t, outcomes[0].Err, "connection reset")
+	require.False(t, outcomes[0].Success)
+
+	// Services that need no testing
+	adapter = &fakeAdapter{checkErr: ErrNotApplicable}
+	s = newTestSprayer(Limits{}, adapter)
+	outcomes = s.Run(context.Background(), []Job{fakeJob(21, creds("a", "secret"))})
+	require.Equal(t, StopNotApplicable, outcomes[0].Stopped)
+	require.Empty(t, adapter.attempts)
+
+	// Unknown protocols
+	outcomes = s.Run(context.Background(), []Job{{Service: Service{Protocol: "rdp"}, Credentials: creds("a", "b")}})
+	require.EqualError(t, outcomes[0].Err, `spray: unsupported protocol "rdp"`)
+}
+
+func TestSprayer_Run_Pacing(t *testing.T) {
+	adapter := &fakeAdapter{}
+	s := newTestSprayer(Limits{HostDelay: 30 * time.Millisecond, Rate: 50}, adapter)
+
+	s.Run(context.Background(), []Job{fakeJob(21, creds("a", "1", "b", "2", "c", "3"))})
+	require.Len(t, adapter.times, 3)
+	for i := 1; i < len(adapter.times); i++ {
+		require.GreaterOrEqual(t, adapter.times[i].Sub(adapter.times[i-1]), 30*time.Millisecond)
+	}
+
+	// The rate limit is shared by concurrent hosts: 50/s spaces attempts 20ms apart
+	adapter = &fakeAdapter{}
+	s = newTestSprayer(Limits{Rate: 50}, adapter)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Run(context.Background(), []Job{fakeJob(21, creds("a", "1", "b", "2"))})
+		}()
+	}
+	wg.Wait()
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestSprayer_Run_Canceled(t *testing.T) {
+	adapter := &fakeAdapter{}
+	s := newTestSprayer(Limits{HostDelay: time.Hour}, adapter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	outcomes := s.Run(ctx, []Job{fakeJob(21, creds("a", "1", "b", "2"))})
+	require.Equal(t, StopCanceled, outcomes[0].Stopped)
+	require.Equal(t, 1, outcomes[0].Attempts)
+}
+
+func TestProtocols(t *testing.T) {
+	require.Equal(t, []string{"ftp", "http-basic", "mysql", "redis", "ssh", "telnet"}, Protocols())
+	require.Equal(t, "(password only)", Credential{Password: "x"}.String())
+	require.Equal(t, "root", Credential{Username: "root", Password: "x"}.String())
+}
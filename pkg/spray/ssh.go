@@ -0,0 +1,57 @@
+package spray
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshAdapter logs in with password or keyboard-interactive
+// authentication, answering every prompt with the password.
+type sshAdapter struct{}
+
+func (sshAdapter) Login(ctx context.Context, svc Service, cred Credential, opts Options) error {
+	conn, done, err := dial(ctx, svc, opts)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	config := &ssh.ClientConfig{
+		User: cred.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.Password(cred.Password),
+			ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range answers {
+					answers[i] = cred.Password
+				}
+				return answers, nil
+			}),
+		},
+		// #nosec G106 -- only whether the login succeeds matters; no data is sent
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         opts.timeout(),
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, svc.Addr(), config)
+	if err != nil {
+		msg := err.Error()
+		switch {
+		case strings.Contains(msg, "unable to authenticate"):
+			return ErrAuthFailed
+		case strings.Contains(msg, "ssh: handshake failed: ssh: overflow reading version string"),
+			strings.Contains(msg, "ssh: handshake failed: ssh: invalid packet length"):
+			return ErrNotApplicable
+		}
+		return ctxErr(ctx, err)
+	}
+	go ssh.DiscardRequests(reqs)
+	go func() {
+		for ch := range chans {
+			_ = ch.Reject(ssh.Prohibited, "")
+		}
+	}()
+	_ = c.Close()
+	return nil
+}
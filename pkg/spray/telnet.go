@@ -0,0 +1,197 @@
+package spray
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+)
+
+// Telnet commands and options (RFC 854, 857, 858).
+const (
+	telnetSE   = 240
+	telnetSB   = 250
+	telnetWill = 251
+	telnetWont = 252
+	telnetDo   = 253
+	telnetDont = 254
+	telnetIAC  = 255
+
+	telnetOptEcho = 1
+	telnetOptSGA  = 3
+)
+
+// Prompts and replies recognized in the login dialog, lowercased.
+var (
+	telnetLoginPrompts    = []string{"login:", "username:", "user name:", "user:"}
+	telnetPasswordPrompts = []string{"password:", "passcode:"}
+	telnetFailures        = []string{"incorrect", "invalid", "failed", "denied", "bad password", "authentication failure"}
+	telnetLockouts        = []string{"locked", "too many", "try again later"}
+	telnetShellPrompts    = []string{"$", "#", ">", "%"}
+)
+
+// telnetAdapter answers the login and password prompts and decides from
+// what follows whether a shell was opened. Option negotiation is refused
+// except for echo and suppress-go-ahead.
+type telnetAdapter struct{}
+
+func (telnetAdapter) Login(ctx context.Context, svc Service, cred Credential, opts Options) error {
+	conn, done, err := dial(ctx, svc, opts)
+	if err != nil {
+		return err
+	}
+	defer done()
+	t := &telnetConn{conn: conn}
+
+	prompt, err := t.readUntil(append(telnetLoginPrompts, telnetPasswordPrompts...), nil)
+	if err != nil {
+		return telnetError(ctx, t, err)
+	}
+	// Some devices ask for a password only
+	if !slicesContainsAny(prompt, telnetPasswordPrompts) {
+		if err := t.writeLine(cred.Username); err != nil {
+			return ctxErr(ctx, err)
+		}
+		if _, err := t.readUntil(telnetPasswordPrompts, nil); err != nil {
+			return telnetError(ctx, t, err)
+		}
+	}
+	if err := t.writeLine(cred.Password); err != nil {
+		return ctxErr(ctx, err)
+	}
+
+	// Read what follows the password: another prompt means it failed
+	t.text.Reset()
+	reply, err := t.readUntil(append(append(telnetLoginPrompts, telnetFailures...), telnetLockouts...), telnetShell)
+	if err != nil {
+		return telnetError(ctx, t, err)
+	}
+	switch {
+	case slicesContainsAny(reply, telnetLockouts):
+		return ErrLockedOut
+	case telnetShell(reply):
+		return nil
+	}
+	return ErrAuthFailed
+}
+
+// telnetShell reports whether text ends in a shell prompt.
+func telnetShell(text string) bool {
+	text = strings.TrimRight(text, " ")
+	for _, p := range telnetShellPrompts {
+		if strings.HasSuffix(text, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// telnetError reports a dialog that ended without a recognized prompt.
+// A peer that never sent any text is probably not a Telnet login.
+func telnetError(ctx context.Context, t *telnetConn, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if t.text.Len() == 0 && !t.negotiated {
+		return ErrNotApplicable
+	}
+	if err == io.EOF {
+		return unexpected("Telnet", t.text.String())
+	}
+	return err
+}
+
+func slicesContainsAny(text string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// telnetConn strips option negotiation from the data read and keeps the
+// text seen since the last reset.
+type telnetConn struct {
+	conn       net.Conn
+	text       bytes.Buffer
+	negotiated bool
+}
+
+// readUntil reads until the lowercased text contains one of words or, when
+// done is given, until done accepts it. It returns the text so far.
+func (t *telnetConn) readUntil(words []string, done func(string) bool) (string, error) {
+	buf := make([]byte, 1024)
+	for {
+		text := strings.ToLower(t.text.String())
+		if slicesContainsAny(text, words) || (done != nil && t.text.Len() > 0 && done(text)) {
+			return text, nil
+		}
+		if t.text.Len() > 64<<10 {
+			return text, unexpected("Telnet", text[len(text)-80:])
+		}
+		n, err := t.conn.Read(buf)
+		if n > 0 {
+			if werr := t.process(buf[:n]); werr != nil {
+				return text, werr
+			}
+		}
+		if err != nil {
+			return text, err
+		}
+	}
+}
+
+// process appends the text in data and answers option negotiation.
+// Commands split across reads are rare enough at login to be ignored.
+func (t *telnetConn) process(data []byte) error {
+	var reply []byte
+	for i := 0; i < len(data); i++ {
+		if data[i] != telnetIAC {
+			t.text.WriteByte(data[i])
+			continue
+		}
+		if i+1 >= len(data) {
+			break
+		}
+		t.negotiated = true
+		cmd := data[i+1]
+		switch {
+		case cmd == telnetIAC:
+			i++
+		case cmd == telnetSB:
+			// Skip the subnegotiation up to IAC SE
+			end := bytes.Index(data[i:], []byte{telnetIAC, telnetSE})
+			if end < 0 {
+				return nil
+			}
+			i += end + 1
+		case cmd >= telnetWill && cmd <= telnetDont && i+2 < len(data):
+			opt := data[i+2]
+			switch cmd {
+			case telnetWill:
+				if opt == telnetOptEcho || opt == telnetOptSGA {
+					reply = append(reply, telnetIAC, telnetDo, opt)
+				} else {
+					reply = append(reply, telnetIAC, telnetDont, opt)
+				}
+			case telnetDo:
+				reply = append(reply, telnetIAC, telnetWont, opt)
+			}
+			i += 2
+		default:
+			i++
+		}
+	}
+	if len(reply) > 0 {
+		_, err := t.conn.Write(reply)
+		return err
+	}
+	return nil
+}
+
+func (t *telnetConn) writeLine(s string) error {
+	_, err := t.conn.Write([]byte(s + "\r\n"))
+	return err
+}
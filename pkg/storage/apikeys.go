@@ -0,0 +1,242 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
+)
+
+// APIKey is a persisted server API key.
+//
+// Only a hash of the key secret is stored; the plaintext token is shown
+// once at creation time and cannot be recovered.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"hash"`              // hex-encoded SHA-256 of the key secret
+	Scopes    []string   `json:"scopes"`            // read, scan:create, finding:manage, plugin:manage, admin
+	UserID    string     `json:"user_id,omitempty"` // owning user; the user's role caps the scopes
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+
+	// Limits of the key; zero uses the server's server.rate_limit settings
+	RateLimit  int `json:"rate_limit,omitempty"`  // requests per minute
+	DailyScans int `json:"daily_scans,omitempty"` // scan submissions per UTC day
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// APIKeyStore manages server API keys.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type APIKeyStore interface {
+	// Create stores a new API key.
+	//
+	// Returns ErrAlreadyExists if a key with the same ID already exists.
+	Create(ctx context.Context, orgID string, key *APIKey) error
+
+	// Get retrieves an API key by ID (including revoked keys).
+	//
+	// Returns ErrNotFound if the key does not exist.
+	Get(ctx context.Context, orgID, keyID string) (*APIKey, error)
+
+	// List returns all API keys ordered by creation time (oldest first).
+	List(ctx context.Context, orgID string) ([]*APIKey, error)
+
+	// Revoke marks an API key as revoked. Revoking an already revoked key is a no-op.
+	//
+	// Returns ErrNotFound if the key does not exist.
+	Revoke(ctx context.Context, orgID, keyID string) error
+}
+
+// APIKeyBackend is implemented by backends that can persist API keys.
+//
+// It is kept separate from Backend so that existing backends (and test
+// doubles) are not forced to implement key storage.
+type APIKeyBackend interface {
+	APIKeys() APIKeyStore
+}
+
+// APIKeys returns the API key storage interface.
+func (b *LocalBackend) APIKeys() APIKeyStore {
+	return b.apiKeyStore
+}
+
+// LocalAPIKeyStore implements APIKeyStore using one JSON file per organization.
+//
+// Storage layout:
+//
+//	{workspace}/auth/{org-id}/apikeys.json
+type LocalAPIKeyStore struct {
+	root string            // Root directory for auth data (workspace/auth)
+	keys *envelope.Keyring // Seals the key files; nil stores plaintext
+}
+
+// Create stores a new API key.
+func (s *LocalAPIKeyStore) Create(ctx context.Context, orgID string, key *APIKey) error {
+	if key == nil || key.ID == "" {
+		return NewInvalidInputError("ID", "api key ID is required")
+	}
+	if key.Hash == "" {
+		return NewInvalidInputError("Hash", "api key hash is required")
+	}
+
+	return s.file(orgID).update(func(keys map[string]*APIKey) error {
+		if _, exists := keys[key.ID]; exists {
+			return NewAlreadyExistsError("api key", key.ID)
+		}
+		if key.CreatedAt.IsZero() {
+			key.CreatedAt = time.Now()
+		}
+		keys[key.ID] = key
+		return nil
+	})
+}
+
+// Get retrieves an API key by ID.
+func (s *LocalAPIKeyStore) Get(ctx context.Context, orgID, keyID string) (*APIKey, error) {
+	keys, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := keys[keyID]
+	if !ok {
+		return nil, NewNotFoundError("api key", keyID)
+	}
+	return key, nil
+}
+
+// List returns all API keys ordered by creation time.
+func (s *LocalAPIKeyStore) List(ctx context.Context, orgID string) ([]*APIKey, error) {
+	keys, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*APIKey, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, key)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.Before(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+// Revoke marks an API key as revoked.
+func (s *LocalAPIKeyStore) Revoke(ctx context.Context, orgID, keyID string) error {
+	return s.file(orgID).update(func(keys map[string]*APIKey) error {
+		key, ok := keys[keyID]
+		if !ok {
+			return NewNotFoundError("api key", keyID)
+		}
+		if key.RevokedAt == nil {
+			now := time.Now()
+			key.RevokedAt = &now
+		}
+		return nil
+	})
+}
+
+func (s *LocalAPIKeyStore) file(orgID string) *jsonMapFile[APIKey] {
+	return &jsonMapFile[APIKey]{path: filepath.Join(s.root, orgID, "apikeys.json"), kind: "api keys", keys: s.keys}
+}
+
+// jsonMapFile is a JSON object file ({id: record}) guarded by a file lock.
+// It backs the small stores (API keys, users, tenants, target groups,
+// finding statuses, attack surface scores, the job queue and leases) of the
+// local backend.
+//
+// With keys set the file is written sealed (see envelope.Keyring.Seal);
+// sealed files are opened on read, plaintext files are read as is.
+type jsonMapFile[T any] struct {
+	path string
+	kind string            // used in error messages, e.g. "api keys"
+	keys *envelope.Keyring // optional
+}
+
+// load reads all records under a shared lock. A missing file yields an empty map.
+func (f *jsonMapFile[T]) load() (map[string]*T, error) {
+	if _, err := os.Stat(f.path); os.IsNotExist(err) {
+		return map[string]*T{}, nil
+	}
+
+	lock := flock.New(f.path + ".lock")
+	if err := lock.RLock(); err != nil {
+		return nil, fmt.Errorf("failed to acquire read lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	return f.read()
+}
+
+// update applies fn to the records under an exclusive lock and persists the
+// result if fn succeeds.
+func (f *jsonMapFile[T]) update(fn func(map[string]*T) error) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", f.kind, err)
+	}
+
+	lock := flock.New(f.path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire write lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	records, err := f.read()
+	if err != nil {
+		return err
+	}
+	if err := fn(records); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", f.kind, err)
+	}
+	if data, err = f.keys.Seal(data); err != nil {
+		return fmt.Errorf("failed to encrypt %s: %w", f.kind, err)
+	}
+
+	// Write to a temp file and rename so readers never see a partial file
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.kind, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.kind, err)
+	}
+	return nil
+}
+
+func (f *jsonMapFile[T]) read() (map[string]*T, error) {
+	data, err := os.ReadFile(f.path)
+	if os.IsNotExist(err) {
+		return map[string]*T{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.kind, err)
+	}
+	if data, err = f.keys.Open(data); err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", f.kind, err)
+	}
+
+	records := map[string]*T{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", f.kind, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
+)
+
+func newTestAPIKeyStore(t *testing.T) (APIKeyStore, string) {
+	t.Helper()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(context.Background(), &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	return backend.APIKeys(), root
+}
+
+func TestLocalAPIKeyStore_CreateGetList(t *testing.T) {
+	ctx := context.Background()
+	store, root := newTestAPIKeyStore(t)
+
+	first := &APIKey{ID: "k1", Name: "ci", Hash: "h1", Scopes: []string{"read"}, CreatedAt: time.Now().Add(-time.Minute)}
+	second := &APIKey{ID: "k2", Name: "dash", Hash: "h2", Scopes: []string{"admin"}}
+	require.NoError(t, store.Create(ctx, "default", first))
+	require.NoError(t, store.Create(ctx, "default", second))
+	require.False(t, second.CreatedAt.IsZero(), "CreatedAt should be set")
+
+	got, err := store.Get(ctx, "default", "k1")
+	require.NoError(t, err)
+	require.Equal(t, "ci", got.Name)
+	require.Equal(t, "h1", got.Hash)
+	require.False(t, got.IsRevoked())
+
+	keys, err := store.List(ctx, "default")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "k1", keys[0].ID)
+	require.Equal(t, "k2", keys[1].ID)
+
+	// Key file is private to the owner
+	info, err := os.Stat(filepath.Join(root, "auth", "default", "apikeys.json"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLocalAPIKeyStore_CreateValidation(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestAPIKeyStore(t)
+
+	err := store.Create(ctx, "default", &APIKey{Hash: "h"})
+	require.True(t, IsInvalidInput(err))
+
+	err = store.Create(ctx, "default", &APIKey{ID: "k1"})
+	require.True(t, IsInvalidInput(err))
+
+	require.NoError(t, store.Create(ctx, "default", &APIKey{ID: "k1", Hash: "h"}))
+	err = store.Create(ctx, "default", &APIKey{ID: "k1", Hash: "h"})
+	require.True(t, IsAlreadyExists(err))
+}
+
+func TestLocalAPIKeyStore_Revoke(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestAPIKeyStore(t)
+
+	require.NoError(t, store.Create(ctx, "default", &APIKey{ID: "k1", Hash: "h"}))
+	require.NoError(t, store.Revoke(ctx, "default", "k1"))
+
+	got, err := store.Get(ctx, "default", "k1")
+	require.NoError(t, err)
+	require.True(t, got.IsRevoked())
+	revokedAt := *got.RevokedAt
+
+	// Revoking again keeps the original timestamp
+	require.NoError(t, store.Revoke(ctx, "default", "k1"))
+	got, err = store.Get(ctx, "default", "k1")
+	require.NoError(t, err)
+	require.True(t, revokedAt.Equal(*got.RevokedAt))
+
+	err = store.Revoke(ctx, "default", "missing")
+	require.True(t, IsNotFound(err))
+}
+
+func TestLocalAPIKeyStore_EmptyOrg(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestAPIKeyStore(t)
+
+	keys, err := store.List(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	_, err = store.Get(ctx, "other", "k1")
+	require.True(t, IsNotFound(err))
+}
+
+func TestLocalAPIKeyStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root, Encryption: EncryptionConfig{Key: key}})
+	require.NoError(t, err)
+	store := backend.APIKeys()
+
+	require.NoError(t, store.Create(ctx, "default", &APIKey{ID: "k1", Name: "ci", Hash: "secret-hash", Scopes: []string{"read"}}))
+	got, err := store.Get(ctx, "default", "k1")
+	require.NoError(t, err)
+	require.Equal(t, "secret-hash", got.Hash)
+
+	raw, err := os.ReadFile(filepath.Join(root, "auth", "default", "apikeys.json"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(raw), envelope.Prefix))
+	require.NotContains(t, string(raw), "secret-hash")
+
+	// Reading without the key fails instead of returning no keys
+	plain, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	_, err = plain.APIKeys().List(ctx, "default")
+	require.ErrorIs(t, err, envelope.ErrNoKey)
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
+	"github.com/vulntor/vulntor/pkg/tracing"
+)
+
+// artifactNamePattern restricts artifact names to plain file names.
+var artifactNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+// Artifact describes a binary file attached to a scan, such as the packet
+// capture of a host.
+type Artifact struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// ValidateArtifactName checks that name is a plain file name: letters,
+// digits, dots, dashes and underscores, not starting with a dot.
+func ValidateArtifactName(name string) error {
+	if !artifactNamePattern.MatchString(name) {
+		return NewInvalidInputError("Name", fmt.Sprintf("invalid artifact name %q", name))
+	}
+	return nil
+}
+
+// ArtifactStore manages the binary files attached to scans. Artifacts are
+// deleted with their scan.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type ArtifactStore interface {
+	// Write stores data as the artifact name of a scan, replacing an
+	// artifact of the same name.
+	//
+	// Returns ErrInvalidInput if name is not a plain file name.
+	Write(ctx context.Context, orgID, scanID, name string, data io.Reader) error
+
+	// Open opens an artifact for reading. The caller closes it.
+	//
+	// Returns ErrNotFound if the artifact does not exist.
+	Open(ctx context.Context, orgID, scanID, name string) (io.ReadCloser, error)
+
+	// List returns the artifacts of a scan sorted by name; none if the
+	// scan has no artifacts.
+	List(ctx context.Context, orgID, scanID string) ([]Artifact, error)
+}
+
+// ArtifactBackend is implemented by backends that can store scan artifacts.
+type ArtifactBackend interface {
+	Artifacts() ArtifactStore
+}
+
+// Artifacts returns the artifact storage interface.
+func (b *LocalBackend) Artifacts() ArtifactStore {
+	return b.artifactStore
+}
+
+// LocalArtifactStore implements ArtifactStore in the scan directories.
+//
+// Storage layout:
+//
+//	{workspace}/scans/{org-id}/{scan-id}/artifacts/{name}
+//
+// Artifacts, such as packet captures, hold the raw traffic of a scan and
+// are sealed as a whole when encryption is configured.
+type LocalArtifactStore struct {
+	root string            // Root directory for scans (workspace/scans)
+	keys *envelope.Keyring // Seals artifacts; nil stores plaintext
+}
+
+// Write stores data as an artifact of a scan.
+func (s *LocalArtifactStore) Write(ctx context.Context, orgID, scanID, name string, data io.Reader) (err error) {
+	span := startScanSpan(ctx, "write_artifact", orgID, scanID, tracing.String("storage.artifact", name))
+	defer func() { endScanSpan(span, err) }()
+
+	if err := ValidateArtifactName(name); err != nil {
+		return err
+	}
+	dir := s.dir(orgID, scanID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	if s.keys != nil {
+		plaintext, err := io.ReadAll(data)
+		if err != nil {
+			return fmt.Errorf("failed to write artifact: %w", err)
+		}
+		sealed, err := s.keys.Seal(plaintext)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt artifact: %w", err)
+		}
+		data = bytes.NewReader(append(sealed, '\n'))
+	}
+
+	// Write to a temporary file first, so readers never see partial data
+	tmp, err := os.CreateTemp(dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create artifact: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := io.Copy(tmp, data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write artifact: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write artifact: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to write artifact: %w", err)
+	}
+	return nil
+}
+
+// Open opens an artifact of a scan for reading.
+func (s *LocalArtifactStore) Open(ctx context.Context, orgID, scanID, name string) (_ io.ReadCloser, err error) {
+	span := startScanSpan(ctx, "open_artifact", orgID, scanID, tracing.String("storage.artifact", name))
+	defer func() { endScanSpan(span, err) }()
+
+	if err := ValidateArtifactName(name); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(filepath.Join(s.dir(orgID, scanID), name))
+	if os.IsNotExist(err) {
+		return nil, NewNotFoundError("artifact", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	// Artifacts may be sealed, even with encryption disabled
+	return sealedFile{Reader: s.keys.NewReader(file), Closer: file}, nil
+}
+
+// List returns the artifacts of a scan.
+func (s *LocalArtifactStore) List(ctx context.Context, orgID, scanID string) (_ []Artifact, err error) {
+	span := startScanSpan(ctx, "list_artifacts", orgID, scanID)
+	defer func() { endScanSpan(span, err) }()
+
+	entries, err := os.ReadDir(s.dir(orgID, scanID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	var artifacts []Artifact
+	for _, entry := range entries {
+		if entry.IsDir() || ValidateArtifactName(entry.Name()) != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		artifacts = append(artifacts, Artifact{Name: entry.Name(), Size: info.Size(), ModifiedAt: info.ModTime()})
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+func (s *LocalArtifactStore) dir(orgID, scanID string) string {
+	return filepath.Join(s.root, orgID, scanID, "artifacts")
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalArtifactStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.Artifacts()
+
+	artifacts, err := store.List(ctx, DefaultOrgID, "scan-1")
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+
+	require.NoError(t, store.Write(ctx, DefaultOrgID, "scan-1", "10.0.0.5.pcap", strings.NewReader("first")))
+	require.NoError(t, store.Write(ctx, DefaultOrgID, "scan-1", "10.0.0.5.pcap", strings.NewReader("second")))
+	require.NoError(t, store.Write(ctx, DefaultOrgID, "scan-1", "10.0.0.1.pcap", strings.NewReader("x")))
+
+	artifacts, err = store.List(ctx, DefaultOrgID, "scan-1")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 2)
+	require.Equal(t, "10.0.0.1.pcap", artifacts[0].Name)
+	require.Equal(t, "10.0.0.5.pcap", artifacts[1].Name)
+	require.Equal(t, int64(len("second")), artifacts[1].Size)
+
+	rc, err := store.Open(ctx, DefaultOrgID, "scan-1", "10.0.0.5.pcap")
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, "second", string(data))
+
+	_, err = store.Open(ctx, DefaultOrgID, "scan-1", "missing.pcap")
+	require.True(t, IsNotFound(err))
+
+	// Artifacts are deleted with their scan
+	require.NoError(t, backend.Scans().Create(ctx, DefaultOrgID, &ScanMetadata{ID: "scan-1", Target: "10.0.0.0/24", Status: "completed"}))
+	require.NoError(t, backend.Scans().Delete(ctx, DefaultOrgID, "scan-1"))
+	artifacts, err = store.List(ctx, DefaultOrgID, "scan-1")
+	require.NoError(t, err)
+	require.Empty(t, artifacts)
+}
+
+func TestLocalArtifactStore_Validation(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.Artifacts()
+
+	for _, name := range []string{"", "../metadata.json", "a/b.pcap", ".hidden", "fe80::1.pcap"} {
+		require.True(t, IsInvalidInput(store.Write(ctx, DefaultOrgID, "scan-1", name, strings.NewReader("x"))), name)
+		_, err := store.Open(ctx, DefaultOrgID, "scan-1", name)
+		require.True(t, IsInvalidInput(err), name)
+	}
+}
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/google/uuid"
+)
+
+// Audit event outcomes.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEvent records a single mutating operation: who did what, to which
+// resource, through which interface, and whether it succeeded.
+//
+// Actions are dotted "<resource-type>.<verb>" names such as "scan.create",
+// "plugin.install" or "apikey.revoke".
+type AuditEvent struct {
+	ID        string            `json:"id"`
+	Timestamp time.Time         `json:"timestamp"`
+	Actor     string            `json:"actor"`              // user ID, "apikey:<id>" or local OS user
+	Source    string            `json:"source"`             // "cli" or "api"
+	Action    string            `json:"action"`             // e.g. "plugin.install"
+	Resource  string            `json:"resource,omitempty"` // e.g. scan ID or plugin name
+	Outcome   string            `json:"outcome"`            // success or failure
+	Error     string            `json:"error,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// AuditFilter selects audit events. Zero values match everything.
+type AuditFilter struct {
+	Actor string
+	// Action matches exactly or by resource type ("plugin" matches
+	// "plugin.install" and "plugin.uninstall").
+	Action string
+	Since  time.Time
+	Until  time.Time
+	Limit  int // 0 = no limit
+}
+
+// Matches reports whether e satisfies the filter (ignoring Limit).
+func (f AuditFilter) Matches(e *AuditEvent) bool {
+	if f.Actor != "" && e.Actor != f.Actor {
+		return false
+	}
+	if f.Action != "" && e.Action != f.Action && !strings.HasPrefix(e.Action, f.Action+".") {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.Timestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// AuditStore is an append-only log of audit events.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type AuditStore interface {
+	// Append records an event. ID and Timestamp are set when empty.
+	//
+	// Returns ErrInvalidInput if Action is missing.
+	Append(ctx context.Context, orgID string, event *AuditEvent) error
+
+	// List returns events matching filter, newest first.
+	List(ctx context.Context, orgID string, filter AuditFilter) ([]*AuditEvent, error)
+}
+
+// AuditBackend is implemented by backends that can persist an audit log.
+type AuditBackend interface {
+	Audit() AuditStore
+}
+
+// Audit returns the audit log storage interface.
+func (b *LocalBackend) Audit() AuditStore {
+	return b.auditStore
+}
+
+// LocalAuditStore implements AuditStore using one JSONL file per organization.
+//
+// Storage layout:
+//
+//	{workspace}/audit/{org-id}/events.jsonl
+type LocalAuditStore struct {
+	root string // Root directory for audit logs (workspace/audit)
+}
+
+// Append records an event.
+func (s *LocalAuditStore) Append(ctx context.Context, orgID string, event *AuditEvent) error {
+	if event == nil || event.Action == "" {
+		return NewInvalidInputError("Action", "audit action is required")
+	}
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+
+	path := s.path(orgID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire write lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append audit event: %w", err)
+	}
+	return nil
+}
+
+// List returns events matching filter, newest first.
+func (s *LocalAuditStore) List(ctx context.Context, orgID string, filter AuditFilter) ([]*AuditEvent, error) {
+	path := s.path(orgID)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return []*AuditEvent{}, nil
+	}
+
+	lock := flock.New(path + ".lock")
+	if err := lock.RLock(); err != nil {
+		return nil, fmt.Errorf("failed to acquire read lock: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var events []*AuditEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// Skip torn or corrupted lines rather than hiding the whole log
+			continue
+		}
+		if filter.Matches(&e) {
+			events = append(events, &e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	// File order is chronological; return newest first
+	out := make([]*AuditEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		out = append(out, events[i])
+		if filter.Limit > 0 && len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *LocalAuditStore) path(orgID string) string {
+	return filepath.Join(s.root, orgID, "events.jsonl")
+}
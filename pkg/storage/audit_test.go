@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditStore(t *testing.T) (AuditStore, string) {
+	t.Helper()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(context.Background(), &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	return backend.Audit(), root
+}
+
+func TestLocalAuditStore_AppendList(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestAuditStore(t)
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := []*AuditEvent{
+		{Timestamp: base, Actor: "alice", Source: "cli", Action: "plugin.install", Resource: "ssh", Outcome: AuditOutcomeSuccess},
+		{Timestamp: base.Add(time.Minute), Actor: "apikey:3f9a", Source: "api", Action: "scan.create", Resource: "scan-1", Outcome: AuditOutcomeSuccess},
+		{Timestamp: base.Add(2 * time.Minute), Actor: "alice", Source: "cli", Action: "plugin.uninstall", Resource: "ssh", Outcome: AuditOutcomeFailure, Error: "not installed"},
+	}
+	for _, e := range events {
+		require.NoError(t, store.Append(ctx, DefaultOrgID, e))
+		require.NotEmpty(t, e.ID)
+	}
+
+	all, err := store.List(ctx, DefaultOrgID, AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Equal(t, "plugin.uninstall", all[0].Action, "events should be newest first")
+	require.Equal(t, "not installed", all[0].Error)
+
+	byActor, err := store.List(ctx, DefaultOrgID, AuditFilter{Actor: "alice"})
+	require.NoError(t, err)
+	require.Len(t, byActor, 2)
+
+	byType, err := store.List(ctx, DefaultOrgID, AuditFilter{Action: "plugin"})
+	require.NoError(t, err)
+	require.Len(t, byType, 2)
+
+	exact, err := store.List(ctx, DefaultOrgID, AuditFilter{Action: "plugin.install"})
+	require.NoError(t, err)
+	require.Len(t, exact, 1)
+
+	// "plug" is not a resource type
+	none, err := store.List(ctx, DefaultOrgID, AuditFilter{Action: "plug"})
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	window, err := store.List(ctx, DefaultOrgID, AuditFilter{Since: base.Add(30 * time.Second), Until: base.Add(90 * time.Second)})
+	require.NoError(t, err)
+	require.Len(t, window, 1)
+	require.Equal(t, "scan-1", window[0].Resource)
+
+	limited, err := store.List(ctx, DefaultOrgID, AuditFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, limited, 2)
+	require.Equal(t, "scan.create", limited[1].Action)
+}
+
+func TestLocalAuditStore_TenantIsolation(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestAuditStore(t)
+
+	require.NoError(t, store.Append(ctx, "acme", &AuditEvent{Actor: "bob", Action: "scan.create"}))
+
+	events, err := store.List(ctx, DefaultOrgID, AuditFilter{})
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = store.List(ctx, "acme", AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.False(t, events[0].Timestamp.IsZero())
+}
+
+func TestLocalAuditStore_Validation(t *testing.T) {
+	store, _ := newTestAuditStore(t)
+	require.True(t, IsInvalidInput(store.Append(context.Background(), DefaultOrgID, nil)))
+	require.True(t, IsInvalidInput(store.Append(context.Background(), DefaultOrgID, &AuditEvent{Actor: "alice"})))
+}
+
+func TestLocalAuditStore_SkipsCorruptLines(t *testing.T) {
+	ctx := context.Background()
+	store, root := newTestAuditStore(t)
+
+	require.NoError(t, store.Append(ctx, DefaultOrgID, &AuditEvent{Action: "scan.create"}))
+
+	path := filepath.Join(root, "audit", DefaultOrgID, "events.jsonl")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString("{truncated\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, store.Append(ctx, DefaultOrgID, &AuditEvent{Action: "scan.delete"}))
+
+	events, err := store.List(ctx, DefaultOrgID, AuditFilter{})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+}
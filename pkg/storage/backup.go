@@ -0,0 +1,451 @@
+package storage
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// Backups are .tar.gz archives of the storage:
+//
+//	workspace/<path>   files of the workspace: scans, auth, tenants,
+//	                   plugins, wordlists and the job queue
+//	config/<name>      the config file, if one was included
+//	manifest.json      BackupManifest, checksums of every file above
+//
+// The manifest comes last, so archives are written in one pass; restoring
+// verifies every file against it before touching the workspace.
+const (
+	// BackupFormatVersion is the version of the archive format.
+	BackupFormatVersion = 1
+
+	backupManifestName = "manifest.json"
+	backupWorkspaceDir = "workspace"
+	backupConfigDir    = "config"
+)
+
+// BackupManifest describes the contents of a backup archive.
+type BackupManifest struct {
+	FormatVersion     int       `json:"format_version"`
+	CreatedAt         time.Time `json:"created_at"`
+	ScanSchemaVersion int       `json:"scan_schema_version"`
+
+	// Files are the files of the workspace, by path relative to it.
+	Files []BackupFile `json:"files"`
+
+	// ConfigFile is the archive path of the config file, if included.
+	ConfigFile string `json:"config_file,omitempty"`
+
+	// SkippedScans are the scans left out because they were still pending
+	// or running, as org-id/scan-id.
+	SkippedScans []string `json:"skipped_scans,omitempty"`
+}
+
+// Size returns the total size of the files in the archive.
+func (m *BackupManifest) Size() int64 {
+	var n int64
+	for _, f := range m.Files {
+		n += f.Size
+	}
+	return n
+}
+
+// BackupFile is a file of a backup archive.
+type BackupFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BackupOptions defines options for backing up the storage.
+type BackupOptions struct {
+	// ConfigFile is a config file to include, e.g. the one passed with
+	// --config. Empty includes none.
+	ConfigFile string
+
+	// IncludeActive includes pending and running scans, whose files may be
+	// caught while they are written.
+	IncludeActive bool
+}
+
+// RestoreOptions defines options for restoring a backup.
+type RestoreOptions struct {
+	// DryRun verifies the archive without restoring it.
+	DryRun bool
+
+	// Force replaces a workspace that is not empty. The replaced workspace
+	// is kept next to it.
+	Force bool
+
+	// ConfigPath is where the config file of the archive is written.
+	// Empty leaves it out.
+	ConfigPath string
+}
+
+// RestoreResult contains the results of a restore.
+type RestoreResult struct {
+	Manifest *BackupManifest
+
+	// PreviousWorkspace is where the replaced workspace was moved, if any.
+	PreviousWorkspace string
+
+	// ConfigPath is where the config file was written, if any.
+	ConfigPath string
+}
+
+// BackupBackend is implemented by backends that can snapshot their state
+// into a portable archive and restore it.
+type BackupBackend interface {
+	Backup(ctx context.Context, w io.Writer, opts BackupOptions) (*BackupManifest, error)
+	Restore(ctx context.Context, r io.Reader, opts RestoreOptions) (*RestoreResult, error)
+}
+
+// Backup writes the workspace to w as a backup archive.
+//
+// Each file is copied under its file lock, if it has one, so that no file
+// is caught half-written; scans still pending or running are left out
+// unless opts.IncludeActive is set. Lock and temporary files and leases,
+// which belong to running processes, are not backed up.
+func (b *LocalBackend) Backup(ctx context.Context, w io.Writer, opts BackupOptions) (*BackupManifest, error) {
+	root := b.cfg.WorkspaceRoot
+	manifest := &BackupManifest{
+		FormatVersion:     BackupFormatVersion,
+		CreatedAt:         time.Now().UTC(),
+		ScanSchemaVersion: ScanSchemaVersion,
+		Files:             make([]BackupFile, 0),
+	}
+
+	skip := map[string]bool{}
+	if !opts.IncludeActive {
+		active, err := b.activeScanDirs()
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range active {
+			skip[dir] = true
+			manifest.SkippedScans = append(manifest.SkippedScans, strings.TrimPrefix(dir, "scans/"))
+		}
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if skip[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !backedUp(rel) {
+			return nil
+		}
+
+		file, err := writeBackupFile(tw, p, path.Join(backupWorkspaceDir, rel))
+		if err != nil {
+			return err
+		}
+		file.Path = rel
+		manifest.Files = append(manifest.Files, file)
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("back up workspace: %w", err)
+	}
+
+	if opts.ConfigFile != "" {
+		name := path.Join(backupConfigDir, filepath.Base(opts.ConfigFile))
+		if _, err := writeBackupFile(tw, opts.ConfigFile, name); err != nil {
+			return nil, fmt.Errorf("back up config file: %w", err)
+		}
+		manifest.ConfigFile = name
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode backup manifest: %w", err)
+	}
+	hdr := &tar.Header{
+		Name:     backupManifestName,
+		Mode:     0o600,
+		Size:     int64(len(data)),
+		ModTime:  manifest.CreatedAt,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, fmt.Errorf("write backup: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return nil, fmt.Errorf("write backup: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("write backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("write backup: %w", err)
+	}
+	return manifest, nil
+}
+
+// backedUp reports whether the workspace file at rel belongs in a backup.
+func backedUp(rel string) bool {
+	base := path.Base(rel)
+	return rel != "leases.json" && !strings.HasSuffix(base, ".lock") && !strings.HasSuffix(base, ".tmp")
+}
+
+// activeScanDirs returns the directories of pending and running scans,
+// relative to the workspace.
+func (b *LocalBackend) activeScanDirs() ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(b.cfg.WorkspaceRoot, "scans", "*", "*", "metadata.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, m := range matches {
+		data, err := os.ReadFile(m)
+		if err != nil {
+			return nil, fmt.Errorf("read scan metadata: %w", err)
+		}
+		var meta struct {
+			Status ScanStatus `json:"status"`
+		}
+		// Unreadable metadata is backed up as is
+		if json.Unmarshal(data, &meta) != nil || meta.Status.IsTerminal() {
+			continue
+		}
+		rel, err := filepath.Rel(b.cfg.WorkspaceRoot, filepath.Dir(m))
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, filepath.ToSlash(rel))
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// writeBackupFile copies the file at p into tw as name, under the file
+// lock of p if it has one, and returns its size and checksum.
+func writeBackupFile(tw *tar.Writer, p, name string) (BackupFile, error) {
+	if _, err := os.Stat(p + ".lock"); err == nil {
+		lock := flock.New(p + ".lock")
+		if err := lock.RLock(); err != nil {
+			return BackupFile{}, fmt.Errorf("failed to acquire read lock: %w", err)
+		}
+		defer func() { _ = lock.Unlock() }()
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return BackupFile{}, err
+	}
+
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return BackupFile{}, err
+	}
+	// A file growing while it is copied is cut at the size it had
+	h := sha256.New()
+	if _, err := io.CopyN(io.MultiWriter(tw, h), f, info.Size()); err != nil {
+		return BackupFile{}, fmt.Errorf("copy %s: %w", name, err)
+	}
+	return BackupFile{Size: info.Size(), SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// Restore replaces the workspace with the contents of a backup archive.
+//
+// The archive is unpacked next to the workspace and verified against its
+// manifest first; the workspace is only replaced, by renaming, once every
+// file checks out, so a damaged archive leaves it untouched. A workspace
+// that is not empty is only replaced with opts.Force. No process should use
+// the workspace during a restore.
+func (b *LocalBackend) Restore(ctx context.Context, r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	root := b.cfg.WorkspaceRoot
+	stamp := time.Now().UTC().Format("20060102-150405")
+	staging := root + ".restore-" + stamp
+	if err := os.MkdirAll(staging, 0o700); err != nil {
+		return nil, fmt.Errorf("create restore directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+
+	manifest, err := unpackBackup(ctx, r, staging)
+	if err != nil {
+		return nil, err
+	}
+	result := &RestoreResult{Manifest: manifest}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if entries, err := os.ReadDir(root); err == nil && len(entries) > 0 {
+		if !opts.Force {
+			return nil, NewAlreadyExistsError("workspace", root)
+		}
+		result.PreviousWorkspace = root + ".pre-restore-" + stamp
+		if err := os.Rename(root, result.PreviousWorkspace); err != nil {
+			return nil, fmt.Errorf("move workspace aside: %w", err)
+		}
+	} else if err := os.RemoveAll(root); err != nil {
+		return nil, fmt.Errorf("replace workspace: %w", err)
+	}
+	if err := os.Rename(filepath.Join(staging, backupWorkspaceDir), root); err != nil {
+		return nil, fmt.Errorf("replace workspace: %w", err)
+	}
+
+	if opts.ConfigPath != "" && manifest.ConfigFile != "" {
+		data, err := os.ReadFile(filepath.Join(staging, filepath.FromSlash(manifest.ConfigFile)))
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(opts.ConfigPath), 0o700); err != nil {
+			return nil, fmt.Errorf("write config file: %w", err)
+		}
+		if err := os.WriteFile(opts.ConfigPath, data, 0o600); err != nil {
+			return nil, fmt.Errorf("write config file: %w", err)
+		}
+		result.ConfigPath = opts.ConfigPath
+	}
+	return result, nil
+}
+
+// unpackBackup unpacks a backup archive into dir and verifies it against
+// its manifest.
+func unpackBackup(ctx context.Context, r io.Reader, dir string) (*BackupManifest, error) {
+	invalid := func(format string, args ...any) error {
+		return NewInvalidInputError("archive", fmt.Sprintf(format, args...))
+	}
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, invalid("not a backup archive: %v", err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+
+	var manifest *BackupManifest
+	unpacked := make(map[string]BackupFile)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, invalid("read archive: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Name == backupManifestName {
+			manifest = &BackupManifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, invalid("parse manifest: %v", err)
+			}
+			continue
+		}
+		if !filepath.IsLocal(hdr.Name) {
+			return nil, invalid("unsafe path %q", hdr.Name)
+		}
+		file, err := unpackFile(tr, filepath.Join(dir, filepath.FromSlash(hdr.Name)), fs.FileMode(hdr.Mode).Perm())
+		if err != nil {
+			return nil, fmt.Errorf("unpack %s: %w", hdr.Name, err)
+		}
+		unpacked[hdr.Name] = file
+	}
+
+	if manifest == nil {
+		return nil, invalid("no manifest")
+	}
+	if manifest.FormatVersion > BackupFormatVersion {
+		return nil, invalid("format version %d is newer than supported version %d", manifest.FormatVersion, BackupFormatVersion)
+	}
+	if manifest.ScanSchemaVersion > ScanSchemaVersion {
+		return nil, invalid("scan schema version %d is newer than supported version %d", manifest.ScanSchemaVersion, ScanSchemaVersion)
+	}
+
+	expected := len(manifest.Files)
+	for _, want := range manifest.Files {
+		got, ok := unpacked[path.Join(backupWorkspaceDir, want.Path)]
+		if !ok {
+			return nil, invalid("missing file %s", want.Path)
+		}
+		if got.Size != want.Size || got.SHA256 != want.SHA256 {
+			return nil, invalid("checksum mismatch for %s", want.Path)
+		}
+	}
+	if manifest.ConfigFile != "" {
+		if _, ok := unpacked[manifest.ConfigFile]; !ok {
+			return nil, invalid("missing config file %s", manifest.ConfigFile)
+		}
+		expected++
+	}
+	if len(unpacked) != expected {
+		return nil, invalid("%d files not listed in the manifest", len(unpacked)-expected)
+	}
+
+	// An empty workspace is still restored as a directory
+	if err := os.MkdirAll(filepath.Join(dir, backupWorkspaceDir), 0o700); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// unpackFile writes the content of the current archive entry to p and
+// returns its size and checksum.
+func unpackFile(r io.Reader, p string, perm fs.FileMode) (BackupFile, error) {
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return BackupFile{}, err
+	}
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm|0o600)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return BackupFile{}, err
+	}
+	return BackupFile{Size: n, SHA256: hex.EncodeToString(h.Sum(nil))}, nil
+}
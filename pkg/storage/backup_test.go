@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend_BackupRestore(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "workspace")
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+
+	scans := backend.Scans()
+	require.NoError(t, scans.Create(ctx, DefaultOrgID, &ScanMetadata{ID: "done", Target: "10.0.0.1", Status: string(StatusCompleted)}))
+	require.NoError(t, scans.WriteData(ctx, DefaultOrgID, "done", DataTypeHosts, strings.NewReader(`{"ip":"10.0.0.1"}`+"\n")))
+	require.NoError(t, scans.Create(ctx, DefaultOrgID, &ScanMetadata{ID: "active", Target: "10.0.0.2", Status: string(StatusRunning)}))
+	require.NoError(t, backend.Jobs().Save(ctx, &QueuedJob{ID: "scan-1", Type: "scan", SubmittedAt: time.Now()}))
+	_, err = backend.Leases().Acquire(ctx, "server-leader", "node-a", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "plugins"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "plugins", "registry.json"), []byte(`{"plugins":{}}`), 0o600))
+	configFile := filepath.Join(t.TempDir(), "vulntor.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("server:\n  port: 8080\n"), 0o600))
+
+	var archive bytes.Buffer
+	manifest, err := backend.Backup(ctx, &archive, BackupOptions{ConfigFile: configFile})
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultOrgID + "/active"}, manifest.SkippedScans)
+	require.Equal(t, "config/vulntor.yaml", manifest.ConfigFile)
+	var paths []string
+	for _, f := range manifest.Files {
+		paths = append(paths, f.Path)
+		require.NotContains(t, f.Path, ".lock")
+	}
+	require.Contains(t, paths, "scans/"+DefaultOrgID+"/done/metadata.json")
+	require.Contains(t, paths, "jobs/queue.json")
+	require.Contains(t, paths, "plugins/registry.json")
+	require.NotContains(t, paths, "leases.json")
+
+	// A dry run verifies the archive only
+	result, err := backend.Restore(ctx, bytes.NewReader(archive.Bytes()), RestoreOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, result.Manifest.Files, len(manifest.Files))
+
+	// An existing workspace is only replaced with Force
+	_, err = backend.Restore(ctx, bytes.NewReader(archive.Bytes()), RestoreOptions{})
+	require.True(t, IsAlreadyExists(err))
+
+	require.NoError(t, scans.Delete(ctx, DefaultOrgID, "done"))
+	restoredConfig := filepath.Join(t.TempDir(), "restored.yaml")
+	result, err = backend.Restore(ctx, bytes.NewReader(archive.Bytes()), RestoreOptions{Force: true, ConfigPath: restoredConfig})
+	require.NoError(t, err)
+	require.DirExists(t, result.PreviousWorkspace)
+	require.Equal(t, restoredConfig, result.ConfigPath)
+	config, err := os.ReadFile(restoredConfig)
+	require.NoError(t, err)
+	require.Equal(t, "server:\n  port: 8080\n", string(config))
+
+	scan, err := scans.Get(ctx, DefaultOrgID, "done")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", scan.Target)
+	hosts, err := scans.ReadData(ctx, DefaultOrgID, "done", DataTypeHosts)
+	require.NoError(t, err)
+	data, _ := io.ReadAll(hosts)
+	_ = hosts.Close()
+	require.Equal(t, `{"ip":"10.0.0.1"}`+"\n", string(data))
+	_, err = scans.Get(ctx, DefaultOrgID, "active")
+	require.True(t, IsNotFound(err))
+	jobs, err := backend.Jobs().List(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	// Into an empty workspace, e.g. on another machine
+	other, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: filepath.Join(t.TempDir(), "fresh")})
+	require.NoError(t, err)
+	result, err = other.Restore(ctx, bytes.NewReader(archive.Bytes()), RestoreOptions{})
+	require.NoError(t, err)
+	require.Empty(t, result.PreviousWorkspace)
+	_, err = other.Scans().Get(ctx, DefaultOrgID, "done")
+	require.NoError(t, err)
+}
+
+func TestLocalBackend_RestoreRejectsDamagedArchive(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "workspace")
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, backend.Scans().Create(ctx, DefaultOrgID, &ScanMetadata{ID: "done", Target: "10.0.0.1", Status: string(StatusCompleted)}))
+
+	var archive bytes.Buffer
+	_, err = backend.Backup(ctx, &archive, BackupOptions{})
+	require.NoError(t, err)
+
+	// Rewrite the archive with a changed scan
+	var damaged bytes.Buffer
+	gzr, err := gzip.NewReader(&archive)
+	require.NoError(t, err)
+	tr := tar.NewReader(gzr)
+	gzw := gzip.NewWriter(&damaged)
+	tw := tar.NewWriter(gzw)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		if strings.HasSuffix(hdr.Name, "metadata.json") {
+			data = bytes.Replace(data, []byte("10.0.0.1"), []byte("10.0.0.9"), 1)
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	_, err = backend.Restore(ctx, &damaged, RestoreOptions{Force: true})
+	require.True(t, IsInvalidInput(err))
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	// The workspace is left as is
+	scan, err := backend.Scans().Get(ctx, DefaultOrgID, "done")
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1", scan.Target)
+	entries, err := os.ReadDir(filepath.Dir(root))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the restore directory is removed")
+
+	_, err = backend.Restore(ctx, strings.NewReader("not an archive"), RestoreOptions{DryRun: true})
+	require.True(t, IsInvalidInput(err))
+}
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
 )
 
 // RetentionConfig defines scan retention policies for automatic cleanup.
@@ -46,6 +49,40 @@ func (r *RetentionConfig) Validate() error {
 	return nil
 }
 
+// EncryptionConfig defines encryption at rest of sensitive stored data:
+// captured banners, raw evidence (which holds the credentials found by
+// default credential checks) and API keys.
+//
+// Keys are references resolved by envelope.ResolveKey: a base64 encoded
+// 32 byte key, env:NAME, file:PATH, exec:COMMAND (e.g. a KMS CLI) or
+// keyring:SERVICE/ACCOUNT. Data written before encryption was enabled
+// stays readable; data written with a previous key needs that key in
+// PreviousKeys.
+type EncryptionConfig struct {
+	// Key encrypts new data. Empty disables encryption (default).
+	Key string `yaml:"key" env:"VULNTOR_ENCRYPTION_KEY"`
+
+	// PreviousKeys decrypt data written before a key rotation.
+	PreviousKeys []string `yaml:"previous_keys" env:"VULNTOR_ENCRYPTION_PREVIOUS_KEYS"`
+}
+
+// IsEnabled returns true if an encryption key is configured.
+func (e *EncryptionConfig) IsEnabled() bool {
+	return e.Key != ""
+}
+
+// Keyring resolves the configured keys. It returns nil when encryption is
+// disabled.
+func (e *EncryptionConfig) Keyring() (*envelope.Keyring, error) {
+	if !e.IsEnabled() {
+		if len(e.PreviousKeys) > 0 {
+			return nil, errors.New("previous_keys requires key")
+		}
+		return nil, nil
+	}
+	return envelope.LoadKeyring(e.Key, e.PreviousKeys...)
+}
+
 // Config holds storage backend configuration.
 //
 // This configuration structure supports both OSS and Enterprise editions.
@@ -63,6 +100,10 @@ type Config struct {
 	// Retention policy configuration (applies to both OSS and Enterprise)
 	Retention RetentionConfig `yaml:"retention"`
 
+	// Encryption configures encryption at rest of sensitive data
+	// (applies to both OSS and Enterprise)
+	Encryption EncryptionConfig `yaml:"encryption"`
+
 	// Enterprise Edition fields (ignored by OSS)
 
 	// DatabaseURL is the PostgreSQL connection string (Enterprise).
@@ -170,9 +211,16 @@ func DefaultConfig() (*Config, error) {
 		return nil, err
 	}
 
-	return &Config{
+	cfg := &Config{
 		WorkspaceRoot: workspaceRoot,
-	}, nil
+		Encryption: EncryptionConfig{
+			Key: os.Getenv("VULNTOR_ENCRYPTION_KEY"),
+		},
+	}
+	if previous := os.Getenv("VULNTOR_ENCRYPTION_PREVIOUS_KEYS"); previous != "" {
+		cfg.Encryption.PreviousKeys = strings.Split(previous, ",")
+	}
+	return cfg, nil
 }
 
 // Platform detection helpers
@@ -143,6 +143,27 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultConfig_Encryption(t *testing.T) {
+	t.Setenv("VULNTOR_ENCRYPTION_KEY", "env:STORAGE_KEY")
+	t.Setenv("VULNTOR_ENCRYPTION_PREVIOUS_KEYS", "file:/etc/vulntor/old.key,env:OLD_KEY")
+
+	cfg, err := DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig() failed: %v", err)
+	}
+	if !cfg.Encryption.IsEnabled() || cfg.Encryption.Key != "env:STORAGE_KEY" {
+		t.Errorf("Encryption.Key = %q, want env:STORAGE_KEY", cfg.Encryption.Key)
+	}
+	if len(cfg.Encryption.PreviousKeys) != 2 || cfg.Encryption.PreviousKeys[1] != "env:OLD_KEY" {
+		t.Errorf("Encryption.PreviousKeys = %v", cfg.Encryption.PreviousKeys)
+	}
+
+	disabled := EncryptionConfig{PreviousKeys: []string{"env:OLD_KEY"}}
+	if _, err := disabled.Keyring(); err == nil {
+		t.Error("Keyring() with previous keys only should fail")
+	}
+}
+
 func TestPlatformDetection(t *testing.T) {
 	t.Run("isWindows", func(t *testing.T) {
 		// Just test that it doesn't panic
@@ -18,7 +18,13 @@ import "context"
 
 type ctxKey string
 
-const configKey ctxKey = "storage.config"
+const (
+	configKey ctxKey = "storage.config"
+	orgIDKey  ctxKey = "storage.org_id"
+)
+
+// DefaultOrgID is the organization (tenant) used when none is selected.
+const DefaultOrgID = "default"
 
 // WithConfig stores the storage configuration on the provided context.
 func WithConfig(ctx context.Context, cfg *Config) context.Context {
@@ -39,3 +45,23 @@ func ConfigFromContext(ctx context.Context) (*Config, bool) {
 	}
 	return nil, false
 }
+
+// WithOrgID selects the organization (tenant) for storage operations.
+func WithOrgID(ctx context.Context, orgID string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, orgIDKey, orgID)
+}
+
+// OrgIDFromContext returns the organization selected with WithOrgID,
+// or DefaultOrgID if none was set.
+func OrgIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return DefaultOrgID
+	}
+	if id, ok := ctx.Value(orgIDKey).(string); ok && id != "" {
+		return id
+	}
+	return DefaultOrgID
+}
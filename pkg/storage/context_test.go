@@ -119,3 +119,19 @@ func TestConfigFromContext(t *testing.T) {
 		}
 	})
 }
+
+func TestOrgIDFromContext(t *testing.T) {
+	if got := OrgIDFromContext(context.Background()); got != DefaultOrgID {
+		t.Errorf("expected default org, got %q", got)
+	}
+	//nolint:staticcheck // Testing nil context handling
+	if got := OrgIDFromContext(nil); got != DefaultOrgID {
+		t.Errorf("expected default org for nil context, got %q", got)
+	}
+	if got := OrgIDFromContext(WithOrgID(context.Background(), "team-a")); got != "team-a" {
+		t.Errorf("expected team-a, got %q", got)
+	}
+	if got := OrgIDFromContext(WithOrgID(context.Background(), "")); got != DefaultOrgID {
+		t.Errorf("expected default org for empty ID, got %q", got)
+	}
+}
@@ -0,0 +1,321 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Finding statuses recorded by rechecks.
+const (
+	FindingStillPresent = "still-present"
+	FindingFixed        = "fixed"
+)
+
+// Finding triage states. Findings are open until triaged.
+const (
+	FindingStateOpen          = "open"
+	FindingStateAcknowledged  = "acknowledged"
+	FindingStateInProgress    = "in-progress"
+	FindingStateFixed         = "fixed"
+	FindingStateAcceptedRisk  = "accepted-risk"
+	FindingStateFalsePositive = "false-positive"
+)
+
+// FindingStates lists the finding triage states in workflow order.
+var FindingStates = []string{
+	FindingStateOpen,
+	FindingStateAcknowledged,
+	FindingStateInProgress,
+	FindingStateFixed,
+	FindingStateAcceptedRisk,
+	FindingStateFalsePositive,
+}
+
+// ValidateFindingState returns ErrInvalidInput for unknown triage states.
+func ValidateFindingState(state string) error {
+	if !slices.Contains(FindingStates, state) {
+		return NewInvalidInputError("state", fmt.Sprintf("unknown finding state %q (valid: %s)", state, strings.Join(FindingStates, ", ")))
+	}
+	return nil
+}
+
+// FindingCheck is one recheck of a finding.
+type FindingCheck struct {
+	Status    string    `json:"status"`
+	ScanID    string    `json:"scan_id"` // the recheck run
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// FindingTransition is one triage change of a finding.
+type FindingTransition struct {
+	From     string    `json:"from"`
+	To       string    `json:"to"`
+	Assignee string    `json:"assignee,omitempty"` // the assignee after the change
+	Actor    string    `json:"actor,omitempty"`
+	Note     string    `json:"note,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// FindingStatus tracks the triage state of a finding and whether it is
+// still present after remediation. Findings are identified across scans by the fingerprint of
+// the plugin, target and port that reported them (see
+// ticketing.Fingerprint), so a finding reported by many scans has a single
+// status.
+type FindingStatus struct {
+	ID       string `json:"id"`
+	Target   string `json:"target"`
+	Port     int    `json:"port,omitempty"`
+	Plugin   string `json:"plugin"`
+	PluginID string `json:"plugin_id,omitempty"`
+	ScanID   string `json:"scan_id"` // the scan that reported the finding
+
+	// Status and CheckedAt are those of the latest check; History lists
+	// every check, oldest first.
+	Status    string         `json:"status"`
+	CheckedAt time.Time      `json:"checked_at"`
+	History   []FindingCheck `json:"history"`
+
+	// State and Assignee are the triage state and owner of the finding;
+	// Transitions lists every triage change, oldest first.
+	State       string              `json:"state"`
+	Assignee    string              `json:"assignee,omitempty"`
+	Transitions []FindingTransition `json:"transitions,omitempty"`
+
+	// Severity is the latest reported severity. FirstSeen is when a scan
+	// first reported the finding and DueAt when it must be remediated by
+	// (none without an SLA for its severity); RemindedAt is when webhooks
+	// were reminded that it is overdue.
+	Severity   string     `json:"severity,omitempty"`
+	FirstSeen  *time.Time `json:"first_seen,omitempty"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	RemindedAt *time.Time `json:"reminded_at,omitempty"`
+}
+
+// Closed reports whether the finding was triaged as fixed, accepted-risk
+// or false-positive, or its latest recheck found it fixed.
+func (s *FindingStatus) Closed() bool {
+	switch s.State {
+	case FindingStateFixed, FindingStateAcceptedRisk, FindingStateFalsePositive:
+		return true
+	}
+	return s.Status == FindingFixed
+}
+
+// Overdue reports whether the finding is past its due date at now and not
+// closed.
+func (s *FindingStatus) Overdue(now time.Time) bool {
+	return s.DueAt != nil && now.After(*s.DueAt) && !s.Closed()
+}
+
+// FindingStatusStore manages the finding statuses of an organization.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type FindingStatusStore interface {
+	// Get retrieves the status of a finding by ID.
+	//
+	// Returns ErrNotFound if the finding was never checked, triaged or
+	// tracked.
+	Get(ctx context.Context, orgID, id string) (*FindingStatus, error)
+
+	// List returns the statuses of all known findings ordered by ID.
+	List(ctx context.Context, orgID string) ([]*FindingStatus, error)
+
+	// Record appends check to the history of the finding, creating its
+	// status from status on its first check, and makes check its latest
+	// status. status is updated to the stored record.
+	//
+	// Returns ErrInvalidInput for statuses without an ID.
+	Record(ctx context.Context, orgID string, status *FindingStatus, check FindingCheck) error
+
+	// Transition moves the finding to the state and assignee of t,
+	// creating its status from status when it was never checked or
+	// triaged, and appends t to its transitions with From set to the
+	// previous state. status is updated to the stored record.
+	//
+	// Returns ErrInvalidInput for statuses without an ID and unknown
+	// states.
+	Transition(ctx context.Context, orgID string, status *FindingStatus, t FindingTransition) error
+
+	// Track records that a scan reported the findings of seen. Findings
+	// without a status are created from theirs; tracked findings keep
+	// their FirstSeen and DueAt and take those of seen when they have
+	// none, e.g. when they were rechecked before being tracked. Severity
+	// is always updated. seen is updated to the stored records.
+	//
+	// Returns ErrInvalidInput for statuses without an ID.
+	Track(ctx context.Context, orgID string, seen []*FindingStatus) error
+
+	// MarkReminded sets the RemindedAt of the findings with the given IDs
+	// to at. Unknown IDs are ignored.
+	MarkReminded(ctx context.Context, orgID string, ids []string, at time.Time) error
+}
+
+// FindingStatusBackend is implemented by backends that can persist finding
+// statuses.
+type FindingStatusBackend interface {
+	FindingStatuses() FindingStatusStore
+}
+
+// FindingStatuses returns the finding status storage interface.
+func (b *LocalBackend) FindingStatuses() FindingStatusStore {
+	return b.findingStore
+}
+
+// LocalFindingStatusStore implements FindingStatusStore using one JSON file
+// per organization.
+//
+// Storage layout:
+//
+//	{workspace}/findings/{org-id}/finding_status.json
+type LocalFindingStatusStore struct {
+	root string // Root directory for finding statuses (workspace/findings)
+}
+
+// Get retrieves the status of a finding by ID.
+func (s *LocalFindingStatusStore) Get(ctx context.Context, orgID, id string) (*FindingStatus, error) {
+	statuses, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	status, ok := statuses[id]
+	if !ok {
+		return nil, NewNotFoundError("finding status", id)
+	}
+	return status, nil
+}
+
+// List returns the statuses of all known findings ordered by ID.
+func (s *LocalFindingStatusStore) List(ctx context.Context, orgID string) ([]*FindingStatus, error) {
+	statuses, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*FindingStatus, 0, len(statuses))
+	for _, status := range statuses {
+		out = append(out, status)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Record appends check to the history of the finding and makes it its
+// latest status.
+func (s *LocalFindingStatusStore) Record(ctx context.Context, orgID string, status *FindingStatus, check FindingCheck) error {
+	if status == nil || status.ID == "" {
+		return NewInvalidInputError("ID", "finding ID is required")
+	}
+	if check.CheckedAt.IsZero() {
+		check.CheckedAt = time.Now()
+	}
+
+	return s.file(orgID).update(func(statuses map[string]*FindingStatus) error {
+		current := findingStatus(statuses, status)
+		current.Status = check.Status
+		current.CheckedAt = check.CheckedAt
+		current.History = append(current.History, check)
+		*status = *current
+		return nil
+	})
+}
+
+// Transition moves the finding to the state and assignee of t.
+func (s *LocalFindingStatusStore) Transition(ctx context.Context, orgID string, status *FindingStatus, t FindingTransition) error {
+	if status == nil || status.ID == "" {
+		return NewInvalidInputError("ID", "finding ID is required")
+	}
+	if err := ValidateFindingState(t.To); err != nil {
+		return err
+	}
+	if t.At.IsZero() {
+		t.At = time.Now()
+	}
+
+	return s.file(orgID).update(func(statuses map[string]*FindingStatus) error {
+		current := findingStatus(statuses, status)
+		t.From = current.State
+		current.State = t.To
+		current.Assignee = t.Assignee
+		current.Transitions = append(current.Transitions, t)
+		*status = *current
+		return nil
+	})
+}
+
+// Track records that a scan reported the findings of seen.
+func (s *LocalFindingStatusStore) Track(ctx context.Context, orgID string, seen []*FindingStatus) error {
+	for _, status := range seen {
+		if status == nil || status.ID == "" {
+			return NewInvalidInputError("ID", "finding ID is required")
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+
+	return s.file(orgID).update(func(statuses map[string]*FindingStatus) error {
+		for _, status := range seen {
+			current := findingStatus(statuses, status)
+			if current.FirstSeen == nil {
+				current.FirstSeen = status.FirstSeen
+			}
+			if current.DueAt == nil {
+				current.DueAt = status.DueAt
+			}
+			if status.Severity != "" {
+				current.Severity = status.Severity
+			}
+			*status = *current
+		}
+		return nil
+	})
+}
+
+// MarkReminded sets the RemindedAt of the findings with the given IDs.
+func (s *LocalFindingStatusStore) MarkReminded(ctx context.Context, orgID string, ids []string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return s.file(orgID).update(func(statuses map[string]*FindingStatus) error {
+		for _, id := range ids {
+			if status, ok := statuses[id]; ok {
+				status.RemindedAt = &at
+			}
+		}
+		return nil
+	})
+}
+
+// findingStatus returns the stored status of the finding of status,
+// adding an open one with the details of status if there is none.
+func findingStatus(statuses map[string]*FindingStatus, status *FindingStatus) *FindingStatus {
+	current, ok := statuses[status.ID]
+	if !ok {
+		current = &FindingStatus{
+			ID:       status.ID,
+			Target:   status.Target,
+			Port:     status.Port,
+			Plugin:   status.Plugin,
+			PluginID: status.PluginID,
+			ScanID:   status.ScanID,
+		}
+		statuses[status.ID] = current
+	}
+	if current.State == "" {
+		current.State = FindingStateOpen // Recorded before triage states
+	}
+	return current
+}
+
+func (s *LocalFindingStatusStore) file(orgID string) *jsonMapFile[FindingStatus] {
+	return &jsonMapFile[FindingStatus]{path: filepath.Join(s.root, orgID, "finding_status.json"), kind: "finding statuses"}
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalFindingStatusStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.FindingStatuses()
+
+	_, err = store.Get(ctx, DefaultOrgID, "14d34128101e76ec")
+	require.True(t, IsNotFound(err))
+
+	status := &FindingStatus{ID: "14d34128101e76ec", Target: "10.0.1.5", Port: 22, Plugin: "ssh-weak-cipher", ScanID: "scan-1"}
+	checked := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(ctx, DefaultOrgID, status, FindingCheck{Status: FindingStillPresent, ScanID: "recheck-1", CheckedAt: checked}))
+	require.Equal(t, FindingStillPresent, status.Status)
+	require.Len(t, status.History, 1)
+
+	// Later checks keep the finding's details and extend its history
+	require.NoError(t, store.Record(ctx, DefaultOrgID, &FindingStatus{ID: "14d34128101e76ec", ScanID: "scan-2"},
+		FindingCheck{Status: FindingFixed, ScanID: "recheck-2", CheckedAt: checked.Add(time.Hour)}))
+	got, err := store.Get(ctx, DefaultOrgID, "14d34128101e76ec")
+	require.NoError(t, err)
+	require.Equal(t, "scan-1", got.ScanID)
+	require.Equal(t, "10.0.1.5", got.Target)
+	require.Equal(t, FindingFixed, got.Status)
+	require.Equal(t, checked.Add(time.Hour), got.CheckedAt.UTC())
+	require.Equal(t, []string{"recheck-1", "recheck-2"}, []string{got.History[0].ScanID, got.History[1].ScanID})
+
+	require.NoError(t, store.Record(ctx, DefaultOrgID, &FindingStatus{ID: "0a1b2c3d4e5f6a7b"}, FindingCheck{Status: FindingFixed}))
+	statuses, err := store.List(ctx, DefaultOrgID)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, "0a1b2c3d4e5f6a7b", statuses[0].ID)
+	require.False(t, statuses[0].CheckedAt.IsZero())
+
+	// Statuses are kept per organization
+	statuses, err = store.List(ctx, "team-a")
+	require.NoError(t, err)
+	require.Empty(t, statuses)
+
+	require.True(t, IsInvalidInput(store.Record(ctx, DefaultOrgID, &FindingStatus{}, FindingCheck{Status: FindingFixed})))
+}
+
+func TestLocalFindingStatusStore_Transition(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.FindingStatuses()
+
+	status := &FindingStatus{ID: "14d34128101e76ec", Target: "10.0.1.5", Port: 22, Plugin: "ssh-weak-cipher", ScanID: "scan-1"}
+	require.NoError(t, store.Transition(ctx, DefaultOrgID, status, FindingTransition{To: FindingStateInProgress, Assignee: "alice", Actor: "bob"}))
+	require.Equal(t, FindingStateInProgress, status.State)
+	require.Equal(t, "alice", status.Assignee)
+	require.Equal(t, FindingStateOpen, status.Transitions[0].From)
+	require.False(t, status.Transitions[0].At.IsZero())
+
+	// Rechecks keep the triage state
+	require.NoError(t, store.Record(ctx, DefaultOrgID, &FindingStatus{ID: status.ID}, FindingCheck{Status: FindingFixed, ScanID: "recheck-1"}))
+	require.NoError(t, store.Transition(ctx, DefaultOrgID, &FindingStatus{ID: status.ID}, FindingTransition{To: FindingStateFixed, Note: "Cipher disabled"}))
+	got, err := store.Get(ctx, DefaultOrgID, status.ID)
+	require.NoError(t, err)
+	require.Equal(t, FindingStateFixed, got.State)
+	require.Equal(t, FindingFixed, got.Status)
+	require.Empty(t, got.Assignee)
+	require.Len(t, got.Transitions, 2)
+	require.Equal(t, FindingStateInProgress, got.Transitions[1].From)
+	require.Equal(t, "Cipher disabled", got.Transitions[1].Note)
+
+	// Findings first seen by a recheck start open
+	require.NoError(t, store.Record(ctx, DefaultOrgID, &FindingStatus{ID: "0a1b2c3d4e5f6a7b"}, FindingCheck{Status: FindingStillPresent}))
+	got, err = store.Get(ctx, DefaultOrgID, "0a1b2c3d4e5f6a7b")
+	require.NoError(t, err)
+	require.Equal(t, FindingStateOpen, got.State)
+
+	require.True(t, IsInvalidInput(store.Transition(ctx, DefaultOrgID, status, FindingTransition{To: "closed"})))
+	require.True(t, IsInvalidInput(store.Transition(ctx, DefaultOrgID, &FindingStatus{}, FindingTransition{To: FindingStateFixed})))
+}
+
+func TestLocalFindingStatusStore_Track(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.FindingStatuses()
+
+	first := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	due := first.Add(7 * 24 * time.Hour)
+	seen := &FindingStatus{ID: "14d34128101e76ec", Target: "10.0.1.5", Port: 22, Plugin: "ssh-weak-cipher", ScanID: "scan-1", Severity: "critical", FirstSeen: &first, DueAt: &due}
+	require.NoError(t, store.Track(ctx, DefaultOrgID, []*FindingStatus{seen}))
+	require.Equal(t, FindingStateOpen, seen.State)
+
+	// Later sightings keep the first sighting and due date
+	later := first.Add(48 * time.Hour)
+	laterDue := later.Add(30 * 24 * time.Hour)
+	again := &FindingStatus{ID: seen.ID, ScanID: "scan-2", Severity: "high", FirstSeen: &later, DueAt: &laterDue}
+	require.NoError(t, store.Track(ctx, DefaultOrgID, []*FindingStatus{again}))
+	require.Equal(t, first, again.FirstSeen.UTC())
+	require.Equal(t, due, again.DueAt.UTC())
+	require.Equal(t, "high", again.Severity)
+	require.Equal(t, "scan-1", again.ScanID)
+
+	require.False(t, again.Overdue(due))
+	require.True(t, again.Overdue(due.Add(time.Second)))
+
+	// Closed findings are not overdue
+	require.NoError(t, store.Transition(ctx, DefaultOrgID, again, FindingTransition{To: FindingStateAcceptedRisk}))
+	require.False(t, again.Overdue(due.Add(time.Second)))
+	require.True(t, (&FindingStatus{Status: FindingFixed}).Closed())
+	require.False(t, (&FindingStatus{}).Overdue(due), "findings without a due date are never overdue")
+
+	reminded := due.Add(time.Hour)
+	require.NoError(t, store.MarkReminded(ctx, DefaultOrgID, []string{seen.ID, "0a1b2c3d4e5f6a7b"}, reminded))
+	got, err := store.Get(ctx, DefaultOrgID, seen.ID)
+	require.NoError(t, err)
+	require.Equal(t, reminded, got.RemindedAt.UTC())
+	_, err = store.Get(ctx, DefaultOrgID, "0a1b2c3d4e5f6a7b")
+	require.True(t, IsNotFound(err))
+
+	require.True(t, IsInvalidInput(store.Track(ctx, DefaultOrgID, []*FindingStatus{{}})))
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"time"
 )
@@ -70,8 +71,12 @@ func (b *LocalBackend) GarbageCollect(ctx context.Context, opts GCOptions) (*GCR
 	// Determine which orgs to process
 	orgs := []string{opts.OrgID}
 	if opts.OrgID == "" {
-		// Process all orgs (for now, just "default" since we don't have multi-tenancy yet)
-		orgs = []string{"default"}
+		// Process every org (tenant) that has scans
+		var err error
+		orgs, err = b.scanOrgs()
+		if err != nil {
+			return result, fmt.Errorf("list orgs: %w", err)
+		}
 	}
 
 	for _, orgID := range orgs {
@@ -167,3 +172,22 @@ func (b *LocalBackend) gcOrganization(ctx context.Context, orgID string, retenti
 
 	return nil
 }
+
+// scanOrgs lists the organizations that have a scan directory.
+func (b *LocalBackend) scanOrgs() ([]string, error) {
+	entries, err := os.ReadDir(b.scanStore.root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			orgs = append(orgs, e.Name())
+		}
+	}
+	return orgs, nil
+}
@@ -235,3 +235,20 @@ func createTestScan(t *testing.T, backend Backend, ctx context.Context, orgID, s
 	err := backend.Scans().Create(ctx, orgID, metadata)
 	require.NoError(t, err)
 }
+
+func TestLocalBackend_GarbageCollect_AllOrgs(t *testing.T) {
+	backend := setupTestBackend(t)
+	ctx := context.Background()
+
+	// Retention applies to each tenant independently
+	createTestScan(t, backend, ctx, "default", "scan-1", time.Now().Add(-20*24*time.Hour))
+	createTestScan(t, backend, ctx, "default", "scan-2", time.Now().Add(-10*24*time.Hour))
+	createTestScan(t, backend, ctx, "team-a", "scan-3", time.Now().Add(-20*24*time.Hour))
+	createTestScan(t, backend, ctx, "team-a", "scan-4", time.Now().Add(-10*24*time.Hour))
+
+	result, err := backend.GarbageCollect(ctx, GCOptions{
+		Retention: &RetentionConfig{MaxScans: 1},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"scan-1", "scan-3"}, result.DeletedScanIDs)
+}
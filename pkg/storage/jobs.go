@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// QueuedJob is a background job waiting for a worker of the server, such as
+// a scan submitted through the API. Jobs are persisted while queued so that
+// they run after a restart.
+type QueuedJob struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	OrgID    string `json:"org_id,omitempty"` // tenant of the job
+	Priority int    `json:"priority"`
+
+	SubmittedAt time.Time `json:"submitted_at"`
+
+	// Payload is the job type specific input, e.g. the scan parameters.
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// JobStore manages the job queue of the server. The queue is shared by all
+// organizations.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type JobStore interface {
+	// Save creates or replaces a queued job.
+	//
+	// Returns ErrInvalidInput for jobs without an ID.
+	Save(ctx context.Context, job *QueuedJob) error
+
+	// Delete removes a job from the queue. Deleting an unknown job is not
+	// an error.
+	Delete(ctx context.Context, jobID string) error
+
+	// List returns the queued jobs in submission order.
+	List(ctx context.Context) ([]*QueuedJob, error)
+}
+
+// JobBackend is implemented by backends that can persist the job queue.
+type JobBackend interface {
+	Jobs() JobStore
+}
+
+// Jobs returns the job queue storage interface.
+func (b *LocalBackend) Jobs() JobStore {
+	return b.jobStore
+}
+
+// LocalJobStore implements JobStore using one JSON file.
+//
+// Storage layout:
+//
+//	{workspace}/jobs/queue.json
+type LocalJobStore struct {
+	root string // Root directory for the job queue (workspace/jobs)
+}
+
+// Save creates or replaces a queued job.
+func (s *LocalJobStore) Save(ctx context.Context, job *QueuedJob) error {
+	if job == nil || job.ID == "" {
+		return NewInvalidInputError("ID", "job ID is required")
+	}
+	return s.file().update(func(jobs map[string]*QueuedJob) error {
+		jobs[job.ID] = job
+		return nil
+	})
+}
+
+// Delete removes a job from the queue.
+func (s *LocalJobStore) Delete(ctx context.Context, jobID string) error {
+	return s.file().update(func(jobs map[string]*QueuedJob) error {
+		delete(jobs, jobID)
+		return nil
+	})
+}
+
+// List returns the queued jobs in submission order.
+func (s *LocalJobStore) List(ctx context.Context) ([]*QueuedJob, error) {
+	jobs, err := s.file().load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*QueuedJob, 0, len(jobs))
+	for _, job := range jobs {
+		out = append(out, job)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].SubmittedAt.Equal(out[j].SubmittedAt) {
+			return out[i].SubmittedAt.Before(out[j].SubmittedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (s *LocalJobStore) file() *jsonMapFile[QueuedJob] {
+	return &jsonMapFile[QueuedJob]{path: filepath.Join(s.root, "queue.json"), kind: "job queue"}
+}
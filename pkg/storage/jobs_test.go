@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalJobStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	store := backend.Jobs()
+
+	jobs, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Save(ctx, &QueuedJob{ID: "scan-2", Type: "scan", OrgID: "team-a", SubmittedAt: at.Add(time.Minute), Payload: json.RawMessage(`{"targets":["10.0.0.2"]}`)}))
+	require.NoError(t, store.Save(ctx, &QueuedJob{ID: "scan-1", Type: "scan", Priority: 1, SubmittedAt: at}))
+	require.True(t, IsInvalidInput(store.Save(ctx, &QueuedJob{Type: "scan"})))
+
+	// The queue survives the backend, in submission order
+	reopened, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	jobs, err = reopened.Jobs().List(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, "scan-1", jobs[0].ID)
+	require.Equal(t, 1, jobs[0].Priority)
+	require.Equal(t, "team-a", jobs[1].OrgID)
+	require.JSONEq(t, `{"targets":["10.0.0.2"]}`, string(jobs[1].Payload))
+
+	require.NoError(t, store.Delete(ctx, "scan-1"))
+	require.NoError(t, store.Delete(ctx, "missing"))
+	jobs, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, "scan-2", jobs[0].ID)
+}
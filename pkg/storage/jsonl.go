@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"slices"
+)
+
+// maxJSONLLine is the longest line ReadJSONL decodes.
+const maxJSONLLine = 1024 * 1024
+
+// ReadJSONL decodes every line of a scan data file into T. A missing file
+// yields no records; empty and malformed lines are skipped, so that one
+// bad record does not hide the others.
+func ReadJSONL[T any](ctx context.Context, scans ScanStore, orgID, scanID string, dataType DataType) ([]T, error) {
+	rc, err := scans.ReadData(ctx, orgID, scanID, dataType)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+
+	var records []T
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLLine)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec T
+		if err := json.Unmarshal(line, &rec); err != nil {
+			continue
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReadHosts loads the hosts recorded for a scan. Scans without a hosts
+// file (e.g., from older versions) have no hosts; records without an IP
+// address are skipped.
+func ReadHosts(ctx context.Context, scans ScanStore, orgID, scanID string) ([]HostRecord, error) {
+	hosts, err := ReadJSONL[HostRecord](ctx, scans, orgID, scanID, DataTypeHosts)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(hosts, func(h HostRecord) bool { return h.IP == "" }), nil
+}
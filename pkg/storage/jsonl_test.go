@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONL(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	scans := backend.Scans()
+	require.NoError(t, scans.Create(ctx, DefaultOrgID, &ScanMetadata{ID: "scan-1", Target: "10.0.0.0/24", Status: "completed"}))
+
+	// A scan without the file has no records
+	hosts, err := ReadJSONL[HostRecord](ctx, scans, DefaultOrgID, "scan-1", DataTypeHosts)
+	require.NoError(t, err)
+	require.Empty(t, hosts)
+
+	require.NoError(t, scans.WriteData(ctx, DefaultOrgID, "scan-1", DataTypeHosts, strings.NewReader(
+		`{"ip":"10.0.0.5"}`+"\n\n"+`{"ip":`+"\n"+`{"ip":"10.0.0.6"}`+"\n")))
+	hosts, err = ReadJSONL[HostRecord](ctx, scans, DefaultOrgID, "scan-1", DataTypeHosts)
+	require.NoError(t, err)
+	require.Len(t, hosts, 2, "empty and malformed lines are skipped")
+	require.Equal(t, "10.0.0.6", hosts[1].IP)
+
+	// Host records without an IP address are skipped
+	require.NoError(t, scans.WriteData(ctx, DefaultOrgID, "scan-1", DataTypeHosts, strings.NewReader(
+		`{"ip":"10.0.0.5"}`+"\n"+`{"target":"10.0.0.0/24"}`+"\n")))
+	hosts, err = ReadHosts(ctx, scans, DefaultOrgID, "scan-1")
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+}
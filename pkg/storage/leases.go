@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is a named, time-limited claim held by one process, such as the
+// leadership of the server replicas sharing a workspace.
+type Lease struct {
+	Name   string `json:"name"`
+	Holder string `json:"holder"` // ID of the process holding the lease
+
+	AcquiredAt time.Time `json:"acquired_at"`
+	RenewedAt  time.Time `json:"renewed_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the lease has lapsed at now.
+func (l *Lease) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// LeaseStore manages leases. The holders of a lease compare expiry times
+// taken from their own clocks, which must roughly agree.
+//
+// Thread-safety: All methods must be safe for concurrent use, also by
+// processes sharing the storage.
+type LeaseStore interface {
+	// Acquire takes the lease for holder until ttl from now if it is free,
+	// expired or already held by holder, which renews it. It returns the
+	// lease as it stands afterwards; holder got it if Holder matches.
+	//
+	// Returns ErrInvalidInput for an empty name or holder or a ttl <= 0.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (*Lease, error)
+
+	// Release gives up the lease if holder holds it, so another holder
+	// can take it without waiting for it to expire.
+	Release(ctx context.Context, name, holder string) error
+
+	// Get returns the lease, expired or not.
+	//
+	// Returns ErrNotFound if the lease was never taken or was released.
+	Get(ctx context.Context, name string) (*Lease, error)
+}
+
+// LeaseBackend is implemented by backends that can hold leases.
+type LeaseBackend interface {
+	Leases() LeaseStore
+}
+
+// Leases returns the lease storage interface.
+func (b *LocalBackend) Leases() LeaseStore {
+	return b.leaseStore
+}
+
+// LocalLeaseStore implements LeaseStore using one JSON file. Processes on
+// several machines may share it through a network file system that supports
+// file locks.
+//
+// Storage layout:
+//
+//	{workspace}/leases.json
+type LocalLeaseStore struct {
+	path string
+	now  func() time.Time
+}
+
+// Acquire takes or renews the lease for holder.
+func (s *LocalLeaseStore) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (*Lease, error) {
+	if name == "" {
+		return nil, NewInvalidInputError("name", "lease name is required")
+	}
+	if holder == "" {
+		return nil, NewInvalidInputError("holder", "lease holder is required")
+	}
+	if ttl <= 0 {
+		return nil, NewInvalidInputError("ttl", "lease TTL must be positive")
+	}
+
+	var current Lease
+	err := s.file().update(func(leases map[string]*Lease) error {
+		now := s.now()
+		l, ok := leases[name]
+		switch {
+		case ok && l.Holder == holder && !l.Expired(now):
+			l.RenewedAt = now
+		case !ok || l.Expired(now):
+			l = &Lease{Name: name, Holder: holder, AcquiredAt: now, RenewedAt: now}
+			leases[name] = l
+		default:
+			// Held by another holder
+			current = *l
+			return nil
+		}
+		l.ExpiresAt = now.Add(ttl)
+		current = *l
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &current, nil
+}
+
+// Release gives up the lease if holder holds it.
+func (s *LocalLeaseStore) Release(ctx context.Context, name, holder string) error {
+	return s.file().update(func(leases map[string]*Lease) error {
+		if l, ok := leases[name]; ok && l.Holder == holder {
+			delete(leases, name)
+		}
+		return nil
+	})
+}
+
+// Get returns the lease.
+func (s *LocalLeaseStore) Get(ctx context.Context, name string) (*Lease, error) {
+	leases, err := s.file().load()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := leases[name]
+	if !ok {
+		return nil, NewNotFoundError("lease", name)
+	}
+	return l, nil
+}
+
+func (s *LocalLeaseStore) file() *jsonMapFile[Lease] {
+	return &jsonMapFile[Lease]{path: s.path, kind: "leases"}
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalLeaseStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	store := backend.leaseStore
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	_, err = store.Get(ctx, "leader")
+	require.True(t, IsNotFound(err))
+
+	l, err := store.Acquire(ctx, "leader", "node-a", 15*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "node-a", l.Holder)
+	require.Equal(t, now.Add(15*time.Second), l.ExpiresAt)
+
+	// Another holder waits for the lease to expire
+	l, err = store.Acquire(ctx, "leader", "node-b", 15*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "node-a", l.Holder)
+
+	// Renewing keeps the acquisition time
+	now = now.Add(10 * time.Second)
+	l, err = store.Acquire(ctx, "leader", "node-a", 15*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "node-a", l.Holder)
+	require.Equal(t, now.Add(-10*time.Second), l.AcquiredAt)
+	require.Equal(t, now.Add(15*time.Second), l.ExpiresAt)
+
+	now = now.Add(15 * time.Second)
+	l, err = store.Acquire(ctx, "leader", "node-b", 15*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, "node-b", l.Holder)
+	require.Equal(t, now, l.AcquiredAt)
+
+	// Only the holder releases the lease
+	require.NoError(t, store.Release(ctx, "leader", "node-a"))
+	l, err = backend.Leases().Get(ctx, "leader")
+	require.NoError(t, err)
+	require.Equal(t, "node-b", l.Holder)
+	require.NoError(t, store.Release(ctx, "leader", "node-b"))
+	_, err = store.Get(ctx, "leader")
+	require.True(t, IsNotFound(err))
+
+	_, err = store.Acquire(ctx, "", "node-a", time.Second)
+	require.True(t, IsInvalidInput(err))
+	_, err = store.Acquire(ctx, "leader", "node-a", 0)
+	require.True(t, IsInvalidInput(err))
+}
@@ -1,18 +1,23 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gofrs/flock"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
+	"github.com/vulntor/vulntor/pkg/tracing"
 )
 
 func init() {
@@ -35,13 +40,50 @@ func init() {
 //	        services.jsonl
 //	        vulnerabilities.jsonl
 //	        banners.txt
+//	  auth/
+//	    {org-id}/
+//	      apikeys.json
+//	      users.json
+//	  audit/
+//	    {org-id}/
+//	      events.jsonl
+//	  groups/
+//	    {org-id}/
+//	      target_groups.json
+//	  findings/
+//	    {org-id}/
+//	      finding_status.json
+//	  jobs/
+//	    queue.json
+//	  leases.json
+//	  quotas.json
+//	  tenants.json
+//
+// Each tenant (see Tenant) is an org-id; tenants never share files, except
+// the job queue, leases and quota counts of the server.
+//
+// With encryption configured (see EncryptionConfig), banners.txt,
+// evidence.jsonl, vulnerabilities.jsonl, artifacts and apikeys.json are
+// stored sealed with envelope encryption and decrypted transparently on
+// read.
 //
 // Thread-safety: All operations are protected by file locks for concurrent access.
 type LocalBackend struct {
-	cfg       *Config
-	scanStore *LocalScanStore
-	mu        sync.RWMutex
-	closed    bool
+	cfg              *Config
+	scanStore        *LocalScanStore
+	apiKeyStore      *LocalAPIKeyStore
+	userStore        *LocalUserStore
+	tenantStore      *LocalTenantStore
+	auditStore       *LocalAuditStore
+	targetGroupStore *LocalTargetGroupStore
+	findingStore     *LocalFindingStatusStore
+	surfaceStore     *LocalSurfaceStore
+	jobStore         *LocalJobStore
+	leaseStore       *LocalLeaseStore
+	quotaStore       *LocalQuotaStore
+	artifactStore    *LocalArtifactStore
+	mu               sync.RWMutex
+	closed           bool
 }
 
 // NewLocalBackend creates a new file-based backend.
@@ -50,6 +92,11 @@ func NewLocalBackend(ctx context.Context, cfg *Config) (*LocalBackend, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	keys, err := cfg.Encryption.Keyring()
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption config: %w", err)
+	}
+
 	backend := &LocalBackend{
 		cfg: cfg,
 	}
@@ -57,6 +104,65 @@ func NewLocalBackend(ctx context.Context, cfg *Config) (*LocalBackend, error) {
 	// Create scan store
 	backend.scanStore = &LocalScanStore{
 		root: filepath.Join(cfg.WorkspaceRoot, "scans"),
+		keys: keys,
+	}
+
+	// Create API key store
+	backend.apiKeyStore = &LocalAPIKeyStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "auth"),
+		keys: keys,
+	}
+
+	// Create user store
+	backend.userStore = &LocalUserStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "auth"),
+	}
+
+	// Create tenant registry
+	backend.tenantStore = &LocalTenantStore{
+		path: filepath.Join(cfg.WorkspaceRoot, "tenants.json"),
+	}
+
+	// Create audit log
+	backend.auditStore = &LocalAuditStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "audit"),
+	}
+
+	// Create target group store
+	backend.targetGroupStore = &LocalTargetGroupStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "groups"),
+	}
+
+	// Create finding status store
+	backend.findingStore = &LocalFindingStatusStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "findings"),
+	}
+
+	// Create attack surface store
+	backend.surfaceStore = &LocalSurfaceStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "surface"),
+	}
+
+	// Create job queue
+	backend.jobStore = &LocalJobStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "jobs"),
+	}
+
+	// Create lease store
+	backend.leaseStore = &LocalLeaseStore{
+		path: filepath.Join(cfg.WorkspaceRoot, "leases.json"),
+		now:  time.Now,
+	}
+
+	// Create quota counts
+	backend.quotaStore = &LocalQuotaStore{
+		path: filepath.Join(cfg.WorkspaceRoot, "quotas.json"),
+	}
+
+	// Create artifact store, in the scan directories
+	backend.artifactStore = &LocalArtifactStore{
+		root: filepath.Join(cfg.WorkspaceRoot, "scans"),
+		keys: keys,
 	}
 
 	return backend, nil
@@ -110,11 +216,15 @@ func (b *LocalBackend) Scans() ScanStore {
 
 // LocalScanStore implements ScanStore using file-based storage.
 type LocalScanStore struct {
-	root string // Root directory for scans (workspace/scans)
+	root string            // Root directory for scans (workspace/scans)
+	keys *envelope.Keyring // Seals sensitive data files; nil stores plaintext
 }
 
 // List returns a list of scans matching the given filter.
-func (s *LocalScanStore) List(ctx context.Context, orgID string, filter ScanFilter) ([]*ScanMetadata, error) {
+func (s *LocalScanStore) List(ctx context.Context, orgID string, filter ScanFilter) (_ []*ScanMetadata, err error) {
+	span := startScanSpan(ctx, "list", orgID, "")
+	defer func() { endScanSpan(span, err) }()
+
 	orgDir := filepath.Join(s.root, orgID)
 
 	// Check if org directory exists
@@ -148,6 +258,9 @@ func (s *LocalScanStore) List(ctx context.Context, orgID string, filter ScanFilt
 		if filter.Target != "" && !strings.Contains(metadata.Target, filter.Target) {
 			continue
 		}
+		if filter.Group != "" && !slices.Contains(metadata.Groups, filter.Group) {
+			continue
+		}
 
 		scans = append(scans, metadata)
 	}
@@ -170,7 +283,10 @@ func (s *LocalScanStore) List(ctx context.Context, orgID string, filter ScanFilt
 }
 
 // ListPaginated returns a paginated list of scans matching the given filter.
-func (s *LocalScanStore) ListPaginated(ctx context.Context, orgID string, filter ScanFilter, cursor string, limit int) ([]*ScanMetadata, string, int, error) {
+func (s *LocalScanStore) ListPaginated(ctx context.Context, orgID string, filter ScanFilter, cursor string, limit int) (_ []*ScanMetadata, _ string, _ int, err error) {
+	span := startScanSpan(ctx, "list_paginated", orgID, "")
+	defer func() { endScanSpan(span, err) }()
+
 	// Validate limit
 	limit = s.normalizeLimit(limit)
 
@@ -249,6 +365,9 @@ func (s *LocalScanStore) matchesFilter(metadata *ScanMetadata, filter ScanFilter
 	if filter.Target != "" && !strings.Contains(metadata.Target, filter.Target) {
 		return false
 	}
+	if filter.Group != "" && !slices.Contains(metadata.Groups, filter.Group) {
+		return false
+	}
 	return true
 }
 
@@ -301,7 +420,10 @@ func (s *LocalScanStore) findCursorPosition(scans []*ScanMetadata, cursorData *C
 }
 
 // Get retrieves metadata for a specific scan.
-func (s *LocalScanStore) Get(ctx context.Context, orgID, scanID string) (*ScanMetadata, error) {
+func (s *LocalScanStore) Get(ctx context.Context, orgID, scanID string) (_ *ScanMetadata, err error) {
+	span := startScanSpan(ctx, "get", orgID, scanID)
+	defer func() { endScanSpan(span, err) }()
+
 	metadataPath := s.metadataPath(orgID, scanID)
 
 	// Check if metadata file exists
@@ -330,7 +452,10 @@ func (s *LocalScanStore) Get(ctx context.Context, orgID, scanID string) (*ScanMe
 }
 
 // Create creates a new scan with the given metadata.
-func (s *LocalScanStore) Create(ctx context.Context, orgID string, scan *ScanMetadata) error {
+func (s *LocalScanStore) Create(ctx context.Context, orgID string, scan *ScanMetadata) (err error) {
+	span := startScanSpan(ctx, "create", orgID, scan.ID)
+	defer func() { endScanSpan(span, err) }()
+
 	if scan.ID == "" {
 		return NewInvalidInputError("scan ID is required", "ID")
 	}
@@ -362,6 +487,9 @@ func (s *LocalScanStore) Create(ctx context.Context, orgID string, scan *ScanMet
 	if scan.OrgID == "" {
 		scan.OrgID = orgID
 	}
+	if scan.SchemaVersion == 0 {
+		scan.SchemaVersion = ScanSchemaVersion
+	}
 
 	// Write metadata with file lock
 	lock := flock.New(metadataPath + ".lock")
@@ -383,7 +511,10 @@ func (s *LocalScanStore) Create(ctx context.Context, orgID string, scan *ScanMet
 }
 
 // Update updates metadata for an existing scan.
-func (s *LocalScanStore) Update(ctx context.Context, orgID, scanID string, updates ScanUpdates) error {
+func (s *LocalScanStore) Update(ctx context.Context, orgID, scanID string, updates ScanUpdates) (err error) {
+	span := startScanSpan(ctx, "update", orgID, scanID)
+	defer func() { endScanSpan(span, err) }()
+
 	metadataPath := s.metadataPath(orgID, scanID)
 
 	// Check if metadata file exists
@@ -431,6 +562,12 @@ func (s *LocalScanStore) Update(ctx context.Context, orgID, scanID string, updat
 	if updates.ErrorMessage != nil {
 		metadata.ErrorMessage = *updates.ErrorMessage
 	}
+	if updates.Resume != nil {
+		metadata.Resume = updates.Resume
+	}
+	if updates.SchemaVersion != nil {
+		metadata.SchemaVersion = *updates.SchemaVersion
+	}
 
 	// Update timestamp
 	metadata.UpdatedAt = time.Now()
@@ -449,7 +586,10 @@ func (s *LocalScanStore) Update(ctx context.Context, orgID, scanID string, updat
 }
 
 // Delete removes a scan and all its associated data.
-func (s *LocalScanStore) Delete(ctx context.Context, orgID, scanID string) error {
+func (s *LocalScanStore) Delete(ctx context.Context, orgID, scanID string) (err error) {
+	span := startScanSpan(ctx, "delete", orgID, scanID)
+	defer func() { endScanSpan(span, err) }()
+
 	scanDir := s.scanDir(orgID, scanID)
 
 	// Check if scan exists
@@ -470,7 +610,10 @@ func (s *LocalScanStore) Delete(ctx context.Context, orgID, scanID string) error
 }
 
 // ReadData opens a data file for reading.
-func (s *LocalScanStore) ReadData(ctx context.Context, orgID, scanID string, dataType DataType) (io.ReadCloser, error) {
+func (s *LocalScanStore) ReadData(ctx context.Context, orgID, scanID string, dataType DataType) (_ io.ReadCloser, err error) {
+	span := startScanSpan(ctx, "read_data", orgID, scanID, tracing.String("storage.data_type", string(dataType)))
+	defer func() { endScanSpan(span, err) }()
+
 	if !dataType.IsValid() {
 		return nil, NewInvalidInputError(fmt.Sprintf("invalid data type: %s", dataType), "dataType")
 	}
@@ -488,11 +631,19 @@ func (s *LocalScanStore) ReadData(ctx context.Context, orgID, scanID string, dat
 		return nil, fmt.Errorf("failed to open data file: %w", err)
 	}
 
+	// Sensitive files may hold sealed lines, even with encryption disabled
+	if dataType.IsSensitive() {
+		return sealedFile{Reader: s.keys.NewReader(file), Closer: file}, nil
+	}
+
 	return file, nil
 }
 
 // WriteData writes data to a file, replacing any existing content.
-func (s *LocalScanStore) WriteData(ctx context.Context, orgID, scanID string, dataType DataType, data io.Reader) error {
+func (s *LocalScanStore) WriteData(ctx context.Context, orgID, scanID string, dataType DataType, data io.Reader) (err error) {
+	span := startScanSpan(ctx, "write_data", orgID, scanID, tracing.String("storage.data_type", string(dataType)))
+	defer func() { endScanSpan(span, err) }()
+
 	if !dataType.IsValid() {
 		return NewInvalidInputError(fmt.Sprintf("invalid data type: %s", dataType), "dataType")
 	}
@@ -505,6 +656,19 @@ func (s *LocalScanStore) WriteData(ctx context.Context, orgID, scanID string, da
 		return fmt.Errorf("failed to create scan directory: %w", err)
 	}
 
+	// Seal sensitive data as a whole
+	if s.keys != nil && dataType.IsSensitive() {
+		plaintext, err := io.ReadAll(data)
+		if err != nil {
+			return fmt.Errorf("failed to write data: %w", err)
+		}
+		sealed, err := s.seal(plaintext)
+		if err != nil {
+			return err
+		}
+		data = bytes.NewReader(sealed)
+	}
+
 	// Create or truncate file
 	file, err := os.Create(dataPath)
 	if err != nil {
@@ -521,7 +685,12 @@ func (s *LocalScanStore) WriteData(ctx context.Context, orgID, scanID string, da
 }
 
 // AppendData appends data to an existing file.
-func (s *LocalScanStore) AppendData(ctx context.Context, orgID, scanID string, dataType DataType, data []byte) error {
+func (s *LocalScanStore) AppendData(ctx context.Context, orgID, scanID string, dataType DataType, data []byte) (err error) {
+	span := startScanSpan(ctx, "append_data", orgID, scanID,
+		tracing.String("storage.data_type", string(dataType)),
+		tracing.Int("storage.bytes", len(data)))
+	defer func() { endScanSpan(span, err) }()
+
 	if !dataType.IsValid() {
 		return NewInvalidInputError(fmt.Sprintf("invalid data type: %s", dataType), "dataType")
 	}
@@ -534,6 +703,13 @@ func (s *LocalScanStore) AppendData(ctx context.Context, orgID, scanID string, d
 		return fmt.Errorf("failed to create scan directory: %w", err)
 	}
 
+	// Seal each appended chunk of sensitive data as one line
+	if s.keys != nil && dataType.IsSensitive() {
+		if data, err = s.seal(data); err != nil {
+			return err
+		}
+	}
+
 	// Use file lock for concurrent append safety
 	lock := flock.New(dataPath + ".lock")
 	if err := lock.Lock(); err != nil {
@@ -563,6 +739,42 @@ func (s *LocalScanStore) GetAnalytics(ctx context.Context, orgID string, period
 
 // Helper methods
 
+// startScanSpan starts a trace span for a scan store operation.
+func startScanSpan(ctx context.Context, op, orgID, scanID string, attrs ...tracing.Attr) *tracing.Span {
+	attrs = append([]tracing.Attr{
+		tracing.String("storage.backend", "local"),
+		tracing.String("org.id", orgID),
+	}, attrs...)
+	if scanID != "" {
+		attrs = append(attrs, tracing.String("scan.id", scanID))
+	}
+	_, span := tracing.Start(ctx, "storage.scans."+op, attrs...)
+	return span
+}
+
+// endScanSpan ends span, recording err unless it is a not-found lookup.
+func endScanSpan(span *tracing.Span, err error) {
+	if !IsNotFound(err) {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// seal encrypts data as one sealed line.
+func (s *LocalScanStore) seal(data []byte) ([]byte, error) {
+	sealed, err := s.keys.Seal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	return append(sealed, '\n'), nil
+}
+
+// sealedFile is a data file read through the decryption of its sealed lines.
+type sealedFile struct {
+	io.Reader
+	io.Closer
+}
+
 func (s *LocalScanStore) scanDir(orgID, scanID string) string {
 	return filepath.Join(s.root, orgID, scanID)
 }
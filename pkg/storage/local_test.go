@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/envelope"
 )
 
 func TestNewLocalBackend(t *testing.T) {
@@ -502,6 +507,117 @@ func TestLocalScanStore_GetAnalytics(t *testing.T) {
 	require.ErrorIs(t, err, ErrNotSupported)
 }
 
+func TestLocalScanStore_Encryption(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+
+	// Evidence written before encryption was enabled stays readable
+	plain, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	require.NoError(t, plain.Scans().Create(ctx, "default", &ScanMetadata{ID: "scan-1", Target: "192.0.2.1"}))
+	require.NoError(t, plain.Scans().AppendData(ctx, "default", "scan-1", DataTypeBanners, []byte("220 old ftp\n")))
+
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root, Encryption: EncryptionConfig{Key: key}})
+	require.NoError(t, err)
+	scans := backend.Scans()
+	require.NoError(t, scans.AppendData(ctx, "default", "scan-1", DataTypeBanners, []byte("SSH-2.0-OpenSSH_8.9\n")))
+	require.NoError(t, scans.WriteData(ctx, "default", "scan-1", DataTypeEvidence, strings.NewReader(`{"key":"creds","value":"admin:admin"}`+"\n")))
+	require.NoError(t, scans.WriteData(ctx, "default", "scan-1", DataTypeHosts, strings.NewReader(`{"ip":"192.0.2.1"}`+"\n")))
+
+	read := func(store ScanStore, dataType DataType) (string, error) {
+		rc, err := store.ReadData(ctx, "default", "scan-1", dataType)
+		require.NoError(t, err)
+		defer func() { _ = rc.Close() }()
+		content, err := io.ReadAll(rc)
+		return string(content), err
+	}
+
+	// Service layer reads plaintext
+	banners, err := read(scans, DataTypeBanners)
+	require.NoError(t, err)
+	require.Equal(t, "220 old ftp\nSSH-2.0-OpenSSH_8.9\n", banners)
+	evidence, err := read(scans, DataTypeEvidence)
+	require.NoError(t, err)
+	require.Equal(t, `{"key":"creds","value":"admin:admin"}`+"\n", evidence)
+
+	// Sensitive files are sealed on disk, others are not
+	raw, err := os.ReadFile(filepath.Join(root, "scans", "default", "scan-1", "evidence.jsonl"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(raw), envelope.Prefix))
+	require.NotContains(t, string(raw), "admin:admin")
+	raw, err = os.ReadFile(filepath.Join(root, "scans", "default", "scan-1", "banners.txt"))
+	require.NoError(t, err)
+	require.NotContains(t, string(raw), "OpenSSH")
+	raw, err = os.ReadFile(filepath.Join(root, "scans", "default", "scan-1", "hosts.jsonl"))
+	require.NoError(t, err)
+	require.Contains(t, string(raw), "192.0.2.1")
+
+	// Without the key sealed data cannot be read
+	_, err = read(plain.Scans(), DataTypeEvidence)
+	require.ErrorIs(t, err, envelope.ErrNoKey)
+
+	// A previous key still opens data after a rotation
+	newKey := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("n", 32)))
+	rotated, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root, Encryption: EncryptionConfig{Key: newKey, PreviousKeys: []string{key}}})
+	require.NoError(t, err)
+	evidence, err = read(rotated.Scans(), DataTypeEvidence)
+	require.NoError(t, err)
+	require.Contains(t, evidence, "admin:admin")
+
+	// Invalid keys are rejected
+	_, err = NewLocalBackend(ctx, &Config{WorkspaceRoot: root, Encryption: EncryptionConfig{Key: "short"}})
+	require.ErrorContains(t, err, "invalid encryption config")
+}
+
+func TestLocalBackend_EncryptionSealsSecrets(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	key := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root, Encryption: EncryptionConfig{Key: key}})
+	require.NoError(t, err)
+
+	// A default credential finding, its evidence and the captured login
+	const secret = "hunter2-s3cret"
+	finding := `{"target":"192.0.2.1","port":21,"plugin":"default-creds","username":"admin","password":"` + secret + `"}` + "\n"
+	pcap := []byte("\xd4\xc3\xb2\xa1USER admin\r\nPASS " + secret + "\r\n")
+	scans := backend.Scans()
+	require.NoError(t, scans.Create(ctx, "default", &ScanMetadata{ID: "scan-1", Target: "192.0.2.1"}))
+	require.NoError(t, scans.WriteData(ctx, "default", "scan-1", DataTypeVulnerabilities, strings.NewReader(finding)))
+	require.NoError(t, scans.AppendData(ctx, "default", "scan-1", DataTypeEvidence, []byte(`{"key":"creds","value":"admin:`+secret+`"}`+"\n")))
+	require.NoError(t, scans.AppendData(ctx, "default", "scan-1", DataTypeBanners, []byte("230 Login successful "+secret+"\n")))
+	require.NoError(t, backend.Artifacts().Write(ctx, "default", "scan-1", "192.0.2.1.pcap", bytes.NewReader(pcap)))
+
+	// No file of the workspace holds the secret in plaintext
+	var files int
+	require.NoError(t, filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContains(t, string(raw), secret, path)
+		files++
+		return nil
+	}))
+	require.GreaterOrEqual(t, files, 5)
+
+	// Reads decrypt transparently
+	rc, err := scans.ReadData(ctx, "default", "scan-1", DataTypeVulnerabilities)
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, finding, string(content))
+
+	rc, err = backend.Artifacts().Open(ctx, "default", "scan-1", "192.0.2.1.pcap")
+	require.NoError(t, err)
+	content, err = io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, pcap, content)
+}
+
 // Helper function to set up a test backend
 func setupTestBackend(t *testing.T) *LocalBackend {
 	t.Helper()
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ScanSchemaVersion is the version of the format scans are stored in: their
+// metadata and data files (hosts, services, findings, evidence). Every
+// format change that leaves stored scans unreadable increments it and adds
+// the migration from the previous version to scanMigrations.
+//
+// Scans stored before schema versioning have version 0.
+const ScanSchemaVersion = 1
+
+// ScanMigration upgrades stored scans from one schema version to the next.
+type ScanMigration struct {
+	// From is the schema version the migration upgrades scans from, to
+	// From+1.
+	From int
+
+	// Description says what the migration changes.
+	Description string
+
+	// Migrate rewrites scan in the format of version From+1 through
+	// scans. The runner records the new version once it succeeds.
+	Migrate func(ctx context.Context, scans ScanStore, orgID string, scan *ScanMetadata) error
+}
+
+// scanMigrations holds a migration for every schema version before
+// ScanSchemaVersion, in order.
+var scanMigrations = []ScanMigration{
+	{
+		From:        0,
+		Description: "record the schema version of scans stored before versioning",
+		Migrate: func(context.Context, ScanStore, string, *ScanMetadata) error {
+			// Version 1 is the format of unversioned scans
+			return nil
+		},
+	},
+}
+
+// MigrateOptions defines options for migrating stored scans.
+type MigrateOptions struct {
+	// DryRun reports the scans that need migrating without changing them.
+	DryRun bool
+
+	// OrgID specifies which organization to migrate.
+	// If empty, migrates all organizations.
+	OrgID string
+}
+
+// MigrateResult contains the results of a migration.
+type MigrateResult struct {
+	// ScansMigrated is the number of scans migrated.
+	ScansMigrated int
+
+	// MigratedScanIDs is the list of scan IDs that were migrated.
+	MigratedScanIDs []string
+
+	// Errors contains the errors migrating individual scans, including
+	// scans stored by a newer version of Vulntor, which are left as is.
+	// Migration continues with the other scans.
+	Errors []error
+}
+
+// MigrationBackend is implemented by backends that can migrate stored scans
+// to the current schema version.
+type MigrationBackend interface {
+	MigrateScans(ctx context.Context, opts MigrateOptions) (*MigrateResult, error)
+}
+
+// MigrateScans upgrades the scans stored in an older schema version to
+// ScanSchemaVersion, one version at a time. The version is recorded after
+// each step, so that an interrupted migration resumes where it stopped.
+func (b *LocalBackend) MigrateScans(ctx context.Context, opts MigrateOptions) (*MigrateResult, error) {
+	result := &MigrateResult{
+		MigratedScanIDs: make([]string, 0),
+		Errors:          make([]error, 0),
+	}
+
+	orgs := []string{opts.OrgID}
+	if opts.OrgID == "" {
+		var err error
+		orgs, err = b.scanOrgs()
+		if err != nil {
+			return result, fmt.Errorf("list orgs: %w", err)
+		}
+	}
+
+	for _, orgID := range orgs {
+		scans, err := b.Scans().List(ctx, orgID, ScanFilter{})
+		if err != nil {
+			return result, fmt.Errorf("migrate org %s: list scans: %w", orgID, err)
+		}
+		sort.Slice(scans, func(i, j int) bool {
+			return scans[i].ID < scans[j].ID
+		})
+
+		for _, scan := range scans {
+			switch {
+			case scan.SchemaVersion == ScanSchemaVersion:
+				continue
+			case scan.SchemaVersion > ScanSchemaVersion:
+				result.Errors = append(result.Errors, fmt.Errorf("scan %s: schema version %d is newer than supported version %d", scan.ID, scan.SchemaVersion, ScanSchemaVersion))
+				continue
+			}
+			if !opts.DryRun {
+				if err := migrateScan(ctx, b.Scans(), orgID, scan); err != nil {
+					result.Errors = append(result.Errors, err)
+					continue
+				}
+			}
+			result.MigratedScanIDs = append(result.MigratedScanIDs, scan.ID)
+			result.ScansMigrated++
+		}
+	}
+
+	return result, nil
+}
+
+// migrateScan applies the migrations from the schema version of scan to
+// ScanSchemaVersion.
+func migrateScan(ctx context.Context, scans ScanStore, orgID string, scan *ScanMetadata) error {
+	for version := scan.SchemaVersion; version < ScanSchemaVersion; version++ {
+		m := scanMigrations[version]
+		if err := m.Migrate(ctx, scans, orgID, scan); err != nil {
+			return fmt.Errorf("migrate scan %s from schema version %d: %w", scan.ID, version, err)
+		}
+		next := version + 1
+		if err := scans.Update(ctx, orgID, scan.ID, ScanUpdates{SchemaVersion: &next}); err != nil {
+			return fmt.Errorf("record schema version %d of scan %s: %w", next, scan.ID, err)
+		}
+		scan.SchemaVersion = next
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanMigrations_CoverEveryVersion(t *testing.T) {
+	require.Len(t, scanMigrations, ScanSchemaVersion)
+	for i, m := range scanMigrations {
+		require.Equal(t, i, m.From)
+		require.NotEmpty(t, m.Description)
+		require.NotNil(t, m.Migrate)
+	}
+}
+
+func TestLocalScanStore_Create_SchemaVersion(t *testing.T) {
+	backend := setupTestBackend(t)
+	ctx := context.Background()
+
+	createTestScan(t, backend, ctx, "default", "scan-1", time.Now())
+
+	scan, err := backend.Scans().Get(ctx, "default", "scan-1")
+	require.NoError(t, err)
+	require.Equal(t, ScanSchemaVersion, scan.SchemaVersion)
+}
+
+func TestLocalBackend_MigrateScans(t *testing.T) {
+	backend := setupTestBackend(t)
+	ctx := context.Background()
+
+	createTestScan(t, backend, ctx, "default", "current", time.Now())
+	createTestScan(t, backend, ctx, "default", "legacy", time.Now())
+	createTestScan(t, backend, ctx, "team-a", "legacy-team", time.Now())
+	createTestScan(t, backend, ctx, "default", "newer", time.Now())
+	setSchemaVersion(t, backend, ctx, "default", "legacy", 0)
+	setSchemaVersion(t, backend, ctx, "team-a", "legacy-team", 0)
+	setSchemaVersion(t, backend, ctx, "default", "newer", ScanSchemaVersion+1)
+
+	// A dry run reports the scans to migrate without changing them
+	result, err := backend.MigrateScans(ctx, MigrateOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.ScansMigrated)
+	require.ElementsMatch(t, []string{"legacy", "legacy-team"}, result.MigratedScanIDs)
+	require.Len(t, result.Errors, 1)
+	require.Contains(t, result.Errors[0].Error(), "scan newer: schema version 2 is newer")
+	scan, err := backend.Scans().Get(ctx, "default", "legacy")
+	require.NoError(t, err)
+	require.Equal(t, 0, scan.SchemaVersion)
+
+	// Migrating one org leaves the others
+	result, err = backend.MigrateScans(ctx, MigrateOptions{OrgID: "team-a"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"legacy-team"}, result.MigratedScanIDs)
+	require.Empty(t, result.Errors)
+
+	result, err = backend.MigrateScans(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"legacy"}, result.MigratedScanIDs)
+	scan, err = backend.Scans().Get(ctx, "default", "legacy")
+	require.NoError(t, err)
+	require.Equal(t, ScanSchemaVersion, scan.SchemaVersion)
+
+	// Scans stored by a newer version are left as is
+	scan, err = backend.Scans().Get(ctx, "default", "newer")
+	require.NoError(t, err)
+	require.Equal(t, ScanSchemaVersion+1, scan.SchemaVersion)
+
+	// Migrated scans are not migrated again
+	result, err = backend.MigrateScans(ctx, MigrateOptions{})
+	require.NoError(t, err)
+	require.Zero(t, result.ScansMigrated)
+}
+
+func setSchemaVersion(t *testing.T, backend Backend, ctx context.Context, orgID, scanID string, version int) {
+	t.Helper()
+
+	err := backend.Scans().Update(ctx, orgID, scanID, ScanUpdates{SchemaVersion: &version})
+	require.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+)
+
+// QuotaCount is the number of uses of a daily quota on one day.
+type QuotaCount struct {
+	Key   string `json:"key"`
+	Day   string `json:"day"` // YYYY-MM-DD
+	Count int    `json:"count"`
+}
+
+// QuotaStore counts the uses of daily quotas, such as the scan submissions
+// of each API client, so the server replicas sharing the storage enforce
+// one count that survives restarts.
+//
+// Thread-safety: All methods must be safe for concurrent use, also by
+// processes sharing the storage.
+type QuotaStore interface {
+	// Reserve counts a use of key on day if fewer than limit were counted.
+	// It returns the uses left on day and whether the use was counted.
+	// Counts of other days are dropped.
+	//
+	// Returns ErrInvalidInput for an empty key or day or a limit <= 0.
+	Reserve(ctx context.Context, key, day string, limit int) (remaining int, ok bool, err error)
+
+	// Release gives back a use of key on day, e.g. of a rejected request.
+	Release(ctx context.Context, key, day string) error
+}
+
+// QuotaBackend is implemented by backends that can count quotas.
+type QuotaBackend interface {
+	Quotas() QuotaStore
+}
+
+// Quotas returns the quota storage interface.
+func (b *LocalBackend) Quotas() QuotaStore {
+	return b.quotaStore
+}
+
+// LocalQuotaStore implements QuotaStore using one JSON file. Processes on
+// several machines may share it through a network file system that supports
+// file locks.
+//
+// Storage layout:
+//
+//	{workspace}/quotas.json
+type LocalQuotaStore struct {
+	path string
+}
+
+// Reserve counts a use of key on day.
+func (s *LocalQuotaStore) Reserve(ctx context.Context, key, day string, limit int) (int, bool, error) {
+	if key == "" {
+		return 0, false, NewInvalidInputError("key", "quota key is required")
+	}
+	if day == "" {
+		return 0, false, NewInvalidInputError("day", "quota day is required")
+	}
+	if limit <= 0 {
+		return 0, false, NewInvalidInputError("limit", "quota limit must be positive")
+	}
+
+	var remaining int
+	var ok bool
+	err := s.file().update(func(counts map[string]*QuotaCount) error {
+		for k, c := range counts {
+			if c.Day != day {
+				delete(counts, k)
+			}
+		}
+		c, found := counts[key]
+		if !found {
+			c = &QuotaCount{Key: key, Day: day}
+			counts[key] = c
+		}
+		if c.Count >= limit {
+			return nil
+		}
+		c.Count++
+		remaining, ok = limit-c.Count, true
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return remaining, ok, nil
+}
+
+// Release gives back a use of key on day.
+func (s *LocalQuotaStore) Release(ctx context.Context, key, day string) error {
+	return s.file().update(func(counts map[string]*QuotaCount) error {
+		if c, ok := counts[key]; ok && c.Day == day && c.Count > 0 {
+			c.Count--
+		}
+		return nil
+	})
+}
+
+func (s *LocalQuotaStore) file() *jsonMapFile[QuotaCount] {
+	return &jsonMapFile[QuotaCount]{path: s.path, kind: "quotas"}
+}
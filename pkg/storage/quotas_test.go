@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalQuotaStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	store := backend.Quotas()
+
+	remaining, ok, err := store.Reserve(ctx, "default/key:k1", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, remaining)
+	remaining, ok, err = store.Reserve(ctx, "default/key:k1", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0, remaining)
+	_, ok, err = store.Reserve(ctx, "default/key:k1", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Other processes sharing the workspace see the same counts
+	other, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: root})
+	require.NoError(t, err)
+	_, ok, err = other.Quotas().Reserve(ctx, "default/key:k1", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Releasing gives a use back; keys count separately
+	require.NoError(t, store.Release(ctx, "default/key:k1", "2026-03-01"))
+	_, ok, err = other.Quotas().Reserve(ctx, "default/key:k1", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	_, ok, err = store.Reserve(ctx, "default/key:k2", "2026-03-01", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A new day starts over
+	remaining, ok, err = store.Reserve(ctx, "default/key:k1", "2026-03-02", 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, remaining)
+
+	_, _, err = store.Reserve(ctx, "", "2026-03-02", 2)
+	require.True(t, IsInvalidInput(err))
+	_, _, err = store.Reserve(ctx, "default/key:k1", "2026-03-02", 0)
+	require.True(t, IsInvalidInput(err))
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Kinds of assets whose attack surface is scored.
+const (
+	SurfaceHost  = "host"
+	SurfaceGroup = "group"
+)
+
+// maxSurfaceHistory is how many scores are kept per asset; older ones are
+// dropped first.
+const maxSurfaceHistory = 100
+
+// SurfacePoint is the attack surface of an asset as observed by a scan
+// (see package surface).
+type SurfacePoint struct {
+	ScanID string    `json:"scan_id"`
+	At     time.Time `json:"at"`
+	Score  int       `json:"score"`
+
+	Hosts          int `json:"hosts"`           // live hosts; 1 for hosts
+	InternetFacing int `json:"internet_facing"` // internet-facing hosts
+	OpenPorts      int `json:"open_ports"`
+	CVEs           int `json:"cves"` // unpatched CVEs, counted once per host
+}
+
+// AssetSurface is the attack surface score history of a host (by IP) or
+// target group (by name).
+type AssetSurface struct {
+	Asset string `json:"asset"`
+	Kind  string `json:"kind"` // SurfaceHost or SurfaceGroup
+
+	// History lists the scores of the asset, oldest first.
+	History []SurfacePoint `json:"history"`
+}
+
+// Latest returns the most recent score of the asset, or nil if it has
+// none.
+func (a *AssetSurface) Latest() *SurfacePoint {
+	if len(a.History) == 0 {
+		return nil
+	}
+	return &a.History[len(a.History)-1]
+}
+
+// Change returns how much the latest score of the asset changed since the
+// one before; 0 without an earlier score.
+func (a *AssetSurface) Change() int {
+	if len(a.History) < 2 {
+		return 0
+	}
+	return a.History[len(a.History)-1].Score - a.History[len(a.History)-2].Score
+}
+
+// SurfaceStore manages the attack surface scores of the assets of an
+// organization.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type SurfaceStore interface {
+	// Get retrieves the score history of an asset.
+	//
+	// Returns ErrNotFound if the asset was never scored.
+	Get(ctx context.Context, orgID, kind, asset string) (*AssetSurface, error)
+
+	// List returns the score histories of all scored assets ordered by
+	// kind, then asset.
+	List(ctx context.Context, orgID string) ([]*AssetSurface, error)
+
+	// Record appends the scores in the history of each of scored to the
+	// stored history of its asset, keeping the most recent
+	// maxSurfaceHistory scores.
+	//
+	// Returns ErrInvalidInput for assets without a name or with an
+	// unknown kind.
+	Record(ctx context.Context, orgID string, scored []*AssetSurface) error
+}
+
+// SurfaceBackend is implemented by backends that can persist attack
+// surface scores.
+type SurfaceBackend interface {
+	Surfaces() SurfaceStore
+}
+
+// Surfaces returns the attack surface storage interface.
+func (b *LocalBackend) Surfaces() SurfaceStore {
+	return b.surfaceStore
+}
+
+// LocalSurfaceStore implements SurfaceStore using one JSON file per
+// organization.
+//
+// Storage layout:
+//
+//	{workspace}/surface/{org-id}/surface.json
+type LocalSurfaceStore struct {
+	root string // Root directory for attack surface scores (workspace/surface)
+}
+
+// Get retrieves the score history of an asset.
+func (s *LocalSurfaceStore) Get(ctx context.Context, orgID, kind, asset string) (*AssetSurface, error) {
+	surfaces, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	surface, ok := surfaces[surfaceKey(kind, asset)]
+	if !ok {
+		return nil, NewNotFoundError(kind+" surface", asset)
+	}
+	return surface, nil
+}
+
+// List returns the score histories of all scored assets ordered by kind,
+// then asset.
+func (s *LocalSurfaceStore) List(ctx context.Context, orgID string) ([]*AssetSurface, error) {
+	surfaces, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*AssetSurface, 0, len(surfaces))
+	for _, surface := range surfaces {
+		out = append(out, surface)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].Asset < out[j].Asset
+	})
+	return out, nil
+}
+
+// Record appends the scores of scored to the histories of their assets.
+func (s *LocalSurfaceStore) Record(ctx context.Context, orgID string, scored []*AssetSurface) error {
+	for _, a := range scored {
+		if a == nil || a.Asset == "" {
+			return NewInvalidInputError("Asset", "asset is required")
+		}
+		if a.Kind != SurfaceHost && a.Kind != SurfaceGroup {
+			return NewInvalidInputError("Kind", "unknown asset kind "+a.Kind)
+		}
+	}
+	if len(scored) == 0 {
+		return nil
+	}
+
+	return s.file(orgID).update(func(surfaces map[string]*AssetSurface) error {
+		for _, a := range scored {
+			key := surfaceKey(a.Kind, a.Asset)
+			current, ok := surfaces[key]
+			if !ok {
+				current = &AssetSurface{Asset: a.Asset, Kind: a.Kind}
+				surfaces[key] = current
+			}
+			current.History = append(current.History, a.History...)
+			if n := len(current.History); n > maxSurfaceHistory {
+				current.History = current.History[n-maxSurfaceHistory:]
+			}
+		}
+		return nil
+	})
+}
+
+// surfaceKey identifies an asset in the surface file; hosts and groups may
+// share names.
+func surfaceKey(kind, asset string) string {
+	return kind + ":" + asset
+}
+
+func (s *LocalSurfaceStore) file(orgID string) *jsonMapFile[AssetSurface] {
+	return &jsonMapFile[AssetSurface]{path: filepath.Join(s.root, orgID, "surface.json"), kind: "attack surface scores"}
+}
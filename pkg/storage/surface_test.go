@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalSurfaceStore(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.Surfaces()
+
+	_, err = store.Get(ctx, DefaultOrgID, SurfaceHost, "10.0.0.5")
+	require.True(t, IsNotFound(err))
+
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, store.Record(ctx, DefaultOrgID, []*AssetSurface{
+		{Asset: "10.0.0.5", Kind: SurfaceHost, History: []SurfacePoint{{ScanID: "scan-1", At: at, Score: 35, Hosts: 1, OpenPorts: 3, CVEs: 1}}},
+		{Asset: "office", Kind: SurfaceGroup, History: []SurfacePoint{{ScanID: "scan-1", At: at, Score: 35, Hosts: 1, OpenPorts: 3, CVEs: 1}}},
+	}))
+	require.NoError(t, store.Record(ctx, DefaultOrgID, []*AssetSurface{
+		{Asset: "10.0.0.5", Kind: SurfaceHost, History: []SurfacePoint{{ScanID: "scan-2", At: at.Add(time.Hour), Score: 14, Hosts: 1, OpenPorts: 2}}},
+	}))
+
+	host, err := store.Get(ctx, DefaultOrgID, SurfaceHost, "10.0.0.5")
+	require.NoError(t, err)
+	require.Len(t, host.History, 2)
+	require.Equal(t, "scan-2", host.Latest().ScanID)
+	require.Equal(t, -21, host.Change())
+
+	// Groups and hosts are listed apart, groups first
+	surfaces, err := store.List(ctx, DefaultOrgID)
+	require.NoError(t, err)
+	require.Len(t, surfaces, 2)
+	require.Equal(t, SurfaceGroup, surfaces[0].Kind)
+	require.Zero(t, surfaces[0].Change())
+
+	// Scores are kept per organization
+	surfaces, err = store.List(ctx, "team-a")
+	require.NoError(t, err)
+	require.Empty(t, surfaces)
+
+	require.True(t, IsInvalidInput(store.Record(ctx, DefaultOrgID, []*AssetSurface{{Kind: SurfaceHost}})))
+	require.True(t, IsInvalidInput(store.Record(ctx, DefaultOrgID, []*AssetSurface{{Asset: "web", Kind: "site"}})))
+}
+
+func TestLocalSurfaceStore_History(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	store := backend.Surfaces()
+
+	// Only the most recent scores are kept
+	for i := 0; i < maxSurfaceHistory+5; i++ {
+		require.NoError(t, store.Record(ctx, DefaultOrgID, []*AssetSurface{
+			{Asset: "10.0.0.5", Kind: SurfaceHost, History: []SurfacePoint{{Score: i}}},
+		}))
+	}
+	host, err := store.Get(ctx, DefaultOrgID, SurfaceHost, "10.0.0.5")
+	require.NoError(t, err)
+	require.Len(t, host.History, maxSurfaceHistory)
+	require.Equal(t, 5, host.History[0].Score)
+}
@@ -0,0 +1,254 @@
+package storage
+
+import (
+	"context"
+	"net/netip"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/blackout"
+)
+
+// targetGroupNamePattern restricts group names to safe, shell-friendly words.
+var targetGroupNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// TargetGroup is a named set of scan targets (e.g., "prod-web" or
+// "branch-office-berlin") with tags.
+//
+// Scans can reference groups instead of listing targets; findings on the
+// targets of a group inherit its tags, so they can be filtered and reported
+// by group. Probes to the targets of a group pause during its blackout
+// windows.
+type TargetGroup struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Targets     []string `json:"targets"` // hosts, IP addresses and CIDR ranges
+	Tags        []string `json:"tags,omitempty"`
+	Blackouts   []string `json:"blackouts,omitempty"` // blackout window specs, see blackout.ParseWindow
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate checks the group's name, targets and blackout windows.
+func (g *TargetGroup) Validate() error {
+	if err := ValidateTargetGroupName(g.Name); err != nil {
+		return err
+	}
+	if len(g.Targets) == 0 {
+		return NewInvalidInputError("Targets", "target group needs at least one target")
+	}
+	for _, target := range g.Targets {
+		if strings.TrimSpace(target) == "" {
+			return NewInvalidInputError("Targets", "targets cannot be empty")
+		}
+		if strings.Contains(target, "/") {
+			if _, err := netip.ParsePrefix(target); err != nil {
+				return NewInvalidInputError("Targets", "invalid CIDR range "+target)
+			}
+		}
+	}
+	for _, tag := range g.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return NewInvalidInputError("Tags", "tags cannot be empty")
+		}
+	}
+	if _, err := blackout.ParseWindows(g.Blackouts); err != nil {
+		return NewInvalidInputError("Blackouts", err.Error())
+	}
+	return nil
+}
+
+// Contains reports whether host (a hostname or IP address) is one of the
+// group's targets or falls in one of its CIDR ranges.
+func (g *TargetGroup) Contains(host string) bool {
+	if host == "" {
+		return false
+	}
+	addr, addrErr := netip.ParseAddr(host)
+	for _, target := range g.Targets {
+		if strings.EqualFold(target, host) {
+			return true
+		}
+		if addrErr != nil {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(target); err == nil && prefix.Contains(addr.Unmap()) {
+			return true
+		}
+		if ip, err := netip.ParseAddr(target); err == nil && ip.Unmap() == addr.Unmap() {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTargetGroupName checks that name can be used as a target group name.
+func ValidateTargetGroupName(name string) error {
+	if !targetGroupNamePattern.MatchString(name) {
+		return NewInvalidInputError("Name", "target group name must be 1-63 lowercase letters, digits or dashes")
+	}
+	return nil
+}
+
+// GroupTags returns the tags of the groups, without duplicates, in order.
+func GroupTags(groups []*TargetGroup) []string {
+	var tags []string
+	for _, g := range groups {
+		for _, tag := range g.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// TargetGroupStore manages the target groups of an organization.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type TargetGroupStore interface {
+	// Create stores a new target group.
+	//
+	// Returns ErrAlreadyExists if a group with the same name exists and
+	// ErrInvalidInput for invalid groups.
+	Create(ctx context.Context, orgID string, group *TargetGroup) error
+
+	// Get retrieves a target group by name.
+	//
+	// Returns ErrNotFound if the group does not exist.
+	Get(ctx context.Context, orgID, name string) (*TargetGroup, error)
+
+	// List returns all target groups ordered by name.
+	List(ctx context.Context, orgID string) ([]*TargetGroup, error)
+
+	// Update replaces the description, targets, tags and blackout windows
+	// of a group.
+	//
+	// Returns ErrNotFound if the group does not exist.
+	Update(ctx context.Context, orgID string, group *TargetGroup) error
+
+	// Delete removes a target group. Scans and findings keep the group
+	// name and tags they were recorded with.
+	//
+	// Returns ErrNotFound if the group does not exist.
+	Delete(ctx context.Context, orgID, name string) error
+}
+
+// TargetGroupBackend is implemented by backends that can persist target groups.
+type TargetGroupBackend interface {
+	TargetGroups() TargetGroupStore
+}
+
+// TargetGroups returns the target group storage interface.
+func (b *LocalBackend) TargetGroups() TargetGroupStore {
+	return b.targetGroupStore
+}
+
+// LocalTargetGroupStore implements TargetGroupStore using one JSON file per
+// organization.
+//
+// Storage layout:
+//
+//	{workspace}/groups/{org-id}/target_groups.json
+type LocalTargetGroupStore struct {
+	root string // Root directory for target groups (workspace/groups)
+}
+
+// Create stores a new target group.
+func (s *LocalTargetGroupStore) Create(ctx context.Context, orgID string, group *TargetGroup) error {
+	if group == nil {
+		return NewInvalidInputError("Name", "target group name is required")
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+
+	return s.file(orgID).update(func(groups map[string]*TargetGroup) error {
+		if _, exists := groups[group.Name]; exists {
+			return NewAlreadyExistsError("target group", group.Name)
+		}
+		now := time.Now()
+		if group.CreatedAt.IsZero() {
+			group.CreatedAt = now
+		}
+		group.UpdatedAt = now
+		groups[group.Name] = group
+		return nil
+	})
+}
+
+// Get retrieves a target group by name.
+func (s *LocalTargetGroupStore) Get(ctx context.Context, orgID, name string) (*TargetGroup, error) {
+	groups, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	group, ok := groups[name]
+	if !ok {
+		return nil, NewNotFoundError("target group", name)
+	}
+	return group, nil
+}
+
+// List returns all target groups ordered by name.
+func (s *LocalTargetGroupStore) List(ctx context.Context, orgID string) ([]*TargetGroup, error) {
+	groups, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*TargetGroup, 0, len(groups))
+	for _, group := range groups {
+		out = append(out, group)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// Update replaces the description, targets, tags and blackout windows of
+// a group.
+func (s *LocalTargetGroupStore) Update(ctx context.Context, orgID string, group *TargetGroup) error {
+	if group == nil {
+		return NewInvalidInputError("Name", "target group name is required")
+	}
+	if err := group.Validate(); err != nil {
+		return err
+	}
+
+	return s.file(orgID).update(func(groups map[string]*TargetGroup) error {
+		current, ok := groups[group.Name]
+		if !ok {
+			return NewNotFoundError("target group", group.Name)
+		}
+		current.Description = group.Description
+		current.Targets = group.Targets
+		current.Tags = group.Tags
+		current.Blackouts = group.Blackouts
+		current.UpdatedAt = time.Now()
+		*group = *current
+		return nil
+	})
+}
+
+// Delete removes a target group.
+func (s *LocalTargetGroupStore) Delete(ctx context.Context, orgID, name string) error {
+	return s.file(orgID).update(func(groups map[string]*TargetGroup) error {
+		if _, ok := groups[name]; !ok {
+			return NewNotFoundError("target group", name)
+		}
+		delete(groups, name)
+		return nil
+	})
+}
+
+func (s *LocalTargetGroupStore) file(orgID string) *jsonMapFile[TargetGroup] {
+	return &jsonMapFile[TargetGroup]{path: filepath.Join(s.root, orgID, "target_groups.json"), kind: "target groups"}
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTargetGroupStore(t *testing.T) TargetGroupStore {
+	t.Helper()
+	backend, err := NewLocalBackend(context.Background(), &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	return backend.TargetGroups()
+}
+
+func TestLocalTargetGroupStore_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTargetGroupStore(t)
+
+	group := &TargetGroup{Name: "prod-web", Targets: []string{"10.0.1.0/24", "www.example.com"}, Tags: []string{"prod", "pci"}}
+	require.NoError(t, store.Create(ctx, DefaultOrgID, group))
+	require.False(t, group.CreatedAt.IsZero())
+	require.NoError(t, store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "branch-berlin", Targets: []string{"10.8.0.0/16"}}))
+	require.True(t, IsAlreadyExists(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "prod-web", Targets: []string{"10.0.0.1"}})))
+
+	groups, err := store.List(ctx, DefaultOrgID)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, "branch-berlin", groups[0].Name)
+
+	// Groups are kept per organization
+	groups, err = store.List(ctx, "team-a")
+	require.NoError(t, err)
+	require.Empty(t, groups)
+
+	update := &TargetGroup{Name: "prod-web", Targets: []string{"10.0.2.0/24"}, Tags: []string{"prod"}, Blackouts: []string{"mon-fri 08:00-18:00"}}
+	require.NoError(t, store.Update(ctx, DefaultOrgID, update))
+	require.Equal(t, group.CreatedAt.Unix(), update.CreatedAt.Unix())
+	got, err := store.Get(ctx, DefaultOrgID, "prod-web")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.2.0/24"}, got.Targets)
+	require.Equal(t, []string{"prod"}, got.Tags)
+	require.Equal(t, []string{"mon-fri 08:00-18:00"}, got.Blackouts)
+
+	require.NoError(t, store.Delete(ctx, DefaultOrgID, "prod-web"))
+	_, err = store.Get(ctx, DefaultOrgID, "prod-web")
+	require.True(t, IsNotFound(err))
+	require.True(t, IsNotFound(store.Delete(ctx, DefaultOrgID, "prod-web")))
+	require.True(t, IsNotFound(store.Update(ctx, DefaultOrgID, update)))
+}
+
+func TestLocalTargetGroupStore_Validation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTargetGroupStore(t)
+
+	require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, nil)))
+	for _, name := range []string{"", "Prod", "../etc", "a/b", "-web", "prod_web"} {
+		require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: name, Targets: []string{"10.0.0.1"}})), name)
+	}
+	require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "web"})))
+	require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "web", Targets: []string{"10.0.0.0/33"}})))
+	require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "web", Targets: []string{"10.0.0.1"}, Tags: []string{" "}})))
+	require.True(t, IsInvalidInput(store.Create(ctx, DefaultOrgID, &TargetGroup{Name: "web", Targets: []string{"10.0.0.1"}, Blackouts: []string{"weekdays"}})))
+}
+
+func TestTargetGroup_Contains(t *testing.T) {
+	g := &TargetGroup{Targets: []string{"10.0.1.0/24", "192.0.2.7", "WWW.example.com"}}
+	require.True(t, g.Contains("10.0.1.20"))
+	require.True(t, g.Contains("192.0.2.7"))
+	require.True(t, g.Contains("::ffff:192.0.2.7"))
+	require.True(t, g.Contains("www.example.com"))
+	require.False(t, g.Contains("10.0.2.1"))
+	require.False(t, g.Contains("example.com"))
+	require.False(t, g.Contains(""))
+}
+
+func TestGroupTags(t *testing.T) {
+	require.Nil(t, GroupTags(nil))
+	require.Equal(t, []string{"prod", "pci", "eu"}, GroupTags([]*TargetGroup{
+		{Tags: []string{"prod", "pci"}},
+		{Tags: []string{"prod", "eu"}},
+	}))
+}
+
+func TestLocalScanStore_FilterByGroup(t *testing.T) {
+	ctx := context.Background()
+	backend, err := NewLocalBackend(ctx, &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+
+	require.NoError(t, backend.Scans().Create(ctx, DefaultOrgID, &ScanMetadata{ID: "scan-1", Target: "10.0.1.0/24", Status: "completed", Groups: []string{"prod-web"}}))
+	require.NoError(t, backend.Scans().Create(ctx, DefaultOrgID, &ScanMetadata{ID: "scan-2", Target: "10.8.0.0/16", Status: "completed"}))
+
+	scans, _, total, err := backend.Scans().ListPaginated(ctx, DefaultOrgID, ScanFilter{Group: "prod-web"}, "", 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Equal(t, "scan-1", scans[0].ID)
+	require.Equal(t, []string{"prod-web"}, scans[0].Groups)
+
+	scans, err = backend.Scans().List(ctx, DefaultOrgID, ScanFilter{Group: "prod-web"})
+	require.NoError(t, err)
+	require.Len(t, scans, 1)
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// tenantIDPattern restricts tenant IDs to safe path components.
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Tenant is an isolated workspace on a shared server (a team or customer).
+//
+// Tenants map 1:1 to the orgID used throughout the storage layer: scans,
+// API keys, users and installed plugins are kept apart per tenant.
+// The DefaultOrgID tenant always exists.
+type Tenant struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+
+	// PluginSources overrides the plugin repositories used for this tenant.
+	// Empty means the server's default sources.
+	PluginSources []TenantPluginSource `json:"plugin_sources,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TenantPluginSource is a plugin repository configured for a tenant.
+type TenantPluginSource struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Priority int    `json:"priority,omitempty"` // lower number = higher priority
+}
+
+// ValidateTenantID checks that id can be used as a tenant identifier.
+func ValidateTenantID(id string) error {
+	if !tenantIDPattern.MatchString(id) {
+		return NewInvalidInputError("ID", "tenant ID must be 1-63 lowercase letters, digits or dashes")
+	}
+	return nil
+}
+
+// TenantStore manages the tenants hosted by a server.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type TenantStore interface {
+	// Create registers a new tenant.
+	//
+	// Returns ErrAlreadyExists if the tenant exists and ErrInvalidInput
+	// for malformed IDs.
+	Create(ctx context.Context, tenant *Tenant) error
+
+	// Get retrieves a tenant by ID. The default tenant is always found.
+	//
+	// Returns ErrNotFound if the tenant does not exist.
+	Get(ctx context.Context, tenantID string) (*Tenant, error)
+
+	// List returns all tenants (including the default tenant) ordered by ID.
+	List(ctx context.Context) ([]*Tenant, error)
+
+	// Delete unregisters a tenant. Its data is left on disk but can no
+	// longer be reached through the server. The default tenant cannot be deleted.
+	//
+	// Returns ErrNotFound if the tenant does not exist.
+	Delete(ctx context.Context, tenantID string) error
+}
+
+// TenantBackend is implemented by backends that can host multiple tenants.
+type TenantBackend interface {
+	Tenants() TenantStore
+}
+
+// Tenants returns the tenant storage interface.
+func (b *LocalBackend) Tenants() TenantStore {
+	return b.tenantStore
+}
+
+// LocalTenantStore implements TenantStore using a single JSON file.
+//
+// Storage layout:
+//
+//	{workspace}/tenants.json
+type LocalTenantStore struct {
+	path string
+}
+
+// Create registers a new tenant.
+func (s *LocalTenantStore) Create(ctx context.Context, tenant *Tenant) error {
+	if tenant == nil {
+		return NewInvalidInputError("ID", "tenant ID is required")
+	}
+	if err := ValidateTenantID(tenant.ID); err != nil {
+		return err
+	}
+	if tenant.ID == DefaultOrgID {
+		return NewAlreadyExistsError("tenant", tenant.ID)
+	}
+
+	return s.file().update(func(tenants map[string]*Tenant) error {
+		if _, exists := tenants[tenant.ID]; exists {
+			return NewAlreadyExistsError("tenant", tenant.ID)
+		}
+		if tenant.CreatedAt.IsZero() {
+			tenant.CreatedAt = time.Now()
+		}
+		tenants[tenant.ID] = tenant
+		return nil
+	})
+}
+
+// Get retrieves a tenant by ID.
+func (s *LocalTenantStore) Get(ctx context.Context, tenantID string) (*Tenant, error) {
+	if tenantID == DefaultOrgID {
+		return defaultTenant(), nil
+	}
+
+	tenants, err := s.file().load()
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, ok := tenants[tenantID]
+	if !ok {
+		return nil, NewNotFoundError("tenant", tenantID)
+	}
+	return tenant, nil
+}
+
+// List returns all tenants ordered by ID.
+func (s *LocalTenantStore) List(ctx context.Context) ([]*Tenant, error) {
+	tenants, err := s.file().load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*Tenant, 0, len(tenants)+1)
+	out = append(out, defaultTenant())
+	for _, tenant := range tenants {
+		out = append(out, tenant)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Delete unregisters a tenant.
+func (s *LocalTenantStore) Delete(ctx context.Context, tenantID string) error {
+	if tenantID == DefaultOrgID {
+		return NewInvalidInputError("ID", "the default tenant cannot be deleted")
+	}
+
+	return s.file().update(func(tenants map[string]*Tenant) error {
+		if _, ok := tenants[tenantID]; !ok {
+			return NewNotFoundError("tenant", tenantID)
+		}
+		delete(tenants, tenantID)
+		return nil
+	})
+}
+
+func (s *LocalTenantStore) file() *jsonMapFile[Tenant] {
+	return &jsonMapFile[Tenant]{path: s.path, kind: "tenants"}
+}
+
+func defaultTenant() *Tenant {
+	return &Tenant{ID: DefaultOrgID, Name: "Default"}
+}
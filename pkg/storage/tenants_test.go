@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTenantStore(t *testing.T) TenantStore {
+	t.Helper()
+	backend, err := NewLocalBackend(context.Background(), &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	return backend.Tenants()
+}
+
+func TestLocalTenantStore_CreateGetList(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTenantStore(t)
+
+	// Default tenant always exists
+	got, err := store.Get(ctx, DefaultOrgID)
+	require.NoError(t, err)
+	require.Equal(t, DefaultOrgID, got.ID)
+
+	tenant := &Tenant{
+		ID:            "team-a",
+		Name:          "Team A",
+		PluginSources: []TenantPluginSource{{Name: "internal", URL: "https://plugins.team-a.example/manifest.yaml"}},
+	}
+	require.NoError(t, store.Create(ctx, tenant))
+	require.False(t, tenant.CreatedAt.IsZero())
+
+	got, err = store.Get(ctx, "team-a")
+	require.NoError(t, err)
+	require.Equal(t, "Team A", got.Name)
+	require.Len(t, got.PluginSources, 1)
+
+	tenants, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, tenants, 2)
+	require.Equal(t, DefaultOrgID, tenants[0].ID)
+	require.Equal(t, "team-a", tenants[1].ID)
+
+	require.True(t, IsAlreadyExists(store.Create(ctx, &Tenant{ID: "team-a"})))
+	require.True(t, IsAlreadyExists(store.Create(ctx, &Tenant{ID: DefaultOrgID})))
+
+	_, err = store.Get(ctx, "team-b")
+	require.True(t, IsNotFound(err))
+}
+
+func TestLocalTenantStore_Validation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTenantStore(t)
+
+	require.True(t, IsInvalidInput(store.Create(ctx, nil)))
+	for _, id := range []string{"", "Team-A", "../etc", "a/b", "-lead", "team_a"} {
+		require.True(t, IsInvalidInput(store.Create(ctx, &Tenant{ID: id})), id)
+	}
+}
+
+func TestLocalTenantStore_Delete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestTenantStore(t)
+	require.NoError(t, store.Create(ctx, &Tenant{ID: "team-a"}))
+
+	require.NoError(t, store.Delete(ctx, "team-a"))
+	_, err := store.Get(ctx, "team-a")
+	require.True(t, IsNotFound(err))
+
+	require.True(t, IsNotFound(store.Delete(ctx, "team-a")))
+	require.True(t, IsInvalidInput(store.Delete(ctx, DefaultOrgID)))
+}
@@ -1,6 +1,11 @@
 package storage
 
-import "time"
+import (
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/cdn"
+	"github.com/vulntor/vulntor/pkg/engagement"
+)
 
 // ScanMetadata contains metadata about a scan.
 //
@@ -17,6 +22,11 @@ type ScanMetadata struct {
 	// OSS uses "default", Enterprise uses actual organization IDs.
 	OrgID string `json:"org_id"`
 
+	// SchemaVersion is the format version of the scan's metadata and data
+	// files (see ScanSchemaVersion). Zero for scans stored before
+	// versioning.
+	SchemaVersion int `json:"schema_version"`
+
 	// UserID identifies the user who created the scan.
 	// OSS uses "local", Enterprise uses actual user IDs.
 	UserID string `json:"user_id"`
@@ -25,10 +35,31 @@ type ScanMetadata struct {
 	// Examples: "192.168.1.0/24", "example.com", "10.0.0.1-10.0.0.255"
 	Target string `json:"target"`
 
+	// Groups names the target groups the scan was run against. Their
+	// targets are part of Target.
+	Groups []string `json:"groups,omitempty"`
+
+	// ReplayOf is the ID of the scan whose stored evidence this scan
+	// re-evaluated, without probing the targets again.
+	ReplayOf string `json:"replay_of,omitempty"`
+
+	// RecheckOf is the ID of the finding this scan rechecked, probing only
+	// the port and plugin that reported it.
+	RecheckOf string `json:"recheck_of,omitempty"`
+
+	// Engagement records the authorization the scan was run under: client,
+	// authorization reference, tester and testing window.
+	Engagement *engagement.Record `json:"engagement,omitempty"`
+
 	// Status indicates the current state of the scan.
-	// Valid values: "pending", "running", "completed", "failed", "canceled"
+	// Valid values: "pending", "running", "completed", "failed", "canceled",
+	// "interrupted"
 	Status string `json:"status"`
 
+	// Resume records how far an interrupted scan got, so that it can be
+	// resumed. Only set for interrupted scans.
+	Resume *ResumeState `json:"resume,omitempty"`
+
 	// StartedAt is when the scan was started (UTC).
 	StartedAt time.Time `json:"started_at"`
 
@@ -82,6 +113,25 @@ type ScanMetadata struct {
 	Extensions map[string]any `json:"-"`
 }
 
+// ResumeState records how far a scan got before it was interrupted, e.g.
+// by Ctrl+C. The results of the modules it completed are stored with the
+// scan.
+type ResumeState struct {
+	// InterruptedAt is when the shutdown of the scan began (UTC).
+	InterruptedAt time.Time `json:"interrupted_at"`
+
+	// Targets lists the targets of the scan, including those of its
+	// target groups.
+	Targets []string `json:"targets"`
+
+	// CompletedModules lists the DAG modules that finished.
+	CompletedModules []string `json:"completed_modules,omitempty"`
+
+	// PendingModules lists the DAG modules that did not start or were
+	// canceled at the drain deadline.
+	PendingModules []string `json:"pending_modules,omitempty"`
+}
+
 // VulnCounts contains vulnerability counts by severity level.
 type VulnCounts struct {
 	Critical int `json:"critical"`
@@ -104,6 +154,9 @@ type ScanFilter struct {
 	// Target filters by target substring match (empty = all targets).
 	Target string
 
+	// Group filters by target group name (empty = all scans).
+	Group string
+
 	// Limit is the maximum number of results to return (0 = no limit).
 	Limit int
 
@@ -140,6 +193,8 @@ type ScanUpdates struct {
 	VulnCount       *VulnCounts     `json:"vuln_count,omitempty"`
 	ErrorMessage    *string         `json:"error_message,omitempty"`
 	StorageLocation *string         `json:"storage_location,omitempty"`
+	Resume          *ResumeState    `json:"resume,omitempty"`
+	SchemaVersion   *int            `json:"schema_version,omitempty"`
 	Extensions      *map[string]any `json:"-"`
 }
 
@@ -166,6 +221,19 @@ const (
 	// DataTypeBanners is the service banners file (banners.txt).
 	// Format: Raw text, one banner per line.
 	DataTypeBanners DataType = "banners.txt"
+
+	// DataTypeEvidence is the raw evidence file (evidence.jsonl): the
+	// results of the modules that probed the targets, such as open ports
+	// and banners, which scans can be replayed from.
+	// Format: One JSON object per line, each holding a data key, the type
+	// of its value and the value.
+	DataTypeEvidence DataType = "evidence.jsonl"
+
+	// DataTypePluginStats is the plugin execution file (plugin_stats.jsonl):
+	// the match attempts, matches, errors and wall-clock time of each
+	// plugin evaluated by the scan.
+	// Format: One JSON object per line, each representing a plugin.
+	DataTypePluginStats DataType = "plugin_stats.jsonl"
 )
 
 // String returns the string representation of DataType.
@@ -173,17 +241,94 @@ func (d DataType) String() string {
 	return string(d)
 }
 
+// IsSensitive reports whether the data file can hold secrets, such as
+// banners, and the evidence and findings of default credential checks,
+// which carry the passwords found. Sensitive files are encrypted at rest
+// when encryption is configured.
+func (d DataType) IsSensitive() bool {
+	return d == DataTypeBanners || d == DataTypeEvidence || d == DataTypeVulnerabilities
+}
+
 // IsValid checks if the DataType is valid.
 func (d DataType) IsValid() bool {
 	switch d {
 	case DataTypeMetadata, DataTypeHosts, DataTypeServices,
-		DataTypeVulnerabilities, DataTypeBanners:
+		DataTypeVulnerabilities, DataTypeBanners, DataTypeEvidence,
+		DataTypePluginStats:
 		return true
 	default:
 		return false
 	}
 }
 
+// HostRecord is one line of the hosts file (DataTypeHosts): a live host
+// and the open ports observed on it during the scan.
+type HostRecord struct {
+	IP              string          `json:"ip"`
+	Target          string          `json:"target"` // scan target the host was found through
+	Hostnames       []string        `json:"hostnames,omitempty"`
+	Ports           []ServiceRecord `json:"ports,omitempty"`
+	Vulnerabilities int             `json:"vulnerabilities"`
+
+	// Cloud is the provider instance the host was listed from, for targets
+	// of a cloud inventory
+	Cloud *CloudRecord `json:"cloud,omitempty"`
+
+	// Intermediary is the CDN or WAF the host was found behind
+	Intermediary *cdn.Intermediary `json:"intermediary,omitempty"`
+}
+
+// CloudRecord identifies the cloud instance behind a host, so that its
+// findings can be routed to the team owning the account or instance.
+type CloudRecord struct {
+	Provider       string            `json:"provider"`
+	InstanceID     string            `json:"instance_id"`
+	Name           string            `json:"name,omitempty"`
+	Account        string            `json:"account,omitempty"` // AWS account, GCP project or Azure subscription
+	Region         string            `json:"region,omitempty"`
+	Network        string            `json:"network,omitempty"`
+	SecurityGroups []string          `json:"security_groups,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+}
+
+// ServiceRecord describes an open port on a host.
+type ServiceRecord struct {
+	Port     int    `json:"port"`
+	Protocol string `json:"protocol"`
+	Service  string `json:"service,omitempty"`
+	Product  string `json:"product,omitempty"`
+	Version  string `json:"version,omitempty"`
+
+	// Certificate is the TLS certificate served on the port, if any
+	Certificate *CertificateRecord `json:"certificate,omitempty"`
+	// JARM and JA3S fingerprint the TLS server on the port, if any
+	JARM string `json:"jarm,omitempty"`
+	JA3S string `json:"ja3s,omitempty"`
+	// Redirect is the Location of an HTTP redirect answered on the port
+	Redirect string `json:"redirect,omitempty"`
+	// WellKnown is what the HTTP service on the port publishes at its
+	// well-known metadata endpoints, if any
+	WellKnown *WellKnownRecord `json:"well_known,omitempty"`
+}
+
+// WellKnownRecord holds the security contacts and policies an HTTP service
+// publishes in its security.txt and robots.txt.
+type WellKnownRecord struct {
+	Endpoints []string  `json:"endpoints"`          // paths served, e.g. /robots.txt
+	Contacts  []string  `json:"contacts,omitempty"` // security.txt Contact
+	Policy    []string  `json:"policy,omitempty"`   // security.txt Policy
+	Expires   time.Time `json:"expires,omitempty"`  // security.txt Expires
+	Disallow  []string  `json:"disallow,omitempty"` // paths robots.txt disallows
+}
+
+// CertificateRecord identifies a TLS certificate observed on a port.
+type CertificateRecord struct {
+	Subject  string    `json:"subject,omitempty"`   // peer common name
+	DNSNames []string  `json:"dns_names,omitempty"` // subject alternative names
+	Issuer   string    `json:"issuer,omitempty"`
+	NotAfter time.Time `json:"not_after,omitempty"`
+}
+
 // ScanStatus represents valid scan status values.
 type ScanStatus string
 
@@ -194,6 +339,9 @@ const (
 	StatusCompleted ScanStatus = "completed"
 	StatusFailed    ScanStatus = "failed"
 	StatusCancelled ScanStatus = "canceled"
+	// StatusInterrupted marks scans shut down gracefully before they
+	// completed, keeping their partial results.
+	StatusInterrupted ScanStatus = "interrupted"
 )
 
 // String returns the string representation of ScanStatus.
@@ -204,7 +352,7 @@ func (s ScanStatus) String() string {
 // IsValid checks if the ScanStatus is valid.
 func (s ScanStatus) IsValid() bool {
 	switch s {
-	case StatusPending, StatusRunning, StatusCompleted, StatusFailed, StatusCancelled:
+	case StatusPending, StatusRunning, StatusCompleted, StatusFailed, StatusCancelled, StatusInterrupted:
 		return true
 	default:
 		return false
@@ -213,5 +361,5 @@ func (s ScanStatus) IsValid() bool {
 
 // IsTerminal returns true if the status indicates the scan is finished.
 func (s ScanStatus) IsTerminal() bool {
-	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled
+	return s == StatusCompleted || s == StatusFailed || s == StatusCancelled || s == StatusInterrupted
 }
@@ -59,6 +59,8 @@ func TestDataType_IsValid(t *testing.T) {
 		{"services", DataTypeServices, true},
 		{"vulnerabilities", DataTypeVulnerabilities, true},
 		{"banners", DataTypeBanners, true},
+		{"evidence", DataTypeEvidence, true},
+		{"plugin stats", DataTypePluginStats, true},
 		{"invalid", DataType("invalid.txt"), false},
 		{"empty", DataType(""), false},
 	}
@@ -105,6 +107,7 @@ func TestScanStatus_IsValid(t *testing.T) {
 		{"completed", StatusCompleted, true},
 		{"failed", StatusFailed, true},
 		{"canceled", StatusCancelled, true},
+		{"interrupted", StatusInterrupted, true},
 		{"invalid", ScanStatus("invalid"), false},
 		{"empty", ScanStatus(""), false},
 	}
@@ -130,6 +133,7 @@ func TestScanStatus_IsTerminal(t *testing.T) {
 		{"completed - terminal", StatusCompleted, true},
 		{"failed - terminal", StatusFailed, true},
 		{"canceled - terminal", StatusCancelled, true},
+		{"interrupted - terminal", StatusInterrupted, true},
 	}
 
 	for _, tt := range tests {
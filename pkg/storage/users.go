@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// User is a server user with a single role (viewer, operator, admin).
+//
+// Users do not authenticate directly; they own API keys (APIKey.UserID) or
+// are resolved from an external identity provider, and their role caps what
+// those credentials may do.
+type User struct {
+	ID        string    `json:"id"` // unique username or IdP subject
+	Name      string    `json:"name,omitempty"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserStore manages server users and their role assignments.
+//
+// Thread-safety: All methods must be safe for concurrent use.
+type UserStore interface {
+	// Create stores a new user.
+	//
+	// Returns ErrAlreadyExists if a user with the same ID already exists.
+	Create(ctx context.Context, orgID string, user *User) error
+
+	// Get retrieves a user by ID.
+	//
+	// Returns ErrNotFound if the user does not exist.
+	Get(ctx context.Context, orgID, userID string) (*User, error)
+
+	// List returns all users ordered by ID.
+	List(ctx context.Context, orgID string) ([]*User, error)
+
+	// SetRole changes a user's role.
+	//
+	// Returns ErrNotFound if the user does not exist.
+	SetRole(ctx context.Context, orgID, userID, role string) error
+
+	// Delete removes a user. API keys owned by the user stop working.
+	//
+	// Returns ErrNotFound if the user does not exist.
+	Delete(ctx context.Context, orgID, userID string) error
+}
+
+// UserBackend is implemented by backends that can persist users.
+type UserBackend interface {
+	Users() UserStore
+}
+
+// Users returns the user storage interface.
+func (b *LocalBackend) Users() UserStore {
+	return b.userStore
+}
+
+// LocalUserStore implements UserStore using one JSON file per organization.
+//
+// Storage layout:
+//
+//	{workspace}/auth/{org-id}/users.json
+type LocalUserStore struct {
+	root string // Root directory for auth data (workspace/auth)
+}
+
+// Create stores a new user.
+func (s *LocalUserStore) Create(ctx context.Context, orgID string, user *User) error {
+	if user == nil || user.ID == "" {
+		return NewInvalidInputError("ID", "user ID is required")
+	}
+	if user.Role == "" {
+		return NewInvalidInputError("Role", "user role is required")
+	}
+
+	return s.file(orgID).update(func(users map[string]*User) error {
+		if _, exists := users[user.ID]; exists {
+			return NewAlreadyExistsError("user", user.ID)
+		}
+		now := time.Now()
+		if user.CreatedAt.IsZero() {
+			user.CreatedAt = now
+		}
+		user.UpdatedAt = now
+		users[user.ID] = user
+		return nil
+	})
+}
+
+// Get retrieves a user by ID.
+func (s *LocalUserStore) Get(ctx context.Context, orgID, userID string) (*User, error) {
+	users, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := users[userID]
+	if !ok {
+		return nil, NewNotFoundError("user", userID)
+	}
+	return user, nil
+}
+
+// List returns all users ordered by ID.
+func (s *LocalUserStore) List(ctx context.Context, orgID string) ([]*User, error) {
+	users, err := s.file(orgID).load()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*User, 0, len(users))
+	for _, user := range users {
+		out = append(out, user)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// SetRole changes a user's role.
+func (s *LocalUserStore) SetRole(ctx context.Context, orgID, userID, role string) error {
+	if role == "" {
+		return NewInvalidInputError("Role", "user role is required")
+	}
+
+	return s.file(orgID).update(func(users map[string]*User) error {
+		user, ok := users[userID]
+		if !ok {
+			return NewNotFoundError("user", userID)
+		}
+		user.Role = role
+		user.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// Delete removes a user.
+func (s *LocalUserStore) Delete(ctx context.Context, orgID, userID string) error {
+	return s.file(orgID).update(func(users map[string]*User) error {
+		if _, ok := users[userID]; !ok {
+			return NewNotFoundError("user", userID)
+		}
+		delete(users, userID)
+		return nil
+	})
+}
+
+func (s *LocalUserStore) file(orgID string) *jsonMapFile[User] {
+	return &jsonMapFile[User]{path: filepath.Join(s.root, orgID, "users.json"), kind: "users"}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUserStore(t *testing.T) UserStore {
+	t.Helper()
+	backend, err := NewLocalBackend(context.Background(), &Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	return backend.Users()
+}
+
+func TestLocalUserStore_CreateGetList(t *testing.T) {
+	ctx := context.Background()
+	store := newTestUserStore(t)
+
+	require.NoError(t, store.Create(ctx, "default", &User{ID: "bob", Role: "viewer"}))
+	require.NoError(t, store.Create(ctx, "default", &User{ID: "alice", Name: "Alice", Role: "admin"}))
+
+	got, err := store.Get(ctx, "default", "alice")
+	require.NoError(t, err)
+	require.Equal(t, "Alice", got.Name)
+	require.Equal(t, "admin", got.Role)
+	require.False(t, got.CreatedAt.IsZero())
+
+	users, err := store.List(ctx, "default")
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+	require.Equal(t, "alice", users[0].ID, "users should be sorted by ID")
+
+	err = store.Create(ctx, "default", &User{ID: "alice", Role: "viewer"})
+	require.True(t, IsAlreadyExists(err))
+
+	_, err = store.Get(ctx, "default", "carol")
+	require.True(t, IsNotFound(err))
+
+	users, err = store.List(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, users)
+}
+
+func TestLocalUserStore_CreateValidation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestUserStore(t)
+
+	require.True(t, IsInvalidInput(store.Create(ctx, "default", nil)))
+	require.True(t, IsInvalidInput(store.Create(ctx, "default", &User{Role: "viewer"})))
+	require.True(t, IsInvalidInput(store.Create(ctx, "default", &User{ID: "alice"})))
+}
+
+func TestLocalUserStore_SetRoleDelete(t *testing.T) {
+	ctx := context.Background()
+	store := newTestUserStore(t)
+	require.NoError(t, store.Create(ctx, "default", &User{ID: "alice", Role: "viewer"}))
+
+	require.NoError(t, store.SetRole(ctx, "default", "alice", "operator"))
+	got, err := store.Get(ctx, "default", "alice")
+	require.NoError(t, err)
+	require.Equal(t, "operator", got.Role)
+	require.False(t, got.UpdatedAt.Before(got.CreatedAt))
+
+	require.True(t, IsNotFound(store.SetRole(ctx, "default", "bob", "admin")))
+
+	require.NoError(t, store.Delete(ctx, "default", "alice"))
+	_, err = store.Get(ctx, "default", "alice")
+	require.True(t, IsNotFound(err))
+	require.True(t, IsNotFound(store.Delete(ctx, "default", "alice")))
+}
@@ -0,0 +1,129 @@
+// Package surface scores the attack surface of hosts: how much of a host
+// an attacker can reach and how likely it is to be exploitable. A host
+// scores a weight per open port, a risk weight per service (remote admin,
+// cleartext and database services weigh most), and a weight per distinct
+// unpatched CVE by severity. Internet-facing hosts score ExposureFactor
+// times as much.
+//
+// Scores are comparable over time as long as the weights do not change,
+// so a decreasing score shows surface reduction.
+package surface
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// InternetFacingTag marks target groups whose targets are reachable from
+// the internet, whatever their addresses.
+const InternetFacingTag = "internet-facing"
+
+// Port is an open port of a host.
+type Port struct {
+	Port    int
+	Service string // detected service name, e.g. "ssh"; empty if unknown
+}
+
+// Host is what a scan observed of a host.
+type Host struct {
+	IP    string
+	Ports []Port
+
+	// CVEs maps the unpatched CVEs reported on the host to their
+	// severity.
+	CVEs map[string]string
+
+	// InternetFacing reports whether the host is reachable from the
+	// internet, see Public and InternetFacingTag.
+	InternetFacing bool
+}
+
+// Weights are the contributions of each part of a host to its score.
+type Weights struct {
+	Port           int            // per open port
+	Services       map[string]int // per open port, by lowercase service name
+	CVEs           map[string]int // per distinct CVE, by lowercase severity
+	ExposureFactor int            // multiplies the score of internet-facing hosts
+}
+
+// DefaultWeights returns the built-in weights.
+func DefaultWeights() Weights {
+	return Weights{
+		Port: 1,
+		Services: map[string]int{
+			// Cleartext logins
+			"telnet": 10,
+			"ftp":    6,
+			"rlogin": 10,
+			"vnc":    8,
+			// Remote administration and file sharing
+			"ms-wbt-server": 8,
+			"rdp":           8,
+			"smb":           8,
+			"microsoft-ds":  8,
+			"netbios":       6,
+			"netbios-ssn":   6,
+			"msrpc":         6,
+			"rpc":           4,
+			"snmp":          6,
+			"ssh":           2,
+			// Datastores and brokers that should not be exposed
+			"mysql":         6,
+			"postgresql":    6,
+			"mssql":         6,
+			"mongodb":       6,
+			"redis":         6,
+			"memcached":     6,
+			"elasticsearch": 6,
+			"rabbitmq":      4,
+			"kafka":         4,
+			"ldap":          4,
+			// Common network services
+			"http":       2,
+			"http-proxy": 2,
+			"https":      1,
+			"smtp":       2,
+			"imap":       2,
+			"pop3":       2,
+			"dns":        2,
+		},
+		CVEs: map[string]int{
+			"critical": 20,
+			"high":     10,
+			"medium":   5,
+			"low":      2,
+		},
+		ExposureFactor: 2,
+	}
+}
+
+// Score returns the attack surface score of h.
+func (w Weights) Score(h Host) int {
+	score := 0
+	for _, p := range h.Ports {
+		score += w.Port + w.Services[strings.ToLower(p.Service)]
+	}
+	for _, severity := range h.CVEs {
+		score += w.CVEs[strings.ToLower(severity)]
+	}
+	if h.InternetFacing && w.ExposureFactor > 1 {
+		score *= w.ExposureFactor
+	}
+	return score
+}
+
+// cgnat is the shared address space of carrier-grade NAT (RFC 6598),
+// which is not reachable from the internet.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// Public reports whether ip is a globally routable unicast address, so a
+// host at ip may be reachable from the internet. Hostnames and invalid
+// addresses are not public.
+func Public(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !cgnat.Contains(addr)
+}
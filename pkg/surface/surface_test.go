@@ -0,0 +1,52 @@
+package surface
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeights_Score(t *testing.T) {
+	w := DefaultWeights()
+	require.Zero(t, w.Score(Host{IP: "10.0.0.5"}))
+
+	host := Host{
+		IP: "10.0.0.5",
+		Ports: []Port{
+			{Port: 22, Service: "ssh"},    // 1 + 2
+			{Port: 23, Service: "Telnet"}, // 1 + 10
+			{Port: 8443},                  // 1, unknown service
+		},
+		CVEs: map[string]string{
+			"CVE-2024-6387": "critical", // 20
+			"CVE-2023-0001": "info",     // 0
+		},
+	}
+	require.Equal(t, 35, w.Score(host))
+
+	host.InternetFacing = true
+	require.Equal(t, 70, w.Score(host))
+
+	// Without a factor, exposure does not change the score
+	w.ExposureFactor = 0
+	require.Equal(t, 35, w.Score(host))
+}
+
+func TestPublic(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"::ffff:1.1.1.1":       true,
+		"10.0.0.5":             false,
+		"192.168.1.1":          false,
+		"172.16.0.1":           false,
+		"100.64.0.1":           false,
+		"127.0.0.1":            false,
+		"169.254.0.1":          false,
+		"fd00::1":              false,
+		"example.com":          false,
+		"":                     false,
+	} {
+		require.Equal(t, want, Public(ip), ip)
+	}
+}
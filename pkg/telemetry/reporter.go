@@ -0,0 +1,252 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+const (
+	// DefaultEndpoint receives the reports of the Vulntor maintainers.
+	DefaultEndpoint = "https://telemetry.vulntor.ai/v1/reports"
+
+	// DefaultInterval is the time between reports.
+	DefaultInterval = 24 * time.Hour
+
+	// StateFile is the name of the file in the workspace recording the
+	// install ID and when the last report was sent.
+	StateFile = "telemetry.json"
+
+	sendTimeout = 10 * time.Second
+)
+
+// ErrDisabled is returned when sending a report while telemetry is
+// disabled.
+var ErrDisabled = errors.New("telemetry is disabled; set telemetry.enabled to opt in")
+
+// State is what the workspace records about reports.
+type State struct {
+	InstallID  string    `json:"install_id"`
+	LastReport time.Time `json:"last_report,omitzero"`
+}
+
+// Reporter builds reports from the scans of a storage backend and sends
+// them when due.
+type Reporter struct {
+	backend   storage.Backend
+	statePath string
+	endpoint  string
+	interval  time.Duration
+	enabled   bool
+	client    *http.Client
+	now       func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewReporter returns a reporter for the scans of backend configured by
+// cfg, recording its state in statePath, usually StateFile in the
+// workspace. Disabled reporters only preview reports.
+func NewReporter(backend storage.Backend, statePath string, cfg config.TelemetryConfig) *Reporter {
+	r := &Reporter{
+		backend:   backend,
+		statePath: statePath,
+		endpoint:  cfg.Endpoint,
+		interval:  cfg.Interval,
+		enabled:   cfg.Enabled,
+		client:    &http.Client{Timeout: sendTimeout, Transport: &http.Transport{Proxy: netproxy.HTTPProxy}},
+		now:       time.Now,
+	}
+	if r.endpoint == "" {
+		r.endpoint = DefaultEndpoint
+	}
+	if r.interval <= 0 {
+		r.interval = DefaultInterval
+	}
+	return r
+}
+
+// Enabled reports whether r sends reports.
+func (r *Reporter) Enabled() bool {
+	return r != nil && r.enabled
+}
+
+// Endpoint returns the URL r sends reports to.
+func (r *Reporter) Endpoint() string {
+	return r.endpoint
+}
+
+// Interval returns the time between reports.
+func (r *Reporter) Interval() time.Duration {
+	return r.interval
+}
+
+// State returns the recorded state. The install ID is generated and
+// recorded on first use.
+func (r *Reporter) State() (State, error) {
+	var s State
+	data, err := os.ReadFile(r.statePath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return s, fmt.Errorf("read telemetry state: %w", err)
+	default:
+		if err := json.Unmarshal(data, &s); err != nil {
+			return s, fmt.Errorf("read telemetry state %s: %w", r.statePath, err)
+		}
+	}
+	if s.InstallID == "" {
+		s.InstallID = uuid.NewString()
+		if err := r.saveState(s); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// NextReport returns when the next report is due: an interval after the
+// last one, or now when none was sent.
+func (r *Reporter) NextReport(s State) time.Time {
+	if s.LastReport.IsZero() {
+		return r.now()
+	}
+	return s.LastReport.Add(r.interval)
+}
+
+// Preview builds the report that would be sent now: the aggregates of the
+// scans since the last report, or of the last interval when none was
+// sent. Nothing is sent.
+func (r *Reporter) Preview(ctx context.Context) (*Report, error) {
+	s, err := r.State()
+	if err != nil {
+		return nil, err
+	}
+	return r.build(ctx, s)
+}
+
+// Send builds and sends a report and records that it was sent. It returns
+// ErrDisabled unless telemetry is enabled.
+func (r *Reporter) Send(ctx context.Context) (*Report, error) {
+	if !r.Enabled() {
+		return nil, ErrDisabled
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.State()
+	if err != nil {
+		return nil, err
+	}
+	return r.send(ctx, s)
+}
+
+// SendIfDue sends a report when telemetry is enabled and the interval has
+// passed since the last one. It returns nil when nothing was due.
+func (r *Reporter) SendIfDue(ctx context.Context) (*Report, error) {
+	if !r.Enabled() {
+		return nil, nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, err := r.State()
+	if err != nil {
+		return nil, err
+	}
+	if !s.LastReport.IsZero() && r.now().Before(r.NextReport(s)) {
+		return nil, nil
+	}
+	return r.send(ctx, s)
+}
+
+func (r *Reporter) build(ctx context.Context, s State) (*Report, error) {
+	now := r.now()
+	since := s.LastReport
+	if since.IsZero() {
+		since = now.Add(-r.interval)
+	}
+	report, err := Build(ctx, r.backend, since, now)
+	if err != nil {
+		return nil, err
+	}
+	report.InstallID = s.InstallID
+	return report, nil
+}
+
+func (r *Reporter) send(ctx context.Context, s State) (*Report, error) {
+	report, err := r.build(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("send telemetry: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vulntor/"+version.GetVersion().Version)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send telemetry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("send telemetry: %s answered %s", r.endpoint, resp.Status)
+	}
+
+	s.LastReport = report.PeriodEnd
+	if err := r.saveState(s); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// saveState atomically replaces the state file.
+func (r *Reporter) saveState(s State) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(r.statePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".telemetry-*")
+	if err != nil {
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), r.statePath); err != nil {
+		return fmt.Errorf("save telemetry state: %w", err)
+	}
+	return nil
+}
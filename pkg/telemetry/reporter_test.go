@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func jsonOf(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestReporter(t *testing.T) {
+	backend := newBackend(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	seedScan(t, backend, storage.DefaultOrgID, "s1", "completed", "", now.Add(-time.Hour),
+		[]string{`{"plugin_id":"ssh-weak-cipher","attempts":1,"matches":1}`}, nil)
+
+	var received []Report
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var report Report
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		received = append(received, report)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	statePath := filepath.Join(t.TempDir(), StateFile)
+	newReporter := func(enabled bool) *Reporter {
+		r := NewReporter(backend, statePath, config.TelemetryConfig{Enabled: enabled, Endpoint: srv.URL})
+		r.now = func() time.Time { return now }
+		return r
+	}
+
+	// Disabled reporters only preview
+	disabled := newReporter(false)
+	preview, err := disabled.Preview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, preview.Scans)
+	require.NotEmpty(t, preview.InstallID)
+	require.Equal(t, now.Add(-DefaultInterval), preview.PeriodStart)
+	_, err = disabled.Send(ctx)
+	require.ErrorIs(t, err, ErrDisabled)
+	sent, err := disabled.SendIfDue(ctx)
+	require.NoError(t, err)
+	require.Nil(t, sent)
+	require.Empty(t, received)
+
+	// The first report is due at once and sends what the preview showed
+	r := newReporter(true)
+	sent, err = r.SendIfDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, preview, sent)
+	require.Len(t, received, 1)
+	require.Equal(t, preview.InstallID, received[0].InstallID)
+
+	state, err := r.State()
+	require.NoError(t, err)
+	require.Equal(t, now, state.LastReport)
+	require.Equal(t, now.Add(DefaultInterval), r.NextReport(state))
+
+	// The next one is due an interval later and covers the scans since
+	sent, err = r.SendIfDue(ctx)
+	require.NoError(t, err)
+	require.Nil(t, sent)
+	now = now.Add(DefaultInterval)
+	status = http.StatusInternalServerError
+	_, err = r.SendIfDue(ctx)
+	require.ErrorContains(t, err, "500 Internal Server Error")
+	state, err = r.State()
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-DefaultInterval), state.LastReport, "failed reports are retried")
+
+	status = http.StatusOK
+	sent, err = r.Send(ctx)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-DefaultInterval), sent.PeriodStart)
+	require.Zero(t, sent.Scans)
+	require.Len(t, received, 3)
+}
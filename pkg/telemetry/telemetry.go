@@ -0,0 +1,236 @@
+// Package telemetry builds anonymous aggregates of the detection quality
+// of recent scans and reports them to the Vulntor maintainers, when the
+// user opts in: how often each plugin matched or failed, how many of the
+// services of each protocol fingerprinting could not identify, and which
+// modules crashed.
+//
+// Reports never contain targets, addresses, hostnames, banners, findings,
+// tenants or error messages. They are identified by a random install ID
+// that is not derived from the host.
+package telemetry
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/storage"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+// SchemaVersion is the format version of reports.
+const SchemaVersion = 1
+
+// Report holds the aggregates of the scans started in a period.
+type Report struct {
+	SchemaVersion int       `json:"schema_version"`
+	InstallID     string    `json:"install_id"`
+	Version       string    `json:"version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	PeriodStart   time.Time `json:"period_start"`
+	PeriodEnd     time.Time `json:"period_end"`
+
+	// Scans counts the completed and failed scans of the period.
+	Scans        int            `json:"scans"`
+	Plugins      []PluginRate   `json:"plugins"`
+	Fingerprints []ProtocolRate `json:"fingerprints"`
+	Crashes      []Crash        `json:"crashes"`
+}
+
+// PluginRate reports how often a plugin matched or failed.
+type PluginRate struct {
+	PluginID  string  `json:"plugin_id"`
+	Scans     int     `json:"scans"`
+	Attempts  int64   `json:"attempts"`
+	Matches   int64   `json:"matches"`
+	Errors    int64   `json:"errors"`
+	MatchRate float64 `json:"match_rate"`
+}
+
+// ProtocolRate reports how many of the services of a protocol were not
+// identified, i.e. have no product.
+type ProtocolRate struct {
+	Protocol    string  `json:"protocol"`
+	Services    int     `json:"services"`
+	Unknown     int     `json:"unknown"`
+	UnknownRate float64 `json:"unknown_rate"`
+}
+
+// Crash reports how many scans a panicking module failed.
+type Crash struct {
+	Module string `json:"module"`
+	Scans  int    `json:"scans"`
+}
+
+// panicPattern matches the errors the engine reports for modules that
+// panicked, e.g. "module fingerprint-parser-instance panicked: ...".
+var panicPattern = regexp.MustCompile(`module (\S+) panicked`)
+
+// Build aggregates the completed and failed scans of all tenants of backend
+// started in [since, until). The report has no install ID.
+func Build(ctx context.Context, backend storage.Backend, since, until time.Time) (*Report, error) {
+	orgs, err := orgIDs(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	v := version.GetVersion()
+	r := &Report{
+		SchemaVersion: SchemaVersion,
+		Version:       v.Version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		PeriodStart:   since.UTC(),
+		PeriodEnd:     until.UTC(),
+		Plugins:       []PluginRate{},
+		Fingerprints:  []ProtocolRate{},
+		Crashes:       []Crash{},
+	}
+	plugins := make(map[string]*PluginRate)
+	protocols := make(map[string]*ProtocolRate)
+	crashes := make(map[string]*Crash)
+
+	for _, orgID := range orgs {
+		scans, err := listScans(ctx, backend.Scans(), orgID, since, until)
+		if err != nil {
+			return nil, err
+		}
+		for _, scan := range scans {
+			r.Scans++
+			if m := panicPattern.FindStringSubmatch(scan.ErrorMessage); m != nil {
+				c, ok := crashes[m[1]]
+				if !ok {
+					c = &Crash{Module: m[1]}
+					crashes[m[1]] = c
+				}
+				c.Scans++
+			}
+
+			stats, err := storage.ReadJSONL[plugin.ExecStats](ctx, backend.Scans(), orgID, scan.ID, storage.DataTypePluginStats)
+			if err != nil {
+				return nil, err
+			}
+			for _, s := range stats {
+				if s.PluginID == "" || s.Attempts == 0 {
+					continue
+				}
+				p, ok := plugins[s.PluginID]
+				if !ok {
+					p = &PluginRate{PluginID: s.PluginID}
+					plugins[s.PluginID] = p
+				}
+				p.Scans++
+				p.Attempts += s.Attempts
+				p.Matches += s.Matches
+				p.Errors += s.Errors
+			}
+
+			hosts, err := storage.ReadJSONL[storage.HostRecord](ctx, backend.Scans(), orgID, scan.ID, storage.DataTypeHosts)
+			if err != nil {
+				return nil, err
+			}
+			for _, h := range hosts {
+				for _, svc := range h.Ports {
+					protocol := protocolOf(svc)
+					p, ok := protocols[protocol]
+					if !ok {
+						p = &ProtocolRate{Protocol: protocol}
+						protocols[protocol] = p
+					}
+					p.Services++
+					if svc.Product == "" {
+						p.Unknown++
+					}
+				}
+			}
+		}
+	}
+
+	for _, p := range plugins {
+		p.MatchRate = ratio(p.Matches, p.Attempts)
+		r.Plugins = append(r.Plugins, *p)
+	}
+	sort.Slice(r.Plugins, func(i, j int) bool { return r.Plugins[i].PluginID < r.Plugins[j].PluginID })
+	for _, p := range protocols {
+		p.UnknownRate = ratio(int64(p.Unknown), int64(p.Services))
+		r.Fingerprints = append(r.Fingerprints, *p)
+	}
+	sort.Slice(r.Fingerprints, func(i, j int) bool { return r.Fingerprints[i].Protocol < r.Fingerprints[j].Protocol })
+	for _, c := range crashes {
+		r.Crashes = append(r.Crashes, *c)
+	}
+	sort.Slice(r.Crashes, func(i, j int) bool { return r.Crashes[i].Module < r.Crashes[j].Module })
+	return r, nil
+}
+
+// orgIDs lists the default organization and the tenants of backend.
+func orgIDs(ctx context.Context, backend storage.Backend) ([]string, error) {
+	orgs := []string{storage.DefaultOrgID}
+	tenantBackend, ok := backend.(storage.TenantBackend)
+	if !ok {
+		return orgs, nil
+	}
+	tenants, err := tenantBackend.Tenants().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tenants {
+		if t.ID != storage.DefaultOrgID {
+			orgs = append(orgs, t.ID)
+		}
+	}
+	return orgs, nil
+}
+
+// listScans returns the completed and failed scans of orgID started in
+// [since, until).
+func listScans(ctx context.Context, scans storage.ScanStore, orgID string, since, until time.Time) ([]*storage.ScanMetadata, error) {
+	var out []*storage.ScanMetadata
+	cursor := ""
+	for {
+		// Pages list the newest scans first
+		page, next, _, err := scans.ListPaginated(ctx, orgID, storage.ScanFilter{}, cursor, 100)
+		if err != nil {
+			return nil, err
+		}
+		for _, scan := range page {
+			if scan.StartedAt.Before(since) {
+				return out, nil
+			}
+			if !scan.StartedAt.Before(until) {
+				continue
+			}
+			if scan.Status == string(storage.StatusCompleted) || scan.Status == string(storage.StatusFailed) {
+				out = append(out, scan)
+			}
+		}
+		if next == "" {
+			return out, nil
+		}
+		cursor = next
+	}
+}
+
+// protocolOf names the protocol of a service: the detected service, or
+// the transport when none was detected.
+func protocolOf(svc storage.ServiceRecord) string {
+	if name := strings.ToLower(strings.TrimSpace(svc.Service)); name != "" {
+		return name
+	}
+	if svc.Protocol != "" {
+		return strings.ToLower(svc.Protocol)
+	}
+	return "unknown"
+}
+
+func ratio(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
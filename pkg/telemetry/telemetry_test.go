@@ -0,0 +1,90 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// seedScan stores a scan started at start with the given plugin stats and
+// hosts, as JSON Lines.
+func seedScan(t *testing.T, backend storage.Backend, orgID, id, status, errMsg string, start time.Time, stats, hosts []string) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, backend.Scans().Create(ctx, orgID, &storage.ScanMetadata{
+		ID: id, Target: "10.0.0.1", Status: status, ErrorMessage: errMsg, StartedAt: start,
+	}))
+	if len(stats) > 0 {
+		require.NoError(t, backend.Scans().WriteData(ctx, orgID, id, storage.DataTypePluginStats, strings.NewReader(strings.Join(stats, "\n"))))
+	}
+	if len(hosts) > 0 {
+		require.NoError(t, backend.Scans().WriteData(ctx, orgID, id, storage.DataTypeHosts, strings.NewReader(strings.Join(hosts, "\n"))))
+	}
+}
+
+func newBackend(t *testing.T) *storage.LocalBackend {
+	t.Helper()
+	backend, err := storage.NewLocalBackend(context.Background(), &storage.Config{WorkspaceRoot: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend
+}
+
+func TestBuild(t *testing.T) {
+	backend := newBackend(t)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-24 * time.Hour)
+
+	seedScan(t, backend, storage.DefaultOrgID, "s1", "completed", "", now.Add(-2*time.Hour),
+		[]string{
+			`{"plugin_id":"ssh-weak-cipher","plugin":"SSH Weak Cipher","attempts":4,"matches":1}`,
+			`{"plugin_id":"http-header","plugin":"HTTP Header","attempts":2,"errors":2}`,
+		},
+		[]string{`{"ip":"10.0.0.1","target":"10.0.0.1","hostnames":["db.acme.internal"],"ports":[{"port":22,"protocol":"tcp","service":"ssh","product":"OpenSSH"},{"port":8080,"protocol":"tcp","service":"http"},{"port":9999,"protocol":"tcp"}]}`})
+	require.NoError(t, backend.Tenants().Create(ctx, &storage.Tenant{ID: "team-a", Name: "Team A"}))
+	seedScan(t, backend, "team-a", "s2", "completed", "", now.Add(-time.Hour),
+		[]string{`{"plugin_id":"ssh-weak-cipher","plugin":"SSH Weak Cipher","attempts":2,"matches":1}`},
+		[]string{`{"ip":"10.0.0.2","target":"10.0.0.2","ports":[{"port":22,"protocol":"tcp","service":"SSH"}]}`})
+	seedScan(t, backend, storage.DefaultOrgID, "s3", "failed", "module fingerprint-parser-instance panicked: runtime error: index out of range", now.Add(-30*time.Minute), nil, nil)
+
+	// Scans before the period, still running or failed without a crash
+	// count only when they are completed or failed in the period
+	seedScan(t, backend, storage.DefaultOrgID, "old", "completed", "", since.Add(-time.Hour),
+		[]string{`{"plugin_id":"old-plugin","attempts":1}`}, nil)
+	seedScan(t, backend, storage.DefaultOrgID, "running", "running", "", now.Add(-10*time.Minute),
+		[]string{`{"plugin_id":"running-plugin","attempts":1}`}, nil)
+
+	r, err := Build(ctx, backend, since, now)
+	require.NoError(t, err)
+	require.Equal(t, SchemaVersion, r.SchemaVersion)
+	require.Equal(t, since, r.PeriodStart)
+	require.Equal(t, now, r.PeriodEnd)
+	require.Equal(t, 3, r.Scans)
+	require.Equal(t, []PluginRate{
+		{PluginID: "http-header", Scans: 1, Attempts: 2, Errors: 2},
+		{PluginID: "ssh-weak-cipher", Scans: 2, Attempts: 6, Matches: 2, MatchRate: 2.0 / 6},
+	}, r.Plugins)
+	require.Equal(t, []ProtocolRate{
+		{Protocol: "http", Services: 1, Unknown: 1, UnknownRate: 1},
+		{Protocol: "ssh", Services: 2, Unknown: 1, UnknownRate: 0.5},
+		{Protocol: "tcp", Services: 1, Unknown: 1, UnknownRate: 1},
+	}, r.Fingerprints)
+	require.Equal(t, []Crash{{Module: "fingerprint-parser-instance", Scans: 1}}, r.Crashes)
+
+	// Nothing identifies the scanned networks
+	for _, s := range []string{"10.0.0", "acme", "s1", "team-a", "index out of range"} {
+		require.NotContains(t, jsonOf(t, r), s)
+	}
+
+	// Periods without scans report empty lists
+	r, err = Build(ctx, backend, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, r.Scans)
+	require.Contains(t, jsonOf(t, r), `"plugins":[],"fingerprints":[],"crashes":[]`)
+}
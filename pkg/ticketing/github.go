@@ -0,0 +1,124 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+const defaultGitHubAPI = "https://api.github.com"
+
+// githubTracker files findings as GitHub issues labelled "vulntor".
+type githubTracker struct {
+	api    *apiClient
+	repo   string // owner/name
+	labels []string
+}
+
+func newGitHubTracker(cfg config.TrackerConfig, client *http.Client) (*githubTracker, error) {
+	owner, name, ok := strings.Cut(cfg.Repository, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid repository: %q (must be owner/name)", cfg.Repository)
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	baseURL := cfg.URL
+	if baseURL == "" {
+		baseURL = defaultGitHubAPI
+	}
+	token, err := secrets.Resolve(context.Background(), cfg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+	return &githubTracker{
+		api: &apiClient{
+			http:    client,
+			baseURL: baseURL,
+			authorize: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+token)
+			},
+			headers: map[string]string{"X-GitHub-Api-Version": "2022-11-28"},
+		},
+		repo:   cfg.Repository,
+		labels: append([]string{ManagedLabel}, cfg.Labels...),
+	}, nil
+}
+
+type githubIssue struct {
+	Number      int    `json:"number"`
+	HTMLURL     string `json:"html_url"`
+	Body        string `json:"body"`
+	PullRequest any    `json:"pull_request,omitempty"`
+}
+
+// ListOpen pages through open issues labelled "vulntor".
+func (g *githubTracker) ListOpen(ctx context.Context) ([]Ticket, error) {
+	var tickets []Ticket
+	for page := 1; ; page++ {
+		q := url.Values{
+			"state":    {"open"},
+			"labels":   {ManagedLabel},
+			"per_page": {"100"},
+			"page":     {strconv.Itoa(page)},
+		}
+		var issues []githubIssue
+		if err := g.api.do(ctx, http.MethodGet, "/repos/"+g.repo+"/issues?"+q.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil {
+				continue
+			}
+			fp, target, ok := parseMarker(issue.Body)
+			if !ok {
+				continue
+			}
+			tickets = append(tickets, Ticket{
+				ID:          strconv.Itoa(issue.Number),
+				URL:         issue.HTMLURL,
+				Fingerprint: fp,
+				Target:      target,
+			})
+		}
+		if len(issues) < 100 {
+			return tickets, nil
+		}
+	}
+}
+
+// Create opens an issue for finding.
+func (g *githubTracker) Create(ctx context.Context, finding Finding, fingerprint string) (*Ticket, error) {
+	req := map[string]any{
+		"title":  title(finding),
+		"body":   description(finding, "<!-- "+marker(fingerprint, finding.Target)+" -->"),
+		"labels": g.labels,
+	}
+	var issue githubIssue
+	if err := g.api.do(ctx, http.MethodPost, "/repos/"+g.repo+"/issues", req, &issue); err != nil {
+		return nil, err
+	}
+	return &Ticket{
+		ID:          strconv.Itoa(issue.Number),
+		URL:         issue.HTMLURL,
+		Fingerprint: fingerprint,
+		Target:      finding.Target,
+	}, nil
+}
+
+// Resolve comments on the issue and closes it as completed.
+func (g *githubTracker) Resolve(ctx context.Context, ticket Ticket, scanID string) error {
+	path := "/repos/" + g.repo + "/issues/" + ticket.ID
+	comment := map[string]string{"body": fmt.Sprintf("Vulntor scan %s no longer reports this finding. Closing.", scanID)}
+	if err := g.api.do(ctx, http.MethodPost, path+"/comments", comment, nil); err != nil {
+		return err
+	}
+	return g.api.do(ctx, http.MethodPatch, path, map[string]string{"state": "closed", "state_reason": "completed"}, nil)
+}
@@ -0,0 +1,80 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func TestGitHubTracker(t *testing.T) {
+	var created, comment, patch map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/security/issues", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer ghp_test", r.Header.Get("Authorization"))
+		require.Equal(t, "vulntor", r.URL.Query().Get("labels"))
+		require.Equal(t, "open", r.URL.Query().Get("state"))
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"number": 12, "html_url": "https://github.com/acme/security/issues/12", "body": "text\n<!-- vulntor-fingerprint: 0123456789abcdef target: 10.0.0.5 -->"},
+			{"number": 13, "body": "opened by hand"},
+			{"number": 14, "body": "<!-- vulntor-fingerprint: fedcba9876543210 target: 10.0.0.6 -->", "pull_request": map[string]any{}},
+		})
+	})
+	mux.HandleFunc("POST /repos/acme/security/issues", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": 15, "html_url": "https://github.com/acme/security/issues/15"})
+	})
+	mux.HandleFunc("POST /repos/acme/security/issues/12/comments", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("PATCH /repos/acme/security/issues/12", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&patch))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tracker, err := newGitHubTracker(config.TrackerConfig{
+		URL: srv.URL, Repository: "acme/security", Token: "ghp_test", Labels: []string{"security"},
+	}, srv.Client())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	open, err := tracker.ListOpen(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Ticket{{ID: "12", URL: "https://github.com/acme/security/issues/12", Fingerprint: "0123456789abcdef", Target: "10.0.0.5"}}, open)
+
+	finding := Finding{Target: "10.0.0.6", Port: 443, Plugin: "tls-expired-cert", Severity: "critical", Message: "Certificate expired", CVE: []string{"CVE-2024-0001"}}
+	ticket, err := tracker.Create(ctx, finding, Fingerprint(finding))
+	require.NoError(t, err)
+	require.Equal(t, "15", ticket.ID)
+	require.Equal(t, "[CRITICAL] tls-expired-cert on 10.0.0.6:443", created["title"])
+	require.Equal(t, []any{"vulntor", "security"}, created["labels"])
+	fp, target, ok := parseMarker(created["body"].(string))
+	require.True(t, ok)
+	require.Equal(t, Fingerprint(finding), fp)
+	require.Equal(t, "10.0.0.6", target)
+	require.Contains(t, created["body"], "CVE-2024-0001")
+
+	require.NoError(t, tracker.Resolve(ctx, open[0], "scan-9"))
+	require.Contains(t, comment["body"], "scan-9")
+	require.Equal(t, map[string]any{"state": "closed", "state_reason": "completed"}, patch)
+}
+
+func TestGitHubTracker_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	tracker, err := newGitHubTracker(config.TrackerConfig{URL: srv.URL, Repository: "acme/security", Token: "bad"}, srv.Client())
+	require.NoError(t, err)
+	_, err = tracker.ListOpen(context.Background())
+	require.ErrorContains(t, err, "Bad credentials")
+}
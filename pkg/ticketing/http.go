@@ -0,0 +1,88 @@
+package ticketing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// apiClient sends JSON requests to a tracker's REST API.
+type apiClient struct {
+	http      *http.Client
+	baseURL   string
+	authorize func(*http.Request)
+	headers   map[string]string
+}
+
+// do sends a request with an optional JSON body and decodes a JSON response
+// into out (when non-nil). Non-2xx responses are returned as errors that
+// include the start of the response body.
+func (c *apiClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	c.authorize(req)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(snippet)))
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// description renders the ticket body for a finding, ending with markerLine.
+func description(f Finding, markerLine string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Vulntor found %s (severity: %s) on %s", f.Plugin, f.Severity, f.Target)
+	if f.Port > 0 {
+		fmt.Fprintf(&b, " port %d", f.Port)
+	}
+	b.WriteString(".\n\n")
+	if f.Message != "" {
+		b.WriteString(f.Message + "\n\n")
+	}
+	if f.Remediation != "" {
+		b.WriteString("Remediation: " + f.Remediation + "\n")
+	}
+	if len(f.CVE) > 0 {
+		b.WriteString("CVE: " + strings.Join(f.CVE, ", ") + "\n")
+	}
+	if f.Reference != "" {
+		b.WriteString("Reference: " + f.Reference + "\n")
+	}
+	b.WriteString("\n" + markerLine + "\n")
+	return b.String()
+}
@@ -0,0 +1,171 @@
+package ticketing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/secrets"
+)
+
+const defaultJiraIssueType = "Bug"
+
+// jiraTracker files findings as issues in a Jira project (REST API v2,
+// Jira Cloud and Data Center).
+type jiraTracker struct {
+	api       *apiClient
+	baseURL   string
+	project   string
+	issueType string
+	labels    []string
+}
+
+func newJiraTracker(cfg config.TrackerConfig, client *http.Client) (*jiraTracker, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url: %q (must be an http(s) URL)", cfg.URL)
+	}
+	if cfg.Project == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("token is required")
+	}
+
+	issueType := cfg.IssueType
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+	token, err := secrets.Resolve(context.Background(), cfg.Token)
+	if err != nil {
+		return nil, fmt.Errorf("token: %w", err)
+	}
+	username := cfg.Username
+	return &jiraTracker{
+		api: &apiClient{
+			http:    client,
+			baseURL: cfg.URL,
+			authorize: func(r *http.Request) {
+				// Jira Cloud uses email + API token; Data Center personal access tokens are bearer tokens
+				if username != "" {
+					r.SetBasicAuth(username, token)
+				} else {
+					r.Header.Set("Authorization", "Bearer "+token)
+				}
+			},
+		},
+		baseURL:   strings.TrimRight(cfg.URL, "/"),
+		project:   cfg.Project,
+		issueType: issueType,
+		labels:    append([]string{ManagedLabel}, cfg.Labels...),
+	}, nil
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Description string `json:"description"`
+	} `json:"fields"`
+}
+
+// ListOpen searches the project for unresolved issues labelled "vulntor".
+func (j *jiraTracker) ListOpen(ctx context.Context) ([]Ticket, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = %s AND statusCategory != Done`, j.project, ManagedLabel)
+
+	var tickets []Ticket
+	for startAt := 0; ; {
+		req := map[string]any{
+			"jql":        jql,
+			"fields":     []string{"description"},
+			"startAt":    startAt,
+			"maxResults": 100,
+		}
+		var resp struct {
+			Issues []jiraIssue `json:"issues"`
+			Total  int         `json:"total"`
+		}
+		if err := j.api.do(ctx, http.MethodPost, "/rest/api/2/search", req, &resp); err != nil {
+			return nil, err
+		}
+		for _, issue := range resp.Issues {
+			fp, target, ok := parseMarker(issue.Fields.Description)
+			if !ok {
+				continue
+			}
+			tickets = append(tickets, Ticket{
+				ID:          issue.Key,
+				URL:         j.baseURL + "/browse/" + issue.Key,
+				Fingerprint: fp,
+				Target:      target,
+			})
+		}
+		startAt += len(resp.Issues)
+		if len(resp.Issues) == 0 || startAt >= resp.Total {
+			return tickets, nil
+		}
+	}
+}
+
+// Create opens an issue for finding.
+func (j *jiraTracker) Create(ctx context.Context, finding Finding, fingerprint string) (*Ticket, error) {
+	req := map[string]any{
+		"fields": map[string]any{
+			"project":     map[string]string{"key": j.project},
+			"issuetype":   map[string]string{"name": j.issueType},
+			"summary":     title(finding),
+			"description": description(finding, marker(fingerprint, finding.Target)),
+			"labels":      j.labels,
+		},
+	}
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := j.api.do(ctx, http.MethodPost, "/rest/api/2/issue", req, &created); err != nil {
+		return nil, err
+	}
+	return &Ticket{
+		ID:          created.Key,
+		URL:         j.baseURL + "/browse/" + created.Key,
+		Fingerprint: fingerprint,
+		Target:      finding.Target,
+	}, nil
+}
+
+// Resolve comments on the issue and moves it through the first transition
+// leading to a done status.
+func (j *jiraTracker) Resolve(ctx context.Context, ticket Ticket, scanID string) error {
+	path := "/rest/api/2/issue/" + ticket.ID
+
+	var resp struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := j.api.do(ctx, http.MethodGet, path+"/transitions", nil, &resp); err != nil {
+		return err
+	}
+	transitionID := ""
+	for _, t := range resp.Transitions {
+		if t.To.StatusCategory.Key == "done" {
+			transitionID = t.ID
+			break
+		}
+	}
+	if transitionID == "" {
+		return fmt.Errorf("issue %s has no transition to a done status", ticket.ID)
+	}
+
+	comment := map[string]string{"body": fmt.Sprintf("Vulntor scan %s no longer reports this finding. Resolving.", scanID)}
+	if err := j.api.do(ctx, http.MethodPost, path+"/comment", comment, nil); err != nil {
+		return err
+	}
+	return j.api.do(ctx, http.MethodPost, path+"/transitions", map[string]any{"transition": map[string]string{"id": transitionID}}, nil)
+}
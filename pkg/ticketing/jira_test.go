@@ -0,0 +1,101 @@
+package ticketing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+func TestJiraTracker(t *testing.T) {
+	var search, created, comment, transition map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "bot@acme.com", user)
+		require.Equal(t, "jira-token", pass)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&search))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"total": 2,
+			"issues": []map[string]any{
+				{"key": "SEC-1", "fields": map[string]any{"description": "text\n\nvulntor-fingerprint: 0123456789abcdef target: 10.0.0.5\n"}},
+				{"key": "SEC-2", "fields": map[string]any{"description": "opened by hand"}},
+			},
+		})
+	})
+	mux.HandleFunc("POST /rest/api/2/issue", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"key": "SEC-3"})
+	})
+	mux.HandleFunc("GET /rest/api/2/issue/SEC-1/transitions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"transitions": []map[string]any{
+			{"id": "11", "to": map[string]any{"statusCategory": map[string]any{"key": "indeterminate"}}},
+			{"id": "31", "to": map[string]any{"statusCategory": map[string]any{"key": "done"}}},
+		}})
+	})
+	mux.HandleFunc("POST /rest/api/2/issue/SEC-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&comment))
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("POST /rest/api/2/issue/SEC-1/transitions", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&transition))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tracker, err := newJiraTracker(config.TrackerConfig{
+		URL: srv.URL, Project: "SEC", Username: "bot@acme.com", Token: "jira-token",
+	}, srv.Client())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	open, err := tracker.ListOpen(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Ticket{{ID: "SEC-1", URL: srv.URL + "/browse/SEC-1", Fingerprint: "0123456789abcdef", Target: "10.0.0.5"}}, open)
+	require.Equal(t, `project = "SEC" AND labels = vulntor AND statusCategory != Done`, search["jql"])
+
+	finding := Finding{Target: "10.0.0.6", Plugin: "smb-signing-disabled", Severity: "high", Remediation: "Require SMB signing"}
+	ticket, err := tracker.Create(ctx, finding, Fingerprint(finding))
+	require.NoError(t, err)
+	require.Equal(t, "SEC-3", ticket.ID)
+	fields := created["fields"].(map[string]any)
+	require.Equal(t, map[string]any{"key": "SEC"}, fields["project"])
+	require.Equal(t, map[string]any{"name": "Bug"}, fields["issuetype"])
+	require.Equal(t, "[HIGH] smb-signing-disabled on 10.0.0.6", fields["summary"])
+	require.Contains(t, fields["description"], "Remediation: Require SMB signing")
+
+	require.NoError(t, tracker.Resolve(ctx, open[0], "scan-9"))
+	require.Contains(t, comment["body"], "scan-9")
+	require.Equal(t, map[string]any{"transition": map[string]any{"id": "31"}}, transition)
+}
+
+func TestJiraTracker_BearerToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(map[string]any{"transitions": []map[string]any{}})
+	}))
+	defer srv.Close()
+
+	tracker, err := newJiraTracker(config.TrackerConfig{URL: srv.URL, Project: "SEC", Token: "pat"}, srv.Client())
+	require.NoError(t, err)
+	err = tracker.Resolve(context.Background(), Ticket{ID: "SEC-1"}, "scan-1")
+	require.ErrorContains(t, err, "no transition to a done status")
+
+	// Tokens may be secret references
+	t.Setenv("VULNTOR_TEST_JIRA_TOKEN", "pat")
+	tracker, err = newJiraTracker(config.TrackerConfig{URL: srv.URL, Project: "SEC", Token: "env:VULNTOR_TEST_JIRA_TOKEN"}, srv.Client())
+	require.NoError(t, err)
+	err = tracker.Resolve(context.Background(), Ticket{ID: "SEC-1"}, "scan-1")
+	require.ErrorContains(t, err, "no transition to a done status")
+
+	_, err = newJiraTracker(config.TrackerConfig{URL: srv.URL, Project: "SEC", Token: "env:VULNTOR_TEST_UNSET"}, srv.Client())
+	require.ErrorContains(t, err, "token: environment variable VULNTOR_TEST_UNSET is not set")
+}
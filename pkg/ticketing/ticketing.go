@@ -0,0 +1,257 @@
+// Package ticketing opens issue tracker tickets for scan findings and
+// resolves them once the findings are gone.
+//
+// Each finding is identified by a fingerprint derived from the plugin,
+// target and port that produced it. Tickets carry the fingerprint, so a
+// finding reported by many scans gets a single ticket. When auto-resolve is
+// enabled, a ticket is resolved once a successful scan covers its host
+// without reporting the finding.
+//
+// Supported trackers are Jira (REST API v2) and GitHub Issues.
+package ticketing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// Tracker types.
+const (
+	TypeJira   = "jira"
+	TypeGitHub = "github"
+)
+
+// ManagedLabel marks tickets created by Vulntor.
+const ManagedLabel = "vulntor"
+
+const defaultMinSeverity = "high"
+
+// Finding is a vulnerability reported by a scan.
+type Finding struct {
+	Target      string   `json:"target"`
+	Port        int      `json:"port,omitempty"`
+	Plugin      string   `json:"plugin"`
+	Severity    string   `json:"severity"`
+	Message     string   `json:"message"`
+	Remediation string   `json:"remediation,omitempty"`
+	CVE         []string `json:"cve,omitempty"`
+	Reference   string   `json:"reference,omitempty"`
+}
+
+// Fingerprint identifies a finding across scans: the same plugin matching
+// on the same target and port always yields the same fingerprint.
+func Fingerprint(f Finding) string {
+	sum := sha256.Sum256([]byte(f.Plugin + "|" + f.Target + "|" + strconv.Itoa(f.Port)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Ticket is an open ticket created for a finding.
+type Ticket struct {
+	ID          string // Jira issue key or GitHub issue number
+	URL         string
+	Fingerprint string
+	Target      string
+}
+
+// Tracker is an issue tracker holding finding tickets.
+type Tracker interface {
+	// ListOpen returns the open tickets created by Vulntor.
+	ListOpen(ctx context.Context) ([]Ticket, error)
+
+	// Create opens a ticket for finding.
+	Create(ctx context.Context, finding Finding, fingerprint string) (*Ticket, error)
+
+	// Resolve closes ticket with a comment that the finding is gone.
+	Resolve(ctx context.Context, ticket Ticket, scanID string) error
+}
+
+// markerRe matches the marker line embedded in ticket bodies.
+var markerRe = regexp.MustCompile(`vulntor-fingerprint: ([0-9a-f]+) target: (\S+)`)
+
+// marker returns the line identifying a finding in a ticket body.
+func marker(fingerprint, target string) string {
+	return fmt.Sprintf("vulntor-fingerprint: %s target: %s", fingerprint, target)
+}
+
+// parseMarker extracts the fingerprint and target from a ticket body.
+func parseMarker(body string) (fingerprint, target string, ok bool) {
+	m := markerRe.FindStringSubmatch(body)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// title returns the ticket summary for a finding.
+func title(f Finding) string {
+	location := f.Target
+	if f.Port > 0 {
+		location += ":" + strconv.Itoa(f.Port)
+	}
+	return fmt.Sprintf("[%s] %s on %s", strings.ToUpper(f.Severity), f.Plugin, location)
+}
+
+// Scan identifies a finished scan and the hosts it covered.
+type Scan struct {
+	ID string
+
+	// Hosts are the hosts the scan examined. Tickets for other hosts are
+	// never resolved by this scan.
+	Hosts []string
+}
+
+// Result counts the ticket changes made for one tracker.
+type Result struct {
+	Created  int
+	Existing int
+	Resolved int
+	Errors   int
+}
+
+// syncTarget is a tracker with its policy.
+type syncTarget struct {
+	name        string
+	tracker     Tracker
+	minSeverity int
+	autoResolve bool
+}
+
+// Syncer keeps trackers in line with scan findings. A nil *Syncer does nothing.
+type Syncer struct {
+	targets []*syncTarget
+}
+
+// New builds a syncer from cfg. Returns nil (and no error) when no trackers
+// are configured.
+func New(cfg config.TicketingConfig) (*Syncer, error) {
+	if len(cfg.Trackers) == 0 {
+		return nil, nil
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	s := &Syncer{}
+	for i, tc := range cfg.Trackers {
+		var (
+			tracker Tracker
+			err     error
+		)
+		switch strings.ToLower(tc.Type) {
+		case TypeJira:
+			tracker, err = newJiraTracker(tc, client)
+		case TypeGitHub:
+			tracker, err = newGitHubTracker(tc, client)
+		default:
+			err = fmt.Errorf("unknown type: %q (must be jira or github)", tc.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ticketing.trackers[%d]: %w", i, err)
+		}
+
+		minSeverity := strings.ToLower(strings.TrimSpace(tc.MinSeverity))
+		if minSeverity == "" {
+			minSeverity = defaultMinSeverity
+		}
+		rank := severity.Rank(minSeverity)
+		if rank == 0 {
+			return nil, fmt.Errorf("ticketing.trackers[%d]: invalid min_severity: %q (must be info, low, medium, high or critical)", i, tc.MinSeverity)
+		}
+
+		name := tc.Name
+		if name == "" {
+			name = strings.ToLower(tc.Type)
+		}
+		s.targets = append(s.targets, &syncTarget{name: name, tracker: tracker, minSeverity: rank, autoResolve: tc.AutoResolve})
+	}
+	return s, nil
+}
+
+// Sync opens tickets for new findings of scan and, for trackers with
+// auto-resolve, resolves tickets of covered hosts whose finding is gone.
+// Tracker errors are logged; Sync never fails the scan.
+func (s *Syncer) Sync(ctx context.Context, scan Scan, findings []Finding) {
+	if s == nil {
+		return
+	}
+	for _, t := range s.targets {
+		res := syncTracker(ctx, t, scan, findings)
+		log.Info().
+			Str("component", "ticketing").
+			Str("tracker", t.name).
+			Str("scan_id", scan.ID).
+			Int("created", res.Created).
+			Int("existing", res.Existing).
+			Int("resolved", res.Resolved).
+			Int("errors", res.Errors).
+			Msg("Tickets synchronized")
+	}
+}
+
+func syncTracker(ctx context.Context, t *syncTarget, scan Scan, findings []Finding) Result {
+	var res Result
+
+	open, err := t.tracker.ListOpen(ctx)
+	if err != nil {
+		log.Warn().Str("component", "ticketing").Str("tracker", t.name).Err(err).Msg("Failed to list open tickets")
+		res.Errors++
+		return res
+	}
+	byFingerprint := make(map[string]Ticket, len(open))
+	for _, ticket := range open {
+		byFingerprint[ticket.Fingerprint] = ticket
+	}
+
+	current := make(map[string]bool)
+	for _, f := range findings {
+		if severity.Rank(strings.ToLower(f.Severity)) < t.minSeverity {
+			continue
+		}
+		fp := Fingerprint(f)
+		current[fp] = true
+		if _, ok := byFingerprint[fp]; ok {
+			res.Existing++
+			continue
+		}
+
+		ticket, err := t.tracker.Create(ctx, f, fp)
+		if err != nil {
+			log.Warn().Str("component", "ticketing").Str("tracker", t.name).Str("plugin", f.Plugin).Err(err).Msg("Failed to create ticket")
+			res.Errors++
+			continue
+		}
+		byFingerprint[fp] = *ticket
+		res.Created++
+	}
+
+	if !t.autoResolve {
+		return res
+	}
+
+	covered := make(map[string]bool, len(scan.Hosts))
+	for _, h := range scan.Hosts {
+		covered[h] = true
+	}
+	for _, ticket := range open {
+		if current[ticket.Fingerprint] || !covered[ticket.Target] {
+			continue
+		}
+		if err := t.tracker.Resolve(ctx, ticket, scan.ID); err != nil {
+			log.Warn().Str("component", "ticketing").Str("tracker", t.name).Str("ticket", ticket.ID).Err(err).Msg("Failed to resolve ticket")
+			res.Errors++
+			continue
+		}
+		res.Resolved++
+	}
+	return res
+}
@@ -0,0 +1,139 @@
+package ticketing
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/severity"
+)
+
+// fakeTracker keeps tickets in memory.
+type fakeTracker struct {
+	open      []Ticket
+	created   []Finding
+	resolved  []string
+	createErr error
+}
+
+func (f *fakeTracker) ListOpen(ctx context.Context) ([]Ticket, error) {
+	return append([]Ticket(nil), f.open...), nil
+}
+
+func (f *fakeTracker) Create(ctx context.Context, finding Finding, fingerprint string) (*Ticket, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.created = append(f.created, finding)
+	t := Ticket{ID: strconv.Itoa(len(f.open) + 1), Fingerprint: fingerprint, Target: finding.Target}
+	f.open = append(f.open, t)
+	return &t, nil
+}
+
+func (f *fakeTracker) Resolve(ctx context.Context, ticket Ticket, scanID string) error {
+	f.resolved = append(f.resolved, ticket.ID)
+	return nil
+}
+
+func TestFingerprint(t *testing.T) {
+	f := Finding{Target: "10.0.0.5", Port: 22, Plugin: "ssh-weak-cipher", Severity: "high", Message: "Weak cipher"}
+	fp := Fingerprint(f)
+	require.Len(t, fp, 16)
+
+	// Message and severity changes keep the fingerprint; location changes do not
+	same := f
+	same.Message, same.Severity = "Updated wording", "critical"
+	require.Equal(t, fp, Fingerprint(same))
+
+	other := f
+	other.Port = 2222
+	require.NotEqual(t, fp, Fingerprint(other))
+}
+
+func TestMarker(t *testing.T) {
+	fp, target, ok := parseMarker("Body text\n\n<!-- " + marker("0123456789abcdef", "10.0.0.5") + " -->\n")
+	require.True(t, ok)
+	require.Equal(t, "0123456789abcdef", fp)
+	require.Equal(t, "10.0.0.5", target)
+
+	_, _, ok = parseMarker("hand-written ticket")
+	require.False(t, ok)
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(config.TicketingConfig{})
+	require.NoError(t, err)
+	require.Nil(t, s)
+
+	s, err = New(config.TicketingConfig{Trackers: []config.TrackerConfig{
+		{Type: "github", Repository: "acme/security", Token: "ghp_x"},
+		{Type: "JIRA", URL: "https://acme.atlassian.net", Project: "SEC", Username: "bot@acme.com", Token: "x", MinSeverity: "critical"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, s.targets, 2)
+	require.Equal(t, "github", s.targets[0].name)
+	require.Equal(t, severity.Rank("high"), s.targets[0].minSeverity)
+	require.Equal(t, severity.Rank("critical"), s.targets[1].minSeverity)
+
+	for _, bad := range []config.TrackerConfig{
+		{Type: "servicenow"},
+		{Type: "github", Repository: "security", Token: "x"},
+		{Type: "github", Repository: "acme/security"},
+		{Type: "jira", URL: "acme.atlassian.net", Project: "SEC", Token: "x"},
+		{Type: "jira", URL: "https://acme.atlassian.net", Token: "x"},
+		{Type: "github", Repository: "acme/security", Token: "x", MinSeverity: "severe"},
+	} {
+		_, err := New(config.TicketingConfig{Trackers: []config.TrackerConfig{bad}})
+		require.Error(t, err, "%+v", bad)
+	}
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	weak := Finding{Target: "10.0.0.5", Port: 22, Plugin: "ssh-weak-cipher", Severity: "high"}
+	banner := Finding{Target: "10.0.0.5", Port: 80, Plugin: "http-server-banner", Severity: "info"}
+	tls := Finding{Target: "10.0.0.6", Port: 443, Plugin: "tls-expired-cert", Severity: "CRITICAL"}
+
+	tracker := &fakeTracker{}
+	s := &Syncer{targets: []*syncTarget{{name: "fake", tracker: tracker, minSeverity: severity.Rank("high"), autoResolve: true}}}
+
+	// Findings below the threshold are not ticketed; duplicates within a scan get one ticket
+	s.Sync(ctx, Scan{ID: "scan-1", Hosts: []string{"10.0.0.5", "10.0.0.6"}}, []Finding{weak, banner, tls, weak})
+	require.Equal(t, []Finding{weak, tls}, tracker.created)
+
+	// A rescan reporting the same findings does not create duplicates
+	s.Sync(ctx, Scan{ID: "scan-2", Hosts: []string{"10.0.0.5", "10.0.0.6"}}, []Finding{weak, tls})
+	require.Len(t, tracker.created, 2)
+	require.Empty(t, tracker.resolved)
+
+	// The weak cipher is fixed on a rescanned host; the other host was not scanned
+	s.Sync(ctx, Scan{ID: "scan-3", Hosts: []string{"10.0.0.5"}}, nil)
+	require.Equal(t, []string{"1"}, tracker.resolved)
+}
+
+func TestSync_NoAutoResolve(t *testing.T) {
+	tracker := &fakeTracker{open: []Ticket{{ID: "7", Fingerprint: "0123456789abcdef", Target: "10.0.0.5"}}}
+	s := &Syncer{targets: []*syncTarget{{name: "fake", tracker: tracker, minSeverity: severity.Rank("high")}}}
+
+	s.Sync(context.Background(), Scan{ID: "scan-1", Hosts: []string{"10.0.0.5"}}, nil)
+	require.Empty(t, tracker.resolved)
+}
+
+func TestSync_CreateErrorsDoNotStopOthers(t *testing.T) {
+	failing := &fakeTracker{createErr: errors.New("403 Forbidden")}
+	working := &fakeTracker{}
+	s := &Syncer{targets: []*syncTarget{
+		{name: "failing", tracker: failing, minSeverity: 1},
+		{name: "working", tracker: working, minSeverity: 1},
+	}}
+
+	s.Sync(context.Background(), Scan{ID: "scan-1"}, []Finding{{Target: "10.0.0.5", Plugin: "p", Severity: "low"}})
+	require.Len(t, working.created, 1)
+
+	var nilSyncer *Syncer
+	nilSyncer.Sync(context.Background(), Scan{ID: "scan-1"}, nil)
+}
@@ -0,0 +1,22 @@
+// Package timeutil provides helpers for parsing times given on the command
+// line and in configuration files.
+package timeutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseTime parses an RFC 3339 time or a YYYY-MM-DD date, reporting which.
+// Dates start at midnight in the local time zone.
+func ParseTime(s string) (t time.Time, date bool, err error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid time %q (want an RFC 3339 time or YYYY-MM-DD)", s)
+}
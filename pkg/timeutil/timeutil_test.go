@@ -0,0 +1,23 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	got, date, err := ParseTime("2026-12-01T08:00:00Z")
+	require.NoError(t, err)
+	require.False(t, date)
+	require.Equal(t, time.Date(2026, 12, 1, 8, 0, 0, 0, time.UTC), got)
+
+	got, date, err = ParseTime(" 2026-12-01 ")
+	require.NoError(t, err)
+	require.True(t, date)
+	require.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.Local), got)
+
+	_, _, err = ParseTime("12/01/2026")
+	require.ErrorContains(t, err, "invalid time")
+}
@@ -0,0 +1,311 @@
+// Package topology builds a service relationship graph from the hosts of a
+// scan. Hosts are linked to the names their TLS certificates cover and to
+// the targets of their HTTP redirects; names known to belong to another
+// scanned host, through its reverse DNS or host names, link the two hosts.
+package topology
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+// Node kinds.
+const (
+	KindHost     = "host"     // a scanned host, identified by its IP
+	KindExternal = "external" // a name no scanned host is known by
+)
+
+// Edge relations.
+const (
+	RelationTLSName  = "tls-name" // the certificate served on Port covers Name
+	RelationRedirect = "redirect" // HTTP requests to Port are redirected to Name
+)
+
+// Node is a scanned host or an external name.
+type Node struct {
+	ID    string   `json:"id"` // IP of a host, or the external name
+	Kind  string   `json:"kind"`
+	Names []string `json:"names,omitempty"` // host names and reverse DNS of a host
+	Ports []int    `json:"ports,omitempty"` // open ports of a host
+}
+
+// Edge links a host to a node it refers to through one of its ports.
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Port     int    `json:"port"`
+	Name     string `json:"name"` // certificate name or redirect host behind the edge
+}
+
+// Graph is the service relationship graph of a scan.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Options configure Build.
+type Options struct {
+	// LookupAddr returns the reverse DNS names of an IP, e.g.
+	// net.DefaultResolver.LookupAddr; nil to only use the host names
+	// recorded by the scan. Failed lookups are ignored.
+	LookupAddr func(ctx context.Context, addr string) ([]string, error)
+}
+
+// Build correlates the certificates, redirects and names of hosts into a
+// graph. A name is attributed to the hosts known by it; failing that, to
+// the other hosts whose certificates cover it. Redirects to names of no
+// other host lead to an external node; certificate names of no other host
+// are the host's own and produce no edge.
+func Build(ctx context.Context, hosts []storage.HostRecord, opts Options) *Graph {
+	g := &Graph{Nodes: []Node{}, Edges: []Edge{}}
+	nodes := make(map[string]*Node)
+	var order []string
+	for _, h := range hosts {
+		n, ok := nodes[h.IP]
+		if !ok {
+			n = &Node{ID: h.IP, Kind: KindHost}
+			nodes[h.IP] = n
+			order = append(order, h.IP)
+		}
+		for _, name := range h.Hostnames {
+			n.Names = appendName(n.Names, name)
+		}
+		for _, p := range h.Ports {
+			if !slices.Contains(n.Ports, p.Port) {
+				n.Ports = append(n.Ports, p.Port)
+			}
+		}
+	}
+	if opts.LookupAddr != nil {
+		for _, ip := range order {
+			if ctx.Err() != nil {
+				break
+			}
+			names, err := opts.LookupAddr(ctx, ip)
+			if err != nil {
+				continue
+			}
+			for _, name := range names {
+				nodes[ip].Names = appendName(nodes[ip].Names, name)
+			}
+		}
+	}
+
+	// Names hosts are known by, and names their certificates cover
+	known := make(map[string][]string)
+	covered := make(map[string][]string)
+	serves := make(map[string][]string)
+	for _, ip := range order {
+		for _, name := range nodes[ip].Names {
+			known[name] = appendName(known[name], ip)
+		}
+	}
+	for _, h := range hosts {
+		for _, p := range h.Ports {
+			for _, name := range certificateNames(p.Certificate) {
+				covered[name] = appendName(covered[name], h.IP)
+				serves[h.IP] = appendName(serves[h.IP], name)
+			}
+		}
+	}
+
+	owners := func(name, from string) []string {
+		var ips []string
+		for known, knownBy := range known {
+			if matchName(name, known) {
+				ips = append(ips, knownBy...)
+			}
+		}
+		if len(ips) == 0 {
+			ips = covered[name]
+		}
+		var others []string
+		for _, ip := range ips {
+			if ip != from && !slices.Contains(others, ip) {
+				others = append(others, ip)
+			}
+		}
+		sort.Strings(others)
+		return others
+	}
+
+	edges := make(map[Edge]bool)
+	link := func(from, relation string, port int, name string) {
+		// A host referring to one of its own names is no relationship, nor
+		// is a redirect to a name its certificates cover (e.g. to HTTPS)
+		own := func(names []string) bool {
+			return slices.ContainsFunc(names, func(own string) bool { return matchName(name, own) })
+		}
+		if name == from || own(nodes[from].Names) || (relation == RelationRedirect && own(serves[from])) {
+			return
+		}
+		targets := owners(name, from)
+		if len(targets) == 0 {
+			if relation == RelationTLSName {
+				return // Only its own certificates cover the name
+			}
+			if _, ok := nodes[name]; !ok {
+				nodes[name] = &Node{ID: name, Kind: KindExternal}
+				order = append(order, name)
+			}
+			targets = []string{name}
+		}
+		for _, to := range targets {
+			edges[Edge{From: from, To: to, Relation: relation, Port: port, Name: name}] = true
+		}
+	}
+	for _, h := range hosts {
+		for _, p := range h.Ports {
+			for _, name := range certificateNames(p.Certificate) {
+				link(h.IP, RelationTLSName, p.Port, name)
+			}
+			if name := redirectHost(p.Redirect); name != "" {
+				link(h.IP, RelationRedirect, p.Port, name)
+			}
+		}
+	}
+
+	for _, id := range order {
+		n := nodes[id]
+		sort.Ints(n.Ports)
+		sort.Strings(n.Names)
+		g.Nodes = append(g.Nodes, *n)
+	}
+	sort.SliceStable(g.Nodes, func(i, j int) bool {
+		if g.Nodes[i].Kind != g.Nodes[j].Kind {
+			return g.Nodes[i].Kind == KindHost
+		}
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	for e := range edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Relation != b.Relation {
+			return a.Relation < b.Relation
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.To < b.To
+	})
+	return g
+}
+
+// WriteJSON writes g as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
+
+// WriteDOT writes g in the Graphviz DOT language, e.g. for
+// `dot -Tsvg`. Hosts are boxes labeled with their names, external names
+// ellipses.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph topology {\n  rankdir=LR;\n  node [fontname=\"Helvetica\"];\n")
+	for _, n := range g.Nodes {
+		if n.Kind == KindHost {
+			label := strings.Join(append([]string{n.ID}, n.Names...), "\n")
+			fmt.Fprintf(&sb, "  %s [shape=box, label=%s];\n", dotQuote(n.ID), dotQuote(label))
+		} else {
+			fmt.Fprintf(&sb, "  %s [shape=ellipse, style=dashed];\n", dotQuote(n.ID))
+		}
+	}
+	for _, e := range g.Edges {
+		label := e.Relation + " :" + strconv.Itoa(e.Port)
+		if e.Name != e.To {
+			label += "\n" + e.Name
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(label))
+	}
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// dotQuote returns s as a DOT string, with newlines as line breaks.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + strings.ReplaceAll(s, "\n", `\n`) + `"`
+}
+
+// certificateNames returns the normalized names a certificate covers.
+func certificateNames(c *storage.CertificateRecord) []string {
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for _, name := range append([]string{c.Subject}, c.DNSNames...) {
+		// Common names are not always host names
+		if strings.Contains(name, ".") && !strings.Contains(name, " ") {
+			names = appendName(names, name)
+		}
+	}
+	return names
+}
+
+// redirectHost returns the host a redirect Location points to, or "" for
+// a relative Location.
+func redirectHost(location string) string {
+	u, err := url.Parse(location)
+	if err != nil {
+		return ""
+	}
+	return normalizeName(u.Hostname())
+}
+
+// appendName appends the normalized name to names unless already present.
+func appendName(names []string, name string) []string {
+	name = normalizeName(name)
+	if name == "" || slices.Contains(names, name) {
+		return names
+	}
+	return append(names, name)
+}
+
+// normalizeName lowercases a host name and strips its trailing dot.
+func normalizeName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	if ip := net.ParseIP(name); ip != nil {
+		return ip.String()
+	}
+	return name
+}
+
+// matchName reports whether pattern, a name or a wildcard name such as
+// '*.example.com', covers name.
+func matchName(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		label, rest, found := strings.Cut(name, ".")
+		return found && label != "" && rest == suffix
+	}
+	if suffix, ok := strings.CutPrefix(name, "*."); ok {
+		label, rest, found := strings.Cut(pattern, ".")
+		return found && label != "" && rest == suffix
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package topology
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/storage"
+)
+
+func testHosts() []storage.HostRecord {
+	return []storage.HostRecord{
+		{
+			IP: "10.0.0.1",
+			Ports: []storage.ServiceRecord{
+				// Redirects to its own HTTPS site are no relationship
+				{Port: 80, Redirect: "https://www.example.com/"},
+				{Port: 443, Certificate: &storage.CertificateRecord{Subject: "www.example.com", DNSNames: []string{"www.example.com", "*.cdn.example.com"}}},
+				{Port: 8080, Redirect: "https://sso.example.com/login"},
+			},
+		},
+		{
+			IP:        "10.0.0.2",
+			Hostnames: []string{"SSO.example.com."},
+			Ports: []storage.ServiceRecord{
+				{Port: 80, Redirect: "https://login.example.net/sso"},
+				{Port: 443, Redirect: "/login"},
+			},
+		},
+		{
+			IP: "10.0.0.3",
+			Ports: []storage.ServiceRecord{
+				{Port: 443, Certificate: &storage.CertificateRecord{Subject: "Plesk", DNSNames: []string{"www.example.com", "api.example.com"}}},
+				{Port: 8443, Redirect: "https://10.0.0.1/"},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	var lookups []string
+	g := Build(context.Background(), testHosts(), Options{LookupAddr: func(_ context.Context, addr string) ([]string, error) {
+		lookups = append(lookups, addr)
+		if addr == "10.0.0.1" {
+			return []string{"web-1.cdn.example.com."}, nil
+		}
+		return nil, errors.New("no PTR record")
+	}})
+	require.Equal(t, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}, lookups)
+
+	require.Equal(t, []Node{
+		{ID: "10.0.0.1", Kind: KindHost, Names: []string{"web-1.cdn.example.com"}, Ports: []int{80, 443, 8080}},
+		{ID: "10.0.0.2", Kind: KindHost, Names: []string{"sso.example.com"}, Ports: []int{80, 443}},
+		{ID: "10.0.0.3", Kind: KindHost, Ports: []int{443, 8443}},
+		{ID: "login.example.net", Kind: KindExternal},
+	}, g.Nodes)
+	// Certificates shared by hosts link them both ways; names only the
+	// certificates of one host cover are its own
+	require.Equal(t, []Edge{
+		{From: "10.0.0.1", To: "10.0.0.3", Relation: RelationTLSName, Port: 443, Name: "www.example.com"},
+		{From: "10.0.0.1", To: "10.0.0.2", Relation: RelationRedirect, Port: 8080, Name: "sso.example.com"},
+		{From: "10.0.0.2", To: "login.example.net", Relation: RelationRedirect, Port: 80, Name: "login.example.net"},
+		{From: "10.0.0.3", To: "10.0.0.1", Relation: RelationTLSName, Port: 443, Name: "www.example.com"},
+		{From: "10.0.0.3", To: "10.0.0.1", Relation: RelationRedirect, Port: 8443, Name: "10.0.0.1"},
+	}, g.Edges)
+}
+
+func TestBuild_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Build(context.Background(), nil, Options{}).WriteJSON(&buf))
+	require.JSONEq(t, `{"nodes": [], "edges": []}`, buf.String())
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := Build(context.Background(), testHosts(), Options{})
+	var buf bytes.Buffer
+	require.NoError(t, g.WriteDOT(&buf))
+	dot := buf.String()
+	require.Contains(t, dot, "digraph topology {\n")
+	require.Contains(t, dot, `  "10.0.0.2" [shape=box, label="10.0.0.2\nsso.example.com"];`)
+	require.Contains(t, dot, `  "login.example.net" [shape=ellipse, style=dashed];`)
+	require.Contains(t, dot, `  "10.0.0.2" -> "login.example.net" [label="redirect :80"];`)
+	require.Contains(t, dot, `  "10.0.0.1" -> "10.0.0.2" [label="redirect :8080\nsso.example.com"];`)
+	require.Contains(t, dot, `  "10.0.0.3" -> "10.0.0.1" [label="redirect :8443"];`)
+	require.Equal(t, "}\n", dot[len(dot)-2:])
+}
+
+func TestMatchName(t *testing.T) {
+	require.True(t, matchName("*.example.com", "www.example.com"))
+	require.True(t, matchName("www.example.com", "*.example.com"))
+	require.False(t, matchName("*.example.com", "a.b.example.com"))
+	require.False(t, matchName("*.example.com", "example.com"))
+	require.False(t, matchName("www.example.com", "api.example.com"))
+}
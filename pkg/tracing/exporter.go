@@ -0,0 +1,325 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/version"
+)
+
+const (
+	// scopeName identifies the instrumentation in exported spans.
+	scopeName = "github.com/vulntor/vulntor"
+
+	defaultBatchSize     = 512
+	defaultMaxQueueSize  = 2048
+	defaultFlushInterval = 5 * time.Second
+	exportTimeout        = 10 * time.Second
+)
+
+// Provider batches finished spans and exports them to an OTLP/HTTP
+// collector. Create one with NewProvider or Setup.
+type Provider struct {
+	url         string
+	headers     map[string]string
+	client      *http.Client
+	serviceName string
+	ratio       float64
+
+	batchSize     int
+	maxQueueSize  int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	queue   []*Span
+	dropped int
+
+	flushCh  chan struct{}
+	stopCh   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewProvider creates a provider exporting to the collector described by
+// cfg and starts its background export loop. Call Shutdown to flush.
+func NewProvider(cfg config.TracingConfig) *Provider {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "vulntor"
+	}
+
+	p := &Provider{
+		url:           cfg.TracesURL(),
+		headers:       cfg.Headers,
+		client:        &http.Client{Timeout: exportTimeout},
+		serviceName:   serviceName,
+		ratio:         cfg.SampleRatio,
+		batchSize:     defaultBatchSize,
+		maxQueueSize:  defaultMaxQueueSize,
+		flushInterval: defaultFlushInterval,
+		flushCh:       make(chan struct{}, 1),
+		stopCh:        make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// Setup installs a global provider when cfg.Enabled is set. The returned
+// shutdown function flushes pending spans and uninstalls the provider; it
+// is safe to call when tracing is disabled.
+func Setup(cfg config.TracingConfig) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("tracing config: %w", err)
+	}
+
+	p := NewProvider(cfg)
+	SetProvider(p)
+
+	log.Info().
+		Str("component", "tracing").
+		Str("endpoint", p.url).
+		Float64("sample_ratio", cfg.SampleRatio).
+		Msg("Tracing enabled")
+
+	return func(ctx context.Context) error {
+		// Only uninstall if no other provider replaced ours meanwhile
+		global.CompareAndSwap(p, nil)
+		return p.Shutdown(ctx)
+	}, nil
+}
+
+// enqueue adds a finished span to the export queue. Spans are dropped when
+// the queue is full so that a slow collector never blocks a scan.
+func (p *Provider) enqueue(s *Span) {
+	p.mu.Lock()
+	if len(p.queue) >= p.maxQueueSize {
+		p.dropped++
+		p.mu.Unlock()
+		return
+	}
+	p.queue = append(p.queue, s)
+	full := len(p.queue) >= p.batchSize
+	p.mu.Unlock()
+
+	if full {
+		select {
+		case p.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (p *Provider) run() {
+	defer close(p.stopped)
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.flush(context.Background())
+		case <-p.flushCh:
+			p.flush(context.Background())
+		case <-p.stopCh:
+			return
+		}
+	}
+}
+
+// ForceFlush exports all queued spans.
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	return p.flush(ctx)
+}
+
+// Shutdown stops the export loop and flushes queued spans.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+
+	select {
+	case <-p.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return p.flush(ctx)
+}
+
+func (p *Provider) flush(ctx context.Context) error {
+	p.mu.Lock()
+	batch := p.queue
+	p.queue = nil
+	dropped := p.dropped
+	p.dropped = 0
+	p.mu.Unlock()
+
+	if dropped > 0 {
+		log.Warn().
+			Str("component", "tracing").
+			Int("dropped", dropped).
+			Msg("Trace export queue full, spans dropped")
+	}
+
+	for len(batch) > 0 {
+		n := min(len(batch), p.batchSize)
+		if err := p.export(ctx, batch[:n]); err != nil {
+			log.Warn().
+				Str("component", "tracing").
+				Err(err).
+				Int("spans", n).
+				Msg("Trace export failed")
+			return err
+		}
+		batch = batch[n:]
+	}
+	return nil
+}
+
+// export sends spans as an OTLP ExportTraceServiceRequest (JSON encoding).
+func (p *Provider) export(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(p.encode(spans))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// OTLP/JSON wire types. Only the fields Vulntor emits are modelled.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		Name              string         `json:"name"`
+		Kind              SpanKind       `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            *otlpStatus    `json:"status,omitempty"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+// otlpStatusError is STATUS_CODE_ERROR.
+const otlpStatusError = 2
+
+func (p *Provider) encode(spans []*Span) otlpRequest {
+	out := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           s.sc.TraceIDString(),
+			SpanID:            s.sc.SpanIDString(),
+			Name:              s.name,
+			Kind:              s.kind,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        encodeAttrs(s.attrs),
+		}
+		if s.parentID != [8]byte{} {
+			span.ParentSpanID = SpanContext{SpanID: s.parentID}.SpanIDString()
+		}
+		if s.hasError {
+			span.Status = &otlpStatus{Code: otlpStatusError, Message: s.errMsg}
+		}
+		s.mu.Unlock()
+		out = append(out, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: encodeAttrs([]Attr{
+			String("service.name", p.serviceName),
+			String("service.version", version.GetVersion().Version),
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: scopeName, Version: version.GetVersion().Version},
+			Spans: out,
+		}},
+	}}}
+}
+
+func encodeAttrs(attrs []Attr) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]otlpKeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var v map[string]any
+		switch val := a.Value.(type) {
+		case string:
+			v = map[string]any{"stringValue": val}
+		case int64:
+			// OTLP/JSON encodes 64-bit integers as strings
+			v = map[string]any{"intValue": strconv.FormatInt(val, 10)}
+		case bool:
+			v = map[string]any{"boolValue": val}
+		case float64:
+			v = map[string]any{"doubleValue": val}
+		default:
+			v = map[string]any{"stringValue": fmt.Sprint(val)}
+		}
+		out = append(out, otlpKeyValue{Key: a.Key, Value: v})
+	}
+	return out
+}
@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// fakeCollector records OTLP/JSON export requests.
+type fakeCollector struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []otlpRequest
+	headers  []http.Header
+}
+
+func newFakeCollector(t *testing.T) *fakeCollector {
+	t.Helper()
+	c := &fakeCollector{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/traces", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var req otlpRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		c.mu.Lock()
+		c.requests = append(c.requests, req)
+		c.headers = append(c.headers, r.Header.Clone())
+		c.mu.Unlock()
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *fakeCollector) spans() []otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []otlpSpan
+	for _, r := range c.requests {
+		for _, rs := range r.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				out = append(out, ss.Spans...)
+			}
+		}
+	}
+	return out
+}
+
+func TestSetup_Disabled(t *testing.T) {
+	shutdown, err := Setup(config.TracingConfig{Enabled: false})
+	require.NoError(t, err)
+	require.False(t, Enabled())
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_InvalidConfig(t *testing.T) {
+	_, err := Setup(config.TracingConfig{Enabled: true, SampleRatio: 2})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tracing config")
+	require.False(t, Enabled())
+}
+
+func TestSetup_ExportsOnShutdown(t *testing.T) {
+	collector := newFakeCollector(t)
+
+	shutdown, err := Setup(config.TracingConfig{
+		Enabled:     true,
+		Endpoint:    collector.URL,
+		ServiceName: "vulntor-test",
+		SampleRatio: 1,
+		Headers:     map[string]string{"Authorization": "Bearer otlp-token"},
+	})
+	require.NoError(t, err)
+	require.True(t, Enabled())
+
+	ctx, root := Start(context.Background(), "scan.run", String("scan.id", "s1"), Int("targets", 2), Bool("dry_run", false))
+	_, child := Start(ctx, "module.execute", Float("ratio", 0.5))
+	child.RecordError(errors.New("module failed"))
+	child.End()
+	root.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	require.False(t, Enabled())
+
+	spans := collector.spans()
+	require.Len(t, spans, 2)
+	require.Equal(t, "Bearer otlp-token", collector.headers[0].Get("Authorization"))
+
+	res := collector.requests[0].ResourceSpans[0]
+	require.Equal(t, otlpKeyValue{Key: "service.name", Value: map[string]any{"stringValue": "vulntor-test"}}, res.Resource.Attributes[0])
+	require.Equal(t, scopeName, res.ScopeSpans[0].Scope.Name)
+
+	mod, run := spans[0], spans[1]
+	require.Equal(t, "module.execute", mod.Name)
+	require.Equal(t, run.TraceID, mod.TraceID)
+	require.Equal(t, run.SpanID, mod.ParentSpanID)
+	require.Empty(t, run.ParentSpanID)
+	require.Equal(t, KindInternal, run.Kind)
+	require.Len(t, run.TraceID, 32)
+	require.Len(t, run.SpanID, 16)
+	require.NotEmpty(t, run.StartTimeUnixNano)
+	require.Equal(t, &otlpStatus{Code: otlpStatusError, Message: "module failed"}, mod.Status)
+	require.Nil(t, run.Status)
+
+	require.Equal(t, []otlpKeyValue{
+		{Key: "scan.id", Value: map[string]any{"stringValue": "s1"}},
+		{Key: "targets", Value: map[string]any{"intValue": "2"}},
+		{Key: "dry_run", Value: map[string]any{"boolValue": false}},
+	}, run.Attributes)
+	require.Equal(t, []otlpKeyValue{{Key: "ratio", Value: map[string]any{"doubleValue": 0.5}}}, mod.Attributes)
+}
+
+func TestProvider_DropsWhenQueueFull(t *testing.T) {
+	p := installProvider(t, 1)
+	p.maxQueueSize = 2
+
+	for range 5 {
+		_, span := Start(context.Background(), "storage.scans.get")
+		span.End()
+	}
+	require.Len(t, queued(p), 2)
+
+	p.mu.Lock()
+	require.Equal(t, 3, p.dropped)
+	p.mu.Unlock()
+}
+
+func TestProvider_ExportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	p := NewProvider(config.TracingConfig{Endpoint: srv.URL, SampleRatio: 1})
+	SetProvider(p)
+	defer SetProvider(nil)
+
+	_, span := Start(context.Background(), "scan.run")
+	span.End()
+
+	err := p.Shutdown(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "503")
+}
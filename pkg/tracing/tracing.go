@@ -0,0 +1,314 @@
+// Package tracing provides lightweight OpenTelemetry-compatible tracing for
+// the scan pipeline and server.
+//
+// Spans are created with Start and carried through context.Context. When
+// tracing is disabled (the default) Start returns a nil *Span and every Span
+// method is a no-op, so instrumented code needs no nil checks:
+//
+//	ctx, span := tracing.Start(ctx, "fingerprint.resolve", tracing.String("protocol", proto))
+//	defer span.End()
+//
+// Finished spans are batched and exported to an OpenTelemetry collector
+// using OTLP/HTTP with the JSON encoding (see Setup). Trace context crosses
+// process boundaries as a W3C traceparent header (see Inject and Extract).
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceparentHeader is the W3C Trace Context propagation header.
+const TraceparentHeader = "traceparent"
+
+// SpanKind describes the role of a span in a trace (OTLP numbering).
+type SpanKind int
+
+const (
+	// KindInternal marks an in-process operation.
+	KindInternal SpanKind = 1
+	// KindServer marks the handling of an incoming request.
+	KindServer SpanKind = 2
+)
+
+// Attr is a span attribute.
+type Attr struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute.
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute.
+func Int(key string, value int) Attr { return Attr{Key: key, Value: int64(value)} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+// Float returns a floating point attribute.
+func Float(key string, value float64) Attr { return Attr{Key: key, Value: value} }
+
+// SpanContext identifies a span within a trace.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether both trace and span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{} && sc.SpanID != [8]byte{}
+}
+
+// TraceIDString returns the hex-encoded trace ID.
+func (sc SpanContext) TraceIDString() string { return hex.EncodeToString(sc.TraceID[:]) }
+
+// SpanIDString returns the hex-encoded span ID.
+func (sc SpanContext) SpanIDString() string { return hex.EncodeToString(sc.SpanID[:]) }
+
+// Span is a single timed operation. A nil *Span is valid and does nothing.
+type Span struct {
+	provider *Provider
+	sc       SpanContext
+	parentID [8]byte
+	name     string
+	kind     SpanKind
+	start    time.Time
+
+	mu        sync.Mutex
+	end       time.Time
+	attrs     []Attr
+	errMsg    string
+	hasError  bool
+	ended     bool
+	recording bool
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.attrs = append(s.attrs, attrs...)
+	}
+}
+
+// RecordError marks the span as failed. A nil error is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil || !s.recording {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.hasError = true
+		s.errMsg = err.Error()
+	}
+}
+
+// End finishes the span and queues it for export. Calling End more than
+// once has no effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	if s.recording {
+		s.provider.enqueue(s)
+	}
+}
+
+// SpanContext returns the span's identity. The zero value is returned for
+// a nil span.
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// SpanFromContext returns the current span, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// ContextWithSpanContext returns a copy of ctx whose new spans descend from
+// sc, a span that may belong to another process or an already finished
+// request. Invalid span contexts are ignored.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// parentContext returns the span context new spans in ctx should descend
+// from: the local current span, or else a remote parent set by Extract.
+func parentContext(ctx context.Context) (SpanContext, bool) {
+	if s := SpanFromContext(ctx); s != nil {
+		return s.sc, true
+	}
+	if sc, ok := ctx.Value(remoteKey{}).(SpanContext); ok && sc.IsValid() {
+		return sc, true
+	}
+	return SpanContext{}, false
+}
+
+var global atomic.Pointer[Provider]
+
+// SetProvider installs p as the process-wide provider. Passing nil
+// disables tracing.
+func SetProvider(p *Provider) {
+	global.Store(p)
+}
+
+// Enabled reports whether a provider is installed.
+func Enabled() bool {
+	return global.Load() != nil
+}
+
+// Start starts an internal span as a child of the span in ctx.
+func Start(ctx context.Context, name string, attrs ...Attr) (context.Context, *Span) {
+	return StartKind(ctx, KindInternal, name, attrs...)
+}
+
+// StartKind starts a span of the given kind as a child of the span in ctx.
+// It returns ctx unchanged and a nil span when tracing is disabled.
+func StartKind(ctx context.Context, kind SpanKind, name string, attrs ...Attr) (context.Context, *Span) {
+	p := global.Load()
+	if p == nil {
+		return ctx, nil
+	}
+
+	span := &Span{
+		provider: p,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+	}
+
+	if parent, ok := parentContext(ctx); ok {
+		span.sc.TraceID = parent.TraceID
+		span.sc.Sampled = parent.Sampled
+		span.parentID = parent.SpanID
+	} else {
+		span.sc.TraceID = newTraceID()
+		span.sc.Sampled = p.sample(span.sc.TraceID)
+	}
+	span.sc.SpanID = newSpanID()
+	span.recording = span.sc.Sampled
+	if span.recording {
+		span.attrs = attrs
+	}
+
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Inject writes the current span context of ctx into h as a traceparent header.
+func Inject(ctx context.Context, h http.Header) {
+	sc, ok := parentContext(ctx)
+	if !ok {
+		return
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	h.Set(TraceparentHeader, "00-"+sc.TraceIDString()+"-"+sc.SpanIDString()+"-"+flags)
+}
+
+// Extract returns a copy of ctx carrying the remote span context found in
+// the traceparent header of h. Malformed headers are ignored.
+func Extract(ctx context.Context, h http.Header) context.Context {
+	sc, ok := parseTraceparent(h.Get(TraceparentHeader))
+	if !ok {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// parseTraceparent parses "version-traceid-spanid-flags".
+func parseTraceparent(v string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(v), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, false
+	}
+	// Version 00 has exactly four fields; later versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	if len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&0x01 == 0x01
+
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// sample makes the root sampling decision from the trace ID so that the
+// decision is deterministic for a given trace.
+func (p *Provider) sample(traceID [16]byte) bool {
+	switch {
+	case p.ratio >= 1:
+		return true
+	case p.ratio <= 0:
+		return false
+	}
+	bound := uint64(p.ratio * math.MaxUint64)
+	return binary.BigEndian.Uint64(traceID[8:]) < bound
+}
+
+func newTraceID() [16]byte {
+	var id [16]byte
+	for id == [16]byte{} {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() [8]byte {
+	var id [8]byte
+	for id == [8]byte{} {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/config"
+)
+
+// installProvider installs a provider exporting to nowhere and removes it
+// after the test. Spans stay in the queue for inspection.
+func installProvider(t *testing.T, ratio float64) *Provider {
+	t.Helper()
+	p := NewProvider(config.TracingConfig{Endpoint: "http://127.0.0.1:1", SampleRatio: ratio})
+	p.batchSize = 1 << 20 // never flush during the test
+	SetProvider(p)
+	t.Cleanup(func() {
+		SetProvider(nil)
+		p.stopOnce.Do(func() { close(p.stopCh) })
+		<-p.stopped
+	})
+	return p
+}
+
+func queued(p *Provider) []*Span {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*Span(nil), p.queue...)
+}
+
+func TestStart_DisabledIsNoop(t *testing.T) {
+	SetProvider(nil)
+
+	ctx := context.Background()
+	got, span := Start(ctx, "noop", String("k", "v"))
+	require.Nil(t, span)
+	require.Equal(t, ctx, got)
+
+	// Every method tolerates a nil span
+	span.SetAttributes(Int("n", 1))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	require.False(t, span.SpanContext().IsValid())
+	require.False(t, Enabled())
+}
+
+func TestStart_ParentChild(t *testing.T) {
+	p := installProvider(t, 1)
+
+	ctx, root := Start(context.Background(), "scan.run", String("scan.id", "s1"))
+	_, child := Start(ctx, "module.execute")
+	child.RecordError(errors.New("timeout"))
+	child.End()
+	root.SetAttributes(Int("targets", 3))
+	root.End()
+	root.End() // second End is ignored
+
+	spans := queued(p)
+	require.Len(t, spans, 2)
+	require.Equal(t, "module.execute", spans[0].name)
+	require.Equal(t, root.sc.TraceID, child.sc.TraceID)
+	require.Equal(t, root.sc.SpanID, child.parentID)
+	require.True(t, child.hasError)
+	require.Equal(t, "timeout", child.errMsg)
+	require.Equal(t, []Attr{String("scan.id", "s1"), Int("targets", 3)}, root.attrs)
+	require.Equal(t, [8]byte{}, root.parentID)
+}
+
+func TestStart_Sampling(t *testing.T) {
+	p := installProvider(t, 0)
+
+	ctx, root := Start(context.Background(), "scan.run")
+	require.NotNil(t, root)
+	require.False(t, root.SpanContext().Sampled)
+
+	// Children follow the parent's decision and nothing is exported
+	_, child := Start(ctx, "module.execute")
+	require.False(t, child.SpanContext().Sampled)
+	child.End()
+	root.End()
+	require.Empty(t, queued(p))
+
+	// A sampled remote parent overrides the local ratio
+	h := http.Header{}
+	h.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, remote := Start(Extract(context.Background(), h), "http.request")
+	require.True(t, remote.SpanContext().Sampled)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", remote.SpanContext().TraceIDString())
+	require.Equal(t, "00f067aa0ba902b7", SpanContext{SpanID: remote.parentID}.SpanIDString())
+}
+
+func TestInjectExtract(t *testing.T) {
+	installProvider(t, 1)
+
+	ctx, span := Start(context.Background(), "client")
+	h := http.Header{}
+	Inject(ctx, h)
+	require.Equal(t, "00-"+span.sc.TraceIDString()+"-"+span.sc.SpanIDString()+"-01", h.Get(TraceparentHeader))
+
+	sc, ok := parentContext(Extract(context.Background(), h))
+	require.True(t, ok)
+	require.Equal(t, span.sc, sc)
+
+	// Nothing to inject without a span
+	empty := http.Header{}
+	Inject(context.Background(), empty)
+	require.Empty(t, empty.Get(TraceparentHeader))
+}
+
+func TestParseTraceparent(t *testing.T) {
+	valid := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, ok := parseTraceparent(valid)
+	require.True(t, ok)
+	require.True(t, sc.Sampled)
+
+	sc, ok = parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	require.True(t, ok)
+	require.False(t, sc.Sampled)
+
+	for _, bad := range []string{
+		"",
+		"garbage",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		valid + "-extra",
+	} {
+		_, ok := parseTraceparent(bad)
+		require.False(t, ok, bad)
+	}
+}
+
+func TestContextWithSpanContext(t *testing.T) {
+	installProvider(t, 1)
+
+	// A finished request span still parents work started from it
+	_, req := Start(context.Background(), "http.request")
+	req.End()
+	ctx := ContextWithSpanContext(context.Background(), req.SpanContext())
+
+	_, child := Start(ctx, "scan.run")
+	require.Equal(t, req.sc.TraceID, child.sc.TraceID)
+	require.Equal(t, req.sc.SpanID, child.parentID)
+
+	require.Equal(t, context.Background(), ContextWithSpanContext(context.Background(), SpanContext{}))
+}
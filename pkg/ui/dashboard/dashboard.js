@@ -0,0 +1,220 @@
+// Built-in Vulntor dashboard.
+//
+// Served from the Go binary when the full web UI is not embedded. It only
+// talks to the public REST API (/api/v1), so it works with every auth mode:
+// API keys are kept in localStorage and sent as bearer tokens, OIDC sessions
+// use the session cookie.
+(function () {
+  'use strict';
+
+  const REFRESH_MS = 15000;
+  const RECENT_SCANS = 10;
+  const FINDING_SCANS = 5;
+  const TOP_FINDINGS = 10;
+  const SEVERITY_RANK = { critical: 0, high: 1, medium: 2, low: 3, info: 4 };
+
+  const streams = new Map(); // scan ID -> AbortController
+
+  class UnauthorizedError extends Error {}
+
+  function headers() {
+    const h = { Accept: 'application/json' };
+    const key = localStorage.getItem('vulntor.apiKey');
+    const tenant = localStorage.getItem('vulntor.tenant');
+    if (key) h.Authorization = 'Bearer ' + key;
+    if (tenant) h['X-Tenant-ID'] = tenant;
+    return h;
+  }
+
+  async function api(path) {
+    const resp = await fetch('/api/v1' + path, { headers: headers(), credentials: 'same-origin' });
+    if (resp.status === 401) throw new UnauthorizedError();
+    if (!resp.ok) throw new Error(path + ': ' + resp.status);
+    return resp.json();
+  }
+
+  // el builds a DOM element; text is always inserted as text, never HTML.
+  function el(tag, attrs, ...children) {
+    const node = document.createElement(tag);
+    for (const [k, v] of Object.entries(attrs || {})) {
+      if (k === 'class') node.className = v;
+      else node.setAttribute(k, v);
+    }
+    for (const c of children) {
+      node.append(c instanceof Node ? c : document.createTextNode(c == null ? '' : String(c)));
+    }
+    return node;
+  }
+
+  function badge(value) {
+    const v = String(value || 'info').toLowerCase();
+    return el('span', { class: 'badge ' + v }, v);
+  }
+
+  function fillRows(tbodyID, rows, emptyText, columns) {
+    const tbody = document.getElementById(tbodyID);
+    tbody.replaceChildren();
+    if (rows.length === 0) {
+      tbody.append(el('tr', {}, el('td', { colspan: columns, class: 'muted' }, emptyText)));
+      return;
+    }
+    for (const cells of rows) {
+      tbody.append(el('tr', {}, ...cells.map((c) => el('td', {}, c))));
+    }
+  }
+
+  function shortID(id) {
+    return el('code', { title: id }, String(id).slice(0, 8));
+  }
+
+  function when(ts) {
+    if (!ts || ts.startsWith('0001-')) return '-';
+    return new Date(ts).toLocaleString();
+  }
+
+  async function loadScans() {
+    const list = await api('/scans?limit=' + RECENT_SCANS);
+    const scans = Array.isArray(list) ? list : list.scans || [];
+    const details = await Promise.all(scans.map((s) => api('/scans/' + encodeURIComponent(s.id)).catch(() => s)));
+
+    fillRows('scans', details.map((d) => {
+      const r = d.results || {};
+      return [shortID(d.id), d.target || '-', badge(d.status), when(d.start_time), r.hosts_found ?? '-', r.vulnerabilities ?? '-'];
+    }), 'No scans yet.', 6);
+
+    return details;
+  }
+
+  async function loadFindings(scans) {
+    const completed = scans.filter((s) => s.status === 'completed').slice(0, FINDING_SCANS);
+    const pages = await Promise.all(completed.map((s) =>
+      api('/scans/' + encodeURIComponent(s.id) + '/findings?limit=100')
+        .then((p) => p.findings.map((f) => ({ ...f, scan_id: s.id })))
+        .catch(() => [])));
+
+    const findings = pages.flat().sort((a, b) =>
+      (SEVERITY_RANK[String(a.severity).toLowerCase()] ?? 5) - (SEVERITY_RANK[String(b.severity).toLowerCase()] ?? 5));
+
+    fillRows('findings', findings.slice(0, TOP_FINDINGS).map((f) => [
+      badge(f.severity),
+      f.title || f.name || f.plugin || f.id || '-',
+      f.target ? f.target + (f.port ? ':' + f.port : '') : '-',
+      shortID(f.scan_id),
+    ]), 'No findings in recent scans.', 4);
+  }
+
+  async function loadAssets() {
+    const inv = await api('/assets?limit=100');
+    fillRows('assets', inv.assets.map((a) => [
+      a.hostnames && a.hostnames.length ? a.ip + ' (' + a.hostnames.join(', ') + ')' : a.ip,
+      (a.ports || []).map((p) => p.port + '/' + p.protocol + (p.service ? ' ' + p.service : '')).join(', ') || '-',
+      a.vulnerabilities,
+      when(a.last_seen),
+    ]), 'No assets discovered yet.', 4);
+  }
+
+  // watchLive follows the event stream of every pending or running scan.
+  // fetch is used instead of EventSource so the API key header is sent.
+  function watchLive(scans) {
+    const active = scans.filter((s) => s.status === 'pending' || s.status === 'running');
+    const container = document.getElementById('live-scans');
+    if (active.length === 0 && streams.size === 0) {
+      container.replaceChildren(el('p', { class: 'muted' }, 'No scans running.'));
+    }
+    for (const scan of active) {
+      if (!streams.has(scan.id)) follow(scan, container);
+    }
+  }
+
+  function follow(scan, container) {
+    const counters = { hosts: 0, ports: 0, findings: 0 };
+    const summary = el('span', { class: 'counters' });
+    const log = el('ol');
+    const card = el('div', { class: 'live-scan' }, shortID(scan.id), ' ', scan.target || '', ' ', summary, log);
+    const render = () => { summary.textContent = `hosts ${counters.hosts} · open ports ${counters.ports} · findings ${counters.findings}`; };
+
+    if (streams.size === 0) container.replaceChildren();
+    container.append(card);
+    render();
+
+    const ctrl = new AbortController();
+    streams.set(scan.id, ctrl);
+
+    const onEvent = (ev) => {
+      const data = ev.data || {};
+      switch (ev.type) {
+        case 'host.discovered': counters.hosts += (data.live_hosts || [data]).length; break;
+        case 'port.open': counters.ports += (data.open_ports || [data]).length; break;
+        case 'finding.created': counters.findings++; break;
+        case 'scan.finished':
+          card.append(el('div', {}, badge(data.status), data.error ? ' ' + data.error : ''));
+          break;
+      }
+      log.append(el('li', {}, new Date(ev.timestamp).toLocaleTimeString() + ' ' + ev.type));
+      log.scrollTop = log.scrollHeight;
+      render();
+    };
+
+    fetch('/api/v1/scans/' + encodeURIComponent(scan.id) + '/events', { headers: headers(), signal: ctrl.signal })
+      .then(async (resp) => {
+        if (!resp.ok || !resp.body) return;
+        const reader = resp.body.pipeThrough(new TextDecoderStream()).getReader();
+        let buf = '';
+        for (;;) {
+          const { value, done } = await reader.read();
+          if (done) break;
+          buf += value;
+          let idx;
+          while ((idx = buf.indexOf('\n\n')) >= 0) {
+            const frame = buf.slice(0, idx);
+            buf = buf.slice(idx + 2);
+            const data = frame.split('\n').filter((l) => l.startsWith('data: ')).map((l) => l.slice(6)).join('\n');
+            if (data) onEvent(JSON.parse(data));
+          }
+        }
+      })
+      .catch(() => {})
+      .finally(() => {
+        streams.delete(scan.id);
+        setTimeout(() => { card.remove(); if (streams.size === 0) refresh(); }, REFRESH_MS);
+      });
+  }
+
+  async function loadIdentity() {
+    const me = await api('/me');
+    const parts = [];
+    if (me.tenant && me.tenant !== 'default') parts.push('tenant ' + me.tenant);
+    if (me.authenticated) parts.push(me.user_id || me.key_id, me.role || me.scopes.join(','));
+    document.getElementById('identity').textContent = parts.join(' · ');
+  }
+
+  async function refresh() {
+    try {
+      await loadIdentity();
+      const scans = await loadScans();
+      document.getElementById('login').hidden = true;
+      document.getElementById('dashboard').hidden = false;
+      watchLive(scans);
+      await Promise.all([loadFindings(scans), loadAssets()]);
+    } catch (err) {
+      if (err instanceof UnauthorizedError) {
+        document.getElementById('dashboard').hidden = true;
+        document.getElementById('login').hidden = false;
+        return;
+      }
+      console.error(err);
+    }
+  }
+
+  document.getElementById('login').addEventListener('submit', (e) => {
+    e.preventDefault();
+    localStorage.setItem('vulntor.apiKey', document.getElementById('login-key').value.trim());
+    const tenant = document.getElementById('login-tenant').value.trim();
+    if (tenant) localStorage.setItem('vulntor.tenant', tenant);
+    else localStorage.removeItem('vulntor.tenant');
+    refresh();
+  });
+
+  refresh();
+  setInterval(() => { if (!document.hidden) refresh(); }, REFRESH_MS);
+})();
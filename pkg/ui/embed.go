@@ -10,3 +10,11 @@ import "embed"
 //
 //go:embed all:dist
 var DistFS embed.FS
+
+// DashboardFS embeds the built-in dashboard: a dependency-free page showing
+// recent scans, live progress, top findings and the asset inventory.
+// It is served when DistFS holds no UI build, so a plain `go build` still
+// ships a usable web interface.
+//
+//go:embed dashboard
+var DashboardFS embed.FS
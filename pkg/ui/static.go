@@ -17,6 +17,7 @@ import (
 // Operating modes:
 //   - Dev mode (cfg.UI.AssetsPath set): Serves from disk for hot reload
 //   - Production mode: Serves from embedded FS (go:embed)
+//   - Built-in dashboard: Served when the binary was built without the UI
 //
 // The handler implements SPA fallback routing:
 //   - Static files (*.js, *.css, etc.) are served directly
@@ -32,11 +33,6 @@ func NewHandler(cfg config.ServerConfig) http.Handler {
 		return spaHandler(http.FileServer(http.Dir(cfg.UI.AssetsPath)))
 	}
 
-	// Production: serve from embedded FS
-	log.Info().
-		Str("component", "ui").
-		Msg("Serving UI from embedded assets (production mode)")
-
 	// Strip "dist/" prefix from embed.FS paths
 	stripped, err := fs.Sub(DistFS, "dist")
 	if err != nil {
@@ -46,9 +42,34 @@ func NewHandler(cfg config.ServerConfig) http.Handler {
 			Msg("Failed to create sub-FS for UI dist")
 	}
 
+	// Binaries built without `npm run build` fall back to the built-in dashboard
+	if _, err := fs.Stat(stripped, "index.html"); err != nil {
+		log.Info().
+			Str("component", "ui").
+			Msg("Serving built-in dashboard (UI build not embedded)")
+		return dashboardHandler()
+	}
+
+	// Production: serve from embedded FS
+	log.Info().
+		Str("component", "ui").
+		Msg("Serving UI from embedded assets (production mode)")
+
 	return spaHandler(http.FileServer(http.FS(stripped)))
 }
 
+// dashboardHandler serves the built-in dashboard (see DashboardFS).
+func dashboardHandler() http.Handler {
+	dashboard, err := fs.Sub(DashboardFS, "dashboard")
+	if err != nil {
+		log.Fatal().
+			Str("component", "ui").
+			Err(err).
+			Msg("Failed to create sub-FS for built-in dashboard")
+	}
+	return spaHandler(http.FileServer(http.FS(dashboard)))
+}
+
 // spaHandler wraps a file server to implement SPA fallback routing.
 //
 // If a requested file doesn't exist, serves index.html instead,
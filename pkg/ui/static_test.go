@@ -196,3 +196,31 @@ func TestNewHandler_Integration(t *testing.T) {
 	// This would test actual file serving with built assets
 	// Left as placeholder for manual testing
 }
+
+func TestNewHandler_BuiltinDashboard(t *testing.T) {
+	if _, err := DistFS.Open("dist/index.html"); err == nil {
+		t.Skip("UI build is embedded; built-in dashboard not served")
+	}
+
+	handler := NewHandler(config.ServerConfig{})
+
+	tests := []struct {
+		path     string
+		contains string
+	}{
+		{"/", "Vulntor Dashboard"},
+		{"/scans/123", "Vulntor Dashboard"}, // SPA fallback
+		{"/dashboard.js", "api('/assets?limit=100')"},
+		{"/dashboard.css", "--critical"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			require.Contains(t, w.Body.String(), tt.contains)
+		})
+	}
+}
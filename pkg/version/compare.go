@@ -0,0 +1,234 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parsed is a software version as reported by services and plugins,
+// split into the parts that order versions:
+//
+//	7.4p1              release 7.4, patch level p1
+//	2020.81            release 2020.81
+//	8.0.35-27-percona  release 8.0.35, build 27-percona
+//	1.1.1k             release 1.1.1, patch level k
+//	2.0.0-rc1          release 2.0.0, pre-release rc1
+//
+// Text before the first digit, such as "v", "OpenSSH_" or "nginx/", is not
+// part of the version, nor is text after the first space.
+type Parsed struct {
+	Release []int  // Dot-separated numbers, e.g. [8 0 35]
+	Pre     string // Pre-release, ordered before the release, e.g. "rc1"
+	Patch   string // Patch level, ordered after the release, e.g. "p1"
+	Build   string // Distribution or build suffix, ignored in comparisons
+}
+
+// preReleaseRank orders the words marking pre-releases.
+var preReleaseRank = map[string]int{
+	"dev": 0, "snapshot": 0,
+	"alpha": 1, "a": 1,
+	"beta": 2, "b": 2,
+	"pre": 3, "preview": 3,
+	"rc": 4,
+}
+
+// ErrNoVersion is returned when a string holds no version.
+var ErrNoVersion = errors.New("no version number")
+
+// Parse parses the version in s.
+func Parse(s string) (Parsed, error) {
+	text := strings.TrimSpace(s)
+	if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+		text = text[:i]
+	}
+	start := strings.IndexFunc(text, isDigit)
+	if start < 0 {
+		return Parsed{}, fmt.Errorf("%w in %q", ErrNoVersion, s)
+	}
+	rest := strings.ToLower(text[start:])
+
+	var v Parsed
+	for {
+		end := strings.IndexFunc(rest, func(r rune) bool { return !isDigit(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return Parsed{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		v.Release = append(v.Release, n)
+		rest = rest[end:]
+		if len(rest) < 2 || rest[0] != '.' || !isDigit(rune(rest[1])) {
+			break
+		}
+		rest = rest[1:]
+	}
+
+	// A qualifier attached to the release (7.4p1, 1.0rc1), or following a
+	// separator when it marks a pre-release (2.0.0-rc1, 1.0.beta)
+	qualifier, after := splitQualifier(rest)
+	switch {
+	case qualifier != "" && isPreRelease(qualifier, true):
+		v.Pre, rest = qualifier, after
+	case qualifier != "":
+		v.Patch, rest = qualifier, after
+	case rest != "" && strings.ContainsRune("-_~.+", rune(rest[0])):
+		if q, after := splitQualifier(rest[1:]); q != "" && isPreRelease(q, false) {
+			v.Pre, rest = q, after
+		}
+	}
+	v.Build = strings.TrimLeft(rest, "-_~.+")
+	return v, nil
+}
+
+// MustParse is like Parse but panics if s holds no version.
+func MustParse(s string) Parsed {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// splitQualifier splits the letters at the start of s, and the digits
+// following them, from the rest of s.
+func splitQualifier(s string) (qualifier, rest string) {
+	i := 0
+	for i < len(s) && s[i] >= 'a' && s[i] <= 'z' {
+		i++
+	}
+	if i == 0 {
+		return "", s
+	}
+	for i < len(s) && isDigit(rune(s[i])) {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// isPreRelease reports whether qualifier marks a pre-release. A lone letter
+// attached to the release is a patch level (OpenSSL's 1.1.1a), while "a1"
+// and "b2" are pre-releases.
+func isPreRelease(qualifier string, attached bool) bool {
+	word, num := splitNumber(qualifier)
+	if _, ok := preReleaseRank[word]; !ok {
+		return false
+	}
+	return !attached || len(word) > 1 || num >= 0
+}
+
+// splitNumber splits a qualifier into its word and number, or -1 without a
+// number.
+func splitNumber(qualifier string) (string, int) {
+	i := strings.IndexFunc(qualifier, isDigit)
+	if i < 0 {
+		return qualifier, -1
+	}
+	n, err := strconv.Atoi(qualifier[i:])
+	if err != nil {
+		return qualifier, -1
+	}
+	return qualifier[:i], n
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// String returns the version in canonical form, e.g. "8.0.35-27-percona".
+func (v Parsed) String() string {
+	parts := make([]string, len(v.Release))
+	for i, n := range v.Release {
+		parts[i] = strconv.Itoa(n)
+	}
+	s := strings.Join(parts, ".")
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	s += v.Patch
+	if v.Build != "" {
+		s += "-" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v orders before, like or
+// after w. Missing release numbers count as zero, so 7.4 equals 7.4.0.
+func (v Parsed) Compare(w Parsed) int {
+	for i := 0; i < max(len(v.Release), len(w.Release)); i++ {
+		a, b := component(v.Release, i), component(w.Release, i)
+		if a != b {
+			return sign(a - b)
+		}
+	}
+	if c := compareQualifiers(v.Pre, w.Pre, true); c != 0 {
+		return c
+	}
+	return compareQualifiers(v.Patch, w.Patch, false)
+}
+
+// compareQualifiers orders pre-releases before their release, and patch
+// levels after it.
+func compareQualifiers(a, b string, pre bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "" && pre, b == "" && !pre:
+		return 1
+	case b == "" && pre, a == "" && !pre:
+		return -1
+	}
+	wa, na := splitNumber(a)
+	wb, nb := splitNumber(b)
+	if pre && preReleaseRank[wa] != preReleaseRank[wb] {
+		return sign(preReleaseRank[wa] - preReleaseRank[wb])
+	}
+	if c := strings.Compare(wa, wb); c != 0 && !pre {
+		return c
+	}
+	return sign(na - nb)
+}
+
+func component(release []int, i int) int {
+	if i < len(release) {
+		return release[i]
+	}
+	return 0
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Compare parses and compares two versions, as Parsed.Compare.
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// Normalize returns the canonical form of the version in s, or s trimmed
+// and lower-cased when it holds none.
+func Normalize(s string) string {
+	v, err := Parse(s)
+	if err != nil {
+		return strings.TrimSpace(strings.ToLower(s))
+	}
+	return v.String()
+}
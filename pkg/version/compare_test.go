@@ -0,0 +1,121 @@
+package version
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Parsed
+		str  string
+	}{
+		{"7.4p1", Parsed{Release: []int{7, 4}, Patch: "p1"}, "7.4p1"},
+		{"OpenSSH_8.9p1 Ubuntu-3ubuntu0.6", Parsed{Release: []int{8, 9}, Patch: "p1"}, "8.9p1"},
+		{"2020.81", Parsed{Release: []int{2020, 81}}, "2020.81"},
+		{"dropbear_2020.81", Parsed{Release: []int{2020, 81}}, "2020.81"},
+		{"8.0.35-27-percona", Parsed{Release: []int{8, 0, 35}, Build: "27-percona"}, "8.0.35-27-percona"},
+		{"5.7.42-log", Parsed{Release: []int{5, 7, 42}, Build: "log"}, "5.7.42-log"},
+		{"1.1.1k", Parsed{Release: []int{1, 1, 1}, Patch: "k"}, "1.1.1k"},
+		{"1.1.1a", Parsed{Release: []int{1, 1, 1}, Patch: "a"}, "1.1.1a"},
+		{"v2.0.0-RC1", Parsed{Release: []int{2, 0, 0}, Pre: "rc1"}, "2.0.0-rc1"},
+		{"3.0a2", Parsed{Release: []int{3, 0}, Pre: "a2"}, "3.0-a2"},
+		{"1.0.beta", Parsed{Release: []int{1, 0}, Pre: "beta"}, "1.0-beta"},
+		{"nginx/1.18.0", Parsed{Release: []int{1, 18, 0}}, "1.18.0"},
+		{"Apache/2.4.41 (Ubuntu)", Parsed{Release: []int{2, 4, 41}}, "2.4.41"},
+		{"10.0.17763.1", Parsed{Release: []int{10, 0, 17763, 1}}, "10.0.17763.1"},
+		{"1.2.", Parsed{Release: []int{1, 2}}, "1.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.in, err)
+			}
+			if !slices.Equal(got.Release, tt.want.Release) || got.Pre != tt.want.Pre || got.Patch != tt.want.Patch || got.Build != tt.want.Build {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if got.String() != tt.str {
+				t.Errorf("Parse(%q).String() = %q, want %q", tt.in, got.String(), tt.str)
+			}
+		})
+	}
+
+	for _, in := range []string{"", "not-a-version", "v.x.y", "99999999999999999999.1"} {
+		if _, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", in)
+		}
+	}
+	if _, err := Parse("unknown"); !errors.Is(err, ErrNoVersion) {
+		t.Errorf("Parse(unknown) error = %v, want ErrNoVersion", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Each version orders before the next one
+	ordered := []string{
+		"1.0.0-dev",
+		"1.0.0-alpha",
+		"1.0.0-beta1",
+		"1.0.0-beta2",
+		"1.0.0-rc1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.1",
+		"1.1.1a",
+		"1.1.1k",
+		"4.3p2",
+		"4.4",
+		"4.4p1",
+		"7.4p1",
+		"7.4p2",
+		"8.0.35",
+		"8.0.36-28-percona",
+		"9.8p1",
+		"2020.81",
+	}
+	for i := 0; i < len(ordered); i++ {
+		for j := 0; j < len(ordered); j++ {
+			got, err := Compare(ordered[i], ordered[j])
+			if err != nil {
+				t.Fatalf("Compare(%q, %q) error: %v", ordered[i], ordered[j], err)
+			}
+			if want := sign(i - j); got != want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+
+	equal := [][2]string{
+		{"7.4", "7.4.0"},
+		{"8.0.35-27-percona", "8.0.35"},
+		{"OpenSSH_8.9p1", "8.9p1"},
+		{"V1.2.3", "1.2.3"},
+	}
+	for _, pair := range equal {
+		if got, err := Compare(pair[0], pair[1]); err != nil || got != 0 {
+			t.Errorf("Compare(%q, %q) = %d, %v, want 0", pair[0], pair[1], got, err)
+		}
+	}
+
+	if _, err := Compare("1.0", "bad"); err == nil {
+		t.Error("Compare with an invalid version succeeded")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		" OpenSSH_8.9P1 ":   "8.9p1",
+		"v1.02.3":           "1.2.3",
+		"8.0.35-27-Percona": "8.0.35-27-percona",
+		"Unknown":           "unknown",
+		"":                  "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
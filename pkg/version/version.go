@@ -1,4 +1,5 @@
-// Package version provides version metadata for the application.
+// Package version provides version metadata for the application, and
+// parses and compares the versions of scanned software.
 package version
 
 import (
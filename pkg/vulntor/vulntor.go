@@ -0,0 +1,368 @@
+// Package vulntor embeds the Vulntor scanner in Go programs: configure a
+// scan, follow its live events and receive its findings as Go values,
+// without running the CLI.
+//
+//	scanner, err := vulntor.NewScanner()
+//	if err != nil {
+//		return err
+//	}
+//	defer scanner.Close()
+//
+//	scan, err := scanner.Start(ctx, vulntor.ScanConfig{
+//		Targets:    []string{"10.0.0.0/24"},
+//		EnableVuln: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for ev := range scan.Events() {
+//		log.Printf("%s: %v", ev.Type, ev.Data)
+//	}
+//	result, err := scan.Wait()
+//
+// Scans run in-process with the modules and embedded plugins of the CLI, and
+// the settings of the Vulntor config file and VULNTOR_* environment
+// variables. The types of this package are stable across minor releases;
+// the internal packages it builds on are not.
+package vulntor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/vulntor/vulntor/pkg/appctx"
+	"github.com/vulntor/vulntor/pkg/config"
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/plugin"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+	"github.com/vulntor/vulntor/pkg/server/events"
+	"github.com/vulntor/vulntor/pkg/storage"
+
+	// Scan modules, as registered by the CLI
+	_ "github.com/vulntor/vulntor/pkg/modules/evaluation"
+	_ "github.com/vulntor/vulntor/pkg/modules/parse"
+	_ "github.com/vulntor/vulntor/pkg/modules/reporting"
+	_ "github.com/vulntor/vulntor/pkg/modules/scan"
+)
+
+// Event is a live event of a scan.
+type Event = events.Event
+
+// Event types, in the order a scan emits them. EventScanFinished is always
+// the last event.
+const (
+	EventHostDiscovered = events.TypeHostDiscovered
+	EventPortOpen       = events.TypePortOpen
+	EventFindingCreated = events.TypeFindingCreated
+	EventScanFinished   = events.TypeScanFinished
+)
+
+// Finding is a vulnerability reported by a scan.
+type Finding = report.Finding
+
+// ScanConfig configures a scan.
+type ScanConfig struct {
+	// Targets are the hosts, IPs or CIDR ranges to scan (required)
+	Targets []string
+
+	// Ports overrides the port list (e.g. "22,80,443" or "1-1024")
+	Ports string
+
+	// Profile is the scan profile name (optional)
+	Profile string
+
+	// EnableVuln evaluates plugins against the services found
+	EnableVuln bool
+
+	// Plugins restricts evaluation to the plugins with these IDs, names,
+	// categories or tags. Empty = all plugins.
+	Plugins []string
+
+	// OnlyDiscover runs host discovery only; SkipDiscover treats all
+	// targets as live
+	OnlyDiscover bool
+	SkipDiscover bool
+
+	// Concurrency limits concurrent probes (0 = engine default)
+	Concurrency int
+
+	// Timeout is the per-probe timeout (0 = engine default)
+	Timeout time.Duration
+
+	// AllowLoopback permits scanning loopback addresses
+	AllowLoopback bool
+}
+
+// params returns the scan parameters of c for the scan scanID.
+func (c ScanConfig) params(scanID string) scanexec.Params {
+	p := scanexec.Params{
+		ScanID:        scanID,
+		Targets:       c.Targets,
+		Ports:         c.Ports,
+		Profile:       c.Profile,
+		EnableVuln:    c.EnableVuln,
+		Plugins:       c.Plugins,
+		OnlyDiscover:  c.OnlyDiscover,
+		SkipDiscover:  c.SkipDiscover,
+		Concurrency:   c.Concurrency,
+		AllowLoopback: c.AllowLoopback,
+		OutputFormat:  "json",
+	}
+	if c.Timeout > 0 {
+		p.CustomTimeout = c.Timeout.String()
+	}
+	return p
+}
+
+// Result is the outcome of a scan.
+type Result struct {
+	// ScanID identifies the scan, also in storage (see WithStorage)
+	ScanID string
+
+	// Status is "completed", "failed" or "interrupted"
+	Status string
+
+	StartedAt   time.Time
+	CompletedAt time.Time
+
+	// Findings are the vulnerabilities found
+	Findings []Finding
+
+	// PluginStats reports the executions of each plugin, slowest first
+	PluginStats []plugin.ExecStats
+
+	// Data holds all scan results by data key (e.g.
+	// "discovery.open_tcp_ports"), as the CLI's JSON output does
+	Data map[string]interface{}
+}
+
+// Option configures a Scanner.
+type Option func(*options)
+
+type options struct {
+	configFile string
+	storage    storage.Backend
+}
+
+// WithConfigFile loads the settings from the config file at path instead of
+// the default locations.
+func WithConfigFile(path string) Option {
+	return func(o *options) {
+		o.configFile = path
+	}
+}
+
+// WithStorage stores scans in backend, as the CLI stores them in the
+// workspace. The caller initializes and closes backend. Without it, scans
+// are not stored.
+func WithStorage(backend storage.Backend) Option {
+	return func(o *options) {
+		o.storage = backend
+	}
+}
+
+// scanRunner runs a scan (scanexec.Service.Run).
+type scanRunner func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error)
+
+// Scanner runs scans. It is safe for concurrent use.
+type Scanner struct {
+	appMgr *engine.AppManager
+	run    scanRunner
+}
+
+// NewScanner returns a scanner with the settings of the Vulntor config file
+// and environment.
+func NewScanner(opts ...Option) (*Scanner, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := config.NewManager()
+	if err := cfg.Load(nil, o.configFile); err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	svc, err := scanexec.NewService().WithConfig(cfg.Get())
+	if err != nil {
+		return nil, err
+	}
+	if o.storage != nil {
+		svc = svc.WithStorage(o.storage)
+	}
+
+	return &Scanner{appMgr: engine.NewAppManager(cfg), run: svc.Run}, nil
+}
+
+// Close releases the scanner. Scans still running are canceled.
+func (s *Scanner) Close() error {
+	s.appMgr.Shutdown()
+	return nil
+}
+
+// Scan runs a scan and returns its result. The result is also returned
+// with the error of scans that failed or were interrupted after they
+// started, with the partial results.
+func (s *Scanner) Scan(ctx context.Context, cfg ScanConfig) (*Result, error) {
+	scan, err := s.Start(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for range scan.Events() {
+		}
+	}()
+	return scan.Wait()
+}
+
+// Start starts a scan in the background. Canceling ctx stops the scan.
+// Callers receive the events of the scan until the channel is closed.
+func (s *Scanner) Start(ctx context.Context, cfg ScanConfig) (*Scan, error) {
+	if len(cfg.Targets) == 0 {
+		return nil, fmt.Errorf("no targets to scan")
+	}
+
+	scan := &Scan{
+		ID:     uuid.New().String(),
+		events: make(chan Event),
+		done:   make(chan struct{}),
+		queue:  newEventQueue(),
+	}
+
+	// Runs need the app manager and config on their context, and stop with
+	// the scanner
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(s.appMgr.Context(), cancel)
+	ctx = context.WithValue(ctx, engine.AppManagerKey, s.appMgr)
+	ctx = appctx.WithConfig(ctx, s.appMgr.Config())
+	ctx = engine.WithOutputObserver(ctx, events.Observer(scan.queue, scan.ID))
+
+	go scan.deliver()
+	go func() {
+		defer cancel()
+		defer stop()
+		res, err := s.run(ctx, cfg.params(scan.ID))
+		scan.finish(res, err)
+	}()
+	return scan, nil
+}
+
+// Scan is a scan started by Scanner.Start.
+type Scan struct {
+	// ID identifies the scan
+	ID string
+
+	events chan Event
+	done   chan struct{}
+	queue  *eventQueue
+
+	result *Result
+	err    error
+}
+
+// Events returns the live events of the scan. The channel is closed after
+// the EventScanFinished event.
+func (sc *Scan) Events() <-chan Event {
+	return sc.events
+}
+
+// Wait waits for the scan to end and returns its result, as Scanner.Scan
+// does.
+func (sc *Scan) Wait() (*Result, error) {
+	<-sc.done
+	return sc.result, sc.err
+}
+
+// deliver sends the queued events to the events channel, and closes it
+// after the scan.finished event.
+func (sc *Scan) deliver() {
+	defer close(sc.events)
+	for {
+		ev := sc.queue.pop()
+		sc.events <- ev
+		if ev.Type == EventScanFinished {
+			return
+		}
+	}
+}
+
+// finish records the outcome of the run and emits the scan.finished event.
+func (sc *Scan) finish(res *scanexec.Result, runErr error) {
+	data := map[string]string{"status": "completed"}
+	if res != nil {
+		sc.result = newResult(res)
+		data["status"] = res.Status
+	} else if runErr != nil {
+		data["status"] = "failed"
+	}
+	if runErr != nil {
+		data["error"] = runErr.Error()
+	}
+	sc.err = runErr
+	close(sc.done)
+	sc.queue.Publish(Event{Type: EventScanFinished, ScanID: sc.ID, Data: data})
+}
+
+// eventQueue is an unbounded events.Publisher, so that slow consumers of
+// events never stall the scan. Events after scan.finished are dropped.
+type eventQueue struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	events   []Event
+	finished bool
+}
+
+func newEventQueue() *eventQueue {
+	q := &eventQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// Publish queues ev.
+func (q *eventQueue) Publish(ev Event) {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now().UTC()
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.finished {
+		return
+	}
+	q.events = append(q.events, ev)
+	q.finished = ev.Type == EventScanFinished
+	q.cond.Signal()
+}
+
+// pop removes and returns the oldest event, waiting for one.
+func (q *eventQueue) pop() Event {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.events) == 0 {
+		q.cond.Wait()
+	}
+	ev := q.events[0]
+	q.events = q.events[1:]
+	return ev
+}
+
+// newResult converts the result of a run.
+func newResult(res *scanexec.Result) *Result {
+	r := &Result{
+		ScanID:      res.RunID,
+		Status:      res.Status,
+		PluginStats: res.PluginStats,
+		Data:        res.RawContext,
+	}
+	r.StartedAt, _ = time.Parse(time.RFC3339, res.StartTime)
+	r.CompletedAt, _ = time.Parse(time.RFC3339, res.EndTime)
+	// Findings the report format cannot read are left out
+	if findings, err := report.FindingsFromContext(res.RawContext); err == nil {
+		r.Findings = findings
+	}
+	return r
+}
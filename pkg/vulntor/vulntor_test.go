@@ -0,0 +1,115 @@
+package vulntor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/engine"
+	"github.com/vulntor/vulntor/pkg/report"
+	"github.com/vulntor/vulntor/pkg/scanexec"
+)
+
+// newTestScanner returns a scanner running scans with run.
+func newTestScanner(t *testing.T, run scanRunner) *Scanner {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	s, err := NewScanner()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	s.run = run
+	return s
+}
+
+func TestScanner_StartStreamsEventsAndFindings(t *testing.T) {
+	var got scanexec.Params
+	s := newTestScanner(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		got = params
+		require.NotNil(t, ctx.Value(engine.AppManagerKey))
+
+		observe, ok := engine.OutputObserverFromContext(ctx)
+		require.True(t, ok)
+		observe(engine.ModuleOutput{DataKey: "discovery.live_hosts", Data: []string{"192.0.2.1"}})
+		observe(engine.ModuleOutput{DataKey: "ssh.banner", Data: "ignored"})
+
+		return &scanexec.Result{
+			RunID:     params.ScanID,
+			Status:    "completed",
+			StartTime: "2026-01-02T03:04:05Z",
+			EndTime:   "2026-01-02T03:05:05Z",
+			RawContext: map[string]interface{}{
+				report.FindingsKey: []interface{}{
+					map[string]interface{}{"target": "192.0.2.1", "port": 22, "plugin": "ssh-weak", "severity": "high", "message": "weak"},
+				},
+			},
+		}, nil
+	})
+
+	scan, err := s.Start(context.Background(), ScanConfig{
+		Targets: []string{"192.0.2.1"},
+		Ports:   "22",
+		Timeout: 2 * time.Second,
+	})
+	require.NoError(t, err)
+
+	var types []string
+	for ev := range scan.Events() {
+		require.Equal(t, scan.ID, ev.ScanID)
+		types = append(types, ev.Type)
+	}
+	require.Equal(t, []string{EventHostDiscovered, EventScanFinished}, types)
+
+	res, err := scan.Wait()
+	require.NoError(t, err)
+	require.Equal(t, scan.ID, got.ScanID)
+	require.Equal(t, "22", got.Ports)
+	require.Equal(t, "2s", got.CustomTimeout)
+	require.Equal(t, scan.ID, res.ScanID)
+	require.Equal(t, "completed", res.Status)
+	require.Equal(t, time.Minute, res.CompletedAt.Sub(res.StartedAt))
+	require.Len(t, res.Findings, 1)
+	require.Equal(t, "ssh-weak", res.Findings[0].Plugin)
+	require.Equal(t, 22, res.Findings[0].Port)
+}
+
+func TestScanner_ScanReturnsError(t *testing.T) {
+	s := newTestScanner(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		return &scanexec.Result{RunID: params.ScanID, Status: "interrupted"}, errors.New("interrupted")
+	})
+
+	res, err := s.Scan(context.Background(), ScanConfig{Targets: []string{"192.0.2.1"}})
+	require.EqualError(t, err, "interrupted")
+	require.NotNil(t, res)
+	require.Equal(t, "interrupted", res.Status)
+	require.Empty(t, res.Findings)
+}
+
+func TestScanner_CloseCancelsScans(t *testing.T) {
+	s := newTestScanner(t, func(ctx context.Context, params scanexec.Params) (*scanexec.Result, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	scan, err := s.Start(context.Background(), ScanConfig{Targets: []string{"192.0.2.1"}})
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	var last Event
+	for ev := range scan.Events() {
+		last = ev
+	}
+	require.Equal(t, EventScanFinished, last.Type)
+	require.Equal(t, "failed", last.Data.(map[string]string)["status"])
+
+	_, err = scan.Wait()
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScanner_StartRequiresTargets(t *testing.T) {
+	s := newTestScanner(t, nil)
+	_, err := s.Start(context.Background(), ScanConfig{})
+	require.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package wordlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+	"github.com/vulntor/vulntor/pkg/netproxy"
+)
+
+// maxDownloadSize bounds the size of a downloaded index or wordlist.
+const maxDownloadSize = 256 << 20
+
+// ErrSourceNotAvailable indicates that no configured source offers a
+// wordlist, or that none is configured.
+var ErrSourceNotAvailable = errors.New("wordlist source not available")
+
+// Source is a remote source of wordlists: the URL of its index.
+type Source struct {
+	Name string
+	URL  string
+}
+
+// Index is the document a source publishes at its URL, in YAML or JSON:
+//
+//	wordlists:
+//	  - name: tomcat-passwords
+//	    kind: passwords
+//	    description: Tomcat manager default passwords
+//	    url: tomcat-passwords.txt   # relative to the index
+//	    checksum: sha256:9f86d08...
+type Index struct {
+	Wordlists []IndexEntry `yaml:"wordlists" json:"wordlists"`
+}
+
+// IndexEntry is a wordlist offered by a source.
+type IndexEntry struct {
+	Name        string `yaml:"name" json:"name"`
+	Kind        Kind   `yaml:"kind" json:"kind"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	URL         string `yaml:"url" json:"url"`
+	Checksum    string `yaml:"checksum" json:"checksum"`
+}
+
+// Available is a wordlist a source offers.
+type Available struct {
+	IndexEntry
+	Source string `json:"source"`
+}
+
+// Update outcomes.
+const (
+	StatusInstalled = "installed"
+	StatusUpdated   = "updated"
+	StatusCurrent   = "current"
+	StatusFailed    = "failed"
+)
+
+// UpdateResult is the outcome of updating one wordlist.
+type UpdateResult struct {
+	Name     string `json:"name"`
+	Source   string `json:"source,omitempty"`
+	Status   string `json:"status"`
+	Checksum string `json:"checksum,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Err      error  `json:"-"` // The failure Error describes, for errors.Is
+}
+
+// Updater installs and updates the wordlists of a Store from remote
+// sources. Sources are tried in order: the first one offering a name
+// provides it. Downloads go through Proxy, if set, at the rate Limiter
+// allows (unlimited when nil), and are rejected unless they match the
+// checksum the index publishes.
+type Updater struct {
+	Store   *Store
+	Sources []Source
+	Client  *http.Client
+	Proxy   *netproxy.Proxy
+	Limiter *bandwidth.Limiter
+}
+
+// Available returns the wordlists the sources offer, the first source
+// offering a name shadowing the others. Sources that cannot be fetched
+// are skipped; their errors are returned joined when no source answered.
+func (u *Updater) Available(ctx context.Context) ([]Available, error) {
+	if len(u.Sources) == 0 {
+		return nil, fmt.Errorf("%w: no wordlist sources configured (wordlists.sources)", ErrSourceNotAvailable)
+	}
+	ctx = netproxy.WithContext(ctx, u.Proxy)
+
+	var (
+		available []Available
+		errs      []error
+		answered  bool
+	)
+	seen := make(map[string]bool)
+	for _, source := range u.Sources {
+		index, err := u.fetchIndex(ctx, source)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			errs = append(errs, fmt.Errorf("source %s: %w", source.Name, err))
+			continue
+		}
+		answered = true
+		for _, entry := range index.Wordlists {
+			if seen[entry.Name] {
+				continue
+			}
+			seen[entry.Name] = true
+			available = append(available, Available{IndexEntry: entry, Source: source.Name})
+		}
+	}
+	if !answered {
+		return nil, fmt.Errorf("%w: %w", ErrSourceNotAvailable, errors.Join(errs...))
+	}
+	return available, nil
+}
+
+// Update installs or updates the wordlists names from the sources. With no
+// names, it updates every stored wordlist that came from a source;
+// wordlists added from local files are left alone. A wordlist whose
+// checksum matches the published one is not downloaded again.
+//
+// Failures of single wordlists are reported in their result; the error is
+// for failures affecting all of them, such as no reachable source.
+func (u *Updater) Update(ctx context.Context, names ...string) ([]UpdateResult, error) {
+	available, err := u.Available(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = netproxy.WithContext(ctx, u.Proxy)
+
+	stored, err := u.Store.List()
+	if err != nil {
+		return nil, err
+	}
+	installed := make(map[string]Wordlist, len(stored))
+	for _, w := range stored {
+		installed[w.Name] = w
+	}
+	if len(names) == 0 {
+		for _, w := range stored {
+			if w.Remote() {
+				names = append(names, w.Name)
+			}
+		}
+	}
+
+	results := make([]UpdateResult, 0, len(names))
+	for _, name := range names {
+		i := slices.IndexFunc(available, func(a Available) bool { return a.Name == name })
+		if i < 0 {
+			err := fmt.Errorf("%w: no source offers %s", ErrSourceNotAvailable, name)
+			results = append(results, UpdateResult{Name: name, Status: StatusFailed, Error: err.Error(), Err: err})
+			continue
+		}
+		result, err := u.update(ctx, available[i], installed)
+		if err != nil {
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			result.Status = StatusFailed
+			result.Error = err.Error()
+			result.Err = err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// update installs entry unless the stored wordlist of its name is current.
+func (u *Updater) update(ctx context.Context, entry Available, installed map[string]Wordlist) (UpdateResult, error) {
+	result := UpdateResult{Name: entry.Name, Source: entry.Source, Checksum: entry.Checksum}
+
+	current, exists := installed[entry.Name]
+	if exists && !current.Remote() {
+		return result, fmt.Errorf("%w: %s was added from a local file; remove it first", ErrInvalid, entry.Name)
+	}
+	if exists && current.Checksum == entry.Checksum && u.Store.Verify(entry.Name) == nil {
+		result.Status = StatusCurrent
+		return result, nil
+	}
+
+	source := u.source(entry.Source)
+	target, err := resolveURL(source.URL, entry.URL)
+	if err != nil {
+		return result, err
+	}
+	data, err := u.download(ctx, target)
+	if err != nil {
+		return result, err
+	}
+	if err := verifyChecksum(data, entry.Checksum); err != nil {
+		return result, err
+	}
+	if _, err := u.Store.Save(Wordlist{
+		Name:        entry.Name,
+		Kind:        entry.Kind,
+		Description: entry.Description,
+		Source:      entry.Source,
+		URL:         target,
+	}, data); err != nil {
+		return result, err
+	}
+
+	result.Status = StatusInstalled
+	if exists {
+		result.Status = StatusUpdated
+	}
+	return result, nil
+}
+
+// source returns the source named name.
+func (u *Updater) source(name string) Source {
+	for _, s := range u.Sources {
+		if s.Name == name {
+			return s
+		}
+	}
+	return Source{Name: name}
+}
+
+// fetchIndex downloads and parses the index of source.
+func (u *Updater) fetchIndex(ctx context.Context, source Source) (*Index, error) {
+	data, err := u.download(ctx, source.URL)
+	if err != nil {
+		return nil, err
+	}
+	var index Index
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parse index: %w", err)
+	}
+	for _, entry := range index.Wordlists {
+		if err := ValidateName(entry.Name); err != nil {
+			return nil, fmt.Errorf("index: %w", err)
+		}
+		if entry.URL == "" || entry.Checksum == "" {
+			return nil, fmt.Errorf("index: %w: %s needs a url and a checksum", ErrInvalid, entry.Name)
+		}
+	}
+	return &index, nil
+}
+
+// download fetches target.
+func (u *Updater) download(ctx context.Context, target string) ([]byte, error) {
+	client := u.Client
+	if client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = netproxy.HTTPProxy // The proxy travels in ctx
+		client = &http.Client{Timeout: 5 * time.Minute, Transport: transport}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", target, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: unexpected status code: %d", target, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(u.Limiter.Reader(ctx, resp.Body), maxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", target, err)
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d MiB", ErrInvalid, target, maxDownloadSize>>20)
+	}
+	return data, nil
+}
+
+// resolveURL resolves ref, the URL of an index entry, against the URL of
+// the index.
+func resolveURL(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid source URL %q: %w", base, err)
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("%w: url %q: %v", ErrInvalid, ref, err)
+	}
+	return b.ResolveReference(r).String(), nil
+}
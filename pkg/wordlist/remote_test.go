@@ -0,0 +1,167 @@
+package wordlist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/vulntor/vulntor/pkg/bandwidth"
+)
+
+// source serves an index offering its wordlists, with their checksums,
+// and counts the downloads of each.
+type source struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	files     map[string]string
+	downloads map[string]int
+}
+
+func newSource(t *testing.T, files map[string]string) *source {
+	t.Helper()
+	s := &source{files: files, downloads: make(map[string]int)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.URL.Path == "/index.yaml" {
+			_, _ = fmt.Fprintln(w, "wordlists:")
+			for name, content := range s.files {
+				_, _ = fmt.Fprintf(w, "  - {name: %s, kind: passwords, url: lists/%s.txt, checksum: %q}\n", name, name, Checksum([]byte(content)))
+			}
+			return
+		}
+		for name, content := range s.files {
+			if r.URL.Path == "/lists/"+name+".txt" {
+				s.downloads[name]++
+				_, _ = fmt.Fprint(w, content)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *source) set(name, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[name] = content
+}
+
+func (s *source) downloaded(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.downloads[name]
+}
+
+func TestUpdater_Update(t *testing.T) {
+	server := newSource(t, map[string]string{"tomcat-passwords": "tomcat\ns3cret\n"})
+	store := NewStore(t.TempDir())
+	updater := &Updater{
+		Store:   store,
+		Sources: []Source{{Name: "official", URL: server.URL + "/index.yaml"}},
+		Limiter: bandwidth.NewLimiter(1 << 20),
+	}
+	ctx := context.Background()
+
+	available, err := updater.Available(ctx)
+	require.NoError(t, err)
+	require.Len(t, available, 1)
+	require.Equal(t, "official", available[0].Source)
+
+	results, err := updater.Update(ctx, "tomcat-passwords")
+	require.NoError(t, err)
+	require.Equal(t, []UpdateResult{{Name: "tomcat-passwords", Source: "official", Status: StatusInstalled, Checksum: Checksum([]byte("tomcat\ns3cret\n"))}}, results)
+
+	w, err := store.Get("tomcat-passwords")
+	require.NoError(t, err)
+	require.Equal(t, KindPasswords, w.Kind)
+	require.Equal(t, "official", w.Source)
+	require.Equal(t, server.URL+"/lists/tomcat-passwords.txt", w.URL, "resolved against the index URL")
+	require.True(t, w.Remote())
+
+	// Current wordlists are not downloaded again
+	results, err = updater.Update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, StatusCurrent, results[0].Status)
+	require.Equal(t, 1, server.downloaded("tomcat-passwords"))
+
+	// A newer version is downloaded
+	server.set("tomcat-passwords", "tomcat\ns3cret\nadmin\n")
+	results, err = updater.Update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, StatusUpdated, results[0].Status)
+	entries, err := store.Entries("tomcat-passwords")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tomcat", "s3cret", "admin"}, entries)
+
+	// So is a file modified since
+	require.NoError(t, os.WriteFile(store.Path("tomcat-passwords"), []byte("edited\n"), 0o644))
+	results, err = updater.Update(ctx)
+	require.NoError(t, err)
+	require.Equal(t, StatusUpdated, results[0].Status)
+	require.Equal(t, 3, server.downloaded("tomcat-passwords"))
+}
+
+func TestUpdater_UpdateFailures(t *testing.T) {
+	server := newSource(t, map[string]string{"tomcat-passwords": "tomcat\n"})
+	store := NewStore(t.TempDir())
+	ctx := context.Background()
+
+	_, err := (&Updater{Store: store}).Update(ctx)
+	require.ErrorIs(t, err, ErrSourceNotAvailable)
+
+	_, err = (&Updater{Store: store, Sources: []Source{{Name: "down", URL: server.URL + "/missing.yaml"}}}).Update(ctx)
+	require.ErrorIs(t, err, ErrSourceNotAvailable)
+	require.ErrorContains(t, err, "source down: fetch")
+
+	updater := &Updater{Store: store, Sources: []Source{
+		{Name: "down", URL: server.URL + "/missing.yaml"},
+		{Name: "official", URL: server.URL + "/index.yaml"},
+	}}
+
+	// Local wordlists are not replaced
+	_, err = store.Save(Wordlist{Name: "tomcat-passwords", Kind: KindPasswords}, []byte("mine\n"))
+	require.NoError(t, err)
+	results, err := updater.Update(ctx, "tomcat-passwords", "ssh-usernames")
+	require.NoError(t, err, "the other source answered")
+	require.Len(t, results, 2)
+	require.Equal(t, StatusFailed, results[0].Status)
+	require.Contains(t, results[0].Error, "added from a local file")
+	require.Equal(t, StatusFailed, results[1].Status)
+	require.Contains(t, results[1].Error, "no source offers ssh-usernames")
+
+	// With no names only remote wordlists are updated
+	results, err = updater.Update(ctx)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestUpdater_ChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/index.yaml" {
+			_, _ = fmt.Fprintf(w, "wordlists:\n  - {name: passwords, url: passwords.txt, checksum: %q}\n", Checksum([]byte("admin\n")))
+			return
+		}
+		_, _ = fmt.Fprint(w, "tampered\n")
+	}))
+	defer server.Close()
+
+	store := NewStore(t.TempDir())
+	updater := &Updater{Store: store, Sources: []Source{{Name: "mirror", URL: server.URL + "/index.yaml"}}}
+	results, err := updater.Update(context.Background(), "passwords")
+	require.NoError(t, err)
+	require.Equal(t, StatusFailed, results[0].Status)
+	require.Contains(t, results[0].Error, "wordlist checksum mismatch")
+
+	_, err = store.Get("passwords")
+	require.ErrorIs(t, err, ErrNotFound, "nothing is stored")
+}
@@ -0,0 +1,364 @@
+// Package wordlist manages the named wordlists that plugins and
+// default-credential testing draw on: user names, passwords and paths,
+// kept in the workspace with checksums and updated from remote sources
+// like plugins are.
+//
+// A Store keeps one text file per wordlist, one entry per line, and an
+// index recording the kind, checksum and origin of each:
+//
+//	<workspace>/wordlists/
+//	  index.json
+//	  tomcat-usernames.txt
+//	  tomcat-passwords.txt
+//
+// Plugins reference a wordlist as {{wordlist:tomcat-passwords}}. The
+// plugin loader reads it from this directory when the plugin ships no
+// wordlist of that name itself.
+package wordlist
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DirName is the directory of the workspace holding the wordlists. It is
+// the directory plugins read their own wordlists from as well.
+const DirName = "wordlists"
+
+// indexFile records the metadata of the wordlists in a store.
+const indexFile = "index.json"
+
+// LocalSource is the source of wordlists added from local files.
+const LocalSource = "local"
+
+var (
+	// ErrNotFound indicates that no wordlist has the requested name.
+	ErrNotFound = errors.New("wordlist not found")
+
+	// ErrInvalid indicates an invalid wordlist name, kind or content.
+	ErrInvalid = errors.New("invalid wordlist")
+
+	// ErrChecksumMismatch indicates that a wordlist file does not match
+	// the checksum recorded or published for it.
+	ErrChecksumMismatch = errors.New("wordlist checksum mismatch")
+)
+
+// namePattern restricts wordlist names to the names plugins can
+// reference.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Kind is what the entries of a wordlist are.
+type Kind string
+
+const (
+	KindUsernames Kind = "usernames"
+	KindPasswords Kind = "passwords"
+	KindPaths     Kind = "paths"
+	KindOther     Kind = "other"
+)
+
+// kinds are the valid kinds, in the order they are listed.
+var kinds = []Kind{KindUsernames, KindPasswords, KindPaths, KindOther}
+
+// ParseKind returns the kind named s. Empty means KindOther.
+func ParseKind(s string) (Kind, error) {
+	if s == "" {
+		return KindOther, nil
+	}
+	for _, k := range kinds {
+		if strings.EqualFold(s, string(k)) {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown kind %q (valid: usernames, passwords, paths, other)", ErrInvalid, s)
+}
+
+// Wordlist describes a stored wordlist.
+type Wordlist struct {
+	Name        string    `json:"name"`
+	Kind        Kind      `json:"kind"`
+	Description string    `json:"description,omitempty"`
+	Entries     int       `json:"entries"`       // Non-empty, non-comment lines
+	Size        int64     `json:"size"`          // File size in bytes
+	Checksum    string    `json:"checksum"`      // sha256:<hex> of the file
+	Source      string    `json:"source"`        // LocalSource or the remote source name
+	URL         string    `json:"url,omitempty"` // Where a remote wordlist was downloaded from
+	UpdatedAt   time.Time `json:"updated_at"`    // When the file was last written
+}
+
+// Remote reports whether w was installed from a remote source.
+func (w Wordlist) Remote() bool {
+	return w.Source != LocalSource
+}
+
+// ValidateName checks that name can be stored and referenced by plugins.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("%w: name %q must contain only letters, digits, dashes or underscores", ErrInvalid, name)
+	}
+	return nil
+}
+
+// Parse returns the entries of wordlist data: its lines, trimmed, without
+// blank lines and # comments.
+func Parse(data []byte) []string {
+	var entries []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	return entries
+}
+
+// Checksum returns the checksum of wordlist data as recorded in the index
+// and published by sources: "sha256:" followed by the hex digest.
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// verifyChecksum checks data against an expected "sha256:<hex>" checksum.
+func verifyChecksum(data []byte, expected string) error {
+	if !strings.HasPrefix(expected, "sha256:") {
+		return fmt.Errorf("%w: unsupported checksum %q (want sha256:<hex>)", ErrInvalid, expected)
+	}
+	if actual := Checksum(data); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+// Store keeps wordlists in a directory. It is safe for concurrent use
+// within a process.
+type Store struct {
+	dir string
+	now func() time.Time
+
+	mu sync.Mutex
+}
+
+// NewStore returns the store in dir, which is created on the first write.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir, now: time.Now}
+}
+
+// Dir returns the directory of the store.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Path returns the file of the wordlist name.
+func (s *Store) Path(name string) string {
+	return filepath.Join(s.dir, name+".txt")
+}
+
+// List returns the stored wordlists sorted by name.
+func (s *Store) List() ([]Wordlist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	lists := make([]Wordlist, 0, len(index))
+	for _, w := range index {
+		lists = append(lists, w)
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].Name < lists[j].Name })
+	return lists, nil
+}
+
+// Get returns the wordlist name, or ErrNotFound.
+func (s *Store) Get(name string) (Wordlist, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.readIndex()
+	if err != nil {
+		return Wordlist{}, err
+	}
+	w, ok := index[name]
+	if !ok {
+		return Wordlist{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return w, nil
+}
+
+// Entries returns the entries of the wordlist name.
+func (s *Store) Entries(name string) ([]string, error) {
+	if _, err := s.Get(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.Path(name)) // #nosec G304 -- names are validated when stored
+	if err != nil {
+		return nil, fmt.Errorf("read wordlist %s: %w", name, err)
+	}
+	return Parse(data), nil
+}
+
+// Save writes data as the wordlist w.Name, replacing any wordlist of that
+// name. The entry count, size, checksum and update time of w are filled
+// in from data; the stored wordlist is returned.
+func (s *Store) Save(w Wordlist, data []byte) (Wordlist, error) {
+	if err := ValidateName(w.Name); err != nil {
+		return Wordlist{}, err
+	}
+	kind, err := ParseKind(string(w.Kind))
+	if err != nil {
+		return Wordlist{}, err
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return Wordlist{}, fmt.Errorf("%w: %s is not a text file", ErrInvalid, w.Name)
+	}
+	w.Kind = kind
+	if w.Source == "" {
+		w.Source = LocalSource
+	}
+	w.Entries = len(Parse(data))
+	w.Size = int64(len(data))
+	w.Checksum = Checksum(data)
+	w.UpdatedAt = s.now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.readIndex()
+	if err != nil {
+		return Wordlist{}, err
+	}
+	if err := writeFileAtomic(s.Path(w.Name), data); err != nil {
+		return Wordlist{}, fmt.Errorf("write wordlist %s: %w", w.Name, err)
+	}
+	index[w.Name] = w
+	if err := s.writeIndex(index); err != nil {
+		return Wordlist{}, err
+	}
+	return w, nil
+}
+
+// Remove deletes the wordlist name, or returns ErrNotFound.
+func (s *Store) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	index, err := s.readIndex()
+	if err != nil {
+		return err
+	}
+	if _, ok := index[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	if err := os.Remove(s.Path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove wordlist %s: %w", name, err)
+	}
+	delete(index, name)
+	return s.writeIndex(index)
+}
+
+// Verify checks the file of the wordlist name against the checksum
+// recorded when it was stored, returning ErrChecksumMismatch when it was
+// modified or is missing.
+func (s *Store) Verify(name string) error {
+	w, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(s.Path(name)) // #nosec G304 -- names are validated when stored
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s: file is missing", ErrChecksumMismatch, name)
+	}
+	if err != nil {
+		return fmt.Errorf("read wordlist %s: %w", name, err)
+	}
+	if err := verifyChecksum(data, w.Checksum); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// readIndex returns the wordlists of the index by name. A missing index is
+// an empty store.
+func (s *Store) readIndex() (map[string]Wordlist, error) {
+	index := make(map[string]Wordlist)
+	data, err := os.ReadFile(filepath.Join(s.dir, indexFile))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read wordlist index: %w", err)
+	}
+
+	var doc struct {
+		Wordlists []Wordlist `json:"wordlists"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse wordlist index: %w", err)
+	}
+	for _, w := range doc.Wordlists {
+		index[w.Name] = w
+	}
+	return index, nil
+}
+
+// writeIndex replaces the index with the wordlists of index.
+func (s *Store) writeIndex(index map[string]Wordlist) error {
+	doc := struct {
+		Wordlists []Wordlist `json:"wordlists"`
+	}{Wordlists: make([]Wordlist, 0, len(index))}
+	for _, w := range index {
+		doc.Wordlists = append(doc.Wordlists, w)
+	}
+	sort.Slice(doc.Wordlists, func(i, j int) bool { return doc.Wordlists[i].Name < doc.Wordlists[j].Name })
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode wordlist index: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(s.dir, indexFile), append(data, '\n')); err != nil {
+		return fmt.Errorf("write wordlist index: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to path through a temporary file, so that
+// readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,109 @@
+package wordlist
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_Lifecycle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), DirName)
+	store := NewStore(dir)
+	store.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	lists, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, lists, "a missing directory is an empty store")
+
+	data := []byte("# Tomcat manager users\nadmin\n\n  tomcat \nmanager\n")
+	saved, err := store.Save(Wordlist{Name: "tomcat-usernames", Kind: "Usernames"}, data)
+	require.NoError(t, err)
+	require.Equal(t, Wordlist{
+		Name:      "tomcat-usernames",
+		Kind:      KindUsernames,
+		Entries:   3,
+		Size:      int64(len(data)),
+		Checksum:  Checksum(data),
+		Source:    LocalSource,
+		UpdatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}, saved)
+	require.False(t, saved.Remote())
+
+	// The file is where plugins read wordlists from
+	written, err := os.ReadFile(filepath.Join(dir, "tomcat-usernames.txt"))
+	require.NoError(t, err)
+	require.Equal(t, data, written)
+
+	entries, err := store.Entries("tomcat-usernames")
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin", "tomcat", "manager"}, entries)
+
+	_, err = store.Save(Wordlist{Name: "common-paths", Kind: KindPaths}, []byte("/admin\n/.git/config\n"))
+	require.NoError(t, err)
+
+	// The index survives a new store on the same directory
+	lists, err = NewStore(dir).List()
+	require.NoError(t, err)
+	require.Len(t, lists, 2)
+	require.Equal(t, "common-paths", lists[0].Name)
+	require.Equal(t, saved, lists[1])
+
+	require.NoError(t, store.Remove("common-paths"))
+	_, err = store.Get("common-paths")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoFileExists(t, filepath.Join(dir, "common-paths.txt"))
+	require.ErrorIs(t, store.Remove("common-paths"), ErrNotFound)
+}
+
+func TestStore_SaveInvalid(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	for _, w := range []Wordlist{
+		{Name: "../passwords", Kind: KindPasswords},
+		{Name: "", Kind: KindPasswords},
+		{Name: "passwords", Kind: "hashes"},
+	} {
+		_, err := store.Save(w, []byte("admin\n"))
+		require.ErrorIs(t, err, ErrInvalid, w.Name)
+	}
+
+	_, err := store.Save(Wordlist{Name: "binary"}, []byte{0x7f, 'E', 'L', 'F', 0})
+	require.ErrorIs(t, err, ErrInvalid)
+
+	lists, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, lists)
+}
+
+func TestStore_Verify(t *testing.T) {
+	store := NewStore(t.TempDir())
+	_, err := store.Save(Wordlist{Name: "passwords", Kind: KindPasswords}, []byte("admin\nchangeme\n"))
+	require.NoError(t, err)
+	require.NoError(t, store.Verify("passwords"))
+
+	require.NoError(t, os.WriteFile(store.Path("passwords"), []byte("admin\n"), 0o644))
+	err = store.Verify("passwords")
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.ErrorContains(t, err, "passwords: wordlist checksum mismatch: expected sha256:")
+
+	require.NoError(t, os.Remove(store.Path("passwords")))
+	require.ErrorIs(t, store.Verify("passwords"), ErrChecksumMismatch)
+
+	require.ErrorIs(t, store.Verify("usernames"), ErrNotFound)
+}
+
+func TestParseKind(t *testing.T) {
+	kind, err := ParseKind("")
+	require.NoError(t, err)
+	require.Equal(t, KindOther, kind)
+
+	kind, err = ParseKind("PASSWORDS")
+	require.NoError(t, err)
+	require.Equal(t, KindPasswords, kind)
+
+	_, err = ParseKind("hashes")
+	require.ErrorIs(t, err, ErrInvalid)
+}